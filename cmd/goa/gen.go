@@ -275,21 +275,15 @@ func (g *Generator) runGoCmd(args ...string) error {
 // cleanupDirs returns the paths of the subdirectories under gendir to delete
 // before generating code.
 func cleanupDirs(cmd, output string) []string {
-	if cmd == "gen" {
-		gendirPath := filepath.Join(output, codegen.Gendir)
-		gendir, err := os.Open(gendirPath)
-		if err != nil {
-			return nil
-		}
-		defer gendir.Close()
-		finfos, err := gendir.Readdir(-1)
-		if err != nil {
-			return []string{gendirPath}
-		}
-		dirs := []string{}
-		for _, fi := range finfos {
-			if fi.IsDir() {
-				dirs = append(dirs, filepath.Join(gendirPath, fi.Name()))
+	switch cmd {
+	case "gen":
+		return subdirs(filepath.Join(output, codegen.Gendir))
+	case "sdk":
+		sdkdir := filepath.Join(output, codegen.SDKdir)
+		dirs := subdirs(sdkdir)
+		for _, f := range []string{"go.mod", "README.md", "version.go"} {
+			if _, err := os.Stat(filepath.Join(sdkdir, f)); err == nil {
+				dirs = append(dirs, filepath.Join(sdkdir, f))
 			}
 		}
 		return dirs
@@ -297,6 +291,27 @@ func cleanupDirs(cmd, output string) []string {
 	return nil
 }
 
+// subdirs returns the paths of the subdirectories of the directory at the
+// given path.
+func subdirs(path string) []string {
+	dir, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer dir.Close()
+	finfos, err := dir.Readdir(-1)
+	if err != nil {
+		return []string{path}
+	}
+	dirs := []string{}
+	for _, fi := range finfos {
+		if fi.IsDir() {
+			dirs = append(dirs, filepath.Join(path, fi.Name()))
+		}
+	}
+	return dirs
+}
+
 // mainT is the template for the generator main.
 const mainT = `func main() {
 	var (
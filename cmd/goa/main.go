@@ -26,7 +26,7 @@ func main() {
 		case "version":
 			fmt.Println("goa version " + goa.Version())
 			os.Exit(0)
-		case "gen", "example":
+		case "gen", "example", "test", "messages", "docs", "sdk":
 			if len(os.Args) == 2 {
 				usage()
 			}
@@ -39,8 +39,9 @@ func main() {
 	}
 
 	var (
-		output = "."
-		debug  bool
+		output   = "."
+		debug    bool
+		skeleton bool
 	)
 	if len(os.Args) > offset+1 {
 		var (
@@ -49,6 +50,7 @@ func main() {
 			out  = fset.String("output", output, "output `directory`")
 		)
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
+		fset.BoolVar(&skeleton, "skeleton", false, "Generate the service implementation skeletons only")
 
 		fset.Usage = usage
 		fset.Parse(os.Args[offset+1:])
@@ -59,6 +61,14 @@ func main() {
 		}
 	}
 
+	if skeleton {
+		if cmd == "example" {
+			cmd = "skeleton"
+		} else {
+			usage()
+		}
+	}
+
 	gen(cmd, path, output, debug)
 }
 
@@ -112,7 +122,11 @@ Learn more at https://goa.design.
 
 Usage:
   goa gen PACKAGE [--out DIRECTORY] [--debug]
-  goa example PACKAGE [--out DIRECTORY] [--debug]
+  goa example PACKAGE [--out DIRECTORY] [--skeleton] [--debug]
+  goa test PACKAGE [--out DIRECTORY] [--debug]
+  goa messages PACKAGE [--out DIRECTORY] [--debug]
+  goa docs PACKAGE [--out DIRECTORY] [--debug]
+  goa sdk PACKAGE [--out DIRECTORY] [--debug]
   goa version
 
 Commands:
@@ -120,6 +134,18 @@ Commands:
         Generate service interfaces, endpoints, transport code and OpenAPI spec.
   example
         Generate example server and client tool.
+  test
+        Generate HTTP contract tests that validate the responses of a service
+        implementation against the design.
+  messages
+        Extract the templates of the validation error messages to a JSON
+        file that can be translated and loaded in a goa.Catalog.
+  docs
+        Generate a static reference documentation of the API in Markdown.
+  sdk
+        Generate a standalone Go module in the "sdk" directory that contains
+        the clients, types and documentation of the API, versioned after the
+        API version.
   version
         Print version information (exclusive with other flags and commands).
 
@@ -131,6 +157,10 @@ Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
 
+  -skeleton
+        Only generate the service implementation skeletons (example command
+        only). Stubs are added for the methods missing from existing files.
+
   -debug
         Print debug information (mainly intended for goa developers)
 
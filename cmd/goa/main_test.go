@@ -33,7 +33,12 @@ func TestCmdLine(t *testing.T) {
 		ExpectedOutput  string
 		ExpectedDebug   bool
 	}{
-		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"gen":  {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"test": {"test " + testPkg, false, "test", testPkg, ".", false},
+
+		"messages": {"messages " + testPkg, false, "messages", testPkg, ".", false},
+		"docs":     {"docs " + testPkg, false, "docs", testPkg, ".", false},
+		"sdk":      {"sdk " + testPkg, false, "sdk", testPkg, ".", false},
 
 		"invalid":     {"invalid " + testPkg, true, "", "", ".", false},
 		"empty":       {"", true, "", "", ".", false},
@@ -43,6 +48,10 @@ func TestCmdLine(t *testing.T) {
 		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false},
 
 		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true},
+
+		"skeleton":         {"example " + testPkg + " -skeleton", false, "skeleton", testPkg, ".", false},
+		"skeleton output":  {"example " + testPkg + " -skeleton -o " + testOutput, false, "skeleton", testPkg, testOutput, false},
+		"invalid skeleton": {"gen " + testPkg + " -skeleton", true, "gen", testPkg, ".", false},
 	}
 
 	for k, c := range cases {
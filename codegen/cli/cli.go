@@ -34,6 +34,23 @@ type (
 		// PkgName is the service HTTP client package import name,
 		// e.g. "storagec".
 		PkgName string
+		// Aliases lists the alternative names of the command set with
+		// the "cli:alias" metadata of the service.
+		Aliases []string
+		// Group is the name of the group the command is listed under in
+		// the CLI usage set with the "cli:group" metadata of the
+		// service, empty if the command is not grouped.
+		Group string
+	}
+
+	// SubcommandGroupData lists the sub-commands that belong to the same
+	// group.
+	SubcommandGroupData struct {
+		// Name is the group name, empty for the sub-commands that do not
+		// belong to a group.
+		Name string
+		// Subcommands is the list of sub-commands in the group.
+		Subcommands []*SubcommandData
 	}
 
 	// SubcommandData contains the data needed to render a sub-command.
@@ -44,6 +61,16 @@ type (
 		FullName string
 		// Description is the help text.
 		Description string
+		// Summary is the first line of the help text used to list the
+		// sub-command in the command usage.
+		Summary string
+		// Aliases lists the alternative names of the sub-command set
+		// with the "cli:alias" metadata of the method.
+		Aliases []string
+		// Group is the name of the group the sub-command is listed under
+		// in the command usage set with the "cli:group" metadata of the
+		// method, empty if the sub-command is not grouped.
+		Group string
 		// Flags is the list of flags supported by the subcommand.
 		Flags []*FlagData
 		// MethodVarName is the endpoint method name, e.g. "Add"
@@ -159,12 +186,42 @@ func BuildCommandData(data *service.Data) *CommandData {
 	if description == "" {
 		description = fmt.Sprintf("Make requests to the %q service", data.Name)
 	}
+	var meta expr.MetaExpr
+	if svc := expr.Root.Service(data.Name); svc != nil {
+		meta = svc.Meta
+	}
 	return &CommandData{
 		Name:        codegen.KebabCase(data.Name),
 		VarName:     codegen.Goify(data.Name, false),
 		Description: description,
 		PkgName:     data.PkgName + "c",
+		Aliases:     meta["cli:alias"],
+		Group:       cliGroup(meta),
+	}
+}
+
+// Groups returns the sub-commands of the command grouped using the
+// "cli:group" metadata of the methods. The sub-commands that do not belong to a
+// group come first followed by the groups in the order they are first used.
+func (c *CommandData) Groups() []*SubcommandGroupData {
+	var (
+		groups  []*SubcommandGroupData
+		indices = make(map[string]int)
+	)
+	groups = append(groups, &SubcommandGroupData{})
+	for _, sub := range c.Subcommands {
+		i, ok := indices[sub.Group]
+		if !ok && sub.Group != "" {
+			i = len(groups)
+			indices[sub.Group] = i
+			groups = append(groups, &SubcommandGroupData{Name: sub.Group})
+		}
+		groups[i].Subcommands = append(groups[i].Subcommands, sub)
+	}
+	if len(groups[0].Subcommands) == 0 {
+		groups = groups[1:]
 	}
+	return groups
 }
 
 // BuildSubcommandData builds the data needed by CLI code generators to render
@@ -216,10 +273,19 @@ func BuildSubcommandData(svcName string, m *service.MethodData, buildFunction *B
 			}
 		}
 	}
+	var meta expr.MetaExpr
+	if svc := expr.Root.Service(svcName); svc != nil {
+		if me := svc.Method(m.Name); me != nil {
+			meta = me.Meta
+		}
+	}
 	sub := &SubcommandData{
 		Name:          name,
 		FullName:      fullName,
 		Description:   description,
+		Summary:       strings.TrimSpace(strings.SplitN(description, "\n", 2)[0]),
+		Aliases:       meta["cli:alias"],
+		Group:         cliGroup(meta),
 		Flags:         flags,
 		MethodVarName: m.VarName,
 		BuildFunction: buildFunction,
@@ -231,7 +297,8 @@ func BuildSubcommandData(svcName string, m *service.MethodData, buildFunction *B
 }
 
 // UsageCommands builds a section template that generates a help text showing
-// the list of allowed commands and sub-commands.
+// the list of allowed commands and sub-commands. The commands that belong to a
+// group are listed after the others under the group name.
 func UsageCommands(data []*CommandData) *codegen.SectionTemplate {
 	usages := make([]string, len(data))
 	for i, cmd := range data {
@@ -246,6 +313,29 @@ func UsageCommands(data []*CommandData) *codegen.SectionTemplate {
 		}
 		usages[i] = fmt.Sprintf("%s %s%s%s", cmd.Name, lp, strings.Join(subs, "|"), rp)
 	}
+	var (
+		grouped []string
+		groups  []string
+		byGroup = make(map[string][]string)
+	)
+	for i, cmd := range data {
+		if cmd.Group == "" {
+			grouped = append(grouped, usages[i])
+			continue
+		}
+		if _, ok := byGroup[cmd.Group]; !ok {
+			groups = append(groups, cmd.Group)
+		}
+		byGroup[cmd.Group] = append(byGroup[cmd.Group], usages[i])
+	}
+	for _, g := range groups {
+		if len(grouped) > 0 {
+			grouped = append(grouped, "")
+		}
+		grouped = append(grouped, g+":")
+		grouped = append(grouped, byGroup[g]...)
+	}
+	usages = grouped
 
 	return &codegen.SectionTemplate{Source: usageT, Data: usages}
 }
@@ -290,7 +380,7 @@ func CommandUsage(data *CommandData) *codegen.SectionTemplate {
 		Name:    "cli-command-usage",
 		Source:  commandUsageT,
 		Data:    data,
-		FuncMap: map[string]interface{}{"printDescription": printDescription},
+		FuncMap: map[string]interface{}{"printDescription": printDescription, "join": strings.Join},
 	}
 }
 
@@ -378,6 +468,14 @@ func flagType(tname string) string {
 		return strings.ToUpper(tname)
 	case bytesN:
 		return "STRING"
+	case durN:
+		return "DURATION"
+	case uuidN:
+		return "UUID"
+	case tsN:
+		return "TIMESTAMP"
+	case codegen.GoNativeTypeName(expr.Decimal):
+		return "DECIMAL"
 	default: // Any, Array, Map, Object, User
 		return "JSON"
 	}
@@ -437,6 +535,9 @@ var (
 	float64N = codegen.GoNativeTypeName(expr.Float64)
 	stringN  = codegen.GoNativeTypeName(expr.String)
 	bytesN   = codegen.GoNativeTypeName(expr.Bytes)
+	durN     = codegen.GoNativeTypeName(expr.Duration)
+	uuidN    = codegen.GoNativeTypeName(expr.UUID)
+	tsN      = codegen.GoNativeTypeName(expr.Timestamp)
 )
 
 // conversionCode produces the code that converts the string stored in the
@@ -494,6 +595,26 @@ func conversionCode(from, to, typeName string, pointer bool) (string, bool) {
 		parse = fmt.Sprintf("%s %s= %s", target, decl, from)
 	case bytesN:
 		parse = fmt.Sprintf("%s %s= []byte(%s)", target, decl, from)
+	case durN:
+		parse = fmt.Sprintf("%s, err %s= goa.ParseDuration(%s)", target, decl, from)
+		checkErr = true
+	case "[]" + durN:
+		// time.Duration does not unmarshal JSON strings, use goa.Duration.
+		parse = fmt.Sprintf("var vals []goa.Duration\nerr = json.Unmarshal([]byte(%s), &vals)\n%s = make([]time.Duration, len(vals))\nfor i, v := range vals {\n\t%s[i] = time.Duration(v)\n}", from, target, target)
+		checkErr = true
+	case uuidN:
+		parse = fmt.Sprintf("%s, err %s= goa.ParseUUID(%s)", target, decl, from)
+		checkErr = true
+	case tsN:
+		parse = fmt.Sprintf("%s, err %s= goa.ParseTimestamp(%s, goa.TimestampRFC3339)", target, decl, from)
+		checkErr = true
+	case codegen.GoNativeTypeName(expr.Decimal):
+		// the decimal type name depends on the design, see DecimalType.
+		if pointer {
+			parse = fmt.Sprintf("var %s %s\n", target, typeName)
+		}
+		parse += fmt.Sprintf("err = %s.UnmarshalText([]byte(%s))", target, from)
+		checkErr = true
 	default:
 		parse = fmt.Sprintf("err = json.Unmarshal([]byte(%s), &%s)", from, target)
 		checkErr = true
@@ -514,6 +635,15 @@ func conversionCode(from, to, typeName string, pointer bool) (string, bool) {
 	return parse, checkErr
 }
 
+// cliGroup returns the name of the group set with the "cli:group" metadata,
+// empty if there is none.
+func cliGroup(meta expr.MetaExpr) string {
+	if g := meta["cli:group"]; len(g) > 0 {
+		return g[0]
+	}
+	return ""
+}
+
 // goifyTerms makes valid go identifiers out of the supplied terms
 func goifyTerms(terms ...string) string {
 	res := codegen.Goify(terms[0], false)
@@ -595,8 +725,11 @@ const parseFlagsT = `var (
 		svcn = flag.Arg(0)
 		switch svcn {
 	{{- range . }}
-		case "{{ .Name }}":
+		case "{{ .Name }}"{{ range .Aliases }}, {{ printf "%q" . }}{{ end }}:
 			svcf = {{ .VarName }}Flags
+		{{- if .Aliases }}
+			svcn = "{{ .Name }}"
+		{{- end }}
 	{{- end }}
 		default:
 			return nil, nil, fmt.Errorf("unknown service %q", svcn)
@@ -617,8 +750,11 @@ const parseFlagsT = `var (
 		case "{{ .Name }}":
 			switch epn {
 		{{- range .Subcommands }}
-			case "{{ .Name }}":
+			case "{{ .Name }}"{{ range .Aliases }}, {{ printf "%q" . }}{{ end }}:
 				epf = {{ .FullName }}Flags
+			{{- if .Aliases }}
+				epn = "{{ .Name }}"
+			{{- end }}
 		{{ end }}
 			}
 	{{ end }}
@@ -642,11 +778,22 @@ func {{ .VarName }}Usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `{{ printDescription .Description }}
 Usage:
     %s [globalflags] {{ .Name }} COMMAND [flags]
+{{- if .Aliases }}
+
+Aliases:
+    {{ join .Aliases ", " }}
+{{- end }}
 
 COMMAND:
-    {{- range .Subcommands }}
-    {{ .Name }}: {{ printDescription .Description }}
-    {{- end }}
+{{- range .Groups }}
+	{{- if .Name }}
+
+{{ .Name }}:
+	{{- end }}
+	{{- range .Subcommands }}
+    {{ .Name }}{{ if .Aliases }} ({{ join .Aliases ", " }}){{ end }}: {{ printDescription .Summary }}
+	{{- end }}
+{{- end }}
 
 Additional help:
     %s {{ .Name }} COMMAND --help
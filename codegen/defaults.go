@@ -0,0 +1,87 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+)
+
+// DefaultFromCode produces Go code that initializes the fields of the data
+// structure held by the variable named target whose attributes use DefaultFrom
+// and that are not set with the value of the corresponding sibling fields. The
+// code recurses into nested objects and arrays of objects. See ValidationCode
+// for a description of the arguments.
+func DefaultFromCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target string) string {
+	return defaultFromCode(att, attCtx, req, target, make(map[string]bool))
+}
+
+func defaultFromCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target string, seen map[string]bool) string {
+	if ut, ok := att.Type.(expr.UserType); ok {
+		if seen[ut.ID()] {
+			return ""
+		}
+		seen[ut.ID()] = true
+		defer delete(seen, ut.ID())
+	}
+	switch {
+	case expr.IsObject(att.Type):
+		var res []string
+		for _, nat := range *expr.AsObject(att.Type) {
+			tgt := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(nat.Attribute, nat.Name, true))
+			if from := nat.Attribute.DefaultFrom(); from != "" {
+				src := att.Find(from)
+				if src == nil {
+					continue // validation error
+				}
+				srcv := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(src, from, true))
+				res = append(res, defaultFromAssignCode(nat.Attribute, src, attCtx, att.IsRequired(from), tgt, srcv))
+				continue
+			}
+			code := defaultFromCode(nat.Attribute, attCtx, att.IsRequired(nat.Name), tgt, seen)
+			if code == "" {
+				continue
+			}
+			if expr.IsObject(nat.Attribute.Type) {
+				code = fmt.Sprintf("if %s != nil {\n%s\n}", tgt, code)
+			}
+			res = append(res, code)
+		}
+		return strings.Join(res, "\n")
+	case expr.IsArray(att.Type):
+		elem := expr.AsArray(att.Type).ElemType
+		if !expr.IsObject(elem.Type) {
+			return ""
+		}
+		code := defaultFromCode(elem, attCtx, true, target+"[i]", seen)
+		if code == "" {
+			return ""
+		}
+		return fmt.Sprintf("for i := range %s {\nif %s[i] != nil {\n%s\n}\n}", target, target, code)
+	}
+	return ""
+}
+
+// defaultFromAssignCode returns the code that sets the field tgt holding the
+// value of att with the value of the field src holding the value of the
+// sibling attribute srcAtt if tgt is not set. The primitive values held by
+// pointers or goa.Optional wrappers are copied so that the two fields do not
+// share memory.
+func defaultFromAssignCode(att, srcAtt *expr.AttributeExpr, attCtx *AttributeContext, srcReq bool, tgt, src string) string {
+	if !expr.IsPrimitive(att.Type) || att.Type.Kind() == expr.BytesKind || att.Type.Kind() == expr.AnyKind {
+		return fmt.Sprintf("if %s == nil {\n%s = %s\n}", tgt, tgt, src)
+	}
+	srcPtr := attCtx.Pointer || !attCtx.IgnoreRequired && (!srcReq && (srcAtt.DefaultValue == nil || !attCtx.UseDefault))
+	unset, assign := tgt+" == nil", tgt+" = &def"
+	if att.IsOptionalField() {
+		unset = "!" + tgt + ".Set"
+		assign = fmt.Sprintf("%s = %s{Value: &def, Set: true}", tgt, GoOptionalTypeName(att.Type))
+	}
+	switch {
+	case srcAtt.IsOptionalField():
+		return fmt.Sprintf("if %s && %s.Value != nil {\ndef := *%s.Value\n%s\n}", unset, src, src, assign)
+	case srcPtr:
+		return fmt.Sprintf("if %s && %s != nil {\ndef := *%s\n%s\n}", unset, src, src, assign)
+	}
+	return fmt.Sprintf("if %s {\ndef := %s\n%s\n}", unset, src, assign)
+}
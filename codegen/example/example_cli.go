@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -32,9 +33,12 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 	}
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
+		{Path: "crypto/tls"},
+		{Path: "crypto/x509"},
 		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
+		{Path: "io/ioutil"},
 		{Path: "net/url"},
 		{Path: "os"},
 		{Path: "strings"},
@@ -46,7 +50,8 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name:   "cli-main-start",
 			Source: cliMainStartT,
 			Data: map[string]interface{}{
-				"Server": svrdata,
+				"Server":  svrdata,
+				"Timeout": cliTimeout(root, svr),
 			},
 			FuncMap: map[string]interface{}{
 				"join": strings.Join,
@@ -74,12 +79,14 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			},
 		},
 		&codegen.SectionTemplate{Name: "cli-main-end", Source: cliMainEndT},
+		&codegen.SectionTemplate{Name: "cli-main-tls", Source: cliMainTLST, Data: svrdata},
 		&codegen.SectionTemplate{
 			Name:   "cli-main-usage",
 			Source: cliMainUsageT,
 			Data: map[string]interface{}{
 				"APIName": root.API.Name,
 				"Server":  svrdata,
+				"Timeout": cliTimeout(root, svr),
 			},
 			FuncMap: map[string]interface{}{
 				"toUpper": strings.ToUpper,
@@ -90,8 +97,31 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 	return &codegen.File{Path: path, SectionTemplates: sections, SkipExist: true}
 }
 
+// cliTimeout returns the default number of seconds the example client tool
+// waits for responses: the largest timeout of the methods of the services
+// hosted by the given server rounded up to the second or 30 if none of the
+// methods define a timeout.
+func cliTimeout(root *expr.RootExpr, svr *expr.ServerExpr) int {
+	var max time.Duration
+	for _, name := range svr.Services {
+		svc := root.Service(name)
+		if svc == nil {
+			continue
+		}
+		for _, m := range svc.Methods {
+			if d := m.Timeout(); d > max {
+				max = d
+			}
+		}
+	}
+	if max == 0 {
+		return 30
+	}
+	return int((max + time.Second - 1) / time.Second)
+}
+
 const (
-	// input: map[string]interface{}{"Server": *Data}
+	// input: map[string]interface{}{"Server": *Data, "Timeout": int}
 	cliMainStartT = `func main() {
 	var (
 		hostF = flag.String("host", {{ printf "%q" .Server.DefaultHost.Name }}, "Server host (valid values: {{ (join .Server.AvailableHosts ", ") }})")
@@ -101,7 +131,12 @@ const (
 	{{- end }}
 		verboseF = flag.Bool("verbose", false, "Print request and response details")
 		vF = flag.Bool("v", false, "Print request and response details")
-		timeoutF = flag.Int("timeout", 30, "Maximum number of seconds to wait for response")
+		timeoutF = flag.Int("timeout", {{ .Timeout }}, "Maximum number of seconds to wait for response")
+	{{- if .Server.TLS }}
+		tlsCertF = flag.String("tls-cert", "", "Path to the PEM encoded client certificate presented to the server")
+		tlsKeyF = flag.String("tls-key", "", "Path to the PEM encoded private key of the client certificate")
+		tlsCAF = flag.String("tls-ca", "", "Path to the PEM encoded certificates of the authorities that sign the server certificate (defaults to the system roots)")
+	{{- end }}
 	)
 	flag.Usage = usage
 	flag.Parse()
@@ -112,6 +147,9 @@ const (
 		addr string
 		timeout int
 		debug bool
+	{{- if .Server.TLS }}
+		tlsConf *tls.Config
+	{{- end }}
 	)
 	{
 		addr = *addrF
@@ -146,6 +184,14 @@ const (
 		}
 		timeout = *timeoutF
 		debug = *verboseF || *vF
+	{{- if .Server.TLS }}
+		var err error
+		tlsConf, err = clientTLSConfig(*tlsCertF, *tlsKeyF, *tlsCAF)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	{{- end }}
 	}
 
 	var (
@@ -160,6 +206,12 @@ const (
 		}
 		scheme = u.Scheme
 		host = u.Host
+	{{- if .Server.HasScheme "unix" }}
+		if scheme == "unix" {
+			{{ comment "The host of the requests sent to a Unix domain socket is the path of the socket." }}
+			host = u.Host + u.Path
+		}
+	{{- end }}
 	}
 `
 
@@ -172,8 +224,8 @@ const (
 	{
 		switch scheme {
 	{{- range $t := .Server.Transports }}
-		case "{{ $t.Type }}", "{{ $t.Type }}s":
-			endpoint, payload, err = do{{ toUpper $t.Name }}(scheme, host, timeout, debug)
+		case "{{ $t.Type }}", "{{ $t.Type }}s"{{ if and (eq $t.Type "http") ($.Server.HasScheme "unix") }}, "unix"{{ end }}:
+			endpoint, payload, err = do{{ toUpper $t.Name }}(scheme, host, timeout, debug{{ if $.Server.TLS }}, tlsConf{{ end }})
 	{{- end }}
 		default:
 			fmt.Fprintf(os.Stderr, "invalid scheme: %q (valid schemes: {{ join .Server.Schemes "|" }})", scheme)
@@ -204,18 +256,52 @@ const (
 }
 `
 
-	// input: map[string]interface{}{"APIName": string, "Server": *Data}
+	// input: *Data
+	cliMainTLST = `{{ if .TLS }}
+// clientTLSConfig returns the TLS configuration used to connect to the server.
+// The client presents the certificate loaded from certFile and keyFile if set
+// and verifies the server certificate with the authorities loaded from caFile
+// or with the system roots if caFile is empty.
+func clientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	conf := &tls.Config{}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS client certificate: %s", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		b, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS CA: %s", err)
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("invalid TLS CA: no certificate found in %s", caFile)
+		}
+	}
+	return conf, nil
+}
+{{ end }}`
+
+	// input: map[string]interface{}{"APIName": string, "Server": *Data, "Timeout": int}
 	cliMainUsageT = `
 func usage() {
   fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the {{ .APIName }} API.
 
 Usage:
-    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v]{{ range .Server.Variables }}[-{{ .Name }} {{ toUpper .Name }}]{{ end }} SERVICE ENDPOINT [flags]
+    %s [-host HOST][-url URL][-timeout SECONDS][-verbose|-v]{{ if .Server.TLS }}[-tls-cert FILE -tls-key FILE][-tls-ca FILE]{{ end }}{{ range .Server.Variables }}[-{{ .Name }} {{ toUpper .Name }}]{{ end }} SERVICE ENDPOINT [flags]
 
     -host HOST:  server host ({{ .Server.DefaultHost.Name }}). valid values: {{ (join .Server.AvailableHosts ", ") }}
     -url URL:    specify service URL overriding host URL (http://localhost:8080)
-    -timeout:    maximum number of seconds to wait for response (30)
+    -timeout:    maximum number of seconds to wait for response ({{ .Timeout }})
     -verbose|-v: print request and response details (false)
+	{{- if .Server.TLS }}
+    -tls-cert:   client certificate presented to the server
+    -tls-key:    private key of the client certificate
+    -tls-ca:     authorities that sign the server certificate (system roots)
+	{{- end }}
 	{{- range .Server.Variables }}
     -{{ .Name }}:    {{ .Description }} ({{ .DefaultValue }})
 	{{- end }}
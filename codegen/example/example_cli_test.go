@@ -17,9 +17,12 @@ func TestExampleCLIFiles(t *testing.T) {
 	}{
 		{"no-server", testdata.NoServerDSL, testdata.NoServerCLIMainCode},
 		{"single-server-single-host", testdata.SingleServerSingleHostDSL, testdata.SingleServerSingleHostCLIMainCode},
+		{"single-server-timeout", testdata.SingleServerTimeoutDSL, testdata.SingleServerTimeoutCLIMainCode},
 		{"single-server-single-host-with-variables", testdata.SingleServerSingleHostWithVariablesDSL, testdata.SingleServerSingleHostWithVariablesCLIMainCode},
 		{"single-server-multiple-hosts", testdata.SingleServerMultipleHostsDSL, testdata.SingleServerMultipleHostsCLIMainCode},
 		{"single-server-multiple-hosts-with-variables", testdata.SingleServerMultipleHostsWithVariablesDSL, testdata.SingleServerMultipleHostsWithVariablesCLIMainCode},
+		{"single-server-mutual-tls", testdata.SingleServerMutualTLSDSL, testdata.SingleServerMutualTLSCLIMainCode},
+		{"single-server-unix-socket", testdata.SingleServerUnixSocketDSL, testdata.SingleServerUnixSocketCLIMainCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -19,6 +19,17 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if m := exampleSvrMain(genpkg, root, svr); m != nil {
 			fw = append(fw, m)
 		}
+		if c := exampleSvrConfig(svr); c != nil {
+			fw = append(fw, c)
+		}
+		if s := exampleSvrShutdown(svr); s != nil {
+			fw = append(fw, s)
+		}
+		if root.API.ExampleGRPCHealth() {
+			if h := exampleSvrHealth(svr); h != nil {
+				fw = append(fw, h)
+			}
+		}
 	}
 	return fw
 }
@@ -41,6 +52,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		{Path: "os/signal"},
 		{Path: "strings"},
 		{Path: "sync"},
+		{Path: "syscall"},
 		{Path: "time"},
 		codegen.GoaImport("middleware"),
 	}
@@ -91,6 +103,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 				"APIPkg": apiPkg,
 			},
 		},
+		&codegen.SectionTemplate{Name: "server-main-config", Source: mainConfigT},
 		&codegen.SectionTemplate{
 			Name:   "server-main-services",
 			Source: mainSvcsT,
@@ -113,6 +126,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			},
 		},
 		&codegen.SectionTemplate{Name: "server-main-interrupts", Source: mainInterruptsT},
+		&codegen.SectionTemplate{Name: "server-main-reload", Source: mainReloadT},
 		&codegen.SectionTemplate{
 			Name:   "server-main-handler",
 			Source: mainServerHndlrT,
@@ -132,6 +146,71 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 	return &codegen.File{Path: mainPath, SectionTemplates: sections, SkipExist: true}
 }
 
+// exampleSvrConfig returns the file that implements the loading and reloading
+// of the configuration settings of the given server expression.
+func exampleSvrConfig(svr *expr.ServerExpr) *codegen.File {
+	svrdata := Servers.Get(svr)
+	cfgPath := filepath.Join("cmd", svrdata.Dir, "config.go")
+	if _, err := os.Stat(cfgPath); !os.IsNotExist(err) {
+		return nil // file already exists, skip it.
+	}
+	specs := []*codegen.ImportSpec{
+		{Path: "crypto/tls"},
+		{Path: "crypto/x509"},
+		{Path: "encoding/json"},
+		{Path: "fmt"},
+		{Path: "io/ioutil"},
+		{Path: "log"},
+		{Path: "os"},
+		{Path: "os/signal"},
+		{Path: "sync"},
+		{Path: "sync/atomic"},
+		{Path: "syscall"},
+		{Path: "time"},
+		codegen.GoaImport("middleware"),
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("", "main", specs),
+		&codegen.SectionTemplate{Name: "server-config", Source: configT, Data: svrdata},
+	}
+	return &codegen.File{Path: cfgPath, SectionTemplates: sections, SkipExist: true}
+}
+
+// exampleSvrShutdown returns the file that implements the hooks run once the
+// servers of the given server expression have shut down.
+func exampleSvrShutdown(svr *expr.ServerExpr) *codegen.File {
+	svrdata := Servers.Get(svr)
+	shutdownPath := filepath.Join("cmd", svrdata.Dir, "shutdown.go")
+	if _, err := os.Stat(shutdownPath); !os.IsNotExist(err) {
+		return nil // file already exists, skip it.
+	}
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "log"},
+		{Path: "sync"},
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("", "main", specs),
+		&codegen.SectionTemplate{Name: "server-shutdown", Source: shutdownT},
+	}
+	return &codegen.File{Path: shutdownPath, SectionTemplates: sections, SkipExist: true}
+}
+
+// exampleSvrHealth returns the file that declares the health checks run by the
+// health check endpoints of the given server expression.
+func exampleSvrHealth(svr *expr.ServerExpr) *codegen.File {
+	svrdata := Servers.Get(svr)
+	healthPath := filepath.Join("cmd", svrdata.Dir, "health.go")
+	if _, err := os.Stat(healthPath); !os.IsNotExist(err) {
+		return nil // file already exists, skip it.
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("", "main", []*codegen.ImportSpec{codegen.GoaImport("")}),
+		&codegen.SectionTemplate{Name: "server-health", Source: healthT},
+	}
+	return &codegen.File{Path: healthPath, SectionTemplates: sections, SkipExist: true}
+}
+
 // mustInitServices returns true if at least one of the services defines methods.
 // It is used by the template to initialize service variables.
 func mustInitServices(data []*service.Data) bool {
@@ -150,15 +229,22 @@ func main() {
 	{{ comment "Define command line flags, add any other flag required to configure the service." }}
 	var(
 		hostF = flag.String("host", {{ printf "%q" .Server.DefaultHost.Name }}, "Server host (valid values: {{ (join .Server.AvailableHosts ", ") }})")
+	{{- if .Server.HasTCPURI }}
 		domainF = flag.String("domain", "", "Host domain name (overrides host domain specified in service design)")
+	{{- end }}
 	{{- range .Server.Transports }}
+		{{- if $.Server.HasTCPURI .Type }}
 	{{ .Type }}PortF = flag.String("{{ .Type }}-port", "", "{{ .Name }} port (overrides host {{ .Name }} port specified in service design)")
+		{{- end }}
 	{{- end }}
 	{{- range .Server.Variables }}
 	{{ .VarName }}F = flag.String({{ printf "%q" .Name }}, {{ printf "%q" .DefaultValue }}, "{{ .Description }}{{ if .Values }} (valid values: {{ join .Values ", " }}){{ end }}")
 	{{- end }}
+	{{- if .Server.HasTCPURI }}
 		secureF = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
+	{{- end }}
 		dbgF  = flag.Bool("debug", false, "Log request and response bodies")
+		configF = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 `
@@ -174,6 +260,14 @@ func main() {
 	}
 `
 
+	mainConfigT = `
+	{{ comment "Load the configuration settings that can be changed without restarting the servers, see config.go." }}
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+`
+
 	// input: map[string]interface{"APIPkg": string, "Services": []*service.Data}
 	mainSvcsT = `
 {{- if mustInitServices .Services }}
@@ -213,6 +307,14 @@ func main() {
 		{{- end }}
 	{{- end }}
 	}
+
+	{{ comment "Inject the latency and errors described by the faults setting of the configuration into the endpoint requests. The setting is read on each request so that reloading the configuration changes the injected faults." }}
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	{{- range .Services }}
+		{{- if .Methods }}
+	{{ .VarName }}Endpoints.Use(middleware.FaultInjection(faults))
+		{{- end }}
+	{{- end }}
 {{- end }}
 `
 
@@ -225,7 +327,7 @@ func main() {
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
@@ -233,6 +335,13 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 `
 
+	mainReloadT = `
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+`
+
 	// input: map[string]interface{"Server": *Data, "Services": []*service.Data}
 	mainServerHndlrT = `
 	{{ comment "Start the servers and send errors (if any) to the error channel." }}
@@ -266,6 +375,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "invalid URL %#v: %s", addr, err)
 				os.Exit(1)
 			}
+		{{- if ne $u.Scheme "unix" }}
 			if *secureF {
 				u.Scheme = "{{ $u.Transport.Type }}s"
 			}
@@ -278,6 +388,7 @@ func main() {
 			} else if u.Port() == "" {
 				u.Host += ":{{ $u.Port }}"
 			}
+		{{- end }}
 			handle{{ toUpper $u.Transport.Name }}Server(ctx, u, {{ range $.Services }}{{ if .Methods }}{{ .VarName }}Endpoints, {{ end }}{{ end }}&wg, errc, logger, *dbgF)
 		}
 	{{- end }}
@@ -292,11 +403,254 @@ func main() {
 	{{ comment "Wait for signal." }}
 	logger.Printf("exiting (%v)", <-errc)
 
-	{{ comment "Send cancellation signal to the goroutines." }}
+	{{ comment "Send cancellation signal to the goroutines, the servers stop accepting new requests and drain the in-flight ones." }}
 	cancel()
 
 	wg.Wait()
+
+	{{ comment "Flush the in-flight work once the servers have shut down, see shutdown.go." }}
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
+`
+
+	shutdownT = `
+var (
+	// shutdownMu protects shutdownHooks.
+	shutdownMu sync.Mutex
+	// shutdownHooks lists the functions called once the servers have shut
+	// down.
+	shutdownHooks []func(context.Context) error
+)
+
+// onShutdown registers a function called once the servers have stopped
+// accepting requests and drained the in-flight ones, e.g. to flush buffered
+// work or close the connections to the service dependencies. The hooks are
+// called in the reverse order of their registration. The context is canceled
+// once the shutdown timeout set in the configuration is reached.
+func onShutdown(hook func(context.Context) error) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
+// runShutdownHooks calls the shutdown hooks and logs their errors.
+func runShutdownHooks(ctx context.Context, logger *log.Logger) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	for i := len(shutdownHooks) - 1; i >= 0; i-- {
+		if err := shutdownHooks[i](ctx); err != nil {
+			logger.Printf("shutdown hook failed: %s", err)
+		}
+	}
+}
+`
+
+	healthT = `
+// health holds the checks run by the health check endpoints of the servers.
+// Register the checks of the service dependencies before starting the
+// servers, for example:
+//
+//    health.AddReadinessCheck("database", db.PingContext)
+//
+var health = goa.NewHealth()
+`
+
+	configT = `
+// config lists the server settings that can be changed without restarting the
+// process. The settings are read from the JSON file given on the command line
+// on startup and each time the process receives a SIGHUP signal. Add user
+// defined settings to the struct and apply them in a function registered with
+// onReload.
+type config struct {
+	// Debug enables logging of request and response bodies.
+	Debug bool ` + "`" + `json:"debug"` + "`" + `
+	// Timeout is the maximum duration of a request, e.g. "30s". No
+	// timeout applies if empty.
+	Timeout string ` + "`" + `json:"timeout"` + "`" + `
+	// ShutdownTimeout is the maximum duration of the graceful shutdown of
+	// the servers and of the shutdown hooks, e.g. "1m". Defaults to 30s.
+	ShutdownTimeout string ` + "`" + `json:"shutdown_timeout"` + "`" + `
+	// TLSCert and TLSKey are the paths to the PEM encoded certificate and
+	// private key used to serve TLS connections.
+	TLSCert string ` + "`" + `json:"tls_cert"` + "`" + `
+	TLSKey  string ` + "`" + `json:"tls_key"` + "`" + `
+{{- with .TLS }}{{ if .ClientAuth }}
+	// TLSClientCA is the path to the PEM encoded certificates of the
+	// authorities that sign the client certificates.
+	TLSClientCA string ` + "`" + `json:"tls_client_ca"` + "`" + `
+{{- end }}{{ end }}
+	// Faults describes the latency and errors injected into the requests
+	// made to the endpoints, keyed by "<service>.<method>", "<service>" or
+	// "*". For example:
+	//
+	//	"faults": {
+	//		"*": {"latency": {"distribution": "normal", "mean": "50ms", "stddev": "20ms"}},
+	//		"svc.method": {"error_rate": 0.1, "error": "unavailable"}
+	//	}
+	//
+	// See the documentation of the goa middleware package for the available
+	// distributions and errors.
+	Faults middleware.Faults ` + "`" + `json:"faults"` + "`" + `
+
+	timeout         time.Duration
+	shutdownTimeout time.Duration
+	cert            *tls.Certificate
+{{- with .TLS }}{{ if .ClientAuth }}
+	clientCAs       *x509.CertPool
+{{- end }}{{ end }}
+}
+
+// defaultShutdownTimeout is the maximum duration of the graceful shutdown used
+// when the configuration does not set one.
+const defaultShutdownTimeout = 30 * time.Second
+
+var (
+	// cfg holds the current configuration.
+	cfg atomic.Value
+	// reloadMu serializes the configuration reloads.
+	reloadMu sync.Mutex
+	// reloadHooks lists the functions called with the new configuration
+	// before it replaces the current one.
+	reloadHooks []func(*config) error
+)
+
+// currentConfig returns the current configuration.
+func currentConfig() *config {
+	if c, ok := cfg.Load().(*config); ok {
+		return c
+	}
+	return &config{shutdownTimeout: defaultShutdownTimeout}
+}
+
+// onReload registers a function that applies the given configuration. The
+// function is called on startup and each time the configuration is reloaded.
+// Returning an error aborts the reload and keeps the current configuration.
+func onReload(hook func(*config) error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// reloadConfig reads the configuration file at path, calls the reload hooks
+// and makes the result the current configuration. debug is the default value
+// of the Debug setting.
+func reloadConfig(path string, debug bool) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	c, err := loadConfig(path, debug)
+	if err != nil {
+		return err
+	}
+{{- if .TLS }}
+	if c.cert == nil {
+		return fmt.Errorf("the server requires TLS, the configuration must set tls_cert and tls_key")
+	}
+	{{- if .TLS.ClientAuth }}
+	if c.clientCAs == nil {
+		return fmt.Errorf("the server verifies the client certificates, the configuration must set tls_client_ca")
+	}
+	{{- end }}
+{{- else }}
+	if cur, ok := cfg.Load().(*config); ok && cur.cert != nil && c.cert == nil {
+		return fmt.Errorf("TLS cannot be disabled without restarting the server")
+	}
+{{- end }}
+	for _, hook := range reloadHooks {
+		if err := hook(c); err != nil {
+			return err
+		}
+	}
+	cfg.Store(c)
+	return nil
+}
+
+// loadConfig reads and validates the configuration file at path. It returns
+// the default configuration if path is empty.
+func loadConfig(path string, debug bool) (*config, error) {
+	c := &config{Debug: debug, shutdownTimeout: defaultShutdownTimeout}
+	if path == "" {
+		return c, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	if c.Timeout != "" {
+		if c.timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return nil, fmt.Errorf("%s: invalid timeout: %s", path, err)
+		}
+	}
+	if c.ShutdownTimeout != "" {
+		if c.shutdownTimeout, err = time.ParseDuration(c.ShutdownTimeout); err != nil {
+			return nil, fmt.Errorf("%s: invalid shutdown timeout: %s", path, err)
+		}
+	}
+	if err := c.Faults.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: invalid %s", path, err)
+	}
+	if c.TLSCert != "" || c.TLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid TLS certificate: %s", path, err)
+		}
+		c.cert = &cert
+	}
+{{- with .TLS }}{{ if .ClientAuth }}
+	if c.TLSClientCA != "" {
+		b, err := ioutil.ReadFile(c.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid TLS client CA: %s", path, err)
+		}
+		c.clientCAs = x509.NewCertPool()
+		if !c.clientCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("%s: invalid TLS client CA: no certificate found in %s", path, c.TLSClientCA)
+		}
+	}
+{{- end }}{{ end }}
+	return c, nil
+}
+
+// serverTLSConfig returns the TLS configuration of the servers that negotiate
+// the given application protocols. The TLS settings are read from the current
+// configuration on each handshake so that reloading the configuration rotates
+// the certificates. The servers only use TLS if the configuration sets a
+// certificate.
+func serverTLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := currentConfig()
+			return &tls.Config{
+				Certificates: []tls.Certificate{*c.cert},
+				NextProtos:   nextProtos,
+			{{- with .TLS }}{{ if .ClientAuth }}
+				ClientAuth:   tls.{{ .ClientAuth }},
+				ClientCAs:    c.clientCAs,
+			{{- end }}{{ end }}
+			}, nil
+		},
+	}
+}
+
+// handleReload reloads the configuration each time the process receives a
+// SIGHUP signal. The current configuration is kept if the new one is invalid.
+func handleReload(path string, debug bool, logger *log.Logger) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	for range c {
+		if err := reloadConfig(path, debug); err != nil {
+			logger.Printf("failed to reload configuration: %s", err)
+			continue
+		}
+		logger.Printf("configuration reloaded")
+	}
+}
 `
 )
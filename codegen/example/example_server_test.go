@@ -26,6 +26,7 @@ func TestExampleServerFiles(t *testing.T) {
 		{"server-hosting-multiple-services", testdata.ServerHostingMultipleServicesDSL, testdata.ServerHostingMultipleServicesServerMainCode},
 		{"single-server-multiple-hosts", testdata.SingleServerMultipleHostsDSL, testdata.SingleServerMultipleHostsServerMainCode},
 		{"single-server-multiple-hosts-with-variables", testdata.SingleServerMultipleHostsWithVariablesDSL, testdata.SingleServerMultipleHostsWithVariablesServerMainCode},
+		{"single-server-unix-socket", testdata.SingleServerUnixSocketDSL, testdata.SingleServerUnixSocketServerMainCode},
 		{"service-name-with-spaces", ctestdata.NamesWithSpacesDSL, testdata.NamesWithSpacesServerMainCode},
 	}
 	for _, c := range cases {
@@ -54,3 +55,88 @@ func TestExampleServerFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestExampleServerConfigFile(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Path string
+		Code string
+	}{
+		{"single-server-single-host", testdata.SingleServerSingleHostDSL, "cmd/single_host/config.go", testdata.ServerConfigCode},
+		{"mutual-tls", testdata.SingleServerMutualTLSDSL, "cmd/mutualtls/config.go", testdata.MutualTLSServerConfigCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			service.Services = make(service.ServicesData)
+			Servers = make(ServersData)
+			codegen.RunDSL(t, c.DSL)
+			fs := ServerFiles("", expr.Root)
+			if len(fs) != 3 {
+				t.Fatalf("got %d files, expected 3", len(fs))
+			}
+			f := fs[1]
+			if f.Path != c.Path {
+				t.Errorf("got file path %q, expected %q", f.Path, c.Path)
+			}
+			var buf bytes.Buffer
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(&buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+			if code != c.Code {
+				t.Errorf("invalid code for %s: got\n%s\ngot vs. expected:\n%s", f.Path, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestExampleServerShutdownFile(t *testing.T) {
+	service.Services = make(service.ServicesData)
+	Servers = make(ServersData)
+	codegen.RunDSL(t, testdata.SingleServerSingleHostDSL)
+	fs := ServerFiles("", expr.Root)
+	if len(fs) != 3 {
+		t.Fatalf("got %d files, expected 3", len(fs))
+	}
+	f := fs[2]
+	if f.Path != "cmd/single_host/shutdown.go" {
+		t.Errorf("got file path %q, expected %q", f.Path, "cmd/single_host/shutdown.go")
+	}
+	var buf bytes.Buffer
+	for _, s := range f.SectionTemplates[1:] {
+		if err := s.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+	if code != testdata.ServerShutdownCode {
+		t.Errorf("invalid code for %s: got\n%s\ngot vs. expected:\n%s", f.Path, code, codegen.Diff(t, code, testdata.ServerShutdownCode))
+	}
+}
+
+func TestExampleServerHealthFile(t *testing.T) {
+	service.Services = make(service.ServicesData)
+	Servers = make(ServersData)
+	codegen.RunDSL(t, testdata.HealthCheckDSL)
+	fs := ServerFiles("", expr.Root)
+	if len(fs) != 4 {
+		t.Fatalf("got %d files, expected 4", len(fs))
+	}
+	f := fs[3]
+	if f.Path != "cmd/single_host/health.go" {
+		t.Errorf("got file path %q, expected %q", f.Path, "cmd/single_host/health.go")
+	}
+	var buf bytes.Buffer
+	for _, s := range f.SectionTemplates[1:] {
+		if err := s.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+	if code != testdata.ServerHealthCode {
+		t.Errorf("invalid code for %s: got\n%s\ngot vs. expected:\n%s", f.Path, code, codegen.Diff(t, code, testdata.ServerHealthCode))
+	}
+}
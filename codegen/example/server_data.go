@@ -36,6 +36,17 @@ type (
 		Transports []*TransportData
 		// Dir is the directory name for the generated client and server examples.
 		Dir string
+		// TLS describes the TLS requirements of the server, nil if the
+		// server does not require TLS.
+		TLS *TLSData
+	}
+
+	// TLSData contains the data about the TLS requirements of a server.
+	TLSData struct {
+		// ClientAuth is the name of the crypto/tls ClientAuthType constant
+		// that corresponds to the client certificate policy, empty if the
+		// server does not ask for client certificates.
+		ClientAuth string
 	}
 
 	// HostData contains the data about a single host in a server.
@@ -147,6 +158,39 @@ func (s *Data) DefaultTransport() *TransportData {
 	return nil // bug
 }
 
+// HasScheme checks if at least one of the server hosts defines a URI with the
+// given scheme.
+func (s *Data) HasScheme(scheme string) bool {
+	for _, sch := range s.Schemes {
+		if sch == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTCPURI checks if at least one of the server hosts defines a URI that is
+// served over TCP, that is a URI that does not use the "unix" scheme. Only the
+// URIs of the given transports are considered if any.
+func (s *Data) HasTCPURI(transports ...Transport) bool {
+	for _, h := range s.Hosts {
+		for _, u := range h.URIs {
+			if u.Scheme == "unix" {
+				continue
+			}
+			if len(transports) == 0 {
+				return true
+			}
+			for _, t := range transports {
+				if u.Transport.Type == t {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // HasTransport checks if the server supports the given transport.
 func (s *Data) HasTransport(transport Transport) bool {
 	for _, t := range s.Transports {
@@ -228,9 +272,25 @@ func buildServerData(svr *expr.ServerExpr) *Data {
 		Variables:   variables,
 		Transports:  transports,
 		Dir:         codegen.SnakeCase(codegen.Goify(svr.Name, true)),
+		TLS:         buildTLSData(svr.TLS),
 	}
 }
 
+// buildTLSData builds the TLS data for the given TLS expression.
+func buildTLSData(t *expr.TLSExpr) *TLSData {
+	if t == nil {
+		return nil
+	}
+	var clientAuth string
+	switch t.ClientAuth {
+	case "request":
+		clientAuth = "VerifyClientCertIfGiven"
+	case "require":
+		clientAuth = "RequireAndVerifyClientCert"
+	}
+	return &TLSData{ClientAuth: clientAuth}
+}
+
 // buildHostData builds the host data for the given host expression.
 func buildHostData(host *expr.HostExpr) *HostData {
 	var (
@@ -268,6 +328,10 @@ func buildHostData(host *expr.HostExpr) *HostData {
 					scheme = "grpc"
 					port = "8080"
 					t = newGRPCTransport()
+				case strings.HasPrefix(ustr, "unix"):
+					// Unix domain sockets serve HTTP and have no port.
+					scheme = "unix"
+					t = newHTTPTransport()
 
 					// No need for default case here because we only support the above
 					// possibilites for the scheme. Invalid scheme would have failed
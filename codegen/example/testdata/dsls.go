@@ -25,6 +25,56 @@ var SameAPIServiceNameDSL = func() {
 	})
 }
 
+var SingleServerMutualTLSDSL = func() {
+	API("SingleServerMutualTLS", func() {
+		Server("MutualTLS", func() {
+			Services("Service")
+			TLS(func() {
+				ClientAuth("require")
+			})
+			Host("dev", func() {
+				URI("https://example:8443")
+				URI("grpcs://example:8080")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var SingleServerUnixSocketDSL = func() {
+	API("SingleServerUnixSocket", func() {
+		Server("UnixSocket", func() {
+			Services("Service")
+			Host("local", func() {
+				URI("unix:///var/run/svc.sock")
+				URI("grpc://localhost:8080")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+			GRPC(func() {})
+		})
+		Method("Stream", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
 var SingleServerSingleHostDSL = func() {
 	API("SingleServerSingleHost", func() {
 		Server("SingleHost", func() {
@@ -46,6 +96,31 @@ var SingleServerSingleHostDSL = func() {
 	})
 }
 
+var SingleServerTimeoutDSL = func() {
+	API("SingleServerTimeout", func() {
+		Server("SingleHost", func() {
+			Services("Service")
+			Host("dev", func() {
+				URI("http://example:8090")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Timeout("1m30s")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Other", func() {
+			Timeout("500ms")
+			HTTP(func() {
+				GET("/other")
+			})
+		})
+	})
+}
+
 var SingleServerSingleHostWithVariablesDSL = func() {
 	API("SingleServerSingleHostWithVariables", func() {
 		Server("SingleHost", func() {
@@ -207,3 +282,40 @@ var SingleServerMultipleHostsWithVariablesDSL = func() {
 		})
 	})
 }
+
+var HealthCheckDSL = func() {
+	API("HealthCheck", func() {
+		HealthCheck()
+		Server("SingleHost", func() {
+			Host("dev", func() {
+				URI("http://example:8090")
+				URI("grpc://example:8080")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var GRPCReflectionHealthDSL = func() {
+	API("GRPCReflectionHealth", func() {
+		Meta("grpc:example:reflection")
+		Meta("grpc:example:health")
+		Server("SingleHost", func() {
+			Host("dev", func() {
+				URI("grpc://example:8080")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			GRPC(func() {})
+		})
+	})
+}
@@ -11,6 +11,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -22,6 +23,13 @@ const (
 		logger = log.New(os.Stderr, "[testapi] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -39,6 +47,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -47,13 +61,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "localhost":
@@ -108,10 +127,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -126,6 +153,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -137,6 +165,13 @@ const (
 		logger = log.New(os.Stderr, "[serviceapi] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -154,6 +189,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -162,13 +203,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "localhost":
@@ -223,10 +269,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -241,6 +295,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -252,6 +307,13 @@ const (
 		logger = log.New(os.Stderr, "[singleserversinglehost] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -269,6 +331,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -277,13 +345,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "dev":
@@ -360,10 +433,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -386,6 +467,7 @@ const (
 		bool_F    = flag.String("bool", "true", "")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -397,6 +479,13 @@ const (
 		logger = log.New(os.Stderr, "[singleserversinglehostwithvariables] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -414,6 +503,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -422,13 +517,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "dev":
@@ -501,10 +601,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -518,6 +626,7 @@ const (
 		httpPortF = flag.String("http-port", "", "HTTP port (overrides host HTTP port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -529,6 +638,13 @@ const (
 		logger = log.New(os.Stderr, "[serverhostingservicewithfileserver] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -537,13 +653,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "svc":
@@ -576,10 +697,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -594,6 +723,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -605,6 +735,13 @@ const (
 		logger = log.New(os.Stderr, "[serverhostingservicesubset] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -622,6 +759,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -630,13 +773,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "dev":
@@ -691,10 +839,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -709,6 +865,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -720,6 +877,13 @@ const (
 		logger = log.New(os.Stderr, "[serverhostingmultipleservices] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc        service.Service
@@ -741,6 +905,13 @@ const (
 		anotherServiceEndpoints = anotherservice.NewEndpoints(anotherServiceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+	anotherServiceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -749,13 +920,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "dev":
@@ -810,10 +986,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -827,6 +1011,7 @@ const (
 		httpPortF = flag.String("http-port", "", "HTTP port (overrides host HTTP port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -838,6 +1023,13 @@ const (
 		logger = log.New(os.Stderr, "[singleservermultiplehosts] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -855,6 +1047,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -863,13 +1061,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "dev":
@@ -925,10 +1128,18 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
@@ -945,6 +1156,7 @@ const (
 		portF     = flag.String("port", "8080", "Port")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -956,6 +1168,13 @@ const (
 		logger = log.New(os.Stderr, "[singleservermultiplehostswithvariables] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceSvc service.Service
@@ -973,6 +1192,12 @@ const (
 		serviceEndpoints = service.NewEndpoints(serviceSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -981,13 +1206,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "dev":
@@ -1059,13 +1289,150 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
+	cancel()
+
+	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
+	logger.Println("exited")
+}
+`
+	SingleServerUnixSocketServerMainCode = `func main() {
+	// Define command line flags, add any other flag required to configure the
+	// service.
+	var (
+		hostF     = flag.String("host", "local", "Server host (valid values: local)")
+		domainF   = flag.String("domain", "", "Host domain name (overrides host domain specified in service design)")
+		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
+		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
+		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
+	)
+	flag.Parse()
+
+	// Setup logger. Replace logger with your own log package of choice.
+	var (
+		logger *log.Logger
+	)
+	{
+		logger = log.New(os.Stderr, "[singleserverunixsocket] ", log.Ltime)
+	}
+
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize the services.
+	var (
+		serviceSvc service.Service
+	)
+	{
+		serviceSvc = singleserverunixsocket.NewService(logger)
+	}
+
+	// Wrap the services in endpoints that can be invoked from other services
+	// potentially running in different processes.
+	var (
+		serviceEndpoints *service.Endpoints
+	)
+	{
+		serviceEndpoints = service.NewEndpoints(serviceSvc)
+	}
+
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceEndpoints.Use(middleware.FaultInjection(faults))
+
+	// Create channel used by both the signal handler and server goroutines
+	// to notify the main goroutine when to stop the server.
+	errc := make(chan error)
+
+	// Setup interrupt handler. This optional step configures the process so
+	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		errc <- fmt.Errorf("%s", <-c)
+	}()
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
+	// Start the servers and send errors (if any) to the error channel.
+	switch *hostF {
+	case "local":
+		{
+			addr := "unix:///var/run/svc.sock"
+			u, err := url.Parse(addr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid URL %#v: %s", addr, err)
+				os.Exit(1)
+			}
+			handleHTTPServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+		}
+
+		{
+			addr := "grpc://localhost:8080"
+			u, err := url.Parse(addr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid URL %#v: %s", addr, err)
+				os.Exit(1)
+			}
+			if *secureF {
+				u.Scheme = "grpcs"
+			}
+			if *domainF != "" {
+				u.Host = *domainF
+			}
+			if *grpcPortF != "" {
+				h := strings.Split(u.Host, ":")[0]
+				u.Host = h + ":" + *grpcPortF
+			} else if u.Port() == "" {
+				u.Host += ":8080"
+			}
+			handleGRPCServer(ctx, u, serviceEndpoints, &wg, errc, logger, *dbgF)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "invalid host argument: %q (valid hosts: local)", *hostF)
+	}
+
+	// Wait for signal.
+	logger.Printf("exiting (%v)", <-errc)
+
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
 `
+
 	NamesWithSpacesServerMainCode = `func main() {
 	// Define command line flags, add any other flag required to configure the
 	// service.
@@ -1076,6 +1443,7 @@ const (
 		grpcPortF = flag.String("grpc-port", "", "gRPC port (overrides host gRPC port specified in service design)")
 		secureF   = flag.Bool("secure", false, "Use secure scheme (https or grpcs)")
 		dbgF      = flag.Bool("debug", false, "Log request and response bodies")
+		configF   = flag.String("config", "", "Path to the JSON configuration file reloaded on SIGHUP")
 	)
 	flag.Parse()
 
@@ -1087,6 +1455,13 @@ const (
 		logger = log.New(os.Stderr, "[apiwithspaces] ", log.Ltime)
 	}
 
+	// Load the configuration settings that can be changed without restarting the
+	// servers, see config.go.
+	if err := reloadConfig(*configF, *dbgF); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize the services.
 	var (
 		serviceWithSpacesSvc servicewithspaces.Service
@@ -1104,6 +1479,12 @@ const (
 		serviceWithSpacesEndpoints = servicewithspaces.NewEndpoints(serviceWithSpacesSvc)
 	}
 
+	// Inject the latency and errors described by the faults setting of the
+	// configuration into the endpoint requests. The setting is read on each
+	// request so that reloading the configuration changes the injected faults.
+	faults := func() middleware.Faults { return currentConfig().Faults }
+	serviceWithSpacesEndpoints.Use(middleware.FaultInjection(faults))
+
 	// Create channel used by both the signal handler and server goroutines
 	// to notify the main goroutine when to stop the server.
 	errc := make(chan error)
@@ -1112,13 +1493,18 @@ const (
 	// that SIGINT and SIGTERM signals cause the services to stop gracefully.
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		errc <- fmt.Errorf("%s", <-c)
 	}()
 
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Setup reload handler. This optional step configures the process so
+	// that SIGHUP signals cause the configuration file to be read again and
+	// applied to the running servers without restarting them.
+	go handleReload(*configF, *dbgF, logger)
+
 	// Start the servers and send errors (if any) to the error channel.
 	switch *hostF {
 	case "svc":
@@ -1173,11 +1559,393 @@ const (
 	// Wait for signal.
 	logger.Printf("exiting (%v)", <-errc)
 
-	// Send cancellation signal to the goroutines.
+	// Send cancellation signal to the goroutines, the servers stop accepting new
+	// requests and drain the in-flight ones.
 	cancel()
 
 	wg.Wait()
+
+	// Flush the in-flight work once the servers have shut down, see shutdown.go.
+	{
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		runShutdownHooks(ctx, logger)
+		cancel()
+	}
 	logger.Println("exited")
 }
+`
+
+	ServerConfigCode = `// config lists the server settings that can be changed without restarting the
+// process. The settings are read from the JSON file given on the command line
+// on startup and each time the process receives a SIGHUP signal. Add user
+// defined settings to the struct and apply them in a function registered with
+// onReload.
+type config struct {
+	// Debug enables logging of request and response bodies.
+	Debug bool ` + "`" + `json:"debug"` + "`" + `
+	// Timeout is the maximum duration of a request, e.g. "30s". No
+	// timeout applies if empty.
+	Timeout string ` + "`" + `json:"timeout"` + "`" + `
+	// ShutdownTimeout is the maximum duration of the graceful shutdown of
+	// the servers and of the shutdown hooks, e.g. "1m". Defaults to 30s.
+	ShutdownTimeout string ` + "`" + `json:"shutdown_timeout"` + "`" + `
+	// TLSCert and TLSKey are the paths to the PEM encoded certificate and
+	// private key used to serve TLS connections.
+	TLSCert string ` + "`" + `json:"tls_cert"` + "`" + `
+	TLSKey  string ` + "`" + `json:"tls_key"` + "`" + `
+	// Faults describes the latency and errors injected into the requests
+	// made to the endpoints, keyed by "<service>.<method>", "<service>" or
+	// "*". For example:
+	//
+	//	"faults": {
+	//		"*": {"latency": {"distribution": "normal", "mean": "50ms", "stddev": "20ms"}},
+	//		"svc.method": {"error_rate": 0.1, "error": "unavailable"}
+	//	}
+	//
+	// See the documentation of the goa middleware package for the available
+	// distributions and errors.
+	Faults middleware.Faults ` + "`" + `json:"faults"` + "`" + `
+
+	timeout         time.Duration
+	shutdownTimeout time.Duration
+	cert            *tls.Certificate
+}
+
+// defaultShutdownTimeout is the maximum duration of the graceful shutdown used
+// when the configuration does not set one.
+const defaultShutdownTimeout = 30 * time.Second
+
+var (
+	// cfg holds the current configuration.
+	cfg atomic.Value
+	// reloadMu serializes the configuration reloads.
+	reloadMu sync.Mutex
+	// reloadHooks lists the functions called with the new configuration
+	// before it replaces the current one.
+	reloadHooks []func(*config) error
+)
+
+// currentConfig returns the current configuration.
+func currentConfig() *config {
+	if c, ok := cfg.Load().(*config); ok {
+		return c
+	}
+	return &config{shutdownTimeout: defaultShutdownTimeout}
+}
+
+// onReload registers a function that applies the given configuration. The
+// function is called on startup and each time the configuration is reloaded.
+// Returning an error aborts the reload and keeps the current configuration.
+func onReload(hook func(*config) error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// reloadConfig reads the configuration file at path, calls the reload hooks
+// and makes the result the current configuration. debug is the default value
+// of the Debug setting.
+func reloadConfig(path string, debug bool) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	c, err := loadConfig(path, debug)
+	if err != nil {
+		return err
+	}
+	if cur, ok := cfg.Load().(*config); ok && cur.cert != nil && c.cert == nil {
+		return fmt.Errorf("TLS cannot be disabled without restarting the server")
+	}
+	for _, hook := range reloadHooks {
+		if err := hook(c); err != nil {
+			return err
+		}
+	}
+	cfg.Store(c)
+	return nil
+}
+
+// loadConfig reads and validates the configuration file at path. It returns
+// the default configuration if path is empty.
+func loadConfig(path string, debug bool) (*config, error) {
+	c := &config{Debug: debug, shutdownTimeout: defaultShutdownTimeout}
+	if path == "" {
+		return c, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	if c.Timeout != "" {
+		if c.timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return nil, fmt.Errorf("%s: invalid timeout: %s", path, err)
+		}
+	}
+	if c.ShutdownTimeout != "" {
+		if c.shutdownTimeout, err = time.ParseDuration(c.ShutdownTimeout); err != nil {
+			return nil, fmt.Errorf("%s: invalid shutdown timeout: %s", path, err)
+		}
+	}
+	if err := c.Faults.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: invalid %s", path, err)
+	}
+	if c.TLSCert != "" || c.TLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid TLS certificate: %s", path, err)
+		}
+		c.cert = &cert
+	}
+	return c, nil
+}
+
+// serverTLSConfig returns the TLS configuration of the servers that negotiate
+// the given application protocols. The TLS settings are read from the current
+// configuration on each handshake so that reloading the configuration rotates
+// the certificates. The servers only use TLS if the configuration sets a
+// certificate.
+func serverTLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := currentConfig()
+			return &tls.Config{
+				Certificates: []tls.Certificate{*c.cert},
+				NextProtos:   nextProtos,
+			}, nil
+		},
+	}
+}
+
+// handleReload reloads the configuration each time the process receives a
+// SIGHUP signal. The current configuration is kept if the new one is invalid.
+func handleReload(path string, debug bool, logger *log.Logger) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	for range c {
+		if err := reloadConfig(path, debug); err != nil {
+			logger.Printf("failed to reload configuration: %s", err)
+			continue
+		}
+		logger.Printf("configuration reloaded")
+	}
+}
+`
+
+	ServerHealthCode = `// health holds the checks run by the health check endpoints of the servers.
+// Register the checks of the service dependencies before starting the
+// servers, for example:
+//
+//	health.AddReadinessCheck("database", db.PingContext)
+var health = goa.NewHealth()
+`
+
+	ServerShutdownCode = `var (
+	// shutdownMu protects shutdownHooks.
+	shutdownMu sync.Mutex
+	// shutdownHooks lists the functions called once the servers have shut
+	// down.
+	shutdownHooks []func(context.Context) error
+)
+
+// onShutdown registers a function called once the servers have stopped
+// accepting requests and drained the in-flight ones, e.g. to flush buffered
+// work or close the connections to the service dependencies. The hooks are
+// called in the reverse order of their registration. The context is canceled
+// once the shutdown timeout set in the configuration is reached.
+func onShutdown(hook func(context.Context) error) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
+// runShutdownHooks calls the shutdown hooks and logs their errors.
+func runShutdownHooks(ctx context.Context, logger *log.Logger) {
+	shutdownMu.Lock()
+	defer shutdownMu.Unlock()
+	for i := len(shutdownHooks) - 1; i >= 0; i-- {
+		if err := shutdownHooks[i](ctx); err != nil {
+			logger.Printf("shutdown hook failed: %s", err)
+		}
+	}
+}
+`
+
+	MutualTLSServerConfigCode = `// config lists the server settings that can be changed without restarting the
+// process. The settings are read from the JSON file given on the command line
+// on startup and each time the process receives a SIGHUP signal. Add user
+// defined settings to the struct and apply them in a function registered with
+// onReload.
+type config struct {
+	// Debug enables logging of request and response bodies.
+	Debug bool ` + "`" + `json:"debug"` + "`" + `
+	// Timeout is the maximum duration of a request, e.g. "30s". No
+	// timeout applies if empty.
+	Timeout string ` + "`" + `json:"timeout"` + "`" + `
+	// ShutdownTimeout is the maximum duration of the graceful shutdown of
+	// the servers and of the shutdown hooks, e.g. "1m". Defaults to 30s.
+	ShutdownTimeout string ` + "`" + `json:"shutdown_timeout"` + "`" + `
+	// TLSCert and TLSKey are the paths to the PEM encoded certificate and
+	// private key used to serve TLS connections.
+	TLSCert string ` + "`" + `json:"tls_cert"` + "`" + `
+	TLSKey  string ` + "`" + `json:"tls_key"` + "`" + `
+	// TLSClientCA is the path to the PEM encoded certificates of the
+	// authorities that sign the client certificates.
+	TLSClientCA string ` + "`" + `json:"tls_client_ca"` + "`" + `
+	// Faults describes the latency and errors injected into the requests
+	// made to the endpoints, keyed by "<service>.<method>", "<service>" or
+	// "*". For example:
+	//
+	//	"faults": {
+	//		"*": {"latency": {"distribution": "normal", "mean": "50ms", "stddev": "20ms"}},
+	//		"svc.method": {"error_rate": 0.1, "error": "unavailable"}
+	//	}
+	//
+	// See the documentation of the goa middleware package for the available
+	// distributions and errors.
+	Faults middleware.Faults ` + "`" + `json:"faults"` + "`" + `
+
+	timeout         time.Duration
+	shutdownTimeout time.Duration
+	cert            *tls.Certificate
+	clientCAs       *x509.CertPool
+}
+
+// defaultShutdownTimeout is the maximum duration of the graceful shutdown used
+// when the configuration does not set one.
+const defaultShutdownTimeout = 30 * time.Second
+
+var (
+	// cfg holds the current configuration.
+	cfg atomic.Value
+	// reloadMu serializes the configuration reloads.
+	reloadMu sync.Mutex
+	// reloadHooks lists the functions called with the new configuration
+	// before it replaces the current one.
+	reloadHooks []func(*config) error
+)
+
+// currentConfig returns the current configuration.
+func currentConfig() *config {
+	if c, ok := cfg.Load().(*config); ok {
+		return c
+	}
+	return &config{shutdownTimeout: defaultShutdownTimeout}
+}
+
+// onReload registers a function that applies the given configuration. The
+// function is called on startup and each time the configuration is reloaded.
+// Returning an error aborts the reload and keeps the current configuration.
+func onReload(hook func(*config) error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// reloadConfig reads the configuration file at path, calls the reload hooks
+// and makes the result the current configuration. debug is the default value
+// of the Debug setting.
+func reloadConfig(path string, debug bool) error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	c, err := loadConfig(path, debug)
+	if err != nil {
+		return err
+	}
+	if c.cert == nil {
+		return fmt.Errorf("the server requires TLS, the configuration must set tls_cert and tls_key")
+	}
+	if c.clientCAs == nil {
+		return fmt.Errorf("the server verifies the client certificates, the configuration must set tls_client_ca")
+	}
+	for _, hook := range reloadHooks {
+		if err := hook(c); err != nil {
+			return err
+		}
+	}
+	cfg.Store(c)
+	return nil
+}
+
+// loadConfig reads and validates the configuration file at path. It returns
+// the default configuration if path is empty.
+func loadConfig(path string, debug bool) (*config, error) {
+	c := &config{Debug: debug, shutdownTimeout: defaultShutdownTimeout}
+	if path == "" {
+		return c, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	if c.Timeout != "" {
+		if c.timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return nil, fmt.Errorf("%s: invalid timeout: %s", path, err)
+		}
+	}
+	if c.ShutdownTimeout != "" {
+		if c.shutdownTimeout, err = time.ParseDuration(c.ShutdownTimeout); err != nil {
+			return nil, fmt.Errorf("%s: invalid shutdown timeout: %s", path, err)
+		}
+	}
+	if err := c.Faults.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: invalid %s", path, err)
+	}
+	if c.TLSCert != "" || c.TLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid TLS certificate: %s", path, err)
+		}
+		c.cert = &cert
+	}
+	if c.TLSClientCA != "" {
+		b, err := ioutil.ReadFile(c.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid TLS client CA: %s", path, err)
+		}
+		c.clientCAs = x509.NewCertPool()
+		if !c.clientCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("%s: invalid TLS client CA: no certificate found in %s", path, c.TLSClientCA)
+		}
+	}
+	return c, nil
+}
+
+// serverTLSConfig returns the TLS configuration of the servers that negotiate
+// the given application protocols. The TLS settings are read from the current
+// configuration on each handshake so that reloading the configuration rotates
+// the certificates. The servers only use TLS if the configuration sets a
+// certificate.
+func serverTLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := currentConfig()
+			return &tls.Config{
+				Certificates: []tls.Certificate{*c.cert},
+				NextProtos:   nextProtos,
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    c.clientCAs,
+			}, nil
+		},
+	}
+}
+
+// handleReload reloads the configuration each time the process receives a
+// SIGHUP signal. The current configuration is kept if the new one is invalid.
+func handleReload(path string, debug bool, logger *log.Logger) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	for range c {
+		if err := reloadConfig(path, debug); err != nil {
+			logger.Printf("failed to reload configuration: %s", err)
+			continue
+		}
+		logger.Printf("configuration reloaded")
+	}
+}
 `
 )
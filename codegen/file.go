@@ -24,6 +24,11 @@ import (
 // run.
 const Gendir = "gen"
 
+// SDKdir is the name of the subdirectory of the output directory that contains
+// the module generated by the "sdk" command. The subdirectories and the files
+// generated at the root of this directory are re-written each time goa is run.
+const SDKdir = "sdk"
+
 type (
 	// A File contains the logic to generate a complete file.
 	File struct {
@@ -3,7 +3,9 @@ package codegen
 import (
 	"bytes"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -62,6 +64,29 @@ func Indent(s, prefix string) string {
 	return string(res)
 }
 
+// GoDuration returns the Go expression that evaluates to the given duration
+// using the largest time unit the duration is a multiple of, for example
+// "90 * time.Second" or "1500 * time.Millisecond". The expression requires
+// the "time" package to be imported.
+func GoDuration(d time.Duration) string {
+	units := []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "Hour"},
+		{time.Minute, "Minute"},
+		{time.Second, "Second"},
+		{time.Millisecond, "Millisecond"},
+		{time.Microsecond, "Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.d == 0 {
+			return strconv.FormatInt(int64(d/u.d), 10) + " * time." + u.name
+		}
+	}
+	return "time.Duration(" + strconv.FormatInt(int64(d), 10) + ")"
+}
+
 // Casing exceptions
 var toLower = map[string]string{"OAuth": "oauth"}
 
@@ -73,7 +98,9 @@ var toLower = map[string]string{"OAuth": "oauth"}
 //
 // If acronym is true and a part of the string is a common acronym
 // then it keeps the part capitalized (firstUpper = true)
-// (e.g. APIVersion) or lowercase (firstUpper = false) (e.g. apiVersion).
+// (e.g. APIVersion) or lowercase (firstUpper = false) (e.g. apiVersion). The
+// "goa:naming:initialisms" and "goa:naming:acronyms" API metadata customize
+// the acronyms and their casing.
 func CamelCase(name string, firstUpper bool, acronym bool) string {
 	if name == "" {
 		return ""
@@ -121,11 +148,11 @@ func CamelCase(name string, firstUpper bool, acronym bool) string {
 		// [w,i] is a word.
 		word := string(runes[w:i])
 		// is it one of our initialisms?
-		if u := strings.ToUpper(word); acronym && commonInitialisms[u] {
-			if firstUpper {
-				u = strings.ToUpper(u)
-			} else if w == 0 {
+		if u := strings.ToUpper(word); acronym && isInitialism(u) {
+			if w == 0 && !firstUpper {
 				u = strings.ToLower(u)
+			} else if titleInitialisms() {
+				u = u[:1] + strings.ToLower(u[1:])
 			}
 
 			// All the common initialisms are ASCII,
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"testing"
+	"time"
 )
 
 func TestWrapText(t *testing.T) {
@@ -66,3 +67,25 @@ func TestWrapText(t *testing.T) {
 		}
 	}
 }
+
+func TestGoDuration(t *testing.T) {
+	cases := map[string]struct {
+		d        time.Duration
+		expected string
+	}{
+		"hours":        {2 * time.Hour, "2 * time.Hour"},
+		"minutes":      {90 * time.Minute, "90 * time.Minute"},
+		"seconds":      {5 * time.Second, "5 * time.Second"},
+		"milliseconds": {1500 * time.Millisecond, "1500 * time.Millisecond"},
+		"microseconds": {3 * time.Microsecond, "3 * time.Microsecond"},
+		"nanoseconds":  {7, "time.Duration(7)"},
+	}
+
+	for k, tc := range cases {
+		actual := GoDuration(tc.d)
+
+		if actual != tc.expected {
+			t.Errorf("%s: got `%s`, expected `%s`", k, actual, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package generator_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/generator"
+	"goa.design/goa/v3/codegen/service"
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/eval"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// TestGeneratedCodeBuilds makes sure that the service and HTTP transport code
+// generated for designs that combine features builds.
+func TestGeneratedCodeBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping build of generated code in short mode")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"optional-fields", optionalFieldsBuildDSL},
+		{"patch-default-from", patchDefaultFromBuildDSL},
+		{"export-enum-types", exportEnumTypesBuildDSL},
+		{"enum-constant-bodies", enumConstantBodiesBuildDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			service.Services = make(service.ServicesData)
+			httpcodegen.HTTPServices = make(httpcodegen.ServicesData)
+			root := codegen.RunDSL(t, c.DSL)
+			roots := []eval.Root{root}
+			fs, err := generator.Service("gen", roots)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ts, err := generator.Transport("gen", roots)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dir := newBuildModule(t)
+			defer os.RemoveAll(dir)
+			for _, f := range append(fs, ts...) {
+				if _, err := f.Render(dir); err != nil {
+					t.Fatalf("%s: %s", f.Path, err)
+				}
+			}
+			cmd := exec.Command(gobin, "build", "./...")
+			cmd.Dir = filepath.Join(dir, "gen")
+			cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("generated code does not build: %s\n%s", err, out)
+			}
+		})
+	}
+}
+
+// newBuildModule creates a temporary directory whose "gen" sub-directory
+// holds the "gen" Go module that uses the goa package of this repository.
+func newBuildModule(t *testing.T) string {
+	goa, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "goa-build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen := filepath.Join(dir, "gen")
+	if err := os.Mkdir(gen, 0755); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	mod := "module gen\n\ngo 1.24\n\nrequire goa.design/goa/v3 v3.0.0\n\nreplace goa.design/goa/v3 => " + goa + "\n"
+	if err := ioutil.WriteFile(filepath.Join(gen, "go.mod"), []byte(mod), 0644); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	sum, err := ioutil.ReadFile(filepath.Join(goa, "go.sum"))
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(gen, "go.sum"), sum, 0644)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return dir
+}
+
+var optionalFieldsBuildDSL = func() {
+	Service("accounts", func() {
+		Method("update", func() {
+			OptionalFields()
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("name", String)
+				Attribute("display", String, func() {
+					DefaultFrom("name")
+				})
+				Attribute("title", String)
+				Attribute("label", String, func() {
+					DefaultFrom("title")
+				})
+				Attribute("secret", String, func() {
+					Encrypted()
+				})
+				Attribute("ssn", String, func() {
+					Sensitive()
+				})
+				Required("id", "title")
+			})
+			Result(func() {
+				Attribute("secret", String, func() {
+					Encrypted()
+				})
+			})
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var patchDefaultFromBuildDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("name", String)
+		Attribute("display", String, func() {
+			DefaultFrom("name")
+		})
+		Attribute("title", String)
+		Required("title")
+	})
+	Service("accounts", func() {
+		Method("create", func() {
+			Payload(Account)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("update", func() {
+			Payload(PatchOf(Account, func() {
+				Attribute("id", Int)
+				Required("id")
+			}))
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var exportEnumTypesBuildDSL = func() {
+	var Status = Type("Status", String, func() {
+		Enum("open", "closed")
+		EnumConstants()
+	})
+	var Level = Type("Level", Int32, func() {
+		Enum(1, 2, 3)
+		EnumConstants()
+	})
+	var Row = Type("Row", func() {
+		Attribute("id", String)
+		Attribute("status", Status)
+		Attribute("level", Level)
+		Attribute("current_status", Status)
+		Attribute("current_level", Level)
+		Required("id", "current_status", "current_level")
+	})
+	Service("reports", func() {
+		Method("export", func() {
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				Export("rows")
+			})
+		})
+	})
+}
+
+var enumConstantBodiesBuildDSL = func() {
+	var Status = Type("Status", String, func() {
+		Enum("open", "closed")
+		EnumConstants()
+	})
+	var Level = Type("Level", Int32, func() {
+		Enum(1, 2, 3)
+		EnumConstants()
+	})
+	Service("tickets", func() {
+		Method("update", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("status", Status)
+				Attribute("level", Level)
+				Attribute("history", ArrayOf(Status))
+				Required("id", "status")
+			})
+			Result(func() {
+				Attribute("status", Status)
+				Attribute("level", Level)
+				Attribute("statuses", MapOf(String, Status))
+				Required("status")
+			})
+			HTTP(func() {
+				PUT("/{id}")
+			})
+		})
+	})
+}
@@ -17,5 +17,29 @@ OpenAPI
 
 The OpenAPI generator generates a OpenAPI v2 specification for the service
 REST endpoints. This generator requires the design to define the HTTP transport.
+
+HTTP Archive
+
+The HAR generator generates a HTTP archive (HAR) containing an example request
+and response for each HTTP endpoint. The examples are built from the design
+examples and use placeholders for the credentials required by the security
+schemes. This generator requires the design to define the HTTP transport.
+
+Reference Documentation
+
+The docs generator generates a static reference documentation of the API
+written in Markdown. The documentation describes the services and their
+methods including the payload, result and error types, examples, HTTP routes,
+gRPC methods and security requirements. It does not rely on the OpenAPI
+specification and can be published in a developer portal as is.
+
+SDK
+
+The SDK generator generates a standalone Go module in the "sdk" directory that
+contains the service clients and types, the transport clients and the reference
+documentation. The servers, endpoints and client command line tools are left
+out. The module path follows the semantic import versioning rules: it ends with
+the major version of the API if it is 2 or more. The module path may be set
+with the "sdk:module" API metadata.
 */
 package generator
@@ -64,6 +64,40 @@ func Example(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 				for _, s := range r.Services {
 					service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
 				}
+				codegen.AddImport(f.SectionTemplates[0], codegen.UserTypeImports(genpkg)...)
+			}
+		}
+	}
+	return files, nil
+}
+
+// Skeleton iterates through the roots and returns files that implement the
+// service skeletons. Existing service implementation files are completed with
+// the stubs of the methods they do not implement yet.
+func Skeleton(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok {
+			continue // could be a plugin root expression
+		}
+
+		// service implementation skeletons
+		if fs := service.SkeletonServiceFiles(genpkg, r); len(fs) != 0 {
+			files = append(files, fs...)
+		}
+
+		// example auth file
+		if f := service.AuthFuncsFile(genpkg, r); f != nil {
+			files = append(files, f)
+		}
+
+		for _, f := range files {
+			if len(f.SectionTemplates) > 0 && f.SectionTemplates[0].Name == "source-header" {
+				for _, s := range r.Services {
+					service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
+				}
+				codegen.AddImport(f.SectionTemplates[0], codegen.UserTypeImports(genpkg)...)
 			}
 		}
 	}
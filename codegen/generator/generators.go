@@ -21,9 +21,19 @@ var Generators = generators
 func generators(cmd string) ([]Genfunc, error) {
 	switch cmd {
 	case "gen":
-		return []Genfunc{Service, Transport, OpenAPI}, nil
+		return []Genfunc{Service, Transport, OpenAPI, HAR, Pact}, nil
 	case "example":
 		return []Genfunc{Example}, nil
+	case "skeleton":
+		return []Genfunc{Skeleton}, nil
+	case "test":
+		return []Genfunc{Test}, nil
+	case "messages":
+		return []Genfunc{Messages}, nil
+	case "docs":
+		return []Genfunc{Docs}, nil
+	case "sdk":
+		return []Genfunc{SDK}, nil
 	default:
 		return nil, fmt.Errorf("unknown command %q", cmd)
 	}
@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// HAR iterates through the roots and returns the HTTP archive containing
+// example requests and responses for the HTTP endpoints. It produces an archive
+// only if the roots define a HTTP service.
+func HAR(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return httpcodegen.HARFiles(r)
+		}
+	}
+	return nil, nil
+}
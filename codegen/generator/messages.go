@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Messages iterates through the roots and returns the file that lists the
+// templates of the validation error messages that the generated code may
+// produce. The file is intended to be translated to localize the error
+// messages, see goa.Catalog.
+func Messages(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			if f := service.MessagesFile(r); f != nil {
+				return []*codegen.File{f}, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// Pact iterates through the roots and returns the Pact contracts of the HTTP
+// services. It produces contracts only if the roots define a HTTP service.
+func Pact(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			return httpcodegen.PactFiles(r)
+		}
+	}
+	return nil, nil
+}
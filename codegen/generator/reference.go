@@ -0,0 +1,22 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Docs iterates through the roots and returns the files of the static reference
+// documentation of the API. The documentation is written in Markdown and
+// describes the services, methods, types, examples and security schemes
+// defined in the design.
+func Docs(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			files = append(files, service.ReferenceFiles(r)...)
+		}
+	}
+	return files, nil
+}
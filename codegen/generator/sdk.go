@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// SDK iterates through the roots and returns the files of a standalone Go
+// module that contains the generated clients, types and reference
+// documentation of the API so that they can be published for the API
+// consumers. The servers, the client command line tools, the service endpoints
+// and the test helpers are left out.
+//
+// The files are written to the "sdk" directory. The module path is the value
+// of the "sdk:module" API metadata if any, the import path of the "sdk"
+// directory otherwise. The module path ends with the major version of the API
+// if it is 2 or more as required by semantic import versioning. The root
+// package defines a Version constant set to the API version.
+func SDK(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	var root *expr.RootExpr
+	for _, r := range roots {
+		if rt, ok := r.(*expr.RootExpr); ok {
+			root = rt
+			break
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("sdk: no goa design found")
+	}
+	version, major, err := sdkVersion(root.API.Version)
+	if err != nil {
+		return nil, err
+	}
+	module := sdkModule(genpkg, root.API, major)
+
+	var files []*codegen.File
+	for _, gen := range []Genfunc{Service, Transport, Docs} {
+		fs, err := gen(module, roots)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fs...)
+	}
+
+	var (
+		excluded = sdkExcludedPaths(root)
+		sdk      []*codegen.File
+		pkgs     = make(map[string]struct{})
+	)
+	for _, f := range files {
+		rel, err := filepath.Rel(codegen.Gendir, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		rel = filepath.ToSlash(rel)
+		if sdkExcluded(rel, excluded) {
+			continue
+		}
+		if path.Ext(rel) == ".go" {
+			pkgs[path.Dir(rel)] = struct{}{}
+		}
+		f.Path = filepath.Join(codegen.SDKdir, filepath.FromSlash(rel))
+		sdk = append(sdk, f)
+	}
+	packages := make([]string, 0, len(pkgs))
+	for p := range pkgs {
+		packages = append(packages, path.Join(module, p))
+	}
+	sort.Strings(packages)
+
+	pkg := codegen.Goify(strings.ToLower(root.API.Name), false)
+	data := map[string]interface{}{
+		"API":         root.API.Name,
+		"Title":       root.API.Title,
+		"Description": root.API.Description,
+		"Module":      module,
+		"Package":     pkg,
+		"Version":     version,
+		"GoaVersion":  goa.Version(),
+		"Packages":    packages,
+	}
+	sdk = append(sdk,
+		&codegen.File{
+			Path:             filepath.Join(codegen.SDKdir, "go.mod"),
+			SectionTemplates: []*codegen.SectionTemplate{{Name: "sdk-go-mod", Source: sdkGoModT, Data: data}},
+		},
+		&codegen.File{
+			Path: filepath.Join(codegen.SDKdir, "version.go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header(root.API.Name+" SDK version", pkg, nil),
+				{Name: "sdk-version", Source: sdkVersionT, Data: data},
+			},
+		},
+		&codegen.File{
+			Path:             filepath.Join(codegen.SDKdir, "README.md"),
+			SectionTemplates: []*codegen.SectionTemplate{{Name: "sdk-readme", Source: sdkReadmeT, Data: data}},
+		},
+	)
+	return sdk, nil
+}
+
+// sdkVersion returns the semantic version of the SDK and its major component
+// given the API version, e.g. "v2.1" returns "2.1.0" and 2. The version
+// defaults to "0.1.0" if the API does not define one.
+func sdkVersion(v string) (string, int, error) {
+	if v == "" {
+		return "0.1.0", 0, nil
+	}
+	var (
+		core = strings.TrimPrefix(v, "v")
+		pre  string
+	)
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core, pre = core[:i], core[i:]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return "", 0, fmt.Errorf("sdk: API version %q is not a semantic version", v)
+	}
+	nums := []int{0, 0, 0}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return "", 0, fmt.Errorf("sdk: API version %q is not a semantic version", v)
+		}
+		nums[i] = n
+	}
+	return fmt.Sprintf("%d.%d.%d%s", nums[0], nums[1], nums[2], pre), nums[0], nil
+}
+
+// sdkModule returns the path of the SDK module. The path ends with the major
+// version if it is 2 or more.
+func sdkModule(genpkg string, api *expr.APIExpr, major int) string {
+	module := path.Join(path.Dir(genpkg), codegen.SDKdir)
+	if m, ok := api.Meta["sdk:module"]; ok && len(m) > 0 {
+		module = m[0]
+	}
+	if major >= 2 {
+		suffix := "/v" + strconv.Itoa(major)
+		if !strings.HasSuffix(module, suffix) {
+			module += suffix
+		}
+	}
+	return module
+}
+
+// sdkExcludedPaths returns the paths relative to the gen directory of the
+// generated files and directories that are not part of the SDK. Directory
+// paths end with a slash.
+func sdkExcludedPaths(root *expr.RootExpr) []string {
+	excluded := []string{"http/cli/", "grpc/cli/"}
+	for _, s := range root.Services {
+		name := codegen.SnakeCase(service.Services.Get(s.Name).VarName)
+		excluded = append(excluded,
+			name+"/endpoints.go",
+			name+"/"+name+"test/",
+			name+"/fixtures/",
+			"http/"+name+"/server/",
+			"grpc/"+name+"/server/",
+			"tcp/"+name+"/server/",
+		)
+	}
+	return excluded
+}
+
+// sdkExcluded returns true if the generated file at the given path relative to
+// the gen directory matches one of the excluded paths.
+func sdkExcluded(rel string, excluded []string) bool {
+	for _, e := range excluded {
+		if rel == e || strings.HasSuffix(e, "/") && strings.HasPrefix(rel, e) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// input: map[string]interface{}
+	sdkGoModT = `module {{ .Module }}
+
+go 1.12
+
+require goa.design/goa/v3 {{ .GoaVersion }}
+`
+
+	// input: map[string]interface{}
+	sdkVersionT = `
+// Version is the version of the {{ .API }} SDK.
+const Version = {{ printf "%q" .Version }}
+`
+
+	// input: map[string]interface{}
+	sdkReadmeT = `# {{ if .Title }}{{ .Title }}{{ else }}{{ .API }}{{ end }} Go SDK
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+
+Version {{ .Version }} of the Go clients of the {{ .API }} API. This module is
+generated by goa from the API design, do not edit it.
+
+## Installation
+
+` + "```" + `
+go get {{ .Module }}@v{{ .Version }}
+` + "```" + `
+
+## Packages
+{{ range .Packages }}
+- ` + "`" + `{{ . }}` + "`" + `
+{{- end }}
+
+## Documentation
+
+The reference documentation of the API is in [docs/index.md](docs/index.md).
+
+## Publishing
+
+Run ` + "`" + `go mod tidy` + "`" + ` to complete the module requirements then tag the
+commit with ` + "`" + `v{{ .Version }}` + "`" + ` prefixed with the path of this directory
+relative to the root of the repository, e.g. ` + "`" + `sdk/v{{ .Version }}` + "`" + `.
+`
+)
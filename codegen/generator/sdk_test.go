@@ -0,0 +1,40 @@
+package generator
+
+import "testing"
+
+func TestSDKVersion(t *testing.T) {
+	cases := map[string]struct {
+		Version  string
+		Expected string
+		Major    int
+		Error    bool
+	}{
+		"empty":      {"", "0.1.0", 0, false},
+		"major":      {"v2", "2.0.0", 2, false},
+		"minor":      {"1.3", "1.3.0", 1, false},
+		"patch":      {"v3.2.1", "3.2.1", 3, false},
+		"prerelease": {"v2.0.0-beta.1", "2.0.0-beta.1", 2, false},
+		"invalid":    {"latest", "", 0, true},
+		"too long":   {"1.2.3.4", "", 0, true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, major, err := sdkVersion(c.Version)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if v != c.Expected {
+				t.Errorf("got version %q, expected %q", v, c.Expected)
+			}
+			if major != c.Major {
+				t.Errorf("got major %d, expected %d", major, c.Major)
+			}
+		})
+	}
+}
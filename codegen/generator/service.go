@@ -18,19 +18,43 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		switch r := root.(type) {
 		case *expr.RootExpr:
 			for _, s := range r.Services {
+				first := len(files)
 				// Make sure service is first so name scope is
 				// properly initialized.
 				files = append(files, service.File(genpkg, s))
 				files = append(files, service.EndpointFile(genpkg, s))
 				files = append(files, service.ClientFile(s))
+				if f := service.InMemoryClientFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.FixturesFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.TestSecurityFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
 				if f := service.ViewsFile(genpkg, s); f != nil {
 					files = append(files, f)
 				}
+				if f := service.StreamSenderFile(s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.EventsFile(s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.RenamedFile(s); f != nil {
+					files = append(files, f)
+				}
 				for _, f := range files {
 					if len(f.SectionTemplates) > 0 {
 						service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
 					}
 				}
+				for _, f := range files[first:] {
+					if len(f.SectionTemplates) > 0 {
+						codegen.AddImport(f.SectionTemplates[0], codegen.UserTypeImports(genpkg)...)
+					}
+				}
 				f, err := service.ConvertFile(r, s)
 				if err != nil {
 					return nil, err
@@ -39,6 +63,7 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 					files = append(files, f)
 				}
 			}
+			files = append(files, service.UserTypeFiles(r)...)
 		}
 	}
 	if len(files) == 0 {
@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// Test iterates through the roots and returns the contract tests of the HTTP
+// services. The tests are written next to the example service implementations
+// and exercise the generated HTTP handlers and client decoders.
+func Test(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok {
+			continue // could be a plugin root expression
+		}
+		files = append(files, httpcodegen.ContractTestFiles(genpkg, r)...)
+	}
+	return files, nil
+}
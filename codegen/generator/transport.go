@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -9,6 +10,7 @@ import (
 	"goa.design/goa/v3/expr"
 	grpccodegen "goa.design/goa/v3/grpc/codegen"
 	httpcodegen "goa.design/goa/v3/http/codegen"
+	tcpcodegen "goa.design/goa/v3/tcp/codegen"
 )
 
 // Transport iterates through the roots and returns the files needed to render
@@ -28,7 +30,10 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, httpcodegen.ServerTypeFiles(genpkg, r)...)
 		files = append(files, httpcodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, httpcodegen.PathFiles(r)...)
+		files = append(files, httpcodegen.StatusCodeFiles(r)...)
 		files = append(files, httpcodegen.ClientCLIFiles(genpkg, r)...)
+		files = append(files, httpcodegen.ClientInteropFiles(genpkg, r)...)
+		files = append(files, httpcodegen.TestServerFiles(genpkg, r)...)
 
 		// GRPC
 		files = append(files, grpccodegen.ProtoFiles(genpkg, r)...)
@@ -38,16 +43,23 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, grpccodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientCLIFiles(genpkg, r)...)
 
+		// TCP
+		files = append(files, tcpcodegen.ServerFiles(genpkg, r)...)
+		files = append(files, tcpcodegen.ClientFiles(genpkg, r)...)
+
 		for _, f := range files {
 			if len(f.SectionTemplates) > 0 {
 				for _, s := range r.Services {
 					service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
 				}
+				if filepath.Ext(f.Path) == ".go" {
+					codegen.AddImport(f.SectionTemplates[0], codegen.UserTypeImports(genpkg)...)
+				}
 			}
 		}
 	}
 	if len(files) == 0 {
-		return nil, fmt.Errorf("transport: no HTTP/gRPC design found")
+		return nil, fmt.Errorf("transport: no HTTP/gRPC/TCP design found")
 	}
 	return files, nil
 }
@@ -84,7 +84,7 @@ func transformAttribute(source, target *expr.AttributeExpr, sourceVar, targetVar
 			cast := ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg)
 			return fmt.Sprintf("%s %s %s(%s)\n", targetVar, assign, cast, sourceVar), nil
 		}
-		code = fmt.Sprintf("%s %s %s\n", targetVar, assign, sourceVar)
+		code = fmt.Sprintf("%s %s %s\n", targetVar, assign, convertPrimitive(source, target, sourceVar, false, ta))
 	}
 	return
 }
@@ -114,7 +114,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				switch {
 				case srcPtr && !tgtPtr:
 					if !srcMatt.IsRequired(n) {
-						postInitCode += fmt.Sprintf("if %s != nil {\n\t%s.%s = %s\n}\n", srcField, targetVar, tgtField, "*"+srcField)
+						postInitCode += fmt.Sprintf("if %s != nil {\n\t%s.%s = %s\n}\n", srcField, targetVar, tgtField, convertPrimitive(srcc, tgtc, "*"+srcField, false, ta))
 						return
 					}
 					deref = "*"
@@ -122,7 +122,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					deref = "&"
 				}
 			}
-			initCode += fmt.Sprintf("\n%s: %s%s,", tgtField, deref, srcField)
+			initCode += fmt.Sprintf("\n%s: %s,", tgtField, convertPrimitive(srcc, tgtc, deref+srcField, tgtPtr, ta))
 		})
 		if initCode != "" {
 			initCode += "\n"
@@ -154,6 +154,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 		)
 		{
 			_, ok := srcc.Type.(expr.UserType)
+			ok = ok && !expr.IsEnumConstType(srcc.Type)
 			switch {
 			case expr.IsArray(srcc.Type):
 				code, err = transformArrayElem(expr.AsArray(srcc.Type), expr.AsArray(tgtc.Type), srcVar, tgtVar, false, ta)
@@ -193,7 +194,11 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			if (ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr) || !expr.IsPrimitive(srcc.Type)) && !srcMatt.IsRequired(n) {
 				code += fmt.Sprintf("if %s == nil {\n\t", srcVar)
 				if ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr) && expr.IsPrimitive(tgtc.Type) {
-					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", GoNativeTypeName(tgtc.Type), tdef, tgtVar)
+					tref := GoNativeTypeName(tgtc.Type)
+					if tgtc.Type == expr.Duration {
+						tref = ta.TargetCtx.Scope.Ref(tgtc, ta.TargetCtx.Pkg)
+					}
+					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", tref, tdef, tgtVar)
 				} else {
 					code += fmt.Sprintf("%s = %#v\n", tgtVar, tdef)
 				}
@@ -237,7 +242,7 @@ func transformArrayElem(source, target *expr.Array, sourceVar, targetVar string,
 	if err := IsCompatible(st, tt, sourceVar+"[0]", targetVar+"[0]"); err != nil {
 		return "", err
 	}
-	if _, ok := st.(expr.UserType); ok {
+	if _, ok := st.(expr.UserType); ok && !expr.IsEnumConstType(st) {
 		data := map[string]interface{}{
 			"ElemTypeRef":    ta.TargetCtx.Scope.Ref(target.ElemType, ta.TargetCtx.Pkg),
 			"SourceElem":     source.ElemType,
@@ -296,7 +301,7 @@ func transformMapElem(source, target *expr.Map, sourceVar, targetVar string, new
 	if err := IsCompatible(source.ElemType.Type, target.ElemType.Type, sourceVar+"[*]", targetVar+"[*]"); err != nil {
 		return "", err
 	}
-	if _, ok := target.ElemType.Type.(expr.UserType); ok {
+	if _, ok := target.ElemType.Type.(expr.UserType); ok && !expr.IsEnumConstType(target.ElemType.Type) {
 		data := map[string]interface{}{
 			"KeyTypeRef":     ta.TargetCtx.Scope.Ref(target.KeyType, ta.TargetCtx.Pkg),
 			"ElemTypeRef":    ta.TargetCtx.Scope.Ref(target.ElemType, ta.TargetCtx.Pkg),
@@ -371,7 +376,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	if _, ok := seen[name]; ok {
 		return
 	}
-	if _, ok := source.Type.(expr.UserType); ok {
+	if _, ok := source.Type.(expr.UserType); ok && !expr.IsEnumConstType(source.Type) {
 		var h *TransformFunctionData
 		if h, err = generateHelper(source, target, req, ta, seen); h != nil {
 			helpers = append(helpers, h)
@@ -427,6 +432,27 @@ func generateHelper(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	return tfd, nil
 }
 
+// convertPrimitive returns the code that converts the primitive value held by
+// sourceVar to the Go type of target. The source and target Go types differ
+// only for durations and timestamps which transport types may represent with a
+// type other than time.Duration and time.Time and for enum constant types which
+// transport types represent with the base primitive type. ptr indicates whether
+// sourceVar holds a pointer.
+func convertPrimitive(source, target *expr.AttributeExpr, sourceVar string, ptr bool, ta *TransformAttrs) string {
+	if target.Type != expr.Duration && target.Type != expr.Timestamp && !expr.IsEnumConstType(source.Type) && !expr.IsEnumConstType(target.Type) {
+		return sourceVar
+	}
+	sref := ta.SourceCtx.Scope.Ref(source, ta.SourceCtx.Pkg)
+	tref := ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg)
+	if sref == tref {
+		return sourceVar
+	}
+	if ptr {
+		return fmt.Sprintf("(*%s)(%s)", tref, sourceVar)
+	}
+	return fmt.Sprintf("%s(%s)", tref, sourceVar)
+}
+
 // walkMatches iterates through the attributes of source and looks for
 // attributes with identical names in target. walkMatches calls the walker
 // function for each pair of matched attributes. Both source and target must be
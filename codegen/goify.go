@@ -23,6 +23,7 @@ func Goify(str string, firstUpper bool) string {
 	if idx > 0 {
 		str = str[:idx]
 	}
+	str = stripNamingPrefix(str)
 
 	str = CamelCase(str, firstUpper, true)
 	if str == "" {
@@ -46,6 +47,50 @@ func GoifyAtt(att *expr.AttributeExpr, name string, upper bool) string {
 	return Goify(name, upper)
 }
 
+// namingMeta returns the values of the API metadata with the given key, see the
+// "goa:naming:xxx" metadata.
+func namingMeta(key string) []string {
+	if expr.Root == nil || expr.Root.API == nil {
+		return nil
+	}
+	return expr.Root.API.Meta[key]
+}
+
+// stripNamingPrefix removes the first "goa:naming:prefix" prefix that str
+// starts with unless it is the whole string.
+func stripNamingPrefix(str string) string {
+	for _, p := range namingMeta("goa:naming:prefix") {
+		if p != "" && len(str) > len(p) && strings.HasPrefix(str, p) {
+			return str[len(p):]
+		}
+	}
+	return str
+}
+
+// isInitialism returns true if the uppercase word u is written as an
+// initialism in the generated identifiers, taking the "goa:naming:initialisms"
+// metadata into account.
+func isInitialism(u string) bool {
+	res := commonInitialisms[u]
+	for _, i := range namingMeta("goa:naming:initialisms") {
+		if strings.HasPrefix(i, "-") {
+			if strings.ToUpper(i[1:]) == u {
+				res = false
+			}
+		} else if strings.ToUpper(i) == u {
+			res = true
+		}
+	}
+	return res
+}
+
+// titleInitialisms returns true if the "goa:naming:acronyms" metadata requires
+// writing the initialisms in title case (e.g. UserId instead of UserID).
+func titleInitialisms() bool {
+	v := namingMeta("goa:naming:acronyms")
+	return len(v) > 0 && v[0] == "title"
+}
+
 // fixReservedGo appends an underscore on to Go reserved keywords.
 func fixReservedGo(w string) string {
 	if reservedGo[w] {
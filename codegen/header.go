@@ -6,6 +6,9 @@ import (
 
 // Header returns a Go source file header section template.
 func Header(title, pack string, imports []*ImportSpec) *SectionTemplate {
+	if _, path := DecimalType(); path != "" && !hasImport(imports, path) {
+		imports = append(append([]*ImportSpec{}, imports...), &ImportSpec{Path: path})
+	}
 	return &SectionTemplate{
 		Name:   "source-header",
 		Source: headerT,
@@ -33,6 +36,16 @@ func AddImport(section *SectionTemplate, imprts ...*ImportSpec) {
 	}
 }
 
+// hasImport returns true if imports contains the given import path.
+func hasImport(imports []*ImportSpec, path string) bool {
+	for _, imp := range imports {
+		if imp.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
 const (
 	headerT = `{{if .Title}}// Code generated by goa {{.ToolVersion}}, DO NOT EDIT.
 //
@@ -2,7 +2,11 @@ package codegen
 
 import (
 	"fmt"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"goa.design/goa/v3/expr"
 	goa "goa.design/goa/v3/pkg"
@@ -140,6 +144,9 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 	if im != nil {
 		uniqueImports[*im] = struct{}{}
 	}
+	for _, im := range validateFuncImports(att, make(map[string]struct{})) {
+		uniqueImports[*im] = struct{}{}
+	}
 	for imp := range uniqueImports {
 		// Copy loop variable into body so next iteration doesnt overwrite its address https://stackoverflow.com/questions/27610039/golang-appending-leaves-only-last-element
 		copy := imp
@@ -148,6 +155,87 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 	return imports
 }
 
+// ValidateFuncImport returns the import spec of the package that defines the
+// custom validation function fn given to the ValidateWith DSL and the Go
+// expression that refers to the function. The package is imported with an
+// explicit name when the last element of its path is not a valid identifier
+// or is a major version suffix. ValidateFuncImport returns nil and an empty
+// string if fn is invalid.
+func ValidateFuncImport(fn string) (*ImportSpec, string) {
+	pkgPath, name, ok := expr.ParseValidateFunc(fn)
+	if !ok {
+		return nil, ""
+	}
+	base := path.Base(pkgPath)
+	pkgName := base
+	if isMajorVersion(base) && path.Dir(pkgPath) != "." {
+		pkgName = path.Base(path.Dir(pkgPath))
+	}
+	pkgName = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, pkgName)
+	if unicode.IsDigit([]rune(pkgName)[0]) {
+		pkgName = "_" + pkgName
+	}
+	spec := &ImportSpec{Path: pkgPath}
+	if pkgName != base {
+		spec.Name = pkgName
+	}
+	return spec, pkgName + "." + name
+}
+
+// isMajorVersion returns true if s is a module major version suffix such as
+// "v2".
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validateFuncImports returns the import specs of the packages that define the
+// custom validation functions of the given attribute and its children.
+func validateFuncImports(att *expr.AttributeExpr, seen map[string]struct{}) []*ImportSpec {
+	if att == nil {
+		return nil
+	}
+	var imports []*ImportSpec
+	if ut, ok := att.Type.(expr.UserType); ok {
+		if _, ok := seen[ut.ID()]; ok {
+			return nil
+		}
+		seen[ut.ID()] = struct{}{}
+		imports = append(imports, validateFuncImports(ut.Attribute(), seen)...)
+	}
+	if att.Validation != nil {
+		for _, fn := range att.Validation.Funcs {
+			if im, _ := ValidateFuncImport(fn); im != nil {
+				imports = append(imports, im)
+			}
+		}
+	}
+	switch t := att.Type.(type) {
+	case *expr.Array:
+		imports = append(imports, validateFuncImports(t.ElemType, seen)...)
+	case *expr.Map:
+		imports = append(imports, validateFuncImports(t.KeyType, seen)...)
+		imports = append(imports, validateFuncImports(t.ElemType, seen)...)
+	case *expr.Object:
+		for _, nat := range *t {
+			imports = append(imports, validateFuncImports(nat.Attribute, seen)...)
+		}
+	}
+	return imports
+}
+
 // AddServiceMetaTypeImports adds meta type imports for each method of the service expr
 func AddServiceMetaTypeImports(header *SectionTemplate, svc *expr.ServiceExpr) {
 	for _, m := range svc.Methods {
@@ -156,3 +244,31 @@ func AddServiceMetaTypeImports(header *SectionTemplate, svc *expr.ServiceExpr) {
 		AddImport(header, GetMetaTypeImports(m.Result)...)
 	}
 }
+
+// UserTypeImports returns the import specs of the packages that contain the
+// user types generated outside of the service packages, see the
+// "struct:pkg:path" metadata.
+func UserTypeImports(genpkg string) []*ImportSpec {
+	if expr.Root == nil {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	var paths []string
+	for _, t := range expr.Root.Types {
+		loc := UserTypeLocation(t)
+		if loc == nil {
+			continue
+		}
+		if _, ok := seen[loc.RelImportPath]; ok {
+			continue
+		}
+		seen[loc.RelImportPath] = struct{}{}
+		paths = append(paths, loc.RelImportPath)
+	}
+	sort.Strings(paths)
+	imports := make([]*ImportSpec, len(paths))
+	for i, p := range paths {
+		imports[i] = &ImportSpec{Path: genpkg + "/" + p}
+	}
+	return imports
+}
@@ -0,0 +1,282 @@
+/*
+Package schemaregistry implements a goa plugin that publishes the schemas of
+the design types to a Confluent compatible schema registry each time "goa gen"
+runs.
+
+The plugin is enabled by importing this package in the design package and
+setting the registry URL using the "schemaregistry:url" API meta or the
+SCHEMA_REGISTRY_URL environment variable which takes precedence:
+
+    import _ "goa.design/goa/v3/codegen/schemaregistry"
+
+    var _ = API("calc", func() {
+        Meta("schemaregistry:url", "http://localhost:8081")
+        Meta("schemaregistry:strategy", "topic-record")
+        Meta("schemaregistry:topic", "calc-events")
+    })
+
+The plugin publishes the JSON schema of each user type and result type and,
+if the design defines gRPC services, the protocol buffer definition of each
+service. The "schemaregistry:format" API meta restricts the published schemas
+to one of "json" or "protobuf".
+
+The subject of each schema is computed using the strategy set with the
+"schemaregistry:strategy" API meta:
+
+    - "record" (default): the subject is the record name, that is the type name
+      for JSON schemas and the protocol buffer package for protobuf schemas.
+    - "topic": the subject is the topic set with "schemaregistry:topic"
+      followed by "-value". All the schemas are published under the same
+      subject so this strategy is best suited to designs defining a single
+      type, use "schemaregistry:format" to exclude the protocol buffer
+      definitions.
+    - "topic-record": the subject is the topic followed by a dash and the
+      record name.
+*/
+package schemaregistry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+type (
+	// Config contains the schema registry settings.
+	Config struct {
+		// URL is the base URL of the schema registry.
+		URL string
+		// Strategy is the subject naming strategy, one of "record",
+		// "topic" or "topic-record".
+		Strategy string
+		// Topic is the topic used by the "topic" and "topic-record"
+		// strategies.
+		Topic string
+		// Format restricts the published schemas to "json" or
+		// "protobuf" if not empty.
+		Format string
+	}
+
+	// Schema is a schema published to the registry.
+	Schema struct {
+		// Subject is the registry subject.
+		Subject string
+		// Type is the schema type, one of "JSON" or "PROTOBUF".
+		Type string
+		// Definition is the schema definition.
+		Definition string
+	}
+
+	// registerRequest is the body of the requests sent to the registry.
+	registerRequest struct {
+		Schema     string `json:"schema"`
+		SchemaType string `json:"schemaType"`
+	}
+)
+
+const (
+	// RecordStrategy uses the record name as subject.
+	RecordStrategy = "record"
+	// TopicStrategy uses the topic name followed by "-value" as subject.
+	TopicStrategy = "topic"
+	// TopicRecordStrategy uses the topic name followed by the record name as
+	// subject.
+	TopicRecordStrategy = "topic-record"
+
+	// contentType is the content type of the registry API requests.
+	contentType = "application/vnd.schemaregistry.v1+json"
+)
+
+// Client is the HTTP client used to publish the schemas.
+var Client = http.DefaultClient
+
+// Register the plugin Generator functions.
+func init() {
+	codegen.RegisterPluginLast("schemaregistry", "gen", nil, Generate)
+}
+
+// Generate publishes the schemas of the design to the schema registry
+// configured in the design. It returns the generated files unchanged.
+func Generate(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok {
+			continue
+		}
+		cfg := NewConfig(r)
+		if cfg.URL == "" {
+			return files, nil
+		}
+		schemas, err := Schemas(r, files, cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range schemas {
+			if err := Publish(cfg.URL, s); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+// NewConfig returns the schema registry settings defined in the design API
+// meta. The SCHEMA_REGISTRY_URL environment variable overrides the registry
+// URL set in the design.
+func NewConfig(root *expr.RootExpr) *Config {
+	cfg := &Config{Strategy: RecordStrategy}
+	meta := root.API.Meta
+	if v, ok := meta["schemaregistry:url"]; ok && len(v) > 0 {
+		cfg.URL = v[0]
+	}
+	if u := os.Getenv("SCHEMA_REGISTRY_URL"); u != "" {
+		cfg.URL = u
+	}
+	if v, ok := meta["schemaregistry:strategy"]; ok && len(v) > 0 {
+		cfg.Strategy = v[0]
+	}
+	if v, ok := meta["schemaregistry:topic"]; ok && len(v) > 0 {
+		cfg.Topic = v[0]
+	}
+	if v, ok := meta["schemaregistry:format"]; ok && len(v) > 0 {
+		cfg.Format = v[0]
+	}
+	return cfg
+}
+
+// Subject returns the registry subject for the given record name.
+func (c *Config) Subject(record string) (string, error) {
+	switch c.Strategy {
+	case RecordStrategy, "":
+		return record, nil
+	case TopicStrategy, TopicRecordStrategy:
+		if c.Topic == "" {
+			return "", fmt.Errorf("schemaregistry: strategy %q requires a topic, use the \"schemaregistry:topic\" API meta to set one", c.Strategy)
+		}
+		if c.Strategy == TopicStrategy {
+			return c.Topic + "-value", nil
+		}
+		return c.Topic + "-" + record, nil
+	default:
+		return "", fmt.Errorf("schemaregistry: unknown subject naming strategy %q, must be one of %q, %q or %q", c.Strategy, RecordStrategy, TopicStrategy, TopicRecordStrategy)
+	}
+}
+
+// Schemas returns the schemas to publish: the JSON schemas of the design user
+// and result types and the protocol buffer definitions found in files.
+func Schemas(root *expr.RootExpr, files []*codegen.File, cfg *Config) ([]*Schema, error) {
+	var schemas []*Schema
+	if cfg.Format == "" || cfg.Format == "json" {
+		ss, err := jsonSchemas(root, cfg)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, ss...)
+	}
+	if cfg.Format == "" || cfg.Format == "protobuf" {
+		for _, f := range files {
+			if filepath.Ext(f.Path) != ".proto" {
+				continue
+			}
+			var buf bytes.Buffer
+			for _, s := range f.SectionTemplates {
+				if err := s.Write(&buf); err != nil {
+					return nil, err
+				}
+			}
+			subject, err := cfg.Subject(strings.TrimSuffix(filepath.Base(f.Path), ".proto"))
+			if err != nil {
+				return nil, err
+			}
+			schemas = append(schemas, &Schema{Subject: subject, Type: "PROTOBUF", Definition: buf.String()})
+		}
+	}
+	return schemas, nil
+}
+
+// Publish registers the given schema with the registry located at the given
+// URL.
+func Publish(registry string, s *Schema) error {
+	body, err := json.Marshal(&registerRequest{Schema: s.Definition, SchemaType: s.Type})
+	if err != nil {
+		return err
+	}
+	u := strings.TrimSuffix(registry, "/") + "/subjects/" + url.PathEscape(s.Subject) + "/versions"
+	req, err := http.NewRequest("POST", u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	resp, err := Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("schemaregistry: failed to publish schema for subject %q: %s", s.Subject, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("schemaregistry: failed to publish schema for subject %q: %s: %s", s.Subject, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// jsonSchemas returns the JSON schemas of the design user and result types.
+// Each schema embeds the definitions of all the types so that references can
+// be resolved by the registry.
+func jsonSchemas(root *expr.RootExpr, cfg *Config) ([]*Schema, error) {
+	// Do not interfere with the definitions computed by the OpenAPI
+	// generator.
+	defs := openapi.Definitions
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	defer func() { openapi.Definitions = defs }()
+
+	var (
+		names []string
+		refs  []string
+	)
+	for _, t := range root.Types {
+		ut, ok := t.(*expr.UserTypeExpr)
+		if !ok {
+			continue
+		}
+		names = append(names, ut.Name())
+		refs = append(refs, openapi.TypeRef(root.API, ut))
+	}
+	for _, t := range root.ResultTypes {
+		rt, ok := t.(*expr.ResultTypeExpr)
+		if !ok {
+			continue
+		}
+		names = append(names, rt.Name())
+		refs = append(refs, openapi.ResultTypeRef(root.API, rt, expr.DefaultView))
+	}
+
+	schemas := make([]*Schema, len(names))
+	for i, n := range names {
+		s := openapi.NewSchema()
+		s.Schema = "http://json-schema.org/draft-04/schema"
+		s.Ref = refs[i]
+		s.Definitions = openapi.Definitions
+		b, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		subject, err := cfg.Subject(n)
+		if err != nil {
+			return nil, err
+		}
+		schemas[i] = &Schema{Subject: subject, Type: "JSON", Definition: string(b)}
+	}
+	return schemas, nil
+}
@@ -0,0 +1,142 @@
+package schemaregistry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/eval"
+)
+
+func TestSubject(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Strategy string
+		Topic    string
+		Expected string
+		Error    string
+	}{
+		{"record", RecordStrategy, "", "Account", ""},
+		{"default", "", "", "Account", ""},
+		{"topic", TopicStrategy, "accounts", "accounts-value", ""},
+		{"topic-record", TopicRecordStrategy, "accounts", "accounts-Account", ""},
+		{"missing-topic", TopicStrategy, "", "", `schemaregistry: strategy "topic" requires a topic, use the "schemaregistry:topic" API meta to set one`},
+		{"unknown", "foo", "", "", `schemaregistry: unknown subject naming strategy "foo", must be one of "record", "topic" or "topic-record"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg := &Config{Strategy: c.Strategy, Topic: c.Topic}
+			subject, err := cfg.Subject("Account")
+			if c.Error != "" {
+				if err == nil || err.Error() != c.Error {
+					t.Fatalf("got error %v, expected %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if subject != c.Expected {
+				t.Errorf("got subject %q, expected %q", subject, c.Expected)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	var (
+		subjects []string
+		types    []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != contentType {
+			t.Errorf("got content type %q, expected %q", ct, contentType)
+		}
+		var body registerRequest
+		b, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Errorf("invalid request body: %s", err)
+		}
+		subjects = append(subjects, r.URL.Path)
+		types = append(types, body.SchemaType)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer srv.Close()
+
+	dsl := func() {
+		API("test", func() {
+			Meta("schemaregistry:url", srv.URL)
+			Meta("schemaregistry:strategy", "topic-record")
+			Meta("schemaregistry:topic", "events")
+		})
+		Type("Account", func() {
+			Attribute("id", Int)
+			Attribute("owner", "User")
+		})
+		Type("User", func() {
+			Attribute("name", String)
+		})
+	}
+	root := codegen.RunDSL(t, dsl)
+	files := []*codegen.File{{Path: "gen/grpc/svc/pb/svc.proto", SectionTemplates: []*codegen.SectionTemplate{
+		{Name: "proto", Source: `syntax = "proto3";`},
+	}}}
+	fs, err := Generate("", []eval.Root{root}, files)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(fs) != 1 {
+		t.Errorf("got %d files, expected the generated files to be returned unchanged", len(fs))
+	}
+	sort.Strings(subjects)
+	expected := []string{"/subjects/events-Account/versions", "/subjects/events-User/versions", "/subjects/events-svc/versions"}
+	if len(subjects) != len(expected) {
+		t.Fatalf("got subjects %v, expected %v", subjects, expected)
+	}
+	for i, s := range subjects {
+		if s != expected[i] {
+			t.Errorf("got subject %q at index %d, expected %q", s, i, expected[i])
+		}
+	}
+	if types[len(types)-1] != "PROTOBUF" {
+		t.Errorf("got schema type %q, expected PROTOBUF", types[len(types)-1])
+	}
+}
+
+func TestStrictMeta(t *testing.T) {
+	dsl := func() {
+		API("test", func() {
+			StrictMeta()
+			Meta("schemaregistry:url", "http://localhost:8081")
+			Meta("schemaregistry:strategy", "topic-record")
+			Meta("schemaregistry:topic", "events")
+			Meta("schemaregistry:format", "json")
+		})
+		Type("Account", func() {
+			Attribute("id", Int)
+		})
+	}
+	codegen.RunDSL(t, dsl)
+}
+
+func TestPublishError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error_code":409,"message":"incompatible schema"}`))
+	}))
+	defer srv.Close()
+
+	err := Publish(srv.URL, &Schema{Subject: "Account", Type: "JSON", Definition: "{}"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `schemaregistry: failed to publish schema for subject "Account": 409 Conflict: {"error_code":409,"message":"incompatible schema"}`
+	if err.Error() != expected {
+		t.Errorf("got error %q, expected %q", err.Error(), expected)
+	}
+}
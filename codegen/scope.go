@@ -96,24 +96,34 @@ func (s *NameScope) Name(name string) string {
 // useDefault if true indicates that the attribute must not be a pointer
 // if it has a default value.
 func (s *NameScope) GoTypeDef(att *expr.AttributeExpr, ptr, useDefault bool) string {
+	return s.GoFullTypeDef(att, "", ptr, useDefault)
+}
+
+// GoFullTypeDef returns the Go code that defines a Go type which matches the
+// data structure definition in the given package. The user types referenced by
+// the definition are qualified with the given package name if not empty.
+func (s *NameScope) GoFullTypeDef(att *expr.AttributeExpr, pkg string, ptr, useDefault bool) string {
 	switch actual := att.Type.(type) {
 	case expr.Primitive:
 		if t, _ := getMetaTypeInfo(att); t != "" {
 			return t
 		}
+		if att.IsOptionalField() {
+			return GoOptionalTypeName(actual)
+		}
 		return GoNativeTypeName(actual)
 	case *expr.Array:
-		d := s.GoTypeDef(actual.ElemType, ptr, useDefault)
+		d := s.GoFullTypeDef(actual.ElemType, pkg, ptr, useDefault)
 		if expr.IsObject(actual.ElemType.Type) {
 			d = "*" + d
 		}
 		return "[]" + d
 	case *expr.Map:
-		keyDef := s.GoTypeDef(actual.KeyType, ptr, useDefault)
+		keyDef := s.GoFullTypeDef(actual.KeyType, pkg, ptr, useDefault)
 		if expr.IsObject(actual.KeyType.Type) {
 			keyDef = "*" + keyDef
 		}
-		elemDef := s.GoTypeDef(actual.ElemType, ptr, useDefault)
+		elemDef := s.GoFullTypeDef(actual.ElemType, pkg, ptr, useDefault)
 		if expr.IsObject(actual.ElemType.Type) {
 			elemDef = "*" + elemDef
 		}
@@ -133,10 +143,10 @@ func (s *NameScope) GoTypeDef(att *expr.AttributeExpr, ptr, useDefault bool) str
 			)
 			{
 				fn = GoifyAtt(at, name, true)
-				tdef = s.GoTypeDef(at, ptr, useDefault)
+				tdef = s.GoFullTypeDef(at, pkg, ptr, useDefault)
 				if expr.IsObject(at.Type) ||
 					att.IsPrimitivePointer(name, useDefault) ||
-					(ptr && expr.IsPrimitive(at.Type) && at.Type.Kind() != expr.AnyKind && at.Type.Kind() != expr.BytesKind) {
+					(ptr && expr.IsPrimitive(at.Type) && at.Type.Kind() != expr.AnyKind && at.Type.Kind() != expr.BytesKind && !at.IsOptionalField()) {
 					tdef = "*" + tdef
 				}
 				if at.Description != "" {
@@ -149,7 +159,7 @@ func (s *NameScope) GoTypeDef(att *expr.AttributeExpr, ptr, useDefault bool) str
 		ss = append(ss, "}")
 		return strings.Join(ss, "\n")
 	case expr.UserType:
-		return s.GoTypeName(att)
+		return s.GoFullTypeName(att, pkg)
 	default:
 		panic(fmt.Sprintf("unknown data type %T", actual)) // bug
 	}
@@ -185,13 +195,18 @@ func (s *NameScope) GoTypeName(att *expr.AttributeExpr) string {
 }
 
 // GoFullTypeName returns the Go type name of the given data type qualified with
-// the given package name if applicable and if not the empty string.
+// the given package name if applicable and if not the empty string. User types
+// that define the "struct:pkg:path" metadata are qualified with the name of
+// the package they are generated in unless it is the given package.
 func (s *NameScope) GoFullTypeName(att *expr.AttributeExpr, pkg string) string {
 	switch actual := att.Type.(type) {
 	case expr.Primitive:
 		if t, _ := getMetaTypeInfo(att); t != "" {
 			return t
 		}
+		if att.IsOptionalField() {
+			return GoOptionalTypeName(actual)
+		}
 		return GoNativeTypeName(actual)
 	case *expr.Array:
 		return "[]" + s.GoFullTypeRef(actual.ElemType, pkg)
@@ -205,6 +220,15 @@ func (s *NameScope) GoFullTypeName(att *expr.AttributeExpr, pkg string) string {
 		if actual == expr.ErrorResult {
 			return "goa.ServiceError"
 		}
+		if loc := UserTypeLocation(actual); loc != nil {
+			// Types generated in a shared package are named after the
+			// design type regardless of the service scope.
+			n := Goify(actual.Name(), true)
+			if pkg == loc.PackageName() {
+				return n
+			}
+			return loc.PackageName() + "." + n
+		}
 		n := s.HashedUnique(actual, Goify(actual.Name(), true), "")
 		if pkg == "" {
 			return n
@@ -30,6 +30,13 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// DependenciesType is the name of the interface implemented by
+		// the service to create and finalize the per-request
+		// dependencies if any.
+		DependenciesType string
+		// Dependencies lists the per-request dependencies of the
+		// endpoints.
+		Dependencies []*DependencyData
 	}
 
 	// endpointMethodData describes a single endpoint method.
@@ -44,6 +51,10 @@ type (
 		ServiceName string
 		// ServiceVarName is the name of the owner service Go interface.
 		ServiceVarName string
+		// DependenciesType is the name of the interface implemented by
+		// the service to create and finalize the per-request
+		// dependencies.
+		DependenciesType string
 	}
 )
 
@@ -71,6 +82,7 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				{Path: "context"},
 				{Path: "fmt"},
 				codegen.GoaImport(""),
+				codegen.GoaImport("middleware"),
 				codegen.GoaImport("security"),
 				{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
 			})
@@ -79,6 +91,11 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			Source: serviceEndpointsT,
 			Data:   data,
 		}
+		for _, d := range data.Dependencies {
+			if d.PkgPath != "" {
+				codegen.AddImport(header, &codegen.ImportSpec{Path: d.PkgPath})
+			}
+		}
 		sections = []*codegen.SectionTemplate{header, def}
 		for _, m := range data.Methods {
 			if m.ServerStream != nil {
@@ -109,6 +126,13 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				},
 			})
 		}
+		for _, d := range data.Dependencies {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoint-dependency",
+				Source: serviceEndpointDependencyT,
+				Data:   map[string]interface{}{"Dependency": d, "DependenciesType": data.DependenciesType},
+			})
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -120,24 +144,27 @@ func endpointData(service *expr.ServiceExpr) *endpointsData {
 	names := make([]string, len(svc.Methods))
 	for i, m := range svc.Methods {
 		methods[i] = &endpointMethodData{
-			MethodData:     m,
-			ArgName:        codegen.Goify(m.VarName, false),
-			ServiceName:    svc.Name,
-			ServiceVarName: serviceInterfaceName,
-			ClientVarName:  clientStructName,
+			MethodData:       m,
+			ArgName:          codegen.Goify(m.VarName, false),
+			ServiceName:      svc.Name,
+			ServiceVarName:   serviceInterfaceName,
+			ClientVarName:    clientStructName,
+			DependenciesType: svc.DependenciesType,
 		}
 		names[i] = codegen.Goify(m.VarName, false)
 	}
 	desc := fmt.Sprintf("%s wraps the %q service endpoints.", endpointsStructName, service.Name)
 	return &endpointsData{
-		Name:           service.Name,
-		Description:    desc,
-		VarName:        endpointsStructName,
-		ClientVarName:  clientStructName,
-		ServiceVarName: serviceInterfaceName,
-		ClientInitArgs: strings.Join(names, ", "),
-		Methods:        methods,
-		Schemes:        svc.Schemes,
+		Name:             service.Name,
+		Description:      desc,
+		VarName:          endpointsStructName,
+		ClientVarName:    clientStructName,
+		ServiceVarName:   serviceInterfaceName,
+		ClientInitArgs:   strings.Join(names, ", "),
+		Methods:          methods,
+		Schemes:          svc.Schemes,
+		DependenciesType: svc.DependenciesType,
+		Dependencies:     svc.Dependencies,
 	}
 }
 
@@ -163,10 +190,14 @@ func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
 {{- if .Schemes }}
 	// Casting service to Auther interface
 	a := s.(Auther)
+{{- end }}
+{{- if .Dependencies }}
+	{{ printf "Casting service to %s interface" .DependenciesType | comment }}
+	d := s.({{ .DependenciesType }})
 {{- end }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
-		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}{{ if .Dependencies }}, d{{ end }}),
 {{- end }}
 	}
 }
@@ -186,7 +217,7 @@ type {{ .ServerStream.EndpointStruct }} struct {
 
 // input: endpointMethodData
 const serviceEndpointMethodT = `{{ printf "New%sEndpoint returns an endpoint function that calls the method %q of service %q." .VarName .Name .ServiceName | comment }}
-func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}) goa.Endpoint {
+func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}{{ if .Dependencies }}, deps {{ .DependenciesType }}{{ end }}) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 {{- if .ServerStream }}
 		ep := req.(*{{ .ServerStream.EndpointStruct }})
@@ -194,6 +225,15 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		p := req.({{ .PayloadRef }})
 {{- end }}
 {{- $payload := payloadVar . }}
+{{- range .LogFields }}
+	{{- if .Pointer }}
+		if {{ $payload }}.{{ .FieldName }} != nil {
+			ctx = middleware.AddLogFields(ctx, {{ printf "%q" .Key }}, *{{ $payload }}.{{ .FieldName }})
+		}
+	{{- else }}
+		ctx = middleware.AddLogFields(ctx, {{ printf "%q" .Key }}, {{ $payload }}.{{ .FieldName }})
+	{{- end }}
+{{- end }}
 {{- if .Requirements }}
 		var err error
 	{{- range $ridx, $r := .Requirements }}
@@ -297,6 +337,14 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		if err != nil {
 			return nil, err
 		}
+	{{- if .Permissions }}
+		if err := security.ValidatePermissions(ctx, {{ range $i, $p := .Permissions }}{{ if $i }}, {{ end }}string({{ $p }}){{ end }}); err != nil {
+			return nil, err
+		}
+	{{- end }}
+{{- end }}
+{{- range .Dependencies }}
+		return with{{ .VarName }}Dependency(ctx, deps, func(ctx context.Context) (interface{}, error) {
 {{- end }}
 {{- if .ServerStream }}
 	return nil, s.{{ .VarName }}(ctx, {{ if .PayloadRef }}{{ $payload }}, {{ end }}ep.Stream)
@@ -305,17 +353,60 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		if err != nil {
 			return nil, err
 		}
+	{{- if not .ViewedResult.ViewName }}
+		if v, ok := ctx.Value(goa.ViewKey).(string); ok && v != "" {
+			view = v
+		}
+	{{- end }}
+	{{- if .FieldMask }}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			res.Prune(mask)
+		}
+	{{- end }}
 		vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
 		return vres, nil
+{{- else if .FieldMask }}
+		res, err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+		if err != nil {
+			return nil, err
+		}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			res.Prune(mask)
+		}
+		return res, nil
 {{- else if .ResultRef }}
 		return s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
 {{- else }}
 	return {{ if not .ResultRef }}nil, {{ end }}s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+{{- end }}
+{{- range .Dependencies }}
+		})
 {{- end }}
 	}
 }
 `
 
+// input: map[string]interface{}{"Dependency": *DependencyData, "DependenciesType": string}
+const serviceEndpointDependencyT = `{{ with .Dependency }}{{ printf "with%sDependency creates the %q dependency with deps, stores it in the context given to fn and finalizes it with the error returned by fn." .VarName .Name | comment }}
+func with{{ .VarName }}Dependency(ctx context.Context, deps {{ $.DependenciesType }}, fn func(context.Context) (interface{}, error)) (res interface{}, err error) {
+	{{ .ArgName }}, err := deps.New{{ .VarName }}(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			deps.Finalize{{ .VarName }}(ctx, {{ .ArgName }}, fmt.Errorf("panic: %v", r))
+			panic(r)
+		}
+		if ferr := deps.Finalize{{ .VarName }}(ctx, {{ .ArgName }}, err); ferr != nil && err == nil {
+			res, err = nil, ferr
+		}
+	}()
+	return fn(context.WithValue(ctx, dependencyKey({{ printf "%q" .Name }}), {{ .ArgName }}))
+}
+{{- end }}
+`
+
 // input: endpointMethodData
 const serviceEndpointsUseT = `{{ printf "Use applies the given middleware to all the %q service endpoints." .Name | comment }}
 func (e *{{ .VarName }}) Use(m func(goa.Endpoint) goa.Endpoint) {
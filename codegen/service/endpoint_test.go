@@ -23,6 +23,10 @@ func TestEndpoint(t *testing.T) {
 		{"no-payload", testdata.NoPayloadEndpointDSL, testdata.NoPayloadEndpoint},
 		{"with-result", testdata.WithResultEndpointDSL, testdata.WithResultEndpoint},
 		{"with-result-multiple-views", testdata.WithResultMultipleViewsEndpointDSL, testdata.WithResultMultipleViewsEndpoint},
+		{"field-mask", testdata.FieldMaskEndpointDSL, testdata.FieldMaskEndpoint},
+		{"log-fields", testdata.LogFieldsEndpointDSL, testdata.LogFieldsEndpoint},
+		{"permissions", testdata.PermissionsEndpointDSL, testdata.PermissionsEndpoint},
+		{"dependencies", testdata.DependenciesEndpointDSL, testdata.DependenciesEndpoint},
 		{"streaming-result", testdata.StreamingResultEndpointDSL, testdata.StreamingResultMethodEndpoint},
 		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadEndpointDSL, testdata.StreamingResultNoPayloadMethodEndpoint},
 		{"streaming-result-with-views", testdata.StreamingResultWithViewsMethodDSL, testdata.StreamingResultWithViewsMethodEndpoint},
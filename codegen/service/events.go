@@ -0,0 +1,107 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// eventsData contains the data needed to render the event bus of a service.
+type eventsData struct {
+	// Name is the service name.
+	Name string
+	// Events lists the methods marked as events.
+	Events []*MethodData
+}
+
+// EventsFile returns the file implementing the in-process event bus of the
+// given service. The bus exposes typed functions to publish and subscribe to
+// each method marked with the Event DSL and the Endpoints struct gets a method
+// that publishes the events received by the transports. EventsFile returns nil
+// if the service does not define events.
+func EventsFile(service *expr.ServiceExpr) *codegen.File {
+	var events []*expr.MethodExpr
+	for _, m := range service.Methods {
+		if m.IsEvent() {
+			events = append(events, m)
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	data := &eventsData{Name: svc.Name}
+	for _, m := range events {
+		data.Events = append(data.Events, svc.Method(m.Name))
+	}
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "events.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" events", svc.PkgName,
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				codegen.GoaImport(""),
+			}),
+		{
+			Name:   "events-struct",
+			Source: eventsStructT,
+			Data:   data,
+		},
+	}
+	for _, m := range data.Events {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "event-functions",
+			Source: eventFunctionsT,
+			Data:   m,
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "endpoints-publish-events",
+		Source: endpointsPublishEventsT,
+		Data:   data,
+	})
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: *eventsData
+const eventsStructT = `{{ printf "Events is the in-process event bus of the %q service. The events published with the Publish functions are delivered synchronously to the handlers registered with the Subscribe functions in order of subscription." .Name | comment }}
+type Events struct {
+	bus *goa.EventBus
+}
+
+// NewEvents returns an event bus with no subscriber.
+func NewEvents() *Events {
+	return &Events{bus: goa.NewEventBus()}
+}
+`
+
+// input: *MethodData
+const eventFunctionsT = `{{ printf "Publish%s publishes the %q event to the handlers subscribed with Subscribe%s. It returns the first error returned by a handler if any." .VarName .Name .VarName | comment }}
+func (e *Events) Publish{{ .VarName }}(ctx context.Context, p {{ .PayloadRef }}) error {
+	return e.bus.Publish(ctx, {{ printf "%q" .Name }}, p)
+}
+
+{{ printf "Subscribe%s registers h to handle the %q events. It returns a function that unregisters h." .VarName .Name | comment }}
+func (e *Events) Subscribe{{ .VarName }}(h func(context.Context, {{ .PayloadRef }}) error) (unsubscribe func()) {
+	return e.bus.Subscribe({{ printf "%q" .Name }}, func(ctx context.Context, v interface{}) error {
+		return h(ctx, v.({{ .PayloadRef }}))
+	})
+}
+`
+
+// input: *eventsData
+const endpointsPublishEventsT = `{{ printf "PublishEvents wraps the endpoints of the %q service events so that the events received by the transports are published on events once handled by the service." .Name | comment }}
+func (e *Endpoints) PublishEvents(events *Events) {
+{{- range .Events }}
+	{
+		ep := e.{{ .VarName }}
+		e.{{ .VarName }} = func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, err := ep(ctx, req); err != nil {
+				return nil, err
+			}
+			return nil, events.Publish{{ .VarName }}(ctx, req.({{ .PayloadRef }}))
+		}
+	}
+{{- end }}
+}
+`
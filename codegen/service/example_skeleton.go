@@ -0,0 +1,378 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// SkeletonServiceFiles returns the service implementation skeletons for every
+// service expression. The skeletons contain a stub for each method that
+// returns the design example value of the method result.
+//
+// If a service implementation file already exists SkeletonServiceFiles only
+// generates the stubs of the methods that are not implemented yet and appends
+// them to the file, leaving the existing code untouched.
+func SkeletonServiceFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	apipkg := exampleAPIPkg(root)
+	for _, svc := range root.Services {
+		if f := skeletonServiceFile(genpkg, svc, apipkg); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// skeletonServiceFile returns the implementation skeleton of the given
+// service, nil if the service implementation file already implements all the
+// service methods.
+func skeletonServiceFile(genpkg string, svc *expr.ServiceExpr, apipkg string) *codegen.File {
+	data := Services.Get(svc.Name)
+	svcName := codegen.SnakeCase(data.VarName)
+	fpath := svcName + ".go"
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "log"},
+		{Path: "time"},
+		codegen.GoaImport(""),
+		{Path: path.Join(genpkg, codegen.SnakeCase(svcName)), Name: data.PkgName},
+	}
+	if _, p := codegen.DecimalType(); p != "" {
+		specs = append(specs, &codegen.ImportSpec{Path: p})
+	}
+
+	var sections []*codegen.SectionTemplate
+	implemented, err := implementedMethods(fpath, data.VarName+"srvc")
+	if os.IsNotExist(err) {
+		sections = []*codegen.SectionTemplate{
+			codegen.Header("", apipkg, specs),
+			{Name: "skeleton-service-struct", Source: svcSkeletonStructT, Data: data},
+			{Name: "basic-service-init", Source: svcInitT, Data: data},
+		}
+	} else if err != nil {
+		return nil // cannot merge into a file that does not parse, skip it.
+	}
+	for _, m := range svc.Methods {
+		if _, ok := implemented[data.Method(m.Name).VarName]; ok {
+			continue
+		}
+		sections = append(sections, skeletonEndpointSection(m, data))
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+
+	return &codegen.File{
+		Path:             fpath,
+		SectionTemplates: sections,
+		FinalizeFunc:     addMissingImports(specs),
+	}
+}
+
+// implementedMethods parses the Go file with the given path and returns the
+// names of the methods defined on the given receiver type.
+func implementedMethods(fpath, recv string) (map[string]struct{}, error) {
+	if _, err := os.Stat(fpath); err != nil {
+		return nil, err
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), fpath, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	methods := make(map[string]struct{})
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		typ := fn.Recv.List[0].Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		if id, ok := typ.(*ast.Ident); ok && id.Name == recv {
+			methods[fn.Name.Name] = struct{}{}
+		}
+	}
+	return methods, nil
+}
+
+// addMissingImports returns a file finalizer that adds the given imports to
+// the file when they are used but not imported. This makes it possible to
+// append stubs to an existing file that does not import all the packages the
+// stubs use.
+func addMissingImports(specs []*codegen.ImportSpec) func(string) error {
+	return func(fpath string) error {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, fpath, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		var added bool
+		for _, spec := range specs {
+			if imported(f, spec.Path) {
+				continue
+			}
+			astutil.AddNamedImport(fset, f, spec.Name, spec.Path)
+			if astutil.UsesImport(f, spec.Path) {
+				added = true
+				continue
+			}
+			if spec.Name != "" {
+				astutil.DeleteNamedImport(fset, f, spec.Name, spec.Path)
+			} else {
+				astutil.DeleteImport(fset, f, spec.Path)
+			}
+		}
+		if !added {
+			return nil
+		}
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, f); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(fpath, buf.Bytes(), 0644)
+	}
+}
+
+// imported returns true if the given file imports the package with the given
+// path.
+func imported(f *ast.File, path string) bool {
+	for _, imp := range f.Imports {
+		if strings.Trim(imp.Path.Value, `"`) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// skeletonEndpointSection returns a section with the stub of the given method.
+func skeletonEndpointSection(m *expr.MethodExpr, svcData *Data) *codegen.SectionTemplate {
+	s := basicEndpointSection(m, svcData)
+	ed := s.Data.(*basicEndpointData)
+	if ed.ResultFullRef != "" && ed.ServerStream == nil {
+		ed.ResultExample = exampleLiteral(m.Result, ed.ResultEx, svcData.Scope, svcData.PkgName, nil)
+	}
+	s.Name = "skeleton-endpoint"
+	s.Source = skeletonEndpointT
+	return s
+}
+
+// literalOptions customizes the literals built by exampleLiteral.
+type literalOptions struct {
+	// ptr returns the expression that initializes an optional primitive
+	// field of the given attribute type from the literal lit. Optional
+	// primitive fields are left out if ptr is nil.
+	ptr func(att *expr.AttributeExpr, lit string) string
+	// defaults makes fields with a default value and no design example use
+	// the default value.
+	defaults bool
+}
+
+// exampleLiteral returns the Go expression that initializes a value of the
+// given attribute type with the example value v. Fields holding optional
+// primitive values or goa.Optional wrappers (unless opts says otherwise),
+// anonymous structs and custom types are left out. It returns the empty string
+// if the value cannot be represented.
+func exampleLiteral(att *expr.AttributeExpr, v interface{}, scope *codegen.NameScope, pkg string, opts *literalOptions) string {
+	if opts == nil {
+		opts = &literalOptions{}
+	}
+	if v == nil {
+		return ""
+	}
+	if _, ok := att.Meta["struct:field:type"]; ok {
+		return ""
+	}
+	switch actual := att.Type.(type) {
+	case expr.Primitive:
+		return primitiveLiteral(actual, v)
+	case *expr.Array:
+		val := reflect.ValueOf(v)
+		if val.Kind() != reflect.Slice {
+			return ""
+		}
+		elems := make([]string, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			if e := exampleLiteral(actual.ElemType, val.Index(i).Interface(), scope, pkg, opts); e != "" {
+				elems = append(elems, e)
+			}
+		}
+		return scope.GoFullTypeName(att, pkg) + compositeLiteral(elems)
+	case *expr.Map:
+		val := reflect.ValueOf(v)
+		if val.Kind() != reflect.Map {
+			return ""
+		}
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		elems := make([]string, 0, len(keys))
+		for _, key := range keys {
+			k := exampleLiteral(actual.KeyType, key.Interface(), scope, pkg, opts)
+			e := exampleLiteral(actual.ElemType, val.MapIndex(key).Interface(), scope, pkg, opts)
+			if k != "" && e != "" {
+				elems = append(elems, k+": "+e)
+			}
+		}
+		return scope.GoFullTypeName(att, pkg) + compositeLiteral(elems)
+	case expr.UserType:
+		if actual == expr.ErrorResult {
+			return ""
+		}
+		ut := actual.Attribute()
+		obj, ok := ut.Type.(*expr.Object)
+		if !ok {
+			lit := exampleLiteral(ut, v, scope, pkg, opts)
+			if lit == "" || expr.IsPrimitive(ut.Type) {
+				return lit
+			}
+			return scope.GoFullTypeName(att, pkg) + "(" + lit + ")"
+		}
+		vals, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		var fields []string
+		for _, nat := range *obj {
+			ptr := ut.IsPrimitivePointer(nat.Name, true)
+			_, prim := nat.Attribute.Type.(expr.Primitive)
+			opt := prim && nat.Attribute.IsOptionalField()
+			if _, ok := nat.Attribute.Type.(*expr.Object); ok || (ptr || opt) && opts.ptr == nil {
+				continue
+			}
+			val, ok := vals[nat.Name]
+			if ok && opts.defaults && nat.Attribute.DefaultValue != nil && len(nat.Attribute.UserExamples) == 0 {
+				val = nat.Attribute.DefaultValue
+			}
+			lit := exampleLiteral(nat.Attribute, val, scope, pkg, opts)
+			if lit == "" {
+				continue
+			}
+			switch {
+			case opt:
+				// Optional fields hold the value in a goa.Optional
+				// wrapper, see the OptionalFields DSL.
+				val := opts.ptr(&expr.AttributeExpr{Type: nat.Attribute.Type}, lit)
+				lit = codegen.GoOptionalTypeName(nat.Attribute.Type) + "{Value: " + val + ", Set: true}"
+			case ptr:
+				lit = opts.ptr(nat.Attribute, lit)
+			}
+			fields = append(fields, codegen.GoifyAtt(nat.Attribute, nat.Name, true)+": "+lit)
+		}
+		lit := scope.GoFullTypeName(att, pkg) + "{}"
+		if len(fields) > 0 {
+			lit = scope.GoFullTypeName(att, pkg) + "{\n" + strings.Join(fields, ",\n") + ",\n}"
+		}
+		if strings.HasPrefix(scope.GoFullTypeRef(att, pkg), "*") {
+			lit = "&" + lit
+		}
+		return lit
+	default:
+		return ""
+	}
+}
+
+// compositeLiteral returns the body of the composite literal with the given
+// elements. The elements are written on separate lines if any of them spans
+// multiple lines.
+func compositeLiteral(elems []string) string {
+	for _, e := range elems {
+		if strings.Contains(e, "\n") {
+			return "{\n" + strings.Join(elems, ",\n") + ",\n}"
+		}
+	}
+	return "{" + strings.Join(elems, ", ") + "}"
+}
+
+// primitiveLiteral returns the Go literal for the given primitive value.
+func primitiveLiteral(p expr.Primitive, v interface{}) string {
+	switch p.Kind() {
+	case expr.BooleanKind, expr.IntKind, expr.Int32Kind, expr.Int64Kind,
+		expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind, expr.Float32Kind, expr.Float64Kind:
+		return fmt.Sprintf("%v", v)
+	case expr.StringKind:
+		if s, ok := v.(string); ok {
+			return fmt.Sprintf("%q", s)
+		}
+	case expr.BytesKind:
+		if b, ok := v.([]byte); ok {
+			return fmt.Sprintf("[]byte(%q)", b)
+		}
+	case expr.DurationKind:
+		if d, ok := expr.DurationValue(v); ok {
+			return fmt.Sprintf("time.Duration(%d)", d)
+		}
+	case expr.UUIDKind:
+		if s, ok := v.(string); ok {
+			return fmt.Sprintf("goa.MustParseUUID(%q)", s)
+		}
+	case expr.TimestampKind:
+		if t, ok := expr.TimestampValue(v); ok {
+			return fmt.Sprintf("time.Unix(%d, %d).UTC()", t.Unix(), t.Nanosecond())
+		}
+	case expr.DecimalKind:
+		if s, ok := expr.DecimalValue(v); ok {
+			return codegen.DecimalFromString(strconv.Quote(s))
+		}
+	case expr.AnyKind:
+		switch v.(type) {
+		case string:
+			return fmt.Sprintf("%q", v)
+		case bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+			return fmt.Sprintf("%v", v)
+		}
+	}
+	return ""
+}
+
+const (
+	// input: service.Data
+	svcSkeletonStructT = `{{ printf "%s service implementation.\nThe method stubs log the requests and return the design example values." .Name | comment }}
+type {{ .VarName }}srvc struct {
+  logger *log.Logger
+}
+`
+
+	// input: basicEndpointData
+	skeletonEndpointT = `{{ comment .Description }}
+{{- if .ServerStream }}
+func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .PayloadFullRef }}, p {{ .PayloadFullRef }}{{ end }}, stream {{ .StreamInterface }}) (err error) {
+{{- else }}
+func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .PayloadFullRef }}, p {{ .PayloadFullRef }}{{ end }}) ({{ if .ResultFullRef }}res {{ .ResultFullRef }}, {{ if .ViewedResult }}{{ if not .ViewedResult.ViewName }}view string, {{ end }}{{ end }} {{ end }}err error) {
+{{- end }}
+  // TODO: implement {{ .ServiceVarName }}.{{ .Name }}
+{{- if and .ResultExample (not .ServerStream) }}
+  res = {{ .ResultExample }}
+{{- else if and (and .ResultFullRef .ResultIsStruct) (not .ServerStream) }}
+  res = &{{ .ResultFullName }}{}
+{{- end }}
+{{- if .ViewedResult }}
+	{{- if not .ViewedResult.ViewName }}
+		{{- if .ServerStream }}
+			stream.SetView({{ printf "%q" .ResultView }})
+		{{- else }}
+			view = {{ printf "%q" .ResultView }}
+		{{- end }}
+	{{- end }}
+{{- end }}
+  s.logger.Print("{{ .ServiceVarName }}.{{ .Name }}")
+  return
+}
+`
+)
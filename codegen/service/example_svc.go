@@ -30,14 +30,29 @@ type (
 		// StreamInterface is the stream interface in the service package used
 		// by the endpoint implementation.
 		StreamInterface string
+		// ResultExample is the Go expression initializing the result with
+		// the design example value. It is only set for skeleton stubs.
+		ResultExample string
 	}
 )
 
 // ExampleServiceFiles returns a basic service implementation for every
 // service expression.
 func ExampleServiceFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	apipkg := exampleAPIPkg(root)
+	var fw []*codegen.File
+	for _, svc := range root.Services {
+		if f := exampleServiceFile(genpkg, root, svc, apipkg); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
 
-	// determine the unique API package name different from the service names
+// exampleAPIPkg returns the name of the package of the example service
+// implementations. The name is unique and different from the service package
+// names.
+func exampleAPIPkg(root *expr.RootExpr) string {
 	scope := codegen.NewNameScope()
 	for _, svc := range root.Services {
 		svc := Services.Get(svc.Name)
@@ -46,15 +61,7 @@ func ExampleServiceFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		}
 		scope.Unique(svc.PkgName)
 	}
-	apipkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
-
-	var fw []*codegen.File
-	for _, svc := range root.Services {
-		if f := exampleServiceFile(genpkg, root, svc, apipkg); f != nil {
-			fw = append(fw, f)
-		}
-	}
-	return fw
+	return scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 }
 
 // exampleServiceFile returns a basic implementation of the given service.
@@ -70,6 +77,11 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 		{Path: "log"},
 		{Path: path.Join(genpkg, codegen.SnakeCase(svcName)), Name: data.PkgName},
 	}
+	for _, d := range data.Dependencies {
+		if d.PkgPath != "" {
+			specs = append(specs, &codegen.ImportSpec{Path: d.PkgPath})
+		}
+	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", apipkg, specs),
 		{Name: "basic-service-struct", Source: svcStructT, Data: data},
@@ -78,6 +90,13 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 	for _, m := range svc.Methods {
 		sections = append(sections, basicEndpointSection(m, data))
 	}
+	for _, d := range data.Dependencies {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "basic-dependency",
+			Source: dependencyT,
+			Data:   map[string]interface{}{"Dependency": d, "ServiceVarName": data.VarName},
+		})
+	}
 
 	return &codegen.File{
 		Path:             fpath,
@@ -158,5 +177,20 @@ func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .Pay
   s.logger.Print("{{ .ServiceVarName }}.{{ .Name }}")
   return
 }
+`
+
+	// input: map[string]interface{}{"Dependency": *DependencyData, "ServiceVarName": string}
+	dependencyT = `{{ with .Dependency }}{{ printf "New%s creates the %q dependency of a request." .VarName .Name | comment }}
+func (s *{{ $.ServiceVarName }}srvc) New{{ .VarName }}(ctx context.Context) ({{ .ArgName }} {{ .TypeRef }}, err error) {
+  s.logger.Print("{{ $.ServiceVarName }}.New{{ .VarName }}")
+  return
+}
+
+{{ printf "Finalize%s finalizes the %q dependency of a request." .VarName .Name | comment }}
+func (s *{{ $.ServiceVarName }}srvc) Finalize{{ .VarName }}(ctx context.Context, {{ .ArgName }} {{ .TypeRef }}, err error) error {
+  s.logger.Print("{{ $.ServiceVarName }}.Finalize{{ .VarName }}")
+  return nil
+}
+{{- end }}
 `
 )
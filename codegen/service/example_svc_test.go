@@ -2,6 +2,8 @@ package service
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"testing"
 
@@ -57,3 +59,70 @@ func TestExampleServiceFiles(t *testing.T) {
 		}
 	})
 }
+
+func TestSkeletonServiceFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Existing string
+		Expected string
+	}{
+		{"new", "", testdata.SkeletonServiceCode},
+		{"merge", testdata.SkeletonExistingCode, testdata.SkeletonMergedCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			defer chdirTemp(t)()
+			if c.Existing != "" {
+				if err := ioutil.WriteFile("skeleton.go", []byte(c.Existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			codegen.RunDSL(t, testdata.SkeletonDSL)
+			fs := SkeletonServiceFiles("goa.design/goa/example/gen", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			if _, err := fs[0].Render("."); err != nil {
+				t.Fatal(err)
+			}
+			content, err := ioutil.ReadFile("skeleton.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if code := string(content); code != c.Expected {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Expected))
+			}
+		})
+	}
+	t.Run("complete", func(t *testing.T) {
+		defer chdirTemp(t)()
+		if err := ioutil.WriteFile("skeleton.go", []byte(testdata.SkeletonMergedCode), 0644); err != nil {
+			t.Fatal(err)
+		}
+		codegen.RunDSL(t, testdata.SkeletonDSL)
+		if fs := SkeletonServiceFiles("goa.design/goa/example/gen", expr.Root); len(fs) != 0 {
+			t.Errorf("got %d files, expected none", len(fs))
+		}
+	})
+}
+
+// chdirTemp changes the current directory to a new temporary directory and
+// returns a function that restores the current directory and deletes the
+// temporary directory.
+func chdirTemp(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "skeleton")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"path/filepath"
+	"sort"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// fixtureData contains the data needed to render a fixture function.
+	fixtureData struct {
+		// Name is the name of the fixture function.
+		Name string
+		// TypeName is the name of the type as defined in the design.
+		TypeName string
+		// View is the name of the view used to select the fixture
+		// attributes if any.
+		View string
+		// Ref is the reference to the service type returned by the
+		// fixture.
+		Ref string
+		// Literal is the Go expression that initializes the fixture.
+		Literal string
+	}
+
+	// fixturePtrData contains the data needed to render a helper function
+	// that returns a pointer to a primitive value.
+	fixturePtrData struct {
+		// Name is the name of the helper function.
+		Name string
+		// Ref is the reference to the primitive type.
+		Ref string
+	}
+)
+
+// FixturesFile returns the file defining the fixtures of the given service.
+// The fixtures are functions that return fully populated values of the
+// payload and result types of the service methods and of the user types they
+// depend on. The values are built from the design examples and default values
+// so that tests do not need to build large structs by hand. Result types with
+// multiple views also get one fixture per view that only populates the
+// attributes rendered by the view. The file is generated in the fixtures
+// package. FixturesFile returns nil if the service does not use any user type.
+func FixturesFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	svcName := codegen.SnakeCase(svc.VarName)
+
+	var (
+		fixtures []*fixtureData
+		ptrs     = make(map[string]*fixturePtrData)
+	)
+	{
+		var (
+			rand  = expr.NewRandom(service.Name)
+			names = codegen.NewNameScope()
+			seen  = make(map[string]struct{})
+		)
+		opts := &literalOptions{
+			ptr: func(att *expr.AttributeExpr, lit string) string {
+				ref := svc.Scope.GoFullTypeRef(att, svc.PkgName)
+				name := codegen.Goify(ref+"Ptr", false)
+				ptrs[name] = &fixturePtrData{Name: name, Ref: ref}
+				return name + "(" + lit + ")"
+			},
+			defaults: true,
+		}
+		record := func(att *expr.AttributeExpr) {
+			ut, ok := att.Type.(expr.UserType)
+			if !ok || ut == expr.Empty {
+				return
+			}
+			if _, ok := seen[ut.ID()]; ok {
+				return
+			}
+			seen[ut.ID()] = struct{}{}
+			att = &expr.AttributeExpr{Type: ut}
+			ex := att.Example(rand)
+			lit := exampleLiteral(att, ex, svc.Scope, svc.PkgName, opts)
+			if lit == "" {
+				return
+			}
+			ref := svc.Scope.GoFullTypeRef(att, svc.PkgName)
+			// Goify the name of types generated in a shared package
+			// e.g. "types.User" into "TypesUser".
+			name := codegen.Goify(svc.Scope.GoTypeName(att), true)
+			fixtures = append(fixtures, &fixtureData{
+				Name:     names.Unique(name),
+				TypeName: ut.Name(),
+				Ref:      ref,
+				Literal:  lit,
+			})
+			rt, ok := ut.(*expr.ResultTypeExpr)
+			if !ok || !rt.HasMultipleViews() {
+				return
+			}
+			vals, ok := ex.(map[string]interface{})
+			if !ok {
+				return
+			}
+			for _, v := range rt.Views {
+				if v.Name == expr.DefaultView {
+					continue
+				}
+				viewed := make(map[string]interface{})
+				for _, nat := range *expr.AsObject(v.Type) {
+					if val, ok := vals[nat.Name]; ok {
+						viewed[nat.Name] = val
+					}
+				}
+				fixtures = append(fixtures, &fixtureData{
+					Name:     names.Unique(name + codegen.Goify(v.Name, true)),
+					TypeName: ut.Name(),
+					View:     v.Name,
+					Ref:      ref,
+					Literal:  exampleLiteral(att, viewed, svc.Scope, svc.PkgName, opts),
+				})
+			}
+		}
+		for _, m := range service.Methods {
+			record(m.Payload)
+			record(m.StreamingPayload)
+			record(m.Result)
+		}
+		for _, t := range svc.userTypes {
+			record(&expr.AttributeExpr{Type: t.Type})
+		}
+	}
+	if len(fixtures) == 0 {
+		return nil
+	}
+
+	path := filepath.Join(codegen.Gendir, svcName, "fixtures", "fixtures.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" fixtures", "fixtures",
+			[]*codegen.ImportSpec{
+				{Path: "time"},
+				codegen.GoaImport(""),
+				{Path: genpkg + "/" + svcName, Name: svc.PkgName},
+			}),
+	}
+	for _, f := range fixtures {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "fixture",
+			Source: fixtureT,
+			Data:   f,
+		})
+	}
+	names := make([]string, 0, len(ptrs))
+	for n := range ptrs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "fixture-ptr",
+			Source: fixturePtrT,
+			Data:   ptrs[n],
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: fixtureData
+const fixtureT = `{{ if .View -}}
+{{ printf "%s returns a %s value initialized with the design examples and default values of the attributes rendered by the %q view. Each call returns a new value." .Name .TypeName .View | comment }}
+{{- else -}}
+{{ printf "%s returns a %s value initialized with the design examples and default values. Each call returns a new value." .Name .TypeName | comment }}
+{{- end }}
+func {{ .Name }}() {{ .Ref }} {
+	return {{ .Literal }}
+}
+`
+
+// input: fixturePtrData
+const fixturePtrT = `{{ printf "%s returns a pointer to v." .Name | comment }}
+func {{ .Name }}(v {{ .Ref }}) *{{ .Ref }} {
+	return &v
+}
+`
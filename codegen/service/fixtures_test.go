@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestFixtures(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"fixtures", testdata.FixturesDSL, testdata.Fixtures},
+		{"optional-fields", testdata.OptionalFixturesDSL, testdata.OptionalFixtures},
+		{"no-fixtures", testdata.NoFixturesDSL, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			if len(expr.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(expr.Root.Services))
+			}
+			f := FixturesFile("goa.design/goa/example", expr.Root.Services[0])
+			if c.Code == "" {
+				if f != nil {
+					t.Fatalf("got fixtures file, expected none")
+				}
+				return
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bs, err := format.Source(buf.Bytes())
+			if err != nil {
+				fmt.Println(buf.String())
+				t.Fatal(err)
+			}
+			code := string(bs)
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
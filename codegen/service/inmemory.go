@@ -0,0 +1,150 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// InMemoryClientFile returns the file implementing the in-memory client of the
+// given service. The client invokes the service endpoints directly without
+// going through a transport so that tests may exercise the payload and result
+// mappings quickly. The file is generated in the <service>test package.
+// InMemoryClientFile returns nil if the service has no method, e.g. if it only
+// serves static files.
+func InMemoryClientFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	if len(service.Methods) == 0 {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	data := endpointData(service)
+	svcName := codegen.SnakeCase(svc.VarName)
+	path := filepath.Join(codegen.Gendir, svcName, svcName+"test", "client.go")
+	var (
+		sections []*codegen.SectionTemplate
+	)
+	{
+		header := codegen.Header(service.Name+" in-memory client", svc.PkgName+"test",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "encoding/json"},
+				{Path: "fmt"},
+				{Path: "reflect"},
+				codegen.GoaImport(""),
+				{Path: genpkg + "/" + svcName, Name: svc.PkgName},
+				{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
+			})
+		init := &codegen.SectionTemplate{
+			Name:   "in-memory-client-init",
+			Source: inMemoryClientInitT,
+			Data:   map[string]interface{}{"Endpoints": data, "PkgName": svc.PkgName},
+		}
+		sections = []*codegen.SectionTemplate{header, init}
+		for _, m := range data.Methods {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "in-memory-client-endpoint",
+				Source: inMemoryClientEndpointT,
+				Data:   map[string]interface{}{"Method": m, "PkgName": svc.PkgName},
+			})
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "in-memory-client-round-trip",
+			Source: inMemoryClientRoundTripT,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: map[string]interface{}{"Endpoints": *endpointsData, "PkgName": string}
+const inMemoryClientInitT = `{{ with .Endpoints }}// InMemoryOption configures the clients created by NewInMemoryClient.
+type InMemoryOption func(*inMemoryOptions)
+
+// inMemoryOptions holds the in-memory client options.
+type inMemoryOptions struct {
+	roundTrip bool
+}
+
+{{ comment "WithRoundTrip makes the client encode the payloads to JSON and decode them back before invoking the endpoints and do the same with the results. This makes it possible to detect values that do not survive serialization without running a server." }}
+func WithRoundTrip() InMemoryOption {
+	return func(o *inMemoryOptions) {
+		o.roundTrip = true
+	}
+}
+
+{{ printf "NewInMemoryClient returns a %q service client that invokes the given endpoints directly, without network or serialization unless WithRoundTrip is used. Streaming methods are not supported and return an error." .Name | comment }}
+func NewInMemoryClient(endpoints *{{ $.PkgName }}.{{ .VarName }}, opts ...InMemoryOption) *{{ $.PkgName }}.{{ .ClientVarName }} {
+	o := &inMemoryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return {{ $.PkgName }}.New{{ .ClientVarName }}(
+{{- range .Methods }}
+		new{{ .VarName }}Endpoint(endpoints.{{ .VarName }}, o),
+{{- end }}
+	)
+}
+{{ end }}`
+
+// input: map[string]interface{}{"Method": *endpointMethodData, "PkgName": string}
+const inMemoryClientEndpointT = `{{ with .Method }}
+{{ printf "new%sEndpoint returns the endpoint used by the in-memory client to call the %q endpoint of the %q service." .VarName .Name .ServiceName | comment }}
+{{- if .ServerStream }}
+func new{{ .VarName }}Endpoint(goa.Endpoint, *inMemoryOptions) goa.Endpoint {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("in-memory client does not support the streaming method %q of service %q", {{ printf "%q" .Name }}, {{ printf "%q" .ServiceName }})
+	}
+}
+{{- else }}
+func new{{ .VarName }}Endpoint(ep goa.Endpoint, o *inMemoryOptions) goa.Endpoint {
+	return func(ctx context.Context, p interface{}) (interface{}, error) {
+	{{- if .PayloadRef }}
+		if o.roundTrip {
+			var err error
+			if p, err = roundTrip(p); err != nil {
+				return nil, fmt.Errorf("failed to serialize %q payload: %s", {{ printf "%q" .Name }}, err)
+			}
+		}
+	{{- end }}
+	{{- if .ResultRef }}
+		res, err := ep(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if o.roundTrip {
+			if res, err = roundTrip(res); err != nil {
+				return nil, fmt.Errorf("failed to serialize %q result: %s", {{ printf "%q" .Name }}, err)
+			}
+		}
+		{{- if .ViewedResult }}
+		return {{ $.PkgName }}.{{ .ViewedResult.ResultInit.Name }}(res.({{ .ViewedResult.FullRef }})), nil
+		{{- else }}
+		return res, nil
+		{{- end }}
+	{{- else }}
+		return ep(ctx, p)
+	{{- end }}
+	}
+}
+{{- end }}
+{{ end }}`
+
+// input: nil
+const inMemoryClientRoundTripT = `// roundTrip encodes v to JSON and decodes the result into a new value of the
+// same type.
+func roundTrip(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	nv := reflect.New(reflect.TypeOf(v))
+	if err := json.Unmarshal(b, nv.Interface()); err != nil {
+		return nil, err
+	}
+	return nv.Elem().Interface(), nil
+}
+`
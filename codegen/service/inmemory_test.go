@@ -0,0 +1,55 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestInMemoryClient(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"single", testdata.SingleEndpointDSL, testdata.SingleMethodInMemoryClient},
+		{"with-result-multiple-views", testdata.WithResultMultipleViewsEndpointDSL, testdata.WithResultMultipleViewsInMemoryClient},
+		{"streaming-result", testdata.StreamingResultEndpointDSL, testdata.StreamingResultInMemoryClient},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			if len(expr.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(expr.Root.Services))
+			}
+			f := InMemoryClientFile("goa.design/goa/example", expr.Root.Services[0])
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bs, err := format.Source(buf.Bytes())
+			if err != nil {
+				fmt.Println(buf.String())
+				t.Fatal(err)
+			}
+			code := string(bs)
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestInMemoryClientNoMethod(t *testing.T) {
+	codegen.RunDSL(t, testdata.NoMethodEndpointDSL)
+	if f := InMemoryClientFile("goa.design/goa/example", expr.Root.Services[0]); f != nil {
+		t.Errorf("got file %q, expected none for a service without methods", f.Path)
+	}
+}
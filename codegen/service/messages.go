@@ -0,0 +1,148 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// MessagesFile returns the file that lists the English templates of the
+// messages of the errors that the generated code may produce when validating
+// the payloads of the service methods. The file is a JSON object that maps the
+// message IDs to the templates, translate the templates and load the result in
+// a goa.Catalog to localize the error messages. MessagesFile returns nil if no
+// method defines a payload.
+func MessagesFile(root *expr.RootExpr) *codegen.File {
+	msgs := make(map[string]string)
+	for _, svc := range root.Services {
+		for _, m := range svc.Methods {
+			collectMessages(m, msgs)
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	section := &codegen.SectionTemplate{
+		Name:    "messages",
+		FuncMap: template.FuncMap{"toIndentedJSON": toIndentedJSON},
+		Source:  "{{ toIndentedJSON . }}",
+		Data:    msgs,
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "messages.json"),
+		SectionTemplates: []*codegen.SectionTemplate{section},
+	}
+}
+
+// collectMessages adds the templates of the messages of the errors produced
+// when validating the payloads of m to msgs.
+func collectMessages(m *expr.MethodExpr, msgs map[string]string) {
+	add := func(ids ...string) {
+		for _, id := range ids {
+			msgs[id] = goa.DefaultMessages[id]
+		}
+	}
+	payloads := []*expr.AttributeExpr{m.Payload, m.StreamingPayload}
+	for _, p := range payloads {
+		if p == nil || p.Type == expr.Empty {
+			continue
+		}
+		add("missing_payload", "decode_payload", "invalid_field_type")
+		if m.IsStrictDecoding() {
+			add("unknown_field")
+		}
+		if svc := httpService(m); svc != nil {
+			if e := svc.Endpoint(m.Name); e != nil {
+				if e.RequestBodyLimit() > 0 {
+					add("request_body_too_large")
+				}
+				if len(e.RequestEncodings()) > 0 {
+					add("unsupported_content_encoding", "request_body_too_large")
+				}
+			}
+		}
+		codegen.Walk(p, func(att *expr.AttributeExpr) error {
+			v := att.Validation
+			if v == nil {
+				return nil
+			}
+			if len(v.Values) > 0 {
+				add("invalid_enum_value")
+			}
+			if v.Format != "" {
+				add("invalid_format")
+			}
+			if v.Pattern != "" {
+				add("invalid_pattern")
+			}
+			if v.TimeZone != "" {
+				add("invalid_time_zone")
+			}
+			if v.Minimum != nil {
+				add("invalid_range.min")
+			}
+			if v.Maximum != nil {
+				add("invalid_range.max")
+			}
+			if v.MinLength != nil {
+				add("invalid_length.min")
+			}
+			if v.MaxLength != nil {
+				add("invalid_length.max")
+			}
+			switch {
+			case v.Precision != nil && v.Scale != nil:
+				add("invalid_decimal")
+			case v.Precision != nil:
+				add("invalid_decimal.precision")
+			case v.Scale != nil:
+				add("invalid_decimal.scale")
+			}
+			if len(v.Required) > 0 {
+				add("missing_field")
+			}
+			if len(v.MutuallyExclusive) > 0 {
+				add("mutually_exclusive_fields")
+			}
+			if len(v.RequiredTogether) > 0 {
+				add("required_together_fields")
+			}
+			if len(v.AtLeastOneOf) > 0 {
+				add("missing_field.at_least_one")
+			}
+			if len(v.RequiredIf) > 0 {
+				add("missing_field.required_if")
+			}
+			if len(v.Funcs) > 0 {
+				add("invalid_field")
+			}
+			return nil
+		})
+	}
+}
+
+// httpService returns the HTTP service of the service that defines the given
+// method, nil if the service does not expose HTTP endpoints.
+func httpService(m *expr.MethodExpr) *expr.HTTPServiceExpr {
+	if expr.Root.API == nil || expr.Root.API.HTTP == nil {
+		return nil
+	}
+	return expr.Root.API.HTTP.Service(m.Service.Name)
+}
+
+// toIndentedJSON returns the indented JSON encoding of d.
+func toIndentedJSON(d interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		panic("messages: " + err.Error()) // bug
+	}
+	return buf.String()
+}
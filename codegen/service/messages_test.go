@@ -0,0 +1,49 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMessagesFile(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected string
+	}{
+		{"validations", testdata.ValidationMessagesDSL, testdata.ValidationMessagesFile},
+		{"strict-decoding", testdata.StrictDecodingMessagesDSL, testdata.StrictDecodingMessagesFile},
+		{"no-payload", testdata.EmptyMethodDSL, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			f := MessagesFile(expr.Root)
+			if c.Expected == "" {
+				if f != nil {
+					t.Fatalf("got file, expected nil")
+				}
+				return
+			}
+			if f == nil {
+				t.Fatalf("got nil file, expected not nil")
+			}
+			if f.Path != "gen/messages.json" {
+				t.Errorf("got path %q, expected %q", f.Path, "gen/messages.json")
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if actual := buf.String(); actual != c.Expected {
+				t.Errorf("got\n%s\ngot vs. expected:\n%s", actual, codegen.Diff(t, actual, c.Expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,688 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// refAPIData is the data used to render the index page of the reference
+	// documentation.
+	refAPIData struct {
+		// Title is the API title.
+		Title string
+		// Description is the API description.
+		Description string
+		// Version is the API version.
+		Version string
+		// Servers lists the API servers.
+		Servers []*refServerData
+		// Services lists the API services.
+		Services []*refServiceData
+		// Schemes lists the security schemes used by the API.
+		Schemes []*refSchemeData
+		// HasTypes is true if the documentation includes a types page.
+		HasTypes bool
+	}
+
+	// refServerData describes a server.
+	refServerData struct {
+		// Name is the server name.
+		Name string
+		// Description is the server description.
+		Description string
+		// URIs lists the URIs of the server hosts.
+		URIs []string
+	}
+
+	// refSchemeData describes a security scheme.
+	refSchemeData struct {
+		// Name is the scheme name.
+		Name string
+		// Kind is the scheme kind, e.g. "JWT".
+		Kind string
+		// Description is the scheme description.
+		Description string
+		// In is the location of the credentials if any, e.g. "header".
+		In string
+		// Param is the name of the header or query string parameter
+		// that holds the credentials if any.
+		Param string
+		// Scopes lists the scheme scopes.
+		Scopes []*expr.ScopeExpr
+	}
+
+	// refServiceData is the data used to render the page of a service.
+	refServiceData struct {
+		// Name is the service name.
+		Name string
+		// Description is the service description.
+		Description string
+		// Page is the name of the service page file.
+		Page string
+		// Methods lists the service methods.
+		Methods []*refMethodData
+	}
+
+	// refMethodData describes a service method.
+	refMethodData struct {
+		// Name is the method name.
+		Name string
+		// Anchor is the anchor of the method section.
+		Anchor string
+		// Description is the method description.
+		Description string
+		// Stream describes the method streaming if any.
+		Stream string
+		// Payload describes the method payload, nil if none.
+		Payload *refTypeData
+		// StreamingPayload describes the payload streamed by the client,
+		// nil if none.
+		StreamingPayload *refTypeData
+		// Result describes the method result, nil if none.
+		Result *refTypeData
+		// Errors lists the method errors.
+		Errors []*refErrorData
+		// Routes lists the HTTP routes of the method.
+		Routes []string
+		// Statuses lists the HTTP status codes of the success responses.
+		Statuses []int
+		// GRPC is the full name of the gRPC method if any.
+		GRPC string
+		// Requirements lists the security requirements, one per
+		// alternative.
+		Requirements []string
+	}
+
+	// refTypeData describes a type.
+	refTypeData struct {
+		// Name is the type name.
+		Name string
+		// Type is the type reference, a link for user types.
+		Type string
+		// Description is the type description.
+		Description string
+		// Constraints describes the validations of non object types.
+		Constraints string
+		// Fields lists the attributes of object types.
+		Fields []*refFieldData
+		// Example is the indented JSON representation of an example
+		// value.
+		Example string
+	}
+
+	// refFieldData describes an object attribute.
+	refFieldData struct {
+		// Name is the attribute name, dot separated for the attributes of
+		// inline objects.
+		Name string
+		// Type is the attribute type reference.
+		Type string
+		// Required is true if the attribute is required.
+		Required bool
+		// Description is the attribute description.
+		Description string
+		// Constraints describes the attribute validations and default
+		// value.
+		Constraints string
+	}
+
+	// refErrorData describes a method error.
+	refErrorData struct {
+		// Name is the error name.
+		Name string
+		// Type is the error type reference.
+		Type string
+		// Description is the error description.
+		Description string
+		// Status is the HTTP status code of the error response if any.
+		Status int
+	}
+)
+
+// refAnchorRegex matches the characters removed from the anchors of the
+// headings.
+var refAnchorRegex = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// ReferenceFiles returns the files of a static reference documentation of the
+// API written in Markdown. The documentation consists of an index page that
+// describes the API, its servers and its security schemes, one page per service
+// that describes the methods, their payloads, results, errors, transport
+// mappings and security requirements, and a page that describes the user types.
+// The files are generated in the "docs" directory of the gen folder and can be
+// published as is or with any static site generator that renders Markdown, no
+// OpenAPI tooling is required. Examples are computed from the design
+// deterministically.
+func ReferenceFiles(root *expr.RootExpr) []*codegen.File {
+	if root.API == nil {
+		return nil
+	}
+	var (
+		rand  = expr.NewRandom(root.API.Name)
+		types = make(map[string]expr.UserType)
+		data  = &refAPIData{
+			Title:       root.API.Title,
+			Description: root.API.Description,
+			Version:     root.API.Version,
+		}
+	)
+	if data.Title == "" {
+		data.Title = root.API.Name
+	}
+	for _, s := range root.API.Servers {
+		sd := &refServerData{Name: s.Name, Description: s.Description}
+		for _, h := range s.Hosts {
+			for _, u := range h.URIs {
+				sd.URIs = append(sd.URIs, string(u))
+			}
+		}
+		data.Servers = append(data.Servers, sd)
+	}
+	for _, s := range root.Schemes {
+		if s.Kind == expr.NoKind {
+			continue
+		}
+		data.Schemes = append(data.Schemes, &refSchemeData{
+			Name:        s.SchemeName,
+			Kind:        s.Type(),
+			Description: s.Description,
+			In:          s.In,
+			Param:       s.Name,
+			Scopes:      s.Scopes,
+		})
+	}
+	for _, svc := range root.Services {
+		data.Services = append(data.Services, refService(root, svc, types, rand))
+	}
+
+	var files []*codegen.File
+	dir := filepath.Join(codegen.Gendir, "docs")
+	data.HasTypes = len(types) > 0
+	files = append(files, refFile(filepath.Join(dir, "index.md"), "reference-index", refIndexT, data))
+	for _, sd := range data.Services {
+		files = append(files, refFile(filepath.Join(dir, sd.Page), "reference-service", refServiceT, sd))
+	}
+	if len(types) > 0 {
+		names := make([]string, 0, len(types))
+		for n := range types {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		tds := make([]*refTypeData, len(names))
+		for i, n := range names {
+			tds[i] = refType(types[n].Attribute(), "", rand)
+			tds[i].Name = n
+		}
+		files = append(files, refFile(filepath.Join(dir, "types.md"), "reference-types", refTypesT, tds))
+	}
+	return files
+}
+
+// refFile returns the file rendered by executing the given template with data.
+func refFile(path, name, source string, data interface{}) *codegen.File {
+	section := &codegen.SectionTemplate{
+		Name:    name,
+		Source:  source,
+		Data:    data,
+		FuncMap: template.FuncMap{"cell": refCell, "join": strings.Join},
+	}
+	return &codegen.File{Path: path, SectionTemplates: []*codegen.SectionTemplate{section}}
+}
+
+// refService builds the data used to render the page of the given service and
+// records the user types used by its methods in types.
+func refService(root *expr.RootExpr, svc *expr.ServiceExpr, types map[string]expr.UserType, rand *expr.Random) *refServiceData {
+	var (
+		hsvc *expr.HTTPServiceExpr
+		gsvc *expr.GRPCServiceExpr
+	)
+	if root.API.HTTP != nil {
+		hsvc = root.API.HTTP.Service(svc.Name)
+	}
+	if root.API.GRPC != nil {
+		gsvc = root.API.GRPC.Service(svc.Name)
+	}
+	sd := &refServiceData{
+		Name:        svc.Name,
+		Description: svc.Description,
+		Page:        codegen.SnakeCase(svc.Name) + ".md",
+	}
+	for _, m := range svc.Methods {
+		md := &refMethodData{
+			Name:        m.Name,
+			Anchor:      refAnchor(m.Name),
+			Description: m.Description,
+		}
+		switch m.Stream {
+		case expr.ClientStreamKind:
+			md.Stream = "client to server"
+		case expr.ServerStreamKind:
+			md.Stream = "server to client"
+		case expr.BidirectionalStreamKind:
+			md.Stream = "bidirectional"
+		}
+		if m.Payload != nil && m.Payload.Type != expr.Empty {
+			md.Payload = refType(m.Payload, "types.md", rand)
+		}
+		if m.StreamingPayload != nil && m.StreamingPayload.Type != expr.Empty {
+			md.StreamingPayload = refType(m.StreamingPayload, "types.md", rand)
+		}
+		if m.Result != nil && m.Result.Type != expr.Empty {
+			md.Result = refType(m.Result, "types.md", rand)
+		}
+		var hep *expr.HTTPEndpointExpr
+		if hsvc != nil {
+			hep = hsvc.Endpoint(m.Name)
+		}
+		for _, e := range m.Errors {
+			att := refErrorAttribute(root, e)
+			ed := &refErrorData{
+				Name:        e.Name,
+				Type:        refTypeRef(att.Type, "types.md"),
+				Description: att.Description,
+			}
+			if hep != nil {
+				for _, he := range hep.HTTPErrors {
+					if he.Name == e.Name {
+						ed.Status = he.Response.StatusCode
+						break
+					}
+				}
+			}
+			md.Errors = append(md.Errors, ed)
+		}
+		if hep != nil {
+			for _, r := range hep.Routes {
+				for _, p := range r.FullPaths() {
+					md.Routes = append(md.Routes, r.Method+" "+p)
+				}
+			}
+			for _, r := range hep.Responses {
+				md.Statuses = append(md.Statuses, r.StatusCode)
+			}
+		}
+		if gsvc != nil && gsvc.Endpoint(m.Name) != nil {
+			md.GRPC = fmt.Sprintf("/%s.%s/%s",
+				codegen.SnakeCase(codegen.Goify(svc.Name, false)),
+				codegen.Goify(svc.Name, true),
+				codegen.Goify(m.Name, true))
+		}
+		for _, r := range m.Requirements {
+			var schemes []string
+			for _, s := range r.Schemes {
+				schemes = append(schemes, "`"+s.SchemeName+"`")
+			}
+			req := strings.Join(schemes, " and ")
+			if len(r.Scopes) > 0 {
+				req += " with scopes `" + strings.Join(r.Scopes, "`, `") + "`"
+			}
+			md.Requirements = append(md.Requirements, req)
+		}
+		for _, att := range []*expr.AttributeExpr{m.Payload, m.StreamingPayload, m.Result} {
+			refCollectTypes(att, types)
+		}
+		for _, e := range m.Errors {
+			refCollectTypes(refErrorAttribute(root, e), types)
+		}
+		sd.Methods = append(sd.Methods, md)
+	}
+	return sd
+}
+
+// refErrorAttribute returns the attribute that describes the error e. It
+// unwraps the user type created by the expr package for the errors that are
+// not defined with a user type.
+func refErrorAttribute(root *expr.RootExpr, e *expr.ErrorExpr) *expr.AttributeExpr {
+	if ut, ok := e.Type.(*expr.UserTypeExpr); ok && ut.TypeName == e.Name && root.UserType(e.Name) == nil {
+		return ut.AttributeExpr
+	}
+	return e.AttributeExpr
+}
+
+// refCollectTypes records the user types used by att in types.
+func refCollectTypes(att *expr.AttributeExpr, types map[string]expr.UserType) {
+	if att == nil {
+		return
+	}
+	codegen.Walk(att, func(a *expr.AttributeExpr) error {
+		if ut, ok := a.Type.(expr.UserType); ok && ut != expr.Empty {
+			types[ut.Name()] = ut
+		}
+		return nil
+	})
+}
+
+// refType describes the type of att, page is the name of the page that
+// describes the user types.
+func refType(att *expr.AttributeExpr, page string, rand *expr.Random) *refTypeData {
+	td := &refTypeData{
+		Type:        refTypeRef(att.Type, page),
+		Description: att.Description,
+		Example:     refExample(att.Example(rand)),
+	}
+	if ut, ok := att.Type.(expr.UserType); ok {
+		att = ut.Attribute()
+	}
+	if obj := expr.AsObject(att.Type); obj != nil {
+		td.Fields = refFields(obj, att, "", page)
+	} else {
+		td.Constraints = refConstraints(att)
+	}
+	return td
+}
+
+// refFields describes the attributes of the object obj defined by parent, the
+// attributes of inline objects are listed with their names prefixed with the
+// name of the parent attribute.
+func refFields(obj *expr.Object, parent *expr.AttributeExpr, prefix, page string) []*refFieldData {
+	var fields []*refFieldData
+	for _, nat := range *obj {
+		fd := &refFieldData{
+			Name:        prefix + nat.Name,
+			Type:        refTypeRef(nat.Attribute.Type, page),
+			Required:    parent.IsRequired(nat.Name),
+			Description: nat.Attribute.Description,
+			Constraints: refConstraints(nat.Attribute),
+		}
+		if parent.HasDefaultValue(nat.Name) {
+			c := fmt.Sprintf("default: `%s`", refJSON(nat.Attribute.DefaultValue))
+			if fd.Constraints != "" {
+				c = fd.Constraints + ", " + c
+			}
+			fd.Constraints = c
+		}
+		fields = append(fields, fd)
+		if o, ok := nat.Attribute.Type.(*expr.Object); ok {
+			fields = append(fields, refFields(o, nat.Attribute, fd.Name+".", page)...)
+		}
+	}
+	return fields
+}
+
+// refTypeRef returns the reference to dt, user types are referenced with a
+// link to their description in the given page.
+func refTypeRef(dt expr.DataType, page string) string {
+	switch actual := dt.(type) {
+	case expr.UserType:
+		return fmt.Sprintf("[%s](%s#%s)", actual.Name(), page, refAnchor(actual.Name()))
+	case *expr.Array:
+		return "array<" + refTypeRef(actual.ElemType.Type, page) + ">"
+	case *expr.Map:
+		return "map<" + refTypeRef(actual.KeyType.Type, page) + ", " + refTypeRef(actual.ElemType.Type, page) + ">"
+	default:
+		return dt.Name()
+	}
+}
+
+// refConstraints describes the validations of att.
+func refConstraints(att *expr.AttributeExpr) string {
+	v := att.Validation
+	if v == nil {
+		return ""
+	}
+	var cs []string
+	if len(v.Values) > 0 {
+		vals := make([]string, len(v.Values))
+		for i, val := range v.Values {
+			vals[i] = "`" + refJSON(val) + "`"
+		}
+		cs = append(cs, "one of "+strings.Join(vals, ", "))
+	}
+	if v.Format != "" {
+		cs = append(cs, "format: "+string(v.Format))
+	}
+	if v.Pattern != "" {
+		cs = append(cs, "pattern: `"+v.Pattern+"`")
+	}
+	if v.Minimum != nil {
+		cs = append(cs, fmt.Sprintf("minimum: %v", *v.Minimum))
+	}
+	if v.Maximum != nil {
+		cs = append(cs, fmt.Sprintf("maximum: %v", *v.Maximum))
+	}
+	if v.MinLength != nil {
+		cs = append(cs, fmt.Sprintf("min length: %d", *v.MinLength))
+	}
+	if v.MaxLength != nil {
+		cs = append(cs, fmt.Sprintf("max length: %d", *v.MaxLength))
+	}
+	return strings.Join(cs, ", ")
+}
+
+// refExample returns the indented JSON representation of the example v.
+func refExample(v interface{}) string {
+	b, err := json.MarshalIndent(refStringMaps(v), "", "  ")
+	if err != nil {
+		panic("reference: " + err.Error()) // bug
+	}
+	return string(b)
+}
+
+// refJSON returns the JSON representation of v.
+func refJSON(v interface{}) string {
+	b, err := json.Marshal(refStringMaps(v))
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// refStringMaps converts the map[interface{}]interface{} values produced by the
+// example generator to map[string]interface{} so they can be serialized to
+// JSON.
+func refStringMaps(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			m[fmt.Sprintf("%v", k)] = refStringMaps(v)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			m[k] = refStringMaps(v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(actual))
+		for i, v := range actual {
+			s[i] = refStringMaps(v)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// refAnchor returns the anchor of the Markdown heading with the given text.
+func refAnchor(heading string) string {
+	return refAnchorRegex.ReplaceAllString(strings.ReplaceAll(strings.ToLower(heading), " ", "-"), "")
+}
+
+// refCell escapes s so it can be written in a Markdown table cell.
+func refCell(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// input: refAPIData
+const refIndexT = `# {{ .Title }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- if .Version }}
+
+Version: {{ .Version }}
+{{- end }}
+{{- if .Servers }}
+
+## Servers
+
+| Server | Description | URIs |
+| --- | --- | --- |
+{{- range .Servers }}
+| {{ .Name }} | {{ cell .Description }} | {{ join .URIs ", " }} |
+{{- end }}
+{{- end }}
+
+## Services
+
+| Service | Description |
+| --- | --- |
+{{- range .Services }}
+| [{{ .Name }}]({{ .Page }}) | {{ cell .Description }} |
+{{- end }}
+{{- if .HasTypes }}
+
+See [Types](types.md) for the description of the types used by the services.
+{{- end }}
+{{- if .Schemes }}
+
+## Security
+{{- range .Schemes }}
+
+### {{ .Name }}
+
+Kind: {{ .Kind }}
+{{- if .In }}
+
+Credentials: {{ .In }} ` + "`{{ .Param }}`" + `
+{{- end }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- if .Scopes }}
+
+| Scope | Description |
+| --- | --- |
+{{- range .Scopes }}
+| ` + "`{{ .Name }}`" + ` | {{ cell .Description }} |
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+// input: refServiceData
+const refServiceT = `# {{ .Name }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+
+[Back to index](index.md)
+
+## Methods
+{{ range .Methods }}
+- [{{ .Name }}](#{{ .Anchor }})
+{{- end }}
+{{- range .Methods }}
+
+## {{ .Name }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- if .Stream }}
+
+Streaming: {{ .Stream }}
+{{- end }}
+{{- if .Routes }}
+
+HTTP:
+{{ range .Routes }}
+- ` + "`{{ . }}`" + `
+{{- end }}
+{{- if .Statuses }}
+
+Success status codes: {{ range $i, $s := .Statuses }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}
+{{- end }}
+{{- end }}
+{{- if .GRPC }}
+
+gRPC: ` + "`{{ .GRPC }}`" + `
+{{- end }}
+{{- if .Requirements }}
+
+Security:
+{{ range .Requirements }}
+- {{ . }}
+{{- end }}
+{{- end }}
+{{- with .Payload }}
+
+### Payload
+{{- template "type" . }}
+{{- end }}
+{{- with .StreamingPayload }}
+
+### Streaming Payload
+{{- template "type" . }}
+{{- end }}
+{{- with .Result }}
+
+### Result
+{{- template "type" . }}
+{{- end }}
+{{- if .Errors }}
+
+### Errors
+
+| Error | Type | HTTP status | Description |
+| --- | --- | --- | --- |
+{{- range .Errors }}
+| {{ .Name }} | {{ .Type }} | {{ if .Status }}{{ .Status }}{{ end }} | {{ cell .Description }} |
+{{- end }}
+{{- end }}
+{{- end }}
+` + refTypePartialT
+
+// input: []*refTypeData
+const refTypesT = `# Types
+
+[Back to index](index.md)
+{{- range . }}
+
+## {{ .Name }}
+{{- template "type" . }}
+{{- end }}
+` + refTypePartialT
+
+// refTypePartialT renders the description of a type.
+const refTypePartialT = `{{ define "type" }}
+
+Type: {{ .Type }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- if .Constraints }}
+
+Constraints: {{ .Constraints }}
+{{- end }}
+{{- if .Fields }}
+
+| Field | Type | Required | Description | Constraints |
+| --- | --- | --- | --- | --- |
+{{- range .Fields }}
+| {{ .Name }} | {{ .Type }} | {{ if .Required }}yes{{ else }}no{{ end }} | {{ cell .Description }} | {{ .Constraints }} |
+{{- end }}
+{{- end }}
+
+Example:
+
+` + "```json" + `
+{{ .Example }}
+` + "```" + `
+{{- end }}`
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestReferenceFiles(t *testing.T) {
+	codegen.RunDSL(t, testdata.ReferenceDSL)
+	files := ReferenceFiles(expr.Root)
+	expected := []struct {
+		Path    string
+		Content string
+	}{
+		{"gen/docs/index.md", testdata.ReferenceIndexFile},
+		{"gen/docs/storage.md", testdata.ReferenceServiceFile},
+		{"gen/docs/types.md", testdata.ReferenceTypesFile},
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(files), len(expected))
+	}
+	for i, f := range files {
+		if f.Path != expected[i].Path {
+			t.Errorf("got path %q, expected %q", f.Path, expected[i].Path)
+		}
+		buf := new(bytes.Buffer)
+		for _, s := range f.SectionTemplates {
+			if err := s.Write(buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if actual := buf.String(); actual != expected[i].Content {
+			t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", f.Path, actual, codegen.Diff(t, actual, expected[i].Content))
+		}
+	}
+}
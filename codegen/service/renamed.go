@@ -0,0 +1,187 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// renamedData contains the data needed to render the compatibility
+	// shims of a service.
+	renamedData struct {
+		// Methods lists the client methods named after the previous
+		// names of the renamed methods.
+		Methods []*renamedMethodData
+		// Aliases lists the type aliases named after the previous names
+		// of the renamed types.
+		Aliases []*typeAliasData
+	}
+
+	// renamedMethodData describes a client method named after a previous
+	// name of a method.
+	renamedMethodData struct {
+		*endpointMethodData
+		// OldName is the previous name of the method.
+		OldName string
+		// OldVarName is the Go name of the client method.
+		OldVarName string
+	}
+
+	// typeAliasData describes a type alias named after a previous name of
+	// a type.
+	typeAliasData struct {
+		// Name is the Go name of the alias.
+		Name string
+		// OldName is the previous design name of the type.
+		OldName string
+		// Target is the Go name of the aliased type.
+		Target string
+	}
+)
+
+// RenamedFile returns the file defining the compatibility shims of the methods
+// and types of the given service renamed with the RenamedFrom DSL: the client
+// methods and the type aliases named after the previous names. The shims are
+// deprecated so that linters flag the code that still uses them. RenamedFile
+// returns nil if the service does not rename any method or type.
+func RenamedFile(service *expr.ServiceExpr) *codegen.File {
+	if !hasRenames(service) {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	eps := endpointData(service)
+	data := &renamedData{}
+	taken := make(map[string]struct{})
+	for _, m := range svc.Methods {
+		taken[m.VarName] = struct{}{}
+		taken[m.Payload] = struct{}{}
+		taken[m.Result] = struct{}{}
+	}
+	for _, ut := range svc.userTypes {
+		taken[ut.VarName] = struct{}{}
+	}
+	addAlias := func(old, target string) {
+		name := codegen.Goify(old, true)
+		if _, ok := taken[name]; ok || target == "" {
+			return
+		}
+		taken[name] = struct{}{}
+		data.Aliases = append(data.Aliases, &typeAliasData{Name: name, OldName: old, Target: target})
+	}
+	for i, m := range service.Methods {
+		md := eps.Methods[i]
+		for _, old := range m.RenamedFrom() {
+			data.Methods = append(data.Methods, &renamedMethodData{
+				endpointMethodData: md,
+				OldName:            old,
+				OldVarName:         codegen.Goify(old, true),
+			})
+			if md.Payload == md.VarName+"Payload" {
+				addAlias(old+"_payload", md.Payload)
+			}
+			if md.Result == md.VarName+"Result" {
+				addAlias(old+"_result", md.Result)
+			}
+		}
+	}
+	seen := make(map[string]struct{})
+	addType := func(att *expr.AttributeExpr, target string) {
+		ut, ok := att.Type.(expr.UserType)
+		if !ok || codegen.UserTypeLocation(ut) != nil {
+			return
+		}
+		if _, ok := seen[ut.ID()]; ok {
+			return
+		}
+		seen[ut.ID()] = struct{}{}
+		for _, old := range ut.Attribute().RenamedFrom() {
+			addAlias(old, target)
+		}
+	}
+	for i, m := range service.Methods {
+		addType(m.Payload, svc.Methods[i].Payload)
+		addType(m.Result, svc.Methods[i].Result)
+	}
+	for _, ut := range svc.userTypes {
+		addType(&expr.AttributeExpr{Type: ut.Type}, ut.VarName)
+	}
+	if len(data.Methods) == 0 && len(data.Aliases) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "renamed.go")
+	var imports []*codegen.ImportSpec
+	if len(data.Methods) > 0 {
+		imports = append(imports, &codegen.ImportSpec{Path: "context"})
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" compatibility shims", svc.PkgName, imports),
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "renamed-client-method",
+			Source: renamedClientMethodT,
+			Data:   m,
+		})
+	}
+	if len(data.Aliases) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "renamed-type-aliases",
+			Source: renamedTypeAliasesT,
+			Data:   data.Aliases,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// hasRenames returns true if a method of the given service or a type used by
+// its methods was renamed with the RenamedFrom DSL.
+func hasRenames(service *expr.ServiceExpr) bool {
+	found := false
+	seen := make(map[string]struct{})
+	walk := func(att *expr.AttributeExpr) {
+		if att == nil {
+			return
+		}
+		_ = codegen.Walk(att, func(a *expr.AttributeExpr) error {
+			if ut, ok := a.Type.(expr.UserType); ok {
+				if _, ok := seen[ut.ID()]; ok {
+					return nil
+				}
+				seen[ut.ID()] = struct{}{}
+				if len(ut.Attribute().RenamedFrom()) > 0 {
+					found = true
+				}
+			}
+			return nil
+		})
+	}
+	for _, m := range service.Methods {
+		if len(m.RenamedFrom()) > 0 {
+			return true
+		}
+		walk(m.Payload)
+		walk(m.StreamingPayload)
+		walk(m.Result)
+	}
+	return found
+}
+
+// input: *renamedMethodData
+const renamedClientMethodT = `
+{{ printf "%s calls the %q endpoint of the %q service." .OldVarName .Name .ServiceName | comment }}
+//
+{{ printf "Deprecated: the method was renamed, use %s instead." .VarName | comment }}
+func (c *{{ .ClientVarName }}) {{ .OldVarName }}(ctx context.Context, {{ if .PayloadRef }}p {{ .PayloadRef }}{{ end }}) ({{ if .ClientStream }}res {{ .ClientStream.Interface }}, {{ else if .ResultRef }}res {{ .ResultRef }}, {{ end }}err error) {
+	return c.{{ .VarName }}(ctx{{ if .PayloadRef }}, p{{ end }})
+}
+`
+
+// input: []*typeAliasData
+const renamedTypeAliasesT = `{{ range . }}
+{{ printf "%s is the previous name of %s." .Name .Target | comment }}
+//
+{{ printf "Deprecated: the type was renamed, use %s instead." .Target | comment }}
+type {{ .Name }} = {{ .Target }}
+{{ end }}`
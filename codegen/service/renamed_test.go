@@ -0,0 +1,42 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestRenamed(t *testing.T) {
+	codegen.RunDSL(t, testdata.RenamedMethodsDSL)
+	f := RenamedFile(expr.Root.Services[0])
+	if f == nil {
+		t.Fatal("got no file, expected one")
+	}
+	buf := new(bytes.Buffer)
+	for _, s := range f.SectionTemplates[1:] {
+		if err := s.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Println(buf.String())
+		t.Fatal(err)
+	}
+	code := string(bs)
+	if code != testdata.RenamedMethodsCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.RenamedMethodsCode))
+	}
+}
+
+func TestRenamedNoRename(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleMethodDSL)
+	if f := RenamedFile(expr.Root.Services[0]); f != nil {
+		t.Errorf("got file %q, expected none", f.Path)
+	}
+}
@@ -18,10 +18,17 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		svc.PkgName,
 		[]*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "strconv"},
+			{Path: "time"},
 			codegen.GoaImport(""),
 			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
 		})
+	for _, d := range svc.Dependencies {
+		if d.PkgPath != "" {
+			codegen.AddImport(header, &codegen.ImportSpec{Path: d.PkgPath})
+		}
+	}
 	def := &codegen.SectionTemplate{
 		Name:   "service",
 		Source: serviceT,
@@ -76,6 +83,38 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		}
 	}
 
+	for _, e := range svc.enumTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "service-enum",
+			Source: enumT,
+			Data:   e,
+		})
+	}
+
+	for _, p := range svc.patchTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "service-patch-apply",
+			Source: patchApplyT,
+			Data:   p,
+		})
+	}
+
+	for _, p := range svc.pruneTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "service-prune",
+			Source: pruneT,
+			Data:   p,
+		})
+	}
+
+	for _, a := range svc.anonymizeTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "service-anonymize",
+			Source: anonymizeT,
+			Data:   a,
+		})
+	}
+
 	var errorTypes []*UserTypeData
 	for _, et := range svc.errorTypes {
 		if et.Type == expr.ErrorResult {
@@ -99,6 +138,13 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			Data:    et,
 		})
 	}
+	if len(svc.errorInits) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "error-sentinels",
+			Source: errorSentinelsT,
+			Data:   svc.errorInits,
+		})
+	}
 	for _, er := range svc.errorInits {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "error-init-func",
@@ -120,6 +166,12 @@ func File(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			Data:   t.Init,
 		})
 	}
+	if svc.MemoizeViews && len(svc.projectedTypes) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "view-memo",
+			Source: viewMemoT,
+		})
+	}
 	var projh []*codegen.TransformFunctionData
 	for _, t := range svc.projectedTypes {
 		for _, i := range t.TypeInits {
@@ -246,6 +298,43 @@ const ServiceName = {{ printf "%q" .Name }}
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
 var MethodNames = [{{ len .Methods }}]string{ {{ range .Methods }}{{ printf "%q" .Name }}, {{ end }} }
+{{- if .Permissions }}
+
+{{ printf "%s is a permission the authenticated principal must be granted to call the service methods that require it, see security.ValidatePermissions." .PermissionType | comment }}
+type {{ .PermissionType }} string
+
+// Permissions required by the service methods as defined in the design.
+const (
+{{- range .Permissions }}
+	{{ printf "%s is the %q permission." .VarName .Name | comment }}
+	{{ .VarName }} {{ $.PermissionType }} = {{ printf "%q" .Name }}
+{{- end }}
+)
+{{- end }}
+{{- if .Dependencies }}
+
+{{ printf "%s creates and finalizes the per-request dependencies of the service methods. The service implementation must implement it." .DependenciesType | comment }}
+type {{ .DependenciesType }} interface {
+{{- range .Dependencies }}
+	{{ printf "New%s creates the %q dependency of a request." .VarName .Name | comment }}
+	New{{ .VarName }}(ctx context.Context) ({{ .TypeRef }}, error)
+	{{ printf "Finalize%s finalizes the %q dependency of a request once the method returns. err is the error returned by the method, nil if the method succeeded." .VarName .Name | comment }}
+	Finalize{{ .VarName }}(ctx context.Context, {{ .ArgName }} {{ .TypeRef }}, err error) error
+{{- end }}
+}
+{{- range .Dependencies }}
+
+{{ printf "%s returns the %q dependency of the request, see %s." .Accessor .Name $.DependenciesType | comment }}
+func {{ .Accessor }}(ctx context.Context) {{ .TypeRef }} {
+	{{ .ArgName }}, _ := ctx.Value(dependencyKey({{ printf "%q" .Name }})).({{ .TypeRef }})
+	return {{ .ArgName }}
+}
+{{- end }}
+
+// dependencyKey is the type of the keys used to store the dependencies in the
+// request contexts.
+type dependencyKey string
+{{- end }}
 {{- range .Methods }}
 	{{- if .ServerStream }}
 		{{ template "stream_interface" (streamInterfaceFor "server" . .ServerStream) }}
@@ -303,6 +392,16 @@ func (e {{ .Ref }}) ErrorName() string {
 }
 `
 
+// input: []*ErrorInitData
+const errorSentinelsT = `
+var (
+{{- range . }}
+	{{ printf "%s is the sentinel of the %q errors. Use errors.Is to check whether an error returned by a method or by a client is a %q error." .SentinelName .ErrName .ErrName | comment }}
+	{{ .SentinelName }} = &{{ .TypeName }}{Name: {{ printf "%q" .ErrName }}}
+{{- end }}
+)
+`
+
 // input: map[string]{"Type": TypeData, "Error": ErrorData}
 const errorInitT = `{{ printf "%s builds a %s from an error." .Name .TypeName |  comment }}
 func {{ .Name }}(err error) {{ .TypeRef }} {
@@ -319,7 +418,169 @@ func {{ .Name }}(err error) {{ .TypeRef }} {
 	{{- if .Fault }}
 		Fault: true,
 	{{- end }}
+	{{- if .RetryAfter }}
+		RetryAfter: {{ .RetryAfter }} * time.Second,
+	{{- end }}
+	}
+}
+`
+
+// input: PatchData
+const patchApplyT = `// Apply merges the JSON merge patch p into v: the fields set to null in p are
+// cleared and the fields set to a value replace the values of v.
+func (p {{ .Ref }}) Apply(v {{ .PatchedRef }}) {
+{{- range .Fields }}
+	{{- if .Optional }}
+	if p.{{ .Name }}.Set {
+		{{- if .Pointer }}
+		v.{{ .Name }} = p.{{ .Name }}.Value
+		{{- else }}
+		if p.{{ .Name }}.Value != nil {
+			v.{{ .Name }} = *p.{{ .Name }}.Value
+		} else {
+			v.{{ .Name }} = {{ .Zero }}
+		}
+		{{- end }}
+	}
+	{{- else }}
+	if p.{{ .Name }} != nil {
+		v.{{ .Name }} = {{ if .Deref }}*{{ end }}p.{{ .Name }}
+	}
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: EnumData
+const enumT = `// Values of {{ .VarName }}.
+const (
+{{- range .Values }}
+	{{ .Name }} {{ $.VarName }} = {{ .Value }}
+{{- end }}
+)
+
+// String returns the string representation of v.
+func (v {{ .VarName }}) String() string {
+	{{- if .IsString }}
+	return string(v)
+	{{- else }}
+	return strconv.FormatInt(int64(v), 10)
+	{{- end }}
+}
+
+// Validate returns an error if v is not one of the values of {{ .VarName }}.
+func (v {{ .VarName }}) Validate() error {
+	switch v {
+	case {{ range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ .Name }}{{ end }}:
+		return nil
+	}
+	return goa.InvalidEnumValueError({{ printf "%q" .Name }}, v, []interface{}{ {{- range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ .Value }}{{ end }} })
+}
+
+// Parse{{ .VarName }} converts s to a {{ .VarName }} value and validates it.
+func Parse{{ .VarName }}(s string) ({{ .VarName }}, error) {
+	{{- if .IsString }}
+	v := {{ .VarName }}(s)
+	{{- else }}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, s, "integer")
+	}
+	v := {{ .VarName }}(n)
+	{{- end }}
+	if err := v.Validate(); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+`
+
+// input: PruneData
+const pruneT = `// Prune clears the fields of r that are not selected by mask. Required fields
+// are never cleared. Prune does nothing if mask is empty.
+func (r {{ .Ref }}) Prune(mask goa.FieldMask) {
+	if r == nil || len(mask) == 0 {
+		return
 	}
+{{- range .Fields }}
+	{{- if .Clear }}
+	if !mask.Includes({{ printf "%q" .Name }}) {
+		r.{{ .FieldName }} = nil
+	}{{ if or .Nested .Elem }} else {
+		{{- template "prune_field" . }}
+	}{{ end }}
+	{{- else }}
+	{{- template "prune_field" . }}
+	{{- end }}
+{{- end }}
+}
+
+{{- define "prune_field" }}
+	{{- if .Nested }}
+	r.{{ .FieldName }}.Prune(mask.Sub({{ printf "%q" .Name }}))
+	{{- else }}
+	for _, v := range r.{{ .FieldName }} {
+		v.Prune(mask.Sub({{ printf "%q" .Name }}))
+	}
+	{{- end }}
+{{- end }}
+`
+
+// input: AnonymizeData
+const anonymizeT = `// Anonymize returns a copy of t where the sensitive fields are masked or
+// hashed. Use it to export the value to analytics systems or to build log and
+// audit events.
+func (t {{ .Ref }}) Anonymize() {{ .Ref }} {
+	if t == nil {
+		return nil
+	}
+	res := *t
+{{- range .Fields }}
+	{{- if .Func }}
+		{{- if .Optional }}
+	if t.{{ .FieldName }}.Set && t.{{ .FieldName }}.Value != nil {
+		v := {{ .Func }}(*t.{{ .FieldName }}.Value)
+		res.{{ .FieldName }}.Value = &v
+	}
+		{{- else if .Pointer }}
+	if t.{{ .FieldName }} != nil {
+		v := {{ .Func }}(*t.{{ .FieldName }})
+		res.{{ .FieldName }} = &v
+	}
+		{{- else }}
+	res.{{ .FieldName }} = {{ .Func }}(t.{{ .FieldName }})
+		{{- end }}
+	{{- else if .Nested }}
+	res.{{ .FieldName }} = t.{{ .FieldName }}.Anonymize()
+	{{- else if .Array }}
+	if t.{{ .FieldName }} != nil {
+		res.{{ .FieldName }} = make({{ .TypeRef }}, len(t.{{ .FieldName }}))
+		for i, v := range t.{{ .FieldName }} {
+			res.{{ .FieldName }}[i] = v.Anonymize()
+		}
+	}
+	{{- else if .Map }}
+	if t.{{ .FieldName }} != nil {
+		res.{{ .FieldName }} = make({{ .TypeRef }}, len(t.{{ .FieldName }}))
+		for k, v := range t.{{ .FieldName }} {
+			res.{{ .FieldName }}[k] = v.Anonymize()
+		}
+	}
+	{{- end }}
+{{- end }}
+	return &res
+}
+`
+
+// input: nil
+const viewMemoT = `// viewMemo records the projections computed while rendering a result so that
+// values referenced multiple times are only projected once per view.
+type viewMemo map[viewMemoKey]interface{}
+
+// viewMemoKey identifies the projection of a value using a view.
+type viewMemoKey struct {
+	res  interface{}
+	view string
 }
 `
 
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
+	"unicode"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -54,10 +56,23 @@ type (
 		Methods []*MethodData
 		// Schemes is the list of security schemes required by the service methods.
 		Schemes SchemesData
+		// Permissions lists the permissions required by the service methods.
+		Permissions []*PermissionData
+		// PermissionType is the name of the permission constants type.
+		PermissionType string
+		// Dependencies lists the per-request dependencies of the service
+		// methods.
+		Dependencies []*DependencyData
+		// DependenciesType is the name of the interface implemented by
+		// the service to create and finalize the dependencies.
+		DependenciesType string
 		// Scope initialized with all the service types.
 		Scope *codegen.NameScope
 		// ViewScope initialized with all the viewed types.
 		ViewScope *codegen.NameScope
+		// MemoizeViews is true if the projections of the result types are
+		// memoized.
+		MemoizeViews bool
 
 		// userTypes lists the type definitions that the service depends on.
 		userTypes []*UserTypeData
@@ -71,6 +86,17 @@ type (
 		projectedTypes []*ProjectedTypeData
 		// viewedResultTypes lists all the viewed method result types.
 		viewedResultTypes []*ViewedResultTypeData
+		// patchTypes lists the JSON merge patch types used by the service
+		// methods payloads.
+		patchTypes []*PatchData
+		// pruneTypes lists the result types pruned by the methods that use
+		// field masks.
+		pruneTypes []*PruneData
+		// anonymizeTypes lists the types that contain sensitive attributes
+		// and define an Anonymize method.
+		anonymizeTypes []*AnonymizeData
+		// enumTypes lists the user types that use EnumConstants.
+		enumTypes []*EnumData
 	}
 
 	// ErrorInitData describes an error returned by a service method of type
@@ -78,6 +104,8 @@ type (
 	ErrorInitData struct {
 		// Name is the name of the init function.
 		Name string
+		// SentinelName is the name of the sentinel error variable.
+		SentinelName string
 		// Description is the error description.
 		Description string
 		// ErrName is the name of the error.
@@ -92,6 +120,9 @@ type (
 		Timeout bool
 		// Fault indicates whether the error is server-side fault.
 		Fault bool
+		// RetryAfter is the retry-after delay of the error in seconds,
+		// zero if there is none.
+		RetryAfter int64
 	}
 
 	// MethodData describes a single service method.
@@ -151,6 +182,21 @@ type (
 		ClientStream *StreamData
 		// StreamKind is the kind of the stream (payload or result or bidirectional).
 		StreamKind expr.StreamKind
+		// FieldMask is true if the method result is pruned according to the
+		// field mask set by the client.
+		FieldMask bool
+		// LogFields lists the payload fields added to the request log
+		// context by the endpoint.
+		LogFields []*LogFieldData
+		// Permissions lists the names of the constants of the permissions
+		// checked by the endpoint.
+		Permissions []string
+		// Dependencies lists the per-request dependencies created by the
+		// endpoint in order.
+		Dependencies []*DependencyData
+		// Timeout is the Go expression of the maximum duration of the
+		// method requests, empty if the method does not define a timeout.
+		Timeout string
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -265,6 +311,153 @@ type (
 		In string
 	}
 
+	// PatchData contains the data needed to render the Apply method of a JSON
+	// merge patch type created with PatchOf.
+	PatchData struct {
+		// Ref is the reference to the patch type.
+		Ref string
+		// PatchedRef is the reference to the patched type.
+		PatchedRef string
+		// Fields lists the patched fields.
+		Fields []*PatchFieldData
+	}
+
+	// PatchFieldData describes a field of a JSON merge patch type.
+	PatchFieldData struct {
+		// Name is the name of the field in both the patch and patched types.
+		Name string
+		// Optional is true if the patch field is a goa.Optional wrapper.
+		Optional bool
+		// Pointer is true if the patched field is a pointer.
+		Pointer bool
+		// Deref is true if the patch field is a pointer and the patched field
+		// is not.
+		Deref bool
+		// Zero is the value assigned to the patched field when the patch sets
+		// it to null and the patched field is not a pointer: the default
+		// value if any, the zero value otherwise.
+		Zero string
+	}
+
+	// LogFieldData describes a payload field that uses LogField.
+	LogFieldData struct {
+		// Key is the log key.
+		Key string
+		// FieldName is the name of the payload struct field.
+		FieldName string
+		// Pointer is true if the payload field is a pointer.
+		Pointer bool
+	}
+
+	// PruneData contains the data needed to render the Prune method of a type
+	// used by the result of a method that uses FieldMask.
+	PruneData struct {
+		// Ref is the reference to the pruned type.
+		Ref string
+		// Fields lists the fields that may be cleared or pruned.
+		Fields []*PruneFieldData
+	}
+
+	// AnonymizeData contains the data needed to render the Anonymize method
+	// of a type that contains sensitive attributes.
+	AnonymizeData struct {
+		// Ref is the reference to the anonymized type.
+		Ref string
+		// Fields lists the fields that are anonymized.
+		Fields []*AnonymizeFieldData
+	}
+
+	// PermissionData describes a permission defined with the Permission DSL.
+	PermissionData struct {
+		// Name is the permission name.
+		Name string
+		// VarName is the name of the permission constant.
+		VarName string
+	}
+
+	// DependencyData describes a per-request dependency defined with the
+	// Dependency DSL.
+	DependencyData struct {
+		// Name is the dependency name.
+		Name string
+		// VarName is the name used to build the names of the methods that
+		// create and finalize the dependency.
+		VarName string
+		// ArgName is the name of the variables holding the dependency.
+		ArgName string
+		// TypeRef is the Go type of the dependency.
+		TypeRef string
+		// PkgPath is the import path of the package that defines the
+		// type if any.
+		PkgPath string
+		// Accessor is the name of the function that retrieves the
+		// dependency from the request context.
+		Accessor string
+	}
+
+	// EnumData contains the data needed to render the constants and
+	// helpers of a user type that uses EnumConstants.
+	EnumData struct {
+		// Name is the type name.
+		Name string
+		// VarName is the Go type name.
+		VarName string
+		// IsString is true if the base type is a string, false if it is
+		// an integer.
+		IsString bool
+		// Values lists the enum values.
+		Values []*EnumValueData
+	}
+
+	// EnumValueData describes an enum value.
+	EnumValueData struct {
+		// Name is the name of the constant.
+		Name string
+		// Value is the Go literal of the value.
+		Value string
+	}
+
+	// PruneFieldData describes a field of a pruned type.
+	PruneFieldData struct {
+		// Name is the name of the attribute used in field masks.
+		Name string
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Clear is true if the field is cleared when not selected by the
+		// mask, false if the field is required.
+		Clear bool
+		// Nested is true if the field holds a value whose type defines a
+		// Prune method.
+		Nested bool
+		// Elem is true if the field holds an array or a map whose element
+		// type defines a Prune method.
+		Elem bool
+	}
+
+	// AnonymizeFieldData describes a field of an anonymized type.
+	AnonymizeFieldData struct {
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Func is the name of the function that anonymizes the value of a
+		// sensitive field, empty if the field is not sensitive.
+		Func string
+		// Pointer is true if the sensitive field is a pointer.
+		Pointer bool
+		// Optional is true if the sensitive field is a goa.Optional wrapper.
+		Optional bool
+		// Nested is true if the field holds a value whose type defines an
+		// Anonymize method.
+		Nested bool
+		// Array is true if the field holds an array whose element type
+		// defines an Anonymize method.
+		Array bool
+		// Map is true if the field holds a map whose element type defines
+		// an Anonymize method.
+		Map bool
+		// TypeRef is the reference to the type of the field.
+		TypeRef string
+	}
+
 	// ViewedResultTypeData contains the data used to generate a viewed result type
 	// (i.e. a method result type with more than one view). The viewed result
 	// type holds the projected type and a view based on which it creates the
@@ -466,6 +659,9 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		errorInits []*ErrorInitData
 		projTypes  []*ProjectedTypeData
 		viewedRTs  []*ViewedResultTypeData
+		patches    []*PatchData
+		prunes     []*PruneData
+		anons      []*AnonymizeData
 		seenErrors map[string]struct{}
 		seen       map[string]struct{}
 		seenProj   map[string]*ProjectedTypeData
@@ -533,12 +729,42 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 			if _, ok := m.Result.Type.(*expr.ResultTypeExpr); ok {
 				// collect projected types for the corresponding result type
 				projected := expr.DupAtt(m.Result)
-				projTypes = append(projTypes, collectProjectedTypes(projected, m.Result, viewspkg, scope, viewScope, seenProj)...)
+				projTypes = append(projTypes, collectProjectedTypes(projected, m.Result, viewspkg, scope, viewScope, seenProj, service.MemoizesViews())...)
 			}
 			for _, er := range m.Errors {
 				recordError(er)
 			}
 		}
+		seenPatches := make(map[string]struct{})
+		for _, m := range service.Methods {
+			pt := expr.PatchedType(m.Payload.Type)
+			if pt == nil {
+				continue
+			}
+			if _, ok := seenPatches[m.Payload.Type.Name()]; ok {
+				continue
+			}
+			seenPatches[m.Payload.Type.Name()] = struct{}{}
+			// the Apply method references the patched type
+			patched := &expr.AttributeExpr{Type: pt}
+			types = append(types, collectTypes(patched, scope, seen)...)
+			patches = append(patches, buildPatchData(m.Payload, patched, scope))
+		}
+		seenPrunes := make(map[string]struct{})
+		for _, m := range service.Methods {
+			if m.HasFieldMask() {
+				prunes = append(prunes, collectPruneData(m.Result, scope, seenPrunes)...)
+			}
+		}
+		seenAnons := make(map[string]struct{})
+		for _, m := range service.Methods {
+			anons = append(anons, collectAnonymizeData(m.Payload, scope, seenAnons)...)
+			anons = append(anons, collectAnonymizeData(m.StreamingPayload, scope, seenAnons)...)
+			anons = append(anons, collectAnonymizeData(m.Result, scope, seenAnons)...)
+		}
+		for _, w := range service.Webhooks {
+			types = append(types, collectTypes(w.Payload, scope, seen)...)
+		}
 	}
 
 	for _, t := range expr.Root.Types {
@@ -559,6 +785,89 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		}
 	}
 
+	var enums []*EnumData
+	{
+		seenEnums := make(map[string]struct{})
+		recordEnum := func(att *expr.AttributeExpr) {
+			if att == nil || !expr.IsEnumConstType(att.Type) {
+				return
+			}
+			ut := att.Type.(expr.UserType)
+			if codegen.UserTypeLocation(ut) != nil {
+				// generated with the type in its package
+				return
+			}
+			if _, ok := seenEnums[ut.ID()]; ok {
+				return
+			}
+			seenEnums[ut.ID()] = struct{}{}
+			enums = append(enums, buildEnumData(att, "", scope))
+		}
+		for _, m := range service.Methods {
+			recordEnum(m.Payload)
+			recordEnum(m.StreamingPayload)
+			recordEnum(m.Result)
+		}
+		for _, t := range append(types, errTypes...) {
+			recordEnum(&expr.AttributeExpr{Type: t.Type})
+		}
+	}
+
+	var (
+		perms    []*PermissionData
+		permType string
+		permVars map[string]string
+	)
+	{
+		permVars = make(map[string]string)
+		for _, m := range service.Methods {
+			for _, p := range m.Permissions() {
+				if _, ok := permVars[p]; ok {
+					continue
+				}
+				if permType == "" {
+					permType = scope.Unique("Permission")
+				}
+				pd := &PermissionData{
+					Name:    p,
+					VarName: scope.Unique("Permission" + codegen.Goify(permissionIdentifier(p), true)),
+				}
+				permVars[p] = pd.VarName
+				perms = append(perms, pd)
+			}
+		}
+	}
+
+	var (
+		deps     []*DependencyData
+		depsType string
+		depsData map[string]*DependencyData
+	)
+	{
+		depsData = make(map[string]*DependencyData)
+		for _, m := range service.Methods {
+			for _, d := range m.Dependencies() {
+				if _, ok := depsData[d.Name]; ok {
+					continue
+				}
+				if depsType == "" {
+					depsType = scope.Unique("Dependencies")
+				}
+				varName := codegen.Goify(d.Name, true)
+				dd := &DependencyData{
+					Name:     d.Name,
+					VarName:  varName,
+					ArgName:  codegen.Goify(d.Name, false),
+					TypeRef:  d.Type,
+					PkgPath:  d.PkgPath,
+					Accessor: scope.Unique(varName + "FromContext"),
+				}
+				depsData[d.Name] = dd
+				deps = append(deps, dd)
+			}
+		}
+	}
+
 	var (
 		methods []*MethodData
 		schemes SchemesData
@@ -567,13 +876,19 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		methods = make([]*MethodData, len(service.Methods))
 		for i, e := range service.Methods {
 			m := buildMethodData(e, pkgName, service, scope)
+			for _, p := range e.Permissions() {
+				m.Permissions = append(m.Permissions, permVars[p])
+			}
+			for _, d := range e.Dependencies() {
+				m.Dependencies = append(m.Dependencies, depsData[d.Name])
+			}
 			if rt, ok := e.Result.Type.(*expr.ResultTypeExpr); ok {
 				if vrt, ok := seenViewed[m.Result]; ok {
 					m.ViewedResult = vrt
 				} else {
 					projected := seenProj[rt.ID()]
 					projAtt := &expr.AttributeExpr{Type: projected.Type}
-					vrt := buildViewedResultType(e.Result, projAtt, viewspkg, scope, viewScope)
+					vrt := buildViewedResultType(e.Result, projAtt, viewspkg, scope, viewScope, service.MemoizesViews())
 					viewedRTs = append(viewedRTs, vrt)
 					seenViewed[vrt.Name] = vrt
 					m.ViewedResult = vrt
@@ -605,13 +920,22 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		ViewsPkg:          viewspkg,
 		Methods:           methods,
 		Schemes:           schemes,
+		Permissions:       perms,
+		PermissionType:    permType,
+		Dependencies:      deps,
+		DependenciesType:  depsType,
 		Scope:             scope,
 		ViewScope:         viewScope,
+		MemoizeViews:      service.MemoizesViews(),
 		errorTypes:        errTypes,
 		errorInits:        errorInits,
 		userTypes:         types,
 		projectedTypes:    projTypes,
 		viewedResultTypes: viewedRTs,
+		patchTypes:        patches,
+		pruneTypes:        prunes,
+		anonymizeTypes:    anons,
+		enumTypes:         enums,
 	}
 	d[service.Name] = data
 
@@ -632,7 +956,8 @@ func projectedTypeContext(pkg string, scope *codegen.NameScope) *codegen.Attribu
 }
 
 // collectTypes recurses through the attribute to gather all user types and
-// records them in userTypes.
+// records them in userTypes. User types generated in a shared package are not
+// recorded.
 func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*UserTypeData) {
 	if at == nil || at.Type == expr.Empty {
 		return
@@ -643,6 +968,9 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 		if _, ok := seen[dt.ID()]; ok {
 			return nil
 		}
+		if codegen.UserTypeLocation(dt) != nil {
+			return nil
+		}
 		data = append(data, &UserTypeData{
 			Name:        dt.Name(),
 			VarName:     scope.GoTypeName(at),
@@ -666,20 +994,234 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 	return
 }
 
+// buildEnumData creates the data needed to generate the constants and helpers
+// of the given user type that uses EnumConstants in the package pkg.
+func buildEnumData(at *expr.AttributeExpr, pkg string, scope *codegen.NameScope) *EnumData {
+	varName := scope.GoFullTypeName(at, pkg)
+	att := at.Type.(expr.UserType).Attribute()
+	isString := att.Type.Kind() == expr.StringKind
+	vals := make([]*EnumValueData, len(att.Validation.Values))
+	names := make(map[string]int, len(vals))
+	for i, v := range att.Validation.Values {
+		s := fmt.Sprint(v)
+		name := s
+		if strings.HasPrefix(name, "-") {
+			name = "Minus" + name[1:]
+		}
+		name = varName + codegen.Goify(name, true)
+		if n, ok := names[name]; ok {
+			names[name] = n + 1
+			name = fmt.Sprintf("%s%d", name, n+1)
+		} else {
+			names[name] = 0
+		}
+		lit := s
+		if isString {
+			lit = fmt.Sprintf("%q", v)
+		}
+		vals[i] = &EnumValueData{Name: name, Value: lit}
+	}
+	return &EnumData{
+		Name:     at.Type.Name(),
+		VarName:  varName,
+		IsString: isString,
+		Values:   vals,
+	}
+}
+
+// buildPatchData creates the data needed to generate the Apply method of the
+// JSON merge patch type patch which modifies values of type patched.
+func buildPatchData(patch, patched *expr.AttributeExpr, scope *codegen.NameScope) *PatchData {
+	var (
+		fields []*PatchFieldData
+		pt     = patch.Type.(expr.UserType).Attribute()
+		tt     = patched.Type.(expr.UserType).Attribute()
+	)
+	for _, nat := range *expr.AsObject(pt.Type) {
+		att := tt.Find(nat.Name)
+		if att == nil {
+			// attribute defined with the PatchOf DSL function
+			continue
+		}
+		field := &PatchFieldData{
+			Name:     codegen.GoifyAtt(att, nat.Name, true),
+			Optional: nat.Attribute.IsOptionalField(),
+			Pointer:  tt.IsPrimitivePointer(nat.Name, true),
+		}
+		if field.Optional {
+			switch {
+			case att.DefaultValue != nil:
+				field.Zero = fmt.Sprintf("%#v", att.DefaultValue)
+			case att.Type.Kind() == expr.BooleanKind:
+				field.Zero = "false"
+			case att.Type.Kind() == expr.StringKind:
+				field.Zero = `""`
+			default:
+				field.Zero = "0"
+			}
+		} else {
+			field.Deref = pt.IsPrimitivePointer(nat.Name, true) && !field.Pointer
+		}
+		fields = append(fields, field)
+	}
+	return &PatchData{
+		Ref:        scope.GoTypeRef(patch),
+		PatchedRef: scope.GoTypeRef(patched),
+		Fields:     fields,
+	}
+}
+
+// collectPruneData returns the data needed to generate the Prune methods of
+// the user type at and of the user types it contains.
+func collectPruneData(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*PruneData) {
+	ut, ok := at.Type.(expr.UserType)
+	if !ok || !expr.IsObject(ut) {
+		return nil
+	}
+	if _, ok := seen[ut.ID()]; ok {
+		return nil
+	}
+	seen[ut.ID()] = struct{}{}
+	var (
+		fields []*PruneFieldData
+		nested []*PruneData
+		att    = ut.Attribute()
+	)
+	for _, nat := range *expr.AsObject(ut) {
+		field := &PruneFieldData{
+			Name:      nat.Name,
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			Clear:     !att.IsRequired(nat.Name) && (!expr.IsPrimitive(nat.Attribute.Type) || att.IsPrimitivePointer(nat.Name, true)),
+		}
+		elem := nat.Attribute
+		switch {
+		case expr.IsArray(elem.Type):
+			elem = expr.AsArray(elem.Type).ElemType
+			field.Elem = true
+		case expr.IsMap(elem.Type):
+			elem = expr.AsMap(elem.Type).ElemType
+			field.Elem = true
+		default:
+			field.Nested = true
+		}
+		if _, ok := elem.Type.(expr.UserType); !ok || !expr.IsObject(elem.Type) {
+			field.Nested, field.Elem = false, false
+		} else {
+			nested = append(nested, collectPruneData(elem, scope, seen)...)
+		}
+		if field.Clear || field.Nested || field.Elem {
+			fields = append(fields, field)
+		}
+	}
+	data = append(data, &PruneData{Ref: scope.GoTypeRef(at), Fields: fields})
+	return append(data, nested...)
+}
+
+// collectAnonymizeData returns the data needed to generate the Anonymize
+// methods of the user type at and of the user types it contains.
+func collectAnonymizeData(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*AnonymizeData) {
+	if !isAnonymized(at.Type) {
+		return nil
+	}
+	ut := at.Type.(expr.UserType)
+	if _, ok := seen[ut.ID()]; ok {
+		return nil
+	}
+	seen[ut.ID()] = struct{}{}
+	var (
+		fields []*AnonymizeFieldData
+		nested []*AnonymizeData
+		att    = ut.Attribute()
+	)
+	for _, nat := range *expr.AsObject(ut) {
+		field := &AnonymizeFieldData{
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			TypeRef:   scope.GoTypeRef(nat.Attribute),
+		}
+		if strategy, ok := nat.Attribute.SensitiveStrategy(); ok {
+			field.Func = "goa.MaskString"
+			if strategy == expr.SensitiveHash {
+				field.Func = "goa.HashString"
+			}
+			field.Optional = nat.Attribute.IsOptionalField()
+			field.Pointer = !field.Optional && att.IsPrimitivePointer(nat.Name, true)
+			fields = append(fields, field)
+			continue
+		}
+		elem := nat.Attribute
+		switch {
+		case expr.IsArray(elem.Type):
+			elem = expr.AsArray(elem.Type).ElemType
+			field.Array = true
+		case expr.IsMap(elem.Type):
+			elem = expr.AsMap(elem.Type).ElemType
+			field.Map = true
+		default:
+			field.Nested = true
+		}
+		if !isAnonymized(elem.Type) {
+			continue
+		}
+		nested = append(nested, collectAnonymizeData(elem, scope, seen)...)
+		fields = append(fields, field)
+	}
+	data = append(data, &AnonymizeData{Ref: scope.GoTypeRef(at), Fields: fields})
+	return append(data, nested...)
+}
+
+// isAnonymized returns true if dt is an object user type generated in the
+// service package that contains sensitive attributes, directly or via nested
+// types.
+func isAnonymized(dt expr.DataType) bool {
+	ut, ok := dt.(expr.UserType)
+	if !ok || !expr.IsObject(ut) || codegen.UserTypeLocation(ut) != nil {
+		return false
+	}
+	return hasSensitive(ut, make(map[string]struct{}))
+}
+
+// hasSensitive returns true if dt contains attributes that use Sensitive.
+func hasSensitive(dt expr.DataType, seen map[string]struct{}) bool {
+	if ut, ok := dt.(expr.UserType); ok {
+		if _, ok := seen[ut.ID()]; ok {
+			return false
+		}
+		seen[ut.ID()] = struct{}{}
+	}
+	switch {
+	case expr.IsObject(dt):
+		for _, nat := range *expr.AsObject(dt) {
+			if _, ok := nat.Attribute.SensitiveStrategy(); ok {
+				return true
+			}
+			if hasSensitive(nat.Attribute.Type, seen) {
+				return true
+			}
+		}
+	case expr.IsArray(dt):
+		return hasSensitive(expr.AsArray(dt).ElemType.Type, seen)
+	case expr.IsMap(dt):
+		return hasSensitive(expr.AsMap(dt).ElemType.Type, seen)
+	}
+	return false
+}
+
 // buildErrorInitData creates the data needed to generate code around endpoint error return values.
 func buildErrorInitData(er *expr.ErrorExpr, scope *codegen.NameScope) *ErrorInitData {
 	_, temporary := er.AttributeExpr.Meta["goa:error:temporary"]
 	_, timeout := er.AttributeExpr.Meta["goa:error:timeout"]
 	_, fault := er.AttributeExpr.Meta["goa:error:fault"]
 	return &ErrorInitData{
-		Name:        fmt.Sprintf("Make%s", codegen.Goify(er.Name, true)),
-		Description: er.Description,
-		ErrName:     er.Name,
-		TypeName:    scope.GoTypeName(er.AttributeExpr),
-		TypeRef:     scope.GoTypeRef(er.AttributeExpr),
-		Temporary:   temporary,
-		Timeout:     timeout,
-		Fault:       fault,
+		Name:         fmt.Sprintf("Make%s", codegen.Goify(er.Name, true)),
+		SentinelName: fmt.Sprintf("Err%s", codegen.Goify(er.Name, true)),
+		Description:  er.Description,
+		ErrName:      er.Name,
+		TypeName:     scope.GoTypeName(er.AttributeExpr),
+		TypeRef:      scope.GoTypeRef(er.AttributeExpr),
+		Temporary:    temporary,
+		Timeout:      timeout,
+		Fault:        fault,
+		RetryAfter:   int64(er.RetryAfter() / time.Second),
 	}
 }
 
@@ -718,7 +1260,7 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 	if m.Payload.Type != expr.Empty {
 		payloadName = scope.GoTypeName(m.Payload)
 		payloadRef = scope.GoTypeRef(m.Payload)
-		if dt, ok := m.Payload.Type.(expr.UserType); ok {
+		if dt, ok := m.Payload.Type.(expr.UserType); ok && codegen.UserTypeLocation(dt) == nil {
 			payloadDef = scope.GoTypeDef(dt.Attribute(), false, true)
 		}
 		payloadDesc = m.Payload.Description
@@ -731,7 +1273,7 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 	if m.StreamingPayload.Type != expr.Empty {
 		spayloadName = scope.GoTypeName(m.StreamingPayload)
 		spayloadRef = scope.GoTypeRef(m.StreamingPayload)
-		if dt, ok := m.StreamingPayload.Type.(expr.UserType); ok {
+		if dt, ok := m.StreamingPayload.Type.(expr.UserType); ok && codegen.UserTypeLocation(dt) == nil {
 			spayloadDef = scope.GoTypeDef(dt.Attribute(), false, true)
 		}
 		spayloadDesc = m.StreamingPayload.Description
@@ -744,7 +1286,7 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 	if m.Result.Type != expr.Empty {
 		rname = scope.GoTypeName(m.Result)
 		resultRef = scope.GoTypeRef(m.Result)
-		if dt, ok := m.Result.Type.(expr.UserType); ok {
+		if dt, ok := m.Result.Type.(expr.UserType); ok && codegen.UserTypeLocation(dt) == nil {
 			resultDef = scope.GoTypeDef(dt.Attribute(), false, true)
 		}
 		resultDesc = m.Result.Description
@@ -816,6 +1358,11 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes})
 	}
 
+	var timeout string
+	if d := m.Timeout(); d > 0 {
+		timeout = codegen.GoDuration(d)
+	}
+
 	return &MethodData{
 		Name:                 m.Name,
 		VarName:              vname,
@@ -841,9 +1388,46 @@ func buildMethodData(m *expr.MethodExpr, svcPkgName string, service *expr.Servic
 		ServerStream:         svrStream,
 		ClientStream:         cliStream,
 		StreamKind:           m.Stream,
+		FieldMask:            m.HasFieldMask(),
+		LogFields:            buildLogFieldsData(m.Payload),
+		Timeout:              timeout,
 	}
 }
 
+// permissionIdentifier returns the given permission name with all the
+// characters that are not letters or digits replaced with underscores so that
+// it can be used to build a Go identifier.
+func permissionIdentifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// buildLogFieldsData builds the data needed to add the top level attributes of
+// the given payload that use LogField to the request log context.
+func buildLogFieldsData(payload *expr.AttributeExpr) []*LogFieldData {
+	obj := expr.AsObject(payload.Type)
+	if obj == nil {
+		return nil
+	}
+	var fields []*LogFieldData
+	for _, nat := range *obj {
+		key, ok := nat.Attribute.LogFieldKey(nat.Name)
+		if !ok {
+			continue
+		}
+		fields = append(fields, &LogFieldData{
+			Key:       key,
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			Pointer:   payload.IsPrimitivePointer(nat.Name, true),
+		})
+	}
+	return fields
+}
+
 // buildSchemeData builds the scheme data for the given scheme and method expr.
 func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 	if !expr.IsObject(m.Payload.Type) {
@@ -948,14 +1532,19 @@ func buildSchemeData(s *expr.SchemeExpr, m *expr.MethodExpr) *SchemeData {
 
 // collectProjectedTypes builds a projected type for every user type found
 // when recursing through the attributes. It stores the projected types in
-// data.
-func collectProjectedTypes(projected, att *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope, seen map[string]*ProjectedTypeData) (data []*ProjectedTypeData) {
+// data. memo indicates whether the projections are memoized.
+func collectProjectedTypes(projected, att *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope, seen map[string]*ProjectedTypeData, memo bool) (data []*ProjectedTypeData) {
 	collect := func(projected, att *expr.AttributeExpr) []*ProjectedTypeData {
-		return collectProjectedTypes(projected, att, viewspkg, scope, viewScope, seen)
+		return collectProjectedTypes(projected, att, viewspkg, scope, viewScope, seen, memo)
 	}
 	switch pt := projected.Type.(type) {
 	case expr.UserType:
 		dt := att.Type.(expr.UserType)
+		if expr.IsEnumConstType(dt) {
+			// Projected types use the base type of enum constant types.
+			*projected = *expr.InlineEnumConstType(projected)
+			return
+		}
 		if pd, ok := seen[dt.ID()]; ok {
 			// a projected type is already created for this user type. We change the
 			// attribute type to this seen projected type. The seen projected type
@@ -972,7 +1561,7 @@ func collectProjectedTypes(projected, att *expr.AttributeExpr, viewspkg string,
 		// We recurse before building the projected type so that user types within
 		// a projected type is also converted to their respective projected types.
 		types := collect(pt.Attribute(), dt.Attribute())
-		pd := buildProjectedType(projected, att, viewspkg, scope, viewScope)
+		pd := buildProjectedType(projected, att, viewspkg, scope, viewScope, memo)
 		seen[dt.ID()] = pd
 		data = append(data, pd)
 		data = append(data, types...)
@@ -996,7 +1585,9 @@ func collectProjectedTypes(projected, att *expr.AttributeExpr, viewspkg string,
 //
 // viewspkg is the name of the views package
 //
-func buildProjectedType(projected, att *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope) *ProjectedTypeData {
+// memo indicates whether the projections are memoized
+//
+func buildProjectedType(projected, att *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope, memo bool) *ProjectedTypeData {
 	var (
 		projections []*InitData
 		typeInits   []*InitData
@@ -1009,7 +1600,7 @@ func buildProjectedType(projected, att *expr.AttributeExpr, viewspkg string, sco
 	{
 		if _, isrt := pt.(*expr.ResultTypeExpr); isrt {
 			typeInits = buildTypeInits(projected, att, viewspkg, scope, viewScope)
-			projections = buildProjections(projected, att, viewspkg, scope, viewScope)
+			projections = buildProjections(projected, att, viewspkg, scope, viewScope, memo)
 			views = buildViews(att.Type.(*expr.ResultTypeExpr), viewScope)
 		}
 		validations = buildValidations(projected, viewScope)
@@ -1051,8 +1642,8 @@ func buildViews(rt *expr.ResultTypeExpr, viewScope *codegen.NameScope) []*ViewDa
 }
 
 // buildViewedResultType builds a viewed result type from the given result type
-// and projected type.
-func buildViewedResultType(att, projected *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope) *ViewedResultTypeData {
+// and projected type. memo indicates whether the projections are memoized.
+func buildViewedResultType(att, projected *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope, memo bool) *ViewedResultTypeData {
 	// collect result type views
 	var (
 		viewName string
@@ -1115,6 +1706,7 @@ func buildViewedResultType(att, projected *expr.AttributeExpr, viewspkg string,
 			"IsCollection":  isarr,
 			"TargetType":    scope.GoFullTypeName(att, viewspkg),
 			"InitName":      "new" + viewScope.GoTypeName(projected),
+			"Memo":          memo,
 		}
 		buf := &bytes.Buffer{}
 		if err := initTypeCodeTmpl.Execute(buf, data); err != nil {
@@ -1268,7 +1860,7 @@ func buildTypeInits(projected, att *expr.AttributeExpr, viewspkg string, scope,
 				if view.Name != expr.DefaultView {
 					name += codegen.Goify(view.Name, true)
 				}
-				code, helpers = buildConstructorCode(src, att, "vres", "res", srcCtx, tgtCtx, view.Name)
+				code, helpers = buildConstructorCode(src, att, "vres", "res", srcCtx, tgtCtx, view.Name, false)
 			}
 
 			init = append(init, &InitData{
@@ -1285,8 +1877,10 @@ func buildTypeInits(projected, att *expr.AttributeExpr, viewspkg string, scope,
 }
 
 // buildProjections builds the data to generate the constructor code to
-// project a result type to a projected type based on a view.
-func buildProjections(projected, att *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope) []*InitData {
+// project a result type to a projected type based on a view. memo indicates
+// whether the projections are memoized, in which case the constructors take
+// the memo as additional argument.
+func buildProjections(projected, att *expr.AttributeExpr, viewspkg string, scope, viewScope *codegen.NameScope, memo bool) []*InitData {
 	var (
 		projections []*InitData
 
@@ -1347,13 +1941,17 @@ func buildProjections(projected, att *expr.AttributeExpr, viewspkg string, scope
 			if view.Name != expr.DefaultView {
 				name += codegen.Goify(view.Name, true)
 			}
-			code, helpers = buildConstructorCode(att, tgt, "res", "vres", srcCtx, tgtCtx, view.Name)
+			code, helpers = buildConstructorCode(att, tgt, "res", "vres", srcCtx, tgtCtx, view.Name, memo)
+		}
+		args := []*InitArgData{{Name: "res", Ref: scope.GoTypeRef(att)}}
+		if memo {
+			args = append(args, &InitArgData{Name: "memo", Ref: "viewMemo"})
 		}
 
 		projections = append(projections, &InitData{
 			Name:          name,
 			Description:   fmt.Sprintf("%s projects result type %s to projected type %s using the %q view.", name, scope.GoTypeName(att), tname, view.Name),
-			Args:          []*InitArgData{{Name: "res", Ref: scope.GoTypeRef(att)}},
+			Args:          args,
 			ReturnTypeRef: viewScope.GoFullTypeRef(projected, viewspkg),
 			Code:          code,
 			Helpers:       helpers,
@@ -1466,7 +2064,10 @@ func buildValidations(projected *expr.AttributeExpr, scope *codegen.NameScope) [
 //
 // view is used to generate the constructor function name.
 //
-func buildConstructorCode(src, tgt *expr.AttributeExpr, sourceVar, targetVar string, sourceCtx, targetCtx *codegen.AttributeContext, view string) (string, []*codegen.TransformFunctionData) {
+// memo indicates whether the generated projection code looks up and stores
+// the projected values in a memo.
+//
+func buildConstructorCode(src, tgt *expr.AttributeExpr, sourceVar, targetVar string, sourceCtx, targetCtx *codegen.AttributeContext, view string, memo bool) (string, []*codegen.TransformFunctionData) {
 	var (
 		helpers []*codegen.TransformFunctionData
 		buf     bytes.Buffer
@@ -1479,6 +2080,7 @@ func buildConstructorCode(src, tgt *expr.AttributeExpr, sourceVar, targetVar str
 		"ReturnVar":    targetVar,
 		"IsCollection": arr != nil,
 		"TargetType":   targetCtx.Scope.Name(tgt, targetCtx.Pkg),
+		"Memo":         memo,
 	}
 
 	if arr != nil {
@@ -1506,6 +2108,10 @@ func buildConstructorCode(src, tgt *expr.AttributeExpr, sourceVar, targetVar str
 	}
 	data["Source"] = sourceVar
 	data["Target"] = targetVar
+	if memo {
+		data["ViewName"] = view
+		data["ReturnTypeRef"] = targetCtx.Scope.Ref(tgt, targetCtx.Pkg)
+	}
 
 	var (
 		code string
@@ -1563,11 +2169,14 @@ func walkViewAttrs(obj *expr.Object, view *expr.ViewExpr, walker func(name strin
 const (
 	initTypeCodeT = `{{- if or .ToResult .ToViewed -}}
 	var {{ .ReturnVar }} {{ .ReturnTypeRef }}
+	{{- if .Memo }}
+	memo := make(viewMemo)
+	{{- end }}
 	switch {{ if .ToResult }}{{ .ArgVar }}.View{{ else }}view{{ end }} {
 	{{- range .Views }}
 		case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
 			{{- if $.ToViewed }}
-				p := {{ $.InitName }}{{ if ne .Name "default" }}{{ goify .Name true }}{{ end }}({{ $.ArgVar }})
+				p := {{ $.InitName }}{{ if ne .Name "default" }}{{ goify .Name true }}{{ end }}({{ $.ArgVar }}{{ if $.Memo }}, memo{{ end }})
 				{{ $.ReturnVar }} = {{ if not $.IsCollection }}&{{ end }}{{ $.TargetType }}{ p,  {{ printf "%q" .Name }} }
 			{{- else }}
 				{{ $.ReturnVar }} = {{ $.InitName }}{{ if ne .Name "default" }}{{ goify .Name true }}{{ end }}({{ $.ArgVar }}.Projected)
@@ -1577,15 +2186,23 @@ const (
 {{- else if .IsCollection -}}
 	{{ .ReturnVar }} := make({{ .TargetType }}, len({{ .ArgVar }}))
 	for i, n := range {{ .ArgVar }} {
-		{{ .ReturnVar }}[i] = {{ .InitName }}(n)
+		{{ .ReturnVar }}[i] = {{ .InitName }}(n{{ if .Memo }}, memo{{ end }})
 	}
 {{- else -}}
+	{{ if .Memo -}}
+	if v, ok := memo[viewMemoKey{ {{ .Source }}, {{ printf "%q" .ViewName }} }]; ok {
+		return v.({{ .ReturnTypeRef }})
+	}
+	{{ end -}}
 	{{ .Code }}
 	{{- range .Fields }}
 		if {{ $.Source }}.{{ .VarName }} != nil {
-			{{ $.Target }}.{{ .VarName }} = {{ .FieldInit }}({{ $.Source }}.{{ .VarName }})
+			{{ $.Target }}.{{ .VarName }} = {{ .FieldInit }}({{ $.Source }}.{{ .VarName }}{{ if $.Memo }}, memo{{ end }})
 		}
 	{{- end }}
+	{{- if .Memo }}
+	memo[viewMemoKey{ {{ .Source }}, {{ printf "%q" .ViewName }} }] = {{ .Target }}
+	{{- end }}
 {{- end }}
 return {{ .ReturnVar }}`
 
@@ -28,8 +28,18 @@ func TestService(t *testing.T) {
 		{"result-collection-multiple-views", testdata.ResultCollectionMultipleViewsMethodDSL, testdata.ResultCollectionMultipleViewsMethod},
 		{"result-with-other-result", testdata.ResultWithOtherResultMethodDSL, testdata.ResultWithOtherResultMethod},
 		{"result-with-result-collection", testdata.ResultWithResultCollectionMethodDSL, testdata.ResultWithResultCollectionMethod},
+		{"result-with-result-collection-memoized", testdata.ResultWithResultCollectionMemoizedMethodDSL, testdata.ResultWithResultCollectionMemoizedMethod},
 		{"service-level-error", testdata.ServiceErrorDSL, testdata.ServiceError},
+		{"retry-after-error", testdata.RetryAfterErrorDSL, testdata.RetryAfterError},
 		{"custom-errors", testdata.CustomErrorsDSL, testdata.CustomErrors},
+		{"patch-payload", testdata.PatchPayloadDSL, testdata.PatchPayload},
+		{"field-mask", testdata.FieldMaskResultDSL, testdata.FieldMaskResult},
+		{"sensitive-types", testdata.SensitiveTypesDSL, testdata.SensitiveTypes},
+		{"sensitive-optional", testdata.SensitiveOptionalDSL, testdata.SensitiveOptional},
+		{"enum-constants", testdata.EnumConstantsTypesDSL, testdata.EnumConstantsTypes},
+		{"shared-types", testdata.SharedTypesDSL, testdata.SharedTypes},
+		{"permissions", testdata.PermissionsEndpointDSL, testdata.Permissions},
+		{"dependencies", testdata.DependenciesEndpointDSL, testdata.Dependencies},
 		{"force-generate-type", testdata.ForceGenerateTypeDSL, testdata.ForceGenerateType},
 		{"force-generate-type-explicit", testdata.ForceGenerateTypeExplicitDSL, testdata.ForceGenerateTypeExplicit},
 		{"streaming-result", testdata.StreamingResultMethodDSL, testdata.StreamingResultMethod},
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// StreamSenderFile returns the file implementing the buffered senders of the
+// client streams of the given service. A sender is generated for each method
+// whose client stream sends values, it queues the values so that the callers
+// are not slowed down by a slow receiver and applies a block or drop policy
+// when the queue is full. StreamSenderFile returns nil if no client stream of
+// the service sends values.
+func StreamSenderFile(service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	var streams []*StreamData
+	for _, m := range svc.Methods {
+		if m.ClientStream != nil && m.ClientStream.SendTypeRef != "" {
+			streams = append(streams, m.ClientStream)
+		}
+	}
+	if len(streams) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.VarName), "stream_senders.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" client stream senders", svc.PkgName,
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				codegen.GoaImport(""),
+			}),
+	}
+	for _, s := range streams {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-stream-sender",
+			Source: streamSenderT,
+			Data:   s,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: *StreamData
+const streamSenderT = `{{ printf "%sSender sends the %q values given to SendContext through a %s from a bounded queue. The values are sent in order by a background goroutine, the policy given to New%sSender determines what happens when the queue is full." .Interface .SendTypeName .Interface .Interface | comment }}
+type {{ .Interface }}Sender struct {
+	sender *goa.StreamSender
+}
+
+{{ printf "New%sSender returns a sender that queues up to size values and sends them through stream. Close must be called once done sending to flush the queue, it does not close stream." .Interface | comment }}
+func New{{ .Interface }}Sender(stream {{ .Interface }}, size int, policy goa.SendPolicy) *{{ .Interface }}Sender {
+	send := func(v interface{}) error {
+		return stream.{{ .SendName }}(v.({{ .SendTypeRef }}))
+	}
+	return &{{ .Interface }}Sender{sender: goa.NewStreamSender(send, size, policy)}
+}
+
+// SendContext queues v. It returns the error returned by a previous send if
+// any. It waits for the queue to have room for v or for ctx to be done with
+// the goa.SendBlock policy.
+func (s *{{ .Interface }}Sender) SendContext(ctx context.Context, v {{ .SendTypeRef }}) error {
+	return s.sender.Send(ctx, v)
+}
+
+// Stats returns the depth and capacity of the queue and the number of values
+// sent and dropped.
+func (s *{{ .Interface }}Sender) Stats() goa.StreamSenderStats {
+	return s.sender.Stats()
+}
+
+// Close waits until the queued values are sent or ctx is done. It does not
+// close the underlying stream.
+func (s *{{ .Interface }}Sender) Close(ctx context.Context) error {
+	return s.sender.Close(ctx)
+}
+`
@@ -181,12 +181,117 @@ func NewAEndpoint(s Service) goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		if v, ok := ctx.Value(goa.ViewKey).(string); ok && v != "" {
+			view = v
+		}
 		vres := NewViewedViewtype(res, view)
 		return vres, nil
 	}
 }
 `
 
+const LogFieldsEndpoint = `// Endpoints wraps the "LogFieldsEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "LogFieldsEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: NewAEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "LogFieldsEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "LogFieldsEndpoint".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*APayload)
+		ctx = middleware.AddLogFields(ctx, "order_id", p.OrderID)
+		if p.Carrier != nil {
+			ctx = middleware.AddLogFields(ctx, "shipping_carrier", *p.Carrier)
+		}
+		return nil, s.A(ctx, p)
+	}
+}
+`
+
+const PermissionsEndpoint = `// Endpoints wraps the "PermissionsEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "PermissionsEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Auther interface
+	a := s.(Auther)
+	return &Endpoints{
+		A: NewAEndpoint(s, a.JWTAuth),
+		B: NewBEndpoint(s, a.JWTAuth),
+	}
+}
+
+// Use applies the given middleware to all the "PermissionsEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "PermissionsEndpoint".
+func NewAEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*APayload)
+		var err error
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authJWTFn(ctx, p.Token, &sc)
+		if err != nil {
+			return nil, err
+		}
+		if err := security.ValidatePermissions(ctx, string(PermissionOrdersRead), string(PermissionOrdersWrite)); err != nil {
+			return nil, err
+		}
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "PermissionsEndpoint".
+func NewBEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*BPayload)
+		var err error
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authJWTFn(ctx, p.Token, &sc)
+		if err != nil {
+			return nil, err
+		}
+		if err := security.ValidatePermissions(ctx, string(PermissionOrdersRead)); err != nil {
+			return nil, err
+		}
+		return nil, s.B(ctx, p)
+	}
+}
+`
+
 const StreamingResultMethodEndpoint = `// Endpoints wraps the "StreamingResultEndpoint" service endpoints.
 type Endpoints struct {
 	StreamingResultMethod goa.Endpoint
@@ -505,3 +610,124 @@ func NewBidirectionalStreamingNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const FieldMaskEndpoint = `// Endpoints wraps the "FieldMaskEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "FieldMaskEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: NewAEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "FieldMaskEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "FieldMaskEndpoint".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := s.A(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok {
+			res.Prune(mask)
+		}
+		return res, nil
+	}
+}
+`
+
+const DependenciesEndpoint = `// Endpoints wraps the "DependenciesEndpoint" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "DependenciesEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	// Casting service to Dependencies interface
+	d := s.(Dependencies)
+	return &Endpoints{
+		A: NewAEndpoint(s, d),
+		B: NewBEndpoint(s, d),
+	}
+}
+
+// Use applies the given middleware to all the "DependenciesEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "DependenciesEndpoint".
+func NewAEndpoint(s Service, deps Dependencies) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		return withTxDependency(ctx, deps, func(ctx context.Context) (interface{}, error) {
+			return withClockDependency(ctx, deps, func(ctx context.Context) (interface{}, error) {
+				return s.A(ctx, p)
+			})
+		})
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "DependenciesEndpoint".
+func NewBEndpoint(s Service, deps Dependencies) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return withTxDependency(ctx, deps, func(ctx context.Context) (interface{}, error) {
+			return nil, s.B(ctx)
+		})
+	}
+}
+
+// withTxDependency creates the "tx" dependency with deps, stores it in the
+// context given to fn and finalizes it with the error returned by fn.
+func withTxDependency(ctx context.Context, deps Dependencies, fn func(context.Context) (interface{}, error)) (res interface{}, err error) {
+	tx, err := deps.NewTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			deps.FinalizeTx(ctx, tx, fmt.Errorf("panic: %v", r))
+			panic(r)
+		}
+		if ferr := deps.FinalizeTx(ctx, tx, err); ferr != nil && err == nil {
+			res, err = nil, ferr
+		}
+	}()
+	return fn(context.WithValue(ctx, dependencyKey("tx"), tx))
+}
+
+// withClockDependency creates the "clock" dependency with deps, stores it in
+// the context given to fn and finalizes it with the error returned by fn.
+func withClockDependency(ctx context.Context, deps Dependencies, fn func(context.Context) (interface{}, error)) (res interface{}, err error) {
+	clock, err := deps.NewClock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			deps.FinalizeClock(ctx, clock, fmt.Errorf("panic: %v", r))
+			panic(r)
+		}
+		if ferr := deps.FinalizeClock(ctx, clock, err); ferr != nil && err == nil {
+			res, err = nil, ferr
+		}
+	}()
+	return fn(context.WithValue(ctx, dependencyKey("clock"), clock))
+}
+`
@@ -87,6 +87,70 @@ var WithResultMultipleViewsEndpointDSL = func() {
 	})
 }
 
+var FieldMaskEndpointDSL = func() {
+	var RT = Type("Account", func() {
+		Attribute("id", String)
+		Attribute("name", String)
+		Required("id")
+	})
+	Service("FieldMaskEndpoint", func() {
+		Method("A", func() {
+			FieldMask()
+			Result(RT)
+		})
+	})
+}
+
+var LogFieldsEndpointDSL = func() {
+	Service("LogFieldsEndpoint", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("order_id", String, func() {
+					LogField()
+				})
+				Attribute("carrier", String, func() {
+					LogField("shipping_carrier")
+				})
+				Attribute("quantity", Int)
+				Required("order_id")
+			})
+		})
+	})
+}
+
+var PermissionsEndpointDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	Service("PermissionsEndpoint", func() {
+		Security(JWTAuth)
+		Permission("orders:read")
+		Method("A", func() {
+			Permission("orders:write")
+			Payload(func() {
+				Token("token", String)
+				Required("token")
+			})
+		})
+		Method("B", func() {
+			Payload(func() {
+				Token("token", String)
+				Required("token")
+			})
+		})
+	})
+}
+
+var DependenciesEndpointDSL = func() {
+	Service("DependenciesEndpoint", func() {
+		Dependency("tx", "*sql.Tx", "database/sql")
+		Method("A", func() {
+			Dependency("clock", "time.Time", "time")
+			Payload(String)
+			Result(String)
+		})
+		Method("B", func() {})
+	})
+}
+
 var StreamingResultEndpointDSL = func() {
 	var AType = Type("AType", func() {
 		Attribute("a", String)
@@ -158,3 +222,7 @@ var BidirectionalStreamingEndpointDSL = func() {
 		})
 	})
 }
+
+var NoMethodEndpointDSL = func() {
+	Service("NoMethodEndpoint", func() {})
+}
@@ -0,0 +1,66 @@
+package testdata
+
+var EventMethodsCode = `// Events is the in-process event bus of the "EventMethods" service. The events
+// published with the Publish functions are delivered synchronously to the
+// handlers registered with the Subscribe functions in order of subscription.
+type Events struct {
+	bus *goa.EventBus
+}
+
+// NewEvents returns an event bus with no subscriber.
+func NewEvents() *Events {
+	return &Events{bus: goa.NewEventBus()}
+}
+
+// PublishCreated publishes the "Created" event to the handlers subscribed with
+// SubscribeCreated. It returns the first error returned by a handler if any.
+func (e *Events) PublishCreated(ctx context.Context, p *APayload) error {
+	return e.bus.Publish(ctx, "Created", p)
+}
+
+// SubscribeCreated registers h to handle the "Created" events. It returns a
+// function that unregisters h.
+func (e *Events) SubscribeCreated(h func(context.Context, *APayload) error) (unsubscribe func()) {
+	return e.bus.Subscribe("Created", func(ctx context.Context, v interface{}) error {
+		return h(ctx, v.(*APayload))
+	})
+}
+
+// PublishDeleted publishes the "Deleted" event to the handlers subscribed with
+// SubscribeDeleted. It returns the first error returned by a handler if any.
+func (e *Events) PublishDeleted(ctx context.Context, p string) error {
+	return e.bus.Publish(ctx, "Deleted", p)
+}
+
+// SubscribeDeleted registers h to handle the "Deleted" events. It returns a
+// function that unregisters h.
+func (e *Events) SubscribeDeleted(h func(context.Context, string) error) (unsubscribe func()) {
+	return e.bus.Subscribe("Deleted", func(ctx context.Context, v interface{}) error {
+		return h(ctx, v.(string))
+	})
+}
+
+// PublishEvents wraps the endpoints of the "EventMethods" service events so
+// that the events received by the transports are published on events once
+// handled by the service.
+func (e *Endpoints) PublishEvents(events *Events) {
+	{
+		ep := e.Created
+		e.Created = func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, err := ep(ctx, req); err != nil {
+				return nil, err
+			}
+			return nil, events.PublishCreated(ctx, req.(*APayload))
+		}
+	}
+	{
+		ep := e.Deleted
+		e.Deleted = func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, err := ep(ctx, req); err != nil {
+				return nil, err
+			}
+			return nil, events.PublishDeleted(ctx, req.(string))
+		}
+	}
+}
+`
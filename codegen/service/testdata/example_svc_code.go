@@ -0,0 +1,144 @@
+package testdata
+
+var SkeletonServiceCode = `package testapi
+
+import (
+	"context"
+	"log"
+	"time"
+
+	skeleton "goa.design/goa/example/gen/skeleton"
+)
+
+// skeleton service implementation.
+// The method stubs log the requests and return the design example values.
+type skeletonsrvc struct {
+	logger *log.Logger
+}
+
+// NewSkeleton returns the skeleton service implementation.
+func NewSkeleton(logger *log.Logger) skeleton.Service {
+	return &skeletonsrvc{logger}
+}
+
+// Show implements show.
+func (s *skeletonsrvc) Show(ctx context.Context, p int) (res *skeleton.Book, err error) {
+	// TODO: implement skeleton.show
+	res = &skeleton.Book{
+		ID:    7,
+		Title: "Dune",
+		TTL:   time.Duration(3600000000000),
+		Authors: []*skeleton.Author{
+			&skeleton.Author{
+				Name: "Frank Herbert",
+			},
+		},
+		Ratings: map[string]int{"bad": 2, "good": 1},
+	}
+	s.logger.Print("skeleton.show")
+	return
+}
+
+// Names implements names.
+func (s *skeletonsrvc) Names(ctx context.Context) (res []string, err error) {
+	// TODO: implement skeleton.names
+	res = []string{"a", "b"}
+	s.logger.Print("skeleton.names")
+	return
+}
+
+// Count implements count.
+func (s *skeletonsrvc) Count(ctx context.Context) (res int, err error) {
+	// TODO: implement skeleton.count
+	res = 42
+	s.logger.Print("skeleton.count")
+	return
+}
+
+// Ping implements ping.
+func (s *skeletonsrvc) Ping(ctx context.Context) (err error) {
+	// TODO: implement skeleton.ping
+	s.logger.Print("skeleton.ping")
+	return
+}
+`
+
+const SkeletonExistingCode = `package skeletonapi
+
+import (
+	"context"
+	"log"
+
+	skeleton "goa.design/goa/example/gen/skeleton"
+)
+
+type skeletonsrvc struct {
+	logger *log.Logger
+}
+
+func NewSkeleton(logger *log.Logger) skeleton.Service {
+	return &skeletonsrvc{logger}
+}
+
+// Count returns the number of books.
+func (s *skeletonsrvc) Count(ctx context.Context) (int, error) {
+	return 3, nil
+}
+`
+
+var SkeletonMergedCode = `package skeletonapi
+
+import (
+	"context"
+	"log"
+	"time"
+
+	skeleton "goa.design/goa/example/gen/skeleton"
+)
+
+type skeletonsrvc struct {
+	logger *log.Logger
+}
+
+func NewSkeleton(logger *log.Logger) skeleton.Service {
+	return &skeletonsrvc{logger}
+}
+
+// Count returns the number of books.
+func (s *skeletonsrvc) Count(ctx context.Context) (int, error) {
+	return 3, nil
+}
+
+// Show implements show.
+func (s *skeletonsrvc) Show(ctx context.Context, p int) (res *skeleton.Book, err error) {
+	// TODO: implement skeleton.show
+	res = &skeleton.Book{
+		ID:    7,
+		Title: "Dune",
+		TTL:   time.Duration(3600000000000),
+		Authors: []*skeleton.Author{
+			&skeleton.Author{
+				Name: "Frank Herbert",
+			},
+		},
+		Ratings: map[string]int{"bad": 2, "good": 1},
+	}
+	s.logger.Print("skeleton.show")
+	return
+}
+
+// Names implements names.
+func (s *skeletonsrvc) Names(ctx context.Context) (res []string, err error) {
+	// TODO: implement skeleton.names
+	res = []string{"a", "b"}
+	s.logger.Print("skeleton.names")
+	return
+}
+
+// Ping implements ping.
+func (s *skeletonsrvc) Ping(ctx context.Context) (err error) {
+	// TODO: implement skeleton.ping
+	s.logger.Print("skeleton.ping")
+	return
+}
+`
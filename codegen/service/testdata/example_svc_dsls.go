@@ -21,3 +21,53 @@ var ConflictWithGoifiedAPINameAndServiceNamesDSL = func() {
 	var _ = Service("good-by-api", func() {})   // API name + 'api' suffix
 	var _ = Service("good-by-api-1", func() {}) // API name + 'api' suffix + sequential no.
 }
+
+var SkeletonDSL = func() {
+	var Author = Type("Author", func() {
+		Attribute("name", String, func() {
+			Example("Frank Herbert")
+		})
+		Attribute("born", Int, func() {
+			Example(1920)
+		})
+		Required("name")
+	})
+	var Book = ResultType("application/vnd.book", func() {
+		TypeName("Book")
+		Attributes(func() {
+			Attribute("id", Int, func() {
+				Example(7)
+			})
+			Attribute("title", String, func() {
+				Example("Dune")
+			})
+			Attribute("ttl", Duration, func() {
+				Example("1h")
+			})
+			Attribute("authors", ArrayOf(Author), func() {
+				Example([]map[string]interface{}{{"name": "Frank Herbert"}})
+			})
+			Attribute("ratings", MapOf(String, Int), func() {
+				Example(map[string]int{"good": 1, "bad": 2})
+			})
+			Required("id", "title", "ttl", "ratings")
+		})
+	})
+	var _ = Service("skeleton", func() {
+		Method("show", func() {
+			Payload(Int)
+			Result(Book)
+		})
+		Method("names", func() {
+			Result(ArrayOf(String), func() {
+				Example([]string{"a", "b"})
+			})
+		})
+		Method("count", func() {
+			Result(Int, func() {
+				Example(42)
+			})
+		})
+		Method("ping", func() {})
+	})
+}
@@ -0,0 +1,87 @@
+package testdata
+
+const Fixtures = `// APayload returns a APayload value initialized with the design examples and
+// default values. Each call returns a new value.
+func APayload() *fixtures.APayload {
+	return &fixtures.APayload{
+		Owner: &fixtures.Owner{
+			Name: "Alice",
+			Age:  intPtr(42),
+		},
+		Ratio: float64Ptr(0.25),
+	}
+}
+
+// Account returns a Account value initialized with the design examples and
+// default values. Each call returns a new value.
+func Account() *fixtures.Account {
+	return &fixtures.Account{
+		ID: "abc",
+		Owner: &fixtures.Owner{
+			Name: "Alice",
+			Age:  intPtr(42),
+		},
+		Tags:  []string{"a", "b"},
+		Count: 10,
+	}
+}
+
+// AccountTiny returns a Account value initialized with the design examples and
+// default values of the attributes rendered by the "tiny" view. Each call
+// returns a new value.
+func AccountTiny() *fixtures.Account {
+	return &fixtures.Account{
+		ID: "abc",
+	}
+}
+
+// Owner returns a Owner value initialized with the design examples and default
+// values. Each call returns a new value.
+func Owner() *fixtures.Owner {
+	return &fixtures.Owner{
+		Name: "Alice",
+		Age:  intPtr(42),
+	}
+}
+
+// float64Ptr returns a pointer to v.
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+// intPtr returns a pointer to v.
+func intPtr(v int) *int {
+	return &v
+}
+`
+
+var OptionalFixtures = `// AccountPatch returns a AccountPatch value initialized with the design
+// examples and default values. Each call returns a new value.
+func AccountPatch() *optionalfixtures.AccountPatch {
+	return &optionalfixtures.AccountPatch{
+		Name: goa.OptionalString{Value: stringPtr("Alice"), Set: true},
+		Age:  goa.OptionalInt{Value: intPtr(42), Set: true},
+		Tags: []string{"a"},
+	}
+}
+
+// Account returns a Account value initialized with the design examples and
+// default values. Each call returns a new value.
+func Account() *optionalfixtures.Account {
+	return &optionalfixtures.Account{
+		Name: "Alice",
+		Age:  intPtr(42),
+		Tags: []string{"a"},
+	}
+}
+
+// intPtr returns a pointer to v.
+func intPtr(v int) *int {
+	return &v
+}
+
+// stringPtr returns a pointer to v.
+func stringPtr(v string) *string {
+	return &v
+}
+`
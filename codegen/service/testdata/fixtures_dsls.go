@@ -0,0 +1,84 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FixturesDSL = func() {
+	var Owner = Type("Owner", func() {
+		Attribute("name", String, func() {
+			Example("Alice")
+		})
+		Attribute("age", Int, func() {
+			Example(42)
+		})
+		Required("name")
+	})
+	var Account = ResultType("application/vnd.account", func() {
+		TypeName("Account")
+		Attributes(func() {
+			Attribute("id", String, func() {
+				Example("abc")
+			})
+			Attribute("owner", Owner)
+			Attribute("tags", ArrayOf(String), func() {
+				Example([]string{"a", "b"})
+			})
+			Attribute("count", Int, func() {
+				Default(10)
+			})
+			Required("id")
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("owner")
+			Attribute("tags")
+			Attribute("count")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("Fixtures", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("owner", Owner)
+				Attribute("ratio", Float64, func() {
+					Example(0.25)
+				})
+			})
+			Result(Account)
+		})
+	})
+}
+
+var NoFixturesDSL = func() {
+	Service("NoFixtures", func() {
+		Method("A", func() {
+			Payload(String)
+		})
+	})
+}
+
+var OptionalFixturesDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("name", String, func() {
+			Example("Alice")
+		})
+		Attribute("age", Int, func() {
+			Example(42)
+		})
+		Attribute("tags", ArrayOf(String), func() {
+			Example([]string{"a"})
+		})
+		Required("name")
+	})
+	Service("OptionalFixtures", func() {
+		Method("A", func() {
+			Payload(PatchOf(Account))
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,191 @@
+package testdata
+
+const SingleMethodInMemoryClient = `// InMemoryOption configures the clients created by NewInMemoryClient.
+type InMemoryOption func(*inMemoryOptions)
+
+// inMemoryOptions holds the in-memory client options.
+type inMemoryOptions struct {
+	roundTrip bool
+}
+
+// WithRoundTrip makes the client encode the payloads to JSON and decode them
+// back before invoking the endpoints and do the same with the results. This
+// makes it possible to detect values that do not survive serialization without
+// running a server.
+func WithRoundTrip() InMemoryOption {
+	return func(o *inMemoryOptions) {
+		o.roundTrip = true
+	}
+}
+
+// NewInMemoryClient returns a "SingleEndpoint" service client that invokes the
+// given endpoints directly, without network or serialization unless
+// WithRoundTrip is used. Streaming methods are not supported and return an
+// error.
+func NewInMemoryClient(endpoints *singleendpoint.Endpoints, opts ...InMemoryOption) *singleendpoint.Client {
+	o := &inMemoryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return singleendpoint.NewClient(
+		newAEndpoint(endpoints.A, o),
+	)
+}
+
+// newAEndpoint returns the endpoint used by the in-memory client to call the
+// "A" endpoint of the "SingleEndpoint" service.
+func newAEndpoint(ep goa.Endpoint, o *inMemoryOptions) goa.Endpoint {
+	return func(ctx context.Context, p interface{}) (interface{}, error) {
+		if o.roundTrip {
+			var err error
+			if p, err = roundTrip(p); err != nil {
+				return nil, fmt.Errorf("failed to serialize %q payload: %s", "A", err)
+			}
+		}
+		return ep(ctx, p)
+	}
+}
+
+// roundTrip encodes v to JSON and decodes the result into a new value of the
+// same type.
+func roundTrip(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	nv := reflect.New(reflect.TypeOf(v))
+	if err := json.Unmarshal(b, nv.Interface()); err != nil {
+		return nil, err
+	}
+	return nv.Elem().Interface(), nil
+}
+`
+
+const WithResultMultipleViewsInMemoryClient = `// InMemoryOption configures the clients created by NewInMemoryClient.
+type InMemoryOption func(*inMemoryOptions)
+
+// inMemoryOptions holds the in-memory client options.
+type inMemoryOptions struct {
+	roundTrip bool
+}
+
+// WithRoundTrip makes the client encode the payloads to JSON and decode them
+// back before invoking the endpoints and do the same with the results. This
+// makes it possible to detect values that do not survive serialization without
+// running a server.
+func WithRoundTrip() InMemoryOption {
+	return func(o *inMemoryOptions) {
+		o.roundTrip = true
+	}
+}
+
+// NewInMemoryClient returns a "WithResultMultipleViews" service client that
+// invokes the given endpoints directly, without network or serialization
+// unless WithRoundTrip is used. Streaming methods are not supported and return
+// an error.
+func NewInMemoryClient(endpoints *withresultmultipleviews.Endpoints, opts ...InMemoryOption) *withresultmultipleviews.Client {
+	o := &inMemoryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return withresultmultipleviews.NewClient(
+		newAEndpoint(endpoints.A, o),
+	)
+}
+
+// newAEndpoint returns the endpoint used by the in-memory client to call the
+// "A" endpoint of the "WithResultMultipleViews" service.
+func newAEndpoint(ep goa.Endpoint, o *inMemoryOptions) goa.Endpoint {
+	return func(ctx context.Context, p interface{}) (interface{}, error) {
+		res, err := ep(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if o.roundTrip {
+			if res, err = roundTrip(res); err != nil {
+				return nil, fmt.Errorf("failed to serialize %q result: %s", "A", err)
+			}
+		}
+		return withresultmultipleviews.NewViewtype(res.(*withresultmultipleviewsviews.Viewtype)), nil
+	}
+}
+
+// roundTrip encodes v to JSON and decodes the result into a new value of the
+// same type.
+func roundTrip(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	nv := reflect.New(reflect.TypeOf(v))
+	if err := json.Unmarshal(b, nv.Interface()); err != nil {
+		return nil, err
+	}
+	return nv.Elem().Interface(), nil
+}
+`
+
+const StreamingResultInMemoryClient = `// InMemoryOption configures the clients created by NewInMemoryClient.
+type InMemoryOption func(*inMemoryOptions)
+
+// inMemoryOptions holds the in-memory client options.
+type inMemoryOptions struct {
+	roundTrip bool
+}
+
+// WithRoundTrip makes the client encode the payloads to JSON and decode them
+// back before invoking the endpoints and do the same with the results. This
+// makes it possible to detect values that do not survive serialization without
+// running a server.
+func WithRoundTrip() InMemoryOption {
+	return func(o *inMemoryOptions) {
+		o.roundTrip = true
+	}
+}
+
+// NewInMemoryClient returns a "StreamingResultEndpoint" service client that
+// invokes the given endpoints directly, without network or serialization
+// unless WithRoundTrip is used. Streaming methods are not supported and return
+// an error.
+func NewInMemoryClient(endpoints *streamingresultendpoint.Endpoints, opts ...InMemoryOption) *streamingresultendpoint.Client {
+	o := &inMemoryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return streamingresultendpoint.NewClient(
+		newStreamingResultMethodEndpoint(endpoints.StreamingResultMethod, o),
+	)
+}
+
+// newStreamingResultMethodEndpoint returns the endpoint used by the in-memory
+// client to call the "StreamingResultMethod" endpoint of the
+// "StreamingResultEndpoint" service.
+func newStreamingResultMethodEndpoint(goa.Endpoint, *inMemoryOptions) goa.Endpoint {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("in-memory client does not support the streaming method %q of service %q", "StreamingResultMethod", "StreamingResultEndpoint")
+	}
+}
+
+// roundTrip encodes v to JSON and decodes the result into a new value of the
+// same type.
+func roundTrip(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	nv := reflect.New(reflect.TypeOf(v))
+	if err := json.Unmarshal(b, nv.Interface()); err != nil {
+		return nil, err
+	}
+	return nv.Elem().Interface(), nil
+}
+`
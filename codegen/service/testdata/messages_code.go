@@ -0,0 +1,24 @@
+package testdata
+
+const ValidationMessagesFile = `{
+  "decode_payload": "{error}",
+  "invalid_enum_value": "value of {field} must be one of {allowed} but got value {value}",
+  "invalid_field_type": "invalid value {value} for \"{field}\", must be a {type}",
+  "invalid_format": "{field} must be formatted as a {format} but got value \"{value}\", {error}",
+  "invalid_length.max": "length of {field} must be lesser or equal than {limit} but got value {value} (len={length})",
+  "invalid_length.min": "length of {field} must be greater or equal than {limit} but got value {value} (len={length})",
+  "invalid_pattern": "{field} must match the regexp \"{pattern}\" but got value \"{value}\"",
+  "invalid_range.min": "{field} must be greater or equal than {limit} but got value {value}",
+  "missing_field": "\"{field}\" is missing from {context}",
+  "missing_payload": "missing required payload",
+  "mutually_exclusive_fields": "at most one of {fields} may be set in {context}"
+}
+`
+
+const StrictDecodingMessagesFile = `{
+  "decode_payload": "{error}",
+  "invalid_field_type": "invalid value {value} for \"{field}\", must be a {type}",
+  "missing_payload": "missing required payload",
+  "unknown_field": "unknown field \"{field}\""
+}
+`
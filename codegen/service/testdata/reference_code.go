@@ -0,0 +1,132 @@
+package testdata
+
+const ReferenceIndexFile = `# Cellar API
+
+The cellar API manages wine bottles.
+
+Version: 1.0
+
+## Servers
+
+| Server | Description | URIs |
+| --- | --- | --- |
+| cellar |  | http://localhost:8000 |
+
+## Services
+
+| Service | Description |
+| --- | --- |
+| [Storage](storage.md) | The storage service stores bottles. |
+
+See [Types](types.md) for the description of the types used by the services.
+
+## Security
+
+### jwt
+
+Kind: JWT
+
+Credentials: header ` + "`" + `Authorization` + "`" + `
+
+JWT based authentication
+
+| Scope | Description |
+| --- | --- |
+| ` + "`" + `api:read` + "`" + ` | Read access |
+`
+
+const ReferenceServiceFile = `# Storage
+
+The storage service stores bottles.
+
+[Back to index](index.md)
+
+## Methods
+
+- [Show](#show)
+
+## Show
+
+Show a bottle by ID.
+
+HTTP:
+
+- ` + "`" + `GET /bottles/{id}` + "`" + `
+
+Success status codes: 200
+
+Security:
+
+- ` + "`" + `jwt` + "`" + ` with scopes ` + "`" + `api:read` + "`" + `
+
+### Payload
+
+Type: object
+
+| Field | Type | Required | Description | Constraints |
+| --- | --- | --- | --- | --- |
+| token | string | yes |  |  |
+| id | string | yes | ID of the bottle |  |
+
+Example:
+
+` + "`" + `` + "`" + `` + "`" + `json
+{
+  "id": "abc",
+  "token": "Voluptates non excepturi."
+}
+` + "`" + `` + "`" + `` + "`" + `
+
+### Result
+
+Type: [Bottle](types.md#bottle)
+
+| Field | Type | Required | Description | Constraints |
+| --- | --- | --- | --- | --- |
+| name | string | yes | Name of the bottle | min length: 1 |
+| vintage | int | yes | Vintage year | minimum: 1900 |
+| color | string | no |  | one of ` + "`" + `"red"` + "`" + `, ` + "`" + `"white"` + "`" + `, default: ` + "`" + `"red"` + "`" + ` |
+
+Example:
+
+` + "`" + `` + "`" + `` + "`" + `json
+{
+  "color": "red",
+  "name": "Chateau Margaux",
+  "vintage": 2015
+}
+` + "`" + `` + "`" + `` + "`" + `
+
+### Errors
+
+| Error | Type | HTTP status | Description |
+| --- | --- | --- | --- |
+| not_found | string | 404 | Bottle not found |
+`
+
+const ReferenceTypesFile = `# Types
+
+[Back to index](index.md)
+
+## Bottle
+
+Type: object
+
+Bottle of wine
+
+| Field | Type | Required | Description | Constraints |
+| --- | --- | --- | --- | --- |
+| name | string | yes | Name of the bottle | min length: 1 |
+| vintage | int | yes | Vintage year | minimum: 1900 |
+| color | string | no |  | one of ` + "`" + `"red"` + "`" + `, ` + "`" + `"white"` + "`" + `, default: ` + "`" + `"red"` + "`" + ` |
+
+Example:
+
+` + "`" + `` + "`" + `` + "`" + `json
+{
+  "color": "red",
+  "name": "Chateau Margaux",
+  "vintage": 2015
+}
+` + "`" + `` + "`" + `` + "`" + `
+`
@@ -0,0 +1,39 @@
+package testdata
+
+var RenamedMethodsCode = `
+// List calls the "list_accounts" endpoint of the "RenamedMethods" service.
+//
+// Deprecated: the method was renamed, use ListAccounts instead.
+func (c *Client) List(ctx context.Context, p *ListAccountsPayload) (res []*Account, err error) {
+	return c.ListAccounts(ctx, p)
+}
+
+// ListAll calls the "list_accounts" endpoint of the "RenamedMethods" service.
+//
+// Deprecated: the method was renamed, use ListAccounts instead.
+func (c *Client) ListAll(ctx context.Context, p *ListAccountsPayload) (res []*Account, err error) {
+	return c.ListAccounts(ctx, p)
+}
+
+// Flush calls the "purge" endpoint of the "RenamedMethods" service.
+//
+// Deprecated: the method was renamed, use Purge instead.
+func (c *Client) Flush(ctx context.Context) (err error) {
+	return c.Purge(ctx)
+}
+
+// ListPayload is the previous name of ListAccountsPayload.
+//
+// Deprecated: the type was renamed, use ListAccountsPayload instead.
+type ListPayload = ListAccountsPayload
+
+// ListAllPayload is the previous name of ListAccountsPayload.
+//
+// Deprecated: the type was renamed, use ListAccountsPayload instead.
+type ListAllPayload = ListAccountsPayload
+
+// Acct is the previous name of Account.
+//
+// Deprecated: the type was renamed, use Account instead.
+type Acct = Account
+`
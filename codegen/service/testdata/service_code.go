@@ -298,6 +298,12 @@ const ServiceName = "ServiceError"
 // MethodKey key.
 var MethodNames = [1]string{"A"}
 
+var (
+	// ErrError is the sentinel of the "error" errors. Use errors.Is to check
+	// whether an error returned by a method or by a client is a "error" error.
+	ErrError = &goa.ServiceError{Name: "error"}
+)
+
 // MakeError builds a goa.ServiceError from an error.
 func MakeError(err error) *goa.ServiceError {
 	return &goa.ServiceError{
@@ -1067,6 +1073,280 @@ func newRT2ViewTiny(res *RT2) *resultwithresulttypecollectionviews.RT2View {
 }
 `
 
+const ResultWithResultCollectionMemoizedMethod = `
+// Service is the ResultWithResultTypeCollectionMemoized service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context) (res *RT, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "ResultWithResultTypeCollectionMemoized"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// RT is the result type of the ResultWithResultTypeCollectionMemoized service
+// A method.
+type RT struct {
+	A RT2Collection
+	B *RT2
+}
+
+type RT2Collection []*RT2
+
+type RT2 struct {
+	C string
+	D int
+}
+
+// NewRT initializes result type RT from viewed result type RT.
+func NewRT(vres *resultwithresulttypecollectionmemoizedviews.RT) *RT {
+	var res *RT
+	switch vres.View {
+	case "default", "":
+		res = newRT(vres.Projected)
+	}
+	return res
+}
+
+// NewViewedRT initializes viewed result type RT from result type RT using the
+// given view.
+func NewViewedRT(res *RT, view string) *resultwithresulttypecollectionmemoizedviews.RT {
+	var vres *resultwithresulttypecollectionmemoizedviews.RT
+	memo := make(viewMemo)
+	switch view {
+	case "default", "":
+		p := newRTView(res, memo)
+		vres = &resultwithresulttypecollectionmemoizedviews.RT{p, "default"}
+	}
+	return vres
+}
+
+// viewMemo records the projections computed while rendering a result so that
+// values referenced multiple times are only projected once per view.
+type viewMemo map[viewMemoKey]interface{}
+
+// viewMemoKey identifies the projection of a value using a view.
+type viewMemoKey struct {
+	res  interface{}
+	view string
+}
+
+// newRT converts projected type RT to service type RT.
+func newRT(vres *resultwithresulttypecollectionmemoizedviews.RTView) *RT {
+	res := &RT{}
+	if vres.A != nil {
+		res.A = newRT2Collection(vres.A)
+	}
+	if vres.B != nil {
+		res.B = newRT2Tiny(vres.B)
+	}
+	return res
+}
+
+// newRTView projects result type RT to projected type RTView using the
+// "default" view.
+func newRTView(res *RT, memo viewMemo) *resultwithresulttypecollectionmemoizedviews.RTView {
+	if v, ok := memo[viewMemoKey{res, "default"}]; ok {
+		return v.(*resultwithresulttypecollectionmemoizedviews.RTView)
+	}
+	vres := &resultwithresulttypecollectionmemoizedviews.RTView{}
+	if res.A != nil {
+		vres.A = newRT2CollectionView(res.A, memo)
+	}
+	if res.B != nil {
+		vres.B = newRT2ViewTiny(res.B, memo)
+	}
+	memo[viewMemoKey{res, "default"}] = vres
+	return vres
+}
+
+// newRT2Collection converts projected type RT2Collection to service type
+// RT2Collection.
+func newRT2Collection(vres resultwithresulttypecollectionmemoizedviews.RT2CollectionView) RT2Collection {
+	res := make(RT2Collection, len(vres))
+	for i, n := range vres {
+		res[i] = newRT2(n)
+	}
+	return res
+}
+
+// newRT2CollectionTiny converts projected type RT2Collection to service type
+// RT2Collection.
+func newRT2CollectionTiny(vres resultwithresulttypecollectionmemoizedviews.RT2CollectionView) RT2Collection {
+	res := make(RT2Collection, len(vres))
+	for i, n := range vres {
+		res[i] = newRT2Tiny(n)
+	}
+	return res
+}
+
+// newRT2CollectionView projects result type RT2Collection to projected type
+// RT2CollectionView using the "default" view.
+func newRT2CollectionView(res RT2Collection, memo viewMemo) resultwithresulttypecollectionmemoizedviews.RT2CollectionView {
+	vres := make(resultwithresulttypecollectionmemoizedviews.RT2CollectionView, len(res))
+	for i, n := range res {
+		vres[i] = newRT2View(n, memo)
+	}
+	return vres
+}
+
+// newRT2CollectionViewTiny projects result type RT2Collection to projected
+// type RT2CollectionView using the "tiny" view.
+func newRT2CollectionViewTiny(res RT2Collection, memo viewMemo) resultwithresulttypecollectionmemoizedviews.RT2CollectionView {
+	vres := make(resultwithresulttypecollectionmemoizedviews.RT2CollectionView, len(res))
+	for i, n := range res {
+		vres[i] = newRT2ViewTiny(n, memo)
+	}
+	return vres
+}
+
+// newRT2 converts projected type RT2 to service type RT2.
+func newRT2(vres *resultwithresulttypecollectionmemoizedviews.RT2View) *RT2 {
+	res := &RT2{}
+	if vres.C != nil {
+		res.C = *vres.C
+	}
+	if vres.D != nil {
+		res.D = *vres.D
+	}
+	return res
+}
+
+// newRT2Tiny converts projected type RT2 to service type RT2.
+func newRT2Tiny(vres *resultwithresulttypecollectionmemoizedviews.RT2View) *RT2 {
+	res := &RT2{}
+	if vres.D != nil {
+		res.D = *vres.D
+	}
+	return res
+}
+
+// newRT2View projects result type RT2 to projected type RT2View using the
+// "default" view.
+func newRT2View(res *RT2, memo viewMemo) *resultwithresulttypecollectionmemoizedviews.RT2View {
+	if v, ok := memo[viewMemoKey{res, "default"}]; ok {
+		return v.(*resultwithresulttypecollectionmemoizedviews.RT2View)
+	}
+	vres := &resultwithresulttypecollectionmemoizedviews.RT2View{
+		C: &res.C,
+		D: &res.D,
+	}
+	memo[viewMemoKey{res, "default"}] = vres
+	return vres
+}
+
+// newRT2ViewTiny projects result type RT2 to projected type RT2View using the
+// "tiny" view.
+func newRT2ViewTiny(res *RT2, memo viewMemo) *resultwithresulttypecollectionmemoizedviews.RT2View {
+	if v, ok := memo[viewMemoKey{res, "tiny"}]; ok {
+		return v.(*resultwithresulttypecollectionmemoizedviews.RT2View)
+	}
+	vres := &resultwithresulttypecollectionmemoizedviews.RT2View{
+		D: &res.D,
+	}
+	memo[viewMemoKey{res, "tiny"}] = vres
+	return vres
+}
+`
+
+const PatchPayload = `
+// Service is the PatchPayload service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *AccountPatch) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "PatchPayload"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// AccountPatch is the payload type of the PatchPayload service A method.
+type AccountPatch struct {
+	Name     goa.OptionalString
+	Nickname goa.OptionalString
+	Age      goa.OptionalInt
+	Address  *Address
+	Tags     []string
+	ID       int
+}
+
+type Address struct {
+	Street *string
+}
+
+type Account struct {
+	Name     string
+	Nickname *string
+	Age      int
+	Address  *Address
+	Tags     []string
+}
+
+// Apply merges the JSON merge patch p into v: the fields set to null in p are
+// cleared and the fields set to a value replace the values of v.
+func (p *AccountPatch) Apply(v *Account) {
+	if p.Name.Set {
+		if p.Name.Value != nil {
+			v.Name = *p.Name.Value
+		} else {
+			v.Name = ""
+		}
+	}
+	if p.Nickname.Set {
+		v.Nickname = p.Nickname.Value
+	}
+	if p.Age.Set {
+		if p.Age.Value != nil {
+			v.Age = *p.Age.Value
+		} else {
+			v.Age = 18
+		}
+	}
+	if p.Address != nil {
+		v.Address = p.Address
+	}
+	if p.Tags != nil {
+		v.Tags = p.Tags
+	}
+}
+`
+
+const SharedTypes = `
+// Service is the SharedTypes service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (res *types.User, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SharedTypes"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the SharedTypes service A method.
+type APayload struct {
+	User *types.User
+	Kind *types.Kind
+}
+`
+
 const ForceGenerateType = `
 // Service is the ForceGenerateType service interface.
 type Service interface {
@@ -2195,3 +2475,414 @@ func newMultipleViewsViewTiny(res *MultipleViews) *bidirectionalstreamingresultw
 	return vres
 }
 `
+
+const SensitiveTypes = `
+// Service is the Sensitive service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *Customer) (res *Contact, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Sensitive"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// Customer is the payload type of the Sensitive service A method.
+type Customer struct {
+	ID       string
+	Email    *string
+	Phone    string
+	Primary  *Contact
+	Contacts []*Contact
+	Labels   map[string]*Contact
+}
+
+// Contact is the result type of the Sensitive service A method.
+type Contact struct {
+	Kind  *string
+	Value string
+}
+
+// Anonymize returns a copy of t where the sensitive fields are masked or
+// hashed. Use it to export the value to analytics systems or to build log and
+// audit events.
+func (t *Customer) Anonymize() *Customer {
+	if t == nil {
+		return nil
+	}
+	res := *t
+	if t.Email != nil {
+		v := goa.HashString(*t.Email)
+		res.Email = &v
+	}
+	res.Phone = goa.MaskString(t.Phone)
+	res.Primary = t.Primary.Anonymize()
+	if t.Contacts != nil {
+		res.Contacts = make([]*Contact, len(t.Contacts))
+		for i, v := range t.Contacts {
+			res.Contacts[i] = v.Anonymize()
+		}
+	}
+	if t.Labels != nil {
+		res.Labels = make(map[string]*Contact, len(t.Labels))
+		for k, v := range t.Labels {
+			res.Labels[k] = v.Anonymize()
+		}
+	}
+	return &res
+}
+
+// Anonymize returns a copy of t where the sensitive fields are masked or
+// hashed. Use it to export the value to analytics systems or to build log and
+// audit events.
+func (t *Contact) Anonymize() *Contact {
+	if t == nil {
+		return nil
+	}
+	res := *t
+	res.Value = goa.MaskString(t.Value)
+	return &res
+}
+`
+
+const SensitiveOptional = `
+// Service is the SensitiveOptional service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SensitiveOptional"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the SensitiveOptional service A method.
+type APayload struct {
+	ID  int
+	Ssn goa.OptionalString
+}
+
+// Anonymize returns a copy of t where the sensitive fields are masked or
+// hashed. Use it to export the value to analytics systems or to build log and
+// audit events.
+func (t *APayload) Anonymize() *APayload {
+	if t == nil {
+		return nil
+	}
+	res := *t
+	if t.Ssn.Set && t.Ssn.Value != nil {
+		v := goa.MaskString(*t.Ssn.Value)
+		res.Ssn.Value = &v
+	}
+	return &res
+}
+`
+
+const FieldMaskResult = `
+// Service is the FieldMask service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context) (res *Account, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "FieldMask"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// Account is the result type of the FieldMask service A method.
+type Account struct {
+	ID      string
+	Name    *string
+	Owner   *Owner
+	Members []*Owner
+	Count   int
+}
+
+type Owner struct {
+	Name  string
+	Email *string
+}
+
+// Prune clears the fields of r that are not selected by mask. Required fields
+// are never cleared. Prune does nothing if mask is empty.
+func (r *Account) Prune(mask goa.FieldMask) {
+	if r == nil || len(mask) == 0 {
+		return
+	}
+	if !mask.Includes("name") {
+		r.Name = nil
+	}
+	if !mask.Includes("owner") {
+		r.Owner = nil
+	} else {
+		r.Owner.Prune(mask.Sub("owner"))
+	}
+	if !mask.Includes("members") {
+		r.Members = nil
+	} else {
+		for _, v := range r.Members {
+			v.Prune(mask.Sub("members"))
+		}
+	}
+}
+
+// Prune clears the fields of r that are not selected by mask. Required fields
+// are never cleared. Prune does nothing if mask is empty.
+func (r *Owner) Prune(mask goa.FieldMask) {
+	if r == nil || len(mask) == 0 {
+		return
+	}
+	if !mask.Includes("email") {
+		r.Email = nil
+	}
+}
+`
+
+const EnumConstantsTypes = `
+// Service is the EnumConstants service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *Item) (res Level, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "EnumConstants"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// Item is the payload type of the EnumConstants service A method.
+type Item struct {
+	Color  Color
+	Colors []Color
+	Level  *Level
+}
+
+// Level is the result type of the EnumConstants service A method.
+type Level int
+
+type Color string
+
+// Values of Level.
+const (
+	LevelMinus1 Level = -1
+	Level1      Level = 1
+	Level2      Level = 2
+)
+
+// String returns the string representation of v.
+func (v Level) String() string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+// Validate returns an error if v is not one of the values of Level.
+func (v Level) Validate() error {
+	switch v {
+	case LevelMinus1, Level1, Level2:
+		return nil
+	}
+	return goa.InvalidEnumValueError("Level", v, []interface{}{-1, 1, 2})
+}
+
+// ParseLevel converts s to a Level value and validates it.
+func ParseLevel(s string) (Level, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, goa.InvalidFieldTypeError("Level", s, "integer")
+	}
+	v := Level(n)
+	if err := v.Validate(); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// Values of Color.
+const (
+	ColorRed     Color = "red"
+	ColorDarkRed Color = "dark-red"
+)
+
+// String returns the string representation of v.
+func (v Color) String() string {
+	return string(v)
+}
+
+// Validate returns an error if v is not one of the values of Color.
+func (v Color) Validate() error {
+	switch v {
+	case ColorRed, ColorDarkRed:
+		return nil
+	}
+	return goa.InvalidEnumValueError("Color", v, []interface{}{"red", "dark-red"})
+}
+
+// ParseColor converts s to a Color value and validates it.
+func ParseColor(s string) (Color, error) {
+	v := Color(s)
+	if err := v.Validate(); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+`
+
+const Permissions = `
+// Service is the PermissionsEndpoint service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+	// B implements B.
+	B(context.Context, *BPayload) (err error)
+}
+
+// Auther defines the authorization functions to be implemented by the service.
+type Auther interface {
+	// JWTAuth implements the authorization logic for the JWT security scheme.
+	JWTAuth(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "PermissionsEndpoint"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [2]string{"A", "B"}
+
+// Permission is a permission the authenticated principal must be granted to
+// call the service methods that require it, see security.ValidatePermissions.
+type Permission string
+
+// Permissions required by the service methods as defined in the design.
+const (
+	// PermissionOrdersRead is the "orders:read" permission.
+	PermissionOrdersRead Permission = "orders:read"
+	// PermissionOrdersWrite is the "orders:write" permission.
+	PermissionOrdersWrite Permission = "orders:write"
+)
+
+// APayload is the payload type of the PermissionsEndpoint service A method.
+type APayload struct {
+	Token string
+}
+
+// BPayload is the payload type of the PermissionsEndpoint service B method.
+type BPayload struct {
+	Token string
+}
+`
+
+const Dependencies = `
+// Service is the DependenciesEndpoint service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, string) (res string, err error)
+	// B implements B.
+	B(context.Context) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "DependenciesEndpoint"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [2]string{"A", "B"}
+
+// Dependencies creates and finalizes the per-request dependencies of the
+// service methods. The service implementation must implement it.
+type Dependencies interface {
+	// NewTx creates the "tx" dependency of a request.
+	NewTx(ctx context.Context) (*sql.Tx, error)
+	// FinalizeTx finalizes the "tx" dependency of a request once the method
+	// returns. err is the error returned by the method, nil if the method
+	// succeeded.
+	FinalizeTx(ctx context.Context, tx *sql.Tx, err error) error
+	// NewClock creates the "clock" dependency of a request.
+	NewClock(ctx context.Context) (time.Time, error)
+	// FinalizeClock finalizes the "clock" dependency of a request once the method
+	// returns. err is the error returned by the method, nil if the method
+	// succeeded.
+	FinalizeClock(ctx context.Context, clock time.Time, err error) error
+}
+
+// TxFromContext returns the "tx" dependency of the request, see Dependencies.
+func TxFromContext(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(dependencyKey("tx")).(*sql.Tx)
+	return tx
+}
+
+// ClockFromContext returns the "clock" dependency of the request, see
+// Dependencies.
+func ClockFromContext(ctx context.Context) time.Time {
+	clock, _ := ctx.Value(dependencyKey("clock")).(time.Time)
+	return clock
+}
+
+// dependencyKey is the type of the keys used to store the dependencies in the
+// request contexts.
+type dependencyKey string
+`
+
+const RetryAfterError = `
+// Service is the RetryAfterError service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "RetryAfterError"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+var (
+	// ErrRateLimited is the sentinel of the "rate_limited" errors. Use errors.Is
+	// to check whether an error returned by a method or by a client is a
+	// "rate_limited" error.
+	ErrRateLimited = &goa.ServiceError{Name: "rate_limited"}
+)
+
+// MakeRateLimited builds a goa.ServiceError from an error.
+func MakeRateLimited(err error) *goa.ServiceError {
+	return &goa.ServiceError{
+		Name:       "rate_limited",
+		ID:         goa.NewErrorID(),
+		Message:    err.Error(),
+		Temporary:  true,
+		RetryAfter: 60 * time.Second,
+	}
+}
+`
@@ -131,6 +131,16 @@ var ServiceErrorDSL = func() {
 	})
 }
 
+var RetryAfterErrorDSL = func() {
+	Service("RetryAfterError", func() {
+		Error("rate_limited", func() {
+			Temporary()
+			RetryAfter("1m")
+		})
+		Method("A", func() {})
+	})
+}
+
 var CustomErrorsDSL = func() {
 	var Result = ResultType("application/vnd.goa.error", func() {
 		TypeName("Result")
@@ -295,6 +305,244 @@ var ResultWithResultCollectionMethodDSL = func() {
 	})
 }
 
+var ResultWithResultCollectionMemoizedMethodDSL = func() {
+	var RT2 = ResultType("application/vnd.result.2", func() {
+		TypeName("RT2")
+		Attributes(func() {
+			Field(1, "c", String)
+			Field(2, "d", Int)
+			Required("c", "d")
+		})
+		View("default", func() {
+			Attribute("c")
+			Attribute("d")
+		})
+		View("tiny", func() {
+			Attribute("d")
+		})
+	})
+	var RT = ResultType("application/vnd.result", func() {
+		TypeName("RT")
+		Attributes(func() {
+			Field(1, "a", CollectionOf(RT2))
+			Field(2, "b", RT2)
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b", func() {
+				View("tiny")
+			})
+		})
+	})
+	Service("ResultWithResultTypeCollectionMemoized", func() {
+		MemoizeViews()
+		Method("A", func() {
+			Result(RT)
+		})
+	})
+}
+
+var PatchPayloadDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("street", String)
+	})
+	var Account = Type("Account", func() {
+		Attribute("name", String, func() {
+			MinLength(1)
+		})
+		Attribute("nickname", String)
+		Attribute("age", Int, func() {
+			Default(18)
+		})
+		Attribute("address", Address)
+		Attribute("tags", ArrayOf(String))
+		Required("name")
+	})
+	Service("PatchPayload", func() {
+		Method("A", func() {
+			Payload(PatchOf(Account, func() {
+				Attribute("id", Int)
+				Required("id")
+			}))
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var FieldMaskResultDSL = func() {
+	var Owner = Type("Owner", func() {
+		Attribute("name", String)
+		Attribute("email", String)
+		Required("name")
+	})
+	var Account = Type("Account", func() {
+		Attribute("id", String)
+		Attribute("name", String)
+		Attribute("owner", Owner)
+		Attribute("members", ArrayOf(Owner))
+		Attribute("count", Int, func() {
+			Default(1)
+		})
+		Required("id")
+	})
+	Service("FieldMask", func() {
+		Method("A", func() {
+			FieldMask()
+			Result(Account)
+		})
+	})
+}
+
+var SensitiveTypesDSL = func() {
+	var Contact = Type("Contact", func() {
+		Attribute("kind", String)
+		Attribute("value", String, func() {
+			Sensitive()
+		})
+		Required("value")
+	})
+	var Customer = Type("Customer", func() {
+		Attribute("id", String)
+		Attribute("email", String, func() {
+			Sensitive(SensitiveHash)
+		})
+		Attribute("phone", String, func() {
+			Sensitive()
+			Default("")
+		})
+		Attribute("primary", Contact)
+		Attribute("contacts", ArrayOf(Contact))
+		Attribute("labels", MapOf(String, Contact))
+		Required("id")
+	})
+	Service("Sensitive", func() {
+		Method("A", func() {
+			Payload(Customer)
+			Result(Contact)
+		})
+	})
+}
+
+var SensitiveOptionalDSL = func() {
+	Service("SensitiveOptional", func() {
+		Method("A", func() {
+			OptionalFields()
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("ssn", String, func() {
+					Sensitive()
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var ValidationMessagesDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("city", String, func() {
+			MinLength(1)
+			MaxLength(50)
+		})
+		Attribute("zip", String, func() {
+			Pattern("^[0-9]{5}$")
+		})
+		Required("city")
+	})
+	Service("ValidationMessages", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("email", String, func() {
+					Format(FormatEmail)
+				})
+				Attribute("count", Int, func() {
+					Minimum(1)
+				})
+				Attribute("kind", String, func() {
+					Enum("a", "b")
+				})
+				Attribute("address", Address)
+			})
+		})
+		Method("B", func() {
+			StreamingPayload(func() {
+				Attribute("a", String)
+				Attribute("b", String)
+				MutuallyExclusive("a", "b")
+			})
+		})
+	})
+}
+
+var StrictDecodingMessagesDSL = func() {
+	Service("StrictDecodingMessages", func() {
+		Method("A", func() {
+			StrictDecoding()
+			Payload(func() {
+				Attribute("a", String)
+			})
+		})
+	})
+}
+
+var EnumConstantsTypesDSL = func() {
+	var Color = Type("Color", String, func() {
+		Enum("red", "dark-red")
+		EnumConstants()
+	})
+	var Level = Type("Level", Int, func() {
+		Enum(-1, 1, 2)
+		EnumConstants()
+	})
+	var Item = Type("Item", func() {
+		Attribute("color", Color)
+		Attribute("colors", ArrayOf(Color))
+		Attribute("level", Level)
+		Required("color")
+	})
+	Service("EnumConstants", func() {
+		Method("A", func() {
+			Payload(Item)
+			Result(Level)
+		})
+	})
+}
+
+var SharedTypesDSL = func() {
+	var Kind = Type("Kind", String, func() {
+		Enum("home", "work")
+		EnumConstants()
+		Meta("struct:pkg:path", "types")
+	})
+	var Address = Type("Address", func() {
+		Description("Address is shared by the services.")
+		Attribute("street", String)
+		Attribute("kind", Kind)
+		Required("street")
+		Meta("struct:pkg:path", "types")
+	})
+	var User = Type("User", func() {
+		Attribute("name", String)
+		Attribute("addresses", ArrayOf(Address))
+		Required("name")
+		Meta("struct:pkg:path", "types")
+	})
+	Service("SharedTypes", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("user", User)
+				Attribute("kind", Kind)
+			})
+			Result(User)
+		})
+	})
+}
+
 var ForceGenerateTypeDSL = func() {
 	var _ = Type("ForcedType", func() {
 		Attribute("a", String)
@@ -548,3 +796,99 @@ var NamesWithSpacesDSL = func() {
 		})
 	})
 }
+
+var ReferenceDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Description("JWT based authentication")
+		Scope("api:read", "Read access")
+	})
+	var Bottle = Type("Bottle", func() {
+		Description("Bottle of wine")
+		Attribute("name", String, "Name of the bottle", func() {
+			MinLength(1)
+			Example("Chateau Margaux")
+		})
+		Attribute("vintage", Int, "Vintage year", func() {
+			Minimum(1900)
+			Example(2015)
+		})
+		Attribute("color", String, func() {
+			Enum("red", "white")
+			Default("red")
+		})
+		Required("name", "vintage")
+	})
+	API("cellar", func() {
+		Title("Cellar API")
+		Description("The cellar API manages wine bottles.")
+		Version("1.0")
+		Server("cellar", func() {
+			Host("localhost", func() {
+				URI("http://localhost:8000")
+			})
+		})
+	})
+	Service("Storage", func() {
+		Description("The storage service stores bottles.")
+		Method("Show", func() {
+			Description("Show a bottle by ID.")
+			Security(JWTAuth, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String, "ID of the bottle", func() {
+					Example("abc")
+				})
+				Required("token", "id")
+			})
+			Result(Bottle)
+			Error("not_found", String, "Bottle not found")
+			HTTP(func() {
+				GET("/bottles/{id}")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
+
+var EventMethodsDSL = func() {
+	Service("EventMethods", func() {
+		Method("Show", func() {
+			Payload(String)
+			Result(AResult)
+		})
+		Method("Created", func() {
+			Event()
+			Payload(APayload)
+		})
+		Method("Deleted", func() {
+			Event()
+			Payload(String)
+		})
+	})
+}
+
+var RenamedMethodsDSL = func() {
+	var Account = Type("Account", func() {
+		RenamedFrom("Acct")
+		Attribute("name", String)
+	})
+	Service("RenamedMethods", func() {
+		Method("list_accounts", func() {
+			RenamedFrom("list", "ListAll")
+			Payload(func() {
+				Attribute("filter", String)
+			})
+			Result(ArrayOf(Account))
+		})
+		Method("create_account", func() {
+			Payload(Account)
+			Result(Account)
+		})
+		Method("purge", func() {
+			RenamedFrom("flush")
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package testdata
+
+var StreamingPayloadMethodStreamSenderCode = `// StreamingPayloadMethodClientStreamSender sends the "APayload" values given
+// to SendContext through a StreamingPayloadMethodClientStream from a bounded
+// queue. The values are sent in order by a background goroutine, the policy
+// given to NewStreamingPayloadMethodClientStreamSender determines what happens
+// when the queue is full.
+type StreamingPayloadMethodClientStreamSender struct {
+	sender *goa.StreamSender
+}
+
+// NewStreamingPayloadMethodClientStreamSender returns a sender that queues up
+// to size values and sends them through stream. Close must be called once done
+// sending to flush the queue, it does not close stream.
+func NewStreamingPayloadMethodClientStreamSender(stream StreamingPayloadMethodClientStream, size int, policy goa.SendPolicy) *StreamingPayloadMethodClientStreamSender {
+	send := func(v interface{}) error {
+		return stream.Send(v.(*APayload))
+	}
+	return &StreamingPayloadMethodClientStreamSender{sender: goa.NewStreamSender(send, size, policy)}
+}
+
+// SendContext queues v. It returns the error returned by a previous send if
+// any. It waits for the queue to have room for v or for ctx to be done with
+// the goa.SendBlock policy.
+func (s *StreamingPayloadMethodClientStreamSender) SendContext(ctx context.Context, v *APayload) error {
+	return s.sender.Send(ctx, v)
+}
+
+// Stats returns the depth and capacity of the queue and the number of values
+// sent and dropped.
+func (s *StreamingPayloadMethodClientStreamSender) Stats() goa.StreamSenderStats {
+	return s.sender.Stats()
+}
+
+// Close waits until the queued values are sent or ctx is done. It does not
+// close the underlying stream.
+func (s *StreamingPayloadMethodClientStreamSender) Close(ctx context.Context) error {
+	return s.sender.Close(ctx)
+}
+`
+
+var StreamingPayloadNoResultMethodStreamSenderCode = `// StreamingPayloadNoResultMethodClientStreamSender sends the "int" values
+// given to SendContext through a StreamingPayloadNoResultMethodClientStream
+// from a bounded queue. The values are sent in order by a background
+// goroutine, the policy given to
+// NewStreamingPayloadNoResultMethodClientStreamSender determines what happens
+// when the queue is full.
+type StreamingPayloadNoResultMethodClientStreamSender struct {
+	sender *goa.StreamSender
+}
+
+// NewStreamingPayloadNoResultMethodClientStreamSender returns a sender that
+// queues up to size values and sends them through stream. Close must be called
+// once done sending to flush the queue, it does not close stream.
+func NewStreamingPayloadNoResultMethodClientStreamSender(stream StreamingPayloadNoResultMethodClientStream, size int, policy goa.SendPolicy) *StreamingPayloadNoResultMethodClientStreamSender {
+	send := func(v interface{}) error {
+		return stream.Send(v.(int))
+	}
+	return &StreamingPayloadNoResultMethodClientStreamSender{sender: goa.NewStreamSender(send, size, policy)}
+}
+
+// SendContext queues v. It returns the error returned by a previous send if
+// any. It waits for the queue to have room for v or for ctx to be done with
+// the goa.SendBlock policy.
+func (s *StreamingPayloadNoResultMethodClientStreamSender) SendContext(ctx context.Context, v int) error {
+	return s.sender.Send(ctx, v)
+}
+
+// Stats returns the depth and capacity of the queue and the number of values
+// sent and dropped.
+func (s *StreamingPayloadNoResultMethodClientStreamSender) Stats() goa.StreamSenderStats {
+	return s.sender.Stats()
+}
+
+// Close waits until the queued values are sent or ctx is done. It does not
+// close the underlying stream.
+func (s *StreamingPayloadNoResultMethodClientStreamSender) Close(ctx context.Context) error {
+	return s.sender.Close(ctx)
+}
+`
+
+var BidirectionalStreamingMethodStreamSenderCode = `// BidirectionalStreamingMethodClientStreamSender sends the "APayload" values
+// given to SendContext through a BidirectionalStreamingMethodClientStream from
+// a bounded queue. The values are sent in order by a background goroutine, the
+// policy given to NewBidirectionalStreamingMethodClientStreamSender determines
+// what happens when the queue is full.
+type BidirectionalStreamingMethodClientStreamSender struct {
+	sender *goa.StreamSender
+}
+
+// NewBidirectionalStreamingMethodClientStreamSender returns a sender that
+// queues up to size values and sends them through stream. Close must be called
+// once done sending to flush the queue, it does not close stream.
+func NewBidirectionalStreamingMethodClientStreamSender(stream BidirectionalStreamingMethodClientStream, size int, policy goa.SendPolicy) *BidirectionalStreamingMethodClientStreamSender {
+	send := func(v interface{}) error {
+		return stream.Send(v.(*APayload))
+	}
+	return &BidirectionalStreamingMethodClientStreamSender{sender: goa.NewStreamSender(send, size, policy)}
+}
+
+// SendContext queues v. It returns the error returned by a previous send if
+// any. It waits for the queue to have room for v or for ctx to be done with
+// the goa.SendBlock policy.
+func (s *BidirectionalStreamingMethodClientStreamSender) SendContext(ctx context.Context, v *APayload) error {
+	return s.sender.Send(ctx, v)
+}
+
+// Stats returns the depth and capacity of the queue and the number of values
+// sent and dropped.
+func (s *BidirectionalStreamingMethodClientStreamSender) Stats() goa.StreamSenderStats {
+	return s.sender.Stats()
+}
+
+// Close waits until the queued values are sent or ctx is done. It does not
+// close the underlying stream.
+func (s *BidirectionalStreamingMethodClientStreamSender) Close(ctx context.Context) error {
+	return s.sender.Close(ctx)
+}
+`
@@ -0,0 +1,133 @@
+package testdata
+
+var BasicAndJWTTestSecurityCode = `// TestSigningKey is the key used to sign the tokens returned by the test token
+// issuers using HMAC SHA-256. The Auther implementation exercised by the tests
+// must validate the token signatures with the same key.
+var TestSigningKey = []byte("goa-test-signing-key")
+
+// NewJWTTestToken returns a JWT for the "jwt" security scheme signed with
+// TestSigningKey. The "scopes" claim defaults to the scopes defined by the
+// scheme when claims does not set it.
+func NewJWTTestToken(claims map[string]interface{}) string {
+	c := map[string]interface{}{"scopes": []string{"api:read", "api:write", "api:admin"}}
+	for k, v := range claims {
+		c[k] = v
+	}
+	return signTestToken(c)
+}
+
+// signTestToken returns a JWT for the given claims signed with TestSigningKey
+// using the HS256 algorithm. It panics if the claims cannot be serialized to
+// JSON.
+func signTestToken(claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		panic(err)
+	}
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte("{\"alg\":\"HS256\",\"typ\":\"JWT\"}")) + "." + enc.EncodeToString(payload)
+	mac := hmac.New(sha256.New, TestSigningKey)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+// BypassSecurity returns a service that delegates to svc but whose
+// authorization functions accept any credentials. The functions store
+// principal in the request context so that the service methods may retrieve it
+// with security.ContextPrincipal.
+func BypassSecurity(svc endpointswithrequirements.Service, principal interface{}) endpointswithrequirements.Service {
+	return &bypassSecurity{Service: svc, principal: principal}
+}
+
+// bypassSecurity wraps a service and implements its Auther interface so that
+// all requests are authorized.
+type bypassSecurity struct {
+	endpointswithrequirements.Service
+	principal interface{}
+}
+
+// BasicAuth authorizes the request and stores the test principal in the
+// context.
+func (s *bypassSecurity) BasicAuth(ctx context.Context, user, pass string, schema *security.BasicScheme) (context.Context, error) {
+	return security.ContextWithPrincipal(ctx, s.principal), nil
+}
+
+// JWTAuth authorizes the request and stores the test principal in the context.
+func (s *bypassSecurity) JWTAuth(ctx context.Context, token string, schema *security.JWTScheme) (context.Context, error) {
+	return security.ContextWithPrincipal(ctx, s.principal), nil
+}
+`
+
+var OAuth2TestSecurityCode = `// TestSigningKey is the key used to sign the tokens returned by the test token
+// issuers using HMAC SHA-256. The Auther implementation exercised by the tests
+// must validate the token signatures with the same key.
+var TestSigningKey = []byte("goa-test-signing-key")
+
+// NewAuthCodeTestToken returns an access token for the "authCode" security
+// scheme. The token is a JWT signed with TestSigningKey whose "scopes" claim
+// lists the given scopes or the scopes defined by the scheme if none is given.
+func NewAuthCodeTestToken(scopes ...string) string {
+	if len(scopes) == 0 {
+		scopes = []string{"api:write", "api:read"}
+	}
+	return signTestToken(map[string]interface{}{"scopes": scopes})
+}
+
+// signTestToken returns a JWT for the given claims signed with TestSigningKey
+// using the HS256 algorithm. It panics if the claims cannot be serialized to
+// JSON.
+func signTestToken(claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		panic(err)
+	}
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte("{\"alg\":\"HS256\",\"typ\":\"JWT\"}")) + "." + enc.EncodeToString(payload)
+	mac := hmac.New(sha256.New, TestSigningKey)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+// BypassSecurity returns a service that delegates to svc but whose
+// authorization functions accept any credentials. The functions store
+// principal in the request context so that the service methods may retrieve it
+// with security.ContextPrincipal.
+func BypassSecurity(svc endpointwithoauth2.Service, principal interface{}) endpointwithoauth2.Service {
+	return &bypassSecurity{Service: svc, principal: principal}
+}
+
+// bypassSecurity wraps a service and implements its Auther interface so that
+// all requests are authorized.
+type bypassSecurity struct {
+	endpointwithoauth2.Service
+	principal interface{}
+}
+
+// OAuth2Auth authorizes the request and stores the test principal in the
+// context.
+func (s *bypassSecurity) OAuth2Auth(ctx context.Context, token string, schema *security.OAuth2Scheme) (context.Context, error) {
+	return security.ContextWithPrincipal(ctx, s.principal), nil
+}
+`
+
+var APIKeyTestSecurityCode = `// BypassSecurity returns a service that delegates to svc but whose
+// authorization functions accept any credentials. The functions store
+// principal in the request context so that the service methods may retrieve it
+// with security.ContextPrincipal.
+func BypassSecurity(svc endpointwithapikeyoverride.Service, principal interface{}) endpointwithapikeyoverride.Service {
+	return &bypassSecurity{Service: svc, principal: principal}
+}
+
+// bypassSecurity wraps a service and implements its Auther interface so that
+// all requests are authorized.
+type bypassSecurity struct {
+	endpointwithapikeyoverride.Service
+	principal interface{}
+}
+
+// APIKeyAuth authorizes the request and stores the test principal in the
+// context.
+func (s *bypassSecurity) APIKeyAuth(ctx context.Context, key string, schema *security.APIKeyScheme) (context.Context, error) {
+	return security.ContextWithPrincipal(ctx, s.principal), nil
+}
+`
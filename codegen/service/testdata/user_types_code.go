@@ -0,0 +1,45 @@
+package testdata
+
+const SharedTypesFile = `
+type Kind string
+
+// Values of Kind.
+const (
+	KindHome Kind = "home"
+	KindWork Kind = "work"
+)
+
+// String returns the string representation of v.
+func (v Kind) String() string {
+	return string(v)
+}
+
+// Validate returns an error if v is not one of the values of Kind.
+func (v Kind) Validate() error {
+	switch v {
+	case KindHome, KindWork:
+		return nil
+	}
+	return goa.InvalidEnumValueError("Kind", v, []interface{}{"home", "work"})
+}
+
+// ParseKind converts s to a Kind value and validates it.
+func ParseKind(s string) (Kind, error) {
+	v := Kind(s)
+	if err := v.Validate(); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// Address is shared by the services.
+type Address struct {
+	Street string
+	Kind   *Kind
+}
+
+type User struct {
+	Name      string
+	Addresses []*Address
+}
+`
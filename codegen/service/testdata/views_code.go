@@ -547,3 +547,73 @@ func ValidateRTViewTiny(result *RTView) (err error) {
 	return
 }
 `
+
+const ResultWithEnumConstantsCode = `// ResultType is the viewed result type that is projected based on a view.
+type ResultType struct {
+	// Type to project
+	Projected *ResultTypeView
+	// View to render
+	View string
+}
+
+// ResultTypeView is a type that runs validations on a projected type.
+type ResultTypeView struct {
+	A *string
+	B []string
+}
+
+var (
+	// ResultTypeMap is a map of attribute names in result type ResultType indexed
+	// by view name.
+	ResultTypeMap = map[string][]string{
+		"default": []string{
+			"a",
+			"b",
+		},
+		"tiny": []string{
+			"a",
+		},
+	}
+)
+
+// ValidateResultType runs the validations defined on the viewed result type
+// ResultType.
+func ValidateResultType(result *ResultType) (err error) {
+	switch result.View {
+	case "default", "":
+		err = ValidateResultTypeView(result.Projected)
+	case "tiny":
+		err = ValidateResultTypeViewTiny(result.Projected)
+	default:
+		err = goa.InvalidEnumValueError("view", result.View, []interface{}{"default", "tiny"})
+	}
+	return
+}
+
+// ValidateResultTypeView runs the validations defined on ResultTypeView using
+// the "default" view.
+func ValidateResultTypeView(result *ResultTypeView) (err error) {
+	if result.A != nil {
+		if !(*result.A == "red" || *result.A == "green") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("result.a", *result.A, []interface{}{"red", "green"}))
+		}
+	}
+	for _, e := range result.B {
+		if !(e == "red" || e == "green") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("result.b[*]", e, []interface{}{"red", "green"}))
+		}
+	}
+	return
+}
+
+// ValidateResultTypeViewTiny runs the validations defined on ResultTypeView
+// using the "tiny" view.
+func ValidateResultTypeViewTiny(result *ResultTypeView) (err error) {
+	if result.A != nil {
+		if !(*result.A == "red" || *result.A == "green") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("result.a", *result.A, []interface{}{"red", "green"}))
+		}
+	}
+	return
+}
+`
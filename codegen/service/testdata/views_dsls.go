@@ -193,3 +193,29 @@ var ResultWithCustomFieldsDSL = func() {
 		})
 	})
 }
+
+var ResultWithEnumConstantsDSL = func() {
+	var Color = Type("Color", String, func() {
+		Enum("red", "green")
+		EnumConstants()
+	})
+	var RT = ResultType("application/vnd.result", func() {
+		TypeName("ResultType")
+		Attributes(func() {
+			Attribute("a", Color)
+			Attribute("b", ArrayOf(Color))
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("ResultWithEnumConstants", func() {
+		Method("A", func() {
+			Result(RT)
+		})
+	})
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// testSecurityData contains the data needed to render the security
+	// test doubles of a service.
+	testSecurityData struct {
+		// PkgName is the name of the service package.
+		PkgName string
+		// Tokens lists the JWT and OAuth2 schemes for which a test token
+		// issuer is generated.
+		Tokens []*testTokenData
+		// AuthTypes lists the distinct types of the service schemes,
+		// one of "Basic", "APIKey", "JWT" or "OAuth2".
+		AuthTypes []string
+	}
+
+	// testTokenData describes a test token issuer.
+	testTokenData struct {
+		// FuncName is the name of the issuer function.
+		FuncName string
+		// Scheme is the security scheme data.
+		Scheme *SchemeData
+		// Scopes is the Go literal for the scopes defined by the scheme.
+		Scopes string
+	}
+)
+
+// TestSecurityFile returns the file implementing test doubles for the security
+// schemes of the given service: token issuers for the JWT and OAuth2 schemes
+// and a service wrapper that bypasses authorization. The file is generated in
+// the <service>test package. TestSecurityFile returns nil if the service
+// methods are not secured.
+func TestSecurityFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	if len(svc.Schemes) == 0 {
+		return nil
+	}
+	data := &testSecurityData{PkgName: svc.PkgName}
+	seen := make(map[string]bool)
+	for _, s := range svc.Schemes {
+		if s.Type == "JWT" || s.Type == "OAuth2" {
+			data.Tokens = append(data.Tokens, &testTokenData{
+				FuncName: "New" + codegen.Goify(s.SchemeName, true) + "TestToken",
+				Scheme:   s,
+				Scopes:   fmt.Sprintf("%#v", append([]string{}, s.Scopes...)),
+			})
+		}
+		if !seen[s.Type] {
+			seen[s.Type] = true
+			data.AuthTypes = append(data.AuthTypes, s.Type)
+		}
+	}
+	svcName := codegen.SnakeCase(svc.VarName)
+	path := filepath.Join(codegen.Gendir, svcName, svcName+"test", "security.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" security test doubles", svc.PkgName+"test",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "crypto/hmac"},
+				{Path: "crypto/sha256"},
+				{Path: "encoding/base64"},
+				{Path: "encoding/json"},
+				codegen.GoaImport("security"),
+				{Path: genpkg + "/" + svcName, Name: svc.PkgName},
+			}),
+	}
+	if len(data.Tokens) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "test-security-tokens",
+			Source: testSecurityTokensT,
+			Data:   data,
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "test-security-bypass",
+		Source: testSecurityBypassT,
+		Data:   data,
+	})
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: *testSecurityData
+const testSecurityTokensT = `{{ comment "TestSigningKey is the key used to sign the tokens returned by the test token issuers using HMAC SHA-256. The Auther implementation exercised by the tests must validate the token signatures with the same key." }}
+var TestSigningKey = []byte("goa-test-signing-key")
+{{- range .Tokens }}
+
+	{{- if eq .Scheme.Type "JWT" }}
+
+{{ printf "%s returns a JWT for the %q security scheme signed with TestSigningKey. The \"scopes\" claim defaults to the scopes defined by the scheme when claims does not set it." .FuncName .Scheme.SchemeName | comment }}
+func {{ .FuncName }}(claims map[string]interface{}) string {
+	c := map[string]interface{}{"scopes": {{ .Scopes }}}
+	for k, v := range claims {
+		c[k] = v
+	}
+	return signTestToken(c)
+}
+	{{- else }}
+
+{{ printf "%s returns an access token for the %q security scheme. The token is a JWT signed with TestSigningKey whose \"scopes\" claim lists the given scopes or the scopes defined by the scheme if none is given." .FuncName .Scheme.SchemeName | comment }}
+func {{ .FuncName }}(scopes ...string) string {
+	if len(scopes) == 0 {
+		scopes = {{ .Scopes }}
+	}
+	return signTestToken(map[string]interface{}{"scopes": scopes})
+}
+	{{- end }}
+{{- end }}
+
+// signTestToken returns a JWT for the given claims signed with TestSigningKey
+// using the HS256 algorithm. It panics if the claims cannot be serialized to
+// JSON.
+func signTestToken(claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		panic(err)
+	}
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte("{\"alg\":\"HS256\",\"typ\":\"JWT\"}")) + "." + enc.EncodeToString(payload)
+	mac := hmac.New(sha256.New, TestSigningKey)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil))
+}
+`
+
+// input: *testSecurityData
+const testSecurityBypassT = `{{ comment "BypassSecurity returns a service that delegates to svc but whose authorization functions accept any credentials. The functions store principal in the request context so that the service methods may retrieve it with security.ContextPrincipal." }}
+func BypassSecurity(svc {{ .PkgName }}.Service, principal interface{}) {{ .PkgName }}.Service {
+	return &bypassSecurity{Service: svc, principal: principal}
+}
+
+// bypassSecurity wraps a service and implements its Auther interface so that
+// all requests are authorized.
+type bypassSecurity struct {
+	{{ .PkgName }}.Service
+	principal interface{}
+}
+{{- range .AuthTypes }}
+
+{{ printf "%sAuth authorizes the request and stores the test principal in the context." . | comment }}
+func (s *bypassSecurity) {{ . }}Auth(ctx context.Context, {{ if eq . "Basic" }}user, pass{{ else if eq . "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ . }}Scheme) (context.Context, error) {
+	return security.ContextWithPrincipal(ctx, s.principal), nil
+}
+{{- end }}
+`
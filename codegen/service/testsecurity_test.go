@@ -0,0 +1,58 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestTestSecurity(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"basic-and-jwt", testdata.EndpointsWithRequirementsDSL, testdata.BasicAndJWTTestSecurityCode},
+		{"oauth2", testdata.EndpointWithOAuth2DSL, testdata.OAuth2TestSecurityCode},
+		{"api-key", testdata.EndpointWithAPIKeyOverrideDSL, testdata.APIKeyTestSecurityCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			if len(expr.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(expr.Root.Services))
+			}
+			f := TestSecurityFile("goa.design/goa/example", expr.Root.Services[0])
+			if f == nil {
+				t.Fatal("got no file, expected one")
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bs, err := format.Source(buf.Bytes())
+			if err != nil {
+				fmt.Println(buf.String())
+				t.Fatal(err)
+			}
+			code := string(bs)
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestTestSecurityNoScheme(t *testing.T) {
+	codegen.RunDSL(t, testdata.EndpointWithoutRequirementDSL)
+	if f := TestSecurityFile("goa.design/goa/example", expr.Root.Services[0]); f != nil {
+		t.Errorf("got file %q, expected none", f.Path)
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"sort"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// UserTypeFiles returns the files that define the user types generated in
+// shared packages with the "struct:pkg:path" metadata, one file per package.
+// The types are generated once and used by all the services so that values
+// can be passed from one service to another without conversion.
+func UserTypeFiles(root *expr.RootExpr) []*codegen.File {
+	var (
+		paths []string
+		types = make(map[string][]expr.UserType)
+	)
+	for _, t := range root.Types {
+		loc := codegen.UserTypeLocation(t)
+		if loc == nil {
+			continue
+		}
+		if _, ok := types[loc.RelImportPath]; !ok {
+			paths = append(paths, loc.RelImportPath)
+		}
+		types[loc.RelImportPath] = append(types[loc.RelImportPath], t)
+	}
+	sort.Strings(paths)
+	files := make([]*codegen.File, len(paths))
+	for i, p := range paths {
+		files[i] = userTypeFile(&codegen.Location{RelImportPath: p}, types[p])
+	}
+	return files
+}
+
+// userTypeFile returns the file that defines the given user types in the
+// package described by loc.
+func userTypeFile(loc *codegen.Location, types []expr.UserType) *codegen.File {
+	var (
+		pkg   = loc.PackageName()
+		scope = codegen.NewNameScope()
+	)
+	header := codegen.Header(
+		"user types shared by the services",
+		pkg,
+		[]*codegen.ImportSpec{
+			{Path: "strconv"},
+			{Path: "time"},
+			codegen.GoaImport(""),
+		})
+	sections := []*codegen.SectionTemplate{header}
+	for _, t := range types {
+		att := &expr.AttributeExpr{Type: t}
+		codegen.AddImport(header, codegen.GetMetaTypeImports(t.Attribute())...)
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "shared-user-type",
+			Source: userTypeT,
+			Data: &UserTypeData{
+				Name:        t.Name(),
+				VarName:     scope.GoFullTypeName(att, pkg),
+				Description: t.Attribute().Description,
+				Def:         scope.GoFullTypeDef(t.Attribute(), pkg, false, true),
+				Ref:         scope.GoFullTypeRef(att, pkg),
+				Type:        t,
+			},
+		})
+		if expr.IsEnumConstType(t) {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "shared-enum",
+				Source: enumT,
+				Data:   buildEnumData(att, pkg, scope),
+			})
+		}
+	}
+	return &codegen.File{Path: loc.FilePath(), SectionTemplates: sections}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"bytes"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestUserTypeFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Path string
+		Code string
+	}{
+		{"shared-types", testdata.SharedTypesDSL, "gen/types/types.go", testdata.SharedTypesFile},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			fs := UserTypeFiles(expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			if fs[0].Path != c.Path {
+				t.Errorf("got path %q, expected %q", fs[0].Path, c.Path)
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range fs[0].SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bs, err := format.Source(buf.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			code := string(bs)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ func ViewsFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		header := codegen.Header(service.Name+" views", "views",
 			[]*codegen.ImportSpec{
 				codegen.GoaImport(""),
+				{Path: "time"},
 				{Path: "unicode/utf8"},
 			})
 		sections = []*codegen.SectionTemplate{header}
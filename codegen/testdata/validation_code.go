@@ -171,6 +171,43 @@ const (
 		err = goa.MergeErrors(err, goa.ValidateFormat("target.string", *target.String, goa.FormatDateTime))
 	}
 }
+`
+
+	DurationRequiredValidationCode = `func Validate() (err error) {
+	if target.RequiredDuration < 1000000000 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError("target.required_duration", target.RequiredDuration, time.Duration(1000000000), true))
+	}
+	if target.RequiredDuration > 3600000000000 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError("target.required_duration", target.RequiredDuration, time.Duration(3600000000000), false))
+	}
+	if target.Duration != nil {
+		if !(*target.Duration == 60000000000 || *target.Duration == 120000000000) {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.duration", time.Duration(*target.Duration), []interface{}{60000000000, 120000000000}))
+		}
+	}
+}
+`
+
+	DurationPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredDuration == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_duration", "target"))
+	}
+	if target.RequiredDuration != nil {
+		if *target.RequiredDuration < 1000000000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.required_duration", *target.RequiredDuration, time.Duration(1000000000), true))
+		}
+	}
+	if target.RequiredDuration != nil {
+		if *target.RequiredDuration > 3600000000000 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.required_duration", *target.RequiredDuration, time.Duration(3600000000000), false))
+		}
+	}
+	if target.Duration != nil {
+		if !(*target.Duration == 60000000000 || *target.Duration == 120000000000) {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.duration", time.Duration(*target.Duration), []interface{}{60000000000, 120000000000}))
+		}
+	}
+}
 `
 
 	UserTypeRequiredValidationCode = `func Validate() (err error) {
@@ -358,5 +395,137 @@ const (
 		}
 	}
 }
+`
+
+	FieldGroupsRequiredValidationCode = `func Validate() (err error) {
+	err = goa.MergeErrors(err, goa.ValidateMutuallyExclusive("target", []string{"string", "array"}, target.String != nil, target.Array != nil))
+	err = goa.MergeErrors(err, goa.ValidateRequiredTogether("target", []string{"int", "default_int"}, target.Int != nil, target.DefaultInt != nil))
+	err = goa.MergeErrors(err, goa.ValidateAtLeastOneOf("target", []string{"required_string", "string"}, true, target.String != nil))
+	err = goa.MergeErrors(err, goa.ValidateRequiredIf("target", "string", "card", target.String != nil && *target.String == "card", []string{"int", "array"}, target.Int != nil, target.Array != nil))
+}
+`
+
+	FieldGroupsPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredString == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_string", "target"))
+	}
+	err = goa.MergeErrors(err, goa.ValidateMutuallyExclusive("target", []string{"string", "array"}, target.String != nil, target.Array != nil))
+	err = goa.MergeErrors(err, goa.ValidateRequiredTogether("target", []string{"int", "default_int"}, target.Int != nil, target.DefaultInt != nil))
+	err = goa.MergeErrors(err, goa.ValidateAtLeastOneOf("target", []string{"required_string", "string"}, target.RequiredString != nil, target.String != nil))
+	err = goa.MergeErrors(err, goa.ValidateRequiredIf("target", "string", "card", target.String != nil && *target.String == "card", []string{"int", "array"}, target.Int != nil, target.Array != nil))
+	err = goa.MergeErrors(err, goa.ValidateRequiredIf("target", "default_int", 2, target.DefaultInt != nil && *target.DefaultInt == 2, []string{"required_string"}, target.RequiredString != nil))
+}
+`
+
+	FieldGroupsUseDefaultValidationCode = `func Validate() (err error) {
+	err = goa.MergeErrors(err, goa.ValidateMutuallyExclusive("target", []string{"string", "array"}, target.String != nil, target.Array != nil))
+	err = goa.MergeErrors(err, goa.ValidateRequiredTogether("target", []string{"int", "default_int"}, target.Int != nil, true))
+	err = goa.MergeErrors(err, goa.ValidateAtLeastOneOf("target", []string{"required_string", "string"}, true, target.String != nil))
+	err = goa.MergeErrors(err, goa.ValidateRequiredIf("target", "string", "card", target.String != nil && *target.String == "card", []string{"int", "array"}, target.Int != nil, target.Array != nil))
+}
+`
+
+	CustomValidationsRequiredValidationCode = `func Validate() (err error) {
+	err = goa.MergeErrors(err, goa.CustomValidationError("target.required_iban", validators.IBAN(target.RequiredIban)))
+	if target.Iban != nil {
+		err = goa.MergeErrors(err, goa.CustomValidationError("target.iban", validators.IBAN(*target.Iban)))
+	}
+	if target.Iban != nil {
+		err = goa.MergeErrors(err, goa.CustomValidationError("target.iban", checks.Checksum(*target.Iban)))
+	}
+}
+`
+
+	CustomValidationsPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredIban == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_iban", "target"))
+	}
+	if target.RequiredIban != nil {
+		err = goa.MergeErrors(err, goa.CustomValidationError("target.required_iban", validators.IBAN(*target.RequiredIban)))
+	}
+	if target.Iban != nil {
+		err = goa.MergeErrors(err, goa.CustomValidationError("target.iban", validators.IBAN(*target.Iban)))
+	}
+	if target.Iban != nil {
+		err = goa.MergeErrors(err, goa.CustomValidationError("target.iban", checks.Checksum(*target.Iban)))
+	}
+}
+`
+
+	SampledValidationsRequiredValidationCode = `func Validate() (err error) {
+	if target.Ids == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("ids", "target"))
+	}
+	for _, e := range target.Ids {
+		if !goa.ValidationSampled(0.1) {
+			continue
+		}
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.ids[*]", e, "^[a-z0-9]{32}$"))
+	}
+	for _, e := range target.Names {
+		if utf8.RuneCountInString(e) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.names[*]", e, utf8.RuneCountInString(e), 1, true))
+		}
+	}
+}
+`
+
+	ValidationMessagesRequiredValidationCode = `func Validate() (err error) {
+	if target.Age < 18 {
+		err = goa.MergeErrors(err, goa.ValidationMessageError(goa.InvalidRangeError("target.age", target.Age, 18, true), "You must be at least 18 years old, got {value}.", target.Age))
+	}
+	if target.Age > 130 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError("target.age", target.Age, 130, false))
+	}
+	if target.Email != nil {
+		err = goa.MergeErrors(err, goa.ValidationMessageError(goa.ValidateFormat("target.email", *target.Email, goa.FormatEmail), "Please enter a valid email address.", *target.Email))
+	}
+	if target.Username != nil {
+		err = goa.MergeErrors(err, goa.ValidationMessageError(goa.ValidatePattern("target.username", *target.Username, "^[a-z]+$"), "{value} may only contain lowercase letters.", *target.Username))
+	}
+	if target.Username != nil {
+		if utf8.RuneCountInString(*target.Username) > 16 {
+			err = goa.MergeErrors(err, goa.ValidationMessageError(goa.InvalidLengthError("target.username", *target.Username, utf8.RuneCountInString(*target.Username), 16, false), "Usernames are at most 16 characters long.", *target.Username))
+		}
+	}
+	if target.Plan != nil {
+		if !(*target.Plan == "free" || *target.Plan == "pro") {
+			err = goa.MergeErrors(err, goa.ValidationMessageError(goa.InvalidEnumValueError("target.plan", *target.Plan, []interface{}{"free", "pro"}), "Unknown plan {value}.", *target.Plan))
+		}
+	}
+}
+`
+
+	ValidationMessagesPointerValidationCode = `func Validate() (err error) {
+	if target.Age == nil {
+		err = goa.MergeErrors(err, goa.ValidationMessageError(goa.MissingFieldError("age", "target"), "Please enter your age.", nil))
+	}
+	if target.Age != nil {
+		if *target.Age < 18 {
+			err = goa.MergeErrors(err, goa.ValidationMessageError(goa.InvalidRangeError("target.age", *target.Age, 18, true), "You must be at least 18 years old, got {value}.", *target.Age))
+		}
+	}
+	if target.Age != nil {
+		if *target.Age > 130 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.age", *target.Age, 130, false))
+		}
+	}
+	if target.Email != nil {
+		err = goa.MergeErrors(err, goa.ValidationMessageError(goa.ValidateFormat("target.email", *target.Email, goa.FormatEmail), "Please enter a valid email address.", *target.Email))
+	}
+	if target.Username != nil {
+		err = goa.MergeErrors(err, goa.ValidationMessageError(goa.ValidatePattern("target.username", *target.Username, "^[a-z]+$"), "{value} may only contain lowercase letters.", *target.Username))
+	}
+	if target.Username != nil {
+		if utf8.RuneCountInString(*target.Username) > 16 {
+			err = goa.MergeErrors(err, goa.ValidationMessageError(goa.InvalidLengthError("target.username", *target.Username, utf8.RuneCountInString(*target.Username), 16, false), "Usernames are at most 16 characters long.", *target.Username))
+		}
+	}
+	if target.Plan != nil {
+		if !(*target.Plan == "free" || *target.Plan == "pro") {
+			err = goa.MergeErrors(err, goa.ValidationMessageError(goa.InvalidEnumValueError("target.plan", *target.Plan, []interface{}{"free", "pro"}), "Unknown plan {value}.", *target.Plan))
+		}
+	}
+}
 `
 )
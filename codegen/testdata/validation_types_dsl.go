@@ -48,6 +48,17 @@ var ValidationTypesDSL = func() {
 			Required("required_string")
 		})
 
+		_ = Type("Duration", func() {
+			Attribute("required_duration", Duration, func() {
+				Minimum("1s")
+				Maximum("PT1H")
+			})
+			Attribute("duration", Duration, func() {
+				Enum("1m", "2m")
+			})
+			Required("required_duration")
+		})
+
 		_ = Type("UserType", func() {
 			Attribute("required_integer", IntegerT)
 			Attribute("default_string", StringT, func() {
@@ -95,5 +106,68 @@ var ValidationTypesDSL = func() {
 			})
 			Required("required_map")
 		})
+
+		_ = Type("FieldGroups", func() {
+			Attribute("required_string", String)
+			Attribute("string", String)
+			Attribute("default_int", Int, func() {
+				Default(1)
+			})
+			Attribute("int", Int)
+			Attribute("array", ArrayOf(String))
+			Required("required_string")
+			MutuallyExclusive("string", "array")
+			RequiredTogether("int", "default_int")
+			AtLeastOneOf("required_string", "string")
+			RequiredIf("string", "card", "int", "array")
+			RequiredIf("default_int", 2, "required_string")
+		})
+
+		_ = Type("CustomValidations", func() {
+			Attribute("required_iban", String, func() {
+				ValidateWith("github.com/acme/validators.IBAN")
+			})
+			Attribute("iban", String, func() {
+				ValidateWith("github.com/acme/validators.IBAN")
+				ValidateWith("github.com/acme/checks/v2.Checksum")
+			})
+			Required("required_iban")
+		})
+
+		_ = Type("SampledValidations", func() {
+			Attribute("ids", ArrayOf(String, func() {
+				Pattern("^[a-z0-9]{32}$")
+			}), func() {
+				Meta("validation:sample", "0.1")
+			})
+			Attribute("names", ArrayOf(String, func() {
+				MinLength(1)
+			}))
+			Required("ids")
+		})
+
+		_ = Type("ValidationMessages", func() {
+			Attribute("age", Int, func() {
+				Minimum(18)
+				Maximum(130)
+				ValidationMessage("minimum", "You must be at least 18 years old, got {value}.")
+				ValidationMessage("required", "Please enter your age.")
+			})
+			Attribute("email", String, func() {
+				Format(FormatEmail)
+				ValidationMessage("format", "Please enter a valid email address.")
+			})
+			Attribute("username", String, func() {
+				Pattern("^[a-z]+$")
+				MaxLength(16)
+				ValidationMessage("pattern", "{value} may only contain lowercase letters.")
+				ValidationMessage("maxLength", "Usernames are at most 16 characters long.")
+			})
+			Attribute("plan", String, func() {
+				Enum("free", "pro")
+				ValidationMessage("enum", "Unknown plan {value}.")
+			})
+			Required("age")
+		})
 	)
 }
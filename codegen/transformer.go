@@ -111,13 +111,27 @@ func IsCompatible(a, b expr.DataType, actx, bctx string) error {
 			return fmt.Errorf("%s is a hash but %s type is %s", actx, bctx, b.Name())
 		}
 	default:
-		if a.Kind() != b.Kind() {
+		if transformKind(a) != transformKind(b) {
 			return fmt.Errorf("%s is a %s but %s type is %s", actx, a.Name(), bctx, b.Name())
 		}
 	}
 	return nil
 }
 
+// transformKind returns the kind of the underlying primitive type if dt is a
+// user type using EnumConstants, StringKind if dt is UUID or Decimal and the
+// kind of dt otherwise. Enum constant types are converted to and from their
+// base type and UUIDs and decimals to and from strings by the transform code.
+func transformKind(dt expr.DataType) expr.Kind {
+	if expr.IsEnumConstType(dt) {
+		return dt.(expr.UserType).Attribute().Type.Kind()
+	}
+	if dt == expr.UUID || dt == expr.Decimal {
+		return expr.StringKind
+	}
+	return dt.Kind()
+}
+
 // AppendHelpers takes care of only appending helper functions from newH that
 // are not already in oldH.
 func AppendHelpers(oldH, newH []*TransformFunctionData) []*TransformFunctionData {
@@ -173,6 +187,9 @@ func mapDepth(dt expr.DataType, depth int, seen ...map[string]struct{}) int {
 				level = lvl
 			}
 		}
+		// seen only guards against recursive types, the same type may appear
+		// in multiple attributes at different depths.
+		delete(s, key)
 		depth += level
 	}
 	return depth
@@ -184,6 +201,9 @@ func (a *AttributeContext) IsPrimitivePointer(name string, att *expr.AttributeEx
 	if at := att.Find(name); at != nil && at.Type == expr.Any || at.Type == expr.Bytes {
 		return false
 	}
+	if at := att.Find(name); at != nil && at.IsOptionalField() {
+		return false
+	}
 	if a.Pointer {
 		return true
 	}
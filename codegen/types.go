@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
@@ -36,11 +37,97 @@ func GoNativeTypeName(t expr.DataType) string {
 		return "[]byte"
 	case expr.AnyKind:
 		return "interface{}"
+	case expr.DurationKind:
+		return "time.Duration"
+	case expr.UUIDKind:
+		return "goa.UUID"
+	case expr.TimestampKind:
+		return "time.Time"
+	case expr.DecimalKind:
+		name, _ := DecimalType()
+		return name
 	default:
 		panic(fmt.Sprintf("cannot compute native Go type for %T", t)) // bug
 	}
 }
 
+// DecimalType returns the qualified name and the import path of the Go type
+// used to hold the values of Decimal attributes, see the DecimalType DSL. The
+// import path is empty when the default goa.Decimal type is used.
+func DecimalType() (name, path string) {
+	if expr.Root != nil && expr.Root.API != nil {
+		if v := expr.Root.API.Meta["goa:decimal:type"]; len(v) == 2 {
+			return v[0], v[1]
+		}
+	}
+	return "goa.Decimal", ""
+}
+
+// DecimalFromString returns the Go expression that converts the string
+// expression s holding a valid decimal representation into a value of the Go
+// type used to hold Decimal values.
+func DecimalFromString(s string) string {
+	name, path := DecimalType()
+	if path == "" {
+		return fmt.Sprintf("goa.MustParseDecimal(%s)", s)
+	}
+	return fmt.Sprintf("func() %s { var d %s; _ = d.UnmarshalText([]byte(%s)); return d }()", name, name, s)
+}
+
+// Location describes the package that contains the Go types generated for the
+// user types that define the "struct:pkg:path" metadata.
+type Location struct {
+	// RelImportPath is the import path of the package relative to the gen
+	// package, e.g. "types".
+	RelImportPath string
+}
+
+// UserTypeLocation returns the location of the package that contains the Go
+// type generated for dt if dt is a design user type (or a copy of one) that
+// defines the "struct:pkg:path" metadata, nil otherwise. Types derived from
+// design types such as HTTP body types inherit the metadata but are renamed
+// and generated in the transport packages so that only the types named after a
+// design type are considered.
+func UserTypeLocation(dt expr.DataType) *Location {
+	ut, ok := dt.(expr.UserType)
+	if !ok || expr.Root == nil {
+		return nil
+	}
+	p := expr.PackagePath(ut)
+	if p == "" {
+		return nil
+	}
+	if t := expr.Root.UserType(ut.Name()); t == nil || expr.PackagePath(t) != p {
+		return nil
+	}
+	return &Location{RelImportPath: p}
+}
+
+// PackageName returns the name of the package, i.e. the last element of its
+// import path.
+func (loc *Location) PackageName() string {
+	return path.Base(loc.RelImportPath)
+}
+
+// FilePath returns the path of the file that contains the type definitions
+// relative to the output directory.
+func (loc *Location) FilePath() string {
+	return path.Join(Gendir, loc.RelImportPath, loc.PackageName()+".go")
+}
+
+// GoOptionalTypeName returns the name of the goa.Optional wrapper type that
+// holds values of the given primitive type, see the OptionalFields DSL.
+func GoOptionalTypeName(t expr.DataType) string {
+	switch t.Kind() {
+	case expr.BooleanKind:
+		return "goa.OptionalBool"
+	case expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		return "goa.OptionalU" + Goify(GoNativeTypeName(t)[1:], true)
+	default:
+		return "goa.Optional" + Goify(GoNativeTypeName(t), true)
+	}
+}
+
 // AttributeTags computes the struct field tags from its metadata if any.
 func AttributeTags(parent, att *expr.AttributeExpr) string {
 	var elems []string
@@ -148,3 +148,34 @@ func TestGoify(t *testing.T) {
 		}
 	}
 }
+
+func TestGoifyNaming(t *testing.T) {
+	cases := map[string]struct {
+		meta       expr.MetaExpr
+		str        string
+		firstUpper bool
+		expected   string
+	}{
+		"initialism":            {expr.MetaExpr{"goa:naming:initialisms": {"SKU"}}, "item_sku", true, "ItemSKU"},
+		"initialism first":      {expr.MetaExpr{"goa:naming:initialisms": {"sku"}}, "sku_id", false, "skuID"},
+		"removed initialism":    {expr.MetaExpr{"goa:naming:initialisms": {"-ID"}}, "user_id", true, "UserId"},
+		"title acronyms":        {expr.MetaExpr{"goa:naming:acronyms": {"title"}}, "api_url", true, "ApiUrl"},
+		"title acronyms lower":  {expr.MetaExpr{"goa:naming:acronyms": {"title"}}, "api_url", false, "apiUrl"},
+		"upper acronyms":        {expr.MetaExpr{"goa:naming:acronyms": {"upper"}}, "api_url", true, "APIURL"},
+		"prefix":                {expr.MetaExpr{"goa:naming:prefix": {"acme_", "corp"}}, "acme_user", true, "User"},
+		"second prefix":         {expr.MetaExpr{"goa:naming:prefix": {"acme_", "corp"}}, "corpUser", false, "user"},
+		"prefix whole name":     {expr.MetaExpr{"goa:naming:prefix": {"acme_"}}, "acme_", true, "Acme"},
+		"prefix no match":       {expr.MetaExpr{"goa:naming:prefix": {"acme_"}}, "user_acme_", true, "UserAcme"},
+		"prefix transport name": {expr.MetaExpr{"goa:naming:prefix": {"acme_"}}, "acme_id:id", true, "ID"},
+	}
+
+	root := expr.Root
+	defer func() { expr.Root = root }()
+	for k, tc := range cases {
+		expr.Root = &expr.RootExpr{API: &expr.APIExpr{Name: "test", Meta: tc.meta}}
+		actual := Goify(tc.str, tc.firstUpper)
+		if actual != tc.expected {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
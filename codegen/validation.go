@@ -13,10 +13,15 @@ import (
 var (
 	enumValT     *template.Template
 	formatValT   *template.Template
+	timeZoneValT *template.Template
+	decimalValT  *template.Template
 	patternValT  *template.Template
 	minMaxValT   *template.Template
 	lengthValT   *template.Template
 	requiredValT *template.Template
+	groupValT    *template.Template
+	reqIfValT    *template.Template
+	customValT   *template.Template
 	arrayValT    *template.Template
 	mapValT      *template.Template
 	userValT     *template.Template
@@ -29,13 +34,20 @@ func init() {
 		"constant": constant,
 		"add":      func(a, b int) int { return a + b },
 		"isset":    func(i interface{}) bool { return i != nil },
+		"msgOpen":  messageOpen,
+		"msgClose": messageClose,
 	}
 	enumValT = template.Must(template.New("enum").Funcs(fm).Parse(enumValTmpl))
 	formatValT = template.Must(template.New("format").Funcs(fm).Parse(formatValTmpl))
+	timeZoneValT = template.Must(template.New("timeZone").Funcs(fm).Parse(timeZoneValTmpl))
+	decimalValT = template.Must(template.New("decimal").Funcs(fm).Parse(decimalValTmpl))
 	patternValT = template.Must(template.New("pattern").Funcs(fm).Parse(patternValTmpl))
 	minMaxValT = template.Must(template.New("minMax").Funcs(fm).Parse(minMaxValTmpl))
 	lengthValT = template.Must(template.New("length").Funcs(fm).Parse(lengthValTmpl))
 	requiredValT = template.Must(template.New("req").Funcs(fm).Parse(requiredValTmpl))
+	groupValT = template.Must(template.New("group").Funcs(fm).Parse(groupValTmpl))
+	reqIfValT = template.Must(template.New("requiredIf").Funcs(fm).Parse(reqIfValTmpl))
+	customValT = template.Must(template.New("custom").Funcs(fm).Parse(customValTmpl))
 	arrayValT = template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl))
 	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
@@ -71,6 +83,12 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 		isPointer       = attCtx.Pointer || !attCtx.IgnoreRequired && (!req && (att.DefaultValue == nil || !attCtx.UseDefault))
 		tval            = target
 	)
+	if att.IsOptionalField() {
+		// validate the value held by the goa.Optional wrapper
+		target = target + ".Value"
+		tval = target
+		isPointer = true
+	}
 	if isPointer && expr.IsPrimitive(att.Type) && !isNativePointer {
 		tval = "*" + tval
 	}
@@ -82,6 +100,7 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 		"target":    target,
 		"targetVal": tval,
 		"string":    kind == expr.StringKind,
+		"duration":  kind == expr.DurationKind,
 		"array":     expr.IsArray(att.Type),
 		"map":       expr.IsMap(att.Type),
 		"zeroVal":   att.ZeroValue,
@@ -96,25 +115,51 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 	var res []string
 	if values := validation.Values; values != nil {
 		data["values"] = values
+		data["message"] = validation.Message("enum")
 		if val := runTemplate(enumValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
 	if format := validation.Format; format != "" {
 		data["format"] = string(format)
+		data["message"] = validation.Message("format")
 		if val := runTemplate(formatValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
+	if tz := validation.TimeZone; tz != "" {
+		data["timeZone"] = tz
+		data["message"] = validation.Message("timeZone")
+		if val := runTemplate(timeZoneValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
+	if validation.Precision != nil || validation.Scale != nil {
+		data["precision"], data["scale"] = -1, -1
+		if validation.Precision != nil {
+			data["precision"] = *validation.Precision
+		}
+		if validation.Scale != nil {
+			data["scale"] = *validation.Scale
+		}
+		if val := runTemplate(decimalValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if pattern := validation.Pattern; pattern != "" {
 		data["pattern"] = pattern
+		data["message"] = validation.Message("pattern")
 		if val := runTemplate(patternValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
 	if min := validation.Minimum; min != nil {
 		data["min"] = *min
+		if kind == expr.DurationKind {
+			data["min"] = int64(*min)
+		}
 		data["isMin"] = true
+		data["message"] = validation.Message("minimum")
 		delete(data, "max")
 		if val := runTemplate(minMaxValT, data); val != "" {
 			res = append(res, val)
@@ -122,7 +167,11 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 	}
 	if max := validation.Maximum; max != nil {
 		data["max"] = *max
+		if kind == expr.DurationKind {
+			data["max"] = int64(*max)
+		}
 		data["isMin"] = false
+		data["message"] = validation.Message("maximum")
 		delete(data, "min")
 		if val := runTemplate(minMaxValT, data); val != "" {
 			res = append(res, val)
@@ -131,6 +180,7 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 	if minLength := validation.MinLength; minLength != nil {
 		data["minLength"] = minLength
 		data["isMinLength"] = true
+		data["message"] = validation.Message("minLength")
 		delete(data, "maxLength")
 		if val := runTemplate(lengthValT, data); val != "" {
 			res = append(res, val)
@@ -139,11 +189,24 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 	if maxLength := validation.MaxLength; maxLength != nil {
 		data["maxLength"] = maxLength
 		data["isMinLength"] = false
+		data["message"] = validation.Message("maxLength")
 		delete(data, "minLength")
 		if val := runTemplate(lengthValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
+	for _, fn := range validation.Funcs {
+		_, call := ValidateFuncImport(fn)
+		if call == "" {
+			continue
+		}
+		arg := tval
+		if expr.IsEnumConstType(att.Type) {
+			arg = fmt.Sprintf("%s(%s)", GoNativeTypeName(att.Type), tval)
+		}
+		data["call"] = fmt.Sprintf("%s(%s)", call, arg)
+		res = append(res, runTemplate(customValT, data))
+	}
 	if req := validation.Required; len(req) > 0 {
 		obj := expr.AsObject(att.Type)
 		for _, r := range req {
@@ -159,12 +222,115 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool,
 			}
 			data["req"] = r
 			data["reqAtt"] = reqAtt
+			data["message"] = reqAtt.Validation.Message("required")
 			res = append(res, runTemplate(requiredValT, data))
 		}
 	}
+	groups := []struct {
+		validator string
+		groups    [][]string
+	}{
+		{"ValidateMutuallyExclusive", validation.MutuallyExclusive},
+		{"ValidateRequiredTogether", validation.RequiredTogether},
+		{"ValidateAtLeastOneOf", validation.AtLeastOneOf},
+	}
+	for _, g := range groups {
+		for _, names := range g.groups {
+			set := make([]string, len(names))
+			for i, n := range names {
+				set[i] = fieldSetCode(att, attCtx, n, target)
+			}
+			data["validator"] = g.validator
+			data["names"] = names
+			data["set"] = set
+			res = append(res, runTemplate(groupValT, data))
+		}
+	}
+	for _, r := range validation.RequiredIf {
+		var names, set []string
+		for _, n := range r.Names {
+			code := fieldSetCode(att, attCtx, n, target)
+			if code == "true" {
+				// field is always set
+				continue
+			}
+			names = append(names, n)
+			set = append(set, code)
+		}
+		if len(names) == 0 {
+			continue
+		}
+		data["field"] = r.Field
+		data["value"] = fmt.Sprintf("%#v", r.Value)
+		data["cond"] = fieldValueCode(att, attCtx, r.Field, target, data["value"].(string))
+		data["names"] = names
+		data["set"] = set
+		res = append(res, runTemplate(reqIfValT, data))
+	}
 	return strings.Join(res, "\n")
 }
 
+// messageOpen returns the code that opens the call to goa.ValidationMessageError
+// wrapping the validation error if msg is a non-empty custom message.
+func messageOpen(msg interface{}) string {
+	if m, _ := msg.(string); m != "" {
+		return "goa.ValidationMessageError("
+	}
+	return ""
+}
+
+// messageClose returns the code that closes the call opened by messageOpen
+// given the custom message and the code of the offending value.
+func messageClose(msg interface{}, val string) string {
+	if m, _ := msg.(string); m != "" {
+		return fmt.Sprintf(", %q, %s)", m, val)
+	}
+	return ""
+}
+
+// fieldSetCode returns the Go expression that evaluates to true when the field
+// with the given name of the object held by target is set. Fields that are
+// neither pointers nor goa.Optional values and that do not have a zero value
+// are always set.
+func fieldSetCode(att *expr.AttributeExpr, attCtx *AttributeContext, name, target string) string {
+	fatt := att.Find(name)
+	if fatt == nil {
+		return "false"
+	}
+	field := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(fatt, name, true))
+	switch {
+	case fatt.IsOptionalField():
+		return field + ".Set"
+	case !expr.IsPrimitive(fatt.Type) || fatt.Type.Kind() == expr.BytesKind || fatt.Type.Kind() == expr.AnyKind:
+		return field + " != nil"
+	case attCtx.Pointer || !attCtx.IgnoreRequired && att.IsPrimitivePointer(name, attCtx.UseDefault):
+		return field + " != nil"
+	case fatt.ZeroValue != nil:
+		return fmt.Sprintf("%s != %#v", field, fatt.ZeroValue)
+	default:
+		return "true"
+	}
+}
+
+// fieldValueCode returns the Go expression that evaluates to true when the
+// primitive field with the given name of the object held by target is set to
+// the value represented by the Go literal lit.
+func fieldValueCode(att *expr.AttributeExpr, attCtx *AttributeContext, name, target, lit string) string {
+	fatt := att.Find(name)
+	if fatt == nil {
+		return "false"
+	}
+	field := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(fatt, name, true))
+	switch set := fieldSetCode(att, attCtx, name, target); {
+	case fatt.IsOptionalField():
+		return fmt.Sprintf("%s.Value != nil && *%s.Value == %s", field, field, lit)
+	case strings.HasSuffix(set, " != nil"):
+		return fmt.Sprintf("%s && *%s == %s", set, field, lit)
+	default:
+		return fmt.Sprintf("%s == %s", field, lit)
+	}
+}
+
 // RecursiveValidationCode produces Go code that runs the validations defined in
 // the given attribute and its children recursively against the value held by
 // the variable named target. See ValidationCode for a description of the
@@ -239,6 +405,9 @@ func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, re
 				"target":     target,
 				"validation": val,
 			}
+			if rate, ok := att.ValidationSampleRate(); ok {
+				data["sampleRate"] = rate
+			}
 			if !first {
 				buf.WriteByte('\n')
 			} else {
@@ -353,6 +522,67 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 	return validation
 }
 
+// DateTimeNormalizationCode produces Go code that converts the values of the
+// date-time attributes that define a time zone validation to UTC in the data
+// structure held by the variable named target. The generated code must run
+// after the validations. See ValidationCode for a description of the
+// arguments.
+func DateTimeNormalizationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target string) string {
+	return normalizeDateTimeCode(att, attCtx, req, target, make(map[string]bool))
+}
+
+func normalizeDateTimeCode(att *expr.AttributeExpr, attCtx *AttributeContext, req bool, target string, seen map[string]bool) string {
+	if ut, ok := att.Type.(expr.UserType); ok {
+		if seen[ut.ID()] {
+			return ""
+		}
+		seen[ut.ID()] = true
+		defer delete(seen, ut.ID())
+	}
+	switch {
+	case expr.IsObject(att.Type):
+		var res []string
+		for _, nat := range *expr.AsObject(att.Type) {
+			tgt := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(nat.Attribute, nat.Name, true))
+			code := normalizeDateTimeCode(nat.Attribute, attCtx, att.IsRequired(nat.Name), tgt, seen)
+			if code == "" {
+				continue
+			}
+			if expr.IsObject(nat.Attribute.Type) {
+				code = fmt.Sprintf("if %s != nil {\n%s\n}", tgt, code)
+			}
+			res = append(res, code)
+		}
+		return strings.Join(res, "\n")
+	case expr.IsArray(att.Type):
+		ctx := attCtx
+		elem := expr.AsArray(att.Type).ElemType
+		if ctx.Pointer && expr.IsPrimitive(elem.Type) {
+			ctx = attCtx.Dup()
+			ctx.Pointer = false
+		}
+		code := normalizeDateTimeCode(elem, ctx, true, target+"[i]", seen)
+		if code == "" {
+			return ""
+		}
+		return fmt.Sprintf("for i := range %s {\n%s\n}", target, code)
+	case att.Type == expr.String:
+		if att.Validation == nil || att.Validation.TimeZone == "" {
+			return ""
+		}
+		isPointer := attCtx.Pointer || !attCtx.IgnoreRequired && (!req && (att.DefaultValue == nil || !attCtx.UseDefault))
+		if att.IsOptionalField() {
+			target = target + ".Value"
+			isPointer = true
+		}
+		if isPointer {
+			return fmt.Sprintf("if %s != nil {\n*%s = goa.NormalizeDateTime(*%s)\n}", target, target, target)
+		}
+		return fmt.Sprintf("%s = goa.NormalizeDateTime(%s)", target, target)
+	}
+	return ""
+}
+
 // toSlice returns Go code that represents the given slice.
 func toSlice(val []interface{}) string {
 	elems := make([]string, len(val))
@@ -403,12 +633,35 @@ func constant(formatName string) string {
 		return "goa.FormatJSON"
 	case "rfc1123":
 		return "goa.FormatRFC1123"
+	case "decimal":
+		return "goa.FormatDecimal"
+	case "semver":
+		return "goa.FormatSemver"
+	case "base64":
+		return "goa.FormatBase64"
+	case "e164":
+		return "goa.FormatE164"
+	case "ulid":
+		return "goa.FormatULID"
+	case "duration":
+		return "goa.FormatDuration"
+	case "credit-card":
+		return "goa.FormatCreditCard"
+	case "country-code":
+		return "goa.FormatCountryCode"
+	case "currency-code":
+		return "goa.FormatCurrencyCode"
 	}
 	panic("unknown format") // bug
 }
 
 const (
 	arrayValTmpl = `for _, e := range {{ .target }} {
+{{- if .sampleRate }}
+if !goa.ValidationSampled({{ .sampleRate }}) {
+        continue
+}
+{{- end }}
 {{ .validation }}
 }`
 
@@ -427,7 +680,7 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
 if !({{ oneof .targetVal .values }}) {
-        err = goa.MergeErrors(err, goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ .targetVal }}, {{ slice .values }}))
+        err = goa.MergeErrors(err, {{ msgOpen .message }}goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ if .duration }}time.Duration({{ .targetVal }}){{ else }}{{ .targetVal }}{{ end }}, {{ slice .values }}){{ msgClose .message .targetVal }})
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{ end -}}
@@ -438,7 +691,7 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}))
+        err = goa.MergeErrors(err, {{ msgOpen .message }}goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}){{ msgClose .message .targetVal }})
 {{- if or (isset .zeroVal) .isPointer }}
 }
 {{- end }}`
@@ -448,9 +701,27 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 {{ else if .isPointer -}}
 if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}))
+        err = goa.MergeErrors(err, {{ msgOpen .message }}goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}){{ msgClose .message .targetVal }})
 {{ if or (isset .zeroVal) .isPointer -}}
 }
+{{- end }}`
+
+	timeZoneValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }}{{ end }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+        err = goa.MergeErrors(err, {{ msgOpen .message }}goa.ValidateTimeZone({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .timeZone }}){{ msgClose .message .targetVal }})
+{{ if or (isset .zeroVal) .isPointer -}}
+}
+{{- end }}`
+
+	decimalValTmpl = `{{ if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+        err = goa.MergeErrors(err, goa.ValidateDecimal({{ printf "%q" .context }}, {{ .targetVal }}, {{ .precision }}, {{ .scale }}))
+{{ if .isPointer -}}
+}
 {{- end }}`
 
 	minMaxValTmpl = `{{ if isset .zeroVal -}}
@@ -459,7 +730,7 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
         if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ msgOpen .message }}goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .duration }}time.Duration({{ end }}{{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }}{{ if .duration }}){{ end }}, {{ if .isMin }}true{{ else }}false{{ end }}){{ msgClose .message .targetVal }})
 {{ if or (isset .zeroVal) .isPointer -}}
 }
 {{ end -}}
@@ -472,12 +743,28 @@ if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }
 if {{ .target }} != nil {
 {{ end -}}
 if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }} {{ if .isMinLength }}<{{ else }}>{{ end }} {{ if .isMinLength }}{{ .minLength }}{{ else }}{{ .maxLength }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ msgOpen .message }}goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}){{ msgClose .message $target }})
 }{{- if and (or (isset .zeroVal) .isPointer) .string }}
 }
 {{- end }}`
 
 	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
-        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
+        err = goa.MergeErrors(err, {{ msgOpen .message }}goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}){{ msgClose .message "nil" }})
 }`
+
+	groupValTmpl = `err = goa.MergeErrors(err, goa.{{ .validator }}({{ printf "%q" .context }}, []string{ {{- range $i, $n := .names }}{{ if $i }}, {{ end }}{{ printf "%q" $n }}{{ end -}} }
+{{- range .set }}, {{ . }}{{ end }}))`
+
+	reqIfValTmpl = `err = goa.MergeErrors(err, goa.ValidateRequiredIf({{ printf "%q" .context }}, {{ printf "%q" .field }}, {{ .value }}, {{ .cond }}, []string{ {{- range $i, $n := .names }}{{ if $i }}, {{ end }}{{ printf "%q" $n }}{{ end -}} }
+{{- range .set }}, {{ . }}{{ end }}))`
+
+	customValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }}{{ end }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+        err = goa.MergeErrors(err, goa.CustomValidationError({{ printf "%q" .context }}, {{ .call }}))
+{{- if or (isset .zeroVal) .isPointer }}
+}
+{{- end }}`
 )
@@ -15,10 +15,15 @@ func TestRecursiveValidationCode(t *testing.T) {
 		integerT = root.UserType("Integer")
 		stringT  = root.UserType("String")
 		floatT   = root.UserType("Float")
+		durT     = root.UserType("Duration")
 		userT    = root.UserType("UserType")
 		arrayUT  = root.UserType("ArrayUserType")
 		arrayT   = root.UserType("Array")
 		mapT     = root.UserType("Map")
+		groupsT  = root.UserType("FieldGroups")
+		customT  = root.UserType("CustomValidations")
+		sampledT = root.UserType("SampledValidations")
+		messageT = root.UserType("ValidationMessages")
 	)
 	cases := []struct {
 		Name       string
@@ -37,6 +42,8 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"string-required", stringT, true, false, false, testdata.StringRequiredValidationCode},
 		{"string-pointer", stringT, false, true, false, testdata.StringPointerValidationCode},
 		{"string-use-default", stringT, false, false, true, testdata.StringUseDefaultValidationCode},
+		{"duration-required", durT, true, false, false, testdata.DurationRequiredValidationCode},
+		{"duration-pointer", durT, false, true, false, testdata.DurationPointerValidationCode},
 		{"user-type-required", userT, true, false, false, testdata.UserTypeRequiredValidationCode},
 		{"user-type-pointer", userT, false, true, false, testdata.UserTypePointerValidationCode},
 		{"user-type-default", userT, false, false, true, testdata.UserTypeUseDefaultValidationCode},
@@ -47,6 +54,14 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"map-required", mapT, true, false, false, testdata.MapRequiredValidationCode},
 		{"map-pointer", mapT, false, true, false, testdata.MapPointerValidationCode},
 		{"map-use-default", mapT, false, false, true, testdata.MapUseDefaultValidationCode},
+		{"field-groups-required", groupsT, true, false, false, testdata.FieldGroupsRequiredValidationCode},
+		{"field-groups-pointer", groupsT, false, true, false, testdata.FieldGroupsPointerValidationCode},
+		{"field-groups-use-default", groupsT, false, false, true, testdata.FieldGroupsUseDefaultValidationCode},
+		{"custom-validations-required", customT, true, false, false, testdata.CustomValidationsRequiredValidationCode},
+		{"custom-validations-pointer", customT, false, true, false, testdata.CustomValidationsPointerValidationCode},
+		{"sampled-validations-required", sampledT, true, false, false, testdata.SampledValidationsRequiredValidationCode},
+		{"validation-messages-required", messageT, true, false, false, testdata.ValidationMessagesRequiredValidationCode},
+		{"validation-messages-pointer", messageT, false, true, false, testdata.ValidationMessagesPointerValidationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
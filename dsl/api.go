@@ -277,3 +277,92 @@ func URL(url string) {
 		eval.IncompatibleDSL()
 	}
 }
+
+// DecimalType sets the Go type used by the generated code to hold the values
+// of Decimal attributes, goa.Decimal by default. The type must implement
+// encoding.TextUnmarshaler with a pointer receiver and fmt.Stringer, and must
+// be encoded as a string or number by encoding/json.
+//
+// DecimalType must appear in a API expression.
+//
+// DecimalType takes two arguments: the qualified name of the Go type and the
+// import path of its package.
+//
+// Example:
+//
+//    var _ = API("billing", func() {
+//        DecimalType("decimal.Decimal", "github.com/shopspring/decimal")
+//    })
+//
+func DecimalType(typeName, importPath string) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if typeName == "" || importPath == "" {
+		eval.ReportError("decimal type name and import path cannot be empty")
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["goa:decimal:type"] = []string{typeName, importPath}
+}
+
+// HealthCheck makes the generated example servers expose health check
+// endpoints: the HTTP servers handle "GET /healthz" (liveness) and
+// "GET /readyz" (readiness) and the gRPC servers implement the standard gRPC
+// health service (grpc.health.v1.Health) which reports readiness. The
+// endpoints run the checks registered with the goa.Health value declared in
+// the generated health.go file of the example server, use its
+// AddLivenessCheck and AddReadinessCheck methods to register the checks of the
+// service dependencies. The endpoints report a healthy server if no check is
+// registered.
+//
+// HealthCheck must appear in a API expression.
+//
+// HealthCheck takes no argument.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HealthCheck()
+//    })
+//
+func HealthCheck() {
+	if a, ok := eval.Current().(*expr.APIExpr); ok {
+		a.HealthCheck = true
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// StrictMeta makes the design evaluation fail when a Meta expression uses a
+// key that has no special meaning for goa, for example because of a typo such
+// as "struct:feild:name" which would otherwise be silently ignored. The
+// evaluation errors suggest the closest known key.
+//
+// StrictMeta must appear in a API expression.
+//
+// StrictMeta accepts an optional list of additional keys allowed in the
+// design, e.g. keys used by plugins or custom generators. Keys ending with ":"
+// allow all the keys that start with them.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        StrictMeta("plugin:option", "myplugin:")
+//    })
+//
+func StrictMeta(keys ...string) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["goa:meta:strict"] = append(a.Meta["goa:meta:strict"], keys...)
+}
@@ -2,6 +2,7 @@ package dsl
 
 import (
 	"fmt"
+	"time"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
@@ -179,7 +180,9 @@ func Attribute(name string, args ...interface{}) {
 // Field must appear wherever Attribute can.
 //
 // Field takes the same arguments as Attribute with the addition of the tag
-// value as first argument.
+// value as first argument. The tag is used as the protocol buffer field number
+// and must be between 1 and 536870911 excluding the range 19000 to 19999
+// reserved by protocol buffers. Tags must be unique within a type.
 //
 // Example:
 //
@@ -215,9 +218,218 @@ func Default(def interface{}) {
 			def, expr.QualifiedTypeName(a.Type))
 		return
 	}
+	if a.Type == expr.UUID {
+		eval.ReportError("UUID attributes cannot have a default value")
+		return
+	}
+	if a.Type == expr.Timestamp {
+		eval.ReportError("Timestamp attributes cannot have a default value")
+		return
+	}
+	if a.Type == expr.Decimal {
+		eval.ReportError("Decimal attributes cannot have a default value")
+		return
+	}
+	if a.Type == expr.Duration {
+		def, _ = expr.DurationValue(def)
+	}
 	a.SetDefault(def)
 }
 
+// DefaultFrom sets the default value of an attribute to the value of another
+// attribute of the same object. The generated code that initializes method
+// payloads from requests copies the value of the other attribute when the
+// attribute is not set.
+//
+// DefaultFrom must appear in an Attribute DSL. The attribute must not be
+// required and must not define a Default value. The other attribute must be
+// defined in the same object, have the same type and must not use DefaultFrom
+// itself.
+//
+// DefaultFrom takes one argument: the name of the other attribute.
+//
+// Example:
+//
+//    var User = Type("User", func() {
+//        Attribute("username", String)
+//        Attribute("display_name", String, func() {
+//            DefaultFrom("username")
+//        })
+//        Required("username")
+//    })
+//
+func DefaultFrom(name string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["goa:defaultfrom"] = []string{name}
+}
+
+// Encrypted marks an attribute as encrypted. The generated HTTP encoders
+// encrypt the value of encrypted body attributes before writing them and the
+// generated decoders decrypt them after reading. Encryption and decryption is
+// delegated to the goahttp.KMS found in the request context, see
+// goahttp.ContextWithKMS. Encrypted strings are base64 encoded on the wire.
+//
+// Encrypted must appear in an Attribute DSL. The attribute must be of type
+// String or Bytes and must be a top level attribute of a request, response or
+// error body.
+//
+// Encrypted takes no argument.
+//
+// Example:
+//
+//    var Account = Type("Account", func() {
+//        Attribute("name", String)
+//        Attribute("ssn", String, func() {
+//            Encrypted()
+//        })
+//    })
+//
+func Encrypted() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["goa:encrypted"] = nil
+}
+
+// LogField adds the value of a method payload attribute to the log context of
+// the requests. The generated endpoints add the values of the payload
+// attributes that use LogField to the request log context with
+// middleware.AddLogFields before calling the service method so that the
+// entries produced by the logging middlewares and by the service code using
+// middleware.LogFields are correlated without per-endpoint logging code.
+// Attributes that are not set are not added.
+//
+// LogField must appear in an Attribute DSL. The attribute must be of a
+// primitive type. Only the top level attributes of method payloads are added
+// to the log context.
+//
+// LogField accepts one optional argument: the log key, defaults to the
+// attribute name.
+//
+// Example:
+//
+//    Method("ship", func() {
+//        Payload(func() {
+//            Attribute("order_id", String, func() {
+//                LogField()
+//            })
+//            Attribute("carrier", String, func() {
+//                LogField("shipping_carrier")
+//            })
+//        })
+//    })
+//
+func LogField(key ...string) {
+	if len(key) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["goa:logfield"] = key
+}
+
+// Sensitive marks an attribute as holding personal or otherwise sensitive
+// data. The service package defines an Anonymize method on the types that
+// contain sensitive attributes, directly or via nested types. Anonymize
+// returns a copy of the value where the sensitive attributes are masked or
+// hashed, use it to export values to analytics systems or to build log and
+// audit events.
+//
+// Sensitive must appear in an Attribute DSL. The attribute must be of type
+// String. Anonymize is not generated for types that use the "struct:pkg:path"
+// metadata.
+//
+// Sensitive accepts one optional argument: the anonymization strategy,
+// SensitiveMask (default) replaces each character of the value with an
+// asterisk while SensitiveHash replaces the value with its hex encoded
+// SHA-256 hash so that anonymized values can still be correlated.
+//
+// Example:
+//
+//    var Customer = Type("Customer", func() {
+//        Attribute("name", String)
+//        Attribute("email", String, func() {
+//            Sensitive(SensitiveHash)
+//        })
+//        Attribute("phone", String, func() {
+//            Sensitive()
+//        })
+//    })
+//
+func Sensitive(strategy ...string) {
+	if len(strategy) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	s := expr.SensitiveMask
+	if len(strategy) == 1 {
+		s = strategy[0]
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["goa:sensitive"] = []string{s}
+}
+
+// TimestampFormat sets the wire format of a Timestamp attribute. The generated
+// HTTP transport code encodes and decodes the values of the attribute in
+// bodies, parameters and headers using the format while the service types use
+// time.Time. The format does not apply to gRPC which always uses
+// google.protobuf.Timestamp. Timestamps used as keys or values of maps in
+// query strings are always encoded as RFC 3339 strings.
+//
+// TimestampFormat must appear in the DSL of an attribute of type Timestamp
+// or of type array of Timestamp in which case the format applies to the
+// elements.
+//
+// TimestampFormat takes one argument: the format, one of TimestampRFC3339
+// (default), TimestampUnix or TimestampUnixMilli.
+//
+// Example:
+//
+//    Attribute("created_at", Timestamp, func() {
+//        TimestampFormat(TimestampUnix)
+//    })
+//
+func TimestampFormat(format string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if arr := expr.AsArray(a.Type); arr != nil {
+		a = arr.ElemType
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["goa:timestamp:format"] = []string{format}
+}
+
 // Example provides an example value for a type, a parameter, a header or any
 // attribute. Example supports two syntaxes: one syntax accepts two arguments
 // where the first argument is a summary describing the example and the second a
@@ -298,6 +510,17 @@ func Example(args ...interface{}) {
 				ex.Value, a.Type.Name())
 			return
 		}
+		if a.Type == expr.Duration {
+			d, _ := expr.DurationValue(ex.Value)
+			ex.Value = d.String()
+		}
+		if a.Type == expr.Timestamp {
+			t, _ := expr.TimestampValue(ex.Value)
+			ex.Value = t.Format(time.RFC3339Nano)
+		}
+		if a.Type == expr.Decimal {
+			ex.Value, _ = expr.DecimalValue(ex.Value)
+		}
 		a.UserExamples = append(a.UserExamples, ex)
 	}
 }
@@ -36,6 +36,10 @@ func Description(d string) {
 		e.Description = d
 	case *expr.MethodExpr:
 		e.Description = d
+	case *expr.WebhookExpr:
+		e.Description = d
+	case *expr.HTTPCallbackExpr:
+		e.Description = d
 	case *expr.ExampleExpr:
 		e.Description = d
 	case *expr.SchemeExpr:
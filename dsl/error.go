@@ -14,6 +14,13 @@ import (
 // Error must appear in the Service (to define error responses that apply to all
 // the service methods) or Method expressions.
 //
+// The generated service package defines a sentinel error for each error that
+// uses the ErrorResult type, e.g. ErrInvalidArguments for the error
+// "invalid_arguments" below. Use errors.Is to compare the errors returned by
+// the service methods and clients with the sentinels. The errors that use a
+// custom type are returned as instances of the generated type, use errors.As
+// to retrieve them.
+//
 // See Attribute for details on the Error arguments.
 //
 // Example:
@@ -80,11 +87,19 @@ func Temporary() {
 	attr.Meta["goa:error:temporary"] = nil
 }
 
-// Timeout qualifies an error type as describing errors due to timeouts.
+// Timeout qualifies an error type as describing errors due to timeouts when
+// used in an Error expression, or sets the maximum duration of the requests
+// handled by a method when used in a Method expression.
 //
-// Timeout must appear in a Error expression.
+// In a Method expression the generated HTTP and gRPC servers cancel the
+// context given to the service method once the duration elapses and the
+// generated clients stop waiting for the response. The default value of the
+// timeout flag of the generated example client tool is set to the largest
+// timeout of the server methods.
 //
-// Timeout takes no argument.
+// Timeout takes no argument in an Error expression. It takes a single argument
+// in a Method expression which is the duration formatted as a Go duration
+// (e.g. "5s" or "1m30s"). Timeout cannot be used on streaming methods.
 //
 // Example:
 //
@@ -92,8 +107,62 @@ func Temporary() {
 //        Error("request_timeout", func() {
 //            Timeout()
 //        })
+//        Method("divide", func() {
+//            Timeout("5s")
+//            Payload(Operands)
+//            Result(Float64)
+//        })
+//    })
+func Timeout(d ...string) {
+	switch e := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		if len(d) > 0 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta["goa:error:timeout"] = nil
+	case *expr.MethodExpr:
+		if len(d) != 1 {
+			eval.ReportError("Timeout in a method requires a single duration argument")
+			return
+		}
+		if e.Meta == nil {
+			e.Meta = make(expr.MetaExpr)
+		}
+		e.Meta["goa:timeout"] = d
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// RetryAfter sets the delay after which a request that failed with the error
+// may be retried. The generated error constructor initializes the RetryAfter
+// field of the goa ServiceError with the delay, the HTTP servers write it in
+// the Retry-After response header and the gRPC servers in a
+// google.rpc.RetryInfo status detail. The generated clients set the RetryAfter
+// field of the errors they decode from these values. The delay may be
+// overridden at runtime by setting the RetryAfter field of the error returned
+// by the service method.
+//
+// RetryAfter must appear in a Error expression that uses the default
+// ErrorResult type.
+//
+// RetryAfter takes a single argument which is the delay formatted as a Go
+// duration (e.g. "30s" or "5m"). The delay must be a positive whole number of
+// seconds.
+//
+// Example:
+//
+//    var _ = Service("divider", func() {
+//        Error("rate_limited", func() {
+//            Temporary()
+//            RetryAfter("30s")
+//        })
 //    })
-func Timeout() {
+func RetryAfter(d string) {
 	attr, ok := eval.Current().(*expr.AttributeExpr)
 	if !ok {
 		eval.IncompatibleDSL()
@@ -102,7 +171,7 @@ func Timeout() {
 	if attr.Meta == nil {
 		attr.Meta = make(expr.MetaExpr)
 	}
-	attr.Meta["goa:error:timeout"] = nil
+	attr.Meta["goa:error:retryafter"] = []string{d}
 }
 
 // Fault qualifies an error type as describing errors due to a server-side
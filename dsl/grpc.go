@@ -312,3 +312,52 @@ func Trailers(fn func()) {
 		eval.IncompatibleDSL()
 	}
 }
+
+// StreamResults makes the gRPC endpoint send the elements of the method result
+// over a server-streaming RPC, at most pageSize elements per message (100 by
+// default), instead of sending the entire result in a single response message.
+// This keeps the response messages under the gRPC message size limit and lets
+// the clients process the elements as they are received.
+//
+// StreamResults must appear in a Method gRPC expression. The method result must
+// be an array or a result type collection and the method must not stream.
+//
+// The service method and its endpoint are unchanged. The generated server
+// sends the result in pages of pageSize elements and the generated client
+// endpoint returns the elements of all the pages. The generated client also
+// defines a <Method>Iter method that returns an iterator whose Next method
+// returns the elements one at a time as the pages are received and io.EOF once
+// all the elements have been received.
+//
+// Example:
+//
+//    var _ = Service("catalog", func() {
+//        Method("list", func() {
+//            Payload(Filter)
+//            Result(ArrayOf(Product))
+//            GRPC(func() {
+//                StreamResults(500)
+//            })
+//        })
+//    })
+//
+func StreamResults(pageSize ...int) {
+	e, ok := eval.Current().(*expr.GRPCEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(pageSize) > 1 {
+		eval.ReportError("too many arguments given to StreamResults")
+		return
+	}
+	size := 100
+	if len(pageSize) == 1 {
+		size = pageSize[0]
+	}
+	if size <= 0 {
+		eval.ReportError("StreamResults page size must be greater than 0, got %d", size)
+		return
+	}
+	e.ResultPageSize = size
+}
@@ -2,6 +2,10 @@ package dsl
 
 import (
 	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"goa.design/goa/v3/eval"
@@ -75,6 +79,27 @@ const (
 	StatusNetworkAuthenticationRequired = expr.StatusNetworkAuthenticationRequired
 )
 
+const (
+	// StyleForm serializes array query string parameters as repeated
+	// parameters or as comma separated values if explode is false.
+	StyleForm = expr.HTTPStyleForm
+
+	// StyleSpaceDelimited serializes array query string parameters as
+	// space separated values.
+	StyleSpaceDelimited = expr.HTTPStyleSpaceDelimited
+
+	// StylePipeDelimited serializes array query string parameters as pipe
+	// separated values.
+	StylePipeDelimited = expr.HTTPStylePipeDelimited
+
+	// StyleDeepObject serializes map query string parameters as nested
+	// keys, e.g. "filter[name]=x".
+	StyleDeepObject = expr.HTTPStyleDeepObject
+
+	// StyleSimple serializes array headers as comma separated values.
+	StyleSimple = expr.HTTPStyleSimple
+)
+
 // HTTP defines the HTTP transport specific properties of an API, a service or a
 // single method. The function maps the method payload and result types to HTTP
 // properties such as parameters (via path wildcards or query strings), request
@@ -163,9 +188,15 @@ func HTTP(fns ...func()) {
 
 // Consumes adds a MIME type to the list of MIME types the APIs supports when
 // accepting requests. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the decoders for other MIME
-// types.
+// only knows to generate the code for "application/json", "application/xml",
+// "application/gob", "application/cbor" and "application/msgpack". The service
+// code must provide the decoders for other MIME types.
+//
+// Listing "application/x-protobuf" makes the HTTP endpoints of the methods
+// that also define a gRPC endpoint decode the requests whose "Content-Type"
+// header is "application/x-protobuf" into the message generated for the gRPC
+// request. This requires the HTTP request to only define a body and the gRPC
+// request to not use metadata.
 //
 // Consumes must appear in the HTTP expression of API.
 //
@@ -176,7 +207,7 @@ func HTTP(fns ...func()) {
 //    API("cellar", func() {
 //        // ...
 //        HTTP(func() {
-//            Consumes("application/json", "application/xml")
+//            Consumes("application/json", "application/cbor")
 //            // ...
 //        })
 //    })
@@ -192,9 +223,17 @@ func Consumes(args ...string) {
 
 // Produces adds a MIME type to the list of MIME types the APIs supports when
 // writing responses. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the encoders for other MIME
-// types.
+// only knows to generate the code for "application/json", "application/xml",
+// "application/gob", "application/cbor" and "application/msgpack". The service
+// code must provide the encoders for other MIME types. Listing a MIME type
+// advertises it in the generated OpenAPI specification. The generated servers
+// select the response encoding from the request "Accept" header.
+//
+// Listing "application/x-protobuf" makes the HTTP endpoints of the methods
+// that also define a gRPC endpoint encode the response using the message
+// generated for the gRPC response when the first MIME type listed in the
+// request "Accept" header is "application/x-protobuf". This requires the HTTP
+// endpoint to define a single response. Errors are encoded as usual.
 //
 // Produces must appear in the HTTP expression of API.
 //
@@ -205,7 +244,7 @@ func Consumes(args ...string) {
 //    API("cellar", func() {
 //        // ...
 //        HTTP(func() {
-//            Produces("application/json", "application/xml")
+//            Produces("application/json", "application/msgpack")
 //            // ...
 //        })
 //    })
@@ -219,6 +258,613 @@ func Produces(args ...string) {
 	}
 }
 
+// FixedHeader declares a response header written with a constant value by all
+// the responses of the API or service, including error responses. This makes
+// it possible to set headers such as security headers once instead of in each
+// response. Headers declared at the service level override the API level
+// headers with the same name and the headers set by a response take precedence
+// over both. The headers are also listed in the generated OpenAPI
+// specification.
+//
+// FixedHeader must appear in the HTTP expression of an API or a service.
+//
+// FixedHeader accepts two arguments: the name and the value of the header.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            FixedHeader("X-Content-Type-Options", "nosniff")
+//            FixedHeader("Strict-Transport-Security", "max-age=63072000")
+//        })
+//    })
+//
+func FixedHeader(name, value string) {
+	var headers *[]*expr.HTTPFixedHeaderExpr
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		headers = &e.API.HTTP.FixedHeaders
+	case *expr.HTTPServiceExpr:
+		headers = &e.FixedHeaders
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if !isHeaderName(name) {
+		eval.ReportError("invalid fixed header name %q", name)
+		return
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		eval.ReportError("invalid value for fixed header %q: value must not contain line breaks", name)
+		return
+	}
+	name = http.CanonicalHeaderKey(name)
+	for _, h := range *headers {
+		if h.Name == name {
+			eval.ReportError("fixed header %q defined twice", name)
+			return
+		}
+	}
+	*headers = append(*headers, &expr.HTTPFixedHeaderExpr{Name: name, Value: value})
+}
+
+// DisableHTMLEscaping disables the escaping of the <, > and & characters in the
+// strings of the JSON responses written by the generated servers. By default
+// the characters are escaped (e.g. "<" is written "\u003c") so that the
+// responses can be embedded safely in HTML documents.
+//
+// DisableHTMLEscaping must appear in an API HTTP expression.
+//
+// DisableHTMLEscaping takes no argument.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            DisableHTMLEscaping()
+//        })
+//    })
+//
+func DisableHTMLEscaping() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.DisableHTMLEscaping = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// NoSniff makes the generated servers set the X-Content-Type-Options header of
+// the responses that have a body to "nosniff" so that browsers do not try to
+// guess a content type different from the one set by the server.
+//
+// NoSniff must appear in an API HTTP expression.
+//
+// NoSniff takes no argument.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            NoSniff()
+//        })
+//    })
+//
+func NoSniff() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.NoSniff = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// JSONPrefix sets a prefix written by the generated servers before the body of
+// the JSON responses. The prefix makes the responses invalid JavaScript so that
+// legacy browsers cannot execute them through script tags (JSON hijacking).
+// The generated clients remove the prefix before decoding the responses, other
+// clients must do the same.
+//
+// JSONPrefix must appear in an API HTTP expression.
+//
+// JSONPrefix accepts one argument: the prefix.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            JSONPrefix(")]}',\n")
+//        })
+//    })
+//
+func JSONPrefix(prefix string) {
+	if prefix == "" {
+		eval.ReportError("JSON prefix cannot be empty")
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.JSONPrefix = prefix
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// MaxRequestBody sets the maximum size of the request bodies read by the
+// generated servers. The generated request decoders stop reading the body once
+// the size is exceeded and return a "request_body_too_large" error which is
+// written as a HTTP 413 Request Entity Too Large response. The OpenAPI
+// specification documents the 413 response for the endpoints that define a
+// request body.
+//
+// MaxRequestBody must appear in an API, Service or Method HTTP expression. The
+// size set on a method overrides the size set on its service which overrides
+// the size set on the API.
+//
+// MaxRequestBody accepts one argument: the size as a number of bytes
+// optionally followed by one of the units "B", "KB", "MB" or "GB" where 1KB is
+// 1024 bytes, e.g. "512KB" or "1MB".
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            MaxRequestBody("1MB")
+//        })
+//    })
+//
+//    var _ = Service("storage", func() {
+//        Method("upload", func() {
+//            Payload(Bottle)
+//            HTTP(func() {
+//                POST("/")
+//                MaxRequestBody("10MB")
+//            })
+//        })
+//    })
+//
+func MaxRequestBody(size string) {
+	n, err := parseByteSize(size)
+	if err != nil {
+		eval.ReportError("invalid request body size %q: %s", size, err)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.MaxRequestBody = n
+	case *expr.HTTPServiceExpr:
+		e.MaxRequestBody = n
+	case *expr.HTTPEndpointExpr:
+		e.MaxRequestBody = n
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Compression makes the generated servers compress the response bodies using
+// the first of the given content codings accepted by the client as listed in
+// the request "Accept-Encoding" header. The servers set the
+// "Content-Encoding" and "Vary" response headers accordingly. The supported
+// codings are "gzip", "deflate", "br" (brotli) and "zstd". The "gzip" and
+// "deflate" codings are built-in, the service must register the compressors
+// of the other codings with the middleware.RegisterCompressor function of the
+// goa http middleware package.
+//
+// The generated HTTP servers expose a UseCompression method that applies the
+// compression middleware to the handlers of the endpoints whose responses are
+// compressed, the generated example server calls it.
+//
+// Compression must appear in an API, Service or Method HTTP expression. The
+// codings set on a method override the codings set on its service which
+// override the codings set on the API. Use NoCompression to disable the
+// compression of a method responses, for example for streaming endpoints.
+//
+// Compression accepts the codings in order of preference as arguments,
+// "gzip" and "deflate" if none is given.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            Compression("br", "gzip")
+//        })
+//    })
+//
+func Compression(encodings ...string) {
+	encodings, ok := contentCodings(encodings)
+	if !ok {
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.Compression = encodings
+	case *expr.HTTPServiceExpr:
+		e.Compression = encodings
+	case *expr.HTTPEndpointExpr:
+		e.Compression = encodings
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// RequestCompression makes the generated servers accept request bodies
+// compressed with the given content codings as indicated by the request
+// "Content-Encoding" header. The generated request decoders decompress the
+// bodies before decoding them and reject the bodies compressed with other
+// codings with a "unsupported_content_encoding" error written as a HTTP 415
+// Unsupported Media Type response. The size of the decompressed bodies is
+// limited by the size set with MaxRequestBody or by
+// goahttp.DefaultMaxDecompressedBody if not set. The supported codings are
+// "gzip", "deflate", "br" (brotli) and "zstd". The "gzip" and "deflate"
+// codings are built-in, the service must register the decompressors of the
+// other codings with the RegisterDecompressor function of the goa http
+// package.
+//
+// The generated clients compress the request bodies of these endpoints with
+// the first of the "gzip" or "deflate" codings listed when the
+// RequestCompressionThreshold field of the client is set and the body is
+// larger than the threshold.
+//
+// RequestCompression must appear in an API, Service or Method HTTP expression.
+// The codings set on a method override the codings set on its service which
+// override the codings set on the API.
+//
+// RequestCompression accepts the codings as arguments, "gzip" and "deflate"
+// if none is given.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            RequestCompression("gzip", "zstd")
+//        })
+//    })
+//
+func RequestCompression(encodings ...string) {
+	encodings, ok := contentCodings(encodings)
+	if !ok {
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.RequestCompression = encodings
+	case *expr.HTTPServiceExpr:
+		e.RequestCompression = encodings
+	case *expr.HTTPEndpointExpr:
+		e.RequestCompression = encodings
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// NoCompression disables the compression of the method responses enabled with
+// Compression on the service or the API. This is typically used for streaming
+// endpoints or for endpoints whose responses are already compressed.
+//
+// NoCompression must appear in a Method HTTP expression.
+//
+// NoCompression takes no argument.
+//
+// Example:
+//
+//    var _ = Service("storage", func() {
+//        HTTP(func() {
+//            Compression()
+//        })
+//        Method("download", func() {
+//            HTTP(func() {
+//                GET("/{id}")
+//                NoCompression()
+//            })
+//        })
+//    })
+//
+func NoCompression() {
+	switch e := eval.Current().(type) {
+	case *expr.HTTPEndpointExpr:
+		e.NoCompression = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Canary makes the generated HTTP server route a share of the requests made to
+// the endpoint to an alternate implementation of the method, e.g. a new version
+// being rolled out. The alternate implementation is given to the server
+// constructor as a second set of service endpoints. The server serves all the
+// requests with the primary endpoints if the alternate endpoints are nil.
+//
+// Canary must appear in a Method HTTP expression.
+//
+// Canary takes the percentage of the requests routed to the alternate
+// implementation and optionally the name of a header and its value. The
+// requests that set the header to the value (or to any value if the value is
+// omitted) are routed to the alternate implementation regardless of the
+// percentage. The generated servers expose the routing rules so that they can
+// be changed or replaced with a custom routing function before the server
+// starts, see the goa http package CanaryRouter type.
+//
+// Example:
+//
+//    var _ = Service("catalog", func() {
+//        Method("search", func() {
+//            Payload(String)
+//            Result(ArrayOf(String))
+//            HTTP(func() {
+//                GET("/search/{q}")
+//                Canary(5, "X-Canary", "true")
+//            })
+//        })
+//    })
+//
+func Canary(percent float64, header ...string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(header) > 2 {
+		eval.ReportError("too many arguments given to Canary")
+		return
+	}
+	c := &expr.HTTPCanaryExpr{Percent: percent, Endpoint: e}
+	if len(header) > 0 {
+		c.Header = header[0]
+	}
+	if len(header) > 1 {
+		c.Value = header[1]
+	}
+	e.Canary = c
+}
+
+// SignedURL makes the endpoint only accept requests made to time-limited URLs
+// signed with a secret shared by the services, e.g. to let clients download
+// files directly without holding credentials. The signature is the HMAC-SHA256
+// of the request path and the expiration time, both given in the "expires" and
+// "signature" query string parameters.
+//
+// The generated HTTP server rejects the requests made to the endpoint with a
+// 403 Forbidden response unless the URL signatures are verified with the
+// signer given to the UseSignedURLs method of the server. The generated server
+// package also defines a function for each route of the endpoint that returns
+// the route path signed with a given signer, for use by the endpoints that
+// return the signed URLs. The signers are created with the NewURLSigner
+// function of the goa http package.
+//
+// SignedURL must appear in a Method HTTP expression. The endpoint routes must
+// use the GET or HEAD methods.
+//
+// SignedURL takes no argument.
+//
+// Example:
+//
+//    var _ = Service("storage", func() {
+//        Method("download", func() {
+//            Payload(String)
+//            HTTP(func() {
+//                GET("/files/{*path}")
+//                SignedURL()
+//            })
+//        })
+//    })
+//
+func SignedURL() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.SignedURL = true
+}
+
+// TracePhases makes the generated HTTP handlers notify the phase observer
+// registered in the request context of the phases of the request processing:
+// decoding, validation of the request body, execution of the method and
+// encoding of the result. This makes it possible to attribute latency to
+// serialization or to business logic. The observer is registered with the
+// PhaseObserverMiddleware function of the goa http package, e.g. using the
+// Use method of the generated servers. The handlers do not notify any
+// observer if none is registered.
+//
+// The handlers of streaming endpoints only notify the decoding and validation
+// phases.
+//
+// TracePhases must appear in an API or Service HTTP expression.
+//
+// TracePhases takes no argument.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            TracePhases()
+//        })
+//    })
+//
+func TracePhases() {
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.TracePhases = true
+	case *expr.HTTPServiceExpr:
+		e.TracePhases = true
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// PassThroughHeaders propagates the given headers of the responses returned by
+// upstream services onto the responses written by the generated servers. This
+// is typically used by gateway services to forward headers such as request IDs
+// or rate limiting information.
+//
+// The generated response decoders of the endpoints using PassThroughHeaders
+// capture the headers of the responses they decode in the request context.
+// The generated handlers of the endpoints using PassThroughHeaders initialize
+// the context given to the service method so that the headers captured by the
+// clients called with this context are written to the response by the
+// generated response and error encoders. The headers defined in the design
+// take precedence over the headers passed through.
+//
+// PassThroughHeaders must appear in an API, Service or Method HTTP
+// expression. The headers set on a method override the headers set on its
+// service which override the headers set on the API.
+//
+// PassThroughHeaders accepts the names of the headers as arguments.
+//
+// Example:
+//
+//    var _ = Service("gateway", func() {
+//        HTTP(func() {
+//            PassThroughHeaders("X-Request-Id", "X-RateLimit-Remaining")
+//        })
+//    })
+//
+func PassThroughHeaders(names ...string) {
+	if len(names) == 0 {
+		eval.ReportError("PassThroughHeaders requires at least one header name")
+		return
+	}
+	headers := make([]string, len(names))
+	for i, n := range names {
+		if !isHeaderName(n) {
+			eval.ReportError("invalid pass through header name %q", n)
+			return
+		}
+		headers[i] = http.CanonicalHeaderKey(n)
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.PassThroughHeaders = headers
+	case *expr.HTTPServiceExpr:
+		e.PassThroughHeaders = headers
+	case *expr.HTTPEndpointExpr:
+		e.PassThroughHeaders = headers
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Router selects the router targeted by the generated HTTP servers. The
+// generated code registers the endpoints using the path pattern syntax of the
+// selected router so that the servers may be mounted on a router that teams
+// already use with its middleware ecosystem. The default is the goa muxer
+// returned by the NewMuxer function of the goa http package.
+//
+// Router must appear in an API HTTP expression.
+//
+// Router takes the name of the router: "chi" (github.com/go-chi/chi),
+// "gorilla" (github.com/gorilla/mux), "httprouter"
+// (github.com/julienschmidt/httprouter) or "servemux" (the net/http ServeMux
+// of Go 1.22 and later). The chi, gorilla and httprouter routers are adapted to
+// the goa Muxer interface with the NewRouterMuxer function of the goa http
+// package, the standard library ServeMux with NewServeMuxer.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            Router("chi")
+//        })
+//    })
+//
+func Router(name string) {
+	switch name {
+	case "chi", "gorilla", "httprouter", "servemux":
+	default:
+		eval.ReportError(`invalid router %q, must be one of "chi", "gorilla", "httprouter" or "servemux"`, name)
+		return
+	}
+	if r, ok := eval.Current().(*expr.RootExpr); ok {
+		r.API.HTTP.Router = name
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// StrictStatusCodes makes goa keep track of all the HTTP status codes of the
+// responses the endpoints may write: the success and error responses defined
+// in the design and the responses written by the generated code, for example
+// when the request cannot be decoded (400), the credentials are invalid (401),
+// the request path does not match any route (404) or matches routes for other
+// methods (405), or the request body is compressed with an unsupported content
+// coding (415). The generated client packages define the list of status codes
+// of each endpoint and a function that describes the responses for each status
+// code so that clients may handle all the responses exhaustively. The OpenAPI
+// specification lists the responses written by the generated code in addition
+// to the responses defined in the design.
+//
+// Code generation fails if an error of a method or a service is not mapped to
+// a HTTP response with Response.
+//
+// StrictStatusCodes must appear in an API HTTP expression.
+//
+// StrictStatusCodes takes no argument.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            StrictStatusCodes()
+//        })
+//    })
+//
+func StrictStatusCodes() {
+	if r, ok := eval.Current().(*expr.RootExpr); ok {
+		r.API.HTTP.StrictStatusCodes = true
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// TrailingSlash sets the policy applied by the generated HTTP servers to the
+// requests whose path is the path of a route followed by a trailing slash, for
+// example "/accounts/" for the route "/accounts". The generated code registers
+// the path with a trailing slash of each route with the muxer according to the
+// policy:
+//
+//   - "strict" responds to these requests as if no route matched the path,
+//     the goa muxer does not redirect them.
+//
+//   - "redirect" redirects these requests to the path of the route with a 301
+//     Moved Permanently response for GET and HEAD requests and a 308
+//     Permanent Redirect response for the other methods.
+//
+//   - "rewrite" serves these requests with the route handler.
+//
+// The routes whose path is "/" or ends with a catch-all wildcard are not
+// affected. The routers are left to their own behavior if TrailingSlash is not
+// used, the goa muxer redirects the requests.
+//
+// TrailingSlash must appear in an API or Service HTTP expression. The policy
+// set on a service overrides the policy set on the API.
+//
+// TrailingSlash takes the name of the policy as argument.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            TrailingSlash("strict")
+//        })
+//    })
+//
+func TrailingSlash(policy string) {
+	switch policy {
+	case "strict", "redirect", "rewrite":
+	default:
+		eval.ReportError(`invalid trailing slash policy %q, must be one of "strict", "redirect" or "rewrite"`, policy)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.TrailingSlash = policy
+	case *expr.HTTPServiceExpr:
+		e.TrailingSlash = policy
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // Path defines an API or service base path, i.e. a common HTTP path prefix to
 // all the API or service methods. The path may define wildcards (see GET for a
 // description of the wildcard syntax). The corresponding parameters must be
@@ -263,6 +909,15 @@ func Path(val string) {
 // A wildcard that starts with '{*' matches the rest of the path. Such wildcards
 // must terminate the path.
 //
+// A wildcard that matches a section of the path may be constrained with a
+// regular expression that follows its name, e.g. "/items/{id:[0-9]+}". The
+// corresponding parameter must be a string, the regular expression is anchored
+// and overrides the parameter pattern. The constraint is part of the patterns
+// registered with the routers that support them (chi and gorilla), it is
+// enforced by the generated request decoders and appears in the OpenAPI
+// parameter schema. A Pattern validation of a path parameter anchored at both
+// ends is handled the same way.
+//
 // GET must appear in a method HTTP function.
 //
 // GET accepts one argument which is the request path.
@@ -274,7 +929,7 @@ func Path(val string) {
 //             Payload(GetAccount)
 //             Result(Account)
 //             HTTP(func() {
-//                 GET("/{accountID}/details")
+//                 GET("/{accountID:[a-z0-9-]+}/details")
 //                 GET("/{*accountPath}")
 //             })
 //         })
@@ -330,6 +985,19 @@ func route(method, path string) *expr.RouteExpr {
 		eval.IncompatibleDSL()
 		return r
 	}
+	p, cons, err := expr.SplitHTTPPathConstraints(path)
+	if err != nil {
+		eval.ReportError(err.Error())
+		return r
+	}
+	for name, re := range cons {
+		if _, err := regexp.Compile(re); err != nil {
+			eval.ReportError("invalid constraint %#v for path parameter %q, %s", re, name, err)
+			return r
+		}
+	}
+	r.Path = p
+	r.Constraints = cons
 	r.Endpoint = a
 	a.Routes = append(a.Routes, r)
 	return r
@@ -382,6 +1050,64 @@ func Header(name string, args ...interface{}) {
 	h.Remap()
 }
 
+// ETag identifies the result attribute that holds the entity tag of the
+// resource returned by the method.
+//
+// ETag must appear in the DSL of a result attribute of type String.
+//
+// ETag takes no argument.
+//
+// The HTTP transport maps the attribute to the "ETag" header of the success
+// responses unless the design maps it explicitly. The generated server
+// encoders of GET and HEAD requests evaluate the request If-Match and
+// If-None-Match headers against the entity tag. They write a 412 Precondition
+// Failed or 304 Not Modified response instead of the result when the
+// conditions are not met.
+//
+// The generated code does not evaluate the headers for the other HTTP methods
+// as the result is only known once the changes have been applied. The service
+// methods that modify the resource (e.g. PUT, PATCH or DELETE) must call
+// goahttp.CheckPrecondition with the current entity tag of the resource before
+// applying the changes. CheckPrecondition returns a "precondition_failed"
+// error which the transport maps to a 412 response. The goahttp.IfMatch and
+// goahttp.IfNoneMatch functions return the raw header values.
+//
+// The generated clients set the If-Match and If-None-Match headers from the
+// request context values stored under the goahttp.IfMatchKey and
+// goahttp.IfNoneMatchKey keys. They return the goahttp.ErrNotModified and
+// goahttp.ErrPreconditionFailed errors on the corresponding responses.
+//
+// Example:
+//
+//    var Account = ResultType("application/vnd.account", func() {
+//        Attribute("id", String)
+//        Attribute("version", String, func() {
+//            ETag()
+//        })
+//    })
+//
+// The service implementation of a method that updates the account:
+//
+//    func (s *svc) Update(ctx context.Context, p *accounts.UpdatePayload) (*accounts.Account, error) {
+//        acct := s.load(p.ID)
+//        if err := goahttp.CheckPrecondition(ctx, acct.Version); err != nil {
+//            return nil, err
+//        }
+//        ...
+//    }
+//
+func ETag() {
+	attr, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if attr.Meta == nil {
+		attr.Meta = make(expr.MetaExpr)
+	}
+	attr.Meta["http:etag"] = nil
+}
+
 // Params groups a set of Param expressions. It makes it possible to list
 // required parameters using the Required function.
 //
@@ -416,63 +1142,170 @@ func Params(args interface{}) {
 		eval.InvalidArgError("function", args)
 		return
 	}
-	eval.Execute(fn, p)
+	eval.Execute(fn, p)
+}
+
+// Param describes a single HTTP request path or query string parameter.
+//
+// Param must appear in the API HTTP expression (to define request parameters
+// common to all the API endpoints), a service HTTP expression to define common
+// parameters to all the service methods or a specific method HTTP
+// expression. Param may also appear in a Params expression.
+//
+// Param accepts the same arguments as the Function Attribute.
+//
+// The name may be of the form "name of attribute:name of parameter" to define a
+// mapping between the attribute and parameter names when they differ.
+//
+// Example:
+//
+//    var ShowPayload = Type("ShowPayload", func() {
+//        Attribute("id", UInt64, "Account ID")
+//        Attribute("version", String, "Version", func() {
+//            Enum("1.0", "2.0")
+//        })
+//    })
+//
+//    var _ = Service("account", func() {
+//        HTTP(func() {
+//            Path("/{parentID}")
+//            Param("parentID", UInt64, "ID of parent account")
+//        })
+//        Method("show", func() {  // default response type.
+//            Payload(ShowPayload)
+//            Result(AccountResult)
+//            HTTP(func() {
+//                GET("/{id}")           // HTTP request uses ShowPayload "id"
+//                                       // attribute to define "id" parameter.
+//                Params(func() {        // Params makes it possible to group
+//                                       // Param expressions.
+//                    Param("version:v") // "version" of ShowPayload to define
+//                                       // path and query string parameters.
+//                                       // Query string "v" maps to attribute
+//                                       // "version" of ShowPayload.
+//                })
+//            })
+//        })
+//    })
+//
+func Param(name string, args ...interface{}) {
+	p := params(eval.Current())
+	if p == nil {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("parameter name cannot be empty")
+	}
+	eval.Execute(func() { Attribute(name, args...) }, p.AttributeExpr)
+	p.Remap()
+}
+
+// Style sets the OpenAPI serialization style of a query string parameter or
+// of a request header. The generated clients encode and the generated servers
+// decode the parameter values using the style and the OpenAPI specification
+// describes it.
+//
+// Style must appear in the DSL of a Param or Header expression. Path
+// parameters cannot define a style.
+//
+// Style accepts one argument: the style. Query string parameters may use
+// StyleForm (default), StyleSpaceDelimited or StylePipeDelimited if they are
+// arrays and StyleDeepObject if they are maps. Headers may use StyleSimple
+// which serializes arrays as comma separated values instead of repeated
+// headers.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("ids", ArrayOf(Int))
+//            Attribute("filter", MapOf(String, String))
+//            Attribute("tags", ArrayOf(String))
+//        })
+//        HTTP(func() {
+//            GET("/")
+//            Param("ids", func() {
+//                Style(StylePipeDelimited) // ?ids=1|2|3
+//            })
+//            Param("filter", func() {
+//                Style(StyleDeepObject) // ?filter[name]=x
+//            })
+//            Header("tags:X-Tags", func() {
+//                Style(StyleSimple) // X-Tags: a,b
+//            })
+//        })
+//    })
+//
+func Style(style string) {
+	attr, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if attr.Meta == nil {
+		attr.Meta = make(expr.MetaExpr)
+	}
+	attr.Meta["http:style"] = []string{style}
+}
+
+// Explode sets whether the values of an array query string parameter are
+// serialized as repeated parameters (e.g. "ids=1&ids=2") or in a single
+// parameter (e.g. "ids=1,2"). Explode defaults to true for the StyleForm
+// style and to false for the StyleSpaceDelimited and StylePipeDelimited
+// styles.
+//
+// Explode must appear in the DSL of a Param expression.
+//
+// Explode accepts one argument: whether the values are exploded.
+//
+// Example:
+//
+//    Param("ids", ArrayOf(Int), func() {
+//        Explode(false) // ?ids=1,2,3
+//    })
+//
+func Explode(explode bool) {
+	attr, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if attr.Meta == nil {
+		attr.Meta = make(expr.MetaExpr)
+	}
+	attr.Meta["http:explode"] = []string{strconv.FormatBool(explode)}
 }
 
-// Param describes a single HTTP request path or query string parameter.
-//
-// Param must appear in the API HTTP expression (to define request parameters
-// common to all the API endpoints), a service HTTP expression to define common
-// parameters to all the service methods or a specific method HTTP
-// expression. Param may also appear in a Params expression.
+// Separator sets the string that separates the values of an array query
+// string parameter or header serialized in a single parameter or header, e.g.
+// ";" serializes the values as "ids=1;2;3". The generated clients join and the
+// generated servers split the values using the separator. Separator makes it
+// possible to use separators that have no OpenAPI style, the OpenAPI
+// specification describes the separator with the "x-separator" extension
+// unless it maps to a collection format.
 //
-// Param accepts the same arguments as the Function Attribute.
+// Separator must appear in the DSL of a Param or Header expression whose type
+// is an array. Separator cannot be combined with Style or Explode.
 //
-// The name may be of the form "name of attribute:name of parameter" to define a
-// mapping between the attribute and parameter names when they differ.
+// Separator accepts one argument: the separator.
 //
 // Example:
 //
-//    var ShowPayload = Type("ShowPayload", func() {
-//        Attribute("id", UInt64, "Account ID")
-//        Attribute("version", String, "Version", func() {
-//            Enum("1.0", "2.0")
-//        })
-//    })
-//
-//    var _ = Service("account", func() {
-//        HTTP(func() {
-//            Path("/{parentID}")
-//            Param("parentID", UInt64, "ID of parent account")
-//        })
-//        Method("show", func() {  // default response type.
-//            Payload(ShowPayload)
-//            Result(AccountResult)
-//            HTTP(func() {
-//                GET("/{id}")           // HTTP request uses ShowPayload "id"
-//                                       // attribute to define "id" parameter.
-//                Params(func() {        // Params makes it possible to group
-//                                       // Param expressions.
-//                    Param("version:v") // "version" of ShowPayload to define
-//                                       // path and query string parameters.
-//                                       // Query string "v" maps to attribute
-//                                       // "version" of ShowPayload.
-//                })
-//            })
-//        })
+//    Param("ids", ArrayOf(Int), func() {
+//        Separator(";") // ?ids=1;2;3
 //    })
 //
-func Param(name string, args ...interface{}) {
-	p := params(eval.Current())
-	if p == nil {
+func Separator(sep string) {
+	attr, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
 		eval.IncompatibleDSL()
 		return
 	}
-	if name == "" {
-		eval.ReportError("parameter name cannot be empty")
+	if attr.Meta == nil {
+		attr.Meta = make(expr.MetaExpr)
 	}
-	eval.Execute(func() { Attribute(name, args...) }, p.AttributeExpr)
-	p.Remap()
+	attr.Meta["http:separator"] = []string{sep}
 }
 
 // MapParams describes the query string parameters in a HTTP request.
@@ -485,6 +1318,13 @@ func Param(name string, args ...interface{}) {
 // attribute must be a map. If no argument is specified, the query string
 // parameters are mapped with the entire Payload (the Payload must be a map).
 //
+// The keys of the map must be primitives and are read from the parameter names,
+// e.g. "p[key]=value". The values of the map may be primitives, arrays of
+// primitives or objects whose attributes are primitives or arrays of
+// primitives. Object values are read from deepObject style parameters, e.g.
+// "ranges[1][min]=0&ranges[1][max]=10". The generated code validates both the
+// keys and the values of the map.
+//
 // Example:
 //
 //     var _ = Service("account", func() {
@@ -510,6 +1350,26 @@ func Param(name string, args ...interface{}) {
 //        })
 //    })
 //
+//    var Range = Type("Range", func() {
+//        Attribute("min", Int, func() {
+//            Minimum(0)
+//        })
+//        Attribute("max", Int)
+//        Required("min")
+//    })
+//
+//    var _ = Service("account", func() {
+//        Method("list", func() {
+//            Payload(func() {
+//                Attribute("ranges", MapOf(Int, Range))
+//            })
+//            HTTP(func() {
+//                GET("/")
+//                MapParams("ranges")
+//            })
+//        })
+//    })
+//
 func MapParams(args ...interface{}) {
 	if len(args) > 1 {
 		eval.ReportError("too many arguments")
@@ -529,6 +1389,165 @@ func MapParams(args ...interface{}) {
 	e.MapQueryParams = &mapName
 }
 
+// ViewParam lets clients select the view used to render the method result
+// with a query string parameter.
+//
+// ViewParam must appear in an API, Service or Method HTTP expression. When used
+// in a Method HTTP expression the method result must be a result type that
+// defines more than one view and the design must not set the view explicitly
+// in the Result expression. When used in an API or Service HTTP expression the
+// parameter applies to all the endpoints that meet these conditions and that
+// do not define a parameter of the same name, the parameter set on a service
+// overrides the parameter set on the API.
+//
+// ViewParam accepts one optional argument which specifies the name of the query
+// string parameter, the name defaults to "view".
+//
+// The generated server validates the parameter value against the result type
+// views and stores it in the request context under the goa.ViewKey key. The
+// generated endpoint uses the value to render the result in place of the view
+// returned by the service method. The generated client sets the parameter
+// from the value stored under the same key in the request context, if any.
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Method("show", func() {
+//            Payload(func() {
+//                Attribute("id", String)
+//            })
+//            Result(Account) // Account defines the "default" and "tiny" views
+//            HTTP(func() {
+//                GET("/{id}")
+//                ViewParam()
+//            })
+//        })
+//    })
+//
+//    var _ = Service("catalog", func() {
+//        HTTP(func() {
+//            ViewParam() // applies to all the service endpoints
+//        })
+//    })
+//
+func ViewParam(name ...string) {
+	if len(name) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	param := "view"
+	if len(name) > 0 {
+		param = name[0]
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.ViewParam = param
+	case *expr.HTTPServiceExpr:
+		e.ViewParam = param
+	case *expr.HTTPEndpointExpr:
+		e.ViewParam = param
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// NDJSON makes the HTTP endpoint stream the method results as newline
+// delimited JSON (also known as JSON Lines) over a chunked HTTP response instead
+// of using a websocket connection.
+//
+// NDJSON must appear in a Method HTTP expression. The method must define a
+// streaming result and no streaming payload. Contrary to websocket streams the
+// endpoint may use any HTTP method so that payloads can be sent in the request
+// body.
+//
+// The generated server writes the response status code and headers with the
+// first result and flushes the response after each result. The generated client
+// returns a stream whose Recv method decodes the results one line at a time and
+// returns io.EOF once the server closes the stream.
+//
+// Example:
+//
+//    var _ = Service("events", func() {
+//        Method("watch", func() {
+//            Payload(Filter)
+//            StreamingResult(Event)
+//            HTTP(func() {
+//                POST("/watch")
+//                NDJSON()
+//            })
+//        })
+//    })
+//
+func NDJSON() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.NDJSON = true
+}
+
+// Export makes the HTTP endpoint stream the method results as the rows of a
+// file written to the HTTP response. The file is a CSV file unless the request
+// Accept header lists the content type of another format made available to the
+// server with the goahttp.ExportFormatsMiddleware middleware (e.g. XLSX).
+//
+// Export must appear in a Method HTTP expression. The method must define a
+// streaming result and no streaming payload. The streaming result type must be
+// defined with Type (not ResultType) and its attributes must be strings,
+// integers, numbers or booleans, or user types based on them such as enum
+// types. The first row of the file lists the names of the attributes and each
+// result sent by the service is written as a row. Export endpoints may use any
+// HTTP method.
+//
+// Export accepts an optional argument which defines the name of the file
+// returned in the Content-Disposition response header without extension. The
+// name may refer to payload attributes using placeholders of the form "{name}".
+// The file name defaults to the method name.
+//
+// The generated client sets the request Accept header to "text/csv" and returns
+// a stream whose Recv method decodes the rows one at a time and returns io.EOF
+// once all the rows have been read.
+//
+// Example:
+//
+//    var Order = Type("Order", func() {
+//        Attribute("id", String)
+//        Attribute("total", Float64)
+//        Required("id", "total")
+//    })
+//
+//    var _ = Service("orders", func() {
+//        Method("export", func() {
+//            Payload(func() {
+//                Attribute("year", Int)
+//            })
+//            StreamingResult(Order)
+//            HTTP(func() {
+//                GET("/orders/export")
+//                Param("year")
+//                Export("orders-{year}")
+//            })
+//        })
+//    })
+//
+func Export(filename ...string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(filename) > 1 {
+		eval.ReportError("too many arguments given to Export")
+		return
+	}
+	ex := &expr.HTTPExportExpr{Filename: e.Name(), Endpoint: e}
+	if len(filename) == 1 {
+		ex.Filename = filename[0]
+	}
+	e.Export = ex
+}
+
 // MultipartRequest indicates that HTTP requests made to the method use
 // MIME multipart encoding as defined in RFC 2046.
 //
@@ -555,10 +1574,68 @@ func MultipartRequest() {
 	e.MultipartRequest = true
 }
 
+// RequestContent describes the request body sent by clients that use the
+// given content type when it differs from the endpoint body. This makes it
+// possible for an endpoint to accept different body shapes depending on the
+// request content type, for example a JSON document and a XML document with a
+// different structure.
+//
+// RequestContent must appear in a HTTP endpoint expression.
+//
+// RequestContent takes two arguments: the media type as defined by RFC 6838
+// and a DSL that uses Body to describe the request body. The body attributes
+// must be defined in the method payload.
+//
+// The generated server request decoder decodes the requests whose
+// Content-Type header matches the media type using the corresponding body and
+// all the other requests using the endpoint body. The decoder given to the
+// server constructor must support the media type (goahttp.RequestDecoder
+// supports JSON, XML, gob, CBOR and MessagePack). The generated client request
+// encoder uses the body that matches the Content-Type header set on the
+// request, typically by mapping a payload attribute to the header. This makes
+// the attribute act as the discriminator of the payload shape.
+//
+// Example:
+//
+//    Method("upload", func() {
+//        Payload(func() {
+//            Attribute("content_type", String)
+//            Attribute("name", String)
+//            Attribute("tags", ArrayOf(String))
+//            Attribute("document", Document)
+//        })
+//        HTTP(func() {
+//            POST("/documents")
+//            Header("content_type:Content-Type")
+//            Body(func() {
+//                Attribute("name")
+//                Attribute("tags")
+//            })
+//            RequestContent("application/xml", func() {
+//                Body("document")
+//            })
+//        })
+//    })
+//
+func RequestContent(contentType string, fn func()) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c := &expr.HTTPRequestContentExpr{ContentType: contentType, Endpoint: e}
+	if !eval.Execute(fn, c) {
+		return
+	}
+	e.RequestContents = append(e.RequestContents, c)
+}
+
 // Body describes a HTTP request or response body.
 //
-// Body must appear in a Method HTTP expression to define the request body or in
-// an Error or Result HTTP expression to define the response body. If Body is
+// Body must appear in a Method HTTP expression to define the request body, in a
+// RequestContent expression to define the request body used with a specific
+// content type or in an Error or Result HTTP expression to define the response
+// body. If Body is
 // absent then the body is built using the HTTP endpoint request or response
 // type attributes not used to describe parameters (request only) or headers.
 //
@@ -614,6 +1691,12 @@ func Body(args ...interface{}) {
 			e.Body = att
 		}
 		kind = "Request"
+	case *expr.HTTPRequestContentExpr:
+		ref = e.Endpoint.MethodExpr.Payload
+		setter = func(att *expr.AttributeExpr) {
+			e.Body = att
+		}
+		kind = "Request"
 	case *expr.HTTPErrorExpr:
 		ref = e.ErrorExpr.AttributeExpr
 		setter = func(att *expr.AttributeExpr) {
@@ -627,7 +1710,12 @@ func Body(args ...interface{}) {
 			kind += " " + e.Name
 		}
 	case *expr.HTTPResponseExpr:
-		ref = e.Parent.(*expr.HTTPEndpointExpr).MethodExpr.Result
+		ep, ok := e.Parent.(*expr.HTTPEndpointExpr)
+		if !ok {
+			eval.IncompatibleDSL()
+			return
+		}
+		ref = ep.MethodExpr.Result
 		setter = func(att *expr.AttributeExpr) {
 			e.Body = att
 		}
@@ -797,6 +1885,190 @@ func ContentType(typ string) {
 	}
 }
 
+// CacheControl sets the value of the Cache-Control response header.
+//
+// CacheControl must appear in a Response expression.
+//
+// CacheControl accepts one or more arguments: the cache directives as defined
+// by RFC 7234. The generated encoders join the directives with commas.
+//
+// Example:
+//
+//    var _ = Method("show", func() {
+//        HTTP(func() {
+//            GET("/{id}")
+//            Response(StatusOK, func() {
+//                CacheControl("public", "max-age=3600")
+//                Expires("1h")
+//                Vary("Accept", "Accept-Encoding")
+//            })
+//        })
+//    })
+//
+func CacheControl(directives ...string) {
+	res, ok := eval.Current().(*expr.HTTPResponseExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	res.CacheControl = strings.Join(directives, ", ")
+}
+
+// Expires sets the Expires response header to the time at which the response
+// is written plus the given duration.
+//
+// Expires must appear in a Response expression.
+//
+// Expires accepts one argument: the duration formatted as described by the
+// time.ParseDuration function of the Go standard library (e.g. "30m" or "1h").
+// The duration is rounded down to the second.
+//
+// See CacheControl for an example.
+func Expires(duration string) {
+	res, ok := eval.Current().(*expr.HTTPResponseExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	res.Expires = duration
+}
+
+// Vary sets the value of the Vary response header.
+//
+// Vary must appear in a Response expression.
+//
+// Vary accepts one or more arguments: the names of the request headers that
+// select the representation returned in the response.
+//
+// See CacheControl for an example.
+func Vary(headers ...string) {
+	res, ok := eval.Current().(*expr.HTTPResponseExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	res.Vary = append(res.Vary, headers...)
+}
+
+// APIVersion sets the API version implemented by the endpoint. Endpoints of the
+// same service may define the same route as long as they implement different
+// versions: the generated server mounts a single handler on the route that
+// dispatches each request to the endpoint of the version read from the request
+// header set with VersionHeader ("X-API-Version" by default). Requests that do
+// not specify a version are served by the endpoint sharing the route that does
+// not set APIVersion if any, by the endpoint declared last otherwise. Requests
+// for unknown versions are rejected with a 400 Bad Request response (406 Not
+// Acceptable when the version is read from the Accept header). The generated
+// clients set the version header on the requests they make.
+//
+// The generated OpenAPI specification documents the operation of the default
+// version and lists the supported versions as the values of the version header
+// parameter or as the media types produced by the operation.
+//
+// APIVersion must appear in a Method HTTP expression.
+//
+// APIVersion accepts one argument: the version.
+//
+// Example:
+//
+//    Service("catalog", func() {
+//        Method("listV1", func() {
+//            Result(ArrayOf(ItemV1))
+//            HTTP(func() {
+//                GET("/items")
+//                APIVersion("1")
+//            })
+//        })
+//        Method("list", func() {
+//            Result(ArrayOf(Item))
+//            HTTP(func() {
+//                GET("/items")
+//                APIVersion("2")
+//            })
+//        })
+//    })
+//
+func APIVersion(version string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if version == "" {
+		eval.ReportError("API version cannot be empty")
+		return
+	}
+	e.Version = version
+}
+
+// VersionHeader sets the name of the request header used to select the API
+// version served by the endpoints that use APIVersion. The default is
+// "X-API-Version". Media type versioning is used when the header is "Accept":
+// the version is then read from the "version" parameter of the requested media
+// type, for example "Accept: application/json; version=2".
+//
+// VersionHeader must appear in an API or Service HTTP expression. The header
+// set on a service overrides the header set on the API.
+//
+// VersionHeader accepts one argument: the header name.
+//
+// Example:
+//
+//    API("catalog", func() {
+//        HTTP(func() {
+//            VersionHeader("Accept")
+//        })
+//    })
+//
+func VersionHeader(name string) {
+	if !isHeaderName(name) {
+		eval.ReportError("invalid version header name %q", name)
+		return
+	}
+	name = http.CanonicalHeaderKey(name)
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.VersionHeader = name
+	case *expr.HTTPServiceExpr:
+		e.VersionHeader = name
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// parseByteSize parses a size expressed as a number of bytes optionally
+// followed by one of the units B, KB, MB or GB.
+func parseByteSize(size string) (int64, error) {
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"KB", 1 << 10},
+		{"MB", 1 << 20},
+		{"GB", 1 << 30},
+		{"B", 1},
+	}
+	s := strings.ToUpper(strings.TrimSpace(size))
+	mult := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s, mult = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("size must be a number of bytes optionally followed by B, KB, MB or GB")
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("size must be positive")
+	}
+	if n > math.MaxInt64/mult {
+		return 0, fmt.Errorf("size is too large")
+	}
+	return n * mult, nil
+}
+
 // headers returns the mapped attribute containing the headers for the given
 // expression if it's either the root, a service or an endpoint - nil otherwise.
 func headers(exp eval.Expression) *expr.MappedAttributeExpr {
@@ -854,3 +2126,44 @@ func params(exp eval.Expression) *expr.MappedAttributeExpr {
 		return nil
 	}
 }
+
+// isHeaderName returns true if name is a valid HTTP header name as defined by
+// RFC 7230, false otherwise.
+func isHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// contentCodings validates the given content codings and returns them, "gzip"
+// and "deflate" if none is given. It reports an error and returns false if a
+// coding is not supported or listed twice.
+func contentCodings(encodings []string) ([]string, bool) {
+	if len(encodings) == 0 {
+		return []string{"gzip", "deflate"}, true
+	}
+	seen := make(map[string]bool, len(encodings))
+	for _, enc := range encodings {
+		switch enc {
+		case "gzip", "deflate", "br", "zstd":
+		default:
+			eval.ReportError("invalid compression %q, must be one of \"gzip\", \"deflate\", \"br\" or \"zstd\"", enc)
+			return nil, false
+		}
+		if seen[enc] {
+			eval.ReportError("compression %q listed twice", enc)
+			return nil, false
+		}
+		seen[enc] = true
+	}
+	return encodings, true
+}
@@ -0,0 +1,56 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Callback describes an out-of-band POST request sent by the service in
+// response to a request made to the endpoint, for example to notify the client
+// once a long running operation completes. Callbacks are listed in the
+// generated OpenAPI specification.
+//
+// Callback must appear in a HTTP endpoint expression.
+//
+// Callback takes a name, the callback URL and an optional DSL. The URL is an
+// OpenAPI runtime expression such as "$request.body#/callback_url" or a string
+// that embeds runtime expressions in curly braces such as
+// "{$request.body#/host}/events?id={$response.body#/id}". The DSL may use
+// Description, Payload to describe the callback request body and Response to
+// list the responses expected by the service (200 by default).
+//
+// Example:
+//
+//    Method("subscribe", func() {
+//        Payload(Subscription)
+//        HTTP(func() {
+//            POST("/subscriptions")
+//            Callback("onEvent", "$request.body#/callback_url", func() {
+//                Description("Sent each time an event occurs.")
+//                Payload(Event)
+//                Response(StatusOK)
+//                Response(StatusGone, func() {
+//                    Description("Unsubscribes the client.")
+//                })
+//            })
+//        })
+//    })
+//
+func Callback(name, url string, fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Callback")
+		return
+	}
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c := &expr.HTTPCallbackExpr{Name: name, URL: url, Endpoint: e}
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], c) {
+			return
+		}
+	}
+	e.Callbacks = append(e.Callbacks, c)
+}
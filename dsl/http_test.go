@@ -0,0 +1,307 @@
+package dsl_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMaxRequestBody(t *testing.T) {
+	cases := map[string]struct {
+		Size     string
+		Expected int64
+		Error    string
+	}{
+		"bytes":     {"512", 512, ""},
+		"bytes-b":   {"512B", 512, ""},
+		"kilobytes": {"64KB", 64 << 10, ""},
+		"megabytes": {"1MB", 1 << 20, ""},
+		"lowercase": {"2 mb", 2 << 20, ""},
+		"gigabytes": {"1GB", 1 << 30, ""},
+		"unit":      {"1TB", 0, "size must be a number of bytes optionally followed by B, KB, MB or GB"},
+		"zero":      {"0MB", 0, "size must be positive"},
+		"overflow":  {"9223372036854775807GB", 0, "size is too large"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			dsl := func() {
+				API("test", func() {
+					HTTP(func() {
+						MaxRequestBody(tc.Size)
+					})
+				})
+			}
+			if tc.Error != "" {
+				err := expr.RunInvalidDSL(t, dsl)
+				if !strings.Contains(err.Error(), tc.Error) {
+					t.Errorf("got error %q, expected error containing %q", err.Error(), tc.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, dsl)
+			if actual := root.API.HTTP.MaxRequestBody; actual != tc.Expected {
+				t.Errorf("got %d, expected %d", actual, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestCompression(t *testing.T) {
+	cases := map[string]struct {
+		API      []string
+		Service  []string
+		Method   []string
+		Disable  bool
+		Expected []string
+		Error    string
+	}{
+		"none":      {nil, nil, nil, false, nil, ""},
+		"default":   {[]string{}, nil, nil, false, []string{"gzip", "deflate"}, ""},
+		"api":       {[]string{"br", "gzip"}, nil, nil, false, []string{"br", "gzip"}, ""},
+		"service":   {[]string{"gzip"}, []string{"zstd"}, nil, false, []string{"zstd"}, ""},
+		"method":    {[]string{"gzip"}, []string{"zstd"}, []string{"deflate"}, false, []string{"deflate"}, ""},
+		"disabled":  {[]string{"gzip"}, nil, nil, true, nil, ""},
+		"invalid":   {[]string{"lzma"}, nil, nil, false, nil, `invalid compression "lzma"`},
+		"duplicate": {[]string{"gzip", "gzip"}, nil, nil, false, nil, `compression "gzip" listed twice`},
+		"both":      {nil, nil, []string{"gzip"}, true, nil, "defines both Compression and NoCompression"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			dsl := func() {
+				API("test", func() {
+					HTTP(func() {
+						if tc.API != nil {
+							Compression(tc.API...)
+						}
+					})
+				})
+				Service("svc", func() {
+					HTTP(func() {
+						if tc.Service != nil {
+							Compression(tc.Service...)
+						}
+					})
+					Method("method", func() {
+						HTTP(func() {
+							GET("/")
+							if tc.Method != nil {
+								Compression(tc.Method...)
+							}
+							if tc.Disable {
+								NoCompression()
+							}
+						})
+					})
+				})
+			}
+			if tc.Error != "" {
+				err := expr.RunInvalidDSL(t, dsl)
+				if !strings.Contains(err.Error(), tc.Error) {
+					t.Errorf("got error %q, expected error containing %q", err.Error(), tc.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, dsl)
+			actual := root.API.HTTP.Service("svc").Endpoint("method").ResponseCompression()
+			if strings.Join(actual, ",") != strings.Join(tc.Expected, ",") {
+				t.Errorf("got %v, expected %v", actual, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestPassThroughHeaders(t *testing.T) {
+	cases := map[string]struct {
+		API      []string
+		Service  []string
+		Method   []string
+		Expected []string
+		Error    string
+	}{
+		"none":    {nil, nil, nil, nil, ""},
+		"api":     {[]string{"x-request-id"}, nil, nil, []string{"X-Request-Id"}, ""},
+		"service": {[]string{"X-Request-Id"}, []string{"X-Trace"}, nil, []string{"X-Trace"}, ""},
+		"method":  {[]string{"X-Request-Id"}, []string{"X-Trace"}, []string{"X-Foo", "X-Bar"}, []string{"X-Foo", "X-Bar"}, ""},
+		"empty":   {[]string{}, nil, nil, nil, "requires at least one header name"},
+		"invalid": {[]string{"X Foo"}, nil, nil, nil, `invalid pass through header name "X Foo"`},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			dsl := func() {
+				API("test", func() {
+					HTTP(func() {
+						if tc.API != nil {
+							PassThroughHeaders(tc.API...)
+						}
+					})
+				})
+				Service("svc", func() {
+					HTTP(func() {
+						if tc.Service != nil {
+							PassThroughHeaders(tc.Service...)
+						}
+					})
+					Method("method", func() {
+						HTTP(func() {
+							GET("/")
+							if tc.Method != nil {
+								PassThroughHeaders(tc.Method...)
+							}
+						})
+					})
+				})
+			}
+			if tc.Error != "" {
+				err := expr.RunInvalidDSL(t, dsl)
+				if !strings.Contains(err.Error(), tc.Error) {
+					t.Errorf("got error %q, expected error containing %q", err.Error(), tc.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, dsl)
+			actual := root.API.HTTP.Service("svc").Endpoint("method").PassedThroughHeaders()
+			if strings.Join(actual, ",") != strings.Join(tc.Expected, ",") {
+				t.Errorf("got %v, expected %v", actual, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestRouter(t *testing.T) {
+	cases := map[string]struct {
+		Router   string
+		Expected string
+		Error    string
+	}{
+		"chi":        {"chi", "chi", ""},
+		"gorilla":    {"gorilla", "gorilla", ""},
+		"httprouter": {"httprouter", "httprouter", ""},
+		"servemux":   {"servemux", "servemux", ""},
+		"invalid":    {"echo", "", `invalid router "echo"`},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			dsl := func() {
+				API("test", func() {
+					HTTP(func() {
+						Router(tc.Router)
+					})
+				})
+			}
+			if tc.Error != "" {
+				err := expr.RunInvalidDSL(t, dsl)
+				if !strings.Contains(err.Error(), tc.Error) {
+					t.Errorf("got error %q, expected error containing %q", err.Error(), tc.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, dsl)
+			if root.API.HTTP.Router != tc.Expected {
+				t.Errorf("got router %q, expected %q", root.API.HTTP.Router, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestTrailingSlash(t *testing.T) {
+	cases := map[string]struct {
+		API      string
+		Service  string
+		Expected string
+		Error    string
+	}{
+		"none":     {"", "", "", ""},
+		"api":      {"redirect", "", "redirect", ""},
+		"service":  {"", "rewrite", "rewrite", ""},
+		"override": {"redirect", "strict", "strict", ""},
+		"invalid":  {"", "ignore", "", `invalid trailing slash policy "ignore"`},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			dsl := func() {
+				API("test", func() {
+					HTTP(func() {
+						if tc.API != "" {
+							TrailingSlash(tc.API)
+						}
+					})
+				})
+				Service("test", func() {
+					HTTP(func() {
+						if tc.Service != "" {
+							TrailingSlash(tc.Service)
+						}
+					})
+					Method("test", func() {
+						HTTP(func() {
+							GET("/")
+						})
+					})
+				})
+			}
+			if tc.Error != "" {
+				err := expr.RunInvalidDSL(t, dsl)
+				if !strings.Contains(err.Error(), tc.Error) {
+					t.Errorf("got error %q, expected error containing %q", err.Error(), tc.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, dsl)
+			e := root.API.HTTP.Services[0].HTTPEndpoints[0]
+			if p := e.TrailingSlashPolicy(); p != tc.Expected {
+				t.Errorf("got policy %q, expected %q", p, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestRouteConstraints(t *testing.T) {
+	cases := map[string]struct {
+		Path        string
+		Expected    string
+		Constraints map[string]string
+		Error       string
+	}{
+		"none":      {"/items/{id}", "/items/{id}", nil, ""},
+		"single":    {"/items/{id:[0-9]+}", "/items/{id}", map[string]string{"id": "[0-9]+"}, ""},
+		"braces":    {"/items/{id:[0-9]{3}}/{*path}", "/items/{id}/{*path}", map[string]string{"id": "[0-9]{3}"}, ""},
+		"catch-all": {"/items/{*path:.+}", "", nil, `catch-all wildcard "path" of path "/items/{*path:.+}" cannot define a constraint`},
+		"invalid":   {"/items/{id:[0-9}", "", nil, `invalid constraint "[0-9" for path parameter "id"`},
+		"unclosed":  {"/items/{id:[0-9]+", "", nil, `unbalanced braces in constraint of wildcard "id"`},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			dsl := func() {
+				Service("test", func() {
+					Method("test", func() {
+						Payload(func() {
+							Attribute("id", String)
+							Attribute("path", String)
+						})
+						HTTP(func() {
+							GET(tc.Path)
+						})
+					})
+				})
+			}
+			if tc.Error != "" {
+				err := expr.RunInvalidDSL(t, dsl)
+				if !strings.Contains(err.Error(), tc.Error) {
+					t.Errorf("got error %q, expected error containing %q", err.Error(), tc.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, dsl)
+			r := root.API.HTTP.Services[0].HTTPEndpoints[0].Routes[0]
+			if r.Path != tc.Expected {
+				t.Errorf("got path %q, expected %q", r.Path, tc.Expected)
+			}
+			if !reflect.DeepEqual(r.Constraints, tc.Constraints) {
+				t.Errorf("got constraints %v, expected %v", r.Constraints, tc.Constraints)
+			}
+		})
+	}
+}
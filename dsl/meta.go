@@ -65,6 +65,21 @@ import (
 //        })
 //    })
 //
+// - "goa:naming:initialisms", "goa:naming:acronyms" and "goa:naming:prefix"
+// customize how goa derives the generated Go identifiers from the design names.
+// "goa:naming:initialisms" lists words written as initialisms in addition to
+// the default ones (e.g. "ID", "HTTP" or "URL"), prefixing a word with "-"
+// removes it from the defaults. "goa:naming:acronyms" sets the casing of the
+// initialisms: "upper" (default, e.g. UserID) or "title" (e.g. UserId).
+// "goa:naming:prefix" lists prefixes removed from the design names, the first
+// matching prefix is removed. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("goa:naming:initialisms", "SKU", "-OK")
+//        Meta("goa:naming:acronyms", "title")
+//        Meta("goa:naming:prefix", "acme_")
+//    })
+//
 // - "struct:field:type" overrides the Go struct field type specified in the design, with one caveat;
 // if the type would have been a pointer (such as its not Required) the new type will also be a pointer.
 // Applicable to attributes only. The import path of the type should be passed in as the second parameter, if needed.
@@ -93,6 +108,35 @@ import (
 //        })
 //    })
 //
+// - "struct:pkg:path" generates the Go struct of the type in the package with
+// the given import path relative to the gen package instead of generating one
+// copy per service package. Services that share the type can then pass values
+// to each other without conversion. The types referenced by the type must be
+// generated in the same package. Applicable to types (not result types) that
+// are not used to define errors, conversions or results of methods that
+// support field masks.
+//
+//    var Address = Type("Address", func() {
+//        Attribute("street", String)
+//        Meta("struct:pkg:path", "types")
+//    })
+//
+// - "validation:sample" samples the validation of the elements of an array,
+// for example to avoid running expensive pattern validations on every element
+// of large arrays. The value is the fraction of the elements validated, a
+// number greater than 0 and lesser than 1. The generated code only samples the
+// validations once goa.SetValidationSampling(true) has been called, typically
+// by the production main function, so that tests keep running the full
+// validations. Applicable to array attributes only.
+//
+//    var Batch = Type("Batch", func() {
+//        Attribute("ids", ArrayOf(String, func() {
+//            Pattern(`^[a-z0-9]{32}$`)
+//        }), func() {
+//            Meta("validation:sample", "0.1")
+//        })
+//    })
+//
 // - "swagger:generate" specifies whether Swagger specification should be
 // generated. Defaults to true. Applicable to services, methods and file
 // servers.
@@ -140,6 +184,75 @@ import (
 //        Meta("swagger:extension:x-api", `{"foo":"bar"}`)
 //    })
 //
+// - "pact:consumer" sets the name of the consumer of the Pact contracts
+// generated in gen/http/pacts. Defaults to the service name followed by
+// "-client". Applicable to API and services.
+//
+//    var _ = Service("MyService", func() {
+//        Meta("pact:consumer", "web-frontend")
+//    })
+//
+// - "grpc:example:reflection" and "grpc:example:health" make the generated
+// example gRPC servers register the gRPC server reflection service and the
+// standard gRPC health service (grpc.health.v1.Health) respectively. The health
+// service runs the readiness checks registered in the generated health.go
+// file, see the HealthCheck DSL which also registers it. Applicable to API
+// only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("grpc:example:reflection")
+//        Meta("grpc:example:health")
+//    })
+//
+// - "http:example:h2c" makes the generated example HTTP servers serve cleartext
+// HTTP/2 (h2c) in addition to HTTP/1.1 and the generated example HTTP clients
+// send the requests made to "http" URLs over HTTP/2 with prior knowledge so
+// that concurrent and streaming requests share a single connection.
+// "http:example:http3" makes the example HTTP servers also serve HTTP/3 over
+// QUIC on the UDP port with the same number as the HTTP port. HTTP/3 requires
+// TLS, the HTTP/3 server only starts if the server configuration sets a
+// certificate. The generated code uses the github.com/quic-go/quic-go/http3
+// package which must be added to the module requirements. Applicable to API
+// only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("http:example:h2c")
+//        Meta("http:example:http3")
+//    })
+//
+// - "wasm:interop" generates a JavaScript interop file for the HTTP client of
+// the service, all the services if set on the API. The file is only compiled
+// when targeting WebAssembly (GOOS=js GOARCH=wasm) and defines an ExposeJS
+// function that makes the non-streaming client methods callable from
+// JavaScript. Applicable to API and services.
+//
+//    var _ = Service("MyService", func() {
+//        Meta("wasm:interop")
+//    })
+//
+// - "cli:alias" sets alternative names of the command or sub-command of the
+// generated command-line client. Applicable to services and methods.
+//
+//    var _ = Service("MyService", func() {
+//        Meta("cli:alias", "my")
+//        Method("MyMethod", func() {
+//            Meta("cli:alias", "mm", "m")
+//        })
+//    })
+//
+// - "cli:group" sets the name of the group the command or sub-command is listed
+// under in the usage of the generated command-line client. Commands and
+// sub-commands that do not define a group are listed first. Applicable to
+// services and methods.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Create", func() {
+//            Meta("cli:group", "Management")
+//        })
+//    })
+//
+// The API may use StrictMeta to reject the keys that are not listed above.
+//
 func Meta(name string, value ...string) {
 	appendMeta := func(meta expr.MetaExpr, name string, value ...string) expr.MetaExpr {
 		if meta == nil {
@@ -177,5 +290,7 @@ func Meta(name string, value ...string) {
 		att.Meta = appendMeta(att.Meta, name, value...)
 	default:
 		eval.IncompatibleDSL()
+		return
 	}
+	expr.Root.TrackMeta(eval.Current(), name)
 }
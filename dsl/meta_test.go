@@ -1,6 +1,7 @@
 package dsl_test
 
 import (
+	"strings"
 	"testing"
 
 	. "goa.design/goa/v3/dsl"
@@ -50,6 +51,45 @@ func TestMetaData(t *testing.T) {
 	}
 }
 
+func TestStrictMeta(t *testing.T) {
+	cases := map[string]struct {
+		Key   string
+		Error string
+	}{
+		"known":   {"struct:field:name", ""},
+		"prefix":  {"struct:tag:json", ""},
+		"allowed": {"plugin:option", ""},
+		"typo":    {"struct:feild:name", `unknown Meta key "struct:feild:name", did you mean "struct:field:name"? (StrictMeta is set)`},
+		"unknown": {"foo", `unknown Meta key "foo" (StrictMeta is set)`},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			dsl := func() {
+				API("test", func() {
+					StrictMeta("plugin:")
+				})
+				Service("test", func() {
+					Method("test", func() {
+						Payload(func() {
+							Attribute("id", String, func() {
+								Meta(tc.Key, "ID")
+							})
+						})
+					})
+				})
+			}
+			if tc.Error != "" {
+				err := expr.RunInvalidDSL(t, dsl)
+				if !strings.Contains(err.Error(), tc.Error) {
+					t.Errorf("got error %q, expected error containing %q", err.Error(), tc.Error)
+				}
+				return
+			}
+			expr.RunDSL(t, dsl)
+		})
+	}
+}
+
 func hasValue(vals []string, val string) bool {
 	for _, v := range vals {
 		if v == val {
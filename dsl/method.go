@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"strconv"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
@@ -33,3 +35,436 @@ func Method(name string, fn func()) {
 	ep := &expr.MethodExpr{Name: name, Service: s, DSLFunc: fn}
 	s.Methods = append(s.Methods, ep)
 }
+
+// Idempotent marks the method as idempotent: clients may send an idempotency
+// key with each request and the server may use the key to detect and replay
+// the response of requests that were already processed.
+//
+// Idempotent must appear in a Method expression.
+//
+// Idempotent takes no argument.
+//
+// Idempotent adds a String attribute named "idempotency_key" to the method
+// payload unless the payload already defines it. The HTTP transport maps the
+// attribute to the "Idempotency-Key" request header unless the design maps it
+// explicitly. The generated HTTP server exposes a UseIdempotency method that
+// wraps the handlers of the idempotent methods with a middleware backed by a
+// user provided store, see the middleware package.
+//
+// Example:
+//
+//    Method("create", func() {
+//        Idempotent()
+//        Payload(Account)
+//        Result(Account)
+//        HTTP(func() {
+//            POST("/accounts")
+//        })
+//    })
+//
+func Idempotent() {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if m.Meta == nil {
+		m.Meta = make(expr.MetaExpr)
+	}
+	m.Meta["goa:idempotent"] = nil
+}
+
+// Event marks the method as an event: the method payload describes the event
+// and the method does not return a result. The service package of a service
+// that defines events includes an in-process event bus, the Events struct,
+// with typed functions that publish the events to the subscribers registered
+// by the business code. The Endpoints PublishEvents method wraps the event
+// endpoints so that the events received by the transports are also published
+// on the bus once handled by the service.
+//
+// Event must appear in a Method expression.
+//
+// Event takes no argument.
+//
+// Example:
+//
+//    Method("order_placed", func() {
+//        Event()
+//        Payload(Order)
+//        HTTP(func() {
+//            POST("/events/order_placed")
+//        })
+//    })
+//
+func Event() {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if m.Meta == nil {
+		m.Meta = make(expr.MetaExpr)
+	}
+	m.Meta["goa:event"] = nil
+}
+
+// RenamedFrom records the previous names of a method or of an attribute so
+// that the generated code keeps accepting them while the clients and the
+// callers of the generated code migrate to the new name.
+//
+// RenamedFrom must appear in a Method, Type or Attribute expression.
+//
+// RenamedFrom accepts one or more arguments: the previous names.
+//
+// For a method, the generated HTTP servers also serve the routes whose path
+// contains the method name as a segment under the path that uses the previous
+// name instead, these alias routes set the "Deprecation" response header and
+// are marked as deprecated in the OpenAPI specifications. The generated service
+// client defines a deprecated method named after each previous name and the
+// service package defines deprecated aliases for the payload and result types
+// named after the method.
+//
+// For an attribute of a method payload, the generated HTTP servers accept the
+// previous names as query string parameter, header and top-level JSON body
+// field names when the request does not use the new name. For a type, the
+// service packages define a deprecated type alias named after each previous
+// name.
+//
+// Example:
+//
+//    var Account = Type("Account", func() {
+//        RenamedFrom("Acct")
+//        Attribute("display_name", String, func() {
+//            RenamedFrom("name")
+//        })
+//    })
+//
+//    Method("list_accounts", func() {
+//        RenamedFrom("list")
+//        Payload(Account)
+//        HTTP(func() {
+//            POST("/list_accounts") // also serves POST /list
+//        })
+//    })
+//
+func RenamedFrom(names ...string) {
+	var meta *expr.MetaExpr
+	switch e := eval.Current().(type) {
+	case *expr.MethodExpr:
+		meta = &e.Meta
+	case *expr.AttributeExpr:
+		meta = &e.Meta
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(names) == 0 {
+		eval.ReportError("RenamedFrom requires at least one name")
+		return
+	}
+	if *meta == nil {
+		*meta = make(expr.MetaExpr)
+	}
+	(*meta)["goa:renamedfrom"] = append((*meta)["goa:renamedfrom"], names...)
+}
+
+// StrictDecoding makes the generated HTTP servers reject request bodies that
+// contain fields not defined in the design instead of silently ignoring them.
+// The error returned to the client names the unknown field. StrictDecoding
+// applies to the decoders that support it, that is JSON request bodies.
+//
+// StrictDecoding must appear in a Service or Method expression. When used in
+// a Service expression StrictDecoding applies to all the service methods.
+//
+// StrictDecoding takes no argument.
+//
+// Example:
+//
+//    var _ = Service("payments", func() {
+//        StrictDecoding()
+//        Method("transfer", func() {
+//            Payload(Transfer)
+//            HTTP(func() {
+//                POST("/transfers")
+//            })
+//        })
+//    })
+//
+func StrictDecoding() {
+	var meta *expr.MetaExpr
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		meta = &e.Meta
+	case *expr.MethodExpr:
+		meta = &e.Meta
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *meta == nil {
+		*meta = make(expr.MetaExpr)
+	}
+	(*meta)["goa:strict"] = nil
+}
+
+// AggregateErrors makes the generated HTTP servers collect all the validation
+// errors of a request, including the errors of the request body, path and
+// query string parameters and headers, before responding. The error response
+// lists each violation in an "errors" array whose elements describe the path
+// of the invalid field, the name of the violated validation rule (e.g.
+// "missing_field" or "invalid_format") and a message. The generated OpenAPI
+// specification documents the shape of the response as the "ValidationError"
+// definition used by the method 400 response unless the design already defines
+// one.
+//
+// AggregateErrors must appear in a Service or Method expression. When used in
+// a Service expression AggregateErrors applies to all the service methods.
+//
+// AggregateErrors takes no argument.
+//
+// Example:
+//
+//    var _ = Service("accounts", func() {
+//        AggregateErrors()
+//        Method("create", func() {
+//            Payload(Account)
+//            HTTP(func() {
+//                POST("/accounts")
+//                Header("request_id:X-Request-ID")
+//            })
+//        })
+//    })
+//
+func AggregateErrors() {
+	var meta *expr.MetaExpr
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		meta = &e.Meta
+	case *expr.MethodExpr:
+		meta = &e.Meta
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *meta == nil {
+		*meta = make(expr.MetaExpr)
+	}
+	(*meta)["goa:aggregate"] = nil
+}
+
+// OptionalFields makes the generated code distinguish between the request body
+// fields that are omitted and the fields explicitly set to null, for example so
+// that PATCH handlers may clear values. The non-required payload attributes
+// mapped to the HTTP request body that are booleans, integers, floats or
+// strings and that do not define a default value are stored in goa.Optional
+// wrappers (e.g. goa.OptionalString) instead of pointers. The wrapper Set field
+// is false when the field is omitted and its Value field is nil when the field
+// is omitted or null.
+//
+// OptionalFields only applies to the top level attributes of the payload and to
+// JSON request bodies. The wrappers are used everywhere the payload type is
+// used so the type should not be used by methods that define gRPC endpoints.
+// The generated clients rely on the "omitzero" JSON struct tag option (Go 1.24
+// or later) to omit the fields that are not set.
+//
+// OptionalFields must appear in a Service or Method expression. When used in a
+// Service expression OptionalFields applies to all the service methods.
+//
+// OptionalFields takes no argument.
+//
+// Example:
+//
+//    var _ = Service("accounts", func() {
+//        Method("update", func() {
+//            OptionalFields()
+//            Payload(func() {
+//                Attribute("id", Int)
+//                Attribute("nickname", String) // null clears the nickname
+//                Required("id")
+//            })
+//            HTTP(func() {
+//                PATCH("/{id}")
+//            })
+//        })
+//    })
+//
+func OptionalFields() {
+	var meta *expr.MetaExpr
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		meta = &e.Meta
+	case *expr.MethodExpr:
+		meta = &e.Meta
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *meta == nil {
+		*meta = make(expr.MetaExpr)
+	}
+	(*meta)["goa:optional"] = nil
+}
+
+// FieldMask lets clients select the result fields returned by the method. The
+// generated endpoint prunes the result according to the goa.FieldMask stored
+// in the request context under the goa.FieldMaskKey key before encoding it:
+// the non-required result attributes that are not selected by the mask are
+// cleared. The HTTP transport reads the mask from the "fields" query string
+// parameter as a comma separated list of dot separated attribute paths (e.g.
+// "?fields=name,owner.email"). The gRPC transport reads the mask from the
+// "field_mask" field of type google.protobuf.FieldMask added to the request
+// message. The generated clients set the query string parameter or message
+// field from the mask stored in the request context, if any.
+//
+// FieldMask must appear in a Method expression. The method result must be an
+// object and the method cannot use streaming.
+//
+// FieldMask accepts one optional argument: the number of the "field_mask"
+// field in the gRPC request message. The number defaults to the number
+// following the largest request message field number.
+//
+// Example:
+//
+//    Method("show", func() {
+//        FieldMask()
+//        Payload(func() {
+//            Field(1, "id", String)
+//        })
+//        Result(Account)
+//        HTTP(func() {
+//            GET("/accounts/{id}")
+//        })
+//        GRPC(func() {})
+//    })
+//
+func FieldMask(tag ...int) {
+	if len(tag) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if m.Meta == nil {
+		m.Meta = make(expr.MetaExpr)
+	}
+	var val []string
+	if len(tag) > 0 {
+		val = []string{strconv.Itoa(tag[0])}
+	}
+	m.Meta["goa:fieldmask"] = val
+}
+
+// Sunset marks the method as deprecated and sets the date after which it may
+// stop being served.
+//
+// Sunset must appear in a Method expression.
+//
+// Sunset takes one argument: the sunset date formatted as a RFC 3339 date
+// (e.g. "2021-06-30") or date-time (e.g. "2021-06-30T12:00:00Z").
+//
+// The generated HTTP handlers of the method set the "Deprecation" and "Sunset"
+// response headers (see RFC 8594) and the generated OpenAPI specifications mark
+// the corresponding operations as deprecated.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Sunset("2021-06-30")
+//        Result(CollectionOf(Account))
+//        HTTP(func() {
+//            GET("/accounts")
+//        })
+//    })
+//
+func Sunset(date string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if m.Meta == nil {
+		m.Meta = make(expr.MetaExpr)
+	}
+	m.Meta["goa:sunset"] = []string{date}
+}
+
+// Dependency defines a per-request dependency of the service methods such as a
+// datastore transaction. The generated endpoints create the dependency before
+// calling the method, store it in the request context and finalize it once the
+// method returns. The methods retrieve the dependency with the
+// <Name>FromContext function generated in the service package.
+//
+// The service implementation creates and finalizes the dependencies: it must
+// implement the Dependencies interface generated in the service package which
+// defines a New<Name> and a Finalize<Name> method for each dependency. The
+// endpoints give the error returned by the method to Finalize<Name> (nil if
+// the method succeeded) so that it may for example commit or roll back a
+// transaction. The endpoints return the error returned by Finalize<Name> if
+// the method succeeded. The dependencies are created once the request is
+// authorized and finalized in the reverse order.
+//
+// Dependency must appear in a Service or Method expression. When used in a
+// Service expression Dependency applies to all the service methods.
+//
+// Dependency accepts two or three arguments: the name of the dependency, the Go
+// type of the dependency and optionally the import path of the package that
+// defines the type.
+//
+// Example:
+//
+//    var _ = Service("orders", func() {
+//        Method("create", func() {
+//            Dependency("tx", "*sql.Tx", "database/sql")
+//            Payload(Order)
+//            HTTP(func() {
+//                POST("/orders")
+//            })
+//        })
+//    })
+//
+// The service implementation then looks like:
+//
+//    func (s *orderssrvc) NewTx(ctx context.Context) (*sql.Tx, error) {
+//        return s.db.BeginTx(ctx, nil)
+//    }
+//
+//    func (s *orderssrvc) FinalizeTx(ctx context.Context, tx *sql.Tx, err error) error {
+//        if err != nil {
+//            return tx.Rollback()
+//        }
+//        return tx.Commit()
+//    }
+//
+//    func (s *orderssrvc) Create(ctx context.Context, p *orders.Order) error {
+//        tx := orders.TxFromContext(ctx)
+//        ...
+//    }
+//
+func Dependency(name, typ string, pkgPath ...string) {
+	if len(pkgPath) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	var meta *expr.MetaExpr
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		meta = &e.Meta
+	case *expr.MethodExpr:
+		meta = &e.Meta
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *meta == nil {
+		*meta = make(expr.MetaExpr)
+	}
+	if _, ok := (*meta)["goa:dependency:"+name]; ok {
+		eval.ReportError("dependency %q is already defined", name)
+		return
+	}
+	(*meta)["goa:dependency"] = append((*meta)["goa:dependency"], name)
+	(*meta)["goa:dependency:"+name] = append([]string{typ}, pkgPath...)
+}
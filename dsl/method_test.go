@@ -91,6 +91,21 @@ func TestMethod(t *testing.T) {
 				}
 			},
 		},
+		"idempotent": {
+			func() {
+				Method("idempotent", func() {
+					Idempotent()
+				})
+			},
+			func(t *testing.T, methods []*expr.MethodExpr) {
+				if len(methods) != 1 {
+					t.Fatalf("idempotent: expected 1 method, got %d", len(methods))
+				}
+				if !methods[0].IsIdempotent() {
+					t.Errorf("idempotent: expected method to be idempotent")
+				}
+			},
+		},
 	}
 	//Run our tests
 	for k, tc := range cases {
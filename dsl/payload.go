@@ -8,7 +8,7 @@ import (
 // Payload defines the data type of an method input. Payload also makes the
 // input required.
 //
-// Payload must appear in a Method expression.
+// Payload must appear in a Method, Webhook or Callback expression.
 //
 // Payload takes one to three arguments. The first argument is either a type or
 // a DSL function. If the first argument is a type then an optional description
@@ -73,12 +73,16 @@ func Payload(val interface{}, args ...interface{}) {
 	if len(args) > 2 {
 		eval.ReportError("too many arguments")
 	}
-	e, ok := eval.Current().(*expr.MethodExpr)
-	if !ok {
+	switch e := eval.Current().(type) {
+	case *expr.MethodExpr:
+		e.Payload = methodDSL("Payload", val, args...)
+	case *expr.WebhookExpr:
+		e.Payload = methodDSL("Payload", val, args...)
+	case *expr.HTTPCallbackExpr:
+		e.Payload = methodDSL("Payload", val, args...)
+	default:
 		eval.IncompatibleDSL()
-		return
 	}
-	e.Payload = methodDSL("Payload", val, args...)
 }
 
 // StreamingPayload defines a method that accepts a stream of instances of the
@@ -141,6 +145,116 @@ func StreamingPayload(val interface{}, args ...interface{}) {
 	}
 }
 
+// PatchOf creates the type of a JSON merge patch (RFC 7396) payload that
+// modifies instances of the given type.
+//
+// PatchOf may be used wherever types can, typically as the argument of
+// Payload. The first argument of PatchOf is the patched type specified by name
+// or by reference, it must be an object. The second argument is an optional
+// function that defines additional attributes, for example the attributes
+// mapped to the request path. The additional attributes are not patched.
+//
+// PatchOf returns a type named after the patched type with the "Patch" suffix
+// which defines the same attributes as the patched type with the same
+// validations except that none are required and none have a default value,
+// DefaultFrom does not apply either as omitted fields are left unchanged. The
+// primitive attributes mapped to the HTTP request body are stored in
+// goa.Optional wrappers so that the generated code can tell fields set to null
+// from omitted fields, see OptionalFields. The generated patch type defines an
+// Apply method that merges the patch into a value of the patched type: fields
+// set to null are cleared (reset to their default value if they have one) and
+// fields set to a value replace the existing values. Non primitive attributes
+// are replaced as a whole when present.
+//
+// The generated HTTP clients send merge patch requests with the
+// "application/merge-patch+json" content type.
+//
+// Example:
+//
+//    var Account = Type("Account", func() {
+//        Attribute("name", String)
+//        Attribute("nickname", String)
+//        Required("name")
+//    })
+//
+//    Method("update", func() {
+//        Payload(PatchOf(Account, func() {
+//            Attribute("id", Int)
+//            Required("id")
+//        }))
+//        HTTP(func() {
+//            PATCH("/{id}")
+//        })
+//    })
+//
+func PatchOf(v interface{}, fn ...func()) expr.UserType {
+	if len(fn) > 1 {
+		eval.ReportError("PatchOf: too many arguments")
+	}
+	var name string
+	switch a := v.(type) {
+	case string:
+		name = a
+	case expr.UserType:
+		name = a.Name()
+	}
+	if name == "" {
+		eval.ReportError("invalid PatchOf argument: not a user type and not a known user type name")
+		// don't return nil to avoid panics, the error will get reported at the end
+		return &expr.UserTypeExpr{TypeName: "InvalidPatch", AttributeExpr: &expr.AttributeExpr{Type: &expr.Object{}}}
+	}
+	typeName := name + "Patch"
+	if t := expr.Root.UserType(typeName); t != nil {
+		if len(fn) > 0 {
+			eval.ReportError("type %#v defined twice", typeName)
+		}
+		return t
+	}
+	var patched expr.UserType
+	resolveType(v, func(t expr.DataType) {
+		ut, ok := t.(expr.UserType)
+		if !ok {
+			eval.ReportError("invalid PatchOf argument: not a user type and not a known user type name")
+			return
+		}
+		patched = ut
+	})
+	att := &expr.AttributeExpr{
+		Type:        &expr.Object{},
+		Description: "JSON merge patch of " + name,
+		Meta:        expr.MetaExpr{"goa:patch": []string{name}},
+	}
+	att.DSLFunc = func() {
+		if patched == nil {
+			return
+		}
+		executeTypeDSL(patched)
+		obj := expr.AsObject(patched)
+		if obj == nil {
+			eval.ReportError("invalid PatchOf argument: %q is not an object", name)
+			return
+		}
+		for _, nat := range *obj {
+			pa := expr.DupAtt(nat.Attribute)
+			pa.Type = nat.Attribute.Type
+			pa.DefaultValue = nil
+			delete(pa.Meta, "goa:defaultfrom")
+			pa.DSLFunc = nil
+			att.Type.(*expr.Object).Set(nat.Name, pa)
+		}
+		if len(fn) == 1 {
+			fn[0]()
+		}
+	}
+	t := &expr.UserTypeExpr{TypeName: typeName, AttributeExpr: att}
+	expr.Root.Types = append(expr.Root.Types, t)
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		// The user types DSL has already run.
+		eval.ExecuteOnce(att)
+	}
+	return t
+}
+
 func methodDSL(suffix string, p interface{}, args ...interface{}) *expr.AttributeExpr {
 	var (
 		att *expr.AttributeExpr
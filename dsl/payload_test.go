@@ -0,0 +1,73 @@
+package dsl_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestPatchOf(t *testing.T) {
+	root := expr.RunDSL(t, func() {
+		Service("accounts", func() {
+			Method("update", func() {
+				Payload(PatchOf("Account", func() {
+					Attribute("id", Int)
+					Required("id")
+				}))
+				HTTP(func() {
+					PATCH("/{id}")
+				})
+			})
+		})
+		Type("Account", func() {
+			Attribute("name", String, func() {
+				MinLength(1)
+			})
+			Attribute("age", Int, func() {
+				Default(18)
+			})
+			Attribute("tags", ArrayOf(String))
+			Attribute("nickname", String, func() {
+				DefaultFrom("name")
+			})
+			Required("name")
+		})
+	})
+	patch := root.UserType("AccountPatch")
+	if patch == nil {
+		t.Fatal("type AccountPatch not found")
+	}
+	if pt := expr.PatchedType(patch); pt != root.UserType("Account") {
+		t.Fatalf("got patched type %v, expected Account", pt)
+	}
+	att := patch.Attribute()
+	for _, n := range []string{"name", "age", "tags", "id"} {
+		if att.Find(n) == nil {
+			t.Errorf("attribute %q not found", n)
+		}
+	}
+	if att.IsRequired("name") {
+		t.Errorf("name attribute is required")
+	}
+	if !att.IsRequired("id") {
+		t.Errorf("id attribute is not required")
+	}
+	if att.Find("age").DefaultValue != nil {
+		t.Errorf("age attribute has a default value")
+	}
+	if from := att.Find("nickname").DefaultFrom(); from != "" {
+		t.Errorf("nickname attribute defaults from %q", from)
+	}
+	if v := att.Find("name").Validation; v == nil || v.MinLength == nil || *v.MinLength != 1 {
+		t.Errorf("name attribute validation not copied")
+	}
+	for n, optional := range map[string]bool{"name": true, "age": true, "tags": false, "id": false} {
+		if att.Find(n).IsOptionalField() != optional {
+			t.Errorf("got optional %v for attribute %q, expected %v", !optional, n, optional)
+		}
+	}
+	if root.UserType("Account").Attribute().Find("name").IsOptionalField() {
+		t.Errorf("patched type attribute name is optional")
+	}
+}
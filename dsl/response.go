@@ -159,6 +159,23 @@ func Response(val interface{}, args ...interface{}) {
 			eval.Execute(fn, resp)
 		}
 		t.Response = resp
+	case *expr.HTTPCallbackExpr:
+		if ok {
+			eval.InvalidArgError("HTTP status code", val)
+			return
+		}
+		code, fn := parseResponseArgs(val, args...)
+		if code == 0 {
+			code = expr.StatusOK
+		}
+		resp := &expr.HTTPResponseExpr{
+			StatusCode: code,
+			Parent:     t,
+		}
+		if fn != nil {
+			eval.Execute(fn, resp)
+		}
+		t.Responses = append(t.Responses, resp)
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"strings"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
@@ -147,3 +149,105 @@ func StreamingResult(val interface{}, args ...interface{}) {
 		e.Stream = expr.ServerStreamKind
 	}
 }
+
+// MultiStatusOf creates the envelope type of a HTTP 207 Multi-Status response
+// whose items each carry the status and either the result or the error of one
+// operation of a batch.
+//
+// MultiStatusOf may be used wherever types can, typically as the argument of
+// Result. The argument of MultiStatusOf is the type of the successful item
+// results specified by name or by reference.
+//
+// MultiStatusOf returns a type named after the item type with the
+// "MultiStatus" suffix which defines a single "items" attribute. The items are
+// objects that define a required "status" attribute holding the item HTTP
+// status code, a "result" attribute holding the item result on success and an
+// "error" attribute holding the item error name and message on failure. The
+// HTTP endpoints of methods whose result is a multi-status type use the 207
+// status code by default.
+//
+// Example:
+//
+//    Method("batch_create", func() {
+//        Payload(ArrayOf(Account))
+//        Result(MultiStatusOf(Account))
+//        HTTP(func() {
+//            POST("/accounts/batch")
+//        })
+//    })
+//
+func MultiStatusOf(v interface{}) expr.UserType {
+	var name string
+	switch a := v.(type) {
+	case string:
+		name = a
+	case expr.UserType:
+		name = a.Name()
+	case expr.Primitive:
+		name = strings.Title(a.Name())
+	}
+	if name == "" {
+		eval.ReportError("invalid MultiStatusOf argument: not a primitive, not a user type and not a known user type name")
+		// don't return nil to avoid panics, the error will get reported at the end
+		return &expr.UserTypeExpr{TypeName: "InvalidMultiStatus", AttributeExpr: &expr.AttributeExpr{Type: &expr.Object{}}}
+	}
+	typeName := name + "MultiStatus"
+	if t := expr.Root.UserType(typeName); t != nil {
+		return t
+	}
+	result := &expr.AttributeExpr{Type: expr.String, Description: "Result of the operation if successful"}
+	resolveType(v, func(t expr.DataType) {
+		if t == nil {
+			eval.ReportError("invalid MultiStatusOf argument: not a type and not a known user type name")
+			return
+		}
+		result.Type = t
+	})
+	item := &expr.UserTypeExpr{
+		TypeName: name + "Status",
+		AttributeExpr: &expr.AttributeExpr{
+			Description: "Status and outcome of a single operation",
+			Type: &expr.Object{
+				{Name: "status", Attribute: &expr.AttributeExpr{Type: expr.Int, Description: "HTTP status code of the operation"}},
+				{Name: "result", Attribute: result},
+				{Name: "error", Attribute: &expr.AttributeExpr{Type: multiStatusError(), Description: "Error of the operation if unsuccessful"}},
+			},
+			Validation: &expr.ValidationExpr{Required: []string{"status"}},
+		},
+	}
+	t := &expr.UserTypeExpr{
+		TypeName: typeName,
+		AttributeExpr: &expr.AttributeExpr{
+			Description: "Multi-status response envelope",
+			Type: &expr.Object{
+				{Name: "items", Attribute: &expr.AttributeExpr{Type: &expr.Array{ElemType: &expr.AttributeExpr{Type: item}}}},
+			},
+			Validation: &expr.ValidationExpr{Required: []string{"items"}},
+			Meta:       expr.MetaExpr{"goa:multistatus": nil},
+		},
+	}
+	expr.Root.Types = append(expr.Root.Types, item, t)
+	return t
+}
+
+// multiStatusError returns the type of the errors of the multi-status response
+// items, it is shared by all the multi-status types.
+func multiStatusError() expr.UserType {
+	const name = "MultiStatusError"
+	if t := expr.Root.UserType(name); t != nil {
+		return t
+	}
+	t := &expr.UserTypeExpr{
+		TypeName: name,
+		AttributeExpr: &expr.AttributeExpr{
+			Description: "Error of a single multi-status operation",
+			Type: &expr.Object{
+				{Name: "name", Attribute: &expr.AttributeExpr{Type: expr.String, Description: "Name of the error"}},
+				{Name: "message", Attribute: &expr.AttributeExpr{Type: expr.String, Description: "Error message"}},
+			},
+			Validation: &expr.ValidationExpr{Required: []string{"name", "message"}},
+		},
+	}
+	expr.Root.Types = append(expr.Root.Types, t)
+	return t
+}
@@ -0,0 +1,66 @@
+package dsl_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestMultiStatusOf(t *testing.T) {
+	root := expr.RunDSL(t, func() {
+		Service("batch", func() {
+			Method("create", func() {
+				Payload(ArrayOf("Account"))
+				Result(MultiStatusOf("Account"))
+				HTTP(func() {
+					POST("/")
+				})
+			})
+			Method("update", func() {
+				Result(MultiStatusOf("Account"))
+				HTTP(func() {
+					PUT("/")
+					Response(StatusOK)
+				})
+			})
+		})
+		Type("Account", func() {
+			Attribute("name", String)
+		})
+	})
+	ms := root.UserType("AccountMultiStatus")
+	if ms == nil {
+		t.Fatal("type AccountMultiStatus not found")
+	}
+	if !expr.IsMultiStatus(ms) {
+		t.Errorf("AccountMultiStatus is not a multi-status type")
+	}
+	if !ms.Attribute().IsRequired("items") {
+		t.Errorf("items attribute is not required")
+	}
+	item := expr.AsArray(ms.Attribute().Find("items").Type).ElemType.Type
+	if item != root.UserType("AccountStatus") {
+		t.Fatalf("got item type %s, expected AccountStatus", item.Name())
+	}
+	if !item.(expr.UserType).Attribute().IsRequired("status") {
+		t.Errorf("status attribute is not required")
+	}
+	if res := item.(expr.UserType).Attribute().Find("result"); res.Type != root.UserType("Account") {
+		t.Errorf("got result type %s, expected Account", res.Type.Name())
+	}
+	if e := item.(expr.UserType).Attribute().Find("error"); e.Type != root.UserType("MultiStatusError") {
+		t.Errorf("got error type %s, expected MultiStatusError", e.Type.Name())
+	}
+	methods := root.Services[0].Methods
+	if methods[0].Result.Type != methods[1].Result.Type {
+		t.Errorf("got distinct multi-status types for the same item type")
+	}
+	endpoints := root.API.HTTP.Services[0].HTTPEndpoints
+	if status := endpoints[0].Responses[0].StatusCode; status != expr.StatusMultiStatus {
+		t.Errorf("got default status %d, expected %d", status, expr.StatusMultiStatus)
+	}
+	if status := endpoints[1].Responses[0].StatusCode; status != expr.StatusOK {
+		t.Errorf("got status %d, expected %d", status, expr.StatusOK)
+	}
+}
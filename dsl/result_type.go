@@ -236,8 +236,10 @@ func View(name string, adsl ...func()) {
 //
 // CollectionOf must appear wherever ResultType can.
 //
-// CollectionOf takes the element result type as first argument and an optional
-// DSL as second argument.
+// CollectionOf takes the element result type or its identifier as first
+// argument and an optional DSL as second argument. Result types specified by
+// identifier may be defined in packages that initialize after the package
+// using CollectionOf.
 //
 // Example:
 //
@@ -262,24 +264,36 @@ func View(name string, adsl ...func()) {
 //     })
 //
 func CollectionOf(v interface{}, adsl ...func()) *expr.ResultTypeExpr {
-	var m *expr.ResultTypeExpr
-	var ok bool
-	m, ok = v.(*expr.ResultTypeExpr)
-	if !ok {
-		if id, ok := v.(string); ok {
-			if dt := expr.Root.UserType(expr.CanonicalIdentifier(id)); dt != nil {
-				if mt, ok := dt.(*expr.ResultTypeExpr); ok {
-					m = mt
+	var (
+		m  *expr.ResultTypeExpr
+		id string
+	)
+	switch a := v.(type) {
+	case *expr.ResultTypeExpr:
+		m = a
+		if m != nil {
+			id = m.Identifier
+		}
+	case string:
+		id = a
+		if m = resultTypeByID(id); m != nil {
+			id = m.Identifier
+		} else {
+			// The element result type may be defined in a package that
+			// initializes later, look it up once all the packages are
+			// initialized.
+			eval.Defer(func() {
+				if m = resultTypeByID(a); m == nil {
+					eval.ReportError("invalid CollectionOf argument: %#v is not a known result type identifier", a)
 				}
-			}
+			})
 		}
 	}
-	if m == nil {
+	if id == "" {
 		eval.ReportError("invalid CollectionOf argument: not a result type and not a known result type identifier")
 		// don't return nil to avoid panics, the error will get reported at the end
 		return expr.NewResultTypeExpr("InvalidCollection", "text/plain", nil)
 	}
-	id := m.Identifier
 	rtype, params, err := mime.ParseMediaType(id)
 	if err != nil {
 		eval.ReportError("invalid result type identifier %#v: %s", id, err)
@@ -308,6 +322,10 @@ func CollectionOf(v interface{}, adsl ...func()) *expr.ResultTypeExpr {
 			eval.IncompatibleDSL()
 			return
 		}
+		if m == nil {
+			// error already reported
+			return
+		}
 		// Cannot compute collection type name before element result type
 		// DSL has executed since the DSL may modify element type name
 		// via the TypeName function.
@@ -333,6 +351,39 @@ func CollectionOf(v interface{}, adsl ...func()) *expr.ResultTypeExpr {
 	return mt
 }
 
+// executeTypeDSL runs the DSL of the given design type if it has not run yet
+// so that the types that reference or extend it may use its attributes even
+// when it is defined in a package that initializes after theirs.
+func executeTypeDSL(t expr.DataType) {
+	for _, ut := range expr.Root.Types {
+		if ut == t {
+			eval.ExecuteOnce(ut.Attribute())
+			return
+		}
+	}
+	for _, rt := range expr.Root.ResultTypes {
+		if rt == t {
+			eval.ExecuteOnce(rt.(*expr.ResultTypeExpr))
+			return
+		}
+	}
+}
+
+// resultTypeByID returns the result type with the given identifier or name,
+// nil if there isn't one.
+func resultTypeByID(id string) *expr.ResultTypeExpr {
+	canonical := expr.CanonicalIdentifier(id)
+	for _, t := range expr.Root.ResultTypes {
+		if rt := t.(*expr.ResultTypeExpr); expr.CanonicalIdentifier(rt.Identifier) == canonical {
+			return rt
+		}
+	}
+	if rt, ok := expr.Root.UserType(id).(*expr.ResultTypeExpr); ok {
+		return rt
+	}
+	return nil
+}
+
 // Reference sets a type or result type reference. The value itself can be a
 // type or a result type. The reference type attributes define the default
 // properties for attributes with the same name in the type using the reference.
@@ -375,6 +426,7 @@ func Reference(t expr.DataType) {
 		eval.ReportError("argument of Reference must be an object, got %s", t.Name())
 		return
 	}
+	executeTypeDSL(t)
 	switch def := eval.Current().(type) {
 	case *expr.ResultTypeExpr:
 		def.References = append(def.References, t)
@@ -412,6 +464,7 @@ func Extend(t expr.DataType) {
 		eval.ReportError("argument of Extend must be an object, got %s", t.Name())
 		return
 	}
+	executeTypeDSL(t)
 	switch def := eval.Current().(type) {
 	case *expr.ResultTypeExpr:
 		def.Bases = append(def.Bases, t)
@@ -306,6 +306,55 @@ func NoSecurity() {
 	}
 }
 
+// Permission defines the permissions the authenticated principal must be
+// granted to call a method. The generated endpoints check the permissions once
+// the request is authorized: the Auther implementation must store the principal
+// in the context with security.ContextWithPrincipal and the principal must
+// implement security.PermissionHolder. The endpoints return a "forbidden"
+// error if a permission is missing, define an error with this name to map it
+// to a transport specific response.
+//
+// Permission must appear in a Service or Method expression of a secured
+// method. When used in a Service expression Permission applies to all the
+// service methods. The generated service package defines a constant for each
+// permission and the OpenAPI specification lists them in the "x-permissions"
+// extension of the operations.
+//
+// Permission accepts one or more permission names as arguments.
+//
+// Example:
+//
+//    var _ = Service("orders", func() {
+//        Security(JWT)
+//        Error("forbidden")
+//
+//        Method("create", func() {
+//            Permission("orders:write")
+//            Payload(Order)
+//            HTTP(func() {
+//                POST("/orders")
+//                Response("forbidden", StatusForbidden)
+//            })
+//        })
+//    })
+//
+func Permission(names ...string) {
+	var meta *expr.MetaExpr
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		meta = &e.Meta
+	case *expr.MethodExpr:
+		meta = &e.Meta
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *meta == nil {
+		*meta = make(expr.MetaExpr)
+	}
+	(*meta)["goa:permission"] = append((*meta)["goa:permission"], names...)
+}
+
 // Username defines the attribute used to provide the username to an endpoint
 // secured with basic authentication. The parameters and usage of Username are
 // the same as the goa DSL Attribute function.
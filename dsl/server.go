@@ -96,6 +96,77 @@ func Services(svcs ...string) {
 	s.Services = append(s.Services, svcs...)
 }
 
+// TLS declares that the hosts of a server only accept TLS connections. The
+// example server generated for the server refuses to start unless its
+// configuration file sets the certificate and private key used to serve TLS.
+// The certificate is read again when the configuration is reloaded.
+//
+// TLS must appear in a Server expression.
+//
+// TLS accepts an optional DSL function that may use ClientAuth to require the
+// clients to authenticate with a certificate (mutual TLS).
+//
+// Example:
+//
+//    var _ = Server("calcsvr", func() {
+//        TLS(func() {
+//            ClientAuth("require")
+//        })
+//        Host("production", func() {
+//            URI("https://goa.design/calc")
+//            URI("grpcs://goa.design")
+//        })
+//    })
+//
+func TLS(fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to TLS")
+		return
+	}
+	s, ok := eval.Current().(*expr.ServerExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	t := &expr.TLSExpr{Server: s}
+	if len(fn) > 0 {
+		eval.Execute(fn[0], t)
+	}
+	s.TLS = t
+}
+
+// ClientAuth sets the policy of a server for the client certificates. The
+// possible values are:
+//
+//    - "request": the server verifies the certificates presented by the
+//      clients but accepts the connections of clients that present none.
+//    - "require": the clients must present a valid certificate (mutual TLS).
+//
+// The example server verifies the client certificates with the certificate
+// authorities listed in its configuration file, the example client tool
+// accepts flags to set the client certificate.
+//
+// ClientAuth must appear in a TLS expression.
+//
+// ClientAuth takes one argument: the policy.
+//
+// Example:
+//
+//    var _ = Server("calcsvr", func() {
+//        TLS(func() {
+//            ClientAuth("require")
+//        })
+//    })
+//
+func ClientAuth(policy string) {
+	t, ok := eval.Current().(*expr.TLSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	t.ClientAuth = policy
+}
+
 // Host defines a server host. A single server may define multiple hosts. Each
 // host lists the set of URIs that identify it.
 //
@@ -131,14 +202,69 @@ func Host(name string, fn func()) {
 	s.Hosts = append(s.Hosts, host)
 }
 
+// Environment declares that a host serves a deployment environment such as
+// "dev", "staging" or "prod". The HTTP client packages define a
+// NewClientForEnvironment function that creates clients that send the
+// requests to the host serving the given environment and the OpenAPI
+// specification lists the URL of each environment in the "x-servers"
+// extension. An environment may only be served by one host of a server.
+//
+// Environment must appear in a Host expression.
+//
+// Environment takes the name of the environment and an optional DSL function.
+// The DSL may use Variable to set the values of the host URI variables in the
+// environment, the other variables use their default value.
+//
+// Example:
+//
+//    var _ = Server("calcsvr", func() {
+//        Host("development", func() {
+//            URI("http://localhost:8000/calc")
+//            Environment("dev")
+//        })
+//        Host("production", func() {
+//            URI("https://{region}.goa.design/calc")
+//            Variable("region", String, "Cloud region", func() {
+//                Enum("us", "eu")
+//                Default("us")
+//            })
+//            Environment("staging", func() {
+//                Variable("region", "eu")
+//            })
+//            Environment("prod")
+//        })
+//    })
+//
+func Environment(name string, fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Environment")
+		return
+	}
+	h, ok := eval.Current().(*expr.HostExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	env := &expr.EnvironmentExpr{Name: name, Host: h}
+	if len(fn) > 0 {
+		eval.Execute(fn[0], env)
+	}
+	h.Environments = append(h.Environments, env)
+}
+
 // URI defines a server host URI. A single host may define multiple URIs. The
-// supported schemes are 'http', 'https', 'grpc' and 'grpcs' where 'grpcs'
-// indicates gRPC using client-side SSL/TLS. gRPC URIs may only define the
-// authority component (in particular no path). URIs may be parameterized using
-// the {param} notation. Note that the variables appearing in a URI must be
+// supported schemes are 'http', 'https', 'grpc', 'grpcs' and 'unix' where
+// 'grpcs' indicates gRPC using client-side SSL/TLS and 'unix' indicates HTTP
+// served on the Unix domain socket whose path follows the scheme (e.g.
+// unix:///var/run/calc.sock). gRPC URIs may only define the authority
+// component (in particular no path). URIs may be parameterized using the
+// {param} notation. Note that the variables appearing in a URI must be
 // provided when the service is initialized and in particular their values
 // cannot defer between requests.
 //
+// The example servers listen on the sockets passed by systemd socket
+// activation that match the host URIs, see goa.Listen.
+//
 // The URI expression is leveraged by the example generator to produce the
 // service and client commands. It is also consumed by the OpenAPI specification
 // generator to initialize the server objects.
@@ -153,6 +279,7 @@ func Host(name string, fn func()) {
 //        Host("development", func() {
 //            URI("http://localhost:80/{version}/calc")
 //            URI("grpc://localhost:8080")
+//            URI("unix:///var/run/calc.sock")
 //        })
 //    })
 //
@@ -171,14 +298,17 @@ func URI(uri string) {
 // service and client commands. It is also consumed by the OpenAPI specification
 // generator to initialize the server objects.
 //
-// Variable must appear in a Host expression.
+// Variable must appear in a Host or Environment expression.
 //
-// The Variable DSL is the same as the Attribute DSL with the following two
-// restrictions:
+// In a Host expression the Variable DSL is the same as the Attribute DSL with
+// the following two restrictions:
 //
 //    1. The type used to define the variable must be a primitive.
 //    2. The variable must have a default value and/or a enum validation.
 //
+// In an Environment expression Variable takes two arguments: the name of a
+// variable defined by the host and its value in the environment.
+//
 // Example:
 //
 //    var _ = Server("calcsvr", func() {
@@ -193,9 +323,19 @@ func URI(uri string) {
 //    })
 //
 func Variable(name string, args ...interface{}) {
-	if _, ok := eval.Current().(*expr.HostExpr); !ok {
+	switch e := eval.Current().(type) {
+	case *expr.HostExpr:
+		Attribute(name, args...)
+	case *expr.EnvironmentExpr:
+		if len(args) != 1 {
+			eval.ReportError("Variable must be given a name and a value in an Environment expression")
+			return
+		}
+		if e.Variables == nil {
+			e.Variables = make(map[string]interface{})
+		}
+		e.Variables[name] = args[0]
+	default:
 		eval.IncompatibleDSL()
-		return
 	}
-	Attribute(name, args...)
 }
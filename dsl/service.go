@@ -65,3 +65,35 @@ func Service(name string, fn func()) *expr.ServiceExpr {
 	expr.Root.Services = append(expr.Root.Services, s)
 	return s
 }
+
+// MemoizeViews makes the generated code memoize the projections of the result
+// types rendered by the service methods. When a result refers to the same
+// value multiple times (for example a collection whose items share the same
+// nested result) the value is projected once per view and the projection is
+// reused for the other occurrences. The memo only lives for the duration of
+// the rendering of a single result.
+//
+// MemoizeViews must appear in a Service expression.
+//
+// MemoizeViews takes no argument.
+//
+// Example:
+//
+//    var _ = Service("catalog", func() {
+//        MemoizeViews()
+//        Method("list", func() {
+//            Result(CollectionOf(Product))
+//        })
+//    })
+//
+func MemoizeViews() {
+	s, ok := eval.Current().(*expr.ServiceExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if s.Meta == nil {
+		s.Meta = make(expr.MetaExpr)
+	}
+	s.Meta["goa:memoize"] = nil
+}
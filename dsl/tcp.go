@@ -0,0 +1,60 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// TCP enables the raw TCP transport for methods that define a StreamingResult.
+// The transport streams the results over plain TCP or TLS connections using a
+// length prefixed framing of the messages, see package goa.design/goa/v3/tcp.
+// It is intended for internal high throughput consumers that do not need the
+// HTTP or WebSocket protocols.
+//
+// TCP must appear in a Service or Method expression. When used in a Service
+// expression TCP applies to all the service methods that define a
+// StreamingResult, the other methods are ignored. When used in a Method
+// expression the method must define a StreamingResult and the encoding
+// overrides the one defined on the service if any.
+//
+// TCP accepts an optional argument which specifies the encoding of the
+// messages: "json" (default) encodes the service payload and result types
+// using JSON while "proto" uses the protocol buffer messages generated for the
+// gRPC transport. The "proto" encoding requires the method to define a gRPC
+// endpoint that does not use metadata and a result that is not a result type.
+//
+// Example:
+//
+//    var _ = Service("ticker", func() {
+//        Method("subscribe", func() {
+//            Payload(Subscription)
+//            StreamingResult(Tick)
+//            TCP("proto")
+//            GRPC(func() {})
+//        })
+//    })
+//
+func TCP(encoding ...string) {
+	if len(encoding) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	enc := expr.TCPEncodingJSON
+	if len(encoding) == 1 {
+		enc = encoding[0]
+	}
+	var meta *expr.MetaExpr
+	switch e := eval.Current().(type) {
+	case *expr.ServiceExpr:
+		meta = &e.Meta
+	case *expr.MethodExpr:
+		meta = &e.Meta
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *meta == nil {
+		*meta = make(expr.MetaExpr)
+	}
+	(*meta)["tcp:encoding"] = []string{enc}
+}
@@ -38,6 +38,58 @@ const (
 
 	// Any is the type for an arbitrary JSON value (interface{} in Go).
 	Any = expr.Any
+
+	// Duration is the type for a duration. Durations map to time.Duration
+	// in the generated Go code and are encoded as strings using the Go
+	// duration format (e.g. "1h30m"). The generated decoders also accept the
+	// ISO 8601 duration format (e.g. "PT1H30M").
+	Duration = expr.Duration
+
+	// UUID is the type for a RFC 4122 UUID. UUIDs map to goa.UUID in the
+	// generated Go code and are encoded as strings (e.g.
+	// "6ba7b810-9dad-11d1-80b4-00c04fd430c8"). The generated decoders
+	// validate the format of the values. goa.UUID is a [16]byte array and
+	// thus converts to the UUID types of the popular Go UUID packages (e.g.
+	// uuid.UUID(v)).
+	UUID = expr.UUID
+
+	// Timestamp is the type for a point in time. Timestamps map to
+	// time.Time in the generated Go code. The wire format of timestamps is
+	// set with TimestampFormat and defaults to RFC 3339 strings (e.g.
+	// "2006-01-02T15:04:05Z"). Timestamps map to google.protobuf.Timestamp
+	// in the generated protocol buffer messages regardless of the format.
+	Timestamp = expr.Timestamp
+
+	// Decimal is the type for arbitrary-precision decimal numbers such as
+	// monetary amounts. Decimals are encoded as strings (e.g. "12.50") so
+	// that no precision is lost and map to goa.Decimal in the generated Go
+	// code unless another Go type is set with DecimalType. The Precision and
+	// Scale validations limit the number of digits of the values.
+	Decimal = expr.Decimal
+)
+
+const (
+	// TimestampRFC3339 encodes timestamps as RFC 3339 strings (e.g.
+	// "2006-01-02T15:04:05Z").
+	TimestampRFC3339 = expr.TimestampRFC3339
+
+	// TimestampUnix encodes timestamps as numbers of seconds since the Unix
+	// epoch (e.g. 1136214245).
+	TimestampUnix = expr.TimestampUnix
+
+	// TimestampUnixMilli encodes timestamps as numbers of milliseconds
+	// since the Unix epoch (e.g. 1136214245000).
+	TimestampUnixMilli = expr.TimestampUnixMilli
+)
+
+const (
+	// SensitiveMask anonymizes sensitive values by replacing each character
+	// with an asterisk.
+	SensitiveMask = expr.SensitiveMask
+
+	// SensitiveHash anonymizes sensitive values by replacing them with their
+	// hex encoded SHA-256 hash.
+	SensitiveHash = expr.SensitiveHash
 )
 
 // Empty represents empty values.
@@ -115,7 +115,8 @@ func Type(name string, args ...interface{}) expr.UserType {
 //
 // ArrayOf may be used wherever types can.
 // The first argument of ArrayOf is the type of the array elements specified by
-// name or by reference.
+// name or by reference. Types specified by name may be defined in packages that
+// initialize after the package using ArrayOf.
 // The second argument of ArrayOf is an optional function that defines
 // validations for the array elements.
 //
@@ -136,34 +137,31 @@ func Type(name string, args ...interface{}) expr.UserType {
 // CollectionOf if the argument is a result type and ArrayOf if it is a user
 // type.
 func ArrayOf(v interface{}, fn ...func()) *expr.Array {
-	var t expr.DataType
-	var ok bool
-	t, ok = v.(expr.DataType)
-	if !ok {
-		if name, ok := v.(string); ok {
-			t = expr.Root.UserType(name)
-		}
-	}
 	// never return nil to avoid panics, errors are reported after DSL execution
-	if t == nil {
-		eval.ReportError("invalid ArrayOf argument: not a type and not a known user type name")
-		return &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.String}}
-	}
 	if len(fn) > 1 {
 		eval.ReportError("ArrayOf: too many arguments")
 		return &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.String}}
 	}
-	at := expr.AttributeExpr{Type: t}
-	if len(fn) == 1 {
-		eval.Execute(fn[0], &at)
-	}
-	return &expr.Array{ElemType: &at}
+	at := &expr.AttributeExpr{Type: expr.String}
+	resolveType(v, func(t expr.DataType) {
+		if t == nil {
+			eval.ReportError("invalid ArrayOf argument: not a type and not a known user type name")
+			return
+		}
+		at.Type = t
+		if len(fn) == 1 {
+			eval.Execute(fn[0], at)
+		}
+	})
+	return &expr.Array{ElemType: at}
 }
 
 // MapOf creates a map from its key and element types.
 //
 // MapOf may be used wherever types can.
 // MapOf takes two arguments: the key and value types either by name of by reference.
+// Types specified by name may be defined in packages that initialize after the
+// package using MapOf.
 //
 // Example:
 //
@@ -181,47 +179,68 @@ func ArrayOf(v interface{}, fn ...func()) *expr.Array {
 //    })
 //
 func MapOf(k, v interface{}, fn ...func()) *expr.Map {
-	var tk, tv expr.DataType
-	var ok bool
-	tk, ok = k.(expr.DataType)
-	if !ok {
-		if name, ok := k.(string); ok {
-			tk = expr.Root.UserType(name)
-		}
-	}
-	tv, ok = v.(expr.DataType)
-	if !ok {
-		if name, ok := v.(string); ok {
-			tv = expr.Root.UserType(name)
-		}
-	}
 	// never return nil to avoid panics, errors are reported after DSL execution
-	if tk == nil {
-		eval.ReportError("invalid MapOf key argument: not a type and not a known user type name")
-		return &expr.Map{KeyType: &expr.AttributeExpr{Type: expr.String}, ElemType: &expr.AttributeExpr{Type: expr.String}}
-	}
-	if expr.IsMap(tk) {
-		eval.ReportError("invalid MapOf key type: key type must be a primitive, array, or user type")
-		return &expr.Map{KeyType: &expr.AttributeExpr{Type: expr.String}, ElemType: &expr.AttributeExpr{Type: expr.String}}
-	}
-	if tv == nil {
-		eval.ReportError("invalid MapOf value argument: not a type and not a known user type name")
-		return &expr.Map{KeyType: &expr.AttributeExpr{Type: expr.String}, ElemType: &expr.AttributeExpr{Type: expr.String}}
-	}
 	if len(fn) > 1 {
 		eval.ReportError("MapOf: too many arguments")
 		return &expr.Map{KeyType: &expr.AttributeExpr{Type: expr.String}, ElemType: &expr.AttributeExpr{Type: expr.String}}
 	}
-	kat := expr.AttributeExpr{Type: tk}
-	vat := expr.AttributeExpr{Type: tv}
-	m := &expr.Map{KeyType: &kat, ElemType: &vat}
-	if len(fn) == 1 {
-		mat := expr.AttributeExpr{Type: m}
-		eval.Execute(fn[0], &mat)
-	}
+	kat := &expr.AttributeExpr{Type: expr.String}
+	vat := &expr.AttributeExpr{Type: expr.String}
+	m := &expr.Map{KeyType: kat, ElemType: vat}
+	resolveType(k, func(tk expr.DataType) {
+		if tk == nil {
+			eval.ReportError("invalid MapOf key argument: not a type and not a known user type name")
+			return
+		}
+		if expr.IsMap(tk) {
+			eval.ReportError("invalid MapOf key type: key type must be a primitive, array, or user type")
+			return
+		}
+		resolveType(v, func(tv expr.DataType) {
+			if tv == nil {
+				eval.ReportError("invalid MapOf value argument: not a type and not a known user type name")
+				return
+			}
+			kat.Type = tk
+			vat.Type = tv
+			if len(fn) == 1 {
+				mat := expr.AttributeExpr{Type: m}
+				eval.Execute(fn[0], &mat)
+			}
+		})
+	})
 	return m
 }
 
+// resolveType calls fn with v if v is a data type or with the user type named
+// v if v is a string. User types referenced by name are looked up once all the
+// design packages have been initialized so that ArrayOf and MapOf may be used
+// in package variable declarations to refer to types defined in other
+// packages regardless of the package initialization order. fn is called with
+// nil if v is neither a data type nor the name of a user type.
+func resolveType(v interface{}, fn func(expr.DataType)) {
+	if t, ok := v.(expr.DataType); ok {
+		fn(t)
+		return
+	}
+	name, ok := v.(string)
+	if !ok {
+		fn(nil)
+		return
+	}
+	if t := expr.Root.UserType(name); t != nil {
+		fn(t)
+		return
+	}
+	eval.Defer(func() {
+		if t := expr.Root.UserType(name); t != nil {
+			fn(t)
+			return
+		}
+		fn(nil)
+	})
+}
+
 // Key makes it possible to specify validations for map keys.
 //
 // Example:
@@ -277,3 +296,59 @@ func Elem(fn func()) {
 		eval.IncompatibleDSL()
 	}
 }
+
+// EnumConstants makes the generated code define typed constants for the values
+// of a user type that uses Enum. The service package defines one constant per
+// value named after the type and the value, a String method, a Validate method
+// that checks that the value is one of the enum values and a Parse function
+// that converts a string to the type, for example:
+//
+//    const (
+//        ColorRed   Color = "red"
+//        ColorGreen Color = "green"
+//    )
+//    func (v Color) String() string
+//    func (v Color) Validate() error
+//    func ParseColor(s string) (Color, error)
+//
+// The transport packages convert their own representation of the type to and
+// from the service type so that the service methods always receive and return
+// the typed values. The HTTP transport only converts the values held in the
+// request and response bodies: attributes whose type uses EnumConstants cannot
+// be mapped to HTTP path or query string parameters nor to HTTP headers.
+//
+// EnumConstants must appear in a Type expression whose base type is String or
+// an integer type and which defines the Enum validation. Types that do not use
+// EnumConstants keep being generated without constants.
+//
+// EnumConstants takes no argument.
+//
+// Example:
+//
+//    var Color = Type("Color", String, func() {
+//        Enum("red", "green")
+//        EnumConstants()
+//    })
+//
+func EnumConstants() {
+	at, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	var isType bool
+	for _, t := range expr.Root.Types {
+		if t.Attribute() == at {
+			isType = true
+			break
+		}
+	}
+	if !isType {
+		eval.IncompatibleDSL()
+		return
+	}
+	if at.Meta == nil {
+		at.Meta = make(expr.MetaExpr)
+	}
+	at.Meta["goa:enum:const"] = nil
+}
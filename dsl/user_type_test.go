@@ -0,0 +1,111 @@
+package dsl_test
+
+import (
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestLazyTypeReferences(t *testing.T) {
+	// The types are used before they are defined to emulate designs where
+	// they are defined in packages that initialize later.
+	root := expr.RunDSL(t, func() {
+		var (
+			Bs    = ArrayOf("B")
+			BsMap = MapOf(String, "B", func() {
+				Elem(func() {
+					Required("x")
+				})
+			})
+			Cs   = CollectionOf("application/vnd.c")
+			Refd expr.UserType
+		)
+		Type("A", func() {
+			Reference(Refd)
+			Attribute("bs", Bs)
+			Attribute("bsmap", BsMap)
+			Attribute("cs", Cs)
+			Attribute("name")
+		})
+		Type("B", func() {
+			Attribute("x", String)
+		})
+		ResultType("application/vnd.c", func() {
+			Attribute("y", Int)
+		})
+		Refd = Type("Referenced", func() {
+			Attribute("name", Int, "referenced name")
+		})
+	})
+	a := root.UserType("A")
+	if a == nil {
+		t.Fatal("type A not found")
+	}
+	if bs := a.Attribute().Find("bs"); expr.AsArray(bs.Type).ElemType.Type != root.UserType("B") {
+		t.Errorf("got array element type %s, expected B", expr.AsArray(bs.Type).ElemType.Type.Name())
+	}
+	bsmap := expr.AsMap(a.Attribute().Find("bsmap").Type)
+	if bsmap.ElemType.Type != root.UserType("B") {
+		t.Errorf("got map element type %s, expected B", bsmap.ElemType.Type.Name())
+	}
+	if v := bsmap.ElemType.Validation; v == nil || len(v.Required) != 1 || v.Required[0] != "x" {
+		t.Errorf("map element validations not applied")
+	}
+	cs, ok := a.Attribute().Find("cs").Type.(*expr.ResultTypeExpr)
+	if !ok {
+		t.Fatalf("got collection type %T, expected result type", a.Attribute().Find("cs").Type)
+	}
+	if cs.Identifier != "application/vnd.c; type=collection" {
+		t.Errorf("got collection identifier %q, expected %q", cs.Identifier, "application/vnd.c; type=collection")
+	}
+	if elem := expr.AsArray(cs.Type).ElemType.Type; elem != root.UserType("C") {
+		t.Errorf("got collection element type %s, expected C", elem.Name())
+	}
+	if name := a.Attribute().Find("name"); name.Type != expr.Int || name.Description != "referenced name" {
+		t.Errorf("got name attribute of type %s with description %q, expected the referenced attribute", name.Type.Name(), name.Description)
+	}
+}
+
+func TestLazyTypeReferencesErrors(t *testing.T) {
+	cases := map[string]struct {
+		DSL   func()
+		Error string
+	}{
+		"array": {
+			func() {
+				Type("A", func() {
+					Attribute("a", ArrayOf("Unknown"))
+				})
+			},
+			"invalid ArrayOf argument",
+		},
+		"map": {
+			func() {
+				var M = MapOf(String, "Unknown")
+				Type("A", func() {
+					Attribute("m", M)
+				})
+			},
+			"invalid MapOf value argument",
+		},
+		"collection": {
+			func() {
+				var C = CollectionOf("application/vnd.unknown")
+				Type("A", func() {
+					Attribute("c", C)
+				})
+			},
+			`"application/vnd.unknown" is not a known result type identifier`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected it to contain %q", err, c.Error)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"time"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
 )
 
 const (
@@ -51,8 +53,38 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = expr.FormatRFC1123
+
+	// FormatDecimal describes decimal numbers (e.g. "-12.50").
+	FormatDecimal = expr.FormatDecimal
+
+	// FormatSemver describes Semantic Versioning 2.0.0 version numbers.
+	FormatSemver = expr.FormatSemver
+
+	// FormatBase64 describes RFC4648 standard base64 encoded values.
+	FormatBase64 = expr.FormatBase64
+
+	// FormatE164 describes ITU-T E.164 international phone numbers.
+	FormatE164 = expr.FormatE164
+
+	// FormatULID describes ULID values.
+	FormatULID = expr.FormatULID
+
+	// FormatDuration describes Go or ISO 8601 duration values.
+	FormatDuration = expr.FormatDuration
+
+	// FormatCreditCard describes payment card numbers.
+	FormatCreditCard = expr.FormatCreditCard
+
+	// FormatCountryCode describes ISO 3166-1 alpha-2 country codes.
+	FormatCountryCode = expr.FormatCountryCode
+
+	// FormatCurrencyCode describes ISO 4217 currency codes.
+	FormatCurrencyCode = expr.FormatCurrencyCode
 )
 
+// timeZoneRegex matches the time zone offsets accepted by TimeZone.
+var timeZoneRegex = regexp.MustCompile(`^[+-](0[0-9]|1[0-4]):[0-5][0-9]$`)
+
 // Enum adds a "enum" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor76.
 //
@@ -70,6 +102,18 @@ const (
 //
 func Enum(vals ...interface{}) {
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type == expr.UUID {
+			eval.ReportError("invalid enum validation definition: attribute cannot be a UUID")
+			return
+		}
+		if a.Type == expr.Timestamp {
+			eval.ReportError("invalid enum validation definition: attribute cannot be a Timestamp")
+			return
+		}
+		if a.Type == expr.Decimal {
+			eval.ReportError("invalid enum validation definition: attribute cannot be a Decimal")
+			return
+		}
 		for i, v := range vals {
 			// When can a.Type be nil? glad you asked
 			// There are two ways to write an Attribute declaration with the DSL that
@@ -107,6 +151,9 @@ func Enum(vals ...interface{}) {
 				case expr.ArrayVal:
 					a.Validation.Values[i] = actual.ToSlice()
 				default:
+					if a.Type == expr.Duration {
+						actual, _ = expr.DurationValue(actual)
+					}
 					a.Validation.Values[i] = actual
 				}
 			}
@@ -142,6 +189,24 @@ func Enum(vals ...interface{}) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// FormatDecimal: decimal number
+//
+// FormatSemver: Semantic Versioning 2.0.0 version number
+//
+// FormatBase64: RFC4648 standard base64 encoded value
+//
+// FormatE164: ITU-T E.164 international phone number (e.g. "+14155552671")
+//
+// FormatULID: ULID
+//
+// FormatDuration: Go (e.g. "1h30m") or ISO 8601 (e.g. "PT1H30M") duration
+//
+// FormatCreditCard: payment card number passing the Luhn checksum
+//
+// FormatCountryCode: ISO 3166-1 alpha-2 country code (e.g. "US")
+//
+// FormatCurrencyCode: ISO 4217 currency code (e.g. "EUR")
+//
 // Example:
 //
 //    Attribute("created_at", String, func() {
@@ -163,6 +228,41 @@ func Format(f expr.ValidationFormat) {
 	}
 }
 
+// TimeZone adds a validation to a date-time attribute that requires the values
+// to use the given time zone. tz is either "UTC" which accepts values with a
+// zero offset or a fixed offset of the form "+hh:mm" or "-hh:mm". The
+// attribute must also use the FormatDateTime format. The generated HTTP
+// servers convert the values to UTC once validated so that the service
+// methods always receive UTC date times.
+//
+// TimeZone must appear in an Attribute expression.
+//
+// TimeZone takes one argument: the time zone.
+//
+// Example:
+//
+//    Attribute("starts_at", String, func() {
+//        Format(FormatDateTime)
+//        TimeZone("+02:00")
+//    })
+//
+func TimeZone(tz string) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if tz != "UTC" && !timeZoneRegex.MatchString(tz) {
+			eval.ReportError("invalid time zone %q, must be \"UTC\" or an offset of the form \"+hh:mm\" or \"-hh:mm\"", tz)
+			return
+		}
+		if a.Type != nil && a.Type.Kind() != expr.StringKind {
+			incompatibleAttributeType("time zone", a.Type.Name(), "a string")
+		} else {
+			if a.Validation == nil {
+				a.Validation = &expr.ValidationExpr{}
+			}
+			a.Validation.TimeZone = tz
+		}
+	}
+}
+
 // Pattern adds a "pattern" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor33.
 //
@@ -192,6 +292,8 @@ func Pattern(p string) {
 
 // Minimum adds a "minimum" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor21.
+// The minimum of a Duration attribute may be given as a duration string (e.g.
+// "1s" or "PT1S") or as a time.Duration.
 //
 // Example:
 //
@@ -199,27 +301,43 @@ func Pattern(p string) {
 //        Minimum(100)
 //    })
 //
+//    Attribute("timeout", Duration, func() {
+//        Minimum("100ms")
+//    })
+//
 func Minimum(val interface{}) {
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
 		if a.Type != nil &&
 			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
 			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
 			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind &&
+			a.Type.Kind() != expr.DurationKind {
 
-			incompatibleAttributeType("minimum", a.Type.Name(), "an integer or a number")
+			incompatibleAttributeType("minimum", a.Type.Name(), "an integer, a number or a duration")
 		} else {
 			var f float64
 			switch v := val.(type) {
-			case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
-				f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
+			case time.Duration:
+				f = float64(v)
 			case string:
+				if a.Type == expr.Duration {
+					d, err := goa.ParseDuration(v)
+					if err != nil {
+						eval.ReportError("invalid duration value %#v", v)
+						return
+					}
+					f = float64(d)
+					break
+				}
 				var err error
 				f, err = strconv.ParseFloat(v, 64)
 				if err != nil {
 					eval.ReportError("invalid number value %#v", v)
 					return
 				}
+			case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+				f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
 			default:
 				eval.ReportError("invalid number value %#v", v)
 				return
@@ -234,6 +352,8 @@ func Minimum(val interface{}) {
 
 // Maximum adds a "maximum" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor17.
+// The maximum of a Duration attribute may be given as a duration string (e.g.
+// "1m" or "PT1M") or as a time.Duration.
 //
 // Example:
 //
@@ -247,21 +367,33 @@ func Maximum(val interface{}) {
 			a.Type.Kind() != expr.IntKind && a.Type.Kind() != expr.UIntKind &&
 			a.Type.Kind() != expr.Int32Kind && a.Type.Kind() != expr.UInt32Kind &&
 			a.Type.Kind() != expr.Int64Kind && a.Type.Kind() != expr.UInt64Kind &&
-			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind {
+			a.Type.Kind() != expr.Float32Kind && a.Type.Kind() != expr.Float64Kind &&
+			a.Type.Kind() != expr.DurationKind {
 
-			incompatibleAttributeType("maximum", a.Type.Name(), "an integer or a number")
+			incompatibleAttributeType("maximum", a.Type.Name(), "an integer, a number or a duration")
 		} else {
 			var f float64
 			switch v := val.(type) {
-			case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
-				f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
+			case time.Duration:
+				f = float64(v)
 			case string:
+				if a.Type == expr.Duration {
+					d, err := goa.ParseDuration(v)
+					if err != nil {
+						eval.ReportError("invalid duration value %#v", v)
+						return
+					}
+					f = float64(d)
+					break
+				}
 				var err error
 				f, err = strconv.ParseFloat(v, 64)
 				if err != nil {
 					eval.ReportError("invalid number value %#v", v)
 					return
 				}
+			case float32, float64, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+				f = reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0.0))).Float()
 			default:
 				eval.ReportError("invalid number value %#v", v)
 				return
@@ -274,6 +406,71 @@ func Maximum(val interface{}) {
 	}
 }
 
+// Precision adds a validation to a Decimal attribute that limits the total
+// number of digits of its values. The leading zeros of the integer part are not
+// counted, e.g. "12.50" has 4 digits and "0.05" 2.
+//
+// Precision must appear in an Attribute expression.
+//
+// Precision takes one argument: the maximum number of digits.
+//
+// Example:
+//
+//    Attribute("amount", Decimal, func() {
+//        Precision(10)
+//        Scale(2)
+//    })
+//
+func Precision(val int) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if val < 1 {
+			eval.ReportError("invalid precision %d, must be greater than 0", val)
+			return
+		}
+		if a.Type != nil && a.Type != expr.Decimal {
+			incompatibleAttributeType("precision", a.Type.Name(), "a decimal")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.Precision = &val
+	}
+}
+
+// Scale adds a validation to a Decimal attribute that limits the number of
+// digits after the decimal point of its values. Values with fewer digits are
+// accepted, e.g. Scale(2) accepts "12", "12.5" and "12.50" but rejects
+// "12.505".
+//
+// Scale must appear in an Attribute expression.
+//
+// Scale takes one argument: the maximum number of digits after the decimal
+// point.
+//
+// Example:
+//
+//    Attribute("amount", Decimal, func() {
+//        Scale(2)
+//    })
+//
+func Scale(val int) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if val < 0 {
+			eval.ReportError("invalid scale %d, must be positive", val)
+			return
+		}
+		if a.Type != nil && a.Type != expr.Decimal {
+			incompatibleAttributeType("scale", a.Type.Name(), "a decimal")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.Scale = &val
+	}
+}
+
 // MinLength adds a "minItems" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor45.
 //
@@ -377,6 +574,184 @@ func Required(names ...string) {
 	}
 }
 
+// MutuallyExclusive adds a validation to the object attribute that fails if
+// more than one of the given fields is set. Fields that are required or that
+// have a default value are always set.
+//
+// MutuallyExclusive may appear in Type, ResultType or Attribute and may be
+// used multiple times to define multiple groups.
+//
+// Example:
+//
+//    var _ = Type("Payment", func() {
+//        Attribute("card", Card)
+//        Attribute("bank_account", BankAccount)
+//        MutuallyExclusive("card", "bank_account")
+//    })
+//
+func MutuallyExclusive(names ...string) {
+	if v := fieldGroupValidation("mutually exclusive"); v != nil {
+		v.MutuallyExclusive = append(v.MutuallyExclusive, names)
+	}
+}
+
+// RequiredTogether adds a validation to the object attribute that fails if
+// some but not all of the given fields are set.
+//
+// RequiredTogether may appear in Type, ResultType or Attribute and may be used
+// multiple times to define multiple groups.
+//
+// Example:
+//
+//    var _ = Type("Range", func() {
+//        Attribute("start", Int)
+//        Attribute("end", Int)
+//        RequiredTogether("start", "end")
+//    })
+//
+func RequiredTogether(names ...string) {
+	if v := fieldGroupValidation("required together"); v != nil {
+		v.RequiredTogether = append(v.RequiredTogether, names)
+	}
+}
+
+// AtLeastOneOf adds a validation to the object attribute that fails if none of
+// the given fields is set.
+//
+// AtLeastOneOf may appear in Type, ResultType or Attribute and may be used
+// multiple times to define multiple groups.
+//
+// Example:
+//
+//    var _ = Type("Contact", func() {
+//        Attribute("email", String)
+//        Attribute("phone", String)
+//        AtLeastOneOf("email", "phone")
+//    })
+//
+func AtLeastOneOf(names ...string) {
+	if v := fieldGroupValidation("at least one of"); v != nil {
+		v.AtLeastOneOf = append(v.AtLeastOneOf, names)
+	}
+}
+
+// RequiredIf adds a validation to the object attribute that fails if the field
+// with the given name has the given value and one of the fields listed in
+// names is not set.
+//
+// RequiredIf may appear in Type, ResultType or Attribute and may be used
+// multiple times. The tested field must be a boolean, number or string.
+//
+// Example:
+//
+//    var _ = Type("Payment", func() {
+//        Attribute("type", String, func() {
+//            Enum("card", "cash")
+//        })
+//        Attribute("card_number", String)
+//        RequiredIf("type", "card", "card_number")
+//    })
+//
+func RequiredIf(name string, value interface{}, names ...string) {
+	if v := fieldGroupValidation("required if"); v != nil {
+		v.RequiredIf = append(v.RequiredIf, &expr.RequiredIfExpr{Field: name, Value: value, Names: names})
+	}
+}
+
+// ValidateWith adds a validation to the attribute that calls the given user
+// provided function in addition to the built-in validations. This makes it
+// possible to implement domain rules (e.g. IBAN checksums) in the validation
+// layer. fn is the function import path followed by a dot and its name, e.g.
+// "github.com/acme/validators.IBAN". The function must accept the Go value of
+// the attribute and return an error if the value is invalid:
+//
+//    func IBAN(v string) error
+//
+// The generated code wraps non-nil errors that are not goa service errors
+// into "invalid_field" errors that name the attribute.
+//
+// ValidateWith may appear in Type or Attribute of primitive types and may be
+// used multiple times.
+//
+// Example:
+//
+//    Attribute("iban", String, func() {
+//        Pattern("^[A-Z]{2}[0-9]{2}[A-Z0-9]+$")
+//        ValidateWith("github.com/acme/validators.IBAN")
+//    })
+//
+func ValidateWith(fn string) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && !expr.IsPrimitive(a.Type) {
+			incompatibleAttributeType("custom", a.Type.Name(), "a primitive")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.Funcs = append(a.Validation.Funcs, fn)
+	}
+}
+
+// ValidationMessage overrides the message of the error returned by the
+// generated code when the given validation of the attribute fails, for
+// example to return human-friendly messages to the users of form based APIs.
+// validation is one of "enum", "format", "pattern", "minimum", "maximum",
+// "minLength", "maxLength", "timeZone" or "required". The "required" message
+// is used when the attribute is required by its parent but missing from the
+// request body. The occurrences of "{value}" in message are replaced with the
+// offending value. The name of the error is unchanged so that clients may
+// still identify the failed validation.
+//
+// ValidationMessage may appear in Type or Attribute and may be used once per
+// validation.
+//
+// Example:
+//
+//    Attribute("age", Int, func() {
+//        Minimum(18)
+//        ValidationMessage("minimum", "You must be at least 18 years old, got {value}.")
+//        ValidationMessage("required", "Please enter your age.")
+//    })
+//
+func ValidationMessage(validation, message string) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		if a.Validation.Messages == nil {
+			a.Validation.Messages = make(map[string]string)
+		}
+		a.Validation.Messages[validation] = message
+	}
+}
+
+// fieldGroupValidation returns the validation of the current object attribute
+// initializing it if needed. It reports an error and returns nil if the
+// current expression is not an object attribute.
+func fieldGroupValidation(validation string) *expr.ValidationExpr {
+	var at *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		at = def
+	case *expr.ResultTypeExpr:
+		at = def.AttributeExpr
+	case *expr.MappedAttributeExpr:
+		at = def.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if at.Type != nil && !expr.IsObject(at.Type) {
+		incompatibleAttributeType(validation, at.Type.Name(), "an object")
+		return nil
+	}
+	if at.Validation == nil {
+		at.Validation = &expr.ValidationExpr{}
+	}
+	return at.Validation
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
@@ -12,20 +12,29 @@ func TestFormat(t *testing.T) {
 	cases := map[string]struct {
 		Format expr.ValidationFormat
 	}{
-		"date":      {expr.FormatDate},
-		"date-time": {expr.FormatDateTime},
-		"uuid":      {expr.FormatUUID},
-		"email":     {expr.FormatEmail},
-		"hostname":  {expr.FormatHostname},
-		"ipv4":      {expr.FormatIPv4},
-		"ipv6":      {expr.FormatIPv6},
-		"ip":        {expr.FormatIP},
-		"uri":       {expr.FormatURI},
-		"mac":       {expr.FormatMAC},
-		"cidr":      {expr.FormatCIDR},
-		"regexp":    {expr.FormatRegexp},
-		"json":      {expr.FormatJSON},
-		"rfc1123":   {expr.FormatRFC1123},
+		"date":          {expr.FormatDate},
+		"date-time":     {expr.FormatDateTime},
+		"uuid":          {expr.FormatUUID},
+		"email":         {expr.FormatEmail},
+		"hostname":      {expr.FormatHostname},
+		"ipv4":          {expr.FormatIPv4},
+		"ipv6":          {expr.FormatIPv6},
+		"ip":            {expr.FormatIP},
+		"uri":           {expr.FormatURI},
+		"mac":           {expr.FormatMAC},
+		"cidr":          {expr.FormatCIDR},
+		"regexp":        {expr.FormatRegexp},
+		"json":          {expr.FormatJSON},
+		"rfc1123":       {expr.FormatRFC1123},
+		"decimal":       {expr.FormatDecimal},
+		"semver":        {expr.FormatSemver},
+		"base64":        {expr.FormatBase64},
+		"e164":          {expr.FormatE164},
+		"ulid":          {expr.FormatULID},
+		"duration":      {expr.FormatDuration},
+		"credit-card":   {expr.FormatCreditCard},
+		"country-code":  {expr.FormatCountryCode},
+		"currency-code": {expr.FormatCurrencyCode},
 	}
 
 	for k, tc := range cases {
@@ -0,0 +1,37 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Webhook defines an event emitted by the service. The generated HTTP client
+// package includes a WebhookSender type that sends the event to the URLs
+// registered by the API consumers via HTTP POST requests signed with a shared
+// secret and retried on failure. The generated OpenAPI specification lists the
+// webhooks so that consumers can validate their receivers.
+//
+// Webhook must appear in a Service expression.
+//
+// Webhook takes two arguments: the name of the webhook and the defining DSL.
+// The DSL must define the webhook payload using Payload and may define a
+// description.
+//
+// Example:
+//
+//    var _ = Service("orders", func() {
+//        Webhook("order_created", func() {
+//            Description("Sent when an order is created.")
+//            Payload(Order)
+//        })
+//    })
+//
+func Webhook(name string, fn func()) {
+	s, ok := eval.Current().(*expr.ServiceExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	w := &expr.WebhookExpr{Name: name, Service: s, DSLFunc: fn}
+	s.Webhooks = append(s.Webhooks, w)
+}
@@ -0,0 +1,129 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// XMLName sets the name of the XML element or attribute used to serialize an
+// attribute. When used in a type definition XMLName sets the name of the root
+// element of the HTTP bodies that use the type.
+//
+// XMLName must appear in an Attribute, Type or ResultType expression. When used
+// on an array attribute XMLName sets the name of the wrapper element and only
+// applies if the attribute is also wrapped (see XMLWrapped). The name of the
+// array items is set by using XMLName in the ArrayOf DSL.
+//
+// XMLName takes one argument: the element or attribute name.
+//
+// Example:
+//
+//    var Book = Type("Book", func() {
+//        XMLName("book")
+//        Attribute("title", String, func() {
+//            XMLName("Title")
+//        })
+//    })
+//
+func XMLName(name string) {
+	setXMLMeta("xml:name", name)
+}
+
+// XMLNamespace sets the XML namespace of an attribute or of the root element of
+// the HTTP bodies that use a type. The generated struct tags qualify the element
+// names with the namespace URI. The optional prefix is only used in the
+// generated OpenAPI specification as the Go encoding/xml package does not
+// support choosing namespace prefixes.
+//
+// XMLNamespace must appear in an Attribute, Type or ResultType expression.
+//
+// XMLNamespace takes one or two arguments: the namespace URI and optionally
+// the namespace prefix.
+//
+// Example:
+//
+//    var Book = Type("Book", func() {
+//        XMLName("book")
+//        XMLNamespace("http://example.com/schema/book", "bk")
+//        Attribute("title", String)
+//    })
+//
+func XMLNamespace(uri string, prefix ...string) {
+	setXMLMeta("xml:namespace", uri)
+	if len(prefix) > 0 {
+		setXMLMeta("xml:prefix", prefix[0])
+	}
+}
+
+// XMLAttribute indicates that an attribute is serialized as an XML attribute of
+// the parent element rather than as a child element.
+//
+// XMLAttribute must appear in an Attribute expression. The attribute type must
+// be a primitive type.
+//
+// XMLAttribute takes no argument.
+//
+// Example:
+//
+//    var Book = Type("Book", func() {
+//        Attribute("id", Int, func() {
+//            XMLAttribute()
+//        })
+//        Attribute("title", String)
+//    })
+//
+func XMLAttribute() {
+	if _, ok := eval.Current().(*expr.AttributeExpr); !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	setXMLMeta("xml:attribute")
+}
+
+// XMLWrapped indicates that the items of an array attribute are serialized
+// inside a wrapper element. The wrapper element name defaults to the attribute
+// name and may be set with XMLName. Arrays are otherwise serialized as
+// repeated elements directly inside the parent element.
+//
+// XMLWrapped must appear in an Attribute expression. The attribute type must be
+// an array.
+//
+// XMLWrapped takes no argument.
+//
+// Example:
+//
+//    var Library = Type("Library", func() {
+//        // <books><book>...</book><book>...</book></books>
+//        Attribute("books", ArrayOf(Book, func() {
+//            XMLName("book")
+//        }), func() {
+//            XMLWrapped()
+//        })
+//    })
+//
+func XMLWrapped() {
+	if _, ok := eval.Current().(*expr.AttributeExpr); !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	setXMLMeta("xml:wrapped")
+}
+
+// setXMLMeta sets the given XML serialization metadata on the current
+// attribute or type.
+func setXMLMeta(key string, value ...string) {
+	var att *expr.AttributeExpr
+	switch e := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		att = e
+	case *expr.ResultTypeExpr:
+		att = e.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if att.Meta == nil {
+		att.Meta = make(expr.MetaExpr)
+	}
+	att.Meta[key] = value
+}
@@ -18,6 +18,14 @@ type (
 
 		// roots is the list of DSL roots as registered by all loaded DSLs.
 		roots []Root
+		// deferred is the list of functions registered with Defer that
+		// have yet to run.
+		deferred []*deferredFunc
+		// running is true once RunDSL has started.
+		running bool
+		// executed records the source expressions whose DSL has run, see
+		// ExecuteOnce.
+		executed map[Expression]bool
 		// dslPackages keeps track of the DSL package import paths so the initiator
 		// may skip any callstack frame that belongs to them when computing error
 		// locations.
@@ -27,6 +35,14 @@ type (
 	// Stack represents the expression evaluation stack. The stack is appended to
 	// each time the initiator executes an expression source DSL.
 	Stack []Expression
+
+	// deferredFunc is a function registered with Defer together with the
+	// location of the user code that caused it to be registered.
+	deferredFunc struct {
+		fn   func()
+		file string
+		line int
+	}
 )
 
 func init() {
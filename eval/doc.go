@@ -115,6 +115,13 @@ of one iteration even though some input may be incorrect (for example it may
 elect to create default value expressions instead of leaving them nil to avoid
 panics later on).
 
+DSL functions that run when the process loads may need to refer to
+expressions created by other packages, for example a type referenced by name
+and defined in a package that initializes later. Such functions use Defer to
+postpone the lookup until all the packages have been initialized: the engine
+calls the deferred functions at the beginning of RunDSL, before the first
+phase.
+
 The package exposes other helper functions such as Execute which allows running
 a DSL function on demand.
 */
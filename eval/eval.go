@@ -22,6 +22,8 @@ func RunDSL() error {
 	if len(roots) == 0 {
 		return nil
 	}
+	Context.running = true
+	runDeferred()
 	executed := 0
 	recursed := 0
 	for executed < len(roots) {
@@ -83,6 +85,64 @@ func Execute(fn func(), def Expression) bool {
 	return endCount <= startCount
 }
 
+// ExecuteOnce runs the DSL of the given source expression unless it has
+// already run. RunDSL uses ExecuteOnce to execute the DSL of the source
+// expressions so that a DSL may use it to make sure that the expressions it
+// depends on are fully initialized, regardless of the order in which they
+// were created. ExecuteOnce returns true if the DSL ran successfully or had
+// already run and false otherwise. It does nothing and returns true if def
+// does not implement Source.
+func ExecuteOnce(def Expression) bool {
+	source, ok := def.(Source)
+	if !ok {
+		return true
+	}
+	if Context.executed == nil {
+		Context.executed = make(map[Expression]bool)
+	}
+	if Context.executed[def] {
+		return true
+	}
+	Context.executed[def] = true
+	return Execute(source.DSL(), def)
+}
+
+// Defer registers a function that is called once all the packages that make
+// up the design have been initialized, right before RunDSL executes the DSL.
+// DSL functions that may be called when packages initialize, such as ArrayOf
+// or MapOf, use Defer to look up expressions by name so that these may be
+// defined in packages that initialize later. This removes the need to order
+// package imports carefully in designs that span multiple packages. Defer
+// calls fn right away if RunDSL has already started. Errors reported by fn
+// are located at the user code that caused Defer to be called.
+func Defer(fn func()) {
+	if Context.running {
+		fn()
+		return
+	}
+	file, line := computeErrorLocation()
+	Context.deferred = append(Context.deferred, &deferredFunc{fn: fn, file: file, line: line})
+}
+
+// runDeferred calls the functions registered with Defer in the order they
+// were registered.
+func runDeferred() {
+	for len(Context.deferred) > 0 {
+		d := Context.deferred[0]
+		Context.deferred = Context.deferred[1:]
+		var start int
+		if Context.Errors != nil {
+			start = len(Context.Errors.(MultiError))
+		}
+		d.fn()
+		if Context.Errors != nil {
+			for _, err := range Context.Errors.(MultiError)[start:] {
+				err.File, err.Line = d.file, d.line
+			}
+		}
+	}
+}
+
 // Current returns the expression whose DSL is currently being executed.
 // As a special case Current returns Top when the execution stack is empty.
 func Current() Expression {
@@ -179,9 +239,7 @@ func runSet(set ExpressionSet) error {
 			if def == nil {
 				continue
 			}
-			if source, ok := def.(Source); ok {
-				Execute(source.DSL(), def)
-			}
+			ExecuteOnce(def)
 		}
 		if recursed > 100 {
 			return fmt.Errorf("too many generated expressions, infinite loop?")
@@ -36,6 +36,9 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// HealthCheck is true if the example servers expose the health
+		// check endpoints.
+		HealthCheck bool
 		// HTTP contains the HTTP specific API level expressions.
 		HTTP *HTTPExpr
 		// GRPC contains the gRPC specific API level expressions.
@@ -84,7 +87,7 @@ func NewAPIExpr(name string, dsl func()) *APIExpr {
 
 // Schemes returns the list of transport schemes used by all the API servers.
 // The possible values for the elements of the returned slice are "http",
-// "https", "grpc" and "grpcs".
+// "https", "grpc", "grpcs" and "unix".
 func (a *APIExpr) Schemes() []string {
 	schemes := make(map[string]struct{})
 	for _, s := range a.Servers {
@@ -131,6 +134,37 @@ func (a *APIExpr) DefaultServer() *ServerExpr {
 	}
 }
 
+// ExampleGRPCHealth returns true if the example gRPC servers register the
+// standard gRPC health service, that is if the API uses the HealthCheck DSL or
+// defines the "grpc:example:health" metadata.
+func (a *APIExpr) ExampleGRPCHealth() bool {
+	_, ok := a.Meta["grpc:example:health"]
+	return a.HealthCheck || ok
+}
+
+// ExampleGRPCReflection returns true if the example gRPC servers register the
+// gRPC server reflection service, that is if the API defines the
+// "grpc:example:reflection" metadata.
+func (a *APIExpr) ExampleGRPCReflection() bool {
+	_, ok := a.Meta["grpc:example:reflection"]
+	return ok
+}
+
+// ExampleH2C returns true if the example HTTP servers serve cleartext HTTP/2
+// and the example HTTP clients use HTTP/2 with prior knowledge, that is if the
+// API defines the "http:example:h2c" metadata.
+func (a *APIExpr) ExampleH2C() bool {
+	_, ok := a.Meta["http:example:h2c"]
+	return ok
+}
+
+// ExampleHTTP3 returns true if the example HTTP servers also serve HTTP/3, that
+// is if the API defines the "http:example:http3" metadata.
+func (a *APIExpr) ExampleHTTP3() bool {
+	_, ok := a.Meta["http:example:http3"]
+	return ok
+}
+
 // EvalName is the qualified name of the expression.
 func (a *APIExpr) EvalName() string { return "API " + a.Name }
 
@@ -2,6 +2,11 @@ package expr
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"goa.design/goa/v3/eval"
 )
@@ -87,6 +92,51 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
 		Required []string
+		// TimeZone is the time zone required for date-time values,
+		// either "UTC" or a fixed offset of the form "+hh:mm" or
+		// "-hh:mm".
+		TimeZone string
+		// Precision is the maximum number of digits of decimal
+		// values.
+		Precision *int
+		// Scale is the maximum number of digits after the decimal
+		// point of decimal values.
+		Scale *int
+		// MutuallyExclusive lists groups of fields of object attributes
+		// where at most one field of each group may be set.
+		MutuallyExclusive [][]string
+		// RequiredTogether lists groups of fields of object attributes
+		// where either all or none of the fields of each group must be
+		// set.
+		RequiredTogether [][]string
+		// AtLeastOneOf lists groups of fields of object attributes where
+		// at least one field of each group must be set.
+		AtLeastOneOf [][]string
+		// RequiredIf lists the conditional requirements of object
+		// attributes.
+		RequiredIf []*RequiredIfExpr
+		// Funcs lists the user provided functions that validate the
+		// attribute value in addition to the built-in validations. Each
+		// function is identified by its import path followed by a dot and
+		// its name, e.g. "github.com/acme/validators.IBAN".
+		Funcs []string
+		// Messages overrides the messages of the errors returned when the
+		// validations fail indexed by validation name (e.g. "minimum").
+		// The messages may use the "{value}" placeholder to refer to the
+		// offending value.
+		Messages map[string]string
+	}
+
+	// RequiredIfExpr describes fields of an object attribute that are
+	// required only when another field has a given value.
+	RequiredIfExpr struct {
+		// Field is the name of the field whose value is tested.
+		Field string
+		// Value is the value that makes the fields listed in Names
+		// required.
+		Value interface{}
+		// Names lists the conditionally required fields.
+		Names []string
 	}
 
 	// ValidationFormat is the type used to enumerate the possible string
@@ -136,6 +186,43 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatDecimal describes decimal numbers (e.g. "-12.50").
+	FormatDecimal = "decimal"
+
+	// FormatSemver describes Semantic Versioning 2.0.0 version numbers.
+	FormatSemver = "semver"
+
+	// FormatBase64 describes RFC4648 standard base64 encoded values.
+	FormatBase64 = "base64"
+
+	// FormatE164 describes ITU-T E.164 international phone numbers.
+	FormatE164 = "e164"
+
+	// FormatULID describes ULID values.
+	FormatULID = "ulid"
+
+	// FormatDuration describes Go or ISO 8601 duration values.
+	FormatDuration = "duration"
+
+	// FormatCreditCard describes payment card numbers.
+	FormatCreditCard = "credit-card"
+
+	// FormatCountryCode describes ISO 3166-1 alpha-2 country codes.
+	FormatCountryCode = "country-code"
+
+	// FormatCurrencyCode describes ISO 4217 currency codes.
+	FormatCurrencyCode = "currency-code"
+)
+
+const (
+	// SensitiveMask is the anonymization strategy that replaces the
+	// characters of sensitive values with asterisks.
+	SensitiveMask = "mask"
+
+	// SensitiveHash is the anonymization strategy that replaces sensitive
+	// values with their SHA-256 hash.
+	SensitiveHash = "hash"
 )
 
 // EvalName returns the name used by the DSL evaluation.
@@ -195,21 +282,70 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		ctx += " - "
 	}
 	verr.Merge(a.validateEnumDefault(ctx, parent))
+	if v := a.Validation; v != nil && len(v.Funcs) > 0 {
+		if !IsPrimitive(a.Type) {
+			verr.Add(parent, "%sdefines custom validation functions but type %s is not a primitive type", ctx, a.Type.Name())
+		}
+		for _, fn := range v.Funcs {
+			if _, _, ok := ParseValidateFunc(fn); !ok {
+				verr.Add(parent, `%sinvalid custom validation function %q, the function must be given as the package import path followed by a dot and the exported function name, e.g. "github.com/acme/validators.IBAN"`, ctx, fn)
+			}
+		}
+	}
+	if v := a.Validation; v != nil && len(v.Messages) > 0 {
+		names := make([]string, 0, len(v.Messages))
+		for n := range v.Messages {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			known, defined := v.defines(n)
+			if !known {
+				verr.Add(parent, "%sdefines a message for unknown validation %q, the validation must be one of %s", ctx, n, strings.Join(validationMessageNames, ", "))
+			} else if !defined {
+				verr.Add(parent, "%sdefines a message for validation %q but does not define the validation", ctx, n)
+			}
+		}
+	}
 	if o := AsObject(a.Type); o != nil {
 		for _, n := range a.AllRequired() {
 			if a.Find(n) == nil {
 				verr.Add(parent, `%srequired field %q does not exist in type %s`, ctx, n, a.Type.Name())
 			}
 		}
+		if v := a.Validation; v != nil {
+			verr.Merge(a.validateFieldGroups(ctx, "mutually exclusive", v.MutuallyExclusive, parent))
+			for _, g := range v.MutuallyExclusive {
+				for _, n := range g {
+					if a.IsRequired(n) || a.HasDefaultValue(n) {
+						verr.Add(parent, `%smutually exclusive field %q cannot be required or have a default value`, ctx, n)
+					}
+				}
+			}
+			verr.Merge(a.validateFieldGroups(ctx, "required together", v.RequiredTogether, parent))
+			verr.Merge(a.validateFieldGroups(ctx, "at least one of", v.AtLeastOneOf, parent))
+			verr.Merge(a.validateRequiredIf(ctx, v.RequiredIf, parent))
+		}
 		for _, nat := range *o {
 			ctx = fmt.Sprintf("field %s", nat.Name)
 			verr.Merge(nat.Attribute.Validate(ctx, parent))
+			if from := nat.Attribute.DefaultFrom(); from != "" {
+				verr.Merge(a.validateDefaultFrom(nat.Name, from, parent))
+			}
+			for _, n := range nat.Attribute.RenamedFrom() {
+				if n == "" || o.Attribute(n) != nil {
+					verr.Add(parent, "field %s - previous name %q cannot be empty or the name of a field", nat.Name, n)
+				}
+			}
 		}
 	} else {
 		if ar := AsArray(a.Type); ar != nil {
 			elemType := ar.ElemType
 			verr.Merge(elemType.Validate(ctx, a))
 		}
+		if v := a.Validation; v != nil && (len(v.MutuallyExclusive) > 0 || len(v.RequiredTogether) > 0 || len(v.AtLeastOneOf) > 0 || len(v.RequiredIf) > 0) {
+			verr.Add(parent, "%sdefines field group validations but type %s is not an object", ctx, a.Type.Name())
+		}
 	}
 
 	if views, ok := a.Meta["view"]; ok {
@@ -231,6 +367,87 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		}
 	}
 
+	if a.Validation != nil && a.Validation.TimeZone != "" && a.Validation.Format != FormatDateTime {
+		verr.Add(parent, "%sdefines a time zone but is not formatted as a date-time, use Format(FormatDateTime)", ctx)
+	}
+
+	if a.Validation != nil && (a.Validation.Precision != nil || a.Validation.Scale != nil) {
+		if a.Type != Decimal {
+			verr.Add(parent, "%sdefines a precision or scale validation but type %s is not Decimal", ctx, a.Type.Name())
+		} else if p, s := a.Validation.Precision, a.Validation.Scale; p != nil && s != nil && *s > *p {
+			verr.Add(parent, "%sdefines a scale of %d greater than its precision of %d", ctx, *s, *p)
+		}
+	}
+
+	if a.IsEncrypted() {
+		if k := a.Type.Kind(); k != StringKind && k != BytesKind {
+			verr.Add(parent, "%sis encrypted but type %s is not String or Bytes", ctx, a.Type.Name())
+		}
+	}
+
+	if _, ok := a.Meta["goa:logfield"]; ok && !IsPrimitive(a.Type) {
+		verr.Add(parent, "%sis a log field but type %s is not a primitive type", ctx, a.Type.Name())
+	}
+
+	if v, ok := a.Meta["goa:sensitive"]; ok {
+		if a.Type != String {
+			verr.Add(parent, "%sis sensitive but type %s is not String", ctx, a.Type.Name())
+		} else if len(v) != 1 || (v[0] != SensitiveMask && v[0] != SensitiveHash) {
+			verr.Add(parent, "%suses an invalid anonymization strategy %v, strategy must be one of %q or %q", ctx, v, SensitiveMask, SensitiveHash)
+		}
+	}
+
+	if v, ok := a.Meta["goa:timestamp:format"]; ok {
+		if a.Type != Timestamp {
+			verr.Add(parent, "%suses a timestamp format but type %s is not Timestamp", ctx, a.Type.Name())
+		} else if len(v) == 0 || (v[0] != TimestampRFC3339 && v[0] != TimestampUnix && v[0] != TimestampUnixMilli) {
+			verr.Add(parent, "%suses an invalid timestamp format %v, format must be one of %q, %q or %q", ctx, v, TimestampRFC3339, TimestampUnix, TimestampUnixMilli)
+		}
+	}
+
+	if v, ok := a.Meta["validation:sample"]; ok {
+		if !IsArray(a.Type) {
+			verr.Add(parent, "%ssamples its validations but type %s is not an array", ctx, a.Type.Name())
+		} else if r, err := strconv.ParseFloat(strings.Join(v, ""), 64); err != nil || len(v) != 1 || r <= 0 || r >= 1 {
+			verr.Add(parent, "%suses an invalid validation sample rate %v, the rate must be a number greater than 0 and lesser than 1", ctx, v)
+		}
+	}
+
+	if _, ok := a.Meta["xml:attribute"]; ok {
+		if !IsPrimitive(a.Type) {
+			verr.Add(parent, "%sis serialized as a XML attribute but type %s is not a primitive type", ctx, a.Type.Name())
+		}
+		if _, ok := a.Meta["xml:wrapped"]; ok {
+			verr.Add(parent, "%scannot be both serialized as a XML attribute and wrapped", ctx)
+		}
+	}
+	if _, ok := a.Meta["xml:wrapped"]; ok && !IsArray(a.Type) {
+		verr.Add(parent, "%sis XML wrapped but type %s is not an array", ctx, a.Type.Name())
+	}
+
+	return verr
+}
+
+// validateDefaultFrom makes sure that the attribute from used to compute the
+// default value of the child attribute name exists and has the same type.
+func (a *AttributeExpr) validateDefaultFrom(name, from string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	att := a.Find(name)
+	src := a.Find(from)
+	switch {
+	case src == nil:
+		verr.Add(parent, "field %s - default attribute %q does not exist in type %s", name, from, a.Type.Name())
+	case from == name:
+		verr.Add(parent, "field %s - attribute cannot default to itself", name)
+	case src.Type.Hash() != att.Type.Hash():
+		verr.Add(parent, "field %s - type %s of default attribute %q does not match attribute type %s", name, src.Type.Name(), from, att.Type.Name())
+	case src.DefaultFrom() != "":
+		verr.Add(parent, "field %s - default attribute %q cannot use DefaultFrom itself", name, from)
+	case att.DefaultValue != nil:
+		verr.Add(parent, "field %s - attribute cannot use both Default and DefaultFrom", name)
+	case a.IsRequired(name):
+		verr.Add(parent, "field %s - attribute is required and cannot use DefaultFrom", name)
+	}
 	return verr
 }
 
@@ -365,12 +582,95 @@ func (a *AttributeExpr) IsPrimitivePointer(attName string, useDefault bool) bool
 		return false
 	}
 	if IsPrimitive(att.Type) {
-		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind &&
+		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind && !att.IsOptionalField() &&
 			!a.IsRequired(attName) && (!a.HasDefaultValue(attName) || !useDefault)
 	}
 	return false
 }
 
+// IsOptionalField returns true if the attribute value is stored in a
+// goa.Optional wrapper rather than in a pointer, see the OptionalFields DSL.
+func (a *AttributeExpr) IsOptionalField() bool {
+	_, ok := a.Meta["goa:optional"]
+	return ok
+}
+
+// ValidationSampleRate returns the fraction of the array elements validated
+// as defined with the "validation:sample" meta and true if the attribute
+// samples its validations, 0 and false otherwise.
+func (a *AttributeExpr) ValidationSampleRate() (float64, bool) {
+	v, ok := a.Meta["validation:sample"]
+	if !ok || len(v) == 0 {
+		return 0, false
+	}
+	r, err := strconv.ParseFloat(v[0], 64)
+	if err != nil {
+		return 0, false
+	}
+	return r, true
+}
+
+// IsEncrypted returns true if the attribute is marked as encrypted via the
+// Encrypted DSL.
+func (a *AttributeExpr) IsEncrypted() bool {
+	_, ok := a.Meta["goa:encrypted"]
+	return ok
+}
+
+// LogFieldKey returns the log key set via the LogField DSL, name if LogField
+// does not specify one, and true if the attribute uses LogField, the empty
+// string and false otherwise.
+func (a *AttributeExpr) LogFieldKey(name string) (string, bool) {
+	v, ok := a.Meta["goa:logfield"]
+	if !ok {
+		return "", false
+	}
+	if len(v) > 0 && v[0] != "" {
+		return v[0], true
+	}
+	return name, true
+}
+
+// SensitiveStrategy returns the anonymization strategy set via the Sensitive
+// DSL and true if the attribute is sensitive, the empty string and false
+// otherwise.
+func (a *AttributeExpr) SensitiveStrategy() (string, bool) {
+	v, ok := a.Meta["goa:sensitive"]
+	if !ok || len(v) == 0 {
+		return "", false
+	}
+	return v[0], true
+}
+
+// TimestampFormat returns the wire format of the timestamps held by the
+// attribute as set via the TimestampFormat DSL, TimestampRFC3339 if there is
+// none. The format of an array attribute is the format of its elements.
+func (a *AttributeExpr) TimestampFormat() string {
+	if arr := AsArray(a.Type); arr != nil {
+		return arr.ElemType.TimestampFormat()
+	}
+	if v, ok := a.Meta["goa:timestamp:format"]; ok && len(v) > 0 {
+		return v[0]
+	}
+	return TimestampRFC3339
+}
+
+// RenamedFrom returns the previous names of the attribute or of the type it
+// defines set via the RenamedFrom DSL, nil if there is none.
+func (a *AttributeExpr) RenamedFrom() []string {
+	return a.Meta["goa:renamedfrom"]
+}
+
+// DefaultFrom returns the name of the sibling attribute whose value is used as
+// default value for the attribute as set via the DefaultFrom DSL, the empty
+// string if there is none.
+func (a *AttributeExpr) DefaultFrom() string {
+	if v, ok := a.Meta["goa:defaultfrom"]; ok && len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
 // HasTag returns true if the attribute is an object that has an attribute with
 // the given tag.
 func (a *AttributeExpr) HasTag(tag string) bool {
@@ -455,6 +755,7 @@ func (a *AttributeExpr) Delete(name string) {
 		AsObject(t).Delete(name)
 		if a.Validation != nil {
 			a.Validation.RemoveRequired(name)
+			a.Validation.RemoveFieldFromGroups(name)
 		}
 		for _, ex := range a.UserExamples {
 			if m, ok := ex.Value.(map[string]interface{}); ok {
@@ -519,6 +820,58 @@ func (a *AttributeExpr) validateEnumDefault(ctx string, parent eval.Expression)
 	return verr
 }
 
+// validateFieldGroups makes sure the fields listed in the given field group
+// validations exist and that each group lists at least two distinct fields.
+func (a *AttributeExpr) validateFieldGroups(ctx, kind string, groups [][]string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, group := range groups {
+		seen := make(map[string]struct{}, len(group))
+		for _, n := range group {
+			if a.Find(n) == nil {
+				verr.Add(parent, `%s%s field %q does not exist in type %s`, ctx, kind, n, a.Type.Name())
+			}
+			seen[n] = struct{}{}
+		}
+		if len(seen) < 2 {
+			verr.Add(parent, `%s%s validation must list at least two distinct fields, got %v`, ctx, kind, group)
+		}
+	}
+	return verr
+}
+
+// validateRequiredIf makes sure the fields listed in the given conditional
+// requirements exist and that the tested fields are primitives compatible with
+// the tested values.
+func (a *AttributeExpr) validateRequiredIf(ctx string, reqs []*RequiredIfExpr, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, r := range reqs {
+		fatt := a.Find(r.Field)
+		if fatt == nil {
+			verr.Add(parent, `%srequired if field %q does not exist in type %s`, ctx, r.Field, a.Type.Name())
+		} else {
+			switch fatt.Type.Kind() {
+			case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind, StringKind:
+				if !fatt.Type.IsCompatible(r.Value) {
+					verr.Add(parent, `%svalue %#v of required if field %q is incompatible with the field type %s`, ctx, r.Value, r.Field, fatt.Type.Name())
+				}
+			default:
+				verr.Add(parent, `%srequired if field %q must be a boolean, number or string, got %s`, ctx, r.Field, fatt.Type.Name())
+			}
+		}
+		if len(r.Names) == 0 {
+			verr.Add(parent, `%srequired if validation on field %q must list at least one required field`, ctx, r.Field)
+		}
+		for _, n := range r.Names {
+			if n == r.Field {
+				verr.Add(parent, `%sfield %q cannot be required if it has a value`, ctx, n)
+			} else if a.Find(n) == nil {
+				verr.Add(parent, `%srequired if field %q does not exist in type %s`, ctx, n, a.Type.Name())
+			}
+		}
+	}
+	return verr
+}
+
 func (a *AttributeExpr) inheritRecursive(parent *AttributeExpr, seen map[*AttributeExpr]struct{}) {
 	if !a.shouldInherit(parent) {
 		return
@@ -582,6 +935,9 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 	if v.Pattern == "" {
 		v.Pattern = other.Pattern
 	}
+	if v.TimeZone == "" {
+		v.TimeZone = other.TimeZone
+	}
 	if v.Minimum == nil || (other.Minimum != nil && *v.Minimum > *other.Minimum) {
 		v.Minimum = other.Minimum
 	}
@@ -594,7 +950,49 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 	if v.MaxLength == nil || (other.MaxLength != nil && *v.MaxLength < *other.MaxLength) {
 		v.MaxLength = other.MaxLength
 	}
+	if v.Precision == nil || (other.Precision != nil && *v.Precision < *other.Precision) {
+		v.Precision = other.Precision
+	}
+	if v.Scale == nil || (other.Scale != nil && *v.Scale < *other.Scale) {
+		v.Scale = other.Scale
+	}
 	v.AddRequired(other.Required...)
+	v.MutuallyExclusive = mergeFieldGroups(v.MutuallyExclusive, other.MutuallyExclusive)
+	v.RequiredTogether = mergeFieldGroups(v.RequiredTogether, other.RequiredTogether)
+	v.AtLeastOneOf = mergeFieldGroups(v.AtLeastOneOf, other.AtLeastOneOf)
+	for n, msg := range other.Messages {
+		if _, ok := v.Messages[n]; ok {
+			continue
+		}
+		if v.Messages == nil {
+			v.Messages = make(map[string]string)
+		}
+		v.Messages[n] = msg
+	}
+	for _, fn := range other.Funcs {
+		found := false
+		for _, f := range v.Funcs {
+			if f == fn {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Funcs = append(v.Funcs, fn)
+		}
+	}
+	for _, o := range other.RequiredIf {
+		found := false
+		for _, r := range v.RequiredIf {
+			if r.Field == o.Field && fmt.Sprintf("%#v", r.Value) == fmt.Sprintf("%#v", o.Value) && fmt.Sprint(r.Names) == fmt.Sprint(o.Names) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.RequiredIf = append(v.RequiredIf, o)
+		}
+	}
 }
 
 // AddRequired merges the required fields into v.
@@ -623,18 +1021,70 @@ func (v *ValidationExpr) RemoveRequired(required string) {
 	}
 }
 
+// RemoveFieldFromGroups removes the given field from the field group
+// validations and conditional requirements. Mutually exclusive and required
+// together groups that end up with less than two fields are removed. At least
+// one of groups that list the field are removed as the field may be the one
+// that is set. Conditional requirements that test the field or that end up
+// with no required field are removed.
+func (v *ValidationExpr) RemoveFieldFromGroups(name string) {
+	prune := func(groups [][]string, drop bool) [][]string {
+		var res [][]string
+		for _, group := range groups {
+			var g []string
+			for _, n := range group {
+				if n != name {
+					g = append(g, n)
+				}
+			}
+			if len(g) == len(group) || !drop && len(g) > 1 {
+				res = append(res, g)
+			}
+		}
+		return res
+	}
+	v.MutuallyExclusive = prune(v.MutuallyExclusive, false)
+	v.RequiredTogether = prune(v.RequiredTogether, false)
+	v.AtLeastOneOf = prune(v.AtLeastOneOf, true)
+	var reqs []*RequiredIfExpr
+	for _, r := range v.RequiredIf {
+		if r.Field == name {
+			continue
+		}
+		var names []string
+		for _, n := range r.Names {
+			if n != name {
+				names = append(names, n)
+			}
+		}
+		if len(names) > 0 {
+			reqs = append(reqs, &RequiredIfExpr{Field: r.Field, Value: r.Value, Names: names})
+		}
+	}
+	v.RequiredIf = reqs
+}
+
 // HasRequiredOnly returns true if the validation only has the Required field
 // with a non-zero value.
 func (v *ValidationExpr) HasRequiredOnly() bool {
 	if len(v.Values) > 0 {
 		return false
 	}
-	if v.Format != "" || v.Pattern != "" {
+	if v.Format != "" || v.Pattern != "" || v.TimeZone != "" {
 		return false
 	}
 	if (v.Minimum != nil) || (v.Maximum != nil) || (v.MinLength != nil) || (v.MaxLength != nil) {
 		return false
 	}
+	if v.Precision != nil || v.Scale != nil {
+		return false
+	}
+	if len(v.MutuallyExclusive) > 0 || len(v.RequiredTogether) > 0 || len(v.AtLeastOneOf) > 0 || len(v.RequiredIf) > 0 {
+		return false
+	}
+	if len(v.Funcs) > 0 {
+		return false
+	}
 	return true
 }
 
@@ -645,6 +1095,13 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		req = make([]string, len(v.Required))
 		copy(req, v.Required)
 	}
+	var msgs map[string]string
+	if len(v.Messages) > 0 {
+		msgs = make(map[string]string, len(v.Messages))
+		for n, msg := range v.Messages {
+			msgs[n] = msg
+		}
+	}
 	return &ValidationExpr{
 		Values:    v.Values,
 		Format:    v.Format,
@@ -654,7 +1111,122 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		MinLength: v.MinLength,
 		MaxLength: v.MaxLength,
 		Required:  req,
+		TimeZone:  v.TimeZone,
+		Precision: v.Precision,
+		Scale:     v.Scale,
+
+		MutuallyExclusive: dupFieldGroups(v.MutuallyExclusive),
+		RequiredTogether:  dupFieldGroups(v.RequiredTogether),
+		AtLeastOneOf:      dupFieldGroups(v.AtLeastOneOf),
+		RequiredIf:        dupRequiredIf(v.RequiredIf),
+		Funcs:             append([]string(nil), v.Funcs...),
+		Messages:          msgs,
+	}
+}
+
+// Message returns the message defined with the ValidationMessage DSL for the
+// validation with the given name, the empty string if there is none.
+func (v *ValidationExpr) Message(name string) string {
+	if v == nil {
+		return ""
 	}
+	return v.Messages[name]
+}
+
+// defines returns whether name is the name of a validation that supports
+// custom messages and whether v defines the validation. The "required"
+// validation is defined by the parent attribute so it is always considered
+// defined.
+func (v *ValidationExpr) defines(name string) (known, defined bool) {
+	switch name {
+	case "enum":
+		return true, len(v.Values) > 0
+	case "format":
+		return true, v.Format != ""
+	case "pattern":
+		return true, v.Pattern != ""
+	case "minimum":
+		return true, v.Minimum != nil
+	case "maximum":
+		return true, v.Maximum != nil
+	case "minLength":
+		return true, v.MinLength != nil
+	case "maxLength":
+		return true, v.MaxLength != nil
+	case "timeZone":
+		return true, v.TimeZone != ""
+	case "required":
+		return true, true
+	}
+	return false, false
+}
+
+// validationMessageNames lists the names of the validations that support
+// custom messages.
+var validationMessageNames = []string{"enum", "format", "pattern", "minimum", "maximum", "minLength", "maxLength", "timeZone", "required"}
+
+// ParseValidateFunc splits the given custom validation function reference
+// into the function package import path and name. ok is false if fn is not of
+// the form "import/path.Func" where Func is an exported identifier.
+func ParseValidateFunc(fn string) (pkgPath, name string, ok bool) {
+	idx := strings.LastIndex(fn, ".")
+	if idx <= 0 || idx == len(fn)-1 || idx < strings.LastIndex(fn, "/") {
+		return "", "", false
+	}
+	pkgPath, name = fn[:idx], fn[idx+1:]
+	if path.IsAbs(pkgPath) || path.Clean(pkgPath) != pkgPath || strings.HasPrefix(pkgPath, ".") || strings.ContainsAny(pkgPath, " \t\\") {
+		return "", "", false
+	}
+	for i, c := range name {
+		if i == 0 && !unicode.IsUpper(c) || !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			return "", "", false
+		}
+	}
+	return pkgPath, name, true
+}
+
+// mergeFieldGroups appends the groups of other that are not already in groups.
+func mergeFieldGroups(groups, other [][]string) [][]string {
+	for _, o := range other {
+		found := false
+		for _, g := range groups {
+			if fmt.Sprint(g) == fmt.Sprint(o) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, o)
+		}
+	}
+	return groups
+}
+
+// dupFieldGroups makes a deep copy of the given field groups.
+func dupFieldGroups(groups [][]string) [][]string {
+	if len(groups) == 0 {
+		return nil
+	}
+	res := make([][]string, len(groups))
+	for i, g := range groups {
+		res[i] = make([]string, len(g))
+		copy(res[i], g)
+	}
+	return res
+}
+
+// dupRequiredIf makes a deep copy of the given conditional requirements.
+func dupRequiredIf(reqs []*RequiredIfExpr) []*RequiredIfExpr {
+	if len(reqs) == 0 {
+		return nil
+	}
+	res := make([]*RequiredIfExpr, len(reqs))
+	for i, r := range reqs {
+		names := make([]string, len(r.Names))
+		copy(names, r.Names)
+		res[i] = &RequiredIfExpr{Field: r.Field, Value: r.Value, Names: names}
+	}
+	return res
 }
 
 // IsSupportedValidationFormat checks if the validation format is supported by goa.
@@ -688,6 +1260,24 @@ func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 		return true
 	case FormatRFC1123:
 		return true
+	case FormatDecimal:
+		return true
+	case FormatSemver:
+		return true
+	case FormatBase64:
+		return true
+	case FormatE164:
+		return true
+	case FormatULID:
+		return true
+	case FormatDuration:
+		return true
+	case FormatCreditCard:
+		return true
+	case FormatCountryCode:
+		return true
+	case FormatCurrencyCode:
+		return true
 	}
 	return false
 }
@@ -124,6 +124,29 @@ func TestAttributeExprValidate(t *testing.T) {
 		errRequiredFieldNotExist = fmt.Errorf(`%srequired field %q does not exist in type %s`, normalizedCtx, "foo", fieldNotExistType.Name())
 		errViewButNotAResultType = fmt.Errorf("%sdefines a view %v but type %s is not a result type", normalizedCtx, metadata["view"], notAResultType.Name())
 		errTypeNotDefineView     = fmt.Errorf("%stype %s does not define view %q", normalizedCtx, viewNotDefinedTypeName, "foo")
+		errEncryptedNotString    = fmt.Errorf("%sis encrypted but type %s is not String or Bytes", normalizedCtx, Int.Name())
+		errSensitiveNotString    = fmt.Errorf("%sis sensitive but type %s is not String", normalizedCtx, Int.Name())
+		errSensitiveStrategy     = fmt.Errorf("%suses an invalid anonymization strategy %v, strategy must be one of %q or %q", normalizedCtx, []string{"foo"}, SensitiveMask, SensitiveHash)
+		errXMLAttributeNotPrim   = fmt.Errorf("%sis serialized as a XML attribute but type %s is not a primitive type", normalizedCtx, "array")
+		errXMLAttributeWrapped   = fmt.Errorf("%scannot be both serialized as a XML attribute and wrapped", normalizedCtx)
+		errXMLWrappedNotArray    = fmt.Errorf("%sis XML wrapped but type %s is not an array", normalizedCtx, String.Name())
+		errTimeZoneNotDateTime   = fmt.Errorf("%sdefines a time zone but is not formatted as a date-time, use Format(FormatDateTime)", normalizedCtx)
+		errDefaultFromNotExist   = fmt.Errorf("field %s - default attribute %q does not exist in type %s", "bar", "baz", "object")
+		errRenamedFromField      = fmt.Errorf("field %s - previous name %q cannot be empty or the name of a field", "bar", "foo")
+		errDefaultFromMismatch   = fmt.Errorf("field %s - type %s of default attribute %q does not match attribute type %s", "bar", Int.Name(), "foo", String.Name())
+		errGroupFieldNotExist    = fmt.Errorf(`%sat least one of field %q does not exist in type %s`, normalizedCtx, "baz", "object")
+		errGroupSingleField      = fmt.Errorf(`%srequired together validation must list at least two distinct fields, got %v`, normalizedCtx, []string{"foo", "foo"})
+		errExclusiveRequired     = fmt.Errorf(`%smutually exclusive field %q cannot be required or have a default value`, normalizedCtx, "foo")
+		errGroupNotObject        = fmt.Errorf("%sdefines field group validations but type %s is not an object", normalizedCtx, String.Name())
+		errRequiredIfNotExist    = fmt.Errorf(`%srequired if field %q does not exist in type %s`, normalizedCtx, "baz", "object")
+		errRequiredIfMismatch    = fmt.Errorf(`%svalue %#v of required if field %q is incompatible with the field type %s`, normalizedCtx, 1, "foo", String.Name())
+		errRequiredIfSelf        = fmt.Errorf(`%sfield %q cannot be required if it has a value`, normalizedCtx, "foo")
+		errFuncNotPrimitive      = fmt.Errorf("%sdefines custom validation functions but type %s is not a primitive type", normalizedCtx, "object")
+		errFuncInvalid           = fmt.Errorf(`%sinvalid custom validation function %q, the function must be given as the package import path followed by a dot and the exported function name, e.g. "github.com/acme/validators.IBAN"`, normalizedCtx, "validators.iban")
+		errMessageUnknown        = fmt.Errorf("%sdefines a message for unknown validation %q, the validation must be one of %s", normalizedCtx, "minItems", "enum, format, pattern, minimum, maximum, minLength, maxLength, timeZone, required")
+		errMessageUndefined      = fmt.Errorf("%sdefines a message for validation %q but does not define the validation", normalizedCtx, "pattern")
+		errSampleNotArray        = fmt.Errorf("%ssamples its validations but type %s is not an array", normalizedCtx, String.Name())
+		errSampleInvalid         = fmt.Errorf("%suses an invalid validation sample rate %v, the rate must be a number greater than 0 and lesser than 1", normalizedCtx, []string{"1.5"})
 	)
 	cases := map[string]struct {
 		typ        DataType
@@ -284,6 +307,204 @@ func TestAttributeExprValidate(t *testing.T) {
 			metadata: metadata,
 			expected: &eval.ValidationErrors{Errors: []error{errTypeNotDefineView}},
 		},
+		"encrypted string": {
+			typ:      String,
+			metadata: MetaExpr{"goa:encrypted": nil},
+			expected: &eval.ValidationErrors{},
+		},
+		"encrypted but not a string": {
+			typ:      Int,
+			metadata: MetaExpr{"goa:encrypted": nil},
+			expected: &eval.ValidationErrors{Errors: []error{errEncryptedNotString}},
+		},
+		"sensitive string": {
+			typ:      String,
+			metadata: MetaExpr{"goa:sensitive": []string{SensitiveHash}},
+			expected: &eval.ValidationErrors{},
+		},
+		"sensitive but not a string": {
+			typ:      Int,
+			metadata: MetaExpr{"goa:sensitive": []string{SensitiveMask}},
+			expected: &eval.ValidationErrors{Errors: []error{errSensitiveNotString}},
+		},
+		"sensitive with invalid strategy": {
+			typ:      String,
+			metadata: MetaExpr{"goa:sensitive": []string{"foo"}},
+			expected: &eval.ValidationErrors{Errors: []error{errSensitiveStrategy}},
+		},
+		"xml attribute": {
+			typ:      String,
+			metadata: MetaExpr{"xml:attribute": nil},
+			expected: &eval.ValidationErrors{},
+		},
+		"xml attribute but not a primitive": {
+			typ:      &Array{ElemType: &AttributeExpr{Type: String}},
+			metadata: MetaExpr{"xml:attribute": nil, "xml:wrapped": nil},
+			expected: &eval.ValidationErrors{Errors: []error{errXMLAttributeNotPrim, errXMLAttributeWrapped}},
+		},
+		"xml wrapped": {
+			typ:      &Array{ElemType: &AttributeExpr{Type: String}},
+			metadata: MetaExpr{"xml:wrapped": nil},
+			expected: &eval.ValidationErrors{},
+		},
+		"xml wrapped but not an array": {
+			typ:      String,
+			metadata: MetaExpr{"xml:wrapped": nil},
+			expected: &eval.ValidationErrors{Errors: []error{errXMLWrappedNotArray}},
+		},
+		"time zone": {
+			typ:        String,
+			validation: &ValidationExpr{Format: FormatDateTime, TimeZone: "UTC"},
+			expected:   &eval.ValidationErrors{},
+		},
+		"time zone but not a date-time": {
+			typ:        String,
+			validation: &ValidationExpr{Format: FormatDate, TimeZone: "UTC"},
+			expected:   &eval.ValidationErrors{Errors: []error{errTimeZoneNotDateTime}},
+		},
+		"default from": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"goa:defaultfrom": []string{"foo"}}}},
+			},
+			expected: &eval.ValidationErrors{},
+		},
+		"default from attribute does not exist": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"goa:defaultfrom": []string{"baz"}}}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{errDefaultFromNotExist}},
+		},
+		"default from attribute with different type": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: Int}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"goa:defaultfrom": []string{"foo"}}}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{errDefaultFromMismatch}},
+		},
+		"renamed from": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"goa:renamedfrom": []string{"baz"}}}},
+			},
+			expected: &eval.ValidationErrors{},
+		},
+		"renamed from the name of a field": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"goa:renamedfrom": []string{"foo"}}}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{errRenamedFromField}},
+		},
+		"field groups": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{
+				MutuallyExclusive: [][]string{{"foo", "bar"}},
+				RequiredTogether:  [][]string{{"foo", "bar"}},
+				AtLeastOneOf:      [][]string{{"foo", "bar"}},
+			},
+			expected: &eval.ValidationErrors{},
+		},
+		"field group field does not exist": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{AtLeastOneOf: [][]string{{"foo", "baz"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errGroupFieldNotExist}},
+		},
+		"field group with a single field": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{RequiredTogether: [][]string{{"foo", "foo"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errGroupSingleField}},
+		},
+		"mutually exclusive field is required": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{Required: []string{"foo"}, MutuallyExclusive: [][]string{{"foo", "bar"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errExclusiveRequired}},
+		},
+		"required if": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{RequiredIf: []*RequiredIfExpr{{Field: "foo", Value: "x", Names: []string{"bar"}}}},
+			expected:   &eval.ValidationErrors{},
+		},
+		"required if field does not exist": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{RequiredIf: []*RequiredIfExpr{{Field: "baz", Value: "x", Names: []string{"foo"}}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errRequiredIfNotExist}},
+		},
+		"required if value with different type": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{RequiredIf: []*RequiredIfExpr{{Field: "foo", Value: 1, Names: []string{"bar"}}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errRequiredIfMismatch}},
+		},
+		"required if field requires itself": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{RequiredIf: []*RequiredIfExpr{{Field: "foo", Value: "x", Names: []string{"foo"}}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errRequiredIfSelf}},
+		},
+		"field groups but not an object": {
+			typ:        String,
+			validation: &ValidationExpr{AtLeastOneOf: [][]string{{"foo", "bar"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errGroupNotObject}},
+		},
+		"custom validation function": {
+			typ:        String,
+			validation: &ValidationExpr{Funcs: []string{"github.com/acme/validators.IBAN"}},
+			expected:   &eval.ValidationErrors{},
+		},
+		"custom validation function on object": {
+			typ:        &Object{{Name: "foo", Attribute: &AttributeExpr{Type: String}}},
+			validation: &ValidationExpr{Funcs: []string{"github.com/acme/validators.IBAN"}},
+			expected:   &eval.ValidationErrors{Errors: []error{errFuncNotPrimitive}},
+		},
+		"invalid custom validation function": {
+			typ:        String,
+			validation: &ValidationExpr{Funcs: []string{"validators.iban"}},
+			expected:   &eval.ValidationErrors{Errors: []error{errFuncInvalid}},
+		},
+		"validation messages": {
+			typ:        String,
+			validation: &ValidationExpr{Pattern: "^[a-z]+$", Messages: map[string]string{"pattern": "lowercase only", "required": "required"}},
+			expected:   &eval.ValidationErrors{},
+		},
+		"invalid validation messages": {
+			typ:        String,
+			validation: &ValidationExpr{Messages: map[string]string{"pattern": "lowercase only", "minItems": "too short"}},
+			expected:   &eval.ValidationErrors{Errors: []error{errMessageUnknown, errMessageUndefined}},
+		},
+		"sampled validation": {
+			typ:      &Array{ElemType: &AttributeExpr{Type: String}},
+			metadata: MetaExpr{"validation:sample": []string{"0.1"}},
+			expected: &eval.ValidationErrors{},
+		},
+		"sampled validation not array": {
+			typ:      String,
+			metadata: MetaExpr{"validation:sample": []string{"0.1"}},
+			expected: &eval.ValidationErrors{Errors: []error{errSampleNotArray}},
+		},
+		"invalid sampled validation rate": {
+			typ:      &Array{ElemType: &AttributeExpr{Type: String}},
+			metadata: MetaExpr{"validation:sample": []string{"1.5"}},
+			expected: &eval.ValidationErrors{Errors: []error{errSampleInvalid}},
+		},
 	}
 
 	for k, tc := range cases {
@@ -659,6 +880,35 @@ func TestAttributeExprHasDefaultValue(t *testing.T) {
 	}
 }
 
+func TestValidationExprRemoveFieldFromGroups(t *testing.T) {
+	validation := &ValidationExpr{
+		MutuallyExclusive: [][]string{{"foo", "bar"}, {"foo", "bar", "baz"}},
+		RequiredTogether:  [][]string{{"bar", "baz"}},
+		AtLeastOneOf:      [][]string{{"foo", "bar"}, {"bar", "baz"}},
+		RequiredIf: []*RequiredIfExpr{
+			{Field: "foo", Value: "x", Names: []string{"bar"}},
+			{Field: "bar", Value: "x", Names: []string{"foo"}},
+			{Field: "baz", Value: "x", Names: []string{"foo", "bar"}},
+		},
+	}
+	validation.RemoveFieldFromGroups("foo")
+	if actual, expected := fmt.Sprint(validation.MutuallyExclusive), "[[bar baz]]"; actual != expected {
+		t.Errorf("mutually exclusive: got %s, expected %s", actual, expected)
+	}
+	if actual, expected := fmt.Sprint(validation.RequiredTogether), "[[bar baz]]"; actual != expected {
+		t.Errorf("required together: got %s, expected %s", actual, expected)
+	}
+	if actual, expected := fmt.Sprint(validation.AtLeastOneOf), "[[bar baz]]"; actual != expected {
+		t.Errorf("at least one of: got %s, expected %s", actual, expected)
+	}
+	if len(validation.RequiredIf) != 1 {
+		t.Fatalf("required if: got %d validations, expected 1", len(validation.RequiredIf))
+	}
+	if actual, expected := fmt.Sprint(validation.RequiredIf[0].Names), "[bar]"; actual != expected {
+		t.Errorf("required if: got %s, expected %s", actual, expected)
+	}
+}
+
 func TestValidationExprHasRequiredOnly(t *testing.T) {
 	var (
 		values    = []interface{}{"foo"}
@@ -1,6 +1,7 @@
 package expr
 
 import (
+	"encoding/base64"
 	"fmt"
 	"math"
 	"regexp"
@@ -24,6 +25,13 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 		// slice.
 		return a.UserExamples[l-1].Value
 	}
+	// durations are strings on the wire but are validated as numbers
+	if a.Type == Duration {
+		return byDuration(a, r)
+	}
+	if a.Type == Decimal {
+		return byDecimal(a, r)
+	}
 	// randomize array length first, since that's from higher level
 	if hasLengthValidation(a) {
 		return byLength(a, r)
@@ -64,11 +72,34 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 		if example == nil {
 			example = a.Type.Example(r)
 		}
-		return example
+		return removeExclusiveFields(a, example)
 	}
 	return a.Type.Example(r)
 }
 
+// removeExclusiveFields removes the fields of the object example that are
+// listed after another field of the example in a MutuallyExclusive validation
+// so that the example validates.
+func removeExclusiveFields(a *AttributeExpr, example interface{}) interface{} {
+	m, ok := example.(map[string]interface{})
+	if !ok || a.Validation == nil {
+		return example
+	}
+	for _, g := range a.Validation.MutuallyExclusive {
+		set := false
+		for _, n := range g {
+			if _, ok := m[n]; !ok {
+				continue
+			}
+			if set {
+				delete(m, n)
+			}
+			set = true
+		}
+	}
+	return example
+}
+
 // NewLength returns an int that validates the generator attribute length
 // validations if any.
 func NewLength(a *AttributeExpr, r *Random) int {
@@ -174,6 +205,30 @@ func byFormat(a *AttributeExpr, r *Random) interface{} {
 		return nil
 	}
 	format := a.Validation.Format
+	switch format {
+	case FormatDecimal:
+		return byDecimal(a, r)
+	case FormatSemver:
+		return fmt.Sprintf("%d.%d.%d", r.Int()%10, r.Int()%20, r.Int()%100)
+	case FormatBase64:
+		return base64.StdEncoding.EncodeToString([]byte(r.faker.Characters(6)))
+	case FormatE164:
+		return fmt.Sprintf("+1%010d", r.Int()%10000000000)
+	case FormatULID:
+		res, err := regen.Generate(`[0-7][0-9A-HJKMNP-TV-Z]{25}`)
+		if err != nil {
+			return "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+		}
+		return res
+	case FormatDuration:
+		return (time.Duration(r.Int()%86400) * time.Second).String()
+	case FormatCreditCard:
+		return "4111111111111111"
+	case FormatCountryCode:
+		return []string{"US", "FR", "DE", "JP", "BR"}[r.Int()%5]
+	case FormatCurrencyCode:
+		return []string{"USD", "EUR", "JPY", "GBP", "BRL"}[r.Int()%5]
+	}
 	if res, ok := map[ValidationFormat]interface{}{
 		FormatEmail:    r.faker.Email(),
 		FormatHostname: r.faker.DomainName() + "." + r.faker.DomainSuffix(),
@@ -288,6 +343,51 @@ func byMinMax(a *AttributeExpr, r *Random) interface{} {
 	}
 }
 
+// byDuration returns a random duration formatted using the Go duration format
+// that satisfies the enum and range validations of a if any.
+func byDuration(a *AttributeExpr, r *Random) interface{} {
+	if hasEnumValidation(a) {
+		d, _ := DurationValue(byEnum(a, r))
+		return d.String()
+	}
+	d := time.Duration(r.Int()%3600) * time.Second
+	if hasMinMaxValidation(a) {
+		min, max := time.Duration(0), time.Duration(math.MaxInt64)
+		if a.Validation.Minimum != nil {
+			min = time.Duration(*a.Validation.Minimum)
+		}
+		if a.Validation.Maximum != nil {
+			max = time.Duration(*a.Validation.Maximum)
+		}
+		if d < min || d > max {
+			d = min
+		}
+	}
+	return d.String()
+}
+
+// byDecimal returns a random decimal representation that satisfies the
+// precision and scale validations of a if any.
+func byDecimal(a *AttributeExpr, r *Random) interface{} {
+	intDigits, scale := 4, 2
+	if a.Validation != nil {
+		if a.Validation.Scale != nil && *a.Validation.Scale < scale {
+			scale = *a.Validation.Scale
+		}
+		if p := a.Validation.Precision; p != nil && *p-scale < intDigits {
+			intDigits = *p - scale
+		}
+	}
+	ip := 0
+	if intDigits > 0 {
+		ip = r.Int() % int(math.Pow10(intDigits))
+	}
+	if scale == 0 {
+		return fmt.Sprintf("%d", ip)
+	}
+	return fmt.Sprintf("%d.%0*d", ip, scale, r.Int()%int(math.Pow10(scale)))
+}
+
 func checkPattern(a *AttributeExpr, example interface{}) bool {
 	if !hasPatternValidation(a) {
 		return true
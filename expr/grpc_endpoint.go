@@ -2,10 +2,20 @@ package expr
 
 import (
 	"fmt"
+	"strconv"
 
 	"goa.design/goa/v3/eval"
 )
 
+const (
+	// maxRPCTag is the largest field number allowed by protocol buffers.
+	maxRPCTag = 1<<29 - 1
+	// firstReservedRPCTag and lastReservedRPCTag delimit the range of field
+	// numbers reserved for the protocol buffers implementation.
+	firstReservedRPCTag = 19000
+	lastReservedRPCTag  = 19999
+)
+
 type (
 	// GRPCEndpointExpr describes a gRPC endpoint. It embeds a MethodExpr
 	// and adds gRPC specific properties.
@@ -28,6 +38,11 @@ type (
 		Metadata *MappedAttributeExpr
 		// Requirements is the list of security requirements for the gRPC endpoint.
 		Requirements []*SecurityExpr
+		// ResultPageSize is the maximum number of elements of the method
+		// result sent in each message of the server-streaming RPC that
+		// streams the result, see StreamResults. The result is sent in a
+		// single message if zero.
+		ResultPageSize int
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -44,6 +59,12 @@ func (e *GRPCEndpointExpr) Description() string {
 	return e.MethodExpr.Description
 }
 
+// StreamsResults returns true if the endpoint streams the elements of the
+// method result in pages over a server-streaming RPC.
+func (e *GRPCEndpointExpr) StreamsResults() bool {
+	return e.ResultPageSize > 0
+}
+
 // EvalName returns the generic expression name used in error messages.
 func (e *GRPCEndpointExpr) EvalName() string {
 	var prefix, suffix string
@@ -129,6 +150,26 @@ func (e *GRPCEndpointExpr) Validate() error {
 		verr.Merge(e.hasAnyType(er.AttributeExpr, fmt.Sprintf("Error %q", er.Name)))
 	}
 
+	// error if the payload, result, and error types define invalid or
+	// colliding field numbers. The fields of explicitly defined messages are
+	// validated by validateMessage.
+	seen := make(map[string]struct{})
+	verr.Merge(e.validateNestedRPCTags(e.MethodExpr.Payload, e.Request.Type != Empty, seen))
+	verr.Merge(e.validateNestedRPCTags(e.MethodExpr.Result, e.Response.Message.Type != Empty, seen))
+	for _, er := range e.MethodExpr.Errors {
+		verr.Merge(e.validateNestedRPCTags(er.AttributeExpr, false, seen))
+	}
+
+	// error if payload defines attributes stored in goa.Optional wrappers
+	// which cannot be represented in protocol buffer messages.
+	if obj := AsObject(e.MethodExpr.Payload.Type); obj != nil {
+		for _, nat := range *obj {
+			if nat.Attribute.IsOptionalField() {
+				verr.Add(e, "Payload attribute %q uses OptionalFields or PatchOf which is not supported by gRPC endpoints", nat.Name)
+			}
+		}
+	}
+
 	var hasMessage, hasMetadata bool
 	// Validate request
 	if e.Request.Type != Empty {
@@ -191,6 +232,19 @@ func (e *GRPCEndpointExpr) Validate() error {
 		}
 	}
 
+	if e.MethodExpr.HasFieldMask() {
+		verr.Merge(e.validateFieldMask())
+	}
+
+	if e.StreamsResults() {
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "StreamResults cannot be used with streaming methods")
+		}
+		if !IsArray(e.MethodExpr.Result.Type) {
+			verr.Add(e, "StreamResults requires the method result to be an array or a result type collection")
+		}
+	}
+
 	// Validate response
 	verr.Merge(e.Response.Validate(e))
 
@@ -404,13 +458,15 @@ func validateMessage(msgAtt, serviceAtt *AttributeExpr, e *GRPCEndpointExpr, req
 }
 
 // validateRPCTags verifies whether every attribute in the object type has
-// "rpc:tag" set in the meta and the tag numbers are unique.
+// "rpc:tag" set in the meta and the tag numbers are valid and unique.
 func validateRPCTags(fields *Object, e *GRPCEndpointExpr) *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
 	foundRPC := make(map[string]string)
 	for _, nat := range *fields {
 		if tag, ok := nat.Attribute.Meta["rpc:tag"]; !ok {
 			verr.Add(e, "attribute %q does not have \"rpc:tag\" defined in the meta", nat.Name)
+		} else if err := checkRPCTag(tag[0]); err != nil {
+			verr.Add(e, "attribute %q: %s", nat.Name, err)
 		} else if a, ok := foundRPC[tag[0]]; ok {
 			verr.Add(e, "field number %s in attribute %q already exists for attribute %q", tag[0], nat.Name, a)
 		} else {
@@ -420,6 +476,97 @@ func validateRPCTags(fields *Object, e *GRPCEndpointExpr) *eval.ValidationErrors
 	return verr
 }
 
+// validateFieldMask makes sure the "field_mask" field added to the request
+// message of methods that use FieldMask does not conflict with the other
+// message fields.
+func (e *GRPCEndpointExpr) validateFieldMask() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	msg := e.Request
+	if msg.Type == Empty {
+		msg = e.MethodExpr.Payload
+	}
+	fields := AsObject(msg.Type)
+	if fields == nil {
+		fields = &Object{}
+	}
+	if fields.Attribute("field_mask") != nil {
+		verr.Add(e, "FieldMask adds a \"field_mask\" field to the request message which conflicts with the attribute of the same name")
+	}
+	tag := e.MethodExpr.FieldMaskTag()
+	if tag == "" {
+		return verr
+	}
+	if err := checkRPCTag(tag); err != nil {
+		verr.Add(e, "FieldMask: %s", err)
+		return verr
+	}
+	for _, nat := range *fields {
+		if t, ok := nat.Attribute.Meta["rpc:tag"]; ok && t[0] == tag {
+			verr.Add(e, "FieldMask field number %s already exists for attribute %q", tag, nat.Name)
+		}
+	}
+	return verr
+}
+
+// validateNestedRPCTags recurses through the given attribute and returns
+// validation errors if any object nested in it defines an invalid "rpc:tag"
+// or reuses the same field number for two attributes. top indicates that the
+// fields of the attribute itself are validated separately.
+func (e *GRPCEndpointExpr) validateNestedRPCTags(a *AttributeExpr, top bool, seen ...map[string]struct{}) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	switch actual := a.Type.(type) {
+	case UserType:
+		var s map[string]struct{}
+		if len(seen) > 0 {
+			s = seen[0]
+		} else {
+			s = make(map[string]struct{})
+			seen = append(seen, s)
+		}
+		if _, ok := s[actual.ID()]; ok {
+			return verr
+		}
+		s[actual.ID()] = struct{}{}
+		verr.Merge(e.validateNestedRPCTags(actual.Attribute(), top, seen...))
+	case *Array:
+		verr.Merge(e.validateNestedRPCTags(actual.ElemType, false, seen...))
+	case *Map:
+		verr.Merge(e.validateNestedRPCTags(actual.KeyType, false, seen...))
+		verr.Merge(e.validateNestedRPCTags(actual.ElemType, false, seen...))
+	case *Object:
+		foundRPC := make(map[string]string)
+		for _, nat := range *actual {
+			if tag, ok := nat.Attribute.Meta["rpc:tag"]; ok && !top {
+				if err := checkRPCTag(tag[0]); err != nil {
+					verr.Add(e, "attribute %q: %s", nat.Name, err)
+				} else if a, ok := foundRPC[tag[0]]; ok {
+					verr.Add(e, "field number %s in attribute %q already exists for attribute %q", tag[0], nat.Name, a)
+				} else {
+					foundRPC[tag[0]] = nat.Name
+				}
+			}
+			verr.Merge(e.validateNestedRPCTags(nat.Attribute, false, seen...))
+		}
+	}
+	return verr
+}
+
+// checkRPCTag returns an error if the given "rpc:tag" value is not a valid
+// protocol buffer field number.
+func checkRPCTag(tag string) error {
+	n, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil {
+		return fmt.Errorf("field number %q is not a positive integer", tag)
+	}
+	switch {
+	case n < 1 || n > maxRPCTag:
+		return fmt.Errorf("field number %d must be between 1 and %d", n, maxRPCTag)
+	case n >= firstReservedRPCTag && n <= lastReservedRPCTag:
+		return fmt.Errorf("field number %d is in the range %d to %d reserved by protocol buffers", n, firstReservedRPCTag, lastReservedRPCTag)
+	}
+	return nil
+}
+
 // validateMetadata validates the gRPC metadata. It compares the given metadata
 // with the service type (Payload or Result) and ensures all the attributes
 // defined in the metadata type are found in the service type.
@@ -592,9 +739,9 @@ func zeroValue(dt DataType) interface{} {
 		return false
 	case IntKind, Int32Kind, Int64Kind,
 		UIntKind, UInt32Kind, UInt64Kind,
-		Float32Kind, Float64Kind:
+		Float32Kind, Float64Kind, DurationKind:
 		return 0
-	case StringKind:
+	case StringKind, UUIDKind, DecimalKind:
 		return ""
 	default:
 		return nil
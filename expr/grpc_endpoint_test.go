@@ -23,6 +23,27 @@ service "Service" gRPC endpoint "Method": Error "invalid_error_type" type is Any
 service "Service" gRPC endpoint "Method": Map element type is Any type which is not supported in gRPC`,
 			},
 		},
+		"endpoint-with-invalid-rpc-tags": {
+			DSL: testdata.GRPCEndpointWithInvalidRPCTags,
+			Errors: []string{`service "Service" gRPC endpoint "Method": field number 1 in attribute "b" already exists for attribute "a"
+service "Service" gRPC endpoint "Method": attribute "c": field number 19500 is in the range 19000 to 19999 reserved by protocol buffers
+service "Service" gRPC endpoint "Method": attribute "reserved": field number 19000 is in the range 19000 to 19999 reserved by protocol buffers
+service "Service" gRPC endpoint "Method": attribute "zero": field number 0 must be between 1 and 536870911
+service "Service" gRPC endpoint "Method": attribute "too_large": field number 536870912 must be between 1 and 536870911`,
+			},
+		},
+		"endpoint-with-invalid-stream-results": {
+			DSL: testdata.GRPCEndpointWithInvalidStreamResults,
+			Errors: []string{`service "Service" gRPC endpoint "Method": StreamResults cannot be used with streaming methods
+service "Service" gRPC endpoint "Method": StreamResults requires the method result to be an array or a result type collection`,
+			},
+		},
+		"endpoint-with-conflicting-field-mask": {
+			DSL: testdata.GRPCEndpointWithConflictingFieldMask,
+			Errors: []string{`service "Service" gRPC endpoint "Method": FieldMask adds a "field_mask" field to the request message which conflicts with the attribute of the same name
+service "Service" gRPC endpoint "Method": FieldMask field number 1 already exists for attribute "field_mask"`,
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
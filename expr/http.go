@@ -1,7 +1,9 @@
 package expr
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 )
 
 type (
@@ -26,7 +28,83 @@ type (
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
 		Errors []*HTTPErrorExpr
+		// FixedHeaders lists the headers written with a constant value
+		// by all the API responses.
+		FixedHeaders []*HTTPFixedHeaderExpr
+		// VersionHeader is the name of the request header used to
+		// select the API version served by the versioned endpoints.
+		VersionHeader string
+		// ViewParam is the name of the query string parameter used by
+		// clients to select the result view of the endpoints whose
+		// result defines multiple views, empty if not set.
+		ViewParam string
+		// DisableHTMLEscaping disables the escaping of the HTML
+		// characters in the JSON responses.
+		DisableHTMLEscaping bool
+		// NoSniff sets the X-Content-Type-Options header of the
+		// responses to "nosniff".
+		NoSniff bool
+		// JSONPrefix is written before the body of the JSON responses
+		// to protect from JSON hijacking, empty if not set.
+		JSONPrefix string
+		// MaxRequestBody is the maximum size in bytes of the request
+		// bodies read by the API endpoints, zero if not limited.
+		MaxRequestBody int64
+		// Compression lists the content codings used to compress the
+		// responses of the API endpoints in order of preference, nil if
+		// not set.
+		Compression []string
+		// RequestCompression lists the content codings of the
+		// compressed request bodies accepted by the API endpoints, nil
+		// if not set.
+		RequestCompression []string
+		// TracePhases is true if the handlers of the API endpoints
+		// notify the phase observer of the request phases.
+		TracePhases bool
+		// PassThroughHeaders lists the canonical names of the headers
+		// of the upstream responses written to the responses of the API
+		// endpoints, nil if not set.
+		PassThroughHeaders []string
+		// Router is the name of the router whose path pattern syntax
+		// is used by the generated servers, empty for the goa muxer.
+		Router string
+		// StrictStatusCodes is true if all the errors must be mapped to
+		// HTTP responses and the generated code and OpenAPI
+		// specification list all the status codes of the endpoints.
+		StrictStatusCodes bool
+		// TrailingSlash is the policy applied to the requests whose
+		// path differs from the path of a route by a trailing slash:
+		// "strict", "redirect" or "rewrite", empty if not set.
+		TrailingSlash string
 	}
+
+	// HTTPFixedHeaderExpr describes a response header whose value is
+	// constant.
+	HTTPFixedHeaderExpr struct {
+		// Name is the canonical name of the header.
+		Name string
+		// Value is the value of the header.
+		Value string
+	}
+)
+
+const (
+	// HTTPStyleForm serializes the array query string parameters as
+	// repeated parameters (e.g. "id=1&id=2") or as comma separated values
+	// (e.g. "id=1,2") if explode is false.
+	HTTPStyleForm = "form"
+	// HTTPStyleSpaceDelimited serializes the array query string parameters
+	// as space separated values (e.g. "id=1%202").
+	HTTPStyleSpaceDelimited = "spaceDelimited"
+	// HTTPStylePipeDelimited serializes the array query string parameters
+	// as pipe separated values (e.g. "id=1|2").
+	HTTPStylePipeDelimited = "pipeDelimited"
+	// HTTPStyleDeepObject serializes the map query string parameters as
+	// nested keys (e.g. "filter[name]=x").
+	HTTPStyleDeepObject = "deepObject"
+	// HTTPStyleSimple serializes the array headers as comma separated
+	// values (e.g. "X-Ids: 1,2").
+	HTTPStyleSimple = "simple"
 )
 
 // HTTPWildcardRegex is the regular expression used to capture path
@@ -44,6 +122,128 @@ func ExtractHTTPWildcards(path string) []string {
 	return wcs
 }
 
+// SplitHTTPPathConstraints removes the regular expressions that constrain the
+// wildcards of the given HTTP path, e.g. "/items/{id:[0-9]+}" becomes
+// "/items/{id}". It returns the path without the constraints and the
+// constraints indexed by wildcard name, nil if the path has none. The
+// regular expressions may contain balanced braces.
+func SplitHTTPPathConstraints(path string) (string, map[string]string, error) {
+	var (
+		res   strings.Builder
+		cons  map[string]string
+		start = 0
+	)
+	for start < len(path) {
+		i := strings.Index(path[start:], "{")
+		if i < 0 {
+			break
+		}
+		i += start
+		j := i + 1
+		for j < len(path) && path[j] != ':' && path[j] != '}' && path[j] != '{' {
+			j++
+		}
+		if j == len(path) || path[j] != ':' {
+			res.WriteString(path[start:j])
+			start = j
+			continue
+		}
+		name := path[i+1 : j]
+		if strings.HasPrefix(name, "*") {
+			return "", nil, fmt.Errorf("catch-all wildcard %q of path %q cannot define a constraint", name[1:], path)
+		}
+		depth := 1
+		k := j + 1
+		for ; k < len(path); k++ {
+			if path[k] == '{' {
+				depth++
+			} else if path[k] == '}' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if k == len(path) {
+			return "", nil, fmt.Errorf("unbalanced braces in constraint of wildcard %q of path %q", name, path)
+		}
+		if path[j+1:k] == "" {
+			return "", nil, fmt.Errorf("empty constraint for wildcard %q of path %q", name, path)
+		}
+		if cons == nil {
+			cons = make(map[string]string)
+		}
+		cons[name] = path[j+1 : k]
+		res.WriteString(path[start:i])
+		res.WriteString("{" + name + "}")
+		start = k + 1
+	}
+	res.WriteString(path[start:])
+	return res.String(), cons, nil
+}
+
+// HTTPParamStyle returns the serialization style and explode flag of the
+// given query string parameter or header as set via the Style and Explode
+// DSLs. The style is empty if not set and explode defaults to true for the
+// form style and to false otherwise.
+func HTTPParamStyle(att *AttributeExpr) (style string, explode bool) {
+	if v, ok := att.Meta["http:style"]; ok && len(v) > 0 {
+		style = v[0]
+	}
+	explode = style == "" || style == HTTPStyleForm || style == HTTPStyleDeepObject
+	if v, ok := att.Meta["http:explode"]; ok && len(v) > 0 {
+		explode = v[0] == "true"
+	}
+	return
+}
+
+// HTTPParamDelimiter returns the delimiter that separates the values of the
+// given array query string parameter or header when they are serialized in a
+// single value as set via the Separator, Style and Explode DSLs, the empty
+// string if each value is serialized as a separate parameter or header.
+func HTTPParamDelimiter(att *AttributeExpr) string {
+	if !IsArray(att.Type) {
+		return ""
+	}
+	if v, ok := att.Meta["http:separator"]; ok && len(v) > 0 {
+		return v[0]
+	}
+	style, explode := HTTPParamStyle(att)
+	switch style {
+	case HTTPStyleSimple:
+		return ","
+	case HTTPStyleSpaceDelimited:
+		if !explode {
+			return " "
+		}
+	case HTTPStylePipeDelimited:
+		if !explode {
+			return "|"
+		}
+	case "", HTTPStyleForm:
+		if !explode {
+			return ","
+		}
+	}
+	return ""
+}
+
+// anchoredPattern returns the regular expression of a path parameter
+// constraint anchored at both ends so that it matches the entire value the
+// same way routers match path segments.
+func anchoredPattern(re string) string {
+	if strings.Contains(re, "|") {
+		re = "(?:" + re + ")"
+	}
+	if !strings.HasPrefix(re, "^") {
+		re = "^" + re
+	}
+	if !strings.HasSuffix(re, "$") {
+		re += "$"
+	}
+	return re
+}
+
 // Service returns the service with the given name if any.
 func (h *HTTPExpr) Service(name string) *HTTPServiceExpr {
 	for _, res := range h.Services {
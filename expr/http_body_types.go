@@ -87,6 +87,7 @@ func httpRequestBody(a *HTTPEndpointExpr) *AttributeExpr {
 		TypeName:      name,
 	}
 	appendSuffix(ut.Attribute().Type, suffix)
+	inheritXMLRoot(ut.AttributeExpr, payload)
 
 	return &AttributeExpr{
 		Type:         ut,
@@ -119,6 +120,30 @@ func httpStreamingBody(e *HTTPEndpointExpr) *AttributeExpr {
 	}
 }
 
+// webhookBody returns an attribute representing the HTTP request body of the
+// given webhook.
+func webhookBody(w *WebhookExpr) *AttributeExpr {
+	att := w.Payload
+	if !IsObject(att.Type) {
+		return DupAtt(att)
+	}
+	const suffix = "WebhookBody"
+	ut := &UserTypeExpr{
+		AttributeExpr: DupAtt(att),
+		TypeName:      concat(w.Name, "Webhook", "Body"),
+	}
+	if u, ok := att.Type.(UserType); ok {
+		ut.AttributeExpr = DupAtt(u.Attribute())
+	}
+	appendSuffix(ut.Attribute().Type, suffix)
+
+	return &AttributeExpr{
+		Type:         ut,
+		Validation:   att.Validation,
+		UserExamples: att.UserExamples,
+	}
+}
+
 // httpResponseBody returns an attribute representing the HTTP response body for
 // the given endpoint and response. If the DSL defines a body explicitly via the
 // Body function then the corresponding attribute is used. Otherwise the
@@ -181,6 +206,7 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 		TypeName:      name,
 	}
 	appendSuffix(userType.Attribute().Type, suffix)
+	inheritXMLRoot(userType.AttributeExpr, attr)
 	rt, isrt := attr.Type.(*ResultTypeExpr)
 	if !isrt {
 		return &AttributeExpr{
@@ -285,6 +311,32 @@ func renameType(att *AttributeExpr, name, suffix string) {
 	}
 }
 
+// inheritXMLRoot copies the XML root element settings defined on the type of
+// from to the attribute of the computed body type so that the body serializes
+// to the same XML root element. The settings defined on body take precedence.
+func inheritXMLRoot(body, from *AttributeExpr) {
+	ut, ok := from.Type.(UserType)
+	if !ok {
+		return
+	}
+	meta := make(MetaExpr, len(body.Meta))
+	for k, v := range body.Meta {
+		meta[k] = v
+	}
+	inherited := false
+	for _, key := range []string{"xml:name", "xml:namespace", "xml:prefix"} {
+		if v, ok := ut.Attribute().Meta[key]; ok {
+			if _, ok := meta[key]; !ok {
+				meta[key] = v
+				inherited = true
+			}
+		}
+	}
+	if inherited {
+		body.Meta = meta
+	}
+}
+
 func appendSuffix(dt DataType, suffix string, seen ...map[string]struct{}) {
 	var s map[string]struct{}
 	if len(seen) > 0 {
@@ -303,11 +355,15 @@ func appendSuffix(dt DataType, suffix string, seen ...map[string]struct{}) {
 		appendSuffix(actual.Attribute().Type, suffix, seen...)
 	case *Object:
 		for _, nat := range *actual {
+			nat.Attribute = InlineEnumConstType(nat.Attribute)
 			appendSuffix(nat.Attribute.Type, suffix, seen...)
 		}
 	case *Array:
+		actual.ElemType = InlineEnumConstType(actual.ElemType)
 		appendSuffix(actual.ElemType.Type, suffix, seen...)
 	case *Map:
+		actual.KeyType = InlineEnumConstType(actual.KeyType)
+		actual.ElemType = InlineEnumConstType(actual.ElemType)
 		appendSuffix(actual.KeyType.Type, suffix, seen...)
 		appendSuffix(actual.ElemType.Type, suffix, seen...)
 	}
@@ -324,6 +380,7 @@ func removeAttribute(attr *MappedAttributeExpr, name string) {
 	attr.Delete(name)
 	if attr.Validation != nil {
 		attr.Validation.RemoveRequired(name)
+		attr.Validation.RemoveFieldFromGroups(name)
 	}
 	for _, ex := range attr.UserExamples {
 		if m, ok := ex.Value.(map[string]interface{}); ok {
@@ -0,0 +1,122 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPCallbackExpr describes an out-of-band POST request sent by the
+	// service back to the client in response to a call to the parent
+	// endpoint. The URL of the callback request is computed from the
+	// endpoint request or response using a runtime expression, see
+	// https://github.com/OAI/OpenAPI-Specification/blob/master/versions/3.0.3.md#runtime-expressions
+	HTTPCallbackExpr struct {
+		// DSLFunc contains the DSL used to initialize the expression.
+		eval.DSLFunc
+		// Name of callback.
+		Name string
+		// URL is the callback URL runtime expression.
+		URL string
+		// Description of callback for consumption by humans.
+		Description string
+		// Payload describes the callback request body.
+		Payload *AttributeExpr
+		// Responses lists the responses expected by the service.
+		Responses []*HTTPResponseExpr
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
+		Meta MetaExpr
+	}
+)
+
+// runtimeExprRegex matches the OpenAPI runtime expressions allowed in callback
+// URLs.
+var runtimeExprRegex = regexp.MustCompile(`^\$(url|method|statuscode|(request|response)\.(header\.[A-Za-z0-9!#$%&'*+.^_` + "`" + `|~-]+|query\.[^{}]+|path\.[^{}]+|body(#(/[^{}]*)?)?))$`)
+
+// EvalName returns the generic expression name used in error messages.
+func (c *HTTPCallbackExpr) EvalName() string {
+	var prefix, suffix string
+	if c.Name != "" {
+		suffix = fmt.Sprintf("callback %#v", c.Name)
+	} else {
+		suffix = "unnamed callback"
+	}
+	if c.Endpoint != nil {
+		prefix = c.Endpoint.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Prepare initializes the callback payload and responses.
+func (c *HTTPCallbackExpr) Prepare() {
+	if c.Payload == nil {
+		c.Payload = &AttributeExpr{Type: Empty}
+	}
+	if len(c.Responses) == 0 {
+		c.Responses = []*HTTPResponseExpr{{StatusCode: StatusOK, Parent: c}}
+	}
+	for _, r := range c.Responses {
+		r.Prepare()
+	}
+}
+
+// Validate makes sure the callback URL is a valid runtime expression and that
+// the callback name is unique in the endpoint.
+func (c *HTTPCallbackExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if c.URL == "" {
+		verr.Add(c, "callback URL cannot be empty")
+	} else {
+		for _, e := range CallbackRuntimeExpressions(c.URL) {
+			if !runtimeExprRegex.MatchString(e) {
+				verr.Add(c, "invalid runtime expression %q in callback URL %q", e, c.URL)
+			}
+		}
+		if strings.Count(c.URL, "{") != strings.Count(c.URL, "}") {
+			verr.Add(c, "callback URL %q contains unbalanced braces", c.URL)
+		}
+	}
+	if c.Payload.Type != Empty {
+		verr.Merge(c.Payload.Validate("payload", c))
+	}
+	for _, o := range c.Endpoint.Callbacks {
+		if o != c && o.Name == c.Name {
+			verr.Add(c, "callback %q is defined multiple times", c.Name)
+			break
+		}
+	}
+	return verr
+}
+
+// Finalize finalizes the callback payload.
+func (c *HTTPCallbackExpr) Finalize() {
+	c.Payload.Finalize()
+}
+
+// CallbackRuntimeExpressions returns the runtime expressions embedded in the
+// given callback URL. The URL may consist of a single runtime expression (e.g.
+// "$request.body#/url") or embed runtime expressions in curly braces (e.g.
+// "http://example.com?id={$request.path.id}").
+func CallbackRuntimeExpressions(url string) []string {
+	if strings.HasPrefix(url, "$") {
+		return []string{url}
+	}
+	var exprs []string
+	for {
+		start := strings.Index(url, "{")
+		if start < 0 {
+			return exprs
+		}
+		end := strings.Index(url[start:], "}")
+		if end < 0 {
+			return exprs
+		}
+		exprs = append(exprs, url[start+1:start+end])
+		url = url[start+end+1:]
+	}
+}
@@ -0,0 +1,42 @@
+package expr
+
+import "goa.design/goa/v3/eval"
+
+type (
+	// HTTPCanaryExpr describes the requests made to an endpoint that are
+	// served by the canary implementation of the endpoint.
+	HTTPCanaryExpr struct {
+		// Percent is the percentage of the requests routed to the canary,
+		// between 0 and 100.
+		Percent float64
+		// Header is the name of the header that routes the requests that
+		// set it to the canary, empty if none.
+		Header string
+		// Value is the value the header must have, any value matches if
+		// empty.
+		Value string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (c *HTTPCanaryExpr) EvalName() string {
+	if c.Endpoint != nil {
+		return c.Endpoint.EvalName() + " canary"
+	}
+	return "canary"
+}
+
+// Validate makes sure the percentage is valid and that the canary receives
+// requests.
+func (c *HTTPCanaryExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if c.Percent < 0 || c.Percent > 100 {
+		verr.Add(c, "Canary percentage must be between 0 and 100, got %v", c.Percent)
+	}
+	if c.Percent == 0 && c.Header == "" {
+		verr.Add(c, "Canary must route a percentage of the requests or the requests that set a header")
+	}
+	return verr
+}
@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"mime"
 	"path"
 	"strings"
 
@@ -54,6 +55,54 @@ type (
 		// MultipartRequest indicates that the request content type for
 		// the endpoint is a multipart type.
 		MultipartRequest bool
+		// RequestContents lists the request bodies used by clients
+		// that send requests with specific content types, the other
+		// requests use Body.
+		RequestContents []*HTTPRequestContentExpr
+		// ViewParam is the name of the query string parameter used by
+		// clients to select the view used to render the result, empty if
+		// clients can't select the view.
+		ViewParam string
+		// NDJSON indicates that the streaming results are written to the
+		// HTTP response as newline delimited JSON instead of being sent
+		// through a websocket connection.
+		NDJSON bool
+		// Export describes how the streaming results are written to the
+		// HTTP response as the rows of a file, nil if the endpoint does
+		// not export files.
+		Export *HTTPExportExpr
+		// Version is the API version implemented by the endpoint, empty
+		// if the endpoint is not versioned. The endpoints of a service
+		// that share a route are served by a single handler that
+		// dispatches the requests using the version header.
+		Version string
+		// Callbacks lists the out-of-band requests sent by the service
+		// in response to requests made to the endpoint.
+		Callbacks []*HTTPCallbackExpr
+		// MaxRequestBody is the maximum size in bytes of the request
+		// body, zero if not set.
+		MaxRequestBody int64
+		// Compression lists the content codings used to compress the
+		// endpoint responses in order of preference, nil if not set.
+		Compression []string
+		// RequestCompression lists the content codings of the
+		// compressed request bodies accepted by the endpoint, nil if not
+		// set.
+		RequestCompression []string
+		// NoCompression is true if the endpoint responses must not be
+		// compressed regardless of the API and service settings.
+		NoCompression bool
+		// Canary describes the requests served by the canary
+		// implementation of the endpoint, nil if the endpoint does not
+		// have a canary.
+		Canary *HTTPCanaryExpr
+		// SignedURL is true if the requests made to the endpoint must
+		// use a time-limited signed URL.
+		SignedURL bool
+		// PassThroughHeaders lists the canonical names of the headers
+		// of the upstream responses written to the endpoint responses,
+		// nil if not set.
+		PassThroughHeaders []string
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -65,6 +114,10 @@ type (
 		Method string
 		// Path is the URL path e.g. "/tasks/{id}"
 		Path string
+		// Constraints maps the names of the path parameters constrained
+		// inline in the route path, e.g. "/tasks/{id:[0-9]+}", to the
+		// regular expressions the parameters must match.
+		Constraints map[string]string
 		// Endpoint is the endpoint this route applies to.
 		Endpoint *HTTPEndpointExpr
 		// Meta is an arbitrary set of key/value pairs, see
@@ -83,6 +136,13 @@ func (e *HTTPEndpointExpr) Description() string {
 	return e.MethodExpr.Description
 }
 
+// StreamsResponse returns true if the streaming results are written to the HTTP
+// response (see NDJSON and Export) instead of being sent through a websocket
+// connection.
+func (e *HTTPEndpointExpr) StreamsResponse() bool {
+	return e.NDJSON || e.Export != nil
+}
+
 // EvalName returns the generic expression name used in error messages.
 func (e *HTTPEndpointExpr) EvalName() string {
 	var prefix, suffix string
@@ -97,6 +157,117 @@ func (e *HTTPEndpointExpr) EvalName() string {
 	return prefix + suffix
 }
 
+// VersionHeader returns the name of the request header used to select the API
+// version served by the endpoint. The header set in the service HTTP expression
+// takes precedence over the header set in the API HTTP expression, the default
+// is "X-API-Version".
+func (e *HTTPEndpointExpr) VersionHeader() string {
+	if e.Service != nil && e.Service.VersionHeader != "" {
+		return e.Service.VersionHeader
+	}
+	if Root != nil && Root.API != nil && Root.API.HTTP != nil && Root.API.HTTP.VersionHeader != "" {
+		return Root.API.HTTP.VersionHeader
+	}
+	return "X-API-Version"
+}
+
+// RequestBodyLimit returns the maximum size in bytes of the endpoint request
+// body set with the MaxRequestBody DSL on the endpoint, its service or the API,
+// zero if the size is not limited. The size set on the endpoint overrides the
+// size set on the service which overrides the size set on the API.
+func (e *HTTPEndpointExpr) RequestBodyLimit() int64 {
+	if e.MaxRequestBody > 0 {
+		return e.MaxRequestBody
+	}
+	if e.Service != nil && e.Service.MaxRequestBody > 0 {
+		return e.Service.MaxRequestBody
+	}
+	if Root != nil && Root.API != nil && Root.API.HTTP != nil {
+		return Root.API.HTTP.MaxRequestBody
+	}
+	return 0
+}
+
+// ResponseCompression returns the content codings used to compress the
+// endpoint responses in order of preference as set with the Compression DSL on
+// the endpoint, its service or the API, nil if the responses are not
+// compressed. The codings set on the endpoint override the codings set on the
+// service which override the codings set on the API.
+func (e *HTTPEndpointExpr) ResponseCompression() []string {
+	if e.NoCompression {
+		return nil
+	}
+	if e.Compression != nil {
+		return e.Compression
+	}
+	if e.Service != nil && e.Service.Compression != nil {
+		return e.Service.Compression
+	}
+	if Root != nil && Root.API != nil && Root.API.HTTP != nil {
+		return Root.API.HTTP.Compression
+	}
+	return nil
+}
+
+// RequestEncodings returns the content codings of the compressed request
+// bodies accepted by the endpoint as set with the RequestCompression DSL on the
+// endpoint, its service or the API, nil if the endpoint does not accept
+// compressed request bodies. The codings set on the endpoint override the
+// codings set on the service which override the codings set on the API.
+func (e *HTTPEndpointExpr) RequestEncodings() []string {
+	if e.RequestCompression != nil {
+		return e.RequestCompression
+	}
+	if e.Service != nil && e.Service.RequestCompression != nil {
+		return e.Service.RequestCompression
+	}
+	if Root != nil && Root.API != nil && Root.API.HTTP != nil {
+		return Root.API.HTTP.RequestCompression
+	}
+	return nil
+}
+
+// TrailingSlashPolicy returns the policy applied to the requests whose path
+// differs from the path of an endpoint route by a trailing slash as set with
+// the TrailingSlash DSL on the endpoint service or on the API, empty if not
+// set.
+func (e *HTTPEndpointExpr) TrailingSlashPolicy() string {
+	if e.Service != nil && e.Service.TrailingSlash != "" {
+		return e.Service.TrailingSlash
+	}
+	if Root != nil && Root.API != nil && Root.API.HTTP != nil {
+		return Root.API.HTTP.TrailingSlash
+	}
+	return ""
+}
+
+// PhasesTraced returns true if the TracePhases DSL is used on the endpoint
+// service or on the API.
+func (e *HTTPEndpointExpr) PhasesTraced() bool {
+	if e.Service != nil && e.Service.TracePhases {
+		return true
+	}
+	return Root != nil && Root.API != nil && Root.API.HTTP != nil && Root.API.HTTP.TracePhases
+}
+
+// PassedThroughHeaders returns the canonical names of the headers of the
+// upstream responses written to the endpoint responses as set with the
+// PassThroughHeaders DSL on the endpoint, its service or the API, nil if not
+// set. The headers set on the endpoint override the headers set on the service
+// which override the headers set on the API.
+func (e *HTTPEndpointExpr) PassedThroughHeaders() []string {
+	if e.PassThroughHeaders != nil {
+		return e.PassThroughHeaders
+	}
+	if e.Service != nil && e.Service.PassThroughHeaders != nil {
+		return e.Service.PassThroughHeaders
+	}
+	if Root != nil && Root.API != nil && Root.API.HTTP != nil {
+		return Root.API.HTTP.PassThroughHeaders
+	}
+	return nil
+}
+
 // HasAbsoluteRoutes returns true if all the endpoint routes are absolute.
 func (e *HTTPEndpointExpr) HasAbsoluteRoutes() bool {
 	for _, r := range e.Routes {
@@ -160,6 +331,46 @@ func (e *HTTPEndpointExpr) QueryParams() *MappedAttributeExpr {
 	return NewMappedAttributeExpr(at)
 }
 
+// addAliasRoutes adds a route for each route of a renamed method whose path
+// contains the method name as a segment and for each previous name of the
+// method. The path of the added route uses the previous name in place of the
+// method name.
+func (e *HTTPEndpointExpr) addAliasRoutes() {
+	olds := e.MethodExpr.RenamedFrom()
+	if len(olds) == 0 {
+		return
+	}
+	var aliases []*RouteExpr
+	for _, r := range e.Routes {
+		if r.IsAlias() {
+			return
+		}
+		segs := strings.Split(r.Path, "/")
+		for _, old := range olds {
+			found := false
+			asegs := make([]string, len(segs))
+			for i, s := range segs {
+				if s == e.MethodExpr.Name {
+					s = old
+					found = true
+				}
+				asegs[i] = s
+			}
+			if !found || old == "" {
+				continue
+			}
+			aliases = append(aliases, &RouteExpr{
+				Method:      r.Method,
+				Path:        strings.Join(asegs, "/"),
+				Constraints: r.Constraints,
+				Endpoint:    e,
+				Meta:        MetaExpr{"goa:alias": []string{old}},
+			})
+		}
+	}
+	e.Routes = append(e.Routes, aliases...)
+}
+
 // Prepare computes the request path and query string parameters as well as the
 // headers and body taking into account the inherited values from the service.
 func (e *HTTPEndpointExpr) Prepare() {
@@ -186,6 +397,19 @@ func (e *HTTPEndpointExpr) Prepare() {
 	e.Headers = headers
 	e.Params = params
 
+	// Inherit the view parameter from the parent service and API
+	if e.ViewParam == "" {
+		name := Root.API.HTTP.ViewParam
+		if e.Service.ViewParam != "" {
+			name = e.Service.ViewParam
+		}
+		if name != "" && e.hasSelectableViews(name) {
+			e.ViewParam = name
+		}
+	}
+
+	e.addAliasRoutes()
+
 	// Initialize path params that are not defined explicitly in
 	for _, r := range e.Routes {
 		for _, p := range r.Params() {
@@ -205,9 +429,12 @@ func (e *HTTPEndpointExpr) Prepare() {
 	// Make sure there's a default response if none define explicitly
 	if len(e.Responses) == 0 {
 		status := StatusOK
-		if e.MethodExpr.Payload.Type == Empty {
+		if e.MethodExpr.Payload.Type == Empty && !e.StreamsResponse() {
 			status = StatusNoContent
 		}
+		if IsMultiStatus(e.MethodExpr.Result.Type) {
+			status = StatusMultiStatus
+		}
 		e.Responses = []*HTTPResponseExpr{{StatusCode: status}}
 	}
 
@@ -223,6 +450,61 @@ func (e *HTTPEndpointExpr) Prepare() {
 	for _, er := range e.HTTPErrors {
 		er.Response.Prepare()
 	}
+
+	// Prepare callbacks
+	for _, c := range e.Callbacks {
+		c.Prepare()
+	}
+
+	// Wrap the optional body fields prior to the body types being computed
+	// so that all the generated types use the wrappers. Merge patch payloads
+	// always use the wrappers so that null clears the patched fields.
+	optional := e.MethodExpr.UsesOptionalFields() || PatchedType(e.MethodExpr.Payload.Type) != nil
+	if optional && e.Body == nil {
+		e.markOptionalFields()
+	}
+}
+
+// markOptionalFields flags the payload attributes mapped to the request body
+// that are stored in goa.Optional wrappers, see the OptionalFields and PatchOf
+// DSLs.
+func (e *HTTPEndpointExpr) markOptionalFields() {
+	payload := e.MethodExpr.Payload
+	obj := AsObject(payload.Type)
+	if obj == nil {
+		return
+	}
+	for _, nat := range *obj {
+		att := nat.Attribute
+		if payload.IsRequired(nat.Name) || att.DefaultValue != nil {
+			continue
+		}
+		switch att.Type.Kind() {
+		case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind,
+			UInt64Kind, Float32Kind, Float64Kind, StringKind:
+		default:
+			continue
+		}
+		if _, ok := att.Meta["struct:field:type"]; ok {
+			continue
+		}
+		if _, ok := att.Meta["security:username"]; ok {
+			continue
+		}
+		if _, ok := att.Meta["security:password"]; ok {
+			continue
+		}
+		if e.Headers.Find(nat.Name) != nil || e.Params.Find(nat.Name) != nil {
+			continue
+		}
+		if e.MapQueryParams != nil && *e.MapQueryParams == nat.Name {
+			continue
+		}
+		if att.Meta == nil {
+			att.Meta = make(MetaExpr)
+		}
+		att.Meta["goa:optional"] = nil
+	}
 }
 
 // Validate validates the endpoint expression.
@@ -241,6 +523,13 @@ func (e *HTTPEndpointExpr) Validate() error {
 	} else {
 		for _, r := range e.Routes {
 			verr.Merge(r.Validate())
+			if r.Method == "GET" && Root.API != nil && Root.API.HealthCheck {
+				for _, p := range r.FullPaths() {
+					if p == "/healthz" || p == "/readyz" {
+						verr.Add(e, "route GET %s conflicts with the health check endpoints", p)
+					}
+				}
+			}
 		}
 		// Make sure that the same parameters are used in all routes
 		params := e.Routes[0].Params()
@@ -272,6 +561,42 @@ func (e *HTTPEndpointExpr) Validate() error {
 		}
 	}
 
+	if e.NDJSON && e.MethodExpr.Stream != ServerStreamKind {
+		verr.Add(e, "NDJSON requires the method to define a streaming result and no streaming payload")
+	}
+	if e.NDJSON {
+		for _, r := range e.Responses {
+			if r.StatusCode < 400 && !bodyAllowedForStatus(r.StatusCode) {
+				verr.Add(r, "NDJSON endpoint response with status code %d cannot have a body", r.StatusCode)
+			}
+		}
+	}
+	if e.Export != nil {
+		verr.Merge(e.Export.Validate())
+	}
+	if e.Canary != nil {
+		verr.Merge(e.Canary.Validate())
+	}
+	if e.NoCompression && e.Compression != nil {
+		verr.Add(e, "HTTP endpoint defines both Compression and NoCompression. At most one of these must be defined.")
+	}
+	if e.SignedURL {
+		for _, r := range e.Routes {
+			if r.Method != "GET" && r.Method != "HEAD" {
+				verr.Add(r, "SignedURL requires GET or HEAD routes, got %s", r.Method)
+			}
+		}
+		qparams := e.QueryParams()
+		for _, nat := range *AsObject(qparams.Type) {
+			if n := qparams.ElemName(nat.Name); n == "expires" || n == "signature" {
+				verr.Add(e, "query string parameter %q is reserved for the URL signature (SignedURL is set)", n)
+			}
+		}
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "SignedURL cannot be used on streaming endpoints")
+		}
+	}
+
 	// Validate responses
 
 	// All responses but one must have tags for the same status code
@@ -311,6 +636,7 @@ func (e *HTTPEndpointExpr) Validate() error {
 	// Make sure parameters and headers use compatible types
 	verr.Merge(e.validateParams())
 	verr.Merge(e.validateHeaders())
+	verr.Merge(e.validateEnumConstMappings())
 
 	// Validate body attribute (required fields exist etc.)
 	if e.Body != nil {
@@ -321,6 +647,54 @@ func (e *HTTPEndpointExpr) Validate() error {
 	for _, er := range e.HTTPErrors {
 		verr.Merge(er.Validate())
 	}
+	if Root.API != nil && Root.API.HTTP.StrictStatusCodes {
+		for _, name := range e.unmappedErrors() {
+			verr.Add(e, "error %q is not mapped to a HTTP response, use Response to map it (StrictStatusCodes is set)", name)
+		}
+	}
+
+	// Validate callbacks
+	for _, c := range e.Callbacks {
+		verr.Merge(c.Validate())
+	}
+
+	// Validate request contents
+	if len(e.RequestContents) > 0 {
+		if e.MultipartRequest {
+			verr.Add(e, "HTTP endpoint defines MultipartRequest and RequestContent. At most one of these must be defined.")
+		}
+		if e.MethodExpr.IsStreaming() && !e.StreamsResponse() {
+			verr.Add(e, "RequestContent cannot be used with streaming endpoints.")
+		}
+		seen := make(map[string]struct{})
+		for _, c := range e.RequestContents {
+			verr.Merge(c.Validate())
+			mt, _, err := mime.ParseMediaType(c.ContentType)
+			if err != nil {
+				continue
+			}
+			if _, ok := seen[mt]; ok {
+				verr.Add(e, "RequestContent %q is defined more than once.", mt)
+			}
+			seen[mt] = struct{}{}
+		}
+	}
+
+	if e.ViewParam != "" {
+		verr.Merge(e.validateViewParam())
+	}
+
+	if e.MethodExpr.HasFieldMask() {
+		for _, nat := range *AsObject(e.Params.Type) {
+			if e.Params.ElemName(nat.Name) == "fields" {
+				verr.Add(e, "FieldMask uses the \"fields\" query string parameter which conflicts with the parameter of the same name")
+			}
+		}
+	}
+
+	if field := TaggedAttribute(e.MethodExpr.Result, "http:etag"); field != "" {
+		verr.Merge(e.validateETag(field))
+	}
 
 	// Validate definitions of params, headers and bodies against definition of payload
 	if isEmpty(e.MethodExpr.Payload) {
@@ -330,6 +704,9 @@ func (e *HTTPEndpointExpr) Validate() error {
 		if e.MultipartRequest {
 			verr.Add(e, "MultipartRequest is set but Payload is not defined")
 		}
+		if len(e.RequestContents) > 0 {
+			verr.Add(e, "RequestContent is set but Payload is not defined")
+		}
 		if !e.Params.IsEmpty() {
 			verr.Add(e, "Params are set but Payload is not defined.")
 		}
@@ -382,15 +759,7 @@ func (e *HTTPEndpointExpr) Validate() error {
 			if *e.MapQueryParams != "" {
 				verr.Add(e, "MapParams is set to an attribute in the Payload but Payload is a map. Payload must be an object with an attribute of map type")
 			}
-			if !IsPrimitive(pMap.KeyType.Type) {
-				verr.Add(e, "MapParams is set and Payload type is map. But payload key type must be a primitive")
-			}
-			if !IsPrimitive(pMap.ElemType.Type) && !IsArray(pMap.ElemType.Type) {
-				verr.Add(e, "MapParams is set and Payload type is map. But payload element type must be a primitive or array")
-			}
-			if IsArray(pMap.ElemType.Type) && !IsPrimitive(AsArray(pMap.ElemType.Type).ElemType.Type) {
-				verr.Add(e, "MapParams is set and Payload type is map. But array elements in payload element type must be primitive")
-			}
+			verr.Merge(e.validateMapQueryParams("Payload type is map", pMap))
 		}
 		var hasParams bool
 		if !e.Params.IsEmpty() {
@@ -416,8 +785,10 @@ func (e *HTTPEndpointExpr) Validate() error {
 		if e.MapQueryParams != nil {
 			if pAttr := *e.MapQueryParams; pAttr == "" {
 				verr.Add(e, "MapParams is set to map entire payload but payload is an object. Payload must be a map.")
-			} else if e.MethodExpr.Payload.Find(pAttr) == nil {
+			} else if att := e.MethodExpr.Payload.Find(pAttr); att == nil || !IsMap(att.Type) {
 				verr.Add(e, "MapParams is set to an attribute in Payload. But payload has no attribute with type map and name %s", pAttr)
+			} else {
+				verr.Merge(e.validateMapQueryParams(fmt.Sprintf("payload attribute %s is a map", pAttr), AsMap(att.Type)))
 			}
 		}
 		if e.Body != nil {
@@ -491,11 +862,46 @@ func (e *HTTPEndpointExpr) Finalize() {
 		}
 	}
 
+	// Map the idempotency key implicitly defined via the Idempotent DSL to
+	// the Idempotency-Key header unless the design maps it explicitly.
+	if e.MethodExpr.IsIdempotent() {
+		if field := TaggedAttribute(e.MethodExpr.Payload, "goa:idempotency-key"); field != "" {
+			if name, _ := findKey(e, field); name == "" {
+				e.Headers.Type.(*Object).Set(field, e.MethodExpr.Payload.Find(field))
+				e.Headers.Map("Idempotency-Key", field)
+				if e.MethodExpr.Payload.IsRequired(field) {
+					if e.Headers.Validation == nil {
+						e.Headers.Validation = &ValidationExpr{}
+					}
+					e.Headers.Validation.AddRequired(field)
+				}
+			}
+		}
+	}
+
 	// Initialize the HTTP specific attributes with the corresponding
 	// payload attributes.
 	initAttr(e.Params, e.MethodExpr.Payload)
 	initAttr(e.Headers, e.MethodExpr.Payload)
 
+	// Validate the path parameters constrained inline in the routes with
+	// the anchored constraint patterns. The constraints override the
+	// patterns inherited from the payload.
+	for _, r := range e.Routes {
+		for name, re := range r.Constraints {
+			att := AsObject(e.Params.Type).Attribute(e.Params.KeyName(name))
+			if att == nil || att.Type != String {
+				continue
+			}
+			if att.Validation == nil {
+				att.Validation = &ValidationExpr{}
+			} else {
+				att.Validation = att.Validation.Dup()
+			}
+			att.Validation.Pattern = anchoredPattern(re)
+		}
+	}
+
 	if e.Body != nil {
 		// rename type to add RequestBody suffix so that we don't end with
 		// duplicate type definitions - https://github.com/goadesign/goa/issues/1969
@@ -512,6 +918,22 @@ func (e *HTTPEndpointExpr) Finalize() {
 
 	e.StreamingBody = httpStreamingBody(e)
 
+	for _, c := range e.RequestContents {
+		c.Finalize()
+	}
+
+	// Map the entity tag defined via the ETag DSL to the ETag header of the
+	// success responses unless the design maps it explicitly.
+	if field := TaggedAttribute(e.MethodExpr.Result, "http:etag"); field != "" {
+		for _, r := range e.Responses {
+			if _, ok := r.Headers.FindKey(field); ok {
+				continue
+			}
+			r.Headers.Type.(*Object).Set(field, e.MethodExpr.Result.Find(field))
+			r.Headers.Map("ETag", field)
+		}
+	}
+
 	// Initialize responses parent, headers and body
 	for _, r := range e.Responses {
 		r.Finalize(e, e.MethodExpr.Result)
@@ -543,6 +965,10 @@ func (e *HTTPEndpointExpr) Finalize() {
 	for _, herr := range e.HTTPErrors {
 		herr.Finalize(e)
 	}
+
+	for _, c := range e.Callbacks {
+		c.Finalize()
+	}
 }
 
 // validateParams checks the endpoint parameters are of an allowed type and the
@@ -579,6 +1005,12 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("path parameter %s", name)
 			verr.Merge(a.Validate(ctx, e))
 		}
+		for _, key := range []string{"http:style", "http:explode", "http:separator"} {
+			if _, ok := a.Meta[key]; ok {
+				verr.Add(e, "path parameter %s cannot define a serialization style", name)
+				break
+			}
+		}
 		return nil
 	})
 	WalkMappedAttr(qparams, func(name, _ string, a *AttributeExpr) error {
@@ -594,6 +1026,26 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("query parameter %s", name)
 			verr.Merge(a.Validate(ctx, e))
 		}
+		verr.Merge(e.validateSeparator(fmt.Sprintf("query parameter %s", name), a))
+		switch style, explode := HTTPParamStyle(a); style {
+		case "", HTTPStyleForm:
+			if IsMap(a.Type) && (style != "" || !explode) {
+				verr.Add(e, "map query parameter %s must use the %q style", name, HTTPStyleDeepObject)
+			}
+		case HTTPStyleSpaceDelimited, HTTPStylePipeDelimited:
+			if !IsArray(a.Type) {
+				verr.Add(e, "query parameter %s must be an array to use the %q style", name, style)
+			}
+		case HTTPStyleDeepObject:
+			if !IsMap(a.Type) {
+				verr.Add(e, "query parameter %s must be a map to use the %q style", name, style)
+			}
+			if !explode {
+				verr.Add(e, "query parameter %s cannot disable explode with the %q style", name, style)
+			}
+		default:
+			verr.Add(e, "query parameter %s uses an invalid style %q, style must be one of %q, %q, %q or %q", name, style, HTTPStyleForm, HTTPStyleSpaceDelimited, HTTPStylePipeDelimited, HTTPStyleDeepObject)
+		}
 		return nil
 	})
 	if e.MethodExpr.Payload != nil {
@@ -624,6 +1076,189 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 	return verr
 }
 
+// validateEnumConstMappings makes sure that the attributes mapped to request
+// parameters and headers or to response headers do not use enum constant
+// types: the generated transport code only converts the values held in the
+// request and response bodies to and from the typed constants.
+func (e *HTTPEndpointExpr) validateEnumConstMappings() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	check := func(ma *MappedAttributeExpr, parent *AttributeExpr, kind string) {
+		if ma == nil || parent == nil || ma.IsEmpty() {
+			return
+		}
+		WalkMappedAttr(ma, func(name, _ string, _ *AttributeExpr) error {
+			att := parent.Find(name)
+			if att == nil {
+				return nil
+			}
+			dt := att.Type
+			if arr := AsArray(dt); arr != nil {
+				dt = arr.ElemType.Type
+			} else if m := AsMap(dt); m != nil {
+				dt = m.ElemType.Type
+			}
+			if IsEnumConstType(dt) {
+				verr.Add(e, "%s %q uses the enum constant type %s, enum constant types can only be used in request and response bodies", kind, name, dt.Name())
+			}
+			return nil
+		})
+	}
+	check(e.Params, e.MethodExpr.Payload, "parameter")
+	check(e.Headers, e.MethodExpr.Payload, "header")
+	for _, r := range e.Responses {
+		check(r.Headers, e.MethodExpr.Result, "response header")
+	}
+	for _, er := range e.HTTPErrors {
+		if er.ErrorExpr != nil {
+			check(er.Response.Headers, er.AttributeExpr, "error response header")
+		}
+	}
+	return verr
+}
+
+// validateETag makes sure the entity tag attribute is a string and that the
+// endpoint does not define responses that conflict with the conditional
+// request responses.
+func (e *HTTPEndpointExpr) validateETag(field string) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if att := e.MethodExpr.Result.Find(field); att.Type != String {
+		verr.Add(e, "entity tag attribute %q must be a String", field)
+	}
+	for _, r := range e.Responses {
+		if r.StatusCode == StatusNotModified || r.StatusCode == StatusPreconditionFailed {
+			verr.Add(e, "result defines an entity tag, response with status code %d is reserved for conditional requests", r.StatusCode)
+		}
+	}
+	for _, er := range e.HTTPErrors {
+		if er.Response.StatusCode == StatusNotModified || er.Response.StatusCode == StatusPreconditionFailed {
+			verr.Add(e, "result defines an entity tag, error %q cannot use status code %d reserved for conditional requests", er.Name, er.Response.StatusCode)
+		}
+	}
+	return verr
+}
+
+// hasSelectableViews returns true if clients may select the view used to
+// render the endpoint result with the query string parameter of the given
+// name.
+func (e *HTTPEndpointExpr) hasSelectableViews(name string) bool {
+	if e.MethodExpr.IsStreaming() {
+		return false
+	}
+	rt, ok := e.MethodExpr.Result.Type.(*ResultTypeExpr)
+	if !ok {
+		return false
+	}
+	views := len(rt.Views)
+	if rt.View(DefaultView) == nil {
+		views++
+	}
+	if views < 2 {
+		return false
+	}
+	if _, ok := e.MethodExpr.Result.Meta["view"]; ok {
+		return false
+	}
+	if e.MethodExpr.HasFieldMask() && name == "fields" {
+		return false
+	}
+	for _, nat := range *AsObject(e.Params.Type) {
+		if e.Params.ElemName(nat.Name) == name {
+			return false
+		}
+	}
+	return true
+}
+
+// validateViewParam makes sure the endpoint result can be rendered using
+// different views and that the view parameter does not conflict with another
+// query string parameter.
+func (e *HTTPEndpointExpr) validateViewParam() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(e, "ViewParam cannot be used with streaming endpoints")
+	}
+	rt, ok := e.MethodExpr.Result.Type.(*ResultTypeExpr)
+	if !ok {
+		verr.Add(e, "ViewParam is set but the method result is not a result type")
+		return verr
+	}
+	views := len(rt.Views)
+	if rt.View(DefaultView) == nil {
+		views++
+	}
+	if views < 2 {
+		verr.Add(e, "ViewParam is set but result type %q defines a single view", rt.Name())
+	}
+	if _, ok := e.MethodExpr.Result.Meta["view"]; ok {
+		verr.Add(e, "ViewParam is set but the method result view is set explicitly")
+	}
+	if e.MethodExpr.HasFieldMask() && e.ViewParam == "fields" {
+		verr.Add(e, "ViewParam \"fields\" conflicts with the FieldMask query string parameter")
+	}
+	for _, nat := range *AsObject(e.Params.Type) {
+		if e.Params.ElemName(nat.Name) == e.ViewParam {
+			verr.Add(e, "ViewParam %q conflicts with the parameter of the same name", e.ViewParam)
+		}
+	}
+	return verr
+}
+
+// validateMapQueryParams makes sure the map decoded from the query string with
+// MapParams uses primitive keys and that its values are primitives, arrays of
+// primitives or objects whose attributes are primitives or arrays of
+// primitives. Object values are decoded from deepObject style parameters, e.g.
+// "ranges[1][min]=0".
+func (e *HTTPEndpointExpr) validateMapQueryParams(ctx string, m *Map) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !IsPrimitive(m.KeyType.Type) {
+		verr.Add(e, "MapParams is set and %s. But payload key type must be a primitive", ctx)
+	}
+	elem := m.ElemType.Type
+	switch {
+	case IsPrimitive(elem):
+	case IsArray(elem):
+		if !IsPrimitive(AsArray(elem).ElemType.Type) {
+			verr.Add(e, "MapParams is set and %s. But array elements in payload element type must be primitive", ctx)
+		}
+	case IsObject(elem):
+		for _, nat := range *AsObject(elem) {
+			t := nat.Attribute.Type
+			if IsArray(t) {
+				t = AsArray(t).ElemType.Type
+			}
+			if !IsPrimitive(t) {
+				verr.Add(e, "MapParams is set and %s. But attribute %s of payload element type must be a primitive or an array of primitives", ctx, nat.Name)
+			}
+		}
+	default:
+		verr.Add(e, "MapParams is set and %s. But payload element type must be a primitive, array or object", ctx)
+	}
+	return verr
+}
+
+// validateSeparator makes sure the separator of the given query string
+// parameter or header, if any, is not empty, applies to an array and is not
+// combined with a style.
+func (e *HTTPEndpointExpr) validateSeparator(ctx string, a *AttributeExpr) *eval.ValidationErrors {
+	sep, ok := a.Meta["http:separator"]
+	if !ok {
+		return nil
+	}
+	verr := new(eval.ValidationErrors)
+	if len(sep) == 0 || sep[0] == "" {
+		verr.Add(e, "%s separator cannot be empty", ctx)
+	}
+	if !IsArray(a.Type) {
+		verr.Add(e, "%s must be an array to define a separator", ctx)
+	}
+	_, style := a.Meta["http:style"]
+	_, explode := a.Meta["http:explode"]
+	if style || explode {
+		verr.Add(e, "%s cannot define both a separator and a style or explode", ctx)
+	}
+	return verr
+}
+
 // validateHeaders makes sure headers are of an allowed type and the method
 // payload contains the headers.
 func (e *HTTPEndpointExpr) validateHeaders() *eval.ValidationErrors {
@@ -648,6 +1283,10 @@ func (e *HTTPEndpointExpr) validateHeaders() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("header %q", name)
 			verr.Merge(a.Validate(ctx, e))
 		}
+		verr.Merge(e.validateSeparator(fmt.Sprintf("header %q", name), a))
+		if style, _ := HTTPParamStyle(a); style != "" && style != HTTPStyleSimple {
+			verr.Add(e, "header %q uses an invalid style %q, style must be %q", name, style, HTTPStyleSimple)
+		}
 		return nil
 	})
 	switch e.MethodExpr.Payload.Type.(type) {
@@ -709,6 +1348,13 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 		}
 	}
 
+	// Make sure the constrained params are strings
+	for name := range r.Constraints {
+		if att := r.paramAttribute(name); att != nil && att.Type != String {
+			verr.Add(r, "Route param %q must be a String to be constrained by a pattern, got %s", name, att.Type.Name())
+		}
+	}
+
 	// Make sure there's no duplicate params in absolute route
 	paths := r.FullPaths()
 	for _, path := range paths {
@@ -723,14 +1369,85 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 	}
 
 	// For streaming endpoints, websockets does not support verbs other than GET
-	if r.Endpoint.MethodExpr.IsStreaming() {
+	if r.Endpoint.MethodExpr.IsStreaming() && !r.Endpoint.StreamsResponse() {
 		if r.Method != "GET" {
 			verr.Add(r, "Streaming endpoint supports only \"GET\" method. Got %q.", r.Method)
 		}
 	}
+
+	// Make sure endpoints sharing the route implement different versions
+	for _, e := range r.VersionedEndpoints() {
+		if e == r.Endpoint {
+			break
+		}
+		if e.Version == r.Endpoint.Version {
+			if e.Version == "" {
+				verr.Add(r, "HTTP endpoint %q defines the same route and neither endpoint sets APIVersion", e.Name())
+			} else {
+				verr.Add(r, "HTTP endpoint %q defines the same route for API version %q", e.Name(), e.Version)
+			}
+		}
+	}
 	return verr
 }
 
+// paramAttribute returns the attribute that defines the type of the route
+// parameter with the given name: the payload attribute or the payload itself if
+// it is a primitive, the HTTP parameter if the payload does not define it.
+func (r *RouteExpr) paramAttribute(name string) *AttributeExpr {
+	e := r.Endpoint
+	key := e.Params.KeyName(name)
+	if p := e.MethodExpr.Payload; p != nil && p.Type != Empty {
+		if IsPrimitive(p.Type) {
+			return p
+		}
+		if att := p.Find(key); att != nil {
+			return att
+		}
+	}
+	return e.Params.Find(key)
+}
+
+// VersionedEndpoints returns the service endpoints that define a route with
+// the same method and path as r, including the endpoint of r, when at least
+// one of them implements a specific API version. It returns nil otherwise.
+func (r *RouteExpr) VersionedEndpoints() []*HTTPEndpointExpr {
+	var (
+		res       []*HTTPEndpointExpr
+		versioned bool
+	)
+	for _, e := range r.Endpoint.Service.HTTPEndpoints {
+		for _, rt := range e.Routes {
+			if rt.Method == r.Method && rt.Path == r.Path {
+				res = append(res, e)
+				versioned = versioned || e.Version != ""
+				break
+			}
+		}
+	}
+	if !versioned {
+		return nil
+	}
+	return res
+}
+
+// DefaultVersionEndpoint returns the endpoint that serves the requests made to
+// the route that do not specify an API version: the endpoint sharing the route
+// that does not set a version if any, the last endpoint declared otherwise. It
+// returns nil if the route is not versioned.
+func (r *RouteExpr) DefaultVersionEndpoint() *HTTPEndpointExpr {
+	eps := r.VersionedEndpoints()
+	if len(eps) == 0 {
+		return nil
+	}
+	for _, e := range eps {
+		if e.Version == "" {
+			return e
+		}
+	}
+	return eps[len(eps)-1]
+}
+
 // Params returns all the route parameters across all the base paths. For
 // example for the route "GET /foo/{fooID:foo_id}" Params returns
 // []string{"fooID:foo_id"}.
@@ -769,6 +1486,13 @@ func (r *RouteExpr) FullPaths() []string {
 	return res
 }
 
+// IsAlias returns true if the route serves a renamed method under the path that
+// uses one of its previous names, see the RenamedFrom DSL.
+func (r *RouteExpr) IsAlias() bool {
+	_, ok := r.Meta["goa:alias"]
+	return ok
+}
+
 // IsAbsolute returns true if the endpoint path should not be concatenated to
 // the service and API base paths.
 func (r *RouteExpr) IsAbsolute() bool {
@@ -16,6 +16,10 @@ func TestHTTPRouteValidation(t *testing.T) {
 	}{
 		{"valid", testdata.ValidRouteDSL, ""},
 		{"invalid", testdata.DuplicateWCRouteDSL, `route POST "/{id}" of service "InvalidRoute" HTTP endpoint "Method": Wildcard "id" appears multiple times in full path "/{id}/{id}"`},
+		{"versioned", testdata.VersionedRouteDSL, ""},
+		{"duplicate-version", testdata.DuplicateVersionRouteDSL, `route GET "/" of service "InvalidVersionedRoute" HTTP endpoint "Method": HTTP endpoint "MethodV1" defines the same route for API version "1"`},
+		{"constrained", testdata.ConstrainedRouteDSL, ""},
+		{"constrained-int", testdata.ConstrainedIntRouteDSL, `route GET "/{id}" of service "InvalidConstrainedRoute" HTTP endpoint "Method": Route param "id" must be a String to be constrained by a pattern, got int`},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -31,6 +35,41 @@ func TestHTTPRouteValidation(t *testing.T) {
 	}
 }
 
+func TestHTTPRouteConstraints(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ConstrainedRouteDSL)
+	e := root.API.HTTP.Services[0].HTTPEndpoints[0]
+	if p := e.Params.Find("id").Validation.Pattern; p != "^[0-9]+$" {
+		t.Errorf("got param pattern %q, expected %q", p, "^[0-9]+$")
+	}
+	if p := e.MethodExpr.Payload.Find("id").Validation.Pattern; p != "^[a-z]+$" {
+		t.Errorf("got payload pattern %q, expected %q", p, "^[a-z]+$")
+	}
+}
+
+func TestHTTPParamDelimiter(t *testing.T) {
+	root := expr.RunDSL(t, testdata.EndpointParamStyles)
+	e := root.API.HTTP.Services[0].HTTPEndpoints[0]
+	cases := []struct {
+		Name     string
+		Attr     *expr.AttributeExpr
+		Expected string
+	}{
+		{"pipe-delimited", e.Params.Find("ids"), "|"},
+		{"no-explode", e.Params.Find("names"), ","},
+		{"space-delimited", e.Params.Find("tags"), " "},
+		{"deep-object", e.Params.Find("filter"), ""},
+		{"simple", e.Headers.Find("labels"), ","},
+		{"separator", e.Params.Find("codes"), ";"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if d := expr.HTTPParamDelimiter(c.Attr); d != c.Expected {
+				t.Errorf("got delimiter %q, expected %q", d, c.Expected)
+			}
+		})
+	}
+}
+
 func TestHTTPEndpointValidation(t *testing.T) {
 	cases := map[string]struct {
 		DSL    func()
@@ -60,6 +99,165 @@ func TestHTTPEndpointValidation(t *testing.T) {
 		"endpoint-missing-token-extend": {
 			DSL: testdata.EndpointExtendToken,
 		},
+		"endpoint-view-param": {
+			DSL: testdata.EndpointViewParam,
+		},
+		"endpoint-view-param-single-view": {
+			DSL: testdata.EndpointViewParamSingleView,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": ViewParam is set but result type \"Rt\" defines a single view\nservice \"Service\" HTTP endpoint \"Method\": ViewParam \"view\" conflicts with the parameter of the same name",
+			},
+		},
+		"endpoint-view-param-field-mask": {
+			DSL: testdata.EndpointViewParamFieldMask,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": ViewParam \"fields\" conflicts with the FieldMask query string parameter",
+			},
+		},
+		"endpoint-etag-invalid": {
+			DSL: testdata.EndpointETagInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": entity tag attribute \"version\" must be a String\nservice \"Service\" HTTP endpoint \"Method\": result defines an entity tag, response with status code 412 is reserved for conditional requests",
+			},
+		},
+		"endpoint-enum-const-params": {
+			DSL: testdata.EndpointEnumConstParams,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": parameter \"status\" uses the enum constant type Status, enum constant types can only be used in request and response bodies\nservice \"Service\" HTTP endpoint \"Method\": header \"history\" uses the enum constant type Status, enum constant types can only be used in request and response bodies\nservice \"Service\" HTTP endpoint \"Method\": response header \"status\" uses the enum constant type Status, enum constant types can only be used in request and response bodies",
+			},
+		},
+		"endpoint-callback-invalid": {
+			DSL: testdata.EndpointCallbackInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" callback \"onEvent\": invalid runtime expression \"$foo\" in callback URL \"http://example.com/{$foo}\"\nservice \"Service\" HTTP endpoint \"Method\" callback \"onEvent\": callback \"onEvent\" is defined multiple times\nservice \"Service\" HTTP endpoint \"Method\" callback \"onEvent\": callback \"onEvent\" is defined multiple times",
+			},
+		},
+		"endpoint-ndjson": {
+			DSL: testdata.EndpointNDJSON,
+		},
+		"endpoint-ndjson-no-stream": {
+			DSL: testdata.EndpointNDJSONNoStream,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": NDJSON requires the method to define a streaming result and no streaming payload",
+			},
+		},
+		"endpoint-ndjson-no-content": {
+			DSL: testdata.EndpointNDJSONNoContent,
+			Errors: []string{
+				"HTTP response of service \"Service\" HTTP endpoint \"Method\": NDJSON endpoint response with status code 204 cannot have a body",
+			},
+		},
+		"endpoint-export": {
+			DSL: testdata.EndpointExport,
+		},
+		"endpoint-export-user-types": {
+			DSL: testdata.EndpointExportUserTypes,
+		},
+		"endpoint-export-no-stream": {
+			DSL: testdata.EndpointExportNoStream,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" export: Export requires the method to define a streaming result and no streaming payload",
+			},
+		},
+		"endpoint-export-invalid-row": {
+			DSL: testdata.EndpointExportInvalidRow,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" export: attribute \"tags\" of the streaming result type cannot be exported, exported attributes must be strings, integers, numbers or booleans",
+			},
+		},
+		"endpoint-export-result-type": {
+			DSL: testdata.EndpointExportResultType,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" export: Export requires the streaming result type to be defined with Type, result types are not supported",
+			},
+		},
+		"endpoint-export-invalid-filename": {
+			DSL: testdata.EndpointExportInvalidFilename,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" export: file name \"rows-{month}-{ids}\" uses placeholder \"month\" which is not a payload attribute\nservice \"Service\" HTTP endpoint \"Method\" export: file name \"rows-{month}-{ids}\" uses placeholder \"ids\" which is not a primitive payload attribute",
+			},
+		},
+		"endpoint-export-ndjson": {
+			DSL: testdata.EndpointExportNDJSON,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" export: Export cannot be used together with NDJSON",
+			},
+		},
+		"endpoint-canary": {
+			DSL: testdata.EndpointCanary,
+		},
+		"endpoint-canary-invalid": {
+			DSL: testdata.EndpointCanaryInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" canary: Canary percentage must be between 0 and 100, got 120\nservice \"Service\" HTTP endpoint \"Method2\" canary: Canary must route a percentage of the requests or the requests that set a header",
+			},
+		},
+		"endpoint-idempotent": {
+			DSL: testdata.EndpointIdempotent,
+		},
+		"endpoint-idempotent-primitive-payload": {
+			DSL: testdata.EndpointIdempotentPrimitivePayload,
+			Errors: []string{
+				"service \"Service\" method \"Method\": payload of idempotent method \"Method\" of service \"Service\" must be an object",
+			},
+		},
+		"endpoint-request-content": {
+			DSL: testdata.EndpointRequestContent,
+		},
+		"endpoint-request-content-invalid": {
+			DSL: testdata.EndpointRequestContentInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\" request content \"application/xml; charset=utf-8\": request content must define a body\nservice \"Service\" HTTP endpoint \"Method\": RequestContent \"application/xml\" is defined more than once.",
+			},
+		},
+		"endpoint-health-check-conflict": {
+			DSL: testdata.EndpointHealthCheckConflict,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": route GET /healthz conflicts with the health check endpoints",
+			},
+		},
+		"endpoint-strict-status-codes": {
+			DSL: testdata.EndpointStrictStatusCodes,
+		},
+		"endpoint-signed-url": {
+			DSL: testdata.EndpointSignedURL,
+		},
+		"endpoint-signed-url-invalid": {
+			DSL: testdata.EndpointSignedURLInvalid,
+			Errors: []string{
+				"route POST \"/files/{id}\" of service \"Service\" HTTP endpoint \"Method\": SignedURL requires GET or HEAD routes, got POST\nservice \"Service\" HTTP endpoint \"Method\": query string parameter \"expires\" is reserved for the URL signature (SignedURL is set)",
+			},
+		},
+		"endpoint-param-styles": {
+			DSL: testdata.EndpointParamStyles,
+		},
+		"endpoint-param-styles-invalid": {
+			DSL: testdata.EndpointParamStylesInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": path parameter id cannot define a serialization style\nservice \"Service\" HTTP endpoint \"Method\": query parameter name must be an array to use the \"pipeDelimited\" style\nservice \"Service\" HTTP endpoint \"Method\": query parameter filter cannot disable explode with the \"deepObject\" style\nservice \"Service\" HTTP endpoint \"Method\": query parameter sort uses an invalid style \"matrix\", style must be one of \"form\", \"spaceDelimited\", \"pipeDelimited\" or \"deepObject\"\nservice \"Service\" HTTP endpoint \"Method\": header \"labels\" uses an invalid style \"form\", style must be \"simple\"",
+			},
+		},
+		"endpoint-param-separator-invalid": {
+			DSL: testdata.EndpointParamSeparatorInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": path parameter id cannot define a serialization style\nservice \"Service\" HTTP endpoint \"Method\": query parameter ids separator cannot be empty\nservice \"Service\" HTTP endpoint \"Method\": query parameter name must be an array to define a separator\nservice \"Service\" HTTP endpoint \"Method\": header \"labels\" cannot define both a separator and a style or explode",
+			},
+		},
+		"endpoint-map-params-object": {
+			DSL: testdata.EndpointMapParamsObject,
+		},
+		"endpoint-map-params-invalid": {
+			DSL: testdata.EndpointMapParamsInvalid,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": MapParams is set and payload attribute ranges is a map. But attribute bounds of payload element type must be a primitive or an array of primitives\nservice \"Service\" HTTP endpoint \"Method2\": MapParams is set to an attribute in Payload. But payload has no attribute with type map and name name\nservice \"Service\" HTTP endpoint \"Method3\": MapParams is set and Payload type is map. But payload element type must be a primitive, array or object",
+			},
+		},
+		"endpoint-strict-status-codes-unmapped": {
+			DSL: testdata.EndpointStrictStatusCodesUnmapped,
+			Errors: []string{
+				"service \"Service\" HTTP endpoint \"Method\": error \"conflict\" is not mapped to a HTTP response, use Response to map it (StrictStatusCodes is set)\nservice \"Service\" HTTP endpoint \"Method\": error \"not_found\" is not mapped to a HTTP response, use Response to map it (StrictStatusCodes is set)",
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,120 @@
+package expr
+
+import (
+	"regexp"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPExportExpr describes an endpoint that streams the method results
+	// as the rows of a file (CSV by default) written to the HTTP response.
+	HTTPExportExpr struct {
+		// Filename is the template used to build the name of the file
+		// returned in the Content-Disposition response header without
+		// extension. The template may refer to payload attributes using
+		// placeholders of the form "{name}".
+		Filename string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// exportFilenameRegex matches the placeholders of export file name templates.
+var exportFilenameRegex = regexp.MustCompile(`{([a-zA-Z0-9_]+)}`)
+
+// EvalName returns the generic expression name used in error messages.
+func (e *HTTPExportExpr) EvalName() string {
+	if e.Endpoint != nil {
+		return e.Endpoint.EvalName() + " export"
+	}
+	return "export"
+}
+
+// FilenameParams returns the names of the payload attributes used in the file
+// name template in order of appearance.
+func (e *HTTPExportExpr) FilenameParams() []string {
+	var params []string
+	seen := make(map[string]struct{})
+	for _, m := range exportFilenameRegex.FindAllStringSubmatch(e.Filename, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		params = append(params, m[1])
+	}
+	return params
+}
+
+// Columns returns the attributes of the streaming result type written as the
+// file columns.
+func (e *HTTPExportExpr) Columns() *Object {
+	return AsObject(e.Endpoint.MethodExpr.Result.Type)
+}
+
+// Validate makes sure the method streams results whose attributes can be
+// written as the cells of a row and that the file name template only refers to
+// primitive payload attributes.
+func (e *HTTPExportExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	m := e.Endpoint.MethodExpr
+	if e.Endpoint.NDJSON {
+		verr.Add(e, "Export cannot be used together with NDJSON")
+	}
+	if m.Stream != ServerStreamKind {
+		verr.Add(e, "Export requires the method to define a streaming result and no streaming payload")
+		return verr
+	}
+	if _, ok := m.Result.Type.(*ResultTypeExpr); ok {
+		verr.Add(e, "Export requires the streaming result type to be defined with Type, result types are not supported")
+	} else if obj := e.Columns(); obj == nil {
+		verr.Add(e, "Export requires the streaming result type to be an object")
+	} else {
+		for _, nat := range *obj {
+			if !isExportable(nat.Attribute) {
+				verr.Add(e, "attribute %q of the streaming result type cannot be exported, exported attributes must be strings, integers, numbers or booleans", nat.Name)
+			}
+		}
+	}
+	for _, p := range e.FilenameParams() {
+		if !IsObject(m.Payload.Type) {
+			verr.Add(e, "file name %q uses placeholder %q but the method payload is not an object", e.Filename, p)
+			break
+		}
+		att := m.Payload.Find(p)
+		if att == nil {
+			verr.Add(e, "file name %q uses placeholder %q which is not a payload attribute", e.Filename, p)
+			continue
+		}
+		if !IsPrimitive(att.Type) {
+			verr.Add(e, "file name %q uses placeholder %q which is not a primitive payload attribute", e.Filename, p)
+		}
+	}
+	for _, r := range e.Endpoint.Responses {
+		if r.StatusCode < 400 && !bodyAllowedForStatus(r.StatusCode) {
+			verr.Add(r, "Export endpoint response with status code %d cannot have a body", r.StatusCode)
+		}
+	}
+	return verr
+}
+
+// isExportable returns true if the value of att can be written in a cell. The
+// user types are exportable if their base type is.
+func isExportable(att *AttributeExpr) bool {
+	if att.IsOptionalField() {
+		return false
+	}
+	dt := att.Type
+	for {
+		ut, ok := dt.(UserType)
+		if !ok {
+			break
+		}
+		dt = ut.Attribute().Type
+	}
+	switch dt.Kind() {
+	case BooleanKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind, StringKind:
+		return true
+	}
+	return false
+}
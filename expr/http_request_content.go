@@ -0,0 +1,113 @@
+package expr
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPRequestContentExpr describes the request body sent by clients
+	// that use a specific content type. Requests that use a content type
+	// described by a HTTPRequestContentExpr are decoded using the
+	// corresponding body, other requests are decoded using the endpoint
+	// body.
+	HTTPRequestContentExpr struct {
+		// DSLFunc contains the DSL used to initialize the expression.
+		eval.DSLFunc
+		// ContentType is the request media type, e.g.
+		// "application/xml".
+		ContentType string
+		// Body describes the request body.
+		Body *AttributeExpr
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (c *HTTPRequestContentExpr) EvalName() string {
+	suffix := fmt.Sprintf("request content %q", c.ContentType)
+	if c.Endpoint != nil {
+		return c.Endpoint.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// Name returns a name derived from the content type subtype that is suitable
+// for naming the types and functions generated for the content, e.g. "xml"
+// for "application/xml" and "form_data" for "multipart/form-data".
+func (c *HTTPRequestContentExpr) Name() string {
+	mt := c.ContentType
+	if parsed, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = parsed
+	}
+	if i := strings.IndexByte(mt, '/'); i >= 0 {
+		mt = mt[i+1:]
+	}
+	mt = strings.TrimPrefix(mt, "x-")
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, mt)
+}
+
+// Validate makes sure the content type is a valid media type and that the
+// content defines a body whose attributes are all defined in the payload.
+func (c *HTTPRequestContentExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if _, _, err := mime.ParseMediaType(c.ContentType); err != nil {
+		verr.Add(c, "invalid content type %q: %s", c.ContentType, err)
+	}
+	if c.Body == nil {
+		verr.Add(c, "request content must define a body")
+		return verr
+	}
+	verr.Merge(c.Body.Validate("HTTP request content body", c))
+	if obj := AsObject(c.Body.Type); obj != nil && IsObject(c.Endpoint.MethodExpr.Payload.Type) {
+		props, ok := c.Body.Meta["origin:attribute"]
+		if !ok {
+			for _, nat := range *obj {
+				props = append(props, strings.Split(nat.Name, ":")[0])
+			}
+		}
+		for _, prop := range props {
+			if c.Endpoint.MethodExpr.Payload.Find(prop) == nil {
+				verr.Add(c, "Body %q is not found in Payload.", prop)
+			}
+		}
+	}
+	return verr
+}
+
+// Finalize makes the body type a user type whose name does not clash with the
+// types generated for the endpoint body and the other request contents.
+func (c *HTTPRequestContentExpr) Finalize() {
+	const suffix = "RequestBody"
+	if c.Body == nil {
+		return
+	}
+	name := concat(c.Endpoint.Name(), c.Name(), "Request", "Body")
+	body := DupAtt(c.Body)
+	if _, ok := body.Type.(UserType); ok || !IsObject(body.Type) {
+		renameType(body, name, suffix)
+		body.Finalize()
+		c.Body = body
+		return
+	}
+	// Inherit the payload attributes properties prior to wrapping the body
+	// object in a user type.
+	body.Finalize()
+	ut := &UserTypeExpr{AttributeExpr: body, TypeName: name}
+	appendSuffix(ut.Attribute().Type, suffix)
+	c.Body = &AttributeExpr{
+		Type:         ut,
+		Validation:   body.Validation,
+		UserExamples: body.UserExamples,
+		Meta:         body.Meta,
+	}
+}
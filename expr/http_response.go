@@ -2,7 +2,9 @@ package expr
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -88,6 +90,13 @@ type (
 		Body *AttributeExpr
 		// Response Content-Type header value
 		ContentType string
+		// CacheControl is the value of the Cache-Control header if any.
+		CacheControl string
+		// Expires is the duration added to the time the response is
+		// written to compute the value of the Expires header if any.
+		Expires string
+		// Vary lists the names of the headers set in the Vary header.
+		Vary []string
 		// Tag the value a field of the result must have for this
 		// response to be used.
 		Tag [2]string
@@ -127,6 +136,8 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 		verr.Add(r, "Response body defined for status code %d which does not allow response body.", r.StatusCode)
 	}
 
+	verr.Merge(r.validateCaching())
+
 	if e.MethodExpr.Result.Type == Empty {
 		if !r.Headers.IsEmpty() {
 			verr.Add(r, "response defines headers but result is empty")
@@ -246,11 +257,14 @@ func (r *HTTPResponseExpr) Finalize(a *HTTPEndpointExpr, svcAtt *AttributeExpr)
 // Dup creates a copy of the response expression.
 func (r *HTTPResponseExpr) Dup() *HTTPResponseExpr {
 	res := HTTPResponseExpr{
-		StatusCode:  r.StatusCode,
-		Description: r.Description,
-		ContentType: r.ContentType,
-		Parent:      r.Parent,
-		Meta:        r.Meta,
+		StatusCode:   r.StatusCode,
+		Description:  r.Description,
+		ContentType:  r.ContentType,
+		CacheControl: r.CacheControl,
+		Expires:      r.Expires,
+		Vary:         r.Vary,
+		Parent:       r.Parent,
+		Meta:         r.Meta,
 	}
 	if r.Body != nil {
 		res.Body = DupAtt(r.Body)
@@ -259,6 +273,63 @@ func (r *HTTPResponseExpr) Dup() *HTTPResponseExpr {
 	return &res
 }
 
+// ExpiresIn returns the number of seconds added to the time the response is
+// written to compute the value of the Expires header, 0 if the response does
+// not set the header.
+func (r *HTTPResponseExpr) ExpiresIn() int64 {
+	if r.Expires == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(r.Expires)
+	if err != nil {
+		return 0
+	}
+	return int64(d / time.Second)
+}
+
+// validateCaching makes sure the caching headers set via the CacheControl,
+// Expires and Vary DSLs are valid and not also defined explicitly.
+func (r *HTTPResponseExpr) validateCaching() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if r.Expires != "" {
+		if d, err := time.ParseDuration(r.Expires); err != nil {
+			verr.Add(r, "invalid Expires duration %q: %s", r.Expires, err)
+		} else if d < time.Second {
+			verr.Add(r, "Expires duration %q must be at least one second", r.Expires)
+		}
+	}
+	for _, h := range r.Vary {
+		if h == "" {
+			verr.Add(r, "Vary header names cannot be empty")
+		}
+	}
+	check := func(set bool, name, dsl string) {
+		if !set || r.Headers == nil {
+			return
+		}
+		for _, nat := range *AsObject(r.Headers.Type) {
+			if http.CanonicalHeaderKey(r.Headers.ElemName(nat.Name)) == name {
+				verr.Add(r, "header %q is set by %s and cannot also be mapped to attribute %q", name, dsl, nat.Name)
+			}
+		}
+	}
+	check(r.CacheControl != "", "Cache-Control", "CacheControl")
+	check(r.Expires != "", "Expires", "Expires")
+	check(len(r.Vary) > 0, "Vary", "Vary")
+	return verr
+}
+
+// IsMultiStatus returns true if dt is a multi-status envelope type created
+// with the MultiStatusOf DSL function.
+func IsMultiStatus(dt DataType) bool {
+	ut, ok := dt.(UserType)
+	if !ok {
+		return false
+	}
+	_, ok = ut.Attribute().Meta["goa:multistatus"]
+	return ok
+}
+
 // bodyAllowedForStatus reports whether a given response status code
 // permits a body. See RFC 2616, section 4.4.
 // See https://golang.org/src/net/http/transfer.go
@@ -23,6 +23,9 @@ func TestHTTPResponseValidation(t *testing.T) {
 		{"map result", mapResultResponseWithHeadersDSL, ""},
 		{"invalid", emptyResultResponseWithHeadersDSL, `HTTP response of service "EmptyResultResponseWithHeaders" HTTP endpoint "Method": response defines headers but result is empty`},
 		{"not string or []byte", intResultResponseWithTextContentTypeDSL, `HTTP response of service "StringResultResponseWithHeaders" HTTP endpoint "Method": Result type must be String or Bytes when ContentType is 'text/plain'`},
+		{"caching", cachingResponseDSL, ""},
+		{"invalid caching", invalidCachingResponseDSL, `HTTP response of service "InvalidCachingResponse" HTTP endpoint "Method": Expires duration "500ms" must be at least one second
+HTTP response of service "InvalidCachingResponse" HTTP endpoint "Method": header "Cache-Control" is set by CacheControl and cannot also be mapped to attribute "cc"`},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -172,3 +175,37 @@ var intResultResponseWithTextContentTypeDSL = func() {
 		})
 	})
 }
+
+var cachingResponseDSL = func() {
+	Service("CachingResponse", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					CacheControl("public", "max-age=60")
+					Expires("1m")
+					Vary("Accept")
+				})
+			})
+		})
+	})
+}
+
+var invalidCachingResponseDSL = func() {
+	Service("InvalidCachingResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("cc", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					CacheControl("no-store")
+					Expires("500ms")
+					Header("cc:Cache-Control")
+				})
+			})
+		})
+	})
+}
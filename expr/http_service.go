@@ -37,6 +37,38 @@ type (
 		HTTPErrors []*HTTPErrorExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*HTTPFileServerExpr
+		// FixedHeaders lists the headers written with a constant value
+		// by all the service responses.
+		FixedHeaders []*HTTPFixedHeaderExpr
+		// VersionHeader is the name of the request header used to
+		// select the API version served by the versioned endpoints.
+		VersionHeader string
+		// ViewParam is the name of the query string parameter used by
+		// clients to select the result view of the endpoints whose
+		// result defines multiple views, empty if not set.
+		ViewParam string
+		// MaxRequestBody is the maximum size in bytes of the request
+		// bodies read by the service endpoints, zero if not set.
+		MaxRequestBody int64
+		// Compression lists the content codings used to compress the
+		// responses of the service endpoints in order of preference,
+		// nil if not set.
+		Compression []string
+		// RequestCompression lists the content codings of the
+		// compressed request bodies accepted by the service endpoints,
+		// nil if not set.
+		RequestCompression []string
+		// TracePhases is true if the handlers of the service endpoints
+		// notify the phase observer of the request phases.
+		TracePhases bool
+		// PassThroughHeaders lists the canonical names of the headers
+		// of the upstream responses written to the responses of the
+		// service endpoints, nil if not set.
+		PassThroughHeaders []string
+		// TrailingSlash is the policy applied to the requests whose
+		// path differs from the path of a service route by a trailing
+		// slash, empty if not set.
+		TrailingSlash string
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -63,6 +95,26 @@ func (svc *HTTPServiceExpr) Error(name string) *ErrorExpr {
 	return Root.Error(name)
 }
 
+// AllFixedHeaders returns the headers written with a constant value by all
+// the service responses: the headers defined at the API level followed by the
+// headers defined at the service level. Service level headers override API
+// level headers with the same name.
+func (svc *HTTPServiceExpr) AllFixedHeaders() []*HTTPFixedHeaderExpr {
+	var res []*HTTPFixedHeaderExpr
+	idx := make(map[string]int)
+	for _, hs := range [][]*HTTPFixedHeaderExpr{Root.API.HTTP.FixedHeaders, svc.FixedHeaders} {
+		for _, h := range hs {
+			if i, ok := idx[h.Name]; ok {
+				res[i] = h
+				continue
+			}
+			idx[h.Name] = len(res)
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
 // Endpoint returns the service endpoint with the given name or nil if there
 // isn't one.
 func (svc *HTTPServiceExpr) Endpoint(name string) *HTTPEndpointExpr {
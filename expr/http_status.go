@@ -0,0 +1,108 @@
+package expr
+
+import (
+	"sort"
+)
+
+// HTTPStatus describes a HTTP status code of the responses that an endpoint
+// may write.
+type HTTPStatus struct {
+	// Code is the HTTP status code.
+	Code int
+	// Response is true if a success response defined in the design uses
+	// the status code.
+	Response bool
+	// Errors lists the names of the errors defined in the design whose
+	// response uses the status code.
+	Errors []string
+	// Generated lists the descriptions of the responses written with the
+	// status code by the generated code and the goa muxer regardless of
+	// the design responses.
+	Generated []string
+}
+
+// StatusCodes returns the HTTP status codes of all the responses the endpoint
+// may write sorted by code: the success and error responses defined in the
+// design and the responses written by the generated code when the request
+// cannot be decoded (400), the credentials are missing or invalid (401), the
+// URL signature of endpoints using SignedURL is invalid (403), the conditional
+// request headers of endpoints using ETag are satisfied (304) or not (412), the
+// request path does not match any route (404) or matches routes for other
+// methods (405), the request body is too large (413), the request body is
+// compressed with an unsupported content coding (415) or the method returns an
+// unexpected error (500). StatusCodes must be called on finalized endpoints.
+func (e *HTTPEndpointExpr) StatusCodes() []*HTTPStatus {
+	var (
+		codes = make(map[int]*HTTPStatus)
+		get   = func(code int) *HTTPStatus {
+			s, ok := codes[code]
+			if !ok {
+				s = &HTTPStatus{Code: code}
+				codes[code] = s
+			}
+			return s
+		}
+		gen = func(code int, desc string) {
+			s := get(code)
+			s.Generated = append(s.Generated, desc)
+		}
+	)
+	for _, r := range e.Responses {
+		get(r.StatusCode).Response = true
+	}
+	for _, er := range e.HTTPErrors {
+		s := get(er.Response.StatusCode)
+		s.Errors = append(s.Errors, er.Name)
+	}
+	if e.MethodExpr.Payload.Type != Empty {
+		gen(StatusBadRequest, "the request cannot be decoded or is invalid")
+	}
+	if len(e.MethodExpr.Requirements) > 0 || len(e.Service.ServiceExpr.Requirements) > 0 {
+		gen(StatusUnauthorized, "the request credentials are missing or invalid")
+	}
+	if TaggedAttribute(e.MethodExpr.Result, "http:etag") != "" {
+		gen(StatusNotModified, "the request If-None-Match header matches the entity tag")
+		gen(StatusPreconditionFailed, "the request If-Match header does not match the entity tag")
+	}
+	if e.SignedURL {
+		gen(StatusForbidden, "the request URL signature is missing, invalid or expired")
+	}
+	gen(StatusNotFound, "the request path does not match any route")
+	gen(StatusMethodNotAllowed, "the request path matches routes for other methods")
+	if e.RequestBodyLimit() > 0 && (e.Body.Type != Empty || e.MultipartRequest) {
+		gen(StatusRequestEntityTooLarge, "the request body is too large")
+	}
+	if len(e.RequestEncodings()) > 0 {
+		gen(StatusUnsupportedResultType, "the request body content coding is not supported")
+	}
+	gen(StatusInternalServerError, "the method returned an unexpected error")
+
+	res := make([]*HTTPStatus, 0, len(codes))
+	for _, s := range codes {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Code < res[j].Code })
+	return res
+}
+
+// unmappedErrors returns the names of the errors defined on the endpoint
+// method or its service that are not mapped to a HTTP response by the
+// endpoint, its service or the API.
+func (e *HTTPEndpointExpr) unmappedErrors() []string {
+	mapped := make(map[string]struct{})
+	for _, er := range e.HTTPErrors {
+		mapped[er.Name] = struct{}{}
+	}
+	if Root != nil && Root.API != nil && Root.API.HTTP != nil {
+		for _, er := range Root.API.HTTP.Errors {
+			mapped[er.Name] = struct{}{}
+		}
+	}
+	var names []string
+	for _, er := range append(e.MethodExpr.Errors, e.MethodExpr.Service.Errors...) {
+		if _, ok := mapped[er.Name]; !ok {
+			names = append(names, er.Name)
+		}
+	}
+	return names
+}
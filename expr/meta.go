@@ -0,0 +1,185 @@
+package expr
+
+import (
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// metaKey records a key set with the Meta DSL and the expression it
+	// was set on.
+	metaKey struct {
+		exp eval.Expression
+		key string
+	}
+)
+
+var (
+	// knownMetaKeys lists the Meta keys that have a special meaning for
+	// goa and its generators.
+	knownMetaKeys = []string{
+		"type:generate:force",
+		"struct:error:name",
+		"struct:field:name",
+		"struct:field:type",
+		"struct:pkg:path",
+		"struct:type:name",
+		"struct.field.external",
+		"goa:naming:initialisms",
+		"goa:naming:acronyms",
+		"goa:naming:prefix",
+		"validation:sample",
+		"swagger:generate",
+		"swagger:summary",
+		"swagger:example",
+		"pact:consumer",
+		"grpc:example:reflection",
+		"grpc:example:health",
+		"http:example:h2c",
+		"http:example:http3",
+		"wasm:interop",
+		"cli:alias",
+		"cli:group",
+		"sdk:module",
+		"rpc:tag",
+		"security:username",
+		"security:password",
+		"security:accesstoken",
+		"security:token",
+	}
+
+	// knownMetaPrefixes lists the prefixes of the Meta keys whose suffix
+	// is defined by the design or by the plugins that ship with goa.
+	knownMetaPrefixes = []string{
+		"struct:tag:",
+		"swagger:tag:",
+		"swagger:extension:",
+		"security:apikey:",
+		"schemaregistry:",
+	}
+)
+
+// TrackMeta records that the Meta DSL set key on the given expression so that
+// the key can be checked against the known keys when the API uses StrictMeta.
+func (r *RootExpr) TrackMeta(exp eval.Expression, key string) {
+	r.metaKeys = append(r.metaKeys, &metaKey{exp: exp, key: key})
+}
+
+// validateMeta makes sure that the keys set with the Meta DSL are known if the
+// API uses StrictMeta. The error suggests the closest known key if any.
+func (r *RootExpr) validateMeta(verr *eval.ValidationErrors) {
+	if r.API == nil {
+		return
+	}
+	extra, ok := r.API.Meta["goa:meta:strict"]
+	if !ok {
+		return
+	}
+	var (
+		keys     = append(append([]string{}, knownMetaKeys...), extra...)
+		prefixes = append([]string{}, knownMetaPrefixes...)
+	)
+	for _, k := range extra {
+		if strings.HasSuffix(k, ":") {
+			prefixes = append(prefixes, k)
+		}
+	}
+	seen := make(map[string]struct{})
+	for _, mk := range r.metaKeys {
+		if isKnownMetaKey(mk.key, keys, prefixes) {
+			continue
+		}
+		// The DSL of some expressions may run more than once.
+		id := mk.exp.EvalName() + "#" + mk.key
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		if s := suggestMetaKey(mk.key, keys, prefixes); s != "" {
+			verr.Add(mk.exp, "unknown Meta key %q, did you mean %q? (StrictMeta is set)", mk.key, s)
+		} else {
+			verr.Add(mk.exp, "unknown Meta key %q (StrictMeta is set)", mk.key)
+		}
+	}
+}
+
+// isKnownMetaKey returns true if key is one of keys or starts with one of
+// prefixes and defines a suffix.
+func isKnownMetaKey(key string, keys, prefixes []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(key, p) && len(key) > len(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestMetaKey returns the known key closest to key, the empty string if no
+// known key is close enough. The candidates built from the prefixes keep the
+// segments of key that follow the prefix segments, e.g. "struct:tags:json"
+// is compared with "struct:tag:json".
+func suggestMetaKey(key string, keys, prefixes []string) string {
+	candidates := append([]string{}, keys...)
+	parts := strings.Split(key, ":")
+	for _, p := range prefixes {
+		n := strings.Count(p, ":")
+		if len(parts) > n && strings.Join(parts[n:], "") != "" {
+			candidates = append(candidates, p+strings.Join(parts[n:], ":"))
+		}
+	}
+	sort.Strings(candidates)
+	var (
+		best string
+		min  = len(key)/3 + 1
+	)
+	if min > 3 {
+		min = 3
+	}
+	for _, c := range candidates {
+		if c == key {
+			continue
+		}
+		if d := editDistance(key, c); d <= min && (best == "" || d < editDistance(key, best)) {
+			best = c
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// minInt returns the smallest of the given integers.
+func minInt(v int, vals ...int) int {
+	for _, o := range vals {
+		if o < v {
+			v = o
+		}
+	}
+	return v
+}
@@ -2,6 +2,8 @@ package expr
 
 import (
 	"fmt"
+	"regexp"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -41,8 +43,23 @@ type (
 		// StreamingPayload is the payload sent across the stream.
 		StreamingPayload *AttributeExpr
 	}
+
+	// Dependency describes a per-request dependency defined with the
+	// Dependency DSL.
+	Dependency struct {
+		// Name is the dependency name.
+		Name string
+		// Type is the Go type of the dependency, e.g. "*sql.Tx".
+		Type string
+		// PkgPath is the import path of the package that defines the
+		// type if any, e.g. "database/sql".
+		PkgPath string
+	}
 )
 
+// dependencyNameRegex matches the valid dependency names.
+var dependencyNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
 const (
 	// NoStreamKind represents no payload or result stream in method.
 	NoStreamKind StreamKind = iota + 1
@@ -57,6 +74,15 @@ const (
 	BidirectionalStreamKind
 )
 
+const (
+	// TCPEncodingJSON is the TCP transport encoding that uses the JSON
+	// encoding of the service types.
+	TCPEncodingJSON = "json"
+	// TCPEncodingProto is the TCP transport encoding that uses the
+	// protocol buffer messages generated for the gRPC transport.
+	TCPEncodingProto = "proto"
+)
+
 // Error returns the error with the given name. It looks up recursively in the
 // endpoint then the service and finally the root expression.
 func (m *MethodExpr) Error(name string) *ErrorExpr {
@@ -94,6 +120,9 @@ func (m *MethodExpr) Prepare() {
 	if m.Result == nil {
 		m.Result = &AttributeExpr{Type: Empty}
 	}
+	if m.IsIdempotent() {
+		m.prepareIdempotencyKey()
+	}
 }
 
 // Validate validates the method payloads, results, and errors (if any).
@@ -151,6 +180,78 @@ func (m *MethodExpr) Validate() error {
 			}
 		}
 	}
+	if m.IsIdempotent() {
+		if obj := AsObject(m.Payload.Type); obj == nil {
+			verr.Add(m, "payload of idempotent method %q of service %q must be an object", m.Name, m.Service.Name)
+		} else if att := obj.Attribute(TaggedAttribute(m.Payload, "goa:idempotency-key")); att != nil && att.Type != String {
+			verr.Add(m, "idempotency key attribute of method %q of service %q must be a String", m.Name, m.Service.Name)
+		}
+	}
+	if m.IsEvent() {
+		if m.Payload.Type == Empty {
+			verr.Add(m, "event method %q of service %q must define a payload", m.Name, m.Service.Name)
+		}
+		if m.Result.Type != Empty {
+			verr.Add(m, "event method %q of service %q cannot define a result", m.Name, m.Service.Name)
+		}
+		if m.IsStreaming() {
+			verr.Add(m, "event method %q of service %q cannot use streaming", m.Name, m.Service.Name)
+		}
+	}
+	for _, n := range m.RenamedFrom() {
+		switch {
+		case n == "":
+			verr.Add(m, "previous name of method %q of service %q cannot be empty", m.Name, m.Service.Name)
+		case m.Service.Method(n) != nil:
+			verr.Add(m, "previous name %q of method %q of service %q is the name of a method of the service", n, m.Name, m.Service.Name)
+		}
+	}
+	if perms := m.Permissions(); len(perms) > 0 {
+		if !m.isSecured() {
+			verr.Add(m, "method %q of service %q defines permissions but is not secured, use Security to define the security requirements", m.Name, m.Service.Name)
+		}
+		for _, p := range perms {
+			if p == "" {
+				verr.Add(m, "permissions of method %q of service %q cannot be empty", m.Name, m.Service.Name)
+			}
+		}
+	}
+	verr.Merge(m.validateDependencies())
+	if enc, ok := m.Meta["tcp:encoding"]; ok && m.Stream != ServerStreamKind {
+		verr.Add(m, "method %q of service %q enables the TCP transport with encoding %q but does not define a StreamingResult", m.Name, m.Service.Name, enc[0])
+	}
+	if enc := m.TCPEncoding(); enc != "" {
+		switch enc {
+		case TCPEncodingJSON:
+		case TCPEncodingProto:
+			verr.Merge(m.validateTCPProto())
+		default:
+			verr.Add(m, "invalid TCP encoding %q of method %q of service %q, the encoding must be %q or %q", enc, m.Name, m.Service.Name, TCPEncodingJSON, TCPEncodingProto)
+		}
+	}
+	if ds, ok := m.Meta["goa:sunset"]; ok && len(ds) > 0 {
+		if _, err := parseSunset(ds[0]); err != nil {
+			verr.Add(m, "invalid sunset date %q of method %q of service %q, the date must be formatted as a RFC 3339 date or date-time", ds[0], m.Name, m.Service.Name)
+		}
+	}
+	if ts, ok := m.Meta["goa:timeout"]; ok && len(ts) > 0 {
+		if d, err := time.ParseDuration(ts[0]); err != nil {
+			verr.Add(m, "invalid timeout %q of method %q of service %q: %s", ts[0], m.Name, m.Service.Name, err)
+		} else if d <= 0 {
+			verr.Add(m, "timeout %q of method %q of service %q must be positive", ts[0], m.Name, m.Service.Name)
+		}
+		if m.IsStreaming() {
+			verr.Add(m, "method %q of service %q cannot use both Timeout and streaming", m.Name, m.Service.Name)
+		}
+	}
+	if m.HasFieldMask() {
+		if !IsObject(m.Result.Type) {
+			verr.Add(m, "result of method %q of service %q must be an object to use FieldMask", m.Name, m.Service.Name)
+		}
+		if m.IsStreaming() {
+			verr.Add(m, "method %q of service %q cannot use both FieldMask and streaming", m.Name, m.Service.Name)
+		}
+	}
 	if m.StreamingPayload.Type != Empty {
 		verr.Merge(m.StreamingPayload.Validate("streaming_payload", m))
 	}
@@ -258,11 +359,280 @@ func (m *MethodExpr) IsStreaming() bool {
 	return m.Stream != 0 && m.Stream != NoStreamKind
 }
 
+// IsIdempotent returns true if the method is marked as idempotent via the
+// Idempotent DSL.
+func (m *MethodExpr) IsIdempotent() bool {
+	_, ok := m.Meta["goa:idempotent"]
+	return ok
+}
+
+// IsEvent returns true if the method is marked as an event via the Event DSL.
+func (m *MethodExpr) IsEvent() bool {
+	_, ok := m.Meta["goa:event"]
+	return ok
+}
+
+// RenamedFrom returns the previous names of the method set via the
+// RenamedFrom DSL, nil if there is none.
+func (m *MethodExpr) RenamedFrom() []string {
+	return m.Meta["goa:renamedfrom"]
+}
+
+// IsStrictDecoding returns true if the method or its service is marked with
+// the StrictDecoding DSL.
+func (m *MethodExpr) IsStrictDecoding() bool {
+	if _, ok := m.Meta["goa:strict"]; ok {
+		return true
+	}
+	if m.Service == nil {
+		return false
+	}
+	_, ok := m.Service.Meta["goa:strict"]
+	return ok
+}
+
+// IsAggregateErrors returns true if the method or its service is marked with
+// the AggregateErrors DSL.
+func (m *MethodExpr) IsAggregateErrors() bool {
+	if _, ok := m.Meta["goa:aggregate"]; ok {
+		return true
+	}
+	if m.Service == nil {
+		return false
+	}
+	_, ok := m.Service.Meta["goa:aggregate"]
+	return ok
+}
+
+// Permissions returns the permissions defined with the Permission DSL on the
+// method and its service without duplicates. The permissions defined on the
+// service come first.
+func (m *MethodExpr) Permissions() []string {
+	var perms []string
+	seen := make(map[string]struct{})
+	add := func(meta MetaExpr) {
+		for _, p := range meta["goa:permission"] {
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				perms = append(perms, p)
+			}
+		}
+	}
+	if m.Service != nil {
+		add(m.Service.Meta)
+	}
+	add(m.Meta)
+	return perms
+}
+
+// Dependencies returns the per-request dependencies defined with the
+// Dependency DSL on the method and its service. The dependencies defined on the
+// service come first. A dependency defined on both the service and the method
+// is only listed once.
+func (m *MethodExpr) Dependencies() []*Dependency {
+	var deps []*Dependency
+	seen := make(map[string]struct{})
+	add := func(meta MetaExpr) {
+		for _, n := range meta["goa:dependency"] {
+			if _, ok := seen[n]; ok {
+				continue
+			}
+			seen[n] = struct{}{}
+			deps = append(deps, newDependency(n, meta))
+		}
+	}
+	if m.Service != nil {
+		add(m.Service.Meta)
+	}
+	add(m.Meta)
+	return deps
+}
+
+// validateDependencies makes sure the dependencies of the method have valid
+// names and types and that the dependencies defined on both the method and its
+// service have the same type.
+func (m *MethodExpr) validateDependencies() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, d := range m.Dependencies() {
+		if !dependencyNameRegex.MatchString(d.Name) {
+			verr.Add(m, "invalid dependency name %q of method %q of service %q, the name must start with a letter and only contain letters, digits and underscores", d.Name, m.Name, m.Service.Name)
+		}
+		if d.Type == "" {
+			verr.Add(m, "type of dependency %q of method %q of service %q cannot be empty", d.Name, m.Name, m.Service.Name)
+		}
+	}
+	if m.Service == nil {
+		return verr
+	}
+	for _, n := range m.Meta["goa:dependency"] {
+		if _, ok := m.Service.Meta["goa:dependency:"+n]; !ok {
+			continue
+		}
+		sd, md := newDependency(n, m.Service.Meta), newDependency(n, m.Meta)
+		if sd.Type != md.Type || sd.PkgPath != md.PkgPath {
+			verr.Add(m, "dependency %q of method %q is defined with type %q but service %q defines it with type %q", n, m.Name, md.Type, m.Service.Name, sd.Type)
+		}
+	}
+	return verr
+}
+
+// newDependency returns the dependency with the given name stored in meta by
+// the Dependency DSL.
+func newDependency(name string, meta MetaExpr) *Dependency {
+	d := &Dependency{Name: name}
+	if v := meta["goa:dependency:"+name]; len(v) > 0 {
+		d.Type = v[0]
+		if len(v) > 1 {
+			d.PkgPath = v[1]
+		}
+	}
+	return d
+}
+
+// TCPEncoding returns the encoding of the messages streamed by the raw TCP
+// transport as defined with the TCP DSL, the empty string if the transport is
+// not enabled for the method. The encoding defined on the method overrides
+// the one defined on the service which only applies to methods that define a
+// StreamingResult.
+func (m *MethodExpr) TCPEncoding() string {
+	if enc, ok := m.Meta["tcp:encoding"]; ok && len(enc) > 0 {
+		return enc[0]
+	}
+	if m.Service == nil || m.Stream != ServerStreamKind {
+		return ""
+	}
+	if enc, ok := m.Service.Meta["tcp:encoding"]; ok && len(enc) > 0 {
+		return enc[0]
+	}
+	return ""
+}
+
+// validateTCPProto validates that the messages streamed by the raw TCP
+// transport can use the protocol buffer messages generated for the gRPC
+// transport.
+func (m *MethodExpr) validateTCPProto() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	var ge *GRPCEndpointExpr
+	if Root != nil && Root.API != nil && Root.API.GRPC != nil {
+		if gs := Root.API.GRPC.Service(m.Service.Name); gs != nil {
+			ge = gs.Endpoint(m.Name)
+		}
+	}
+	if ge == nil {
+		verr.Add(m, "method %q of service %q uses the %q TCP encoding but does not define a gRPC endpoint", m.Name, m.Service.Name, TCPEncodingProto)
+		return verr
+	}
+	if ge.Metadata != nil {
+		if obj := AsObject(ge.Metadata.Type); obj != nil && len(*obj) > 0 {
+			verr.Add(m, "method %q of service %q uses the %q TCP encoding but its gRPC endpoint defines metadata", m.Name, m.Service.Name, TCPEncodingProto)
+		}
+	}
+	if _, ok := m.Result.Type.(*ResultTypeExpr); ok {
+		verr.Add(m, "method %q of service %q uses the %q TCP encoding but its result is a result type, use the %q encoding or a user type", m.Name, m.Service.Name, TCPEncodingProto, TCPEncodingJSON)
+	}
+	return verr
+}
+
+// isSecured returns true if the method or its service define security
+// requirements that are not removed with NoSecurity.
+func (m *MethodExpr) isSecured() bool {
+	reqs := m.Requirements
+	if len(reqs) == 0 && m.Service != nil {
+		reqs = m.Service.Requirements
+	}
+	for _, r := range reqs {
+		for _, s := range r.Schemes {
+			if s.Kind == NoKind {
+				return false
+			}
+		}
+	}
+	return len(reqs) > 0
+}
+
+// UsesOptionalFields returns true if the method or its service is marked with
+// the OptionalFields DSL.
+func (m *MethodExpr) UsesOptionalFields() bool {
+	if _, ok := m.Meta["goa:optional"]; ok {
+		return true
+	}
+	if m.Service == nil {
+		return false
+	}
+	_, ok := m.Service.Meta["goa:optional"]
+	return ok
+}
+
+// HasFieldMask returns true if the method is marked with the FieldMask DSL.
+func (m *MethodExpr) HasFieldMask() bool {
+	_, ok := m.Meta["goa:fieldmask"]
+	return ok
+}
+
+// FieldMaskTag returns the number of the field holding the field mask in the
+// gRPC request message set via the FieldMask DSL, empty if not set.
+func (m *MethodExpr) FieldMaskTag() string {
+	if t := m.Meta["goa:fieldmask"]; len(t) > 0 {
+		return t[0]
+	}
+	return ""
+}
+
+// Sunset returns the sunset date of the method set via the Sunset DSL and true
+// or the zero time and false if the method does not define a valid sunset
+// date.
+func (m *MethodExpr) Sunset() (time.Time, bool) {
+	ds, ok := m.Meta["goa:sunset"]
+	if !ok || len(ds) == 0 {
+		return time.Time{}, false
+	}
+	t, err := parseSunset(ds[0])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Timeout returns the maximum duration of the requests handled by the method
+// as defined with the Timeout DSL, zero if there is none.
+func (m *MethodExpr) Timeout() time.Duration {
+	if ts, ok := m.Meta["goa:timeout"]; ok && len(ts) > 0 {
+		d, _ := time.ParseDuration(ts[0])
+		return d
+	}
+	return 0
+}
+
 // IsPayloadStreaming determines whether the method streams payload.
 func (m *MethodExpr) IsPayloadStreaming() bool {
 	return m.Stream == ClientStreamKind || m.Stream == BidirectionalStreamKind
 }
 
+// prepareIdempotencyKey tags the payload attribute holding the idempotency key,
+// it adds the attribute to the payload if the design does not define it.
+func (m *MethodExpr) prepareIdempotencyKey() {
+	if m.Payload.Type == Empty {
+		m.Payload = &AttributeExpr{Type: &Object{}}
+	}
+	obj := AsObject(m.Payload.Type)
+	if obj == nil {
+		// Validate reports the error
+		return
+	}
+	att := obj.Attribute("idempotency_key")
+	if att == nil {
+		att = &AttributeExpr{
+			Type:        String,
+			Description: "Key used by the server to detect and replay retried requests.",
+		}
+		obj.Set("idempotency_key", att)
+	}
+	if att.Meta == nil {
+		att.Meta = make(MetaExpr)
+	}
+	att.Meta["goa:idempotency-key"] = nil
+}
+
 // helper function that duplicates just enough of a security expression so that
 // its scheme names can be overridden without affecting the original.
 func copyReqs(reqs []*SecurityExpr) []*SecurityExpr {
@@ -287,3 +657,11 @@ func copyReqs(reqs []*SecurityExpr) []*SecurityExpr {
 	}
 	return reqs2
 }
+
+// parseSunset parses a sunset date formatted as a RFC 3339 date or date-time.
+func parseSunset(date string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", date)
+}
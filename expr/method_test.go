@@ -26,6 +26,38 @@ service "InvalidSecuritySchemesService" method "InheritedSecureMethod": payload
 service "InvalidSecuritySchemesService" method "InheritedSecureMethod": payload of method "InheritedSecureMethod" of service "InvalidSecuritySchemesService" does not define an API key attribute, use APIKey to define one
 service "InvalidSecuritySchemesService" method "InheritedSecureMethod": security scope "not:found" not found in any of the security schemes.`,
 		},
+		{"invalid-tcp", testdata.InvalidTCPDSL,
+			`service "InvalidTCPService" method "NotStreaming": method "NotStreaming" of service "InvalidTCPService" enables the TCP transport with encoding "json" but does not define a StreamingResult
+service "InvalidTCPService" method "NoGRPC": method "NoGRPC" of service "InvalidTCPService" uses the "proto" TCP encoding but does not define a gRPC endpoint
+service "InvalidTCPService" method "InvalidEncoding": invalid TCP encoding "xml" of method "InvalidEncoding" of service "InvalidTCPService", the encoding must be "json" or "proto"`,
+		},
+		{"invalid-permission", testdata.InvalidPermissionDSL,
+			`service "InvalidPermissionService" method "Method": method "Method" of service "InvalidPermissionService" defines permissions but is not secured, use Security to define the security requirements
+service "InvalidPermissionService" method "Method": permissions of method "Method" of service "InvalidPermissionService" cannot be empty`,
+		},
+		{"invalid-dependency", testdata.InvalidDependencyDSL,
+			`service "InvalidDependencyService" method "Method": invalid dependency name "1clock" of method "Method" of service "InvalidDependencyService", the name must start with a letter and only contain letters, digits and underscores
+service "InvalidDependencyService" method "Method": type of dependency "logger" of method "Method" of service "InvalidDependencyService" cannot be empty
+service "InvalidDependencyService" method "Method": dependency "tx" of method "Method" is defined with type "*sqlx.Tx" but service "InvalidDependencyService" defines it with type "*sql.Tx"`,
+		},
+		{"invalid-timeout", testdata.InvalidTimeoutDSL,
+			`service "InvalidTimeoutService" method "Invalid": invalid timeout "five seconds" of method "Invalid" of service "InvalidTimeoutService": time: invalid duration "five seconds"
+service "InvalidTimeoutService" method "Negative": timeout "-5s" of method "Negative" of service "InvalidTimeoutService" must be positive
+service "InvalidTimeoutService" method "Streaming": method "Streaming" of service "InvalidTimeoutService" cannot use both Timeout and streaming`,
+		},
+		{"invalid-event", testdata.InvalidEventDSL,
+			`service "InvalidEventService" method "NoPayload": event method "NoPayload" of service "InvalidEventService" must define a payload
+service "InvalidEventService" method "Result": event method "Result" of service "InvalidEventService" cannot define a result
+service "InvalidEventService" method "Streaming": event method "Streaming" of service "InvalidEventService" must define a payload
+service "InvalidEventService" method "Streaming": event method "Streaming" of service "InvalidEventService" cannot use streaming`,
+		},
+		{"invalid-renamed-from", testdata.InvalidRenamedFromDSL,
+			`service "InvalidRenamedFromService" method "Empty": previous name of method "Empty" of service "InvalidRenamedFromService" cannot be empty
+service "InvalidRenamedFromService" method "Taken": previous name "Empty" of method "Taken" of service "InvalidRenamedFromService" is the name of a method of the service`,
+		},
+		{"invalid-sunset", testdata.InvalidSunsetDSL,
+			`service "InvalidSunsetService" method "Method": invalid sunset date "June 30th 2021" of method "Method" of service "InvalidSunsetService", the date must be formatted as a RFC 3339 date or date-time`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -144,3 +176,47 @@ func TestMethodExprIsPayloadStreaming(t *testing.T) {
 		}
 	}
 }
+
+func TestMethodExprIsStrictDecoding(t *testing.T) {
+	strict := expr.MetaExpr{"goa:strict": nil}
+	cases := map[string]struct {
+		methodMeta  expr.MetaExpr
+		serviceMeta expr.MetaExpr
+		expected    bool
+	}{
+		"none":    {nil, nil, false},
+		"method":  {strict, nil, true},
+		"service": {nil, strict, true},
+	}
+	for k, tc := range cases {
+		m := expr.MethodExpr{
+			Meta:    tc.methodMeta,
+			Service: &expr.ServiceExpr{Meta: tc.serviceMeta},
+		}
+		if actual := m.IsStrictDecoding(); actual != tc.expected {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestMethodExprIsAggregateErrors(t *testing.T) {
+	aggregate := expr.MetaExpr{"goa:aggregate": nil}
+	cases := map[string]struct {
+		methodMeta  expr.MetaExpr
+		serviceMeta expr.MetaExpr
+		expected    bool
+	}{
+		"none":    {nil, nil, false},
+		"method":  {aggregate, nil, true},
+		"service": {nil, aggregate, true},
+	}
+	for k, tc := range cases {
+		m := expr.MethodExpr{
+			Meta:    tc.methodMeta,
+			Service: &expr.ServiceExpr{Meta: tc.serviceMeta},
+		}
+		if actual := m.IsAggregateErrors(); actual != tc.expected {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
@@ -100,6 +100,58 @@ var (
 		}},
 	}
 
+	// ValidationErrorResult describes the error responses written by the
+	// HTTP servers of the methods that use the AggregateErrors DSL.
+	ValidationErrorResult = &UserTypeExpr{
+		AttributeExpr: &AttributeExpr{
+			Type:        validationErrorResultType,
+			Description: "Validation error response listing each field validation error",
+			Validation:  &ValidationExpr{Required: []string{"name", "id", "message", "temporary", "timeout", "fault", "errors"}},
+		},
+		TypeName: "ValidationError",
+	}
+
+	validationErrorResultType = &Object{
+		(*errorResultType)[0],
+		(*errorResultType)[1],
+		(*errorResultType)[2],
+		(*errorResultType)[3],
+		(*errorResultType)[4],
+		(*errorResultType)[5],
+		{"errors", &AttributeExpr{
+			Type:        &Array{ElemType: &AttributeExpr{Type: fieldErrorType}},
+			Description: "Errors lists the field validation errors.",
+			UserExamples: []*ExampleExpr{{Value: []interface{}{
+				Val{"path": "body.email", "rule": "missing_field", "message": `"email" is missing from body`},
+			}}},
+		}},
+	}
+
+	fieldErrorType = &UserTypeExpr{
+		AttributeExpr: &AttributeExpr{
+			Type: &Object{
+				{"path", &AttributeExpr{
+					Type:         String,
+					Description:  "Path is the path to the invalid field.",
+					UserExamples: []*ExampleExpr{{Value: "body.email"}},
+				}},
+				{"rule", &AttributeExpr{
+					Type:         String,
+					Description:  "Rule is the name of the violated validation rule.",
+					UserExamples: []*ExampleExpr{{Value: "missing_field"}},
+				}},
+				{"message", &AttributeExpr{
+					Type:         String,
+					Description:  "Message describes the violation.",
+					UserExamples: []*ExampleExpr{{Value: `"email" is missing from body`}},
+				}},
+			},
+			Description: "Field validation error",
+			Validation:  &ValidationExpr{Required: []string{"path", "rule", "message"}},
+		},
+		TypeName: "FieldError",
+	}
+
 	errorResultView = &ViewExpr{
 		AttributeExpr: &AttributeExpr{Type: errorResultType},
 		Name:          "default",
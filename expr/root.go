@@ -1,7 +1,9 @@
 package expr
 
 import (
+	"path"
 	"sort"
+	"strings"
 
 	"goa.design/goa/v3/eval"
 )
@@ -35,6 +37,9 @@ type (
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
+
+		// metaKeys lists the keys set with the Meta DSL.
+		metaKeys []*metaKey
 	}
 
 	// MetaExpr is a set of key/value pairs
@@ -100,6 +105,15 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 	}
 	walk(methods)
 
+	// Webhooks (must be done after services)
+	var webhooks eval.ExpressionSet
+	for _, s := range r.Services {
+		for _, w := range s.Webhooks {
+			webhooks = append(webhooks, w)
+		}
+	}
+	walk(webhooks)
+
 	// HTTP services and endpoints
 	httpsvcs := make(eval.ExpressionSet, len(r.API.HTTP.Services))
 	sort.SliceStable(r.API.HTTP.Services, func(i, j int) bool {
@@ -230,9 +244,144 @@ func (r *RootExpr) Validate() error {
 	if r.API == nil {
 		verr.Add(r, "Missing API declaration")
 	}
+	for _, t := range r.Types {
+		if !IsEnumConstType(t) {
+			continue
+		}
+		switch t.Attribute().Type.Kind() {
+		case StringKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind:
+		default:
+			verr.Add(t.Attribute(), "type %q uses EnumConstants but is not a string or integer type", t.Name())
+			continue
+		}
+		if v := t.Attribute().Validation; v == nil || len(v.Values) == 0 {
+			verr.Add(t.Attribute(), "type %q uses EnumConstants but does not define an Enum validation", t.Name())
+		}
+	}
+	r.validatePackagePaths(&verr)
+	r.validateNaming(&verr)
+	r.validateMeta(&verr)
 	return &verr
 }
 
+// validateNaming makes sure that the "goa:naming:xxx" metadata of the API
+// produce valid Go identifiers.
+func (r *RootExpr) validateNaming(verr *eval.ValidationErrors) {
+	if r.API == nil {
+		return
+	}
+	for _, i := range r.API.Meta["goa:naming:initialisms"] {
+		w := strings.TrimPrefix(i, "-")
+		if w == "" {
+			verr.Add(r.API, "invalid \"goa:naming:initialisms\" value %q, initialisms cannot be empty", i)
+			continue
+		}
+		for _, c := range w {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+				verr.Add(r.API, "invalid \"goa:naming:initialisms\" value %q, initialisms must only contain ASCII letters and digits", i)
+				break
+			}
+		}
+	}
+	if v, ok := r.API.Meta["goa:naming:acronyms"]; ok {
+		if len(v) != 1 || v[0] != "upper" && v[0] != "title" {
+			verr.Add(r.API, "invalid \"goa:naming:acronyms\" value %q, must be \"upper\" or \"title\"", strings.Join(v, ", "))
+		}
+	}
+	for _, p := range r.API.Meta["goa:naming:prefix"] {
+		if p == "" {
+			verr.Add(r.API, "invalid \"goa:naming:prefix\" value, prefixes cannot be empty")
+		}
+	}
+}
+
+// validatePackagePaths makes sure that the user types generated in a shared
+// package with the "struct:pkg:path" metadata only reference types generated
+// in the same package and are not used in ways that require generating methods
+// in the service packages.
+func (r *RootExpr) validatePackagePaths(verr *eval.ValidationErrors) {
+	for _, t := range r.ResultTypes {
+		if PackagePath(t) != "" {
+			verr.Add(t.Attribute(), "result type %q cannot define the \"struct:pkg:path\" metadata, only types can", t.Name())
+		}
+	}
+	for _, t := range r.Types {
+		p := PackagePath(t)
+		if p == "" {
+			continue
+		}
+		if !validPackagePath(p) {
+			verr.Add(t.Attribute(), "type %q: invalid \"struct:pkg:path\" value %q, the path must be relative and its last element must be a valid Go package name", t.Name(), p)
+			continue
+		}
+		if PatchedType(t) != nil {
+			verr.Add(t.Attribute(), "JSON merge patch type %q cannot define the \"struct:pkg:path\" metadata", t.Name())
+		}
+		for _, ut := range childUserTypes(t.Attribute()) {
+			if ut != ErrorResult && PackagePath(ut) != p {
+				verr.Add(t.Attribute(), "type %q is generated in package %q but references type %q which is not", t.Name(), p, ut.Name())
+			}
+		}
+	}
+	for _, c := range append(r.Conversions, r.Creations...) {
+		if PackagePath(c.User) != "" {
+			verr.Add(c.User.Attribute(), "type %q defines the \"struct:pkg:path\" metadata and cannot be used with ConvertTo or CreateFrom", c.User.Name())
+		}
+	}
+	checkError := func(er *ErrorExpr) {
+		if PackagePath(er.Type) != "" {
+			verr.Add(er, "type %q of error %q cannot define the \"struct:pkg:path\" metadata", er.Type.Name(), er.Name)
+		}
+	}
+	for _, er := range r.Errors {
+		checkError(er)
+	}
+	for _, s := range r.Services {
+		for _, er := range s.Errors {
+			checkError(er)
+		}
+		for _, m := range s.Methods {
+			for _, er := range m.Errors {
+				checkError(er)
+			}
+			if !m.HasFieldMask() {
+				continue
+			}
+			seen := make(map[string]struct{})
+			var check func(uts []UserType)
+			check = func(uts []UserType) {
+				for _, ut := range uts {
+					if _, ok := seen[ut.ID()]; ok {
+						continue
+					}
+					seen[ut.ID()] = struct{}{}
+					if PackagePath(ut) != "" {
+						verr.Add(m, "type %q defines the \"struct:pkg:path\" metadata and cannot be used in the result of a method that supports field masks", ut.Name())
+					}
+					check(childUserTypes(ut.Attribute()))
+				}
+			}
+			check(childUserTypes(m.Result))
+		}
+	}
+}
+
+// validPackagePath returns true if p is a relative slash separated path whose
+// last element is a valid Go package name.
+func validPackagePath(p string) bool {
+	if p == "" || path.IsAbs(p) || path.Clean(p) != p || strings.HasPrefix(p, "..") {
+		return false
+	}
+	name := path.Base(p)
+	for i, c := range name {
+		if c >= 'a' && c <= 'z' || c == '_' || i > 0 && c >= '0' && c <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
 // Finalize finalizes the server expressions.
 func (r *RootExpr) Finalize() {
 	if r.API == nil {
@@ -8,8 +8,30 @@ import (
 )
 
 func TestRootExprValidate(t *testing.T) {
+	enumConst := func(name string, dt DataType, vals ...interface{}) UserType {
+		att := &AttributeExpr{Type: dt, Meta: MetaExpr{"goa:enum:const": nil}}
+		if len(vals) > 0 {
+			att.Validation = &ValidationExpr{Values: vals}
+		}
+		return &UserTypeExpr{TypeName: name, AttributeExpr: att}
+	}
+	shared := func(name, path string, ref UserType) UserType {
+		att := &AttributeExpr{Type: &Object{}}
+		if ref != nil {
+			att.Type = &Object{&NamedAttributeExpr{Name: "ref", Attribute: &AttributeExpr{Type: &Array{ElemType: &AttributeExpr{Type: ref}}}}}
+		}
+		if path != "" {
+			att.Meta = MetaExpr{"struct:pkg:path": []string{path}}
+		}
+		return &UserTypeExpr{TypeName: name, AttributeExpr: att}
+	}
+	address := shared("Address", "types", nil)
+	local := shared("Local", "", nil)
+	attr := &AttributeExpr{}
 	cases := map[string]struct {
 		api      *APIExpr
+		types    []UserType
+		meta     []*metaKey
 		expected *eval.ValidationErrors
 	}{
 		"no error": {
@@ -26,11 +48,121 @@ func TestRootExprValidate(t *testing.T) {
 				Errors: []error{fmt.Errorf("Missing API declaration")},
 			},
 		},
+		"enum constants": {
+			api:   &APIExpr{Name: "foo"},
+			types: []UserType{enumConst("Color", String, "red"), enumConst("Level", Int, 1, 2)},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"enum constants invalid type": {
+			api:   &APIExpr{Name: "foo"},
+			types: []UserType{enumConst("Ratio", Float64, 0.5)},
+			expected: &eval.ValidationErrors{
+				Errors: []error{fmt.Errorf("type \"Ratio\" uses EnumConstants but is not a string or integer type")},
+			},
+		},
+		"enum constants missing enum": {
+			api:   &APIExpr{Name: "foo"},
+			types: []UserType{enumConst("Color", String)},
+			expected: &eval.ValidationErrors{
+				Errors: []error{fmt.Errorf("type \"Color\" uses EnumConstants but does not define an Enum validation")},
+			},
+		},
+		"shared types": {
+			api:   &APIExpr{Name: "foo"},
+			types: []UserType{address, shared("User", "types", address)},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"shared type invalid path": {
+			api:   &APIExpr{Name: "foo"},
+			types: []UserType{shared("User", "../types", nil)},
+			expected: &eval.ValidationErrors{
+				Errors: []error{fmt.Errorf("type \"User\": invalid \"struct:pkg:path\" value \"../types\", the path must be relative and its last element must be a valid Go package name")},
+			},
+		},
+		"shared type references service type": {
+			api:   &APIExpr{Name: "foo"},
+			types: []UserType{local, shared("User", "types", local)},
+			expected: &eval.ValidationErrors{
+				Errors: []error{fmt.Errorf("type \"User\" is generated in package \"types\" but references type \"Local\" which is not")},
+			},
+		},
+		"naming": {
+			api: &APIExpr{Name: "foo", Meta: MetaExpr{
+				"goa:naming:initialisms": {"SKU", "-OK"},
+				"goa:naming:acronyms":    {"title"},
+				"goa:naming:prefix":      {"acme_"},
+			}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"invalid naming": {
+			api: &APIExpr{Name: "foo", Meta: MetaExpr{
+				"goa:naming:initialisms": {"S-KU", "-"},
+				"goa:naming:acronyms":    {"lower"},
+				"goa:naming:prefix":      {""},
+			}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf("invalid \"goa:naming:initialisms\" value \"S-KU\", initialisms must only contain ASCII letters and digits"),
+					fmt.Errorf("invalid \"goa:naming:initialisms\" value \"-\", initialisms cannot be empty"),
+					fmt.Errorf("invalid \"goa:naming:acronyms\" value \"lower\", must be \"upper\" or \"title\""),
+					fmt.Errorf("invalid \"goa:naming:prefix\" value, prefixes cannot be empty"),
+				},
+			},
+		},
+		"meta not strict": {
+			api:  &APIExpr{Name: "foo"},
+			meta: []*metaKey{{exp: attr, key: "struct:feild:name"}},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"strict meta": {
+			api: &APIExpr{Name: "foo", Meta: MetaExpr{
+				"goa:meta:strict": {"plugin:option", "myplugin:"},
+			}},
+			meta: []*metaKey{
+				{exp: attr, key: "struct:field:name"},
+				{exp: attr, key: "struct:tag:json"},
+				{exp: attr, key: "plugin:option"},
+				{exp: attr, key: "myplugin:foo"},
+			},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"strict meta unknown keys": {
+			api: &APIExpr{Name: "foo", Meta: MetaExpr{
+				"goa:meta:strict": nil,
+			}},
+			meta: []*metaKey{
+				{exp: attr, key: "struct:feild:name"},
+				{exp: attr, key: "struct:tags:json"},
+				{exp: attr, key: "struct:tag:"},
+				{exp: attr, key: "foo:bar"},
+				{exp: attr, key: "foo:bar"},
+			},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf("unknown Meta key \"struct:feild:name\", did you mean \"struct:field:name\"? (StrictMeta is set)"),
+					fmt.Errorf("unknown Meta key \"struct:tags:json\", did you mean \"struct:tag:json\"? (StrictMeta is set)"),
+					fmt.Errorf("unknown Meta key \"struct:tag:\" (StrictMeta is set)"),
+					fmt.Errorf("unknown Meta key \"foo:bar\" (StrictMeta is set)"),
+				},
+			},
+		},
 	}
 
 	for k, tc := range cases {
 		e := RootExpr{
-			API: tc.api,
+			API:      tc.api,
+			Types:    tc.types,
+			metaKeys: tc.meta,
 		}
 		if actual := e.Validate().(*eval.ValidationErrors); len(tc.expected.Errors) != len(actual.Errors) {
 			t.Errorf("%s: expected the number of error values to match %d got %d ", k, len(tc.expected.Errors), len(actual.Errors))
@@ -25,6 +25,21 @@ type (
 		Services []string
 		// Hosts list the server hosts.
 		Hosts []*HostExpr
+		// TLS describes the TLS requirements of the server, nil if the
+		// server does not require TLS.
+		TLS *TLSExpr
+	}
+
+	// TLSExpr describes the TLS requirements of a server.
+	TLSExpr struct {
+		// Server that requires TLS.
+		Server *ServerExpr
+		// ClientAuth is the policy for the client certificates: "" if
+		// the server does not ask for them, "request" if the server
+		// verifies the certificates presented by the clients and
+		// "require" if the clients must present a valid certificate
+		// (mutual TLS).
+		ClientAuth string
 	}
 
 	// HostExpr describes a server host.
@@ -40,6 +55,21 @@ type (
 		URIs []URIExpr
 		// Variables defines the URI variables if any.
 		Variables *AttributeExpr
+		// Environments lists the environments served by the host.
+		Environments []*EnvironmentExpr
+	}
+
+	// EnvironmentExpr describes a deployment environment (e.g. "staging")
+	// served by a host.
+	EnvironmentExpr struct {
+		// Name of environment
+		Name string
+		// Host that serves the environment.
+		Host *HostExpr
+		// Variables lists the values of the host URI variables used in
+		// the environment indexed by variable name. The variables that
+		// are not listed use their default value.
+		Variables map[string]interface{}
 	}
 
 	// URIExpr represents a parameterized URI.
@@ -60,9 +90,30 @@ func (s *ServerExpr) Validate() error {
 			verr.Add(s, "service %q undefined", svc)
 		}
 	}
+	if s.TLS != nil {
+		verr.Merge(s.TLS.Validate().(*eval.ValidationErrors))
+	}
+	envs := make(map[string]string)
+	for _, h := range s.Hosts {
+		for _, e := range h.Environments {
+			if other, ok := envs[e.Name]; ok && other != h.Name {
+				verr.Add(s, "environment %q is served by both hosts %q and %q", e.Name, other, h.Name)
+			}
+			envs[e.Name] = h.Name
+		}
+	}
 	return verr
 }
 
+// Environments returns the environments served by the server hosts.
+func (s *ServerExpr) Environments() []*EnvironmentExpr {
+	var envs []*EnvironmentExpr
+	for _, h := range s.Hosts {
+		envs = append(envs, h.Environments...)
+	}
+	return envs
+}
+
 // Finalize initializes the server services and/or host with default values if
 // not set explicitly in the design.
 func (s *ServerExpr) Finalize() {
@@ -102,7 +153,7 @@ func (s *ServerExpr) Finalize() {
 
 // Schemes returns the list of transport schemes used by all the server
 // endpoints. The possible values for the elements of the returned slice are
-// "http", "https", "grpc" and "grpcs".
+// "http", "https", "grpc", "grpcs" and "unix".
 func (s *ServerExpr) Schemes() []string {
 	schemes := make(map[string]struct{})
 	for _, h := range s.Hosts {
@@ -120,7 +171,7 @@ func (s *ServerExpr) Schemes() []string {
 	return ss
 }
 
-var validSchemes = map[string]struct{}{"http": {}, "https": {}, "grpc": {}, "grpcs": {}}
+var validSchemes = map[string]struct{}{"http": {}, "https": {}, "grpc": {}, "grpcs": {}, "unix": {}}
 
 // Validate validates the host.
 func (h *HostExpr) Validate() error {
@@ -136,9 +187,11 @@ func (h *HostExpr) Validate() error {
 			continue
 		}
 		if pu.Scheme == "" {
-			verr.Add(h, "missing scheme for URI %q, scheme must be one of 'http', 'https', 'grpc' or 'grpcs'", u)
+			verr.Add(h, "missing scheme for URI %q, scheme must be one of 'http', 'https', 'grpc', 'grpcs' or 'unix'", u)
 		} else if _, ok := validSchemes[pu.Scheme]; !ok {
-			verr.Add(h, "invalid scheme for URI %q, scheme must be one of 'http', 'https', 'grpc' or 'grpcs'", u)
+			verr.Add(h, "invalid scheme for URI %q, scheme must be one of 'http', 'https', 'grpc', 'grpcs' or 'unix'", u)
+		} else if pu.Scheme == "unix" && pu.Host+pu.Path == "" {
+			verr.Add(h, "missing socket path for URI %q, e.g. unix:///var/run/svc.sock", u)
 		}
 	}
 	if h.Variables != nil {
@@ -155,9 +208,96 @@ func (h *HostExpr) Validate() error {
 			}
 		}
 	}
+	seen := make(map[string]struct{})
+	for _, e := range h.Environments {
+		if _, ok := seen[e.Name]; ok {
+			verr.Add(h, "environment %q is defined more than once", e.Name)
+		}
+		seen[e.Name] = struct{}{}
+		verr.Merge(e.Validate().(*eval.ValidationErrors))
+	}
+	return verr
+}
+
+// EvalName returns the name returned in error messages.
+func (t *TLSExpr) EvalName() string {
+	return "TLS of " + t.Server.EvalName()
+}
+
+// Validate makes sure the client authentication policy is valid.
+func (t *TLSExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	switch t.ClientAuth {
+	case "", "request", "require":
+	default:
+		verr.Add(t, "invalid client authentication %q, must be one of 'request' or 'require'", t.ClientAuth)
+	}
 	return verr
 }
 
+// EvalName returns the name returned in error messages.
+func (e *EnvironmentExpr) EvalName() string {
+	return fmt.Sprintf("environment %q of %s", e.Name, e.Host.EvalName())
+}
+
+// Validate makes sure the environment variables are defined by the host and
+// that their values are valid.
+func (e *EnvironmentExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	names := make([]string, 0, len(e.Variables))
+	for n := range e.Variables {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		val := e.Variables[n]
+		att := e.Host.Attribute().Find(n)
+		if att == nil {
+			verr.Add(e, "URI variable %q is not defined by the host", n)
+			continue
+		}
+		if !att.Type.IsCompatible(val) {
+			verr.Add(e, "value %#v of URI variable %q is not compatible with type %s", val, n, att.Type.Name())
+			continue
+		}
+		if att.Validation != nil && len(att.Validation.Values) > 0 {
+			var found bool
+			for _, v := range att.Validation.Values {
+				if v == val {
+					found = true
+					break
+				}
+			}
+			if !found {
+				verr.Add(e, "value %#v of URI variable %q must be one of %v", val, n, att.Validation.Values)
+			}
+		}
+	}
+	return verr
+}
+
+// URI returns u with the host variables replaced with their values in the
+// environment. The variables that the environment does not set are replaced
+// with their default value or with the first value of their enum validation.
+func (e *EnvironmentExpr) URI(u URIExpr) string {
+	ustr := string(u)
+	for _, p := range u.Params() {
+		val, ok := e.Variables[p]
+		if !ok {
+			att := e.Host.Attribute().Find(p)
+			if att == nil {
+				continue
+			}
+			val = att.DefaultValue
+			if val == nil && att.Validation != nil && len(att.Validation.Values) > 0 {
+				val = att.Validation.Values[0]
+			}
+		}
+		ustr = strings.Replace(ustr, "{"+p+"}", fmt.Sprintf("%v", val), -1)
+	}
+	return ustr
+}
+
 // Finalize makes sure Variables is set.
 func (h *HostExpr) Finalize() {
 	if h.Variables == nil {
@@ -180,7 +320,7 @@ func (h *HostExpr) Attribute() *AttributeExpr {
 
 // Schemes returns the list of transport schemes defined for the host. The
 // possible values for the elements of the returned slice are "http", "https",
-// "grpc" and "grpcs".
+// "grpc", "grpcs" and "unix".
 func (h *HostExpr) Schemes() []string {
 	schemes := make(map[string]struct{})
 	for _, uri := range h.URIs {
@@ -198,6 +338,8 @@ func (h *HostExpr) Schemes() []string {
 			schemes["grpcs"] = struct{}{}
 		case strings.HasPrefix(ustr, "grpc"):
 			schemes["grpc"] = struct{}{}
+		case strings.HasPrefix(ustr, "unix"):
+			schemes["unix"] = struct{}{}
 		}
 	}
 	ss := make([]string, len(schemes))
@@ -211,9 +353,9 @@ func (h *HostExpr) Schemes() []string {
 }
 
 // HasHTTPScheme returns true if at least one of the URIs in the host
-// expression define "http" or "https" scheme.
+// expression define "http", "https" or "unix" scheme.
 func (h *HostExpr) HasHTTPScheme() bool {
-	for _, s := range []string{"http", "https"} {
+	for _, s := range []string{"http", "https", "unix"} {
 		for _, sch := range h.Schemes() {
 			if s == sch {
 				return true
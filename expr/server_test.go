@@ -41,6 +41,7 @@ func TestServerExprValidate(t *testing.T) {
 	cases := map[string]struct {
 		hosts    []*HostExpr
 		services []string
+		tls      *TLSExpr
 		expected *eval.ValidationErrors
 	}{
 		"no error": {
@@ -78,6 +79,25 @@ func TestServerExprValidate(t *testing.T) {
 				},
 			},
 		},
+		"environment served by two hosts": {
+			hosts: []*HostExpr{
+				envHost(foo, "staging"),
+				envHost(bar, "staging"),
+			},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf("environment %q is served by both hosts %q and %q", "staging", foo, bar),
+				},
+			},
+		},
+		"invalid client authentication": {
+			tls: &TLSExpr{ClientAuth: "always"},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					fmt.Errorf("invalid client authentication %q, must be one of 'request' or 'require'", "always"),
+				},
+			},
+		},
 		"error in both": {
 			hosts: []*HostExpr{
 				{
@@ -110,6 +130,7 @@ func TestServerExprValidate(t *testing.T) {
 		s := ServerExpr{
 			Hosts:    tc.hosts,
 			Services: tc.services,
+			TLS:      tc.tls,
 		}
 		if actual := s.Validate().(*eval.ValidationErrors); len(tc.expected.Errors) != len(actual.Errors) {
 			t.Errorf("%s: expected the number of error values to match %d got %d ", k, len(tc.expected.Errors), len(actual.Errors))
@@ -133,6 +154,8 @@ func TestHostExprValidate(t *testing.T) {
 		malformedURI     = URIExpr("http://%")
 		missingSchemeURI = URIExpr("example.com")
 		invalidSchemeURI = URIExpr("ftp:example.com")
+		unixURI          = URIExpr("unix:///var/run/svc.sock")
+		missingPathURI   = URIExpr("unix://")
 		validURIs        = []URIExpr{
 			validURI,
 		}
@@ -175,8 +198,9 @@ func TestHostExprValidate(t *testing.T) {
 		}
 		errNoURI                          = fmt.Errorf("host must defined at least one URI")
 		errMalformedURI                   = fmt.Errorf("malformed URI %q", malformedURI)
-		errMissingSchemeURI               = fmt.Errorf("missing scheme for URI %q, scheme must be one of 'http', 'https', 'grpc' or 'grpcs'", missingSchemeURI)
-		errInvalidSchemeURI               = fmt.Errorf("invalid scheme for URI %q, scheme must be one of 'http', 'https', 'grpc' or 'grpcs'", invalidSchemeURI)
+		errMissingSchemeURI               = fmt.Errorf("missing scheme for URI %q, scheme must be one of 'http', 'https', 'grpc', 'grpcs' or 'unix'", missingSchemeURI)
+		errInvalidSchemeURI               = fmt.Errorf("invalid scheme for URI %q, scheme must be one of 'http', 'https', 'grpc', 'grpcs' or 'unix'", invalidSchemeURI)
+		errMissingPath                    = fmt.Errorf("missing socket path for URI %q, e.g. unix:///var/run/svc.sock", missingPathURI)
 		errInvalidType                    = fmt.Errorf("invalid type for URI variable %q: type must be a primitive", bar)
 		errNoDefaultValueOrEnumValidation = fmt.Errorf("URI variable %q must have a default value or an enum validation", foo)
 	)
@@ -224,6 +248,20 @@ func TestHostExprValidate(t *testing.T) {
 				},
 			},
 		},
+		"unix socket": {
+			uris: []URIExpr{unixURI},
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"missing socket path": {
+			uris: []URIExpr{missingPathURI},
+			expected: &eval.ValidationErrors{
+				Errors: []error{
+					errMissingPath,
+				},
+			},
+		},
 		"invalid type for uri variable": {
 			uris: validURIs,
 			variables: attribute(objectNonPrimitive(map[string]int{
@@ -318,3 +356,80 @@ func TestHostExprAttribute(t *testing.T) {
 		}
 	}
 }
+
+func TestEnvironmentExprValidate(t *testing.T) {
+	variables := &AttributeExpr{Type: &Object{
+		{Name: "version", Attribute: &AttributeExpr{Type: String, DefaultValue: "v1"}},
+		{Name: "region", Attribute: &AttributeExpr{Type: String, Validation: &ValidationExpr{Values: []interface{}{"us", "eu"}}}},
+	}}
+	cases := map[string]struct {
+		variables map[string]interface{}
+		expected  []string
+	}{
+		"no variable": {
+			expected: []string{},
+		},
+		"valid": {
+			variables: map[string]interface{}{"version": "v2", "region": "eu"},
+			expected:  []string{},
+		},
+		"undefined variable": {
+			variables: map[string]interface{}{"port": "8080"},
+			expected:  []string{`URI variable "port" is not defined by the host`},
+		},
+		"incompatible value": {
+			variables: map[string]interface{}{"version": 2},
+			expected:  []string{`value 2 of URI variable "version" is not compatible with type string`},
+		},
+		"value not in enum": {
+			variables: map[string]interface{}{"region": "asia"},
+			expected:  []string{`value "asia" of URI variable "region" must be one of [us eu]`},
+		},
+	}
+
+	for k, tc := range cases {
+		e := EnvironmentExpr{Name: "staging", Host: &HostExpr{Variables: variables}, Variables: tc.variables}
+		if actual := e.Validate().(*eval.ValidationErrors); len(tc.expected) != len(actual.Errors) {
+			t.Errorf("%s: expected the number of error values to match %d got %d ", k, len(tc.expected), len(actual.Errors))
+		} else {
+			for i, err := range actual.Errors {
+				if err.Error() != tc.expected[i] {
+					t.Errorf("%s: got %q, expected %q at index %d", k, err.Error(), tc.expected[i], i)
+				}
+			}
+		}
+	}
+}
+
+func TestEnvironmentExprURI(t *testing.T) {
+	variables := &AttributeExpr{Type: &Object{
+		{Name: "version", Attribute: &AttributeExpr{Type: String, DefaultValue: "v1"}},
+		{Name: "region", Attribute: &AttributeExpr{Type: String, Validation: &ValidationExpr{Values: []interface{}{"us", "eu"}}}},
+	}}
+	uri := URIExpr("https://{region}.example.com/{version}")
+	cases := map[string]struct {
+		variables map[string]interface{}
+		expected  string
+	}{
+		"defaults":   {expected: "https://us.example.com/v1"},
+		"region":     {variables: map[string]interface{}{"region": "eu"}, expected: "https://eu.example.com/v1"},
+		"all values": {variables: map[string]interface{}{"region": "eu", "version": "v2"}, expected: "https://eu.example.com/v2"},
+	}
+
+	for k, tc := range cases {
+		e := EnvironmentExpr{Name: "staging", Host: &HostExpr{Variables: variables}, Variables: tc.variables}
+		if actual := e.URI(uri); actual != tc.expected {
+			t.Errorf("%s: got %q, expected %q", k, actual, tc.expected)
+		}
+	}
+}
+
+// envHost returns a host with the given name that serves the given
+// environments.
+func envHost(name string, envs ...string) *HostExpr {
+	h := &HostExpr{Name: name, URIs: []URIExpr{"http://example.com"}}
+	for _, e := range envs {
+		h.Environments = append(h.Environments, &EnvironmentExpr{Name: e, Host: h})
+	}
+	return h
+}
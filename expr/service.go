@@ -2,6 +2,8 @@ package expr
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -19,6 +21,8 @@ type (
 		Docs *DocsExpr
 		// Methods is the list of service methods.
 		Methods []*MethodExpr
+		// Webhooks is the list of events emitted by the service.
+		Webhooks []*WebhookExpr
 		// Errors list the errors common to all the service methods.
 		Errors []*ErrorExpr
 		// Requirements contains the security requirements that apply to
@@ -70,6 +74,13 @@ func (s *ServiceExpr) Error(name string) *ErrorExpr {
 	return Root.Error(name)
 }
 
+// MemoizesViews returns true if the service is marked with the MemoizeViews
+// DSL.
+func (s *ServiceExpr) MemoizesViews() bool {
+	_, ok := s.Meta["goa:memoize"]
+	return ok
+}
+
 // Hash returns a unique hash value for s.
 func (s *ServiceExpr) Hash() string {
 	return "_service_+" + s.Name
@@ -85,6 +96,51 @@ func (s *ServiceExpr) Validate() error {
 			}
 		}
 	}
+	verr.Merge(s.validateCLIAliases())
+	return verr
+}
+
+// validateCLIAliases makes sure the command-line aliases of the service and of
+// its methods set with the "cli:alias" metadata are valid and do not conflict
+// with the names or aliases of the other services and methods.
+func (s *ServiceExpr) validateCLIAliases() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	names := make(map[string]string)
+	for _, svc := range Root.Services {
+		names[svc.Name] = svc.Name
+	}
+	for _, svc := range Root.Services {
+		for _, a := range svc.Meta["cli:alias"] {
+			if svc == s {
+				if a == "" || strings.ContainsAny(a, " \t\n") {
+					verr.Add(s, "invalid CLI alias %q of service %q, aliases cannot be empty or contain spaces", a, s.Name)
+					continue
+				}
+				if other, ok := names[a]; ok {
+					verr.Add(s, "CLI alias %q of service %q is already used by service %q", a, s.Name, other)
+					continue
+				}
+			}
+			names[a] = svc.Name
+		}
+	}
+	names = make(map[string]string)
+	for _, m := range s.Methods {
+		names[m.Name] = m.Name
+	}
+	for _, m := range s.Methods {
+		for _, a := range m.Meta["cli:alias"] {
+			if a == "" || strings.ContainsAny(a, " \t\n") {
+				verr.Add(m, "invalid CLI alias %q of method %q of service %q, aliases cannot be empty or contain spaces", a, m.Name, s.Name)
+				continue
+			}
+			if other, ok := names[a]; ok {
+				verr.Add(m, "CLI alias %q of method %q of service %q is already used by method %q", a, m.Name, s.Name, other)
+				continue
+			}
+			names[a] = m.Name
+		}
+	}
 	return verr
 }
 
@@ -115,9 +171,30 @@ func (e *ErrorExpr) Validate() error {
 		}
 		return nil
 	})
+	if ra, ok := e.AttributeExpr.Meta["goa:error:retryafter"]; ok && len(ra) > 0 {
+		if d, err := time.ParseDuration(ra[0]); err != nil {
+			verr.Add(e, "invalid retry-after delay %q of error %q: %s", ra[0], e.Name, err)
+		} else if d <= 0 || d%time.Second != 0 {
+			verr.Add(e, "retry-after delay %q of error %q must be a positive whole number of seconds", ra[0], e.Name)
+		}
+		if e.AttributeExpr.Type != ErrorResult {
+			verr.Add(e, "error %q defines a retry-after delay but does not use the ErrorResult type", e.Name)
+		}
+	}
 	return verr
 }
 
+// RetryAfter returns the delay after which the requests that fail with the
+// error may be retried as defined with the RetryAfter DSL, zero if there is
+// none.
+func (e *ErrorExpr) RetryAfter() time.Duration {
+	if ra, ok := e.AttributeExpr.Meta["goa:error:retryafter"]; ok && len(ra) > 0 {
+		d, _ := time.ParseDuration(ra[0])
+		return d
+	}
+	return 0
+}
+
 // Finalize makes sure the error type is a user type since it has to generate a
 // Go error.
 // Note: this may produce a user type with an attribute that is not an object!
@@ -94,6 +94,14 @@ func TestServiceExprValidate(t *testing.T) {
 		Error string
 	}{
 		{"service errors", testdata.ServiceErrorDSL, `attribute: attribute "a" with 'struct:error:name' in the meta must be required in "ServiceError" type`},
+		{"webhook without payload", testdata.WebhookWithoutPayloadDSL, `service "Service" webhook "created": webhook must define a payload`},
+		{"duplicate webhook", testdata.DuplicateWebhookDSL, `service "Service" webhook "created": webhook "created" is defined multiple times
+service "Service" webhook "created": webhook "created" is defined multiple times`},
+		{"invalid cli alias", testdata.InvalidCLIAliasDSL, `service "Service": CLI alias "Other" of service "Service" is already used by service "Other"
+service "Service" method "list": invalid CLI alias "l s" of method "list" of service "Service", aliases cannot be empty or contain spaces
+service "Service" method "show": CLI alias "ls" of method "show" of service "Service" is already used by method "list"
+service "Service" method "show": CLI alias "list" of method "show" of service "Service" is already used by method "list"
+service "Other": CLI alias "svc" of service "Other" is already used by service "Service"`},
 	}
 
 	for _, tc := range cases {
@@ -121,6 +129,11 @@ attribute: attribute "a" with 'struct:error:name' in the meta must be required i
 attribute: type "ErrorType" is used to define multiple errors and must identify the attribute containing error name. Use Meta with the key 'struct:error:name' on the error name attribute
 attribute: type "ErrorType" is used to define multiple errors and must identify the attribute containing error name. Use Meta with the key 'struct:error:name' on the error name attribute`,
 		},
+		{"invalid-retry-after", testdata.InvalidRetryAfterDSL,
+			`attribute: invalid retry-after delay "soon" of error "invalid_duration": time: invalid duration "soon"
+attribute: retry-after delay "1500ms" of error "sub_second" must be a positive whole number of seconds
+attribute: error "custom_type" defines a retry-after delay but does not use the ErrorResult type`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -37,6 +37,68 @@ var DuplicateWCRouteDSL = func() {
 	})
 }
 
+var VersionedRouteDSL = func() {
+	Service("VersionedRoute", func() {
+		Method("MethodV1", func() {
+			HTTP(func() {
+				GET("/")
+				APIVersion("1")
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				APIVersion("2")
+			})
+		})
+	})
+}
+
+var DuplicateVersionRouteDSL = func() {
+	Service("InvalidVersionedRoute", func() {
+		Method("MethodV1", func() {
+			HTTP(func() {
+				GET("/")
+				APIVersion("1")
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				APIVersion("1")
+			})
+		})
+	})
+}
+
+var ConstrainedRouteDSL = func() {
+	Service("ConstrainedRoute", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Pattern("^[a-z]+$")
+				})
+			})
+			HTTP(func() {
+				GET("/{id:[0-9]+}")
+			})
+		})
+	})
+}
+
+var ConstrainedIntRouteDSL = func() {
+	Service("InvalidConstrainedRoute", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+			HTTP(func() {
+				GET("/{id:[0-9]+}")
+			})
+		})
+	})
+}
+
 var EndpointBodyAsPayloadProp = func() {
 	Service("Service", func() {
 		Method("Method", func() {
@@ -150,6 +212,194 @@ var EndpointExtendToken = func() {
 	})
 }
 
+var EndpointIdempotent = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Idempotent()
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var EndpointIdempotentPrimitivePayload = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Idempotent()
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var EndpointRequestContent = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				POST("/")
+				Body(func() {
+					Attribute("name")
+				})
+				RequestContent("application/xml", func() {
+					Body(func() {
+						Attribute("name")
+						Attribute("tags")
+					})
+				})
+			})
+		})
+	})
+}
+
+var EndpointRequestContentInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				RequestContent("application/xml", func() {
+					Body(func() {
+						Attribute("name")
+					})
+				})
+				RequestContent("application/xml; charset=utf-8", func() {})
+			})
+		})
+	})
+}
+
+var EndpointViewParam = func() {
+	var RT = ResultType("application/vnd.rt", func() {
+		Attribute("a", String)
+		Attribute("b", String)
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(RT)
+			HTTP(func() {
+				GET("/")
+				ViewParam()
+			})
+		})
+	})
+}
+
+var EndpointViewParamFieldMask = func() {
+	var RT = ResultType("application/vnd.rt", func() {
+		Attribute("a", String)
+		Attribute("b", String)
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			FieldMask()
+			Result(RT)
+			HTTP(func() {
+				GET("/")
+				ViewParam("fields")
+			})
+		})
+	})
+}
+
+var EndpointViewParamSingleView = func() {
+	var RT = ResultType("application/vnd.rt", func() {
+		Attribute("a", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("view", String)
+			})
+			Result(RT)
+			HTTP(func() {
+				GET("/")
+				Param("view")
+				ViewParam()
+			})
+		})
+	})
+}
+
+var EndpointETagInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("version", Int, func() {
+					ETag()
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusPreconditionFailed)
+			})
+		})
+	})
+}
+
+var EndpointEnumConstParams = func() {
+	var Status = Type("Status", String, func() {
+		Enum("open", "closed")
+		EnumConstants()
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("status", Status)
+				Attribute("history", ArrayOf(Status))
+			})
+			Result(func() {
+				Attribute("status", Status)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("status")
+				Header("history")
+				Response(StatusOK, func() {
+					Header("status")
+				})
+			})
+		})
+	})
+}
+
+var EndpointCallbackInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Callback("onEvent", "http://example.com/{$foo}")
+				Callback("onEvent", "$request.body#/url")
+			})
+		})
+	})
+}
+
 var FinalizeEndpointBodyAsExtendedTypeDSL = func() {
 	var EntityData = Type("EntityData", func() {
 		Attribute("name", String)
@@ -218,3 +468,484 @@ var GRPCEndpointWithAnyType = func() {
 		})
 	})
 }
+
+var GRPCEndpointWithInvalidRPCTags = func() {
+	var Nested = Type("Nested", func() {
+		Field(1, "a", String)
+		Field(1, "b", String)
+		Field(19500, "c", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Field(1, "nested", MapOf(String, Nested))
+				Field(0, "zero", Int)
+				Field(536870912, "too_large", Int)
+			})
+			Result(func() {
+				Field(1, "nested", ArrayOf(Nested))
+				Field(19000, "reserved", Int)
+			})
+			GRPC(func() {
+				Message(func() {
+					Attribute("nested")
+					Attribute("zero")
+					Attribute("too_large")
+				})
+			})
+		})
+	})
+}
+
+var GRPCEndpointWithConflictingFieldMask = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			FieldMask(1)
+			Payload(func() {
+				Field(1, "field_mask", String)
+			})
+			Result(func() {
+				Field(1, "name", String)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var GRPCEndpointWithInvalidStreamResults = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(func() {
+				Field(1, "name", String)
+			})
+			GRPC(func() {
+				StreamResults()
+			})
+		})
+	})
+}
+
+var EndpointNDJSON = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				POST("/")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var EndpointNDJSONNoStream = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var EndpointExport = func() {
+	var Row = Type("Row", func() {
+		Attribute("id", Int)
+		Attribute("name", String)
+		Attribute("total", Float64)
+		Attribute("active", Boolean)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("year", Int)
+			})
+			StreamingResult(Row)
+			HTTP(func() {
+				POST("/")
+				Param("year")
+				Export("rows-{year}")
+			})
+		})
+	})
+}
+
+var EndpointExportUserTypes = func() {
+	var Status = Type("Status", String, func() {
+		Enum("open", "closed")
+		EnumConstants()
+	})
+	var Count = Type("Count", Int64)
+	var Row = Type("Row", func() {
+		Attribute("id", Int)
+		Attribute("status", Status)
+		Attribute("count", Count)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				Export()
+			})
+		})
+	})
+}
+
+var EndpointCanary = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Canary(10, "X-Canary")
+			})
+		})
+	})
+}
+
+var EndpointCanaryInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Canary(120)
+			})
+		})
+		Method("Method2", func() {
+			HTTP(func() {
+				GET("/2")
+				Canary(0)
+			})
+		})
+	})
+}
+
+var EndpointExportNoStream = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Export()
+			})
+		})
+	})
+}
+
+var EndpointExportInvalidRow = func() {
+	var Row = Type("Row", func() {
+		Attribute("id", Int)
+		Attribute("tags", ArrayOf(String))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				Export()
+			})
+		})
+	})
+}
+
+var EndpointExportResultType = func() {
+	var Row = ResultType("application/vnd.row", func() {
+		Attribute("id", Int)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				Export()
+			})
+		})
+	})
+}
+
+var EndpointExportInvalidFilename = func() {
+	var Row = Type("Row", func() {
+		Attribute("id", Int)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("year", Int)
+				Attribute("ids", ArrayOf(Int))
+			})
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				Param("year")
+				Param("ids")
+				Export("rows-{month}-{ids}")
+			})
+		})
+	})
+}
+
+var EndpointExportNDJSON = func() {
+	var Row = Type("Row", func() {
+		Attribute("id", Int)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				NDJSON()
+				Export()
+			})
+		})
+	})
+}
+
+var EndpointNDJSONNoContent = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				NDJSON()
+				Response(StatusNoContent)
+			})
+		})
+	})
+}
+
+var EndpointHealthCheckConflict = func() {
+	API("Test", func() {
+		HealthCheck()
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/healthz")
+			})
+		})
+	})
+}
+
+var EndpointStrictStatusCodes = func() {
+	API("Test", func() {
+		HTTP(func() {
+			StrictStatusCodes()
+		})
+	})
+	Service("Service", func() {
+		Error("not_found")
+		HTTP(func() {
+			Response("not_found", StatusNotFound)
+		})
+		Method("Method", func() {
+			Error("conflict")
+			HTTP(func() {
+				GET("/")
+				Response("conflict", StatusConflict)
+			})
+		})
+	})
+}
+
+var EndpointStrictStatusCodesUnmapped = func() {
+	API("Test", func() {
+		HTTP(func() {
+			StrictStatusCodes()
+		})
+	})
+	Service("Service", func() {
+		Error("not_found")
+		Method("Method", func() {
+			Error("conflict")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var EndpointSignedURL = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("path", String)
+				Attribute("inline", Boolean)
+			})
+			HTTP(func() {
+				GET("/files/{*path}")
+				Param("inline")
+				SignedURL()
+			})
+		})
+	})
+}
+
+var EndpointSignedURLInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("exp", Int)
+			})
+			HTTP(func() {
+				POST("/files/{id}")
+				Param("exp:expires")
+				SignedURL()
+			})
+		})
+	})
+}
+
+var EndpointParamStyles = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("names", ArrayOf(String))
+				Attribute("tags", ArrayOf(String))
+				Attribute("filter", MapOf(String, String))
+				Attribute("labels", ArrayOf(String))
+				Attribute("codes", ArrayOf(Int))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Style(StylePipeDelimited)
+				})
+				Param("codes", func() {
+					Separator(";")
+				})
+				Param("names", func() {
+					Explode(false)
+				})
+				Param("tags", func() {
+					Style(StyleSpaceDelimited)
+				})
+				Param("filter", func() {
+					Style(StyleDeepObject)
+				})
+				Header("labels:X-Labels", func() {
+					Style(StyleSimple)
+				})
+			})
+		})
+	})
+}
+
+var EndpointParamStylesInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+				Attribute("filter", MapOf(String, String))
+				Attribute("sort", String)
+				Attribute("labels", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("id", func() {
+					Style(StyleSimple)
+				})
+				Param("name", func() {
+					Style(StylePipeDelimited)
+				})
+				Param("filter", func() {
+					Style(StyleDeepObject)
+					Explode(false)
+				})
+				Param("sort", func() {
+					Style("matrix")
+				})
+				Header("labels:X-Labels", func() {
+					Style(StyleForm)
+				})
+			})
+		})
+	})
+}
+
+var EndpointParamSeparatorInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("ids", ArrayOf(Int))
+				Attribute("name", String)
+				Attribute("labels", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("id", func() {
+					Separator(";")
+				})
+				Param("ids", func() {
+					Separator("")
+				})
+				Param("name", func() {
+					Separator(";")
+				})
+				Header("labels:X-Labels", func() {
+					Style(StyleSimple)
+					Separator(";")
+				})
+			})
+		})
+	})
+}
+
+var EndpointMapParamsObject = func() {
+	var Range = Type("Range", func() {
+		Attribute("min", Int)
+		Attribute("tags", ArrayOf(String))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("ranges", MapOf(Int, Range))
+			})
+			HTTP(func() {
+				GET("/")
+				MapParams("ranges")
+			})
+		})
+	})
+}
+
+var EndpointMapParamsInvalid = func() {
+	var Range = Type("Range", func() {
+		Attribute("min", Int)
+		Attribute("bounds", func() {
+			Attribute("max", Int)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("ranges", MapOf(Int, Range))
+			})
+			HTTP(func() {
+				GET("/")
+				MapParams("ranges")
+			})
+		})
+		Method("Method2", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				GET("/2")
+				MapParams("name")
+			})
+		})
+		Method("Method3", func() {
+			Payload(MapOf(String, MapOf(String, String)))
+			HTTP(func() {
+				GET("/3")
+				MapParams()
+			})
+		})
+	})
+}
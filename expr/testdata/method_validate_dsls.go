@@ -18,6 +18,65 @@ var OAuth2 = OAuth2Security("authCode", func() {
 	Scope("api:read", "Read access")
 })
 
+var InvalidSunsetDSL = func() {
+	Service("InvalidSunsetService", func() {
+		Method("Method", func() {
+			Sunset("June 30th 2021")
+		})
+	})
+}
+
+var InvalidTimeoutDSL = func() {
+	Service("InvalidTimeoutService", func() {
+		Method("Invalid", func() {
+			Timeout("five seconds")
+		})
+		Method("Negative", func() {
+			Timeout("-5s")
+		})
+		Method("Streaming", func() {
+			Timeout("5s")
+			StreamingResult(String)
+		})
+	})
+}
+
+var InvalidDependencyDSL = func() {
+	Service("InvalidDependencyService", func() {
+		Dependency("tx", "*sql.Tx", "database/sql")
+		Method("Method", func() {
+			Dependency("tx", "*sqlx.Tx", "github.com/jmoiron/sqlx")
+			Dependency("1clock", "time.Time", "time")
+			Dependency("logger", "")
+		})
+	})
+}
+
+var InvalidTCPDSL = func() {
+	Service("InvalidTCPService", func() {
+		Method("NotStreaming", func() {
+			Result(String)
+			TCP()
+		})
+		Method("NoGRPC", func() {
+			StreamingResult(String)
+			TCP("proto")
+		})
+		Method("InvalidEncoding", func() {
+			StreamingResult(String)
+			TCP("xml")
+		})
+	})
+}
+
+var InvalidPermissionDSL = func() {
+	Service("InvalidPermissionService", func() {
+		Method("Method", func() {
+			Permission("orders:write", "")
+		})
+	})
+}
+
 var InvalidSecuritySchemesDSL = func() {
 	Service("InvalidSecuritySchemesService", func() {
 		Security(OAuth2, APIKeyAuth, func() {
@@ -40,3 +99,34 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var InvalidEventDSL = func() {
+	Service("InvalidEventService", func() {
+		Method("NoPayload", func() {
+			Event()
+		})
+		Method("Result", func() {
+			Event()
+			Payload(String)
+			Result(String)
+		})
+		Method("Streaming", func() {
+			Event()
+			StreamingPayload(String)
+		})
+	})
+}
+
+var InvalidRenamedFromDSL = func() {
+	Service("InvalidRenamedFromService", func() {
+		Method("Empty", func() {
+			RenamedFrom("")
+		})
+		Method("Taken", func() {
+			RenamedFrom("Empty")
+		})
+		Method("Valid", func() {
+			RenamedFrom("Previous")
+		})
+	})
+}
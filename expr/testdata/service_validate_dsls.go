@@ -27,6 +27,25 @@ var ValidErrorsDSL = func() {
 	})
 }
 
+var InvalidRetryAfterDSL = func() {
+	var AType = Type("AType", func() {
+		Attribute("a", String)
+	})
+	Service("InvalidRetryAfter", func() {
+		Method("Method", func() {
+			Error("invalid_duration", func() {
+				RetryAfter("soon")
+			})
+			Error("sub_second", func() {
+				RetryAfter("1500ms")
+			})
+			Error("custom_type", AType, func() {
+				RetryAfter("30s")
+			})
+		})
+	})
+}
+
 var InvalidStructErrorNameDSL = func() {
 	var Common = Type("Common", func() {
 		Attribute("a", Int, func() { // invalid type for struct:error:name
@@ -75,3 +94,37 @@ var ServiceErrorDSL = func() {
 		Method("Method", func() {})
 	})
 }
+
+var WebhookWithoutPayloadDSL = func() {
+	Service("Service", func() {
+		Webhook("created", func() {
+			Description("Webhook without payload.")
+		})
+	})
+}
+
+var DuplicateWebhookDSL = func() {
+	Service("Service", func() {
+		Webhook("created", func() {
+			Payload(String)
+		})
+		Webhook("created", func() {
+			Payload(String)
+		})
+	})
+}
+
+var InvalidCLIAliasDSL = func() {
+	Service("Service", func() {
+		Meta("cli:alias", "svc", "Other")
+		Method("list", func() {
+			Meta("cli:alias", "ls", "l s")
+		})
+		Method("show", func() {
+			Meta("cli:alias", "ls", "list")
+		})
+	})
+	Service("Other", func() {
+		Meta("cli:alias", "svc")
+	})
+}
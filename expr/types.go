@@ -3,8 +3,11 @@ package expr
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"time"
 
 	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -119,6 +122,14 @@ const (
 	ResultTypeKind
 	// AnyKind represents an unknown type.
 	AnyKind
+	// DurationKind represents a duration.
+	DurationKind
+	// UUIDKind represents a UUID.
+	UUIDKind
+	// TimestampKind represents a timestamp.
+	TimestampKind
+	// DecimalKind represents an arbitrary-precision decimal number.
+	DecimalKind
 )
 
 const (
@@ -157,6 +168,37 @@ const (
 
 	// Any is the type for an arbitrary JSON value (interface{} in Go).
 	Any = Primitive(AnyKind)
+
+	// Duration is the type for a duration encoded as a string using the Go
+	// (e.g. "300ms") or ISO 8601 (e.g. "PT5M") duration formats.
+	Duration = Primitive(DurationKind)
+
+	// UUID is the type for a RFC 4122 UUID encoded as a string (e.g.
+	// "6ba7b810-9dad-11d1-80b4-00c04fd430c8").
+	UUID = Primitive(UUIDKind)
+
+	// Timestamp is the type for a point in time. The wire format of
+	// timestamps is set with the TimestampFormat DSL and defaults to RFC
+	// 3339 strings (e.g. "2006-01-02T15:04:05Z").
+	Timestamp = Primitive(TimestampKind)
+
+	// Decimal is the type for an arbitrary-precision decimal number encoded
+	// as a string (e.g. "12.50").
+	Decimal = Primitive(DecimalKind)
+)
+
+const (
+	// TimestampRFC3339 is the wire format of timestamps encoded as RFC 3339
+	// strings.
+	TimestampRFC3339 = goa.TimestampRFC3339
+
+	// TimestampUnix is the wire format of timestamps encoded as numbers of
+	// seconds since the Unix epoch.
+	TimestampUnix = goa.TimestampUnix
+
+	// TimestampUnixMilli is the wire format of timestamps encoded as
+	// numbers of milliseconds since the Unix epoch.
+	TimestampUnixMilli = goa.TimestampUnixMilli
 )
 
 // Built-in composite types
@@ -347,6 +389,14 @@ func (p Primitive) Name() string {
 		return "bytes"
 	case Any:
 		return "any"
+	case Duration:
+		return "duration"
+	case UUID:
+		return "uuid"
+	case Timestamp:
+		return "timestamp"
+	case Decimal:
+		return "decimal"
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -357,6 +407,26 @@ func (p Primitive) IsCompatible(val interface{}) bool {
 	if p == Any {
 		return true
 	}
+	if p == Duration {
+		_, ok := DurationValue(val)
+		return ok
+	}
+	if p == Timestamp {
+		_, ok := TimestampValue(val)
+		return ok
+	}
+	if p == Decimal {
+		_, ok := DecimalValue(val)
+		return ok
+	}
+	if p == UUID {
+		v, ok := val.(string)
+		if !ok {
+			return false
+		}
+		_, err := goa.ParseUUID(v)
+		return err == nil
+	}
 	switch val.(type) {
 	case bool:
 		return p == Boolean
@@ -396,11 +466,85 @@ func (p Primitive) Example(r *Random) interface{} {
 		return r.String()
 	case Bytes:
 		return []byte(r.String())
+	case Duration:
+		return (time.Duration(r.Int()%3600) * time.Second).String()
+	case UUID:
+		var u goa.UUID
+		for i := range u {
+			u[i] = byte(r.Int())
+		}
+		u[6] = u[6]&0x0f | 0x40 // version 4
+		u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+		return u.String()
+	case Timestamp:
+		return time.Unix(1500000000+int64(r.Int()%100000000), 0).UTC().Format(time.RFC3339)
+	case Decimal:
+		return fmt.Sprintf("%d.%02d", r.Int()%10000, r.Int()%100)
 	default:
 		panic("unknown primitive type") // bug
 	}
 }
 
+// DurationValue returns the duration corresponding to val and true if val is
+// a time.Duration, an integer number of nanoseconds or a string using the Go
+// or ISO 8601 duration formats, false otherwise.
+func DurationValue(val interface{}) (time.Duration, bool) {
+	switch v := val.(type) {
+	case time.Duration:
+		return v, true
+	case int:
+		return time.Duration(v), true
+	case int64:
+		return time.Duration(v), true
+	case string:
+		d, err := goa.ParseDuration(v)
+		return d, err == nil
+	}
+	return 0, false
+}
+
+// TimestampValue returns the time corresponding to val and true if val is a
+// time.Time, an integer number of seconds since the Unix epoch or a RFC 3339
+// string, false otherwise.
+func TimestampValue(val interface{}) (time.Time, bool) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, true
+	case int:
+		return time.Unix(int64(v), 0).UTC(), true
+	case int64:
+		return time.Unix(v, 0).UTC(), true
+	case string:
+		t, err := goa.ParseTimestamp(v, TimestampRFC3339)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// DecimalValue returns the decimal representation of val and true if val is a
+// string holding a decimal number or an integer or floating point number,
+// false otherwise.
+func DecimalValue(val interface{}) (string, bool) {
+	var s string
+	switch v := val.(type) {
+	case string:
+		s = v
+	case int, int32, int64, uint, uint32, uint64:
+		s = fmt.Sprintf("%d", v)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return "", false
+	}
+	d, err := goa.ParseDecimal(s)
+	if err != nil {
+		return "", false
+	}
+	return d.String(), true
+}
+
 // Hash returns a unique hash value for p.
 func (p Primitive) Hash() string {
 	return p.Name()
@@ -684,11 +828,16 @@ func toReflectType(dtype DataType) reflect.Type {
 		return reflect.TypeOf(float32(0))
 	case Float64Kind:
 		return reflect.TypeOf(float64(0))
-	case StringKind:
+	case StringKind, DurationKind, UUIDKind, TimestampKind, DecimalKind:
 		return reflect.TypeOf("")
 	case BytesKind:
 		return reflect.TypeOf([]byte{})
-	case ObjectKind, UserTypeKind, ResultTypeKind:
+	case UserTypeKind, ResultTypeKind:
+		if IsPrimitive(dtype) {
+			return toReflectType(dtype.(UserType).Attribute().Type)
+		}
+		return reflect.TypeOf(map[string]interface{}{})
+	case ObjectKind:
 		return reflect.TypeOf(map[string]interface{}{})
 	case ArrayKind:
 		return reflect.SliceOf(toReflectType(dtype.(*Array).ElemType.Type))
@@ -91,7 +91,91 @@ func (u *UserTypeExpr) recExample(r *Random) *interface{} {
 	var ex interface{}
 	pex := &ex
 	r.Seen[u.ID()] = pex
-	actual := u.Type.Example(r)
+	actual := u.AttributeExpr.Example(r)
 	*pex = actual
 	return pex
 }
+
+// PatchedType returns the user type patched by dt if dt is a JSON merge patch
+// type created with the PatchOf DSL function, nil otherwise.
+func PatchedType(dt DataType) UserType {
+	ut, ok := dt.(UserType)
+	if !ok {
+		return nil
+	}
+	name, ok := ut.Attribute().Meta["goa:patch"]
+	if !ok || len(name) == 0 {
+		return nil
+	}
+	return Root.UserType(name[0])
+}
+
+// PackagePath returns the import path relative to the gen package of the
+// package that contains the Go type generated for dt as set with the
+// "struct:pkg:path" metadata, empty if dt is generated in the service packages.
+func PackagePath(dt DataType) string {
+	ut, ok := dt.(UserType)
+	if !ok {
+		return ""
+	}
+	if p := ut.Attribute().Meta["struct:pkg:path"]; len(p) > 0 {
+		return p[0]
+	}
+	return ""
+}
+
+// childUserTypes returns the user types referenced by att, the attributes of
+// the user types themselves are not visited.
+func childUserTypes(att *AttributeExpr) []UserType {
+	if att == nil {
+		return nil
+	}
+	switch dt := att.Type.(type) {
+	case UserType:
+		return []UserType{dt}
+	case *Object:
+		var uts []UserType
+		for _, nat := range *dt {
+			uts = append(uts, childUserTypes(nat.Attribute)...)
+		}
+		return uts
+	case *Array:
+		return childUserTypes(dt.ElemType)
+	case *Map:
+		return append(childUserTypes(dt.KeyType), childUserTypes(dt.ElemType)...)
+	case CompositeExpr:
+		return childUserTypes(dt.Attribute())
+	}
+	return nil
+}
+
+// IsEnumConstType returns true if dt is a user type that uses the
+// EnumConstants DSL function so that the generated code defines typed
+// constants for its enum values.
+func IsEnumConstType(dt DataType) bool {
+	ut, ok := dt.(UserType)
+	if !ok {
+		return false
+	}
+	_, ok = ut.Attribute().Meta["goa:enum:const"]
+	return ok
+}
+
+// InlineEnumConstType returns a copy of att using the base primitive type and
+// validations of the enum constant type if att uses one, att otherwise. The
+// transport types use the base type so that the generated transport code
+// converts the values to and from the typed constants of the service package.
+func InlineEnumConstType(att *AttributeExpr) *AttributeExpr {
+	if !IsEnumConstType(att.Type) {
+		return att
+	}
+	ut := att.Type.(UserType).Attribute()
+	inlined := DupAtt(att)
+	inlined.Type = ut.Type
+	if inlined.Validation == nil {
+		inlined.Validation = ut.Validation.Dup()
+	} else {
+		inlined.Validation.Merge(ut.Validation.Dup())
+	}
+	return inlined
+}
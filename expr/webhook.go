@@ -0,0 +1,85 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// WebhookExpr describes an event emitted by a service and sent to
+	// the URLs registered by the API consumers via HTTP POST requests.
+	WebhookExpr struct {
+		// DSLFunc contains the DSL used to initialize the expression.
+		eval.DSLFunc
+		// Name of webhook.
+		Name string
+		// Description of webhook for consumption by humans.
+		Description string
+		// Payload describes the event sent with the webhook.
+		Payload *AttributeExpr
+		// Body describes the HTTP request body of the webhook requests.
+		Body *AttributeExpr
+		// Service that owns webhook.
+		Service *ServiceExpr
+		// Meta is an arbitrary set of key/value pairs, see dsl.Meta
+		Meta MetaExpr
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (w *WebhookExpr) EvalName() string {
+	var prefix, suffix string
+	if w.Name != "" {
+		suffix = fmt.Sprintf("webhook %#v", w.Name)
+	} else {
+		suffix = "unnamed webhook"
+	}
+	if w.Service != nil {
+		prefix = w.Service.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Prepare makes sure the payload is initialized (to the Empty type if nil).
+func (w *WebhookExpr) Prepare() {
+	if w.Payload == nil {
+		w.Payload = &AttributeExpr{Type: Empty}
+	}
+}
+
+// Validate makes sure the webhook defines a valid payload and that its name is
+// unique in the service.
+func (w *WebhookExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if w.Payload.Type == Empty {
+		verr.Add(w, "webhook must define a payload")
+	} else {
+		verr.Merge(w.Payload.Validate("payload", w))
+	}
+	for _, o := range w.Service.Webhooks {
+		if o != w && o.Name == w.Name {
+			verr.Add(w, "webhook %q is defined multiple times", w.Name)
+			break
+		}
+	}
+	return verr
+}
+
+// Finalize makes sure the payload is an user type so that code generators can
+// name it and computes the HTTP request body.
+func (w *WebhookExpr) Finalize() {
+	w.Payload.Finalize()
+	if obj, ok := w.Payload.Type.(*Object); ok {
+		w.Payload.Type = &UserTypeExpr{
+			AttributeExpr: &AttributeExpr{
+				Type:         obj,
+				Description:  w.Payload.Description,
+				Validation:   w.Payload.Validation,
+				UserExamples: w.Payload.UserExamples,
+			},
+			TypeName: concat(w.Name, "Webhook", "Payload"),
+		}
+	}
+	w.Body = webhookBody(w)
+}
@@ -39,7 +39,10 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC client", "client", []*codegen.ImportSpec{
 				{Path: "context"},
+				{Path: "time"},
 				{Path: "google.golang.org/grpc"},
+				{Path: "google.golang.org/grpc/metadata"},
+				{Path: "google.golang.org/genproto/protobuf/field_mask"},
 				codegen.GoaImport(""),
 				codegen.GoaNamedImport("grpc", "goagrpc"),
 				codegen.GoaNamedImport("grpc/pb", "goapb"),
@@ -74,6 +77,15 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 				Data:   e,
 			})
 		}
+		for _, e := range data.Endpoints {
+			if e.ResultPages != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-result-iterator",
+					Source: clientResultIteratorT,
+					Data:   e,
+				})
+			}
+		}
 		for _, e := range data.Endpoints {
 			if e.ClientStream != nil {
 				if e.ClientStream.RecvConvert != nil {
@@ -123,9 +135,11 @@ func clientEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC client encoders and decoders", "client", []*codegen.ImportSpec{
 				{Path: "context"},
+				{Path: "io"},
 				{Path: "strconv"},
 				{Path: "google.golang.org/grpc"},
 				{Path: "google.golang.org/grpc/metadata"},
+				{Path: "google.golang.org/genproto/protobuf/field_mask"},
 				codegen.GoaImport(""),
 				codegen.GoaNamedImport("grpc", "goagrpc"),
 				{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
@@ -199,6 +213,10 @@ func New{{ .ClientStruct }}(cc *grpc.ClientConn, opts ...grpc.CallOption) *{{ .C
 const clientEndpointInitT = `{{ printf "%s calls the %q function in %s.%s interface." .Method.VarName .Method.VarName .PkgName .ClientInterface | comment }}
 func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 	return func(ctx context.Context, v interface{}) (interface{}, error) {
+	{{- if .Method.Timeout }}
+		ctx, cancel := context.WithTimeout(ctx, {{ .Method.Timeout }})
+		defer cancel()
+	{{- end }}
 		inv := goagrpc.NewInvoker(
 			Build{{ .Method.VarName }}Func(c.grpccli, c.opts...),
 			{{ if .PayloadRef }}Encode{{ .Method.VarName }}Request{{ else }}nil{{ end }},
@@ -220,7 +238,9 @@ func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 				{{- end }}
 			{{- end }}
 			case *goapb.ErrorResponse:
-				return nil, goagrpc.NewServiceError(message)
+				gerr := goagrpc.NewServiceError(message, goagrpc.DecodeFieldErrors(err)...)
+				gerr.RetryAfter = goagrpc.DecodeRetryAfter(err)
+				return nil, gerr
 			default:
 				return nil, goa.Fault(err.Error())
 			}
@@ -233,6 +253,66 @@ func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
 }
 `
 
+// input: EndpointData
+const clientResultIteratorT = `{{ printf "%s iterates over the elements of the result of the %q service %q endpoint as the pages streamed by the server are received." .ResultPages.IteratorName .ServiceName .Method.Name | comment }}
+type {{ .ResultPages.IteratorName }} struct {
+	ctx    context.Context
+	stream {{ .ResultPages.ClientInterface }}
+	page   []{{ .ResultPages.ElemRef }}
+}
+
+{{ printf "%sIter calls the %q function in %s.%s interface and returns an iterator over the elements of the result. The context controls the entire call, the method timeout does not apply." .Method.VarName .Method.VarName .PkgName .ClientInterface | comment }}
+func (c *{{ .ClientStruct }}) {{ .Method.VarName }}Iter(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}) (*{{ .ResultPages.IteratorName }}, error) {
+{{- if .PayloadRef }}
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	reqpb, err := Encode{{ .Method.VarName }}Request(ctx, p, &md)
+	if err != nil {
+		return nil, err
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	message := reqpb.({{ .Request.ClientConvert.TgtRef }})
+{{- else }}
+	message := &{{ .Request.ClientConvert.TgtName }}{}
+{{- end }}
+{{- if .Method.FieldMask }}
+	if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok && len(mask) > 0 {
+		message.FieldMask = &field_mask.FieldMask{Paths: mask}
+	}
+{{- end }}
+	stream, err := c.grpccli.{{ .Method.VarName }}(ctx, message, c.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &{{ .ResultPages.IteratorName }}{ctx: ctx, stream: stream}, nil
+}
+
+{{ comment "Next returns the next element of the result. It receives and decodes the next page if all the elements of the current page have been returned. Next returns io.EOF once all the elements have been returned and the gRPC status error if the call fails." }}
+func (it *{{ .ResultPages.IteratorName }}) Next() ({{ .ResultPages.ElemRef }}, error) {
+	var elem {{ .ResultPages.ElemRef }}
+	for len(it.page) == 0 {
+		message, err := it.stream.Recv()
+		if err != nil {
+			return elem, err
+		}
+		hdr, err := it.stream.Header()
+		if err != nil {
+			return elem, err
+		}
+		res, err := Decode{{ .Method.VarName }}Response(it.ctx, message, hdr, nil)
+		if err != nil {
+			return elem, err
+		}
+		it.page = res.({{ .ResultRef }})
+	}
+	elem = it.page[0]
+	it.page = it.page[1:]
+	return elem, nil
+}
+`
+
 // input: EndpointData
 const remoteMethodBuilderT = `{{ printf "Build%sFunc builds the remote method to invoke for %q service %q endpoint." .Method.VarName .ServiceName .Method.Name | comment }}
 func Build{{ .Method.VarName }}Func(grpccli {{ .PkgName }}.{{ .ClientInterface }}, cliopts ...grpc.CallOption) goagrpc.RemoteFunc {
@@ -240,10 +320,42 @@ func Build{{ .Method.VarName }}Func(grpccli {{ .PkgName }}.{{ .ClientInterface }
 		for _, opt := range cliopts {
 			opts = append(opts, opt)
 		}
+{{- if or .Method.FieldMask .ResultPages }}
+		message := &{{ .Request.ClientConvert.TgtName }}{}
+		if reqpb != nil {
+			message = reqpb.({{ .Request.ClientConvert.TgtRef }})
+		}
+	{{- if .Method.FieldMask }}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok && len(mask) > 0 {
+			message.FieldMask = &field_mask.FieldMask{Paths: mask}
+		}
+	{{- end }}
+	{{- if .ResultPages }}
+		stream, err := grpccli.{{ .Method.VarName }}(ctx, message, opts...)
+		if err != nil {
+			return nil, err
+		}
+		{{ comment "Gather the elements of all the pages streamed by the server." }}
+		resp := &{{ .ResultPages.MessageName }}{}
+		for {
+			page, err := stream.Recv()
+			if err == io.EOF {
+				return resp, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			resp.{{ .ResultPages.FieldName }} = append(resp.{{ .ResultPages.FieldName }}, page.{{ .ResultPages.FieldName }}...)
+		}
+	{{- else }}
+		return grpccli.{{ .Method.VarName }}(ctx, message, opts...)
+	{{- end }}
+{{- else }}
 		if reqpb != nil {
 			return grpccli.{{ .Method.VarName }}(ctx{{ if not .Method.StreamingPayload }}, reqpb.({{ .Request.ClientConvert.TgtRef }}){{ end }}, opts...)
 		}
 		return grpccli.{{ .Method.VarName }}(ctx{{ if not .Method.StreamingPayload }}, &{{ .Request.ClientConvert.TgtName }}{}{{ end }}, opts...)
+{{- end }}
 	}
 }
 `
@@ -384,7 +496,7 @@ func Encode{{ .Method.VarName }}Request(ctx context.Context, v interface{}, md *
 		}
 	{{- else if .Slice }}
 		for _, value := range payload{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
-			{{ template "string_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
+			{{ template "string_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value" .TimestampFormat) }}
 			(*md).Append({{ printf "%q" .Name }}, valueStr)
 		}
 	{{- else }}
@@ -398,9 +510,12 @@ func Encode{{ .Method.VarName }}Request(ctx context.Context, v interface{}, md *
 			{{- end }}
 				(*md).Append({{ printf "%q" .Name }},
 					{{- if eq .Type.Name "bytes" }} string(
+					{{- else if eq .Type.Name "timestamp" }} goa.FormatTimestamp(
 					{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
 					{{- end }}
 					{{- if .Pointer }}*{{ end }}payload{{ if .FieldName }}.{{ .FieldName }}{{ end }}
+					{{- if eq .Type.Name "timestamp" }}, {{ printf "%q" .TimestampFormat }}
+					{{- end }}
 					{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
 					{{- end }})
 			{{- if (and (eq .Name "Authorization") (isBearer $.MetadataSchemes)) }}
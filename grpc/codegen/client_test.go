@@ -18,6 +18,7 @@ func TestClientEndpointInit(t *testing.T) {
 		{"unary-rpc-no-payload", testdata.UnaryRPCNoPayloadDSL, testdata.UnaryRPCNoPayloadClientEndpointInitCode},
 		{"unary-rpc-no-result", testdata.UnaryRPCNoResultDSL, testdata.UnaryRPCNoResultClientEndpointInitCode},
 		{"unary-rpc-with-errors", testdata.UnaryRPCWithErrorsDSL, testdata.UnaryRPCWithErrorsClientEndpointInitCode},
+		{"unary-rpc-with-timeout", testdata.UnaryRPCWithTimeoutDSL, testdata.UnaryRPCWithTimeoutClientEndpointInitCode},
 		{"server-streaming-rpc", testdata.ServerStreamingRPCDSL, testdata.ServerStreamingRPCClientEndpointInitCode},
 		{"client-streaming-rpc", testdata.ClientStreamingRPCDSL, testdata.ClientStreamingRPCClientEndpointInitCode},
 		{"client-streaming-rpc-no-result", testdata.ClientStreamingNoResultDSL, testdata.ClientStreamingNoResultClientEndpointInitCode},
@@ -45,6 +46,22 @@ func TestClientEndpointInit(t *testing.T) {
 	}
 }
 
+func TestClientResultIterator(t *testing.T) {
+	RunGRPCDSL(t, testdata.StreamResultsRPCDSL)
+	fs := ClientFiles("", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[0].Section("client-result-iterator")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected one", len(sections))
+	}
+	code := codegen.SectionsCode(t, sections)
+	if code != testdata.StreamResultsRPCClientIteratorCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.StreamResultsRPCClientIteratorCode))
+	}
+}
+
 func TestRequestEncoder(t *testing.T) {
 	cases := []struct {
 		Name string
@@ -74,8 +74,11 @@ func clientType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC client types", "client",
 				[]*codegen.ImportSpec{
+					{Path: "time"},
 					{Path: "unicode/utf8"},
+					{Path: "github.com/golang/protobuf/ptypes/timestamp"},
 					codegen.GoaImport(""),
+					codegen.GoaNamedImport("grpc", "goagrpc"),
 					{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
 					{Path: path.Join(genpkg, svcName, "views"), Name: sd.Service.ViewsPkg},
 					{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: sd.PkgName},
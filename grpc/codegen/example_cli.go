@@ -62,7 +62,9 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 			{Path: "encoding/json"},
 			{Path: "flag"},
 			{Path: "fmt"},
+			{Path: "crypto/tls"},
 			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/credentials"},
 			{Path: "os"},
 			{Path: "time"},
 			codegen.GoaImport(""),
@@ -86,8 +88,13 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 }
 
 const (
-	grpcCLIDoT = `func doGRPC(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
-	conn, err := grpc.Dial(host, grpc.WithInsecure())
+	// input: *example.Data
+	grpcCLIDoT = `func doGRPC(scheme, host string, timeout int, debug bool{{ if .TLS }}, tlsConf *tls.Config{{ end }}) (goa.Endpoint, interface{}, error) {
+	creds := grpc.WithInsecure()
+	if scheme == "grpcs" {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS({{ if .TLS }}tlsConf{{ else }}nil{{ end }}))
+	}
+	conn, err := grpc.Dial(host, creds)
 	if err != nil {
     fmt.Fprintln(os.Stderr, fmt.Sprintf("could not connect to gRPC server at %s: %v", host, err))
   }
@@ -45,16 +45,24 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		specs = []*codegen.ImportSpec{
 			{Path: "context"},
 			{Path: "log"},
-			{Path: "net"},
 			{Path: "net/url"},
 			{Path: "os"},
 			{Path: "sync"},
+			{Path: "time"},
+			codegen.GoaImport(""),
 			codegen.GoaImport("middleware"),
 			codegen.GoaNamedImport("grpc", "goagrpc"),
 			codegen.GoaNamedImport("grpc/middleware", "grpcmdlwr"),
 			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/credentials"},
 			{Path: "github.com/grpc-ecosystem/go-grpc-middleware", Name: "grpcmiddleware"},
 		}
+		if root.API.ExampleGRPCHealth() {
+			specs = append(specs, &codegen.ImportSpec{Path: "google.golang.org/grpc/health/grpc_health_v1", Name: "healthpb"})
+		}
+		if root.API.ExampleGRPCReflection() {
+			specs = append(specs, &codegen.ImportSpec{Path: "google.golang.org/grpc/reflection"})
+		}
 		for _, svc := range root.API.GRPC.Services {
 			sd := GRPCServices.Get(svc.Name())
 			svcName := codegen.SnakeCase(sd.Service.VarName)
@@ -119,7 +127,9 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				Name:   "server-grpc-register",
 				Source: grpcRegisterSvrT,
 				Data: map[string]interface{}{
-					"Services": svcdata,
+					"Services":    svcdata,
+					"HealthCheck": root.API.ExampleGRPCHealth(),
+					"Reflection":  root.API.ExampleGRPCReflection(),
 				},
 				FuncMap: map[string]interface{}{
 					"goify":      codegen.Goify,
@@ -189,10 +199,12 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "HealthCheck":bool, "Reflection":bool}
 	grpcRegisterSvrT = `
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+	// Initialize gRPC server with the middleware. The server serves TLS
+	// with the settings of the current configuration if it sets a
+	// certificate, see serverTLSConfig in config.go.
+	opts := []grpc.ServerOption{
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
@@ -203,12 +215,34 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 			grpcmdlwr.StreamServerLog(adapter),
 		),
 	{{- end }}
-	)
+	}
+	if currentConfig().cert != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(serverTLSConfig("h2"))))
+	}
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	{{- range .Services }}
 	{{ .PkgName }}.Register{{ goify .Service.VarName true }}Server(srv, {{ .Service.VarName }}Server)
 	{{- end }}
+	{{- if .HealthCheck }}
+
+	// Register the standard gRPC health service, it runs the readiness
+	// checks registered in health.go.
+	{
+		var services []string
+		for svc := range srv.GetServiceInfo() {
+			services = append(services, svc)
+		}
+		healthpb.RegisterHealthServer(srv, goagrpc.NewHealthServer(health, services...))
+	}
+	{{- end }}
+	{{- if .Reflection }}
+
+	// Register the gRPC server reflection service so that tools such as
+	// grpcurl can list and call the services.
+	reflection.Register(srv)
+	{{- end }}
 
 	for svc, info := range srv.GetServiceInfo() {
 		for _, m := range info.Methods {
@@ -225,9 +259,11 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 
 		{{ comment "Start gRPC server in a separate goroutine." }}
 		go func() {
-			lis, err := net.Listen("tcp", u.Host)
+			{{ comment "Listen on the TCP address of the URL or on the matching socket passed by systemd socket activation." }}
+			lis, err := goa.Listen(u)
 			if err != nil {
 				errc <- err
+				return
 			}
 			logger.Printf("gRPC server listening on %q", u.Host)
 			errc <- srv.Serve(lis)
@@ -235,7 +271,19 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
+
+		{{ comment "Shutdown gracefully: send GOAWAY to the clients and wait for the in-flight RPCs including the streams to complete up to the shutdown timeout set in the configuration." }}
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(currentConfig().shutdownTimeout):
+			logger.Printf("failed to shutdown gRPC server gracefully: timeout")
+			srv.Stop()
+		}
   }()
 }
 `
@@ -47,6 +47,8 @@ func protoFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Data: map[string]interface{}{
 				"ProtoVersion": ProtoVersion,
 				"Pkg":          codegen.SnakeCase(codegen.Goify(svcName, false)),
+				"FieldMask":    data.FieldMask,
+				"Timestamp":    data.Timestamp,
 			},
 		},
 		// service definition
@@ -97,6 +99,14 @@ syntax = {{ printf "%q" .ProtoVersion }};
 package {{ .Pkg }};
 
 option go_package = "{{ .Pkg }}pb";
+{{- if or .FieldMask .Timestamp }}
+{{ end }}
+{{- if .FieldMask }}
+import "google/protobuf/field_mask.proto";
+{{- end }}
+{{- if .Timestamp }}
+import "google/protobuf/timestamp.proto";
+{{- end }}
 `
 
 	// input: ServiceData
@@ -105,7 +115,7 @@ option go_package = "{{ .Pkg }}pb";
 service {{ .Name }} {
 	{{- range .Endpoints }}
 	{{ if .Method.Description }}{{ .Method.Description | comment }}{{ end }}
-	{{- $serverStream := or (eq .Method.StreamKind 3) (eq .Method.StreamKind 4) }}
+	{{- $serverStream := or (eq .Method.StreamKind 3) (eq .Method.StreamKind 4) .ResultPages }}
 	{{- $clientStream := or (eq .Method.StreamKind 2) (eq .Method.StreamKind 4) }}
 	rpc {{ .Method.VarName }} ({{ if $clientStream }}stream {{ end }}{{ .Request.Message.VarName }}) returns ({{ if $serverStream }}stream {{ end }}{{ .Response.Message.VarName }});
 	{{- end }}
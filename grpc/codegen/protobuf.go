@@ -57,7 +57,7 @@ func protoBufTypeContext(pkg string, scope *codegen.NameScope) *codegen.Attribut
 // attribute under an attribute with name "field" and RPC tag number 1. For,
 // nested arrays/maps, the inner array/map is wrapped into a user type.
 func makeProtoBufMessage(att *expr.AttributeExpr, tname string, scope *codegen.NameScope) *expr.AttributeExpr {
-	att = expr.DupAtt(att)
+	att = inlineProtoBufType(expr.DupAtt(att))
 	switch dt := att.Type.(type) {
 	case expr.Primitive:
 		wrapAttr(att, tname)
@@ -119,20 +119,44 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, scope *codegen
 		}
 		makeProtoBufMessageR(dt.Attribute(), tname, scope, seen...)
 	case *expr.Array:
+		dt.ElemType = inlineProtoBufType(dt.ElemType)
 		makeProtoBufMessageR(dt.ElemType, tname, scope, seen...)
 		wrap(dt.ElemType, *tname)
 	case *expr.Map:
 		// need not worry about map keys because protocol buffer supports
 		// only primitives as map keys.
+		dt.KeyType = inlineProtoBufType(dt.KeyType)
+		dt.ElemType = inlineProtoBufType(dt.ElemType)
 		makeProtoBufMessageR(dt.ElemType, tname, scope, seen...)
 		wrap(dt.ElemType, *tname)
 	case *expr.Object:
 		for _, nat := range *dt {
+			nat.Attribute = inlineProtoBufType(nat.Attribute)
 			makeProtoBufMessageR(nat.Attribute, tname, scope, seen...)
 		}
 	}
 }
 
+// inlineProtoBufType returns a copy of att using the type of the protocol
+// buffer message field if it differs from the design type, att otherwise.
+// Enum constant types use their base type and UUIDs and decimals are strings
+// validated with the uuid and decimal formats.
+func inlineProtoBufType(att *expr.AttributeExpr) *expr.AttributeExpr {
+	if att.Type != expr.UUID && att.Type != expr.Decimal {
+		return expr.InlineEnumConstType(att)
+	}
+	inlined := expr.DupAtt(att)
+	inlined.Type = expr.String
+	if inlined.Validation == nil {
+		inlined.Validation = &expr.ValidationExpr{}
+	}
+	inlined.Validation.Format = expr.FormatUUID
+	if att.Type == expr.Decimal {
+		inlined.Validation.Format = expr.FormatDecimal
+	}
+	return inlined
+}
+
 // wrapAttr makes the attribute type a user type by wrapping the given
 // attribute into an attribute named "field".
 func wrapAttr(att *expr.AttributeExpr, tname string) {
@@ -345,6 +369,12 @@ func protoBufNativeMessageTypeName(t expr.DataType) string {
 		return "string"
 	case expr.BytesKind:
 		return "bytes"
+	case expr.DurationKind:
+		return "int64"
+	case expr.UUIDKind, expr.DecimalKind:
+		return "string"
+	case expr.TimestampKind:
+		return "google.protobuf.Timestamp"
 	default:
 		panic(fmt.Sprintf("cannot compute native protocol buffer type for %T", t)) // bug
 	}
@@ -377,6 +407,12 @@ func protoBufNativeGoTypeName(t expr.DataType) string {
 		return "string"
 	case expr.BytesKind:
 		return "[]byte"
+	case expr.DurationKind:
+		return "int64"
+	case expr.UUIDKind, expr.DecimalKind:
+		return "string"
+	case expr.TimestampKind:
+		return "*timestamp.Timestamp"
 	default:
 		panic(fmt.Sprintf("cannot compute native protocol buffer type for %T", t)) // bug
 	}
@@ -36,7 +36,7 @@ var (
 
 // NOTE: can't initialize inline because https://github.com/golang/go/issues/1817
 func init() {
-	fm := template.FuncMap{"transformAttribute": transformAttribute}
+	fm := template.FuncMap{"transformAttribute": transformAttribute, "transformElem": transformElem}
 	transformGoArrayT = template.Must(template.New("transformGoArray").Funcs(fm).Parse(transformGoArrayTmpl))
 	transformGoMapT = template.Must(template.New("transformGoMap").Funcs(fm).Parse(transformGoMapTmpl))
 }
@@ -132,6 +132,21 @@ func transformAttribute(source, target *expr.AttributeExpr, sourceVar, targetVar
 	return initCode + code, nil
 }
 
+// transformElem returns the code to initialize a target array or map element
+// from a source element. User type elements are initialized by calling the
+// corresponding transform helper function so that recursive types and user
+// types nested in maps and arrays are not inlined.
+func transformElem(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *transformAttrs) (string, error) {
+	if _, ok := source.Type.(expr.UserType); !ok || !expr.IsObject(source.Type) {
+		return transformAttribute(source, target, sourceVar, targetVar, newVar, ta)
+	}
+	assign := "="
+	if newVar {
+		assign = ":="
+	}
+	return fmt.Sprintf("%s %s %s\n", targetVar, assign, convertType(source, target, sourceVar, ta)), nil
+}
+
 // transformObject returns the code to transform source attribute of object
 // type to target attribute of object type. It returns an error if source
 // and target are not compatible for transformation.
@@ -230,6 +245,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				}
 			}
 			_, ok := srcc.Type.(expr.UserType)
+			ok = ok && !expr.IsEnumConstType(srcc.Type)
 			switch {
 			case expr.IsArray(srcc.Type):
 				code, err = transformArray(expr.AsArray(srcc.Type), expr.AsArray(tgtc.Type), srcVar, tgtVar, false, ta)
@@ -443,13 +459,46 @@ func transformMap(source, target *expr.Map, sourceVar, targetVar string, newVar
 // held by sourceVar.
 // NOTE: For Int and UInt kinds, protocol buffer Go compiler generates
 // int32 and uint32 respectively whereas goa v2 generates int and uint.
+// Durations are represented as int64 numbers of nanoseconds in protocol buffer
+// messages. Enum constant types are represented with their base type. UUIDs
+// and decimals are represented as strings. Timestamps are represented as
+// google.protobuf.Timestamp messages.
 func convertType(source, target *expr.AttributeExpr, sourceVar string, ta *transformAttrs) string {
+	if expr.IsEnumConstType(source.Type) || expr.IsEnumConstType(target.Type) {
+		return fmt.Sprintf("%s(%s)", ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg), sourceVar)
+	}
+	if source.Type == expr.Timestamp {
+		if ta.proto {
+			return fmt.Sprintf("goagrpc.TimestampProto(%s)", sourceVar)
+		}
+		return fmt.Sprintf("goagrpc.Timestamp(%s)", sourceVar)
+	}
+	if source.Type == expr.UUID && target.Type != expr.UUID {
+		if strings.HasPrefix(sourceVar, "*") {
+			sourceVar = "(" + sourceVar + ")"
+		}
+		return sourceVar + ".String()"
+	}
+	if target.Type == expr.UUID && source.Type != expr.UUID {
+		// the protocol buffer message validation checks the format
+		return fmt.Sprintf("goa.MustParseUUID(%s)", sourceVar)
+	}
+	if source.Type == expr.Decimal && target.Type != expr.Decimal {
+		if strings.HasPrefix(sourceVar, "*") {
+			sourceVar = "(" + sourceVar + ")"
+		}
+		return sourceVar + ".String()"
+	}
+	if target.Type == expr.Decimal && source.Type != expr.Decimal {
+		// the protocol buffer message validation checks the format
+		return codegen.DecimalFromString(sourceVar)
+	}
 	if _, ok := source.Type.(expr.UserType); ok {
 		// return a function name for the conversion
 		return fmt.Sprintf("%s(%s)", transformHelperName(source, target, ta), sourceVar)
 	}
 
-	if source.Type.Kind() != expr.IntKind && source.Type.Kind() != expr.UIntKind {
+	if source.Type.Kind() != expr.IntKind && source.Type.Kind() != expr.UIntKind && source.Type.Kind() != expr.DurationKind {
 		return sourceVar
 	}
 	if ta.proto {
@@ -468,7 +517,7 @@ func checkZeroValue(dt expr.DataType, target string, negate bool) string {
 	// don't check for BooleanKind since by default boolean is set to false
 	case expr.IntKind, expr.Int32Kind, expr.Int64Kind,
 		expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind,
-		expr.Float32Kind, expr.Float64Kind:
+		expr.Float32Kind, expr.Float64Kind, expr.DurationKind:
 		return fmt.Sprintf("%s %s 0", target, eq)
 	case expr.StringKind:
 		return fmt.Sprintf("%s %s \"\"", target, eq)
@@ -510,14 +559,14 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *transform
 		case expr.IsArray(source.Type):
 			source = expr.AsArray(source.Type).ElemType
 			target = expr.AsArray(target.Type).ElemType
-			helpers, err = transformAttributeHelpers(source, target, ta, seen...)
+			helpers, err = elemHelpers(source, target, ta, seen...)
 		case expr.IsMap(source.Type):
 			sm := expr.AsMap(source.Type)
 			tm := expr.AsMap(target.Type)
-			helpers, err = transformAttributeHelpers(sm.ElemType, tm.ElemType, ta, seen...)
+			helpers, err = elemHelpers(sm.ElemType, tm.ElemType, ta, seen...)
 			if err == nil {
 				var other []*codegen.TransformFunctionData
-				other, err = transformAttributeHelpers(sm.KeyType, tm.KeyType, ta, seen...)
+				other, err = elemHelpers(sm.KeyType, tm.KeyType, ta, seen...)
 				helpers = append(helpers, other...)
 			}
 		case expr.IsObject(source.Type):
@@ -550,6 +599,23 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *transform
 	return helpers, nil
 }
 
+// elemHelpers returns the transform helper functions required to transform
+// the elements of an array or a map. The elements may be wrapped in a message
+// if they are nested arrays or maps (see grpc/docs/FAQ.md).
+func elemHelpers(source, target *expr.AttributeExpr, ta *transformAttrs, seen ...map[string]*codegen.TransformFunctionData) ([]*codegen.TransformFunctionData, error) {
+	if err := codegen.IsCompatible(source.Type, target.Type, "", ""); err != nil {
+		if ta.proto {
+			target = unwrapAttr(expr.DupAtt(target))
+		} else {
+			source = unwrapAttr(expr.DupAtt(source))
+		}
+		if err = codegen.IsCompatible(source.Type, target.Type, "", ""); err != nil {
+			return nil, err
+		}
+	}
+	return collectHelpers(source, target, false, ta, seen...)
+}
+
 // collectHelpers recursively traverses the given attributes and return the
 // transform helper functions required to generate the transform code.
 func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformAttrs, seen ...map[string]*codegen.TransformFunctionData) ([]*codegen.TransformFunctionData, error) {
@@ -558,7 +624,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformA
 	)
 	switch {
 	case expr.IsArray(source.Type):
-		helpers, err := transformAttributeHelpers(
+		helpers, err := elemHelpers(
 			expr.AsArray(source.Type).ElemType,
 			expr.AsArray(target.Type).ElemType,
 			ta, seen...)
@@ -567,7 +633,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformA
 		}
 		data = append(data, helpers...)
 	case expr.IsMap(source.Type):
-		helpers, err := transformAttributeHelpers(
+		helpers, err := elemHelpers(
 			expr.AsMap(source.Type).KeyType,
 			expr.AsMap(target.Type).KeyType,
 			ta, seen...)
@@ -575,7 +641,7 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformA
 			return nil, err
 		}
 		data = append(data, helpers...)
-		helpers, err = transformAttributeHelpers(
+		helpers, err = elemHelpers(
 			expr.AsMap(source.Type).ElemType,
 			expr.AsMap(target.Type).ElemType,
 			ta, seen...)
@@ -675,14 +741,14 @@ func transformHelperName(source, target *expr.AttributeExpr, ta *transformAttrs)
 const (
 	transformGoArrayTmpl = `{{ .TargetVar }} {{ if .NewVar }}:={{ else }}={{ end }} make([]{{ .ElemTypeRef }}, len({{ .SourceVar }}))
 for {{ .LoopVar }}, val := range {{ .SourceVar }} {
-  {{ transformAttribute .SourceElem .TargetElem "val" (printf "%s[%s]" .TargetVar .LoopVar) false .TransformAttrs -}}
+  {{ transformElem .SourceElem .TargetElem "val" (printf "%s[%s]" .TargetVar .LoopVar) false .TransformAttrs -}}
 }
 `
 
 	transformGoMapTmpl = `{{ .TargetVar }} {{ if .NewVar }}:={{ else }}={{ end }} make(map[{{ .KeyTypeRef }}]{{ .ElemTypeRef }}, len({{ .SourceVar }}))
 for key, val := range {{ .SourceVar }} {
   {{ transformAttribute .SourceKey .TargetKey "key" "tk" true .TransformAttrs -}}
-  {{ transformAttribute .SourceElem .TargetElem "val" (printf "tv%s" .LoopVar) true .TransformAttrs -}}
+  {{ transformElem .SourceElem .TargetElem "val" (printf "tv%s" .LoopVar) true .TransformAttrs -}}
   {{ .TargetVar }}[tk] = {{ printf "tv%s" .LoopVar }}
 }
 `
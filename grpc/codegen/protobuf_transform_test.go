@@ -334,13 +334,7 @@ const (
 	if source.TypeArray != nil {
 		target.TypeArray = make([]*SimpleArray, len(source.TypeArray))
 		for i, val := range source.TypeArray {
-			target.TypeArray[i] = &SimpleArray{}
-			if val.StringArray != nil {
-				target.TypeArray[i].StringArray = make([]string, len(val.StringArray))
-				for j, val := range val.StringArray {
-					target.TypeArray[i].StringArray[j] = val
-				}
-			}
+			target.TypeArray[i] = svcSimpleArrayToSimpleArray(val)
 		}
 	}
 }
@@ -465,18 +459,7 @@ const (
 		target.Collection = &ResultTypeCollection{}
 		target.Collection.Field = make([]*ResultType, len(source.Collection))
 		for i, val := range source.Collection {
-			target.Collection.Field[i] = &ResultType{}
-			if val.Int != nil {
-				target.Collection.Field[i].Int = int32(*val.Int)
-			}
-			if val.Map != nil {
-				target.Collection.Field[i].Map_ = make(map[int32]string, len(val.Map))
-				for key, val := range val.Map {
-					tk := int32(key)
-					tv := val
-					target.Collection.Field[i].Map_[tk] = tv
-				}
-			}
+			target.Collection.Field[i] = svcResultTypeToResultType(val)
 		}
 	}
 }
@@ -738,13 +721,7 @@ const (
 	if source.TypeArray != nil {
 		target.TypeArray = make([]*SimpleArray, len(source.TypeArray))
 		for i, val := range source.TypeArray {
-			target.TypeArray[i] = &SimpleArray{}
-			if val.StringArray != nil {
-				target.TypeArray[i].StringArray = make([]string, len(val.StringArray))
-				for j, val := range val.StringArray {
-					target.TypeArray[i].StringArray[j] = val
-				}
-			}
+			target.TypeArray[i] = protobufSimpleArrayToSimpleArray(val)
 		}
 	}
 }
@@ -868,19 +845,7 @@ const (
 	if source.Collection != nil {
 		target.Collection = make([]*ResultType, len(source.Collection.Field))
 		for i, val := range source.Collection.Field {
-			target.Collection[i] = &ResultType{}
-			if val.Int != 0 {
-				int_ptr := int(val.Int)
-				target.Collection[i].Int = &int_ptr
-			}
-			if val.Map_ != nil {
-				target.Collection[i].Map = make(map[int]string, len(val.Map_))
-				for key, val := range val.Map_ {
-					tk := int(key)
-					tv := val
-					target.Collection[i].Map[tk] = tv
-				}
-			}
+			target.Collection[i] = protobufResultTypeToResultType(val)
 		}
 	}
 }
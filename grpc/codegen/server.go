@@ -40,6 +40,7 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC server", "server", []*codegen.ImportSpec{
 				{Path: "context"},
+				{Path: "time"},
 				codegen.GoaImport(""),
 				codegen.GoaNamedImport("grpc", "goagrpc"),
 				{Path: "google.golang.org/grpc/codes"},
@@ -176,11 +177,12 @@ func transTmplFuncs(s *expr.GRPCServiceExpr) map[string]interface{} {
 
 // typeConversionData produces the template data suitable for executing the
 // "type_conversion" template.
-func typeConversionData(dt expr.DataType, varName string, target string) map[string]interface{} {
+func typeConversionData(dt expr.DataType, varName, target, tsFormat string) map[string]interface{} {
 	return map[string]interface{}{
-		"Type":    dt,
-		"VarName": varName,
-		"Target":  target,
+		"Type":            dt,
+		"VarName":         varName,
+		"Target":          target,
+		"TimestampFormat": tsFormat,
 	}
 }
 
@@ -232,14 +234,23 @@ func New{{ .Method.VarName }}Handler(endpoint goa.Endpoint, h goagrpc.{{ if .Ser
 // input: EndpointData
 const serverGRPCInterfaceT = `{{ printf "%s implements the %q method in %s.%s interface." .Method.VarName .Method.VarName .PkgName .ServerInterface | comment }}
 func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
-	{{- if not .ServerStream }}ctx context.Context, {{ end }}
-	{{- if not .Method.StreamingPayload }}message {{ .Request.Message.Ref }}{{ if .ServerStream }}, {{ end }}{{ end }}
-	{{- if .ServerStream }}stream {{ .ServerStream.Interface }}{{ end }}) {{ if .ServerStream }}error{{ else if .Response.Message }}({{ .Response.Message.Ref }},	error{{ if .Response.Message }}){{ end }}{{ end }} {
-{{- if .ServerStream }}
+	{{- if not (or .ServerStream .ResultPages) }}ctx context.Context, {{ end }}
+	{{- if not .Method.StreamingPayload }}message {{ .Request.Message.Ref }}{{ if or .ServerStream .ResultPages }}, {{ end }}{{ end }}
+	{{- if .ServerStream }}stream {{ .ServerStream.Interface }}{{ else if .ResultPages }}stream {{ .ResultPages.ServerInterface }}{{ end }}) {{ if or .ServerStream .ResultPages }}error{{ else if .Response.Message }}({{ .Response.Message.Ref }},	error{{ if .Response.Message }}){{ end }}{{ end }} {
+{{- if or .ServerStream .ResultPages }}
 	ctx := stream.Context()
 {{- end }}
 	ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 	ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+{{- if .Method.Timeout }}
+	ctx, cancel := context.WithTimeout(ctx, {{ .Method.Timeout }})
+	defer cancel()
+{{- end }}
+{{- if .Method.FieldMask }}
+	if message.FieldMask != nil {
+		ctx = context.WithValue(ctx, goa.FieldMaskKey, goa.FieldMask(message.FieldMask.Paths))
+	}
+{{- end }}
 
 {{- if .ServerStream }}
 	p, err := s.{{ .Method.VarName }}H.Decode(ctx, {{ if .Method.StreamingPayload }}nil{{ else }}message{{ end }})
@@ -255,7 +266,21 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 	resp, err := s.{{ .Method.VarName }}H.Handle(ctx, message)
 {{- end }}
 	{{- template "handle_error" . }}
+{{- if .ResultPages }}
+	elems := resp.({{ .Response.ServerConvert.TgtRef }}).{{ .ResultPages.FieldName }}
+	for start := 0; start < len(elems); start += {{ .ResultPages.PageSize }} {
+		end := start + {{ .ResultPages.PageSize }}
+		if end > len(elems) {
+			end = len(elems)
+		}
+		if err := stream.Send(&{{ .ResultPages.MessageName }}{ {{- .ResultPages.FieldName }}: elems[start:end]}); err != nil {
+			return err
+		}
+	}
+	return nil
+{{- else }}
 	return {{ if not $.ServerStream }}resp.({{ .Response.ServerConvert.TgtRef }}), {{ end }}nil
+{{- end }}
 }
 
 {{- define "handle_error" }}
@@ -268,12 +293,12 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 				{{- if .Response.ServerConvert }}
 					er := err.({{ .Response.ServerConvert.SrcRef }})
 				{{- end }}
-				return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.NewStatusError({{ .Response.StatusCode }}, err, {{ if .Response.ServerConvert }}{{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }}){{ else }}goagrpc.NewErrorResponse(err){{ end }})
+				return {{ if not (or $.ServerStream $.ResultPages) }}nil, {{ end }}goagrpc.NewStatusError({{ .Response.StatusCode }}, err, {{ if .Response.ServerConvert }}{{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }}){{ else }}goagrpc.NewErrorResponse(err){{ end }}, goagrpc.NewErrorInfo({{ printf "%q" $.ServiceName }}, err))
 		{{- end }}
 			}
 		}
 	{{- end }}
-		return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.EncodeError(err)
+		return {{ if not (or $.ServerStream $.ResultPages) }}nil, {{ end }}goagrpc.EncodeError(err, goagrpc.NewErrorInfo({{ printf "%q" $.ServiceName }}, err))
 	}
 {{- end }}
 `
@@ -385,6 +410,9 @@ func Decode{{ .Method.VarName }}Request(ctx context.Context, v interface{}, md m
 			}
 		{{- end }}
 	{{- end }}
+{{- end }}
+{{- if .Request.DefaultFrom }}
+		{{ .Request.DefaultFrom }}
 {{- end }}
 	}
 	return payload, nil
@@ -422,7 +450,7 @@ func Encode{{ .Method.VarName }}Response(ctx context.Context, v interface{}, hdr
 	{{ .VarName }}.Append({{ printf "%q" .Metadata.Name }}, res.{{ .Metadata.FieldName }}...)
 	{{- else if .Metadata.Slice }}
 		for _, value := range res.{{ .Metadata.FieldName }} {
-			{{ template "string_conversion" (typeConversionData .Metadata.Type.ElemType.Type "valueStr" "value") }}
+			{{ template "string_conversion" (typeConversionData .Metadata.Type.ElemType.Type "valueStr" "value" .Metadata.TimestampFormat) }}
 			{{ .VarName }}.Append({{ printf "%q" .Metadata.Name }}, valueStr)
 		}
 	{{- else }}
@@ -431,9 +459,12 @@ func Encode{{ .Method.VarName }}Response(ctx context.Context, v interface{}, hdr
 		{{- end }}
 		{{ .VarName }}.Append({{ printf "%q" .Metadata.Name }},
 			{{- if eq .Metadata.Type.Name "bytes" }} string(
+			{{- else if eq .Metadata.Type.Name "timestamp" }} goa.FormatTimestamp(
 			{{- else if not (eq .Metadata.Type.Name "string") }} fmt.Sprintf("%v",
 			{{- end }}
 			{{- if .Metadata.Pointer }}*{{ end }}p.{{ .Metadata.FieldName }}
+			{{- if eq .Metadata.Type.Name "timestamp" }}, {{ printf "%q" .Metadata.TimestampFormat }}
+			{{- end }}
 			{{- if or (eq .Metadata.Type.Name "bytes") (not (eq .Metadata.Type.Name "string")) }})
 			{{- end }})
 		{{- if .Metadata.Pointer }}
@@ -510,6 +541,30 @@ const convertStringToTypeT = `{{- define "slice_conversion" }}
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of booleans"))
 		}
 		{{ .VarName }}[i] = v
+	{{- else if eq .Type.ElemType.Type.Name "duration" }}
+		v, err2 := goa.ParseDuration(rv)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of durations"))
+		}
+		{{ .VarName }}[i] = v
+	{{- else if eq .Type.ElemType.Type.Name "uuid" }}
+		v, err2 := goa.ParseUUID(rv)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of uuids"))
+		}
+		{{ .VarName }}[i] = v
+	{{- else if eq .Type.ElemType.Type.Name "timestamp" }}
+		v, err2 := goa.ParseTimestamp(rv, {{ printf "%q" .TimestampFormat }})
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of timestamps"))
+		}
+		{{ .VarName }}[i] = v
+	{{- else if eq .Type.ElemType.Type.Name "decimal" }}
+		var v {{ goTypeRef .Type.ElemType.Type }}
+		if err2 := v.UnmarshalText([]byte(rv)); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of decimals"))
+		}
+		{{ .VarName }}[i] = v
 	{{- else if eq .Type.ElemType.Type.Name "any" }}
 		{{ .VarName }}[i] = rv
 	{{- else }}
@@ -599,6 +654,30 @@ const convertStringToTypeT = `{{- define "slice_conversion" }}
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "duration" }}
+		v, err2 := goa.ParseDuration({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "duration"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "uuid" }}
+		v, err2 := goa.ParseUUID({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "uuid"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "timestamp" }}
+		v, err2 := goa.ParseTimestamp({{ .VarName }}Raw, {{ printf "%q" .TimestampFormat }})
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "timestamp"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "decimal" }}
+		var v {{ goTypeRef .Type }}
+		if err2 := v.UnmarshalText([]byte({{ .VarName }}Raw)); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "decimal"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
@@ -629,6 +708,12 @@ const convertTypeToStringT = `{{- define "string_conversion" }}
 		{{ .VarName }} := {{ .Target }}
 	{{- else if eq .Type.Name "bytes" -}}
 		{{ .VarName }} := string({{ .Target }})
+	{{- else if eq .Type.Name "duration" -}}
+		{{ .VarName }} := time.Duration({{ .Target }}).String()
+	{{- else if or (eq .Type.Name "uuid") (eq .Type.Name "decimal") -}}
+		{{ .VarName }} := {{ .Target }}.String()
+	{{- else if eq .Type.Name "timestamp" -}}
+		{{ .VarName }} := goa.FormatTimestamp({{ .Target }}, {{ printf "%q" .TimestampFormat }})
 	{{- else if eq .Type.Name "any" -}}
 		{{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
 	{{- else }}
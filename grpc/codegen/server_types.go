@@ -75,8 +75,11 @@ func serverType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 		sections = []*codegen.SectionTemplate{
 			codegen.Header(svc.Name()+" gRPC server types", "server",
 				[]*codegen.ImportSpec{
+					{Path: "time"},
 					{Path: "unicode/utf8"},
+					{Path: "github.com/golang/protobuf/ptypes/timestamp"},
 					codegen.GoaImport(""),
+					codegen.GoaNamedImport("grpc", "goagrpc"),
 					{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
 					{Path: path.Join(genpkg, svcName, "views"), Name: sd.Service.ViewsPkg},
 					{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: sd.PkgName},
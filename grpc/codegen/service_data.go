@@ -2,6 +2,8 @@ package codegen
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -51,6 +53,12 @@ type (
 		ClientInterfaceInit string
 		// Scope is the name scope for protocol buffers
 		Scope *codegen.NameScope
+		// FieldMask is true if the request message of any of the service
+		// methods has a field of type google.protobuf.FieldMask.
+		FieldMask bool
+		// Timestamp is true if any of the service messages has a field of
+		// type google.protobuf.Timestamp.
+		Timestamp bool
 
 		// transformHelpers is the list of transform functions required by the
 		// constructors.
@@ -109,6 +117,31 @@ type (
 		ClientInterface string
 		// ClientStream is the client stream data.
 		ClientStream *StreamData
+		// ResultPages describes how the elements of the result are streamed
+		// in pages if the endpoint uses StreamResults, nil otherwise.
+		ResultPages *ResultPagesData
+	}
+
+	// ResultPagesData describes the server-streaming RPC that sends the
+	// elements of the method result in pages.
+	ResultPagesData struct {
+		// PageSize is the maximum number of elements sent in each message.
+		PageSize int
+		// MessageName is the name of the response message struct in
+		// *.pb.go.
+		MessageName string
+		// FieldName is the name of the response message field that holds
+		// the elements.
+		FieldName string
+		// ServerInterface is the server stream interface in *.pb.go.
+		ServerInterface string
+		// ClientInterface is the client stream interface in *.pb.go.
+		ClientInterface string
+		// IteratorName is the name of the client iterator struct.
+		IteratorName string
+		// ElemRef is the fully qualified reference to the service type of
+		// the elements.
+		ElemRef string
 	}
 
 	// MetadataData describes a gRPC metadata field.
@@ -151,6 +184,9 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// TimestampFormat is the wire format of the metadata value or of
+		// its elements if the metadata value is an array of timestamps.
+		TimestampFormat string
 	}
 
 	// ErrorData contains the error information required to generate the
@@ -185,6 +221,10 @@ type (
 		// CLIArgs is the list of arguments for the command-line client.
 		// This is set only for the client side.
 		CLIArgs []*InitArgData
+		// DefaultFrom is the code that initializes the payload fields
+		// whose attributes use DefaultFrom and that are not set in the
+		// request.
+		DefaultFrom string
 	}
 
 	// ResponseData describes a gRPC success or error response.
@@ -510,6 +550,9 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 				ServerConvert: buildRequestConvertData(e.Request, e.MethodExpr.Payload, reqMD, e, sd, true),
 				ClientConvert: buildRequestConvertData(e.Request, e.MethodExpr.Payload, reqMD, e, sd, false),
 			}
+			if expr.IsObject(e.MethodExpr.Payload.Type) {
+				request.DefaultFrom = codegen.DefaultFromCode(e.MethodExpr.Payload, serviceTypeContext(svc.PkgName, svc.Scope), true, "payload")
+			}
 			if obj := expr.AsObject(e.Request.Type); len(*obj) > 0 {
 				// add the request message as the first argument to the CLI
 				request.CLIArgs = append(request.CLIArgs, &InitArgData{
@@ -539,6 +582,10 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			} else {
 				request.Message = collect(e.Request)
 			}
+			if e.MethodExpr.HasFieldMask() {
+				request.Message.Def = addFieldMaskField(request.Message.Def, e)
+				sd.FieldMask = true
+			}
 		}
 
 		// build response data
@@ -605,6 +652,9 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			ClientInterface: sd.ClientInterface,
 		}
 		sd.Endpoints = append(sd.Endpoints, ed)
+		if e.StreamsResults() {
+			ed.ResultPages = buildResultPagesData(e, sd)
+		}
 		if e.MethodExpr.IsStreaming() {
 			ed.ServerStream = buildStreamData(e, sd, true)
 			ed.ClientStream = buildStreamData(e, sd, false)
@@ -613,6 +663,48 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 	return sd
 }
 
+// buildResultPagesData builds the data needed to render the server-streaming
+// RPC that sends the elements of the result of the given endpoint in pages.
+func buildResultPagesData(e *expr.GRPCEndpointExpr, sd *ServiceData) *ResultPagesData {
+	var field string
+	for _, nat := range *expr.AsObject(e.Response.Message.Type) {
+		if expr.IsArray(nat.Attribute.Type) {
+			field = protoBufifyAtt(nat.Attribute, nat.Name, true)
+			break
+		}
+	}
+	svc := sd.Service
+	md := svc.Method(e.Name())
+	return &ResultPagesData{
+		PageSize:        e.ResultPageSize,
+		MessageName:     protoBufGoFullTypeName(e.Response.Message, sd.PkgName, sd.Scope),
+		FieldName:       field,
+		ServerInterface: fmt.Sprintf("%s.%s_%sServer", sd.PkgName, svc.StructName, md.VarName),
+		ClientInterface: fmt.Sprintf("%s.%s_%sClient", sd.PkgName, svc.StructName, md.VarName),
+		IteratorName:    md.VarName + "Iterator",
+		ElemRef:         svc.Scope.GoFullTypeRef(expr.AsArray(e.MethodExpr.Result.Type).ElemType, svc.PkgName),
+	}
+}
+
+// addFieldMaskField adds the "field_mask" field of type
+// google.protobuf.FieldMask to the given request message definition. The
+// field number is the number set with the FieldMask DSL or the number
+// following the largest message field number.
+func addFieldMaskField(def string, e *expr.GRPCEndpointExpr) string {
+	tag := e.MethodExpr.FieldMaskTag()
+	if tag == "" {
+		var max uint64
+		for _, nat := range *expr.AsObject(e.Request.Type) {
+			if t := rpcTag(nat.Attribute); t > max {
+				max = t
+			}
+		}
+		tag = strconv.FormatUint(max+1, 10)
+	}
+	i := strings.LastIndex(def, "}")
+	return def[:i] + fmt.Sprintf("\tgoogle.protobuf.FieldMask field_mask = %s;\n", tag) + def[i:]
+}
+
 // collectMessages recurses through the attribute to gather all the messages.
 func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]struct{}) (data []*service.UserTypeData) {
 	if at == nil {
@@ -633,11 +725,15 @@ func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]st
 				att = &expr.AttributeExpr{Type: expr.AsObject(rt)}
 			}
 		}
+		def := protoBufMessageDef(att, sd.Scope)
+		if strings.Contains(def, "google.protobuf.Timestamp ") {
+			sd.Timestamp = true
+		}
 		data = append(data, &service.UserTypeData{
 			Name:        dt.Name(),
 			VarName:     protoBufMessageName(at, sd.Scope),
 			Description: dt.Attribute().Description,
-			Def:         protoBufMessageDef(att, sd.Scope),
+			Def:         def,
 			Ref:         protoBufGoFullTypeRef(at, sd.PkgName, sd.Scope),
 			Type:        dt,
 		})
@@ -1217,9 +1313,10 @@ func extractMetadata(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, s
 				mp.KeyType.Type.Kind() == expr.StringKind &&
 				mp.ElemType.Type.Kind() == expr.ArrayKind &&
 				expr.AsArray(mp.ElemType.Type).ElemType.Type.Kind() == expr.StringKind,
-			Validate:     codegen.RecursiveValidationCode(c, ctx, required, varn),
-			DefaultValue: c.DefaultValue,
-			Example:      c.Example(expr.Root.API.Random()),
+			Validate:        codegen.RecursiveValidationCode(c, ctx, required, varn),
+			DefaultValue:    c.DefaultValue,
+			Example:         c.Example(expr.Root.API.Random()),
+			TimestampFormat: c.TimestampFormat(),
 		})
 		return nil
 	})
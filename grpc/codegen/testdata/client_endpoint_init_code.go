@@ -50,6 +50,25 @@ func (c *Client) MethodUnaryRPCNoPayload() goa.Endpoint {
 }
 `
 
+const UnaryRPCWithTimeoutClientEndpointInitCode = `// MethodUnaryRPCWithTimeout calls the "MethodUnaryRPCWithTimeout" function in
+// service_unaryrpc_with_timeoutpb.ServiceUnaryRPCWithTimeoutClient interface.
+func (c *Client) MethodUnaryRPCWithTimeout() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+		inv := goagrpc.NewInvoker(
+			BuildMethodUnaryRPCWithTimeoutFunc(c.grpccli, c.opts...),
+			EncodeMethodUnaryRPCWithTimeoutRequest,
+			DecodeMethodUnaryRPCWithTimeoutResponse)
+		res, err := inv.Invoke(ctx, v)
+		if err != nil {
+			return nil, goa.Fault(err.Error())
+		}
+		return res, nil
+	}
+}
+`
+
 const UnaryRPCNoResultClientEndpointInitCode = `// MethodUnaryRPCNoResult calls the "MethodUnaryRPCNoResult" function in
 // service_unaryrpc_no_resultpb.ServiceUnaryRPCNoResultClient interface.
 func (c *Client) MethodUnaryRPCNoResult() goa.Endpoint {
@@ -92,7 +111,9 @@ func (c *Client) MethodUnaryRPCWithErrors() goa.Endpoint {
 			case *service_unaryrpc_with_errorspb.MethodUnaryRPCWithErrorsCustomErrorError:
 				return nil, NewMethodUnaryRPCWithErrorsCustomErrorError(message)
 			case *goapb.ErrorResponse:
-				return nil, goagrpc.NewServiceError(message)
+				gerr := goagrpc.NewServiceError(message, goagrpc.DecodeFieldErrors(err)...)
+				gerr.RetryAfter = goagrpc.DecodeRetryAfter(err)
+				return nil, gerr
 			default:
 				return nil, goa.Fault(err.Error())
 			}
@@ -227,7 +248,9 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 			resp := goagrpc.DecodeError(err)
 			switch message := resp.(type) {
 			case *goapb.ErrorResponse:
-				return nil, goagrpc.NewServiceError(message)
+				gerr := goagrpc.NewServiceError(message, goagrpc.DecodeFieldErrors(err)...)
+				gerr.RetryAfter = goagrpc.DecodeRetryAfter(err)
+				return nil, gerr
 			default:
 				return nil, goa.Fault(err.Error())
 			}
@@ -236,3 +259,61 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 	}
 }
 `
+
+const StreamResultsRPCClientIteratorCode = `// MethodStreamResultsRPCIterator iterates over the elements of the result of
+// the "ServiceStreamResultsRPC" service "MethodStreamResultsRPC" endpoint as
+// the pages streamed by the server are received.
+type MethodStreamResultsRPCIterator struct {
+	ctx    context.Context
+	stream service_stream_resultsrpcpb.ServiceStreamResultsRPC_MethodStreamResultsRPCClient
+	page   []*servicestreamresultsrpc.Item
+}
+
+// MethodStreamResultsRPCIter calls the "MethodStreamResultsRPC" function in
+// service_stream_resultsrpcpb.ServiceStreamResultsRPCClient interface and
+// returns an iterator over the elements of the result. The context controls
+// the entire call, the method timeout does not apply.
+func (c *Client) MethodStreamResultsRPCIter(ctx context.Context, p *servicestreamresultsrpc.MethodStreamResultsRPCPayload) (*MethodStreamResultsRPCIterator, error) {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	reqpb, err := EncodeMethodStreamResultsRPCRequest(ctx, p, &md)
+	if err != nil {
+		return nil, err
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	message := reqpb.(*service_stream_resultsrpcpb.MethodStreamResultsRPCRequest)
+	stream, err := c.grpccli.MethodStreamResultsRPC(ctx, message, c.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MethodStreamResultsRPCIterator{ctx: ctx, stream: stream}, nil
+}
+
+// Next returns the next element of the result. It receives and decodes the
+// next page if all the elements of the current page have been returned. Next
+// returns io.EOF once all the elements have been returned and the gRPC status
+// error if the call fails.
+func (it *MethodStreamResultsRPCIterator) Next() (*servicestreamresultsrpc.Item, error) {
+	var elem *servicestreamresultsrpc.Item
+	for len(it.page) == 0 {
+		message, err := it.stream.Recv()
+		if err != nil {
+			return elem, err
+		}
+		hdr, err := it.stream.Header()
+		if err != nil {
+			return elem, err
+		}
+		res, err := DecodeMethodStreamResultsRPCResponse(it.ctx, message, hdr, nil)
+		if err != nil {
+			return elem, err
+		}
+		it.page = res.([]*servicestreamresultsrpc.Item)
+	}
+	elem = it.page[0]
+	it.page = it.page[1:]
+	return elem, nil
+}
+`
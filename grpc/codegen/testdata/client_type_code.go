@@ -133,16 +133,28 @@ func svcServiceresultwithcollectionResultTToServiceResultWithCollectionpbResultT
 		res.CollectionField = &service_result_with_collectionpb.RTCollection{}
 		res.CollectionField.Field = make([]*service_result_with_collectionpb.RT, len(v.CollectionField))
 		for i, val := range v.CollectionField {
-			res.CollectionField.Field[i] = &service_result_with_collectionpb.RT{}
-			if val.IntField != nil {
-				res.CollectionField.Field[i].IntField = int32(*val.IntField)
-			}
+			res.CollectionField.Field[i] = svcServiceresultwithcollectionRTToServiceResultWithCollectionpbRT(val)
 		}
 	}
 
 	return res
 }
 
+// svcServiceresultwithcollectionRTToServiceResultWithCollectionpbRT builds a
+// value of type *service_result_with_collectionpb.RT from a value of type
+// *serviceresultwithcollection.RT.
+func svcServiceresultwithcollectionRTToServiceResultWithCollectionpbRT(v *serviceresultwithcollection.RT) *service_result_with_collectionpb.RT {
+	if v == nil {
+		return nil
+	}
+	res := &service_result_with_collectionpb.RT{}
+	if v.IntField != nil {
+		res.IntField = int32(*v.IntField)
+	}
+
+	return res
+}
+
 // protobufServiceResultWithCollectionpbResultTToServiceresultwithcollectionResultT
 // builds a value of type *serviceresultwithcollection.ResultT from a value of
 // type *service_result_with_collectionpb.ResultT.
@@ -154,16 +166,28 @@ func protobufServiceResultWithCollectionpbResultTToServiceresultwithcollectionRe
 	if v.CollectionField != nil {
 		res.CollectionField = make([]*serviceresultwithcollection.RT, len(v.CollectionField.Field))
 		for i, val := range v.CollectionField.Field {
-			res.CollectionField[i] = &serviceresultwithcollection.RT{}
-			if val.IntField != 0 {
-				intFieldptr := int(val.IntField)
-				res.CollectionField[i].IntField = &intFieldptr
-			}
+			res.CollectionField[i] = protobufServiceResultWithCollectionpbRTToServiceresultwithcollectionRT(val)
 		}
 	}
 
 	return res
 }
+
+// protobufServiceResultWithCollectionpbRTToServiceresultwithcollectionRT
+// builds a value of type *serviceresultwithcollection.RT from a value of type
+// *service_result_with_collectionpb.RT.
+func protobufServiceResultWithCollectionpbRTToServiceresultwithcollectionRT(v *service_result_with_collectionpb.RT) *serviceresultwithcollection.RT {
+	if v == nil {
+		return nil
+	}
+	res := &serviceresultwithcollection.RT{}
+	if v.IntField != 0 {
+		intFieldptr := int(v.IntField)
+		res.IntField = &intFieldptr
+	}
+
+	return res
+}
 `
 
 const WithErrorsClientTypeCode = `// NewMethodUnaryRPCWithErrorsRequest builds the gRPC request type from the
@@ -43,6 +43,25 @@ var UnaryRPCNoPayloadDSL = func() {
 	})
 }
 
+var StreamResultsRPCDSL = func() {
+	var Item = Type("Item", func() {
+		Field(1, "id", String)
+		Field(2, "name", String)
+		Required("id")
+	})
+	Service("ServiceStreamResultsRPC", func() {
+		Method("MethodStreamResultsRPC", func() {
+			Payload(func() {
+				Field(1, "filter", String)
+			})
+			Result(ArrayOf(Item))
+			GRPC(func() {
+				StreamResults(50)
+			})
+		})
+	})
+}
+
 var UnaryRPCNoResultDSL = func() {
 	Service("ServiceUnaryRPCNoResult", func() {
 		Method("MethodUnaryRPCNoResult", func() {
@@ -52,6 +71,17 @@ var UnaryRPCNoResultDSL = func() {
 	})
 }
 
+var UnaryRPCWithTimeoutDSL = func() {
+	Service("ServiceUnaryRPCWithTimeout", func() {
+		Method("MethodUnaryRPCWithTimeout", func() {
+			Timeout("500ms")
+			Payload(String)
+			Result(String)
+			GRPC(func() {})
+		})
+	})
+}
+
 var UnaryRPCWithErrorsDSL = func() {
 	var ErrorType = Type("ErrorType", func() {
 		Attribute("a", String)
@@ -483,6 +513,26 @@ var PayloadWithNestedTypesDSL = func() {
 	})
 }
 
+var PayloadWithNestedMapsDSL = func() {
+	var Leaf = Type("Leaf", func() {
+		Field(1, "name", String)
+	})
+	var Node = Type("Node", func() {
+		Field(1, "children", MapOf(String, "Node"))
+		Field(2, "leaves", MapOf(String, ArrayOf(MapOf(Int, Leaf))))
+	})
+	Service("ServicePayloadWithNestedMaps", func() {
+		Method("MethodPayloadWithNestedMaps", func() {
+			Payload(func() {
+				Field(1, "nodes", MapOf(String, ArrayOf(Node)))
+			})
+			GRPC(func() {
+				Response(CodeOK)
+			})
+		})
+	})
+}
+
 var MessageArrayDSL = func() {
 	var UT = Type("UT", func() {
 		Field(1, "ArrayOfPrimitives", ArrayOf(UInt))
@@ -676,3 +726,19 @@ var MultipleMethodsSameResultCollectionDSL = func() {
 		})
 	})
 }
+
+var MessageWithDefaultFromDSL = func() {
+	var RequestUT = Type("RequestUT", func() {
+		Field(1, "username", String)
+		Field(2, "display_name", String, func() {
+			DefaultFrom("username")
+		})
+		Required("username")
+	})
+	Service("ServiceMessageWithDefaultFrom", func() {
+		Method("MethodMessageWithDefaultFrom", func() {
+			Payload(RequestUT)
+			GRPC(func() {})
+		})
+	})
+}
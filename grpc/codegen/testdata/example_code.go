@@ -23,13 +23,19 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		serviceServer = servicesvr.New(serviceEndpoints, nil)
 	}
 
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+	// Initialize gRPC server with the middleware. The server serves TLS
+	// with the settings of the current configuration if it sets a
+	// certificate, see serverTLSConfig in config.go.
+	opts := []grpc.ServerOption{
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
 		),
-	)
+	}
+	if currentConfig().cert != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(serverTLSConfig("h2"))))
+	}
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	servicepb.RegisterServiceServer(srv, serviceServer)
@@ -46,9 +52,12 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		// Start gRPC server in a separate goroutine.
 		go func() {
-			lis, err := net.Listen("tcp", u.Host)
+			// Listen on the TCP address of the URL or on the matching socket passed by
+			// systemd socket activation.
+			lis, err := goa.Listen(u)
 			if err != nil {
 				errc <- err
+				return
 			}
 			logger.Printf("gRPC server listening on %q", u.Host)
 			errc <- srv.Serve(lis)
@@ -56,7 +65,21 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
+
+		// Shutdown gracefully: send GOAWAY to the clients and wait for the in-flight
+		// RPCs including the streams to complete up to the shutdown timeout set in the
+		// configuration.
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(currentConfig().shutdownTimeout):
+			logger.Printf("failed to shutdown gRPC server gracefully: timeout")
+			srv.Stop()
+		}
 	}()
 }
 `
@@ -84,13 +107,19 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		serviceServer = servicesvr.New(serviceEndpoints, nil)
 	}
 
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+	// Initialize gRPC server with the middleware. The server serves TLS
+	// with the settings of the current configuration if it sets a
+	// certificate, see serverTLSConfig in config.go.
+	opts := []grpc.ServerOption{
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
 		),
-	)
+	}
+	if currentConfig().cert != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(serverTLSConfig("h2"))))
+	}
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	servicepb.RegisterServiceServer(srv, serviceServer)
@@ -107,9 +136,12 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		// Start gRPC server in a separate goroutine.
 		go func() {
-			lis, err := net.Listen("tcp", u.Host)
+			// Listen on the TCP address of the URL or on the matching socket passed by
+			// systemd socket activation.
+			lis, err := goa.Listen(u)
 			if err != nil {
 				errc <- err
+				return
 			}
 			logger.Printf("gRPC server listening on %q", u.Host)
 			errc <- srv.Serve(lis)
@@ -117,7 +149,21 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
+
+		// Shutdown gracefully: send GOAWAY to the clients and wait for the in-flight
+		// RPCs including the streams to complete up to the shutdown timeout set in the
+		// configuration.
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(currentConfig().shutdownTimeout):
+			logger.Printf("failed to shutdown gRPC server gracefully: timeout")
+			srv.Stop()
+		}
 	}()
 }
 `
@@ -147,13 +193,19 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		anotherServiceServer = anotherservicesvr.New(anotherServiceEndpoints, nil)
 	}
 
-	// Initialize gRPC server with the middleware.
-	srv := grpc.NewServer(
+	// Initialize gRPC server with the middleware. The server serves TLS
+	// with the settings of the current configuration if it sets a
+	// certificate, see serverTLSConfig in config.go.
+	opts := []grpc.ServerOption{
 		grpcmiddleware.WithUnaryServerChain(
 			grpcmdlwr.UnaryRequestID(),
 			grpcmdlwr.UnaryServerLog(adapter),
 		),
-	)
+	}
+	if currentConfig().cert != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(serverTLSConfig("h2"))))
+	}
+	srv := grpc.NewServer(opts...)
 
 	// Register the servers.
 	servicepb.RegisterServiceServer(srv, serviceServer)
@@ -171,9 +223,12 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		// Start gRPC server in a separate goroutine.
 		go func() {
-			lis, err := net.Listen("tcp", u.Host)
+			// Listen on the TCP address of the URL or on the matching socket passed by
+			// systemd socket activation.
+			lis, err := goa.Listen(u)
 			if err != nil {
 				errc <- err
+				return
 			}
 			logger.Printf("gRPC server listening on %q", u.Host)
 			errc <- srv.Serve(lis)
@@ -181,7 +236,21 @@ func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 
 		<-ctx.Done()
 		logger.Printf("shutting down gRPC server at %q", u.Host)
-		srv.Stop()
+
+		// Shutdown gracefully: send GOAWAY to the clients and wait for the in-flight
+		// RPCs including the streams to complete up to the shutdown timeout set in the
+		// configuration.
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(currentConfig().shutdownTimeout):
+			logger.Printf("failed to shutdown gRPC server gracefully: timeout")
+			srv.Stop()
+		}
 	}()
 }
 `
@@ -193,6 +262,7 @@ const ExampleCLIImport = `import (
 
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 `
 
@@ -203,6 +273,7 @@ const ExampleSingleHostCLIImport = `import (
 
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 `
 
@@ -213,6 +284,7 @@ const ExamplePkgPathCLIImport = `import (
 
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 `
 
@@ -223,11 +295,233 @@ const ExampleSingleHostPkgPathCLIImport = `import (
 
 	goa "goa.design/goa/v3/pkg"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 `
 
 const ExampleCLICode = `func doGRPC(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
-	conn, err := grpc.Dial(host, grpc.WithInsecure())
+	creds := grpc.WithInsecure()
+	if scheme == "grpcs" {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(nil))
+	}
+	conn, err := grpc.Dial(host, creds)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, fmt.Sprintf("could not connect to gRPC server at %s: %v", host, err))
+	}
+	return cli.ParseEndpoint(conn)
+}
+`
+
+const HealthCheckServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to gRPC requests and
+	// responses.
+	var (
+		serviceServer *servicesvr.Server
+	)
+	{
+		serviceServer = servicesvr.New(serviceEndpoints, nil)
+	}
+
+	// Initialize gRPC server with the middleware. The server serves TLS
+	// with the settings of the current configuration if it sets a
+	// certificate, see serverTLSConfig in config.go.
+	opts := []grpc.ServerOption{
+		grpcmiddleware.WithUnaryServerChain(
+			grpcmdlwr.UnaryRequestID(),
+			grpcmdlwr.UnaryServerLog(adapter),
+		),
+	}
+	if currentConfig().cert != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(serverTLSConfig("h2"))))
+	}
+	srv := grpc.NewServer(opts...)
+
+	// Register the servers.
+	servicepb.RegisterServiceServer(srv, serviceServer)
+
+	// Register the standard gRPC health service, it runs the readiness
+	// checks registered in health.go.
+	{
+		var services []string
+		for svc := range srv.GetServiceInfo() {
+			services = append(services, svc)
+		}
+		healthpb.RegisterHealthServer(srv, goagrpc.NewHealthServer(health, services...))
+	}
+
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			logger.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start gRPC server in a separate goroutine.
+		go func() {
+			// Listen on the TCP address of the URL or on the matching socket passed by
+			// systemd socket activation.
+			lis, err := goa.Listen(u)
+			if err != nil {
+				errc <- err
+				return
+			}
+			logger.Printf("gRPC server listening on %q", u.Host)
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down gRPC server at %q", u.Host)
+
+		// Shutdown gracefully: send GOAWAY to the clients and wait for the in-flight
+		// RPCs including the streams to complete up to the shutdown timeout set in the
+		// configuration.
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(currentConfig().shutdownTimeout):
+			logger.Printf("failed to shutdown gRPC server gracefully: timeout")
+			srv.Stop()
+		}
+	}()
+}
+`
+
+const ReflectionHealthServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to gRPC requests and
+	// responses.
+	var (
+		serviceServer *servicesvr.Server
+	)
+	{
+		serviceServer = servicesvr.New(serviceEndpoints, nil)
+	}
+
+	// Initialize gRPC server with the middleware. The server serves TLS
+	// with the settings of the current configuration if it sets a
+	// certificate, see serverTLSConfig in config.go.
+	opts := []grpc.ServerOption{
+		grpcmiddleware.WithUnaryServerChain(
+			grpcmdlwr.UnaryRequestID(),
+			grpcmdlwr.UnaryServerLog(adapter),
+		),
+	}
+	if currentConfig().cert != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(serverTLSConfig("h2"))))
+	}
+	srv := grpc.NewServer(opts...)
+
+	// Register the servers.
+	servicepb.RegisterServiceServer(srv, serviceServer)
+
+	// Register the standard gRPC health service, it runs the readiness
+	// checks registered in health.go.
+	{
+		var services []string
+		for svc := range srv.GetServiceInfo() {
+			services = append(services, svc)
+		}
+		healthpb.RegisterHealthServer(srv, goagrpc.NewHealthServer(health, services...))
+	}
+
+	// Register the gRPC server reflection service so that tools such as
+	// grpcurl can list and call the services.
+	reflection.Register(srv)
+
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			logger.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start gRPC server in a separate goroutine.
+		go func() {
+			// Listen on the TCP address of the URL or on the matching socket passed by
+			// systemd socket activation.
+			lis, err := goa.Listen(u)
+			if err != nil {
+				errc <- err
+				return
+			}
+			logger.Printf("gRPC server listening on %q", u.Host)
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down gRPC server at %q", u.Host)
+
+		// Shutdown gracefully: send GOAWAY to the clients and wait for the in-flight
+		// RPCs including the streams to complete up to the shutdown timeout set in the
+		// configuration.
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(currentConfig().shutdownTimeout):
+			logger.Printf("failed to shutdown gRPC server gracefully: timeout")
+			srv.Stop()
+		}
+	}()
+}
+`
+
+const ExampleMutualTLSCLIImport = `import (
+	"crypto/tls"
+	"fmt"
+	cli "grpc/cli/mutualtls"
+	"os"
+
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+`
+
+const ExampleMutualTLSCLICode = `func doGRPC(scheme, host string, timeout int, debug bool, tlsConf *tls.Config) (goa.Endpoint, interface{}, error) {
+	creds := grpc.WithInsecure()
+	if scheme == "grpcs" {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tlsConf))
+	}
+	conn, err := grpc.Dial(host, creds)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, fmt.Sprintf("could not connect to gRPC server at %s: %v", host, err))
 	}
@@ -297,3 +297,28 @@ func DecodeMethodMessageWithSecurityRequest(ctx context.Context, v interface{},
 	return payload, nil
 }
 `
+
+const PayloadWithDefaultFromRequestDecoderCode = `// DecodeMethodMessageWithDefaultFromRequest decodes requests sent to
+// "ServiceMessageWithDefaultFrom" service "MethodMessageWithDefaultFrom"
+// endpoint.
+func DecodeMethodMessageWithDefaultFromRequest(ctx context.Context, v interface{}, md metadata.MD) (interface{}, error) {
+	var (
+		message *service_message_with_default_frompb.MethodMessageWithDefaultFromRequest
+		ok      bool
+	)
+	{
+		if message, ok = v.(*service_message_with_default_frompb.MethodMessageWithDefaultFromRequest); !ok {
+			return nil, goagrpc.ErrInvalidType("ServiceMessageWithDefaultFrom", "MethodMessageWithDefaultFrom", "*service_message_with_default_frompb.MethodMessageWithDefaultFromRequest", v)
+		}
+	}
+	var payload *servicemessagewithdefaultfrom.RequestUT
+	{
+		payload = NewMethodMessageWithDefaultFromPayload(message)
+		if payload.DisplayName == nil {
+			def := payload.Username
+			payload.DisplayName = &def
+		}
+	}
+	return payload, nil
+}
+`
@@ -7,7 +7,7 @@ func (s *Server) MethodUnaryRPCA(ctx context.Context, message *service_unaryrp_c
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
 	resp, err := s.MethodUnaryRPCAH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceUnaryRPCs", err))
 	}
 	return resp.(*service_unaryrp_cspb.MethodUnaryRPCAResponse), nil
 }
@@ -19,7 +19,7 @@ func (s *Server) MethodUnaryRPCB(ctx context.Context, message *service_unaryrp_c
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCs")
 	resp, err := s.MethodUnaryRPCBH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceUnaryRPCs", err))
 	}
 	return resp.(*service_unaryrp_cspb.MethodUnaryRPCBResponse), nil
 }
@@ -32,12 +32,28 @@ func (s *Server) MethodUnaryRPCNoPayload(ctx context.Context, message *service_u
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoPayload")
 	resp, err := s.MethodUnaryRPCNoPayloadH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceUnaryRPCNoPayload", err))
 	}
 	return resp.(*service_unaryrpc_no_payloadpb.MethodUnaryRPCNoPayloadResponse), nil
 }
 `
 
+const UnaryRPCWithTimeoutServerInterfaceCode = `// MethodUnaryRPCWithTimeout implements the "MethodUnaryRPCWithTimeout" method
+// in service_unaryrpc_with_timeoutpb.ServiceUnaryRPCWithTimeoutServer
+// interface.
+func (s *Server) MethodUnaryRPCWithTimeout(ctx context.Context, message *service_unaryrpc_with_timeoutpb.MethodUnaryRPCWithTimeoutRequest) (*service_unaryrpc_with_timeoutpb.MethodUnaryRPCWithTimeoutResponse, error) {
+	ctx = context.WithValue(ctx, goa.MethodKey, "MethodUnaryRPCWithTimeout")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCWithTimeout")
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	resp, err := s.MethodUnaryRPCWithTimeoutH.Handle(ctx, message)
+	if err != nil {
+		return nil, goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceUnaryRPCWithTimeout", err))
+	}
+	return resp.(*service_unaryrpc_with_timeoutpb.MethodUnaryRPCWithTimeoutResponse), nil
+}
+`
+
 const UnaryRPCNoResultServerInterfaceCode = `// MethodUnaryRPCNoResult implements the "MethodUnaryRPCNoResult" method in
 // service_unaryrpc_no_resultpb.ServiceUnaryRPCNoResultServer interface.
 func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_unaryrpc_no_resultpb.MethodUnaryRPCNoResultRequest) (*service_unaryrpc_no_resultpb.MethodUnaryRPCNoResultResponse, error) {
@@ -45,7 +61,7 @@ func (s *Server) MethodUnaryRPCNoResult(ctx context.Context, message *service_un
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceUnaryRPCNoResult")
 	resp, err := s.MethodUnaryRPCNoResultH.Handle(ctx, message)
 	if err != nil {
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceUnaryRPCNoResult", err))
 	}
 	return resp.(*service_unaryrpc_no_resultpb.MethodUnaryRPCNoResultResponse), nil
 }
@@ -61,19 +77,19 @@ func (s *Server) MethodUnaryRPCWithErrors(ctx context.Context, message *service_
 		if en, ok := err.(ErrorNamer); ok {
 			switch en.ErrorName() {
 			case "timeout":
-				return nil, goagrpc.NewStatusError(codes.Canceled, err, goagrpc.NewErrorResponse(err))
+				return nil, goagrpc.NewStatusError(codes.Canceled, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceUnaryRPCWithErrors", err))
 			case "internal":
 				er := err.(*serviceunaryrpcwitherrors.AnotherError)
-				return nil, goagrpc.NewStatusError(codes.Unknown, err, NewMethodUnaryRPCWithErrorsInternalError(er))
+				return nil, goagrpc.NewStatusError(codes.Unknown, err, NewMethodUnaryRPCWithErrorsInternalError(er), goagrpc.NewErrorInfo("ServiceUnaryRPCWithErrors", err))
 			case "bad_request":
 				er := err.(*serviceunaryrpcwitherrors.AnotherError)
-				return nil, goagrpc.NewStatusError(codes.InvalidArgument, err, NewMethodUnaryRPCWithErrorsBadRequestError(er))
+				return nil, goagrpc.NewStatusError(codes.InvalidArgument, err, NewMethodUnaryRPCWithErrorsBadRequestError(er), goagrpc.NewErrorInfo("ServiceUnaryRPCWithErrors", err))
 			case "custom_error":
 				er := err.(*serviceunaryrpcwitherrors.ErrorType)
-				return nil, goagrpc.NewStatusError(codes.Unknown, err, NewMethodUnaryRPCWithErrorsCustomErrorError(er))
+				return nil, goagrpc.NewStatusError(codes.Unknown, err, NewMethodUnaryRPCWithErrorsCustomErrorError(er), goagrpc.NewErrorInfo("ServiceUnaryRPCWithErrors", err))
 			}
 		}
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceUnaryRPCWithErrors", err))
 	}
 	return resp.(*service_unaryrpc_with_errorspb.MethodUnaryRPCWithErrorsResponse), nil
 }
@@ -91,12 +107,12 @@ func (s *Server) MethodUnaryRPCWithOverridingErrors(ctx context.Context, message
 		if en, ok := err.(ErrorNamer); ok {
 			switch en.ErrorName() {
 			case "overridden":
-				return nil, goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err))
+				return nil, goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceUnaryRPCWithOverridingErrors", err))
 			case "internal":
-				return nil, goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err))
+				return nil, goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceUnaryRPCWithOverridingErrors", err))
 			}
 		}
-		return nil, goagrpc.EncodeError(err)
+		return nil, goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceUnaryRPCWithOverridingErrors", err))
 	}
 	return resp.(*service_unaryrpc_with_overriding_errorspb.MethodUnaryRPCWithOverridingErrorsResponse), nil
 }
@@ -110,7 +126,7 @@ func (s *Server) MethodServerStreamingRPC(message *service_server_streamingrpcpb
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceServerStreamingRPC")
 	p, err := s.MethodServerStreamingRPCH.Decode(ctx, message)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceServerStreamingRPC", err))
 	}
 	ep := &serviceserverstreamingrpc.MethodServerStreamingRPCEndpointInput{
 		Stream:  &MethodServerStreamingRPCServerStream{stream: stream},
@@ -118,7 +134,7 @@ func (s *Server) MethodServerStreamingRPC(message *service_server_streamingrpcpb
 	}
 	err = s.MethodServerStreamingRPCH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceServerStreamingRPC", err))
 	}
 	return nil
 }
@@ -132,14 +148,14 @@ func (s *Server) MethodClientStreamingRPC(stream service_client_streamingrpcpb.S
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPC")
 	p, err := s.MethodClientStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceClientStreamingRPC", err))
 	}
 	ep := &serviceclientstreamingrpc.MethodClientStreamingRPCEndpointInput{
 		Stream: &MethodClientStreamingRPCServerStream{stream: stream},
 	}
 	err = s.MethodClientStreamingRPCH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceClientStreamingRPC", err))
 	}
 	return nil
 }
@@ -155,7 +171,7 @@ func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_strea
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceClientStreamingRPCWithPayload")
 	p, err := s.MethodClientStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceClientStreamingRPCWithPayload", err))
 	}
 	ep := &serviceclientstreamingrpcwithpayload.MethodClientStreamingRPCWithPayloadEndpointInput{
 		Stream:  &MethodClientStreamingRPCWithPayloadServerStream{stream: stream},
@@ -163,7 +179,7 @@ func (s *Server) MethodClientStreamingRPCWithPayload(stream service_client_strea
 	}
 	err = s.MethodClientStreamingRPCWithPayloadH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceClientStreamingRPCWithPayload", err))
 	}
 	return nil
 }
@@ -179,14 +195,14 @@ func (s *Server) MethodBidirectionalStreamingRPC(stream service_bidirectional_st
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPC")
 	p, err := s.MethodBidirectionalStreamingRPCH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPC", err))
 	}
 	ep := &servicebidirectionalstreamingrpc.MethodBidirectionalStreamingRPCEndpointInput{
 		Stream: &MethodBidirectionalStreamingRPCServerStream{stream: stream},
 	}
 	err = s.MethodBidirectionalStreamingRPCH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPC", err))
 	}
 	return nil
 }
@@ -202,7 +218,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidir
 	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceBidirectionalStreamingRPCWithPayload")
 	p, err := s.MethodBidirectionalStreamingRPCWithPayloadH.Decode(ctx, nil)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithPayload", err))
 	}
 	ep := &servicebidirectionalstreamingrpcwithpayload.MethodBidirectionalStreamingRPCWithPayloadEndpointInput{
 		Stream:  &MethodBidirectionalStreamingRPCWithPayloadServerStream{stream: stream},
@@ -210,7 +226,7 @@ func (s *Server) MethodBidirectionalStreamingRPCWithPayload(stream service_bidir
 	}
 	err = s.MethodBidirectionalStreamingRPCWithPayloadH.Handle(ctx, ep)
 	if err != nil {
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithPayload", err))
 	}
 	return nil
 }
@@ -229,14 +245,14 @@ func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidire
 		if en, ok := err.(ErrorNamer); ok {
 			switch en.ErrorName() {
 			case "timeout":
-				return goagrpc.NewStatusError(codes.Canceled, err, goagrpc.NewErrorResponse(err))
+				return goagrpc.NewStatusError(codes.Canceled, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
 			case "internal":
-				return goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err))
+				return goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
 			case "bad_request":
-				return goagrpc.NewStatusError(codes.InvalidArgument, err, goagrpc.NewErrorResponse(err))
+				return goagrpc.NewStatusError(codes.InvalidArgument, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
 			}
 		}
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
 	}
 	ep := &servicebidirectionalstreamingrpcwitherrors.MethodBidirectionalStreamingRPCWithErrorsEndpointInput{
 		Stream: &MethodBidirectionalStreamingRPCWithErrorsServerStream{stream: stream},
@@ -246,14 +262,38 @@ func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidire
 		if en, ok := err.(ErrorNamer); ok {
 			switch en.ErrorName() {
 			case "timeout":
-				return goagrpc.NewStatusError(codes.Canceled, err, goagrpc.NewErrorResponse(err))
+				return goagrpc.NewStatusError(codes.Canceled, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
 			case "internal":
-				return goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err))
+				return goagrpc.NewStatusError(codes.Unknown, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
 			case "bad_request":
-				return goagrpc.NewStatusError(codes.InvalidArgument, err, goagrpc.NewErrorResponse(err))
+				return goagrpc.NewStatusError(codes.InvalidArgument, err, goagrpc.NewErrorResponse(err), goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
 			}
 		}
-		return goagrpc.EncodeError(err)
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceBidirectionalStreamingRPCWithErrors", err))
+	}
+	return nil
+}
+`
+
+const StreamResultsRPCServerInterfaceCode = `// MethodStreamResultsRPC implements the "MethodStreamResultsRPC" method in
+// service_stream_resultsrpcpb.ServiceStreamResultsRPCServer interface.
+func (s *Server) MethodStreamResultsRPC(message *service_stream_resultsrpcpb.MethodStreamResultsRPCRequest, stream service_stream_resultsrpcpb.ServiceStreamResultsRPC_MethodStreamResultsRPCServer) error {
+	ctx := stream.Context()
+	ctx = context.WithValue(ctx, goa.MethodKey, "MethodStreamResultsRPC")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceStreamResultsRPC")
+	resp, err := s.MethodStreamResultsRPCH.Handle(ctx, message)
+	if err != nil {
+		return goagrpc.EncodeError(err, goagrpc.NewErrorInfo("ServiceStreamResultsRPC", err))
+	}
+	elems := resp.(*service_stream_resultsrpcpb.MethodStreamResultsRPCResponse).Field
+	for start := 0; start < len(elems); start += 50 {
+		end := start + 50
+		if end > len(elems) {
+			end = len(elems)
+		}
+		if err := stream.Send(&service_stream_resultsrpcpb.MethodStreamResultsRPCResponse{Field: elems[start:end]}); err != nil {
+			return err
+		}
 	}
 	return nil
 }
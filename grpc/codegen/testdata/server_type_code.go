@@ -147,6 +147,208 @@ func svcServicepayloadwithnestedtypesBParamsToServicePayloadWithNestedTypespbBPa
 }
 `
 
+const PayloadWithNestedMapsServerTypeCode = `// NewMethodPayloadWithNestedMapsPayload builds the payload of the
+// "MethodPayloadWithNestedMaps" endpoint of the "ServicePayloadWithNestedMaps"
+// service from the gRPC request type.
+func NewMethodPayloadWithNestedMapsPayload(message *service_payload_with_nested_mapspb.MethodPayloadWithNestedMapsRequest) *servicepayloadwithnestedmaps.MethodPayloadWithNestedMapsPayload {
+	v := &servicepayloadwithnestedmaps.MethodPayloadWithNestedMapsPayload{}
+	if message.Nodes != nil {
+		v.Nodes = make(map[string][]*servicepayloadwithnestedmaps.Node, len(message.Nodes))
+		for key, val := range message.Nodes {
+			tk := key
+			tvc := make([]*servicepayloadwithnestedmaps.Node, len(val.Field))
+			for i, val := range val.Field {
+				tvc[i] = protobufServicePayloadWithNestedMapspbNodeToServicepayloadwithnestedmapsNode(val)
+			}
+			v.Nodes[tk] = tvc
+		}
+	}
+	return v
+}
+
+// NewMethodPayloadWithNestedMapsResponse builds the gRPC response type from
+// the result of the "MethodPayloadWithNestedMaps" endpoint of the
+// "ServicePayloadWithNestedMaps" service.
+func NewMethodPayloadWithNestedMapsResponse() *service_payload_with_nested_mapspb.MethodPayloadWithNestedMapsResponse {
+	message := &service_payload_with_nested_mapspb.MethodPayloadWithNestedMapsResponse{}
+	return message
+}
+
+// ValidateMethodPayloadWithNestedMapsRequest runs the validations defined on
+// MethodPayloadWithNestedMapsRequest.
+func ValidateMethodPayloadWithNestedMapsRequest(message *service_payload_with_nested_mapspb.MethodPayloadWithNestedMapsRequest) (err error) {
+	for _, v := range message.Nodes {
+		if v != nil {
+			if err2 := ValidateArrayOfNode(v); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateArrayOfNode runs the validations defined on ArrayOfNode.
+func ValidateArrayOfNode(message *service_payload_with_nested_mapspb.ArrayOfNode) (err error) {
+	if message.Field == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("field", "message"))
+	}
+	for _, e := range message.Field {
+		if e != nil {
+			if err2 := ValidateNode(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateNode runs the validations defined on Node.
+func ValidateNode(message *service_payload_with_nested_mapspb.Node) (err error) {
+	for _, v := range message.Leaves {
+		if v != nil {
+			if err2 := ValidateArrayOfMapOfSint32Leaf(v); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateArrayOfMapOfSint32Leaf runs the validations defined on
+// ArrayOfMapOfSint32Leaf.
+func ValidateArrayOfMapOfSint32Leaf(message *service_payload_with_nested_mapspb.ArrayOfMapOfSint32Leaf) (err error) {
+	if message.Field == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("field", "message"))
+	}
+	for _, e := range message.Field {
+		if e != nil {
+			if err2 := ValidateMapOfSint32Leaf(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidateMapOfSint32Leaf runs the validations defined on MapOfSint32Leaf.
+func ValidateMapOfSint32Leaf(message *service_payload_with_nested_mapspb.MapOfSint32Leaf) (err error) {
+	if message.Field == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("field", "message"))
+	}
+	return
+}
+
+// ValidateLeaf runs the validations defined on Leaf.
+func ValidateLeaf(message *service_payload_with_nested_mapspb.Leaf) (err error) {
+
+	return
+}
+
+// protobufServicePayloadWithNestedMapspbNodeToServicepayloadwithnestedmapsNode
+// builds a value of type *servicepayloadwithnestedmaps.Node from a value of
+// type *service_payload_with_nested_mapspb.Node.
+func protobufServicePayloadWithNestedMapspbNodeToServicepayloadwithnestedmapsNode(v *service_payload_with_nested_mapspb.Node) *servicepayloadwithnestedmaps.Node {
+	if v == nil {
+		return nil
+	}
+	res := &servicepayloadwithnestedmaps.Node{}
+	if v.Children != nil {
+		res.Children = make(map[string]*servicepayloadwithnestedmaps.Node, len(v.Children))
+		for key, val := range v.Children {
+			tk := key
+			tvc := protobufServicePayloadWithNestedMapspbNodeToServicepayloadwithnestedmapsNode(val)
+			res.Children[tk] = tvc
+		}
+	}
+	if v.Leaves != nil {
+		res.Leaves = make(map[string][]map[int]*servicepayloadwithnestedmaps.Leaf, len(v.Leaves))
+		for key, val := range v.Leaves {
+			tk := key
+			tvb := make([]map[int]*servicepayloadwithnestedmaps.Leaf, len(val.Field))
+			for i, val := range val.Field {
+				tvb[i] = make(map[int]*servicepayloadwithnestedmaps.Leaf, len(val.Field))
+				for key, val := range val.Field {
+					tk := int(key)
+					tv := protobufServicePayloadWithNestedMapspbLeafToServicepayloadwithnestedmapsLeaf(val)
+					tvb[i][tk] = tv
+				}
+			}
+			res.Leaves[tk] = tvb
+		}
+	}
+
+	return res
+}
+
+// protobufServicePayloadWithNestedMapspbLeafToServicepayloadwithnestedmapsLeaf
+// builds a value of type *servicepayloadwithnestedmaps.Leaf from a value of
+// type *service_payload_with_nested_mapspb.Leaf.
+func protobufServicePayloadWithNestedMapspbLeafToServicepayloadwithnestedmapsLeaf(v *service_payload_with_nested_mapspb.Leaf) *servicepayloadwithnestedmaps.Leaf {
+	if v == nil {
+		return nil
+	}
+	res := &servicepayloadwithnestedmaps.Leaf{}
+	if v.Name != "" {
+		res.Name = &v.Name
+	}
+
+	return res
+}
+
+// svcServicepayloadwithnestedmapsNodeToServicePayloadWithNestedMapspbNode
+// builds a value of type *service_payload_with_nested_mapspb.Node from a value
+// of type *servicepayloadwithnestedmaps.Node.
+func svcServicepayloadwithnestedmapsNodeToServicePayloadWithNestedMapspbNode(v *servicepayloadwithnestedmaps.Node) *service_payload_with_nested_mapspb.Node {
+	if v == nil {
+		return nil
+	}
+	res := &service_payload_with_nested_mapspb.Node{}
+	if v.Children != nil {
+		res.Children = make(map[string]*service_payload_with_nested_mapspb.Node, len(v.Children))
+		for key, val := range v.Children {
+			tk := key
+			tvc := svcServicepayloadwithnestedmapsNodeToServicePayloadWithNestedMapspbNode(val)
+			res.Children[tk] = tvc
+		}
+	}
+	if v.Leaves != nil {
+		res.Leaves = make(map[string]*service_payload_with_nested_mapspb.ArrayOfMapOfSint32Leaf, len(v.Leaves))
+		for key, val := range v.Leaves {
+			tk := key
+			tvb := &service_payload_with_nested_mapspb.ArrayOfMapOfSint32Leaf{}
+			tvb.Field = make([]*service_payload_with_nested_mapspb.MapOfSint32Leaf, len(val))
+			for i, val := range val {
+				tvb.Field[i] = &service_payload_with_nested_mapspb.MapOfSint32Leaf{}
+				tvb.Field[i].Field = make(map[int32]*service_payload_with_nested_mapspb.Leaf, len(val))
+				for key, val := range val {
+					tk := int32(key)
+					tv := svcServicepayloadwithnestedmapsLeafToServicePayloadWithNestedMapspbLeaf(val)
+					tvb.Field[i].Field[tk] = tv
+				}
+			}
+			res.Leaves[tk] = tvb
+		}
+	}
+
+	return res
+}
+
+// svcServicepayloadwithnestedmapsLeafToServicePayloadWithNestedMapspbLeaf
+// builds a value of type *service_payload_with_nested_mapspb.Leaf from a value
+// of type *servicepayloadwithnestedmaps.Leaf.
+func svcServicepayloadwithnestedmapsLeafToServicePayloadWithNestedMapspbLeaf(v *servicepayloadwithnestedmaps.Leaf) *service_payload_with_nested_mapspb.Leaf {
+	if v == nil {
+		return nil
+	}
+	res := &service_payload_with_nested_mapspb.Leaf{}
+	if v.Name != nil {
+		res.Name = *v.Name
+	}
+
+	return res
+}
+`
+
 const ResultWithCollectionServerTypeCode = `// NewMethodResultWithCollectionResponse builds the gRPC response type from the
 // result of the "MethodResultWithCollection" endpoint of the
 // "ServiceResultWithCollection" service.
@@ -170,16 +372,28 @@ func svcServiceresultwithcollectionResultTToServiceResultWithCollectionpbResultT
 		res.CollectionField = &service_result_with_collectionpb.RTCollection{}
 		res.CollectionField.Field = make([]*service_result_with_collectionpb.RT, len(v.CollectionField))
 		for i, val := range v.CollectionField {
-			res.CollectionField.Field[i] = &service_result_with_collectionpb.RT{}
-			if val.IntField != nil {
-				res.CollectionField.Field[i].IntField = int32(*val.IntField)
-			}
+			res.CollectionField.Field[i] = svcServiceresultwithcollectionRTToServiceResultWithCollectionpbRT(val)
 		}
 	}
 
 	return res
 }
 
+// svcServiceresultwithcollectionRTToServiceResultWithCollectionpbRT builds a
+// value of type *service_result_with_collectionpb.RT from a value of type
+// *serviceresultwithcollection.RT.
+func svcServiceresultwithcollectionRTToServiceResultWithCollectionpbRT(v *serviceresultwithcollection.RT) *service_result_with_collectionpb.RT {
+	if v == nil {
+		return nil
+	}
+	res := &service_result_with_collectionpb.RT{}
+	if v.IntField != nil {
+		res.IntField = int32(*v.IntField)
+	}
+
+	return res
+}
+
 // protobufServiceResultWithCollectionpbResultTToServiceresultwithcollectionResultT
 // builds a value of type *serviceresultwithcollection.ResultT from a value of
 // type *service_result_with_collectionpb.ResultT.
@@ -191,16 +405,28 @@ func protobufServiceResultWithCollectionpbResultTToServiceresultwithcollectionRe
 	if v.CollectionField != nil {
 		res.CollectionField = make([]*serviceresultwithcollection.RT, len(v.CollectionField.Field))
 		for i, val := range v.CollectionField.Field {
-			res.CollectionField[i] = &serviceresultwithcollection.RT{}
-			if val.IntField != 0 {
-				intFieldptr := int(val.IntField)
-				res.CollectionField[i].IntField = &intFieldptr
-			}
+			res.CollectionField[i] = protobufServiceResultWithCollectionpbRTToServiceresultwithcollectionRT(val)
 		}
 	}
 
 	return res
 }
+
+// protobufServiceResultWithCollectionpbRTToServiceresultwithcollectionRT
+// builds a value of type *serviceresultwithcollection.RT from a value of type
+// *service_result_with_collectionpb.RT.
+func protobufServiceResultWithCollectionpbRTToServiceresultwithcollectionRT(v *service_result_with_collectionpb.RT) *serviceresultwithcollection.RT {
+	if v == nil {
+		return nil
+	}
+	res := &serviceresultwithcollection.RT{}
+	if v.IntField != 0 {
+		intFieldptr := int(v.IntField)
+		res.IntField = &intFieldptr
+	}
+
+	return res
+}
 `
 
 const WithErrorsServerTypeCode = `// NewMethodUnaryRPCWithErrorsPayload builds the payload of the
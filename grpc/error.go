@@ -2,10 +2,15 @@ package grpc
 
 import (
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -50,8 +55,9 @@ func NewErrorResponse(err error) *goapb.ErrorResponse {
 }
 
 // NewServiceError returns a goa ServiceError type for the given ErrorResponse
-// message.
-func NewServiceError(resp *goapb.ErrorResponse) *goa.ServiceError {
+// message. fields lists the field validation errors decoded from the status
+// details with DecodeFieldErrors if any.
+func NewServiceError(resp *goapb.ErrorResponse, fields ...*goa.FieldError) *goa.ServiceError {
 	return &goa.ServiceError{
 		Name:      resp.Name,
 		ID:        resp.Id,
@@ -59,12 +65,70 @@ func NewServiceError(resp *goapb.ErrorResponse) *goa.ServiceError {
 		Timeout:   resp.Timeout,
 		Temporary: resp.Temporary,
 		Fault:     resp.Fault,
+		Fields:    fields,
+	}
+}
+
+// NewErrorInfo creates a google.rpc.ErrorInfo message that describes the given
+// error. The reason is the upper snake case version of the goa ServiceError
+// name ("FAULT" if err is not a ServiceError) and domain is typically the name
+// of the service. The metadata contains the error name and the error ID if err
+// is a ServiceError.
+func NewErrorInfo(domain string, err error) *goapb.ErrorInfo {
+	name, md := "fault", map[string]string{}
+	if gerr, ok := err.(*goa.ServiceError); ok {
+		name, md["id"] = gerr.Name, gerr.ID
+	}
+	md["name"] = name
+	reason := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+	return &goapb.ErrorInfo{Reason: reason, Domain: domain, Metadata: md}
+}
+
+// NewBadRequest creates a google.rpc.BadRequest message that lists the field
+// validation errors of the given error, see goa.ServiceError.FieldErrors. It
+// returns nil if err is not a goa ServiceError or does not contain field
+// validation errors.
+func NewBadRequest(err error) *errdetails.BadRequest {
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok || len(gerr.FieldErrors()) == 0 {
+		return nil
+	}
+	fes := gerr.FieldErrors()
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(fes))
+	for i, fe := range fes {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: fe.Path, Description: fe.Message}
 	}
+	return &errdetails.BadRequest{FieldViolations: violations}
+}
+
+// NewRetryInfo creates a google.rpc.RetryInfo message with the RetryAfter
+// delay of the given error. It returns nil if err is not a goa ServiceError or
+// does not have a delay.
+func NewRetryInfo(err error) *errdetails.RetryInfo {
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok || gerr.RetryAfter <= 0 {
+		return nil
+	}
+	return &errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(gerr.RetryAfter)}
 }
 
 // NewStatusError creates a gRPC status error with the error response
-// messages added to its details.
+// messages added to its details. A google.rpc.BadRequest message that lists
+// the field validation errors of err is appended to the details if err
+// contains any, see NewBadRequest. A google.rpc.RetryInfo message is appended
+// if err has a RetryAfter delay, see NewRetryInfo.
 func NewStatusError(code codes.Code, err error, details ...proto.Message) error {
+	if br := NewBadRequest(err); br != nil {
+		details = append(details, br)
+	}
+	if ri := NewRetryInfo(err); ri != nil {
+		details = append(details, ri)
+	}
 	st := status.New(code, err.Error())
 	if s, err := st.WithDetails(details...); err == nil {
 		return s.Err()
@@ -73,14 +137,17 @@ func NewStatusError(code codes.Code, err error, details ...proto.Message) error
 }
 
 // EncodeError returns a gRPC status error from the given error with the error
-// response encoded in the status details. If error is a goa ServiceError type
-// it implements a heuristic to compute the status code from the Timeout,
-// Fault, and Temporary characteristics of the ServiceError. If error is not a
-// ServiceError or a gRPC status error it returns a gRPC status error with
-// Unknown code and Fault characteristic set.
-func EncodeError(err error) error {
+// response followed by the given details encoded in the status details. If
+// error is a goa ServiceError type it implements a heuristic to compute the
+// status code from the Timeout, Fault, and Temporary characteristics of the
+// ServiceError, the code of errors that contain field validation errors is
+// InvalidArgument otherwise. If error is not a ServiceError or a gRPC status
+// error it returns a gRPC status error with Unknown code and Fault
+// characteristic set.
+func EncodeError(err error, details ...proto.Message) error {
+	details = append([]proto.Message{NewErrorResponse(err)}, details...)
 	if st, ok := status.FromError(err); ok {
-		if s, err := st.WithDetails(NewErrorResponse(err)); err == nil {
+		if s, err := st.WithDetails(details...); err == nil {
 			return s.Err()
 		}
 		return st.Err()
@@ -91,6 +158,9 @@ func EncodeError(err error) error {
 		var code codes.Code
 		{
 			code = codes.Unknown
+			if len(gerr.FieldErrors()) > 0 {
+				code = codes.InvalidArgument
+			}
 			if gerr.Fault {
 				code = codes.Internal
 			}
@@ -101,10 +171,10 @@ func EncodeError(err error) error {
 				code = codes.Unavailable
 			}
 		}
-		return NewStatusError(code, err, NewErrorResponse(err))
+		return NewStatusError(code, err, details...)
 	}
 	// Return an unknown gRPC status error with fault characteristic set.
-	return NewStatusError(codes.Unknown, err, NewErrorResponse(err))
+	return NewStatusError(codes.Unknown, err, details...)
 }
 
 // DecodeError returns the error message encoded in the status details if error
@@ -123,6 +193,71 @@ func DecodeError(err error) proto.Message {
 	return details[0].(proto.Message)
 }
 
+// DecodeFieldErrors returns the field validation errors listed in the
+// google.rpc.BadRequest message of the status details if error is a gRPC
+// status error. The Rule of the returned field errors is not set. It returns
+// nil if there is no such message.
+func DecodeFieldErrors(err error) []*goa.FieldError {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		fes := make([]*goa.FieldError, len(br.FieldViolations))
+		for i, v := range br.FieldViolations {
+			fes[i] = &goa.FieldError{Path: v.Field, Message: v.Description}
+		}
+		return fes
+	}
+	return nil
+}
+
+// DecodeRetryAfter returns the delay of the google.rpc.RetryInfo message of the
+// status details if error is a gRPC status error, zero if there is no such
+// message.
+func DecodeRetryAfter(err error) time.Duration {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0
+	}
+	for _, d := range st.Details() {
+		ri, ok := d.(*errdetails.RetryInfo)
+		if !ok {
+			continue
+		}
+		delay, err := ptypes.Duration(ri.RetryDelay)
+		if err != nil {
+			return 0
+		}
+		return delay
+	}
+	return 0
+}
+
+// DecodeErrorInfo returns the google.rpc.ErrorInfo message of the status
+// details if error is a gRPC status error, nil if there is no such message.
+func DecodeErrorInfo(err error) *goapb.ErrorInfo {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, d := range st.Proto().GetDetails() {
+		if !strings.HasSuffix(d.GetTypeUrl(), "/"+goapb.ErrorInfoMessageName) {
+			continue
+		}
+		var info goapb.ErrorInfo
+		if err := proto.Unmarshal(d.GetValue(), &info); err != nil {
+			return nil
+		}
+		return &info
+	}
+	return nil
+}
+
 // ErrInvalidType is the error returned when the wrong type is given to a
 // encoder or decoder.
 func ErrInvalidType(svc, m, expected string, actual interface{}) error {
@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	goapb "goa.design/goa/v3/grpc/pb"
+	goa "goa.design/goa/v3/pkg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryInfo(t *testing.T) {
+	limited := goa.TemporaryError("rate_limited", "too many requests")
+	limited.RetryAfter = 30 * time.Second
+	cases := []struct {
+		Name     string
+		Err      error
+		Expected time.Duration
+	}{
+		{"retry-after", limited, 30 * time.Second},
+		{"no-retry-after", goa.TemporaryError("unavailable", "try again"), 0},
+		{"other", errors.New("boom"), 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := EncodeError(c.Err)
+			if d := DecodeRetryAfter(err); d != c.Expected {
+				t.Errorf("got delay %s, expected %s", d, c.Expected)
+			}
+		})
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	validation := goa.MergeErrors(goa.MissingFieldError("name", "body"), goa.InvalidLengthError("body.id", "a", 1, 3, true))
+	cases := []struct {
+		Name   string
+		Err    error
+		Code   codes.Code
+		Reason string
+		Fields []*goa.FieldError
+	}{
+		{"validation", validation, codes.InvalidArgument, "MISSING_FIELD", []*goa.FieldError{
+			{Path: "body.name", Message: `"name" is missing from body`},
+			{Path: "body.id", Message: `length of body.id must be greater or equal than 3 but got value "a" (len=1)`},
+		}},
+		{"permanent", goa.PermanentError("not_found", "user not found"), codes.Unknown, "NOT_FOUND", nil},
+		{"temporary", goa.TemporaryError("unavailable", "try again"), codes.Unavailable, "UNAVAILABLE", nil},
+		{"other", errors.New("boom"), codes.Unknown, "FAULT", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := EncodeError(c.Err, NewErrorInfo("svc", c.Err))
+
+			st, _ := status.FromError(err)
+			if st.Code() != c.Code {
+				t.Errorf("got code %s, expected %s", st.Code(), c.Code)
+			}
+			resp, ok := DecodeError(err).(*goapb.ErrorResponse)
+			if !ok {
+				t.Fatalf("got first detail %T, expected *goapb.ErrorResponse", DecodeError(err))
+			}
+			info := DecodeErrorInfo(err)
+			if info == nil {
+				t.Fatal("got no error info")
+			}
+			if info.Reason != c.Reason {
+				t.Errorf("got reason %q, expected %q", info.Reason, c.Reason)
+			}
+			if info.Domain != "svc" {
+				t.Errorf("got domain %q, expected %q", info.Domain, "svc")
+			}
+			var id string
+			if _, ok := c.Err.(*goa.ServiceError); ok {
+				id = resp.Id
+			}
+			if info.Metadata["id"] != id {
+				t.Errorf("got id %q, expected %q", info.Metadata["id"], id)
+			}
+			fields := NewServiceError(resp, DecodeFieldErrors(err)...).Fields
+			if len(fields) != len(c.Fields) {
+				t.Fatalf("got %d field errors, expected %d", len(fields), len(c.Fields))
+			}
+			for i, f := range fields {
+				if *f != *c.Fields[i] {
+					t.Errorf("got field error %d %+v, expected %+v", i, *f, *c.Fields[i])
+				}
+			}
+		})
+	}
+}
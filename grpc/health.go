@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// HealthServer implements the standard gRPC health service
+// (grpc.health.v1.Health) by running the readiness checks of a goa.Health. The
+// example servers generated for the designs that use the HealthCheck DSL
+// register it with the gRPC server.
+type HealthServer struct {
+	// WatchInterval is the interval at which Watch runs the readiness
+	// checks to detect status changes.
+	WatchInterval time.Duration
+
+	health   *goa.Health
+	services map[string]bool
+}
+
+// DefaultHealthWatchInterval is the default interval at which the Watch method
+// of HealthServer runs the readiness checks.
+const DefaultHealthWatchInterval = 5 * time.Second
+
+// NewHealthServer returns the gRPC health service that reports the status of
+// the readiness checks of h for the server as a whole (empty service name) and
+// for each of the given fully qualified service names. The status of the
+// other services is unknown.
+func NewHealthServer(h *goa.Health, services ...string) *HealthServer {
+	known := make(map[string]bool, len(services))
+	for _, s := range services {
+		known[s] = true
+	}
+	return &HealthServer{WatchInterval: DefaultHealthWatchInterval, health: h, services: known}
+}
+
+// Check runs the readiness checks and returns the corresponding serving status.
+// It returns a NotFound error if the requested service is unknown.
+func (s *HealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.Service != "" && !s.services[req.Service] {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+// Watch sends the serving status of the requested service immediately and
+// then each time it changes until the client cancels the call. The status of
+// unknown services is SERVICE_UNKNOWN.
+func (s *HealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	if req.Service != "" && !s.services[req.Service] {
+		if err := stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN}); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		return status.Error(codes.Canceled, "stream has ended")
+	}
+	ticker := time.NewTicker(s.WatchInterval)
+	defer ticker.Stop()
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		if st := s.status(ctx); st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case <-ticker.C:
+		}
+	}
+}
+
+// status runs the readiness checks and returns the corresponding serving
+// status.
+func (s *HealthServer) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if s.health.Ready(ctx).OK() {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -21,7 +21,9 @@ import (
 // corresponding response details.
 //
 // The middleware logs the incoming requests gRPC method. It also logs the
-// response gRPC status code, message length (in bytes), and timing information.
+// response gRPC status code, message length (in bytes), and timing information
+// together with the fields added to the request log context (see
+// middleware.AddLogFields).
 func UnaryServerLog(l middleware.Logger) grpc.UnaryServerInterceptor {
 	return grpc.UnaryServerInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		var reqID string
@@ -44,14 +46,16 @@ func UnaryServerLog(l middleware.Logger) grpc.UnaryServerInterceptor {
 			"bytes", messageLength(req))
 
 		// invoke rpc
+		ctx = middleware.WithLogContext(ctx)
 		resp, err = handler(ctx, req)
 
 		// after executing rpc
 		s, _ := status.FromError(err)
-		l.Log("id", reqID,
+		l.Log(append([]interface{}{"id", reqID,
 			"status", s.Code(),
 			"bytes", messageLength(resp),
-			"time", time.Since(started).String())
+			"time", time.Since(started).String()},
+			middleware.LogFields(ctx)...)...)
 		return resp, err
 	})
 }
@@ -83,14 +87,16 @@ func StreamServerLog(l middleware.Logger) grpc.StreamServerInterceptor {
 			"msg", "started stream")
 
 		// invoke rpc
-		err := handler(srv, ss)
+		ctx := middleware.WithLogContext(ss.Context())
+		err := handler(srv, NewWrappedServerStream(ctx, ss))
 
 		// after executing rpc
 		s, _ := status.FromError(err)
-		l.Log("id", reqID,
+		l.Log(append([]interface{}{"id", reqID,
 			"status", s.Code(),
 			"msg", "completed stream",
-			"time", time.Since(started).String())
+			"time", time.Since(started).String()},
+			middleware.LogFields(ctx)...)...)
 		return err
 	})
 }
@@ -0,0 +1,35 @@
+package goapb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+// ErrorInfo is the google.rpc.ErrorInfo message that describes the cause of
+// an error with a reason and the domain that defines the reason, see
+// https://github.com/googleapis/googleapis/blob/master/google/rpc/error_details.proto.
+//
+// ErrorInfo is wire compatible with the message of the same name in
+// google.golang.org/genproto/googleapis/rpc/errdetails. It is not registered
+// in the protocol buffer registry so that it does not conflict with that
+// message. As a consequence the status details do not decode ErrorInfo
+// messages unless that package is linked in, use the DecodeErrorInfo function
+// of the goa grpc package instead.
+type ErrorInfo struct {
+	// Reason is the reason of the error in UPPER_SNAKE_CASE.
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Domain is the logical grouping to which the reason belongs.
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// Metadata contains additional structured details about the error.
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// ErrorInfoMessageName is the fully qualified name of the ErrorInfo message.
+const ErrorInfoMessageName = "google.rpc.ErrorInfo"
+
+func (m *ErrorInfo) Reset()         { *m = ErrorInfo{} }
+func (m *ErrorInfo) String() string { return proto.CompactTextString(m) }
+func (*ErrorInfo) ProtoMessage()    {}
+
+// XXX_MessageName returns the fully qualified name of the message used in the
+// type URL of the status details.
+func (*ErrorInfo) XXX_MessageName() string { return ErrorInfoMessageName }
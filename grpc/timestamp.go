@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// TimestampProto converts t to a google.protobuf.Timestamp message. The
+// generated code uses TimestampProto to initialize the protocol buffer message
+// fields that correspond to attributes of type Timestamp.
+func TimestampProto(t time.Time) *timestamp.Timestamp {
+	return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+// Timestamp converts the google.protobuf.Timestamp message ts to a time.Time
+// in UTC. It returns the zero time if ts is nil. The generated code uses
+// Timestamp to initialize the attributes of type Timestamp from the protocol
+// buffer message fields.
+func Timestamp(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC()
+}
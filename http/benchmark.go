@@ -0,0 +1,279 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type (
+	// BenchmarkCodec describes a pair of server encoder and decoder
+	// constructors benchmarked by BenchmarkCodecs. The constructors have
+	// the same signatures as the ones given to the generated servers so
+	// that the encoders and decoders used in production can be compared.
+	BenchmarkCodec struct {
+		// Name identifies the codec in the benchmark names.
+		Name string
+		// ContentType is the mime type set in the request Content-Type
+		// header and in the encoder context under the AcceptTypeKey
+		// key.
+		ContentType string
+		// Encoder creates the response body encoder.
+		Encoder func(context.Context, http.ResponseWriter) Encoder
+		// Decoder creates the request body decoder.
+		Decoder func(*http.Request) Decoder
+	}
+
+	// BenchmarkBody is a body value encoded and decoded by
+	// BenchmarkCodecs.
+	BenchmarkBody struct {
+		// Name identifies the body in the benchmark names.
+		Name string
+		// Value is a pointer to the body, typically a generated body
+		// type initialized with FillExample.
+		Value interface{}
+	}
+
+	// benchmarkWriter is a http.ResponseWriter that discards the data
+	// written to it.
+	benchmarkWriter struct {
+		header http.Header
+	}
+
+	// bufferWriter is a http.ResponseWriter that writes to a buffer.
+	bufferWriter struct {
+		benchmarkWriter
+		buf *bytes.Buffer
+	}
+)
+
+// DefaultBenchmarkCodecs returns the codecs built with ResponseEncoder and
+// RequestDecoder for the JSON, gob, CBOR and MessagePack mime types.
+func DefaultBenchmarkCodecs() []*BenchmarkCodec {
+	cts := []string{"application/json", "application/gob", "application/cbor", "application/msgpack"}
+	codecs := make([]*BenchmarkCodec, len(cts))
+	for i, ct := range cts {
+		codecs[i] = &BenchmarkCodec{
+			Name:        ct[len("application/"):],
+			ContentType: ct,
+			Encoder:     ResponseEncoder,
+			Decoder:     RequestDecoder,
+		}
+	}
+	return codecs
+}
+
+// BenchmarkCodecs runs a sub-benchmark encoding and a sub-benchmark decoding
+// each body with each codec. The sub-benchmarks are named
+// "<body>/<codec>/encode" and "<body>/<codec>/decode" and report the
+// allocations and the encoded body size. Decoding uses a fresh value of the
+// body type on each iteration.
+//
+// Example:
+//
+//    func BenchmarkBodies(b *testing.B) {
+//        var body server.CreateRequestBody
+//        goahttp.FillExample(&body)
+//        codecs := append(goahttp.DefaultBenchmarkCodecs(), &goahttp.BenchmarkCodec{
+//            Name:        "jsoniter",
+//            ContentType: "application/json",
+//            Encoder:     jsoniterEncoder,
+//            Decoder:     jsoniterDecoder,
+//        })
+//        goahttp.BenchmarkCodecs(b, codecs, goahttp.BenchmarkBody{"create", &body})
+//    }
+//
+func BenchmarkCodecs(b *testing.B, codecs []*BenchmarkCodec, bodies ...BenchmarkBody) {
+	for _, body := range bodies {
+		for _, c := range codecs {
+			data, err := encodeBenchmarkBody(c, body.Value)
+			if err != nil {
+				b.Fatalf("%s: failed to encode %s: %s", c.Name, body.Name, err)
+			}
+			b.Run(body.Name+"/"+c.Name+"/encode", func(b *testing.B) {
+				benchmarkEncode(b, c, body.Value, len(data))
+			})
+			b.Run(body.Name+"/"+c.Name+"/decode", func(b *testing.B) {
+				benchmarkDecode(b, c, body.Value, data)
+			})
+		}
+	}
+}
+
+// FillExample initializes the value pointed to by v with representative
+// example data: strings and byte slices are set to short text, numbers to
+// non-zero values, slices and maps get 3 elements and pointers are allocated.
+// Recursive types are filled up to 3 levels deep. Unexported struct fields and
+// interface values are left untouched.
+func FillExample(v interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		panic("goa: FillExample requires a non-nil pointer")
+	}
+	fillExample(rv.Elem(), make(map[reflect.Type]int))
+}
+
+// Header returns the response headers.
+func (w *benchmarkWriter) Header() http.Header { return w.header }
+
+// WriteHeader does nothing.
+func (w *benchmarkWriter) WriteHeader(int) {}
+
+// Write discards p.
+func (w *benchmarkWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+// Write writes p to the buffer.
+func (w *bufferWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+// benchmarkEncode measures the encoding of v with the given codec.
+func benchmarkEncode(b *testing.B, c *BenchmarkCodec, v interface{}, size int) {
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, c.ContentType)
+	w := &benchmarkWriter{header: make(http.Header)}
+	b.SetBytes(int64(size))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Encoder(ctx, w).Encode(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkDecode measures the decoding of data into new values of the type
+// of v with the given codec.
+func benchmarkDecode(b *testing.B, c *BenchmarkCodec, v interface{}, data []byte) {
+	t := reflect.TypeOf(v).Elem()
+	r, err := http.NewRequest("POST", "/", nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r.Header.Set("Content-Type", c.ContentType)
+	body := bytes.NewReader(data)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		body.Reset(data)
+		r.Body = ioutil.NopCloser(body)
+		if err := c.Decoder(r).Decode(reflect.New(t).Interface()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// encodeBenchmarkBody encodes v with the given codec and returns the encoded
+// bytes. It also checks that the result can be decoded back.
+func encodeBenchmarkBody(c *BenchmarkCodec, v interface{}) ([]byte, error) {
+	if reflect.TypeOf(v).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("body must be a pointer, got %T", v)
+	}
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, c.ContentType)
+	var buf bytes.Buffer
+	w := &bufferWriter{benchmarkWriter{header: make(http.Header)}, &buf}
+	if err := c.Encoder(ctx, w).Encode(v); err != nil {
+		return nil, err
+	}
+	data := buf.Bytes()
+	r, err := http.NewRequest("POST", "/", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", c.ContentType)
+	if err := c.Decoder(r).Decode(reflect.New(reflect.TypeOf(v).Elem()).Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode encoded body: %s", err)
+	}
+	return data, nil
+}
+
+// fillExample initializes v with example data. depths counts the number of
+// times each type appears in the current path to stop recursion.
+func fillExample(v reflect.Value, depths map[reflect.Type]int) {
+	t := v.Type()
+	if exhausted(t, depths) {
+		return
+	}
+	depths[t]++
+	defer func() { depths[t]-- }()
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(42)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(42)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(3.14)
+	case reflect.String:
+		v.SetString("Lorem ipsum dolor sit amet")
+	case reflect.Ptr:
+		if exhausted(t.Elem(), depths) {
+			return
+		}
+		p := reflect.New(t.Elem())
+		fillExample(p.Elem(), depths)
+		v.Set(p)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte("Lorem ipsum dolor sit amet"))
+			return
+		}
+		if exhausted(t.Elem(), depths) {
+			return
+		}
+		s := reflect.MakeSlice(t, 3, 3)
+		for i := 0; i < 3; i++ {
+			fillExample(s.Index(i), depths)
+		}
+		v.Set(s)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fillExample(v.Index(i), depths)
+		}
+	case reflect.Map:
+		if exhausted(t.Elem(), depths) {
+			return
+		}
+		m := reflect.MakeMap(t)
+		for i := 0; i < 3; i++ {
+			k := reflect.New(t.Key()).Elem()
+			fillExample(k, depths)
+			switch k.Kind() {
+			case reflect.String:
+				k.SetString(fmt.Sprintf("key%d", i))
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				k.SetInt(int64(i))
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				k.SetUint(uint64(i))
+			}
+			e := reflect.New(t.Elem()).Elem()
+			fillExample(e, depths)
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			fillExample(v.Field(i), depths)
+		}
+	}
+}
+
+// exhausted returns true if values of type t may not be filled anymore because
+// the maximum depth is reached. Pointers, slices and maps are left nil in this
+// case rather than filled with nil elements.
+func exhausted(t reflect.Type, depths map[reflect.Type]int) bool {
+	if depths[t] >= 3 {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return exhausted(t.Elem(), depths)
+	}
+	return false
+}
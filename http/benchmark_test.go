@@ -0,0 +1,73 @@
+package http
+
+import (
+	"reflect"
+	"testing"
+)
+
+type (
+	benchmarkBody struct {
+		Name    *string                   `json:"name,omitempty"`
+		Count   int                       `json:"count"`
+		Ratio   *float64                  `json:"ratio,omitempty"`
+		Tags    []string                  `json:"tags,omitempty"`
+		Attrs   map[string]*benchmarkBody `json:"attrs,omitempty"`
+		Child   *benchmarkBody            `json:"child,omitempty"`
+		private string
+	}
+)
+
+func TestFillExample(t *testing.T) {
+	var body benchmarkBody
+	FillExample(&body)
+	if body.Name == nil || *body.Name == "" {
+		t.Errorf("name not set")
+	}
+	if body.Count == 0 {
+		t.Errorf("count not set")
+	}
+	if body.Ratio == nil {
+		t.Errorf("ratio not set")
+	}
+	if len(body.Tags) != 3 {
+		t.Errorf("got %d tags, expected 3", len(body.Tags))
+	}
+	if len(body.Attrs) != 3 {
+		t.Errorf("got %d attrs, expected 3", len(body.Attrs))
+	}
+	if body.private != "" {
+		t.Errorf("unexported field set")
+	}
+	depth := 0
+	for c := &body; c != nil; c = c.Child {
+		depth++
+	}
+	if depth != 3 {
+		t.Errorf("got depth %d, expected 3", depth)
+	}
+}
+
+func TestEncodeBenchmarkBody(t *testing.T) {
+	var body benchmarkBody
+	FillExample(&body)
+	for _, c := range DefaultBenchmarkCodecs() {
+		t.Run(c.Name, func(t *testing.T) {
+			data, err := encodeBenchmarkBody(c, &body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data) == 0 {
+				t.Errorf("got empty encoded body")
+			}
+		})
+	}
+	if _, err := encodeBenchmarkBody(DefaultBenchmarkCodecs()[0], body); err == nil {
+		t.Errorf("expected an error for a non pointer body")
+	}
+}
+
+func BenchmarkDefaultCodecs(b *testing.B) {
+	var body benchmarkBody
+	FillExample(&body)
+	BenchmarkCodecs(b, DefaultBenchmarkCodecs(), BenchmarkBody{Name: reflect.TypeOf(body).Name(), Value: &body})
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"io"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// maxBytesBody is a request body that fails once more than a maximum number of
+// bytes have been read.
+type maxBytesBody struct {
+	rc  io.ReadCloser
+	n   int64 // number of bytes left
+	max int64
+	err error
+}
+
+// LimitRequestBody replaces the body of r with a reader that fails with the
+// error returned by goa.RequestBodyTooLargeError once more than max bytes have
+// been read. Reading the body of a request whose Content-Length header is
+// greater than max fails right away. The generated request decoders call
+// LimitRequestBody for the endpoints that define a maximum request body size
+// with the MaxRequestBody DSL. LimitRequestBody does nothing if the body is
+// already limited to max bytes or less.
+func LimitRequestBody(r *http.Request, max int64) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return
+	}
+	if b, ok := r.Body.(*maxBytesBody); ok && b.max <= max {
+		return
+	}
+	b := &maxBytesBody{rc: r.Body, n: max, max: max}
+	if r.ContentLength > max {
+		b.err = goa.RequestBodyTooLargeError(max)
+	}
+	r.Body = b
+}
+
+// Read reads from the underlying body and fails if more than the maximum
+// number of bytes have been read.
+func (b *maxBytesBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// Read one more byte than allowed to detect bodies that are too large.
+	if int64(len(p)) > b.n+1 {
+		p = p[:b.n+1]
+	}
+	n, err := b.rc.Read(p)
+	if int64(n) <= b.n {
+		b.n -= int64(n)
+		b.err = err
+		return n, err
+	}
+	n = int(b.n)
+	b.n = 0
+	b.err = goa.RequestBodyTooLargeError(b.max)
+	return n, b.err
+}
+
+// Close closes the underlying body.
+func (b *maxBytesBody) Close() error {
+	return b.rc.Close()
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// unsizedReader hides the length of the underlying reader so that requests
+// created with it do not set ContentLength.
+type unsizedReader struct {
+	r *strings.Reader
+}
+
+func (u *unsizedReader) Read(p []byte) (int, error) { return u.r.Read(p) }
+
+func TestLimitRequestBody(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Body     string
+		Sized    bool
+		Max      int64
+		Expected string
+		TooLarge bool
+	}{
+		{"smaller", "abc", false, 4, "abc", false},
+		{"exact", "abcd", false, 4, "abcd", false},
+		{"larger", "abcde", false, 4, "abcd", true},
+		{"content-length", "abcde", true, 4, "", true},
+		{"content-length-smaller", "abc", true, 4, "abc", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var req *http.Request
+			if c.Sized {
+				req = httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			} else {
+				req = httptest.NewRequest("POST", "/", &unsizedReader{strings.NewReader(c.Body)})
+			}
+			LimitRequestBody(req, c.Max)
+			LimitRequestBody(req, c.Max+1)
+			b, err := ioutil.ReadAll(req.Body)
+			if string(b) != c.Expected {
+				t.Errorf("got body %q, expected %q", string(b), c.Expected)
+			}
+			if !c.TooLarge {
+				if err != nil {
+					t.Errorf("got error %q, expected none", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("got no error, expected request_body_too_large")
+			}
+			if code := NewErrorResponse(err).StatusCode(); code != http.StatusRequestEntityTooLarge {
+				t.Errorf("got status code %d, expected %d", code, http.StatusRequestEntityTooLarge)
+			}
+		})
+	}
+}
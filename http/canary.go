@@ -0,0 +1,55 @@
+package http
+
+import (
+	"math/rand"
+	"net/http"
+)
+
+// CanaryRouter selects the requests made to an endpoint that are served by the
+// canary implementation of the endpoint. The generated servers use a
+// CanaryRouter per endpoint that defines the Canary DSL when they are given the
+// canary endpoints on construction. The routers are initialized from the
+// design and may be modified before the server starts.
+type CanaryRouter struct {
+	// Percent is the percentage of the requests served by the canary,
+	// between 0 and 100.
+	Percent float64
+	// Header is the name of the request header that routes the requests
+	// that set it to the canary regardless of Percent, ignored if empty.
+	Header string
+	// Value is the value the header must have, any non-empty value
+	// matches if empty.
+	Value string
+	// Route overrides the default routing if not nil, it returns true if
+	// the request must be served by the canary.
+	Route func(*http.Request) bool
+}
+
+// Canary returns true if the request must be served by the canary
+// implementation.
+func (cr *CanaryRouter) Canary(r *http.Request) bool {
+	if cr.Route != nil {
+		return cr.Route(r)
+	}
+	if cr.Header != "" {
+		if v := r.Header.Get(cr.Header); v != "" && (cr.Value == "" || v == cr.Value) {
+			return true
+		}
+	}
+	return cr.Percent > 0 && rand.Float64()*100 < cr.Percent
+}
+
+// Handler returns a HTTP handler that serves the requests selected by the
+// router with canary and the other requests with primary.
+func (cr *CanaryRouter) Handler(primary, canary http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cr.Header != "" {
+			w.Header().Add("Vary", cr.Header)
+		}
+		if cr.Canary(r) {
+			canary.ServeHTTP(w, r)
+			return
+		}
+		primary.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCanaryRouter(t *testing.T) {
+	handler := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name))
+		})
+	}
+	cases := []struct {
+		name         string
+		router       *CanaryRouter
+		header       string
+		expectedBody string
+	}{
+		{"none", &CanaryRouter{}, "", "primary"},
+		{"all", &CanaryRouter{Percent: 100}, "", "canary"},
+		{"header", &CanaryRouter{Header: "X-Canary"}, "1", "canary"},
+		{"header-missing", &CanaryRouter{Header: "X-Canary"}, "", "primary"},
+		{"header-value", &CanaryRouter{Header: "X-Canary", Value: "true"}, "true", "canary"},
+		{"header-other-value", &CanaryRouter{Header: "X-Canary", Value: "true"}, "false", "primary"},
+		{"route", &CanaryRouter{Percent: 100, Route: func(*http.Request) bool { return false }}, "", "primary"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.header != "" {
+				req.Header.Set("X-Canary", c.header)
+			}
+			rw := httptest.NewRecorder()
+			c.router.Handler(handler("primary"), handler("canary")).ServeHTTP(rw, req)
+			if rw.Body.String() != c.expectedBody {
+				t.Errorf("got body %q, expected %q", rw.Body.String(), c.expectedBody)
+			}
+			if vary := rw.Header().Get("Vary"); vary != c.router.Header {
+				t.Errorf("got Vary header %q, expected %q", vary, c.router.Header)
+			}
+		})
+	}
+}
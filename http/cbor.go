@@ -0,0 +1,703 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// CBOR major types, see RFC 8949 section 3.1.
+const (
+	cborUint byte = iota
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const (
+	// cborIndefinite is the additional information value used by
+	// indefinite length byte strings, text strings, arrays and maps.
+	cborIndefinite = 31
+	// cborMaxDepth is the maximum nesting of the encoded and decoded values.
+	cborMaxDepth = 1000
+	// cborMaxPrealloc is the maximum number of array elements allocated
+	// upfront when decoding so that a length header alone cannot cause large
+	// allocations.
+	cborMaxPrealloc = 1024
+)
+
+type (
+	// cborEncoder encodes values using the CBOR format.
+	cborEncoder struct {
+		w io.Writer
+	}
+
+	// cborDecoder decodes values using the CBOR format.
+	cborDecoder struct {
+		r byteReader
+	}
+)
+
+// NewCBOREncoder returns an encoder that writes the CBOR (RFC 8949)
+// representation of values to w. Struct fields are encoded as map entries
+// keyed by the names given in their "json" tags so that the encoding of the
+// generated types matches their JSON encoding. Map entries are written in
+// the deterministic order defined by the RFC.
+func NewCBOREncoder(w io.Writer) Encoder {
+	return &cborEncoder{w}
+}
+
+// NewCBORDecoder returns a decoder that reads CBOR (RFC 8949) values from r.
+// Map entries are decoded into struct fields using the names given in their
+// "json" tags. Tags are ignored and the tagged values decoded as if untagged.
+// Arbitrary values are decoded as int64, uint64, float64, bool, string,
+// []byte, []interface{}, map[string]interface{} or map[interface{}]interface{}
+// when some keys are not strings.
+func NewCBORDecoder(r io.Reader) Decoder {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &cborDecoder{br}
+}
+
+// Encode writes the CBOR encoding of v.
+func (e *cborEncoder) Encode(v interface{}) error {
+	var buf bytes.Buffer
+	if err := cborEncode(&buf, reflect.ValueOf(v), 0); err != nil {
+		return err
+	}
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+// Decode reads the next CBOR value and stores it in the value pointed to by
+// v.
+func (d *cborDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("cbor: cannot decode into non-pointer %T", v)
+	}
+	major, info, arg, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	return d.decode(rv.Elem(), major, info, arg, 0)
+}
+
+func cborEncode(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if depth > cborMaxDepth {
+		return errors.New("cbor: maximum nesting depth exceeded")
+	}
+	if !v.IsValid() {
+		buf.WriteByte(0xf6)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteByte(0xf5)
+		} else {
+			buf.WriteByte(0xf4)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := v.Int(); i < 0 {
+			cborWriteHead(buf, cborNegInt, uint64(-(i + 1)))
+		} else {
+			cborWriteHead(buf, cborUint, uint64(i))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		cborWriteHead(buf, cborUint, v.Uint())
+	case reflect.Float32:
+		var b [5]byte
+		b[0] = 0xfa
+		binary.BigEndian.PutUint32(b[1:], math.Float32bits(float32(v.Float())))
+		buf.Write(b[:])
+	case reflect.Float64:
+		var b [9]byte
+		b[0] = 0xfb
+		binary.BigEndian.PutUint64(b[1:], math.Float64bits(v.Float()))
+		buf.Write(b[:])
+	case reflect.String:
+		s := v.String()
+		cborWriteHead(buf, cborText, uint64(len(s)))
+		buf.WriteString(s)
+	case reflect.Slice:
+		if v.IsNil() {
+			buf.WriteByte(0xf6)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := v.Bytes()
+			cborWriteHead(buf, cborBytes, uint64(len(b)))
+			buf.Write(b)
+			return nil
+		}
+		return cborEncodeArray(buf, v, depth)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			cborWriteHead(buf, cborBytes, uint64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				buf.WriteByte(byte(v.Index(i).Uint()))
+			}
+			return nil
+		}
+		return cborEncodeArray(buf, v, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteByte(0xf6)
+			return nil
+		}
+		return cborEncodeMap(buf, v, depth)
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteByte(0xf6)
+			return nil
+		}
+		return cborEncode(buf, v.Elem(), depth+1)
+	case reflect.Struct:
+		return cborEncodeStruct(buf, v, depth)
+	default:
+		return fmt.Errorf("cbor: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func cborEncodeArray(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	cborWriteHead(buf, cborArray, uint64(v.Len()))
+	for i := 0; i < v.Len(); i++ {
+		if err := cborEncode(buf, v.Index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cborEncodeMap writes the map entries sorted by the bytewise lexicographic
+// order of the encoded keys as required by the RFC 8949 deterministic
+// encoding.
+func cborEncodeMap(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	type entry struct {
+		key []byte
+		val reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		var kb bytes.Buffer
+		if err := cborEncode(&kb, iter.Key(), depth+1); err != nil {
+			return err
+		}
+		entries = append(entries, entry{kb.Bytes(), iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+	cborWriteHead(buf, cborMap, uint64(len(entries)))
+	for _, e := range entries {
+		buf.Write(e.key)
+		if err := cborEncode(buf, e.val, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cborEncodeStruct(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	fields := structFields(v.Type())
+	vals := make([]reflect.Value, 0, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		fv := v.FieldByIndex(f.index)
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		vals = append(vals, fv)
+		names = append(names, f.name)
+	}
+	cborWriteHead(buf, cborMap, uint64(len(vals)))
+	for i, fv := range vals {
+		cborWriteHead(buf, cborText, uint64(len(names[i])))
+		buf.WriteString(names[i])
+		if err := cborEncode(buf, fv, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cborWriteHead writes the initial byte and argument of a data item using the
+// shortest possible form.
+func cborWriteHead(buf *bytes.Buffer, major byte, arg uint64) {
+	m := major << 5
+	switch {
+	case arg < 24:
+		buf.WriteByte(m | byte(arg))
+	case arg <= math.MaxUint8:
+		buf.Write([]byte{m | 24, byte(arg)})
+	case arg <= math.MaxUint16:
+		var b [3]byte
+		b[0] = m | 25
+		binary.BigEndian.PutUint16(b[1:], uint16(arg))
+		buf.Write(b[:])
+	case arg <= math.MaxUint32:
+		var b [5]byte
+		b[0] = m | 26
+		binary.BigEndian.PutUint32(b[1:], uint32(arg))
+		buf.Write(b[:])
+	default:
+		var b [9]byte
+		b[0] = m | 27
+		binary.BigEndian.PutUint64(b[1:], arg)
+		buf.Write(b[:])
+	}
+}
+
+// readHead reads the initial byte and argument of the next data item.
+func (d *cborDecoder) readHead() (major, info byte, arg uint64, err error) {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info = b>>5, b&0x1f
+	var n int
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		n = 1
+	case info == 25:
+		n = 2
+	case info == 26:
+		n = 4
+	case info == 27:
+		n = 8
+	case info == cborIndefinite:
+		if major == cborUint || major == cborNegInt || major == cborTag {
+			return 0, 0, 0, fmt.Errorf("cbor: invalid indefinite length for major type %d", major)
+		}
+		return major, info, 0, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+	var p [8]byte
+	if _, err := io.ReadFull(d.r, p[8-n:]); err != nil {
+		return 0, 0, 0, unexpectedEOF(err)
+	}
+	return major, info, binary.BigEndian.Uint64(p[:]), nil
+}
+
+// decode decodes the data item whose head has already been read into v.
+func (d *cborDecoder) decode(v reflect.Value, major, info byte, arg uint64, depth int) error {
+	if depth > cborMaxDepth {
+		return errors.New("cbor: maximum nesting depth exceeded")
+	}
+	if major == cborTag {
+		major, info, arg, err := d.readHead()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		return d.decode(v, major, info, arg, depth+1)
+	}
+	if major == cborSimple && (info == 22 || info == 23) {
+		// null or undefined
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decode(v.Elem(), major, info, arg, depth+1)
+	case reflect.Interface:
+		if v.NumMethod() > 0 {
+			return fmt.Errorf("cbor: cannot decode into non-empty interface %s", v.Type())
+		}
+		val, err := d.decodeAny(major, info, arg, depth)
+		if err != nil {
+			return err
+		}
+		if val == nil {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(val))
+		}
+		return nil
+	}
+	switch major {
+	case cborUint, cborNegInt:
+		return cborSetInt(v, major, arg)
+	case cborBytes, cborText:
+		b, err := d.readString(major, info, arg)
+		if err != nil {
+			return err
+		}
+		switch {
+		case v.Kind() == reflect.String:
+			v.SetString(string(b))
+		case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+			v.SetBytes(b)
+		case v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8:
+			reflect.Copy(v, reflect.ValueOf(b))
+		default:
+			return cborTypeError("string", v)
+		}
+		return nil
+	case cborArray:
+		return d.decodeArray(v, info, arg, depth)
+	case cborMap:
+		switch v.Kind() {
+		case reflect.Map:
+			return d.decodeMap(v, info, arg, depth)
+		case reflect.Struct:
+			return d.decodeStruct(v, info, arg, depth)
+		}
+		if _, err := d.decodeAny(cborMap, info, arg, depth); err != nil {
+			return err
+		}
+		return cborTypeError("map", v)
+	default:
+		switch info {
+		case 20, 21:
+			if v.Kind() != reflect.Bool {
+				return cborTypeError("boolean", v)
+			}
+			v.SetBool(info == 21)
+			return nil
+		case 25, 26, 27:
+			switch v.Kind() {
+			case reflect.Float32, reflect.Float64:
+				f := cborFloat(info, arg)
+				if v.OverflowFloat(f) {
+					return fmt.Errorf("cbor: value %v overflows %s", f, v.Type())
+				}
+				v.SetFloat(f)
+				return nil
+			}
+			return cborTypeError("float", v)
+		case cborIndefinite:
+			return errors.New("cbor: unexpected break")
+		}
+		return fmt.Errorf("cbor: unsupported simple value %d", arg)
+	}
+}
+
+func (d *cborDecoder) decodeArray(v reflect.Value, info byte, arg uint64, depth int) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		n := cborMaxPrealloc
+		if info != cborIndefinite && arg < uint64(n) {
+			n = int(arg)
+		}
+		s := reflect.MakeSlice(v.Type(), 0, n)
+		err := d.each(info, arg, func(major, info byte, arg uint64) error {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(elem, major, info, arg, depth+1); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		i := 0
+		err := d.each(info, arg, func(major, info byte, arg uint64) error {
+			if i >= v.Len() {
+				_, err := d.decodeAny(major, info, arg, depth+1)
+				return err
+			}
+			i++
+			return d.decode(v.Index(i-1), major, info, arg, depth+1)
+		})
+		if err != nil {
+			return err
+		}
+		for ; i < v.Len(); i++ {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		}
+		return nil
+	}
+	if _, err := d.decodeAny(cborArray, info, arg, depth); err != nil {
+		return err
+	}
+	return cborTypeError("array", v)
+}
+
+func (d *cborDecoder) decodeMap(v reflect.Value, info byte, arg uint64, depth int) error {
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	t := v.Type()
+	key := true
+	var k reflect.Value
+	n, err := cborMapItems(info, arg)
+	if err != nil {
+		return err
+	}
+	return d.each(info, n, func(major, info byte, arg uint64) error {
+		defer func() { key = !key }()
+		if key {
+			k = reflect.New(t.Key()).Elem()
+			if err := d.decode(k, major, info, arg, depth+1); err != nil {
+				return err
+			}
+			if k.Kind() == reflect.Interface && !k.IsNil() && !k.Elem().Type().Comparable() {
+				return fmt.Errorf("cbor: invalid map key of type %s", k.Elem().Type())
+			}
+			return nil
+		}
+		val := reflect.New(t.Elem()).Elem()
+		if err := d.decode(val, major, info, arg, depth+1); err != nil {
+			return err
+		}
+		v.SetMapIndex(k, val)
+		return nil
+	})
+}
+
+func (d *cborDecoder) decodeStruct(v reflect.Value, info byte, arg uint64, depth int) error {
+	fields := structFields(v.Type())
+	key := true
+	var field *structField
+	n, err := cborMapItems(info, arg)
+	if err != nil {
+		return err
+	}
+	return d.each(info, n, func(major, info byte, arg uint64) error {
+		defer func() { key = !key }()
+		if key {
+			var name string
+			if err := d.decode(reflect.ValueOf(&name).Elem(), major, info, arg, depth+1); err != nil {
+				return err
+			}
+			field = findStructField(fields, name)
+			return nil
+		}
+		if field == nil {
+			_, err := d.decodeAny(major, info, arg, depth+1)
+			return err
+		}
+		return d.decode(v.FieldByIndex(field.index), major, info, arg, depth+1)
+	})
+}
+
+// decodeAny decodes the data item whose head has already been read into a
+// value whose type is inferred from the CBOR data type.
+func (d *cborDecoder) decodeAny(major, info byte, arg uint64, depth int) (interface{}, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("cbor: maximum nesting depth exceeded")
+	}
+	switch major {
+	case cborUint:
+		if arg > math.MaxInt64 {
+			return arg, nil
+		}
+		return int64(arg), nil
+	case cborNegInt:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: value -%d-1 overflows int64", arg)
+		}
+		return -int64(arg) - 1, nil
+	case cborBytes:
+		return d.readString(major, info, arg)
+	case cborText:
+		b, err := d.readString(major, info, arg)
+		return string(b), err
+	case cborArray:
+		var s []interface{}
+		return s, d.decodeArray(reflect.ValueOf(&s).Elem(), info, arg, depth)
+	case cborMap:
+		var m map[interface{}]interface{}
+		if err := d.decodeMap(reflect.ValueOf(&m).Elem(), info, arg, depth); err != nil {
+			return nil, err
+		}
+		sm := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			s, ok := k.(string)
+			if !ok {
+				return m, nil
+			}
+			sm[s] = v
+		}
+		return sm, nil
+	case cborTag:
+		major, info, arg, err := d.readHead()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return d.decodeAny(major, info, arg, depth+1)
+	default:
+		switch info {
+		case 20, 21:
+			return info == 21, nil
+		case 22, 23:
+			return nil, nil
+		case 25, 26, 27:
+			return cborFloat(info, arg), nil
+		case cborIndefinite:
+			return nil, errors.New("cbor: unexpected break")
+		}
+		return nil, fmt.Errorf("cbor: unsupported simple value %d", arg)
+	}
+}
+
+// each calls fn with the head of each of the n data items that follow or with
+// the head of each data item up to the next break if info indicates an
+// indefinite length.
+func (d *cborDecoder) each(info byte, n uint64, fn func(major, info byte, arg uint64) error) error {
+	if info != cborIndefinite {
+		for i := uint64(0); i < n; i++ {
+			major, info, arg, err := d.readHead()
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			if err := fn(major, info, arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for {
+		major, info, arg, err := d.readHead()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if major == cborSimple && info == cborIndefinite {
+			return nil
+		}
+		if err := fn(major, info, arg); err != nil {
+			return err
+		}
+	}
+}
+
+// readString reads the content of the byte or text string whose head has
+// already been read. Indefinite length strings are concatenated.
+func (d *cborDecoder) readString(major, info byte, arg uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	if info != cborIndefinite {
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor: invalid string length")
+		}
+		if _, err := io.CopyN(&buf, d.r, int64(arg)); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return buf.Bytes(), nil
+	}
+	for {
+		m, i, a, err := d.readHead()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if m == cborSimple && i == cborIndefinite {
+			return buf.Bytes(), nil
+		}
+		if m != major || i == cborIndefinite {
+			return nil, errors.New("cbor: invalid indefinite length string chunk")
+		}
+		chunk, err := d.readString(m, i, a)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(chunk)
+	}
+}
+
+// cborMapItems returns the number of data items, keys and values, of a map
+// of definite length arg.
+func cborMapItems(info byte, arg uint64) (uint64, error) {
+	if info != cborIndefinite && arg > math.MaxUint64/2 {
+		return 0, errors.New("cbor: invalid map length")
+	}
+	return arg * 2, nil
+}
+
+func cborSetInt(v reflect.Value, major byte, arg uint64) error {
+	neg := major == cborNegInt
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if arg > math.MaxInt64 {
+			return cborOverflowError(neg, arg, v)
+		}
+		i := int64(arg)
+		if neg {
+			i = -i - 1
+		}
+		if v.OverflowInt(i) {
+			return cborOverflowError(neg, arg, v)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if neg || v.OverflowUint(arg) {
+			return cborOverflowError(neg, arg, v)
+		}
+		v.SetUint(arg)
+	case reflect.Float32, reflect.Float64:
+		f := float64(arg)
+		if neg {
+			f = -f - 1
+		}
+		v.SetFloat(f)
+	default:
+		return cborTypeError("integer", v)
+	}
+	return nil
+}
+
+// cborFloat returns the value of the half, single or double precision float
+// encoded in arg.
+func cborFloat(info byte, arg uint64) float64 {
+	switch info {
+	case 25:
+		h := uint16(arg)
+		sign := 1.0
+		if h&0x8000 != 0 {
+			sign = -1
+		}
+		exp := int(h>>10) & 0x1f
+		mant := float64(h & 0x3ff)
+		switch exp {
+		case 0:
+			return sign * math.Ldexp(mant, -24)
+		case 0x1f:
+			if mant == 0 {
+				return math.Inf(int(sign))
+			}
+			return math.NaN()
+		}
+		return sign * math.Ldexp(mant+1024, exp-25)
+	case 26:
+		return float64(math.Float32frombits(uint32(arg)))
+	default:
+		return math.Float64frombits(arg)
+	}
+}
+
+func cborTypeError(typ string, v reflect.Value) error {
+	return fmt.Errorf("cbor: cannot decode %s into value of type %s", typ, v.Type())
+}
+
+func cborOverflowError(neg bool, arg uint64, v reflect.Value) error {
+	if neg {
+		return fmt.Errorf("cbor: value -%d-1 overflows %s", arg, v.Type())
+	}
+	return fmt.Errorf("cbor: value %d overflows %s", arg, v.Type())
+}
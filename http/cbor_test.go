@@ -0,0 +1,177 @@
+package http
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCBOREncoder(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected string
+	}{
+		// Values from RFC 8949 appendix A.
+		{"zero", 0, "00"},
+		{"small-int", 23, "17"},
+		{"one-byte-int", 24, "1818"},
+		{"two-bytes-int", 1000, "1903e8"},
+		{"four-bytes-int", 1000000, "1a000f4240"},
+		{"eight-bytes-uint", uint64(18446744073709551615), "1bffffffffffffffff"},
+		{"negative-int", -1000, "3903e7"},
+		{"float64", 1.1, "fb3ff199999999999a"},
+		{"float32", float32(100000), "fa47c35000"},
+		{"false", false, "f4"},
+		{"true", true, "f5"},
+		{"nil", nil, "f6"},
+		{"nil-pointer", (*string)(nil), "f6"},
+		{"bytes", []byte{1, 2, 3, 4}, "4401020304"},
+		{"string", "IETF", "6449455446"},
+		{"unicode-string", "ü", "62c3bc"},
+		{"array", []int{1, 2, 3}, "83010203"},
+		{"nested-array", []interface{}{1, []int{2, 3}, []int{4, 5}}, "8301820203820405"},
+		{"map", map[string]interface{}{"b": []int{2, 3}, "a": 1}, "a26161016162820203"},
+		{"map-sorted-keys", map[int]string{10: "a", -1: "b", 100: "c"}, "a30a6161186461632061 62"},
+		{"struct", struct {
+			A int    `json:"a"`
+			B string `json:"b,omitempty"`
+			C string `json:"-"`
+			d int
+		}{A: 1, C: "c", d: 2}, "a1616101"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewCBOREncoder(&buf).Encode(c.Value); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			expected := string(bytes.Replace([]byte(c.Expected), []byte(" "), nil, -1))
+			if actual := hex.EncodeToString(buf.Bytes()); actual != expected {
+				t.Errorf("got %s, expected %s", actual, expected)
+			}
+		})
+	}
+}
+
+func TestCBORDecoder(t *testing.T) {
+	type (
+		inner struct {
+			Name *string `json:"name"`
+		}
+		outer struct {
+			ID     int              `json:"id"`
+			Tags   []string         `json:"tags"`
+			Inner  *inner           `json:"inner"`
+			Attrs  map[string]int64 `json:"attrs"`
+			Ignore string           `json:"-"`
+		}
+	)
+	name := "n"
+	cases := []struct {
+		Name     string
+		CBOR     string
+		Target   interface{}
+		Expected interface{}
+	}{
+		{"uint", "1903e8", new(uint16), uint16(1000)},
+		{"negative-int", "3903e7", new(int), -1000},
+		{"int-as-float", "1903e8", new(float64), float64(1000)},
+		{"half-float", "f93e00", new(float32), float32(1.5)},
+		{"half-float-infinity", "f97c00", new(float64), math.Inf(1)},
+		{"float32", "fa47c35000", new(float64), float64(100000)},
+		{"bool", "f5", new(bool), true},
+		{"null-pointer", "f6", &[]*string{&name}[0], (*string)(nil)},
+		{"bytes", "4401020304", new([]byte), []byte{1, 2, 3, 4}},
+		{"indefinite-bytes", "5f42010243030405ff", new([]byte), []byte{1, 2, 3, 4, 5}},
+		{"indefinite-string", "7f657374726561646d696e67ff", new(string), "streaming"},
+		{"array", "83010203", new([]int), []int{1, 2, 3}},
+		{"indefinite-array", "9f018202039f0405ffff", new([]interface{}), []interface{}{int64(1), []interface{}{int64(2), int64(3)}, []interface{}{int64(4), int64(5)}}},
+		{"fixed-array", "83010203", new([2]int), [2]int{1, 2}},
+		{"map", "a26161016162820203", new(map[string]interface{}), map[string]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}},
+		{"non-string-keys", "a10102", new(interface{}), map[interface{}]interface{}{int64(1): int64(2)}},
+		{"tagged", "c11a514b67b0", new(int64), int64(1363896240)},
+		{"struct", "a562696401647461677381616165696e6e6572a1644e414d45616e656174747273a161782067756e6b6e6f776e83010203", new(outer),
+			outer{ID: 1, Tags: []string{"a"}, Inner: &inner{Name: &name}, Attrs: map[string]int64{"x": -1}}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(string(bytes.Replace([]byte(c.CBOR), []byte(" "), nil, -1)))
+			if err != nil {
+				t.Fatalf("invalid test data: %s", err)
+			}
+			if err := NewCBORDecoder(bytes.NewReader(b)).Decode(c.Target); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if actual := reflect.ValueOf(c.Target).Elem().Interface(); !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("got %#v, expected %#v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCBORDecoderErrors(t *testing.T) {
+	cases := []struct {
+		Name   string
+		CBOR   string
+		Target interface{}
+	}{
+		{"truncated", "1903", new(int)},
+		{"truncated-string", "6449", new(string)},
+		{"truncated-array", "830102", new([]int)},
+		{"overflow", "190100", new(uint8)},
+		{"negative-uint", "20", new(uint)},
+		{"type-mismatch", "6449455446", new(int)},
+		{"unexpected-break", "ff", new(interface{})},
+		{"invalid-info", "1c", new(int)},
+		{"huge-length", "5bffffffffffffffff", new([]byte)},
+		{"non-comparable-key", "a1820102f5", new(interface{})},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(c.CBOR)
+			if err != nil {
+				t.Fatalf("invalid test data: %s", err)
+			}
+			if err := NewCBORDecoder(bytes.NewReader(b)).Decode(c.Target); err == nil {
+				t.Errorf("got no error, expected one")
+			}
+		})
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	type body struct {
+		Name    string                 `json:"name"`
+		Age     *int                   `json:"age,omitempty"`
+		Rate    float64                `json:"rate"`
+		Data    []byte                 `json:"data"`
+		Nested  []*body                `json:"nested,omitempty"`
+		Any     interface{}            `json:"any"`
+		Labels  map[string]string      `json:"labels"`
+		Unknown map[string]interface{} `json:"unknown,omitempty"`
+	}
+	age := 42
+	v := &body{
+		Name:   "goa",
+		Age:    &age,
+		Rate:   -0.5,
+		Data:   []byte("data"),
+		Nested: []*body{{Name: "child", Any: "x", Labels: map[string]string{}}},
+		Any:    []interface{}{"a", true, nil},
+		Labels: map[string]string{"k": "v"},
+	}
+	var buf bytes.Buffer
+	if err := NewCBOREncoder(&buf).Encode(v); err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	var actual body
+	if err := NewCBORDecoder(&buf).Decode(&actual); err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	if !reflect.DeepEqual(&actual, v) {
+		t.Errorf("got %#v, expected %#v", actual, *v)
+	}
+}
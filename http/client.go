@@ -207,6 +207,18 @@ func ErrInvalidResponse(svc, m string, code int, body string) error {
 		Temporary: temporary, Timeout: timeout, Fault: fault}
 }
 
+// ErrNotModified is the error returned when the service responded with 304 Not
+// Modified to a conditional request.
+func ErrNotModified(svc, m string) error {
+	return &ClientError{Name: "not_modified", Message: "resource not modified", Service: svc, Method: m}
+}
+
+// ErrPreconditionFailed is the error returned when the service responded with
+// 412 Precondition Failed to a conditional request.
+func ErrPreconditionFailed(svc, m string) error {
+	return &ClientError{Name: "precondition_failed", Message: "precondition failed", Service: svc, Method: m}
+}
+
 // ErrRequestError is the error returned when the request fails to be sent.
 func ErrRequestError(svc, m string, err error) error {
 	temporary := false
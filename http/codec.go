@@ -0,0 +1,118 @@
+package http
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type (
+	// byteReader is the interface used by the binary decoders to read data.
+	byteReader interface {
+		io.Reader
+		io.ByteReader
+	}
+
+	// structField describes a struct field encoded as a map entry by the
+	// binary encoders.
+	structField struct {
+		name      string
+		index     []int
+		omitEmpty bool
+	}
+)
+
+// structFieldsCache caches the fields of the struct types encoded or decoded
+// by the binary encoders and decoders indexed by type.
+var structFieldsCache sync.Map
+
+// structFields returns the fields of the given struct type encoded by the
+// binary encoders. The fields are named after their "json" tags, fields tagged
+// with "-" and unexported fields are ignored and the fields of embedded
+// structs are promoted.
+func structFields(t reflect.Type) []structField {
+	if f, ok := structFieldsCache.Load(t); ok {
+		return f.([]structField)
+	}
+	var fields []structField
+	seen := make(map[string]bool)
+	var collect func(t reflect.Type, index []int)
+	collect = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			idx := append(append([]int{}, index...), i)
+			if sf.Anonymous && tag == "" && sf.Type.Kind() == reflect.Struct {
+				collect(sf.Type, idx)
+				continue
+			}
+			if sf.PkgPath != "" {
+				continue
+			}
+			name := sf.Name
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				name = opts[0]
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			f := structField{name: name, index: idx}
+			for _, o := range opts[1:] {
+				if o == "omitempty" {
+					f.omitEmpty = true
+				}
+			}
+			fields = append(fields, f)
+		}
+	}
+	collect(t, nil)
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// findStructField returns the field with the given name, falling back to a
+// case insensitive match like encoding/json.
+func findStructField(fields []structField, name string) *structField {
+	for i := range fields {
+		if fields[i].name == name {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, name) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -3,6 +3,7 @@ package codegen
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -18,9 +19,24 @@ func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	for i, r := range root.API.HTTP.Services {
 		fw[i+len(root.API.HTTP.Services)] = clientEncodeDecode(genpkg, r)
 	}
+	for _, r := range root.API.HTTP.Services {
+		if f := webhookFile(genpkg, r); f != nil {
+			fw = append(fw, f)
+		}
+	}
 	return fw
 }
 
+// environmentNames returns the names of the environments of the service
+// separated with commas.
+func environmentNames(data *ServiceData) string {
+	names := make([]string, len(data.Environments))
+	for i, e := range data.Environments {
+		names[i] = e.Name
+	}
+	return strings.Join(names, ", ")
+}
+
 // client returns the client HTTP transport file
 func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	data := HTTPServices.Get(svc.Name())
@@ -51,6 +67,7 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		Data:   data,
 		FuncMap: map[string]interface{}{
 			"streamingEndpointExists": streamingEndpointExists,
+			"clientCompressionExists": clientCompressionExists,
 		},
 	})
 	if streamingEndpointExists(data) {
@@ -92,6 +109,18 @@ func client(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		},
 	})
 
+	if len(data.Environments) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-environment-init",
+			Source: clientEnvironmentInitT,
+			Data:   data,
+			FuncMap: map[string]interface{}{
+				"streamingEndpointExists": streamingEndpointExists,
+				"environmentNames":        environmentNames,
+			},
+		})
+	}
+
 	if streamingEndpointExists(data) {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "client-stream-conn-configurer-struct-init",
@@ -174,6 +203,7 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			{Path: "net/url"},
 			{Path: "strconv"},
 			{Path: "strings"},
+			{Path: "time"},
 			{Path: "unicode/utf8"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
@@ -236,21 +266,26 @@ func clientEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 
 // typeConversionData produces the template data suitable for executing the
 // "header_conversion" template.
-func typeConversionData(dt expr.DataType, varName string, target string) map[string]interface{} {
+func typeConversionData(dt expr.DataType, varName, target, tsFormat string) map[string]interface{} {
 	return map[string]interface{}{
-		"Type":    dt,
-		"VarName": varName,
-		"Target":  target,
+		"Type":            dt,
+		"VarName":         varName,
+		"Target":          target,
+		"TimestampFormat": tsFormat,
 	}
 }
 
+// mapConversionData produces the template data suitable for executing the
+// "map_conversion" template. The keys and values of maps are encoded as RFC
+// 3339 strings when they hold timestamps.
 func mapConversionData(dt expr.DataType, varName, sourceVar, sourceField string, newVar bool) map[string]interface{} {
 	return map[string]interface{}{
-		"Type":        dt,
-		"VarName":     varName,
-		"SourceVar":   sourceVar,
-		"SourceField": sourceField,
-		"NewVar":      newVar,
+		"Type":            dt,
+		"VarName":         varName,
+		"SourceVar":       sourceVar,
+		"SourceField":     sourceField,
+		"NewVar":          newVar,
+		"TimestampFormat": expr.TimestampRFC3339,
 	}
 }
 
@@ -277,6 +312,13 @@ type {{ .ClientStruct }} struct {
 	// RestoreResponseBody controls whether the response bodies are reset after
 	// decoding so they can be read again.
 	RestoreResponseBody bool
+	{{- if clientCompressionExists . }}
+
+	// RequestCompressionThreshold is the minimum size in bytes of the request
+	// bodies compressed by the endpoints whose server accepts compressed
+	// requests, zero disables the compression.
+	RequestCompressionThreshold int64
+	{{- end }}
 
 	scheme     string
 	host       string
@@ -307,6 +349,9 @@ func New{{ .ClientStruct }}(
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
 	}
+{{- end }}
+{{- if and .EncoderOptions .EncoderOptions.JSONPrefix }}
+	dec = goahttp.StripJSONPrefix(dec, {{ printf "%q" .EncoderOptions.JSONPrefix }})
 {{- end }}
 	return &{{ .ClientStruct }}{
 		{{- range .Endpoints }}
@@ -325,6 +370,32 @@ func New{{ .ClientStruct }}(
 }
 `
 
+// input: ServiceData
+const clientEnvironmentInitT = `{{ printf "New%sForEnvironment instantiates HTTP clients for all the %s service servers that send the requests to the server of the given environment as defined in the design. The valid environments are %s." .ClientStruct .Service.Name (environmentNames .) | comment }}
+func New{{ .ClientStruct }}ForEnvironment(
+	env string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+	{{- if streamingEndpointExists . }}
+	dialer goahttp.Dialer,
+	cfn *ConnConfigurer,
+	{{- end }}
+) (*{{ .ClientStruct }}, error) {
+	var scheme, host string
+	switch env {
+	{{- range .Environments }}
+	case {{ printf "%q" .Name }}:
+		scheme, host = {{ printf "%q" .Scheme }}, {{ printf "%q" .Host }}
+	{{- end }}
+	default:
+		return nil, fmt.Errorf("unknown environment %q, valid environments are %s", env, {{ printf "%q" (environmentNames .) }})
+	}
+	return New{{ .ClientStruct }}(scheme, host, doer, enc, dec, restoreBody{{ if streamingEndpointExists . }}, dialer, cfn{{ end }}), nil
+}
+`
+
 // input: EndpointData
 const endpointInitT = `{{ printf "%s returns an endpoint that makes HTTP requests to the %s service %s server." .EndpointInit .ServiceName .Method.Name | comment }}
 func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.VarName }} {{ .MultipartRequestEncoder.FuncName }}{{ end }}) goa.Endpoint {
@@ -339,6 +410,10 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		decodeResponse = {{ .ResponseDecoder }}(c.decoder, c.RestoreResponseBody)
 	)
 	return func(ctx context.Context, v interface{}) (interface{}, error) {
+	{{- if .Method.Timeout }}
+		ctx, cancel := context.WithTimeout(ctx, {{ .Method.Timeout }})
+		defer cancel()
+	{{- end }}
 		req, err := c.{{ .RequestInit.Name }}(ctx, {{ range .RequestInit.ClientArgs }}{{ .Ref }}{{ end }})
 		if err != nil {
 			return nil, err
@@ -348,9 +423,37 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		if err != nil {
 			return nil, err
 		}
+		{{- if .ClientCompression }}
+		if c.RequestCompressionThreshold > 0 {
+			if err := goahttp.CompressRequestBody(req, {{ printf "%q" .ClientCompression }}, c.RequestCompressionThreshold); err != nil {
+				return nil, goahttp.ErrEncodingError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+			}
+		}
+		{{- end }}
 	{{- end }}
 
 	{{- if .ClientStream }}
+		{{- if .ClientStream.NDJSON }}
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .ClientStream.Response.StatusCode }} {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientStream.VarName }}{r: goahttp.NewNDJSONReader(resp.Body)}
+		{{- else if .ClientStream.Export }}
+		req.Header.Set("Accept", goahttp.CSVContentType)
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .ClientStream.Response.StatusCode }} {
+			return decodeResponse(resp)
+		}
+		columns := []string{ {{- range $i, $c := .ClientStream.Export.Columns }}{{ if $i }}, {{ end }}{{ printf "%q" .Name }}{{ end }} }
+		stream := &{{ .ClientStream.VarName }}{r: goahttp.NewExportReader(resp.Body, columns)}
+		{{- else }}
 		var cancel context.CancelFunc
 		{
 			ctx, cancel = context.WithCancel(ctx)
@@ -377,6 +480,7 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		}()
 	{{- end }}
 		stream := &{{ .ClientStream.VarName }}{conn: conn}
+		{{- end }}
 		{{- if .Method.ViewedResult }}
 			{{- if not .Method.ViewedResult.ViewName }}
 		view := resp.Header.Get("goa-view")
@@ -412,7 +516,20 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			return goahttp.ErrInvalidType("{{ .ServiceName }}", "{{ .Method.Name }}", "{{ .Payload.Ref }}", v)
 		}
 	{{- range .Payload.Request.Headers }}
-		{{- if .FieldName }}
+		{{- if and .FieldName .Slice .Delimiter }}
+		if len(p.{{ .FieldName }}) > 0 {
+			{{- if .StringSlice }}
+			req.Header.Set({{ printf "%q" .Name }}, strings.Join(p.{{ .FieldName }}, {{ printf "%q" .Delimiter }}))
+			{{- else }}
+			{{ .VarName }}Values := make([]string, len(p.{{ .FieldName }}))
+			for i, value := range p.{{ .FieldName }} {
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value" .TimestampFormat) }}
+				{{ .VarName }}Values[i] = valueStr
+			}
+			req.Header.Set({{ printf "%q" .Name }}, strings.Join({{ .VarName }}Values, {{ printf "%q" .Delimiter }}))
+			{{- end }}
+		}
+		{{- else if .FieldName }}
 			{{- if .FieldPointer }}
 		if p.{{ .FieldName }} != nil {
 			{{- end }}
@@ -421,7 +538,15 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			req.Header.Set({{ printf "%q" .Name }}, "Bearer "+{{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }})
 		} else {
 			{{- end }}
+			{{- if eq .Type.Name "duration" }}
+			req.Header.Set({{ printf "%q" .Name }}, time.Duration({{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}).String())
+			{{- else if or (eq .Type.Name "uuid") (eq .Type.Name "decimal") }}
+			req.Header.Set({{ printf "%q" .Name }}, p.{{ .FieldName }}.String())
+			{{- else if eq .Type.Name "timestamp" }}
+			req.Header.Set({{ printf "%q" .Name }}, goa.FormatTimestamp({{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}, {{ printf "%q" .TimestampFormat }}))
+			{{- else }}
 			req.Header.Set({{ printf "%q" .Name }}, {{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }})
+			{{- end }}
 			{{- if (and (eq .Name "Authorization") (isBearer $.HeaderSchemes)) }}
 		}
 			{{- end }}
@@ -436,24 +561,62 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 	{{- range .Payload.Request.QueryParams }}
 		{{- if .MapQueryParams }}
 		for key, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
-			{{ template "type_conversion" (typeConversionData .Type.KeyType.Type "keyStr" "key") }}
-			{{- if eq .Type.ElemType.Type.Name "array" }}
+			{{ template "type_conversion" (typeConversionData .Type.KeyType.Type "keyStr" "key" .TimestampFormat) }}
+			keyName := fmt.Sprintf("{{ .Name }}[%s]", keyStr)
+			{{- if .MapObject }}
+			if value == nil {
+				continue
+			}
+				{{- range .MapObject.Fields }}
+					{{- if .StringSlice }}
+			for _, val := range value.{{ .FieldName }} {
+				values.Add(keyName+"[{{ .Name }}]", val)
+			}
+					{{- else if .Slice }}
+			for _, val := range value.{{ .FieldName }} {
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valStr" "val" .TimestampFormat) }}
+				values.Add(keyName+"[{{ .Name }}]", valStr)
+			}
+					{{- else if .Pointer }}
+			if value.{{ .FieldName }} != nil {
+				{{ template "type_conversion" (typeConversionData .Type (printf "%sStr" .VarName) (printf "*value.%s" .FieldName) .TimestampFormat) }}
+				values.Add(keyName+"[{{ .Name }}]", {{ .VarName }}Str)
+			}
+					{{- else }}
+			{{ template "type_conversion" (typeConversionData .Type (printf "%sStr" .VarName) (printf "value.%s" .FieldName) .TimestampFormat) }}
+			values.Add(keyName+"[{{ .Name }}]", {{ .VarName }}Str)
+					{{- end }}
+				{{- end }}
+			{{- else if eq .Type.ElemType.Type.Name "array" }}
 			for _, val := range value {
-				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type.ElemType.Type "valStr" "val") }}
-				values.Add(keyStr, valStr)
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type.ElemType.Type "valStr" "val" .TimestampFormat) }}
+				values.Add(keyName, valStr)
 			}
 			{{- else }}
-			{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
-			values.Add(keyStr, valueStr)
+			{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value" .TimestampFormat) }}
+			values.Add(keyName, valueStr)
 			{{- end }}
     }
+		{{- else if and .Slice .Delimiter }}
+		if len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}) > 0 {
+			{{- if .StringSlice }}
+			values.Add("{{ .Name }}", strings.Join(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}, {{ printf "%q" .Delimiter }}))
+			{{- else }}
+			{{ .VarName }}Values := make([]string, len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}))
+			for i, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value" .TimestampFormat) }}
+				{{ .VarName }}Values[i] = valueStr
+			}
+			values.Add("{{ .Name }}", strings.Join({{ .VarName }}Values, {{ printf "%q" .Delimiter }}))
+			{{- end }}
+		}
 		{{- else if .StringSlice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				values.Add("{{ .Name }}", value)
 			}
 		{{- else if .Slice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
-				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value" .TimestampFormat) }}
 				values.Add("{{ .Name }}", valueStr)
 			}
 		{{- else if .Map }}
@@ -464,9 +627,12 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- end }}
 		values.Add("{{ .Name }}",
 			{{- if eq .Type.Name "bytes" }} string(
+			{{- else if eq .Type.Name "timestamp" }} goa.FormatTimestamp(
 			{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
 			{{- end }}
 			{{- if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
+			{{- if eq .Type.Name "timestamp" }}, {{ printf "%q" .TimestampFormat }}
+			{{- end }}
 			{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
 			{{- end }})
 			{{- if .FieldPointer }}
@@ -482,14 +648,49 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
 		}
 	{{- else if .Payload.Request.ClientBody }}
+		{{- if .RequestContents }}
+		switch ct := req.Header.Get("Content-Type"); {
+		{{- range .RequestContents }}
+		case goahttp.IsContentType(ct, {{ printf "%q" .ContentType }}):
+			{{- with .Payload.Request.ClientBody }}
+			{{- if .Init }}
+			body := {{ .Init.Name }}({{ range .Init.ClientArgs }}{{ if .FieldPointer }}&{{ end }}{{ .Name }}, {{ end }})
+			{{- if .Encryption }}
+			if err := {{ .Encryption.Name }}(req.Context(), body); err != nil {
+				return goahttp.ErrEncodingError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
+			}
+			{{- end }}
+			{{- else }}
+			body := p
+			{{- end }}
+			{{- end }}
+			if err := encoder(req).Encode(&body); err != nil {
+				return goahttp.ErrEncodingError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
+			}
+		{{- end }}
+		default:
+		{{- end }}
 		{{- if .Payload.Request.ClientBody.Init }}
 		body := {{ .Payload.Request.ClientBody.Init.Name }}({{ range .Payload.Request.ClientBody.Init.ClientArgs }}{{ if .FieldPointer }}&{{ end }}{{ .Name }}, {{ end }})
+		{{- if .Payload.Request.ClientBody.Encryption }}
+		if err := {{ .Payload.Request.ClientBody.Encryption.Name }}(req.Context(), body); err != nil {
+			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		{{- end }}
 		{{- else }}
 		body := p
 		{{- end }}
 		if err := encoder(req).Encode(&body); err != nil {
 			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
 		}
+		{{- if .MergePatch }}
+		if req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+		}
+		{{- end }}
+		{{- if .RequestContents }}
+		}
+		{{- end }}
 	{{- end }}
 	{{- if .BasicScheme }}{{ with .BasicScheme }}
 		{{- if not .UsernameRequired }}
@@ -513,7 +714,7 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 {{- define "map_conversion" }}
   for k{{ if not (eq .Type.KeyType.Type.Name "string") }}Raw{{ end }}, value := range {{ .SourceVar }}{{ if .SourceField }}.{{ .SourceField }}{{ end }} {
 		{{- if not (eq .Type.KeyType.Type.Name "string") }}
-			{{- template "type_conversion" (typeConversionData .Type.KeyType.Type "k" "kRaw") }}
+			{{- template "type_conversion" (typeConversionData .Type.KeyType.Type "k" "kRaw" .TimestampFormat) }}
 		{{- end }}
 		key {{ if .NewVar }}:={{ else }}={{ end }} fmt.Sprintf("{{ .VarName }}[%s]", {{ if not .NewVar }}key, {{ end }}k)
 		{{- if eq .Type.ElemType.Type.Name "string" }}
@@ -525,12 +726,12 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 				values[key] = value
 			{{- else }}
 				for _, val := range value {
-					{{ template "type_conversion" (typeConversionData .Type.ElemType.Type.ElemType.Type "valStr" "val") }}
+					{{ template "type_conversion" (typeConversionData .Type.ElemType.Type.ElemType.Type "valStr" "val" .TimestampFormat) }}
 					values.Add(key, valStr)
 				}
 			{{- end }}
 		{{- else }}
-			{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
+			{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value" .TimestampFormat) }}
 			values.Add(key, valueStr)
 		{{- end }}
 	}
@@ -559,6 +760,12 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
     {{ .VarName }} := {{ .Target }}
   {{- else if eq .Type.Name "bytes" -}}
     {{ .VarName }} := string({{ .Target }})
+  {{- else if eq .Type.Name "duration" -}}
+    {{ .VarName }} := time.Duration({{ .Target }}).String()
+  {{- else if or (eq .Type.Name "uuid") (eq .Type.Name "decimal") -}}
+    {{ .VarName }} := {{ .Target }}.String()
+  {{- else if eq .Type.Name "timestamp" -}}
+    {{ .VarName }} := goa.FormatTimestamp({{ .Target }}, {{ printf "%q" .TimestampFormat }})
   {{- else if eq .Type.Name "any" -}}
     {{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
   {{- else }}
@@ -592,6 +799,9 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 		} else {
 			defer resp.Body.Close()
 		}
+	{{- if .PassThroughHeaders }}
+		goahttp.PassThroughHeaders(resp{{ range .PassThroughHeaders }}, {{ printf "%q" . }}{{ end }})
+	{{- end }}
 		switch resp.StatusCode {
 	{{- range .Result.Responses }}
 		case {{ .StatusCode }}:
@@ -640,9 +850,14 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 		switch en {
 			{{- range .Errors }}
 		case {{ printf "%q" .Name }}:
+				{{- $retryAfter := .RetryAfter }}
 				{{- with .Response }}
 ` + singleResponseT + `
-					{{- if .ResultInit }}
+					{{- if and .ResultInit $retryAfter }}
+			res := {{ .ResultInit.Name }}({{ range .ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
+			res.RetryAfter = goahttp.RetryAfter(resp)
+			return nil, res
+					{{- else if .ResultInit }}
 			return nil, {{ .ResultInit.Name }}({{ range .ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
 					{{- else if .ClientBody }}
 			return nil, body
@@ -656,9 +871,14 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 			return nil, goahttp.ErrInvalidResponse({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, resp.StatusCode, string(body))
 		}
 		{{- else }}
+			{{- $retryAfter := (index .Errors 0).RetryAfter }}
 			{{- with (index .Errors 0).Response }}
 ` + singleResponseT + `
-				{{- if .ResultInit }}
+				{{- if and .ResultInit $retryAfter }}
+			res := {{ .ResultInit.Name }}({{ range .ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
+			res.RetryAfter = goahttp.RetryAfter(resp)
+			return nil, res
+				{{- else if .ResultInit }}
 			return nil, {{ .ResultInit.Name }}({{ range .ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
 				{{- else if .ClientBody }}
 			return nil, body
@@ -667,6 +887,12 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 				{{- end }}
 			{{- end }}
 		{{- end }}
+	{{- end }}
+	{{- if .ETag }}
+		case http.StatusNotModified:
+			return nil, goahttp.ErrNotModified({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }})
+		case http.StatusPreconditionFailed:
+			return nil, goahttp.ErrPreconditionFailed({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }})
 	{{- end }}
 		default:
 			body, _ := ioutil.ReadAll(resp.Body)
@@ -686,6 +912,11 @@ const singleResponseT = ` {{- if .ClientBody }}
 			if err != nil {
 				return nil, goahttp.ErrDecodingError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
 			}
+		{{- if .ClientBody.Encryption }}
+			if err = {{ .ClientBody.Encryption.Name }}(resp.Request.Context(), &body); err != nil {
+				return nil, goahttp.ErrDecodingError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
+			}
+		{{- end }}
 		{{- if .ClientBody.ValidateRef }}
 			{{ .ClientBody.ValidateRef }}
 			if err != nil {
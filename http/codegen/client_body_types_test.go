@@ -71,6 +71,7 @@ func TestClientTypes(t *testing.T) {
 		{"mixed-payload-attrs", testdata.MixedPayloadInBodyDSL, MixedPayloadInBodyClientTypesFile},
 		{"multiple-methods", testdata.MultipleMethodsDSL, MultipleMethodsClientTypesFile},
 		{"payload-extend-validate", testdata.PayloadExtendedValidateDSL, PayloadExtendedValidateClientTypesFile},
+		{"body-optional-fields", testdata.PayloadBodyOptionalFieldsDSL, BodyOptionalFieldsClientTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -299,3 +300,25 @@ func NewMethodQueryStringExtendedValidatePayloadRequestBody(p *servicequerystrin
 	return body
 }
 `
+
+const BodyOptionalFieldsClientTypesFile = `// MethodBodyOptionalFieldsRequestBody is the type of the
+// "ServiceBodyOptionalFields" service "MethodBodyOptionalFields" endpoint HTTP
+// request body.
+type MethodBodyOptionalFieldsRequestBody struct {
+	Name   goa.OptionalString ` + "`" + `form:"name,omitempty" json:"name,omitzero" xml:"name,omitempty"` + "`" + `
+	Age    goa.OptionalUInt   ` + "`" + `form:"age,omitempty" json:"age,omitzero" xml:"age,omitempty"` + "`" + `
+	Active bool               ` + "`" + `form:"active,omitempty" json:"active,omitempty" xml:"active,omitempty"` + "`" + `
+}
+
+// NewMethodBodyOptionalFieldsRequestBody builds the HTTP request body from the
+// payload of the "MethodBodyOptionalFields" endpoint of the
+// "ServiceBodyOptionalFields" service.
+func NewMethodBodyOptionalFieldsRequestBody(p *servicebodyoptionalfields.MethodBodyOptionalFieldsPayload) *MethodBodyOptionalFieldsRequestBody {
+	body := &MethodBodyOptionalFieldsRequestBody{
+		Name:   p.Name,
+		Age:    p.Age,
+		Active: p.Active,
+	}
+	return body
+}
+`
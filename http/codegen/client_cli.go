@@ -92,6 +92,7 @@ func endpointParser(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr, da
 		{Path: "net/http"},
 		{Path: "os"},
 		{Path: "strconv"},
+		{Path: "time"},
 		{Path: "unicode/utf8"},
 		codegen.GoaImport(""),
 		codegen.GoaNamedImport("http", "goahttp"),
@@ -150,6 +151,7 @@ func payloadBuilders(genpkg string, svc *expr.HTTPServiceExpr, data *cli.Command
 		{Path: "net/http"},
 		{Path: "os"},
 		{Path: "strconv"},
+		{Path: "time"},
 		{Path: "unicode/utf8"},
 		codegen.GoaImport(""),
 		codegen.GoaNamedImport("http", "goahttp"),
@@ -188,6 +190,17 @@ func buildFlags(svc *ServiceData, e *EndpointData) ([]*cli.FlagData, *cli.BuildF
 	return flags, buildFunction
 }
 
+// isPayloadMap returns true if arg holds the map of objects decoded from the
+// query string when MapParams maps the entire payload.
+func isPayloadMap(e *EndpointData, arg *InitArgData) bool {
+	for _, p := range e.Payload.Request.QueryParams {
+		if p.MapObject != nil && p.FieldName == "" && p.VarName == arg.Name {
+			return true
+		}
+	}
+	return false
+}
+
 func makeFlags(e *EndpointData, args []*InitArgData) ([]*cli.FlagData, *cli.BuildFunctionData) {
 	var (
 		fdata     []*cli.FieldData
@@ -207,7 +220,7 @@ func makeFlags(e *EndpointData, args []*InitArgData) ([]*cli.FlagData, *cli.Buil
 		f := cli.NewFlagData(e.ServiceName, e.Method.Name, arg.Name, arg.TypeName, arg.Description, arg.Required, arg.Example)
 		flags[i] = f
 		params[i] = f.FullName
-		if arg.FieldName == "" && arg.Name != "body" {
+		if arg.FieldName == "" && arg.Name != "body" && !isPayloadMap(e, arg) {
 			continue
 		}
 		code, chek := cli.FieldLoadCode(f, arg.Name, arg.TypeName, arg.Validate, arg.DefaultValue)
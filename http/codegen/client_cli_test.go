@@ -22,6 +22,9 @@ func TestClientCLIFiles(t *testing.T) {
 		{"multi-parse", testdata.MultiDSL, testdata.MultiParseCode, 0, 3},
 		{"multi-required-payload", testdata.MultiRequiredPayloadDSL, testdata.MultiRequiredPayloadParseCode, 0, 3},
 		{"streaming-parse", testdata.StreamingMultipleServicesDSL, testdata.StreamingParseCode, 0, 3},
+		{"cli-alias-usage-commands", testdata.MultiCLIAliasDSL, testdata.MultiCLIAliasUsageCommandsCode, 0, 1},
+		{"cli-alias-parse", testdata.MultiCLIAliasDSL, testdata.MultiCLIAliasParseCode, 0, 3},
+		{"cli-alias-command-usage", testdata.MultiCLIAliasDSL, testdata.MultiCLIAliasCommandUsageCode, 0, 4},
 		{"simple-build", testdata.MultiSimpleDSL, testdata.MultiSimpleBuildCode, 1, 1},
 		{"multi-build", testdata.MultiDSL, testdata.MultiBuildCode, 1, 1},
 		{"bool-build", testdata.PayloadQueryBoolDSL, testdata.QueryBoolBuildCode, 1, 1},
@@ -37,8 +40,10 @@ func TestClientCLIFiles(t *testing.T) {
 		{"payload-map-user-type", testdata.PayloadBodyInlineMapUserDSL, testdata.PayloadMapUserTypeBuildCode, 1, 1},
 		{"map-query", testdata.PayloadMapQueryPrimitiveArrayDSL, testdata.MapQueryParseCode, 0, 3},
 		{"map-query-object", testdata.PayloadMapQueryObjectDSL, testdata.MapQueryObjectBuildCode, 1, 1},
+		{"map-query-object-values-map", testdata.PayloadMapQueryObjectValuesMapDSL, testdata.MapQueryObjectValuesMapBuildCode, 1, 1},
 		{"empty-body-build", testdata.PayloadBodyPrimitiveFieldEmptyDSL, testdata.EmptyBodyBuildCode, 1, 1},
 		{"with-params-and-headers-dsl", testdata.WithParamsAndHeadersBlockDSL, testdata.WithParamsAndHeadersBlockBuildCode, 1, 1},
+		{"body-optional-fields-build", testdata.PayloadBodyOptionalFieldsDSL, testdata.BodyOptionalFieldsBuildCode, 1, 1},
 	}
 
 	for _, c := range cases {
@@ -28,7 +28,11 @@ func TestClientDecode(t *testing.T) {
 		{"header-array-validate", testdata.ResultHeaderArrayValidateDSL, testdata.ResultHeaderArrayValidateResponseDecodeCode},
 		{"with-headers-dsl", testdata.WithHeadersBlockDSL, testdata.WithHeadersBlockResponseDecodeCode},
 		{"with-headers-dsl-viewed-result", testdata.WithHeadersBlockViewedResultDSL, testdata.WithHeadersBlockViewedResultResponseDecodeCode},
+		{"etag", testdata.ResultETagDSL, testdata.ResultETagDecodeCode},
+		{"encrypted", testdata.ResultBodyEncryptedDSL, testdata.ResultEncryptedDecodeCode},
 		{"validate-error-response-type", testdata.ValidateErrorResponseTypeDSL, testdata.ValidateErrorResponseTypeDecodeCode},
+		{"retry-after-error-response", testdata.RetryAfterErrorResponseDSL, testdata.RetryAfterErrorResponseDecodeCode},
+		{"pass-through-headers", testdata.ResultPassThroughHeadersDSL, testdata.ResultPassThroughHeadersDecodeCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
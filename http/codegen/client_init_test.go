@@ -17,6 +17,10 @@ func TestClientInit(t *testing.T) {
 	}{
 		{"multiple endpoints", testdata.ServerMultiEndpointsDSL, testdata.MultipleEndpointsClientInitCode, 2},
 		{"streaming", testdata.StreamingResultDSL, testdata.StreamingClientInitCode, 4},
+		{"environments", testdata.EnvironmentsDSL, testdata.EnvironmentsClientInitCode, 3},
+		{"encoder options", testdata.ServerEncoderOptionsDSL, testdata.EncoderOptionsClientInitCode, 2},
+		{"request compression struct", testdata.ServerRequestCompressionDSL, testdata.RequestCompressionClientStructCode, 1},
+		{"request compression endpoint", testdata.ServerRequestCompressionDSL, testdata.RequestCompressionClientEndpointCode, 3},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// ClientInteropFiles returns the JavaScript interop files of the HTTP clients of
+// the services that define the "wasm:interop" metadata or of all the services
+// if the API defines it. The files are only compiled when targeting
+// WebAssembly (GOOS=js GOARCH=wasm).
+func ClientInteropFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	_, all := root.API.Meta["wasm:interop"]
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		if _, ok := svc.ServiceExpr.Meta["wasm:interop"]; !ok && !all {
+			continue
+		}
+		if f := clientInteropFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// clientInteropFile returns the file that exposes the methods of the HTTP client
+// of the given service to JavaScript.
+func clientInteropFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	var endpoints []*EndpointData
+	for _, e := range data.Endpoints {
+		// Streaming endpoints and endpoints with multipart requests
+		// cannot be exposed as JavaScript functions taking and returning
+		// JSON values.
+		if e.ServerStream != nil || e.ClientStream != nil || e.MultipartRequestEncoder != nil {
+			continue
+		}
+		endpoints = append(endpoints, e)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+	svcName := codegen.SnakeCase(data.Service.VarName)
+	path := filepath.Join(codegen.Gendir, "http", svcName, "client", "interop_js_wasm.go")
+	title := fmt.Sprintf("%s HTTP client JavaScript interop", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/json"},
+			{Path: "fmt"},
+			{Path: "syscall/js"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+		{
+			Name:   "client-js-interop",
+			Source: clientInteropT,
+			Data: map[string]interface{}{
+				"Service":   data.Service,
+				"JSName":    codegen.Goify(svc.Name(), false),
+				"Endpoints": endpoints,
+			},
+			FuncMap: map[string]interface{}{
+				"goify": codegen.Goify,
+			},
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: map[string]interface{}{"Service":*service.Data, "JSName":string, "Endpoints":[]*EndpointData}
+const clientInteropT = `{{ printf "ExposeJS sets the %q property of target, e.g. js.Global(), to an object whose methods call the %q service endpoints with c. The methods take the payload as their argument and return a Promise resolved with the result, the payloads and results are converted from and to JavaScript values with their JSON representation." .JSName .Service.Name | comment }}
+func ExposeJS(c *Client, target js.Value) {
+	target.Set({{ printf "%q" .JSName }}, goahttp.JSObject(map[string]goahttp.JSEndpoint{
+	{{- range .Endpoints }}
+		{{ printf "%q" (goify .Method.Name false) }}: func(ctx context.Context, payload []byte) (interface{}, error) {
+		{{- if .Payload.Ref }}
+			if payload == nil {
+				return nil, fmt.Errorf("missing payload")
+			}
+			var p {{ .Payload.Ref }}
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, fmt.Errorf("invalid payload: %s", err)
+			}
+			return c.{{ .Method.VarName }}()(ctx, p)
+		{{- else }}
+			return c.{{ .Method.VarName }}()(ctx, nil)
+		{{- end }}
+		},
+	{{- end }}
+	}))
+}
+`
@@ -0,0 +1,26 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestClientInteropFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.ClientInteropDSL)
+	fs := ClientInteropFiles("", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	expected := filepath.Join("gen", "http", "service_interop", "client", "interop_js_wasm.go")
+	if fs[0].Path != expected {
+		t.Errorf("got file path %q, expected %q", fs[0].Path, expected)
+	}
+	code := codegen.SectionCode(t, fs[0].SectionTemplates[1])
+	if code != testdata.ClientInteropCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ClientInteropCode))
+	}
+}
@@ -51,16 +51,21 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 	path = filepath.Join(codegen.Gendir, "http", svcName, "client", "types.go")
 	header := codegen.Header(svc.Name()+" HTTP client types", "client",
 		[]*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/xml"},
+			{Path: "time"},
 			{Path: "unicode/utf8"},
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 			codegen.GoaImport(""),
+			codegen.GoaNamedImport("http", "goahttp"),
 		},
 	)
 
 	var (
 		initData       []*InitData
 		validatedTypes []*TypeData
+		encryptedTypes []*TypeData
 
 		sections = []*codegen.SectionTemplate{header}
 	)
@@ -82,6 +87,29 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 			if data.ValidateDef != "" {
 				validatedTypes = append(validatedTypes, data)
 			}
+			if data.Encryption != nil {
+				encryptedTypes = append(encryptedTypes, data)
+			}
+		}
+		for _, c := range adata.RequestContents {
+			if data := c.Payload.Request.ClientBody; data != nil {
+				if data.Def != "" {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "client-request-body",
+						Source: typeDeclT,
+						Data:   data,
+					})
+				}
+				if data.Init != nil {
+					initData = append(initData, data.Init)
+				}
+				if data.ValidateDef != "" {
+					validatedTypes = append(validatedTypes, data)
+				}
+				if data.Encryption != nil {
+					encryptedTypes = append(encryptedTypes, data)
+				}
+			}
 		}
 		if adata.ClientStream != nil {
 			if data := adata.ClientStream.Payload; data != nil {
@@ -102,6 +130,20 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 		}
 	}
 
+	// webhook body types
+	for _, w := range data.Webhooks {
+		if w.Body.Def != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-webhook-body",
+				Source: typeDeclT,
+				Data:   w.Body,
+			})
+		}
+		if w.Body.Init != nil {
+			initData = append(initData, w.Body.Init)
+		}
+	}
+
 	// response body types
 	for _, a := range svc.HTTPEndpoints {
 		adata := data.Endpoint(a.Name())
@@ -117,6 +159,9 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				if data.ValidateDef != "" {
 					validatedTypes = append(validatedTypes, data)
 				}
+				if data.Encryption != nil {
+					encryptedTypes = append(encryptedTypes, data)
+				}
 			}
 		}
 	}
@@ -137,6 +182,9 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 					if data.ValidateDef != "" {
 						validatedTypes = append(validatedTypes, data)
 					}
+					if data.Encryption != nil {
+						encryptedTypes = append(encryptedTypes, data)
+					}
 				}
 			}
 		}
@@ -200,6 +248,15 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 			Data:   data,
 		})
 	}
+
+	// encryption functions
+	for _, data := range encryptedTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-encryption",
+			Source: encryptionT,
+			Data:   data,
+		})
+	}
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -0,0 +1,202 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// contractData contains the data needed to render the contract tests
+	// of a service.
+	contractData struct {
+		// Service is the service data.
+		Service *ServiceData
+		// SvcPkg is the service package import name.
+		SvcPkg string
+		// ServerPkg is the service HTTP server package import name.
+		ServerPkg string
+		// ClientPkg is the service HTTP client package import name.
+		ClientPkg string
+		// ServiceVar is the name of the variable holding the service
+		// implementation used by the tests.
+		ServiceVar string
+		// ServeFunc is the name of the function that serves the test
+		// requests.
+		ServeFunc string
+		// ExtraArgs contains the nil websocket and multipart decoder
+		// arguments passed to the server constructor if any.
+		ExtraArgs string
+		// Tests lists the contract tests, one per endpoint route.
+		Tests []*contractTestData
+	}
+
+	// contractTestData contains the data needed to render a contract test.
+	contractTestData struct {
+		// Name is the name of the test function.
+		Name string
+		// ServiceName is the name of the service.
+		ServiceName string
+		// MethodName is the name of the method.
+		MethodName string
+		// Verb is the HTTP method of the request.
+		Verb string
+		// URL is the request URL.
+		URL string
+		// Headers lists the request headers.
+		Headers []*harNameValue
+		// Body is the request body if any.
+		Body string
+		// ResponseDecoder is the name of the client response decoder.
+		ResponseDecoder string
+	}
+)
+
+// ContractTestFiles returns the contract tests of the HTTP services. The tests
+// send example requests built from the design to the generated server
+// handlers and use the generated client response decoders to make sure that
+// the responses produced by the service and the encoders have the status
+// codes, headers and bodies described in the design. The tests exercise the
+// service implementation assigned to the <service>ContractService variable
+// and are skipped if it is not set. Streaming and multipart endpoints are not
+// tested.
+func ContractTestFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	// use the same package as the example service implementations
+	scope := codegen.NewNameScope()
+	for _, svc := range root.Services {
+		scope.Unique(service.Services.Get(svc.Name).PkgName)
+	}
+	apipkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
+
+	var (
+		rand  = expr.NewRandom(root.API.Name)
+		files []*codegen.File
+	)
+	for _, svc := range root.API.HTTP.Services {
+		if f := contractTestFile(genpkg, svc, apipkg, rand); f != nil {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// contractTestFile returns the file containing the contract tests of the given
+// service, nil if the service does not define any testable endpoint.
+func contractTestFile(genpkg string, svc *expr.HTTPServiceExpr, apipkg string, rand *expr.Random) *codegen.File {
+	sd := HTTPServices.Get(svc.Name())
+	data := &contractData{
+		Service:    sd,
+		SvcPkg:     sd.Service.PkgName,
+		ServerPkg:  sd.Service.PkgName + "svr",
+		ClientPkg:  sd.Service.PkgName + "c",
+		ServiceVar: sd.Service.VarName + "ContractService",
+		ServeFunc:  "serve" + sd.Service.StructName + "Contract",
+	}
+	if streamingEndpointExists(sd) {
+		data.ExtraArgs = ", nil, nil"
+	}
+	for _, e := range svc.HTTPEndpoints {
+		ed := sd.Endpoint(e.Name())
+		if ed.MultipartRequestDecoder != nil {
+			data.ExtraArgs += ", nil"
+		}
+		if e.MethodExpr.IsStreaming() || e.MultipartRequest {
+			continue
+		}
+		for i, r := range e.Routes {
+			entry := harEntryFromExpr("", r, rand)
+			name := "TestContract" + sd.Service.StructName + ed.Method.VarName
+			if i > 0 {
+				name += fmt.Sprintf("%d", i+1)
+			}
+			test := &contractTestData{
+				Name:            name,
+				ServiceName:     svc.Name(),
+				MethodName:      e.Name(),
+				Verb:            entry.Request.Method,
+				URL:             entry.Request.URL,
+				Headers:         entry.Request.Headers,
+				ResponseDecoder: ed.ResponseDecoder,
+			}
+			if entry.Request.PostData != nil {
+				test.Body = entry.Request.PostData.Text
+			}
+			data.Tests = append(data.Tests, test)
+		}
+	}
+	if len(data.Tests) == 0 {
+		return nil
+	}
+
+	svcName := codegen.SnakeCase(sd.Service.VarName)
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "net/http"},
+		{Path: "net/http/httptest"},
+		{Path: "strings"},
+		{Path: "testing"},
+		codegen.GoaNamedImport("http", "goahttp"),
+		{Path: path.Join(genpkg, svcName), Name: data.SvcPkg},
+		{Path: path.Join(genpkg, "http", svcName, "server"), Name: data.ServerPkg},
+		{Path: path.Join(genpkg, "http", svcName, "client"), Name: data.ClientPkg},
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(sd.Service.Name+" HTTP contract tests", apipkg, specs),
+		{Name: "contract-serve", Source: contractServeT, Data: data},
+	}
+	for _, t := range data.Tests {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "contract-test",
+			Source: contractTestT,
+			Data:   map[string]interface{}{"Serve": data.ServeFunc, "ClientPkg": data.ClientPkg, "Test": t},
+		})
+	}
+	return &codegen.File{Path: svcName + "_contract_test.go", SectionTemplates: sections}
+}
+
+// input: contractData
+const contractServeT = `{{ printf "%s is the %s service implementation exercised by the contract tests, the tests are skipped if it is nil. Set it to a fake of the service, for example in the init function of a test file." .ServiceVar .Service.Service.Name | comment }}
+var {{ .ServiceVar }} {{ .SvcPkg }}.Service
+
+{{ printf "%s serves req with the generated %s service HTTP handlers and returns the recorded response." .ServeFunc .Service.Service.Name | comment }}
+func {{ .ServeFunc }}(t *testing.T, req *http.Request) *http.Response {
+	if {{ .ServiceVar }} == nil {
+		t.Skip("{{ .ServiceVar }} is not set")
+	}
+	var (
+		mux = goahttp.NewMuxer()
+		eh  = func(ctx context.Context, w http.ResponseWriter, err error) {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	)
+	endpoints := {{ .SvcPkg }}.NewEndpoints({{ .ServiceVar }})
+	server := {{ .ServerPkg }}.{{ .Service.ServerInit }}(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh{{ .ExtraArgs }})
+	{{ .ServerPkg }}.{{ .Service.MountServer }}(mux, server)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	resp := w.Result()
+	resp.Request = req
+	return resp
+}
+`
+
+// input: map[string]interface{}{"Serve": string, "ClientPkg": string, "Test": *contractTestData}
+const contractTestT = `{{ with .Test }}{{ printf "%s sends an example request to the %q endpoint of the %q service and checks that the response matches the design." .Name .MethodName .ServiceName | comment }}
+func {{ .Name }}(t *testing.T) {
+	req := httptest.NewRequest({{ printf "%q" .Verb }}, {{ printf "%q" .URL }}, {{ if .Body }}strings.NewReader({{ printf "%q" .Body }}){{ else }}nil{{ end }})
+	{{- range .Headers }}
+	req.Header.Add({{ printf "%q" .Name }}, {{ printf "%q" .Value }})
+	{{- end }}
+
+	resp := {{ $.Serve }}(t, req)
+
+	_, err := {{ $.ClientPkg }}.{{ .ResponseDecoder }}(goahttp.ResponseDecoder, false)(resp)
+	if cerr, ok := err.(*goahttp.ClientError); ok {
+		t.Errorf("%s response does not match the design: %s", resp.Status, cerr)
+	}
+}
+{{ end }}`
@@ -0,0 +1,24 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestContractTestFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.ContractDSL)
+	fs := ContractTestFiles("", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if fs[0].Path != "catalog_contract_test.go" {
+		t.Errorf("got path %q, expected %q", fs[0].Path, "catalog_contract_test.go")
+	}
+	code := codegen.SectionsCode(t, fs[0].SectionTemplates[1:])
+	if code != testdata.ContractTestCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ContractTestCode))
+	}
+}
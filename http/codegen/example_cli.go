@@ -47,6 +47,7 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 	}
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
+		{Path: "crypto/tls"},
 		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
@@ -70,12 +71,22 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
-		&codegen.SectionTemplate{Name: "cli-http-start", Source: httpCLIStartT},
+		&codegen.SectionTemplate{
+			Name:   "cli-http-start",
+			Source: httpCLIStartT,
+			Data: map[string]interface{}{
+				"H2C":  root.API.ExampleH2C(),
+				"TLS":  svrdata.TLS != nil,
+				"Unix": svrdata.HasScheme("unix"),
+			},
+		},
 		&codegen.SectionTemplate{
 			Name:   "cli-http-streaming",
 			Source: httpCLIStreamingT,
 			Data: map[string]interface{}{
 				"Services": svcData,
+				"TLS":      svrdata.TLS != nil,
+				"Unix":     svrdata.HasScheme("unix"),
 			},
 			FuncMap: map[string]interface{}{
 				"needStream": needStream,
@@ -102,25 +113,62 @@ func exampleCLI(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *codeg
 }
 
 const (
-	httpCLIStartT = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
+	// input: map[string]interface{}{"H2C": bool, "TLS": bool, "Unix": bool}
+	httpCLIStartT = `func doHTTP(scheme, host string, timeout int, debug bool{{ if .TLS }}, tlsConf *tls.Config{{ end }}) (goa.Endpoint, interface{}, error) {
 	var (
 		doer goahttp.Doer
+	{{- if .Unix }}
+		socket string
+	{{- end }}
 	)
+	{{- if .Unix }}
+	if scheme == "unix" {
+		{{ comment "Send the requests to the Unix domain socket given as host using the http scheme." }}
+		scheme, host, socket = "http", "localhost", host
+	}
+	{{- end }}
 	{
+	{{- if .TLS }}
+		{{ comment "Present the client certificate and verify the server certificate as configured by the command line flags." }}
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = tlsConf
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Transport: tr}
+	{{- else if .H2C }}
+		{{ comment "Send the requests over cleartext HTTP/2 with prior knowledge when the scheme is http." }}
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Transport: goahttp.NewH2CTransport()}
+	{{- else }}
 		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	{{- end }}
+	{{- if .Unix }}
+		if socket != "" {
+			doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Transport: goahttp.NewUnixTransport(socket)}
+		}
+	{{- end }}
 		if debug {
 			doer = goahttp.NewDebugDoer(doer)
 		}
 	}
 `
 
-	// input: map[string]interface{}{"Services": []*ServiceData}
+	// input: map[string]interface{}{"Services": []*ServiceData, "TLS": bool, "Unix": bool}
 	httpCLIStreamingT = `{{- if needStream .Services }}
 	var (
     dialer *websocket.Dialer
   )
   {
     dialer = websocket.DefaultDialer
+	{{- if .TLS }}
+		d := *websocket.DefaultDialer
+		d.TLSClientConfig = tlsConf
+		dialer = &d
+	{{- end }}
+	{{- if .Unix }}
+		if socket != "" {
+			d := *dialer
+			d.NetDialContext = goahttp.UnixDialContext(socket)
+			dialer = &d
+		}
+	{{- end }}
   }
 	{{ end }}
 `
@@ -7,6 +7,7 @@ import (
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/example"
 	ctestdata "goa.design/goa/v3/codegen/example/testdata"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 	"goa.design/goa/v3/http/codegen/testdata"
 )
@@ -22,10 +23,15 @@ func TestExampleCLIFiles(t *testing.T) {
 		{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL, testdata.ExampleCLICode},
 		{"streaming", testdata.StreamingResultDSL, testdata.StreamingExampleCLICode},
 		{"streaming-multiple-services", testdata.StreamingMultipleServicesDSL, testdata.StreamingMultipleServicesExampleCLICode},
+		{"h2c", testdata.ServerH2CHTTP3DSL, testdata.H2CExampleCLICode},
+		{"mutual-tls", ctestdata.SingleServerMutualTLSDSL, testdata.MutualTLSExampleCLICode},
+		{"unix-socket", ctestdata.SingleServerUnixSocketDSL, testdata.UnixSocketExampleCLICode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
 			// reset global variable
+			HTTPServices = make(ServicesData)
+			service.Services = make(service.ServicesData)
 			example.Servers = make(example.ServersData)
 			codegen.RunDSL(t, c.DSL)
 			fs := ExampleCLIFiles("", expr.Root)
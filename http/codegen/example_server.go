@@ -37,8 +37,10 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		{Path: "net/http"},
 		{Path: "net/url"},
 		{Path: "os"},
+		{Path: "strings"},
 		{Path: "sync"},
 		{Path: "time"},
+		codegen.GoaImport(""),
 		codegen.GoaNamedImport("http", "goahttp"),
 		codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
 		codegen.GoaImport("middleware"),
@@ -73,6 +75,12 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
+	if root.API.ExampleHTTP3() {
+		specs = append(specs, &codegen.ImportSpec{Path: "github.com/quic-go/quic-go/http3"})
+	}
+	if spec := routerImport(); spec != nil {
+		specs = append(specs, spec)
+	}
 
 	var svcdata []*ServiceData
 	for _, svc := range svr.Services {
@@ -92,15 +100,22 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		},
 		&codegen.SectionTemplate{Name: "server-http-logger", Source: httpSvrLoggerT},
 		&codegen.SectionTemplate{Name: "server-http-encoding", Source: httpSvrEncodingT},
-		&codegen.SectionTemplate{Name: "server-http-mux", Source: httpSvrMuxT},
+		&codegen.SectionTemplate{Name: "server-http-mux", Source: httpSvrMuxT, Data: root.API.HTTP.Router},
 		&codegen.SectionTemplate{
 			Name:   "server-http-init",
 			Source: httpSvrInitT,
 			Data: map[string]interface{}{
-				"Services": svcdata,
-				"APIPkg":   apiPkg,
+				"Services":    svcdata,
+				"APIPkg":      apiPkg,
+				"HealthCheck": root.API.HealthCheck,
+				"Router":      root.API.HTTP.Router,
+			},
+			FuncMap: map[string]interface{}{
+				"needStream":                needStream,
+				"compressionEndpointExists": compressionEndpointExists,
+				"canaryEndpointExists":      canaryEndpointExists,
+				"signedURLEndpointExists":   signedURLEndpointExists,
 			},
-			FuncMap: map[string]interface{}{"needStream": needStream},
 		},
 		&codegen.SectionTemplate{Name: "server-http-middleware", Source: httpSvrMiddlewareT},
 		&codegen.SectionTemplate{
@@ -108,6 +123,11 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			Source: httpSvrEndT,
 			Data: map[string]interface{}{
 				"Services": svcdata,
+				"H2C":      root.API.ExampleH2C(),
+				"HTTP3":    root.API.ExampleHTTP3(),
+			},
+			FuncMap: map[string]interface{}{
+				"needStream": needStream,
 			},
 		},
 		&codegen.SectionTemplate{Name: "server-http-errorhandler", Source: httpSvrErrorHandlerT},
@@ -213,16 +233,71 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	)
 `
 
+	// input: string
 	httpSvrMuxT = `
 	// Build the service HTTP request multiplexer and configure it to serve
 	// HTTP requests to the service endpoints.
 	var mux goahttp.Muxer
+{{- if . }}
+	// The route table writes the 404 and 405 responses to the requests that
+	// do not match any of the routes mounted below.
+	routes := goahttp.NewRouteTable(nil)
+{{- end }}
 	{
+{{- if eq . "chi" }}
+		r := chi.NewRouter()
+		r.NotFound(routes.ServeHTTP)
+		r.MethodNotAllowed(routes.ServeHTTP)
+		mux = goahttp.NewRouterMuxer(r,
+			func(method, pattern string, h http.HandlerFunc) {
+				r.Method(method, pattern, h)
+			},
+			func(req *http.Request) map[string]string {
+				params := chi.RouteContext(req.Context()).URLParams
+				vars := make(map[string]string, len(params.Keys))
+				for i, k := range params.Keys {
+					vars[k] = params.Values[i]
+				}
+				return vars
+			})
+{{- else if eq . "gorilla" }}
+		r := gorillamux.NewRouter()
+		r.NotFoundHandler = routes
+		r.MethodNotAllowedHandler = routes
+		mux = goahttp.NewRouterMuxer(r,
+			func(method, pattern string, h http.HandlerFunc) {
+				r.HandleFunc(pattern, h).Methods(method)
+			},
+			gorillamux.Vars)
+{{- else if eq . "httprouter" }}
+		r := httprouter.New()
+		r.NotFound = routes
+		r.MethodNotAllowed = routes
+		mux = goahttp.NewRouterMuxer(r,
+			func(method, pattern string, h http.HandlerFunc) {
+				r.Handler(method, pattern, h)
+			},
+			func(req *http.Request) map[string]string {
+				params := httprouter.ParamsFromContext(req.Context())
+				vars := make(map[string]string, len(params))
+				for _, p := range params {
+					// httprouter includes the leading slash in the
+					// value of catch-all parameters.
+					vars[p.Key] = strings.TrimPrefix(p.Value, "/")
+				}
+				return vars
+			})
+{{- else if eq . "servemux" }}
+		sm := http.NewServeMux()
+		sm.Handle("/", routes)
+		mux = goahttp.NewServeMuxer(sm)
+{{- else }}
 		mux = goahttp.NewMuxer()
+{{- end }}
 	}
 `
 
-	// input: map[string]interface{}{"APIPkg":string, "Services":[]*ServiceData}
+	// input: map[string]interface{}{"APIPkg":string, "Services":[]*ServiceData, "HealthCheck":bool, "Router":string}
 	httpSvrInitT = `
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
@@ -233,43 +308,119 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 		{{ .Service.VarName }}Server *{{.Service.PkgName}}svr.Server
 	{{- end }}
 	)
-	{
-		eh := errorHandler(logger)
 	{{- if needStream .Services }}
-		upgrader := &websocket.Upgrader{}
+	// The WebSocket connections of the streaming endpoints are tracked so
+	// that they can be closed with a close frame on shutdown.
+	upgrader := goahttp.NewDrainUpgrader(&websocket.Upgrader{})
 	{{- end }}
+	{
+		eh := errorHandler(logger)
 	{{- range .Services }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }})
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ if canaryEndpointExists . }}, nil{{ end }})
 		{{-  else }}
 		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh)
 		{{-  end }}
+		{{- if compressionEndpointExists . }}
+		{{ .Service.VarName }}Server.UseCompression()
+		{{- end }}
+		{{- if signedURLEndpointExists . }}
+		// The signed URLs are verified with the secret read from the
+		// URL_SIGNING_SECRET environment variable which must be shared
+		// with the services that sign them, the requests are rejected if
+		// the variable is not set.
+		if secret := os.Getenv("URL_SIGNING_SECRET"); secret != "" {
+			{{ .Service.VarName }}Server.UseSignedURLs(goahttp.NewURLSigner([]byte(secret)))
+		}
+		{{- end }}
 	{{- end }}
 	}
 	// Configure the mux.
 	{{- range .Services }}
 		{{ .Service.PkgName }}svr.Mount(mux{{ if .Endpoints }}, {{ .Service.VarName }}Server{{ end }})
 	{{- end }}
+	{{- if .HealthCheck }}
+
+	// Mount the health check handlers, they run the checks registered in
+	// health.go.
+	mux.Handle("GET", "/healthz", goahttp.LivenessHandler(health))
+	mux.Handle("GET", "/readyz", goahttp.ReadinessHandler(health))
+	{{- end }}
+	{{- if .Router }}
+
+	// Add the mounted routes to the route table so that the Allow header of
+	// the 405 responses lists the methods of the routes matching the path.
+		{{- range .Services }}
+			{{- if .Endpoints }}
+	for _, m := range {{ .Service.VarName }}Server.Mounts {
+		routes.Add(m.Verb, m.Pattern)
+	}
+			{{- end }}
+		{{- end }}
+		{{- if .HealthCheck }}
+	routes.Add("GET", "/healthz")
+	routes.Add("GET", "/readyz")
+		{{- end }}
+	{{- end }}
 `
 
 	httpSvrMiddlewareT = `
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
 	var handler http.Handler = mux
 	{
-		if debug {
-			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
-		}
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
 	}
 `
 
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "H2C":bool, "HTTP3":bool}
 	httpSvrEndT = `
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
 	srv := &http.Server{Addr: u.Host, Handler: handler}
+	{{- if .HTTP3 }}
+
+	// Serve HTTP/3 over QUIC on the UDP port with the same number as the
+	// HTTP port. HTTP/3 requires TLS so the HTTP/3 server only starts if
+	// the configuration sets a certificate. The responses sent over TLS
+	// advertise it with the Alt-Svc header.
+	h3srv := &http3.Server{Addr: u.Host, Handler: handler}
+	{
+		h := srv.Handler
+		srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS != nil {
+				h3srv.SetQUICHeaders(w.Header())
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+	{{- end }}
+	{{- if .H2C }}
+
+	// Serve cleartext HTTP/2 (h2c) in addition to HTTP/1.1 so that clients
+	// using HTTP/2 with prior knowledge multiplex their requests over a
+	// single connection.
+	if err := goahttp.ConfigureH2C(srv); err != nil {
+		logger.Printf("failed to configure h2c: %s", err)
+	}
+	{{- end }}
 
 	{{- range .Services }}
 		for _, m := range {{ .Service.VarName }}Server.Mounts {
@@ -281,20 +432,58 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	go func() {
 		defer (*wg).Done()
 
+		{{ comment "Listen on the TCP address or the Unix domain socket of the URL, or on the matching socket passed by systemd socket activation." }}
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
 		{{ comment "Start HTTP server in a separate goroutine." }}
 		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				{{ comment "Serve TLS with the settings of the current configuration, see serverTLSConfig in config.go." }}
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+			{{- if .HTTP3 }}
+				h3srv.TLSConfig = http3.ConfigureTLSConfig(serverTLSConfig())
+				go func() {
+					logger.Printf("HTTP/3 server listening on %q", u.Host)
+					errc <- h3srv.ListenAndServe()
+				}()
+			{{- end }}
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+		{{- if .HTTP3 }}
+			logger.Printf("HTTP/3 server disabled: the configuration does not set a TLS certificate")
+		{{- end }}
+			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down HTTP server at %q", u.Host)
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
 
-		{{ comment "Shutdown gracefully with a 30s timeout." }}
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		{{ comment "Shutdown gracefully: stop accepting connections and wait for the in-flight requests to complete up to the shutdown timeout set in the configuration." }}
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
 		defer cancel()
+	{{- if needStream .Services }}
+		drained := make(chan error, 1)
+		go func() { drained <- upgrader.Drain(ctx) }()
+	{{- end }}
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
+	{{- if .HTTP3 }}
+		h3srv.Close()
+	{{- end }}
+	{{- if needStream .Services }}
+		if err := <-drained; err != nil {
+			logger.Printf("failed to close WebSocket connections gracefully: %s", err)
+		}
+	{{- end }}
 	}()
 }
 `
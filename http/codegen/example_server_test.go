@@ -23,6 +23,11 @@ func TestExampleServerFiles(t *testing.T) {
 		{"server-hosting-service-subset", ctestdata.ServerHostingServiceSubsetDSL, testdata.ServerHostingServiceSubsetServerHandleCode},
 		{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL, testdata.ServerHostingMultipleServicesServerHandleCode},
 		{"streaming", testdata.StreamingMultipleServicesDSL, testdata.StreamingServerHandleCode},
+		{"compression", testdata.ServerCompressionDSL, testdata.CompressionServerHandleCode},
+		{"health-check", ctestdata.HealthCheckDSL, testdata.HealthCheckServerHandleCode},
+		{"h2c-http3", testdata.ServerH2CHTTP3DSL, testdata.H2CHTTP3ServerHandleCode},
+		{"router", testdata.ServerRouterChiDSL, testdata.RouterServerHandleCode},
+		{"signed-url", testdata.ServerSignedURLDSL, testdata.SignedURLServerHandleCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -20,6 +20,12 @@ func TestHandlerInit(t *testing.T) {
 		{"no payload result", testdata.ServerNoPayloadResultDSL, testdata.ServerNoPayloadResultHandlerConstructorCode},
 		{"payload result", testdata.ServerPayloadResultDSL, testdata.ServerPayloadResultHandlerConstructorCode},
 		{"payload result error", testdata.ServerPayloadResultErrorDSL, testdata.ServerPayloadResultErrorHandlerConstructorCode},
+		{"etag", testdata.ResultETagDSL, testdata.ServerETagHandlerConstructorCode},
+		{"view param", testdata.ResultViewParamDSL, testdata.ServerViewParamHandlerConstructorCode},
+		{"sunset", testdata.ServerSunsetDSL, testdata.ServerSunsetHandlerConstructorCode},
+		{"timeout", testdata.ServerTimeoutDSL, testdata.ServerTimeoutHandlerConstructorCode},
+		{"trace phases", testdata.ServerTracePhasesDSL, testdata.ServerTracePhasesHandlerConstructorCode},
+		{"field mask", testdata.ResultFieldMaskDSL, testdata.ServerFieldMaskHandlerConstructorCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -32,7 +38,7 @@ func TestHandlerInit(t *testing.T) {
 			if len(sections) < 7 {
 				t.Fatalf("got %d sections, expected at least 6", len(sections))
 			}
-			code := codegen.SectionCode(t, sections[8])
+			code := codegen.SectionCode(t, sections[9])
 			if code != c.Code {
 				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
 			}
@@ -0,0 +1,430 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// har is the root object of a HTTP archive as described in the HAR 1.2
+	// specification (http://www.softwareishard.com/blog/har-12-spec).
+	har struct {
+		Log *harLog `json:"log"`
+	}
+
+	// harLog contains the archive entries.
+	harLog struct {
+		Version string      `json:"version"`
+		Creator *harCreator `json:"creator"`
+		Entries []*harEntry `json:"entries"`
+	}
+
+	// harCreator describes the application that created the archive.
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	// harEntry describes a single request and response pair.
+	harEntry struct {
+		StartedDateTime string       `json:"startedDateTime"`
+		Time            int          `json:"time"`
+		Request         *harRequest  `json:"request"`
+		Response        *harResponse `json:"response"`
+		Cache           struct{}     `json:"cache"`
+		Timings         *harTimings  `json:"timings"`
+		Comment         string       `json:"comment,omitempty"`
+	}
+
+	// harRequest describes an example request.
+	harRequest struct {
+		Method      string          `json:"method"`
+		URL         string          `json:"url"`
+		HTTPVersion string          `json:"httpVersion"`
+		Cookies     []*harNameValue `json:"cookies"`
+		Headers     []*harNameValue `json:"headers"`
+		QueryString []*harNameValue `json:"queryString"`
+		PostData    *harPostData    `json:"postData,omitempty"`
+		HeadersSize int             `json:"headersSize"`
+		BodySize    int             `json:"bodySize"`
+	}
+
+	// harResponse describes an example response.
+	harResponse struct {
+		Status      int             `json:"status"`
+		StatusText  string          `json:"statusText"`
+		HTTPVersion string          `json:"httpVersion"`
+		Cookies     []*harNameValue `json:"cookies"`
+		Headers     []*harNameValue `json:"headers"`
+		Content     *harContent     `json:"content"`
+		RedirectURL string          `json:"redirectURL"`
+		HeadersSize int             `json:"headersSize"`
+		BodySize    int             `json:"bodySize"`
+	}
+
+	// harNameValue is a header, query string parameter or cookie.
+	harNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// harPostData describes a request body.
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+
+	// harContent describes a response body.
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+	}
+
+	// harTimings contains the entry timings, HAR requires the send, wait
+	// and receive timings to be set.
+	harTimings struct {
+		Send    int `json:"send"`
+		Wait    int `json:"wait"`
+		Receive int `json:"receive"`
+	}
+)
+
+// harStartedDateTime is the date used for all entries so that the generated
+// archive does not change from one generation to the next.
+const harStartedDateTime = "1970-01-01T00:00:00Z"
+
+// HARFiles returns the HTTP archive containing an example request and
+// response for each route of the HTTP endpoints of the given API. The
+// examples are built from the design examples and the credentials required by
+// the security schemes are replaced with placeholders of the form
+// {{scheme_name_token}}.
+func HARFiles(root *expr.RootExpr) ([]*codegen.File, error) {
+	// Only create an archive if there are HTTP services.
+	if len(root.API.HTTP.Services) == 0 {
+		return nil, nil
+	}
+	archive := buildHAR(root)
+	section := &codegen.SectionTemplate{
+		Name:    "har",
+		FuncMap: template.FuncMap{"toIndentedJSON": toIndentedJSON},
+		Source:  "{{ toIndentedJSON . }}",
+		Data:    archive,
+	}
+	return []*codegen.File{{
+		Path:             filepath.Join(codegen.Gendir, "http", "examples.har"),
+		SectionTemplates: []*codegen.SectionTemplate{section},
+	}}, nil
+}
+
+// buildHAR builds the HTTP archive for the given API. It uses a random
+// generator seeded with the API name so that the examples are stable.
+func buildHAR(root *expr.RootExpr) *har {
+	var (
+		base    = harBaseURL(root)
+		rand    = expr.NewRandom(root.API.Name)
+		entries []*harEntry
+	)
+	for _, svc := range root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			if e.MethodExpr.IsStreaming() {
+				// HAR cannot describe websocket exchanges.
+				continue
+			}
+			for _, r := range e.Routes {
+				entries = append(entries, harEntryFromExpr(base, r, rand))
+			}
+		}
+	}
+	return &har{Log: &harLog{
+		Version: "1.2",
+		Creator: &harCreator{Name: "goa", Version: goa.Version()},
+		Entries: entries,
+	}}
+}
+
+// harEntryFromExpr builds the archive entry for the given route.
+func harEntryFromExpr(base string, r *expr.RouteExpr, rand *expr.Random) *harEntry {
+	var (
+		e            = r.Endpoint
+		payload      = e.MethodExpr.Payload.Example(rand)
+		placeholders = harCredentials(e)
+		headers      = []*harNameValue{}
+		query        = []*harNameValue{}
+	)
+	value := func(name string, att *expr.AttributeExpr) interface{} {
+		if p, ok := placeholders[name]; ok {
+			return p
+		}
+		return harExample(payload, e.MethodExpr.Payload, name, att, rand)
+	}
+
+	path := r.FullPaths()[0]
+	codegen.WalkMappedAttr(e.PathParams(), func(name, elem string, _ bool, att *expr.AttributeExpr) error {
+		val := strings.Join(harValues(value(name, att)), ",")
+		path = strings.Replace(path, "{"+elem+"}", harPlaceholders.Replace(url.PathEscape(val)), -1)
+		path = strings.Replace(path, "{*"+elem+"}", val, -1)
+		return nil
+	})
+	codegen.WalkMappedAttr(e.QueryParams(), func(name, elem string, _ bool, att *expr.AttributeExpr) error {
+		for _, v := range harValues(value(name, att)) {
+			query = append(query, &harNameValue{Name: elem, Value: v})
+		}
+		return nil
+	})
+	codegen.WalkMappedAttr(e.Headers, func(name, elem string, _ bool, att *expr.AttributeExpr) error {
+		headers = append(headers, &harNameValue{Name: elem, Value: strings.Join(harValues(value(name, att)), ",")})
+		return nil
+	})
+	if auth, ok := placeholders[""]; ok {
+		// Basic auth credentials are not mapped to a payload attribute.
+		headers = append(headers, &harNameValue{Name: "Authorization", Value: auth.(string)})
+	}
+
+	u := base + path
+	if len(query) > 0 {
+		qs := make([]string, len(query))
+		for i, q := range query {
+			qs[i] = harEscape(q.Name) + "=" + harEscape(q.Value)
+		}
+		u += "?" + strings.Join(qs, "&")
+	}
+	req := &harRequest{
+		Method:      r.Method,
+		URL:         u,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*harNameValue{},
+		Headers:     headers,
+		QueryString: query,
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+	if e.Body.Type != expr.Empty {
+		text := harJSON(harBody(payload, e.MethodExpr.Payload, e.Body, rand))
+		req.Headers = append(req.Headers, &harNameValue{Name: "Content-Type", Value: "application/json"})
+		req.PostData = &harPostData{MimeType: "application/json", Text: text}
+		req.BodySize = len(text)
+	}
+
+	return &harEntry{
+		StartedDateTime: harStartedDateTime,
+		Request:         req,
+		Response:        harResponseFromExpr(e, rand),
+		Timings:         &harTimings{},
+		Comment:         fmt.Sprintf("%s#%s", e.Service.Name(), e.Name()),
+	}
+}
+
+// harResponseFromExpr builds the example response of the given endpoint using
+// its first response definition.
+func harResponseFromExpr(e *expr.HTTPEndpointExpr, rand *expr.Random) *harResponse {
+	resp := &harResponse{
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []*harNameValue{},
+		Headers:     []*harNameValue{},
+		Content:     &harContent{},
+		HeadersSize: -1,
+	}
+	if len(e.Responses) == 0 {
+		resp.Status = http.StatusNoContent
+		resp.StatusText = http.StatusText(http.StatusNoContent)
+		return resp
+	}
+	r := e.Responses[0]
+	resp.Status = r.StatusCode
+	resp.StatusText = http.StatusText(r.StatusCode)
+	result := e.MethodExpr.Result.Example(rand)
+	codegen.WalkMappedAttr(r.Headers, func(name, elem string, _ bool, att *expr.AttributeExpr) error {
+		val := harExample(result, e.MethodExpr.Result, name, att, rand)
+		resp.Headers = append(resp.Headers, &harNameValue{Name: elem, Value: strings.Join(harValues(val), ",")})
+		return nil
+	})
+	if r.Body != nil && r.Body.Type != expr.Empty {
+		ct := r.ContentType
+		if ct == "" {
+			ct = "application/json"
+		}
+		text := harJSON(harBody(result, e.MethodExpr.Result, r.Body, rand))
+		resp.Headers = append(resp.Headers, &harNameValue{Name: "Content-Type", Value: ct})
+		resp.Content = &harContent{Size: len(text), MimeType: ct, Text: text}
+		resp.BodySize = len(text)
+	}
+	return resp
+}
+
+// harCredentials returns the placeholders used in place of the credentials
+// required by the first security requirement of the endpoint indexed by name
+// of the payload attribute. The placeholder for basic auth credentials which
+// are not mapped to a single attribute is indexed by the empty string.
+func harCredentials(e *expr.HTTPEndpointExpr) map[string]interface{} {
+	res := make(map[string]interface{})
+	if len(e.Requirements) == 0 {
+		return res
+	}
+	payload := e.MethodExpr.Payload
+	for _, sch := range e.Requirements[0].Schemes {
+		name := codegen.SnakeCase(sch.SchemeName)
+		switch sch.Kind {
+		case expr.BasicAuthKind:
+			res[""] = "Basic {{" + name + "_credentials}}"
+		case expr.APIKeyKind:
+			res[expr.TaggedAttribute(payload, "security:apikey:"+sch.SchemeName)] = "{{" + name + "_key}}"
+		case expr.JWTKind, expr.OAuth2Kind:
+			tag := "security:token"
+			if sch.Kind == expr.OAuth2Kind {
+				tag = "security:accesstoken"
+			}
+			token := "{{" + name + "_token}}"
+			if sch.In == "header" && sch.Name == "Authorization" {
+				token = "Bearer " + token
+			}
+			res[expr.TaggedAttribute(payload, tag)] = token
+		}
+	}
+	return res
+}
+
+// harExample returns the example value of the attribute name of the object
+// parent given the example of parent. It returns the example of parent itself
+// if parent is not an object (i.e. it's mapped to a single parameter, header
+// or body) and computes a new example if the one of parent does not define a
+// value for name.
+func harExample(example interface{}, parent *expr.AttributeExpr, name string, att *expr.AttributeExpr, rand *expr.Random) interface{} {
+	if !expr.IsObject(parent.Type) {
+		return example
+	}
+	if m, ok := example.(map[string]interface{}); ok {
+		if v, ok := m[name]; ok {
+			return v
+		}
+	}
+	return att.Example(rand)
+}
+
+// harBody returns the example body built from the example of the method
+// payload or result att.
+func harBody(example interface{}, att, body *expr.AttributeExpr, rand *expr.Random) interface{} {
+	if o, ok := body.Meta["origin:attribute"]; ok {
+		return harExample(example, att, o[0], body, rand)
+	}
+	obj := expr.AsObject(body.Type)
+	if obj == nil || !expr.IsObject(att.Type) {
+		return example
+	}
+	res := make(map[string]interface{}, len(*obj))
+	for _, nat := range *obj {
+		res[nat.Name] = harExample(example, att, nat.Name, nat.Attribute, rand)
+	}
+	return res
+}
+
+// harPlaceholders restores the credential placeholders escaped by the URL
+// encoding functions.
+var harPlaceholders = strings.NewReplacer("%7B%7B", "{{", "%7D%7D", "}}")
+
+// harEscape escapes s so it can be used in a URL query string leaving the
+// credential placeholders readable.
+func harEscape(s string) string {
+	return harPlaceholders.Replace(url.QueryEscape(s))
+}
+
+// harValues returns the string representations of v, one per element if v is
+// a slice.
+func harValues(v interface{}) []string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return []string{fmt.Sprintf("%v", v)}
+	}
+	res := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		res[i] = fmt.Sprintf("%v", rv.Index(i).Interface())
+	}
+	return res
+}
+
+// harJSON returns the JSON representation of the given example.
+func harJSON(v interface{}) string {
+	b, err := json.Marshal(harStringMaps(v))
+	if err != nil {
+		panic("har: " + err.Error()) // bug
+	}
+	return string(b)
+}
+
+// harStringMaps converts the map[interface{}]interface{} values produced by
+// the example generator to map[string]interface{} so they can be serialized
+// to JSON.
+func harStringMaps(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			m[fmt.Sprintf("%v", k)] = harStringMaps(v)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			m[k] = harStringMaps(v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(actual))
+		for i, e := range actual {
+			s[i] = harStringMaps(e)
+		}
+		return s
+	default:
+		return actual
+	}
+}
+
+// harBaseURL returns the URL of the first HTTP host of the first server of the
+// API with the host variables replaced with their default values.
+func harBaseURL(root *expr.RootExpr) string {
+	for _, svr := range root.API.Servers {
+		for _, h := range svr.Hosts {
+			for _, u := range h.URIs {
+				ustr := string(u)
+				if !strings.HasPrefix(ustr, "http") {
+					continue
+				}
+				if h.Variables != nil {
+					for _, v := range *expr.AsObject(h.Variables.Type) {
+						def := v.Attribute.DefaultValue
+						if def == nil && v.Attribute.Validation != nil && len(v.Attribute.Validation.Values) > 0 {
+							def = v.Attribute.Validation.Values[0]
+						}
+						ustr = strings.Replace(ustr, "{"+v.Name+"}", fmt.Sprintf("%v", def), -1)
+					}
+				}
+				return strings.TrimSuffix(ustr, "/")
+			}
+		}
+	}
+	return "http://localhost"
+}
+
+// toIndentedJSON returns the indented JSON representation of d. It does not
+// escape HTML characters so that URLs remain readable.
+func toIndentedJSON(d interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		panic("har: " + err.Error()) // bug
+	}
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestHAR(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "har")
+	)
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"valid", testdata.HARDSL},
+		{"security", testdata.SecurityDSL},
+		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := RunHTTPDSL(t, c.DSL)
+			fs, err := HARFiles(root)
+			if err != nil {
+				t.Fatalf("HAR failed with %s", err)
+			}
+			if len(fs) != 1 {
+				t.Fatalf("expected 1 file, got %d", len(fs))
+			}
+			if fs[0].Path != filepath.Join("gen", "http", "examples.har") {
+				t.Errorf("invalid output path %#v", fs[0].Path)
+			}
+			s := fs[0].SectionTemplates
+			if len(s) != 1 {
+				t.Fatalf("expected 1 section, got %d", len(s))
+			}
+			var buf bytes.Buffer
+			tmpl := template.Must(template.New("har").Funcs(s[0].FuncMap).Parse(s[0].Source))
+			if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+				t.Fatalf("failed to render template: %s", err)
+			}
+			var v interface{}
+			if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+				t.Errorf("invalid JSON: %s", err)
+			}
+
+			golden := filepath.Join(goldenPath, fmt.Sprintf("%s.golden", c.Name))
+			if *update {
+				if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+
+			want, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -46,8 +47,19 @@ type (
 		Required             []string      `json:"required,omitempty" yaml:"required,omitempty"`
 		AdditionalProperties bool          `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 
+		// Field groups
+		Dependencies map[string][]string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+		OneOf        []*Schema           `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+		AllOf        []*Schema           `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+		Not          *Schema             `json:"not,omitempty" yaml:"not,omitempty"`
+		If           *Schema             `json:"if,omitempty" yaml:"if,omitempty"`
+		Then         *Schema             `json:"then,omitempty" yaml:"then,omitempty"`
+
 		// Union
 		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+
+		// XML serialization
+		XML *XML `json:"xml,omitempty" yaml:"xml,omitempty"`
 	}
 
 	// Type is the JSON type enum.
@@ -59,6 +71,16 @@ type (
 		Type           string `json:"type,omitempty" yaml:"type,omitempty"`
 	}
 
+	// XML represents the OpenAPI "xml" object that describes the XML
+	// serialization of a schema.
+	XML struct {
+		Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+		Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+		Prefix    string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+		Attribute bool   `json:"attribute,omitempty" yaml:"attribute,omitempty"`
+		Wrapped   bool   `json:"wrapped,omitempty" yaml:"wrapped,omitempty"`
+	}
+
 	// Link represents a "link" field in a JSON hyper schema.
 	Link struct {
 		Title        string  `json:"title,omitempty" yaml:"title,omitempty"`
@@ -232,6 +254,9 @@ func ResultTypeRefWithPrefix(api *expr.APIExpr, mt *expr.ResultTypeExpr, view st
 	if _, ok := Definitions[projected.TypeName]; !ok {
 		projected.TypeName = codegen.Goify(prefix, true) + codegen.Goify(projected.TypeName, true)
 		GenerateResultTypeDefinition(api, projected, "default")
+		if d := Definitions[projected.TypeName]; d.XML == nil {
+			d.XML = xmlObject(mt.AttributeExpr)
+		}
 	}
 	return fmt.Sprintf("#/definitions/%s", projected.TypeName)
 }
@@ -316,6 +341,18 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 		case expr.BytesKind:
 			s.Type = Type("string")
 			s.Format = "byte"
+		case expr.DurationKind:
+			s.Type = Type("string")
+			s.Format = "duration"
+		case expr.UUIDKind:
+			s.Type = Type("string")
+			s.Format = "uuid"
+		case expr.TimestampKind:
+			s.Type = Type("string")
+			s.Format = "date-time"
+		case expr.DecimalKind:
+			s.Type = Type("string")
+			s.Format = "decimal"
 		}
 	case *expr.Array:
 		s.Type = Array
@@ -371,6 +408,7 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.MaxLength, other.MaxLength, maxInt(s.MaxLength, other.MaxLength)},
 		{&s.MinItems, other.MinItems, minInt(s.MinItems, other.MinItems)},
 		{&s.MaxItems, other.MaxItems, maxInt(s.MaxItems, other.MaxItems)},
+		{&s.XML, other.XML, s.XML == nil},
 	}
 }
 
@@ -427,6 +465,13 @@ func (s *Schema) Dup() *Schema {
 		MaxItems:             s.MaxItems,
 		Required:             s.Required,
 		AdditionalProperties: s.AdditionalProperties,
+		Dependencies:         s.Dependencies,
+		OneOf:                s.OneOf,
+		AllOf:                s.AllOf,
+		Not:                  s.Not,
+		If:                   s.If,
+		Then:                 s.Then,
+		XML:                  s.XML,
 	}
 	for n, p := range s.Properties {
 		js.Properties[n] = p.Dup()
@@ -451,20 +496,80 @@ func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr)
 	s.DefaultValue = toStringMap(at.DefaultValue)
 	s.Description = at.Description
 	s.Example = at.Example(api.Random())
+	s.XML = xmlObject(at)
 	initAttributeValidation(s, at)
+	if at.Type == expr.Timestamp {
+		typ, format := timestampType(at)
+		s.Type = Type(typ)
+		s.Format = format
+		if typ == "integer" {
+			s.Example = timestampNumber(at, s.Example)
+		}
+	}
 
 	return s
 }
 
+// timestampType returns the JSON schema type and format of the values of the
+// timestamp attribute at given its timestamp format.
+func timestampType(at *expr.AttributeExpr) (string, string) {
+	switch at.TimestampFormat() {
+	case expr.TimestampUnix, expr.TimestampUnixMilli:
+		return "integer", "int64"
+	default:
+		return "string", "date-time"
+	}
+}
+
+// timestampNumber converts the example value v of the timestamp attribute at
+// to the number of seconds or milliseconds since the Unix epoch according to
+// the attribute timestamp format.
+func timestampNumber(at *expr.AttributeExpr, v interface{}) interface{} {
+	t, ok := expr.TimestampValue(v)
+	if !ok {
+		return v
+	}
+	if at.TimestampFormat() == expr.TimestampUnixMilli {
+		return t.UnixNano() / int64(time.Millisecond)
+	}
+	return t.Unix()
+}
+
+// xmlObject returns the XML object describing the XML serialization settings
+// defined in the design for the given attribute, nil if there is none.
+func xmlObject(at *expr.AttributeExpr) *XML {
+	var x XML
+	if v := at.Meta["xml:name"]; len(v) > 0 {
+		x.Name = v[0]
+	}
+	if v := at.Meta["xml:namespace"]; len(v) > 0 {
+		x.Namespace = v[0]
+	}
+	if v := at.Meta["xml:prefix"]; len(v) > 0 {
+		x.Prefix = v[0]
+	}
+	_, x.Attribute = at.Meta["xml:attribute"]
+	_, x.Wrapped = at.Meta["xml:wrapped"]
+	if x == (XML{}) {
+		return nil
+	}
+	return &x
+}
+
 // initAttributeValidation initializes validation rules for an attribute.
 func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 	val := at.Validation
 	if val == nil {
 		return
 	}
+	if at.Type == expr.Duration {
+		// durations are strings, only the enum validation applies
+		s.Enum = toStringMap(val.Values).([]interface{})
+		return
+	}
 	s.Enum = val.Values
 	s.Format = string(val.Format)
-	s.Pattern = val.Pattern
+	s.Pattern = validationPattern(val)
 	if val.Minimum != nil {
 		s.Minimum = val.Minimum
 	}
@@ -486,6 +591,122 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 		}
 	}
 	s.Required = val.Required
+	initFieldGroupValidations(s, val)
+}
+
+// formatPatterns lists the regular expressions that describe the string
+// formats unknown to most OpenAPI tools. They are used as the schema pattern
+// when the attribute does not define one explicitly.
+var formatPatterns = map[expr.ValidationFormat]string{
+	expr.FormatSemver:       `^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`,
+	expr.FormatBase64:       `^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$`,
+	expr.FormatE164:         `^\+[1-9][0-9]{1,14}$`,
+	expr.FormatULID:         `^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`,
+	expr.FormatCreditCard:   `^[0-9]{12,19}$`,
+	expr.FormatCountryCode:  `^[A-Z]{2}$`,
+	expr.FormatCurrencyCode: `^[A-Z]{3}$`,
+}
+
+// validationPattern returns the pattern that describes the values accepted by
+// the validation: the explicit pattern if any, the pattern corresponding to
+// the format otherwise.
+func validationPattern(val *expr.ValidationExpr) string {
+	if val.Pattern != "" {
+		return val.Pattern
+	}
+	return formatPatterns[val.Format]
+}
+
+// initFieldGroupValidations initializes the schema constraints corresponding
+// to the field group validations. Mutually exclusive groups whose fields are
+// also listed in a at least one of group are described with oneOf (exactly one
+// field is set), the other mutually exclusive groups with not and at least one
+// of groups with anyOf. Required together groups are described with
+// dependencies and conditional requirements with if and then.
+func initFieldGroupValidations(s *Schema, val *expr.ValidationExpr) {
+	var constraints []*Schema
+	for _, g := range val.MutuallyExclusive {
+		if hasFieldGroup(val.AtLeastOneOf, g) {
+			constraints = append(constraints, &Schema{OneOf: requiredSchemas(g)})
+			continue
+		}
+		var pairs []*Schema
+		for i, n := range g {
+			for _, o := range g[i+1:] {
+				pairs = append(pairs, &Schema{Required: []string{n, o}})
+			}
+		}
+		constraints = append(constraints, &Schema{Not: &Schema{AnyOf: pairs}})
+	}
+	for _, g := range val.AtLeastOneOf {
+		if !hasFieldGroup(val.MutuallyExclusive, g) {
+			constraints = append(constraints, &Schema{AnyOf: requiredSchemas(g)})
+		}
+	}
+	for _, g := range val.RequiredTogether {
+		if s.Dependencies == nil {
+			s.Dependencies = make(map[string][]string)
+		}
+		for _, n := range g {
+			for _, o := range g {
+				if o != n {
+					s.Dependencies[n] = append(s.Dependencies[n], o)
+				}
+			}
+		}
+	}
+	for _, r := range val.RequiredIf {
+		constraints = append(constraints, &Schema{
+			If: &Schema{
+				Properties: map[string]*Schema{r.Field: {Enum: []interface{}{r.Value}}},
+				Required:   []string{r.Field},
+			},
+			Then: &Schema{Required: r.Names},
+		})
+	}
+	if len(constraints) == 1 && s.AnyOf == nil {
+		c := constraints[0]
+		s.OneOf, s.AnyOf, s.Not, s.If, s.Then = c.OneOf, c.AnyOf, c.Not, c.If, c.Then
+	} else if len(constraints) > 0 {
+		s.AllOf = constraints
+	}
+}
+
+// requiredSchemas returns one schema requiring each of the given fields.
+func requiredSchemas(names []string) []*Schema {
+	res := make([]*Schema, len(names))
+	for i, n := range names {
+		res[i] = &Schema{Required: []string{n}}
+	}
+	return res
+}
+
+// hasFieldGroup returns true if groups contains a group that lists the same
+// fields as g.
+func hasFieldGroup(groups [][]string, g []string) bool {
+	for _, o := range groups {
+		if len(o) != len(g) {
+			continue
+		}
+		found := true
+		for _, n := range g {
+			contained := false
+			for _, m := range o {
+				if m == n {
+					contained = true
+					break
+				}
+			}
+			if !contained {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
 }
 
 // AttributeTypeSchema produces the JSON schema corresponding to the given attribute.
@@ -517,6 +738,8 @@ func toStringMap(val interface{}) interface{} {
 			mapSlice[i] = toStringMap(e)
 		}
 		return mapSlice
+	case time.Duration:
+		return actual.String()
 	default:
 		return actual
 	}
@@ -579,4 +802,8 @@ func buildResultTypeSchema(api *expr.APIExpr, mt *expr.ResultTypeExpr, view stri
 		panic(fmt.Sprintf("failed to project media type %#v: %s", mt.Identifier, err)) // bug
 	}
 	buildAttributeSchema(api, s, projected.AttributeExpr)
+	if s.XML == nil {
+		// projected result types do not retain the design metadata
+		s.XML = xmlObject(mt.AttributeExpr)
+	}
 }
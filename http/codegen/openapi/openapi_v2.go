@@ -27,6 +27,20 @@ type (
 		SecurityDefinitions map[string]*SecurityDefinition `json:"securityDefinitions,omitempty" yaml:"securityDefinitions,omitempty"`
 		Tags                []*Tag                         `json:"tags,omitempty" yaml:"tags,omitempty"`
 		ExternalDocs        *ExternalDocs                  `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+		Webhooks            map[string]*Path               `json:"x-webhooks,omitempty" yaml:"x-webhooks,omitempty"`
+		Servers             []*Server                      `json:"x-servers,omitempty" yaml:"x-servers,omitempty"`
+	}
+
+	// Server describes the server of a deployment environment using the
+	// OpenAPI 3 server object layout.
+	Server struct {
+		// URL is the server URL.
+		URL string `json:"url" yaml:"url"`
+		// Description of the server.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Environment is the name of the environment served by the
+		// server.
+		Environment string `json:"x-environment" yaml:"x-environment"`
 	}
 
 	// Info provides metadata about the API. The metadata can be used by the clients if needed,
@@ -97,6 +111,11 @@ type (
 		Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 		// Security is a declaration of which security schemes are applied for this operation.
 		Security []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+		// Callbacks lists the out-of-band requests sent in response to the
+		// operation indexed by name and URL runtime expression. Swagger does
+		// not support callbacks so they are listed under the "x-callbacks"
+		// extension using the structure of the OpenAPI 3 callbacks object.
+		Callbacks map[string]map[string]*Path `json:"x-callbacks,omitempty" yaml:"x-callbacks,omitempty"`
 		// Extensions defines the swagger extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
 	}
@@ -59,6 +59,7 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 		Tags:                tags,
 		SecurityDefinitions: securitySpecFromExpr(root),
 		ExternalDocs:        docsFromExpr(root.API.Docs),
+		Servers:             serversFromExpr(root),
 	}
 
 	for _, he := range root.API.HTTP.Errors {
@@ -90,6 +91,15 @@ func NewV2(root *expr.RootExpr, h *expr.HostExpr) (*V2, error) {
 				buildPathFromExpr(s, root, h, route, basePath)
 			}
 		}
+		for _, w := range res.ServiceExpr.Webhooks {
+			if !mustGenerate(w.Meta) {
+				continue
+			}
+			if s.Webhooks == nil {
+				s.Webhooks = make(map[string]*Path)
+			}
+			s.Webhooks[w.Name] = webhookFromExpr(root, w)
+		}
 	}
 	if len(Definitions) > 0 {
 		s.Definitions = make(map[string]*Schema)
@@ -132,6 +142,29 @@ func ExtensionsFromExpr(mdata expr.MetaExpr) map[string]interface{} {
 	return extensions
 }
 
+// serversFromExpr returns the HTTP servers of the environments served by the
+// hosts of the API servers.
+func serversFromExpr(root *expr.RootExpr) []*Server {
+	var servers []*Server
+	for _, s := range root.API.Servers {
+		for _, env := range s.Environments() {
+			for _, u := range env.Host.URIs {
+				uri := env.URI(u)
+				if !strings.HasPrefix(uri, "http:") && !strings.HasPrefix(uri, "https:") {
+					continue
+				}
+				servers = append(servers, &Server{
+					URL:         uri,
+					Description: env.Host.Description,
+					Environment: env.Name,
+				})
+				break
+			}
+		}
+	}
+	return servers
+}
+
 // defaultURI returns the first URI defined in the host. It substitutes any URI
 // parameters with their default values or the first item in their enum.
 func defaultURI(h *expr.HostExpr) string {
@@ -434,6 +467,204 @@ func paramsFromHeaders(endpoint *expr.HTTPEndpointExpr) []*Parameter {
 	return params
 }
 
+// viewParamFromExpr returns the query string parameter used by clients to
+// select the view used to render the endpoint result.
+func viewParamFromExpr(endpoint *expr.HTTPEndpointExpr) *Parameter {
+	rt := endpoint.MethodExpr.Result.Type.(*expr.ResultTypeExpr)
+	views := make([]interface{}, len(rt.Views))
+	for i, v := range rt.Views {
+		views[i] = v.Name
+	}
+	return &Parameter{
+		In:          "query",
+		Name:        endpoint.ViewParam,
+		Description: "Name of the view used to render the result.",
+		Type:        "string",
+		Enum:        views,
+	}
+}
+
+// versionParamFromExpr returns the header parameter used to select the API
+// version served by the given route shared by the given endpoints.
+func versionParamFromExpr(route *expr.RouteExpr, eps []*expr.HTTPEndpointExpr) *Parameter {
+	var versions []interface{}
+	for _, e := range eps {
+		if e.Version != "" {
+			versions = append(versions, e.Version)
+		}
+	}
+	p := &Parameter{
+		In:          "header",
+		Name:        route.Endpoint.VersionHeader(),
+		Description: "API version used to serve the request.",
+		Type:        "string",
+		Enum:        versions,
+	}
+	if def := route.DefaultVersionEndpoint().Version; def != "" {
+		p.Default = def
+	}
+	return p
+}
+
+// validationErrorResponse returns the response written by the HTTP servers of
+// the methods that use the AggregateErrors DSL when the request is invalid.
+func validationErrorResponse(root *expr.RootExpr) *Response {
+	return &Response{
+		Description: "Bad Request response listing the field validation errors.",
+		Schema:      &Schema{Ref: TypeRef(root.API, expr.ValidationErrorResult)},
+	}
+}
+
+// addSunsetHeaders adds the Deprecation and Sunset headers set by the handlers
+// of deprecated methods to the given response.
+func addSunsetHeaders(resp *Response) {
+	if resp.Ref != "" {
+		return
+	}
+	if resp.Headers == nil {
+		resp.Headers = make(map[string]*Header)
+	}
+	resp.Headers["Deprecation"] = &Header{
+		Description: "Indicates that the operation is deprecated.",
+		Type:        "string",
+	}
+	resp.Headers["Sunset"] = &Header{
+		Description: "Date after which the operation may stop being served.",
+		Type:        "string",
+	}
+}
+
+// addFixedHeaders adds the headers written with a constant value by the
+// response encoders to resp unless the response already describes them.
+func addFixedHeaders(resp *Response, headers []*expr.HTTPFixedHeaderExpr) {
+	if resp.Ref != "" {
+		return
+	}
+	if resp.Headers == nil {
+		resp.Headers = make(map[string]*Header)
+	}
+	for _, h := range headers {
+		if _, ok := resp.Headers[h.Name]; ok {
+			continue
+		}
+		resp.Headers[h.Name] = &Header{
+			Type: "string",
+			Enum: []interface{}{h.Value},
+		}
+	}
+}
+
+// webhookFromExpr returns the path item describing the requests sent by the
+// given webhook. Swagger does not support webhooks so the path items are
+// listed under the "x-webhooks" extension using the structure of the OpenAPI
+// 3.1 "webhooks" object.
+func webhookFromExpr(root *expr.RootExpr, w *expr.WebhookExpr) *Path {
+	svc := w.Service.Name
+	params := []*Parameter{
+		{
+			In:          "header",
+			Name:        "Webhook-Event",
+			Description: "Name of the webhook event.",
+			Required:    true,
+			Type:        "string",
+		},
+		{
+			In:          "header",
+			Name:        "Webhook-Timestamp",
+			Description: "Time the request was signed as a Unix timestamp.",
+			Required:    true,
+			Type:        "string",
+		},
+		{
+			In:          "header",
+			Name:        "Webhook-Signature",
+			Description: "Hex encoded HMAC-SHA256 of the timestamp, a dot and the request body prefixed with \"sha256=\".",
+			Required:    true,
+			Type:        "string",
+		},
+		{
+			Name:        w.Body.Type.Name(),
+			In:          "body",
+			Description: w.Body.Description,
+			Required:    true,
+			Schema:      AttributeTypeSchemaWithPrefix(root.API, w.Body, codegen.Goify(svc, true)),
+		},
+	}
+	op := &Operation{
+		Tags:        []string{svc},
+		Summary:     fmt.Sprintf("%s %s webhook", w.Name, svc),
+		Description: w.Description,
+		OperationID: fmt.Sprintf("%s#%s", svc, w.Name),
+		Consumes:    []string{"application/json"},
+		Parameters:  params,
+		Responses: map[string]*Response{
+			"200": {Description: "The webhook was received successfully, any 2xx status is accepted."},
+		},
+		Extensions: ExtensionsFromExpr(w.Meta),
+	}
+	return &Path{Post: op}
+}
+
+// callbacksFromExpr returns the callbacks of the given endpoint indexed by
+// name and URL runtime expression.
+func callbacksFromExpr(root *expr.RootExpr, endpoint *expr.HTTPEndpointExpr) map[string]map[string]*Path {
+	if len(endpoint.Callbacks) == 0 {
+		return nil
+	}
+	callbacks := make(map[string]map[string]*Path, len(endpoint.Callbacks))
+	for _, c := range endpoint.Callbacks {
+		if !mustGenerate(c.Meta) {
+			continue
+		}
+		var params []*Parameter
+		if c.Payload.Type != expr.Empty {
+			params = []*Parameter{{
+				Name:        "body",
+				In:          "body",
+				Description: c.Payload.Description,
+				Required:    true,
+				Schema:      AttributeTypeSchemaWithPrefix(root.API, c.Payload, codegen.Goify(endpoint.Service.Name(), true)+codegen.Goify(c.Name, true)),
+			}}
+		}
+		responses := make(map[string]*Response, len(c.Responses))
+		for _, r := range c.Responses {
+			desc := r.Description
+			if desc == "" {
+				desc = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
+			}
+			responses[strconv.Itoa(r.StatusCode)] = &Response{Description: desc}
+		}
+		op := &Operation{
+			Description: c.Description,
+			OperationID: fmt.Sprintf("%s#%s#%s", endpoint.Service.Name(), endpoint.Name(), c.Name),
+			Parameters:  params,
+			Responses:   responses,
+			Extensions:  ExtensionsFromExpr(c.Meta),
+		}
+		callbacks[c.Name] = map[string]*Path{c.URL: {Post: op}}
+	}
+	return callbacks
+}
+
+// conditionalParams returns the conditional request headers accepted by
+// endpoints whose result defines an entity tag.
+func conditionalParams() []*Parameter {
+	return []*Parameter{
+		{
+			In:          "header",
+			Name:        "If-Match",
+			Description: "Entity tags the current resource entity tag must match.",
+			Type:        "string",
+		},
+		{
+			In:          "header",
+			Name:        "If-None-Match",
+			Description: "Entity tags the current resource entity tag must not match.",
+			Type:        "string",
+		},
+	}
+}
+
 func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter {
 	p := &Parameter{
 		In:          in,
@@ -443,9 +674,23 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 		Required:    required,
 		Type:        at.Type.Name(),
 	}
+	var separator string
 	if expr.IsArray(at.Type) {
 		p.Items = itemsFromExpr(expr.AsArray(at.Type).ElemType)
-		p.CollectionFormat = "multi"
+		switch sep := expr.HTTPParamDelimiter(at); sep {
+		case "":
+			p.CollectionFormat = "multi"
+		case ",":
+			p.CollectionFormat = "csv"
+		case " ":
+			p.CollectionFormat = "ssv"
+		case "\t":
+			p.CollectionFormat = "tsv"
+		case "|":
+			p.CollectionFormat = "pipes"
+		default:
+			separator = sep
+		}
 	}
 	switch at.Type {
 	case expr.Int, expr.UInt, expr.UInt32, expr.UInt64:
@@ -462,14 +707,47 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 	case expr.Bytes:
 		p.Type = "string"
 		p.Format = "byte"
+	case expr.Duration:
+		p.Type = "string"
+		p.Format = "duration"
+	case expr.UUID:
+		p.Type = "string"
+		p.Format = "uuid"
+	case expr.Timestamp:
+		p.Type, p.Format = timestampType(at)
+	case expr.Decimal:
+		p.Type = "string"
+		p.Format = "decimal"
 	}
 	p.Extensions = ExtensionsFromExpr(at.Meta)
+	if separator != "" {
+		// Swagger has no collection format for arbitrary separators.
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]interface{})
+		}
+		p.Extensions["x-separator"] = separator
+	}
 	initValidations(at, p)
 	return p
 }
 
 func itemsFromExpr(at *expr.AttributeExpr) *Items {
 	items := &Items{Type: at.Type.Name()}
+	if at.Type == expr.Duration {
+		items.Type = "string"
+		items.Format = "duration"
+	}
+	if at.Type == expr.UUID {
+		items.Type = "string"
+		items.Format = "uuid"
+	}
+	if at.Type == expr.Timestamp {
+		items.Type, items.Format = timestampType(at)
+	}
+	if at.Type == expr.Decimal {
+		items.Type = "string"
+		items.Format = "decimal"
+	}
 	initValidations(at, items)
 	if expr.IsArray(at.Type) {
 		items.Items = itemsFromExpr(expr.AsArray(at.Type).ElemType)
@@ -490,6 +768,14 @@ func responseSpecFromExpr(s *V2, root *expr.RootExpr, r *expr.HTTPResponseExpr,
 		schema = AttributeTypeSchemaWithPrefix(root.API, r.Body, typeNamePrefix)
 	}
 	headers := headersFromExpr(r.Headers)
+	if ch := cachingHeaders(r); len(ch) > 0 {
+		if headers == nil {
+			headers = make(map[string]*Header, len(ch))
+		}
+		for n, h := range ch {
+			headers[n] = h
+		}
+	}
 	desc := r.Description
 	if desc == "" {
 		desc = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
@@ -502,6 +788,33 @@ func responseSpecFromExpr(s *V2, root *expr.RootExpr, r *expr.HTTPResponseExpr,
 	}
 }
 
+// cachingHeaders returns the caching headers set by the CacheControl, Expires
+// and Vary DSLs on the given response.
+func cachingHeaders(r *expr.HTTPResponseExpr) map[string]*Header {
+	res := make(map[string]*Header)
+	if r.CacheControl != "" {
+		res["Cache-Control"] = &Header{
+			Description: "Caching directives.",
+			Type:        "string",
+			Default:     r.CacheControl,
+		}
+	}
+	if r.Expires != "" {
+		res["Expires"] = &Header{
+			Description: fmt.Sprintf("Date after which the response is considered stale, set to %s after the response is written.", r.Expires),
+			Type:        "string",
+		}
+	}
+	if len(r.Vary) > 0 {
+		res["Vary"] = &Header{
+			Description: "Request headers that select the representation.",
+			Type:        "string",
+			Default:     strings.Join(r.Vary, ", "),
+		}
+	}
+	return res
+}
+
 func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 	if headers == nil {
 		return nil
@@ -509,10 +822,25 @@ func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 	res := make(map[string]*Header)
 	codegen.WalkMappedAttr(headers, func(_, n string, required bool, at *expr.AttributeExpr) error {
 		header := &Header{
-			Default:     at.DefaultValue,
+			Default:     toStringMap(at.DefaultValue),
 			Description: at.Description,
 			Type:        at.Type.Name(),
 		}
+		if at.Type == expr.Duration {
+			header.Type = "string"
+			header.Format = "duration"
+		}
+		if at.Type == expr.UUID {
+			header.Type = "string"
+			header.Format = "uuid"
+		}
+		if at.Type == expr.Timestamp {
+			header.Type, header.Format = timestampType(at)
+		}
+		if at.Type == expr.Decimal {
+			header.Type = "string"
+			header.Format = "decimal"
+		}
 		initValidations(at, header)
 		res[n] = header
 		return nil
@@ -550,10 +878,10 @@ func buildPathFromFileServer(s *V2, root *expr.RootExpr, fs *expr.HTTPFileServer
 
 		operationID := fmt.Sprintf("%s#%s", fs.Service.Name(), path)
 		schemes := root.API.Schemes()
-		// remove grpc and grpcs from schemes since it is not a valid scheme in
-		// openapi.
+		// remove grpc, grpcs and unix from schemes since they are not valid
+		// schemes in openapi.
 		for i := len(schemes) - 1; i >= 0; i-- {
-			if schemes[i] == "grpc" || schemes[i] == "grpcs" {
+			if schemes[i] == "grpc" || schemes[i] == "grpcs" || schemes[i] == "unix" {
 				schemes = append(schemes[:i], schemes[i+1:]...)
 			}
 		}
@@ -591,6 +919,11 @@ func buildPathFromFileServer(s *V2, root *expr.RootExpr, fs *expr.HTTPFileServer
 
 func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr.RouteExpr, basePath string) {
 	endpoint := route.Endpoint
+	if def := route.DefaultVersionEndpoint(); def != nil && def != endpoint {
+		// Only the operation of the default version is documented for
+		// routes shared by endpoints implementing different API versions.
+		return
+	}
 
 	tagNames := tagNamesFromExpr(endpoint.Service.Meta, endpoint.Meta)
 	if len(tagNames) == 0 {
@@ -600,10 +933,44 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 	for _, key := range route.FullPaths() {
 		params := paramsFromExpr(endpoint.Params, key)
 		params = append(params, paramsFromHeaders(endpoint)...)
+		if endpoint.ViewParam != "" {
+			params = append(params, viewParamFromExpr(endpoint))
+		}
+		if endpoint.MethodExpr.HasFieldMask() {
+			params = append(params, &Parameter{
+				In:          "query",
+				Name:        "fields",
+				Description: "Comma separated list of the result fields to return, e.g. \"name,owner.email\".",
+				Type:        "string",
+			})
+		}
+		etag := expr.TaggedAttribute(endpoint.MethodExpr.Result, "http:etag") != ""
+		if etag {
+			params = append(params, conditionalParams()...)
+		}
 		produces := []string{}
+		if endpoint.NDJSON {
+			produces = append(produces, "application/x-ndjson")
+		}
+		if endpoint.Export != nil {
+			produces = append(produces, "text/csv")
+		}
+		if eps := route.VersionedEndpoints(); eps != nil {
+			if strings.EqualFold(endpoint.VersionHeader(), "Accept") {
+				for _, e := range eps {
+					mt := "application/json"
+					if e.Version != "" {
+						mt = fmt.Sprintf("%s; version=%s", mt, e.Version)
+					}
+					produces = append(produces, mt)
+				}
+			} else {
+				params = append(params, versionParamFromExpr(route, eps))
+			}
+		}
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
-			if endpoint.MethodExpr.IsStreaming() {
+			if endpoint.MethodExpr.IsStreaming() && !endpoint.StreamsResponse() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -631,6 +998,70 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			resp := responseSpecFromExpr(s, root, er.Response, endpoint.Service.Name())
 			responses[strconv.Itoa(er.Response.StatusCode)] = resp
 		}
+		if endpoint.MethodExpr.IsAggregateErrors() {
+			if _, ok := responses[strconv.Itoa(expr.StatusBadRequest)]; !ok {
+				responses[strconv.Itoa(expr.StatusBadRequest)] = validationErrorResponse(root)
+			}
+		}
+		if etag {
+			responses[strconv.Itoa(expr.StatusNotModified)] = &Response{Description: "Not Modified response."}
+			responses[strconv.Itoa(expr.StatusPreconditionFailed)] = &Response{Description: "Precondition Failed response."}
+		}
+		if max := endpoint.RequestBodyLimit(); max > 0 && (endpoint.Body.Type != expr.Empty || endpoint.MultipartRequest) {
+			if _, ok := responses[strconv.Itoa(expr.StatusRequestEntityTooLarge)]; !ok {
+				responses[strconv.Itoa(expr.StatusRequestEntityTooLarge)] = &Response{
+					Description: fmt.Sprintf("Request Entity Too Large response, the request body must not be larger than %d bytes.", max),
+				}
+			}
+		}
+		if root.API.HTTP.StrictStatusCodes {
+			for _, st := range endpoint.StatusCodes() {
+				code := strconv.Itoa(st.Code)
+				if _, ok := responses[code]; ok || len(st.Generated) == 0 {
+					continue
+				}
+				responses[code] = &Response{
+					Description: fmt.Sprintf("%s response, %s.", http.StatusText(st.Code), strings.Join(st.Generated, ", ")),
+				}
+			}
+		}
+		_, deprecated := endpoint.MethodExpr.Sunset()
+		if deprecated {
+			for _, resp := range responses {
+				addSunsetHeaders(resp)
+			}
+		}
+		// The alias routes of renamed methods are deprecated.
+		deprecated = deprecated || route.IsAlias()
+		if fixed := endpoint.Service.AllFixedHeaders(); len(fixed) > 0 {
+			for _, resp := range responses {
+				addFixedHeaders(resp, fixed)
+			}
+		}
+
+		var consumes []string
+		if expr.PatchedType(endpoint.MethodExpr.Payload.Type) != nil {
+			consumes = []string{"application/merge-patch+json"}
+		}
+		if len(endpoint.RequestContents) > 0 {
+			// List the content types that use a specific request body
+			// in addition to the ones supported by the endpoint body.
+			if consumes == nil {
+				consumes = append(consumes, root.API.HTTP.Consumes...)
+			}
+			for _, c := range endpoint.RequestContents {
+				found := false
+				for _, ct := range consumes {
+					if ct == c.ContentType {
+						found = true
+						break
+					}
+				}
+				if !found {
+					consumes = append(consumes, c.ContentType)
+				}
+			}
+		}
 
 		if endpoint.Body.Type != expr.Empty {
 			pp := &Parameter{
@@ -656,16 +1087,16 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		}
 
 		schemes := h.Schemes()
-		// remove grpc and grpcs from schemes since it is not a valid scheme in
-		// openapi.
+		// remove grpc, grpcs and unix from schemes since they are not valid
+		// schemes in openapi.
 		for i := len(schemes) - 1; i >= 0; i-- {
-			if schemes[i] == "grpc" || schemes[i] == "grpcs" {
+			if schemes[i] == "grpc" || schemes[i] == "grpcs" || schemes[i] == "unix" {
 				schemes = append(schemes[:i], schemes[i+1:]...)
 			}
 		}
 
-		// replace http with ws for streaming endpoints
-		if endpoint.MethodExpr.IsStreaming() {
+		// replace http with ws for websocket streaming endpoints
+		if endpoint.MethodExpr.IsStreaming() && !endpoint.StreamsResponse() {
 			for i := len(schemes) - 1; i >= 0; i-- {
 				if schemes[i] == "http" {
 					news := append([]string{"ws"}, schemes[i+1:]...)
@@ -712,12 +1143,37 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			ExternalDocs: docsFromExpr(endpoint.MethodExpr.Docs),
 			OperationID:  operationID,
 			Parameters:   params,
+			Consumes:     consumes,
 			Produces:     produces,
 			Responses:    responses,
 			Schemes:      schemes,
-			Deprecated:   false,
+			Deprecated:   deprecated,
 			Extensions:   ExtensionsFromExpr(route.Meta),
 			Security:     requirements,
+			Callbacks:    callbacksFromExpr(root, endpoint),
+		}
+		if len(endpoint.RequestContents) > 0 {
+			// OpenAPI v2 only supports one body parameter per operation,
+			// describe the request bodies used with specific content types
+			// using an extension.
+			content := make(map[string]interface{}, len(endpoint.RequestContents))
+			for _, c := range endpoint.RequestContents {
+				content[c.ContentType] = map[string]interface{}{
+					"schema": AttributeTypeSchemaWithPrefix(root.API, c.Body, codegen.Goify(endpoint.Service.Name(), true)),
+				}
+			}
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-request-body-content"] = content
+		}
+		if perms := endpoint.MethodExpr.Permissions(); len(perms) > 0 {
+			// List the permissions checked by the endpoint for
+			// documentation and API gateways.
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-permissions"] = perms
 		}
 
 		if key == "" {
@@ -865,9 +1321,14 @@ func initValidations(attr *expr.AttributeExpr, def interface{}) {
 	if val == nil {
 		return
 	}
+	if attr.Type == expr.Duration {
+		// durations are strings, only the enum validation applies
+		initEnumValidation(def, toStringMap(val.Values).([]interface{}))
+		return
+	}
 	initEnumValidation(def, val.Values)
 	initFormatValidation(def, string(val.Format))
-	initPatternValidation(def, val.Pattern)
+	initPatternValidation(def, validationPattern(val))
 	if val.Minimum != nil {
 		initMinimumValidation(def, val.Minimum)
 	}
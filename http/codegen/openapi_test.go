@@ -76,9 +76,35 @@ func TestSections(t *testing.T) {
 		{"multiple-services", testdata.MultipleServicesDSL},
 		{"multiple-views", testdata.MultipleViewsDSL},
 		{"explicit-view", testdata.ExplicitViewDSL},
+		{"view-param", testdata.ViewParamDSL},
+		{"view-param-service", testdata.ServiceViewParamDSL},
 		{"security", testdata.SecurityDSL},
+		{"idempotent", testdata.IdempotentDSL},
+		{"etag", testdata.ETagDSL},
+		{"sunset", testdata.SunsetDSL},
+		{"caching", testdata.CachingDSL},
+		{"max-request-body", testdata.MaxRequestBodyDSL},
+		{"fixed-headers", testdata.FixedHeadersDSL},
+		{"webhook", testdata.WebhookDSL},
+		{"callbacks", testdata.CallbacksDSL},
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
+		{"environments", testdata.EnvironmentsDSL},
 		{"with-spaces", testdata.WithSpacesDSL},
+		{"cbor", testdata.CBORDSL},
+		{"ndjson", testdata.NDJSONDSL},
+		{"export", testdata.ExportDSL},
+		{"xml", testdata.XMLDSL},
+		{"multi-status", testdata.MultiStatusDSL},
+		{"patch", testdata.PatchDSL},
+		{"api-version", testdata.APIVersionDSL},
+		{"api-version-accept", testdata.APIVersionAcceptDSL},
+		{"field-mask", testdata.FieldMaskResultDSL},
+		{"aggregate-errors", testdata.AggregateErrorsDSL},
+		{"strict-status-codes", testdata.StrictStatusCodesDSL},
+		{"path-constraints", testdata.PathConstraintsDSL},
+		{"param-styles", testdata.ParamStylesDSL},
+		{"param-separator", testdata.ParamSeparatorDSL},
+		{"renamed-method", testdata.RenamedMethodDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
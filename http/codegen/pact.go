@@ -0,0 +1,192 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// pact is the root object of a consumer driven contract as described
+	// in the Pact specification version 2
+	// (https://github.com/pact-foundation/pact-specification/tree/version-2).
+	pact struct {
+		Consumer     *pactParticipant   `json:"consumer"`
+		Provider     *pactParticipant   `json:"provider"`
+		Interactions []*pactInteraction `json:"interactions"`
+		Metadata     *pactMetadata      `json:"metadata"`
+	}
+
+	// pactParticipant names the consumer or the provider of a contract.
+	pactParticipant struct {
+		Name string `json:"name"`
+	}
+
+	// pactInteraction describes an example request and the response
+	// expected by the consumer.
+	pactInteraction struct {
+		Description string        `json:"description"`
+		Request     *pactRequest  `json:"request"`
+		Response    *pactResponse `json:"response"`
+	}
+
+	// pactRequest describes an example request.
+	pactRequest struct {
+		Method  string            `json:"method"`
+		Path    string            `json:"path"`
+		Query   string            `json:"query,omitempty"`
+		Headers map[string]string `json:"headers,omitempty"`
+		Body    json.RawMessage   `json:"body,omitempty"`
+	}
+
+	// pactResponse describes the response expected by the consumer.
+	pactResponse struct {
+		Status        int                          `json:"status"`
+		Headers       map[string]string            `json:"headers,omitempty"`
+		Body          json.RawMessage              `json:"body,omitempty"`
+		MatchingRules map[string]*pactMatchingRule `json:"matchingRules,omitempty"`
+	}
+
+	// pactMatchingRule relaxes the comparison of the values found at the
+	// path the rule is indexed by.
+	pactMatchingRule struct {
+		Match string `json:"match"`
+	}
+
+	// pactMetadata lists the versions of the specification and of the tool
+	// used to write the contract.
+	pactMetadata struct {
+		PactSpecification *pactVersion `json:"pactSpecification"`
+		Goa               *pactVersion `json:"goa"`
+	}
+
+	// pactVersion is a version number.
+	pactVersion struct {
+		Version string `json:"version"`
+	}
+)
+
+// pactSlugRegex matches the sequences of characters that are not allowed in
+// the names of the contract files.
+var pactSlugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// PactFiles returns the Pact contracts of the HTTP services of the given API,
+// one file per service. The contracts list an interaction per route of the
+// service HTTP endpoints built from the same examples as the HTTP archive
+// returned by HARFiles. The provider is named after the service and the
+// consumer after the "pact:consumer" metadata of the service or of the API,
+// "<service>-client" if neither define it. The response bodies are matched by
+// type so that providers can be verified against their actual data.
+func PactFiles(root *expr.RootExpr) ([]*codegen.File, error) {
+	var (
+		base = harBaseURL(root)
+		rand = expr.NewRandom(root.API.Name)
+		fs   []*codegen.File
+	)
+	for _, svc := range root.API.HTTP.Services {
+		var interactions []*pactInteraction
+		for _, e := range svc.HTTPEndpoints {
+			if e.MethodExpr.IsStreaming() {
+				// Pact cannot describe streamed responses.
+				continue
+			}
+			for _, r := range e.Routes {
+				interactions = append(interactions, pactInteractionFromHAR(base, r, harEntryFromExpr(base, r, rand)))
+			}
+		}
+		if len(interactions) == 0 {
+			continue
+		}
+		contract := &pact{
+			Consumer:     &pactParticipant{Name: pactConsumer(root, svc)},
+			Provider:     &pactParticipant{Name: svc.Name()},
+			Interactions: interactions,
+			Metadata: &pactMetadata{
+				PactSpecification: &pactVersion{Version: "2.0.0"},
+				Goa:               &pactVersion{Version: goa.Version()},
+			},
+		}
+		section := &codegen.SectionTemplate{
+			Name:    "pact",
+			FuncMap: template.FuncMap{"toIndentedJSON": toIndentedJSON},
+			Source:  "{{ toIndentedJSON . }}",
+			Data:    contract,
+		}
+		name := fmt.Sprintf("%s-%s.json", pactSlug(contract.Consumer.Name), pactSlug(contract.Provider.Name))
+		fs = append(fs, &codegen.File{
+			Path:             filepath.Join(codegen.Gendir, "http", "pacts", name),
+			SectionTemplates: []*codegen.SectionTemplate{section},
+		})
+	}
+	return fs, nil
+}
+
+// pactConsumer returns the name of the consumer of the contract of the given
+// service.
+func pactConsumer(root *expr.RootExpr, svc *expr.HTTPServiceExpr) string {
+	if c, ok := svc.ServiceExpr.Meta["pact:consumer"]; ok && len(c) > 0 {
+		return c[0]
+	}
+	if c, ok := root.API.Meta["pact:consumer"]; ok && len(c) > 0 {
+		return c[0]
+	}
+	return svc.Name() + "-client"
+}
+
+// pactInteractionFromHAR builds the interaction of the given route from its
+// HTTP archive entry.
+func pactInteractionFromHAR(base string, r *expr.RouteExpr, entry *harEntry) *pactInteraction {
+	path := strings.TrimPrefix(entry.Request.URL, base)
+	var query string
+	if i := strings.Index(path, "?"); i >= 0 {
+		path, query = path[:i], path[i+1:]
+	}
+	req := &pactRequest{
+		Method:  entry.Request.Method,
+		Path:    path,
+		Query:   query,
+		Headers: pactHeaders(entry.Request.Headers),
+	}
+	if entry.Request.PostData != nil {
+		req.Body = json.RawMessage(entry.Request.PostData.Text)
+	}
+	resp := &pactResponse{
+		Status:  entry.Response.Status,
+		Headers: pactHeaders(entry.Response.Headers),
+	}
+	if entry.Response.Content.Text != "" {
+		resp.Body = json.RawMessage(entry.Response.Content.Text)
+		resp.MatchingRules = map[string]*pactMatchingRule{"$.body": {Match: "type"}}
+	}
+	return &pactInteraction{
+		Description: fmt.Sprintf("%s %s %s", entry.Comment, r.Method, r.FullPaths()[0]),
+		Request:     req,
+		Response:    resp,
+	}
+}
+
+// pactSlug returns the lowercase version of name where the sequences of
+// characters other than letters and digits are replaced with dashes so that it
+// may be used in a file name.
+func pactSlug(name string) string {
+	return strings.Trim(pactSlugRegex.ReplaceAllString(codegen.KebabCase(name), "-"), "-")
+}
+
+// pactHeaders returns the headers indexed by name, nil if there are none.
+func pactHeaders(headers []*harNameValue) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(headers))
+	for _, h := range headers {
+		res[h.Name] = h.Value
+	}
+	return res
+}
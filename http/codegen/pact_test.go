@@ -0,0 +1,73 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestPact(t *testing.T) {
+	var (
+		goldenPath = filepath.Join("testdata", "pact")
+	)
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Files []string
+	}{
+		{"valid", testdata.HARDSL, []string{"accounts-client-accounts.json"}},
+		{"consumer", testdata.PactConsumerDSL, []string{"mobile-app-billing.json", "web-ledger.json"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := RunHTTPDSL(t, c.DSL)
+			fs, err := PactFiles(root)
+			if err != nil {
+				t.Fatalf("Pact failed with %s", err)
+			}
+			if len(fs) != len(c.Files) {
+				t.Fatalf("expected %d files, got %d", len(c.Files), len(fs))
+			}
+			for i, f := range fs {
+				if f.Path != filepath.Join("gen", "http", "pacts", c.Files[i]) {
+					t.Errorf("invalid output path %#v", f.Path)
+				}
+				s := f.SectionTemplates
+				if len(s) != 1 {
+					t.Fatalf("expected 1 section, got %d", len(s))
+				}
+				var buf bytes.Buffer
+				tmpl := template.Must(template.New("pact").Funcs(s[0].FuncMap).Parse(s[0].Source))
+				if err := tmpl.Execute(&buf, s[0].Data); err != nil {
+					t.Fatalf("failed to render template: %s", err)
+				}
+				var v interface{}
+				if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+					t.Errorf("invalid JSON: %s", err)
+				}
+
+				golden := filepath.Join(goldenPath, fmt.Sprintf("%s_%s.golden", c.Name, strings.TrimSuffix(c.Files[i], ".json")))
+				if *update {
+					if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+						t.Fatalf("failed to update golden file: %s", err)
+					}
+				}
+
+				want, err := ioutil.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("failed to read golden file: %s", err)
+				}
+				if !bytes.Equal(buf.Bytes(), want) {
+					t.Errorf("result do not match the golden file:\n--BEGIN--\n%s\n--END--\n", buf.Bytes())
+				}
+			}
+		})
+	}
+}
@@ -44,6 +44,9 @@ func pathSections(svc *expr.HTTPServiceExpr, pkg string) []*codegen.SectionTempl
 			{Path: "net/url"},
 			{Path: "strconv"},
 			{Path: "strings"},
+			{Path: "time"},
+			codegen.GoaImport(""),
+			codegen.GoaNamedImport("http", "goahttp"),
 		}),
 	}
 	sdata := HTTPServices.Get(svc.Name())
@@ -53,6 +56,13 @@ func pathSections(svc *expr.HTTPServiceExpr, pkg string) []*codegen.SectionTempl
 			Source: pathT,
 			Data:   sdata.Endpoint(e.Name()),
 		})
+		if pkg == "server" && e.SignedURL {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "signed-path",
+				Source: signedPathT,
+				Data:   sdata.Endpoint(e.Name()),
+			})
+		}
 	}
 
 	return sections
@@ -64,3 +74,10 @@ func {{ .PathInit.Name }}({{ range .PathInit.ServerArgs }}{{ .Name }} {{ .TypeRe
 {{- .PathInit.ServerCode }}
 }
 {{ end }}`
+
+// input: EndpointData
+const signedPathT = `{{ range .Routes }}{{ printf "Signed%s returns the URL path to the %s service %s HTTP endpoint signed with signer. The signature expires at the given time." .PathInit.Name $.ServiceName $.Method.Name | comment }}
+func Signed{{ .PathInit.Name }}(signer *goahttp.URLSigner, {{ range .PathInit.ServerArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }}expires time.Time) string {
+	return signer.Sign({{ .PathInit.Name }}({{ range .PathInit.ServerArgs }}{{ .Name }}, {{ end }}), expires)
+}
+{{ end }}`
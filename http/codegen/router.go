@@ -0,0 +1,124 @@
+package codegen
+
+import (
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+var (
+	// segmentWildcard matches the wildcards that capture a single path
+	// segment.
+	segmentWildcard = regexp.MustCompile(`/{([a-zA-Z0-9_]+)}`)
+	// catchAllWildcard matches the wildcards that capture the end of the
+	// path.
+	catchAllWildcard = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
+)
+
+// routerPattern returns the given path pattern using the wildcard syntax of the
+// router selected in the design. The pattern is returned as is for the goa
+// muxer. The optional constraints indexed by wildcard name are added to the
+// pattern for the routers that support regular expressions (chi and gorilla).
+func routerPattern(pattern string, constraints ...map[string]string) string {
+	switch expr.Root.API.HTTP.Router {
+	case "chi", "gorilla":
+		for _, cons := range constraints {
+			pattern = constrainedPattern(pattern, cons)
+		}
+		if expr.Root.API.HTTP.Router == "chi" {
+			return catchAllWildcard.ReplaceAllString(pattern, "/*")
+		}
+		return catchAllWildcard.ReplaceAllString(pattern, "/{$1:.*}")
+	case "httprouter":
+		pattern = segmentWildcard.ReplaceAllString(pattern, "/:$1")
+		return catchAllWildcard.ReplaceAllString(pattern, "/*$1")
+	case "servemux":
+		pattern = catchAllWildcard.ReplaceAllString(pattern, "/{$1...}")
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "{$}"
+		}
+		return pattern
+	}
+	return pattern
+}
+
+// constrainedPattern returns the given path pattern where the wildcards that
+// match a path segment are followed by the corresponding constraints, e.g.
+// "/items/{id:[0-9]+}".
+func constrainedPattern(pattern string, constraints map[string]string) string {
+	if len(constraints) == 0 {
+		return pattern
+	}
+	return segmentWildcard.ReplaceAllStringFunc(pattern, func(wc string) string {
+		name := wc[2 : len(wc)-1]
+		if re, ok := constraints[name]; ok {
+			return "/{" + name + ":" + re + "}"
+		}
+		return wc
+	})
+}
+
+// routerPathParams sets the keys of the given path parameters of the endpoint
+// to the names of the path variables returned by the router selected in the
+// design. chi does not name the catch-all wildcards and uses "*" instead.
+func routerPathParams(e *expr.HTTPEndpointExpr, params []*ParamData) []*ParamData {
+	if expr.Root.API.HTTP.Router != "chi" {
+		return params
+	}
+	for _, r := range e.Routes {
+		for _, p := range r.FullPaths() {
+			for _, m := range catchAllWildcard.FindAllStringSubmatch(p, -1) {
+				for _, pd := range params {
+					if pd.Name == m[1] {
+						pd.MuxKey = "*"
+					}
+				}
+			}
+		}
+	}
+	return params
+}
+
+// pathConstraints returns the regular expressions that the path parameters of
+// the given endpoint must match indexed by wildcard name, nil if none. Only the
+// string parameters whose pattern is anchored at both ends are constrained so
+// that the routers match the same values as the request decoders. The anchors
+// are removed as the routers match entire path segments.
+func pathConstraints(e *expr.HTTPEndpointExpr) map[string]string {
+	var cons map[string]string
+	obj := expr.AsObject(e.Params.Type)
+	for _, r := range e.Routes {
+		for _, p := range r.Params() {
+			att := obj.Attribute(e.Params.KeyName(p))
+			if att == nil || att.Type != expr.String || att.Validation == nil {
+				continue
+			}
+			pat := att.Validation.Pattern
+			if len(pat) < 2 || !strings.HasPrefix(pat, "^") || !strings.HasSuffix(pat, "$") {
+				continue
+			}
+			if cons == nil {
+				cons = make(map[string]string)
+			}
+			cons[p] = pat[1 : len(pat)-1]
+		}
+	}
+	return cons
+}
+
+// routerImport returns the import spec of the package of the router selected
+// in the design used by the example server, nil if the router is provided by
+// the goa http package.
+func routerImport() *codegen.ImportSpec {
+	switch expr.Root.API.HTTP.Router {
+	case "chi":
+		return &codegen.ImportSpec{Path: "github.com/go-chi/chi/v5", Name: "chi"}
+	case "gorilla":
+		return &codegen.ImportSpec{Path: "github.com/gorilla/mux", Name: "gorillamux"}
+	case "httprouter":
+		return &codegen.ImportSpec{Path: "github.com/julienschmidt/httprouter"}
+	}
+	return nil
+}
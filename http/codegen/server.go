@@ -31,9 +31,14 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	title := fmt.Sprintf("%s HTTP server", svc.Name())
 	funcs := map[string]interface{}{
 		"join":                    func(ss []string, s string) string { return strings.Join(ss, s) },
+		"isStreamingEndpoint":     isStreamingEndpoint,
 		"streamingEndpointExists": streamingEndpointExists,
+		"canaryEndpointExists":    canaryEndpointExists,
 		"upgradeParams":           upgradeParams,
 		"viewedServerBody":        viewedServerBody,
+		"pattern":                 routerPattern,
+		"constrainedPattern":      constrainedPattern,
+		"slashPaths":              slashPaths,
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
@@ -43,18 +48,20 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: "mime/multipart"},
 			{Path: "net/http"},
 			{Path: "path"},
+			{Path: "strconv"},
 			{Path: "strings"},
 			{Path: "sync"},
 			{Path: "time"},
 			{Path: "github.com/gorilla/websocket"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaImport("http/middleware"),
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
 
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data, FuncMap: funcs})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mountpoint", Source: mountPointStructT, Data: data})
 
 	// public types
@@ -102,11 +109,21 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	}
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-service", Source: serverServiceT, Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-use", Source: serverUseT, Data: data})
-	sections = append(sections, &codegen.SectionTemplate{Name: "server-mount", Source: serverMountT, Data: data})
+	if idempotentEndpointExists(data) {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-use-idempotency", Source: serverUseIdempotencyT, Data: data})
+	}
+	if compressionEndpointExists(data) {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-use-compression", Source: serverUseCompressionT, Data: data})
+	}
+	if signedURLEndpointExists(data) {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-use-signed-urls", Source: serverUseSignedURLsT, Data: data})
+	}
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-use-response-hook", Source: serverUseResponseHookT, Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-mount", Source: serverMountT, Data: data, FuncMap: funcs})
 
 	for _, e := range data.Endpoints {
-		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler", Source: serverHandlerT, Data: e})
-		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler-init", Source: serverHandlerInitT, Data: e})
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler", Source: serverHandlerT, Data: e, FuncMap: funcs})
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler-init", Source: serverHandlerInitT, Data: e, FuncMap: funcs})
 	}
 	for _, s := range data.FileServers {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-files", Source: fileServerT, FuncMap: funcs, Data: s})
@@ -129,6 +146,8 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		}
 	}
 
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-use-error-verbosity", Source: serverUseErrorVerbosityT, Data: data})
+
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -147,6 +166,7 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			{Path: "net/http"},
 			{Path: "strconv"},
 			{Path: "strings"},
+			{Path: "time"},
 			{Path: "encoding/json"},
 			{Path: "mime/multipart"},
 			{Path: "unicode/utf8"},
@@ -156,6 +176,15 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
+	for _, e := range data.Endpoints {
+		if e.Protobuf != nil {
+			codegen.AddImport(sections[0],
+				&codegen.ImportSpec{Path: genpkg + "/grpc/" + svcName + "/pb", Name: e.Protobuf.PbPkgName},
+				&codegen.ImportSpec{Path: genpkg + "/grpc/" + svcName + "/server", Name: e.Protobuf.ServerPkgName},
+			)
+			break
+		}
+	}
 
 	for _, e := range data.Endpoints {
 		if e.ServerStream == nil {
@@ -169,16 +198,31 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 		if e.Payload.Ref != "" {
 			fm := transTmplFuncs(svc)
 			fm["mapQueryDecodeData"] = mapQueryDecodeData
+			fm["fieldConversionData"] = fieldConversionData
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "request-decoder",
 				Source:  requestDecoderT,
 				FuncMap: fm,
 				Data:    e,
 			})
+			for _, c := range e.RequestContents {
+				ce := *e
+				ce.RequestDecoder = c.RequestDecoder
+				ce.Payload = c.Payload
+				ce.RequestContents = nil
+				ce.Protobuf = nil
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:    "request-decoder",
+					Source:  requestDecoderT,
+					FuncMap: fm,
+					Data:    &ce,
+				})
+			}
 		}
 		if e.MultipartRequestDecoder != nil {
 			fm := transTmplFuncs(svc)
 			fm["mapQueryDecodeData"] = mapQueryDecodeData
+			fm["fieldConversionData"] = fieldConversionData
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "multipart-request-decoder",
 				Source:  multipartRequestDecoderT,
@@ -203,6 +247,13 @@ func serverEncodeDecode(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File
 			Data:   h,
 		})
 	}
+	if len(data.ErrorMessages) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "error-messages",
+			Source: errorMessagesT,
+			Data:   data,
+		})
+	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
@@ -220,23 +271,40 @@ func transTmplFuncs(s *expr.HTTPServiceExpr) map[string]interface{} {
 }
 
 // conversionData creates a template context suitable for executing the
-// "type_conversion" template.
+// "type_conversion" template. conversionData is used to decode the keys and
+// values of maps which are encoded as RFC 3339 strings when they hold
+// timestamps.
 func conversionData(varName, name string, dt expr.DataType) map[string]interface{} {
 	return map[string]interface{}{
-		"VarName": varName,
-		"Name":    name,
-		"Type":    dt,
+		"VarName":         varName,
+		"Name":            name,
+		"Type":            dt,
+		"TimestampFormat": expr.TimestampRFC3339,
+	}
+}
+
+// fieldConversionData produces the template data suitable for executing the
+// "type_conversion" and "slice_conversion" templates for a struct field that
+// may be a pointer.
+func fieldConversionData(varName string, dt expr.DataType, pointer bool, tsFormat string) map[string]interface{} {
+	return map[string]interface{}{
+		"VarName":         varName,
+		"Name":            varName,
+		"Type":            dt,
+		"Pointer":         pointer,
+		"TimestampFormat": tsFormat,
 	}
 }
 
 // headerConversionData produces the template data suitable for executing the
 // "header_conversion" template.
-func headerConversionData(dt expr.DataType, varName string, required bool, target string) map[string]interface{} {
+func headerConversionData(dt expr.DataType, varName string, required bool, target, tsFormat string) map[string]interface{} {
 	return map[string]interface{}{
-		"Type":     dt,
-		"VarName":  varName,
-		"Required": required,
-		"Target":   target,
+		"Type":            dt,
+		"VarName":         varName,
+		"Required":        required,
+		"Target":          target,
+		"TimestampFormat": tsFormat,
 	}
 }
 
@@ -286,6 +354,10 @@ type {{ .ServerStruct }} struct {
 	{{- range .Endpoints }}
 	{{ .Method.VarName }} http.Handler
 	{{- end }}
+	{{- if canaryEndpointExists . }}
+	{{ printf "Canaries lists the routers that select the requests served by the canary endpoints indexed by method name, nil if the server was created without canary endpoints. The routers may be modified before the server starts." | comment }}
+	Canaries map[string]*goahttp.CanaryRouter
+	{{- end }}
 }
 
 // ErrorNamer is an interface implemented by generated error structs that
@@ -324,17 +396,33 @@ func {{ .ServerInit }}(
 	{{ .MultipartRequestDecoder.VarName }} {{ .MultipartRequestDecoder.FuncName }},
 		{{- end }}
 	{{- end }}
+	{{- if canaryEndpointExists . }}
+	canary *{{ .Service.PkgName }}.Endpoints,
+	{{- end }}
 ) *{{ .ServerStruct }} {
 {{- if streamingEndpointExists . }}
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
 	}
 {{- end }}
-	return &{{ .ServerStruct }}{
+{{- with .EncoderOptions }}
+	enc = goahttp.EncoderWithOptions(enc, &goahttp.EncoderOptions{
+	{{- if .DisableHTMLEscaping }}
+		DisableHTMLEscaping: true,
+	{{- end }}
+	{{- if .NoSniff }}
+		NoSniff: true,
+	{{- end }}
+	{{- if .JSONPrefix }}
+		JSONPrefix: {{ printf "%q" .JSONPrefix }},
+	{{- end }}
+	})
+{{- end }}
+	{{ if canaryEndpointExists . }}s := {{ else }}return {{ end }}&{{ .ServerStruct }}{
 		Mounts: []*{{ .MountPointStruct }}{
 			{{- range $e := .Endpoints }}
 				{{- range $e.Routes }}
-			{"{{ $e.Method.VarName }}", "{{ .Verb }}", "{{ .Path }}"},
+			{"{{ $e.Method.VarName }}", "{{ .Verb }}", {{ printf "%q" (constrainedPattern .Path .Constraints) }}},
 				{{- end }}
 			{{- end }}
 			{{- range .FileServers }}
@@ -345,9 +433,27 @@ func {{ .ServerInit }}(
 			{{- end }}
 		},
 		{{- range .Endpoints }}
-		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if .ServerStream }}, up, cfn.{{ .Method.VarName }}Fn{{ end }}),
+		{{ .Method.VarName }}: {{ if .SignedURL }}goahttp.RequireSignedURL({{ end }}{{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if isStreamingEndpoint . }}, up, cfn.{{ .Method.VarName }}Fn{{ end }}){{ if .SignedURL }}){{ end }},
 		{{- end }}
 	}
+{{- if canaryEndpointExists . }}
+	if canary != nil {
+		{{ comment "Serve the requests selected by the routers with the handlers of the canary endpoints." }}
+		s.Canaries = map[string]*goahttp.CanaryRouter{
+		{{- range .Endpoints }}
+			{{- if .Canary }}
+			{{ printf "%q" .Method.Name }}: {Percent: {{ .Canary.Percent }}{{ if .Canary.Header }}, Header: {{ printf "%q" .Canary.Header }}{{ end }}{{ if .Canary.Value }}, Value: {{ printf "%q" .Canary.Value }}{{ end }}},
+			{{- end }}
+		{{- end }}
+		}
+	{{- range .Endpoints }}
+		{{- if .Canary }}
+		s.{{ .Method.VarName }} = s.Canaries[{{ printf "%q" .Method.Name }}].Handler(s.{{ .Method.VarName }}, {{ if .SignedURL }}goahttp.RequireSignedURL({{ end }}{{ .HandlerInit }}(canary.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if isStreamingEndpoint . }}, up, cfn.{{ .Method.VarName }}Fn{{ end }}){{ if .SignedURL }}){{ end }})
+		{{- end }}
+	{{- end }}
+	}
+	return s
+{{- end }}
 }
 `
 
@@ -365,11 +471,98 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 }
 `
 
+// input: ServiceData
+const serverUseErrorVerbosityT = `{{ printf "UseErrorVerbosity sets the verbosity of the error responses written by the server handlers. goahttp.ErrorVerbose writes the full error messages and error response bodies and is intended for development, goahttp.ErrorSanitized only writes the error names, generic messages and error IDs and is intended for production." | comment }}
+func (s *{{ .ServerStruct }}) UseErrorVerbosity(v goahttp.ErrorVerbosity) {
+	s.Use(goahttp.ErrorVerbosityMiddleware(v))
+}
+`
+
+// input: ServiceData
+const serverUseSignedURLsT = `{{ printf "UseSignedURLs makes the handlers of the endpoints that require signed URLs verify the URL signatures with the given signer. The handlers reject all the requests with a 403 Forbidden response if UseSignedURLs is not called before the server is mounted." | comment }}
+func (s *{{ .ServerStruct }}) UseSignedURLs(signer *goahttp.URLSigner) {
+	m := goahttp.VerifySignedURLs(signer)
+{{- range .Endpoints }}
+	{{- if .SignedURL }}
+	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: ServiceData
+const serverUseIdempotencyT = `{{ printf "UseIdempotency wraps the handlers of the idempotent methods with the idempotency middleware backed by the given store." | comment }}
+func (s *{{ .ServerStruct }}) UseIdempotency(store middleware.IdempotencyStore, opts ...middleware.IdempotencyOption) {
+	m := middleware.Idempotency(store, opts...)
+{{- range .Endpoints }}
+	{{- if .Idempotent }}
+	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: ServiceData
+const serverUseCompressionT = `{{ printf "UseCompression wraps the handlers of the endpoints whose responses are compressed with the compression middleware. The middleware selects the content coding from the request Accept-Encoding header." | comment }}
+func (s *{{ .ServerStruct }}) UseCompression() {
+{{- range .Endpoints }}
+	{{- if .Compression }}
+	s.{{ .Method.VarName }} = middleware.Compress({{ range $i, $e := .Compression }}{{ if $i }}, {{ end }}{{ printf "%q" $e }}{{ end }})(s.{{ .Method.VarName }})
+	{{- end }}
+{{- end }}
+}
+`
+
+// input: ServiceData
+const serverUseResponseHookT = `{{- range .Endpoints }}
+	{{- if not .ServerStream }}
+		{{- if .Result.Ref }}
+{{ printf "Use%sResponseHook registers a hook invoked with the result of the %q endpoint and the response writer before the result is encoded. The hook may set response headers and override the response status code by calling WriteHeader." .Method.VarName .Method.Name | comment }}
+		{{- else }}
+{{ printf "Use%sResponseHook registers a hook invoked with the response writer of the %q endpoint before the response is encoded. The hook may set response headers and override the response status code by calling WriteHeader." .Method.VarName .Method.Name | comment }}
+		{{- end }}
+func (s *{{ $.ServerStruct }}) Use{{ .Method.VarName }}ResponseHook(hook func(context.Context, http.ResponseWriter{{ if .Result.Ref }}, {{ .Result.Ref }}{{ end }})) {
+	s.{{ .Method.VarName }} = goahttp.ResponseHookMiddleware(func(ctx context.Context, w http.ResponseWriter, v interface{}) {
+		{{- if not .Result.Ref }}
+		hook(ctx, w)
+		{{- else if .Method.ViewedResult }}
+		hook(ctx, w, {{ .ServicePkgName }}.{{ .Method.ViewedResult.ResultInit.Name }}(v.({{ .Method.ViewedResult.FullRef }})))
+		{{- else }}
+		hook(ctx, w, v.({{ .Result.Ref }}))
+		{{- end }}
+	})(s.{{ .Method.VarName }})
+}
+	{{- end }}
+{{- end }}
+`
+
 // input: ServiceData
 const serverMountT = `{{ printf "%s configures the mux to serve the %s endpoints." .MountServer .Service.Name | comment }}
 func {{ .MountServer }}(mux goahttp.Muxer{{ if .Endpoints }}, h *{{ .ServerStruct }}{{ end }}) {
 	{{- range .Endpoints }}
+		{{- if not .Versioned }}
 	{{ .MountHandler }}(mux, h.{{ .Method.VarName }})
+		{{- end }}
+	{{- end }}
+	{{- range .VersionedRoutes }}
+		{{- $route := . }}
+		{{- range $i, $path := (slashPaths .) }}
+			{{- if and $i (ne $route.TrailingSlash "rewrite") }}
+				{{- if eq $route.TrailingSlash "redirect" }}
+	mux.Handle("{{ $route.Verb }}", {{ printf "%q" (pattern $path $route.Constraints) }}, goahttp.RedirectTrailingSlash)
+				{{- else }}
+	goahttp.RejectTrailingSlash(mux, "{{ $route.Verb }}", {{ printf "%q" (pattern $path $route.Constraints) }})
+				{{- end }}
+			{{- else if $route.Header }}
+	mux.Handle("{{ $route.Verb }}", {{ printf "%q" (pattern $path $route.Constraints) }}, goahttp.VersionHandler({{ printf "%q" $route.Header }}, {{ printf "%q" $route.Default }}, map[string]http.Handler{
+				{{- range $route.Handlers }}
+		{{ printf "%q" .Version }}: h.{{ .VarName }},
+				{{- end }}
+	}))
+			{{- else }}
+	mux.Handle("{{ $route.Verb }}", {{ printf "%q" (pattern $path $route.Constraints) }}, h.{{ (index $route.Handlers 0).VarName }}.ServeHTTP)
+			{{- end }}
+		{{- end }}
 	{{- end }}
 	{{- range .FileServers }}
 		{{- if .IsDir }}
@@ -402,7 +595,16 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 		}
 	}
 	{{- range .Routes }}
-	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
+	mux.Handle("{{ .Verb }}", {{ printf "%q" (pattern .Path .Constraints) }}, {{ if .Alias }}goahttp.DeprecatedRoute(f){{ else }}f{{ end }})
+		{{- if .SlashPath }}
+			{{- if eq .TrailingSlash "redirect" }}
+	mux.Handle("{{ .Verb }}", {{ printf "%q" (pattern .SlashPath .Constraints) }}, goahttp.RedirectTrailingSlash)
+			{{- else if eq .TrailingSlash "rewrite" }}
+	mux.Handle("{{ .Verb }}", {{ printf "%q" (pattern .SlashPath .Constraints) }}, {{ if .Alias }}goahttp.DeprecatedRoute(f){{ else }}f{{ end }})
+			{{- else }}
+	goahttp.RejectTrailingSlash(mux, "{{ .Verb }}", {{ printf "%q" (pattern .SlashPath .Constraints) }})
+			{{- end }}
+		{{- end }}
 	{{- end }}
 }
 `
@@ -412,12 +614,12 @@ const fileServerT = `{{ printf "%s configures the mux to serve GET request made
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 	{{- if .IsDir }}
 		{{- range .RequestPaths }}
-	mux.Handle("GET", "{{ . }}/", h.ServeHTTP)
-	mux.Handle("GET", "{{ . }}/*{{ $.PathParam }}", h.ServeHTTP)
+	mux.Handle("GET", "{{ pattern (printf "%s/" .) }}", h.ServeHTTP)
+	mux.Handle("GET", "{{ pattern (printf "%s/{*%s}" . $.PathParam) }}", h.ServeHTTP)
 		{{- end }}
 	{{- else }}
 		{{- range .RequestPaths }}
-	mux.Handle("GET", "{{ . }}", h.ServeHTTP)
+	mux.Handle("GET", "{{ pattern . }}", h.ServeHTTP)
 		{{- end }}
 	{{- end }}
 }
@@ -431,7 +633,7 @@ func {{ .HandlerInit }}(
 	dec func(*http.Request) goahttp.Decoder,
 	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	eh func(context.Context, http.ResponseWriter, error),
-	{{- if .ServerStream }}
+	{{- if isStreamingEndpoint . }}
 	up goahttp.Upgrader,
 	connConfigFn goahttp.ConnConfigureFunc,
 	{{- end }}
@@ -453,9 +655,47 @@ func {{ .HandlerInit }}(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .Method.Timeout }}
+		ctx, cancel := context.WithTimeout(ctx, {{ .Method.Timeout }})
+		defer cancel()
+	{{- end }}
+	{{- if .PassThroughHeaders }}
+		ctx = goahttp.ContextWithPassThroughHeaders(ctx)
+	{{- end }}
+
+	{{- if .ETag }}
+		ctx = goahttp.ContextWithConditionalRequest(ctx, r)
+	{{- end }}
+	{{- if .Sunset }}
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", {{ printf "%q" .Sunset }})
+	{{- end }}
+	{{- if .ViewParam }}
+		if view := r.URL.Query().Get({{ printf "%q" .ViewParam }}); view != "" {
+			if !({{ range $i, $v := .Method.ViewedResult.Views }}{{ if $i }} || {{ end }}view == {{ printf "%q" $v.Name }}{{ end }}) {
+				err := goa.InvalidEnumValueError({{ printf "%q" .ViewParam }}, view, []interface{}{ {{- range $i, $v := .Method.ViewedResult.Views }}{{ if $i }}, {{ end }}{{ printf "%q" $v.Name }}{{ end }} })
+				if err := encodeError(ctx, w, err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
+			ctx = context.WithValue(ctx, goa.ViewKey, view)
+		}
+	{{- end }}
+	{{- if .Method.FieldMask }}
+		if fields := r.URL.Query().Get("fields"); fields != "" {
+			ctx = context.WithValue(ctx, goa.FieldMaskKey, goa.ParseFieldMask(fields))
+		}
+	{{- end }}
 
 	{{- if .Payload.Ref }}
+		{{- if .TracePhases }}
+		decodeCtx, endPhase := goahttp.StartPhase(ctx, goahttp.PhaseDecode)
+		payload, err := decodeRequest(r.WithContext(decodeCtx))
+		endPhase(err)
+		{{- else }}
 		payload, err := decodeRequest(r)
+		{{- end }}
 		if err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
@@ -465,6 +705,38 @@ func {{ .HandlerInit }}(
 	{{- end }}
 
 	{{ if .ServerStream }}
+		{{- if .ServerStream.NDJSON }}
+		stream := &{{ .ServerStream.VarName }}{w: goahttp.NewNDJSONWriter(w, {{ .ServerStream.Response.StatusCode }})}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: stream,
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err {{ if not .Payload.Ref }}:{{ end }}= endpoint(ctx, v)
+		{{- else if .ServerStream.Export }}
+			{{- if .ServerStream.Export.Params }}
+		p := payload.({{ .Payload.Ref }})
+		filename := goahttp.ExportFilename({{ printf "%q" .ServerStream.Export.Filename }}, map[string]interface{}{
+			{{- range .ServerStream.Export.Params }}
+			{{ printf "%q" .Name }}: p.{{ .FieldName }},
+			{{- end }}
+		})
+			{{- else }}
+		filename := {{ printf "%q" .ServerStream.Export.Filename }}
+			{{- end }}
+		columns := []string{ {{- range $i, $c := .ServerStream.Export.Columns }}{{ if $i }}, {{ end }}{{ printf "%q" .Name }}{{ end }} }
+		stream := &{{ .ServerStream.VarName }}{w: goahttp.NewExportWriter(w, r, {{ .ServerStream.Response.StatusCode }}, filename, columns)}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: stream,
+		{{- if .ServerStream.Export.Params }}
+			Payload: p,
+		{{- else if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err {{ if not .Payload.Ref }}:{{ end }}= endpoint(ctx, v)
+		{{- else }}
 		var cancel context.CancelFunc
 		{
 			ctx, cancel = context.WithCancel(ctx)
@@ -482,15 +754,27 @@ func {{ .HandlerInit }}(
 		{{- end }}
 		}
 		_, err = endpoint(ctx, v)
+		{{- end }}
+	{{- else if .TracePhases }}
+		endpointCtx, endPhase := goahttp.StartPhase(ctx, goahttp.PhaseEndpoint)
+		res, err := endpoint(endpointCtx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
+		endPhase(err)
 	{{- else }}
 		res, err := endpoint(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
 	{{- end }}
 
 		if err != nil {
 			{{- if .ServerStream }}
+				{{- if or .ServerStream.NDJSON .ServerStream.Export }}
+			if stream.w.Started() {
+				eh(ctx, w, err)
+				return
+			}
+				{{- else }}
 			if _, ok := err.(websocket.HandshakeError); ok {
 				return
 			}
+				{{- end }}
 			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
@@ -498,9 +782,23 @@ func {{ .HandlerInit }}(
 			return
 		}
 	{{- if not .ServerStream }}
+		w = goahttp.RunResponseHooks(ctx, w, res)
+		{{- if .TracePhases }}
+		encodeCtx, endPhase := goahttp.StartPhase(ctx, goahttp.PhaseEncode)
+		err = encodeResponse(encodeCtx, w, res)
+		endPhase(err)
+		if err != nil {
+			eh(ctx, w, err)
+		}
+		{{- else }}
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
+		{{- end }}
+	{{- else if or .ServerStream.NDJSON .ServerStream.Export }}
+		if err := stream.w.Close(); err != nil {
+			eh(ctx, w, err)
+		}
 	{{- end }}
 	})
 }
@@ -518,9 +816,68 @@ func {{ .Name }}(v {{ .ParamTypeRef }}) {{ .ResultTypeRef }} {
 const requestDecoderT = `{{ printf "%s returns a decoder for requests sent to the %s %s endpoint." .RequestDecoder .ServiceName .Method.Name | comment }}
 func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
 	return func(r *http.Request) (interface{}, error) {
+{{- if .MaxRequestBody }}
+		goahttp.LimitRequestBody(r, {{ .MaxRequestBody }})
+{{- end }}
+{{- if .RequestCompression }}
+		if err := goahttp.DecompressRequestBody(r, {{ .MaxRequestBody }}{{ range .RequestCompression }}, {{ printf "%q" . }}{{ end }}); err != nil {
+			return nil, err
+		}
+{{- end }}
+{{- with .Renames }}
+		if err := goahttp.RenameRequest(r, &goahttp.Renames{
+		{{- if .Query }}
+			Query: map[string]string{ {{- range $old, $new := .Query }}{{ printf "%q" $old }}: {{ printf "%q" $new }}, {{ end }}},
+		{{- end }}
+		{{- if .Header }}
+			Header: map[string]string{ {{- range $old, $new := .Header }}{{ printf "%q" $old }}: {{ printf "%q" $new }}, {{ end }}},
+		{{- end }}
+		{{- if .Body }}
+			Body: map[string]string{ {{- range $old, $new := .Body }}{{ printf "%q" $old }}: {{ printf "%q" $new }}, {{ end }}},
+		{{- end }}
+		}); err != nil {
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+{{- end }}
+{{- if and .Protobuf .Protobuf.RequestMessage }}
+		if goahttp.IsProtobuf(r.Header.Get("Content-Type")) {
+			var message {{ .Protobuf.RequestMessage }}
+			if err := goahttp.NewProtobufDecoder(r.Body).Decode(&message); err != nil {
+			{{- if or .MaxRequestBody .RequestCompression }}
+				if _, ok := err.(*goa.ServiceError); ok {
+					return nil, err
+				}
+			{{- end }}
+				return nil, goa.DecodePayloadError(err.Error())
+			}
+		{{- if .Protobuf.RequestValidation }}
+			if err := {{ .Protobuf.RequestValidation }}(&message); err != nil {
+				return nil, err
+			}
+		{{- end }}
+			payload := {{ .Protobuf.PayloadInit }}(&message)
+		{{- if .Payload.Request.DefaultFrom }}
+			{{ .Payload.Request.DefaultFrom }}
+		{{- end }}
+			return payload, nil
+		}
+{{- end }}
+{{- range .RequestContents }}
+		if goahttp.IsContentType(r.Header.Get("Content-Type"), {{ printf "%q" .ContentType }}) {
+			return {{ .RequestDecoder }}(mux, decoder)(r)
+		}
+{{- end }}
 {{- if .MultipartRequestDecoder }}
 		var payload {{ .Payload.Ref }}
 		if err := decoder(r).Decode(&payload); err != nil {
+		{{- if or .MaxRequestBody .RequestCompression }}
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+		{{- end }}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 {{- else if .Payload.Request.ServerBody }}
@@ -528,25 +885,47 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 			body {{ .Payload.Request.ServerBody.VarName }}
 			err  error
 		)
+		{{- if .StrictDecoding }}
+		err = goahttp.StrictDecoder(decoder(r)).Decode(&body)
+		{{- else }}
 		err = decoder(r).Decode(&body)
+		{{- end }}
 		if err != nil {
 			if err == io.EOF {
 				return nil, goa.MissingPayloadError()
 			}
+			{{- if or .StrictDecoding .MaxRequestBody .RequestCompression }}
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			{{- end }}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
+		{{- if .Payload.Request.ServerBody.Encryption }}
+		if err = {{ .Payload.Request.ServerBody.Encryption.Name }}(r.Context(), &body); err != nil {
+			return nil, err
+		}
+		{{- end }}
 		{{- if .Payload.Request.ServerBody.ValidateRef }}
+			{{- if .TracePhases }}
+		_, endPhase := goahttp.StartPhase(r.Context(), goahttp.PhaseValidate)
+			{{- end }}
 		{{ .Payload.Request.ServerBody.ValidateRef }}
+			{{- if .TracePhases }}
+		endPhase(err)
+			{{- end }}
+			{{- if not (and .AggregateErrors .Payload.Request.MustValidate) }}
 		if err != nil {
-			return nil, err
+			return nil, {{ if .AggregateErrors }}goa.AggregateErrors(err){{ else }}err{{ end }}
 		}
+			{{- end }}
 		{{- end }}
 {{- end }}
 {{- if not .MultipartRequestDecoder }}
 	{{- template "request_params_headers" .Payload.Request }}
 	{{- if .Payload.Request.MustValidate }}
 		if err != nil {
-			return nil, err
+			return nil, {{ if .AggregateErrors }}goa.AggregateErrors(err){{ else }}err{{ end }}
 		}
 	{{- end }}
 	{{- if .Payload.Request.PayloadInit }}
@@ -580,6 +959,12 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 	}
 	{{- end }}
 {{- end }}
+{{- if .Payload.Request.DateTimeNormalization }}
+	{{ .Payload.Request.DateTimeNormalization }}
+{{- end }}
+{{- if .Payload.Request.DefaultFrom }}
+	{{ .Payload.Request.DefaultFrom }}
+{{- end }}
 
 	return payload, nil
 	}
@@ -612,11 +997,11 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 {{- range .PathParams }}
 	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		{{ .VarName }} = params["{{ .Name }}"]
+		{{ .VarName }} = params["{{ .MuxKey }}"]
 
 	{{- else }}{{/* not string and not any */}}
 		{
-			{{ .VarName }}Raw := params["{{ .Name }}"]
+			{{ .VarName }}Raw := params["{{ .MuxKey }}"]
 			{{- template "path_conversion" . }}
 		}
 
@@ -644,7 +1029,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- end }}
 
 	{{- else if .StringSlice }}
-		{{ .VarName }} = r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }} = {{ if .Delimiter }}goahttp.SplitValues(r.URL.Query()["{{ .Name }}"], {{ printf "%q" .Delimiter }}){{ else }}r.URL.Query()["{{ .Name }}"]{{ end }}
 		{{- if .Required }}
 		if {{ .VarName }} == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -657,7 +1042,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 	{{- else if .Slice }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }}Raw := {{ if .Delimiter }}goahttp.SplitValues(r.URL.Query()["{{ .Name }}"], {{ printf "%q" .Delimiter }}){{ else }}r.URL.Query()["{{ .Name }}"]{{ end }}
 		{{- if .Required }}
 		if {{ .VarName }}Raw == nil {
 			return goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -678,7 +1063,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- end }}
 	}
 
-	{{- else if .Map }}
+	{{- else if and .Map (not .MapObject) }}
 	{
 		{{ .VarName }}Raw := r.URL.Query()
 		{{- if .Required }}
@@ -722,11 +1107,21 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- else if not .Required }}
 		if len({{ .VarName }}Raw) != 0 {
 		{{- end }}
+		{{- if .MapObject }}
+		{{ .VarName }}Fields := make(map[{{ goTypeRef .Type.KeyType.Type }}]map[string][]string)
+		for nameRaw, valRaw := range {{ .VarName }}Raw {
+			if strings.HasPrefix(nameRaw, "{{ .Name }}[") {
+				{{- template "map_object_fields" . }}
+			}
+		}
+		{{- template "map_object_conversion" . }}
+		{{- else }}
 		for keyRaw, valRaw := range {{ .VarName }}Raw {
 			if strings.HasPrefix(keyRaw, "{{ .Name }}[") {
 				{{- template "map_conversion" (mapQueryDecodeData .Type .VarName 0) }}
 			}
 		}
+		{{- end }}
 		{{- if or .DefaultValue (not .Required) }}
 		}
 		{{- end }}
@@ -779,7 +1174,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- end }}
 
 	{{- else if .StringSlice }}
-		{{ .VarName }} = r.Header["{{ .CanonicalName }}"]
+		{{ .VarName }} = {{ if .Delimiter }}goahttp.SplitValues(r.Header["{{ .CanonicalName }}"], {{ printf "%q" .Delimiter }}){{ else }}r.Header["{{ .CanonicalName }}"]{{ end }}
 		{{- if .Required }}
 		if {{ .VarName }} == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
@@ -792,7 +1187,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 	{{- else if .Slice }}
 	{
-		{{ .VarName }}Raw := r.Header["{{ .CanonicalName }}"]
+		{{ .VarName }}Raw := {{ if .Delimiter }}goahttp.SplitValues(r.Header["{{ .CanonicalName }}"], {{ printf "%q" .Delimiter }}){{ else }}r.Header["{{ .CanonicalName }}"]{{ end }}
 		{{ if .Required }}if {{ .VarName }}Raw == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
 		}
@@ -894,6 +1289,71 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{ .VarName }}[key{{ .Loop }}] = val
 	{{- end }}
 {{- end }}
+
+{{- define "map_object_fields" }}
+	var (
+		key   {{ goTypeRef .Type.KeyType.Type }}
+		field string
+	)
+	{
+		openIdx := strings.IndexRune(nameRaw, '[')
+		closeIdx := strings.IndexRune(nameRaw, ']')
+	{{- if eq .Type.KeyType.Type.Name "string" }}
+		key = nameRaw[openIdx+1 : closeIdx]
+	{{- else }}
+		keyRaw := nameRaw[openIdx+1 : closeIdx]
+		{{- template "type_conversion" (conversionData "key" (printf "%q" "query") .Type.KeyType.Type) }}
+	{{- end }}
+		field = nameRaw[closeIdx+1:]
+	}
+	if len(field) < 3 || field[0] != '[' || field[len(field)-1] != ']' {
+		err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, nameRaw, "object"))
+		continue
+	}
+	if {{ .VarName }}Fields[key] == nil {
+		{{ .VarName }}Fields[key] = make(map[string][]string)
+	}
+	{{ .VarName }}Fields[key][field[1:len(field)-1]] = valRaw
+{{- end }}
+
+{{- define "map_object_conversion" }}
+	for key, fields := range {{ .VarName }}Fields {
+		if {{ .VarName }} == nil {
+			{{ .VarName }} = make({{ .TypeRef }})
+		}
+		val := &{{ .MapObject.TypeName }}{}
+	{{- range .MapObject.Fields }}
+		if vals, ok := fields[{{ printf "%q" .Name }}]; ok {
+		{{- if .StringSlice }}
+			val.{{ .FieldName }} = vals
+		{{- else if .Slice }}
+			var {{ .VarName }} {{ .TypeRef }}
+			{{ .VarName }}Raw := vals
+			{{- template "slice_conversion" (fieldConversionData .VarName .Type false .TimestampFormat) }}
+			val.{{ .FieldName }} = {{ .VarName }}
+		{{- else if eq .Type.Name "string" }}
+			val.{{ .FieldName }} = {{ if .Pointer }}&{{ end }}vals[0]
+		{{- else }}
+			var {{ .VarName }} {{ if .Pointer }}*{{ end }}{{ .TypeRef }}
+			{{ .VarName }}Raw := vals[0]
+			{{- template "type_conversion" (fieldConversionData .VarName .Type .Pointer .TimestampFormat) }}
+			val.{{ .FieldName }} = {{ .VarName }}
+		{{- end }}
+		}
+		{{- if .DefaultValue }} else {
+			val.{{ .FieldName }} = {{ printf "%#v" .DefaultValue }}
+		}
+		{{- else if and .Required (not .Slice) }} else {
+			err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, "query string"))
+		}
+		{{- end }}
+	{{- end }}
+	{{- with .MapObject.Validate }}
+		{{ . }}
+	{{- end }}
+		{{ .VarName }}[key] = val
+	}
+{{- end }}
 ` + typeConversionT
 
 const typeConversionT = `{{- define "slice_conversion" }}
@@ -985,6 +1445,30 @@ const typeConversionT = `{{- define "slice_conversion" }}
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "duration" }}
+		v, err2 := goa.ParseDuration({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "duration"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "uuid" }}
+		v, err2 := goa.ParseUUID({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "uuid"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "timestamp" }}
+		v, err2 := goa.ParseTimestamp({{ .VarName }}Raw, {{ printf "%q" .TimestampFormat }})
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "timestamp"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "decimal" }}
+		var v {{ goTypeRef .Type }}
+		if err2 := v.UnmarshalText([]byte({{ .VarName }}Raw)); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "decimal"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
@@ -1048,6 +1532,30 @@ const typeConversionT = `{{- define "slice_conversion" }}
 				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of booleans"))
 			}
 			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "duration" }}
+			v, err2 := goa.ParseDuration(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of durations"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "uuid" }}
+			v, err2 := goa.ParseUUID(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of uuids"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "timestamp" }}
+			v, err2 := goa.ParseTimestamp(rv, {{ printf "%q" .TimestampFormat }})
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of timestamps"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "decimal" }}
+			var v {{ goTypeRef .Type.ElemType.Type }}
+			if err2 := v.UnmarshalText([]byte(rv)); err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of decimals"))
+			}
+			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "any" }}
 			{{ .VarName }}[i] = rv
 		{{- else }}
@@ -1060,6 +1568,12 @@ const typeConversionT = `{{- define "slice_conversion" }}
 const responseEncoderT = `{{ printf "%s returns an encoder for responses returned by the %s %s endpoint." .ResponseEncoder .ServiceName .Method.Name | comment }}
 func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
 	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+	{{- if .PassThroughHeaders }}
+		goahttp.WritePassThroughHeaders(ctx, w)
+	{{- end }}
+	{{- range .FixedHeaders }}
+		w.Header().Set({{ printf "%q" .Name }}, {{ printf "%q" .Value }})
+	{{- end }}
 	{{- if .Result.MustInit }}
 		{{- if .Method.ViewedResult }}
 			res := v.({{ .Method.ViewedResult.FullRef }})
@@ -1069,6 +1583,13 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 		{{- else }}
 			res := v.({{ .Result.Ref }})
 		{{- end }}
+		{{- if and .Protobuf .Protobuf.ResponseInit }}
+			if goahttp.AcceptsProtobuf(ctx) {
+				w.Header().Set("Content-Type", goahttp.ProtobufContentType)
+				w.WriteHeader({{ (index .Result.Responses 0).StatusCode }})
+				return goahttp.NewProtobufEncoder(w).Encode({{ .Protobuf.ResponseInit }}(res{{ if .Method.ViewedResult }}.Projected{{ end }}))
+			}
+		{{- end }}
 		{{- range .Result.Responses }}
 			{{- if .ContentType }}
 				ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "{{ .ContentType }}")
@@ -1100,11 +1621,26 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 }
 ` + responseT
 
+// input: ServiceData
+const errorMessagesT = `{{ printf "errorMessages maps the names of the errors returned by the %s service endpoints to the messages written in the error responses when the error verbosity is goahttp.ErrorSanitized." .Service.Name | comment }}
+var errorMessages = map[string]string{
+{{- range .ErrorMessages }}
+	{{ printf "%q" .Name }}: {{ printf "%q" .Message }},
+{{- end }}
+}
+`
+
 // input: EndpointData
 const errorEncoderT = `{{ printf "%s returns an encoder for errors returned by the %s %s endpoint." .ErrorEncoder .Method.Name .ServiceName | comment }}
 func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
 	encodeError := goahttp.ErrorEncoder(encoder)
 	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+	{{- if .PassThroughHeaders }}
+		goahttp.WritePassThroughHeaders(ctx, w)
+	{{- end }}
+	{{- range .FixedHeaders }}
+		w.Header().Set({{ printf "%q" .Name }}, {{ printf "%q" .Value }})
+	{{- end }}
 		en, ok := v.(ErrorNamer)
 		if !ok {
 			return encodeError(ctx, w, v)
@@ -1113,6 +1649,14 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 	{{- range $gerr := .Errors }}
 	{{- range $err := .Errors }}
 		case {{ printf "%q" .Name }}:
+		{{- if .RetryAfter }}
+			goahttp.SetRetryAfter(w, v)
+		{{- end }}
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader({{ $gerr.StatusCode }})
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, {{ printf "%q" .Name }}, errorMessages[{{ printf "%q" .Name }}]))
+			}
 			res := v.({{ $err.Ref }})
 			{{- with .Response}}
 				{{- template "response" . }}
@@ -1142,11 +1686,24 @@ const responseT = `{{ define "response" -}}
 			{{- range $.ViewedResult.Views }}
 	case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
 		{{- $vsb := (viewedServerBody $.ServerBody .Name) }}
+		{{- if $vsb.Encryption }}
+		b := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		if err := {{ $vsb.Encryption.Name }}(ctx, b); err != nil {
+			return err
+		}
+		body = b
+		{{- else }}
 		body = {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
 			{{- end }}
 	}
 		{{- else if (index .ServerBody 0).Init }}
 	body := {{ (index .ServerBody 0).Init.Name }}({{ range (index .ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+			{{- with (index .ServerBody 0).Encryption }}
+	if err := {{ .Name }}(ctx, body); err != nil {
+		return err
+	}
+			{{- end }}
 		{{- else }}
 	body := res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .ResultAttr }}.{{ .ResultAttr }}{{ end }}
 		{{- end }}
@@ -1162,7 +1719,7 @@ const responseT = `{{ define "response" -}}
 	w.Header().Set("{{ .CanonicalName }}", {{ if or .FieldPointer $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }})
 		{{- else }}
 	val := res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}
-	{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) (not .FieldPointer) "val") }}
+	{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) (not .FieldPointer) "val" .TimestampFormat) }}
 	w.Header().Set("{{ .CanonicalName }}", {{ .VarName }}s)
 		{{- end }}
 
@@ -1180,6 +1737,21 @@ const responseT = `{{ define "response" -}}
 	{{- if .ErrorHeader }}
 	w.Header().Set("goa-error", {{ printf "%q" .ErrorHeader }})
 	{{- end }}
+	{{- if .CacheControl }}
+	w.Header().Set("Cache-Control", {{ printf "%q" .CacheControl }})
+	{{- end }}
+	{{- if .ExpiresIn }}
+	w.Header().Set("Expires", time.Now().Add({{ .ExpiresIn }} * time.Second).UTC().Format(http.TimeFormat))
+	{{- end }}
+	{{- if .Vary }}
+	w.Header().Set("Vary", {{ printf "%q" .Vary }})
+	{{- end }}
+	{{- if .ETag }}
+	if status := goahttp.CheckConditionalRequest(ctx, w.Header().Get("ETag")); status != 0 {
+		w.WriteHeader(status)
+		return nil
+	}
+	{{- end }}
 	w.WriteHeader({{ .StatusCode }})
 {{- end }}
 
@@ -1206,6 +1778,12 @@ const responseT = `{{ define "response" -}}
 		{{ .VarName }} := {{ .Target }}
 	{{- else if eq .Type.Name "bytes" -}}
 		{{ .VarName }} := string({{ .Target }})
+	{{- else if eq .Type.Name "duration" -}}
+		{{ .VarName }} := time.Duration({{ if not .Required }}*{{ end }}{{ .Target }}).String()
+	{{- else if or (eq .Type.Name "uuid") (eq .Type.Name "decimal") -}}
+		{{ .VarName }} := {{ .Target }}.String()
+	{{- else if eq .Type.Name "timestamp" -}}
+		{{ .VarName }} := goa.FormatTimestamp({{ if not .Required }}*{{ end }}{{ .Target }}, {{ printf "%q" .TimestampFormat }})
 	{{- else if eq .Type.Name "any" -}}
 		{{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
 	{{- else if eq .Type.Name "array" -}}
@@ -1214,7 +1792,7 @@ const responseT = `{{ define "response" -}}
 		{{- else -}}
 		{{ .VarName }}Slice := make([]string, len({{ .Target }}))
 		for i, e := range {{ .Target }}  {
-			{{ template "header_conversion" (headerConversionData .Type.ElemType.Type "es" true "e") }}
+			{{ template "header_conversion" (headerConversionData .Type.ElemType.Type "es" true "e" .TimestampFormat) }}
 			{{ .VarName }}Slice[i] = es	
 		}
 		{{ .VarName }} := strings.Join({{ .VarName }}Slice, ", ")
@@ -38,6 +38,9 @@ func TestDecode(t *testing.T) {
 		{"query-string-not-required-validate", testdata.PayloadQueryStringNotRequiredValidateDSL, testdata.PayloadQueryStringNotRequiredValidateDecodeCode},
 		{"query-bytes", testdata.PayloadQueryBytesDSL, testdata.PayloadQueryBytesDecodeCode},
 		{"query-bytes-validate", testdata.PayloadQueryBytesValidateDSL, testdata.PayloadQueryBytesValidateDecodeCode},
+		{"query-uuid", testdata.PayloadQueryUUIDDSL, testdata.PayloadQueryUUIDDecodeCode},
+		{"query-timestamp", testdata.PayloadQueryTimestampDSL, testdata.PayloadQueryTimestampDecodeCode},
+		{"query-decimal", testdata.PayloadQueryDecimalDSL, testdata.PayloadQueryDecimalDecodeCode},
 		{"query-any", testdata.PayloadQueryAnyDSL, testdata.PayloadQueryAnyDecodeCode},
 		{"query-any-validate", testdata.PayloadQueryAnyValidateDSL, testdata.PayloadQueryAnyValidateDecodeCode},
 		{"query-array-bool", testdata.PayloadQueryArrayBoolDSL, testdata.PayloadQueryArrayBoolDecodeCode},
@@ -62,6 +65,11 @@ func TestDecode(t *testing.T) {
 		{"query-array-string-validate", testdata.PayloadQueryArrayStringValidateDSL, testdata.PayloadQueryArrayStringValidateDecodeCode},
 		{"query-array-bytes", testdata.PayloadQueryArrayBytesDSL, testdata.PayloadQueryArrayBytesDecodeCode},
 		{"query-array-bytes-validate", testdata.PayloadQueryArrayBytesValidateDSL, testdata.PayloadQueryArrayBytesValidateDecodeCode},
+		{"query-array-uuid", testdata.PayloadQueryArrayUUIDDSL, testdata.PayloadQueryArrayUUIDDecodeCode},
+		{"query-array-timestamp-unix", testdata.PayloadQueryArrayTimestampUnixDSL, testdata.PayloadQueryArrayTimestampUnixDecodeCode},
+		{"query-header-styles", testdata.PayloadQueryHeaderStylesDSL, testdata.PayloadQueryHeaderStylesDecodeCode},
+		{"query-separator", testdata.PayloadQuerySeparatorDSL, testdata.PayloadQuerySeparatorDecodeCode},
+		{"renamed", testdata.PayloadRenamedDSL, testdata.PayloadRenamedDecodeCode},
 		{"query-array-any", testdata.PayloadQueryArrayAnyDSL, testdata.PayloadQueryArrayAnyDecodeCode},
 		{"query-array-any-validate", testdata.PayloadQueryArrayAnyValidateDSL, testdata.PayloadQueryArrayAnyValidateDecodeCode},
 		{"query-map-string-string", testdata.PayloadQueryMapStringStringDSL, testdata.PayloadQueryMapStringStringDecodeCode},
@@ -109,6 +117,7 @@ func TestDecode(t *testing.T) {
 		{"path-primitive-array-bool-validate", testdata.PayloadPathPrimitiveArrayBoolValidateDSL, testdata.PayloadPathPrimitiveArrayBoolValidateDecodeCode},
 
 		{"header-string", testdata.PayloadHeaderStringDSL, testdata.PayloadHeaderStringDecodeCode},
+		{"header-idempotency-key", testdata.PayloadHeaderIdempotencyKeyDSL, testdata.PayloadHeaderIdempotencyKeyDecodeCode},
 		{"header-string-validate", testdata.PayloadHeaderStringValidateDSL, testdata.PayloadHeaderStringValidateDecodeCode},
 		{"header-array-string", testdata.PayloadHeaderArrayStringDSL, testdata.PayloadHeaderArrayStringDecodeCode},
 		{"header-array-string-validate", testdata.PayloadHeaderArrayStringValidateDSL, testdata.PayloadHeaderArrayStringValidateDecodeCode},
@@ -126,8 +135,17 @@ func TestDecode(t *testing.T) {
 		{"body-string-validate", testdata.PayloadBodyStringValidateDSL, testdata.PayloadBodyStringValidateDecodeCode},
 		{"body-user", testdata.PayloadBodyUserDSL, testdata.PayloadBodyUserDecodeCode},
 		{"body-user-required", testdata.PayloadBodyUserRequiredDSL, testdata.PayloadBodyUserRequiredDecodeCode},
+		{"body-user-strict", testdata.PayloadBodyUserStrictDSL, testdata.PayloadBodyUserStrictDecodeCode},
+		{"body-request-compression", testdata.ServerRequestCompressionDSL, testdata.PayloadBodyRequestCompressionDecodeCode},
+		{"body-trace-phases", testdata.ServerTracePhasesDSL, testdata.PayloadBodyTracePhasesDecodeCode},
+		{"body-user-max-request-body", testdata.PayloadBodyUserMaxRequestBodyDSL, testdata.PayloadBodyUserMaxRequestBodyDecodeCode},
+		{"body-protobuf", testdata.PayloadBodyProtobufDSL, testdata.PayloadBodyProtobufDecodeCode},
 		{"body-user-nested", testdata.PayloadBodyNestedUserDSL, testdata.PayloadBodyNestedUserDecodeCode},
 		{"body-user-validate", testdata.PayloadBodyUserValidateDSL, testdata.PayloadBodyUserValidateDecodeCode},
+		{"body-user-aggregate", testdata.PayloadBodyUserAggregateDSL, testdata.PayloadBodyUserAggregateDecodeCode},
+		{"body-encrypted", testdata.PayloadBodyEncryptedDSL, testdata.PayloadBodyEncryptedDecodeCode},
+		{"body-time-zone", testdata.PayloadBodyTimeZoneDSL, testdata.PayloadBodyTimeZoneDecodeCode},
+		{"body-default-from", testdata.PayloadBodyDefaultFromDSL, testdata.PayloadBodyDefaultFromDecodeCode},
 		{"body-array-string", testdata.PayloadBodyArrayStringDSL, testdata.PayloadBodyArrayStringDecodeCode},
 		{"body-array-string-validate", testdata.PayloadBodyArrayStringValidateDSL, testdata.PayloadBodyArrayStringValidateDecodeCode},
 		{"body-array-user", testdata.PayloadBodyArrayUserDSL, testdata.PayloadBodyArrayUserDecodeCode},
@@ -160,17 +178,21 @@ func TestDecode(t *testing.T) {
 
 		{"body-query-path-object", testdata.PayloadBodyQueryPathObjectDSL, testdata.PayloadBodyQueryPathObjectDecodeCode},
 		{"body-query-path-object-validate", testdata.PayloadBodyQueryPathObjectValidateDSL, testdata.PayloadBodyQueryPathObjectValidateDecodeCode},
+		{"body-query-path-object-aggregate", testdata.PayloadBodyQueryPathObjectAggregateDSL, testdata.PayloadBodyQueryPathObjectAggregateDecodeCode},
 		{"body-query-path-user", testdata.PayloadBodyQueryPathUserDSL, testdata.PayloadBodyQueryPathUserDecodeCode},
 		{"body-query-path-user-validate", testdata.PayloadBodyQueryPathUserValidateDSL, testdata.PayloadBodyQueryPathUserValidateDecodeCode},
 
 		{"map-query-primitive-primitive", testdata.PayloadMapQueryPrimitivePrimitiveDSL, testdata.PayloadMapQueryPrimitivePrimitiveDecodeCode},
 		{"map-query-primitive-array", testdata.PayloadMapQueryPrimitiveArrayDSL, testdata.PayloadMapQueryPrimitiveArrayDecodeCode},
 		{"map-query-object", testdata.PayloadMapQueryObjectDSL, testdata.PayloadMapQueryObjectDecodeCode},
+		{"map-query-object-values", testdata.PayloadMapQueryObjectValuesDSL, testdata.PayloadMapQueryObjectValuesDecodeCode},
+		{"map-query-object-values-map", testdata.PayloadMapQueryObjectValuesMapDSL, testdata.PayloadMapQueryObjectValuesMapDecodeCode},
 		{"multipart-body-primitive", testdata.PayloadMultipartPrimitiveDSL, testdata.PayloadMultipartPrimitiveDecodeCode},
 		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.PayloadMultipartUserTypeDecodeCode},
 		{"multipart-body-array-type", testdata.PayloadMultipartArrayTypeDSL, testdata.PayloadMultipartArrayTypeDecodeCode},
 		{"multipart-body-map-type", testdata.PayloadMultipartMapTypeDSL, testdata.PayloadMultipartMapTypeDecodeCode},
 		{"with-params-and-headers-dsl", testdata.WithParamsAndHeadersBlockDSL, testdata.WithParamsAndHeadersBlockDecodeCode},
+		{"body-request-content", testdata.PayloadBodyRequestContentDSL, testdata.PayloadBodyRequestContentDecodeCode},
 	}
 	golden := makeGolden(t, "testdata/payload_decode_functions.go")
 	if golden != nil {
@@ -15,6 +15,12 @@ func TestEncode(t *testing.T) {
 		Code string
 	}{
 		{"header-bool", testdata.ResultHeaderBoolDSL, testdata.ResultHeaderBoolEncodeCode},
+		{"etag", testdata.ResultETagDSL, testdata.ResultETagEncodeCode},
+		{"caching", testdata.ResultCachingDSL, testdata.ResultCachingEncodeCode},
+		{"fixed-headers", testdata.ResultFixedHeadersDSL, testdata.ResultFixedHeadersEncodeCode},
+		{"pass-through-headers", testdata.ResultPassThroughHeadersDSL, testdata.ResultPassThroughHeadersEncodeCode},
+		{"protobuf", testdata.ResultProtobufDSL, testdata.ResultProtobufEncodeCode},
+		{"encrypted", testdata.PayloadBodyEncryptedDSL, testdata.ResultEncryptedEncodeCode},
 		{"header-int", testdata.ResultHeaderIntDSL, testdata.ResultHeaderIntEncodeCode},
 		{"header-int32", testdata.ResultHeaderInt32DSL, testdata.ResultHeaderInt32EncodeCode},
 		{"header-int64", testdata.ResultHeaderInt64DSL, testdata.ResultHeaderInt64EncodeCode},
@@ -16,7 +16,10 @@ func TestEncodeError(t *testing.T) {
 	}{
 		{"primitive-error-response", testdata.PrimitiveErrorResponseDSL, testdata.PrimitiveErrorResponseEncoderCode},
 		{"default-error-response", testdata.DefaultErrorResponseDSL, testdata.DefaultErrorResponseEncoderCode},
+		{"retry-after-error-response", testdata.RetryAfterErrorResponseDSL, testdata.RetryAfterErrorResponseEncoderCode},
 		{"service-error-response", testdata.ServiceErrorResponseDSL, testdata.ServiceErrorResponseEncoderCode},
+		{"fixed-headers-error-response", testdata.FixedHeadersErrorResponseDSL, testdata.FixedHeadersErrorResponseEncoderCode},
+		{"pass-through-headers-error-response", testdata.ResultPassThroughHeadersDSL, testdata.PassThroughHeadersErrorResponseEncoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -36,3 +39,31 @@ func TestEncodeError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorMessages(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"described-error-response", testdata.DescribedErrorResponseDSL, testdata.DescribedErrorResponseMessagesCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			var code string
+			for _, s := range fs[1].SectionTemplates {
+				if s.Name == "error-messages" {
+					code = codegen.SectionCode(t, s)
+				}
+			}
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
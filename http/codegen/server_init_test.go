@@ -22,6 +22,8 @@ func TestServerInit(t *testing.T) {
 		{"mixed", testdata.ServerMixedDSL, testdata.ServerMixedConstructorCode, 3},
 		{"multipart", testdata.ServerMultipartDSL, testdata.ServerMultipartConstructorCode, 4},
 		{"streaming", testdata.StreamingResultDSL, testdata.ServerStreamingConstructorCode, 5},
+		{"encoder options", testdata.ServerEncoderOptionsDSL, testdata.ServerEncoderOptionsConstructorCode, 3},
+		{"canary", testdata.ServerCanaryDSL, testdata.ServerCanaryConstructorCode, 3},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -41,3 +43,207 @@ func TestServerInit(t *testing.T) {
 		})
 	}
 }
+
+func TestServerStructCanary(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerCanaryDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var code string
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name == "server-struct" {
+			code = codegen.SectionCode(t, s)
+		}
+	}
+	if code != testdata.ServerCanaryStructCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerCanaryStructCode))
+	}
+}
+
+func TestServerUseIdempotency(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerIdempotentDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[0].SectionTemplates
+	if len(sections) < 7 {
+		t.Fatalf("got %d sections, expected at least 7", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[6])
+	if code != testdata.ServerUseIdempotencyCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerUseIdempotencyCode))
+	}
+}
+
+func TestServerUseCompression(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerCompressionDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var code string
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name == "server-use-compression" {
+			code = codegen.SectionCode(t, s)
+		}
+	}
+	if code != testdata.ServerUseCompressionCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerUseCompressionCode))
+	}
+}
+
+func TestServerUseResponseHook(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerResponseHookDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var code string
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name == "server-use-response-hook" {
+			code = codegen.SectionCode(t, s)
+		}
+	}
+	if code != testdata.ServerUseResponseHookCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerUseResponseHookCode))
+	}
+}
+
+func TestServerMountVersioned(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"versioned", testdata.ServerVersionedDSL, testdata.ServerMountVersionedCode},
+		{"trailing-slash", testdata.ServerVersionedTrailingSlashDSL, testdata.ServerMountVersionedTrailingSlashCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("gen", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			var code string
+			for _, s := range fs[0].SectionTemplates {
+				if s.Name == "server-mount" {
+					code = codegen.SectionCode(t, s)
+				}
+			}
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerSignedURL(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerSignedURLDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var code string
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name == "server-init" || s.Name == "server-use-signed-urls" {
+			code += codegen.SectionCode(t, s)
+		}
+	}
+	for _, s := range serverPath(expr.Root.API.HTTP.Services[0]).SectionTemplates {
+		if s.Name == "signed-path" {
+			code += codegen.SectionCode(t, s)
+		}
+	}
+	if code != testdata.ServerSignedURLCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerSignedURLCode))
+	}
+}
+
+func TestServerTrailingSlash(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"strict", testdata.ServerTrailingSlashStrictDSL, testdata.ServerTrailingSlashStrictCode},
+		{"redirect", testdata.ServerTrailingSlashRedirectDSL, testdata.ServerTrailingSlashRedirectCode},
+		{"rewrite", testdata.ServerTrailingSlashRewriteDSL, testdata.ServerTrailingSlashRewriteCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("gen", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			var code string
+			for _, s := range fs[0].SectionTemplates {
+				if s.Name == "server-handler" {
+					code += codegen.SectionCode(t, s)
+				}
+			}
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerRenamed(t *testing.T) {
+	RunHTTPDSL(t, testdata.PayloadRenamedDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var code string
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name == "server-handler" {
+			code += codegen.SectionCode(t, s)
+		}
+	}
+	if code != testdata.ServerRenamedCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerRenamedCode))
+	}
+}
+
+func TestServerRouter(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"chi", testdata.ServerRouterChiDSL, testdata.ServerRouterChiCode},
+		{"gorilla", testdata.ServerRouterGorillaDSL, testdata.ServerRouterGorillaCode},
+		{"httprouter", testdata.ServerRouterHTTPRouterDSL, testdata.ServerRouterHTTPRouterCode},
+		{"servemux", testdata.ServerRouterServeMuxDSL, testdata.ServerRouterServeMuxCode},
+		{"chi-constraints", testdata.ServerRouterChiConstraintsDSL, testdata.ServerRouterChiConstraintsCode},
+		{"gorilla-constraints", testdata.ServerRouterGorillaConstraintsDSL, testdata.ServerRouterGorillaConstraintsCode},
+		{"constraints", testdata.ServerRouterConstraintsDSL, testdata.ServerRouterConstraintsCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("gen", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			var code string
+			for _, s := range fs[0].SectionTemplates {
+				if s.Name == "server-handler" {
+					code += codegen.SectionCode(t, s)
+				}
+			}
+			for _, s := range fs[1].SectionTemplates {
+				if s.Name == "request-decoder" {
+					code += codegen.SectionCode(t, s)
+				}
+			}
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -51,9 +51,13 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 	path = filepath.Join(codegen.Gendir, "http", svcName, "server", "types.go")
 	header := codegen.Header(svc.Name()+" HTTP server types", "server",
 		[]*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/xml"},
+			{Path: "time"},
 			{Path: "unicode/utf8"},
 			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
 			codegen.GoaImport(""),
+			codegen.GoaNamedImport("http", "goahttp"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 		},
 	)
@@ -61,6 +65,7 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 	var (
 		initData       []*InitData
 		validatedTypes []*TypeData
+		encryptedTypes []*TypeData
 
 		sections = []*codegen.SectionTemplate{header}
 	)
@@ -79,6 +84,26 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 			if data.ValidateDef != "" {
 				validatedTypes = append(validatedTypes, data)
 			}
+			if data.Encryption != nil {
+				encryptedTypes = append(encryptedTypes, data)
+			}
+		}
+		for _, c := range adata.RequestContents {
+			if data := c.Payload.Request.ServerBody; data != nil {
+				if data.Def != "" {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "request-body-type-decl",
+						Source: typeDeclT,
+						Data:   data,
+					})
+				}
+				if data.ValidateDef != "" {
+					validatedTypes = append(validatedTypes, data)
+				}
+				if data.Encryption != nil {
+					encryptedTypes = append(encryptedTypes, data)
+				}
+			}
 		}
 		if adata.ServerStream != nil {
 			if data := adata.ServerStream.Payload; data != nil {
@@ -115,6 +140,9 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 					if tdata.ValidateDef != "" {
 						validatedTypes = append(validatedTypes, tdata)
 					}
+					if tdata.Encryption != nil {
+						encryptedTypes = append(encryptedTypes, tdata)
+					}
 					data.ServerTypeNames[tdata.Name] = true
 				}
 			}
@@ -140,6 +168,9 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 					if data.ValidateDef != "" {
 						validatedTypes = append(validatedTypes, data)
 					}
+					if data.Encryption != nil {
+						encryptedTypes = append(encryptedTypes, data)
+					}
 				}
 			}
 		}
@@ -178,6 +209,15 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				Data:   init,
 			})
 		}
+		for _, c := range adata.RequestContents {
+			if init := c.Payload.Request.PayloadInit; init != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "server-payload-init",
+					Source: serverTypeInitT,
+					Data:   init,
+				})
+			}
+		}
 		if adata.ServerStream != nil && adata.ServerStream.Payload != nil {
 			if init := adata.ServerStream.Payload.Init; init != nil {
 				sections = append(sections, &codegen.SectionTemplate{
@@ -198,6 +238,15 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 		})
 	}
 
+	// encryption functions
+	for _, data := range encryptedTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-encryption",
+			Source: encryptionT,
+			Data:   data,
+		})
+	}
+
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -253,3 +302,11 @@ func Validate{{ .VarName }}(body {{ .Ref }}) (err error) {
 	return
 }
 `
+
+// input: TypeData
+const encryptionT = `{{ comment .Encryption.Description }}
+func {{ .Encryption.Name }}(ctx context.Context, body *{{ .VarName }}) (err error) {
+	{{ .Encryption.Code }}
+	return
+}
+`
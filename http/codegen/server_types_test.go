@@ -19,6 +19,9 @@ func TestServerTypes(t *testing.T) {
 		{"mixed-payload-attrs", testdata.MixedPayloadInBodyDSL, MixedPayloadInBodyServerTypesFile},
 		{"multiple-methods", testdata.MultipleMethodsDSL, MultipleMethodsServerTypesFile},
 		{"payload-extend-validate", testdata.PayloadExtendedValidateDSL, PayloadExtendedValidateServerTypesFile},
+		{"body-encrypted", testdata.PayloadBodyEncryptedDSL, BodyEncryptedServerTypesFile},
+		{"body-xml", testdata.PayloadBodyXMLDSL, BodyXMLServerTypesFile},
+		{"body-optional-fields", testdata.PayloadBodyOptionalFieldsDSL, BodyOptionalFieldsServerTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -217,3 +220,186 @@ func ValidateMethodQueryStringExtendedValidatePayloadRequestBody(body *MethodQue
 	return
 }
 `
+
+const BodyEncryptedServerTypesFile = `// MethodBodyEncryptedRequestBody is the type of the "ServiceBodyEncrypted"
+// service "MethodBodyEncrypted" endpoint HTTP request body.
+type MethodBodyEncryptedRequestBody struct {
+	Name *string ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+	Ssn  *string ` + "`" + `form:"ssn,omitempty" json:"ssn,omitempty" xml:"ssn,omitempty"` + "`" + `
+	Key  []byte  ` + "`" + `form:"key,omitempty" json:"key,omitempty" xml:"key,omitempty"` + "`" + `
+	Pin  *string ` + "`" + `form:"pin,omitempty" json:"pin,omitempty" xml:"pin,omitempty"` + "`" + `
+}
+
+// MethodBodyEncryptedResponseBody is the type of the "ServiceBodyEncrypted"
+// service "MethodBodyEncrypted" endpoint HTTP response body.
+type MethodBodyEncryptedResponseBody struct {
+	Name string  ` + "`" + `form:"name" json:"name" xml:"name"` + "`" + `
+	Ssn  *string ` + "`" + `form:"ssn,omitempty" json:"ssn,omitempty" xml:"ssn,omitempty"` + "`" + `
+	Key  []byte  ` + "`" + `form:"key,omitempty" json:"key,omitempty" xml:"key,omitempty"` + "`" + `
+	Pin  string  ` + "`" + `form:"pin" json:"pin" xml:"pin"` + "`" + `
+}
+
+// NewMethodBodyEncryptedResponseBody builds the HTTP response body from the
+// result of the "MethodBodyEncrypted" endpoint of the "ServiceBodyEncrypted"
+// service.
+func NewMethodBodyEncryptedResponseBody(res *servicebodyencrypted.Account) *MethodBodyEncryptedResponseBody {
+	body := &MethodBodyEncryptedResponseBody{
+		Name: res.Name,
+		Ssn:  res.Ssn,
+		Key:  res.Key,
+		Pin:  res.Pin,
+	}
+	return body
+}
+
+// NewMethodBodyEncryptedAccount builds a ServiceBodyEncrypted service
+// MethodBodyEncrypted endpoint payload.
+func NewMethodBodyEncryptedAccount(body *MethodBodyEncryptedRequestBody) *servicebodyencrypted.Account {
+	v := &servicebodyencrypted.Account{
+		Name: *body.Name,
+		Ssn:  body.Ssn,
+		Key:  body.Key,
+		Pin:  *body.Pin,
+	}
+	return v
+}
+
+// ValidateMethodBodyEncryptedRequestBody runs the validations defined on
+// MethodBodyEncryptedRequestBody
+func ValidateMethodBodyEncryptedRequestBody(body *MethodBodyEncryptedRequestBody) (err error) {
+	if body.Name == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("name", "body"))
+	}
+	if body.Pin == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("pin", "body"))
+	}
+	return
+}
+
+// DecryptMethodBodyEncryptedRequestBody decrypts the fields of
+// MethodBodyEncryptedRequestBody marked as encrypted in the design using the
+// KMS stored in ctx.
+func DecryptMethodBodyEncryptedRequestBody(ctx context.Context, body *MethodBodyEncryptedRequestBody) (err error) {
+	if body.Ssn != nil {
+		var v string
+		if v, err = goahttp.DecryptString(ctx, *body.Ssn); err != nil {
+			return
+		}
+		body.Ssn = &v
+	}
+	if body.Key != nil {
+		if body.Key, err = goahttp.DecryptBytes(ctx, body.Key); err != nil {
+			return
+		}
+	}
+	if body.Pin != nil {
+		var v string
+		if v, err = goahttp.DecryptString(ctx, *body.Pin); err != nil {
+			return
+		}
+		body.Pin = &v
+	}
+	return
+}
+
+// EncryptMethodBodyEncryptedResponseBody encrypts the fields of
+// MethodBodyEncryptedResponseBody marked as encrypted in the design using the
+// KMS stored in ctx.
+func EncryptMethodBodyEncryptedResponseBody(ctx context.Context, body *MethodBodyEncryptedResponseBody) (err error) {
+	if body.Ssn != nil {
+		var v string
+		if v, err = goahttp.EncryptString(ctx, *body.Ssn); err != nil {
+			return
+		}
+		body.Ssn = &v
+	}
+	if body.Key != nil {
+		if body.Key, err = goahttp.EncryptBytes(ctx, body.Key); err != nil {
+			return
+		}
+	}
+	if body.Pin, err = goahttp.EncryptString(ctx, body.Pin); err != nil {
+		return
+	}
+	return
+}
+`
+const BodyXMLServerTypesFile = `// MethodBodyXMLRequestBody is the type of the "ServiceBodyXML" service
+// "MethodBodyXML" endpoint HTTP request body.
+type MethodBodyXMLRequestBody struct {
+	XMLName xml.Name ` + "`" + `form:"-" json:"-" xml:"http://example.com/book book"` + "`" + `
+	ID      *int     ` + "`" + `form:"id,omitempty" json:"id,omitempty" xml:"id,attr,omitempty"` + "`" + `
+	Title   *string  ` + "`" + `form:"title,omitempty" json:"title,omitempty" xml:"Title,omitempty"` + "`" + `
+	Tags    []string ` + "`" + `form:"tags,omitempty" json:"tags,omitempty" xml:"tags>tag,omitempty"` + "`" + `
+	Authors []string ` + "`" + `form:"authors,omitempty" json:"authors,omitempty" xml:"author,omitempty"` + "`" + `
+}
+
+// NewMethodBodyXMLBook builds a ServiceBodyXML service MethodBodyXML endpoint
+// payload.
+func NewMethodBodyXMLBook(body *MethodBodyXMLRequestBody) *servicebodyxml.Book {
+	v := &servicebodyxml.Book{
+		ID:    *body.ID,
+		Title: body.Title,
+	}
+	if body.Tags != nil {
+		v.Tags = make([]string, len(body.Tags))
+		for i, val := range body.Tags {
+			v.Tags[i] = val
+		}
+	}
+	if body.Authors != nil {
+		v.Authors = make([]string, len(body.Authors))
+		for i, val := range body.Authors {
+			v.Authors[i] = val
+		}
+	}
+	return v
+}
+
+// ValidateMethodBodyXMLRequestBody runs the validations defined on
+// MethodBodyXMLRequestBody
+func ValidateMethodBodyXMLRequestBody(body *MethodBodyXMLRequestBody) (err error) {
+	if body.ID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("id", "body"))
+	}
+	return
+}
+`
+
+const BodyOptionalFieldsServerTypesFile = `// MethodBodyOptionalFieldsRequestBody is the type of the
+// "ServiceBodyOptionalFields" service "MethodBodyOptionalFields" endpoint HTTP
+// request body.
+type MethodBodyOptionalFieldsRequestBody struct {
+	Name   goa.OptionalString ` + "`" + `form:"name,omitempty" json:"name,omitzero" xml:"name,omitempty"` + "`" + `
+	Age    goa.OptionalUInt   ` + "`" + `form:"age,omitempty" json:"age,omitzero" xml:"age,omitempty"` + "`" + `
+	Active *bool              ` + "`" + `form:"active,omitempty" json:"active,omitempty" xml:"active,omitempty"` + "`" + `
+}
+
+// NewMethodBodyOptionalFieldsPayload builds a ServiceBodyOptionalFields
+// service MethodBodyOptionalFields endpoint payload.
+func NewMethodBodyOptionalFieldsPayload(body *MethodBodyOptionalFieldsRequestBody, id int) *servicebodyoptionalfields.MethodBodyOptionalFieldsPayload {
+	v := &servicebodyoptionalfields.MethodBodyOptionalFieldsPayload{
+		Name: body.Name,
+		Age:  body.Age,
+	}
+	if body.Active != nil {
+		v.Active = *body.Active
+	}
+	if body.Active == nil {
+		v.Active = true
+	}
+	v.ID = id
+	return v
+}
+
+// ValidateMethodBodyOptionalFieldsRequestBody runs the validations defined on
+// MethodBodyOptionalFieldsRequestBody
+func ValidateMethodBodyOptionalFieldsRequestBody(body *MethodBodyOptionalFieldsRequestBody) (err error) {
+	if body.Name.Value != nil {
+		if utf8.RuneCountInString(*body.Name.Value) < 2 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.name", *body.Name.Value, utf8.RuneCountInString(*body.Name.Value), 2, true))
+		}
+	}
+	return
+}
+`
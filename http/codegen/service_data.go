@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
 )
 
 // HTTPServices holds the data computed from the design needed to generate the
@@ -38,6 +40,21 @@ type (
 		Endpoints []*EndpointData
 		// FileServers lists the file servers for this service.
 		FileServers []*FileServerData
+		// VersionedRoutes lists the routes shared by endpoints that
+		// implement different API versions.
+		VersionedRoutes []*VersionedRouteData
+		// Webhooks describes the webhooks emitted by this service.
+		Webhooks []*WebhookData
+		// ErrorMessages lists the generic messages written in the
+		// error responses when the error verbosity is sanitized indexed
+		// by error name.
+		ErrorMessages []*ErrorMessageData
+		// Environments lists the environments served by the hosts of
+		// the servers that expose the service.
+		Environments []*EnvironmentData
+		// EncoderOptions lists the hardening options applied to the
+		// server responses, nil if the design does not define any.
+		EncoderOptions *EncoderOptionsData
 		// ServerStruct is the name of the HTTP server struct.
 		ServerStruct string
 		// MountPointStruct is the name of the mount point struct.
@@ -95,6 +112,9 @@ type (
 		ServicePkgName string
 		// Payload describes the method HTTP payload.
 		Payload *PayloadData
+		// RequestContents describes the request bodies used with specific
+		// request content types if any.
+		RequestContents []*RequestContentData
 		// Result describes the method HTTP result.
 		Result *ResultData
 		// Errors describes the method HTTP errors.
@@ -133,6 +153,72 @@ type (
 		// ServerStream holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerStream *StreamData
+		// Idempotent is true if the method is marked as idempotent in the
+		// design.
+		Idempotent bool
+		// Compression lists the content codings used to compress the
+		// responses in order of preference, nil if the responses are
+		// not compressed.
+		Compression []string
+		// Canary describes the requests served by the canary
+		// implementation of the endpoint, nil if the endpoint does not
+		// have a canary.
+		Canary *expr.HTTPCanaryExpr
+		// SignedURL is true if the requests made to the endpoint must
+		// use a signed URL.
+		SignedURL bool
+		// TracePhases is true if the handler notifies the phase observer
+		// of the request phases.
+		TracePhases bool
+		// StrictDecoding is true if the request decoder rejects the body
+		// fields that are not defined in the design.
+		StrictDecoding bool
+		// AggregateErrors is true if the request decoder collects all the
+		// validation errors and returns them as a list of field errors.
+		AggregateErrors bool
+		// MergePatch is true if the payload is a JSON merge patch created
+		// with PatchOf, in which case clients send the request body with the
+		// "application/merge-patch+json" content type.
+		MergePatch bool
+		// ViewParam is the name of the query string parameter used by
+		// clients to select the result view, empty if clients can't
+		// select the view.
+		ViewParam string
+		// ETag is true if the method result defines an entity tag. The
+		// generated code handles conditional requests in this case.
+		ETag bool
+		// Sunset is the value of the Sunset response header formatted as
+		// a HTTP date, empty if the method is not deprecated.
+		Sunset string
+		// Renames lists the previous names of the request elements
+		// accepted by the request decoder, nil if none.
+		Renames *RenamesData
+		// MaxRequestBody is the maximum size in bytes of the request
+		// body read by the request decoder, zero if not limited.
+		MaxRequestBody int64
+		// RequestCompression lists the content codings of the
+		// compressed request bodies decompressed by the request
+		// decoder, nil if the decoder does not accept compressed
+		// bodies.
+		RequestCompression []string
+		// ClientCompression is the content coding used by the client to
+		// compress large request bodies, empty if the client does not
+		// compress request bodies.
+		ClientCompression string
+		// PassThroughHeaders lists the names of the headers captured
+		// from the responses by the client response decoder and written
+		// to the responses by the server response and error encoders.
+		PassThroughHeaders []string
+		// FixedHeaders lists the headers written with a constant value
+		// by all the endpoint responses.
+		FixedHeaders []*expr.HTTPFixedHeaderExpr
+		// Protobuf describes the gRPC messages used to decode the requests
+		// and encode the responses with the "application/x-protobuf"
+		// content type, nil if the endpoint does not support it.
+		Protobuf *ProtobufData
+		// Versioned is true if the endpoint is mounted through the
+		// handlers that dispatch requests based on the API version.
+		Versioned bool
 
 		// client
 
@@ -155,6 +241,19 @@ type (
 		ClientStream *StreamData
 	}
 
+	// RequestContentData describes the request body used with a specific
+	// request content type.
+	RequestContentData struct {
+		// ContentType is the request media type.
+		ContentType string
+		// RequestDecoder is the name of the function that decodes the
+		// requests that use the content type.
+		RequestDecoder string
+		// Payload describes the method HTTP payload built from the
+		// request body.
+		Payload *PayloadData
+	}
+
 	// FileServerData lists the data needed to generate file servers.
 	FileServerData struct {
 		// MountHandler is the name of the mount handler function.
@@ -171,6 +270,44 @@ type (
 		PathParam string
 	}
 
+	// VersionedRouteData describes a route shared by endpoints that implement
+	// different API versions.
+	VersionedRouteData struct {
+		// Verb is the HTTP method.
+		Verb string
+		// Path is the fullpath including wildcards.
+		Path string
+		// Header is the name of the request header that selects the
+		// version, empty if the route is not versioned in which case
+		// Handlers contains a single item.
+		Header string
+		// Default is the version served to requests that do not specify
+		// one.
+		Default string
+		// Handlers lists the handlers of each version.
+		Handlers []*VersionedHandlerData
+		// SlashPath is Path followed by a trailing slash if the path is
+		// registered according to TrailingSlash, empty otherwise.
+		SlashPath string
+		// TrailingSlash is the trailing slash policy of the route:
+		// "strict", "redirect" or "rewrite".
+		TrailingSlash string
+		// Constraints maps the names of the path parameters to the
+		// regular expressions registered with the router, nil if none.
+		Constraints map[string]string
+	}
+
+	// VersionedHandlerData describes the handler of a versioned route for a
+	// given API version.
+	VersionedHandlerData struct {
+		// Version is the API version, empty for the endpoint that does
+		// not set one.
+		Version string
+		// VarName is the name of the server struct field holding the
+		// endpoint handler.
+		VarName string
+	}
+
 	// PayloadData contains the payload information required to generate the
 	// transport decode (server) and encode (client) code.
 	PayloadData struct {
@@ -229,6 +366,47 @@ type (
 		Ref string
 		// Response is the error response data.
 		Response *ResponseData
+		// RetryAfter is true if the error defines a retry-after delay
+		// that the server writes in the Retry-After header and the
+		// client reads from it.
+		RetryAfter bool
+	}
+
+	// ErrorMessageData contains the generic message written in the response
+	// of a sanitized error.
+	ErrorMessageData struct {
+		// Name is the error name.
+		Name string
+		// Message is the error description if any, the text of the
+		// error response status code otherwise.
+		Message string
+	}
+
+	// EnvironmentData describes the HTTP server of a service in a
+	// deployment environment.
+	EnvironmentData struct {
+		// Name is the environment name.
+		Name string
+		// Scheme is the scheme of the server URL, "http" or "https".
+		Scheme string
+		// Host is the host of the server URL including the port if
+		// any.
+		Host string
+	}
+
+	// EncoderOptionsData describes the hardening options applied to the
+	// server responses as defined with the DisableHTMLEscaping, NoSniff and
+	// JSONPrefix DSL.
+	EncoderOptionsData struct {
+		// DisableHTMLEscaping disables the escaping of the HTML
+		// characters in the JSON responses.
+		DisableHTMLEscaping bool
+		// NoSniff sets the X-Content-Type-Options response header to
+		// "nosniff".
+		NoSniff bool
+		// JSONPrefix is written before the JSON response bodies, empty
+		// if not set.
+		JSONPrefix string
 	}
 
 	// RequestData describes a request.
@@ -259,6 +437,14 @@ type (
 		// Multipart if true indicates the request is a multipart
 		// request.
 		Multipart bool
+		// DateTimeNormalization is the code that converts the payload
+		// date-time values that define a time zone to UTC once the
+		// request is validated.
+		DateTimeNormalization string
+		// DefaultFrom is the code that initializes the payload fields
+		// whose attributes use DefaultFrom and that are not set in the
+		// request.
+		DefaultFrom string
 	}
 
 	// ResponseData describes a response.
@@ -314,6 +500,18 @@ type (
 		// ViewedResult indicates whether the response body type is a
 		// result type.
 		ViewedResult *service.ViewedResultTypeData
+		// ETag is true if the response sets the ETag header from the
+		// result entity tag attribute. The server encoder evaluates the
+		// conditional request headers in this case.
+		ETag bool
+		// CacheControl is the value of the Cache-Control header if any.
+		CacheControl string
+		// ExpiresIn is the number of seconds added to the current time
+		// to compute the value of the Expires header, 0 if the response
+		// does not set the header.
+		ExpiresIn int64
+		// Vary is the value of the Vary header if any.
+		Vary string
 	}
 
 	// InitData contains the data required to render a constructor.
@@ -397,12 +595,38 @@ type (
 		// PathInit contains the information needed to render and call
 		// the path constructor for the route.
 		PathInit *InitData
+		// SlashPath is Path followed by a trailing slash if the path is
+		// registered according to TrailingSlash, empty otherwise.
+		SlashPath string
+		// TrailingSlash is the trailing slash policy of the route:
+		// "strict", "redirect" or "rewrite".
+		TrailingSlash string
+		// Constraints maps the names of the path parameters to the
+		// regular expressions registered with the router, nil if none.
+		Constraints map[string]string
+		// Alias is true if the route serves the method under a path
+		// that uses a previous name of the method.
+		Alias bool
+	}
+
+	// RenamesData maps the previous names of the request elements to their
+	// current names.
+	RenamesData struct {
+		// Query maps the names of the query string parameters.
+		Query map[string]string
+		// Header maps the names of the headers.
+		Header map[string]string
+		// Body maps the names of the top-level body fields.
+		Body map[string]string
 	}
 
 	// ParamData describes a HTTP request parameter.
 	ParamData struct {
 		// Name is the name of the mapping to the actual variable name.
 		Name string
+		// MuxKey is the key of the path parameter in the map returned
+		// by the muxer Vars method.
+		MuxKey string
 		// AttributeName is the name of the corresponding attribute.
 		AttributeName string
 		// Description is the parameter description
@@ -444,10 +668,64 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// TimestampFormat is the wire format of the parameter value or
+		// of its elements if the parameter is an array of timestamps.
+		TimestampFormat string
 		// MapQueryParams indicates that the query params must be mapped
 		// to the entire payload (empty string) or a payload attribute
 		// (attribute name).
 		MapQueryParams *string
+		// Delimiter is the separator of the values of an array query
+		// string parameter serialized in a single parameter as set via
+		// the Style and Explode DSLs, empty if each value is sent as a
+		// separate parameter.
+		Delimiter string
+		// MapObject describes the values of the map built from the
+		// query string with MapParams if they are objects, nil
+		// otherwise.
+		MapObject *MapObjectData
+	}
+
+	// MapObjectData describes the object values of a map decoded from
+	// deepObject style query string parameters, e.g. "ranges[1][min]=0".
+	MapObjectData struct {
+		// TypeName is the name of the service type of the values
+		// including the package, e.g. "svc.Range".
+		TypeName string
+		// Fields lists the attributes of the values.
+		Fields []*MapObjectFieldData
+		// Validate contains the code that validates a value held in
+		// the "val" variable if any.
+		Validate string
+	}
+
+	// MapObjectFieldData describes an attribute of the object values of a
+	// map decoded from deepObject style query string parameters.
+	MapObjectFieldData struct {
+		// Name is the name of the attribute used in the query string.
+		Name string
+		// FieldName is the name of the service type struct field.
+		FieldName string
+		// VarName is the name of the Go variable used to convert the
+		// attribute value.
+		VarName string
+		// Type is the attribute type.
+		Type expr.DataType
+		// TypeRef is the reference to the attribute type.
+		TypeRef string
+		// Required is true if the attribute is required.
+		Required bool
+		// Pointer is true if the struct field is a pointer.
+		Pointer bool
+		// Slice is true if the attribute is an array.
+		Slice bool
+		// StringSlice is true if the attribute is an array of strings.
+		StringSlice bool
+		// DefaultValue contains the default value if any.
+		DefaultValue interface{}
+		// TimestampFormat is the wire format of the attribute value or
+		// of its elements if the attribute is an array of timestamps.
+		TimestampFormat string
 	}
 
 	// HeaderData describes a HTTP request or response header.
@@ -490,6 +768,28 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// TimestampFormat is the wire format of the header value or of
+		// its elements if the header is an array of timestamps.
+		TimestampFormat string
+		// Delimiter is the separator of the values of an array header
+		// serialized in a single header value as set via the Style DSL,
+		// empty if each value is sent as a separate header.
+		Delimiter string
+	}
+
+	// WebhookData contains the data needed to render the client code that
+	// sends a service webhook.
+	WebhookData struct {
+		// Name is the webhook name.
+		Name string
+		// Description is the webhook description.
+		Description string
+		// SendName is the name of the sender method.
+		SendName string
+		// PayloadRef is the reference to the webhook payload type.
+		PayloadRef string
+		// Body is the webhook request body type.
+		Body *TypeData
 	}
 
 	// TypeData contains the data needed to render a type definition.
@@ -515,6 +815,20 @@ type (
 		Example interface{}
 		// View is the view using which the type is rendered.
 		View string
+		// Encryption describes the function that encrypts or decrypts
+		// the body fields marked as encrypted if any.
+		Encryption *EncryptionData
+	}
+
+	// EncryptionData contains the data needed to render the function that
+	// encrypts or decrypts the fields of a body type marked with Encrypted.
+	EncryptionData struct {
+		// Name is the function name.
+		Name string
+		// Description is the function description.
+		Description string
+		// Code is the function body.
+		Code string
 	}
 
 	// MultipartData contains the data needed to render multipart
@@ -535,6 +849,30 @@ type (
 		Payload *PayloadData
 	}
 
+	// ProtobufData contains the data needed to render the code that
+	// decodes the requests and encodes the responses of an endpoint using
+	// the protocol buffer messages generated for the gRPC transport.
+	ProtobufData struct {
+		// PbPkgName is the name of the package generated by protoc.
+		PbPkgName string
+		// ServerPkgName is the name used to import the gRPC server
+		// package that defines the message constructors.
+		ServerPkgName string
+		// RequestMessage is the qualified name of the request message
+		// type, empty if requests cannot be decoded from protobuf.
+		RequestMessage string
+		// PayloadInit is the qualified name of the function that builds
+		// the payload from the request message.
+		PayloadInit string
+		// RequestValidation is the qualified name of the function that
+		// validates the request message if any.
+		RequestValidation string
+		// ResponseInit is the qualified name of the function that builds
+		// the response message from the result, empty if responses cannot
+		// be encoded using protobuf.
+		ResponseInit string
+	}
+
 	// StreamData contains the data needed to render struct type that
 	// implements the server and client stream interfaces.
 	StreamData struct {
@@ -581,6 +919,56 @@ type (
 		// Kind is the kind of the stream (payload, result or
 		// bidirectional).
 		Kind expr.StreamKind
+		// NDJSON is true if the results are streamed as newline
+		// delimited JSON over the HTTP response instead of a websocket
+		// connection.
+		NDJSON bool
+		// Export describes the file rows written to the HTTP response
+		// if the endpoint exports files, nil otherwise.
+		Export *ExportData
+	}
+
+	// ExportData describes the file written by an export endpoint.
+	ExportData struct {
+		// Filename is the template of the file name.
+		Filename string
+		// Params lists the payload fields used in the file name
+		// template.
+		Params []*ExportParamData
+		// Columns lists the file columns.
+		Columns []*ExportColumnData
+	}
+
+	// ExportParamData describes a payload field used in the file name
+	// template of an export endpoint.
+	ExportParamData struct {
+		// Name is the placeholder name.
+		Name string
+		// FieldName is the name of the payload struct field.
+		FieldName string
+	}
+
+	// ExportColumnData describes a column of the file written by an export
+	// endpoint.
+	ExportColumnData struct {
+		// Name is the column name.
+		Name string
+		// FieldName is the name of the result struct field.
+		FieldName string
+		// Pointer is true if the field is a pointer.
+		Pointer bool
+		// TypeName is the name of the column type used in error
+		// messages.
+		TypeName string
+		// Format is the format of the code that converts the field
+		// value to a string.
+		Format string
+		// Parse is the format of the code that parses a cell into a
+		// value, empty if the field is a string.
+		Parse string
+		// Conv is the format of the code that converts the parsed value
+		// into the field type.
+		Conv string
 	}
 )
 
@@ -720,9 +1108,13 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				}
 
 				routes = append(routes, &RouteData{
-					Verb:     strings.ToUpper(r.Method),
-					Path:     rpath,
-					PathInit: init,
+					Verb:          strings.ToUpper(r.Method),
+					Path:          rpath,
+					PathInit:      init,
+					SlashPath:     slashPath(a, rpath),
+					TrailingSlash: a.TrailingSlashPolicy(),
+					Constraints:   pathConstraints(a),
+					Alias:         r.IsAlias(),
 				})
 			}
 		}
@@ -784,14 +1176,19 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				}
 			}
 			data := map[string]interface{}{
-				"PayloadRef":   payloadRef,
-				"HasFields":    expr.IsObject(a.MethodExpr.Payload.Type),
-				"ServiceName":  svc.Name,
-				"EndpointName": ep.Name,
-				"Args":         args,
-				"PathInit":     routes[0].PathInit,
-				"Verb":         routes[0].Verb,
-				"IsStreaming":  a.MethodExpr.IsStreaming(),
+				"PayloadRef":    payloadRef,
+				"HasFields":     expr.IsObject(a.MethodExpr.Payload.Type),
+				"ServiceName":   svc.Name,
+				"EndpointName":  ep.Name,
+				"Args":          args,
+				"PathInit":      routes[0].PathInit,
+				"Verb":          routes[0].Verb,
+				"IsStreaming":   a.MethodExpr.IsStreaming() && !a.StreamsResponse(),
+				"ViewParam":     a.ViewParam,
+				"FieldMask":     a.MethodExpr.HasFieldMask(),
+				"ETag":          expr.TaggedAttribute(a.MethodExpr.Result, "http:etag") != "",
+				"Version":       a.Version,
+				"VersionHeader": a.VersionHeader(),
 			}
 			var buf bytes.Buffer
 			if err := requestInitTmpl.Execute(&buf, data); err != nil {
@@ -832,8 +1229,47 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			RequestInit:     requestInit,
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
+			Idempotent:      a.MethodExpr.IsIdempotent(),
+			Compression:     a.ResponseCompression(),
+			Canary:          a.Canary,
+			SignedURL:       a.SignedURL,
+			TracePhases:     a.PhasesTraced(),
+			StrictDecoding:  a.MethodExpr.IsStrictDecoding(),
+			AggregateErrors: a.MethodExpr.IsAggregateErrors(),
+			MergePatch:      expr.PatchedType(a.MethodExpr.Payload.Type) != nil,
+			ViewParam:       a.ViewParam,
+			ETag:            expr.TaggedAttribute(a.MethodExpr.Result, "http:etag") != "",
+			Sunset:          sunsetHeader(a.MethodExpr),
+			Renames:         renamesData(a),
+			MaxRequestBody:  maxRequestBody(a),
+			FixedHeaders:    a.Service.AllFixedHeaders(),
 		}
 		buildStreamData(ad, a, rd)
+		ad.RequestCompression = requestCompression(a)
+		if ad.ClientStream == nil || ad.ClientStream.NDJSON || ad.ClientStream.Export != nil {
+			ad.ClientCompression = clientCompression(ad.RequestCompression)
+		}
+		ad.PassThroughHeaders = a.PassedThroughHeaders()
+
+		for _, c := range a.RequestContents {
+			// Build the payload data using a copy of the endpoint that
+			// uses the content body in place of the endpoint body.
+			ce := *a
+			ce.Body = c.Body
+			ce.RequestContents = nil
+			suffix := codegen.Goify(c.Name(), true)
+			cp := buildPayloadData(&ce, rd)
+			if init := cp.Request.PayloadInit; init != nil {
+				name := init.Name + suffix
+				init.Description = strings.Replace(init.Description, init.Name, name, 1)
+				init.Name = name
+			}
+			ad.RequestContents = append(ad.RequestContents, &RequestContentData{
+				ContentType:    c.ContentType,
+				RequestDecoder: fmt.Sprintf("Decode%s%sRequest", ep.VarName, suffix),
+				Payload:        cp,
+			})
+		}
 
 		if a.MultipartRequest {
 			ad.MultipartRequestDecoder = &MultipartData{
@@ -854,9 +1290,16 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			}
 		}
 
+		ad.Protobuf = protobufData(a, ad)
+
 		rd.Endpoints = append(rd.Endpoints, ad)
 	}
 
+	rd.VersionedRoutes = buildVersionedRoutes(hs, rd)
+	rd.ErrorMessages = buildErrorMessagesData(hs)
+	rd.Environments = buildEnvironmentsData(hs.ServiceExpr.Name)
+	rd.EncoderOptions = buildEncoderOptionsData(expr.Root.API.HTTP)
+
 	for _, a := range hs.HTTPEndpoints {
 		collectUserTypes(a.Body.Type, func(ut expr.UserType) {
 			if d := attributeTypeData(ut, true, true, true, rd); d != nil {
@@ -867,6 +1310,17 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			}
 		})
 
+		for _, c := range a.RequestContents {
+			collectUserTypes(c.Body.Type, func(ut expr.UserType) {
+				if d := attributeTypeData(ut, true, true, true, rd); d != nil {
+					rd.ServerBodyAttributeTypes = append(rd.ServerBodyAttributeTypes, d)
+				}
+				if d := attributeTypeData(ut, true, false, false, rd); d != nil {
+					rd.ClientBodyAttributeTypes = append(rd.ClientBodyAttributeTypes, d)
+				}
+			})
+		}
+
 		if a.MethodExpr.StreamingPayload.Type != expr.Empty {
 			collectUserTypes(a.StreamingBody.Type, func(ut expr.UserType) {
 				if d := attributeTypeData(ut, true, true, true, rd); d != nil {
@@ -903,9 +1357,124 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		}
 	}
 
+	for _, w := range hs.ServiceExpr.Webhooks {
+		rd.Webhooks = append(rd.Webhooks, buildWebhookData(w, rd))
+		collectUserTypes(w.Body.Type, func(ut expr.UserType) {
+			if d := attributeTypeData(ut, true, false, false, rd); d != nil {
+				rd.ClientBodyAttributeTypes = append(rd.ClientBodyAttributeTypes, d)
+			}
+		})
+	}
+
 	return rd
 }
 
+// buildWebhookData returns the data needed to render the client code that
+// sends the given webhook.
+func buildWebhookData(w *expr.WebhookExpr, sd *ServiceData) *WebhookData {
+	var (
+		body    = w.Body
+		svc     = sd.Service
+		httpctx = httpContext("", sd.Scope, true, false)
+		svcctx  = serviceContext(svc.PkgName, svc.Scope)
+	)
+	bd := &TypeData{
+		Name:    body.Type.Name(),
+		VarName: sd.Scope.GoTypeRef(body),
+		Ref:     sd.Scope.GoTypeRef(body),
+		Example: body.Example(expr.Root.API.Random()),
+	}
+	if ut, ok := body.Type.(expr.UserType); ok {
+		bd.VarName = codegen.Goify(ut.Name(), true)
+		bd.Def = withXMLRoot(goTypeDef(sd.Scope, ut.Attribute(), false, true), body)
+		bd.Description = fmt.Sprintf("%s is the type of the %q service %q webhook HTTP request body.",
+			bd.VarName, svc.Name, w.Name)
+		sd.ClientTypeNames[ut.Name()] = false
+	}
+	payloadRef := svc.Scope.GoFullTypeRef(w.Payload, svc.PkgName)
+	if needInit(body.Type) {
+		code, helpers, err := marshal(w.Payload, body, "p", "body", svcctx, httpctx)
+		if err != nil {
+			fmt.Println(err.Error()) // TBD validate DSL so errors are not possible
+		}
+		sd.ClientTransformHelpers = codegen.AppendHelpers(sd.ClientTransformHelpers, helpers)
+		name := fmt.Sprintf("New%s", codegen.Goify(sd.Scope.GoTypeName(body), true))
+		bd.Init = &InitData{
+			Name:          name,
+			Description:   fmt.Sprintf("%s builds the HTTP request body of the %q webhook of the %q service.", name, w.Name, svc.Name),
+			ReturnTypeRef: bd.Ref,
+			ClientCode:    code,
+			ClientArgs:    []*InitArgData{{Name: "p", Ref: "p", TypeRef: payloadRef}},
+		}
+	}
+	return &WebhookData{
+		Name:        w.Name,
+		Description: w.Description,
+		SendName:    "Send" + codegen.Goify(w.Name, true),
+		PayloadRef:  payloadRef,
+		Body:        bd,
+	}
+}
+
+// buildVersionedRoutes returns the data for the routes shared by endpoints
+// that implement different API versions and flags the corresponding endpoints
+// as versioned. The other routes of the versioned endpoints are returned with
+// a single handler as the endpoint mount handler is not used.
+func buildVersionedRoutes(hs *expr.HTTPServiceExpr, sd *ServiceData) []*VersionedRouteData {
+	var versioned []*expr.HTTPEndpointExpr
+	for _, a := range hs.HTTPEndpoints {
+		for _, r := range a.Routes {
+			if r.VersionedEndpoints() != nil {
+				versioned = append(versioned, a)
+				break
+			}
+		}
+	}
+	if len(versioned) == 0 {
+		return nil
+	}
+	var (
+		res  []*VersionedRouteData
+		seen = make(map[string]struct{})
+	)
+	for _, a := range versioned {
+		ed := sd.Endpoint(a.Name())
+		ed.Versioned = true
+		for _, r := range a.Routes {
+			eps := r.VersionedEndpoints()
+			for _, p := range r.FullPaths() {
+				key := r.Method + " " + p
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				vr := &VersionedRouteData{
+					Verb:          strings.ToUpper(r.Method),
+					Path:          p,
+					SlashPath:     slashPath(a, p),
+					TrailingSlash: a.TrailingSlashPolicy(),
+					Constraints:   pathConstraints(a),
+				}
+				if eps == nil {
+					vr.Handlers = []*VersionedHandlerData{{VarName: ed.Method.VarName}}
+					res = append(res, vr)
+					continue
+				}
+				vr.Header = a.VersionHeader()
+				vr.Default = r.DefaultVersionEndpoint().Version
+				for _, e := range eps {
+					vr.Handlers = append(vr.Handlers, &VersionedHandlerData{
+						Version: e.Version,
+						VarName: sd.Endpoint(e.Name()).Method.VarName,
+					})
+				}
+				res = append(res, vr)
+			}
+		}
+	}
+	return res
+}
+
 // buildPayloadData returns the data structure used to describe the endpoint
 // payload including the HTTP request details. It also returns the user types
 // used by the request body type recursively if any.
@@ -926,7 +1495,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 		var (
 			serverBodyData = buildRequestBodyType(e.Body, payload, e, true, sd)
 			clientBodyData = buildRequestBodyType(e.Body, payload, e, false, sd)
-			paramsData     = extractPathParams(e.PathParams(), payload, sd.Scope)
+			paramsData     = routerPathParams(e, extractPathParams(e.PathParams(), payload, sd.Scope))
 			queryData      = extractQueryParams(e.QueryParams(), payload, sd.Scope)
 			headersData    = extractHeaders(e.Headers, payload, svcctx, sd.Scope)
 
@@ -961,6 +1530,19 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					Example:        pAtt.Example(expr.Root.API.Random()),
 					MapQueryParams: e.MapQueryParams,
 				}
+				if m := expr.AsMap(pAtt.Type); m != nil && expr.IsObject(m.ElemType.Type) {
+					// The values are service types decoded from
+					// deepObject style parameters, the map itself
+					// only validates its keys.
+					mapQueryParam.MapObject = mapObjectData(m.ElemType, sd.Service)
+					mapQueryParam.TypeName = sd.Service.Scope.GoFullTypeName(pAtt, sd.Service.PkgName)
+					mapQueryParam.TypeRef = sd.Service.Scope.GoFullTypeRef(pAtt, sd.Service.PkgName)
+					keys := &expr.AttributeExpr{
+						Type:       &expr.Map{KeyType: m.KeyType, ElemType: &expr.AttributeExpr{Type: expr.Any}},
+						Validation: pAtt.Validation,
+					}
+					mapQueryParam.Validate = codegen.RecursiveValidationCode(keys, httpsvrctx, required, varn)
+				}
 				queryData = append(queryData, mapQueryParam)
 			}
 			if serverBodyData != nil {
@@ -1199,6 +1781,11 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				if err == nil {
 					sd.ClientTransformHelpers = codegen.AppendHelpers(sd.ClientTransformHelpers, helpers)
 				}
+			} else if mapQueryParam != nil && mapQueryParam.MapObject != nil {
+				// The map decoded from the query string already holds
+				// the service types.
+				serverCode = "v := " + mapQueryParam.VarName
+				clientCode = serverCode
 			}
 		}
 		if err != nil {
@@ -1219,6 +1806,10 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 		}
 	}
 	request.PayloadInit = init
+	if init != nil && expr.IsObject(payload.Type) {
+		request.DateTimeNormalization = codegen.DateTimeNormalizationCode(payload, svcctx, true, "payload")
+		request.DefaultFrom = codegen.DefaultFromCode(payload, svcctx, true, "payload")
+	}
 
 	var (
 		returnValue string
@@ -1301,6 +1892,155 @@ func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 	}
 }
 
+// renamesData returns the previous names of the query string parameters,
+// headers and top-level body fields of the given endpoint, nil if none. The
+// previous names are the ones of the payload attributes renamed with the
+// RenamedFrom DSL whose wire name is the attribute name.
+func renamesData(e *expr.HTTPEndpointExpr) *RenamesData {
+	payload := expr.AsObject(e.MethodExpr.Payload.Type)
+	if payload == nil {
+		return nil
+	}
+	var (
+		rd    RenamesData
+		found bool
+		query = e.QueryParams()
+		body  *expr.Object
+	)
+	if e.Body != nil {
+		body = expr.AsObject(e.Body.Type)
+	}
+	add := func(m *map[string]string, olds []string, name string) {
+		if *m == nil {
+			*m = make(map[string]string)
+		}
+		for _, old := range olds {
+			(*m)[old] = name
+		}
+		found = true
+	}
+	for _, nat := range *payload {
+		olds := nat.Attribute.RenamedFrom()
+		if len(olds) == 0 {
+			continue
+		}
+		if query.Find(nat.Name) != nil && query.ElemName(nat.Name) == nat.Name {
+			add(&rd.Query, olds, nat.Name)
+		}
+		if e.Headers.Find(nat.Name) != nil && e.Headers.ElemName(nat.Name) == nat.Name {
+			add(&rd.Header, olds, nat.Name)
+		}
+		if body != nil && body.Attribute(nat.Name) != nil {
+			add(&rd.Body, olds, nat.Name)
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &rd
+}
+
+// maxRequestBody returns the maximum size of the request body read by the
+// given endpoint or zero if the size is not limited or the endpoint does not
+// read the request body.
+func maxRequestBody(e *expr.HTTPEndpointExpr) int64 {
+	if e.Body.Type == expr.Empty && !e.MultipartRequest {
+		return 0
+	}
+	return e.RequestBodyLimit()
+}
+
+// requestCompression returns the content codings of the compressed request
+// bodies accepted by the given endpoint, nil if the endpoint does not accept
+// compressed request bodies or does not read the request body.
+func requestCompression(e *expr.HTTPEndpointExpr) []string {
+	if e.Body.Type == expr.Empty && !e.MultipartRequest {
+		return nil
+	}
+	return e.RequestEncodings()
+}
+
+// clientCompression returns the content coding used by the generated clients
+// to compress the request bodies of an endpoint that accepts the given
+// codings, empty if none of the codings is supported by the clients.
+func clientCompression(encodings []string) string {
+	for _, enc := range encodings {
+		if enc == "gzip" || enc == "deflate" {
+			return enc
+		}
+	}
+	return ""
+}
+
+// clientCompressionExists returns true if the client of at least one of the
+// service endpoints compresses request bodies.
+func clientCompressionExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.ClientCompression != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sunsetHeader returns the value of the Sunset response header for the given
+// method or the empty string if the method does not define a sunset date.
+func sunsetHeader(m *expr.MethodExpr) string {
+	t, ok := m.Sunset()
+	if !ok {
+		return ""
+	}
+	return t.UTC().Format(http.TimeFormat)
+}
+
+// protobufData returns the data needed to decode the requests and encode the
+// responses of the given endpoint using the protocol buffer messages generated
+// for the gRPC transport. Requests may be decoded from protobuf if the API
+// lists "application/x-protobuf" in the MIME types it consumes and the HTTP
+// request only defines a body while the gRPC request does not use metadata.
+// Responses may be encoded using protobuf if the API lists the MIME type in
+// the types it produces and the HTTP endpoint defines a single response.
+// protobufData returns nil if the method does not define a unary gRPC endpoint
+// or if neither requests nor responses may use protobuf.
+func protobufData(e *expr.HTTPEndpointExpr, ed *EndpointData) *ProtobufData {
+	var consumes, produces bool
+	for _, ct := range expr.Root.API.HTTP.Consumes {
+		consumes = consumes || ct == "application/x-protobuf"
+	}
+	for _, ct := range expr.Root.API.HTTP.Produces {
+		produces = produces || ct == "application/x-protobuf"
+	}
+	if !consumes && !produces || e.MethodExpr.IsStreaming() || expr.Root.API.GRPC.Service(e.Service.Name()) == nil {
+		return nil
+	}
+	gsd := grpccodegen.GRPCServices.Get(e.Service.Name())
+	ged := gsd.Endpoint(e.Name())
+	if ged == nil {
+		return nil
+	}
+	pkg := ed.ServicePkgName + "grpc"
+	pd := &ProtobufData{PbPkgName: gsd.PkgName, ServerPkgName: pkg}
+	req := ed.Payload.Request
+	if consumes && req.ServerBody != nil && ed.MultipartRequestDecoder == nil && ed.BasicScheme == nil &&
+		len(req.PathParams) == 0 && len(req.QueryParams) == 0 && len(req.Headers) == 0 &&
+		ged.Request.ServerConvert != nil && ged.Request.ServerConvert.Init != nil && len(ged.Request.Metadata) == 0 {
+		pd.RequestMessage = strings.TrimPrefix(ged.Request.ServerConvert.SrcRef, "*")
+		pd.PayloadInit = pkg + "." + ged.Request.ServerConvert.Init.Name
+		if v := ged.Request.ServerConvert.Validation; v != nil {
+			pd.RequestValidation = pkg + "." + v.Name
+		}
+	}
+	if produces && ed.Result.MustInit && len(ed.Result.Responses) == 1 &&
+		ged.Response.ServerConvert != nil && ged.Response.ServerConvert.Init != nil &&
+		len(ged.Response.ServerConvert.Init.Args) == 1 {
+		pd.ResponseInit = pkg + "." + ged.Response.ServerConvert.Init.Name
+	}
+	if pd.RequestMessage == "" && pd.ResponseInit == "" {
+		return nil
+	}
+	return pd
+}
+
 // buildResponses builds the response data for all the responses in the
 // endpoint expression. The response headers and body for each response
 // are inferred from the method's result expression if not specified
@@ -1515,6 +2255,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 						tagPtr = viewed || result.IsPrimitivePointer(resp.Tag[0], true)
 					}
 				}
+				_, etag := resp.Headers.FindKey(expr.TaggedAttribute(e.MethodExpr.Result, "http:etag"))
 				responses = append(responses, &ResponseData{
 					StatusCode:   statusCodeToHTTPConst(resp.StatusCode),
 					Description:  resp.Description,
@@ -1529,6 +2270,10 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 					MustValidate: mustValidate,
 					ResultAttr:   codegen.Goify(origin, true),
 					ViewedResult: md.ViewedResult,
+					ETag:         etag,
+					CacheControl: resp.CacheControl,
+					ExpiresIn:    resp.ExpiresIn(),
+					Vary:         strings.Join(resp.Vary, ", "),
 				})
 			}
 		}
@@ -1681,14 +2426,18 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 				ClientBody:   clientBodyData,
 				ResultInit:   init,
 				MustValidate: mustValidate,
+				CacheControl: v.Response.CacheControl,
+				ExpiresIn:    v.Response.ExpiresIn(),
+				Vary:         strings.Join(v.Response.Vary, ", "),
 			}
 		}
 
 		ref := svc.Scope.GoFullTypeRef(v.ErrorExpr.AttributeExpr, svc.PkgName)
 		data[ref] = append(data[ref], &ErrorData{
-			Name:     v.Name,
-			Response: responseData,
-			Ref:      ref,
+			Name:       v.Name,
+			Response:   responseData,
+			Ref:        ref,
+			RetryAfter: v.ErrorExpr.RetryAfter() > 0,
 		})
 	}
 	keys := make([]string, len(data))
@@ -1722,6 +2471,80 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 	return vals
 }
 
+// buildErrorMessagesData returns the generic messages of the errors returned
+// by the service endpoints sorted by error name.
+func buildErrorMessagesData(hs *expr.HTTPServiceExpr) []*ErrorMessageData {
+	var (
+		msgs []*ErrorMessageData
+		seen = make(map[string]struct{})
+	)
+	for _, e := range hs.HTTPEndpoints {
+		for _, v := range e.HTTPErrors {
+			if _, ok := seen[v.Name]; ok {
+				continue
+			}
+			seen[v.Name] = struct{}{}
+			msg := v.ErrorExpr.Description
+			if msg == "" {
+				msg = http.StatusText(v.Response.StatusCode)
+			}
+			msgs = append(msgs, &ErrorMessageData{Name: v.Name, Message: msg})
+		}
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Name < msgs[j].Name })
+	return msgs
+}
+
+// buildEncoderOptionsData returns the hardening options applied to the server
+// responses as defined in the API HTTP expression, nil if there are none.
+func buildEncoderOptionsData(h *expr.HTTPExpr) *EncoderOptionsData {
+	if !h.DisableHTMLEscaping && !h.NoSniff && h.JSONPrefix == "" {
+		return nil
+	}
+	return &EncoderOptionsData{
+		DisableHTMLEscaping: h.DisableHTMLEscaping,
+		NoSniff:             h.NoSniff,
+		JSONPrefix:          h.JSONPrefix,
+	}
+}
+
+// buildEnvironmentsData returns the URLs of the HTTP server of the given
+// service in the environments served by the hosts of the servers that expose
+// the service. The first server that serves an environment wins.
+func buildEnvironmentsData(svc string) []*EnvironmentData {
+	var (
+		envs []*EnvironmentData
+		seen = make(map[string]struct{})
+	)
+	for _, s := range expr.Root.API.Servers {
+		var hosted bool
+		for _, n := range s.Services {
+			if n == svc {
+				hosted = true
+				break
+			}
+		}
+		if !hosted {
+			continue
+		}
+		for _, env := range s.Environments() {
+			if _, ok := seen[env.Name]; ok {
+				continue
+			}
+			for _, uri := range env.Host.URIs {
+				u, err := url.Parse(env.URI(uri))
+				if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+					continue
+				}
+				seen[env.Name] = struct{}{}
+				envs = append(envs, &EnvironmentData{Name: env.Name, Scheme: u.Scheme, Host: u.Host})
+				break
+			}
+		}
+	}
+	return envs
+}
+
 func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
 	if !e.MethodExpr.IsStreaming() {
 		return
@@ -1747,6 +2570,14 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 		svrSendTypeRef = ed.Result.Ref
 		svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint websocket connection.", md.ServerStream.SendName, svrSendTypeName, md.Name)
 		cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint websocket connection.", md.ClientStream.RecvName, svrSendTypeName, md.Name)
+		if e.NDJSON {
+			svrSendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint HTTP response as newline delimited JSON.", md.ServerStream.SendName, svrSendTypeName, md.Name)
+			cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint HTTP response. It returns io.EOF once all the instances have been read.", md.ClientStream.RecvName, svrSendTypeName, md.Name)
+		}
+		if e.Export != nil {
+			svrSendDesc = fmt.Sprintf("%s writes instances of %q to the %q endpoint HTTP response as the rows of a file.", md.ServerStream.SendName, svrSendTypeName, md.Name)
+			cliRecvDesc = fmt.Sprintf("%s reads instances of %q from the rows of the file read from the %q endpoint HTTP response. It returns io.EOF once all the rows have been read.", md.ClientStream.RecvName, svrSendTypeName, md.Name)
+		}
 		if e.MethodExpr.Stream == expr.ClientStreamKind || e.MethodExpr.Stream == expr.BidirectionalStreamKind {
 			svrRecvTypeName = sd.Scope.GoFullTypeName(e.MethodExpr.StreamingPayload, svc.PkgName)
 			svrRecvTypeRef = sd.Scope.GoFullTypeRef(e.MethodExpr.StreamingPayload, svc.PkgName)
@@ -1855,6 +2686,8 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 		RecvTypeName: svrRecvTypeName,
 		RecvTypeRef:  svrRecvTypeRef,
 		MustClose:    md.ServerStream.MustClose,
+		NDJSON:       e.NDJSON,
+		Export:       buildExportData(e, svc),
 	}
 	ed.ClientStream = &StreamData{
 		VarName:      md.ClientStream.VarName,
@@ -1874,7 +2707,103 @@ func buildStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData
 		RecvTypeName: svrSendTypeName,
 		RecvTypeRef:  svrSendTypeRef,
 		MustClose:    md.ClientStream.MustClose,
+		NDJSON:       e.NDJSON,
+		Export:       buildExportData(e, svc),
+	}
+}
+
+// buildExportData returns the data needed to write and read the file rows of
+// the export endpoint e, nil if e does not export files. The values of the
+// columns whose type is a user type are converted to and from the underlying
+// primitive type.
+func buildExportData(e *expr.HTTPEndpointExpr, svc *service.Data) *ExportData {
+	if e.Export == nil {
+		return nil
+	}
+	var params []*ExportParamData
+	for _, p := range e.Export.FilenameParams() {
+		params = append(params, &ExportParamData{
+			Name:      p,
+			FieldName: codegen.GoifyAtt(e.MethodExpr.Payload.Find(p), p, true),
+		})
+	}
+	var columns []*ExportColumnData
+	res := e.MethodExpr.Result
+	for _, nat := range *e.Export.Columns() {
+		c := &ExportColumnData{
+			Name:      nat.Name,
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			Pointer:   res.IsPrimitivePointer(nat.Name, true),
+			Conv:      "%s",
+		}
+		dt := nat.Attribute.Type
+		for {
+			ut, ok := dt.(expr.UserType)
+			if !ok {
+				break
+			}
+			dt = ut.Attribute().Type
+		}
+		gt := codegen.GoNativeTypeName(dt)
+		val, conv := "%s", ""
+		if dt != nat.Attribute.Type {
+			val = gt + "(%s)"
+			conv = svc.Scope.GoFullTypeName(nat.Attribute, svc.PkgName) + "(%s)"
+		}
+		switch dt.Kind() {
+		case expr.BooleanKind:
+			c.TypeName = "boolean"
+			c.Format = "strconv.FormatBool(" + val + ")"
+			c.Parse = "strconv.ParseBool(%s)"
+		case expr.IntKind, expr.Int32Kind, expr.Int64Kind:
+			c.TypeName = "integer"
+			c.Parse = "strconv.ParseInt(%s, 10, " + bitSize(gt) + ")"
+			if gt != "int64" {
+				c.Format = "strconv.FormatInt(int64(%s), 10)"
+				c.Conv = gt + "(%s)"
+			} else {
+				c.Format = "strconv.FormatInt(" + val + ", 10)"
+			}
+		case expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+			c.TypeName = "unsigned integer"
+			c.Parse = "strconv.ParseUint(%s, 10, " + bitSize(gt) + ")"
+			if gt != "uint64" {
+				c.Format = "strconv.FormatUint(uint64(%s), 10)"
+				c.Conv = gt + "(%s)"
+			} else {
+				c.Format = "strconv.FormatUint(" + val + ", 10)"
+			}
+		case expr.Float32Kind:
+			c.TypeName = "float"
+			c.Format = "strconv.FormatFloat(float64(%s), 'g', -1, 32)"
+			c.Parse = "strconv.ParseFloat(%s, 32)"
+			c.Conv = "float32(%s)"
+		case expr.Float64Kind:
+			c.TypeName = "float"
+			c.Format = "strconv.FormatFloat(" + val + ", 'g', -1, 64)"
+			c.Parse = "strconv.ParseFloat(%s, 64)"
+		default:
+			c.TypeName = "string"
+			c.Format = val
+		}
+		if conv != "" {
+			c.Conv = conv
+		}
+		columns = append(columns, c)
+	}
+	return &ExportData{Filename: e.Export.Filename, Params: params, Columns: columns}
+}
+
+// bitSize returns the bit size argument of the strconv functions used to parse
+// values of the Go integer type t.
+func bitSize(t string) string {
+	switch t {
+	case "int32", "uint32":
+		return "32"
+	case "int", "uint":
+		return "0"
 	}
+	return "64"
 }
 
 // buildRequestBodyType builds the TypeData for a request body. The data makes
@@ -1903,6 +2832,7 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 		ref         string
 		validateDef string
 		validateRef string
+		encryption  *EncryptionData
 
 		svc     = sd.Service
 		httpctx = httpContext("", sd.Scope, true, svr)
@@ -1913,9 +2843,10 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 		ref = sd.Scope.GoTypeRef(body)
 		if ut, ok := body.Type.(expr.UserType); ok {
 			varname = codegen.Goify(ut.Name(), true)
-			def = goTypeDef(sd.Scope, ut.Attribute(), svr, !svr)
+			def = withXMLRoot(goTypeDef(sd.Scope, ut.Attribute(), svr, !svr), body)
 			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP request body.",
 				varname, svc.Name, e.Name())
+			encryption = encryptionData(ut, varname, !svr, svr, !svr)
 			if svr {
 				// generate validation code for unmarshaled type (server-side).
 				validateDef = codegen.RecursiveValidationCode(ut.Attribute(), httpctx, true, "body")
@@ -1990,6 +2921,7 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 		ValidateDef: validateDef,
 		ValidateRef: validateRef,
 		Example:     body.Example(expr.Root.API.Random()),
+		Encryption:  encryption,
 	}
 }
 
@@ -2019,6 +2951,8 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 		validateRef string
 		viewName    string
 		mustInit    bool
+		encryption  *EncryptionData
+		unprojected *expr.AttributeExpr
 
 		svc     = sd.Service
 		httpctx = httpContext("", sd.Scope, false, svr)
@@ -2030,6 +2964,7 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 		// possible to return only the attributes in the view in the server response.
 		if svr && view != nil && *view != "" {
 			viewName = *view
+			unprojected = body
 			body = expr.DupAtt(body)
 			if rt, ok := body.Type.(*expr.ResultTypeExpr); ok {
 				var err error
@@ -2049,9 +2984,10 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 		if ut, ok := body.Type.(expr.UserType); ok {
 			// response body is a user type.
 			varname = codegen.Goify(ut.Name(), true)
-			def = goTypeDef(sd.Scope, ut.Attribute(), !svr, svr)
+			def = withXMLRoot(goTypeDef(sd.Scope, ut.Attribute(), !svr, svr), body, unprojected)
 			desc = fmt.Sprintf("%s is the type of the %q service %q endpoint HTTP response body.",
 				varname, svc.Name, e.Name())
+			encryption = encryptionData(ut, varname, svr, !svr, svr)
 			if !svr && view == nil {
 				// generate validation code for unmarshaled type (client-side).
 				validateDef = codegen.RecursiveValidationCode(body, httpctx, true, "body")
@@ -2178,6 +3114,7 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 		ValidateRef: validateRef,
 		Example:     body.Example(expr.Root.API.Random()),
 		View:        viewName,
+		Encryption:  encryption,
 	}
 }
 
@@ -2194,24 +3131,26 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 			fieldName = ""
 		}
 		params = append(params, &ParamData{
-			Name:           elem,
-			AttributeName:  name,
-			Description:    c.Description,
-			FieldName:      fieldName,
-			FieldPointer:   expr.IsObject(service.Type) && service.IsPrimitivePointer(name, true),
-			VarName:        varn,
-			Required:       true,
-			Type:           c.Type,
-			TypeName:       scope.GoTypeName(c),
-			TypeRef:        scope.GoTypeRef(c),
-			Pointer:        false,
-			Slice:          arr != nil,
-			StringSlice:    arr != nil && arr.ElemType.Type.Kind() == expr.StringKind,
-			Map:            false,
-			MapStringSlice: false,
-			Validate:       codegen.RecursiveValidationCode(c, ctx, true, varn),
-			DefaultValue:   c.DefaultValue,
-			Example:        c.Example(expr.Root.API.Random()),
+			Name:            elem,
+			MuxKey:          elem,
+			AttributeName:   name,
+			Description:     c.Description,
+			FieldName:       fieldName,
+			FieldPointer:    expr.IsObject(service.Type) && service.IsPrimitivePointer(name, true),
+			VarName:         varn,
+			Required:        true,
+			Type:            c.Type,
+			TypeName:        scope.GoTypeName(c),
+			TypeRef:         scope.GoTypeRef(c),
+			Pointer:         false,
+			Slice:           arr != nil,
+			StringSlice:     arr != nil && arr.ElemType.Type.Kind() == expr.StringKind,
+			Map:             false,
+			MapStringSlice:  false,
+			Validate:        codegen.RecursiveValidationCode(c, ctx, true, varn),
+			DefaultValue:    c.DefaultValue,
+			Example:         c.Example(expr.Root.API.Random()),
+			TimestampFormat: c.TimestampFormat(),
 		})
 		return nil
 	})
@@ -2257,9 +3196,11 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 				mp.KeyType.Type.Kind() == expr.StringKind &&
 				mp.ElemType.Type.Kind() == expr.ArrayKind &&
 				expr.AsArray(mp.ElemType.Type).ElemType.Type.Kind() == expr.StringKind,
-			Validate:     codegen.RecursiveValidationCode(c, ctx, required, varn),
-			DefaultValue: c.DefaultValue,
-			Example:      c.Example(expr.Root.API.Random()),
+			Validate:        codegen.RecursiveValidationCode(c, ctx, required, varn),
+			DefaultValue:    c.DefaultValue,
+			Example:         c.Example(expr.Root.API.Random()),
+			TimestampFormat: c.TimestampFormat(),
+			Delimiter:       expr.HTTPParamDelimiter(c),
 		})
 		return nil
 	})
@@ -2269,7 +3210,7 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 
 func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svcCtx *codegen.AttributeContext, scope *codegen.NameScope) []*HeaderData {
 	var headers []*HeaderData
-	codegen.WalkMappedAttr(a, func(name, elem string, required bool, _ *expr.AttributeExpr) error {
+	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *expr.AttributeExpr) error {
 		var (
 			hattr *expr.AttributeExpr
 		)
@@ -2296,23 +3237,25 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 			}
 		}
 		headers = append(headers, &HeaderData{
-			Name:          elem,
-			AttributeName: name,
-			Description:   hattr.Description,
-			CanonicalName: http.CanonicalHeaderKey(elem),
-			FieldName:     fieldName,
-			FieldPointer:  expr.IsObject(svcAtt.Type) && svcCtx.IsPrimitivePointer(name, svcAtt),
-			VarName:       varn,
-			TypeName:      scope.GoTypeName(hattr),
-			TypeRef:       typeRef,
-			Required:      required,
-			Pointer:       pointer,
-			Slice:         arr != nil,
-			StringSlice:   arr != nil && arr.ElemType.Type.Kind() == expr.StringKind,
-			Type:          hattr.Type,
-			Validate:      codegen.RecursiveValidationCode(hattr, svcCtx, required, varn),
-			DefaultValue:  hattr.DefaultValue,
-			Example:       hattr.Example(expr.Root.API.Random()),
+			Name:            elem,
+			AttributeName:   name,
+			Description:     hattr.Description,
+			CanonicalName:   http.CanonicalHeaderKey(elem),
+			FieldName:       fieldName,
+			FieldPointer:    expr.IsObject(svcAtt.Type) && svcCtx.IsPrimitivePointer(name, svcAtt),
+			VarName:         varn,
+			TypeName:        scope.GoTypeName(hattr),
+			TypeRef:         typeRef,
+			Required:        required,
+			Pointer:         pointer,
+			Slice:           arr != nil,
+			StringSlice:     arr != nil && arr.ElemType.Type.Kind() == expr.StringKind,
+			Type:            hattr.Type,
+			Validate:        codegen.RecursiveValidationCode(hattr, svcCtx, required, varn),
+			DefaultValue:    hattr.DefaultValue,
+			Example:         hattr.Example(expr.Root.API.Random()),
+			TimestampFormat: hattr.TimestampFormat(),
+			Delimiter:       expr.HTTPParamDelimiter(c),
 		})
 		return nil
 	})
@@ -2397,6 +3340,89 @@ func attributeTypeData(ut expr.UserType, req, ptr, server bool, rd *ServiceData)
 	}
 }
 
+// encryptionData returns the data needed to render the function that encrypts
+// (if encrypt is true) or decrypts the top level fields of the body type ut
+// marked with Encrypted, nil if there is none. ptr and useDefault must be the
+// values used to generate the body type definition.
+func encryptionData(ut expr.UserType, varname string, encrypt, ptr, useDefault bool) *EncryptionData {
+	att := ut.Attribute()
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	verb, fn := "decrypts", "Decrypt"
+	if encrypt {
+		verb, fn = "encrypts", "Encrypt"
+	}
+	var code []string
+	for _, nat := range *obj {
+		if !nat.Attribute.IsEncrypted() {
+			continue
+		}
+		kind, typ := "String", "string"
+		if nat.Attribute.Type.Kind() == expr.BytesKind {
+			kind, typ = "Bytes", "[]byte"
+		}
+		field := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		call := fmt.Sprintf("goahttp.%s%s(ctx, ", fn, kind)
+		if nat.Attribute.IsOptionalField() {
+			// goa.Optional wrapper, leave omitted and null values untouched.
+			code = append(code, fmt.Sprintf("if body.%s.Set && body.%s.Value != nil {\n\tvar v %s\n\tif v, err = %s*body.%s.Value); err != nil {\n\t\treturn\n\t}\n\tbody.%s.Value = &v\n}",
+				field, field, typ, call, field, field))
+		} else if kind == "String" && (ptr || att.IsPrimitivePointer(nat.Name, useDefault)) {
+			code = append(code, fmt.Sprintf("if body.%s != nil {\n\tvar v %s\n\tif v, err = %s*body.%s); err != nil {\n\t\treturn\n\t}\n\tbody.%s = &v\n}",
+				field, typ, call, field, field))
+		} else if kind == "Bytes" {
+			code = append(code, fmt.Sprintf("if body.%s != nil {\n\tif body.%s, err = %sbody.%s); err != nil {\n\t\treturn\n\t}\n}", field, field, call, field))
+		} else {
+			code = append(code, fmt.Sprintf("if body.%s, err = %sbody.%s); err != nil {\n\treturn\n}", field, call, field))
+		}
+	}
+	if len(code) == 0 {
+		return nil
+	}
+	name := fn + varname
+	return &EncryptionData{
+		Name:        name,
+		Description: fmt.Sprintf("%s %s the fields of %s marked as encrypted in the design using the KMS stored in ctx.", name, verb, varname),
+		Code:        strings.Join(code, "\n"),
+	}
+}
+
+// mapObjectData builds the data needed to decode the object values of a map
+// from deepObject style query string parameters. The values are decoded
+// directly into the service type.
+func mapObjectData(att *expr.AttributeExpr, svc *service.Data) *MapObjectData {
+	// The conversion code uses these variables.
+	scope := codegen.NewNameScope()
+	for _, n := range []string{"err", "err2", "v", "pv", "val", "vals", "key", "keyStr", "keyName", "fields", "ok", "i", "rv", "value", "values", "p", "req", "goa", "strconv", "strings", "time", "fmt", "http"} {
+		scope.Unique(n)
+	}
+	obj := expr.AsObject(att.Type)
+	fields := make([]*MapObjectFieldData, len(*obj))
+	for i, nat := range *obj {
+		arr := expr.AsArray(nat.Attribute.Type)
+		fields[i] = &MapObjectFieldData{
+			Name:            nat.Name,
+			FieldName:       codegen.Goify(nat.Name, true),
+			VarName:         scope.Unique(codegen.Goify(nat.Name, false)),
+			Type:            nat.Attribute.Type,
+			TypeRef:         svc.Scope.GoTypeRef(nat.Attribute),
+			Required:        att.IsRequired(nat.Name),
+			Pointer:         att.IsPrimitivePointer(nat.Name, true),
+			Slice:           arr != nil,
+			StringSlice:     arr != nil && arr.ElemType.Type.Kind() == expr.StringKind,
+			DefaultValue:    nat.Attribute.DefaultValue,
+			TimestampFormat: nat.Attribute.TimestampFormat(),
+		}
+	}
+	return &MapObjectData{
+		TypeName: svc.Scope.GoFullTypeName(att, svc.PkgName),
+		Fields:   fields,
+		Validate: codegen.RecursiveValidationCode(att, serviceContext(svc.PkgName, svc.Scope), true, "val"),
+	}
+}
+
 // httpContext returns a context for attributes of types used to marshal and
 // unmarshal HTTP requests and responses.
 //
@@ -2415,7 +3441,9 @@ func httpContext(pkg string, scope *codegen.NameScope, request, svr bool) *codeg
 	if !marshal {
 		ptr = true
 	}
-	return codegen.NewAttributeContext(ptr, false, marshal, pkg, scope)
+	ctx := codegen.NewAttributeContext(ptr, false, marshal, pkg, scope)
+	ctx.Scope = &bodyScope{ctx.Scope}
+	return ctx
 }
 
 // serviceContext returns an attribute context for service types.
@@ -2521,7 +3549,7 @@ func upgradeParams(e *EndpointData, fn string) map[string]interface{} {
 }
 
 // needStream returns true if at least one method in the defined services
-// uses stream for sending payload/result.
+// uses a websocket stream for sending payload/result.
 func needStream(data []*ServiceData) bool {
 	for _, svc := range data {
 		if streamingEndpointExists(svc) {
@@ -2532,7 +3560,7 @@ func needStream(data []*ServiceData) bool {
 }
 
 // streamingEndpointExists returns true if at least one of the endpoints in
-// the service defines a streaming payload or result.
+// the service streams its payload or result through a websocket connection.
 func streamingEndpointExists(sd *ServiceData) bool {
 	for _, e := range sd.Endpoints {
 		if isStreamingEndpoint(e) {
@@ -2542,9 +3570,56 @@ func streamingEndpointExists(sd *ServiceData) bool {
 	return false
 }
 
-// isStreamingEndpoint returns true if the endpoint defines a streaming payload
-// or result.
+// idempotentEndpointExists returns true if at least one of the service
+// endpoints is idempotent.
+func idempotentEndpointExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.Idempotent {
+			return true
+		}
+	}
+	return false
+}
+
+// compressionEndpointExists returns true if at least one of the service
+// endpoints compresses its responses.
+func compressionEndpointExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if len(e.Compression) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// canaryEndpointExists returns true if at least one endpoint of the service
+// has a canary implementation.
+func canaryEndpointExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.Canary != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// signedURLEndpointExists returns true if at least one endpoint of the service
+// requires signed URLs.
+func signedURLEndpointExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.SignedURL {
+			return true
+		}
+	}
+	return false
+}
+
+// isStreamingEndpoint returns true if the endpoint streams its payload or
+// result through a websocket connection.
 func isStreamingEndpoint(ed *EndpointData) bool {
+	if ed.ServerStream != nil && (ed.ServerStream.NDJSON || ed.ServerStream.Export != nil) {
+		return false
+	}
 	return ed.ServerStream != nil || ed.ClientStream != nil
 }
 
@@ -2557,12 +3632,17 @@ const (
 		{{- if eq $typ.Name "array" }}
 	{{ .Name }}Slice := make([]string, len({{ .Name }}))
 	for i, v := range {{ .Name }} {
+		{{- if eq $typ.ElemType.Type.Name "timestamp" }}
+		{{ .Name }}Slice[i] = url.QueryEscape(goa.FormatTimestamp(v, {{ printf "%q" (index $.PathParams $i).Attribute.TimestampFormat }}))
+		{{- else }}
 		{{ .Name }}Slice[i] = {{ template "slice_conversion" $typ.ElemType.Type.Name }}
+		{{- end }}
 	}
 		{{- end }}
 	{{- end }}
 	return fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}
 	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .Name }}Slice, ", ")
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "timestamp" }}goa.FormatTimestamp({{ .Name }}, {{ printf "%q" (index $.PathParams $i).Attribute.TimestampFormat }})
 	{{- else }}{{ .Name }}
 	{{- end }}, {{ end }})
 {{- else }}
@@ -2579,6 +3659,7 @@ const (
 	{{- else if eq . "float64" }} strconv.FormatFloat(v, 'f', -1, 64)
 	{{- else if eq . "boolean" }} strconv.FormatBool(v)
 	{{- else if eq . "bytes" }} url.QueryEscape(string(v))
+	{{- else if eq . "duration" "uuid" "decimal" }} url.QueryEscape(v.String())
 	{{- else }} url.QueryEscape(fmt.Sprintf("%v", v))
 	{{- end }}
 {{- end }}`
@@ -2624,8 +3705,33 @@ const (
 	if err != nil {
 		return nil, goahttp.ErrInvalidURL("{{ .ServiceName }}", "{{ .EndpointName }}", u.String(), err)
 	}
+{{- if .Version }}
+	goahttp.SetRequestVersion(req, {{ printf "%q" .VersionHeader }}, {{ printf "%q" .Version }})
+{{- end }}
 	if ctx != nil {
 		req = req.WithContext(ctx)
+	{{- if .ViewParam }}
+		if view, ok := ctx.Value(goa.ViewKey).(string); ok && view != "" {
+			values := req.URL.Query()
+			values.Add({{ printf "%q" .ViewParam }}, view)
+			req.URL.RawQuery = values.Encode()
+		}
+	{{- end }}
+	{{- if .FieldMask }}
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok && len(mask) > 0 {
+			values := req.URL.Query()
+			values.Add("fields", mask.String())
+			req.URL.RawQuery = values.Encode()
+		}
+	{{- end }}
+	{{- if .ETag }}
+		if etag, ok := ctx.Value(goahttp.IfMatchKey).(string); ok && etag != "" {
+			req.Header.Set("If-Match", etag)
+		}
+		if etag, ok := ctx.Value(goahttp.IfNoneMatchKey).(string); ok && etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+	{{- end }}
 	}
 
 	return req, nil`
@@ -2635,6 +3741,23 @@ const (
 	// input: StreamData
 	streamStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
 type {{ .VarName }} struct {
+{{- if .NDJSON }}
+	{{- if eq .Type "server" }}
+	{{ comment "w writes the results to the HTTP response." }}
+	w *goahttp.NDJSONWriter
+	{{- else }}
+	{{ comment "r reads the results from the HTTP response body." }}
+	r *goahttp.NDJSONReader
+	{{- end }}
+{{- else if .Export }}
+	{{- if eq .Type "server" }}
+	{{ comment "w writes the rows to the HTTP response." }}
+	w *goahttp.ExportWriter
+	{{- else }}
+	{{ comment "r reads the rows from the HTTP response body." }}
+	r *goahttp.ExportReader
+	{{- end }}
+{{- else }}
 {{- if eq .Type "server" }}
 	once sync.Once
 	{{ comment "upgrader is the websocket connection upgrader." }}
@@ -2650,9 +3773,16 @@ type {{ .VarName }} struct {
 {{- end }}
 	{{ comment "conn is the underlying websocket connection." }}
 	conn *websocket.Conn
+{{- end }}
 	{{- if .Endpoint.Method.ViewedResult }}
 		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+		{{- if and .NDJSON (eq .Type "client") }}
+	{{ comment "view is the view used to render the results read from the HTTP response." }}
+		{{- else if .NDJSON }}
+	{{ printf "view is the view to render %s result type before sending to the HTTP response." .SendTypeName | comment }}
+		{{- else }}
 	{{ printf "view is the view to render %s result type before sending to the websocket connection." .SendTypeName | comment }}
+		{{- end }}
 	view string
 		{{- end }}
 	{{- end }}
@@ -2693,8 +3823,21 @@ func NewConnConfigurer(fn goahttp.ConnConfigureFunc) *ConnConfigurer {
 	// input: StreamData
 	streamSendT = `{{ comment .SendDesc }}
 func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
-{{- if eq .Type "server" }}
-	{{- if eq .SendName "Send" }}
+{{- if .Export }}
+	row := make([]string, {{ len .Export.Columns }})
+	{{- range $i, $c := .Export.Columns }}
+		{{- if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		row[{{ $i }}] = {{ printf .Format (printf "*v.%s" .FieldName) }}
+	}
+		{{- else }}
+	row[{{ $i }}] = {{ printf .Format (printf "v.%s" .FieldName) }}
+		{{- end }}
+	{{- end }}
+	return s.w.WriteRow(row)
+{{- else if eq .Type "server" }}
+	{{- if .NDJSON }}
+	{{- else if eq .SendName "Send" }}
 		var err error
 		{{- template "websocket_upgrade" (upgradeParams .Endpoint .SendName) }}
 	{{- else }} {{/* SendAndClose */}}
@@ -2729,12 +3872,12 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 			{{- else }}
 				body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
 			{{- end }}
-			return s.conn.WriteJSON(body)
+			return s.{{ if .NDJSON }}w.Write{{ else }}conn.WriteJSON{{ end }}(body)
 		{{- else }}
-			return s.conn.WriteJSON(res)
+			return s.{{ if .NDJSON }}w.Write{{ else }}conn.WriteJSON{{ end }}(res)
 		{{- end }}
 	{{- else }}
-		return s.conn.WriteJSON(res)
+		return s.{{ if .NDJSON }}w.Write{{ else }}conn.WriteJSON{{ end }}(res)
 	{{- end }}
 {{- else }}
 	{{- if .Payload.Init }}
@@ -2752,6 +3895,43 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 	// input: StreamData
 	streamRecvT = `{{ comment .RecvDesc }}
 func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+{{- if .Export }}
+	row, err := s.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	res := &{{ .RecvTypeName }}{}
+	{{- range $i, $c := .Export.Columns }}
+		{{- if .Parse }}
+	if row[{{ $i }}] != "" {
+		v, err := {{ printf .Parse (printf "row[%d]" $i) }}
+		if err != nil {
+			return nil, goa.InvalidFieldTypeError({{ printf "%q" .Name }}, row[{{ $i }}], {{ printf "%q" .TypeName }})
+		}
+			{{- if and .Pointer (eq .Conv "%s") }}
+		res.{{ .FieldName }} = &v
+			{{- else if .Pointer }}
+		cv := {{ printf .Conv "v" }}
+		res.{{ .FieldName }} = &cv
+			{{- else }}
+		res.{{ .FieldName }} = {{ printf .Conv "v" }}
+			{{- end }}
+	}
+		{{- else if and .Pointer (eq .Conv "%s") }}
+	if row[{{ $i }}] != "" {
+		res.{{ .FieldName }} = &row[{{ $i }}]
+	}
+		{{- else if .Pointer }}
+	if row[{{ $i }}] != "" {
+		cv := {{ printf .Conv (printf "row[%d]" $i) }}
+		res.{{ .FieldName }} = &cv
+	}
+		{{- else }}
+	res.{{ .FieldName }} = {{ printf .Conv (printf "row[%d]" $i) }}
+		{{- end }}
+	{{- end }}
+	return res, nil
+{{- else }}
 	var (
 		rv {{ .RecvTypeRef }}
 	{{- if eq .Type "server" }}
@@ -2782,6 +3962,11 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 		return body, nil
 	{{- end }}
 {{- else }} {{/* client side code */}}
+	{{- if .NDJSON }}
+	if err = s.r.Read(&body); err != nil {
+		return rv, err
+	}
+	{{- else }}
 	{{- if eq .RecvName "CloseAndRecv" }}
 		defer s.conn.Close()
 		{{ comment "Send a nil payload to the server implying end of message" }}
@@ -2799,6 +3984,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	if err != nil {
 		return rv, err
 	}
+	{{- end }}
 	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
 	{{ .Response.ClientBody.ValidateRef }}
 	if err != nil {
@@ -2820,6 +4006,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 		return body, nil
 	{{- end }}
 {{- end }}
+{{- end }}
 }
 ` + upgradeT
 
@@ -2865,7 +4052,17 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	// streamCloseT renders the function implementing the Close method in
 	// stream interface.
 	// input: StreamData
-	streamCloseT = `{{ printf "Close closes the %q endpoint websocket connection." .Endpoint.Method.Name | comment }}
+	streamCloseT = `{{- if or .NDJSON .Export }}
+{{ printf "Close ends the %q endpoint HTTP response." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	{{- if eq .Type "server" }}
+	return s.w.Close()
+	{{- else }}
+	return s.r.Close()
+	{{- end }}
+}
+{{- else }}
+{{ printf "Close closes the %q endpoint websocket connection." .Endpoint.Method.Name | comment }}
 func (s *{{ .VarName }}) Close() error {
 	var err error
 {{- if eq .Type "server" }}
@@ -2887,14 +4084,22 @@ func (s *{{ .VarName }}) Close() error {
 {{- end }}
 	return s.conn.Close()
 }
+{{- end }}
 ` + upgradeT
 
 	// streamSetViewT renders the function implementing the SetView method in
 	// server stream interface.
 	// input: StreamData
-	streamSetViewT = `{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint websocket connection." .SendTypeName .Endpoint.Method.Name | comment }}
+	streamSetViewT = `{{- if .NDJSON }}
+{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint HTTP response." .SendTypeName .Endpoint.Method.Name | comment }}
+{{- else }}
+{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint websocket connection." .SendTypeName .Endpoint.Method.Name | comment }}
+{{- end }}
 func (s *{{ .VarName }}) SetView(view string) {
 	s.view = view
+	{{- if and .NDJSON (eq .Type "server") }}
+	s.w.Header().Set("goa-view", view)
+	{{- end }}
 }
 `
 )
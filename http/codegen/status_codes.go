@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// StatusCodesData contains the data needed to render the status codes
+	// of an endpoint.
+	StatusCodesData struct {
+		// VarName is the name of the variable that lists the status
+		// codes.
+		VarName string
+		// FuncName is the name of the function that describes the
+		// responses for each status code.
+		FuncName string
+		// ServiceName is the name of the service.
+		ServiceName string
+		// MethodName is the name of the method.
+		MethodName string
+		// Codes lists the status codes sorted by value.
+		Codes []*StatusCodeData
+	}
+
+	// StatusCodeData describes the responses of an endpoint that use a
+	// given status code.
+	StatusCodeData struct {
+		// Code is the Go expression of the status code, e.g.
+		// "http.StatusOK".
+		Code string
+		// Description describes the responses.
+		Description string
+	}
+)
+
+// StatusCodeFiles returns the files that list the HTTP status codes of the
+// responses of each endpoint if the design uses StrictStatusCodes.
+func StatusCodeFiles(root *expr.RootExpr) []*codegen.File {
+	if !root.API.HTTP.StrictStatusCodes {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		if f := statusCodesFile(svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// statusCodesFile returns the client file that lists the HTTP status codes of
+// the responses of the given service endpoints.
+func statusCodesFile(svc *expr.HTTPServiceExpr) *codegen.File {
+	if len(svc.HTTPEndpoints) == 0 {
+		return nil
+	}
+	data := HTTPServices.Get(svc.Name())
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(data.Service.VarName), "client", "status_codes.go")
+	title := fmt.Sprintf("%s HTTP response status codes", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{{Path: "net/http"}}),
+	}
+	for _, e := range svc.HTTPEndpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "status-codes",
+			Source: statusCodesT,
+			Data:   buildStatusCodesData(e, data),
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// buildStatusCodesData builds the data needed to render the status codes of
+// the given endpoint.
+func buildStatusCodesData(e *expr.HTTPEndpointExpr, sd *ServiceData) *StatusCodesData {
+	ed := sd.Endpoint(e.Name())
+	var codes []*StatusCodeData
+	for _, s := range e.StatusCodes() {
+		var descs []string
+		if s.Response {
+			descs = append(descs, "success response")
+		}
+		for _, name := range s.Errors {
+			descs = append(descs, fmt.Sprintf("%q error", name))
+		}
+		descs = append(descs, s.Generated...)
+		codes = append(codes, &StatusCodeData{
+			Code:        statusCodeToHTTPConst(s.Code),
+			Description: strings.Join(descs, "; "),
+		})
+	}
+	return &StatusCodesData{
+		VarName:     ed.Method.VarName + "StatusCodes",
+		FuncName:    ed.Method.VarName + "Status",
+		ServiceName: sd.Service.Name,
+		MethodName:  ed.Method.Name,
+		Codes:       codes,
+	}
+}
+
+// input: StatusCodesData
+const statusCodesT = `{{ printf "%s lists the HTTP status codes of all the responses of the %q endpoint of the %q service." .VarName .MethodName .ServiceName | comment }}
+var {{ .VarName }} = []int{
+{{- range .Codes }}
+	{{ .Code }},
+{{- end }}
+}
+
+{{ printf "%s returns the description of the responses of the %q endpoint of the %q service that use the given HTTP status code and true if the endpoint may write responses with this status code, false otherwise." .FuncName .MethodName .ServiceName | comment }}
+func {{ .FuncName }}(code int) (string, bool) {
+	switch code {
+{{- range .Codes }}
+	case {{ .Code }}:
+		return {{ printf "%q" .Description }}, true
+{{- end }}
+	default:
+		return "", false
+	}
+}
+`
@@ -0,0 +1,44 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestStatusCodeFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"strict-status-codes", testdata.StrictStatusCodesDSL, testdata.StrictStatusCodesCode},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := StatusCodeFiles(expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d file(s), expected 1", len(fs))
+			}
+			sections := fs[0].Section("status-codes")
+			if len(sections) != 1 {
+				t.Fatalf("got %d section(s), expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestStatusCodeFilesNotStrict(t *testing.T) {
+	RunHTTPDSL(t, testdata.PathNoParamDSL)
+	if fs := StatusCodeFiles(expr.Root); len(fs) != 0 {
+		t.Errorf("got %d file(s), expected none", len(fs))
+	}
+}
@@ -78,6 +78,21 @@ func TestServerStreaming(t *testing.T) {
 		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadDSL, []*sectionExpectation{
 			{"server-handler-init", &testdata.StreamingResultNoPayloadServerHandlerInitCode},
 		}},
+		{"streaming-result-ndjson", testdata.StreamingResultNDJSONDSL, []*sectionExpectation{
+			{"server-stream-conn-configurer-struct", nil},
+			{"server-stream-struct-type", &testdata.StreamingResultNDJSONServerStreamStructCode},
+			{"server-handler-init", &testdata.StreamingResultNDJSONServerHandlerInitCode},
+			{"server-stream-send", &testdata.StreamingResultNDJSONServerStreamSendCode},
+			{"server-stream-close", &testdata.StreamingResultNDJSONServerStreamCloseCode},
+			{"server-stream-set-view", &testdata.StreamingResultNDJSONServerStreamSetViewCode},
+		}},
+		{"streaming-result-export", testdata.StreamingResultExportDSL, []*sectionExpectation{
+			{"server-stream-conn-configurer-struct", nil},
+			{"server-stream-struct-type", &testdata.StreamingResultExportServerStreamStructCode},
+			{"server-handler-init", &testdata.StreamingResultExportServerHandlerInitCode},
+			{"server-stream-send", &testdata.StreamingResultExportServerStreamSendCode},
+			{"server-stream-close", &testdata.StreamingResultExportServerStreamCloseCode},
+		}},
 
 		// streaming payload
 
@@ -222,6 +237,20 @@ func TestClientStreaming(t *testing.T) {
 			{"client-stream-close", nil},
 			{"client-stream-set-view", &testdata.StreamingResultWithViewsClientStreamSetViewCode},
 		}},
+		{"streaming-result-ndjson", testdata.StreamingResultNDJSONDSL, []*sectionExpectation{
+			{"client-stream-conn-configurer-struct", nil},
+			{"client-stream-struct-type", &testdata.StreamingResultNDJSONClientStreamStructCode},
+			{"client-endpoint-init", &testdata.StreamingResultNDJSONClientEndpointCode},
+			{"client-stream-recv", &testdata.StreamingResultNDJSONClientStreamRecvCode},
+			{"client-stream-close", nil},
+		}},
+		{"streaming-result-export", testdata.StreamingResultExportDSL, []*sectionExpectation{
+			{"client-stream-conn-configurer-struct", nil},
+			{"client-stream-struct-type", &testdata.StreamingResultExportClientStreamStructCode},
+			{"client-endpoint-init", &testdata.StreamingResultExportClientEndpointCode},
+			{"client-stream-recv", &testdata.StreamingResultExportClientStreamRecvCode},
+			{"client-stream-close", nil},
+		}},
 		{"streaming-result-with-explicit-view", testdata.StreamingResultWithExplicitViewDSL, []*sectionExpectation{
 			{"client-endpoint-init", &testdata.StreamingResultWithExplicitViewClientEndpointCode},
 			{"client-stream-recv", &testdata.StreamingResultWithExplicitViewClientStreamRecvCode},
@@ -0,0 +1,198 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// TestServerFiles returns the files that implement the test HTTP server
+// helpers of the services. The helpers are generated in the <service>test
+// package alongside the in-memory clients.
+func TestServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	fw := make([]*codegen.File, len(root.API.HTTP.Services))
+	for i, svc := range root.API.HTTP.Services {
+		fw[i] = testServerFile(genpkg, svc)
+	}
+	return fw
+}
+
+// testServerFile returns the file implementing the test HTTP server helpers of
+// the given service.
+func testServerFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	svcName := codegen.SnakeCase(data.Service.VarName)
+	fpath := filepath.Join(codegen.Gendir, svcName, svcName+"test", "http_server.go")
+	var extra []string
+	if streamingEndpointExists(data) {
+		extra = append(extra, "&websocket.Upgrader{}", "nil")
+	}
+	for _, e := range data.Endpoints {
+		if e.MultipartRequestDecoder != nil {
+			extra = append(extra, "o."+e.MultipartRequestDecoder.VarName)
+		}
+	}
+	if canaryEndpointExists(data) {
+		extra = append(extra, "nil")
+	}
+	var extraArgs string
+	if len(extra) > 0 {
+		extraArgs = ", " + strings.Join(extra, ", ")
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" test HTTP server", data.Service.PkgName+"test", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "fmt"},
+			{Path: "mime/multipart"},
+			{Path: "net/http"},
+			{Path: "net/http/httptest"},
+			{Path: "github.com/gorilla/websocket"},
+			codegen.GoaImport(""),
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+			{Path: path.Join(genpkg, "http", svcName, "server"), Name: data.Service.PkgName + "svr"},
+		}),
+		{
+			Name:   "test-http-server",
+			Source: testHTTPServerT,
+			Data: map[string]interface{}{
+				"Service":   data,
+				"SvcPkg":    data.Service.PkgName,
+				"ServerPkg": data.Service.PkgName + "svr",
+				"ExtraArgs": extraArgs,
+			},
+		},
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// input: map[string]interface{}{"Service": *ServiceData, "SvcPkg": string, "ServerPkg": string, "ExtraArgs": string}
+const testHTTPServerT = `// HTTPServerOption configures the servers created by NewTestHTTPServer.
+type HTTPServerOption func(*httpServerOptions)
+
+// httpServerOptions holds the test HTTP server options.
+type httpServerOptions struct {
+	dec                 func(*http.Request) goahttp.Decoder
+	enc                 func(context.Context, http.ResponseWriter) goahttp.Encoder
+	eh                  func(context.Context, http.ResponseWriter, error)
+	middlewares         []func(http.Handler) http.Handler
+	endpointMiddlewares []func(goa.Endpoint) goa.Endpoint
+{{- if .Service.Service.Schemes }}
+	bypassSecurity      bool
+	principal           interface{}
+{{- end }}
+{{- range .Service.Endpoints }}
+	{{- if .MultipartRequestDecoder }}
+	{{ .MultipartRequestDecoder.VarName }} {{ $.ServerPkg }}.{{ .MultipartRequestDecoder.FuncName }}
+	{{- end }}
+{{- end }}
+}
+
+// WithHTTPDecoder sets the request decoder used by the test server. The
+// default is goahttp.RequestDecoder.
+func WithHTTPDecoder(dec func(*http.Request) goahttp.Decoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.dec = dec
+	}
+}
+
+// WithHTTPEncoder sets the response encoder used by the test server. The
+// default is goahttp.ResponseEncoder.
+func WithHTTPEncoder(enc func(context.Context, http.ResponseWriter) goahttp.Encoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.enc = enc
+	}
+}
+
+// WithHTTPErrorHandler sets the function called by the test server when
+// encoding a response or an error fails. The default writes a 500 response
+// with the error message.
+func WithHTTPErrorHandler(eh func(context.Context, http.ResponseWriter, error)) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.eh = eh
+	}
+}
+
+// WithHTTPMiddleware adds a HTTP middleware applied to the test server
+// handlers. Middlewares are applied in the order they are added.
+func WithHTTPMiddleware(m func(http.Handler) http.Handler) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.middlewares = append(o.middlewares, m)
+	}
+}
+
+// WithEndpointMiddleware adds a middleware applied to the service endpoints
+// served by the test server. Middlewares are applied in the order they are
+// added.
+func WithEndpointMiddleware(m func(goa.Endpoint) goa.Endpoint) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.endpointMiddlewares = append(o.endpointMiddlewares, m)
+	}
+}
+
+{{- if .Service.Service.Schemes }}
+
+// WithTestPrincipal makes the test server authorize all requests regardless of
+// their credentials. The service authorization functions are bypassed and the
+// given principal is stored in the request context instead, see
+// BypassSecurity.
+func WithTestPrincipal(principal interface{}) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.bypassSecurity = true
+		o.principal = principal
+	}
+}
+{{- end }}
+
+{{- range .Service.Endpoints }}
+	{{- if .MultipartRequestDecoder }}
+
+{{ printf "With%sMultipartDecoder sets the function used by the test server to decode the multipart requests sent to the %q endpoint. The default returns an error." .Method.VarName .Method.Name | comment }}
+func With{{ .Method.VarName }}MultipartDecoder(fn {{ $.ServerPkg }}.{{ .MultipartRequestDecoder.FuncName }}) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.{{ .MultipartRequestDecoder.VarName }} = fn
+	}
+}
+	{{- end }}
+{{- end }}
+
+{{ printf "NewTestHTTPServer starts and returns a server that serves the %q service HTTP endpoints implemented by impl. Tests send requests to the server URL and must call Close on the server once done to shut it down." .Service.Service.Name | comment }}
+func NewTestHTTPServer(impl {{ .SvcPkg }}.Service, opts ...HTTPServerOption) *httptest.Server {
+	o := &httpServerOptions{
+		dec: goahttp.RequestDecoder,
+		enc: goahttp.ResponseEncoder,
+		eh: func(ctx context.Context, w http.ResponseWriter, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+{{- range .Service.Endpoints }}
+	{{- if .MultipartRequestDecoder }}
+		{{ .MultipartRequestDecoder.VarName }}: func(*multipart.Reader, *{{ .MultipartRequestDecoder.Payload.Ref }}) error {
+			return fmt.Errorf("no multipart decoder set for the %q endpoint, use With{{ .Method.VarName }}MultipartDecoder", {{ printf "%q" .Method.Name }})
+		},
+	{{- end }}
+{{- end }}
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+{{- if .Service.Service.Schemes }}
+	if o.bypassSecurity {
+		impl = BypassSecurity(impl, o.principal)
+	}
+{{- end }}
+	endpoints := {{ .SvcPkg }}.NewEndpoints(impl)
+	for _, m := range o.endpointMiddlewares {
+		endpoints.Use(m)
+	}
+	mux := goahttp.NewMuxer()
+	server := {{ .ServerPkg }}.{{ .Service.ServerInit }}(endpoints, mux, o.dec, o.enc, o.eh{{ .ExtraArgs }})
+	for _, m := range o.middlewares {
+		server.Use(m)
+	}
+	{{ .ServerPkg }}.{{ .Service.MountServer }}(mux{{ if .Service.Endpoints }}, server{{ end }})
+	return httptest.NewServer(mux)
+}
+`
@@ -0,0 +1,36 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestTestServerFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"simple", testdata.ServerMultiEndpointsDSL, testdata.SimpleTestServerCode},
+		{"multipart", testdata.PayloadMultipartPrimitiveDSL, testdata.MultipartTestServerCode},
+		{"streaming", testdata.StreamingResultDSL, testdata.StreamingTestServerCode},
+		{"secure", testdata.HARDSL, testdata.SecureTestServerCode},
+		{"file-server", testdata.ServerFileServerDSL, testdata.FileServerTestServerCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := TestServerFiles("", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			code := codegen.SectionsCode(t, fs[0].SectionTemplates[1:])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
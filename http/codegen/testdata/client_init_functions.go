@@ -49,5 +49,106 @@ func NewClient(
 		configurer:                cfn,
 	}
 }
+`
+
+	EnvironmentsClientInitCode = `// NewClientForEnvironment instantiates HTTP clients for all the testService
+// service servers that send the requests to the server of the given
+// environment as defined in the design. The valid environments are dev,
+// staging, prod.
+func NewClientForEnvironment(
+	env string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) (*Client, error) {
+	var scheme, host string
+	switch env {
+	case "dev":
+		scheme, host = "http", "localhost:8000"
+	case "staging":
+		scheme, host = "https", "eu.goa.design"
+	case "prod":
+		scheme, host = "https", "us.goa.design"
+	default:
+		return nil, fmt.Errorf("unknown environment %q, valid environments are %s", env, "dev, staging, prod")
+	}
+	return NewClient(scheme, host, doer, enc, dec, restoreBody), nil
+}
+`
+
+	EncoderOptionsClientInitCode = `// NewClient instantiates HTTP clients for all the ServiceEncoderOptions
+// service servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) *Client {
+	dec = goahttp.StripJSONPrefix(dec, ")]}',\n")
+	return &Client{
+		MethodEncoderOptionsDoer: doer,
+		RestoreResponseBody:      restoreBody,
+		scheme:                   scheme,
+		host:                     host,
+		decoder:                  dec,
+		encoder:                  enc,
+	}
+}
 `
 )
+
+var RequestCompressionClientStructCode = `// Client lists the ServiceRequestCompression service endpoint HTTP clients.
+type Client struct {
+	// MethodRequestCompression Doer is the HTTP client used to make requests to
+	// the MethodRequestCompression endpoint.
+	MethodRequestCompressionDoer goahttp.Doer
+
+	// RestoreResponseBody controls whether the response bodies are reset after
+	// decoding so they can be read again.
+	RestoreResponseBody bool
+
+	// RequestCompressionThreshold is the minimum size in bytes of the request
+	// bodies compressed by the endpoints whose server accepts compressed
+	// requests, zero disables the compression.
+	RequestCompressionThreshold int64
+
+	scheme  string
+	host    string
+	encoder func(*http.Request) goahttp.Encoder
+	decoder func(*http.Response) goahttp.Decoder
+}
+`
+
+var RequestCompressionClientEndpointCode = `// MethodRequestCompression returns an endpoint that makes HTTP requests to the
+// ServiceRequestCompression service MethodRequestCompression server.
+func (c *Client) MethodRequestCompression() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeMethodRequestCompressionRequest(c.encoder)
+		decodeResponse = DecodeMethodRequestCompressionResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildMethodRequestCompressionRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		if c.RequestCompressionThreshold > 0 {
+			if err := goahttp.CompressRequestBody(req, "gzip", c.RequestCompressionThreshold); err != nil {
+				return nil, goahttp.ErrEncodingError("ServiceRequestCompression", "MethodRequestCompression", err)
+			}
+		}
+		resp, err := c.MethodRequestCompressionDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceRequestCompression", "MethodRequestCompression", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
@@ -0,0 +1,35 @@
+package testdata
+
+var ClientInteropCode = `// ExposeJS sets the "serviceInterop" property of target, e.g. js.Global(), to
+// an object whose methods call the "ServiceInterop" service endpoints with c.
+// The methods take the payload as their argument and return a Promise resolved
+// with the result, the payloads and results are converted from and to
+// JavaScript values with their JSON representation.
+func ExposeJS(c *Client, target js.Value) {
+	target.Set("serviceInterop", goahttp.JSObject(map[string]goahttp.JSEndpoint{
+		"create": func(ctx context.Context, payload []byte) (interface{}, error) {
+			if payload == nil {
+				return nil, fmt.Errorf("missing payload")
+			}
+			var p *serviceinterop.Item
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, fmt.Errorf("invalid payload: %s", err)
+			}
+			return c.Create()(ctx, p)
+		},
+		"ping": func(ctx context.Context, payload []byte) (interface{}, error) {
+			return c.Ping()(ctx, nil)
+		},
+		"echo": func(ctx context.Context, payload []byte) (interface{}, error) {
+			if payload == nil {
+				return nil, fmt.Errorf("missing payload")
+			}
+			var p string
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, fmt.Errorf("invalid payload: %s", err)
+			}
+			return c.Echo()(ctx, p)
+		},
+	}))
+}
+`
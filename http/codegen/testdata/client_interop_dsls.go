@@ -0,0 +1,49 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ClientInteropDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", String)
+		Attribute("count", Int)
+		Required("id")
+	})
+	Service("ServiceInterop", func() {
+		Meta("wasm:interop")
+		Method("Create", func() {
+			Payload(Item)
+			Result(Item)
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+		Method("Ping", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+		Method("Echo", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/echo")
+			})
+		})
+		Method("Chat", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/chat")
+			})
+		})
+	})
+	Service("ServiceNoInterop", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
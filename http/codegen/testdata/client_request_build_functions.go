@@ -82,3 +82,86 @@ func (c *Client) BuildMethodPathStringDefaultRequest(ctx context.Context, v inte
 	return req, nil
 }
 `
+
+var ViewParamRequestBuildCode = `// BuildMethodViewParamRequest instantiates a HTTP request object with method
+// and path set to call the "ServiceViewParam" service "MethodViewParam"
+// endpoint
+func (c *Client) BuildMethodViewParamRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodViewParamServiceViewParamPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceViewParam", "MethodViewParam", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+		if view, ok := ctx.Value(goa.ViewKey).(string); ok && view != "" {
+			values := req.URL.Query()
+			values.Add("view", view)
+			req.URL.RawQuery = values.Encode()
+		}
+	}
+
+	return req, nil
+}
+`
+
+var ETagRequestBuildCode = `// BuildMethodETagRequest instantiates a HTTP request object with method and
+// path set to call the "ServiceETag" service "MethodETag" endpoint
+func (c *Client) BuildMethodETagRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodETagServiceETagPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceETag", "MethodETag", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+		if etag, ok := ctx.Value(goahttp.IfMatchKey).(string); ok && etag != "" {
+			req.Header.Set("If-Match", etag)
+		}
+		if etag, ok := ctx.Value(goahttp.IfNoneMatchKey).(string); ok && etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	return req, nil
+}
+`
+
+var VersionedRequestBuildCode = `// BuildListV1Request instantiates a HTTP request object with method and path
+// set to call the "ServiceVersioned" service "ListV1" endpoint
+func (c *Client) BuildListV1Request(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: ListV1ServiceVersionedPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceVersioned", "ListV1", u.String(), err)
+	}
+	goahttp.SetRequestVersion(req, "X-API-Version", "1")
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	return req, nil
+}
+`
+
+var FieldMaskRequestBuildCode = `// BuildMethodFieldMaskRequest instantiates a HTTP request object with method
+// and path set to call the "ServiceFieldMask" service "MethodFieldMask"
+// endpoint
+func (c *Client) BuildMethodFieldMaskRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodFieldMaskServiceFieldMaskPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceFieldMask", "MethodFieldMask", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+		if mask, ok := ctx.Value(goa.FieldMaskKey).(goa.FieldMask); ok && len(mask) > 0 {
+			values := req.URL.Query()
+			values.Add("fields", mask.String())
+			req.URL.RawQuery = values.Encode()
+		}
+	}
+
+	return req, nil
+}
+`
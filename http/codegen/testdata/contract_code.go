@@ -0,0 +1,71 @@
+package testdata
+
+var ContractTestCode = `// catalogContractService is the catalog service implementation exercised by
+// the contract tests, the tests are skipped if it is nil. Set it to a fake of
+// the service, for example in the init function of a test file.
+var catalogContractService catalog.Service
+
+// serveCatalogContract serves req with the generated catalog service HTTP
+// handlers and returns the recorded response.
+func serveCatalogContract(t *testing.T, req *http.Request) *http.Response {
+	if catalogContractService == nil {
+		t.Skip("catalogContractService is not set")
+	}
+	var (
+		mux = goahttp.NewMuxer()
+		eh  = func(ctx context.Context, w http.ResponseWriter, err error) {
+			t.Errorf("failed to encode response: %s", err)
+		}
+	)
+	endpoints := catalog.NewEndpoints(catalogContractService)
+	server := catalogsvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh, nil, nil)
+	catalogsvr.Mount(mux, server)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	resp := w.Result()
+	resp.Request = req
+	return resp
+}
+
+// TestContractCatalogShow sends an example request to the "show" endpoint of
+// the "catalog" service and checks that the response matches the design.
+func TestContractCatalogShow(t *testing.T) {
+	req := httptest.NewRequest("GET", "/items/1?view=full", nil)
+	req.Header.Add("X-Tenant", "acme")
+
+	resp := serveCatalogContract(t, req)
+
+	_, err := catalogc.DecodeShowResponse(goahttp.ResponseDecoder, false)(resp)
+	if cerr, ok := err.(*goahttp.ClientError); ok {
+		t.Errorf("%s response does not match the design: %s", resp.Status, cerr)
+	}
+}
+
+// TestContractCatalogCreate sends an example request to the "create" endpoint
+// of the "catalog" service and checks that the response matches the design.
+func TestContractCatalogCreate(t *testing.T) {
+	req := httptest.NewRequest("POST", "/items", strings.NewReader("{\"name\":\"widget\"}"))
+	req.Header.Add("Content-Type", "application/json")
+
+	resp := serveCatalogContract(t, req)
+
+	_, err := catalogc.DecodeCreateResponse(goahttp.ResponseDecoder, false)(resp)
+	if cerr, ok := err.(*goahttp.ClientError); ok {
+		t.Errorf("%s response does not match the design: %s", resp.Status, cerr)
+	}
+}
+
+// TestContractCatalogCreate2 sends an example request to the "create" endpoint
+// of the "catalog" service and checks that the response matches the design.
+func TestContractCatalogCreate2(t *testing.T) {
+	req := httptest.NewRequest("POST", "/products", strings.NewReader("{\"name\":\"widget\"}"))
+	req.Header.Add("Content-Type", "application/json")
+
+	resp := serveCatalogContract(t, req)
+
+	_, err := catalogc.DecodeCreateResponse(goahttp.ResponseDecoder, false)(resp)
+	if cerr, ok := err.(*goahttp.ClientError); ok {
+		t.Errorf("%s response does not match the design: %s", resp.Status, cerr)
+	}
+}
+`
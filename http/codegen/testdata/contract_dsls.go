@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ContractDSL = func() {
+	Service("catalog", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", Int, func() {
+					Example(1)
+				})
+				Attribute("view", String, func() {
+					Example("full")
+				})
+				Attribute("tenant", String, func() {
+					Example("acme")
+				})
+				Required("id")
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/items/{id}")
+				Param("view")
+				Header("tenant:X-Tenant")
+				Response(StatusOK)
+			})
+		})
+		Method("create", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					Example("widget")
+				})
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/items")
+				POST("/products")
+				Response(StatusCreated)
+			})
+		})
+		Method("watch", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/items/watch")
+			})
+		})
+	})
+}
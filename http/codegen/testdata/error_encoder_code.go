@@ -12,6 +12,11 @@ func EncodeMethodPrimitiveErrorResponseError(encoder func(context.Context, http.
 		}
 		switch en.ErrorName() {
 		case "bad_request":
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusBadRequest)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "bad_request", errorMessages["bad_request"]))
+			}
 			res := v.(serviceprimitiveerrorresponse.BadRequest)
 			enc := encoder(ctx, w)
 			body := NewMethodPrimitiveErrorResponseBadRequestResponseBody(res)
@@ -19,6 +24,11 @@ func EncodeMethodPrimitiveErrorResponseError(encoder func(context.Context, http.
 			w.WriteHeader(http.StatusBadRequest)
 			return enc.Encode(body)
 		case "internal_error":
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusInternalServerError)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "internal_error", errorMessages["internal_error"]))
+			}
 			res := v.(serviceprimitiveerrorresponse.InternalError)
 			enc := encoder(ctx, w)
 			body := NewMethodPrimitiveErrorResponseInternalErrorResponseBody(res)
@@ -43,6 +53,11 @@ func EncodeMethodDefaultErrorResponseError(encoder func(context.Context, http.Re
 		}
 		switch en.ErrorName() {
 		case "bad_request":
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusBadRequest)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "bad_request", errorMessages["bad_request"]))
+			}
 			res := v.(*goa.ServiceError)
 			enc := encoder(ctx, w)
 			body := NewMethodDefaultErrorResponseBadRequestResponseBody(res)
@@ -67,6 +82,11 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 		}
 		switch en.ErrorName() {
 		case "internal_error":
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusInternalServerError)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "internal_error", errorMessages["internal_error"]))
+			}
 			res := v.(*goa.ServiceError)
 			enc := encoder(ctx, w)
 			body := NewMethodServiceErrorResponseInternalErrorResponseBody(res)
@@ -74,6 +94,11 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 			w.WriteHeader(http.StatusInternalServerError)
 			return enc.Encode(body)
 		case "bad_request":
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusBadRequest)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "bad_request", errorMessages["bad_request"]))
+			}
 			res := v.(*goa.ServiceError)
 			enc := encoder(ctx, w)
 			body := NewMethodServiceErrorResponseBadRequestResponseBody(res)
@@ -86,3 +111,104 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 	}
 }
 `
+
+var FixedHeadersErrorResponseEncoderCode = `// EncodeMethodFixedHeadersErrorResponseError returns an encoder for errors
+// returned by the MethodFixedHeadersErrorResponse
+// ServiceFixedHeadersErrorResponse endpoint.
+func EncodeMethodFixedHeadersErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "bad_request":
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusBadRequest)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "bad_request", errorMessages["bad_request"]))
+			}
+			res := v.(*goa.ServiceError)
+			enc := encoder(ctx, w)
+			body := NewMethodFixedHeadersErrorResponseBadRequestResponseBody(res)
+			w.Header().Set("goa-error", "bad_request")
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
+
+var DescribedErrorResponseMessagesCode = `// errorMessages maps the names of the errors returned by the
+// ServiceDescribedErrorResponse service endpoints to the messages written in
+// the error responses when the error verbosity is goahttp.ErrorSanitized.
+var errorMessages = map[string]string{
+	"bad_request": "Bad Request",
+	"not_found":   "The requested resource does not exist.",
+}
+`
+
+var RetryAfterErrorResponseEncoderCode = `// EncodeMethodRetryAfterErrorResponseError returns an encoder for errors
+// returned by the MethodRetryAfterErrorResponse ServiceRetryAfterErrorResponse
+// endpoint.
+func EncodeMethodRetryAfterErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "rate_limited":
+			goahttp.SetRetryAfter(w, v)
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "rate_limited", errorMessages["rate_limited"]))
+			}
+			res := v.(*goa.ServiceError)
+			enc := encoder(ctx, w)
+			body := NewMethodRetryAfterErrorResponseRateLimitedResponseBody(res)
+			w.Header().Set("goa-error", "rate_limited")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
+
+var PassThroughHeadersErrorResponseEncoderCode = `// EncodeMethodPassThroughHeadersError returns an encoder for errors returned
+// by the MethodPassThroughHeaders ServicePassThroughHeaders endpoint.
+func EncodeMethodPassThroughHeadersError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		goahttp.WritePassThroughHeaders(ctx, w)
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "bad_request":
+			if goahttp.ContextErrorVerbosity(ctx) == goahttp.ErrorSanitized {
+				enc := encoder(ctx, w)
+				w.WriteHeader(http.StatusBadRequest)
+				return enc.Encode(goahttp.NewSanitizedErrorResponse(v, "bad_request", errorMessages["bad_request"]))
+			}
+			res := v.(*goa.ServiceError)
+			enc := encoder(ctx, w)
+			body := NewMethodPassThroughHeadersBadRequestResponseBody(res)
+			w.Header().Set("goa-error", "bad_request")
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
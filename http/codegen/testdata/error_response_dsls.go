@@ -16,6 +16,21 @@ var DefaultErrorResponseDSL = func() {
 	})
 }
 
+var RetryAfterErrorResponseDSL = func() {
+	Service("ServiceRetryAfterErrorResponse", func() {
+		Method("MethodRetryAfterErrorResponse", func() {
+			Error("rate_limited", func() {
+				Temporary()
+				RetryAfter("30s")
+			})
+			HTTP(func() {
+				GET("/one/two")
+				Response("rate_limited", StatusTooManyRequests)
+			})
+		})
+	})
+}
+
 var PrimitiveErrorResponseDSL = func() {
 	Service("ServicePrimitiveErrorResponse", func() {
 		Method("MethodPrimitiveErrorResponse", func() {
@@ -45,3 +60,36 @@ var ServiceErrorResponseDSL = func() {
 		})
 	})
 }
+
+var DescribedErrorResponseDSL = func() {
+	Service("ServiceDescribedErrorResponse", func() {
+		Error("not_found", ErrorResult, "The requested resource does not exist.")
+		HTTP(func() {
+			Response("not_found", StatusNotFound)
+		})
+		Method("MethodDescribedErrorResponse", func() {
+			Error("bad_request")
+			HTTP(func() {
+				GET("/one/two")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
+
+var FixedHeadersErrorResponseDSL = func() {
+	API("FixedHeadersErrorResponse", func() {
+		HTTP(func() {
+			FixedHeader("X-Content-Type-Options", "nosniff")
+		})
+	})
+	Service("ServiceFixedHeadersErrorResponse", func() {
+		Method("MethodFixedHeadersErrorResponse", func() {
+			Error("bad_request")
+			HTTP(func() {
+				GET("/one/two")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
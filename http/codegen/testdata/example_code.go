@@ -44,12 +44,25 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	servicesvr.Mount(mux, serviceServer)
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
 	var handler http.Handler = mux
 	{
-		if debug {
-			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
-		}
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
 	}
@@ -65,20 +78,39 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	go func() {
 		defer (*wg).Done()
 
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
 		// Start HTTP server in a separate goroutine.
 		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down HTTP server at %q", u.Host)
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
 	}()
 }
 
@@ -137,12 +169,25 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 	servicesvr.Mount(mux)
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
 	var handler http.Handler = mux
 	{
-		if debug {
-			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
-		}
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
 	}
@@ -158,20 +203,39 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 	go func() {
 		defer (*wg).Done()
 
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
 		// Start HTTP server in a separate goroutine.
 		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down HTTP server at %q", u.Host)
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
 	}()
 }
 
@@ -230,12 +294,25 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	servicesvr.Mount(mux, serviceServer)
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
 	var handler http.Handler = mux
 	{
-		if debug {
-			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
-		}
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
 	}
@@ -251,20 +328,39 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	go func() {
 		defer (*wg).Done()
 
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
 		// Start HTTP server in a separate goroutine.
 		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down HTTP server at %q", u.Host)
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
 	}()
 }
 
@@ -326,12 +422,25 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	anotherservicesvr.Mount(mux, anotherServiceServer)
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
 	var handler http.Handler = mux
 	{
-		if debug {
-			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
-		}
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
 	}
@@ -350,20 +459,39 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 	go func() {
 		defer (*wg).Done()
 
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
 		// Start HTTP server in a separate goroutine.
 		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down HTTP server at %q", u.Host)
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
 		defer cancel()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
 	}()
 }
 
@@ -415,9 +543,11 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 		streamingServiceAServer *streamingserviceasvr.Server
 		streamingServiceBServer *streamingservicebsvr.Server
 	)
+	// The WebSocket connections of the streaming endpoints are tracked so
+	// that they can be closed with a close frame on shutdown.
+	upgrader := goahttp.NewDrainUpgrader(&websocket.Upgrader{})
 	{
 		eh := errorHandler(logger)
-		upgrader := &websocket.Upgrader{}
 		streamingServiceAServer = streamingserviceasvr.New(streamingServiceAEndpoints, mux, dec, enc, eh, upgrader, nil)
 		streamingServiceBServer = streamingservicebsvr.New(streamingServiceBEndpoints, mux, dec, enc, eh, upgrader, nil)
 	}
@@ -426,12 +556,25 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 	streamingservicebsvr.Mount(mux, streamingServiceBServer)
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
 	var handler http.Handler = mux
 	{
-		if debug {
-			handler = httpmdlwr.Debug(mux, os.Stdout)(handler)
-		}
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
 	}
@@ -450,20 +593,44 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 	go func() {
 		defer (*wg).Done()
 
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
 		// Start HTTP server in a separate goroutine.
 		go func() {
-			logger.Printf("HTTP server listening on %q", u.Host)
-			errc <- srv.ListenAndServe()
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
 		}()
 
 		<-ctx.Done()
-		logger.Printf("shutting down HTTP server at %q", u.Host)
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
 
-		// Shutdown gracefully with a 30s timeout.
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
 		defer cancel()
+		drained := make(chan error, 1)
+		go func() { drained <- upgrader.Drain(ctx) }()
 
-		srv.Shutdown(ctx)
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
+		if err := <-drained; err != nil {
+			logger.Printf("failed to close WebSocket connections gracefully: %s", err)
+		}
 	}()
 }
 
@@ -586,5 +753,814 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+`
+
+	CompressionServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceCompressionEndpoints *servicecompression.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Provide the transport specific request decoder and response encoder.
+	// The goa http package has built-in support for JSON, XML and gob.
+	// Other encodings can be used by providing the corresponding functions,
+	// see goa.design/encoding.
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.ResponseEncoder
+	)
+
+	// Build the service HTTP request multiplexer and configure it to serve
+	// HTTP requests to the service endpoints.
+	var mux goahttp.Muxer
+	{
+		mux = goahttp.NewMuxer()
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceCompressionServer *servicecompressionsvr.Server
+	)
+	{
+		eh := errorHandler(logger)
+		serviceCompressionServer = servicecompressionsvr.New(serviceCompressionEndpoints, mux, dec, enc, eh)
+		serviceCompressionServer.UseCompression()
+	}
+	// Configure the mux.
+	servicecompressionsvr.Mount(mux, serviceCompressionServer)
+
+	// Wrap the multiplexer with additional middlewares. Middlewares mounted
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
+	var handler http.Handler = mux
+	{
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
+		handler = httpmdlwr.Log(adapter)(handler)
+		handler = httpmdlwr.RequestID()(handler)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: handler}
+	for _, m := range serviceCompressionServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
+
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
+
+	HealthCheckServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Provide the transport specific request decoder and response encoder.
+	// The goa http package has built-in support for JSON, XML and gob.
+	// Other encodings can be used by providing the corresponding functions,
+	// see goa.design/encoding.
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.ResponseEncoder
+	)
+
+	// Build the service HTTP request multiplexer and configure it to serve
+	// HTTP requests to the service endpoints.
+	var mux goahttp.Muxer
+	{
+		mux = goahttp.NewMuxer()
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceServer *servicesvr.Server
+	)
+	{
+		eh := errorHandler(logger)
+		serviceServer = servicesvr.New(serviceEndpoints, mux, dec, enc, eh)
+	}
+	// Configure the mux.
+	servicesvr.Mount(mux, serviceServer)
+
+	// Mount the health check handlers, they run the checks registered in
+	// health.go.
+	mux.Handle("GET", "/healthz", goahttp.LivenessHandler(health))
+	mux.Handle("GET", "/readyz", goahttp.ReadinessHandler(health))
+
+	// Wrap the multiplexer with additional middlewares. Middlewares mounted
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
+	var handler http.Handler = mux
+	{
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
+		handler = httpmdlwr.Log(adapter)(handler)
+		handler = httpmdlwr.RequestID()(handler)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: handler}
+	for _, m := range serviceServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
+
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
+
+	H2CHTTP3ServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Provide the transport specific request decoder and response encoder.
+	// The goa http package has built-in support for JSON, XML and gob.
+	// Other encodings can be used by providing the corresponding functions,
+	// see goa.design/encoding.
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.ResponseEncoder
+	)
+
+	// Build the service HTTP request multiplexer and configure it to serve
+	// HTTP requests to the service endpoints.
+	var mux goahttp.Muxer
+	{
+		mux = goahttp.NewMuxer()
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceServer *servicesvr.Server
+	)
+	{
+		eh := errorHandler(logger)
+		serviceServer = servicesvr.New(serviceEndpoints, mux, dec, enc, eh)
+	}
+	// Configure the mux.
+	servicesvr.Mount(mux, serviceServer)
+
+	// Wrap the multiplexer with additional middlewares. Middlewares mounted
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
+	var handler http.Handler = mux
+	{
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
+		handler = httpmdlwr.Log(adapter)(handler)
+		handler = httpmdlwr.RequestID()(handler)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: handler}
+
+	// Serve HTTP/3 over QUIC on the UDP port with the same number as the
+	// HTTP port. HTTP/3 requires TLS so the HTTP/3 server only starts if
+	// the configuration sets a certificate. The responses sent over TLS
+	// advertise it with the Alt-Svc header.
+	h3srv := &http3.Server{Addr: u.Host, Handler: handler}
+	{
+		h := srv.Handler
+		srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS != nil {
+				h3srv.SetQUICHeaders(w.Header())
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+
+	// Serve cleartext HTTP/2 (h2c) in addition to HTTP/1.1 so that clients
+	// using HTTP/2 with prior knowledge multiplex their requests over a
+	// single connection.
+	if err := goahttp.ConfigureH2C(srv); err != nil {
+		logger.Printf("failed to configure h2c: %s", err)
+	}
+	for _, m := range serviceServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				h3srv.TLSConfig = http3.ConfigureTLSConfig(serverTLSConfig())
+				go func() {
+					logger.Printf("HTTP/3 server listening on %q", u.Host)
+					errc <- h3srv.ListenAndServe()
+				}()
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			logger.Printf("HTTP/3 server disabled: the configuration does not set a TLS certificate")
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
+
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
+		h3srv.Close()
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
+
+	H2CExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
+	var (
+		doer goahttp.Doer
+	)
+	{
+		// Send the requests over cleartext HTTP/2 with prior knowledge when the scheme
+		// is http.
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Transport: goahttp.NewH2CTransport()}
+		if debug {
+			doer = goahttp.NewDebugDoer(doer)
+		}
+	}
+
+	return cli.ParseEndpoint(
+		scheme,
+		host,
+		doer,
+		goahttp.RequestEncoder,
+		goahttp.ResponseDecoder,
+		debug,
+	)
+}
+
+func httpUsageCommands() string {
+	return cli.UsageCommands()
+}
+
+func httpUsageExamples() string {
+	return cli.UsageExamples()
+}
+`
+
+	MutualTLSExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool, tlsConf *tls.Config) (goa.Endpoint, interface{}, error) {
+	var (
+		doer goahttp.Doer
+	)
+	{
+		// Present the client certificate and verify the server certificate as
+		// configured by the command line flags.
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = tlsConf
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Transport: tr}
+		if debug {
+			doer = goahttp.NewDebugDoer(doer)
+		}
+	}
+
+	return cli.ParseEndpoint(
+		scheme,
+		host,
+		doer,
+		goahttp.RequestEncoder,
+		goahttp.ResponseDecoder,
+		debug,
+	)
+}
+
+func httpUsageCommands() string {
+	return cli.UsageCommands()
+}
+
+func httpUsageExamples() string {
+	return cli.UsageExamples()
+}
+`
+
+	UnixSocketExampleCLICode = `func doHTTP(scheme, host string, timeout int, debug bool) (goa.Endpoint, interface{}, error) {
+	var (
+		doer   goahttp.Doer
+		socket string
+	)
+	if scheme == "unix" {
+		// Send the requests to the Unix domain socket given as host using the http
+		// scheme.
+		scheme, host, socket = "http", "localhost", host
+	}
+	{
+		doer = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+		if socket != "" {
+			doer = &http.Client{Timeout: time.Duration(timeout) * time.Second, Transport: goahttp.NewUnixTransport(socket)}
+		}
+		if debug {
+			doer = goahttp.NewDebugDoer(doer)
+		}
+	}
+
+	var (
+		dialer *websocket.Dialer
+	)
+	{
+		dialer = websocket.DefaultDialer
+		if socket != "" {
+			d := *dialer
+			d.NetDialContext = goahttp.UnixDialContext(socket)
+			dialer = &d
+		}
+	}
+
+	return cli.ParseEndpoint(
+		scheme,
+		host,
+		doer,
+		goahttp.RequestEncoder,
+		goahttp.ResponseDecoder,
+		debug,
+		dialer,
+		nil,
+	)
+}
+
+func httpUsageCommands() string {
+	return cli.UsageCommands()
+}
+
+func httpUsageExamples() string {
+	return cli.UsageExamples()
+}
+`
+
+	RouterServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceRouterEndpoints *servicerouter.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Provide the transport specific request decoder and response encoder.
+	// The goa http package has built-in support for JSON, XML and gob.
+	// Other encodings can be used by providing the corresponding functions,
+	// see goa.design/encoding.
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.ResponseEncoder
+	)
+
+	// Build the service HTTP request multiplexer and configure it to serve
+	// HTTP requests to the service endpoints.
+	var mux goahttp.Muxer
+	// The route table writes the 404 and 405 responses to the requests that
+	// do not match any of the routes mounted below.
+	routes := goahttp.NewRouteTable(nil)
+	{
+		r := chi.NewRouter()
+		r.NotFound(routes.ServeHTTP)
+		r.MethodNotAllowed(routes.ServeHTTP)
+		mux = goahttp.NewRouterMuxer(r,
+			func(method, pattern string, h http.HandlerFunc) {
+				r.Method(method, pattern, h)
+			},
+			func(req *http.Request) map[string]string {
+				params := chi.RouteContext(req.Context()).URLParams
+				vars := make(map[string]string, len(params.Keys))
+				for i, k := range params.Keys {
+					vars[k] = params.Values[i]
+				}
+				return vars
+			})
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceRouterServer *serviceroutersvr.Server
+	)
+	{
+		eh := errorHandler(logger)
+		serviceRouterServer = serviceroutersvr.New(serviceRouterEndpoints, mux, dec, enc, eh)
+	}
+	// Configure the mux.
+	serviceroutersvr.Mount(mux, serviceRouterServer)
+
+	// Add the mounted routes to the route table so that the Allow header of
+	// the 405 responses lists the methods of the routes matching the path.
+	for _, m := range serviceRouterServer.Mounts {
+		routes.Add(m.Verb, m.Pattern)
+	}
+
+	// Wrap the multiplexer with additional middlewares. Middlewares mounted
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
+	var handler http.Handler = mux
+	{
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
+		handler = httpmdlwr.Log(adapter)(handler)
+		handler = httpmdlwr.RequestID()(handler)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: handler}
+	for _, m := range serviceRouterServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
+
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
+
+	SignedURLServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceSignedURLEndpoints *servicesignedurl.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Provide the transport specific request decoder and response encoder.
+	// The goa http package has built-in support for JSON, XML and gob.
+	// Other encodings can be used by providing the corresponding functions,
+	// see goa.design/encoding.
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.ResponseEncoder
+	)
+
+	// Build the service HTTP request multiplexer and configure it to serve
+	// HTTP requests to the service endpoints.
+	var mux goahttp.Muxer
+	{
+		mux = goahttp.NewMuxer()
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceSignedURLServer *servicesignedurlsvr.Server
+	)
+	{
+		eh := errorHandler(logger)
+		serviceSignedURLServer = servicesignedurlsvr.New(serviceSignedURLEndpoints, mux, dec, enc, eh)
+		// The signed URLs are verified with the secret read from the
+		// URL_SIGNING_SECRET environment variable which must be shared
+		// with the services that sign them, the requests are rejected if
+		// the variable is not set.
+		if secret := os.Getenv("URL_SIGNING_SECRET"); secret != "" {
+			serviceSignedURLServer.UseSignedURLs(goahttp.NewURLSigner([]byte(secret)))
+		}
+	}
+	// Configure the mux.
+	servicesignedurlsvr.Mount(mux, serviceSignedURLServer)
+
+	// Wrap the multiplexer with additional middlewares. Middlewares mounted
+	// here apply to all the service endpoints. The debug and timeout
+	// settings are read from the current configuration on each request so
+	// that reloading the configuration applies them to the running server.
+	var handler http.Handler = mux
+	{
+		dbg := httpmdlwr.Debug(mux, os.Stdout)(mux)
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c := currentConfig()
+			if c.timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), c.timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			if c.Debug {
+				dbg.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		})
+		handler = httpmdlwr.Log(adapter)(handler)
+		handler = httpmdlwr.RequestID()(handler)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: handler}
+	for _, m := range serviceSignedURLServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Listen on the TCP address or the Unix domain socket of the URL, or on the
+		// matching socket passed by systemd socket activation.
+		lis, err := goa.Listen(u)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", lis.Addr())
+			if currentConfig().cert != nil {
+				// Serve TLS with the settings of the current configuration, see
+				// serverTLSConfig in config.go.
+				srv.TLSConfig = serverTLSConfig("h2", "http/1.1")
+				errc <- srv.ServeTLS(lis, "", "")
+				return
+			}
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", lis.Addr())
+
+		// Shutdown gracefully: stop accepting connections and wait for the in-flight
+		// requests to complete up to the shutdown timeout set in the configuration.
+		ctx, cancel := context.WithTimeout(context.Background(), currentConfig().shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown HTTP server gracefully: %s", err)
+			srv.Close()
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
 `
 )
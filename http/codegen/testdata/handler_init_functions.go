@@ -27,6 +27,7 @@ func NewMethodNoPayloadNoResultHandler(
 			}
 			return
 		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
@@ -69,6 +70,7 @@ func NewMethodPayloadNoResultHandler(
 			}
 			return
 		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
@@ -103,6 +105,7 @@ func NewMethodNoPayloadResultHandler(
 			}
 			return
 		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
@@ -145,6 +148,7 @@ func NewMethodPayloadResultHandler(
 			}
 			return
 		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
@@ -187,9 +191,248 @@ func NewMethodPayloadResultErrorHandler(
 			}
 			return
 		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
 		if err := encodeResponse(ctx, w, res); err != nil {
 			eh(ctx, w, err)
 		}
 	})
 }
 `
+
+var ServerViewParamHandlerConstructorCode = `// NewMethodViewParamHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceViewParam" service "MethodViewParam" endpoint.
+func NewMethodViewParamHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodViewParamResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodViewParam")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceViewParam")
+		if view := r.URL.Query().Get("view"); view != "" {
+			if !(view == "default" || view == "tiny") {
+				err := goa.InvalidEnumValueError("view", view, []interface{}{"default", "tiny"})
+				if err := encodeError(ctx, w, err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
+			ctx = context.WithValue(ctx, goa.ViewKey, view)
+		}
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerETagHandlerConstructorCode = `// NewMethodETagHandler creates a HTTP handler which loads the HTTP request and
+// calls the "ServiceETag" service "MethodETag" endpoint.
+func NewMethodETagHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodETagResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodETag")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceETag")
+		ctx = goahttp.ContextWithConditionalRequest(ctx, r)
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerTimeoutHandlerConstructorCode = `// NewMethodTimeoutHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceTimeout" service "MethodTimeout" endpoint.
+func NewMethodTimeoutHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodTimeoutResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodTimeout")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceTimeout")
+		ctx, cancel := context.WithTimeout(ctx, 90*time.Second)
+		defer cancel()
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerSunsetHandlerConstructorCode = `// NewMethodSunsetHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceSunset" service "MethodSunset" endpoint.
+func NewMethodSunsetHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodSunsetResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodSunset")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceSunset")
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", "Wed, 30 Jun 2021 00:00:00 GMT")
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerFieldMaskHandlerConstructorCode = `// NewMethodFieldMaskHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceFieldMask" service "MethodFieldMask" endpoint.
+func NewMethodFieldMaskHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodFieldMaskResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodFieldMask")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceFieldMask")
+		if fields := r.URL.Query().Get("fields"); fields != "" {
+			ctx = context.WithValue(ctx, goa.FieldMaskKey, goa.ParseFieldMask(fields))
+		}
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerTracePhasesHandlerConstructorCode = `// NewMethodTracePhasesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceTracePhases" service "MethodTracePhases"
+// endpoint.
+func NewMethodTracePhasesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodTracePhasesRequest(mux, dec)
+		encodeResponse = EncodeMethodTracePhasesResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodTracePhases")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceTracePhases")
+		decodeCtx, endPhase := goahttp.StartPhase(ctx, goahttp.PhaseDecode)
+		payload, err := decodeRequest(r.WithContext(decodeCtx))
+		endPhase(err)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		endpointCtx, endPhase := goahttp.StartPhase(ctx, goahttp.PhaseEndpoint)
+		res, err := endpoint(endpointCtx, payload)
+		endPhase(err)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		w = goahttp.RunResponseHooks(ctx, w, res)
+		encodeCtx, endPhase := goahttp.StartPhase(ctx, goahttp.PhaseEncode)
+		err = encodeResponse(encodeCtx, w, res)
+		endPhase(err)
+		if err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var HARDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var Account = Type("Account", func() {
+		Attribute("id", Int, func() {
+			Example(1)
+		})
+		Attribute("name", String, func() {
+			Example("alice")
+		})
+		Attribute("org", String, func() {
+			Example("goa")
+		})
+		Attribute("version", String, func() {
+			Example("v1")
+		})
+		Attribute("tags", ArrayOf(String), func() {
+			Example([]string{"a", "b"})
+		})
+		Token("token", String)
+	})
+	Service("accounts", func() {
+		Method("update", func() {
+			Security(JWTAuth)
+			Payload(Account)
+			Result(Account)
+			HTTP(func() {
+				PUT("/orgs/{org}/accounts/{id}")
+				Param("tags")
+				Header("version:X-Version")
+				Response(StatusOK, func() {
+					Header("version:X-Version")
+				})
+			})
+		})
+		Method("list", func() {
+			Result(ArrayOf(String), func() {
+				Example([]string{"alice"})
+			})
+			HTTP(func() {
+				GET("/accounts")
+			})
+		})
+	})
+}
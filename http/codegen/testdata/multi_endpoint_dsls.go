@@ -31,6 +31,43 @@ var MultiNoPayloadDSL = func() {
 	})
 }
 
+var MultiCLIAliasDSL = func() {
+	Service("ServiceAlias1", func() {
+		Meta("cli:alias", "s1")
+		Method("MethodList", func() {
+			Description("List the things.\nThe things are listed in creation order.")
+			Meta("cli:alias", "ls")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("MethodCreate", func() {
+			Meta("cli:alias", "new", "add")
+			Meta("cli:group", "Management")
+			Payload(func() {
+				Attribute("a", Boolean)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodDelete", func() {
+			Meta("cli:group", "Management")
+			HTTP(func() {
+				DELETE("/")
+			})
+		})
+	})
+	Service("ServiceAlias2", func() {
+		Meta("cli:group", "Admin")
+		Method("MethodShow", func() {
+			HTTP(func() {
+				GET("/2")
+			})
+		})
+	})
+}
+
 var MultiSimpleDSL = func() {
 	Service("ServiceMultiSimple1", func() {
 		Method("MethodMultiSimpleNoPayload", func() {
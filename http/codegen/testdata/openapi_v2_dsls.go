@@ -109,6 +109,36 @@ var MultipleViewsDSL = func() {
 	})
 }
 
+var ViewParamDSL = func() {
+	var ResultT = ResultType("application/json", func() {
+		TypeName("Result")
+		Attributes(func() {
+			Attribute("string", String, func() {
+				Example("")
+			})
+			Attribute("int", Int, func() {
+				Example(1)
+			})
+		})
+		View("default", func() {
+			Attribute("string")
+			Attribute("int")
+		})
+		View("tiny", func() {
+			Attribute("string")
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Result(ResultT)
+			HTTP(func() {
+				GET("/")
+				ViewParam()
+			})
+		})
+	})
+}
+
 var ExplicitViewDSL = func() {
 	var ResultT = ResultType("application/json", func() {
 		TypeName("Result")
@@ -286,6 +316,139 @@ var ExtensionDSL = func() {
 	})
 }
 
+var IdempotentDSL = func() {
+	var PayloadT = Type("Payload", func() {
+		Attribute("string", String, func() {
+			Example("")
+		})
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Idempotent()
+			Payload(PayloadT)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var ETagDSL = func() {
+	var ResultT = Type("Result", func() {
+		Attribute("string", String, func() {
+			Example("")
+		})
+		Attribute("version", String, func() {
+			Example("v1")
+			ETag()
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Result(ResultT)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var SunsetDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Sunset("2021-06-30T12:00:00Z")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var MaxRequestBodyDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			MaxRequestBody("1MB")
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var FixedHeadersDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			FixedHeader("X-Content-Type-Options", "nosniff")
+		})
+	})
+	Service("testService", func() {
+		HTTP(func() {
+			FixedHeader("Strict-Transport-Security", "max-age=63072000")
+		})
+		Method("testEndpoint", func() {
+			Result(String)
+			Error("not_found")
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
+
+var CachingDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					CacheControl("public", "max-age=3600")
+					Expires("1h")
+					Vary("Accept")
+				})
+			})
+		})
+	})
+}
+
+var CallbacksDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Callback("onEvent", "{$request.body#/callback_url}", func() {
+					Description("Event notification")
+					Payload(func() {
+						Attribute("event", String)
+						Required("event")
+					})
+					Response(StatusOK)
+					Response(StatusGone, func() {
+						Description("Unsubscribe")
+					})
+				})
+			})
+		})
+	})
+}
+
 var SecurityDSL = func() {
 	var JWTAuth = JWTSecurity("jwt", func() {
 		Description(`Secures endpoint by requiring a valid JWT token retrieved via the signin endpoint. Supports scopes "api:read" and "api:write".`)
@@ -370,6 +533,37 @@ var ServerHostWithVariablesDSL = func() {
 	})
 }
 
+var EnvironmentsDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("development", func() {
+				URI("http://localhost:8000")
+				Environment("dev")
+			})
+			Host("production", func() {
+				URI("https://{region}.goa.design")
+				Variable("region", String, "Region", func() {
+					Enum("us", "eu")
+					Default("us")
+				})
+				Environment("staging", func() {
+					Variable("region", "eu")
+				})
+				Environment("prod")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(Empty)
+			Result(Empty)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var WithSpacesDSL = func() {
 	var Bar = Type("bar", func() {
 		Attribute("string", String, func() {
@@ -395,3 +589,366 @@ var WithSpacesDSL = func() {
 		})
 	})
 }
+
+var CBORDSL = func() {
+	var PayloadT = Type("Payload", func() {
+		Attribute("string", String, func() {
+			Example("")
+		})
+	})
+	var ResultT = Type("Result", func() {
+		Attribute("string", String, func() {
+			Example("")
+		})
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+		HTTP(func() {
+			Consumes("application/json", "application/cbor")
+			Produces("application/json", "application/cbor")
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(PayloadT)
+			Result(ResultT)
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK, func() {
+					ContentType("application/cbor")
+				})
+			})
+		})
+	})
+}
+
+var NDJSONDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", Int)
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				POST("/")
+				NDJSON()
+			})
+		})
+	})
+}
+
+var ExportDSL = func() {
+	var Row = Type("Row", func() {
+		Attribute("id", String)
+		Attribute("qty", Int)
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				Export()
+			})
+		})
+	})
+}
+
+var XMLDSL = func() {
+	var Book = Type("Book", func() {
+		XMLName("book")
+		XMLNamespace("http://example.com/book", "bk")
+		Attribute("id", Int, func() {
+			XMLAttribute()
+		})
+		Attribute("tags", ArrayOf(String, func() {
+			XMLName("tag")
+		}), func() {
+			XMLWrapped()
+		})
+	})
+	var BookResult = ResultType("application/vnd.book-result", func() {
+		XMLName("bookResult")
+		Attributes(func() {
+			Attribute("title", String, func() {
+				XMLName("Title")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(Book)
+			Result(BookResult)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var MultiStatusDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", Int)
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(ArrayOf(Item))
+			Result(MultiStatusOf(Item))
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var APIVersionDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("id", Int)
+	})
+	var ItemV1 = Type("ItemV1", func() {
+		Attribute("id", String)
+	})
+	Service("testService", func() {
+		Method("testEndpointV1", func() {
+			Result(ItemV1)
+			HTTP(func() {
+				GET("/")
+				APIVersion("1")
+			})
+		})
+		Method("testEndpoint", func() {
+			Result(Item)
+			HTTP(func() {
+				GET("/")
+				APIVersion("2")
+			})
+		})
+	})
+}
+
+var APIVersionAcceptDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			VersionHeader("Accept")
+		})
+	})
+	var Item = Type("Item", func() {
+		Attribute("id", Int)
+	})
+	Service("testService", func() {
+		Method("testEndpointV1", func() {
+			Result(Item)
+			HTTP(func() {
+				GET("/")
+				APIVersion("1")
+			})
+		})
+		Method("testEndpoint", func() {
+			Result(Item)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var PatchDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+		Required("name")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(PatchOf(Item))
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
+
+var FieldMaskResultDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("id", String)
+		Attribute("name", String)
+		Required("id")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			FieldMask()
+			Result(Account)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServiceViewParamDSL = func() {
+	var ResultT = ResultType("application/json", func() {
+		TypeName("Result")
+		Attributes(func() {
+			Attribute("string", String, func() {
+				Example("")
+			})
+			Attribute("int", Int, func() {
+				Example(1)
+			})
+		})
+		View("default", func() {
+			Attribute("string")
+			Attribute("int")
+		})
+		View("tiny", func() {
+			Attribute("string")
+		})
+	})
+	Service("testService", func() {
+		HTTP(func() {
+			ViewParam()
+		})
+		Method("testEndpoint", func() {
+			Result(ResultT)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("testEndpointNoViews", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/string")
+			})
+		})
+	})
+}
+
+var AggregateErrorsDSL = func() {
+	Service("testService", func() {
+		AggregateErrors()
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					MinLength(1)
+				})
+				Attribute("limit", Int, func() {
+					Minimum(1)
+				})
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/")
+				Param("limit")
+			})
+		})
+		Method("testEndpointBadRequest", func() {
+			Payload(String)
+			Error("bad_request")
+			HTTP(func() {
+				POST("/bad")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
+
+var StrictStatusCodesDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			StrictStatusCodes()
+			RequestCompression("gzip")
+		})
+	})
+	Service("testService", func() {
+		Error("not_found")
+		HTTP(func() {
+			Response("not_found", StatusNotFound)
+		})
+		Method("testEndpoint", func() {
+			Payload(String)
+			Result(String)
+			Error("conflict")
+			HTTP(func() {
+				POST("/")
+				Response("conflict", StatusConflict)
+			})
+		})
+	})
+}
+
+var PathConstraintsDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("slug", String, func() {
+					Pattern("^[a-z-]+$")
+				})
+			})
+			HTTP(func() {
+				GET("/items/{id:[0-9]+}/{slug}")
+			})
+		})
+	})
+}
+
+var ParamStylesDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("names", ArrayOf(String))
+				Attribute("tags", ArrayOf(String))
+				Attribute("labels", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Style(StylePipeDelimited)
+				})
+				Param("names", func() {
+					Explode(false)
+				})
+				Param("tags", func() {
+					Style(StyleSpaceDelimited)
+				})
+				Header("labels:X-Labels", func() {
+					Style(StyleSimple)
+				})
+			})
+		})
+	})
+}
+
+var ParamSeparatorDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Separator(";")
+				})
+				Param("tags", func() {
+					Separator("\t")
+				})
+			})
+		})
+	})
+}
+
+var RenamedMethodDSL = func() {
+	Service("testService", func() {
+		Method("list_items", func() {
+			RenamedFrom("list")
+			HTTP(func() {
+				GET("/list_items")
+			})
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var PactConsumerDSL = func() {
+	var _ = API("pact", func() {
+		Meta("pact:consumer", "web")
+	})
+	var Invoice = Type("Invoice", func() {
+		Attribute("id", String, func() {
+			Example("inv-1")
+		})
+		Attribute("amount", Int, func() {
+			Example(42)
+		})
+	})
+	Service("billing", func() {
+		Meta("pact:consumer", "Mobile App")
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Example("inv-1")
+				})
+			})
+			Result(Invoice)
+			HTTP(func() {
+				GET("/invoices/{id}")
+				GET("/bills/{id}")
+			})
+		})
+	})
+	Service("ledger", func() {
+		Method("delete", func() {
+			HTTP(func() {
+				DELETE("/ledger")
+			})
+		})
+	})
+	Service("events", func() {
+		Method("watch", func() {
+			StreamingResult(Invoice)
+			HTTP(func() {
+				GET("/events")
+			})
+		})
+	})
+}
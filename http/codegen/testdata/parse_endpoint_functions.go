@@ -134,6 +134,212 @@ func ParseEndpoint(
 }
 `
 
+var MultiCLIAliasUsageCommandsCode = `// UsageCommands returns the set of commands and sub-commands using the format
+//
+//	command (subcommand1|subcommand2|...)
+func UsageCommands() string {
+	return ` + "`" + `service-alias1 (method-list|method-create|method-delete)
+
+Admin:
+service-alias2 method-show
+` + "`" + `
+}
+`
+
+var MultiCLIAliasParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
+// line.
+func ParseEndpoint(
+	scheme, host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restore bool,
+) (goa.Endpoint, interface{}, error) {
+	var (
+		serviceAlias1Flags = flag.NewFlagSet("service-alias1", flag.ContinueOnError)
+
+		serviceAlias1MethodListFlags = flag.NewFlagSet("method-list", flag.ExitOnError)
+
+		serviceAlias1MethodCreateFlags    = flag.NewFlagSet("method-create", flag.ExitOnError)
+		serviceAlias1MethodCreateBodyFlag = serviceAlias1MethodCreateFlags.String("body", "REQUIRED", "")
+
+		serviceAlias1MethodDeleteFlags = flag.NewFlagSet("method-delete", flag.ExitOnError)
+
+		serviceAlias2Flags = flag.NewFlagSet("service-alias2", flag.ContinueOnError)
+
+		serviceAlias2MethodShowFlags = flag.NewFlagSet("method-show", flag.ExitOnError)
+	)
+	serviceAlias1Flags.Usage = serviceAlias1Usage
+	serviceAlias1MethodListFlags.Usage = serviceAlias1MethodListUsage
+	serviceAlias1MethodCreateFlags.Usage = serviceAlias1MethodCreateUsage
+	serviceAlias1MethodDeleteFlags.Usage = serviceAlias1MethodDeleteUsage
+
+	serviceAlias2Flags.Usage = serviceAlias2Usage
+	serviceAlias2MethodShowFlags.Usage = serviceAlias2MethodShowUsage
+
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	if flag.NArg() < 2 { // two non flag args are required: SERVICE and ENDPOINT (aka COMMAND)
+		return nil, nil, fmt.Errorf("not enough arguments")
+	}
+
+	var (
+		svcn string
+		svcf *flag.FlagSet
+	)
+	{
+		svcn = flag.Arg(0)
+		switch svcn {
+		case "service-alias1", "s1":
+			svcf = serviceAlias1Flags
+			svcn = "service-alias1"
+		case "service-alias2":
+			svcf = serviceAlias2Flags
+		default:
+			return nil, nil, fmt.Errorf("unknown service %q", svcn)
+		}
+	}
+	if err := svcf.Parse(flag.Args()[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		epn string
+		epf *flag.FlagSet
+	)
+	{
+		epn = svcf.Arg(0)
+		switch svcn {
+		case "service-alias1":
+			switch epn {
+			case "method-list", "ls":
+				epf = serviceAlias1MethodListFlags
+				epn = "method-list"
+
+			case "method-create", "new", "add":
+				epf = serviceAlias1MethodCreateFlags
+				epn = "method-create"
+
+			case "method-delete":
+				epf = serviceAlias1MethodDeleteFlags
+
+			}
+
+		case "service-alias2":
+			switch epn {
+			case "method-show":
+				epf = serviceAlias2MethodShowFlags
+
+			}
+
+		}
+	}
+	if epf == nil {
+		return nil, nil, fmt.Errorf("unknown %q endpoint %q", svcn, epn)
+	}
+
+	// Parse endpoint flags if any
+	if svcf.NArg() > 1 {
+		if err := epf.Parse(svcf.Args()[1:]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var (
+		data     interface{}
+		endpoint goa.Endpoint
+		err      error
+	)
+	{
+		switch svcn {
+		case "service-alias1":
+			c := servicealias1c.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "method-list":
+				endpoint = c.MethodList()
+				data = nil
+			case "method-create":
+				endpoint = c.MethodCreate()
+				data, err = servicealias1c.BuildMethodCreatePayload(*serviceAlias1MethodCreateBodyFlag)
+			case "method-delete":
+				endpoint = c.MethodDelete()
+				data = nil
+			}
+		case "service-alias2":
+			c := servicealias2c.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "method-show":
+				endpoint = c.MethodShow()
+				data = nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return endpoint, data, nil
+}
+`
+
+var MultiCLIAliasCommandUsageCode = `// service-alias1Usage displays the usage of the service-alias1 command and its
+// subcommands.
+func serviceAlias1Usage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `Service is the ServiceAlias1 service interface.
+Usage:
+    %s [globalflags] service-alias1 COMMAND [flags]
+
+Aliases:
+    s1
+
+COMMAND:
+    method-list (ls): List the things.
+
+Management:
+    method-create (new, add): MethodCreate implements MethodCreate.
+    method-delete: MethodDelete implements MethodDelete.
+
+Additional help:
+    %s service-alias1 COMMAND --help
+` + "`" + `, os.Args[0], os.Args[0])
+}
+func serviceAlias1MethodListUsage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] service-alias1 method-list
+
+List the things.
+	The things are listed in creation order.
+
+Example:
+    ` + "`" + `+os.Args[0]+` + "`" + ` service-alias1 method-list
+` + "`" + `, os.Args[0])
+}
+
+func serviceAlias1MethodCreateUsage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] service-alias1 method-create -body JSON
+
+MethodCreate implements MethodCreate.
+    -body JSON: 
+
+Example:
+    ` + "`" + `+os.Args[0]+` + "`" + ` service-alias1 method-create --body '{
+      "a": false
+   }'
+` + "`" + `, os.Args[0])
+}
+
+func serviceAlias1MethodDeleteUsage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] service-alias1 method-delete
+
+MethodDelete implements MethodDelete.
+
+Example:
+    ` + "`" + `+os.Args[0]+` + "`" + ` service-alias1 method-delete
+` + "`" + `, os.Args[0])
+}
+`
+
 var MultiSimpleParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
 // line.
 func ParseEndpoint(
@@ -1113,6 +1319,23 @@ func BuildMethodMapQueryObjectPayload(serviceMapQueryObjectMethodMapQueryObjectB
 }
 `
 
+var MapQueryObjectValuesMapBuildCode = `// BuildMethodMapQueryObjectValuesMapPayload builds the payload for the
+// ServiceMapQueryObjectValuesMap MethodMapQueryObjectValuesMap endpoint from
+// CLI flags.
+func BuildMethodMapQueryObjectValuesMapPayload(serviceMapQueryObjectValuesMapMethodMapQueryObjectValuesMapQuery string) (map[string]*servicemapqueryobjectvaluesmap.Range, error) {
+	var err error
+	var query map[string]*servicemapqueryobjectvaluesmap.Range
+	{
+		err = json.Unmarshal([]byte(serviceMapQueryObjectValuesMapMethodMapQueryObjectValuesMapQuery), &query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for query, example of valid JSON:\n%s", "'{\n      \"Qui molestiae iure.\": {\n         \"min\": 9215564792544893495,\n         \"tags\": [\n            \"Et quae sunt itaque.\",\n            \"Optio quia ullam aut.\",\n            \"Iste perspiciatis.\",\n            \"Harum et.\"\n         ]\n      }\n   }'")
+		}
+	}
+	v := query
+	return v, nil
+}
+`
+
 var QueryUInt32BuildCode = `// BuildMethodQueryUInt32Payload builds the payload for the ServiceQueryUInt32
 // MethodQueryUInt32 endpoint from CLI flags.
 func BuildMethodQueryUInt32Payload(serviceQueryUInt32MethodQueryUInt32Q string) (*servicequeryuint32.MethodQueryUInt32Payload, error) {
@@ -1291,3 +1514,41 @@ func BuildMethodAPayload(serviceWithParamsAndHeadersBlockMethodABody string, ser
 	return v, nil
 }
 `
+
+const BodyOptionalFieldsBuildCode = `// BuildMethodBodyOptionalFieldsPayload builds the payload for the
+// ServiceBodyOptionalFields MethodBodyOptionalFields endpoint from CLI flags.
+func BuildMethodBodyOptionalFieldsPayload(serviceBodyOptionalFieldsMethodBodyOptionalFieldsBody string, serviceBodyOptionalFieldsMethodBodyOptionalFieldsID string) (*servicebodyoptionalfields.MethodBodyOptionalFieldsPayload, error) {
+	var err error
+	var body MethodBodyOptionalFieldsRequestBody
+	{
+		err = json.Unmarshal([]byte(serviceBodyOptionalFieldsMethodBodyOptionalFieldsBody), &body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for body, example of valid JSON:\n%s", "'{\n      \"active\": true,\n      \"age\": 7388093990298529880,\n      \"name\": \"nq6\"\n   }'")
+		}
+		if body.Name.Value != nil {
+			if utf8.RuneCountInString(*body.Name.Value) < 2 {
+				err = goa.MergeErrors(err, goa.InvalidLengthError("body.name", *body.Name.Value, utf8.RuneCountInString(*body.Name.Value), 2, true))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var id int
+	{
+		var v int64
+		v, err = strconv.ParseInt(serviceBodyOptionalFieldsMethodBodyOptionalFieldsID, 10, 64)
+		id = int(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for id, must be INT")
+		}
+	}
+	v := &servicebodyoptionalfields.MethodBodyOptionalFieldsPayload{
+		Name:   body.Name,
+		Age:    body.Age,
+		Active: body.Active,
+	}
+	v.ID = id
+	return v, nil
+}
+`
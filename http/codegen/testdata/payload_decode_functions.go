@@ -664,6 +664,93 @@ func DecodeMethodQueryBytesValidateRequest(mux goahttp.Muxer, decoder func(*http
 }
 `
 
+var PayloadQueryTimestampDecodeCode = `// DecodeMethodQueryTimestampRequest returns a decoder for requests sent to the
+// ServiceQueryTimestamp MethodQueryTimestamp endpoint.
+func DecodeMethodQueryTimestampRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   *time.Time
+			err error
+		)
+		{
+			qRaw := r.URL.Query().Get("q")
+			if qRaw != "" {
+				v, err2 := goa.ParseTimestamp(qRaw, "rfc3339")
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "timestamp"))
+				}
+				q = &v
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryTimestampPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryDecimalDecodeCode = `// DecodeMethodQueryDecimalRequest returns a decoder for requests sent to the
+// ServiceQueryDecimal MethodQueryDecimal endpoint.
+func DecodeMethodQueryDecimalRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   *goa.Decimal
+			err error
+		)
+		{
+			qRaw := r.URL.Query().Get("q")
+			if qRaw != "" {
+				var v goa.Decimal
+				if err2 := v.UnmarshalText([]byte(qRaw)); err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "decimal"))
+				}
+				q = &v
+			}
+		}
+		if q != nil {
+			err = goa.MergeErrors(err, goa.ValidateDecimal("q", *q, 10, 2))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryDecimalPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryUUIDDecodeCode = `// DecodeMethodQueryUUIDRequest returns a decoder for requests sent to the
+// ServiceQueryUUID MethodQueryUUID endpoint.
+func DecodeMethodQueryUUIDRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   *goa.UUID
+			err error
+		)
+		{
+			qRaw := r.URL.Query().Get("q")
+			if qRaw != "" {
+				v, err2 := goa.ParseUUID(qRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "uuid"))
+				}
+				q = &v
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryUUIDPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadQueryAnyDecodeCode = `// DecodeMethodQueryAnyRequest returns a decoder for requests sent to the
 // ServiceQueryAny MethodQueryAny endpoint.
 func DecodeMethodQueryAnyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -1460,6 +1547,69 @@ func DecodeMethodQueryArrayBytesValidateRequest(mux goahttp.Muxer, decoder func(
 }
 `
 
+var PayloadQueryArrayTimestampUnixDecodeCode = `// DecodeMethodQueryArrayTimestampUnixRequest returns a decoder for requests
+// sent to the ServiceQueryArrayTimestampUnix MethodQueryArrayTimestampUnix
+// endpoint.
+func DecodeMethodQueryArrayTimestampUnixRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   []time.Time
+			err error
+		)
+		{
+			qRaw := r.URL.Query()["q"]
+			if qRaw != nil {
+				q = make([]time.Time, len(qRaw))
+				for i, rv := range qRaw {
+					v, err2 := goa.ParseTimestamp(rv, "unix")
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "array of timestamps"))
+					}
+					q[i] = v
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryArrayTimestampUnixPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryArrayUUIDDecodeCode = `// DecodeMethodQueryArrayUUIDRequest returns a decoder for requests sent to the
+// ServiceQueryArrayUUID MethodQueryArrayUUID endpoint.
+func DecodeMethodQueryArrayUUIDRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   []goa.UUID
+			err error
+		)
+		{
+			qRaw := r.URL.Query()["q"]
+			if qRaw != nil {
+				q = make([]goa.UUID, len(qRaw))
+				for i, rv := range qRaw {
+					v, err2 := goa.ParseUUID(rv)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "array of uuids"))
+					}
+					q[i] = v
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryArrayUUIDPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadQueryArrayAnyDecodeCode = `// DecodeMethodQueryArrayAnyRequest returns a decoder for requests sent to the
 // ServiceQueryArrayAny MethodQueryArrayAny endpoint.
 func DecodeMethodQueryArrayAnyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -3122,6 +3272,36 @@ func DecodeMethodHeaderStringRequest(mux goahttp.Muxer, decoder func(*http.Reque
 }
 `
 
+var PayloadHeaderIdempotencyKeyDecodeCode = `// DecodeMethodHeaderIdempotencyKeyRequest returns a decoder for requests sent
+// to the ServiceHeaderIdempotencyKey MethodHeaderIdempotencyKey endpoint.
+func DecodeMethodHeaderIdempotencyKeyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodHeaderIdempotencyKeyRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			idempotencyKey *string
+		)
+		idempotencyKeyRaw := r.Header.Get("Idempotency-Key")
+		if idempotencyKeyRaw != "" {
+			idempotencyKey = &idempotencyKeyRaw
+		}
+		payload := NewMethodHeaderIdempotencyKeyPayload(&body, idempotencyKey)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadHeaderStringValidateDecodeCode = `// DecodeMethodHeaderStringValidateRequest returns a decoder for requests sent
 // to the ServiceHeaderStringValidate MethodHeaderStringValidate endpoint.
 func DecodeMethodHeaderStringValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -4556,6 +4736,197 @@ func DecodeMethodMapQueryObjectRequest(mux goahttp.Muxer, decoder func(*http.Req
 }
 `
 
+var PayloadMapQueryObjectValuesDecodeCode = `// DecodeMethodMapQueryObjectValuesRequest returns a decoder for requests sent
+// to the ServiceMapQueryObjectValues MethodMapQueryObjectValues endpoint.
+func DecodeMethodMapQueryObjectValuesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			a      string
+			ranges map[int]*servicemapqueryobjectvalues.Range
+			err    error
+
+			params = mux.Vars(r)
+		)
+		a = params["a"]
+		{
+			rangesRaw := r.URL.Query()
+			if len(rangesRaw) != 0 {
+				rangesFields := make(map[int]map[string][]string)
+				for nameRaw, valRaw := range rangesRaw {
+					if strings.HasPrefix(nameRaw, "ranges[") {
+						var (
+							key   int
+							field string
+						)
+						{
+							openIdx := strings.IndexRune(nameRaw, '[')
+							closeIdx := strings.IndexRune(nameRaw, ']')
+							keyRaw := nameRaw[openIdx+1 : closeIdx]
+							v, err2 := strconv.ParseInt(keyRaw, 10, strconv.IntSize)
+							if err2 != nil {
+								err = goa.MergeErrors(err, goa.InvalidFieldTypeError("key", keyRaw, "integer"))
+							}
+							key = int(v)
+							field = nameRaw[closeIdx+1:]
+						}
+						if len(field) < 3 || field[0] != '[' || field[len(field)-1] != ']' {
+							err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ranges", nameRaw, "object"))
+							continue
+						}
+						if rangesFields[key] == nil {
+							rangesFields[key] = make(map[string][]string)
+						}
+						rangesFields[key][field[1:len(field)-1]] = valRaw
+					}
+				}
+				for key, fields := range rangesFields {
+					if ranges == nil {
+						ranges = make(map[int]*servicemapqueryobjectvalues.Range)
+					}
+					val := &servicemapqueryobjectvalues.Range{}
+					if vals, ok := fields["min"]; ok {
+						var min int
+						minRaw := vals[0]
+						v, err2 := strconv.ParseInt(minRaw, 10, strconv.IntSize)
+						if err2 != nil {
+							err = goa.MergeErrors(err, goa.InvalidFieldTypeError("min", minRaw, "integer"))
+						}
+						min = int(v)
+						val.Min = min
+					} else {
+						err = goa.MergeErrors(err, goa.MissingFieldError("min", "query string"))
+					}
+					if vals, ok := fields["max"]; ok {
+						var max *int
+						maxRaw := vals[0]
+						v, err2 := strconv.ParseInt(maxRaw, 10, strconv.IntSize)
+						if err2 != nil {
+							err = goa.MergeErrors(err, goa.InvalidFieldTypeError("max", maxRaw, "integer"))
+						}
+						pv := int(v)
+						max = &pv
+						val.Max = max
+					}
+					if vals, ok := fields["step"]; ok {
+						var step float64
+						stepRaw := vals[0]
+						v, err2 := strconv.ParseFloat(stepRaw, 64)
+						if err2 != nil {
+							err = goa.MergeErrors(err, goa.InvalidFieldTypeError("step", stepRaw, "float"))
+						}
+						step = v
+						val.Step = step
+					} else {
+						val.Step = 1.5
+					}
+					if vals, ok := fields["tags"]; ok {
+						val.Tags = vals
+					}
+					if vals, ok := fields["ids"]; ok {
+						var ids []uint
+						idsRaw := vals
+						ids = make([]uint, len(idsRaw))
+						for i, rv := range idsRaw {
+							v, err2 := strconv.ParseUint(rv, 10, strconv.IntSize)
+							if err2 != nil {
+								err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ids", idsRaw, "array of unsigned integers"))
+							}
+							ids[i] = uint(v)
+						}
+						val.Ids = ids
+					}
+					if val.Min < 0 {
+						err = goa.MergeErrors(err, goa.InvalidRangeError("val.min", val.Min, 0, true))
+					}
+					ranges[key] = val
+				}
+			}
+		}
+		for k, _ := range ranges {
+			if k < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("ranges.key", k, 1, true))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodMapQueryObjectValuesPayloadType(a, ranges)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadMapQueryObjectValuesMapDecodeCode = `// DecodeMethodMapQueryObjectValuesMapRequest returns a decoder for requests
+// sent to the ServiceMapQueryObjectValuesMap MethodMapQueryObjectValuesMap
+// endpoint.
+func DecodeMethodMapQueryObjectValuesMapRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			query map[string]*servicemapqueryobjectvaluesmap.Range
+			err   error
+		)
+		{
+			queryRaw := r.URL.Query()
+			if len(queryRaw) == 0 {
+				err = goa.MergeErrors(err, goa.MissingFieldError("query", "query string"))
+			}
+			queryFields := make(map[string]map[string][]string)
+			for nameRaw, valRaw := range queryRaw {
+				if strings.HasPrefix(nameRaw, "query[") {
+					var (
+						key   string
+						field string
+					)
+					{
+						openIdx := strings.IndexRune(nameRaw, '[')
+						closeIdx := strings.IndexRune(nameRaw, ']')
+						key = nameRaw[openIdx+1 : closeIdx]
+						field = nameRaw[closeIdx+1:]
+					}
+					if len(field) < 3 || field[0] != '[' || field[len(field)-1] != ']' {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("query", nameRaw, "object"))
+						continue
+					}
+					if queryFields[key] == nil {
+						queryFields[key] = make(map[string][]string)
+					}
+					queryFields[key][field[1:len(field)-1]] = valRaw
+				}
+			}
+			for key, fields := range queryFields {
+				if query == nil {
+					query = make(map[string]*servicemapqueryobjectvaluesmap.Range)
+				}
+				val := &servicemapqueryobjectvaluesmap.Range{}
+				if vals, ok := fields["min"]; ok {
+					var min int
+					minRaw := vals[0]
+					v, err2 := strconv.ParseInt(minRaw, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("min", minRaw, "integer"))
+					}
+					min = int(v)
+					val.Min = min
+				} else {
+					err = goa.MergeErrors(err, goa.MissingFieldError("min", "query string"))
+				}
+				if vals, ok := fields["tags"]; ok {
+					val.Tags = vals
+				}
+				query[key] = val
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodMapQueryObjectValuesMapMapStringRange(query)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadMultipartPrimitiveDecodeCode = `// DecodeMethodMultipartPrimitiveRequest returns a decoder for requests sent to
 // the ServiceMultipartPrimitive MethodMultipartPrimitive endpoint.
 func DecodeMethodMultipartPrimitiveRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -4691,3 +5062,515 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 	}
 }
 `
+
+var PayloadBodyEncryptedDecodeCode = `// DecodeMethodBodyEncryptedRequest returns a decoder for requests sent to the
+// ServiceBodyEncrypted MethodBodyEncrypted endpoint.
+func DecodeMethodBodyEncryptedRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyEncryptedRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		if err = DecryptMethodBodyEncryptedRequestBody(r.Context(), &body); err != nil {
+			return nil, err
+		}
+		err = ValidateMethodBodyEncryptedRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodBodyEncryptedAccount(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyTimeZoneDecodeCode = `// DecodeMethodBodyTimeZoneRequest returns a decoder for requests sent to the
+// ServiceBodyTimeZone MethodBodyTimeZone endpoint.
+func DecodeMethodBodyTimeZoneRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyTimeZoneRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyTimeZoneRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			since *string
+		)
+		sinceRaw := r.URL.Query().Get("since")
+		if sinceRaw != "" {
+			since = &sinceRaw
+		}
+		if since != nil {
+			err = goa.MergeErrors(err, goa.ValidateFormat("since", *since, goa.FormatDateTime))
+		}
+		if since != nil {
+			err = goa.MergeErrors(err, goa.ValidateTimeZone("since", *since, "UTC"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodBodyTimeZonePayload(&body, since)
+		if payload.Event != nil {
+			payload.Event.StartsAt = goa.NormalizeDateTime(payload.Event.StartsAt)
+			if payload.Event.EndsAt != nil {
+				*payload.Event.EndsAt = goa.NormalizeDateTime(*payload.Event.EndsAt)
+			}
+			for i := range payload.Event.Reminders {
+				payload.Event.Reminders[i] = goa.NormalizeDateTime(payload.Event.Reminders[i])
+			}
+		}
+		if payload.Since != nil {
+			*payload.Since = goa.NormalizeDateTime(*payload.Since)
+		}
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyDefaultFromDecodeCode = `// DecodeMethodBodyDefaultFromRequest returns a decoder for requests sent to
+// the ServiceBodyDefaultFrom MethodBodyDefaultFrom endpoint.
+func DecodeMethodBodyDefaultFromRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyDefaultFromRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyDefaultFromRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			id   string
+			name *string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		nameRaw := r.URL.Query().Get("name")
+		if nameRaw != "" {
+			name = &nameRaw
+		}
+		payload := NewMethodBodyDefaultFromPayload(&body, id, name)
+		if payload.User != nil {
+			if payload.User.DisplayName == nil {
+				def := payload.User.Username
+				payload.User.DisplayName = &def
+			}
+			if payload.User.Contact == nil && payload.User.Email != nil {
+				def := *payload.User.Email
+				payload.User.Contact = &def
+			}
+		}
+		if payload.Name == nil {
+			def := payload.ID
+			payload.Name = &def
+		}
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyUserStrictDecodeCode = `// DecodeMethodBodyUserStrictRequest returns a decoder for requests sent to the
+// ServiceBodyUserStrict MethodBodyUserStrict endpoint.
+func DecodeMethodBodyUserStrictRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyUserStrictRequestBody
+			err  error
+		)
+		err = goahttp.StrictDecoder(decoder(r)).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodBodyUserStrictPayloadType(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyUserMaxRequestBodyDecodeCode = `// DecodeMethodBodyUserMaxRequestBodyRequest returns a decoder for requests
+// sent to the ServiceBodyUserMaxRequestBody MethodBodyUserMaxRequestBody
+// endpoint.
+func DecodeMethodBodyUserMaxRequestBodyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		goahttp.LimitRequestBody(r, 1024)
+		var (
+			body MethodBodyUserMaxRequestBodyRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodBodyUserMaxRequestBodyPayloadType(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyRequestCompressionDecodeCode = `// DecodeMethodRequestCompressionRequest returns a decoder for requests sent to
+// the ServiceRequestCompression MethodRequestCompression endpoint.
+func DecodeMethodRequestCompressionRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		goahttp.LimitRequestBody(r, 1048576)
+		if err := goahttp.DecompressRequestBody(r, 1048576, "zstd", "gzip"); err != nil {
+			return nil, err
+		}
+		var (
+			body MethodRequestCompressionRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodRequestCompressionPayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyTracePhasesDecodeCode = `// DecodeMethodTracePhasesRequest returns a decoder for requests sent to the
+// ServiceTracePhases MethodTracePhases endpoint.
+func DecodeMethodTracePhasesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodTracePhasesRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		_, endPhase := goahttp.StartPhase(r.Context(), goahttp.PhaseValidate)
+		err = ValidateMethodTracePhasesRequestBody(&body)
+		endPhase(err)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodTracePhasesPayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyProtobufDecodeCode = `// DecodeMethodBodyProtobufRequest returns a decoder for requests sent to the
+// ServiceBodyProtobuf MethodBodyProtobuf endpoint.
+func DecodeMethodBodyProtobufRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		if goahttp.IsProtobuf(r.Header.Get("Content-Type")) {
+			var message service_body_protobufpb.MethodBodyProtobufRequest
+			if err := goahttp.NewProtobufDecoder(r.Body).Decode(&message); err != nil {
+				return nil, goa.DecodePayloadError(err.Error())
+			}
+			if err := servicebodyprotobufgrpc.ValidateMethodBodyProtobufRequest(&message); err != nil {
+				return nil, err
+			}
+			payload := servicebodyprotobufgrpc.NewMethodBodyProtobufPayload(&message)
+			return payload, nil
+		}
+		var (
+			body MethodBodyProtobufRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyProtobufRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodBodyProtobufPayloadType(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyRequestContentDecodeCode = `// DecodeMethodBodyRequestContentRequest returns a decoder for requests sent to
+// the ServiceBodyRequestContent MethodBodyRequestContent endpoint.
+func DecodeMethodBodyRequestContentRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		if goahttp.IsContentType(r.Header.Get("Content-Type"), "application/xml") {
+			return DecodeMethodBodyRequestContentXMLRequest(mux, decoder)(r)
+		}
+		var (
+			body struct {
+				Name *string
+			}
+			err error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			contentType *string
+		)
+		contentTypeRaw := r.Header.Get("Content-Type")
+		if contentTypeRaw != "" {
+			contentType = &contentTypeRaw
+		}
+		payload := NewMethodBodyRequestContentPayload(body, contentType)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyQueryPathObjectAggregateDecodeCode = `// DecodeMethodBodyQueryPathObjectAggregateRequest returns a decoder for
+// requests sent to the ServiceBodyQueryPathObjectAggregate
+// MethodBodyQueryPathObjectAggregate endpoint.
+func DecodeMethodBodyQueryPathObjectAggregateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyQueryPathObjectAggregateRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyQueryPathObjectAggregateRequestBody(&body)
+
+		var (
+			c2 string
+			b  string
+
+			params = mux.Vars(r)
+		)
+		c2 = params["c"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("c2", c2, "patternc"))
+		b = r.URL.Query().Get("b")
+		if b == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("b", "query string"))
+		}
+		err = goa.MergeErrors(err, goa.ValidatePattern("b", b, "patternb"))
+		if err != nil {
+			return nil, goa.AggregateErrors(err)
+		}
+		payload := NewMethodBodyQueryPathObjectAggregatePayload(&body, c2, b)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyUserAggregateDecodeCode = `// DecodeMethodBodyUserAggregateRequest returns a decoder for requests sent to
+// the ServiceBodyUserAggregate MethodBodyUserAggregate endpoint.
+func DecodeMethodBodyUserAggregateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyUserAggregateRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyUserAggregateRequestBody(&body)
+		if err != nil {
+			return nil, goa.AggregateErrors(err)
+		}
+		payload := NewMethodBodyUserAggregatePayloadType(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryHeaderStylesDecodeCode = `// DecodeMethodQueryHeaderStylesRequest returns a decoder for requests sent to
+// the ServiceQueryHeaderStyles MethodQueryHeaderStyles endpoint.
+func DecodeMethodQueryHeaderStylesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			ids    []int
+			names  []string
+			labels []string
+			codes  []int
+			err    error
+		)
+		{
+			idsRaw := goahttp.SplitValues(r.URL.Query()["ids"], "|")
+			if idsRaw != nil {
+				ids = make([]int, len(idsRaw))
+				for i, rv := range idsRaw {
+					v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ids", idsRaw, "array of integers"))
+					}
+					ids[i] = int(v)
+				}
+			}
+		}
+		names = goahttp.SplitValues(r.URL.Query()["names"], ",")
+		labels = goahttp.SplitValues(r.Header["X-Labels"], ",")
+		{
+			codesRaw := goahttp.SplitValues(r.Header["X-Codes"], ",")
+
+			if codesRaw != nil {
+				codes = make([]int, len(codesRaw))
+				for i, rv := range codesRaw {
+					v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("codes", codesRaw, "array of integers"))
+					}
+					codes[i] = int(v)
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryHeaderStylesPayload(ids, names, labels, codes)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQuerySeparatorDecodeCode = `// DecodeMethodQuerySeparatorRequest returns a decoder for requests sent to the
+// ServiceQuerySeparator MethodQuerySeparator endpoint.
+func DecodeMethodQuerySeparatorRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			ids  []int
+			tags []string
+			err  error
+		)
+		{
+			idsRaw := goahttp.SplitValues(r.URL.Query()["ids"], ";")
+			if idsRaw != nil {
+				ids = make([]int, len(idsRaw))
+				for i, rv := range idsRaw {
+					v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ids", idsRaw, "array of integers"))
+					}
+					ids[i] = int(v)
+				}
+			}
+		}
+		tags = goahttp.SplitValues(r.Header["X-Tags"], ";")
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQuerySeparatorPayload(ids, tags)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadRenamedDecodeCode = `// DecodeListAccountsRequest returns a decoder for requests sent to the
+// ServiceRenamed list_accounts endpoint.
+func DecodeListAccountsRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		if err := goahttp.RenameRequest(r, &goahttp.Renames{
+			Query:  map[string]string{"q": "query_text"},
+			Header: map[string]string{"x_trace": "trace"},
+			Body:   map[string]string{"name": "display_name"},
+		}); err != nil {
+			if _, ok := err.(*goa.ServiceError); ok {
+				return nil, err
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		var (
+			body ListAccountsRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			queryText *string
+			trace     *string
+		)
+		queryTextRaw := r.URL.Query().Get("query_text")
+		if queryTextRaw != "" {
+			queryText = &queryTextRaw
+		}
+		traceRaw := r.Header.Get("trace")
+		if traceRaw != "" {
+			trace = &traceRaw
+		}
+		payload := NewListAccountsPayload(&body, queryText, trace)
+
+		return payload, nil
+	}
+}
+`
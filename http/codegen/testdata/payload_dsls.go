@@ -367,6 +367,51 @@ var PayloadQueryBytesValidateDSL = func() {
 	})
 }
 
+var PayloadQueryUUIDDSL = func() {
+	Service("ServiceQueryUUID", func() {
+		Method("MethodQueryUUID", func() {
+			Payload(func() {
+				Attribute("q", UUID)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+			})
+		})
+	})
+}
+
+var PayloadQueryTimestampDSL = func() {
+	Service("ServiceQueryTimestamp", func() {
+		Method("MethodQueryTimestamp", func() {
+			Payload(func() {
+				Attribute("q", Timestamp)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+			})
+		})
+	})
+}
+
+var PayloadQueryDecimalDSL = func() {
+	Service("ServiceQueryDecimal", func() {
+		Method("MethodQueryDecimal", func() {
+			Payload(func() {
+				Attribute("q", Decimal, func() {
+					Precision(10)
+					Scale(2)
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+			})
+		})
+	})
+}
+
 var PayloadQueryAnyDSL = func() {
 	Service("ServiceQueryAny", func() {
 		Method("MethodQueryAny", func() {
@@ -772,6 +817,108 @@ var PayloadQueryArrayBytesValidateDSL = func() {
 	})
 }
 
+var PayloadQueryArrayUUIDDSL = func() {
+	Service("ServiceQueryArrayUUID", func() {
+		Method("MethodQueryArrayUUID", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(UUID))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+			})
+		})
+	})
+}
+
+var PayloadQueryArrayTimestampUnixDSL = func() {
+	Service("ServiceQueryArrayTimestampUnix", func() {
+		Method("MethodQueryArrayTimestampUnix", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(Timestamp), func() {
+					TimestampFormat(TimestampUnix)
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+			})
+		})
+	})
+}
+
+var PayloadQueryHeaderStylesDSL = func() {
+	Service("ServiceQueryHeaderStyles", func() {
+		Method("MethodQueryHeaderStyles", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("names", ArrayOf(String))
+				Attribute("labels", ArrayOf(String))
+				Attribute("codes", ArrayOf(Int))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Style(StylePipeDelimited)
+				})
+				Param("names", func() {
+					Explode(false)
+				})
+				Header("labels:X-Labels", func() {
+					Style(StyleSimple)
+				})
+				Header("codes:X-Codes", func() {
+					Style(StyleSimple)
+				})
+			})
+		})
+	})
+}
+
+var PayloadRenamedDSL = func() {
+	Service("ServiceRenamed", func() {
+		Method("list_accounts", func() {
+			RenamedFrom("list")
+			Payload(func() {
+				Attribute("query_text", String, func() {
+					RenamedFrom("q")
+				})
+				Attribute("trace", String, func() {
+					RenamedFrom("x_trace")
+				})
+				Attribute("display_name", String, func() {
+					RenamedFrom("name")
+				})
+			})
+			HTTP(func() {
+				POST("/accounts/list_accounts")
+				Param("query_text")
+				Header("trace")
+			})
+		})
+	})
+}
+
+var PayloadQuerySeparatorDSL = func() {
+	Service("ServiceQuerySeparator", func() {
+		Method("MethodQuerySeparator", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Separator(";")
+				})
+				Header("tags:X-Tags", func() {
+					Separator(";")
+				})
+			})
+		})
+	})
+}
+
 var PayloadQueryArrayAnyDSL = func() {
 	Service("ServiceQueryArrayAny", func() {
 		Method("MethodQueryArrayAny", func() {
@@ -1505,6 +1652,20 @@ var PayloadHeaderStringDSL = func() {
 	})
 }
 
+var PayloadHeaderIdempotencyKeyDSL = func() {
+	Service("ServiceHeaderIdempotencyKey", func() {
+		Method("MethodHeaderIdempotencyKey", func() {
+			Idempotent()
+			Payload(func() {
+				Attribute("a", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var PayloadHeaderStringValidateDSL = func() {
 	Service("ServiceHeaderStringValidate", func() {
 		Method("MethodHeaderStringValidate", func() {
@@ -1703,6 +1864,132 @@ var PayloadBodyUserDSL = func() {
 	})
 }
 
+var PayloadBodyUserStrictDSL = func() {
+	var PayloadType = Type("PayloadType", func() {
+		Attribute("a", String)
+	})
+	Service("ServiceBodyUserStrict", func() {
+		StrictDecoding()
+		Method("MethodBodyUserStrict", func() {
+			Payload(PayloadType)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PayloadBodyUserMaxRequestBodyDSL = func() {
+	var PayloadType = Type("PayloadType", func() {
+		Attribute("a", String)
+	})
+	Service("ServiceBodyUserMaxRequestBody", func() {
+		HTTP(func() {
+			MaxRequestBody("1MB")
+		})
+		Method("MethodBodyUserMaxRequestBody", func() {
+			Payload(PayloadType)
+			HTTP(func() {
+				POST("/")
+				MaxRequestBody("1KB")
+			})
+		})
+	})
+}
+
+var PayloadBodyOptionalFieldsDSL = func() {
+	Service("ServiceBodyOptionalFields", func() {
+		OptionalFields()
+		Method("MethodBodyOptionalFields", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("name", String, func() {
+					MinLength(2)
+				})
+				Attribute("age", UInt)
+				Attribute("active", Boolean, func() {
+					Default(true)
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var PayloadBodyPatchDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("name", String)
+		Attribute("age", Int)
+		Required("name")
+	})
+	Service("ServiceBodyPatch", func() {
+		Method("MethodBodyPatch", func() {
+			Payload(PatchOf(Account, func() {
+				Attribute("id", Int)
+				Required("id")
+			}))
+			HTTP(func() {
+				PATCH("/{id}")
+			})
+		})
+	})
+}
+
+var PayloadBodyRequestContentDSL = func() {
+	var Document = Type("Document", func() {
+		Attribute("title", String)
+		Attribute("pages", Int)
+		Required("title")
+	})
+	Service("ServiceBodyRequestContent", func() {
+		Method("MethodBodyRequestContent", func() {
+			Payload(func() {
+				Attribute("content_type", String)
+				Attribute("name", String)
+				Attribute("document", Document)
+				Required("name", "document")
+			})
+			HTTP(func() {
+				POST("/")
+				Header("content_type:Content-Type")
+				Body(func() {
+					Attribute("name")
+				})
+				RequestContent("application/xml", func() {
+					Body("document")
+				})
+			})
+		})
+	})
+}
+
+var PayloadBodyProtobufDSL = func() {
+	var PayloadType = Type("PayloadType", func() {
+		Field(1, "a", String, func() {
+			MinLength(1)
+		})
+		Field(2, "b", String)
+		Required("a")
+	})
+	API("ProtobufAPI", func() {
+		HTTP(func() {
+			Consumes("application/json", "application/x-protobuf")
+		})
+	})
+	Service("ServiceBodyProtobuf", func() {
+		Method("MethodBodyProtobuf", func() {
+			Payload(PayloadType)
+			HTTP(func() {
+				POST("/")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
 var PayloadBodyUserRequiredDSL = func() {
 	var PayloadType = Type("PayloadType", func() {
 		Attribute("a", String)
@@ -1755,6 +2042,23 @@ var PayloadBodyUserValidateDSL = func() {
 	})
 }
 
+var PayloadBodyUserAggregateDSL = func() {
+	var PayloadType = Type("PayloadType", func() {
+		Attribute("a", String, func() {
+			Pattern("apattern")
+		})
+	})
+	Service("ServiceBodyUserAggregate", func() {
+		Method("MethodBodyUserAggregate", func() {
+			AggregateErrors()
+			Payload(PayloadType)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var PayloadBodyArrayStringDSL = func() {
 	Service("ServiceBodyArrayString", func() {
 		Method("MethodBodyArrayString", func() {
@@ -2240,6 +2544,30 @@ var PayloadBodyQueryPathObjectValidateDSL = func() {
 	})
 }
 
+var PayloadBodyQueryPathObjectAggregateDSL = func() {
+	Service("ServiceBodyQueryPathObjectAggregate", func() {
+		AggregateErrors()
+		Method("MethodBodyQueryPathObjectAggregate", func() {
+			Payload(func() {
+				Attribute("a", String, func() {
+					Pattern("patterna")
+				})
+				Attribute("b", String, func() {
+					Pattern("patternb")
+				})
+				Attribute("c", String, func() {
+					Pattern("patternc")
+				})
+				Required("a", "b", "c")
+			})
+			HTTP(func() {
+				POST("/{c}")
+				Param("b")
+			})
+		})
+	})
+}
+
 var PayloadBodyQueryPathUserDSL = func() {
 	var PayloadType = Type("PayloadType", func() {
 		Attribute("a", String)
@@ -2448,6 +2776,58 @@ var PayloadMapQueryObjectDSL = func() {
 	})
 }
 
+var PayloadMapQueryObjectValuesDSL = func() {
+	var Range = Type("Range", func() {
+		Attribute("min", Int, func() {
+			Minimum(0)
+		})
+		Attribute("max", Int)
+		Attribute("step", Float64, func() {
+			Default(1.5)
+		})
+		Attribute("tags", ArrayOf(String))
+		Attribute("ids", ArrayOf(UInt))
+		Required("min")
+	})
+	var PayloadType = Type("PayloadType", func() {
+		Attribute("a", String)
+		Attribute("ranges", MapOf(Int, Range), func() {
+			Key(func() {
+				Minimum(1)
+			})
+		})
+		Required("a")
+	})
+
+	Service("ServiceMapQueryObjectValues", func() {
+		Method("MethodMapQueryObjectValues", func() {
+			Payload(PayloadType)
+			HTTP(func() {
+				GET("/{a}")
+				MapParams("ranges")
+			})
+		})
+	})
+}
+
+var PayloadMapQueryObjectValuesMapDSL = func() {
+	var Range = Type("Range", func() {
+		Attribute("min", Int)
+		Attribute("tags", ArrayOf(String))
+		Required("min")
+	})
+
+	Service("ServiceMapQueryObjectValuesMap", func() {
+		Method("MethodMapQueryObjectValuesMap", func() {
+			Payload(MapOf(String, Range))
+			HTTP(func() {
+				GET("/")
+				MapParams()
+			})
+		})
+	})
+}
+
 var PayloadMultipartPrimitiveDSL = func() {
 	Service("ServiceMultipartPrimitive", func() {
 		Method("MethodMultipartPrimitive", func() {
@@ -2646,3 +3026,123 @@ var WithParamsAndHeadersBlockDSL = func() {
 		})
 	})
 }
+
+var PayloadBodyEncryptedDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("name", String)
+		Attribute("ssn", String, func() {
+			Encrypted()
+		})
+		Attribute("key", Bytes, func() {
+			Encrypted()
+		})
+		Attribute("pin", String, func() {
+			Encrypted()
+		})
+		Required("name", "pin")
+	})
+	Service("ServiceBodyEncrypted", func() {
+		Method("MethodBodyEncrypted", func() {
+			Payload(Account)
+			Result(Account)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PayloadBodyTimeZoneDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("starts_at", String, func() {
+			Format(FormatDateTime)
+			TimeZone("UTC")
+		})
+		Attribute("ends_at", String, func() {
+			Format(FormatDateTime)
+			TimeZone("+02:00")
+		})
+		Attribute("reminders", ArrayOf(String, func() {
+			Format(FormatDateTime)
+			TimeZone("UTC")
+		}))
+		Required("starts_at")
+	})
+	Service("ServiceBodyTimeZone", func() {
+		Method("MethodBodyTimeZone", func() {
+			Payload(func() {
+				Attribute("event", Event)
+				Attribute("since", String, func() {
+					Format(FormatDateTime)
+					TimeZone("UTC")
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Body("event")
+				Param("since")
+			})
+		})
+	})
+}
+
+var PayloadBodyDefaultFromDSL = func() {
+	var User = Type("User", func() {
+		Attribute("username", String)
+		Attribute("display_name", String, func() {
+			DefaultFrom("username")
+		})
+		Attribute("email", String)
+		Attribute("contact", String, func() {
+			DefaultFrom("email")
+		})
+		Required("username")
+	})
+	Service("ServiceBodyDefaultFrom", func() {
+		Method("MethodBodyDefaultFrom", func() {
+			Payload(func() {
+				Attribute("user", User)
+				Attribute("id", String)
+				Attribute("name", String, func() {
+					DefaultFrom("id")
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				POST("/{id}")
+				Body("user")
+				Param("name")
+			})
+		})
+	})
+}
+
+var PayloadBodyXMLDSL = func() {
+	var Book = Type("Book", func() {
+		XMLName("book")
+		XMLNamespace("http://example.com/book", "bk")
+		Attribute("id", Int, func() {
+			XMLAttribute()
+		})
+		Attribute("title", String, func() {
+			XMLName("Title")
+		})
+		Attribute("tags", ArrayOf(String, func() {
+			XMLName("tag")
+		}), func() {
+			XMLWrapped()
+		})
+		Attribute("authors", ArrayOf(String, func() {
+			XMLName("author")
+		}))
+		Required("id")
+	})
+	Service("ServiceBodyXML", func() {
+		Method("MethodBodyXML", func() {
+			Payload(Book)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
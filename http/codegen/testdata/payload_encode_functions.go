@@ -2172,8 +2172,9 @@ func EncodeMapQueryPrimitivePrimitiveRequest(encoder func(*http.Request) goahttp
 		values := req.URL.Query()
 		for key, value := range p {
 			keyStr := key
+			keyName := fmt.Sprintf("query[%s]", keyStr)
 			valueStr := value
-			values.Add(keyStr, valueStr)
+			values.Add(keyName, valueStr)
 		}
 		req.URL.RawQuery = values.Encode()
 		return nil
@@ -2192,9 +2193,10 @@ func EncodeMapQueryPrimitiveArrayRequest(encoder func(*http.Request) goahttp.Enc
 		values := req.URL.Query()
 		for key, value := range p {
 			keyStr := key
+			keyName := fmt.Sprintf("query[%s]", keyStr)
 			for _, val := range value {
 				valStr := strconv.FormatUint(uint64(val), 10)
-				values.Add(keyStr, valStr)
+				values.Add(keyName, valStr)
 			}
 		}
 		req.URL.RawQuery = values.Encode()
@@ -2214,9 +2216,10 @@ func EncodeMethodMapQueryObjectRequest(encoder func(*http.Request) goahttp.Encod
 		values := req.URL.Query()
 		for key, value := range p.C {
 			keyStr := strconv.Itoa(key)
+			keyName := fmt.Sprintf("c[%s]", keyStr)
 			for _, val := range value {
 				valStr := val
-				values.Add(keyStr, valStr)
+				values.Add(keyName, valStr)
 			}
 		}
 		req.URL.RawQuery = values.Encode()
@@ -2229,6 +2232,43 @@ func EncodeMethodMapQueryObjectRequest(encoder func(*http.Request) goahttp.Encod
 }
 `
 
+var PayloadMapQueryObjectValuesEncodeCode = `// EncodeMethodMapQueryObjectValuesRequest returns an encoder for requests sent
+// to the ServiceMapQueryObjectValues MethodMapQueryObjectValues server.
+func EncodeMethodMapQueryObjectValuesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicemapqueryobjectvalues.PayloadType)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceMapQueryObjectValues", "MethodMapQueryObjectValues", "*servicemapqueryobjectvalues.PayloadType", v)
+		}
+		values := req.URL.Query()
+		for key, value := range p.Ranges {
+			keyStr := strconv.Itoa(key)
+			keyName := fmt.Sprintf("ranges[%s]", keyStr)
+			if value == nil {
+				continue
+			}
+			minStr := strconv.Itoa(value.Min)
+			values.Add(keyName+"[min]", minStr)
+			if value.Max != nil {
+				maxStr := strconv.Itoa(*value.Max)
+				values.Add(keyName+"[max]", maxStr)
+			}
+			stepStr := strconv.FormatFloat(value.Step, 'f', -1, 64)
+			values.Add(keyName+"[step]", stepStr)
+			for _, val := range value.Tags {
+				values.Add(keyName+"[tags]", val)
+			}
+			for _, val := range value.Ids {
+				valStr := strconv.FormatUint(uint64(val), 10)
+				values.Add(keyName+"[ids]", valStr)
+			}
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
 var PayloadMultipartBodyPrimitiveEncodeCode = `// EncodeMethodMultipartPrimitiveRequest returns an encoder for requests sent
 // to the ServiceMultipartPrimitive MethodMultipartPrimitive server.
 func EncodeMethodMultipartPrimitiveRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
@@ -2292,3 +2332,134 @@ func EncodeMethodMultipartMapTypeRequest(encoder func(*http.Request) goahttp.Enc
 	}
 }
 `
+
+var PayloadBodyEncryptedEncodeCode = `// EncodeMethodBodyEncryptedRequest returns an encoder for requests sent to the
+// ServiceBodyEncrypted MethodBodyEncrypted server.
+func EncodeMethodBodyEncryptedRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicebodyencrypted.Account)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceBodyEncrypted", "MethodBodyEncrypted", "*servicebodyencrypted.Account", v)
+		}
+		body := NewMethodBodyEncryptedRequestBody(p)
+		if err := EncryptMethodBodyEncryptedRequestBody(req.Context(), body); err != nil {
+			return goahttp.ErrEncodingError("ServiceBodyEncrypted", "MethodBodyEncrypted", err)
+		}
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("ServiceBodyEncrypted", "MethodBodyEncrypted", err)
+		}
+		return nil
+	}
+}
+`
+
+var PayloadBodyPatchEncodeCode = `// EncodeMethodBodyPatchRequest returns an encoder for requests sent to the
+// ServiceBodyPatch MethodBodyPatch server.
+func EncodeMethodBodyPatchRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicebodypatch.AccountPatch)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceBodyPatch", "MethodBodyPatch", "*servicebodypatch.AccountPatch", v)
+		}
+		body := NewMethodBodyPatchRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("ServiceBodyPatch", "MethodBodyPatch", err)
+		}
+		if req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+		}
+		return nil
+	}
+}
+`
+
+var PayloadBodyRequestContentEncodeCode = `// EncodeMethodBodyRequestContentRequest returns an encoder for requests sent
+// to the ServiceBodyRequestContent MethodBodyRequestContent server.
+func EncodeMethodBodyRequestContentRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicebodyrequestcontent.MethodBodyRequestContentPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceBodyRequestContent", "MethodBodyRequestContent", "*servicebodyrequestcontent.MethodBodyRequestContentPayload", v)
+		}
+		if p.ContentType != nil {
+			req.Header.Set("Content-Type", *p.ContentType)
+		}
+		switch ct := req.Header.Get("Content-Type"); {
+		case goahttp.IsContentType(ct, "application/xml"):
+			body := NewMethodBodyRequestContentXMLRequestBody(p)
+			if err := encoder(req).Encode(&body); err != nil {
+				return goahttp.ErrEncodingError("ServiceBodyRequestContent", "MethodBodyRequestContent", err)
+			}
+		default:
+			body := p
+			if err := encoder(req).Encode(&body); err != nil {
+				return goahttp.ErrEncodingError("ServiceBodyRequestContent", "MethodBodyRequestContent", err)
+			}
+		}
+		return nil
+	}
+}
+`
+
+var PayloadQueryHeaderStylesEncodeCode = `// EncodeMethodQueryHeaderStylesRequest returns an encoder for requests sent to
+// the ServiceQueryHeaderStyles MethodQueryHeaderStyles server.
+func EncodeMethodQueryHeaderStylesRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryheaderstyles.MethodQueryHeaderStylesPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryHeaderStyles", "MethodQueryHeaderStyles", "*servicequeryheaderstyles.MethodQueryHeaderStylesPayload", v)
+		}
+		if len(p.Labels) > 0 {
+			req.Header.Set("X-Labels", strings.Join(p.Labels, ","))
+		}
+		if len(p.Codes) > 0 {
+			codesValues := make([]string, len(p.Codes))
+			for i, value := range p.Codes {
+				valueStr := strconv.Itoa(value)
+				codesValues[i] = valueStr
+			}
+			req.Header.Set("X-Codes", strings.Join(codesValues, ","))
+		}
+		values := req.URL.Query()
+		if len(p.Ids) > 0 {
+			idsValues := make([]string, len(p.Ids))
+			for i, value := range p.Ids {
+				valueStr := strconv.Itoa(value)
+				idsValues[i] = valueStr
+			}
+			values.Add("ids", strings.Join(idsValues, "|"))
+		}
+		if len(p.Names) > 0 {
+			values.Add("names", strings.Join(p.Names, ","))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
+var PayloadQuerySeparatorEncodeCode = `// EncodeMethodQuerySeparatorRequest returns an encoder for requests sent to
+// the ServiceQuerySeparator MethodQuerySeparator server.
+func EncodeMethodQuerySeparatorRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryseparator.MethodQuerySeparatorPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQuerySeparator", "MethodQuerySeparator", "*servicequeryseparator.MethodQuerySeparatorPayload", v)
+		}
+		if len(p.Tags) > 0 {
+			req.Header.Set("X-Tags", strings.Join(p.Tags, ";"))
+		}
+		values := req.URL.Query()
+		if len(p.Ids) > 0 {
+			idsValues := make([]string, len(p.Ids))
+			for i, value := range p.Ids {
+				valueStr := strconv.Itoa(value)
+				idsValues[i] = valueStr
+			}
+			values.Add("ids", strings.Join(idsValues, ";"))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
@@ -784,3 +784,199 @@ func DecodeMethodAResponse(decoder func(*http.Response) goahttp.Decoder, restore
 	}
 }
 `
+
+var ResultETagDecodeCode = `// DecodeMethodETagResponse returns a decoder for responses returned by the
+// ServiceETag MethodETag endpoint. restoreBody controls whether the response
+// body should be restored after having been read.
+func DecodeMethodETagResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body MethodETagResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceETag", "MethodETag", err)
+			}
+			var (
+				version *string
+			)
+			versionRaw := resp.Header.Get("Etag")
+			if versionRaw != "" {
+				version = &versionRaw
+			}
+			res := NewMethodETagResultOK(&body, version)
+			return res, nil
+		case http.StatusNotModified:
+			return nil, goahttp.ErrNotModified("ServiceETag", "MethodETag")
+		case http.StatusPreconditionFailed:
+			return nil, goahttp.ErrPreconditionFailed("ServiceETag", "MethodETag")
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceETag", "MethodETag", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var ResultEncryptedDecodeCode = `// DecodeMethodBodyEncryptedResponse returns a decoder for responses returned
+// by the ServiceBodyEncrypted MethodBodyEncrypted endpoint. restoreBody
+// controls whether the response body should be restored after having been read.
+func DecodeMethodBodyEncryptedResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			var (
+				body MethodBodyEncryptedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceBodyEncrypted", "MethodBodyEncrypted", err)
+			}
+			if err = DecryptMethodBodyEncryptedResponseBody(resp.Request.Context(), &body); err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceBodyEncrypted", "MethodBodyEncrypted", err)
+			}
+			err = ValidateMethodBodyEncryptedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceBodyEncrypted", "MethodBodyEncrypted", err)
+			}
+			res := NewMethodBodyEncryptedAccountNoContent(&body)
+			return res, nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceBodyEncrypted", "MethodBodyEncrypted", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var RetryAfterErrorResponseDecodeCode = `// DecodeMethodRetryAfterErrorResponseResponse returns a decoder for responses
+// returned by the ServiceRetryAfterErrorResponse MethodRetryAfterErrorResponse
+// endpoint. restoreBody controls whether the response body should be restored
+// after having been read.
+// DecodeMethodRetryAfterErrorResponseResponse may return the following errors:
+//   - "rate_limited" (type *goa.ServiceError): http.StatusTooManyRequests
+//   - error: internal error
+func DecodeMethodRetryAfterErrorResponseResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil, nil
+		case http.StatusTooManyRequests:
+			var (
+				body MethodRetryAfterErrorResponseRateLimitedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceRetryAfterErrorResponse", "MethodRetryAfterErrorResponse", err)
+			}
+			err = ValidateMethodRetryAfterErrorResponseRateLimitedResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceRetryAfterErrorResponse", "MethodRetryAfterErrorResponse", err)
+			}
+			res := NewMethodRetryAfterErrorResponseRateLimited(&body)
+			res.RetryAfter = goahttp.RetryAfter(resp)
+			return nil, res
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceRetryAfterErrorResponse", "MethodRetryAfterErrorResponse", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var ResultPassThroughHeadersDecodeCode = `// DecodeMethodPassThroughHeadersResponse returns a decoder for responses
+// returned by the ServicePassThroughHeaders MethodPassThroughHeaders endpoint.
+// restoreBody controls whether the response body should be restored after
+// having been read.
+// DecodeMethodPassThroughHeadersResponse may return the following errors:
+//   - "bad_request" (type *goa.ServiceError): http.StatusBadRequest
+//   - error: internal error
+func DecodeMethodPassThroughHeadersResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		goahttp.PassThroughHeaders(resp, "X-Request-Id", "X-Ratelimit-Remaining")
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body MethodPassThroughHeadersResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServicePassThroughHeaders", "MethodPassThroughHeaders", err)
+			}
+			res := NewMethodPassThroughHeadersResultOK(&body)
+			return res, nil
+		case http.StatusBadRequest:
+			var (
+				body MethodPassThroughHeadersBadRequestResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServicePassThroughHeaders", "MethodPassThroughHeaders", err)
+			}
+			err = ValidateMethodPassThroughHeadersBadRequestResponseBody(&body)
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServicePassThroughHeaders", "MethodPassThroughHeaders", err)
+			}
+			return nil, NewMethodPassThroughHeadersBadRequest(&body)
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServicePassThroughHeaders", "MethodPassThroughHeaders", resp.StatusCode, string(body))
+		}
+	}
+}
+`
@@ -626,6 +626,159 @@ var ResultBodyMultipleViewsDSL = func() {
 	})
 }
 
+var ResultViewParamDSL = func() {
+	var ResultType = ResultType("ResultTypeViewParam", func() {
+		Attribute("a", String)
+		Attribute("b", String)
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("ServiceViewParam", func() {
+		Method("MethodViewParam", func() {
+			Result(ResultType)
+			HTTP(func() {
+				GET("/")
+				ViewParam()
+			})
+		})
+	})
+}
+
+var ResultFieldMaskDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("id", String)
+		Attribute("name", String)
+		Required("id")
+	})
+	Service("ServiceFieldMask", func() {
+		Method("MethodFieldMask", func() {
+			FieldMask()
+			Result(Account)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ResultETagDSL = func() {
+	Service("ServiceETag", func() {
+		Method("MethodETag", func() {
+			Result(func() {
+				Attribute("a", String)
+				Attribute("version", String, func() {
+					ETag()
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ResultFixedHeadersDSL = func() {
+	API("FixedHeaders", func() {
+		HTTP(func() {
+			FixedHeader("X-Content-Type-Options", "nosniff")
+			FixedHeader("X-Frame-Options", "DENY")
+		})
+	})
+	Service("ServiceFixedHeaders", func() {
+		HTTP(func() {
+			FixedHeader("x-frame-options", "SAMEORIGIN")
+			FixedHeader("Strict-Transport-Security", "max-age=63072000")
+		})
+		Method("MethodFixedHeaders", func() {
+			Result(func() {
+				Attribute("a", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ResultPassThroughHeadersDSL = func() {
+	Service("ServicePassThroughHeaders", func() {
+		HTTP(func() {
+			PassThroughHeaders("x-request-id", "X-RateLimit-Remaining")
+		})
+		Method("MethodPassThroughHeaders", func() {
+			Result(func() {
+				Attribute("a", String)
+			})
+			Error("bad_request")
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
+
+var ResultProtobufDSL = func() {
+	var RT = ResultType("application/vnd.result", func() {
+		Attributes(func() {
+			Field(1, "a", String)
+			Field(2, "b", String)
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("b")
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	API("ProtobufAPI", func() {
+		HTTP(func() {
+			Produces("application/json", "application/x-protobuf")
+		})
+	})
+	Service("ServiceProtobuf", func() {
+		Method("MethodProtobuf", func() {
+			Result(RT)
+			HTTP(func() {
+				GET("/")
+				Response(StatusCreated)
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var ResultCachingDSL = func() {
+	Service("ServiceCaching", func() {
+		Method("MethodCaching", func() {
+			Result(func() {
+				Attribute("a", String)
+			})
+			Error("bad_request")
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					CacheControl("public", "max-age=3600")
+					Expires("1h")
+					Vary("Accept", "Accept-Encoding")
+				})
+				Response("bad_request", StatusBadRequest, func() {
+					CacheControl("no-store")
+				})
+			})
+		})
+	})
+}
+
 var ResultBodyCollectionDSL = func() {
 	var RT = ResultType("ResultTypeCollection", func() {
 		Attributes(func() {
@@ -1234,3 +1387,21 @@ var ValidateErrorResponseTypeDSL = func() {
 		})
 	})
 }
+
+var ResultBodyEncryptedDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("name", String)
+		Attribute("ssn", String, func() {
+			Encrypted()
+		})
+		Required("name")
+	})
+	Service("ServiceBodyEncrypted", func() {
+		Method("MethodBodyEncrypted", func() {
+			Result(Account)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
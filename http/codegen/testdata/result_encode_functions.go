@@ -993,3 +993,110 @@ func EncodeMethodEmptyServerResponseWithTagsResponse(encoder func(context.Contex
 	}
 }
 `
+
+var ResultETagEncodeCode = `// EncodeMethodETagResponse returns an encoder for responses returned by the
+// ServiceETag MethodETag endpoint.
+func EncodeMethodETagResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*serviceetag.MethodETagResult)
+		enc := encoder(ctx, w)
+		body := NewMethodETagResponseBody(res)
+		if res.Version != nil {
+			w.Header().Set("Etag", *res.Version)
+		}
+		if status := goahttp.CheckConditionalRequest(ctx, w.Header().Get("ETag")); status != 0 {
+			w.WriteHeader(status)
+			return nil
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultCachingEncodeCode = `// EncodeMethodCachingResponse returns an encoder for responses returned by the
+// ServiceCaching MethodCaching endpoint.
+func EncodeMethodCachingResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicecaching.MethodCachingResult)
+		enc := encoder(ctx, w)
+		body := NewMethodCachingResponseBody(res)
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Header().Set("Expires", time.Now().Add(3600*time.Second).UTC().Format(http.TimeFormat))
+		w.Header().Set("Vary", "Accept, Accept-Encoding")
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultEncryptedEncodeCode = `// EncodeMethodBodyEncryptedResponse returns an encoder for responses returned
+// by the ServiceBodyEncrypted MethodBodyEncrypted endpoint.
+func EncodeMethodBodyEncryptedResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicebodyencrypted.Account)
+		enc := encoder(ctx, w)
+		body := NewMethodBodyEncryptedResponseBody(res)
+		if err := EncryptMethodBodyEncryptedResponseBody(ctx, body); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultFixedHeadersEncodeCode = `// EncodeMethodFixedHeadersResponse returns an encoder for responses returned
+// by the ServiceFixedHeaders MethodFixedHeaders endpoint.
+func EncodeMethodFixedHeadersResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+		w.Header().Set("Strict-Transport-Security", "max-age=63072000")
+		res := v.(*servicefixedheaders.MethodFixedHeadersResult)
+		enc := encoder(ctx, w)
+		body := NewMethodFixedHeadersResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultProtobufEncodeCode = `// EncodeMethodProtobufResponse returns an encoder for responses returned by
+// the ServiceProtobuf MethodProtobuf endpoint.
+func EncodeMethodProtobufResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*serviceprotobufviews.Result)
+		w.Header().Set("goa-view", res.View)
+		if goahttp.AcceptsProtobuf(ctx) {
+			w.Header().Set("Content-Type", goahttp.ProtobufContentType)
+			w.WriteHeader(http.StatusCreated)
+			return goahttp.NewProtobufEncoder(w).Encode(serviceprotobufgrpc.NewMethodProtobufResponse(res.Projected))
+		}
+		enc := encoder(ctx, w)
+		var body interface{}
+		switch res.View {
+		case "default", "":
+			body = NewMethodProtobufResponseBody(res.Projected)
+		case "tiny":
+			body = NewMethodProtobufResponseBodyTiny(res.Projected)
+		}
+		w.WriteHeader(http.StatusCreated)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultPassThroughHeadersEncodeCode = `// EncodeMethodPassThroughHeadersResponse returns an encoder for responses
+// returned by the ServicePassThroughHeaders MethodPassThroughHeaders endpoint.
+func EncodeMethodPassThroughHeadersResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		goahttp.WritePassThroughHeaders(ctx, w)
+		res := v.(*servicepassthroughheaders.MethodPassThroughHeadersResult)
+		enc := encoder(ctx, w)
+		body := NewMethodPassThroughHeadersResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
@@ -41,6 +41,74 @@ var ServerNoPayloadResultDSL = func() {
 	})
 }
 
+var ServerSunsetDSL = func() {
+	Service("ServiceSunset", func() {
+		Method("MethodSunset", func() {
+			Sunset("2021-06-30")
+			Result(func() {
+				Attribute("b", Boolean)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ServerTimeoutDSL = func() {
+	Service("ServiceTimeout", func() {
+		Method("MethodTimeout", func() {
+			Timeout("1m30s")
+			Result(func() {
+				Attribute("b", Boolean)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ServerTracePhasesDSL = func() {
+	Service("ServiceTracePhases", func() {
+		HTTP(func() {
+			TracePhases()
+		})
+		Method("MethodTracePhases", func() {
+			Payload(func() {
+				Attribute("a", String)
+				Required("a")
+			})
+			Result(func() {
+				Attribute("b", Boolean)
+			})
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ServerRequestCompressionDSL = func() {
+	Service("ServiceRequestCompression", func() {
+		HTTP(func() {
+			RequestCompression("zstd", "gzip")
+			MaxRequestBody("1MB")
+		})
+		Method("MethodRequestCompression", func() {
+			Payload(func() {
+				Attribute("a", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var ServerPayloadResultDSL = func() {
 	Service("ServicePayloadResult", func() {
 		Method("MethodPayloadResult", func() {
@@ -119,6 +187,24 @@ var ServerMultiEndpointsDSL = func() {
 	})
 }
 
+var ServerEncoderOptionsDSL = func() {
+	API("EncoderOptions", func() {
+		HTTP(func() {
+			DisableHTMLEscaping()
+			NoSniff()
+			JSONPrefix(")]}',\n")
+		})
+	})
+	Service("ServiceEncoderOptions", func() {
+		Method("MethodEncoderOptions", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var ServerFileServerDSL = func() {
 	Service("ServiceFileServer", func() {
 		HTTP(func() {
@@ -156,3 +242,294 @@ var ServerMultipartDSL = func() {
 		})
 	})
 }
+
+var ServerIdempotentDSL = func() {
+	Service("ServiceIdempotent", func() {
+		Method("MethodIdempotent", func() {
+			Idempotent()
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodNotIdempotent", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerCompressionDSL = func() {
+	Service("ServiceCompression", func() {
+		HTTP(func() {
+			Compression()
+		})
+		Method("MethodCompressed", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("MethodBrotli", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/brotli")
+				Compression("br", "gzip")
+			})
+		})
+		Method("MethodNotCompressed", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/raw")
+				NoCompression()
+			})
+		})
+	})
+}
+
+var ServerH2CHTTP3DSL = func() {
+	API("H2CHTTP3", func() {
+		Meta("http:example:h2c")
+		Meta("http:example:http3")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerResponseHookDSL = func() {
+	var HookResult = Type("HookResult", func() {
+		Attribute("a", String)
+	})
+	var HookResultType = ResultType("application/vnd.result", func() {
+		Attributes(func() {
+			Attribute("b", String)
+		})
+	})
+	Service("ServiceResponseHook", func() {
+		Method("MethodNoResult", func() {
+			HTTP(func() {
+				GET("/no-result")
+			})
+		})
+		Method("MethodPrimitiveResult", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/primitive")
+			})
+		})
+		Method("MethodUserTypeResult", func() {
+			Result(HookResult)
+			HTTP(func() {
+				GET("/user-type")
+			})
+		})
+		Method("MethodViewedResult", func() {
+			Result(HookResultType)
+			HTTP(func() {
+				GET("/viewed")
+			})
+		})
+		Method("MethodStreamingResult", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/streaming")
+			})
+		})
+	})
+}
+
+var ServerCanaryDSL = func() {
+	Service("ServiceCanary", func() {
+		Method("MethodCanary", func() {
+			Payload(String)
+			HTTP(func() {
+				GET("/{p}")
+				Canary(12.5, "X-Canary", "true")
+			})
+		})
+		Method("MethodNoCanary", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var ServerVersionedDSL = func() {
+	Service("ServiceVersioned", func() {
+		Method("ListV1", func() {
+			HTTP(func() {
+				GET("/items")
+				APIVersion("1")
+			})
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/items")
+				GET("/all")
+				APIVersion("2")
+			})
+		})
+		Method("Create", func() {
+			HTTP(func() {
+				POST("/items")
+			})
+		})
+	})
+}
+
+var ServerVersionedTrailingSlashDSL = func() {
+	Service("ServiceVersioned", func() {
+		HTTP(func() {
+			TrailingSlash("rewrite")
+		})
+		Method("ListV1", func() {
+			HTTP(func() {
+				GET("/items")
+				APIVersion("1")
+			})
+		})
+		Method("List", func() {
+			HTTP(func() {
+				GET("/items")
+				GET("/all")
+				APIVersion("2")
+			})
+		})
+	})
+}
+
+var ServerRouterChiDSL = serverRouterDSL("chi")
+
+var ServerRouterGorillaDSL = serverRouterDSL("gorilla")
+
+var ServerRouterHTTPRouterDSL = serverRouterDSL("httprouter")
+
+var ServerRouterServeMuxDSL = serverRouterDSL("servemux")
+
+func serverRouterDSL(router string) func() {
+	return func() {
+		API("test", func() {
+			HTTP(func() {
+				Router(router)
+			})
+		})
+		Service("ServiceRouter", func() {
+			Method("List", func() {
+				HTTP(func() {
+					GET("/")
+				})
+			})
+			Method("Download", func() {
+				Payload(func() {
+					Attribute("id", String)
+					Attribute("path", String)
+				})
+				HTTP(func() {
+					GET("/items/{id}/files/{*path}")
+				})
+			})
+		})
+	}
+}
+
+var ServerTrailingSlashStrictDSL = serverTrailingSlashDSL("strict")
+
+var ServerTrailingSlashRedirectDSL = serverTrailingSlashDSL("redirect")
+
+var ServerTrailingSlashRewriteDSL = serverTrailingSlashDSL("rewrite")
+
+func serverTrailingSlashDSL(policy string) func() {
+	return func() {
+		API("test", func() {
+			HTTP(func() {
+				TrailingSlash(policy)
+			})
+		})
+		Service("ServiceTrailingSlash", func() {
+			Method("Root", func() {
+				HTTP(func() {
+					GET("/")
+				})
+			})
+			Method("List", func() {
+				HTTP(func() {
+					GET("/items")
+				})
+			})
+			Method("Show", func() {
+				Payload(String)
+				HTTP(func() {
+					GET("/items/{id}")
+				})
+			})
+			Method("Download", func() {
+				Payload(String)
+				HTTP(func() {
+					GET("/files/{*path}")
+				})
+			})
+		})
+	}
+}
+
+var ServerSignedURLDSL = func() {
+	Service("ServiceSignedURL", func() {
+		Method("Download", func() {
+			Payload(func() {
+				Attribute("bucket", String)
+				Attribute("path", String)
+			})
+			HTTP(func() {
+				GET("/buckets/{bucket}/files/{*path}")
+				SignedURL()
+			})
+		})
+		Method("Share", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/share")
+			})
+		})
+	})
+}
+
+var ServerRouterChiConstraintsDSL = serverRouterConstraintsDSL("chi")
+
+var ServerRouterGorillaConstraintsDSL = serverRouterConstraintsDSL("gorilla")
+
+var ServerRouterConstraintsDSL = serverRouterConstraintsDSL("")
+
+func serverRouterConstraintsDSL(router string) func() {
+	return func() {
+		API("test", func() {
+			HTTP(func() {
+				if router != "" {
+					Router(router)
+				}
+			})
+		})
+		Service("ServiceRouter", func() {
+			Method("Show", func() {
+				Payload(func() {
+					Attribute("id", String)
+					Attribute("slug", String, func() {
+						Pattern("^[a-z-]+$")
+					})
+					Attribute("version", String, func() {
+						Pattern("^v")
+					})
+				})
+				HTTP(func() {
+					GET("/items/{id:[0-9]+}/{slug}/{version}")
+				})
+			})
+		})
+	}
+}
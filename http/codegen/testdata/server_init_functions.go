@@ -118,3 +118,636 @@ func New(
 	}
 }
 `
+
+var ServerUseIdempotencyCode = `// UseIdempotency wraps the handlers of the idempotent methods with the
+// idempotency middleware backed by the given store.
+func (s *Server) UseIdempotency(store middleware.IdempotencyStore, opts ...middleware.IdempotencyOption) {
+	m := middleware.Idempotency(store, opts...)
+	s.MethodIdempotent = m(s.MethodIdempotent)
+}
+`
+
+var ServerUseCompressionCode = `// UseCompression wraps the handlers of the endpoints whose responses are
+// compressed with the compression middleware. The middleware selects the
+// content coding from the request Accept-Encoding header.
+func (s *Server) UseCompression() {
+	s.MethodCompressed = middleware.Compress("gzip", "deflate")(s.MethodCompressed)
+	s.MethodBrotli = middleware.Compress("br", "gzip")(s.MethodBrotli)
+}
+`
+
+var ServerUseResponseHookCode = `// UseMethodNoResultResponseHook registers a hook invoked with the response
+// writer of the "MethodNoResult" endpoint before the response is encoded. The
+// hook may set response headers and override the response status code by
+// calling WriteHeader.
+func (s *Server) UseMethodNoResultResponseHook(hook func(context.Context, http.ResponseWriter)) {
+	s.MethodNoResult = goahttp.ResponseHookMiddleware(func(ctx context.Context, w http.ResponseWriter, v interface{}) {
+		hook(ctx, w)
+	})(s.MethodNoResult)
+}
+
+// UseMethodPrimitiveResultResponseHook registers a hook invoked with the
+// result of the "MethodPrimitiveResult" endpoint and the response writer
+// before the result is encoded. The hook may set response headers and override
+// the response status code by calling WriteHeader.
+func (s *Server) UseMethodPrimitiveResultResponseHook(hook func(context.Context, http.ResponseWriter, string)) {
+	s.MethodPrimitiveResult = goahttp.ResponseHookMiddleware(func(ctx context.Context, w http.ResponseWriter, v interface{}) {
+		hook(ctx, w, v.(string))
+	})(s.MethodPrimitiveResult)
+}
+
+// UseMethodUserTypeResultResponseHook registers a hook invoked with the result
+// of the "MethodUserTypeResult" endpoint and the response writer before the
+// result is encoded. The hook may set response headers and override the
+// response status code by calling WriteHeader.
+func (s *Server) UseMethodUserTypeResultResponseHook(hook func(context.Context, http.ResponseWriter, *serviceresponsehook.HookResult)) {
+	s.MethodUserTypeResult = goahttp.ResponseHookMiddleware(func(ctx context.Context, w http.ResponseWriter, v interface{}) {
+		hook(ctx, w, v.(*serviceresponsehook.HookResult))
+	})(s.MethodUserTypeResult)
+}
+
+// UseMethodViewedResultResponseHook registers a hook invoked with the result
+// of the "MethodViewedResult" endpoint and the response writer before the
+// result is encoded. The hook may set response headers and override the
+// response status code by calling WriteHeader.
+func (s *Server) UseMethodViewedResultResponseHook(hook func(context.Context, http.ResponseWriter, *serviceresponsehook.Result)) {
+	s.MethodViewedResult = goahttp.ResponseHookMiddleware(func(ctx context.Context, w http.ResponseWriter, v interface{}) {
+		hook(ctx, w, serviceresponsehook.NewResult(v.(*serviceresponsehookviews.Result)))
+	})(s.MethodViewedResult)
+}
+`
+
+var ServerMountVersionedCode = `// Mount configures the mux to serve the ServiceVersioned endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountCreateHandler(mux, h.Create)
+	mux.Handle("GET", "/items", goahttp.VersionHandler("X-API-Version", "2", map[string]http.Handler{
+		"1": h.ListV1,
+		"2": h.List,
+	}))
+	mux.Handle("GET", "/all", goahttp.VersionHandler("X-API-Version", "2", map[string]http.Handler{
+		"2": h.List,
+	}))
+}
+`
+
+var ServerMountVersionedTrailingSlashCode = `// Mount configures the mux to serve the ServiceVersioned endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	mux.Handle("GET", "/items", goahttp.VersionHandler("X-API-Version", "2", map[string]http.Handler{
+		"1": h.ListV1,
+		"2": h.List,
+	}))
+	mux.Handle("GET", "/items/", goahttp.VersionHandler("X-API-Version", "2", map[string]http.Handler{
+		"1": h.ListV1,
+		"2": h.List,
+	}))
+	mux.Handle("GET", "/all", goahttp.VersionHandler("X-API-Version", "2", map[string]http.Handler{
+		"2": h.List,
+	}))
+	mux.Handle("GET", "/all/", goahttp.VersionHandler("X-API-Version", "2", map[string]http.Handler{
+		"2": h.List,
+	}))
+}
+`
+
+var ServerEncoderOptionsConstructorCode = `// New instantiates HTTP handlers for all the ServiceEncoderOptions service
+// endpoints.
+func New(
+	e *serviceencoderoptions.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	enc = goahttp.EncoderWithOptions(enc, &goahttp.EncoderOptions{
+		DisableHTMLEscaping: true,
+		NoSniff:             true,
+		JSONPrefix:          ")]}',\n",
+	})
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodEncoderOptions", "GET", "/"},
+		},
+		MethodEncoderOptions: NewMethodEncoderOptionsHandler(e.MethodEncoderOptions, mux, dec, enc, eh),
+	}
+}
+`
+
+var ServerCanaryConstructorCode = `// New instantiates HTTP handlers for all the ServiceCanary service endpoints.
+func New(
+	e *servicecanary.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	canary *servicecanary.Endpoints,
+) *Server {
+	s := &Server{
+		Mounts: []*MountPoint{
+			{"MethodCanary", "GET", "/{p}"},
+			{"MethodNoCanary", "POST", "/"},
+		},
+		MethodCanary:   NewMethodCanaryHandler(e.MethodCanary, mux, dec, enc, eh),
+		MethodNoCanary: NewMethodNoCanaryHandler(e.MethodNoCanary, mux, dec, enc, eh),
+	}
+	if canary != nil {
+		// Serve the requests selected by the routers with the handlers of the canary
+		// endpoints.
+		s.Canaries = map[string]*goahttp.CanaryRouter{
+			"MethodCanary": {Percent: 12.5, Header: "X-Canary", Value: "true"},
+		}
+		s.MethodCanary = s.Canaries["MethodCanary"].Handler(s.MethodCanary, NewMethodCanaryHandler(canary.MethodCanary, mux, dec, enc, eh))
+	}
+	return s
+}
+`
+
+var ServerCanaryStructCode = `// Server lists the ServiceCanary service endpoint HTTP handlers.
+type Server struct {
+	Mounts         []*MountPoint
+	MethodCanary   http.Handler
+	MethodNoCanary http.Handler
+	// Canaries lists the routers that select the requests served by the canary
+	// endpoints indexed by method name, nil if the server was created without
+	// canary endpoints. The routers may be modified before the server starts.
+	Canaries map[string]*goahttp.CanaryRouter
+}
+
+// ErrorNamer is an interface implemented by generated error structs that
+// exposes the name of the error as defined in the design.
+type ErrorNamer interface {
+	ErrorName() string
+}
+`
+
+var ServerRouterChiCode = `// MountListHandler configures the mux to serve the "ServiceRouter" service
+// "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/", f)
+}
+// MountDownloadHandler configures the mux to serve the "ServiceRouter" service
+// "Download" endpoint.
+func MountDownloadHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id}/files/*", f)
+}
+// DecodeDownloadRequest returns a decoder for requests sent to the
+// ServiceRouter Download endpoint.
+func DecodeDownloadRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id   string
+			path string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		path = params["*"]
+		payload := NewDownloadPayload(id, path)
+
+		return payload, nil
+	}
+}
+`
+
+var ServerRouterGorillaCode = `// MountListHandler configures the mux to serve the "ServiceRouter" service
+// "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/", f)
+}
+// MountDownloadHandler configures the mux to serve the "ServiceRouter" service
+// "Download" endpoint.
+func MountDownloadHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id}/files/{path:.*}", f)
+}
+// DecodeDownloadRequest returns a decoder for requests sent to the
+// ServiceRouter Download endpoint.
+func DecodeDownloadRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id   string
+			path string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		path = params["path"]
+		payload := NewDownloadPayload(id, path)
+
+		return payload, nil
+	}
+}
+`
+
+var ServerRouterHTTPRouterCode = `// MountListHandler configures the mux to serve the "ServiceRouter" service
+// "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/", f)
+}
+// MountDownloadHandler configures the mux to serve the "ServiceRouter" service
+// "Download" endpoint.
+func MountDownloadHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/:id/files/*path", f)
+}
+// DecodeDownloadRequest returns a decoder for requests sent to the
+// ServiceRouter Download endpoint.
+func DecodeDownloadRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id   string
+			path string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		path = params["path"]
+		payload := NewDownloadPayload(id, path)
+
+		return payload, nil
+	}
+}
+`
+
+var ServerRouterServeMuxCode = `// MountListHandler configures the mux to serve the "ServiceRouter" service
+// "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/{$}", f)
+}
+// MountDownloadHandler configures the mux to serve the "ServiceRouter" service
+// "Download" endpoint.
+func MountDownloadHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id}/files/{path...}", f)
+}
+// DecodeDownloadRequest returns a decoder for requests sent to the
+// ServiceRouter Download endpoint.
+func DecodeDownloadRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id   string
+			path string
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		path = params["path"]
+		payload := NewDownloadPayload(id, path)
+
+		return payload, nil
+	}
+}
+`
+
+var ServerTrailingSlashStrictCode = `// MountRootHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Root" endpoint.
+func MountRootHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/", f)
+}
+// MountListHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items", f)
+	goahttp.RejectTrailingSlash(mux, "GET", "/items/")
+}
+// MountShowHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Show" endpoint.
+func MountShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id}", f)
+	goahttp.RejectTrailingSlash(mux, "GET", "/items/{id}/")
+}
+// MountDownloadHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Download" endpoint.
+func MountDownloadHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/files/{*path}", f)
+}
+`
+
+var ServerTrailingSlashRedirectCode = `// MountRootHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Root" endpoint.
+func MountRootHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/", f)
+}
+// MountListHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items", f)
+	mux.Handle("GET", "/items/", goahttp.RedirectTrailingSlash)
+}
+// MountShowHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Show" endpoint.
+func MountShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id}", f)
+	mux.Handle("GET", "/items/{id}/", goahttp.RedirectTrailingSlash)
+}
+// MountDownloadHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Download" endpoint.
+func MountDownloadHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/files/{*path}", f)
+}
+`
+
+var ServerTrailingSlashRewriteCode = `// MountRootHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Root" endpoint.
+func MountRootHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/", f)
+}
+// MountListHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "List" endpoint.
+func MountListHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items", f)
+	mux.Handle("GET", "/items/", f)
+}
+// MountShowHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Show" endpoint.
+func MountShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id}", f)
+	mux.Handle("GET", "/items/{id}/", f)
+}
+// MountDownloadHandler configures the mux to serve the "ServiceTrailingSlash"
+// service "Download" endpoint.
+func MountDownloadHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/files/{*path}", f)
+}
+`
+
+var ServerSignedURLCode = `// New instantiates HTTP handlers for all the ServiceSignedURL service
+// endpoints.
+func New(
+	e *servicesignedurl.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"Download", "GET", "/buckets/{bucket}/files/{*path}"},
+			{"Share", "POST", "/share"},
+		},
+		Download: goahttp.RequireSignedURL(NewDownloadHandler(e.Download, mux, dec, enc, eh)),
+		Share:    NewShareHandler(e.Share, mux, dec, enc, eh),
+	}
+}
+// UseSignedURLs makes the handlers of the endpoints that require signed URLs
+// verify the URL signatures with the given signer. The handlers reject all the
+// requests with a 403 Forbidden response if UseSignedURLs is not called before
+// the server is mounted.
+func (s *Server) UseSignedURLs(signer *goahttp.URLSigner) {
+	m := goahttp.VerifySignedURLs(signer)
+	s.Download = m(s.Download)
+}
+// SignedDownloadServiceSignedURLPath returns the URL path to the
+// ServiceSignedURL service Download HTTP endpoint signed with signer. The
+// signature expires at the given time.
+func SignedDownloadServiceSignedURLPath(signer *goahttp.URLSigner, bucket string, path string, expires time.Time) string {
+	return signer.Sign(DownloadServiceSignedURLPath(bucket, path), expires)
+}
+`
+
+var ServerRouterChiConstraintsCode = `// MountShowHandler configures the mux to serve the "ServiceRouter" service
+// "Show" endpoint.
+func MountShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id:[0-9]+}/{slug:[a-z-]+}/{version}", f)
+}
+// DecodeShowRequest returns a decoder for requests sent to the ServiceRouter
+// Show endpoint.
+func DecodeShowRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id      string
+			slug    string
+			version string
+			err     error
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("id", id, "^[0-9]+$"))
+		slug = params["slug"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("slug", slug, "^[a-z-]+$"))
+		version = params["version"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("version", version, "^v"))
+		if err != nil {
+			return nil, err
+		}
+		payload := NewShowPayload(id, slug, version)
+
+		return payload, nil
+	}
+}
+`
+
+var ServerRouterGorillaConstraintsCode = `// MountShowHandler configures the mux to serve the "ServiceRouter" service
+// "Show" endpoint.
+func MountShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id:[0-9]+}/{slug:[a-z-]+}/{version}", f)
+}
+// DecodeShowRequest returns a decoder for requests sent to the ServiceRouter
+// Show endpoint.
+func DecodeShowRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id      string
+			slug    string
+			version string
+			err     error
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("id", id, "^[0-9]+$"))
+		slug = params["slug"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("slug", slug, "^[a-z-]+$"))
+		version = params["version"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("version", version, "^v"))
+		if err != nil {
+			return nil, err
+		}
+		payload := NewShowPayload(id, slug, version)
+
+		return payload, nil
+	}
+}
+`
+
+var ServerRouterConstraintsCode = `// MountShowHandler configures the mux to serve the "ServiceRouter" service
+// "Show" endpoint.
+func MountShowHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/items/{id}/{slug}/{version}", f)
+}
+// DecodeShowRequest returns a decoder for requests sent to the ServiceRouter
+// Show endpoint.
+func DecodeShowRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id      string
+			slug    string
+			version string
+			err     error
+
+			params = mux.Vars(r)
+		)
+		id = params["id"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("id", id, "^[0-9]+$"))
+		slug = params["slug"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("slug", slug, "^[a-z-]+$"))
+		version = params["version"]
+		err = goa.MergeErrors(err, goa.ValidatePattern("version", version, "^v"))
+		if err != nil {
+			return nil, err
+		}
+		payload := NewShowPayload(id, slug, version)
+
+		return payload, nil
+	}
+}
+`
+
+var ServerRenamedCode = `// MountListAccountsHandler configures the mux to serve the "ServiceRenamed"
+// service "list_accounts" endpoint.
+func MountListAccountsHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/accounts/list_accounts", f)
+	mux.Handle("POST", "/accounts/list", goahttp.DeprecatedRoute(f))
+}
+`
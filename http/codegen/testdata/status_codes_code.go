@@ -0,0 +1,39 @@
+package testdata
+
+var StrictStatusCodesCode = `// TestEndpointStatusCodes lists the HTTP status codes of all the responses of
+// the "testEndpoint" endpoint of the "testService" service.
+var TestEndpointStatusCodes = []int{
+	http.StatusOK,
+	http.StatusBadRequest,
+	http.StatusNotFound,
+	http.StatusMethodNotAllowed,
+	http.StatusConflict,
+	http.StatusUnsupportedMediaType,
+	http.StatusInternalServerError,
+}
+
+// TestEndpointStatus returns the description of the responses of the
+// "testEndpoint" endpoint of the "testService" service that use the given HTTP
+// status code and true if the endpoint may write responses with this status
+// code, false otherwise.
+func TestEndpointStatus(code int) (string, bool) {
+	switch code {
+	case http.StatusOK:
+		return "success response", true
+	case http.StatusBadRequest:
+		return "the request cannot be decoded or is invalid", true
+	case http.StatusNotFound:
+		return "\"not_found\" error; the request path does not match any route", true
+	case http.StatusMethodNotAllowed:
+		return "the request path matches routes for other methods", true
+	case http.StatusConflict:
+		return "\"conflict\" error", true
+	case http.StatusUnsupportedMediaType:
+		return "the request body content coding is not supported", true
+	case http.StatusInternalServerError:
+		return "the method returned an unexpected error", true
+	default:
+		return "", false
+	}
+}
+`
@@ -3386,3 +3386,326 @@ func (s *BidirectionalStreamingUserTypeMapMethodClientStream) Recv() (map[string
 	return res, nil
 }
 `
+
+var StreamingResultNDJSONServerStreamStructCode = `// StreamingResultNDJSONMethodServerStream implements the
+// streamingresultndjsonservice.StreamingResultNDJSONMethodServerStream
+// interface.
+type StreamingResultNDJSONMethodServerStream struct {
+	// w writes the results to the HTTP response.
+	w *goahttp.NDJSONWriter
+	// view is the view to render streamingresultndjsonservice.Usertype result type
+	// before sending to the HTTP response.
+	view string
+}
+`
+
+var StreamingResultNDJSONServerHandlerInitCode = `// NewStreamingResultNDJSONMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamingResultNDJSONService" service
+// "StreamingResultNDJSONMethod" endpoint.
+func NewStreamingResultNDJSONMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest = DecodeStreamingResultNDJSONMethodRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultNDJSONMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultNDJSONService")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		stream := &StreamingResultNDJSONMethodServerStream{w: goahttp.NewNDJSONWriter(w, http.StatusOK)}
+		v := &streamingresultndjsonservice.StreamingResultNDJSONMethodEndpointInput{
+			Stream:  stream,
+			Payload: payload.(*streamingresultndjsonservice.Request),
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if stream.w.Started() {
+				eh(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := stream.w.Close(); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var StreamingResultNDJSONServerStreamSendCode = `// Send streams instances of "streamingresultndjsonservice.Usertype" to the
+// "StreamingResultNDJSONMethod" endpoint HTTP response as newline delimited
+// JSON.
+func (s *StreamingResultNDJSONMethodServerStream) Send(v *streamingresultndjsonservice.Usertype) error {
+	res := streamingresultndjsonservice.NewViewedUsertype(v, s.view)
+	var body interface{}
+	switch s.view {
+	case "tiny":
+		body = NewStreamingResultNDJSONMethodResponseBodyTiny(res.Projected)
+	case "default", "":
+		body = NewStreamingResultNDJSONMethodResponseBody(res.Projected)
+	}
+	return s.w.Write(body)
+}
+`
+
+var StreamingResultNDJSONServerStreamCloseCode = `// Close ends the "StreamingResultNDJSONMethod" endpoint HTTP response.
+func (s *StreamingResultNDJSONMethodServerStream) Close() error {
+	return s.w.Close()
+}
+`
+
+var StreamingResultNDJSONServerStreamSetViewCode = `// SetView sets the view to render the streamingresultndjsonservice.Usertype
+// type before sending to the "StreamingResultNDJSONMethod" endpoint HTTP
+// response.
+func (s *StreamingResultNDJSONMethodServerStream) SetView(view string) {
+	s.view = view
+	s.w.Header().Set("goa-view", view)
+}
+`
+
+var StreamingResultNDJSONClientStreamStructCode = `// StreamingResultNDJSONMethodClientStream implements the
+// streamingresultndjsonservice.StreamingResultNDJSONMethodClientStream
+// interface.
+type StreamingResultNDJSONMethodClientStream struct {
+	// r reads the results from the HTTP response body.
+	r *goahttp.NDJSONReader
+	// view is the view used to render the results read from the HTTP response.
+	view string
+}
+`
+
+var StreamingResultNDJSONClientEndpointCode = `// StreamingResultNDJSONMethod returns an endpoint that makes HTTP requests to
+// the StreamingResultNDJSONService service StreamingResultNDJSONMethod server.
+func (c *Client) StreamingResultNDJSONMethod() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeStreamingResultNDJSONMethodRequest(c.encoder)
+		decodeResponse = DecodeStreamingResultNDJSONMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultNDJSONMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.StreamingResultNDJSONMethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("StreamingResultNDJSONService", "StreamingResultNDJSONMethod", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &StreamingResultNDJSONMethodClientStream{r: goahttp.NewNDJSONReader(resp.Body)}
+		view := resp.Header.Get("goa-view")
+		stream.SetView(view)
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultNDJSONClientStreamRecvCode = `// Recv reads instances of "streamingresultndjsonservice.Usertype" from the
+// "StreamingResultNDJSONMethod" endpoint HTTP response. It returns io.EOF once
+// all the instances have been read.
+func (s *StreamingResultNDJSONMethodClientStream) Recv() (*streamingresultndjsonservice.Usertype, error) {
+	var (
+		rv   *streamingresultndjsonservice.Usertype
+		body StreamingResultNDJSONMethodResponseBody
+		err  error
+	)
+	if err = s.r.Read(&body); err != nil {
+		return rv, err
+	}
+	res := NewStreamingResultNDJSONMethodUsertypeOK(&body)
+	vres := &streamingresultndjsonserviceviews.Usertype{res, s.view}
+	if err := streamingresultndjsonserviceviews.ValidateUsertype(vres); err != nil {
+		return rv, goahttp.ErrValidationError("StreamingResultNDJSONService", "StreamingResultNDJSONMethod", err)
+	}
+	return streamingresultndjsonservice.NewUsertype(vres), nil
+}
+`
+
+var StreamingResultExportServerStreamStructCode = `// StreamingResultExportMethodServerStream implements the
+// streamingresultexportservice.StreamingResultExportMethodServerStream
+// interface.
+type StreamingResultExportMethodServerStream struct {
+	// w writes the rows to the HTTP response.
+	w *goahttp.ExportWriter
+}
+`
+
+var StreamingResultExportServerHandlerInitCode = `// NewStreamingResultExportMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamingResultExportService" service
+// "StreamingResultExportMethod" endpoint.
+func NewStreamingResultExportMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest = DecodeStreamingResultExportMethodRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultExportMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultExportService")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		p := payload.(*streamingresultexportservice.StreamingResultExportMethodPayload)
+		filename := goahttp.ExportFilename("rows-{year}", map[string]interface{}{
+			"year": p.Year,
+		})
+		columns := []string{"id", "name", "qty", "total", "paid"}
+		stream := &StreamingResultExportMethodServerStream{w: goahttp.NewExportWriter(w, r, http.StatusOK, filename, columns)}
+		v := &streamingresultexportservice.StreamingResultExportMethodEndpointInput{
+			Stream:  stream,
+			Payload: p,
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if stream.w.Started() {
+				eh(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := stream.w.Close(); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var StreamingResultExportServerStreamSendCode = `// Send writes instances of "streamingresultexportservice.Row" to the
+// "StreamingResultExportMethod" endpoint HTTP response as the rows of a file.
+func (s *StreamingResultExportMethodServerStream) Send(v *streamingresultexportservice.Row) error {
+	row := make([]string, 5)
+	row[0] = v.ID
+	if v.Name != nil {
+		row[1] = *v.Name
+	}
+	row[2] = strconv.FormatInt(int64(v.Qty), 10)
+	if v.Total != nil {
+		row[3] = strconv.FormatFloat(*v.Total, 'g', -1, 64)
+	}
+	if v.Paid != nil {
+		row[4] = strconv.FormatBool(*v.Paid)
+	}
+	return s.w.WriteRow(row)
+}
+`
+
+var StreamingResultExportServerStreamCloseCode = `// Close ends the "StreamingResultExportMethod" endpoint HTTP response.
+func (s *StreamingResultExportMethodServerStream) Close() error {
+	return s.w.Close()
+}
+`
+
+var StreamingResultExportClientStreamStructCode = `// StreamingResultExportMethodClientStream implements the
+// streamingresultexportservice.StreamingResultExportMethodClientStream
+// interface.
+type StreamingResultExportMethodClientStream struct {
+	// r reads the rows from the HTTP response body.
+	r *goahttp.ExportReader
+}
+`
+
+var StreamingResultExportClientEndpointCode = `// StreamingResultExportMethod returns an endpoint that makes HTTP requests to
+// the StreamingResultExportService service StreamingResultExportMethod server.
+func (c *Client) StreamingResultExportMethod() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeStreamingResultExportMethodRequest(c.encoder)
+		decodeResponse = DecodeStreamingResultExportMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultExportMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", goahttp.CSVContentType)
+		resp, err := c.StreamingResultExportMethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("StreamingResultExportService", "StreamingResultExportMethod", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		columns := []string{"id", "name", "qty", "total", "paid"}
+		stream := &StreamingResultExportMethodClientStream{r: goahttp.NewExportReader(resp.Body, columns)}
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultExportClientStreamRecvCode = `// Recv reads instances of "streamingresultexportservice.Row" from the rows of
+// the file read from the "StreamingResultExportMethod" endpoint HTTP response.
+// It returns io.EOF once all the rows have been read.
+func (s *StreamingResultExportMethodClientStream) Recv() (*streamingresultexportservice.Row, error) {
+	row, err := s.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	res := &streamingresultexportservice.Row{}
+	res.ID = row[0]
+	if row[1] != "" {
+		res.Name = &row[1]
+	}
+	if row[2] != "" {
+		v, err := strconv.ParseInt(row[2], 10, 32)
+		if err != nil {
+			return nil, goa.InvalidFieldTypeError("qty", row[2], "integer")
+		}
+		res.Qty = int32(v)
+	}
+	if row[3] != "" {
+		v, err := strconv.ParseFloat(row[3], 64)
+		if err != nil {
+			return nil, goa.InvalidFieldTypeError("total", row[3], "float")
+		}
+		res.Total = &v
+	}
+	if row[4] != "" {
+		v, err := strconv.ParseBool(row[4])
+		if err != nil {
+			return nil, goa.InvalidFieldTypeError("paid", row[4], "boolean")
+		}
+		res.Paid = &v
+	}
+	return res, nil
+}
+`
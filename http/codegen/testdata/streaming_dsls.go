@@ -754,3 +754,54 @@ var BidirectionalStreamingResultCollectionWithExplicitViewDSL = func() {
 		})
 	})
 }
+
+var StreamingResultNDJSONDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Result = ResultType("UserType", func() {
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", Int)
+			Attribute("c", String)
+		})
+		View("tiny", func() {
+			Attribute("a", String)
+		})
+	})
+	Service("StreamingResultNDJSONService", func() {
+		Method("StreamingResultNDJSONMethod", func() {
+			Payload(Request)
+			StreamingResult(Result)
+			HTTP(func() {
+				POST("/")
+				NDJSON()
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var StreamingResultExportDSL = func() {
+	var Row = Type("Row", func() {
+		Attribute("id", String)
+		Attribute("name", String)
+		Attribute("qty", Int32)
+		Attribute("total", Float64)
+		Attribute("paid", Boolean)
+		Required("id", "qty")
+	})
+	Service("StreamingResultExportService", func() {
+		Method("StreamingResultExportMethod", func() {
+			Payload(func() {
+				Attribute("year", Int)
+			})
+			StreamingResult(Row)
+			HTTP(func() {
+				GET("/")
+				Param("year")
+				Export("rows-{year}")
+			})
+		})
+	})
+}
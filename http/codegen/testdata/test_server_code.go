@@ -0,0 +1,444 @@
+package testdata
+
+var SimpleTestServerCode = `// HTTPServerOption configures the servers created by NewTestHTTPServer.
+type HTTPServerOption func(*httpServerOptions)
+
+// httpServerOptions holds the test HTTP server options.
+type httpServerOptions struct {
+	dec                 func(*http.Request) goahttp.Decoder
+	enc                 func(context.Context, http.ResponseWriter) goahttp.Encoder
+	eh                  func(context.Context, http.ResponseWriter, error)
+	middlewares         []func(http.Handler) http.Handler
+	endpointMiddlewares []func(goa.Endpoint) goa.Endpoint
+}
+
+// WithHTTPDecoder sets the request decoder used by the test server. The
+// default is goahttp.RequestDecoder.
+func WithHTTPDecoder(dec func(*http.Request) goahttp.Decoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.dec = dec
+	}
+}
+
+// WithHTTPEncoder sets the response encoder used by the test server. The
+// default is goahttp.ResponseEncoder.
+func WithHTTPEncoder(enc func(context.Context, http.ResponseWriter) goahttp.Encoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.enc = enc
+	}
+}
+
+// WithHTTPErrorHandler sets the function called by the test server when
+// encoding a response or an error fails. The default writes a 500 response
+// with the error message.
+func WithHTTPErrorHandler(eh func(context.Context, http.ResponseWriter, error)) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.eh = eh
+	}
+}
+
+// WithHTTPMiddleware adds a HTTP middleware applied to the test server
+// handlers. Middlewares are applied in the order they are added.
+func WithHTTPMiddleware(m func(http.Handler) http.Handler) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.middlewares = append(o.middlewares, m)
+	}
+}
+
+// WithEndpointMiddleware adds a middleware applied to the service endpoints
+// served by the test server. Middlewares are applied in the order they are
+// added.
+func WithEndpointMiddleware(m func(goa.Endpoint) goa.Endpoint) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.endpointMiddlewares = append(o.endpointMiddlewares, m)
+	}
+}
+
+// NewTestHTTPServer starts and returns a server that serves the
+// "ServiceMultiEndpoints" service HTTP endpoints implemented by impl. Tests
+// send requests to the server URL and must call Close on the server once done
+// to shut it down.
+func NewTestHTTPServer(impl servicemultiendpoints.Service, opts ...HTTPServerOption) *httptest.Server {
+	o := &httpServerOptions{
+		dec: goahttp.RequestDecoder,
+		enc: goahttp.ResponseEncoder,
+		eh: func(ctx context.Context, w http.ResponseWriter, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	endpoints := servicemultiendpoints.NewEndpoints(impl)
+	for _, m := range o.endpointMiddlewares {
+		endpoints.Use(m)
+	}
+	mux := goahttp.NewMuxer()
+	server := servicemultiendpointssvr.New(endpoints, mux, o.dec, o.enc, o.eh)
+	for _, m := range o.middlewares {
+		server.Use(m)
+	}
+	servicemultiendpointssvr.Mount(mux, server)
+	return httptest.NewServer(mux)
+}
+`
+
+var MultipartTestServerCode = `// HTTPServerOption configures the servers created by NewTestHTTPServer.
+type HTTPServerOption func(*httpServerOptions)
+
+// httpServerOptions holds the test HTTP server options.
+type httpServerOptions struct {
+	dec                                                        func(*http.Request) goahttp.Decoder
+	enc                                                        func(context.Context, http.ResponseWriter) goahttp.Encoder
+	eh                                                         func(context.Context, http.ResponseWriter, error)
+	middlewares                                                []func(http.Handler) http.Handler
+	endpointMiddlewares                                        []func(goa.Endpoint) goa.Endpoint
+	serviceMultipartPrimitiveMethodMultipartPrimitiveDecoderFn servicemultipartprimitivesvr.ServiceMultipartPrimitiveMethodMultipartPrimitiveDecoderFunc
+}
+
+// WithHTTPDecoder sets the request decoder used by the test server. The
+// default is goahttp.RequestDecoder.
+func WithHTTPDecoder(dec func(*http.Request) goahttp.Decoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.dec = dec
+	}
+}
+
+// WithHTTPEncoder sets the response encoder used by the test server. The
+// default is goahttp.ResponseEncoder.
+func WithHTTPEncoder(enc func(context.Context, http.ResponseWriter) goahttp.Encoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.enc = enc
+	}
+}
+
+// WithHTTPErrorHandler sets the function called by the test server when
+// encoding a response or an error fails. The default writes a 500 response
+// with the error message.
+func WithHTTPErrorHandler(eh func(context.Context, http.ResponseWriter, error)) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.eh = eh
+	}
+}
+
+// WithHTTPMiddleware adds a HTTP middleware applied to the test server
+// handlers. Middlewares are applied in the order they are added.
+func WithHTTPMiddleware(m func(http.Handler) http.Handler) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.middlewares = append(o.middlewares, m)
+	}
+}
+
+// WithEndpointMiddleware adds a middleware applied to the service endpoints
+// served by the test server. Middlewares are applied in the order they are
+// added.
+func WithEndpointMiddleware(m func(goa.Endpoint) goa.Endpoint) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.endpointMiddlewares = append(o.endpointMiddlewares, m)
+	}
+}
+
+// WithMethodMultipartPrimitiveMultipartDecoder sets the function used by the
+// test server to decode the multipart requests sent to the
+// "MethodMultipartPrimitive" endpoint. The default returns an error.
+func WithMethodMultipartPrimitiveMultipartDecoder(fn servicemultipartprimitivesvr.ServiceMultipartPrimitiveMethodMultipartPrimitiveDecoderFunc) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.serviceMultipartPrimitiveMethodMultipartPrimitiveDecoderFn = fn
+	}
+}
+
+// NewTestHTTPServer starts and returns a server that serves the
+// "ServiceMultipartPrimitive" service HTTP endpoints implemented by impl.
+// Tests send requests to the server URL and must call Close on the server once
+// done to shut it down.
+func NewTestHTTPServer(impl servicemultipartprimitive.Service, opts ...HTTPServerOption) *httptest.Server {
+	o := &httpServerOptions{
+		dec: goahttp.RequestDecoder,
+		enc: goahttp.ResponseEncoder,
+		eh: func(ctx context.Context, w http.ResponseWriter, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+		serviceMultipartPrimitiveMethodMultipartPrimitiveDecoderFn: func(*multipart.Reader, *string) error {
+			return fmt.Errorf("no multipart decoder set for the %q endpoint, use WithMethodMultipartPrimitiveMultipartDecoder", "MethodMultipartPrimitive")
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	endpoints := servicemultipartprimitive.NewEndpoints(impl)
+	for _, m := range o.endpointMiddlewares {
+		endpoints.Use(m)
+	}
+	mux := goahttp.NewMuxer()
+	server := servicemultipartprimitivesvr.New(endpoints, mux, o.dec, o.enc, o.eh, o.serviceMultipartPrimitiveMethodMultipartPrimitiveDecoderFn)
+	for _, m := range o.middlewares {
+		server.Use(m)
+	}
+	servicemultipartprimitivesvr.Mount(mux, server)
+	return httptest.NewServer(mux)
+}
+`
+
+var StreamingTestServerCode = `// HTTPServerOption configures the servers created by NewTestHTTPServer.
+type HTTPServerOption func(*httpServerOptions)
+
+// httpServerOptions holds the test HTTP server options.
+type httpServerOptions struct {
+	dec                 func(*http.Request) goahttp.Decoder
+	enc                 func(context.Context, http.ResponseWriter) goahttp.Encoder
+	eh                  func(context.Context, http.ResponseWriter, error)
+	middlewares         []func(http.Handler) http.Handler
+	endpointMiddlewares []func(goa.Endpoint) goa.Endpoint
+}
+
+// WithHTTPDecoder sets the request decoder used by the test server. The
+// default is goahttp.RequestDecoder.
+func WithHTTPDecoder(dec func(*http.Request) goahttp.Decoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.dec = dec
+	}
+}
+
+// WithHTTPEncoder sets the response encoder used by the test server. The
+// default is goahttp.ResponseEncoder.
+func WithHTTPEncoder(enc func(context.Context, http.ResponseWriter) goahttp.Encoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.enc = enc
+	}
+}
+
+// WithHTTPErrorHandler sets the function called by the test server when
+// encoding a response or an error fails. The default writes a 500 response
+// with the error message.
+func WithHTTPErrorHandler(eh func(context.Context, http.ResponseWriter, error)) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.eh = eh
+	}
+}
+
+// WithHTTPMiddleware adds a HTTP middleware applied to the test server
+// handlers. Middlewares are applied in the order they are added.
+func WithHTTPMiddleware(m func(http.Handler) http.Handler) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.middlewares = append(o.middlewares, m)
+	}
+}
+
+// WithEndpointMiddleware adds a middleware applied to the service endpoints
+// served by the test server. Middlewares are applied in the order they are
+// added.
+func WithEndpointMiddleware(m func(goa.Endpoint) goa.Endpoint) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.endpointMiddlewares = append(o.endpointMiddlewares, m)
+	}
+}
+
+// NewTestHTTPServer starts and returns a server that serves the
+// "StreamingResultService" service HTTP endpoints implemented by impl. Tests
+// send requests to the server URL and must call Close on the server once done
+// to shut it down.
+func NewTestHTTPServer(impl streamingresultservice.Service, opts ...HTTPServerOption) *httptest.Server {
+	o := &httpServerOptions{
+		dec: goahttp.RequestDecoder,
+		enc: goahttp.ResponseEncoder,
+		eh: func(ctx context.Context, w http.ResponseWriter, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	endpoints := streamingresultservice.NewEndpoints(impl)
+	for _, m := range o.endpointMiddlewares {
+		endpoints.Use(m)
+	}
+	mux := goahttp.NewMuxer()
+	server := streamingresultservicesvr.New(endpoints, mux, o.dec, o.enc, o.eh, &websocket.Upgrader{}, nil)
+	for _, m := range o.middlewares {
+		server.Use(m)
+	}
+	streamingresultservicesvr.Mount(mux, server)
+	return httptest.NewServer(mux)
+}
+`
+
+var SecureTestServerCode = `// HTTPServerOption configures the servers created by NewTestHTTPServer.
+type HTTPServerOption func(*httpServerOptions)
+
+// httpServerOptions holds the test HTTP server options.
+type httpServerOptions struct {
+	dec                 func(*http.Request) goahttp.Decoder
+	enc                 func(context.Context, http.ResponseWriter) goahttp.Encoder
+	eh                  func(context.Context, http.ResponseWriter, error)
+	middlewares         []func(http.Handler) http.Handler
+	endpointMiddlewares []func(goa.Endpoint) goa.Endpoint
+	bypassSecurity      bool
+	principal           interface{}
+}
+
+// WithHTTPDecoder sets the request decoder used by the test server. The
+// default is goahttp.RequestDecoder.
+func WithHTTPDecoder(dec func(*http.Request) goahttp.Decoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.dec = dec
+	}
+}
+
+// WithHTTPEncoder sets the response encoder used by the test server. The
+// default is goahttp.ResponseEncoder.
+func WithHTTPEncoder(enc func(context.Context, http.ResponseWriter) goahttp.Encoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.enc = enc
+	}
+}
+
+// WithHTTPErrorHandler sets the function called by the test server when
+// encoding a response or an error fails. The default writes a 500 response
+// with the error message.
+func WithHTTPErrorHandler(eh func(context.Context, http.ResponseWriter, error)) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.eh = eh
+	}
+}
+
+// WithHTTPMiddleware adds a HTTP middleware applied to the test server
+// handlers. Middlewares are applied in the order they are added.
+func WithHTTPMiddleware(m func(http.Handler) http.Handler) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.middlewares = append(o.middlewares, m)
+	}
+}
+
+// WithEndpointMiddleware adds a middleware applied to the service endpoints
+// served by the test server. Middlewares are applied in the order they are
+// added.
+func WithEndpointMiddleware(m func(goa.Endpoint) goa.Endpoint) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.endpointMiddlewares = append(o.endpointMiddlewares, m)
+	}
+}
+
+// WithTestPrincipal makes the test server authorize all requests regardless of
+// their credentials. The service authorization functions are bypassed and the
+// given principal is stored in the request context instead, see
+// BypassSecurity.
+func WithTestPrincipal(principal interface{}) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.bypassSecurity = true
+		o.principal = principal
+	}
+}
+
+// NewTestHTTPServer starts and returns a server that serves the "accounts"
+// service HTTP endpoints implemented by impl. Tests send requests to the
+// server URL and must call Close on the server once done to shut it down.
+func NewTestHTTPServer(impl accounts.Service, opts ...HTTPServerOption) *httptest.Server {
+	o := &httpServerOptions{
+		dec: goahttp.RequestDecoder,
+		enc: goahttp.ResponseEncoder,
+		eh: func(ctx context.Context, w http.ResponseWriter, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.bypassSecurity {
+		impl = BypassSecurity(impl, o.principal)
+	}
+	endpoints := accounts.NewEndpoints(impl)
+	for _, m := range o.endpointMiddlewares {
+		endpoints.Use(m)
+	}
+	mux := goahttp.NewMuxer()
+	server := accountssvr.New(endpoints, mux, o.dec, o.enc, o.eh)
+	for _, m := range o.middlewares {
+		server.Use(m)
+	}
+	accountssvr.Mount(mux, server)
+	return httptest.NewServer(mux)
+}
+`
+
+var FileServerTestServerCode = `// HTTPServerOption configures the servers created by NewTestHTTPServer.
+type HTTPServerOption func(*httpServerOptions)
+
+// httpServerOptions holds the test HTTP server options.
+type httpServerOptions struct {
+	dec                 func(*http.Request) goahttp.Decoder
+	enc                 func(context.Context, http.ResponseWriter) goahttp.Encoder
+	eh                  func(context.Context, http.ResponseWriter, error)
+	middlewares         []func(http.Handler) http.Handler
+	endpointMiddlewares []func(goa.Endpoint) goa.Endpoint
+}
+
+// WithHTTPDecoder sets the request decoder used by the test server. The
+// default is goahttp.RequestDecoder.
+func WithHTTPDecoder(dec func(*http.Request) goahttp.Decoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.dec = dec
+	}
+}
+
+// WithHTTPEncoder sets the response encoder used by the test server. The
+// default is goahttp.ResponseEncoder.
+func WithHTTPEncoder(enc func(context.Context, http.ResponseWriter) goahttp.Encoder) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.enc = enc
+	}
+}
+
+// WithHTTPErrorHandler sets the function called by the test server when
+// encoding a response or an error fails. The default writes a 500 response
+// with the error message.
+func WithHTTPErrorHandler(eh func(context.Context, http.ResponseWriter, error)) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.eh = eh
+	}
+}
+
+// WithHTTPMiddleware adds a HTTP middleware applied to the test server
+// handlers. Middlewares are applied in the order they are added.
+func WithHTTPMiddleware(m func(http.Handler) http.Handler) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.middlewares = append(o.middlewares, m)
+	}
+}
+
+// WithEndpointMiddleware adds a middleware applied to the service endpoints
+// served by the test server. Middlewares are applied in the order they are
+// added.
+func WithEndpointMiddleware(m func(goa.Endpoint) goa.Endpoint) HTTPServerOption {
+	return func(o *httpServerOptions) {
+		o.endpointMiddlewares = append(o.endpointMiddlewares, m)
+	}
+}
+
+// NewTestHTTPServer starts and returns a server that serves the
+// "ServiceFileServer" service HTTP endpoints implemented by impl. Tests send
+// requests to the server URL and must call Close on the server once done to
+// shut it down.
+func NewTestHTTPServer(impl servicefileserver.Service, opts ...HTTPServerOption) *httptest.Server {
+	o := &httpServerOptions{
+		dec: goahttp.RequestDecoder,
+		enc: goahttp.ResponseEncoder,
+		eh: func(ctx context.Context, w http.ResponseWriter, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	endpoints := servicefileserver.NewEndpoints(impl)
+	for _, m := range o.endpointMiddlewares {
+		endpoints.Use(m)
+	}
+	mux := goahttp.NewMuxer()
+	server := servicefileserversvr.New(endpoints, mux, o.dec, o.enc, o.eh)
+	for _, m := range o.middlewares {
+		server.Use(m)
+	}
+	servicefileserversvr.Mount(mux)
+	return httptest.NewServer(mux)
+}
+`
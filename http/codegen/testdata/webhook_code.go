@@ -0,0 +1,28 @@
+package testdata
+
+var WebhookSenderCode = `// WebhookSender sends the webhooks emitted by the orders service.
+// The requests are signed using the secret given to NewWebhookSender, see
+// goahttp.VerifyWebhook.
+type WebhookSender struct {
+	*goahttp.WebhookSender
+}
+
+// NewWebhookSender instantiates a sender for the orders service
+// webhooks.
+func NewWebhookSender(doer goahttp.Doer, secret []byte, opts ...goahttp.WebhookOption) *WebhookSender {
+	return &WebhookSender{goahttp.NewWebhookSender(doer, secret, opts...)}
+}
+
+// SendCreated sends the "created" webhook to the given URL.
+// Created is sent each time an order is created.
+func (s *WebhookSender) SendCreated(ctx context.Context, url string, p *orders.Order) error {
+	body := NewCreatedWebhookBody(p)
+	return s.Send(ctx, url, "created", body)
+}
+
+// SendCancelled sends the "cancelled" webhook to the given URL.
+func (s *WebhookSender) SendCancelled(ctx context.Context, url string, p *orders.CancelledWebhookPayload) error {
+	body := NewCancelledWebhookBody(p)
+	return s.Send(ctx, url, "cancelled", body)
+}
+`
@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var WebhookDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("sku", String)
+	})
+	var Order = Type("Order", func() {
+		Attribute("id", Int)
+		Attribute("items", ArrayOf(Item))
+		Required("id")
+	})
+	Service("orders", func() {
+		Method("create", func() {
+			Payload(Order)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Webhook("created", func() {
+			Description("Created is sent each time an order is created.")
+			Payload(Order)
+		})
+		Webhook("cancelled", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("reason", String)
+			})
+		})
+	})
+}
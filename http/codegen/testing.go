@@ -6,6 +6,7 @@ import (
 
 	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
 )
 
 // RunHTTPDSL returns the HTTP DSL root resulting from running the given DSL.
@@ -13,6 +14,7 @@ func RunHTTPDSL(t *testing.T, dsl func()) *expr.RootExpr {
 	// reset all roots and codegen data structures
 	service.Services = make(service.ServicesData)
 	HTTPServices = make(ServicesData)
+	grpccodegen.GRPCServices = make(grpccodegen.ServicesData)
 	return expr.RunDSL(t, dsl)
 }
 
@@ -0,0 +1,28 @@
+package codegen
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/expr"
+)
+
+// slashPath returns the given route path of the endpoint followed by a
+// trailing slash. The generated servers register the path according to the
+// trailing slash policy of the endpoint (see the TrailingSlash DSL). slashPath
+// returns an empty string if the policy is not set or if the route path is "/"
+// or ends with a catch-all wildcard.
+func slashPath(e *expr.HTTPEndpointExpr, path string) string {
+	if e.TrailingSlashPolicy() == "" || strings.HasSuffix(path, "/") || strings.Contains(path, "/{*") {
+		return ""
+	}
+	return path + "/"
+}
+
+// slashPaths returns the path of the given versioned route followed by the
+// path with a trailing slash if registered.
+func slashPaths(r *VersionedRouteData) []string {
+	if r.SlashPath == "" {
+		return []string{r.Path}
+	}
+	return []string{r.Path, r.SlashPath}
+}
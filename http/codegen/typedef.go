@@ -14,6 +14,10 @@ import (
 //
 //    - It defines marshaler tags on each fields using the HTTP element names.
 //
+//    - It uses goa.Duration to hold durations so that they are encoded as
+//      strings and goa.UnixTime or goa.UnixMilliTime to hold timestamps that
+//      use the corresponding format (see bodyPrimitiveTypeName).
+//
 //    - It produced fields with pointers even if the corresponding attribute is
 //      required when ptr is true so that the generated code may validate
 //      explicitly.
@@ -26,6 +30,12 @@ import (
 func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefault bool) string {
 	switch actual := att.Type.(type) {
 	case expr.Primitive:
+		if n := bodyPrimitiveTypeName(att); n != "" {
+			return n
+		}
+		if att.IsOptionalField() {
+			return codegen.GoOptionalTypeName(actual)
+		}
 		return codegen.GoNativeTypeName(actual)
 	case *expr.Array:
 		d := goTypeDef(scope, actual.ElemType, ptr, useDefault)
@@ -59,7 +69,7 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 				fn = codegen.GoifyAtt(at, name, true)
 				tdef = goTypeDef(scope, at, ptr, useDefault)
 				if expr.IsPrimitive(at.Type) {
-					if (ptr || mat.IsPrimitivePointer(name, useDefault)) && at.Type != expr.Bytes && at.Type != expr.Any {
+					if (ptr || mat.IsPrimitivePointer(name, useDefault)) && at.Type != expr.Bytes && at.Type != expr.Any && !at.IsOptionalField() {
 						tdef = "*" + tdef
 					}
 				} else if expr.IsObject(at.Type) {
@@ -87,9 +97,133 @@ func attributeTags(parent, att *expr.AttributeExpr, t string, optional bool) str
 	if tags := codegen.AttributeTags(parent, att); tags != "" {
 		return tags
 	}
-	var o string
+	var o, jo string
 	if optional {
 		o = ",omitempty"
+		jo = o
+	}
+	if att.IsOptionalField() {
+		// omitempty has no effect on structs, omitzero relies on IsZero.
+		jo = ",omitzero"
+	}
+	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, jo, xmlTag(att, t), o)
+}
+
+// xmlTag computes the value of the xml struct field tag of the field holding
+// att from the XML serialization metadata set in the design. elem is the name
+// of the field HTTP element. The tag does not include the omitempty option.
+func xmlTag(att *expr.AttributeExpr, elem string) string {
+	name := xmlName(att, elem)
+	if arr := expr.AsArray(att.Type); arr != nil {
+		item := xmlName(arr.ElemType, elem)
+		if _, ok := att.Meta["xml:wrapped"]; ok {
+			name += ">" + item
+		} else {
+			name = item
+		}
+	}
+	if ns := att.Meta["xml:namespace"]; len(ns) > 0 {
+		name = ns[0] + " " + name
+	}
+	if _, ok := att.Meta["xml:attribute"]; ok {
+		name += ",attr"
+	}
+	return name
+}
+
+// xmlName returns the XML name set with XMLName on att if any, def otherwise.
+func xmlName(att *expr.AttributeExpr, def string) string {
+	if n := att.Meta["xml:name"]; len(n) > 0 {
+		return n[0]
+	}
+	return def
+}
+
+// xmlRootField returns the definition of the XMLName field that sets the name
+// and namespace of the root element of a HTTP body if the design sets them on
+// any of the given attributes, the empty string otherwise. The attributes are
+// looked up in order and user types are looked up for their own metadata.
+func xmlRootField(atts ...*expr.AttributeExpr) string {
+	for _, att := range atts {
+		if att == nil {
+			continue
+		}
+		meta := att.Meta
+		if ut, ok := att.Type.(expr.UserType); ok && len(meta["xml:name"]) == 0 {
+			meta = ut.Attribute().Meta
+		}
+		name := meta["xml:name"]
+		if len(name) == 0 {
+			continue
+		}
+		tag := name[0]
+		if ns := meta["xml:namespace"]; len(ns) > 0 {
+			tag = ns[0] + " " + tag
+		}
+		return fmt.Sprintf("XMLName xml.Name `form:\"-\" json:\"-\" xml:\"%s\"`", tag)
+	}
+	return ""
+}
+
+// withXMLRoot adds the XMLName field computed by xmlRootField to the struct
+// definition def. It returns def unchanged if def is not a struct definition
+// or if the design does not set the root element name.
+func withXMLRoot(def string, atts ...*expr.AttributeExpr) string {
+	field := xmlRootField(atts...)
+	if field == "" || !strings.HasPrefix(def, "struct {") {
+		return def
+	}
+	return "struct {\n\t" + field + strings.TrimPrefix(def, "struct {")
+}
+
+// bodyScope is the attribute scope used to transform HTTP body types. It
+// produces the same type names and references as the wrapped scope except for
+// durations and timestamps which may be held in other types (see goTypeDef).
+type bodyScope struct {
+	codegen.Attributor
+}
+
+// Name returns the type name for the given attribute.
+func (s *bodyScope) Name(att *expr.AttributeExpr, pkg string) string {
+	switch actual := att.Type.(type) {
+	case expr.Primitive:
+		if n := bodyPrimitiveTypeName(att); n != "" {
+			return n
+		}
+	case *expr.Array:
+		return "[]" + s.Ref(actual.ElemType, pkg)
+	case *expr.Map:
+		return fmt.Sprintf("map[%s]%s", s.Ref(actual.KeyType, pkg), s.Ref(actual.ElemType, pkg))
+	}
+	return s.Attributor.Name(att, pkg)
+}
+
+// Ref returns the type reference for the given attribute.
+func (s *bodyScope) Ref(att *expr.AttributeExpr, pkg string) string {
+	switch att.Type.(type) {
+	case expr.Primitive, *expr.Array, *expr.Map:
+		return s.Name(att, pkg)
+	}
+	return s.Attributor.Ref(att, pkg)
+}
+
+// bodyPrimitiveTypeName returns the name of the type used by the body types to
+// hold the values of the primitive attribute att if it differs from the type
+// used by the service types, the empty string otherwise. Durations are held in
+// goa.Duration values so that they are encoded as strings and timestamps that
+// use the unix formats in goa.UnixTime or goa.UnixMilliTime values so that
+// they are encoded as numbers.
+func bodyPrimitiveTypeName(att *expr.AttributeExpr) string {
+	switch att.Type {
+	case expr.Duration:
+		return "goa.Duration"
+	case expr.Timestamp:
+		switch att.TimestampFormat() {
+		case expr.TimestampUnix:
+			return "goa.UnixTime"
+		case expr.TimestampUnixMilli:
+			return "goa.UnixMilliTime"
+		}
 	}
-	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
+	return ""
 }
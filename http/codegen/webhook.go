@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// webhookFile returns the file implementing the senders of the webhooks
+// emitted by the given service, nil if the service does not define any
+// webhook.
+func webhookFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if len(data.Webhooks) == 0 {
+		return nil
+	}
+	svcName := codegen.SnakeCase(data.Service.VarName)
+	path := filepath.Join(codegen.Gendir, "http", svcName, "client", "webhooks.go")
+	title := fmt.Sprintf("%s webhook senders", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "context"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+		{
+			Name:   "webhook-sender",
+			Source: webhookSenderT,
+			Data:   data,
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const webhookSenderT = `// WebhookSender sends the webhooks emitted by the {{ .Service.Name }} service.
+// The requests are signed using the secret given to NewWebhookSender, see
+// goahttp.VerifyWebhook.
+type WebhookSender struct {
+	*goahttp.WebhookSender
+}
+
+// NewWebhookSender instantiates a sender for the {{ .Service.Name }} service
+// webhooks.
+func NewWebhookSender(doer goahttp.Doer, secret []byte, opts ...goahttp.WebhookOption) *WebhookSender {
+	return &WebhookSender{goahttp.NewWebhookSender(doer, secret, opts...)}
+}
+{{ range .Webhooks }}
+{{ printf "%s sends the %q webhook to the given URL." .SendName .Name | comment }}
+{{- if .Description }}
+{{ comment .Description }}
+{{- end }}
+func (s *WebhookSender) {{ .SendName }}(ctx context.Context, url string, p {{ .PayloadRef }}) error {
+{{- if .Body.Init }}
+	body := {{ .Body.Init.Name }}(p)
+	return s.Send(ctx, url, {{ printf "%q" .Name }}, body)
+{{- else }}
+	return s.Send(ctx, url, {{ printf "%q" .Name }}, p)
+{{- end }}
+}
+{{ end }}`
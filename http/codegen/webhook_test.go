@@ -0,0 +1,25 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestWebhookSender(t *testing.T) {
+	RunHTTPDSL(t, testdata.WebhookDSL)
+	fs := ClientFiles("", expr.Root)
+	if len(fs) != 3 {
+		t.Fatalf("got %d files, expected 3", len(fs))
+	}
+	sections := fs[2].SectionTemplates
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, expected 2", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[1])
+	if code != testdata.WebhookSenderCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.WebhookSenderCode))
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// conditionalRequest holds the request properties used to evaluate the
+// conditional request headers.
+type conditionalRequest struct {
+	method      string
+	ifMatch     string
+	ifNoneMatch string
+}
+
+// ContextWithConditionalRequest returns a copy of ctx that holds the values of
+// the If-Match and If-None-Match headers of r. The generated server handlers
+// of endpoints whose result defines an entity tag call this function prior to
+// invoking the endpoint.
+func ContextWithConditionalRequest(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, conditionalRequestKey, &conditionalRequest{
+		method:      r.Method,
+		ifMatch:     r.Header.Get("If-Match"),
+		ifNoneMatch: r.Header.Get("If-None-Match"),
+	})
+}
+
+// IfMatch returns the value of the If-Match header of the request stored in
+// ctx by ContextWithConditionalRequest, the empty string if there is none.
+func IfMatch(ctx context.Context) string {
+	if cr, ok := ctx.Value(conditionalRequestKey).(*conditionalRequest); ok {
+		return cr.ifMatch
+	}
+	return ""
+}
+
+// IfNoneMatch returns the value of the If-None-Match header of the request
+// stored in ctx by ContextWithConditionalRequest, the empty string if there is
+// none.
+func IfNoneMatch(ctx context.Context) string {
+	if cr, ok := ctx.Value(conditionalRequestKey).(*conditionalRequest); ok {
+		return cr.ifNoneMatch
+	}
+	return ""
+}
+
+// CheckPrecondition evaluates the conditional request headers stored in ctx by
+// ContextWithConditionalRequest against etag, the current entity tag of the
+// resource targeted by the request, as described in RFC 7232. etag is empty if
+// the resource does not exist. Service methods that modify resources must call
+// CheckPrecondition prior to applying the changes and return the error it
+// returns if any. The error is a "precondition_failed" goa.ServiceError which
+// the HTTP servers write with the 412 Precondition Failed status code.
+func CheckPrecondition(ctx context.Context, etag string) error {
+	cr, ok := ctx.Value(conditionalRequestKey).(*conditionalRequest)
+	if !ok {
+		return nil
+	}
+	if cr.ifMatch != "" && (etag == "" || !matchETag(cr.ifMatch, etag, false)) {
+		return goa.PermanentError("precondition_failed", "resource entity tag does not match If-Match header")
+	}
+	if cr.ifNoneMatch != "" && etag != "" && matchETag(cr.ifNoneMatch, etag, true) {
+		return goa.PermanentError("precondition_failed", "resource entity tag matches If-None-Match header")
+	}
+	return nil
+}
+
+// CheckConditionalRequest evaluates the conditional request headers stored in
+// ctx by ContextWithConditionalRequest against the entity tag of the response
+// as described in RFC 7232. It returns http.StatusPreconditionFailed if the
+// If-Match header does not match etag, http.StatusNotModified if the
+// If-None-Match header matches etag and 0 otherwise. CheckConditionalRequest
+// returns 0 if etag is empty or if the request method is not GET or HEAD: the
+// response of a request that modifies a resource holds the entity tag of the
+// modified resource, the preconditions must be evaluated by the service method
+// prior to modifying the resource instead, see CheckPrecondition.
+func CheckConditionalRequest(ctx context.Context, etag string) int {
+	if etag == "" {
+		return 0
+	}
+	cr, ok := ctx.Value(conditionalRequestKey).(*conditionalRequest)
+	if !ok || (cr.method != http.MethodGet && cr.method != http.MethodHead) {
+		return 0
+	}
+	if cr.ifMatch != "" && !matchETag(cr.ifMatch, etag, false) {
+		return http.StatusPreconditionFailed
+	}
+	if cr.ifNoneMatch != "" && matchETag(cr.ifNoneMatch, etag, true) {
+		return http.StatusNotModified
+	}
+	return 0
+}
+
+// matchETag returns true if the list of entity tags in header matches etag.
+// weak controls whether the weak comparison function is used.
+func matchETag(header, etag string, weak bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if weak {
+			if strings.TrimPrefix(t, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+			continue
+		}
+		if strings.HasPrefix(t, "W/") || strings.HasPrefix(etag, "W/") {
+			continue
+		}
+		if t == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckConditionalRequest(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Method      string
+		IfMatch     string
+		IfNoneMatch string
+		ETag        string
+		Expected    int
+	}{
+		{"no header", "GET", "", "", `"a"`, 0},
+		{"no etag", "GET", `"b"`, "", "", 0},
+		{"if-match match", "GET", `"a"`, "", `"a"`, 0},
+		{"if-match list", "GET", `"b", "a"`, "", `"a"`, 0},
+		{"if-match star", "GET", "*", "", `"a"`, 0},
+		{"if-match mismatch", "GET", `"b"`, "", `"a"`, http.StatusPreconditionFailed},
+		{"if-match weak", "GET", `W/"a"`, "", `"a"`, http.StatusPreconditionFailed},
+		{"if-match put", "PUT", `"b"`, "", `"a"`, 0},
+		{"if-none-match mismatch", "GET", "", `"b"`, `"a"`, 0},
+		{"if-none-match get", "GET", "", `"a"`, `"a"`, http.StatusNotModified},
+		{"if-none-match head", "HEAD", "", `"a"`, `"a"`, http.StatusNotModified},
+		{"if-none-match weak", "GET", "", `W/"a"`, `"a"`, http.StatusNotModified},
+		{"if-none-match star", "GET", "", "*", `"a"`, http.StatusNotModified},
+		{"if-none-match put", "PUT", "", `"a"`, `"a"`, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r, _ := http.NewRequest(c.Method, "/", nil)
+			if c.IfMatch != "" {
+				r.Header.Set("If-Match", c.IfMatch)
+			}
+			if c.IfNoneMatch != "" {
+				r.Header.Set("If-None-Match", c.IfNoneMatch)
+			}
+			ctx := ContextWithConditionalRequest(context.Background(), r)
+			if actual := CheckConditionalRequest(ctx, c.ETag); actual != c.Expected {
+				t.Errorf("got %d, expected %d", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCheckPrecondition(t *testing.T) {
+	cases := []struct {
+		Name        string
+		IfMatch     string
+		IfNoneMatch string
+		ETag        string
+		Fail        bool
+	}{
+		{"no header", "", "", `"a"`, false},
+		{"if-match match", `"a"`, "", `"a"`, false},
+		{"if-match star", "*", "", `"a"`, false},
+		{"if-match mismatch", `"b"`, "", `"a"`, true},
+		{"if-match weak", `W/"a"`, "", `"a"`, true},
+		{"if-match missing", "*", "", "", true},
+		{"if-none-match mismatch", "", `"b"`, `"a"`, false},
+		{"if-none-match match", "", `"a"`, `"a"`, true},
+		{"if-none-match star", "", "*", `"a"`, true},
+		{"if-none-match missing", "", "*", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r, _ := http.NewRequest("PUT", "/", nil)
+			if c.IfMatch != "" {
+				r.Header.Set("If-Match", c.IfMatch)
+			}
+			if c.IfNoneMatch != "" {
+				r.Header.Set("If-None-Match", c.IfNoneMatch)
+			}
+			ctx := ContextWithConditionalRequest(context.Background(), r)
+			if IfMatch(ctx) != c.IfMatch {
+				t.Errorf("got If-Match %q, expected %q", IfMatch(ctx), c.IfMatch)
+			}
+			if IfNoneMatch(ctx) != c.IfNoneMatch {
+				t.Errorf("got If-None-Match %q, expected %q", IfNoneMatch(ctx), c.IfNoneMatch)
+			}
+			err := CheckPrecondition(ctx, c.ETag)
+			if (err != nil) != c.Fail {
+				t.Fatalf("got error %v, expected failure %v", err, c.Fail)
+			}
+			if err != nil {
+				if code := NewErrorResponse(err).StatusCode(); code != http.StatusPreconditionFailed {
+					t.Errorf("got status code %d, expected %d", code, http.StatusPreconditionFailed)
+				}
+			}
+		})
+	}
+}
+
+func TestConditionalUpdate(t *testing.T) {
+	var (
+		value = "old"
+		etag  = `"1"`
+	)
+	// update mimics a service method that modifies a resource.
+	update := func(ctx context.Context, v string) error {
+		if err := CheckPrecondition(ctx, etag); err != nil {
+			return err
+		}
+		value, etag = v, `"2"`
+		return nil
+	}
+	encodeError := ErrorEncoder(ResponseEncoder)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ContextWithConditionalRequest(r.Context(), r)
+		if err := update(ctx, "new"); err != nil {
+			encodeError(ctx, w, err)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if status := CheckConditionalRequest(ctx, etag); status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := httptest.NewRequest("PUT", "/", nil)
+	r.Header.Set("If-Match", `"0"`)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("stale If-Match: got status code %d, expected %d", w.Code, http.StatusPreconditionFailed)
+	}
+	if value != "old" {
+		t.Errorf("stale If-Match: got value %q, expected the update not to be applied", value)
+	}
+
+	r = httptest.NewRequest("PUT", "/", nil)
+	r.Header.Set("If-Match", `"1"`)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("current If-Match: got status code %d, expected %d", w.Code, http.StatusOK)
+	}
+	if value != "new" {
+		t.Errorf("current If-Match: got value %q, expected %q", value, "new")
+	}
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Decompressor creates the reader that decompresses the data read from
+	// r.
+	Decompressor func(r io.Reader) (io.ReadCloser, error)
+
+	// decompressedBody is a request body that decompresses the original
+	// body.
+	decompressedBody struct {
+		io.ReadCloser
+		orig io.ReadCloser
+	}
+)
+
+// DefaultMaxDecompressedBody is the maximum size in bytes of the decompressed
+// request bodies of the endpoints that do not set a maximum request body size
+// with the MaxRequestBody DSL.
+const DefaultMaxDecompressedBody int64 = 32 << 20
+
+var (
+	decompressorsMu sync.RWMutex
+	decompressors   = map[string]Decompressor{
+		"gzip": func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+		"deflate": func(r io.Reader) (io.ReadCloser, error) {
+			return flate.NewReader(r), nil
+		},
+	}
+)
+
+// RegisterDecompressor registers the decompressor used by the generated request
+// decoders for the given content coding, e.g. "br" or "zstd". The "gzip" and
+// "deflate" codings are registered by default, registering them again replaces
+// the default decompressors.
+func RegisterDecompressor(encoding string, d Decompressor) {
+	decompressorsMu.Lock()
+	defer decompressorsMu.Unlock()
+	decompressors[encoding] = d
+}
+
+// DecompressRequestBody replaces the body of r with a reader that decompresses
+// it according to the request Content-Encoding header. It returns the error
+// produced by goa.UnsupportedContentEncodingError if the coding is not one of
+// the given encodings or has no registered decompressor. Reading the
+// decompressed body fails once more than max bytes have been read, see
+// LimitRequestBody, DefaultMaxDecompressedBody is used if max is zero.
+// DecompressRequestBody does nothing if the request body is not compressed.
+// The generated request decoders call DecompressRequestBody for the endpoints
+// that accept compressed request bodies with the RequestCompression DSL.
+func DecompressRequestBody(r *http.Request, max int64, encodings ...string) error {
+	enc := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+	if enc == "" || enc == "identity" || r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	var accepted bool
+	for _, e := range encodings {
+		if e == enc {
+			accepted = true
+			break
+		}
+	}
+	decompressorsMu.RLock()
+	d, ok := decompressors[enc]
+	decompressorsMu.RUnlock()
+	if !accepted || !ok {
+		return goa.UnsupportedContentEncodingError(enc)
+	}
+	rc, err := d(r.Body)
+	if err != nil {
+		if _, ok := err.(*goa.ServiceError); ok {
+			return err
+		}
+		return goa.DecodePayloadError(err.Error())
+	}
+	r.Body = &decompressedBody{ReadCloser: rc, orig: r.Body}
+	r.Header.Del("Content-Encoding")
+	r.ContentLength = -1
+	if max <= 0 {
+		max = DefaultMaxDecompressedBody
+	}
+	LimitRequestBody(r, max)
+	return nil
+}
+
+// CompressRequestBody compresses the body of req with the given content coding
+// if its size is greater or equal than min and sets the request
+// Content-Encoding header accordingly. The supported codings are "gzip" and
+// "deflate". The generated clients call CompressRequestBody for the endpoints
+// that accept compressed request bodies when their RequestCompressionThreshold
+// field is set.
+func CompressRequestBody(req *http.Request, encoding string, min int64) error {
+	if req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+	if req.ContentLength > 0 && req.ContentLength < min {
+		return nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+	if int64(len(body)) < min {
+		setRequestBody(req, body)
+		return nil
+	}
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		if w, err = flate.NewWriter(&buf, flate.DefaultCompression); err != nil {
+			return err
+		}
+	default:
+		setRequestBody(req, body)
+		return nil
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	setRequestBody(req, buf.Bytes())
+	req.Header.Set("Content-Encoding", encoding)
+	return nil
+}
+
+// Close closes both the decompressing reader and the original body.
+func (b *decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if cerr := b.orig.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// setRequestBody sets the body of req to b.
+func setRequestBody(req *http.Request, b []byte) {
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.ContentLength = int64(len(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+}
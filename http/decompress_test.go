@@ -0,0 +1,102 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestDecompressRequestBody(t *testing.T) {
+	gzipped := func(s string) string {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write([]byte(s))
+		w.Close()
+		return buf.String()
+	}
+	cases := []struct {
+		Name          string
+		Encoding      string
+		Body          string
+		Max           int64
+		Expected      string
+		ExpectedError string
+	}{
+		{"identity", "", "abc", 0, "abc", ""},
+		{"gzip", "gzip", gzipped("abc"), 0, "abc", ""},
+		{"gzip-too-large", "gzip", gzipped("abcdef"), 4, "", "request_body_too_large"},
+		{"unsupported", "br", "abc", 0, "", "unsupported_content_encoding"},
+		{"not-accepted", "deflate", "abc", 0, "", "unsupported_content_encoding"},
+		{"invalid", "gzip", "abc", 0, "", "decode_payload"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			if c.Encoding != "" {
+				r.Header.Set("Content-Encoding", c.Encoding)
+			}
+			err := DecompressRequestBody(r, c.Max, "gzip", "br")
+			var b []byte
+			if err == nil {
+				b, err = ioutil.ReadAll(r.Body)
+			}
+			if c.ExpectedError != "" {
+				serr, ok := err.(*goa.ServiceError)
+				if !ok {
+					t.Fatalf("got error %v, expected %q", err, c.ExpectedError)
+				}
+				if serr.Name != c.ExpectedError {
+					t.Errorf("got error %q, expected %q", serr.Name, c.ExpectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(b) != c.Expected {
+				t.Errorf("got body %q, expected %q", string(b), c.Expected)
+			}
+			if enc := r.Header.Get("Content-Encoding"); enc != "" {
+				t.Errorf("got Content-Encoding %q, expected none", enc)
+			}
+		})
+	}
+}
+
+func TestCompressRequestBody(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Body     string
+		Min      int64
+		Encoding string
+	}{
+		{"compressed", "abcdef", 4, "gzip"},
+		{"small", "abc", 4, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			if err := CompressRequestBody(req, "gzip", c.Min); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if enc := req.Header.Get("Content-Encoding"); enc != c.Encoding {
+				t.Errorf("got Content-Encoding %q, expected %q", enc, c.Encoding)
+			}
+			if err := DecompressRequestBody(req, 0, "gzip"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(b) != c.Body {
+				t.Errorf("got body %q, expected %q", string(b), c.Body)
+			}
+		})
+	}
+}
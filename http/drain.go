@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DrainUpgrader is an Upgrader that keeps track of the WebSocket connections
+// it upgrades so that they can be closed gracefully when the server shuts
+// down. The example servers generated for the designs that define streaming
+// endpoints use it to upgrade the connections.
+type DrainUpgrader struct {
+	Upgrader
+
+	mu       sync.Mutex
+	conns    map[*websocket.Conn]chan struct{}
+	draining bool
+}
+
+// closeFrameTimeout is the maximum duration of writing the close frame sent to
+// the connections on shutdown.
+const closeFrameTimeout = time.Second
+
+// NewDrainUpgrader returns a DrainUpgrader that upgrades the connections with
+// u.
+func NewDrainUpgrader(u Upgrader) *DrainUpgrader {
+	return &DrainUpgrader{Upgrader: u, conns: make(map[*websocket.Conn]chan struct{})}
+}
+
+// Upgrade upgrades the HTTP connection to the websocket protocol and keeps
+// track of the connection until the request handler returns. The connection
+// is closed right away with a close frame if the upgrader is draining.
+func (u *DrainUpgrader) Upgrade(w http.ResponseWriter, r *http.Request, responseHeader http.Header) (*websocket.Conn, error) {
+	conn, err := u.Upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	u.mu.Lock()
+	u.conns[conn] = done
+	draining := u.draining
+	u.mu.Unlock()
+	go func() {
+		<-r.Context().Done()
+		u.mu.Lock()
+		delete(u.conns, conn)
+		u.mu.Unlock()
+		close(done)
+	}()
+	if draining {
+		writeGoingAway(conn)
+	}
+	return conn, nil
+}
+
+// Drain sends a close frame with the "going away" status code to the tracked
+// connections and waits for their request handlers to return. It closes the
+// remaining connections and returns the context error if ctx is done first.
+func (u *DrainUpgrader) Drain(ctx context.Context) error {
+	u.mu.Lock()
+	u.draining = true
+	conns := make(map[*websocket.Conn]chan struct{}, len(u.conns))
+	for conn, done := range u.conns {
+		conns[conn] = done
+	}
+	u.mu.Unlock()
+
+	for conn := range conns {
+		writeGoingAway(conn)
+	}
+	for _, done := range conns {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			for conn := range conns {
+				conn.Close()
+			}
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// writeGoingAway writes a close frame with the "going away" status code to
+// conn.
+func writeGoingAway(conn *websocket.Conn) {
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeFrameTimeout))
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDrainUpgrader(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Echo        bool
+		ExpectedErr error
+	}{
+		{"closed-by-client", true, nil},
+		{"closed-by-server", false, context.DeadlineExceeded},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			up := NewDrainUpgrader(&websocket.Upgrader{})
+			upgraded := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := up.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				close(upgraded)
+				if !c.Echo {
+					<-time.After(time.Second)
+					return
+				}
+				for {
+					if _, _, err := conn.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}))
+			defer ts.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			<-upgraded
+
+			received := make(chan error, 1)
+			go func() {
+				_, _, err := conn.ReadMessage()
+				received <- err
+			}()
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			if err := up.Drain(ctx); err != c.ExpectedErr {
+				t.Errorf("got error %v, expected %v", err, c.ExpectedErr)
+			}
+			err = <-received
+			if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+				t.Errorf("got error %v, expected close error with code %d", err, websocket.CloseGoingAway)
+			}
+		})
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"strconv"
 	"strings"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 const (
@@ -23,6 +26,45 @@ const (
 	// response Content-Type header when explicitly set in the DSL. The value
 	// may be used by encoders to set the header appropriately.
 	ContentTypeKey
+	// IfMatchKey is the context key used by clients to set the value of the
+	// HTTP request If-Match header for endpoints whose result defines an
+	// entity tag.
+	IfMatchKey
+	// IfNoneMatchKey is the context key used by clients to set the value of
+	// the HTTP request If-None-Match header for endpoints whose result
+	// defines an entity tag.
+	IfNoneMatchKey
+
+	// conditionalRequestKey is the context key used to store the
+	// conditional request headers evaluated by CheckConditionalRequest.
+	conditionalRequestKey
+	// kmsKey is the context key used to store the KMS used to encrypt and
+	// decrypt the body fields marked as encrypted, see ContextWithKMS.
+	kmsKey
+	// responseHooksKey is the context key used to store the response hooks
+	// registered with ResponseHookMiddleware.
+	responseHooksKey
+	// errorVerbosityKey is the context key used to store the error
+	// verbosity set with ErrorVerbosityMiddleware.
+	errorVerbosityKey
+	// translatorKey is the context key used to store the translator set
+	// with TranslatorMiddleware.
+	translatorKey
+	// languagesKey is the context key used to store the languages listed
+	// in the request Accept-Language header by TranslatorMiddleware.
+	languagesKey
+	// exportFormatsKey is the context key used to store the export formats
+	// set with ExportFormatsMiddleware.
+	exportFormatsKey
+	// encoderOptionsKey is the context key used to store the encoder
+	// options set with EncoderWithOptions.
+	encoderOptionsKey
+	// phaseObserverKey is the context key used to store the phase
+	// observer set with PhaseObserverMiddleware.
+	phaseObserverKey
+	// passThroughHeadersKey is the context key used to store the headers
+	// captured from upstream responses, see ContextWithPassThroughHeaders.
+	passThroughHeadersKey
 )
 
 type (
@@ -54,6 +96,8 @@ type (
 //     * application/json using package encoding/json
 //     * application/xml using package encoding/xml
 //     * application/gob using package encoding/gob
+//     * application/cbor using NewCBORDecoder
+//     * application/msgpack and application/x-msgpack using NewMsgPackDecoder
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
 // header does not match any of the supported mime type or is missing
@@ -76,11 +120,45 @@ func RequestDecoder(r *http.Request) Decoder {
 		return gob.NewDecoder(r.Body)
 	case "application/xml":
 		return xml.NewDecoder(r.Body)
+	case "application/cbor":
+		return NewCBORDecoder(r.Body)
+	case "application/msgpack", "application/x-msgpack":
+		return NewMsgPackDecoder(r.Body)
 	default:
 		return json.NewDecoder(r.Body)
 	}
 }
 
+// StrictDecoder returns a decoder that rejects the fields of the decoded
+// values that do not match a field of the target type. It relies on the
+// DisallowUnknownFields method of the given decoder if it has one (e.g. the
+// encoding/json decoder) and returns the decoder unmodified otherwise. The
+// decoding errors caused by unknown fields are reported as
+// goa.UnknownFieldError errors.
+//
+// The generated servers use StrictDecoder to decode the request bodies of
+// the methods that use the StrictDecoding DSL.
+func StrictDecoder(dec Decoder) Decoder {
+	sd, ok := dec.(interface{ DisallowUnknownFields() })
+	if !ok {
+		return dec
+	}
+	sd.DisallowUnknownFields()
+	return EncodingFunc(func(v interface{}) error {
+		err := dec.Decode(v)
+		if err == nil {
+			return nil
+		}
+		const prefix = "json: unknown field "
+		if msg := err.Error(); strings.HasPrefix(msg, prefix) {
+			if name, uerr := strconv.Unquote(strings.TrimPrefix(msg, prefix)); uerr == nil {
+				return goa.UnknownFieldError(name)
+			}
+		}
+		return err
+	})
+}
+
 // ResponseEncoder returns a HTTP response encoder leveraging the mime type
 // set in the context under the AcceptTypeKey or the ContentTypeKey if any.
 // The encoder supports the following mime types:
@@ -88,21 +166,28 @@ func RequestDecoder(r *http.Request) Decoder {
 //     * application/json using package encoding/json
 //     * application/xml using package encoding/xml
 //     * application/gob using package encoding/gob
+//     * application/cbor using NewCBOREncoder
+//     * application/msgpack and application/x-msgpack using NewMsgPackEncoder
 //     * text/html and text/plain for strings
 //
 // ResponseEncoder defaults to the JSON encoder if the context AcceptTypeKey or
 // ContentTypeKey value does not match any of the supported mime types or is
-// missing altogether.
+// missing altogether. The JSON encoder applies the HTML escaping and prefix
+// settings of the context encoder options if any, see EncoderWithOptions.
 func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 	negotiate := func(a string) (Encoder, string) {
 		switch a {
 		case "", "application/json":
 			// default to JSON
-			return json.NewEncoder(w), "application/json"
+			return newResponseJSONEncoder(ctx, w), "application/json"
 		case "application/xml":
 			return xml.NewEncoder(w), "application/xml"
 		case "application/gob":
 			return gob.NewEncoder(w), "application/gob"
+		case "application/cbor":
+			return NewCBOREncoder(w), "application/cbor"
+		case "application/msgpack", "application/x-msgpack":
+			return NewMsgPackEncoder(w), a
 		case "text/html", "text/plain":
 			return newTextEncoder(w, a), a
 		}
@@ -132,16 +217,20 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 			if mt, _, err = mime.ParseMediaType(ct); err == nil {
 				switch {
 				case ct == "application/json" || strings.HasSuffix(ct, "+json"):
-					enc = json.NewEncoder(w)
+					enc = newResponseJSONEncoder(ctx, w)
 				case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
 					enc = xml.NewEncoder(w)
 				case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
 					enc = gob.NewEncoder(w)
+				case ct == "application/cbor" || strings.HasSuffix(ct, "+cbor"):
+					enc = NewCBOREncoder(w)
+				case isMsgPack(ct):
+					enc = NewMsgPackEncoder(w)
 				case ct == "text/html" || ct == "text/plain" ||
 					strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
 					enc = newTextEncoder(w, ct)
 				default:
-					enc = json.NewEncoder(w)
+					enc = newResponseJSONEncoder(ctx, w)
 				}
 			}
 			SetContentType(w, mt)
@@ -164,11 +253,66 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 }
 
 // RequestEncoder returns a HTTP request encoder.
-// The encoder uses package encoding/json.
+// The encoder uses package encoding/json unless the request "Content-Type"
+// header is already set in which case the encoder corresponding to the header
+// value is used. The supported mime types are the same as ResponseEncoder, the
+// encoder defaults to JSON for other mime types.
 func RequestEncoder(r *http.Request) Encoder {
 	var buf bytes.Buffer
 	r.Body = ioutil.NopCloser(&buf)
-	return json.NewEncoder(&buf)
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return json.NewEncoder(&buf)
+	}
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = mt
+	}
+	return newRequestEncoder(&buf, ct)
+}
+
+// ContentTypeRequestEncoder returns a HTTP request encoder constructor that
+// encodes the request bodies using the given mime type and sets the request
+// "Content-Type" header accordingly. The request "Accept" header is also set
+// to the mime type unless already set so that servers using ResponseEncoder
+// reply with the same encoding. The supported mime types are the same as
+// ResponseEncoder, the encoder defaults to JSON for other mime types.
+//
+// The returned function may be given to the generated client constructors in
+// place of RequestEncoder, for example:
+//
+//    client := calcsvr.NewClient(
+//        scheme, host, doer,
+//        goahttp.ContentTypeRequestEncoder("application/msgpack"),
+//        goahttp.ResponseDecoder,
+//        false,
+//    )
+//
+func ContentTypeRequestEncoder(ct string) func(*http.Request) Encoder {
+	mt := ct
+	if parsed, _, err := mime.ParseMediaType(ct); err == nil {
+		mt = parsed
+	}
+	return func(r *http.Request) Encoder {
+		r.Header.Set("Content-Type", ct)
+		if r.Header.Get("Accept") == "" {
+			r.Header.Set("Accept", ct)
+		}
+		var buf bytes.Buffer
+		r.Body = ioutil.NopCloser(&buf)
+		return newRequestEncoder(&buf, mt)
+	}
+}
+
+// IsContentType returns true if the given "Content-Type" header value denotes
+// the given mime type. Media type parameters are ignored.
+func IsContentType(ct, mt string) bool {
+	if parsed, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = parsed
+	}
+	if parsed, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = parsed
+	}
+	return ct != "" && ct == mt
 }
 
 // ResponseDecoder returns a HTTP response decoder.
@@ -177,6 +321,8 @@ func RequestEncoder(r *http.Request) Encoder {
 //   * application/json using package encoding/json (default)
 //   * application/xml using package encoding/xml
 //   * application/gob using package encoding/gob
+//   * application/cbor using NewCBORDecoder
+//   * application/msgpack and application/x-msgpack using NewMsgPackDecoder
 //   * text/html and text/plain for strings
 //
 func ResponseDecoder(resp *http.Response) Decoder {
@@ -194,6 +340,10 @@ func ResponseDecoder(resp *http.Response) Decoder {
 		return xml.NewDecoder(resp.Body)
 	case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
 		return gob.NewDecoder(resp.Body)
+	case ct == "application/cbor" || strings.HasSuffix(ct, "+cbor"):
+		return NewCBORDecoder(resp.Body)
+	case isMsgPack(ct):
+		return NewMsgPackDecoder(resp.Body)
 	case ct == "text/html" || ct == "text/plain" ||
 		strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
 		return newTextDecoder(resp.Body, ct)
@@ -207,11 +357,23 @@ func ResponseDecoder(resp *http.Response) Decoder {
 // and if so uses the error temporary and timeout fields to infer a proper HTTP
 // status code and marshals the error struct to the body using the provided
 // encoder. If the error is not a goa ServiceError struct then it is encoded
-// as a permanent internal server error.
+// as a permanent internal server error. The error messages are translated in
+// the request language when the context contains a translator set with
+// TranslatorMiddleware. The error message is replaced with the text of the
+// status code when the context error verbosity is ErrorSanitized. The
+// Retry-After header is set if the error has a RetryAfter delay.
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		WritePassThroughHeaders(ctx, w)
 		enc := encoder(ctx, w)
+		if t, ok := ctx.Value(translatorKey).(goa.Translator); ok {
+			err = goa.TranslateError(err, t, ContextLanguages(ctx)...)
+		}
 		resp := NewErrorResponse(err)
+		if ContextErrorVerbosity(ctx) == ErrorSanitized {
+			resp.Message = http.StatusText(resp.StatusCode())
+		}
+		SetRetryAfter(w, err)
 		w.WriteHeader(resp.StatusCode())
 		return enc.Encode(resp)
 	}
@@ -249,6 +411,30 @@ func SetContentType(w http.ResponseWriter, ct string) {
 	w.Header().Set("Content-Type", h+suffix)
 }
 
+// isMsgPack returns true if the given mime type denotes MessagePack content.
+func isMsgPack(mt string) bool {
+	return mt == "application/msgpack" || mt == "application/x-msgpack" || strings.HasSuffix(mt, "+msgpack")
+}
+
+// newRequestEncoder returns the request body encoder for the given mime type.
+func newRequestEncoder(w io.Writer, mt string) Encoder {
+	switch {
+	case mt == "application/xml" || strings.HasSuffix(mt, "+xml"):
+		return xml.NewEncoder(w)
+	case mt == "application/gob" || strings.HasSuffix(mt, "+gob"):
+		return gob.NewEncoder(w)
+	case mt == "application/cbor" || strings.HasSuffix(mt, "+cbor"):
+		return NewCBOREncoder(w)
+	case isMsgPack(mt):
+		return NewMsgPackEncoder(w)
+	case mt == "text/html" || mt == "text/plain" ||
+		strings.HasSuffix(mt, "+html") || strings.HasSuffix(mt, "+txt"):
+		return newTextEncoder(w, mt)
+	default:
+		return json.NewEncoder(w)
+	}
+}
+
 func newTextEncoder(w io.Writer, ct string) Encoder {
 	return &textEncoder{w, ct}
 }
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 var (
@@ -24,6 +27,9 @@ func TestResponseEncoder(t *testing.T) {
 		{"no ct, at json", "", "application/json", "*json.Encoder"},
 		{"no ct, at xml", "", "application/xml", "*xml.Encoder"},
 		{"no ct, at gob", "", "application/gob", "*gob.Encoder"},
+		{"no ct, at cbor", "", "application/cbor", "*http.cborEncoder"},
+		{"no ct, at msgpack", "", "application/msgpack", "*http.msgpackEncoder"},
+		{"no ct, at x-msgpack", "", "application/x-msgpack", "*http.msgpackEncoder"},
 		{"no ct, at html", "", "text/html", "*http.textEncoder"},
 		{"no ct, at plain", "", "text/plain", "*http.textEncoder"},
 		{"ct json", "application/json", "application/gob", "*json.Encoder"},
@@ -32,6 +38,10 @@ func TestResponseEncoder(t *testing.T) {
 		{"ct +xml", "+xml", "application/gob", "*xml.Encoder"},
 		{"ct gob", "application/gob", "application/xml", "*gob.Encoder"},
 		{"ct +gob", "+gob", "application/xml", "*gob.Encoder"},
+		{"ct cbor", "application/cbor", "application/xml", "*http.cborEncoder"},
+		{"ct +cbor", "+cbor", "application/xml", "*http.cborEncoder"},
+		{"ct msgpack", "application/msgpack", "application/xml", "*http.msgpackEncoder"},
+		{"ct +msgpack", "+msgpack", "application/xml", "*http.msgpackEncoder"},
 		{"ct html", "text/html", "application/gob", "*http.textEncoder"},
 		{"ct +html", "+html", "application/gob", "*http.textEncoder"},
 		{"ct plain", "text/plain", "application/gob", "*http.textEncoder"},
@@ -63,6 +73,11 @@ func TestResponseDecoder(t *testing.T) {
 		{"+xml", "*xml.Decoder"},
 		{"application/gob", "*gob.Decoder"},
 		{"+gob", "*gob.Decoder"},
+		{"application/cbor", "*http.cborDecoder"},
+		{"+cbor", "*http.cborDecoder"},
+		{"application/msgpack", "*http.msgpackDecoder"},
+		{"application/x-msgpack", "*http.msgpackDecoder"},
+		{"+msgpack", "*http.msgpackDecoder"},
 		{"text/html", "*http.textDecoder"},
 		{"+html", "*http.textDecoder"},
 		{"text/plain", "*http.textDecoder"},
@@ -84,6 +99,132 @@ func TestResponseDecoder(t *testing.T) {
 	}
 }
 
+func TestStrictDecoder(t *testing.T) {
+	type body struct {
+		Name string `json:"name"`
+	}
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+		err         string
+	}{
+		{"json", "application/json", `{"name":"a"}`, "a", ""},
+		{"json-unknown", "application/json", `{"name":"a","amount":1}`, "", "unknown_field: unknown field \"amount\""},
+		{"json-invalid", "application/json", `{"name":1}`, "", "json: cannot unmarshal number into Go struct field body.name of type string"},
+		{"xml", "application/xml", `<body><Name>a</Name><Amount>1</Amount></body>`, "a", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.body))
+			r.Header.Set("Content-Type", c.contentType)
+			var b body
+			err := StrictDecoder(RequestDecoder(r)).Decode(&b)
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("got error %q", err)
+				}
+				if b.Name != c.expected {
+					t.Errorf("got name %q, expected %q", b.Name, c.expected)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("got no error, expected %q", c.err)
+			}
+			if serr, ok := err.(*goa.ServiceError); ok {
+				if actual := serr.Name + ": " + serr.Message; actual != c.err {
+					t.Errorf("got error %q, expected %q", actual, c.err)
+				}
+			} else if err.Error() != c.err {
+				t.Errorf("got error %q, expected %q", err, c.err)
+			}
+		})
+	}
+}
+
+func TestContentTypeRequestEncoder(t *testing.T) {
+	cases := []struct {
+		contentType string
+		accept      string
+		encoderType string
+		expected    string
+	}{
+		{"application/json", "", "*json.Encoder", "application/json"},
+		{"application/msgpack", "", "*http.msgpackEncoder", "application/msgpack"},
+		{"application/vnd.goa+msgpack; charset=utf-8", "", "*http.msgpackEncoder", "application/vnd.goa+msgpack; charset=utf-8"},
+		{"application/cbor", "application/json", "*http.cborEncoder", "application/json"},
+		{"application/unknown", "", "*json.Encoder", "application/unknown"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.contentType, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", nil)
+			if c.accept != "" {
+				r.Header.Set("Accept", c.accept)
+			}
+			encoder := ContentTypeRequestEncoder(c.contentType)(r)
+			if c.encoderType != fmt.Sprintf("%T", encoder) {
+				t.Errorf("got encoder type %s, expected %s", fmt.Sprintf("%T", encoder), c.encoderType)
+			}
+			if ct := r.Header.Get("Content-Type"); ct != c.contentType {
+				t.Errorf("got Content-Type %q, expected %q", ct, c.contentType)
+			}
+			if accept := r.Header.Get("Accept"); accept != c.expected {
+				t.Errorf("got Accept %q, expected %q", accept, c.expected)
+			}
+		})
+	}
+}
+
+func TestRequestEncoder(t *testing.T) {
+	cases := []struct {
+		contentType string
+		encoderType string
+	}{
+		{"", "*json.Encoder"},
+		{"application/xml", "*xml.Encoder"},
+		{"application/vnd.goa+xml; charset=utf-8", "*xml.Encoder"},
+		{"application/msgpack", "*http.msgpackEncoder"},
+		{"application/unknown", "*json.Encoder"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.contentType, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", nil)
+			if c.contentType != "" {
+				r.Header.Set("Content-Type", c.contentType)
+			}
+			encoder := RequestEncoder(r)
+			if c.encoderType != fmt.Sprintf("%T", encoder) {
+				t.Errorf("got encoder type %s, expected %s", fmt.Sprintf("%T", encoder), c.encoderType)
+			}
+		})
+	}
+}
+
+func TestIsContentType(t *testing.T) {
+	cases := []struct {
+		contentType string
+		mediaType   string
+		expected    bool
+	}{
+		{"application/xml", "application/xml", true},
+		{"application/xml; charset=utf-8", "application/xml", true},
+		{"application/json", "application/xml", false},
+		{"", "application/xml", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.contentType, func(t *testing.T) {
+			if actual := IsContentType(c.contentType, c.mediaType); actual != c.expected {
+				t.Errorf("got %v, expected %v", actual, c.expected)
+			}
+		})
+	}
+}
+
 func TestTextEncoder_Encode(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// KMS is the interface implemented by the key management services used by
+// the generated encoders and decoders to encrypt and decrypt the body fields
+// marked with Encrypted in the design. Implementations typically use envelope
+// encryption: the data is encrypted with a data key which is itself encrypted
+// with a master key held by the KMS and stored alongside the ciphertext.
+type KMS interface {
+	// Encrypt returns the ciphertext for the given plaintext.
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	// Decrypt returns the plaintext for the given ciphertext.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// ErrMissingKMS is the error returned when encrypting or decrypting a field
+// using a context that does not hold a KMS.
+var ErrMissingKMS = errors.New("no KMS in context, use ContextWithKMS to set one")
+
+// ContextWithKMS returns a copy of ctx that holds kms. Servers typically use
+// the middleware.KMS middleware to store the KMS in the request context while
+// clients set it in the context given to the endpoint.
+func ContextWithKMS(ctx context.Context, kms KMS) context.Context {
+	return context.WithValue(ctx, kmsKey, kms)
+}
+
+// KMSFromContext returns the KMS stored in ctx by ContextWithKMS if any, nil
+// otherwise.
+func KMSFromContext(ctx context.Context) KMS {
+	kms, _ := ctx.Value(kmsKey).(KMS)
+	return kms
+}
+
+// EncryptString encrypts v using the KMS stored in ctx and returns the base64
+// encoded ciphertext.
+func EncryptString(ctx context.Context, v string) (string, error) {
+	b, err := EncryptBytes(ctx, []byte(v))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// DecryptString decodes the base64 encoded ciphertext v and decrypts it using
+// the KMS stored in ctx.
+func DecryptString(ctx context.Context, v string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %s", err)
+	}
+	b, err = DecryptBytes(ctx, b)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// EncryptBytes encrypts v using the KMS stored in ctx.
+func EncryptBytes(ctx context.Context, v []byte) ([]byte, error) {
+	kms := KMSFromContext(ctx)
+	if kms == nil {
+		return nil, ErrMissingKMS
+	}
+	return kms.Encrypt(ctx, v)
+}
+
+// DecryptBytes decrypts v using the KMS stored in ctx.
+func DecryptBytes(ctx context.Context, v []byte) ([]byte, error) {
+	kms := KMSFromContext(ctx)
+	if kms == nil {
+		return nil, ErrMissingKMS
+	}
+	return kms.Decrypt(ctx, v)
+}
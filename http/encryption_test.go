@@ -0,0 +1,66 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// reverseKMS is a test KMS that "encrypts" by reversing the bytes.
+type reverseKMS struct{}
+
+func (reverseKMS) Encrypt(_ context.Context, b []byte) ([]byte, error) { return reverse(b), nil }
+func (reverseKMS) Decrypt(_ context.Context, b []byte) ([]byte, error) { return reverse(b), nil }
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, c := range b {
+		r[len(b)-1-i] = c
+	}
+	return r
+}
+
+func TestEncryptString(t *testing.T) {
+	ctx := ContextWithKMS(context.Background(), reverseKMS{})
+	enc, err := EncryptString(ctx, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc != "dGVyY2Vz" {
+		t.Errorf("got encrypted value %q, expected %q", enc, "dGVyY2Vz")
+	}
+	dec, err := DecryptString(ctx, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec != "secret" {
+		t.Errorf("got decrypted value %q, expected %q", dec, "secret")
+	}
+	if _, err := DecryptString(ctx, "not base64!"); err == nil {
+		t.Error("expected an error decrypting an invalid value")
+	}
+}
+
+func TestEncryptBytes(t *testing.T) {
+	ctx := ContextWithKMS(context.Background(), reverseKMS{})
+	enc, err := EncryptBytes(ctx, []byte("ab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := DecryptBytes(ctx, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dec, []byte("ab")) {
+		t.Errorf("got decrypted value %q, expected %q", dec, "ab")
+	}
+}
+
+func TestEncryptMissingKMS(t *testing.T) {
+	if _, err := EncryptString(context.Background(), "secret"); err != ErrMissingKMS {
+		t.Errorf("got error %v, expected %v", err, ErrMissingKMS)
+	}
+	if _, err := DecryptBytes(context.Background(), nil); err != ErrMissingKMS {
+		t.Errorf("got error %v, expected %v", err, ErrMissingKMS)
+	}
+}
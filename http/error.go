@@ -1,7 +1,12 @@
 package http
 
 import (
+	"context"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	goa "goa.design/goa/v3/pkg"
 )
@@ -23,7 +28,26 @@ type (
 		Timeout bool `json:"timeout" xml:"timeout" form:"timeout"`
 		// Fault indicates whether the error is a server-side fault.
 		Fault bool `json:"fault" xml:"fault" form:"fault"`
+		// Errors lists the individual field validation errors when the
+		// method uses the AggregateErrors DSL.
+		Errors []*goa.FieldError `json:"errors,omitempty" xml:"errors,omitempty" form:"errors,omitempty"`
 	}
+
+	// ErrorVerbosity controls how much detail the generated error encoders
+	// write in error responses.
+	ErrorVerbosity int
+)
+
+const (
+	// ErrorVerbose causes the error encoders to write the full error
+	// messages and the error response types defined in the design. This is
+	// the default and is intended for development.
+	ErrorVerbose ErrorVerbosity = iota
+	// ErrorSanitized causes the error encoders to write error responses
+	// that only contain the error name, a generic message and an error ID
+	// that can be used to correlate the response with the server logs.
+	// This is intended for production.
+	ErrorSanitized
 )
 
 // NewErrorResponse creates a HTTP response from the given error.
@@ -36,19 +60,174 @@ func NewErrorResponse(err error) *ErrorResponse {
 			Timeout:   gerr.Timeout,
 			Temporary: gerr.Temporary,
 			Fault:     gerr.Fault,
+			Errors:    gerr.Fields,
 		}
 	}
 	return NewErrorResponse(goa.Fault(err.Error()))
 }
 
+// NewSanitizedErrorResponse creates a HTTP response from the given error that
+// does not include the error details. name overrides the name of the error and
+// msg the message if not empty, the message defaults to the text of the
+// response status code otherwise. The response uses the ID of err if err is a
+// goa ServiceError and a new ID otherwise.
+func NewSanitizedErrorResponse(err error, name, msg string) *ErrorResponse {
+	var resp *ErrorResponse
+	if _, ok := err.(*goa.ServiceError); ok || name == "" {
+		resp = NewErrorResponse(err)
+	} else {
+		resp = &ErrorResponse{}
+	}
+	if name != "" {
+		resp.Name = name
+	}
+	if resp.ID == "" {
+		resp.ID = goa.NewErrorID()
+	}
+	resp.Message = msg
+	resp.Errors = nil
+	if msg == "" {
+		resp.Message = http.StatusText(resp.StatusCode())
+	}
+	return resp
+}
+
+// SetRetryAfter sets the Retry-After header of the response to the RetryAfter
+// delay of err rounded up to the second if err is a goa ServiceError with a
+// delay. It does nothing otherwise.
+func SetRetryAfter(w http.ResponseWriter, err error) {
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok || gerr.RetryAfter <= 0 {
+		return
+	}
+	secs := int64((gerr.RetryAfter + time.Second - 1) / time.Second)
+	w.Header().Set("Retry-After", strconv.FormatInt(secs, 10))
+}
+
+// RetryAfter returns the delay indicated by the Retry-After header of the
+// response. The header may contain a number of seconds or a HTTP date.
+// RetryAfter returns zero if the header is missing or invalid or if the date
+// is in the past.
+func RetryAfter(resp *http.Response) time.Duration {
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseInt(h, 10, 64); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	t, err := http.ParseTime(h)
+	if err != nil {
+		return 0
+	}
+	if d := time.Until(t); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// ErrorVerbosityMiddleware returns a middleware that sets the verbosity of the
+// error responses written by the generated error encoders for the requests it
+// handles.
+func ErrorVerbosityMiddleware(v ErrorVerbosity) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), errorVerbosityKey, v)))
+		})
+	}
+}
+
+// ContextErrorVerbosity returns the error verbosity stored in ctx by
+// ErrorVerbosityMiddleware, ErrorVerbose if there is none.
+func ContextErrorVerbosity(ctx context.Context) ErrorVerbosity {
+	v, _ := ctx.Value(errorVerbosityKey).(ErrorVerbosity)
+	return v
+}
+
+// TranslatorMiddleware returns a middleware that makes the error encoders
+// translate the messages of the errors produced by the generated code using t
+// for the requests it handles. The messages are translated in the first
+// language listed in the request Accept-Language header for which t defines
+// them and are left unchanged if there is none. The errors defined in the
+// design and encoded using their own response types are not translated.
+func TranslatorMiddleware(t goa.Translator) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), translatorKey, t)
+			ctx = context.WithValue(ctx, languagesKey, ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ContextLanguages returns the languages listed in the request Accept-Language
+// header stored in ctx by TranslatorMiddleware, nil if there are none.
+func ContextLanguages(ctx context.Context) []string {
+	langs, _ := ctx.Value(languagesKey).([]string)
+	return langs
+}
+
+// ParseAcceptLanguage returns the language tags listed in the value of an
+// Accept-Language header ordered by decreasing quality. The wildcard and the
+// languages with a quality of 0 are omitted.
+func ParseAcceptLanguage(h string) []string {
+	type lang struct {
+		tag string
+		q   float64
+	}
+	var langs []lang
+	for _, part := range strings.Split(h, ",") {
+		elems := strings.Split(part, ";")
+		tag := strings.TrimSpace(elems[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, p := range elems[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if v, err := strconv.ParseFloat(p[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		langs = append(langs, lang{tag, q})
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+	tags := make([]string, len(langs))
+	for i, l := range langs {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
 // StatusCode implements a heuristic that computes a HTTP response status code
 // appropriate for the timeout, temporary and fault characteristics of the
 // error. This method is used by the generated server code when the error is not
-// described explicitly in the design.
+// described explicitly in the design. The errors produced when a request body
+// exceeds the size set with the MaxRequestBody DSL use the HTTP 413 Request
+// Entity Too Large status code and the errors produced when a request body is
+// compressed with an unsupported content coding use the HTTP 415 Unsupported
+// Media Type status code and the errors returned by CheckPrecondition use the
+// HTTP 412 Precondition Failed status code.
 func (resp *ErrorResponse) StatusCode() int {
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
+	switch resp.Name {
+	case "request_body_too_large":
+		return http.StatusRequestEntityTooLarge
+	case "unsupported_content_encoding":
+		return http.StatusUnsupportedMediaType
+	case "precondition_failed":
+		return http.StatusPreconditionFailed
+	}
 	if resp.Timeout {
 		if resp.Temporary {
 			return http.StatusGatewayTimeout
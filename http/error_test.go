@@ -0,0 +1,244 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestNewSanitizedErrorResponse(t *testing.T) {
+	serr := goa.PermanentError("not_found", "user 42 does not exist in table users")
+	cases := []struct {
+		Name         string
+		Err          error
+		ErrName      string
+		Msg          string
+		ExpName      string
+		ExpID        string
+		ExpMessage   string
+		ExpStatus    int
+		ExpTemporary bool
+	}{
+		{"service error", serr, "not_found", "Not found.", "not_found", serr.ID, "Not found.", http.StatusBadRequest, false},
+		{"service error no message", goa.TemporaryError("busy", "db connection pool exhausted"), "", "", "busy", "", "Service Unavailable", http.StatusServiceUnavailable, true},
+		{"custom error", errors.New("secret"), "custom", "Custom error.", "custom", "", "Custom error.", http.StatusBadRequest, false},
+		{"unknown error", errors.New("secret"), "", "", "fault", "", "Internal Server Error", http.StatusInternalServerError, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			resp := NewSanitizedErrorResponse(c.Err, c.ErrName, c.Msg)
+			if resp.Name != c.ExpName {
+				t.Errorf("got name %q, expected %q", resp.Name, c.ExpName)
+			}
+			if resp.ID == "" {
+				t.Error("got empty ID")
+			}
+			if c.ExpID != "" && resp.ID != c.ExpID {
+				t.Errorf("got ID %q, expected %q", resp.ID, c.ExpID)
+			}
+			if resp.Message != c.ExpMessage {
+				t.Errorf("got message %q, expected %q", resp.Message, c.ExpMessage)
+			}
+			if resp.StatusCode() != c.ExpStatus {
+				t.Errorf("got status %d, expected %d", resp.StatusCode(), c.ExpStatus)
+			}
+			if resp.Temporary != c.ExpTemporary {
+				t.Errorf("got temporary %v, expected %v", resp.Temporary, c.ExpTemporary)
+			}
+		})
+	}
+}
+
+func TestNewErrorResponseAggregateErrors(t *testing.T) {
+	var err error
+	err = goa.MergeErrors(err, goa.MissingFieldError("name", "body"))
+	err = goa.MergeErrors(err, goa.InvalidFormatError("body.email", "foo", goa.FormatEmail, errors.New("invalid email")))
+	err = goa.MergeErrors(err, goa.InvalidRangeError("limit", 0, 1, true))
+	err = goa.MergeErrors(err, goa.MissingFieldError("X-Request-ID", "header"))
+
+	if resp := NewErrorResponse(err); resp.Errors != nil {
+		t.Errorf("got %d field errors without AggregateErrors, expected none", len(resp.Errors))
+	}
+
+	resp := NewErrorResponse(goa.AggregateErrors(err))
+	expected := []*goa.FieldError{
+		{Path: "body.name", Rule: "missing_field", Message: `"name" is missing from body`},
+		{Path: "body.email", Rule: "invalid_format", Message: `body.email must be formatted as a email but got value "foo", invalid email`},
+		{Path: "limit", Rule: "invalid_range", Message: "limit must be greater or equal than 1 but got value 0"},
+		{Path: "X-Request-ID", Rule: "missing_field", Message: `"X-Request-ID" is missing from header`},
+	}
+	if len(resp.Errors) != len(expected) {
+		t.Fatalf("got %d field errors, expected %d", len(resp.Errors), len(expected))
+	}
+	for i, fe := range resp.Errors {
+		if *fe != *expected[i] {
+			t.Errorf("field error %d: got %+v, expected %+v", i, *fe, *expected[i])
+		}
+	}
+	if resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("got status %d, expected %d", resp.StatusCode(), http.StatusBadRequest)
+	}
+
+	if sresp := NewSanitizedErrorResponse(goa.AggregateErrors(err), "", ""); sresp.Errors != nil {
+		t.Errorf("got %d field errors in sanitized response, expected none", len(sresp.Errors))
+	}
+}
+
+func TestErrorEncoderVerbosity(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Verbosity  ErrorVerbosity
+		ExpMessage string
+	}{
+		{"verbose", ErrorVerbose, "user 42 does not exist"},
+		{"sanitized", ErrorSanitized, "Bad Request"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var resp *ErrorResponse
+			encoder := func(context.Context, http.ResponseWriter) Encoder {
+				return EncodingFunc(func(v interface{}) error {
+					resp = v.(*ErrorResponse)
+					return nil
+				})
+			}
+			h := ErrorVerbosityMiddleware(c.Verbosity)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				err := goa.PermanentError("not_found", "user 42 does not exist")
+				if err := ErrorEncoder(encoder)(r.Context(), w, err); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusBadRequest)
+			}
+			if resp == nil {
+				t.Fatal("error response not encoded")
+			}
+			if resp.Message != c.ExpMessage {
+				t.Errorf("got message %q, expected %q", resp.Message, c.ExpMessage)
+			}
+			if resp.ID == "" {
+				t.Error("got empty ID")
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	limited := goa.TemporaryError("rate_limited", "too many requests")
+	limited.RetryAfter = 1500 * time.Millisecond
+	cases := []struct {
+		Name     string
+		Err      error
+		Header   string
+		Expected time.Duration
+	}{
+		{"retry-after", limited, "2", 2 * time.Second},
+		{"no-retry-after", goa.TemporaryError("unavailable", "try again"), "", 0},
+		{"not-service-error", errors.New("boom"), "", 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			encoder := func(context.Context, http.ResponseWriter) Encoder {
+				return EncodingFunc(func(interface{}) error { return nil })
+			}
+			w := httptest.NewRecorder()
+			if err := ErrorEncoder(encoder)(context.Background(), w, c.Err); err != nil {
+				t.Fatal(err)
+			}
+			if h := w.Header().Get("Retry-After"); h != c.Header {
+				t.Errorf("got Retry-After %q, expected %q", h, c.Header)
+			}
+			if d := RetryAfter(w.Result()); d != c.Expected {
+				t.Errorf("got delay %s, expected %s", d, c.Expected)
+			}
+		})
+	}
+	t.Run("http-date", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		if d := RetryAfter(resp); d <= 58*time.Minute || d > time.Hour {
+			t.Errorf("got delay %s, expected about 1h", d)
+		}
+		resp.Header.Set("Retry-After", "Wed, 21 Oct 2015 07:28:00 GMT")
+		if d := RetryAfter(resp); d != 0 {
+			t.Errorf("got delay %s for past date, expected 0", d)
+		}
+	})
+}
+
+func TestErrorEncoderTranslator(t *testing.T) {
+	catalog := goa.Catalog{"fr": {"missing_field": `"{field}" est manquant`}}
+	cases := []struct {
+		Name           string
+		AcceptLanguage string
+		ExpMessage     string
+	}{
+		{"translated", "fr-CA, en;q=0.8", `"name" est manquant`},
+		{"preferred-language", "de;q=0.5, fr;q=0.7", `"name" est manquant`},
+		{"unknown-language", "de", `"name" is missing from body`},
+		{"no-header", "", `"name" is missing from body`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var resp *ErrorResponse
+			encoder := func(context.Context, http.ResponseWriter) Encoder {
+				return EncodingFunc(func(v interface{}) error {
+					resp = v.(*ErrorResponse)
+					return nil
+				})
+			}
+			h := TranslatorMiddleware(catalog)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				err := goa.MissingFieldError("name", "body")
+				if err := ErrorEncoder(encoder)(r.Context(), w, err); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.AcceptLanguage != "" {
+				req.Header.Set("Accept-Language", c.AcceptLanguage)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if resp == nil {
+				t.Fatal("error response not encoded")
+			}
+			if resp.Message != c.ExpMessage {
+				t.Errorf("got message %q, expected %q", resp.Message, c.ExpMessage)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Header   string
+		Expected []string
+	}{
+		{"empty", "", []string{}},
+		{"single", "fr", []string{"fr"}},
+		{"ordered", "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5", []string{"fr-CH", "fr", "en"}},
+		{"unordered", "en;q=0.5, de, fr;q=0.7", []string{"de", "fr", "en"}},
+		{"excluded", "en;q=0, fr", []string{"fr"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			actual := ParseAcceptLanguage(c.Header)
+			if len(actual) != len(c.Expected) {
+				t.Fatalf("got %v, expected %v", actual, c.Expected)
+			}
+			for i, l := range actual {
+				if l != c.Expected[i] {
+					t.Errorf("got %v, expected %v", actual, c.Expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// CSVContentType is the mime type of the CSV files written by the export
+// endpoints.
+const CSVContentType = "text/csv"
+
+type (
+	// RowEncoder writes the rows of a file, see ExportFormat.
+	RowEncoder interface {
+		// EncodeRow writes the given cells as a new row.
+		EncodeRow(cells []string) error
+		// Close writes the buffered rows and the end of the file if
+		// any. Close does not close the underlying writer.
+		Close() error
+	}
+
+	// ExportFormat describes a file format supported by the export
+	// endpoints, see the Export DSL. Export endpoints write CSV files unless
+	// the request Accept header lists the content type of a format made
+	// available with ExportFormatsMiddleware.
+	ExportFormat struct {
+		// ContentType is the mime type of the files, e.g.
+		// "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet".
+		ContentType string
+		// Extension is the file name extension without the leading dot,
+		// e.g. "xlsx".
+		Extension string
+		// NewEncoder returns an encoder that writes a file to w.
+		NewEncoder func(w io.Writer) RowEncoder
+	}
+
+	// ExportWriter writes rows to a HTTP response as a file. The response
+	// status code and headers are written with the first row which lists
+	// the column names. The response is flushed after each row so that
+	// clients receive the rows as soon as they are written.
+	ExportWriter struct {
+		w        http.ResponseWriter
+		status   int
+		format   *ExportFormat
+		filename string
+		columns  []string
+		enc      RowEncoder
+		started  bool
+	}
+
+	// ExportReader reads the rows of a CSV file from a HTTP response body.
+	ExportReader struct {
+		body    io.ReadCloser
+		r       *csv.Reader
+		columns []string
+		// index maps the position of the columns to the position of the
+		// cells in the file, -1 if the file does not have the column.
+		index []int
+	}
+
+	// csvEncoder is the RowEncoder used to write CSV files.
+	csvEncoder struct {
+		w *csv.Writer
+	}
+)
+
+// CSVFormat is the format of the CSV files written by the export endpoints.
+var CSVFormat = &ExportFormat{
+	ContentType: CSVContentType,
+	Extension:   "csv",
+	NewEncoder:  func(w io.Writer) RowEncoder { return &csvEncoder{w: csv.NewWriter(w)} },
+}
+
+// ExportFormatsMiddleware returns a HTTP middleware that makes the given formats
+// available to the export endpoints. The endpoints use the first format listed
+// in the request Accept header, CSV if none is listed.
+func ExportFormatsMiddleware(formats ...*ExportFormat) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), exportFormatsKey, formats)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// NewExportWriter returns a writer that streams rows to w using the given
+// response status code. The file format is negotiated using the Accept header
+// of r. filename is the name of the file returned in the Content-Disposition
+// header without extension and columns lists the column names.
+func NewExportWriter(w http.ResponseWriter, r *http.Request, status int, filename string, columns []string) *ExportWriter {
+	return &ExportWriter{
+		w:        w,
+		status:   status,
+		format:   negotiateExportFormat(r),
+		filename: filename,
+		columns:  columns,
+	}
+}
+
+// Header returns the response headers. Changes made to the headers after the
+// first row is written have no effect.
+func (s *ExportWriter) Header() http.Header {
+	return s.w.Header()
+}
+
+// Started returns true if the response status code and headers have been
+// written.
+func (s *ExportWriter) Started() bool {
+	return s.started
+}
+
+// WriteRow writes a row to the response and flushes it.
+func (s *ExportWriter) WriteRow(cells []string) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	if err := s.enc.EncodeRow(cells); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Close writes the end of the file. It writes the response status code,
+// headers and column names if no row was written.
+func (s *ExportWriter) Close() error {
+	if err := s.start(); err != nil {
+		return err
+	}
+	return s.enc.Close()
+}
+
+// start writes the response status code, headers and column names once.
+func (s *ExportWriter) start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+	s.w.Header().Set("Content-Type", s.format.ContentType)
+	if s.filename != "" {
+		name := s.filename
+		if s.format.Extension != "" {
+			name += "." + s.format.Extension
+		}
+		cd := mime.FormatMediaType("attachment", map[string]string{"filename": name})
+		s.w.Header().Set("Content-Disposition", cd)
+	}
+	s.w.WriteHeader(s.status)
+	s.enc = s.format.NewEncoder(s.w)
+	return s.enc.EncodeRow(s.columns)
+}
+
+// ExportFilename returns the file name built by replacing the placeholders of
+// the form "{name}" in tmpl with the values of the corresponding params.
+// Pointer values are dereferenced and nil pointers are replaced with empty
+// strings. The path separators are replaced with underscores.
+func ExportFilename(tmpl string, params map[string]interface{}) string {
+	oldnew := make([]string, 0, 2*len(params))
+	for k, v := range params {
+		var s string
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+			if !rv.IsNil() {
+				s = fmt.Sprint(rv.Elem().Interface())
+			}
+		} else {
+			s = fmt.Sprint(v)
+		}
+		oldnew = append(oldnew, "{"+k+"}", s)
+	}
+	name := strings.NewReplacer(oldnew...).Replace(tmpl)
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+}
+
+// NewExportReader returns a reader that reads the rows of the CSV file read
+// from body. columns lists the names of the columns returned by Read in
+// order.
+func NewExportReader(body io.ReadCloser, columns []string) *ExportReader {
+	r := csv.NewReader(body)
+	r.FieldsPerRecord = -1
+	return &ExportReader{body: body, r: r, columns: columns}
+}
+
+// Read returns the cells of the next row in the order of the reader columns.
+// The cells of the columns missing from the file are empty. Read closes the
+// body and returns io.EOF once all the rows have been read.
+func (r *ExportReader) Read() ([]string, error) {
+	if r.index == nil {
+		header, err := r.r.Read()
+		if err != nil {
+			r.body.Close()
+			return nil, err
+		}
+		r.index = make([]int, len(r.columns))
+		for i, c := range r.columns {
+			r.index[i] = -1
+			for j, h := range header {
+				if h == c {
+					r.index[i] = j
+					break
+				}
+			}
+		}
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		r.body.Close()
+		return nil, err
+	}
+	cells := make([]string, len(r.columns))
+	for i, j := range r.index {
+		if j >= 0 && j < len(record) {
+			cells[i] = record[j]
+		}
+	}
+	return cells, nil
+}
+
+// Close closes the response body. Close must be called by clients that stop
+// reading rows before the end of the file.
+func (r *ExportReader) Close() error {
+	return r.body.Close()
+}
+
+// EncodeRow writes the cells as a CSV record and flushes it.
+func (e *csvEncoder) EncodeRow(cells []string) error {
+	if err := e.w.Write(cells); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// Close flushes the buffered records.
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// negotiateExportFormat returns the first format listed in the Accept header
+// of r that is either CSV or made available with ExportFormatsMiddleware, CSV
+// if none is listed.
+func negotiateExportFormat(r *http.Request) *ExportFormat {
+	formats, _ := r.Context().Value(exportFormatsKey).([]*ExportFormat)
+	for _, a := range strings.Split(r.Header.Get("Accept"), ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(a))
+		if err != nil {
+			continue
+		}
+		if mt == CSVContentType {
+			return CSVFormat
+		}
+		for _, f := range formats {
+			if strings.EqualFold(mt, f.ContentType) {
+				return f
+			}
+		}
+	}
+	return CSVFormat
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// tsvEncoder is a RowEncoder that writes tab separated values.
+type tsvEncoder struct {
+	w io.Writer
+}
+
+func (e *tsvEncoder) EncodeRow(cells []string) error {
+	_, err := io.WriteString(e.w, strings.Join(cells, "\t")+"\n")
+	return err
+}
+
+func (e *tsvEncoder) Close() error { return nil }
+
+func TestExportWriter(t *testing.T) {
+	tsv := &ExportFormat{
+		ContentType: "text/tab-separated-values",
+		Extension:   "tsv",
+		NewEncoder:  func(w io.Writer) RowEncoder { return &tsvEncoder{w: w} },
+	}
+	columns := []string{"id", "name"}
+	cases := []struct {
+		name        string
+		accept      string
+		filename    string
+		rows        [][]string
+		contentType string
+		disposition string
+		expected    string
+	}{
+		{"empty", "", "orders", nil, CSVContentType, `attachment; filename=orders.csv`, "id,name\n"},
+		{"rows", "", "orders", [][]string{{"1", "a"}, {"2", "b,c"}}, CSVContentType, `attachment; filename=orders.csv`, "id,name\n1,a\n2,\"b,c\"\n"},
+		{"no-filename", "", "", [][]string{{"1", "a"}}, CSVContentType, "", "id,name\n1,a\n"},
+		{"quoted-filename", "", "orders 2020", nil, CSVContentType, `attachment; filename="orders 2020.csv"`, "id,name\n"},
+		{"negotiated", "application/json, text/tab-separated-values", "orders", [][]string{{"1", "a"}}, tsv.ContentType, `attachment; filename=orders.tsv`, "id\tname\n1\ta\n"},
+		{"csv-first", "text/csv, text/tab-separated-values", "orders", nil, CSVContentType, `attachment; filename=orders.csv`, "id,name\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			var w *ExportWriter
+			h := ExportFormatsMiddleware(tsv)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				w = NewExportWriter(rw, r, http.StatusOK, c.filename, columns)
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.accept != "" {
+				req.Header.Set("Accept", c.accept)
+			}
+			h.ServeHTTP(rw, req)
+			for _, row := range c.rows {
+				if err := w.WriteRow(row); err != nil {
+					t.Fatalf("got error %q", err)
+				}
+				if !rw.Flushed {
+					t.Errorf("response not flushed")
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if !w.Started() {
+				t.Errorf("got not started, expected started")
+			}
+			if ct := rw.Header().Get("Content-Type"); ct != c.contentType {
+				t.Errorf("got content type %q, expected %q", ct, c.contentType)
+			}
+			if cd := rw.Header().Get("Content-Disposition"); cd != c.disposition {
+				t.Errorf("got content disposition %q, expected %q", cd, c.disposition)
+			}
+			if body := rw.Body.String(); body != c.expected {
+				t.Errorf("got body %q, expected %q", body, c.expected)
+			}
+		})
+	}
+}
+
+func TestExportFilename(t *testing.T) {
+	year, month := 2020, "01"
+	var missing *string
+	cases := []struct {
+		name     string
+		tmpl     string
+		params   map[string]interface{}
+		expected string
+	}{
+		{"no-param", "orders", nil, "orders"},
+		{"values", "orders-{year}-{month}", map[string]interface{}{"year": year, "month": month}, "orders-2020-01"},
+		{"pointers", "orders-{year}-{month}", map[string]interface{}{"year": &year, "month": &month}, "orders-2020-01"},
+		{"nil", "orders-{month}", map[string]interface{}{"month": missing}, "orders-"},
+		{"path", "orders-{month}", map[string]interface{}{"month": "../01"}, "orders-.._01"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := ExportFilename(c.tmpl, c.params); actual != c.expected {
+				t.Errorf("got %q, expected %q", actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestExportReader(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		expected [][]string
+	}{
+		{"empty", "id,name\n", nil},
+		{"rows", "id,name\n1,a\n2,\"b,c\"\n", [][]string{{"1", "a"}, {"2", "b,c"}}},
+		{"reordered", "name,id\na,1\n", [][]string{{"1", "a"}}},
+		{"missing-column", "id\n1\n", [][]string{{"1", ""}}},
+		{"extra-column", "id,other,name\n1,x,a\n", [][]string{{"1", "a"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := NewExportReader(ioutil.NopCloser(bytes.NewBufferString(c.body)), []string{"id", "name"})
+			var rows [][]string
+			for {
+				row, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("got error %q", err)
+				}
+				rows = append(rows, row)
+			}
+			if len(rows) != len(c.expected) {
+				t.Fatalf("got %d rows, expected %d", len(rows), len(c.expected))
+			}
+			for i, row := range rows {
+				if strings.Join(row, "|") != strings.Join(c.expected[i], "|") {
+					t.Errorf("got row %d %q, expected %q", i, row, c.expected[i])
+				}
+			}
+		})
+	}
+}
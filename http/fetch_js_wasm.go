@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+)
+
+// FetchDoer is a Doer that sends the requests of the generated clients with
+// the Fetch API of the browser running the WebAssembly module. It exposes the
+// fetch options that have no equivalent in net/http. The zero value uses the
+// browser defaults.
+type FetchDoer struct {
+	// Client is the HTTP client used to send the requests,
+	// http.DefaultClient if nil. Its transport must be the default
+	// fetch-based transport of the js/wasm port of net/http.
+	Client *http.Client
+	// Mode is the request mode: "cors", "no-cors" or "same-origin".
+	Mode string
+	// Credentials controls whether the browser sends cookies and HTTP
+	// authentication: "omit", "same-origin" or "include".
+	Credentials string
+	// Redirect controls how redirects are handled: "follow", "error" or
+	// "manual".
+	Redirect string
+}
+
+// NewFetchDoer returns a FetchDoer that sends the requests in the given mode
+// and with the given credentials policy, e.g. NewFetchDoer("cors", "include")
+// to send cookies to a service hosted on another origin.
+func NewFetchDoer(mode, credentials string) *FetchDoer {
+	return &FetchDoer{Mode: mode, Credentials: credentials}
+}
+
+// Do sends the request with the Fetch API.
+func (d *FetchDoer) Do(req *http.Request) (*http.Response, error) {
+	if d.Mode != "" || d.Credentials != "" || d.Redirect != "" {
+		r := new(http.Request)
+		*r = *req
+		r.Header = make(http.Header, len(req.Header)+3)
+		for k, v := range req.Header {
+			r.Header[k] = v
+		}
+		// The js/wasm transport of net/http turns these headers into
+		// fetch options instead of sending them.
+		if d.Mode != "" {
+			r.Header.Set("js.fetch:mode", d.Mode)
+		}
+		if d.Credentials != "" {
+			r.Header.Set("js.fetch:credentials", d.Credentials)
+		}
+		if d.Redirect != "" {
+			r.Header.Set("js.fetch:redirect", d.Redirect)
+		}
+		req = r
+	}
+	c := d.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return c.Do(req)
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// h2cTransport is the RoundTripper returned by NewH2CTransport.
+type h2cTransport struct {
+	h2c *http2.Transport
+	tls http.RoundTripper
+}
+
+// ConfigureH2C configures srv to serve cleartext HTTP/2 (h2c) connections in
+// addition to HTTP/1.1. Clients may either use HTTP/2 with prior knowledge or
+// upgrade HTTP/1.1 connections. ConfigureH2C must be called after the server
+// handler is set. Shutting down the server gracefully closes the HTTP/2
+// connections. The example servers generated for the designs that define the
+// "http:example:h2c" metadata use ConfigureH2C.
+func ConfigureH2C(srv *http.Server) error {
+	h2s := &http2.Server{}
+	if err := http2.ConfigureServer(srv, h2s); err != nil {
+		return err
+	}
+	srv.Handler = h2c.NewHandler(srv.Handler, h2s)
+	return nil
+}
+
+// NewH2CTransport returns a RoundTripper that sends the requests made to "http"
+// URLs over cleartext HTTP/2 with prior knowledge, that is without upgrading
+// an HTTP/1.1 connection first. This makes it possible for the streaming
+// requests to share a single connection. The other requests are sent with
+// http.DefaultTransport which negotiates HTTP/2 over TLS.
+func NewH2CTransport() http.RoundTripper {
+	return &h2cTransport{
+		h2c: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+		tls: http.DefaultTransport,
+	}
+}
+
+// RoundTrip sends the request over cleartext HTTP/2 if it uses the "http"
+// scheme.
+func (t *h2cTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "http" {
+		return t.h2c.RoundTrip(req)
+	}
+	return t.tls.RoundTrip(req)
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestH2C(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	if err := ConfigureH2C(ts.Config); err != nil {
+		t.Fatal(err)
+	}
+	ts.Start()
+	defer ts.Close()
+
+	cases := []struct {
+		Name          string
+		Transport     http.RoundTripper
+		ExpectedProto string
+	}{
+		{"http1", http.DefaultTransport, "HTTP/1.1"},
+		{"prior-knowledge", NewH2CTransport(), "HTTP/2.0"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			client := &http.Client{Transport: c.Transport}
+			resp, err := client.Get(ts.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.Proto != c.ExpectedProto {
+				t.Errorf("got response protocol %q, expected %q", resp.Proto, c.ExpectedProto)
+			}
+			buf := make([]byte, 8)
+			n, _ := resp.Body.Read(buf)
+			if got := string(buf[:n]); got != c.ExpectedProto {
+				t.Errorf("got request protocol %q, expected %q", got, c.ExpectedProto)
+			}
+		})
+	}
+}
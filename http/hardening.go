@@ -0,0 +1,99 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+type (
+	// EncoderOptions lists the hardening options applied to the responses
+	// by the encoders returned by EncoderWithOptions. The generated server
+	// constructors apply the options defined in the design with the
+	// DisableHTMLEscaping, NoSniff and JSONPrefix DSL.
+	EncoderOptions struct {
+		// DisableHTMLEscaping disables the escaping of the <, > and &
+		// characters in the JSON strings. The characters are escaped by
+		// default so that the JSON responses can be embedded safely in
+		// HTML documents.
+		DisableHTMLEscaping bool
+		// NoSniff sets the X-Content-Type-Options header of the responses
+		// to "nosniff" so that browsers do not override the response
+		// content type.
+		NoSniff bool
+		// JSONPrefix is written before the JSON response bodies so that
+		// legacy browsers cannot execute them as scripts (JSON
+		// hijacking), e.g. ")]}',\n". Clients must remove the prefix
+		// before decoding the bodies, see StripJSONPrefix.
+		JSONPrefix string
+	}
+
+	// prefixedBody is a response body whose reader skips a JSON prefix.
+	prefixedBody struct {
+		io.Reader
+		io.Closer
+	}
+)
+
+// EncoderWithOptions returns a response encoder constructor that applies opts
+// to the encoders created by encoder. The X-Content-Type-Options header is set
+// prior to calling encoder if opts.NoSniff is true. The options are stored in
+// the context given to encoder so that ResponseEncoder applies the JSON
+// options, custom encoders may retrieve them with ContextEncoderOptions.
+func EncoderWithOptions(encoder func(context.Context, http.ResponseWriter) Encoder, opts *EncoderOptions) func(context.Context, http.ResponseWriter) Encoder {
+	return func(ctx context.Context, w http.ResponseWriter) Encoder {
+		if opts.NoSniff {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+		}
+		return encoder(context.WithValue(ctx, encoderOptionsKey, opts), w)
+	}
+}
+
+// ContextEncoderOptions returns the encoder options stored in the context by
+// EncoderWithOptions, nil if there are none.
+func ContextEncoderOptions(ctx context.Context) *EncoderOptions {
+	opts, _ := ctx.Value(encoderOptionsKey).(*EncoderOptions)
+	return opts
+}
+
+// StripJSONPrefix returns a response decoder constructor that removes prefix
+// from the beginning of the response bodies prior to calling decoder. The
+// bodies that do not start with prefix are left unchanged. The generated
+// client constructors use StripJSONPrefix when the design defines a prefix
+// with the JSONPrefix DSL.
+func StripJSONPrefix(decoder func(*http.Response) Decoder, prefix string) func(*http.Response) Decoder {
+	return func(resp *http.Response) Decoder {
+		size := len(prefix)
+		if size < 16 {
+			size = 16
+		}
+		br := bufio.NewReaderSize(resp.Body, size)
+		if b, err := br.Peek(len(prefix)); err == nil && string(b) == prefix {
+			br.Discard(len(prefix))
+		}
+		resp.Body = &prefixedBody{Reader: br, Closer: resp.Body}
+		return decoder(resp)
+	}
+}
+
+// newResponseJSONEncoder returns a JSON encoder that writes to w and applies
+// the context encoder options if any.
+func newResponseJSONEncoder(ctx context.Context, w io.Writer) Encoder {
+	enc := json.NewEncoder(w)
+	opts := ContextEncoderOptions(ctx)
+	if opts == nil {
+		return enc
+	}
+	enc.SetEscapeHTML(!opts.DisableHTMLEscaping)
+	if opts.JSONPrefix == "" {
+		return enc
+	}
+	return EncodingFunc(func(v interface{}) error {
+		if _, err := io.WriteString(w, opts.JSONPrefix); err != nil {
+			return err
+		}
+		return enc.Encode(v)
+	})
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEncoderWithOptions(t *testing.T) {
+	const (
+		escaped   = `{"html":"\u003cb\u003e\u0026\u003c/b\u003e"}` + "\n"
+		unescaped = `{"html":"<b>&</b>"}` + "\n"
+	)
+	v := map[string]string{"html": "<b>&</b>"}
+	cases := []struct {
+		Name     string
+		Opts     *EncoderOptions
+		NoSniff  string
+		Accept   string
+		Expected string
+	}{
+		{"none", &EncoderOptions{}, "", "", escaped},
+		{"no-html-escaping", &EncoderOptions{DisableHTMLEscaping: true}, "", "", unescaped},
+		{"no-sniff", &EncoderOptions{NoSniff: true}, "nosniff", "", escaped},
+		{"prefix", &EncoderOptions{JSONPrefix: ")]}',\n"}, "", "", ")]}',\n" + escaped},
+		{"prefix-xml", &EncoderOptions{JSONPrefix: ")]}',\n"}, "", "application/xml", "<map></map>"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.Accept)
+			enc := EncoderWithOptions(ResponseEncoder, c.Opts)(ctx, w)
+			var val interface{} = v
+			if c.Accept == "application/xml" {
+				val = struct {
+					XMLName struct{} `xml:"map"`
+				}{}
+			}
+			if err := enc.Encode(val); err != nil {
+				t.Fatal(err)
+			}
+			if h := w.Header().Get("X-Content-Type-Options"); h != c.NoSniff {
+				t.Errorf("got X-Content-Type-Options %q, expected %q", h, c.NoSniff)
+			}
+			if body := w.Body.String(); body != c.Expected {
+				t.Errorf("got body %q, expected %q", body, c.Expected)
+			}
+		})
+	}
+}
+
+func TestStripJSONPrefix(t *testing.T) {
+	const prefix = ")]}',\n"
+	cases := []struct {
+		Name string
+		Body string
+	}{
+		{"prefix", prefix + `{"a":"b"}`},
+		{"no-prefix", `{"a":"b"}`},
+		{"short", `{}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+				Body:   ioutil.NopCloser(bytes.NewBufferString(c.Body)),
+			}
+			var v map[string]string
+			if err := StripJSONPrefix(ResponseDecoder, prefix)(resp).Decode(&v); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if c.Name != "short" && v["a"] != "b" {
+				t.Errorf("got %v, expected a=b", v)
+			}
+		})
+	}
+}
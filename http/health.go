@@ -0,0 +1,40 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// LivenessHandler returns the handler that runs the liveness checks of h and
+// writes their report as a JSON document. The response status is 200 if all
+// the checks succeed, 503 otherwise. The example servers generated for the
+// designs that use the HealthCheck DSL mount it on "GET /healthz".
+func LivenessHandler(h *goa.Health) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeHealthReport(w, h.Live(r.Context()))
+	}
+}
+
+// ReadinessHandler returns the handler that runs the readiness checks of h and
+// writes their report as a JSON document. The response status is 200 if all
+// the checks succeed, 503 otherwise. The example servers generated for the
+// designs that use the HealthCheck DSL mount it on "GET /readyz".
+func ReadinessHandler(h *goa.Health) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeHealthReport(w, h.Ready(r.Context()))
+	}
+}
+
+// writeHealthReport writes the given health report to w.
+func writeHealthReport(w http.ResponseWriter, r *goa.HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if r.OK() {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(r)
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestHealthHandlers(t *testing.T) {
+	h := goa.NewHealth()
+	h.AddLivenessCheck("loop", func(context.Context) error { return nil })
+	h.AddReadinessCheck("db", func(context.Context) error { return errors.New("down") })
+	cases := []struct {
+		Name           string
+		Handler        http.HandlerFunc
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{"liveness", LivenessHandler(h), http.StatusOK, `{"status":"ok","checks":{"loop":"ok"}}` + "\n"},
+		{"readiness", ReadinessHandler(h), http.StatusServiceUnavailable, `{"status":"unavailable","checks":{"db":"down"}}` + "\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c.Handler(w, httptest.NewRequest("GET", "/", nil))
+			if w.Code != c.ExpectedStatus {
+				t.Errorf("got status %d, expected %d", w.Code, c.ExpectedStatus)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("got content type %q, expected %q", ct, "application/json")
+			}
+			if body := w.Body.String(); body != c.ExpectedBody {
+				t.Errorf("got body %q, expected %q", body, c.ExpectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"syscall/js"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// JSEndpoint is the Go implementation of a JavaScript function created with
+// JSObject. payload is the JSON representation of the first argument of the
+// function, nil if the function is called without argument. The result is
+// returned to JavaScript as the value of its JSON representation.
+type JSEndpoint func(ctx context.Context, payload []byte) (interface{}, error)
+
+// JSObject returns a JavaScript object with a method per given endpoint. The
+// methods return a Promise resolved with the endpoint result or rejected with
+// an Error whose message is the endpoint error message. The name of the error
+// is the name of the service error if the endpoint returns a goa.ServiceError.
+// The JavaScript interop files generated for the services that define the
+// "wasm:interop" metadata use JSObject to expose the client methods.
+func JSObject(endpoints map[string]JSEndpoint) js.Value {
+	obj := js.Global().Get("Object").New()
+	for name, e := range endpoints {
+		obj.Set(name, jsFunc(e))
+	}
+	return obj
+}
+
+// jsFunc returns the JavaScript function that calls e asynchronously. The
+// function must not block as the HTTP requests sent by the endpoint are only
+// processed once it returns.
+func jsFunc(e JSEndpoint) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		var payload []byte
+		if len(args) > 0 && args[0].Type() != js.TypeUndefined {
+			payload = []byte(js.Global().Get("JSON").Call("stringify", args[0]).String())
+		}
+		executor := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			resolve, reject := args[0], args[1]
+			go func() {
+				res, err := e(context.Background(), payload)
+				if err != nil {
+					reject.Invoke(jsError(err))
+					return
+				}
+				if res == nil {
+					resolve.Invoke(js.Undefined())
+					return
+				}
+				b, err := json.Marshal(res)
+				if err != nil {
+					reject.Invoke(jsError(err))
+					return
+				}
+				resolve.Invoke(js.Global().Get("JSON").Call("parse", string(b)))
+			}()
+			return nil
+		})
+		// The Promise constructor calls the executor synchronously.
+		defer executor.Release()
+		return js.Global().Get("Promise").New(executor)
+	})
+}
+
+// jsError returns the JavaScript Error corresponding to err.
+func jsError(err error) js.Value {
+	jerr := js.Global().Get("Error").New(err.Error())
+	if serr, ok := err.(*goa.ServiceError); ok {
+		jerr.Set("name", serr.Name)
+	}
+	return jerr
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Compressor creates the writer that compresses the data written to it
+	// and writes the result to w.
+	Compressor func(w io.Writer) (io.WriteCloser, error)
+
+	// compressWriter is a http.ResponseWriter which compresses the response
+	// body once the handler writes the response header.
+	compressWriter struct {
+		http.ResponseWriter
+		encoding   string
+		compressor Compressor
+		cw         io.WriteCloser
+		started    bool
+		compress   bool
+	}
+
+	// flusher is implemented by the compressed writers that can write the
+	// data buffered so far.
+	flusher interface {
+		Flush() error
+	}
+)
+
+var (
+	compressorsMu sync.RWMutex
+	compressors   = map[string]Compressor{
+		"gzip": func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+		"deflate": func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.DefaultCompression)
+		},
+	}
+)
+
+// RegisterCompressor registers the compressor used by the Compress middleware
+// for the given content coding, e.g. "br" or "zstd". The "gzip" and "deflate"
+// codings are registered by default, registering them again replaces the
+// default compressors.
+func RegisterCompressor(encoding string, c Compressor) {
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+	compressors[encoding] = c
+}
+
+// Compress returns a middleware that compresses the response bodies using the
+// given content coding preferred by the client as listed in the request
+// Accept-Encoding header, the order of the given codings breaks ties. Codings
+// that have no registered compressor are ignored. The middleware sets the Content-Encoding response header and
+// adds Accept-Encoding to the Vary response header. It leaves untouched the
+// responses that set the Content-Encoding header, the responses that cannot
+// have a body and the responses to protocol upgrade requests (e.g.
+// websockets).
+//
+// The generated HTTP servers expose a UseCompression method that applies this
+// middleware to the handlers of the endpoints whose design enables
+// compression.
+func Compress(encodings ...string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Upgrade") != "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Accept-Encoding")
+			enc, c := negotiateEncoding(r.Header.Get("Accept-Encoding"), encodings)
+			if c == nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{ResponseWriter: w, encoding: enc, compressor: c}
+			defer cw.Close()
+			h.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// WriteHeader sets the Content-Encoding header of the responses that are
+// compressed before writing the header.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.started {
+		return
+	}
+	if code >= 100 && code <= 199 {
+		// Informational responses precede the final response.
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.started = true
+	h := w.Header()
+	w.compress = h.Get("Content-Encoding") == "" && bodyAllowed(code)
+	if w.compress {
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write compresses b unless the response is not compressed.
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.started {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.compress {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.cw == nil {
+		cw, err := w.compressor(w.ResponseWriter)
+		if err != nil {
+			return 0, err
+		}
+		w.cw = cw
+	}
+	return w.cw.Write(b)
+}
+
+// Flush writes the data compressed so far and flushes the underlying response
+// writer. It supports the http.Flusher interface so that streamed responses
+// are sent as they are written.
+func (w *compressWriter) Flush() {
+	if w.cw != nil {
+		if f, ok := w.cw.(flusher); ok {
+			f.Flush()
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports the http.Hijacker interface.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+}
+
+// Close writes the remaining compressed data.
+func (w *compressWriter) Close() error {
+	if w.cw == nil {
+		return nil
+	}
+	return w.cw.Close()
+}
+
+// negotiateEncoding returns the encoding preferred by the given Accept-Encoding
+// header value among the given encodings and its compressor, nil if the header
+// accepts none of the encodings.
+func negotiateEncoding(accept string, encodings []string) (string, Compressor) {
+	if accept == "" {
+		return "", nil
+	}
+	qs := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		qs[name] = q
+	}
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, enc := range encodings {
+		q, ok := qs[enc]
+		if !ok {
+			q, ok = qs["*"]
+		}
+		if !ok || q <= bestQ {
+			continue
+		}
+		if _, ok := compressors[enc]; !ok {
+			continue
+		}
+		best, bestQ = enc, q
+	}
+	if best == "" {
+		return "", nil
+	}
+	return best, compressors[best]
+}
+
+// bodyAllowed returns true if a response with the given status code may have a
+// body.
+func bodyAllowed(code int) bool {
+	return code != http.StatusNoContent && code != http.StatusNotModified
+}
@@ -0,0 +1,117 @@
+package middleware_test
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestCompress(t *testing.T) {
+	const body = "hello, hello, hello, hello"
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case "/encoded":
+			w.Header().Set("Content-Encoding", "identity")
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(body))
+	})
+	cases := []struct {
+		Name             string
+		Path             string
+		Encodings        []string
+		AcceptEncoding   string
+		ExpectedEncoding string
+		ExpectedStatus   int
+	}{
+		{"gzip", "/", []string{"gzip", "deflate"}, "gzip, deflate", "gzip", http.StatusOK},
+		{"deflate", "/", []string{"gzip", "deflate"}, "deflate", "deflate", http.StatusOK},
+		{"quality", "/", []string{"gzip", "deflate"}, "gzip;q=0.5, deflate", "deflate", http.StatusOK},
+		{"preference", "/", []string{"deflate", "gzip"}, "gzip, deflate", "deflate", http.StatusOK},
+		{"wildcard", "/", []string{"gzip"}, "*", "gzip", http.StatusOK},
+		{"refused", "/", []string{"gzip"}, "gzip;q=0, deflate", "", http.StatusOK},
+		{"unregistered", "/", []string{"zstd"}, "zstd", "", http.StatusOK},
+		{"no-accept-encoding", "/", []string{"gzip"}, "", "", http.StatusOK},
+		{"no-content", "/empty", []string{"gzip"}, "gzip", "", http.StatusNoContent},
+		{"already-encoded", "/encoded", []string{"gzip"}, "gzip", "identity", http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", c.Path, nil)
+			if c.AcceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", c.AcceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			httpm.Compress(c.Encodings...)(h).ServeHTTP(w, req)
+
+			if w.Code != c.ExpectedStatus {
+				t.Errorf("got status %d, expected %d", w.Code, c.ExpectedStatus)
+			}
+			if enc := w.Header().Get("Content-Encoding"); enc != c.ExpectedEncoding {
+				t.Errorf("got Content-Encoding %q, expected %q", enc, c.ExpectedEncoding)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+				t.Errorf("got Vary %q, expected %q", vary, "Accept-Encoding")
+			}
+			if c.ExpectedStatus == http.StatusNoContent {
+				return
+			}
+			var r io.Reader = w.Body
+			switch c.ExpectedEncoding {
+			case "gzip":
+				gr, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("invalid gzip body: %s", err)
+				}
+				r = gr
+			case "deflate":
+				r = flate.NewReader(w.Body)
+			}
+			b, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("failed to read body: %s", err)
+			}
+			if string(b) != body {
+				t.Errorf("got body %q, expected %q", string(b), body)
+			}
+		})
+	}
+}
+
+func TestRegisterCompressor(t *testing.T) {
+	httpm.RegisterCompressor("upper", func(w io.Writer) (io.WriteCloser, error) {
+		return upperWriter{w}, nil
+	})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "upper")
+	w := httptest.NewRecorder()
+	httpm.Compress("upper")(h).ServeHTTP(w, req)
+
+	if enc := w.Header().Get("Content-Encoding"); enc != "upper" {
+		t.Errorf("got Content-Encoding %q, expected %q", enc, "upper")
+	}
+	if b := w.Body.String(); b != "HELLO" {
+		t.Errorf("got body %q, expected %q", b, "HELLO")
+	}
+}
+
+type upperWriter struct{ io.Writer }
+
+func (w upperWriter) Write(b []byte) (int, error) {
+	return w.Writer.Write([]byte(strings.ToUpper(string(b))))
+}
+
+func (upperWriter) Close() error { return nil }
@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+type (
+	// IdempotencyStore is the interface implemented by the stores used by the
+	// Idempotency middleware to reserve the idempotency keys of the requests
+	// being processed and to record and look up their responses. The keys
+	// are namespaced by the middleware with the request method and path.
+	// Implementations must be safe for concurrent use.
+	IdempotencyStore interface {
+		// Reserve atomically reserves key for the request identified by
+		// fingerprint. It returns nil if key was not reserved yet and
+		// the entry recorded for key otherwise.
+		Reserve(ctx context.Context, key, fingerprint string) (*IdempotencyEntry, error)
+		// Release removes the reservation of key so that the request
+		// may be processed again.
+		Release(ctx context.Context, key string) error
+		// Store records the response written for the reserved key.
+		Store(ctx context.Context, key string, resp *IdempotentResponse) error
+	}
+
+	// IdempotencyEntry is the entry recorded by an IdempotencyStore for a
+	// reserved key.
+	IdempotencyEntry struct {
+		// Fingerprint identifies the payload of the request that
+		// reserved the key.
+		Fingerprint string
+		// Response is the recorded response, nil while the request that
+		// reserved the key is being processed.
+		Response *IdempotentResponse
+	}
+
+	// IdempotentResponse is the response recorded by the Idempotency
+	// middleware.
+	IdempotentResponse struct {
+		// StatusCode is the response status code.
+		StatusCode int
+		// Header contains the response headers.
+		Header http.Header
+		// Body is the response body.
+		Body []byte
+	}
+
+	// IdempotencyOption customizes the Idempotency middleware.
+	IdempotencyOption func(*idempotencyOptions)
+
+	// idempotencyOptions holds the Idempotency middleware options.
+	idempotencyOptions struct {
+		maxBodySize int64
+	}
+
+	// idempotencyCapture is a http.ResponseWriter which records the response
+	// written by the wrapped handler.
+	idempotencyCapture struct {
+		http.ResponseWriter
+		statusCode int
+		body       bytes.Buffer
+	}
+)
+
+// IdempotencyKeyHeader is the name of the HTTP request header that carries the
+// idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultIdempotencyMaxBodySize is the default maximum size in bytes of the
+// bodies of the requests that set an idempotency key.
+const DefaultIdempotencyMaxBodySize = 1 << 20
+
+// errBodyTooLarge is returned by requestFingerprint when the request body
+// exceeds the maximum size.
+var errBodyTooLarge = errors.New("request body too large")
+
+// IdempotencyMaxBodySize sets the maximum size in bytes of the bodies of the
+// requests that set an idempotency key. The middleware reads the bodies in
+// memory to compute their fingerprint and responds with 413 Request Entity Too
+// Large to the requests whose body is larger. The default maximum size is
+// DefaultIdempotencyMaxBodySize.
+func IdempotencyMaxBodySize(n int64) IdempotencyOption {
+	return func(o *idempotencyOptions) {
+		o.maxBodySize = n
+	}
+}
+
+// Idempotency returns a middleware that replays the response recorded in store
+// for requests whose Idempotency-Key header matches a previous request made
+// with the same method on the same path. Requests that do not set the header
+// are passed through unchanged. The middleware responds with 409 Conflict
+// while a request using the same key is being processed and with 422
+// Unprocessable Entity when the key is reused with a different query string or
+// body. The middleware records the responses whose status code is not a
+// server error so that retries of failed requests are processed again. The
+// bodies of the requests that set the header are limited in size, see
+// IdempotencyMaxBodySize.
+//
+// The generated HTTP servers expose a UseIdempotency method that applies this
+// middleware to the handlers of the methods marked with the Idempotent DSL.
+func Idempotency(store IdempotencyStore, opts ...IdempotencyOption) func(http.Handler) http.Handler {
+	o := &idempotencyOptions{maxBodySize: DefaultIdempotencyMaxBodySize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			key = r.Method + " " + r.URL.Path + " " + key
+			fingerprint, err := requestFingerprint(r, o.maxBodySize)
+			if err == errBodyTooLarge {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			ctx := r.Context()
+			entry, err := store.Reserve(ctx, key, fingerprint)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if entry != nil {
+				switch {
+				case entry.Fingerprint != fingerprint:
+					http.Error(w, "idempotency key reused with a different request", http.StatusUnprocessableEntity)
+				case entry.Response == nil:
+					http.Error(w, "a request with the same idempotency key is being processed", http.StatusConflict)
+				default:
+					ReplayResponse(w, entry.Response)
+				}
+				return
+			}
+			stored := false
+			defer func() {
+				if !stored {
+					// Use a context that is not canceled so the
+					// key is released even if the client is gone.
+					_ = store.Release(context.Background(), key)
+				}
+			}()
+			c := &idempotencyCapture{ResponseWriter: w}
+			h.ServeHTTP(c, r)
+			if c.statusCode == 0 {
+				c.statusCode = http.StatusOK
+			}
+			if c.statusCode >= http.StatusInternalServerError {
+				return
+			}
+			// The response is already written, errors can only be
+			// ignored at this point.
+			stored = store.Store(ctx, key, &IdempotentResponse{
+				StatusCode: c.statusCode,
+				Header:     copyHeader(w.Header()),
+				Body:       c.body.Bytes(),
+			}) == nil
+		})
+	}
+}
+
+// ReplayResponse writes the recorded response to w.
+func ReplayResponse(w http.ResponseWriter, resp *IdempotentResponse) {
+	for k, vals := range resp.Header {
+		w.Header()[k] = append([]string(nil), vals...)
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(resp.Body)
+}
+
+// WriteHeader records the value of the status code before writing it.
+func (c *idempotencyCapture) WriteHeader(code int) {
+	if c.statusCode == 0 {
+		c.statusCode = code
+	}
+	c.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the written bytes before writing them.
+func (c *idempotencyCapture) Write(b []byte) (int, error) {
+	if c.statusCode == 0 {
+		c.statusCode = http.StatusOK
+	}
+	c.body.Write(b)
+	return c.ResponseWriter.Write(b)
+}
+
+// copyHeader returns a deep copy of h.
+func copyHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for k, vals := range h {
+		c[k] = append([]string(nil), vals...)
+	}
+	return c
+}
+
+// requestFingerprint returns the hex encoded SHA-256 hash of the query string
+// and body of r. It restores the body so that it may be read again and returns
+// errBodyTooLarge if the body is larger than max bytes.
+func requestFingerprint(r *http.Request, max int64) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(r.URL.RawQuery))
+	h.Write([]byte{0})
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, max+1))
+		r.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		if int64(len(body)) > max {
+			return "", errBodyTooLarge
+		}
+		h.Write(body)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,198 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+type memIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*httpm.IdempotencyEntry
+}
+
+func newMemIdempotencyStore() *memIdempotencyStore {
+	return &memIdempotencyStore{entries: make(map[string]*httpm.IdempotencyEntry)}
+}
+
+func (s *memIdempotencyStore) Reserve(_ context.Context, key, fingerprint string) (*httpm.IdempotencyEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		return &httpm.IdempotencyEntry{Fingerprint: e.Fingerprint, Response: e.Response}, nil
+	}
+	s.entries[key] = &httpm.IdempotencyEntry{Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (s *memIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *memIdempotencyStore) Store(_ context.Context, key string, resp *httpm.IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		e.Response = resp
+	}
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	var calls int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Call", "called")
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	})
+	cases := []struct {
+		Name           string
+		Path           string
+		Body           string
+		Key            string
+		ExpectedCalls  int
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		{"no-key", "/", "", "", 1, http.StatusCreated, "created"},
+		{"first", "/", "", "key", 2, http.StatusCreated, "created"},
+		{"replay", "/", "", "key", 2, http.StatusCreated, "created"},
+		{"other-key", "/", "", "other", 3, http.StatusCreated, "created"},
+		{"other-path", "/other", "", "key", 4, http.StatusCreated, "created"},
+		{"other-body", "/", "body", "key", 4, http.StatusUnprocessableEntity, "idempotency key reused with a different request\n"},
+		{"server-error", "/fail", "", "fail", 5, http.StatusServiceUnavailable, ""},
+		{"server-error-retry", "/fail", "", "fail", 6, http.StatusServiceUnavailable, ""},
+	}
+	m := httpm.Idempotency(newMemIdempotencyStore())(h)
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", c.Path, strings.NewReader(c.Body))
+			if c.Key != "" {
+				req.Header.Set(httpm.IdempotencyKeyHeader, c.Key)
+			}
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, req)
+			if calls != c.ExpectedCalls {
+				t.Errorf("got %d handler calls, expected %d", calls, c.ExpectedCalls)
+			}
+			if w.Code != c.ExpectedStatus {
+				t.Errorf("got status %d, expected %d", w.Code, c.ExpectedStatus)
+			}
+			if w.Body.String() != c.ExpectedBody {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.ExpectedBody)
+			}
+			if w.Code == http.StatusUnprocessableEntity {
+				return
+			}
+			if w.Header().Get("X-Call") != "called" {
+				t.Errorf("got header %q, expected %q", w.Header().Get("X-Call"), "called")
+			}
+		})
+	}
+}
+
+func TestIdempotencyMaxBodySize(t *testing.T) {
+	var calls int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	})
+	cases := []struct {
+		Name           string
+		Body           string
+		Key            string
+		ExpectedCalls  int
+		ExpectedStatus int
+	}{
+		{"within-limit", "body", "small", 1, http.StatusCreated},
+		{"too-large", "large body", "large", 1, http.StatusRequestEntityTooLarge},
+		{"no-key", "large body", "", 2, http.StatusCreated},
+	}
+	m := httpm.Idempotency(newMemIdempotencyStore(), httpm.IdempotencyMaxBodySize(4))(h)
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			if c.Key != "" {
+				req.Header.Set(httpm.IdempotencyKeyHeader, c.Key)
+			}
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, req)
+			if calls != c.ExpectedCalls {
+				t.Errorf("got %d handler calls, expected %d", calls, c.ExpectedCalls)
+			}
+			if w.Code != c.ExpectedStatus {
+				t.Errorf("got status %d, expected %d", w.Code, c.ExpectedStatus)
+			}
+		})
+	}
+}
+
+func TestIdempotencyConcurrent(t *testing.T) {
+	var (
+		calls   int32
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	})
+	m := httpm.Idempotency(newMemIdempotencyStore())(h)
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("body"))
+		req.Header.Set(httpm.IdempotencyKeyHeader, "key")
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, req)
+		return w
+	}
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- serve() }()
+	<-started
+
+	const n = 10
+	var wg sync.WaitGroup
+	codes := make([]int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i] = serve().Code
+		}(i)
+	}
+	wg.Wait()
+	for i, code := range codes {
+		if code != http.StatusConflict {
+			t.Errorf("concurrent request %d: got status %d, expected %d", i, code, http.StatusConflict)
+		}
+	}
+
+	close(release)
+	if w := <-first; w.Code != http.StatusCreated {
+		t.Errorf("first request: got status %d, expected %d", w.Code, http.StatusCreated)
+	}
+	if w := serve(); w.Code != http.StatusCreated || w.Body.String() != "created" {
+		t.Errorf("replay: got status %d and body %q, expected %d and %q", w.Code, w.Body.String(), http.StatusCreated, "created")
+	}
+	if c := atomic.LoadInt32(&calls); c != 1 {
+		t.Errorf("got %d handler calls, expected 1", c)
+	}
+}
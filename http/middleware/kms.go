@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+// KMS returns a middleware that stores kms in the request context so that the
+// generated decoders and encoders may decrypt and encrypt the body fields
+// marked with Encrypted in the design.
+//
+// example of use:
+//  handler = middleware.KMS(kms)(handler)
+func KMS(kms goahttp.KMS) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(goahttp.ContextWithKMS(r.Context(), kms)))
+		})
+	}
+}
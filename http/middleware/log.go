@@ -17,7 +17,8 @@ import (
 // originator of the request. The originator is computed by looking at the
 // X-Forwarded-For HTTP header or - absent of that - the originating IP. The
 // middleware also logs the response HTTP status code, body length (in bytes) and
-// timing information.
+// timing information together with the fields added to the request log context
+// (see middleware.AddLogFields).
 func Log(l middleware.Logger) func(h http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -31,13 +32,15 @@ func Log(l middleware.Logger) func(h http.Handler) http.Handler {
 				"req", r.Method+" "+r.URL.String(),
 				"from", from(r))
 
+			ctx := middleware.WithLogContext(r.Context())
 			rw := CaptureResponse(w)
-			h.ServeHTTP(rw, r)
+			h.ServeHTTP(rw, r.WithContext(ctx))
 
-			l.Log("id", reqID,
+			l.Log(append([]interface{}{"id", reqID,
 				"status", rw.StatusCode,
 				"bytes", rw.ContentLength,
-				"time", time.Since(started).String())
+				"time", time.Since(started).String()},
+				middleware.LogFields(ctx)...)...)
 		})
 	}
 }
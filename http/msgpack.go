@@ -0,0 +1,713 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// MessagePack value families, see
+// https://github.com/msgpack/msgpack/blob/master/spec.md#formats.
+const (
+	msgpackNil byte = iota
+	msgpackBool
+	msgpackUint
+	msgpackInt
+	msgpackFloat32
+	msgpackFloat64
+	msgpackStr
+	msgpackBin
+	msgpackArray
+	msgpackMap
+	msgpackExt
+)
+
+const (
+	// msgpackMaxDepth is the maximum nesting of the encoded and decoded
+	// values.
+	msgpackMaxDepth = 1000
+	// msgpackMaxPrealloc is the maximum number of array elements allocated
+	// upfront when decoding so that a length header alone cannot cause large
+	// allocations.
+	msgpackMaxPrealloc = 1024
+)
+
+type (
+	// msgpackEncoder encodes values using the MessagePack format.
+	msgpackEncoder struct {
+		w io.Writer
+	}
+
+	// msgpackDecoder decodes values using the MessagePack format.
+	msgpackDecoder struct {
+		r byteReader
+	}
+)
+
+// NewMsgPackEncoder returns an encoder that writes the MessagePack
+// representation of values to w. Struct fields are encoded as map entries
+// keyed by the names given in their "json" tags so that the encoding of the
+// generated types matches their JSON encoding. Integers use the most compact
+// representation and map entries are sorted by encoded key so that the output
+// is deterministic.
+func NewMsgPackEncoder(w io.Writer) Encoder {
+	return &msgpackEncoder{w}
+}
+
+// NewMsgPackDecoder returns a decoder that reads MessagePack values from r.
+// Map entries are decoded into struct fields using the names given in their
+// "json" tags. Arbitrary values are decoded as int64, uint64, float64, bool,
+// string, []byte, []interface{}, map[string]interface{} or
+// map[interface{}]interface{} when some keys are not strings. The data of
+// extension values is decoded as []byte.
+func NewMsgPackDecoder(r io.Reader) Decoder {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &msgpackDecoder{br}
+}
+
+// Encode writes the MessagePack encoding of v.
+func (e *msgpackEncoder) Encode(v interface{}) error {
+	var buf bytes.Buffer
+	if err := msgpackEncode(&buf, reflect.ValueOf(v), 0); err != nil {
+		return err
+	}
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+// Decode reads the next MessagePack value and stores it in the value pointed
+// to by v.
+func (d *msgpackDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("msgpack: cannot decode into non-pointer %T", v)
+	}
+	family, arg, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	return d.decode(rv.Elem(), family, arg, 0)
+}
+
+func msgpackEncode(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if depth > msgpackMaxDepth {
+		return errors.New("msgpack: maximum nesting depth exceeded")
+	}
+	if !v.IsValid() {
+		buf.WriteByte(0xc0)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		msgpackWriteInt(buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		msgpackWriteUint(buf, v.Uint())
+	case reflect.Float32:
+		var b [5]byte
+		b[0] = 0xca
+		binary.BigEndian.PutUint32(b[1:], math.Float32bits(float32(v.Float())))
+		buf.Write(b[:])
+	case reflect.Float64:
+		var b [9]byte
+		b[0] = 0xcb
+		binary.BigEndian.PutUint64(b[1:], math.Float64bits(v.Float()))
+		buf.Write(b[:])
+	case reflect.String:
+		s := v.String()
+		if err := msgpackWriteStrHead(buf, len(s)); err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case reflect.Slice:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := v.Bytes()
+			if err := msgpackWriteBinHead(buf, len(b)); err != nil {
+				return err
+			}
+			buf.Write(b)
+			return nil
+		}
+		return msgpackEncodeArray(buf, v, depth)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if err := msgpackWriteBinHead(buf, v.Len()); err != nil {
+				return err
+			}
+			for i := 0; i < v.Len(); i++ {
+				buf.WriteByte(byte(v.Index(i).Uint()))
+			}
+			return nil
+		}
+		return msgpackEncodeArray(buf, v, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		return msgpackEncodeMap(buf, v, depth)
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteByte(0xc0)
+			return nil
+		}
+		return msgpackEncode(buf, v.Elem(), depth+1)
+	case reflect.Struct:
+		return msgpackEncodeStruct(buf, v, depth)
+	default:
+		return fmt.Errorf("msgpack: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func msgpackEncodeArray(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if err := msgpackWriteContainerHead(buf, 0x90, 0xdc, v.Len()); err != nil {
+		return err
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := msgpackEncode(buf, v.Index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// msgpackEncodeMap writes the map entries sorted by the bytewise
+// lexicographic order of the encoded keys.
+func msgpackEncodeMap(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	type entry struct {
+		key []byte
+		val reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		var kb bytes.Buffer
+		if err := msgpackEncode(&kb, iter.Key(), depth+1); err != nil {
+			return err
+		}
+		entries = append(entries, entry{kb.Bytes(), iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+	if err := msgpackWriteContainerHead(buf, 0x80, 0xde, len(entries)); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		buf.Write(e.key)
+		if err := msgpackEncode(buf, e.val, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func msgpackEncodeStruct(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	fields := structFields(v.Type())
+	vals := make([]reflect.Value, 0, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		fv := v.FieldByIndex(f.index)
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		vals = append(vals, fv)
+		names = append(names, f.name)
+	}
+	if err := msgpackWriteContainerHead(buf, 0x80, 0xde, len(vals)); err != nil {
+		return err
+	}
+	for i, fv := range vals {
+		if err := msgpackWriteStrHead(buf, len(names[i])); err != nil {
+			return err
+		}
+		buf.WriteString(names[i])
+		if err := msgpackEncode(buf, fv, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// msgpackWriteUint writes u using the shortest possible form.
+func msgpackWriteUint(buf *bytes.Buffer, u uint64) {
+	switch {
+	case u <= 0x7f:
+		buf.WriteByte(byte(u))
+	case u <= math.MaxUint8:
+		buf.Write([]byte{0xcc, byte(u)})
+	case u <= math.MaxUint16:
+		msgpackWriteSized(buf, 0xcd, 2, u)
+	case u <= math.MaxUint32:
+		msgpackWriteSized(buf, 0xce, 4, u)
+	default:
+		msgpackWriteSized(buf, 0xcf, 8, u)
+	}
+}
+
+// msgpackWriteInt writes i using the shortest possible form. Positive values
+// are written as unsigned integers.
+func msgpackWriteInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0:
+		msgpackWriteUint(buf, uint64(i))
+	case i >= -32:
+		buf.WriteByte(byte(i))
+	case i >= math.MinInt8:
+		buf.Write([]byte{0xd0, byte(i)})
+	case i >= math.MinInt16:
+		msgpackWriteSized(buf, 0xd1, 2, uint64(i))
+	case i >= math.MinInt32:
+		msgpackWriteSized(buf, 0xd2, 4, uint64(i))
+	default:
+		msgpackWriteSized(buf, 0xd3, 8, uint64(i))
+	}
+}
+
+func msgpackWriteStrHead(buf *bytes.Buffer, n int) error {
+	switch {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.Write([]byte{0xd9, byte(n)})
+	case n <= math.MaxUint16:
+		msgpackWriteSized(buf, 0xda, 2, uint64(n))
+	case uint64(n) <= math.MaxUint32:
+		msgpackWriteSized(buf, 0xdb, 4, uint64(n))
+	default:
+		return errors.New("msgpack: string too long")
+	}
+	return nil
+}
+
+func msgpackWriteBinHead(buf *bytes.Buffer, n int) error {
+	switch {
+	case n <= math.MaxUint8:
+		buf.Write([]byte{0xc4, byte(n)})
+	case n <= math.MaxUint16:
+		msgpackWriteSized(buf, 0xc5, 2, uint64(n))
+	case uint64(n) <= math.MaxUint32:
+		msgpackWriteSized(buf, 0xc6, 4, uint64(n))
+	default:
+		return errors.New("msgpack: binary too long")
+	}
+	return nil
+}
+
+// msgpackWriteContainerHead writes the head of an array or map of n entries
+// given the code of the fix form and of the 16-bit form, the 32-bit form code
+// follows the 16-bit one.
+func msgpackWriteContainerHead(buf *bytes.Buffer, fix, code16 byte, n int) error {
+	switch {
+	case n < 16:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		msgpackWriteSized(buf, code16, 2, uint64(n))
+	case uint64(n) <= math.MaxUint32:
+		msgpackWriteSized(buf, code16+1, 4, uint64(n))
+	default:
+		return errors.New("msgpack: too many entries")
+	}
+	return nil
+}
+
+// msgpackWriteSized writes the code followed by the size low order bytes of
+// arg in big endian order.
+func msgpackWriteSized(buf *bytes.Buffer, code byte, size int, arg uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], arg)
+	buf.WriteByte(code)
+	buf.Write(b[8-size:])
+}
+
+// readHead reads the code and argument of the next value and returns the
+// value family. The argument holds the value of booleans and integers, the
+// bits of floats and the length of strings, binaries, arrays, maps and
+// extensions. Negative integers are returned as the two's complement of their
+// value.
+func (d *msgpackDecoder) readHead() (family byte, arg uint64, err error) {
+	c, err := d.r.ReadByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case c <= 0x7f:
+		return msgpackUint, uint64(c), nil
+	case c <= 0x8f:
+		return msgpackMap, uint64(c & 0x0f), nil
+	case c <= 0x9f:
+		return msgpackArray, uint64(c & 0x0f), nil
+	case c <= 0xbf:
+		return msgpackStr, uint64(c & 0x1f), nil
+	case c >= 0xe0:
+		return msgpackInt, uint64(int64(int8(c))), nil
+	}
+	switch c {
+	case 0xc0:
+		return msgpackNil, 0, nil
+	case 0xc2, 0xc3:
+		return msgpackBool, uint64(c - 0xc2), nil
+	case 0xc4, 0xc5, 0xc6:
+		arg, err = d.readUint(1 << (c - 0xc4))
+		return msgpackBin, arg, err
+	case 0xc7, 0xc8, 0xc9:
+		arg, err = d.readUint(1 << (c - 0xc7))
+		return msgpackExt, arg, err
+	case 0xca:
+		arg, err = d.readUint(4)
+		return msgpackFloat32, arg, err
+	case 0xcb:
+		arg, err = d.readUint(8)
+		return msgpackFloat64, arg, err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		arg, err = d.readUint(1 << (c - 0xcc))
+		return msgpackUint, arg, err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		arg, err = d.readUint(size)
+		if err != nil {
+			return 0, 0, err
+		}
+		// sign extend
+		shift := uint(64 - 8*size)
+		return msgpackInt, uint64(int64(arg<<shift) >> shift), nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return msgpackExt, 1 << (c - 0xd4), nil
+	case 0xd9, 0xda, 0xdb:
+		arg, err = d.readUint(1 << (c - 0xd9))
+		return msgpackStr, arg, err
+	case 0xdc, 0xdd:
+		arg, err = d.readUint(2 << (c - 0xdc))
+		return msgpackArray, arg, err
+	case 0xde, 0xdf:
+		arg, err = d.readUint(2 << (c - 0xde))
+		return msgpackMap, arg, err
+	}
+	return 0, 0, fmt.Errorf("msgpack: invalid code 0x%x", c)
+}
+
+// readUint reads a big endian unsigned integer of the given size in bytes.
+func (d *msgpackDecoder) readUint(size int) (uint64, error) {
+	var p [8]byte
+	if _, err := io.ReadFull(d.r, p[8-size:]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return binary.BigEndian.Uint64(p[:]), nil
+}
+
+// decode decodes the value whose head has already been read into v.
+func (d *msgpackDecoder) decode(v reflect.Value, family byte, arg uint64, depth int) error {
+	if depth > msgpackMaxDepth {
+		return errors.New("msgpack: maximum nesting depth exceeded")
+	}
+	if family == msgpackNil {
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decode(v.Elem(), family, arg, depth+1)
+	case reflect.Interface:
+		if v.NumMethod() > 0 {
+			return fmt.Errorf("msgpack: cannot decode into non-empty interface %s", v.Type())
+		}
+		val, err := d.decodeAny(family, arg, depth)
+		if err != nil {
+			return err
+		}
+		if val == nil {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(val))
+		}
+		return nil
+	}
+	switch family {
+	case msgpackBool:
+		if v.Kind() != reflect.Bool {
+			return msgpackTypeError("boolean", v)
+		}
+		v.SetBool(arg == 1)
+		return nil
+	case msgpackUint, msgpackInt:
+		return msgpackSetInt(v, family == msgpackInt && int64(arg) < 0, arg)
+	case msgpackFloat32, msgpackFloat64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := msgpackFloat(family, arg)
+			if v.OverflowFloat(f) {
+				return fmt.Errorf("msgpack: value %v overflows %s", f, v.Type())
+			}
+			v.SetFloat(f)
+			return nil
+		}
+		return msgpackTypeError("float", v)
+	case msgpackStr, msgpackBin, msgpackExt:
+		if family == msgpackExt {
+			// Skip the extension type.
+			if _, err := d.r.ReadByte(); err != nil {
+				return unexpectedEOF(err)
+			}
+		}
+		b, err := d.readBytes(arg)
+		if err != nil {
+			return err
+		}
+		switch {
+		case v.Kind() == reflect.String && family != msgpackExt:
+			v.SetString(string(b))
+		case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+			v.SetBytes(b)
+		case v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8:
+			reflect.Copy(v, reflect.ValueOf(b))
+		default:
+			return msgpackTypeError("string", v)
+		}
+		return nil
+	case msgpackArray:
+		return d.decodeArray(v, arg, depth)
+	default:
+		switch v.Kind() {
+		case reflect.Map:
+			return d.decodeMap(v, arg, depth)
+		case reflect.Struct:
+			return d.decodeStruct(v, arg, depth)
+		}
+		if _, err := d.decodeAny(msgpackMap, arg, depth); err != nil {
+			return err
+		}
+		return msgpackTypeError("map", v)
+	}
+}
+
+func (d *msgpackDecoder) decodeArray(v reflect.Value, n uint64, depth int) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		c := msgpackMaxPrealloc
+		if n < uint64(c) {
+			c = int(n)
+		}
+		s := reflect.MakeSlice(v.Type(), 0, c)
+		for i := uint64(0); i < n; i++ {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decodeNext(elem, depth+1); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		for i := uint64(0); i < n; i++ {
+			if i >= uint64(v.Len()) {
+				if err := d.skipNext(depth + 1); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.decodeNext(v.Index(int(i)), depth+1); err != nil {
+				return err
+			}
+		}
+		for i := int(n); i < v.Len(); i++ {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		}
+		return nil
+	}
+	if _, err := d.decodeAny(msgpackArray, n, depth); err != nil {
+		return err
+	}
+	return msgpackTypeError("array", v)
+}
+
+func (d *msgpackDecoder) decodeMap(v reflect.Value, n uint64, depth int) error {
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	t := v.Type()
+	for i := uint64(0); i < n; i++ {
+		k := reflect.New(t.Key()).Elem()
+		if err := d.decodeNext(k, depth+1); err != nil {
+			return err
+		}
+		if k.Kind() == reflect.Interface && !k.IsNil() && !k.Elem().Type().Comparable() {
+			return fmt.Errorf("msgpack: invalid map key of type %s", k.Elem().Type())
+		}
+		val := reflect.New(t.Elem()).Elem()
+		if err := d.decodeNext(val, depth+1); err != nil {
+			return err
+		}
+		v.SetMapIndex(k, val)
+	}
+	return nil
+}
+
+func (d *msgpackDecoder) decodeStruct(v reflect.Value, n uint64, depth int) error {
+	fields := structFields(v.Type())
+	for i := uint64(0); i < n; i++ {
+		var name string
+		if err := d.decodeNext(reflect.ValueOf(&name).Elem(), depth+1); err != nil {
+			return err
+		}
+		field := findStructField(fields, name)
+		if field == nil {
+			if err := d.skipNext(depth + 1); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.decodeNext(v.FieldByIndex(field.index), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeNext reads the next value and decodes it into v.
+func (d *msgpackDecoder) decodeNext(v reflect.Value, depth int) error {
+	family, arg, err := d.readHead()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	return d.decode(v, family, arg, depth)
+}
+
+// skipNext reads and discards the next value.
+func (d *msgpackDecoder) skipNext(depth int) error {
+	family, arg, err := d.readHead()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	_, err = d.decodeAny(family, arg, depth)
+	return err
+}
+
+// decodeAny decodes the value whose head has already been read into a value
+// whose type is inferred from the MessagePack family.
+func (d *msgpackDecoder) decodeAny(family byte, arg uint64, depth int) (interface{}, error) {
+	if depth > msgpackMaxDepth {
+		return nil, errors.New("msgpack: maximum nesting depth exceeded")
+	}
+	switch family {
+	case msgpackNil:
+		return nil, nil
+	case msgpackBool:
+		return arg == 1, nil
+	case msgpackUint:
+		if arg > math.MaxInt64 {
+			return arg, nil
+		}
+		return int64(arg), nil
+	case msgpackInt:
+		return int64(arg), nil
+	case msgpackFloat32, msgpackFloat64:
+		return msgpackFloat(family, arg), nil
+	case msgpackStr:
+		b, err := d.readBytes(arg)
+		return string(b), err
+	case msgpackBin:
+		return d.readBytes(arg)
+	case msgpackExt:
+		if _, err := d.r.ReadByte(); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return d.readBytes(arg)
+	case msgpackArray:
+		var s []interface{}
+		return s, d.decodeArray(reflect.ValueOf(&s).Elem(), arg, depth)
+	default:
+		var m map[interface{}]interface{}
+		if err := d.decodeMap(reflect.ValueOf(&m).Elem(), arg, depth); err != nil {
+			return nil, err
+		}
+		sm := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			s, ok := k.(string)
+			if !ok {
+				return m, nil
+			}
+			sm[s] = v
+		}
+		return sm, nil
+	}
+}
+
+// readBytes reads the n bytes of the string, binary or extension data whose
+// head has already been read.
+func (d *msgpackDecoder) readBytes(n uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, d.r, int64(n)); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func msgpackSetInt(v reflect.Value, neg bool, arg uint64) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := int64(arg)
+		if !neg && arg > math.MaxInt64 || v.OverflowInt(i) {
+			return msgpackOverflowError(neg, arg, v)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if neg || v.OverflowUint(arg) {
+			return msgpackOverflowError(neg, arg, v)
+		}
+		v.SetUint(arg)
+	case reflect.Float32, reflect.Float64:
+		if neg {
+			v.SetFloat(float64(int64(arg)))
+		} else {
+			v.SetFloat(float64(arg))
+		}
+	default:
+		return msgpackTypeError("integer", v)
+	}
+	return nil
+}
+
+// msgpackFloat returns the value of the single or double precision float
+// encoded in arg.
+func msgpackFloat(family byte, arg uint64) float64 {
+	if family == msgpackFloat32 {
+		return float64(math.Float32frombits(uint32(arg)))
+	}
+	return math.Float64frombits(arg)
+}
+
+func msgpackTypeError(typ string, v reflect.Value) error {
+	return fmt.Errorf("msgpack: cannot decode %s into value of type %s", typ, v.Type())
+}
+
+func msgpackOverflowError(neg bool, arg uint64, v reflect.Value) error {
+	if neg {
+		return fmt.Errorf("msgpack: value %d overflows %s", int64(arg), v.Type())
+	}
+	return fmt.Errorf("msgpack: value %d overflows %s", arg, v.Type())
+}
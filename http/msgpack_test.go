@@ -0,0 +1,178 @@
+package http
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMsgPackEncoder(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected string
+	}{
+		{"zero", 0, "00"},
+		{"positive-fixint", 127, "7f"},
+		{"uint8", 128, "cc80"},
+		{"uint16", 256, "cd0100"},
+		{"uint32", 65536, "ce00010000"},
+		{"uint64", uint64(18446744073709551615), "cfffffffffffffffff"},
+		{"negative-fixint", -1, "ff"},
+		{"min-negative-fixint", -32, "e0"},
+		{"int8", -33, "d0df"},
+		{"int16", -129, "d1ff7f"},
+		{"int32", -32769, "d2ffff7fff"},
+		{"int64", int64(math.MinInt64), "d38000000000000000"},
+		{"float64", 1.5, "cb3ff8000000000000"},
+		{"float32", float32(1.5), "ca3fc00000"},
+		{"false", false, "c2"},
+		{"true", true, "c3"},
+		{"nil", nil, "c0"},
+		{"nil-pointer", (*string)(nil), "c0"},
+		{"bytes", []byte{1, 2}, "c4020102"},
+		{"fixstr", "abc", "a3616263"},
+		{"str8", "abcdefghijklmnopqrstuvwxyz012345", "d920 6162636465666768696a6b6c6d6e6f707172737475767778797a303132333435"},
+		{"array", []int{1, 2, 3}, "93010203"},
+		{"map", map[string]interface{}{"b": []int{2, 3}, "a": 1}, "82a16101a162920203"},
+		{"map-sorted-keys", map[int]string{10: "a", -1: "b", 100: "c"}, "830aa16164a163ffa162"},
+		{"struct", struct {
+			A int    `json:"a"`
+			B string `json:"b,omitempty"`
+			C string `json:"-"`
+			d int
+		}{A: 1, C: "c", d: 2}, "81a16101"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewMsgPackEncoder(&buf).Encode(c.Value); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			expected := string(bytes.Replace([]byte(c.Expected), []byte(" "), nil, -1))
+			if actual := hex.EncodeToString(buf.Bytes()); actual != expected {
+				t.Errorf("got %s, expected %s", actual, expected)
+			}
+		})
+	}
+}
+
+func TestMsgPackDecoder(t *testing.T) {
+	type (
+		inner struct {
+			Name *string `json:"name"`
+		}
+		outer struct {
+			ID     int              `json:"id"`
+			Tags   []string         `json:"tags"`
+			Inner  *inner           `json:"inner"`
+			Attrs  map[string]int64 `json:"attrs"`
+			Ignore string           `json:"-"`
+		}
+	)
+	name := "n"
+	cases := []struct {
+		Name     string
+		MsgPack  string
+		Target   interface{}
+		Expected interface{}
+	}{
+		{"uint", "cd03e8", new(uint16), uint16(1000)},
+		{"negative-int", "d1fc18", new(int), -1000},
+		{"negative-fixint", "ff", new(int8), int8(-1)},
+		{"int-as-float", "cd03e8", new(float64), float64(1000)},
+		{"float32", "ca3fc00000", new(float32), float32(1.5)},
+		{"float64", "cb3ff8000000000000", new(float64), 1.5},
+		{"bool", "c3", new(bool), true},
+		{"null-pointer", "c0", &[]*string{&name}[0], (*string)(nil)},
+		{"bytes", "c4020102", new([]byte), []byte{1, 2}},
+		{"string-as-bytes", "a3616263", new([]byte), []byte("abc")},
+		{"str8", "d903616263", new(string), "abc"},
+		{"array16", "dc0003010203", new([]int), []int{1, 2, 3}},
+		{"fixed-array", "93010203", new([2]int), [2]int{1, 2}},
+		{"map", "82a16101a162920203", new(map[string]interface{}), map[string]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}},
+		{"non-string-keys", "810102", new(interface{}), map[interface{}]interface{}{int64(1): int64(2)}},
+		{"negative-any", "e0", new(interface{}), int64(-32)},
+		{"ext", "d4010a", new(interface{}), []byte{0x0a}},
+		{"struct", "85a2696401a47461677391a161a5696e6e657281a44e414d45a16ea5617474727381a178ffa7756e6b6e6f776e93010203", new(outer),
+			outer{ID: 1, Tags: []string{"a"}, Inner: &inner{Name: &name}, Attrs: map[string]int64{"x": -1}}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(c.MsgPack)
+			if err != nil {
+				t.Fatalf("invalid test data: %s", err)
+			}
+			if err := NewMsgPackDecoder(bytes.NewReader(b)).Decode(c.Target); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if actual := reflect.ValueOf(c.Target).Elem().Interface(); !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("got %#v, expected %#v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestMsgPackDecoderErrors(t *testing.T) {
+	cases := []struct {
+		Name    string
+		MsgPack string
+		Target  interface{}
+	}{
+		{"truncated", "cd03", new(int)},
+		{"truncated-string", "a361", new(string)},
+		{"truncated-array", "930102", new([]int)},
+		{"overflow", "cd0100", new(uint8)},
+		{"negative-uint", "ff", new(uint)},
+		{"type-mismatch", "a3616263", new(int)},
+		{"invalid-code", "c1", new(interface{})},
+		{"non-comparable-key", "81920102c3", new(interface{})},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(c.MsgPack)
+			if err != nil {
+				t.Fatalf("invalid test data: %s", err)
+			}
+			if err := NewMsgPackDecoder(bytes.NewReader(b)).Decode(c.Target); err == nil {
+				t.Errorf("got no error, expected one")
+			}
+		})
+	}
+}
+
+func TestMsgPackRoundTrip(t *testing.T) {
+	type body struct {
+		Name    string                 `json:"name"`
+		Age     *int                   `json:"age,omitempty"`
+		Rate    float64                `json:"rate"`
+		Data    []byte                 `json:"data"`
+		Nested  []*body                `json:"nested,omitempty"`
+		Any     interface{}            `json:"any"`
+		Labels  map[string]string      `json:"labels"`
+		Unknown map[string]interface{} `json:"unknown,omitempty"`
+	}
+	age := 42
+	v := &body{
+		Name:   "goa",
+		Age:    &age,
+		Rate:   -0.5,
+		Data:   []byte("data"),
+		Nested: []*body{{Name: "child", Any: "x", Labels: map[string]string{}}},
+		Any:    []interface{}{"a", true, nil},
+		Labels: map[string]string{"k": "v"},
+	}
+	var buf bytes.Buffer
+	if err := NewMsgPackEncoder(&buf).Encode(v); err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	var actual body
+	if err := NewMsgPackDecoder(&buf).Decode(&actual); err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	if !reflect.DeepEqual(&actual, v) {
+		t.Errorf("got %#v, expected %#v", actual, *v)
+	}
+}
@@ -1,10 +1,9 @@
 package http
 
 import (
-	"context"
-	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/dimfeld/httptreemux"
 )
@@ -56,25 +55,59 @@ type (
 	// "{*wildcard}" respectively.
 	mux struct {
 		*httptreemux.ContextMux
+		routes   *RouteTable
+		handlers map[string]*muxHandler
+	}
+
+	// muxHandler is the handler registered with httptreemux for a given
+	// method and pattern.
+	muxHandler struct {
+		handler http.HandlerFunc
+		// weak is true if the handler was registered by the muxer for
+		// the alternate trailing slash form of the pattern of a route in
+		// which case registering a route for the pattern replaces it.
+		weak bool
 	}
 )
 
 // NewMuxer returns a Muxer implementation based on the httptreemux router.
-func NewMuxer() Muxer {
+// The muxer responds with 404 Not Found to the requests whose path does not
+// match any route and with 405 Method Not Allowed to the requests whose path
+// matches routes registered for other methods. The Allow header of the 405
+// responses lists the methods of these routes. The responses are written with
+// the optional encoder, EncodeMuxError by default. The requests whose path
+// differs from the path of a route only by a trailing slash are redirected to
+// the route path unless a route is registered for their path or the generated
+// code applies another trailing slash policy, see the TrailingSlash DSL.
+func NewMuxer(encoder ...MuxErrorEncoder) Muxer {
+	var enc MuxErrorEncoder
+	if len(encoder) > 0 {
+		enc = encoder[0]
+	}
+	routes := NewRouteTable(enc)
 	r := httptreemux.NewContextMux()
 	r.EscapeAddedRoutes = true
-	r.NotFoundHandler = func(w http.ResponseWriter, req *http.Request) {
-		ctx := context.WithValue(req.Context(), AcceptTypeKey, req.Header.Get("Accept"))
-		enc := ResponseEncoder(ctx, w)
-		w.WriteHeader(http.StatusNotFound)
-		enc.Encode(NewErrorResponse(fmt.Errorf("404 page not found")))
+	r.RedirectTrailingSlash = false
+	r.NotFoundHandler = routes.ServeHTTP
+	r.MethodNotAllowedHandler = func(w http.ResponseWriter, req *http.Request, _ map[string]httptreemux.HandlerFunc) {
+		routes.ServeHTTP(w, req)
 	}
-	return &mux{r}
+	return &mux{r, routes, make(map[string]*muxHandler)}
 }
 
 // Handle maps the wildcard format used by goa to the one used by httptreemux.
+// Handle also registers a handler that redirects the requests made to the
+// alternate trailing slash form of the pattern, see RedirectTrailingSlash.
 func (m *mux) Handle(method, pattern string, handler http.HandlerFunc) {
-	m.ContextMux.Handle(method, treemuxify(pattern), handler)
+	m.routes.Add(method, pattern)
+	if h, ok := m.handlers[method+" "+pattern]; ok && h.weak {
+		h.handler, h.weak = handler, false
+	} else {
+		m.handle(method, pattern, &muxHandler{handler: handler})
+	}
+	if alt := slashPattern(pattern); alt != "" {
+		m.handleWeak(method, alt, RedirectTrailingSlash)
+	}
 }
 
 // Vars extracts the path variables from the request context.
@@ -82,8 +115,44 @@ func (m *mux) Vars(r *http.Request) map[string]string {
 	return httptreemux.ContextParams(r.Context())
 }
 
+// handle registers h with httptreemux.
+func (m *mux) handle(method, pattern string, h *muxHandler) {
+	m.handlers[method+" "+pattern] = h
+	m.ContextMux.Handle(method, treemuxify(pattern), func(w http.ResponseWriter, r *http.Request) {
+		h.handler(w, r)
+	})
+}
+
+// handleWeak registers handler for the given method and pattern unless a route
+// is registered for them. The routes registered later for the same method and
+// pattern replace the handler.
+func (m *mux) handleWeak(method, pattern string, handler http.HandlerFunc) {
+	h, ok := m.handlers[method+" "+pattern]
+	if !ok {
+		m.handle(method, pattern, &muxHandler{handler: handler, weak: true})
+		return
+	}
+	if h.weak {
+		h.handler = handler
+	}
+}
+
+// slashPattern returns the pattern with the trailing slash removed if the
+// pattern ends with a slash, added otherwise. slashPattern returns an empty
+// string if pattern is "/" or ends with a catch-all wildcard.
+func slashPattern(pattern string) string {
+	if pattern == "/" || pattern == "" || wildPathEnd.MatchString(pattern) {
+		return ""
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return strings.TrimSuffix(pattern, "/")
+	}
+	return pattern + "/"
+}
+
 var wildSeg = regexp.MustCompile(`/{([a-zA-Z0-9_]+)}`)
 var wildPath = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
+var wildPathEnd = regexp.MustCompile(`/{\*[a-zA-Z0-9_]+}$`)
 
 func treemuxify(pattern string) string {
 	pattern = wildSeg.ReplaceAllString(pattern, "/:$1")
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// NDJSONContentType is the mime type of the HTTP response bodies that consist
+// of newline delimited JSON values.
+const NDJSONContentType = "application/x-ndjson"
+
+type (
+	// NDJSONWriter writes values to a HTTP response as newline delimited
+	// JSON. The response status code and headers are written with the first
+	// value and the response is flushed after each value so that clients
+	// receive the values as soon as they are written.
+	NDJSONWriter struct {
+		w       http.ResponseWriter
+		status  int
+		enc     *json.Encoder
+		started bool
+	}
+
+	// NDJSONReader reads newline delimited JSON values from a HTTP response
+	// body.
+	NDJSONReader struct {
+		body io.ReadCloser
+		dec  *json.Decoder
+	}
+)
+
+// NewNDJSONWriter returns a writer that streams values to w using the given
+// response status code.
+func NewNDJSONWriter(w http.ResponseWriter, status int) *NDJSONWriter {
+	return &NDJSONWriter{w: w, status: status, enc: json.NewEncoder(w)}
+}
+
+// Header returns the response headers. Changes made to the headers after the
+// first value is written have no effect.
+func (s *NDJSONWriter) Header() http.Header {
+	return s.w.Header()
+}
+
+// Started returns true if the response status code and headers have been
+// written.
+func (s *NDJSONWriter) Started() bool {
+	return s.started
+}
+
+// Write writes the JSON encoding of v followed by a newline to the response
+// and flushes it.
+func (s *NDJSONWriter) Write(v interface{}) error {
+	s.start()
+	if err := s.enc.Encode(v); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Close writes the response status code and headers if no value was written.
+func (s *NDJSONWriter) Close() error {
+	s.start()
+	return nil
+}
+
+// start writes the response status code and headers once.
+func (s *NDJSONWriter) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.w.Header().Set("Content-Type", NDJSONContentType)
+	s.w.WriteHeader(s.status)
+}
+
+// NewNDJSONReader returns a reader that decodes the newline delimited JSON
+// values read from body.
+func NewNDJSONReader(body io.ReadCloser) *NDJSONReader {
+	return &NDJSONReader{body: body, dec: json.NewDecoder(body)}
+}
+
+// Read decodes the next value into v. It closes the body and returns io.EOF
+// once all the values have been read.
+func (r *NDJSONReader) Read(v interface{}) error {
+	if err := r.dec.Decode(v); err != nil {
+		r.body.Close()
+		return err
+	}
+	return nil
+}
+
+// Close closes the response body. Close must be called by clients that stop
+// reading values before the end of the stream.
+func (r *NDJSONReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	type msg struct {
+		Name string `json:"name"`
+	}
+	cases := []struct {
+		name     string
+		values   []interface{}
+		expected string
+	}{
+		{"empty", nil, ""},
+		{"one", []interface{}{&msg{"a"}}, "{\"name\":\"a\"}\n"},
+		{"many", []interface{}{&msg{"a"}, &msg{"b"}, 1}, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n1\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			w := NewNDJSONWriter(rw, http.StatusCreated)
+			for _, v := range c.values {
+				if err := w.Write(v); err != nil {
+					t.Fatalf("got error %q", err)
+				}
+				if !rw.Flushed {
+					t.Errorf("response not flushed")
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if !w.Started() {
+				t.Errorf("got not started, expected started")
+			}
+			if rw.Code != http.StatusCreated {
+				t.Errorf("got status %d, expected %d", rw.Code, http.StatusCreated)
+			}
+			if ct := rw.Header().Get("Content-Type"); ct != NDJSONContentType {
+				t.Errorf("got content type %q, expected %q", ct, NDJSONContentType)
+			}
+			if body := rw.Body.String(); body != c.expected {
+				t.Errorf("got body %q, expected %q", body, c.expected)
+			}
+		})
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		expected []string
+		err      bool
+	}{
+		{"empty", "", nil, false},
+		{"one", "\"a\"\n", []string{"a"}, false},
+		{"many", "\"a\"\n\"b\"\n\n\"c\"", []string{"a", "b", "c"}, false},
+		{"truncated", "\"a\"\n\"b", []string{"a"}, true},
+		{"invalid", "\"a\"\n1\n", []string{"a"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			body := &closeRecorder{Reader: strings.NewReader(c.body)}
+			r := NewNDJSONReader(body)
+			var actual []string
+			var err error
+			for {
+				var v string
+				if err = r.Read(&v); err != nil {
+					break
+				}
+				actual = append(actual, v)
+			}
+			if c.err && err == io.EOF {
+				t.Errorf("got io.EOF, expected a decoding error")
+			}
+			if !c.err && err != io.EOF {
+				t.Errorf("got error %q, expected io.EOF", err)
+			}
+			if strings.Join(actual, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("got values %v, expected %v", actual, c.expected)
+			}
+			if !body.closed {
+				t.Errorf("body not closed")
+			}
+		})
+	}
+}
+
+// closeRecorder is a io.ReadCloser that records whether Close was called.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
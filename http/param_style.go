@@ -0,0 +1,23 @@
+package http
+
+import "strings"
+
+// SplitValues splits the values of a query string parameter or of a header
+// serialized with a delimited style (e.g. "ids=1|2|3") using sep. The
+// generated servers use SplitValues to decode the parameters whose design
+// sets a Separator, a Style or disables Explode. Each value is split so that
+// clients sending repeated parameters are also supported. SplitValues returns
+// nil if values is nil.
+func SplitValues(values []string, sep string) []string {
+	if values == nil {
+		return nil
+	}
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		res = append(res, strings.Split(v, sep)...)
+	}
+	return res
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitValues(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Values   []string
+		Sep      string
+		Expected []string
+	}{
+		{"nil", nil, ",", nil},
+		{"empty", []string{""}, ",", []string{}},
+		{"comma", []string{"a,b,c"}, ",", []string{"a", "b", "c"}},
+		{"pipe", []string{"1|2"}, "|", []string{"1", "2"}},
+		{"space", []string{"1 2"}, " ", []string{"1", "2"}},
+		{"repeated", []string{"a,b", "c"}, ",", []string{"a", "b", "c"}},
+		{"single", []string{"a"}, "|", []string{"a"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			actual := SplitValues(c.Values, c.Sep)
+			if !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("got %#v, expected %#v", actual, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// passThroughHeaders holds the headers captured from the responses of the
+// upstream services called while handling a request.
+type passThroughHeaders struct {
+	mu sync.Mutex
+	h  http.Header
+}
+
+// ContextWithPassThroughHeaders returns a copy of ctx that captures the headers
+// of the upstream responses passed to PassThroughHeaders so that
+// WritePassThroughHeaders writes them to the response. The generated HTTP
+// handlers of the endpoints whose design uses the PassThroughHeaders DSL call
+// ContextWithPassThroughHeaders before calling the service method.
+func ContextWithPassThroughHeaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, passThroughHeadersKey, &passThroughHeaders{h: make(http.Header)})
+}
+
+// PassThroughHeaders captures the values of the headers of resp with the given
+// names in the context of the request that produced resp if the context was
+// initialized with ContextWithPassThroughHeaders. The values captured from a
+// response replace the values of the same headers captured from previous
+// responses. The generated response decoders of the endpoints whose design
+// uses the PassThroughHeaders DSL call PassThroughHeaders.
+func PassThroughHeaders(resp *http.Response, names ...string) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+	p, ok := resp.Request.Context().Value(passThroughHeadersKey).(*passThroughHeaders)
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, n := range names {
+		if vals, ok := resp.Header[http.CanonicalHeaderKey(n)]; ok {
+			p.h[http.CanonicalHeaderKey(n)] = append([]string(nil), vals...)
+		}
+	}
+}
+
+// WritePassThroughHeaders sets the headers captured in ctx by
+// PassThroughHeaders on the response written by w. It does nothing if ctx was
+// not initialized with ContextWithPassThroughHeaders. The generated response
+// encoders of the endpoints whose design uses the PassThroughHeaders DSL call
+// WritePassThroughHeaders before setting the headers defined in the design so
+// that the latter take precedence, so does ErrorEncoder.
+func WritePassThroughHeaders(ctx context.Context, w http.ResponseWriter) {
+	p, ok := ctx.Value(passThroughHeadersKey).(*passThroughHeaders)
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h := w.Header()
+	for n, vals := range p.h {
+		h[n] = append([]string(nil), vals...)
+	}
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPassThroughHeaders(t *testing.T) {
+	upstream := func(ctx context.Context, h http.Header) *http.Response {
+		req := httptest.NewRequest("GET", "/", nil).WithContext(ctx)
+		return &http.Response{Header: h, Request: req}
+	}
+	cases := []struct {
+		Name      string
+		Init      bool
+		Responses []http.Header
+		Expected  http.Header
+	}{
+		{"no-context", false, []http.Header{{"X-Request-Id": {"a"}}}, http.Header{}},
+		{"single", true, []http.Header{{"X-Request-Id": {"a"}, "X-Other": {"b"}}}, http.Header{"X-Request-Id": {"a"}}},
+		{"multiple-values", true, []http.Header{{"X-Ratelimit-Remaining": {"1", "2"}}}, http.Header{"X-Ratelimit-Remaining": {"1", "2"}}},
+		{"last-wins", true, []http.Header{{"X-Request-Id": {"a"}}, {"X-Request-Id": {"b"}}}, http.Header{"X-Request-Id": {"b"}}},
+		{"missing", true, []http.Header{{"X-Other": {"b"}}}, http.Header{}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.Init {
+				ctx = ContextWithPassThroughHeaders(ctx)
+			}
+			for _, h := range c.Responses {
+				PassThroughHeaders(upstream(ctx, h), "x-request-id", "X-RateLimit-Remaining")
+			}
+			w := httptest.NewRecorder()
+			WritePassThroughHeaders(ctx, w)
+			if !reflect.DeepEqual(w.Header(), c.Expected) {
+				t.Errorf("got headers %v, expected %v", w.Header(), c.Expected)
+			}
+		})
+	}
+	t.Run("error-encoder", func(t *testing.T) {
+		ctx := ContextWithPassThroughHeaders(context.Background())
+		PassThroughHeaders(upstream(ctx, http.Header{"X-Request-Id": {"a"}}), "X-Request-Id")
+		w := httptest.NewRecorder()
+		if err := ErrorEncoder(ResponseEncoder)(ctx, w, errors.New("boom")); err != nil {
+			t.Fatal(err)
+		}
+		if id := w.Header().Get("X-Request-Id"); id != "a" {
+			t.Errorf("got X-Request-Id %q, expected %q", id, "a")
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type (
+	// Phase identifies a step of the processing of a request by a generated
+	// HTTP handler.
+	Phase string
+
+	// PhaseObserver is notified of the phases of the requests processed by
+	// the generated HTTP handlers of the services whose design enables
+	// phase tracing with the TracePhases DSL. Observers typically start a
+	// tracing span or a timer in StartPhase.
+	PhaseObserver interface {
+		// StartPhase is called when the handler starts the given phase.
+		// The name of the service and of the method are stored in ctx
+		// under goa.ServiceKey and goa.MethodKey. StartPhase returns the
+		// context used while processing the phase, e.g. a context
+		// holding a child span, and a function called with the error
+		// returned by the phase if any when the phase ends.
+		StartPhase(ctx context.Context, phase Phase) (context.Context, func(error))
+	}
+
+	// PhaseDurationFunc is a PhaseObserver which calls the function with
+	// the duration of each phase, for example to record latency metrics.
+	PhaseDurationFunc func(ctx context.Context, phase Phase, d time.Duration, err error)
+)
+
+const (
+	// PhaseDecode is the phase that decodes the request into the method
+	// payload. It includes PhaseValidate.
+	PhaseDecode Phase = "decode"
+	// PhaseValidate is the phase that validates the request body.
+	PhaseValidate Phase = "validate"
+	// PhaseEndpoint is the phase that runs the service method.
+	PhaseEndpoint Phase = "endpoint"
+	// PhaseEncode is the phase that encodes the method result into the
+	// response.
+	PhaseEncode Phase = "encode"
+)
+
+// PhaseObserverMiddleware returns a middleware that registers o with the
+// context of the requests it handles so that the generated handlers notify it
+// of the request phases by calling StartPhase.
+func PhaseObserverMiddleware(o PhaseObserver) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), phaseObserverKey, o)))
+		})
+	}
+}
+
+// StartPhase notifies the phase observer registered in ctx that the given
+// phase starts. It returns the context used to process the phase and the
+// function that must be called with the error returned by the phase if any
+// when the phase ends. StartPhase returns ctx and a function that does nothing
+// if ctx holds no observer.
+func StartPhase(ctx context.Context, phase Phase) (context.Context, func(error)) {
+	o, ok := ctx.Value(phaseObserverKey).(PhaseObserver)
+	if !ok {
+		return ctx, func(error) {}
+	}
+	return o.StartPhase(ctx, phase)
+}
+
+// StartPhase records the time the phase starts and returns a function that
+// calls f with the duration of the phase.
+func (f PhaseDurationFunc) StartPhase(ctx context.Context, phase Phase) (context.Context, func(error)) {
+	start := time.Now()
+	return ctx, func(err error) {
+		f(ctx, phase, time.Since(start), err)
+	}
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStartPhase(t *testing.T) {
+	var phases []string
+	o := PhaseDurationFunc(func(ctx context.Context, phase Phase, d time.Duration, err error) {
+		if d < 0 {
+			t.Errorf("got negative duration %s for phase %s", d, phase)
+		}
+		p := string(phase)
+		if err != nil {
+			p += ":" + err.Error()
+		}
+		phases = append(phases, p)
+	})
+	cases := []struct {
+		name     string
+		observer PhaseObserver
+		expected string
+	}{
+		{"none", nil, ""},
+		{"observer", o, "decode,endpoint:boom,encode"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			phases = nil
+			var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, end := StartPhase(r.Context(), PhaseDecode)
+				end(nil)
+				_, end = StartPhase(r.Context(), PhaseEndpoint)
+				end(errors.New("boom"))
+				_, end = StartPhase(r.Context(), PhaseEncode)
+				end(nil)
+			})
+			if c.observer != nil {
+				h = PhaseObserverMiddleware(c.observer)(h)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+			if actual := strings.Join(phases, ","); actual != c.expected {
+				t.Errorf("got phases %q, expected %q", actual, c.expected)
+			}
+		})
+	}
+}
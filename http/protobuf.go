@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// ProtobufContentType is the mime type of the HTTP request and response bodies
+// encoded using protocol buffers.
+const ProtobufContentType = "application/x-protobuf"
+
+type (
+	// protobufEncoder encodes protocol buffer messages.
+	protobufEncoder struct {
+		w io.Writer
+	}
+
+	// protobufDecoder decodes protocol buffer messages.
+	protobufDecoder struct {
+		r io.Reader
+	}
+)
+
+// NewProtobufEncoder returns an encoder that writes the protocol buffer
+// encoding of messages to w. The encoder only accepts values that implement
+// proto.Message such as the messages generated for the gRPC transport.
+func NewProtobufEncoder(w io.Writer) Encoder {
+	return &protobufEncoder{w}
+}
+
+// NewProtobufDecoder returns a decoder that reads a protocol buffer message
+// from r. The message spans until the end of r so that a single value can be
+// decoded. The decoder only accepts values that implement proto.Message.
+func NewProtobufDecoder(r io.Reader) Decoder {
+	return &protobufDecoder{r}
+}
+
+// Encode writes the protocol buffer encoding of v.
+func (e *protobufEncoder) Encode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf: cannot encode %T, value must be a proto.Message", v)
+	}
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+// Decode reads the protocol buffer message and stores it in v.
+func (d *protobufDecoder) Decode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf: cannot decode into %T, value must be a proto.Message", v)
+	}
+	b, err := ioutil.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(b, m)
+}
+
+// IsProtobuf returns true if the given "Content-Type" header value denotes a
+// body encoded using protocol buffers.
+func IsProtobuf(ct string) bool {
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = mt
+	}
+	return ct == ProtobufContentType
+}
+
+// AcceptsProtobuf returns true if the first media range listed in the request
+// "Accept" header stored in ctx under AcceptTypeKey is ProtobufContentType.
+func AcceptsProtobuf(ctx context.Context) bool {
+	accept, _ := ctx.Value(AcceptTypeKey).(string)
+	if accept == "" {
+		return false
+	}
+	return IsProtobuf(strings.TrimSpace(strings.SplitN(accept, ",", 2)[0]))
+}
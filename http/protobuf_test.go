@@ -0,0 +1,61 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+func TestProtobufRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewProtobufEncoder(&buf).Encode(&wrappers.StringValue{Value: "goa"}); err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	var actual wrappers.StringValue
+	if err := NewProtobufDecoder(&buf).Decode(&actual); err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	if !proto.Equal(&actual, &wrappers.StringValue{Value: "goa"}) {
+		t.Errorf("got %v, expected %q", actual.Value, "goa")
+	}
+}
+
+func TestProtobufErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewProtobufEncoder(&buf).Encode("goa"); err == nil {
+		t.Errorf("encode: got no error, expected one")
+	}
+	var s string
+	if err := NewProtobufDecoder(&buf).Decode(&s); err == nil {
+		t.Errorf("decode: got no error, expected one")
+	}
+	var v wrappers.StringValue
+	if err := NewProtobufDecoder(bytes.NewReader([]byte{0x0a, 0x05})).Decode(&v); err == nil {
+		t.Errorf("decode truncated: got no error, expected one")
+	}
+}
+
+func TestAcceptsProtobuf(t *testing.T) {
+	cases := []struct {
+		accept   string
+		expected bool
+	}{
+		{"", false},
+		{"application/json", false},
+		{"application/x-protobuf", true},
+		{"application/x-protobuf; charset=binary", true},
+		{"application/x-protobuf, application/json;q=0.9", true},
+		{"application/json, application/x-protobuf", false},
+	}
+	for _, c := range cases {
+		t.Run(c.accept, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.accept)
+			if actual := AcceptsProtobuf(ctx); actual != c.expected {
+				t.Errorf("got %v, expected %v", actual, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Renames maps the previous names of the elements of a request to their
+// current names, see the RenamedFrom DSL.
+type Renames struct {
+	// Query maps the previous names of query string parameters.
+	Query map[string]string
+	// Header maps the previous names of headers.
+	Header map[string]string
+	// Body maps the previous names of the top-level fields of JSON
+	// bodies.
+	Body map[string]string
+}
+
+// RenameRequest rewrites the request r so that the query string parameters,
+// headers and top-level JSON body fields that use a previous name use the
+// current name instead. An element is renamed only if the request does not
+// already use the current name. The bodies that RequestDecoder does not decode
+// as JSON and the bodies that are not a valid JSON object are left untouched.
+// RenameRequest returns the error returned when reading the body, e.g. if the
+// body exceeds the limit set with LimitRequestBody. The generated servers call
+// RenameRequest before decoding the requests of the endpoints whose payload
+// attributes define previous names.
+func RenameRequest(r *http.Request, rn *Renames) error {
+	if len(rn.Query) > 0 {
+		q := r.URL.Query()
+		changed := false
+		for old, cur := range rn.Query {
+			v, ok := q[old]
+			if !ok {
+				continue
+			}
+			if _, ok := q[cur]; !ok {
+				q[cur] = v
+			}
+			delete(q, old)
+			changed = true
+		}
+		if changed {
+			r.URL.RawQuery = q.Encode()
+		}
+	}
+	for old, cur := range rn.Header {
+		old, cur = http.CanonicalHeaderKey(old), http.CanonicalHeaderKey(cur)
+		v, ok := r.Header[old]
+		if !ok {
+			continue
+		}
+		if _, ok := r.Header[cur]; !ok {
+			r.Header[cur] = v
+		}
+		delete(r.Header, old)
+	}
+	if len(rn.Body) == 0 || r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	if !isJSONBody(r) {
+		return nil
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil || fields == nil {
+		return nil
+	}
+	changed := false
+	for old, cur := range rn.Body {
+		v, ok := fields[old]
+		if !ok {
+			continue
+		}
+		if _, ok := fields[cur]; !ok {
+			fields[cur] = v
+		}
+		delete(fields, old)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	if b, err = json.Marshal(fields); err != nil {
+		return nil
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	r.ContentLength = int64(len(b))
+	return nil
+}
+
+// isJSONBody returns true if the body of r is decoded as JSON by
+// RequestDecoder.
+func isJSONBody(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = mt
+	}
+	switch ct {
+	case "application/gob", "application/xml", "application/cbor",
+		"application/msgpack", "application/x-msgpack", "application/x-protobuf":
+		return false
+	}
+	return !strings.HasPrefix(ct, "multipart/")
+}
+
+// DeprecatedRoute returns a handler that sets the "Deprecation" response
+// header and calls f. The generated servers serve the alias routes of the
+// methods renamed with the RenamedFrom DSL with DeprecatedRoute so that the
+// clients using the previous paths are told to migrate.
+func DeprecatedRoute(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		f(w, r)
+	}
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenameRequest(t *testing.T) {
+	rn := &Renames{
+		Query:  map[string]string{"q": "query"},
+		Header: map[string]string{"x-old": "X-New"},
+		Body:   map[string]string{"name": "display_name"},
+	}
+	cases := []struct {
+		Name        string
+		URL         string
+		Header      http.Header
+		Body        string
+		ContentType string
+
+		ExpectedQuery  string
+		ExpectedHeader http.Header
+		ExpectedBody   string
+	}{
+		{"previous names", "/?q=a&q=b", http.Header{"X-Old": {"v"}}, `{"name":"n","id":1}`, "application/json",
+			"query=a&query=b", http.Header{"X-New": {"v"}}, `{"display_name":"n","id":1}`},
+		{"current names", "/?query=a", http.Header{"X-New": {"v"}}, `{"display_name":"n"}`, "",
+			"query=a", http.Header{"X-New": {"v"}}, `{"display_name":"n"}`},
+		{"both names", "/?q=a&query=b", http.Header{"X-Old": {"o"}, "X-New": {"v"}}, `{"name":"o","display_name":"n"}`, "application/json",
+			"query=b", http.Header{"X-New": {"v"}}, `{"display_name":"n"}`},
+		{"default json", "/", nil, `{"name":"n"}`, "text/plain",
+			"", nil, `{"display_name":"n"}`},
+		{"not json", "/", nil, `{"name":"n"}`, "application/xml",
+			"", nil, `{"name":"n"}`},
+		{"invalid json", "/", nil, `{"name":`, "application/json",
+			"", nil, `{"name":`},
+		{"not an object", "/", nil, `["name"]`, "application/json",
+			"", nil, `["name"]`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", c.URL, strings.NewReader(c.Body))
+			for k, v := range c.Header {
+				r.Header[k] = v
+			}
+			if c.ContentType != "" {
+				r.Header.Set("Content-Type", c.ContentType)
+			}
+			if err := RenameRequest(r, rn); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if r.URL.RawQuery != c.ExpectedQuery {
+				t.Errorf("got query %q, expected %q", r.URL.RawQuery, c.ExpectedQuery)
+			}
+			r.Header.Del("Content-Type")
+			if len(r.Header) > 0 || len(c.ExpectedHeader) > 0 {
+				if !reflect.DeepEqual(r.Header, c.ExpectedHeader) {
+					t.Errorf("got headers %v, expected %v", r.Header, c.ExpectedHeader)
+				}
+			}
+			b, _ := ioutil.ReadAll(r.Body)
+			if string(b) != c.ExpectedBody {
+				t.Errorf("got body %s, expected %s", b, c.ExpectedBody)
+			}
+		})
+	}
+}
+
+func TestDeprecatedRoute(t *testing.T) {
+	called := false
+	h := DeprecatedRoute(func(w http.ResponseWriter, r *http.Request) { called = true })
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest("GET", "/", nil))
+	if !called {
+		t.Error("handler not called")
+	}
+	if d := w.Header().Get("Deprecation"); d != "true" {
+		t.Errorf("got Deprecation header %q, expected %q", d, "true")
+	}
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// ResponseHook is a function invoked with the result returned by an
+	// endpoint and the HTTP response writer before the result is encoded.
+	// Hooks may set response headers and override the response status code
+	// by calling WriteHeader. Hooks must not write the response body.
+	ResponseHook func(ctx context.Context, w http.ResponseWriter, res interface{})
+
+	// hookResponseWriter is the response writer given to the response
+	// hooks and to the response encoder. It records the status code set by
+	// the hooks and uses it in place of the one written by the encoder.
+	hookResponseWriter struct {
+		http.ResponseWriter
+		// status is the status code set by the hooks if any.
+		status int
+		// encoding is true once the hooks have run.
+		encoding bool
+	}
+)
+
+// ResponseHookMiddleware returns a middleware that registers hook with the
+// context of the requests it handles so that the generated handlers invoke it
+// by calling RunResponseHooks. The hooks registered by the middlewares closer
+// to the handler run first.
+func ResponseHookMiddleware(hook ResponseHook) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hooks, _ := r.Context().Value(responseHooksKey).([]ResponseHook)
+			hooks = append([]ResponseHook{hook}, hooks...)
+			h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), responseHooksKey, hooks)))
+		})
+	}
+}
+
+// RunResponseHooks invokes the hooks registered in ctx with res and returns
+// the response writer the result must be encoded with. The returned writer
+// uses the last status code set by the hooks if any in place of the status
+// code written by the encoder. RunResponseHooks returns w if ctx holds no hook.
+func RunResponseHooks(ctx context.Context, w http.ResponseWriter, res interface{}) http.ResponseWriter {
+	hooks, _ := ctx.Value(responseHooksKey).([]ResponseHook)
+	if len(hooks) == 0 {
+		return w
+	}
+	hw := &hookResponseWriter{ResponseWriter: w}
+	for _, hook := range hooks {
+		hook(ctx, hw, res)
+	}
+	hw.encoding = true
+	return hw
+}
+
+// WriteHeader records the status code when called by the hooks and writes
+// the recorded status code if any or code otherwise when called by the
+// encoder.
+func (w *hookResponseWriter) WriteHeader(code int) {
+	if !w.encoding {
+		w.status = code
+		return
+	}
+	if w.status != 0 {
+		code = w.status
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
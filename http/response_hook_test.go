@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseHooks(t *testing.T) {
+	var calls []string
+	hook := func(name string, status int) ResponseHook {
+		return func(ctx context.Context, w http.ResponseWriter, res interface{}) {
+			calls = append(calls, name+":"+res.(string))
+			w.Header().Set("X-Hook", name)
+			if status != 0 {
+				w.WriteHeader(status)
+			}
+		}
+	}
+	cases := []struct {
+		name           string
+		hooks          []ResponseHook
+		expectedCalls  string
+		expectedHeader string
+		expectedStatus int
+	}{
+		{"none", nil, "", "", http.StatusOK},
+		{"one", []ResponseHook{hook("a", 0)}, "a:res", "a", http.StatusOK},
+		{"status", []ResponseHook{hook("a", http.StatusAccepted)}, "a:res", "a", http.StatusAccepted},
+		{"order", []ResponseHook{hook("a", http.StatusAccepted), hook("b", 0)}, "a:res,b:res", "b", http.StatusAccepted},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			calls = nil
+			var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w = RunResponseHooks(r.Context(), w, "res")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("body"))
+			})
+			for _, hook := range c.hooks {
+				h = ResponseHookMiddleware(hook)(h)
+			}
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest("GET", "/", nil))
+			if actual := strings.Join(calls, ","); actual != c.expectedCalls {
+				t.Errorf("got calls %q, expected %q", actual, c.expectedCalls)
+			}
+			if actual := rw.Header().Get("X-Hook"); actual != c.expectedHeader {
+				t.Errorf("got header %q, expected %q", actual, c.expectedHeader)
+			}
+			if rw.Code != c.expectedStatus {
+				t.Errorf("got status %d, expected %d", rw.Code, c.expectedStatus)
+			}
+			if rw.Body.String() != "body" {
+				t.Errorf("got body %q, expected %q", rw.Body.String(), "body")
+			}
+		})
+	}
+}
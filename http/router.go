@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// RouterHandleFunc registers the handler for the given method and
+	// pattern with a third party router. The pattern uses the syntax of the
+	// router selected in the design with the Router DSL.
+	RouterHandleFunc func(method, pattern string, handler http.HandlerFunc)
+
+	// RouterVarsFunc returns the path variables captured by a third party
+	// router for the given request.
+	RouterVarsFunc func(*http.Request) map[string]string
+
+	// routerMuxer is a Muxer that delegates to a third party router.
+	routerMuxer struct {
+		http.Handler
+		handle RouterHandleFunc
+		vars   RouterVarsFunc
+	}
+
+	// serveMuxer is a Muxer that delegates to a standard library
+	// http.ServeMux.
+	serveMuxer struct {
+		*http.ServeMux
+	}
+
+	// serveMuxVarsKey is the private type used to store the path variables
+	// captured by a serveMuxer in the request context.
+	serveMuxVarsKey struct{}
+)
+
+// NewRouterMuxer returns a Muxer that adapts a third party router such as chi,
+// gorilla/mux or httprouter. handle registers the handlers of the generated
+// servers with the router and vars returns the path variables captured by the
+// router. The patterns given to handle use the syntax of the router selected in
+// the design with the Router DSL so that they can be given to the router as is.
+// A RouteTable may be used as the router handler for the requests that do not
+// match any route so that the responses are consistent across routers.
+//
+// Example using chi:
+//
+//    r := chi.NewRouter()
+//    routes := goahttp.NewRouteTable(nil)
+//    r.NotFound(routes.ServeHTTP)
+//    r.MethodNotAllowed(routes.ServeHTTP)
+//    mux := goahttp.NewRouterMuxer(r,
+//        func(method, pattern string, h http.HandlerFunc) {
+//            r.Method(method, pattern, h)
+//        },
+//        func(req *http.Request) map[string]string {
+//            params := chi.RouteContext(req.Context()).URLParams
+//            vars := make(map[string]string, len(params.Keys))
+//            for i, k := range params.Keys {
+//                vars[k] = params.Values[i]
+//            }
+//            return vars
+//        })
+//
+func NewRouterMuxer(router http.Handler, handle RouterHandleFunc, vars RouterVarsFunc) Muxer {
+	return &routerMuxer{Handler: router, handle: handle, vars: vars}
+}
+
+// NewServeMuxer returns a Muxer that adapts the given standard library
+// http.ServeMux. The patterns registered with the muxer must use the syntax of
+// the "servemux" router (see the Router DSL) which requires Go 1.22 or later.
+// A new ServeMux is created if mux is nil.
+func NewServeMuxer(mux *http.ServeMux) Muxer {
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+	return &serveMuxer{mux}
+}
+
+// Handle registers the handler with the router.
+func (m *routerMuxer) Handle(method, pattern string, handler http.HandlerFunc) {
+	m.handle(method, pattern, handler)
+}
+
+// Vars returns the path variables captured by the router.
+func (m *routerMuxer) Vars(r *http.Request) map[string]string {
+	return m.vars(r)
+}
+
+// Handle registers the handler for the pattern prefixed with the method. The
+// handler stores the path variables in the request context.
+func (m *serveMuxer) Handle(method, pattern string, handler http.HandlerFunc) {
+	segs := strings.Split(pattern, "/")
+	m.ServeMux.HandleFunc(method+" "+pattern, func(w http.ResponseWriter, r *http.Request) {
+		vars := serveMuxVars(segs, r.URL.EscapedPath())
+		handler(w, r.WithContext(context.WithValue(r.Context(), serveMuxVarsKey{}, vars)))
+	})
+}
+
+// Vars returns the path variables stored in the request context by the
+// handlers.
+func (m *serveMuxer) Vars(r *http.Request) map[string]string {
+	vars, _ := r.Context().Value(serveMuxVarsKey{}).(map[string]string)
+	return vars
+}
+
+// serveMuxVars returns the values of the wildcards of the pattern split in
+// segments captured from the given escaped path.
+func serveMuxVars(segs []string, path string) map[string]string {
+	vars := make(map[string]string)
+	psegs := strings.Split(path, "/")
+	for i, seg := range segs {
+		if i >= len(psegs) || !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") {
+			continue
+		}
+		name := seg[1 : len(seg)-1]
+		if name == "$" {
+			continue
+		}
+		val := psegs[i]
+		if strings.HasSuffix(name, "...") {
+			name = strings.TrimSuffix(name, "...")
+			val = strings.Join(psegs[i:], "/")
+		}
+		if v, err := url.PathUnescape(val); err == nil {
+			val = v
+		}
+		vars[name] = val
+	}
+	return vars
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouterMuxer(t *testing.T) {
+	var (
+		method, pattern string
+		handler         http.HandlerFunc
+	)
+	router := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r)
+	})
+	mux := NewRouterMuxer(router,
+		func(m, p string, h http.HandlerFunc) {
+			method, pattern, handler = m, p, h
+		},
+		func(r *http.Request) map[string]string {
+			return map[string]string{"id": r.URL.Path[len("/items/"):]}
+		})
+	var id string
+	mux.Handle("GET", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id = mux.Vars(r)["id"]
+	})
+	if method != "GET" || pattern != "/items/{id}" {
+		t.Errorf("got %s %s, expected GET /items/{id}", method, pattern)
+	}
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/items/42", nil))
+	if id != "42" {
+		t.Errorf("got id %q, expected %q", id, "42")
+	}
+}
+
+func TestServeMuxVars(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Pattern  string
+		Path     string
+		Expected map[string]string
+	}{
+		{"no wildcard", "/items", "/items", map[string]string{}},
+		{"exact", "/{$}", "/", map[string]string{}},
+		{"segment", "/items/{id}", "/items/42", map[string]string{"id": "42"}},
+		{"segments", "/{a}/x/{b}", "/1/x/2", map[string]string{"a": "1", "b": "2"}},
+		{"escaped", "/items/{id}", "/items/a%2Fb", map[string]string{"id": "a/b"}},
+		{"catch-all", "/files/{path...}", "/files/a/b.txt", map[string]string{"path": "a/b.txt"}},
+		{"empty catch-all", "/files/{path...}", "/files/", map[string]string{"path": ""}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", c.Path, nil)
+			vars := serveMuxVars(strings.Split(c.Pattern, "/"), r.URL.EscapedPath())
+			if len(vars) != len(c.Expected) {
+				t.Fatalf("got %v, expected %v", vars, c.Expected)
+			}
+			for k, v := range c.Expected {
+				if vars[k] != v {
+					t.Errorf("got %q for %q, expected %q", vars[k], k, v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// MuxErrorEncoder writes the responses to the requests that do not
+	// match any route. status is http.StatusNotFound if no route matches
+	// the request path and http.StatusMethodNotAllowed if routes match the
+	// path but not the request method in which case allow lists the
+	// methods of these routes.
+	MuxErrorEncoder func(w http.ResponseWriter, r *http.Request, status int, allow []string)
+
+	// RouteTable lists the methods and path patterns of the routes served
+	// by a muxer. The patterns use the goa wildcard syntax (see Muxer). The
+	// route table is a http.Handler that writes the response to the
+	// requests that do not match any route: 404 Not Found if no route
+	// matches the request path, 405 Method Not Allowed with an Allow header
+	// listing the methods of the routes matching the path otherwise.
+	RouteTable struct {
+		encoder MuxErrorEncoder
+		mu      sync.RWMutex
+		routes  []*tableRoute
+	}
+
+	// tableRoute is a route of a route table.
+	tableRoute struct {
+		method string
+		segs   []string
+		// rexs contains the regular expressions that constrain the
+		// wildcards of the pattern indexed by segment, nil if none.
+		rexs map[int]*regexp.Regexp
+	}
+)
+
+// NewRouteTable returns an empty route table that writes the 404 and 405
+// responses with the given encoder. The table uses EncodeMuxError if encoder is
+// nil.
+func NewRouteTable(encoder MuxErrorEncoder) *RouteTable {
+	if encoder == nil {
+		encoder = EncodeMuxError
+	}
+	return &RouteTable{encoder: encoder}
+}
+
+// EncodeMuxError is the default MuxErrorEncoder. It sets the Allow header of
+// the 405 responses and writes an ErrorResponse encoded with the encoder that
+// corresponds to the request Accept header.
+func EncodeMuxError(w http.ResponseWriter, r *http.Request, status int, allow []string) {
+	ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+	enc := ResponseEncoder(ctx, w)
+	msg := "404 page not found"
+	if status == http.StatusMethodNotAllowed {
+		w.Header().Set("Allow", strings.Join(allow, ", "))
+		msg = "405 method not allowed"
+	}
+	w.WriteHeader(status)
+	enc.Encode(NewErrorResponse(fmt.Errorf(msg)))
+}
+
+// Add adds the route with the given method and pattern to the table. The
+// wildcards that match a path segment may be followed by a regular expression
+// that constrains the segment, e.g. "/items/{id:[0-9]+}". Invalid regular
+// expressions are ignored.
+func (t *RouteTable) Add(method, pattern string) {
+	r := &tableRoute{method: method, segs: strings.Split(pattern, "/")}
+	for i, s := range r.segs {
+		if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+			continue
+		}
+		idx := strings.Index(s, ":")
+		if idx < 0 {
+			continue
+		}
+		rex, err := regexp.Compile("^(?:" + s[idx+1:len(s)-1] + ")$")
+		if err != nil {
+			continue
+		}
+		if r.rexs == nil {
+			r.rexs = make(map[int]*regexp.Regexp)
+		}
+		r.rexs[i] = rex
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.routes = append(t.routes, r)
+}
+
+// Allowed returns the sorted methods of the routes whose pattern matches the
+// given path.
+func (t *RouteTable) Allowed(path string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var (
+		segs    = strings.Split(path, "/")
+		seen    = make(map[string]struct{})
+		methods []string
+	)
+	for _, r := range t.routes {
+		if _, ok := seen[r.method]; ok || !r.match(segs) {
+			continue
+		}
+		seen[r.method] = struct{}{}
+		methods = append(methods, r.method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// ServeHTTP writes a 405 response if routes match the request path, a 404
+// response otherwise.
+func (t *RouteTable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if allow := t.Allowed(r.URL.Path); len(allow) > 0 {
+		t.encoder(w, r, http.StatusMethodNotAllowed, allow)
+		return
+	}
+	t.encoder(w, r, http.StatusNotFound, nil)
+}
+
+// match returns true if the route pattern matches the given path segments.
+func (r *tableRoute) match(segs []string) bool {
+	for i, s := range r.segs {
+		if strings.HasPrefix(s, "{*") {
+			return i < len(segs)
+		}
+		if i >= len(segs) {
+			return false
+		}
+		if strings.HasPrefix(s, "{") {
+			if segs[i] == "" {
+				return false
+			}
+			if rex, ok := r.rexs[i]; ok && !rex.MatchString(segs[i]) {
+				return false
+			}
+			continue
+		}
+		if s != segs[i] {
+			return false
+		}
+	}
+	return len(segs) == len(r.segs)
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRouteTableAllowed(t *testing.T) {
+	table := NewRouteTable(nil)
+	table.Add("GET", "/")
+	table.Add("GET", "/items")
+	table.Add("POST", "/items")
+	table.Add("GET", "/items/{id}")
+	table.Add("DELETE", "/items/{id}")
+	table.Add("PUT", "/items/{id}")
+	table.Add("GET", "/files/{*path}")
+	table.Add("GET", "/orders/{id:[0-9]+}")
+	cases := []struct {
+		Name     string
+		Path     string
+		Expected []string
+	}{
+		{"root", "/", []string{"GET"}},
+		{"literal", "/items", []string{"GET", "POST"}},
+		{"segment", "/items/42", []string{"DELETE", "GET", "PUT"}},
+		{"empty segment", "/items/", nil},
+		{"too long", "/items/42/x", nil},
+		{"catch-all", "/files/a/b.txt", []string{"GET"}},
+		{"unknown", "/unknown", nil},
+		{"constrained", "/orders/42", []string{"GET"}},
+		{"constraint mismatch", "/orders/4a", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			actual := table.Allowed(c.Path)
+			if strings.Join(actual, ",") != strings.Join(c.Expected, ",") {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestMuxerNotAllowed(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Encoder MuxErrorEncoder
+		Method  string
+		Path    string
+		Status  int
+		Allow   string
+		Body    string
+	}{
+		{"found", nil, "GET", "/items/42", http.StatusOK, "", ""},
+		{"not found", nil, "GET", "/unknown", http.StatusNotFound, "", "404 page not found"},
+		{"not allowed", nil, "PATCH", "/items/42", http.StatusMethodNotAllowed, "DELETE, GET", "405 method not allowed"},
+		{"custom encoder", func(w http.ResponseWriter, r *http.Request, status int, allow []string) {
+			w.Header().Set("Allow", strings.Join(allow, ","))
+			w.WriteHeader(status)
+			w.Write([]byte("custom"))
+		}, "POST", "/items/42", http.StatusMethodNotAllowed, "DELETE,GET", "custom"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var mux Muxer
+			if c.Encoder != nil {
+				mux = NewMuxer(c.Encoder)
+			} else {
+				mux = NewMuxer()
+			}
+			h := func(w http.ResponseWriter, r *http.Request) {}
+			mux.Handle("GET", "/items/{id}", h)
+			mux.Handle("DELETE", "/items/{id}", h)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(c.Method, c.Path, nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if allow := w.Header().Get("Allow"); allow != c.Allow {
+				t.Errorf("got Allow header %q, expected %q", allow, c.Allow)
+			}
+			if !strings.Contains(w.Body.String(), c.Body) {
+				t.Errorf("got body %q, expected it to contain %q", w.Body.String(), c.Body)
+			}
+		})
+	}
+}
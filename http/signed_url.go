@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	// SignedURLExpiresParam is the name of the query string parameter of
+	// signed URLs that contains the expiration time as a Unix timestamp.
+	SignedURLExpiresParam = "expires"
+	// SignedURLSignatureParam is the name of the query string parameter of
+	// signed URLs that contains the signature.
+	SignedURLSignatureParam = "signature"
+)
+
+var (
+	// ErrInvalidURLSignature is the error returned by URLSigner.Verify when
+	// the request URL is not signed or the signature is invalid.
+	ErrInvalidURLSignature = errors.New("invalid URL signature")
+	// ErrExpiredURL is the error returned by URLSigner.Verify when the
+	// request URL signature is valid but expired.
+	ErrExpiredURL = errors.New("signed URL expired")
+)
+
+type (
+	// URLSigner signs time-limited URLs and verifies the requests made to
+	// them. The signature is the hex encoded HMAC-SHA256 of the URL path
+	// followed by a newline and the expiration time.
+	URLSigner struct {
+		secret []byte
+		now    func() time.Time
+	}
+
+	// signedURLKey is the private type used to flag the requests whose
+	// URL signature has been verified in the request context.
+	signedURLKey struct{}
+)
+
+// NewURLSigner returns a URL signer that signs the URLs with secret. The
+// servers must use the same secret as the services that sign the URLs.
+func NewURLSigner(secret []byte) *URLSigner {
+	return &URLSigner{secret: secret, now: time.Now}
+}
+
+// Sign returns u with the expiration time and the signature of its path added
+// to the query string. u may be a URL path as returned by the path
+// constructors of the generated server packages or an absolute URL.
+func (s *URLSigner) Sign(u string, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	sep := "?"
+	if strings.Contains(u, "?") {
+		sep = "&"
+	}
+	return u + sep + SignedURLExpiresParam + "=" + exp + "&" +
+		SignedURLSignatureParam + "=" + s.signature(urlPath(u), exp)
+}
+
+// Verify returns nil if the request URL is signed with the signer secret and
+// has not expired, ErrInvalidURLSignature if the URL is not signed or the
+// signature is invalid and ErrExpiredURL if the URL has expired. Verify always
+// returns ErrInvalidURLSignature if the signer secret is empty.
+func (s *URLSigner) Verify(r *http.Request) error {
+	if len(s.secret) == 0 {
+		return ErrInvalidURLSignature
+	}
+	q := r.URL.Query()
+	exp, sig := q.Get(SignedURLExpiresParam), q.Get(SignedURLSignatureParam)
+	if exp == "" || sig == "" {
+		return ErrInvalidURLSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(s.signature(r.URL.Path, exp))) {
+		return ErrInvalidURLSignature
+	}
+	ts, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return ErrInvalidURLSignature
+	}
+	if s.now().Unix() > ts {
+		return ErrExpiredURL
+	}
+	return nil
+}
+
+// signature computes the signature of the given unescaped path and
+// expiration time.
+func (s *URLSigner) signature(path, exp string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(path))
+	mac.Write([]byte("\n"))
+	mac.Write([]byte(exp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignedURLs returns a middleware that rejects the requests whose URL is
+// not signed by signer or has expired with a 403 Forbidden response. The
+// generated servers of services that define endpoints using the SignedURL DSL
+// apply the middleware to the handlers of these endpoints in their
+// UseSignedURLs method.
+func VerifySignedURLs(signer *URLSigner) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := signer.Verify(r); err != nil {
+				encodeSignedURLError(w, r, err)
+				return
+			}
+			h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), signedURLKey{}, true)))
+		})
+	}
+}
+
+// RequireSignedURL returns a handler that rejects the requests that have not
+// been verified by the middleware returned by VerifySignedURLs with a 403
+// Forbidden response. The generated servers wrap the handlers of the endpoints
+// using the SignedURL DSL with RequireSignedURL so that the endpoints cannot be
+// served without verifying the URL signatures.
+func RequireSignedURL(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if verified, _ := r.Context().Value(signedURLKey{}).(bool); !verified {
+			encodeSignedURLError(w, r, ErrInvalidURLSignature)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// encodeSignedURLError writes a 403 Forbidden response describing err.
+func encodeSignedURLError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+	enc := ResponseEncoder(ctx, w)
+	w.WriteHeader(http.StatusForbidden)
+	enc.Encode(NewErrorResponse(goa.PermanentError("invalid_signed_url", "%s", err.Error())))
+}
+
+// urlPath returns the unescaped path of the given URL or URL path.
+func urlPath(u string) string {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	if pu, err := url.Parse(u); err == nil && pu.Scheme != "" {
+		u = pu.EscapedPath()
+	}
+	if p, err := url.PathUnescape(u); err == nil {
+		return p
+	}
+	return u
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestURLSigner(t *testing.T) {
+	var (
+		now     = time.Unix(1000, 0)
+		signer  = &URLSigner{secret: []byte("secret"), now: func() time.Time { return now }}
+		other   = NewURLSigner([]byte("other"))
+		future  = now.Add(time.Minute)
+		past    = now.Add(-time.Minute)
+		invalid = "/files/a.txt?expires=1060&signature=00"
+	)
+	cases := []struct {
+		Name     string
+		URL      string
+		Expected error
+	}{
+		{"valid", signer.Sign("/files/a.txt", future), nil},
+		{"query", signer.Sign("/files/a.txt?inline=true", future), nil},
+		{"absolute", strings.TrimPrefix(signer.Sign("https://example.com/files/a%20b.txt", future), "https://example.com"), nil},
+		{"escaped", signer.Sign("/files/a b.txt", future), nil},
+		{"expired", signer.Sign("/files/a.txt", past), ErrExpiredURL},
+		{"other path", strings.Replace(signer.Sign("/files/a.txt", future), "a.txt", "b.txt", 1), ErrInvalidURLSignature},
+		{"other expiry", strings.Replace(signer.Sign("/files/a.txt", future), "expires=1060", "expires=2060", 1), ErrInvalidURLSignature},
+		{"other secret", other.Sign("/files/a.txt", future), ErrInvalidURLSignature},
+		{"invalid", invalid, ErrInvalidURLSignature},
+		{"unsigned", "/files/a.txt", ErrInvalidURLSignature},
+	}
+	empty := NewURLSigner(nil)
+	if err := empty.Verify(httptest.NewRequest("GET", empty.Sign("/files/a.txt", time.Now().Add(time.Minute)), nil)); err != ErrInvalidURLSignature {
+		t.Errorf("got error %v with empty secret, expected %v", err, ErrInvalidURLSignature)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			u := strings.Replace(c.URL, " ", "%20", -1)
+			if err := signer.Verify(httptest.NewRequest("GET", u, nil)); err != c.Expected {
+				t.Errorf("got error %v, expected %v", err, c.Expected)
+			}
+		})
+	}
+}
+
+func TestSignedURLMiddleware(t *testing.T) {
+	signer := NewURLSigner([]byte("secret"))
+	h := RequireSignedURL(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	cases := []struct {
+		Name     string
+		Handler  http.Handler
+		URL      string
+		Expected int
+	}{
+		{"verified", VerifySignedURLs(signer)(h), signer.Sign("/files/a.txt", time.Now().Add(time.Minute)), http.StatusOK},
+		{"invalid", VerifySignedURLs(signer)(h), "/files/a.txt", http.StatusForbidden},
+		{"not verified", h, signer.Sign("/files/a.txt", time.Now().Add(time.Minute)), http.StatusForbidden},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c.Handler.ServeHTTP(w, httptest.NewRequest("GET", c.URL, nil))
+			if w.Code != c.Expected {
+				t.Errorf("got status %d, expected %d", w.Code, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+type (
+	// singleflightDoer wraps a doer and collapses concurrent identical GET
+	// requests into a single call.
+	singleflightDoer struct {
+		Doer
+		mu    sync.Mutex
+		calls map[string]*singleflightCall
+	}
+
+	// singleflightCall is an in-flight or completed call shared by the
+	// requests with the same key.
+	singleflightCall struct {
+		wg   sync.WaitGroup
+		resp *http.Response
+		body []byte
+		err  error
+	}
+)
+
+// NewSingleflightDoer wraps the given doer so that concurrent identical GET
+// requests made through it result in a single upstream call. Two requests are
+// identical if they have the same URL and headers, the generated clients
+// encode the method payload in both so that requests made to the same endpoint
+// with the same payload share the call. Each caller receives its own copy of
+// the response with the body buffered in memory. Requests using other HTTP
+// methods are always sent.
+//
+// Note that the context of the first request governs the shared call: if it is
+// canceled the other callers receive the same error.
+//
+// Example:
+//
+//    doer := goahttp.NewSingleflightDoer(http.DefaultClient)
+//    c := client.NewClient("http", "localhost:8080", doer, enc, dec, false)
+//
+func NewSingleflightDoer(d Doer) Doer {
+	return &singleflightDoer{Doer: d, calls: make(map[string]*singleflightCall)}
+}
+
+// Do sends the request unless an identical GET request is already in flight in
+// which case it waits for and returns a copy of its response.
+func (d *singleflightDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != "GET" {
+		return d.Doer.Do(req)
+	}
+	key := singleflightKey(req)
+	d.mu.Lock()
+	if c, ok := d.calls[key]; ok {
+		d.mu.Unlock()
+		c.wg.Wait()
+		return c.response(req)
+	}
+	c := new(singleflightCall)
+	c.wg.Add(1)
+	d.calls[key] = c
+	d.mu.Unlock()
+
+	c.resp, c.err = d.Doer.Do(req)
+	if c.err == nil {
+		c.body, c.err = ioutil.ReadAll(c.resp.Body)
+		c.resp.Body.Close()
+	}
+	d.mu.Lock()
+	delete(d.calls, key)
+	d.mu.Unlock()
+	c.wg.Done()
+
+	return c.response(req)
+}
+
+// response returns a copy of the call response for the given request.
+func (c *singleflightCall) response(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp := *c.resp
+	resp.Header = make(http.Header, len(c.resp.Header))
+	for k, v := range c.resp.Header {
+		resp.Header[k] = append([]string(nil), v...)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(c.body))
+	resp.ContentLength = int64(len(c.body))
+	resp.Request = req
+	return &resp, nil
+}
+
+// singleflightKey computes the key identifying identical requests from the
+// request URL and headers.
+func singleflightKey(req *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(req.URL.String()))
+	names := make([]string, 0, len(req.Header))
+	for n := range req.Header {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		h.Write([]byte{0})
+		h.Write([]byte(n))
+		for _, v := range req.Header[n] {
+			h.Write([]byte{0})
+			h.Write([]byte(v))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleflightDoer(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Method string
+		Paths  []string
+		Calls  int32
+	}{
+		{"identical", "GET", []string{"/a", "/a", "/a"}, 1},
+		{"different paths", "GET", []string{"/a", "/b", "/a"}, 2},
+		{"not get", "POST", []string{"/a", "/a", "/a"}, 3},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var calls int32
+			release := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				if r.Method == "GET" {
+					<-release
+				}
+				w.Header().Set("X-Path", r.URL.Path)
+				w.Write([]byte(r.URL.Path))
+			}))
+			defer srv.Close()
+			doer := NewSingleflightDoer(http.DefaultClient)
+
+			var wg sync.WaitGroup
+			var started sync.WaitGroup
+			for _, p := range c.Paths {
+				wg.Add(1)
+				started.Add(1)
+				go func(p string) {
+					defer wg.Done()
+					req, _ := http.NewRequest(c.Method, srv.URL+p, nil)
+					started.Done()
+					resp, err := doer.Do(req)
+					if err != nil {
+						t.Errorf("unexpected error: %s", err)
+						return
+					}
+					defer resp.Body.Close()
+					body, _ := ioutil.ReadAll(resp.Body)
+					if string(body) != p {
+						t.Errorf("got body %q, expected %q", body, p)
+					}
+					if h := resp.Header.Get("X-Path"); h != p {
+						t.Errorf("got header %q, expected %q", h, p)
+					}
+					if resp.Request != req {
+						t.Errorf("got response for a different request")
+					}
+				}(p)
+			}
+			started.Wait()
+			// let the duplicate requests join the in-flight calls
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			if calls := atomic.LoadInt32(&calls); calls != c.Calls {
+				t.Errorf("got %d upstream calls, expected %d", calls, c.Calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RedirectTrailingSlash redirects the request to the URL whose path is the
+// request path with the trailing slash removed if the path ends with a slash,
+// added otherwise. The redirect uses a 301 Moved Permanently response for GET
+// and HEAD requests and a 308 Permanent Redirect response for the other
+// methods so that clients repeat the request with the same method and body.
+// The generated servers register RedirectTrailingSlash for the alternate
+// paths of the routes of the services that use the "redirect" trailing slash
+// policy, see the TrailingSlash DSL.
+func RedirectTrailingSlash(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.EscapedPath()
+	if strings.HasSuffix(p, "/") {
+		p = strings.TrimSuffix(p, "/")
+	} else {
+		p += "/"
+	}
+	// Collapse the leading slashes so that the target cannot be a
+	// protocol-relative URL.
+	p = "/" + strings.TrimLeft(p, "/")
+	if r.URL.RawQuery != "" {
+		p += "?" + r.URL.RawQuery
+	}
+	code := http.StatusPermanentRedirect
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		code = http.StatusMovedPermanently
+	}
+	http.Redirect(w, r, p, code)
+}
+
+// RejectTrailingSlash registers the alternate path pattern of a route with the
+// goa muxer so that the requests made to the path are not redirected to the
+// route path and get a 404 Not Found or 405 Method Not Allowed response
+// instead. The generated servers call RejectTrailingSlash for the routes of
+// the services that use the "strict" trailing slash policy, see the
+// TrailingSlash DSL. RejectTrailingSlash does nothing if m is not the goa
+// muxer, the routers wrapped with NewRouterMuxer must be configured not to
+// redirect the requests, e.g. by setting the RedirectTrailingSlash field of
+// httprouter routers to false.
+func RejectTrailingSlash(m Muxer, method, pattern string) {
+	tm, ok := m.(*mux)
+	if !ok {
+		return
+	}
+	tm.handleWeak(method, pattern, tm.routes.ServeHTTP)
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedirectTrailingSlash(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Method   string
+		URL      string
+		Status   int
+		Location string
+	}{
+		{"add", "GET", "/items", http.StatusMovedPermanently, "/items/"},
+		{"remove", "GET", "/items/", http.StatusMovedPermanently, "/items"},
+		{"query", "HEAD", "/items/?q=1", http.StatusMovedPermanently, "/items?q=1"},
+		{"escaped", "GET", "/items/a%2Fb/", http.StatusMovedPermanently, "/items/a%2Fb"},
+		{"post", "POST", "/items/", http.StatusPermanentRedirect, "/items"},
+		{"protocol-relative", "GET", "//evil.com/", http.StatusMovedPermanently, "/evil.com"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			RedirectTrailingSlash(w, httptest.NewRequest(c.Method, c.URL, nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if loc := w.Header().Get("Location"); loc != c.Location {
+				t.Errorf("got location %q, expected %q", loc, c.Location)
+			}
+		})
+	}
+}
+
+func TestRejectTrailingSlash(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	cases := []struct {
+		Name   string
+		Strict bool
+		Method string
+		Path   string
+		Status int
+	}{
+		{"redirected", false, "GET", "/items/", http.StatusMovedPermanently},
+		{"not found", true, "GET", "/items/", http.StatusNotFound},
+		{"other method", true, "POST", "/items/", http.StatusNotFound},
+		{"route", true, "GET", "/items", http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			mux := NewMuxer()
+			mux.Handle("GET", "/items", ok)
+			if c.Strict {
+				RejectTrailingSlash(mux, "GET", "/items/")
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(c.Method, c.Path, nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+		})
+	}
+}
+
+func TestMuxerTrailingSlash(t *testing.T) {
+	body := func(s string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(s)) }
+	}
+	mux := NewMuxer()
+	mux.Handle("GET", "/items", body("items"))
+	mux.Handle("GET", "/dirs/", body("dirs"))
+	mux.Handle("GET", "/both/", body("both/"))
+	mux.Handle("GET", "/both", body("both"))
+	mux.Handle("GET", "/files/{*path}", body("files"))
+	mux.Handle("GET", "/rewrite", body("rewrite"))
+	mux.Handle("GET", "/rewrite/", body("rewrite"))
+	cases := []struct {
+		Name     string
+		Path     string
+		Status   int
+		Location string
+		Body     string
+	}{
+		{"add", "/dirs", http.StatusMovedPermanently, "/dirs/", ""},
+		{"remove", "/items/", http.StatusMovedPermanently, "/items", ""},
+		{"registered", "/both/", http.StatusOK, "", "both/"},
+		{"registered after", "/both", http.StatusOK, "", "both"},
+		{"catch-all", "/files/a/", http.StatusOK, "", "files"},
+		{"rewrite", "/rewrite/", http.StatusOK, "", "rewrite"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", c.Path, nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if loc := w.Header().Get("Location"); loc != c.Location {
+				t.Errorf("got location %q, expected %q", loc, c.Location)
+			}
+			if c.Body != "" && w.Body.String() != c.Body {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.Body)
+			}
+		})
+	}
+}
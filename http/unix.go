@@ -0,0 +1,29 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+)
+
+// NewUnixTransport returns a RoundTripper that sends the requests to the Unix
+// domain socket at path regardless of the request URL host. The example
+// clients generated for the hosts that define "unix" URIs use the returned
+// RoundTripper with the "http" scheme.
+func NewUnixTransport(path string) http.RoundTripper {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = UnixDialContext(path)
+	return t
+}
+
+// UnixDialContext returns a dial function that connects to the Unix domain
+// socket at path regardless of the network and address it is given. The
+// returned function may be used to set the DialContext field of a
+// http.Transport or the NetDialContext field of a websocket.Dialer.
+func UnixDialContext(path string) func(context.Context, string, string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", path)
+	}
+}
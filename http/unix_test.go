@@ -0,0 +1,41 @@
+package http
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnixTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "svc.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + r.URL.Path))
+	}))
+	ts.Listener = l
+	ts.Start()
+	defer ts.Close()
+
+	client := &http.Client{Transport: NewUnixTransport(path)}
+	resp, err := client.Get("http://localhost/ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != "localhost/ping" {
+		t.Errorf("got body %q, expected %q", string(body), "localhost/ping")
+	}
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// VersionHandler returns a HTTP handler that dispatches the requests to the
+// handler of the API version read from the given request header. Requests that
+// do not specify a version are dispatched to the handler of the def version.
+// Requests for versions that have no handler are rejected with a 400 Bad
+// Request response or with a 406 Not Acceptable response if header is
+// "Accept". The generated servers use VersionHandler to serve the endpoints
+// that define the same route for different API versions.
+//
+// See RequestVersion for how the version is read from the request.
+func VersionHandler(header, def string, handlers map[string]http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", header)
+		v := RequestVersion(r, header)
+		if v == "" {
+			v = def
+		}
+		h, ok := handlers[v]
+		if !ok {
+			status := http.StatusBadRequest
+			if isAccept(header) {
+				status = http.StatusNotAcceptable
+			}
+			ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+			enc := ResponseEncoder(ctx, w)
+			w.WriteHeader(status)
+			enc.Encode(NewErrorResponse(goa.PermanentError("unsupported_version", "unsupported API version %q", v)))
+			return
+		}
+		h.ServeHTTP(w, r)
+	}
+}
+
+// RequestVersion returns the API version read from the given request header,
+// the empty string if the request does not specify a version. The version is
+// read from the "version" parameter of the first media type that defines one
+// if header is "Accept", e.g. "Accept: application/json; version=2".
+func RequestVersion(r *http.Request, header string) string {
+	val := r.Header.Get(header)
+	if !isAccept(header) {
+		return strings.TrimSpace(val)
+	}
+	for _, mt := range strings.Split(val, ",") {
+		if _, params, err := mime.ParseMediaType(mt); err == nil {
+			if v, ok := params["version"]; ok {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// SetRequestVersion sets the given request header so that the request selects
+// the given API version. If header is "Accept" the version is set as the
+// "version" parameter of the requested media type which defaults to
+// "application/json". The generated clients call SetRequestVersion when
+// building the requests made to versioned endpoints.
+func SetRequestVersion(req *http.Request, header, version string) {
+	if !isAccept(header) {
+		req.Header.Set(header, version)
+		return
+	}
+	mt, params, err := mime.ParseMediaType(req.Header.Get("Accept"))
+	if err != nil {
+		mt, params = "application/json", make(map[string]string)
+	}
+	params["version"] = version
+	req.Header.Set("Accept", mime.FormatMediaType(mt, params))
+}
+
+// isAccept returns true if the given header name is "Accept".
+func isAccept(header string) bool {
+	return strings.EqualFold(header, "Accept")
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersionHandler(t *testing.T) {
+	handler := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name))
+		})
+	}
+	handlers := map[string]http.Handler{"1": handler("v1"), "2": handler("v2")}
+	cases := []struct {
+		name           string
+		header         string
+		def            string
+		value          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"default", "X-API-Version", "2", "", http.StatusOK, "v2"},
+		{"version", "X-API-Version", "2", "1", http.StatusOK, "v1"},
+		{"unknown", "X-API-Version", "2", "3", http.StatusBadRequest, ""},
+		{"accept-default", "Accept", "1", "application/json", http.StatusOK, "v1"},
+		{"accept-version", "Accept", "1", "application/json; version=2", http.StatusOK, "v2"},
+		{"accept-list", "Accept", "1", "text/html, application/json;version=2", http.StatusOK, "v2"},
+		{"accept-unknown", "Accept", "1", "application/json; version=3", http.StatusNotAcceptable, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.value != "" {
+				req.Header.Set(c.header, c.value)
+			}
+			rw := httptest.NewRecorder()
+			VersionHandler(c.header, c.def, handlers).ServeHTTP(rw, req)
+			if rw.Code != c.expectedStatus {
+				t.Errorf("got status %d, expected %d", rw.Code, c.expectedStatus)
+			}
+			if c.expectedBody != "" && rw.Body.String() != c.expectedBody {
+				t.Errorf("got body %q, expected %q", rw.Body.String(), c.expectedBody)
+			}
+			if vary := rw.Header().Get("Vary"); vary != c.header {
+				t.Errorf("got Vary header %q, expected %q", vary, c.header)
+			}
+		})
+	}
+}
+
+func TestSetRequestVersion(t *testing.T) {
+	cases := []struct {
+		name     string
+		header   string
+		accept   string
+		expected string
+	}{
+		{"header", "X-API-Version", "", "2"},
+		{"accept", "Accept", "", "application/json; version=2"},
+		{"accept-existing", "Accept", "application/xml", "application/xml; version=2"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.accept != "" {
+				req.Header.Set("Accept", c.accept)
+			}
+			SetRequestVersion(req, c.header, "2")
+			if actual := req.Header.Get(c.header); actual != c.expected {
+				t.Errorf("got %q, expected %q", actual, c.expected)
+			}
+			if v := RequestVersion(req, c.header); v != "2" {
+				t.Errorf("got version %q, expected %q", v, "2")
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// WebhookEventHeader is the name of the header that contains the name
+	// of the webhook event.
+	WebhookEventHeader = "Webhook-Event"
+	// WebhookTimestampHeader is the name of the header that contains the
+	// time the webhook request was signed as a Unix timestamp.
+	WebhookTimestampHeader = "Webhook-Timestamp"
+	// WebhookSignatureHeader is the name of the header that contains the
+	// signature of the webhook request, see SignWebhook.
+	WebhookSignatureHeader = "Webhook-Signature"
+)
+
+type (
+	// WebhookSender sends signed webhook requests and retries the requests
+	// that fail with a network error or a retryable status code (429 and
+	// 5xx).
+	WebhookSender struct {
+		doer        Doer
+		secret      []byte
+		maxAttempts int
+		backoff     func(attempt int) time.Duration
+	}
+
+	// WebhookOption configures a WebhookSender.
+	WebhookOption func(*WebhookSender)
+)
+
+// NewWebhookSender returns a webhook sender that uses doer to send the
+// requests and signs them with secret. By default the sender makes up to 3
+// attempts and waits 1s then 2s between them.
+func NewWebhookSender(doer Doer, secret []byte, opts ...WebhookOption) *WebhookSender {
+	s := &WebhookSender{
+		doer:        doer,
+		secret:      secret,
+		maxAttempts: 3,
+		backoff: func(attempt int) time.Duration {
+			return time.Duration(1<<uint(attempt-1)) * time.Second
+		},
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// WithWebhookMaxAttempts sets the maximum number of attempts made to send a
+// webhook request.
+func WithWebhookMaxAttempts(n int) WebhookOption {
+	return func(s *WebhookSender) {
+		if n > 0 {
+			s.maxAttempts = n
+		}
+	}
+}
+
+// WithWebhookBackoff sets the function that computes the time to wait after
+// the given failed attempt (starting at 1) before retrying.
+func WithWebhookBackoff(backoff func(attempt int) time.Duration) WebhookOption {
+	return func(s *WebhookSender) {
+		s.backoff = backoff
+	}
+}
+
+// Send encodes body to JSON and sends it to url with a POST request signed
+// with the sender secret. event is the name of the webhook event set in the
+// Webhook-Event header. Send retries the request until it succeeds, the
+// maximum number of attempts is reached or ctx is done.
+func (s *WebhookSender) Send(ctx context.Context, url, event string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q webhook body: %s", event, err)
+	}
+	for attempt := 1; ; attempt++ {
+		retry, err := s.send(ctx, url, event, b)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.maxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.backoff(attempt)):
+		}
+	}
+}
+
+// send makes a single webhook request attempt. It returns true if the request
+// failed and may be retried.
+func (s *WebhookSender) send(ctx context.Context, url, event string, body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("invalid %q webhook URL %q: %s", event, url, err)
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookTimestampHeader, ts)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(s.secret, ts, body))
+	resp, err := s.doer.Do(req.WithContext(ctx))
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("failed to send %q webhook: %s", event, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("failed to send %q webhook: unexpected status %s", event, resp.Status)
+}
+
+// SignWebhook computes the signature of a webhook request: the hex encoded
+// HMAC-SHA256 of the timestamp followed by a dot and the body, prefixed with
+// "sha256=".
+func SignWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook returns true if signature is the signature of the webhook
+// request with the given timestamp and body. Receivers should also reject
+// requests whose timestamp is too old to prevent replay attacks.
+func VerifyWebhook(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookSenderSend(t *testing.T) {
+	secret := []byte("secret")
+	cases := []struct {
+		Name     string
+		Statuses []int
+		Attempts int
+		Error    bool
+	}{
+		{"success", []int{200}, 1, false},
+		{"retry 5xx", []int{503, 500, 204}, 3, false},
+		{"retry 429", []int{429, 200}, 2, false},
+		{"no retry 4xx", []int{400}, 1, true},
+		{"max attempts", []int{500, 500, 500, 200}, 3, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var attempts int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if got := r.Header.Get(WebhookEventHeader); got != "created" {
+					t.Errorf("got event %q, expected %q", got, "created")
+				}
+				ts := r.Header.Get(WebhookTimestampHeader)
+				if !VerifyWebhook(secret, ts, body, r.Header.Get(WebhookSignatureHeader)) {
+					t.Errorf("invalid signature %q", r.Header.Get(WebhookSignatureHeader))
+				}
+				if string(body) != `{"id":1}` {
+					t.Errorf("got body %s", body)
+				}
+				w.WriteHeader(c.Statuses[attempts])
+				attempts++
+			}))
+			defer srv.Close()
+			s := NewWebhookSender(http.DefaultClient, secret, WithWebhookBackoff(func(int) time.Duration { return 0 }))
+
+			err := s.Send(context.Background(), srv.URL, "created", map[string]int{"id": 1})
+
+			if c.Error && err == nil {
+				t.Error("expected an error")
+			}
+			if !c.Error && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if attempts != c.Attempts {
+				t.Errorf("got %d attempts, expected %d", attempts, c.Attempts)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	secret := []byte("secret")
+	sig := SignWebhook(secret, "1", []byte("body"))
+	if !VerifyWebhook(secret, "1", []byte("body"), sig) {
+		t.Error("valid signature rejected")
+	}
+	if VerifyWebhook(secret, "2", []byte("body"), sig) {
+		t.Error("signature with different timestamp accepted")
+	}
+	if VerifyWebhook([]byte("other"), "1", []byte("body"), sig) {
+		t.Error("signature with different secret accepted")
+	}
+}
@@ -21,4 +21,9 @@ const (
 	// TraceParentSpanIDKey is the request context key used to store the current
 	// trace parent span ID if any.
 	TraceParentSpanIDKey
+
+	// LogFieldsKey is the request context key used to store the log fields
+	// added by the generated endpoints for the payload attributes that use
+	// the LogField DSL.
+	LogFieldsKey
 )
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Faults maps endpoints to the latency and errors injected into their
+	// requests by the FaultInjection middleware. The keys are either
+	// "<service>.<method>", "<service>" or "*", the most specific key that
+	// matches an endpoint applies.
+	Faults map[string]*Fault
+
+	// Fault describes the latency and the errors injected into the requests
+	// made to an endpoint.
+	Fault struct {
+		// Latency is the distribution of the delay added before the
+		// endpoint is called. No delay is added if nil.
+		Latency *Latency `json:"latency,omitempty"`
+		// ErrorRate is the fraction of the requests that fail with an
+		// injected error, between 0 and 1.
+		ErrorRate float64 `json:"error_rate,omitempty"`
+		// Error is the kind of the injected errors: "fault" (the
+		// default), "timeout" or "unavailable". The HTTP transport maps
+		// them to the 500, 504 and 503 status codes respectively.
+		Error string `json:"error,omitempty"`
+	}
+
+	// Latency describes the distribution of the delays added to the
+	// requests.
+	Latency struct {
+		// Distribution is either "constant" (the default), "uniform",
+		// "normal" or "exponential".
+		Distribution string `json:"distribution,omitempty"`
+		// Mean is the delay of the constant distribution and the mean of
+		// the normal and exponential distributions.
+		Mean goa.Duration `json:"mean,omitempty"`
+		// StdDev is the standard deviation of the normal distribution.
+		StdDev goa.Duration `json:"stddev,omitempty"`
+		// Min and Max bound the delays of all the distributions, they
+		// are the bounds of the uniform distribution. Max is ignored if
+		// zero.
+		Min goa.Duration `json:"min,omitempty"`
+		Max goa.Duration `json:"max,omitempty"`
+	}
+)
+
+// FaultInjection returns a middleware that delays and fails the requests made
+// to the endpoints as described by the faults returned by the given function.
+// The function is called on each request so that the faults can change while
+// the server runs, it may return nil to disable the injection. The middleware
+// relies on the service and method names stored in the request context by the
+// generated transport handlers.
+func FaultInjection(faults func() Faults) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			f := faults().lookup(ctx)
+			if f == nil {
+				return e(ctx, req)
+			}
+			if f.Latency != nil {
+				t := time.NewTimer(f.Latency.sample())
+				select {
+				case <-t.C:
+				case <-ctx.Done():
+					t.Stop()
+					return nil, ctx.Err()
+				}
+			}
+			if f.ErrorRate > 0 && rand.Float64() < f.ErrorRate {
+				return nil, f.err()
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// Validate returns an error if the faults are invalid.
+func (fs Faults) Validate() error {
+	for key, f := range fs {
+		if f == nil {
+			continue
+		}
+		if f.ErrorRate < 0 || f.ErrorRate > 1 {
+			return fmt.Errorf("faults %q: error rate must be between 0 and 1, got %v", key, f.ErrorRate)
+		}
+		switch f.Error {
+		case "", "fault", "timeout", "unavailable":
+		default:
+			return fmt.Errorf("faults %q: unknown error %q, must be one of fault, timeout or unavailable", key, f.Error)
+		}
+		if l := f.Latency; l != nil {
+			switch l.Distribution {
+			case "", "constant", "uniform", "normal", "exponential":
+			default:
+				return fmt.Errorf("faults %q: unknown latency distribution %q, must be one of constant, uniform, normal or exponential", key, l.Distribution)
+			}
+			if l.Mean < 0 || l.StdDev < 0 || l.Min < 0 || l.Max < 0 {
+				return fmt.Errorf("faults %q: latency durations cannot be negative", key)
+			}
+			if l.Distribution == "uniform" && l.Max == 0 {
+				return fmt.Errorf("faults %q: uniform latency distribution requires max", key)
+			}
+			if l.Max > 0 && l.Max < l.Min {
+				return fmt.Errorf("faults %q: latency max cannot be lower than min", key)
+			}
+		}
+	}
+	return nil
+}
+
+// lookup returns the fault that applies to the endpoint whose service and
+// method names are stored in ctx, nil if there is none.
+func (fs Faults) lookup(ctx context.Context) *Fault {
+	if len(fs) == 0 {
+		return nil
+	}
+	svc, _ := ctx.Value(goa.ServiceKey).(string)
+	meth, _ := ctx.Value(goa.MethodKey).(string)
+	for _, key := range []string{svc + "." + meth, svc, "*"} {
+		if f, ok := fs[key]; ok {
+			return f
+		}
+	}
+	return nil
+}
+
+// err returns the error injected by f.
+func (f *Fault) err() error {
+	switch f.Error {
+	case "timeout":
+		return goa.TemporaryTimeoutError("timeout", "injected timeout")
+	case "unavailable":
+		return goa.TemporaryError("unavailable", "injected unavailability")
+	default:
+		return goa.Fault("injected fault")
+	}
+}
+
+// sample returns a delay drawn from the distribution.
+func (l *Latency) sample() time.Duration {
+	var d float64
+	switch l.Distribution {
+	case "uniform":
+		d = float64(l.Min) + rand.Float64()*float64(l.Max-l.Min)
+	case "normal":
+		d = float64(l.Mean) + rand.NormFloat64()*float64(l.StdDev)
+	case "exponential":
+		d = rand.ExpFloat64() * float64(l.Mean)
+	default:
+		d = float64(l.Mean)
+	}
+	d = math.Max(d, float64(l.Min))
+	if l.Max > 0 {
+		d = math.Min(d, float64(l.Max))
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestFaultInjection(t *testing.T) {
+	var (
+		always  = &Fault{ErrorRate: 1, Error: "unavailable"}
+		never   = &Fault{ErrorRate: 0}
+		delayed = &Fault{Latency: &Latency{Mean: goa.Duration(50 * time.Millisecond)}}
+	)
+	cases := []struct {
+		Name         string
+		Faults       Faults
+		ExpectedErr  string
+		ExpectedWait time.Duration
+	}{
+		{"none", nil, "", 0},
+		{"method", Faults{"svc.meth": always, "svc": never}, "unavailable", 0},
+		{"service", Faults{"svc.other": never, "svc": always}, "unavailable", 0},
+		{"wildcard", Faults{"other": never, "*": always}, "unavailable", 0},
+		{"no-match", Faults{"other": always}, "", 0},
+		{"latency", Faults{"svc": delayed}, "", 50 * time.Millisecond},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			called := false
+			e := FaultInjection(func() Faults { return c.Faults })(func(context.Context, interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			ctx := context.WithValue(context.Background(), goa.ServiceKey, "svc")
+			ctx = context.WithValue(ctx, goa.MethodKey, "meth")
+			start := time.Now()
+			_, err := e(ctx, nil)
+			if c.ExpectedErr == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error %v", err)
+				}
+				if !called {
+					t.Error("endpoint not called")
+				}
+			} else {
+				serr, ok := err.(*goa.ServiceError)
+				if !ok || serr.Name != c.ExpectedErr {
+					t.Fatalf("got error %v, expected %q", err, c.ExpectedErr)
+				}
+				if called {
+					t.Error("endpoint called")
+				}
+			}
+			if elapsed := time.Since(start); elapsed < c.ExpectedWait {
+				t.Errorf("got delay %s, expected at least %s", elapsed, c.ExpectedWait)
+			}
+		})
+	}
+}
+
+func TestFaultInjectionCanceled(t *testing.T) {
+	faults := Faults{"*": {Latency: &Latency{Mean: goa.Duration(time.Minute)}}}
+	e := FaultInjection(func() Faults { return faults })(func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := e(ctx, nil); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLatencySample(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Latency  *Latency
+		Min, Max time.Duration
+	}{
+		{"constant", &Latency{Mean: goa.Duration(time.Second)}, time.Second, time.Second},
+		{"uniform", &Latency{Distribution: "uniform", Min: goa.Duration(time.Second), Max: goa.Duration(2 * time.Second)}, time.Second, 2 * time.Second},
+		{"normal-bounded", &Latency{Distribution: "normal", Mean: goa.Duration(time.Second), StdDev: goa.Duration(time.Second), Max: goa.Duration(1500 * time.Millisecond)}, 0, 1500 * time.Millisecond},
+		{"exponential-min", &Latency{Distribution: "exponential", Mean: goa.Duration(time.Millisecond), Min: goa.Duration(time.Second)}, time.Second, time.Hour},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if d := c.Latency.sample(); d < c.Min || d > c.Max {
+					t.Fatalf("got delay %s, expected between %s and %s", d, c.Min, c.Max)
+				}
+			}
+		})
+	}
+}
+
+func TestFaultsValidate(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Faults   Faults
+		Expected string
+	}{
+		{"valid", Faults{"svc": {ErrorRate: 0.5, Error: "timeout", Latency: &Latency{Distribution: "normal", Mean: goa.Duration(time.Second)}}}, ""},
+		{"error-rate", Faults{"svc": {ErrorRate: 2}}, `faults "svc": error rate must be between 0 and 1, got 2`},
+		{"error", Faults{"svc": {Error: "foo"}}, `faults "svc": unknown error "foo", must be one of fault, timeout or unavailable`},
+		{"distribution", Faults{"svc": {Latency: &Latency{Distribution: "foo"}}}, `faults "svc": unknown latency distribution "foo", must be one of constant, uniform, normal or exponential`},
+		{"uniform-max", Faults{"svc": {Latency: &Latency{Distribution: "uniform"}}}, `faults "svc": uniform latency distribution requires max`},
+		{"min-max", Faults{"svc": {Latency: &Latency{Min: goa.Duration(2 * time.Second), Max: goa.Duration(time.Second)}}}, `faults "svc": latency max cannot be lower than min`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := c.Faults.Validate()
+			if c.Expected == "" {
+				if err != nil {
+					t.Errorf("got unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.Expected {
+				t.Errorf("got error %v, expected %q", err, c.Expected)
+			}
+		})
+	}
+}
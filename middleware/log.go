@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
+	"sync"
 )
 
 type (
@@ -20,6 +22,13 @@ type (
 	adapter struct {
 		*log.Logger
 	}
+
+	// logFields holds the key/value pairs added to the log context of a
+	// request.
+	logFields struct {
+		mu      sync.Mutex
+		keyvals []interface{}
+	}
 )
 
 // NewLogger creates a Logger backed by a stdlib logger.
@@ -43,3 +52,41 @@ func (a *adapter) Log(keyvals ...interface{}) error {
 	a.Logger.Printf(fm.String(), vals...)
 	return nil
 }
+
+// WithLogContext returns a copy of ctx that holds an empty log context. The key
+// value pairs added to the log context with AddLogFields by the handlers
+// invoked with the returned context, including the generated endpoints, are
+// visible to LogFields called with ctx. The logging middlewares call
+// WithLogContext so that the entries they produce once the request completes
+// include these fields.
+func WithLogContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, LogFieldsKey, &logFields{})
+}
+
+// AddLogFields adds the given alternating keys and values to the log context
+// of ctx. AddLogFields creates the log context if ctx does not hold one yet and
+// returns the resulting context.
+func AddLogFields(ctx context.Context, keyvals ...interface{}) context.Context {
+	lf, ok := ctx.Value(LogFieldsKey).(*logFields)
+	if !ok {
+		lf = &logFields{}
+		ctx = context.WithValue(ctx, LogFieldsKey, lf)
+	}
+	lf.mu.Lock()
+	lf.keyvals = append(lf.keyvals, keyvals...)
+	lf.mu.Unlock()
+	return ctx
+}
+
+// LogFields returns the alternating keys and values held by the log context of
+// ctx, nil if there is none. The result is typically appended to the key value
+// pairs given to Logger.Log to correlate log entries with the request.
+func LogFields(ctx context.Context) []interface{} {
+	lf, ok := ctx.Value(LogFieldsKey).(*logFields)
+	if !ok {
+		return nil
+	}
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	return append([]interface{}(nil), lf.keyvals...)
+}
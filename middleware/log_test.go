@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestLogFields(t *testing.T) {
+	if fields := LogFields(context.Background()); fields != nil {
+		t.Errorf("got %v, expected nil", fields)
+	}
+
+	// fields added downstream are visible upstream
+	ctx := WithLogContext(context.Background())
+	inner := AddLogFields(ctx, "order_id", "123")
+	AddLogFields(inner, "carrier", "ups")
+	expected := []interface{}{"order_id", "123", "carrier", "ups"}
+	if fields := LogFields(ctx); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("got %v, expected %v", fields, expected)
+	}
+
+	// the log context is created if missing
+	ctx = AddLogFields(context.Background(), "order_id", "123")
+	expected = []interface{}{"order_id", "123"}
+	if fields := LogFields(ctx); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("got %v, expected %v", fields, expected)
+	}
+}
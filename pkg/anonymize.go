@@ -0,0 +1,24 @@
+package goa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskString returns a string made of as many asterisks as s has characters.
+// The generated Anonymize methods use it to anonymize the attributes marked
+// with Sensitive(SensitiveMask).
+func MaskString(s string) string {
+	return strings.Repeat("*", utf8.RuneCountInString(s))
+}
+
+// HashString returns the hex encoded SHA-256 hash of s. The generated
+// Anonymize methods use it to anonymize the attributes marked with
+// Sensitive(SensitiveHash) so that the anonymized values can still be
+// correlated.
+func HashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
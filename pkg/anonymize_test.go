@@ -0,0 +1,29 @@
+package goa
+
+import "testing"
+
+func TestMaskString(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected string
+	}{
+		{"empty", "", ""},
+		{"ascii", "secret", "******"},
+		{"multibyte", "héllo", "*****"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := MaskString(c.Value); actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestHashString(t *testing.T) {
+	const expected = "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"
+	if actual := HashString("secret"); actual != expected {
+		t.Errorf("got %q, expected %q", actual, expected)
+	}
+}
@@ -0,0 +1,166 @@
+package goa
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal is the default type used by the generated code to hold the values of
+// attributes of type Decimal. It holds an arbitrary-precision decimal number
+// and preserves its scale, that is the number of digits after the decimal
+// point (e.g. "12.50" has a scale of 2). Decimals are encoded as JSON strings
+// and decoded from either JSON strings or numbers. Use Rat and
+// NewDecimalFromRat to do arithmetic with math/big. The zero value is 0.
+type Decimal struct {
+	// s is the textual representation of the decimal, "" means "0".
+	s string
+}
+
+// decimalRegex matches the decimal representations accepted by ParseDecimal.
+var decimalRegex = regexp.MustCompile(`^([+-])?([0-9]*)(?:\.([0-9]*))?(?:[eE]([+-]?[0-9]+))?$`)
+
+// ParseDecimal parses the decimal representation of a number, e.g. "-12.50".
+// Numbers using an exponent (e.g. "1.5e3") are also accepted.
+func ParseDecimal(s string) (Decimal, error) {
+	m := decimalRegex.FindStringSubmatch(s)
+	if m == nil || m[2] == "" && m[3] == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	sign, ip, fp := m[1], m[2], m[3]
+	if m[4] != "" {
+		exp, err := strconv.Atoi(m[4])
+		if err != nil || exp > 1000 || exp < -1000 {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		digits := ip + fp
+		point := len(ip) + exp
+		switch {
+		case point <= 0:
+			ip, fp = "", strings.Repeat("0", -point)+digits
+		case point >= len(digits):
+			ip, fp = digits+strings.Repeat("0", point-len(digits)), ""
+		default:
+			ip, fp = digits[:point], digits[point:]
+		}
+	}
+	ip = strings.TrimLeft(ip, "0")
+	if ip == "" {
+		ip = "0"
+	}
+	res := ip
+	if fp != "" {
+		res += "." + fp
+	}
+	if sign == "-" && strings.Trim(res, "0.") != "" {
+		res = "-" + res
+	}
+	return Decimal{s: res}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s cannot be parsed. It
+// is used by the generated code to initialize values that have already been
+// validated.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimalFromRat returns the decimal representation of r rounded to scale
+// digits after the decimal point.
+func NewDecimalFromRat(r *big.Rat, scale int) Decimal {
+	return MustParseDecimal(r.FloatString(scale))
+}
+
+// Rat returns the value of d as a math/big rational number.
+func (d Decimal) Rat() *big.Rat {
+	r, _ := new(big.Rat).SetString(d.String())
+	return r
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	_, scale := decimalDigits(d.String())
+	return scale
+}
+
+// Precision returns the total number of digits not counting the leading zeros
+// of the integer part, e.g. 4 for "12.50" and 2 for "0.05".
+func (d Decimal) Precision() int {
+	precision, _ := decimalDigits(d.String())
+	return precision
+}
+
+// String returns the decimal representation of d.
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+	return d.s
+}
+
+// MarshalText encodes d using its decimal representation.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes the decimal representation in text.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes d as a JSON string so that no precision is lost.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes a JSON string or number.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) > 1 && s[0] == '"' {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return err
+		}
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary encodes d using its decimal representation.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return d.MarshalText()
+}
+
+// UnmarshalBinary decodes the decimal representation in data.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	return d.UnmarshalText(data)
+}
+
+// decimalDigits returns the precision and the scale of the decimal
+// representation s, see Decimal.Precision and Decimal.Scale.
+func decimalDigits(s string) (precision, scale int) {
+	s = strings.TrimLeft(s, "+-")
+	ip := s
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		ip = s[:i]
+		scale = len(s) - i - 1
+	}
+	precision = len(strings.TrimLeft(ip, "0")) + scale
+	if precision == 0 {
+		precision = 1
+	}
+	return precision, scale
+}
@@ -0,0 +1,95 @@
+package goa
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Value     string
+		Expected  string
+		Precision int
+		Scale     int
+		Error     bool
+	}{
+		{"integer", "42", "42", 2, 0, false},
+		{"trailing-zeros", "12.50", "12.50", 4, 2, false},
+		{"leading-zeros", "007.5", "7.5", 2, 1, false},
+		{"fraction", "0.05", "0.05", 2, 2, false},
+		{"no-integer-part", ".5", "0.5", 1, 1, false},
+		{"negative", "-1.25", "-1.25", 3, 2, false},
+		{"plus", "+1.25", "1.25", 3, 2, false},
+		{"negative-zero", "-0.00", "0.00", 2, 2, false},
+		{"exponent", "1.5e3", "1500", 4, 0, false},
+		{"negative-exponent", "15e-3", "0.015", 3, 3, false},
+		{"zero", "0", "0", 1, 0, false},
+		{"empty", "", "", 0, 0, true},
+		{"point", ".", "", 0, 0, true},
+		{"letters", "12a", "", 0, 0, true},
+		{"fraction-slash", "1/2", "", 0, 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDecimal(c.Value)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if d.String() != c.Expected {
+				t.Errorf("got %q, expected %q", d.String(), c.Expected)
+			}
+			if d.Precision() != c.Precision {
+				t.Errorf("got precision %d, expected %d", d.Precision(), c.Precision)
+			}
+			if d.Scale() != c.Scale {
+				t.Errorf("got scale %d, expected %d", d.Scale(), c.Scale)
+			}
+		})
+	}
+}
+
+func TestDecimalRat(t *testing.T) {
+	d := MustParseDecimal("12.50")
+	sum := new(big.Rat).Add(d.Rat(), big.NewRat(1, 4))
+	if got := NewDecimalFromRat(sum, 2).String(); got != "12.75" {
+		t.Errorf("got %q, expected %q", got, "12.75")
+	}
+	var zero Decimal
+	if zero.Rat().Sign() != 0 {
+		t.Errorf("got %s, expected 0", zero.Rat())
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	type body struct {
+		Amount Decimal `json:"amount"`
+	}
+	b, err := json.Marshal(body{MustParseDecimal("10.10")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(b) != `{"amount":"10.10"}` {
+		t.Errorf("got %s, expected %s", b, `{"amount":"10.10"}`)
+	}
+	for _, data := range []string{`{"amount":"10.10"}`, `{"amount":10.10}`} {
+		var v body
+		if err := json.Unmarshal([]byte(data), &v); err != nil {
+			t.Fatalf("unexpected error decoding %s: %s", data, err)
+		}
+		if v.Amount.String() != "10.10" {
+			t.Errorf("got %q decoding %s, expected %q", v.Amount.String(), data, "10.10")
+		}
+	}
+	var v body
+	if err := json.Unmarshal([]byte(`{"amount":"ten"}`), &v); err == nil {
+		t.Errorf("got no error decoding invalid decimal, expected one")
+	}
+}
@@ -0,0 +1,95 @@
+package goa
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is the type used by the generated transport types to hold the
+// values of attributes of type Duration. It is identical to time.Duration
+// except that it is encoded using the Go duration format (e.g. "1h30m") and
+// decoded from either the Go or the ISO 8601 (e.g. "PT1H30M") duration
+// formats. JSON numbers are also accepted and interpreted as a number of
+// nanoseconds.
+type Duration time.Duration
+
+// isoDurationRegex matches ISO 8601 durations that do not use years or
+// months, the length of these is not fixed.
+var isoDurationRegex = regexp.MustCompile(`^([-+])?P(?:([0-9]+(?:[.,][0-9]+)?)W)?(?:([0-9]+(?:[.,][0-9]+)?)D)?(?:T(?:([0-9]+(?:[.,][0-9]+)?)H)?(?:([0-9]+(?:[.,][0-9]+)?)M)?(?:([0-9]+(?:[.,][0-9]+)?)S)?)?$`)
+
+// isoDurationUnits lists the units of the ISO 8601 duration components in the
+// order they appear in isoDurationRegex.
+var isoDurationUnits = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// ParseDuration parses a duration using either the Go duration format (e.g.
+// "300ms" or "1h30m", see time.ParseDuration) or the ISO 8601 duration format
+// (e.g. "PT5M" or "P1DT12H"). ISO 8601 durations that use years or months are
+// rejected as their length is not fixed.
+func ParseDuration(s string) (time.Duration, error) {
+	iso := strings.HasPrefix(strings.TrimLeft(s, "+-"), "P")
+	if !iso {
+		return time.ParseDuration(s)
+	}
+	m := isoDurationRegex.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	var d float64
+	for i, unit := range isoDurationUnits {
+		c := m[i+2]
+		if c == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.Replace(c, ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+		}
+		d += v * float64(unit)
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return time.Duration(d), nil
+}
+
+// String returns the duration formatted using the Go duration format.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalText encodes d using the Go duration format.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes the Go or ISO 8601 duration in text.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// UnmarshalJSON decodes a JSON string containing a Go or ISO 8601 duration or
+// a JSON number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("invalid duration %s", data)
+		}
+		*d = Duration(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
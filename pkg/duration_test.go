@@ -0,0 +1,93 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected time.Duration
+		Error    bool
+	}{
+		{"go-millis", "300ms", 300 * time.Millisecond, false},
+		{"go-compound", "1h30m", 90 * time.Minute, false},
+		{"go-negative", "-2s", -2 * time.Second, false},
+		{"iso-minutes", "PT5M", 5 * time.Minute, false},
+		{"iso-days", "P1DT12H", 36 * time.Hour, false},
+		{"iso-weeks", "P2W", 14 * 24 * time.Hour, false},
+		{"iso-fraction", "PT1.5S", 1500 * time.Millisecond, false},
+		{"iso-comma-fraction", "PT0,5S", 500 * time.Millisecond, false},
+		{"iso-negative", "-PT10S", -10 * time.Second, false},
+		{"iso-months", "P1M", 0, true},
+		{"iso-empty", "P", 0, true},
+		{"iso-empty-time", "P1DT", 0, true},
+		{"invalid", "5 minutes", 0, true},
+		{"empty", "", 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDuration(c.Value)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if d != c.Expected {
+				t.Errorf("got %s, expected %s", d, c.Expected)
+			}
+		})
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	var v struct {
+		D Duration `json:"d"`
+	}
+	cases := []struct {
+		Name     string
+		JSON     string
+		Expected time.Duration
+		Error    bool
+	}{
+		{"go", `{"d":"1m30s"}`, 90 * time.Second, false},
+		{"iso", `{"d":"PT1M30S"}`, 90 * time.Second, false},
+		{"nanoseconds", `{"d":1000}`, time.Microsecond, false},
+		{"invalid", `{"d":"soon"}`, 0, true},
+		{"invalid-type", `{"d":true}`, 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			v.D = 0
+			err := json.Unmarshal([]byte(c.JSON), &v)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if time.Duration(v.D) != c.Expected {
+				t.Errorf("got %s, expected %s", v.D, c.Expected)
+			}
+		})
+	}
+
+	v.D = Duration(300 * time.Millisecond)
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	if string(b) != `{"d":"300ms"}` {
+		t.Errorf("got %s, expected %s", b, `{"d":"300ms"}`)
+	}
+}
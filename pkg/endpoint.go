@@ -12,6 +12,21 @@ const (
 	// service as defined in the design. The generated transport code
 	// initializes the corresponding value prior to invoking the endpoint.
 	ServiceKey
+
+	// ViewKey is the request context key used to store the name of the
+	// view selected by the client to render the method result. The
+	// generated transport code initializes the corresponding value prior to
+	// invoking the endpoint when the design lets clients select the view.
+	// The generated endpoint code uses the value in place of the view
+	// returned by the service method.
+	ViewKey
+
+	// FieldMaskKey is the request context key used to store the FieldMask
+	// selecting the result fields returned to the client. The generated
+	// transport code initializes the corresponding value prior to invoking
+	// the endpoint when the design uses the FieldMask DSL. The generated
+	// endpoint code prunes the result according to the mask.
+	FieldMaskKey
 )
 
 type (
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 type (
@@ -24,6 +25,48 @@ type (
 		Temporary bool
 		// Is the error a server-side fault?
 		Fault bool
+		// RetryAfter is the delay after which the request may be retried,
+		// zero if unknown. The generated error constructors initialize it
+		// with the value given to the RetryAfter DSL. The HTTP transport
+		// encodes it in the Retry-After header and the gRPC transport in a
+		// google.rpc.RetryInfo status detail.
+		RetryAfter time.Duration
+		// Fields lists the field validation errors that make up the error.
+		// It is only set by AggregateErrors, see the AggregateErrors DSL.
+		Fields []*FieldError
+		// MessageID identifies the template of the error message in
+		// message catalogs, see Translator. It is empty if the message
+		// cannot be translated.
+		MessageID string
+		// Params contains the values of the message template placeholders
+		// indexed by placeholder name.
+		Params map[string]interface{}
+
+		// violations records the field validation errors created by the
+		// validation error constructors and merged with MergeErrors.
+		violations []*FieldError
+		// messages records the message templates of the violations,
+		// messages[i] describes violations[i].
+		messages []*message
+	}
+
+	// FieldError describes the violation of a validation rule by a single
+	// payload field.
+	FieldError struct {
+		// Path is the path to the invalid field, e.g. "body.address.city".
+		Path string `json:"path" xml:"path" form:"path"`
+		// Rule is the name of the violated validation rule, e.g.
+		// "invalid_format" or "missing_field".
+		Rule string `json:"rule" xml:"rule" form:"rule"`
+		// Message describes the violation.
+		Message string `json:"message" xml:"message" form:"message"`
+	}
+
+	// message identifies the template of a message and the values of its
+	// placeholders.
+	message struct {
+		id     string
+		params map[string]interface{}
 	}
 )
 
@@ -63,25 +106,53 @@ func TemporaryTimeoutError(name, format string, v ...interface{}) *ServiceError
 // MissingPayloadError is the error produced by the generated code when a
 // request is missing a required payload.
 func MissingPayloadError() error {
-	return PermanentError("missing_payload", "missing required payload")
+	e := PermanentError("missing_payload", "missing required payload")
+	e.MessageID, e.Params = "missing_payload", map[string]interface{}{}
+	return e
 }
 
 // DecodePayloadError is the error produced by the generated code when a request
 // body cannot be decoded successfully.
 func DecodePayloadError(msg string) error {
-	return PermanentError("decode_payload", msg)
+	e := PermanentError("decode_payload", msg)
+	e.MessageID, e.Params = "decode_payload", map[string]interface{}{"error": msg}
+	return e
+}
+
+// RequestBodyTooLargeError is the error produced by the generated code when the
+// body of a request is larger than the maximum size set in the design.
+func RequestBodyTooLargeError(max int64) error {
+	e := PermanentError("request_body_too_large", "request body must not be larger than %d bytes", max)
+	e.MessageID, e.Params = "request_body_too_large", map[string]interface{}{"limit": max}
+	return e
+}
+
+// UnsupportedContentEncodingError is the error produced by the generated code
+// when a request body is compressed with a content coding that the endpoint
+// does not accept.
+func UnsupportedContentEncodingError(encoding string) error {
+	e := PermanentError("unsupported_content_encoding", "unsupported content encoding %q", encoding)
+	e.MessageID, e.Params = "unsupported_content_encoding", map[string]interface{}{"encoding": encoding}
+	return e
+}
+
+// UnknownFieldError is the error produced by the generated code when a request
+// body contains a field that is not defined in the design and the method uses
+// strict decoding.
+func UnknownFieldError(name string) error {
+	return newFieldError("unknown_field", name, params{"field": name}, "unknown field %q", name)
 }
 
 // InvalidFieldTypeError is the error produced by the generated code when the
 // type of a payload field does not match the type defined in the design.
 func InvalidFieldTypeError(name string, val interface{}, expected string) error {
-	return PermanentError("invalid_field_type", "invalid value %#v for %q, must be a %s", val, name, expected)
+	return newFieldError("invalid_field_type", name, params{"field": name, "value": val, "type": expected}, "invalid value %#v for %q, must be a %s", val, name, expected)
 }
 
 // MissingFieldError is the error produced by the generated code when a payload
 // is missing a required field.
 func MissingFieldError(name, context string) error {
-	return PermanentError("missing_field", "%q is missing from %s", name, context)
+	return newFieldError("missing_field", fieldPath(name, context), params{"field": name, "context": context}, "%q is missing from %s", name, context)
 }
 
 // InvalidEnumValueError is the error produced by the generated code when the
@@ -89,46 +160,172 @@ func MissingFieldError(name, context string) error {
 // Enum validation.
 func InvalidEnumValueError(name string, val interface{}, allowed []interface{}) error {
 	elems := make([]string, len(allowed))
+	if d, ok := val.(time.Duration); ok {
+		for i, a := range allowed {
+			if n, ok := a.(int); ok {
+				a = time.Duration(n)
+			}
+			elems[i] = fmt.Sprintf("%v", a)
+		}
+		return newFieldError("invalid_enum_value", name, params{"field": name, "value": d, "allowed": strings.Join(elems, ", ")}, "value of %s must be one of %s but got value %v", name, strings.Join(elems, ", "), d)
+	}
 	for i, a := range allowed {
 		elems[i] = fmt.Sprintf("%#v", a)
 	}
-	return PermanentError("invalid_enum_value", "value of %s must be one of %s but got value %#v", name, strings.Join(elems, ", "), val)
+	return newFieldError("invalid_enum_value", name, params{"field": name, "value": val, "allowed": strings.Join(elems, ", ")}, "value of %s must be one of %s but got value %#v", name, strings.Join(elems, ", "), val)
 }
 
 // InvalidFormatError is the error produced by the generated code when the value
 // of a payload field does not match the format validation defined in the
 // design.
 func InvalidFormatError(name, target string, format Format, formatError error) error {
-	return PermanentError("invalid_format", "%s must be formatted as a %s but got value %q, %s", name, format, target, formatError.Error())
+	return newFieldError("invalid_format", name, params{"field": name, "value": target, "format": format, "error": formatError.Error()}, "%s must be formatted as a %s but got value %q, %s", name, format, target, formatError.Error())
 }
 
 // InvalidPatternError is the error produced by the generated code when the
 // value of a payload field does not match the pattern validation defined in the
 // design.
 func InvalidPatternError(name, target string, pattern string) error {
-	return PermanentError("invalid_pattern", "%s must match the regexp %q but got value %q", name, pattern, target)
+	return newFieldError("invalid_pattern", name, params{"field": name, "value": target, "pattern": pattern}, "%s must match the regexp %q but got value %q", name, pattern, target)
+}
+
+// InvalidTimeZoneError is the error produced by the generated code when the
+// value of a payload field does not use the time zone required by the design.
+func InvalidTimeZoneError(name, target, tz string) error {
+	return newFieldError("invalid_time_zone", name, params{"field": name, "value": target, "time_zone": tz}, "%s must be a date time in the %s time zone but got value %q", name, tz, target)
+}
+
+// InvalidDecimalError is the error produced by the generated code when the
+// value of a payload field has more digits than allowed by the precision or
+// scale validations defined in the design. A negative precision or scale means
+// no limit.
+func InvalidDecimalError(name, target string, precision, scale int) error {
+	var (
+		limits []string
+		p      = params{"field": name, "value": target, "precision": precision, "scale": scale}
+	)
+	if precision >= 0 {
+		limits = append(limits, fmt.Sprintf("at most %d digits", precision))
+	}
+	if scale >= 0 {
+		limits = append(limits, fmt.Sprintf("at most %d digits after the decimal point", scale))
+	}
+	e := newFieldError("invalid_decimal", name, p, "%s must have %s but got value %q", name, strings.Join(limits, " and "), target)
+	switch {
+	case scale < 0:
+		setMessageID(e, "invalid_decimal.precision")
+	case precision < 0:
+		setMessageID(e, "invalid_decimal.scale")
+	}
+	return e
+}
+
+// MutuallyExclusiveFieldsError is the error produced by the generated code when
+// more than one of the payload fields listed in a MutuallyExclusive validation
+// is set.
+func MutuallyExclusiveFieldsError(names []string, context string) error {
+	return newFieldError("mutually_exclusive_fields", context, params{"fields": quoteNames(names), "context": context}, "at most one of %s may be set in %s", quoteNames(names), context)
+}
+
+// RequiredTogetherFieldsError is the error produced by the generated code when
+// some but not all of the payload fields listed in a RequiredTogether
+// validation are set.
+func RequiredTogetherFieldsError(names []string, context string) error {
+	return newFieldError("required_together_fields", context, params{"fields": quoteNames(names), "context": context}, "%s must be set together in %s", quoteNames(names), context)
+}
+
+// AtLeastOneOfFieldsError is the error produced by the generated code when none
+// of the payload fields listed in a AtLeastOneOf validation is set.
+func AtLeastOneOfFieldsError(names []string, context string) error {
+	e := newFieldError("missing_field", context, params{"fields": quoteNames(names), "context": context}, "at least one of %s must be set in %s", quoteNames(names), context)
+	setMessageID(e, "missing_field.at_least_one")
+	return e
+}
+
+// RequiredIfFieldError is the error produced by the generated code when a
+// payload field listed in a RequiredIf validation is not set while the tested
+// field has the tested value.
+func RequiredIfFieldError(name, context, field string, value interface{}) error {
+	p := params{"field": name, "context": context, "condition_field": field, "condition_value": value}
+	e := newFieldError("missing_field", fieldPath(name, context), p, "%q must be set in %s when %q is %#v", name, context, field, value)
+	setMessageID(e, "missing_field.required_if")
+	return e
+}
+
+// CustomValidationError is the error produced by the generated code when a
+// function given to the ValidateWith DSL returns err for the value of the
+// payload field with the given name. It returns nil if err is nil and err
+// unchanged if it is already a ServiceError.
+func CustomValidationError(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*ServiceError); ok {
+		return err
+	}
+	return newFieldError("invalid_field", name, params{"field": name, "error": err.Error()}, "%s is invalid: %s", name, err)
+}
+
+// ValidationMessageError returns err with its message replaced by msg. It is
+// used by the generated code for the validations that define a custom message
+// with the ValidationMessage DSL. The occurrences of "{value}" in msg are
+// replaced with the offending value val or with the empty string if val is nil
+// as is the case for required fields. The error name is unchanged but the
+// message is not translated by TranslateError. ValidationMessageError returns
+// nil if err is nil.
+func ValidationMessageError(err error, msg string, val interface{}) error {
+	if err == nil {
+		return nil
+	}
+	var v string
+	if val != nil {
+		v = fmt.Sprint(val)
+	}
+	msg = strings.Replace(msg, "{value}", v, -1)
+	e := asError(err)
+	e.Message = msg
+	e.MessageID, e.Params = "", nil
+	for i, v := range e.violations {
+		v.Message = msg
+		e.messages[i] = &message{}
+	}
+	return e
 }
 
 // InvalidRangeError is the error produced by the generated code when the value
 // of a payload field does not match the range validation defined in the design.
-// value may be an int or a float64.
+// value may be an int, a float64 or a time.Duration.
 func InvalidRangeError(name string, target interface{}, value interface{}, min bool) error {
-	comp := "greater or equal"
+	var (
+		comp = "greater or equal"
+		id   = "invalid_range.min"
+		p    = params{"field": name, "value": target, "limit": value}
+		e    *ServiceError
+	)
 	if !min {
-		comp = "lesser or equal"
+		comp, id = "lesser or equal", "invalid_range.max"
+	}
+	if d, ok := value.(time.Duration); ok {
+		e = newFieldError("invalid_range", name, p, "%s must be %s than %s but got value %v", name, comp, d, target)
+	} else {
+		e = newFieldError("invalid_range", name, p, "%s must be %s than %d but got value %#v", name, comp, value, target)
 	}
-	return PermanentError("invalid_range", "%s must be %s than %d but got value %#v", name, comp, value, target)
+	setMessageID(e, id)
+	return e
 }
 
 // InvalidLengthError is the error produced by the generated code when the value
 // of a payload field does not match the length validation defined in the
 // design.
 func InvalidLengthError(name string, target interface{}, ln, value int, min bool) error {
-	comp := "greater or equal"
+	comp, id := "greater or equal", "invalid_length.min"
 	if !min {
-		comp = "lesser or equal"
+		comp, id = "lesser or equal", "invalid_length.max"
 	}
-	return PermanentError("invalid_length", "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln)
+	p := params{"field": name, "value": target, "length": ln, "limit": value}
+	e := newFieldError("invalid_length", name, p, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln)
+	setMessageID(e, id)
+	return e
 }
 
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
@@ -144,6 +341,15 @@ func NewErrorID() string {
 	return base64.RawURLEncoding.EncodeToString(b)
 }
 
+// quoteNames returns the given names quoted and separated with commas.
+func quoteNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // MergeErrors updates an error by merging another into it. It first converts
 // other into a ServiceError if not already one. The merge algorithm then:
 //
@@ -153,6 +359,8 @@ func NewErrorID() string {
 //
 // * computes Timeout and Temporary by "and"ing the fields of both errors.
 //
+// * uses the largest RetryAfter of both errors.
+//
 // Merge returns the updated error. This makes it possible to return other when
 // err is nil.
 func MergeErrors(err, other error) error {
@@ -174,16 +382,58 @@ func MergeErrors(err, other error) error {
 	e.Timeout = e.Timeout && o.Timeout
 	e.Temporary = e.Temporary && o.Temporary
 	e.Fault = e.Fault && o.Fault
+	if o.RetryAfter > e.RetryAfter {
+		e.RetryAfter = o.RetryAfter
+	}
+	e.violations = append(e.violations, o.violations...)
+	e.messages = append(e.messages, o.messages...)
 
 	return e
 }
 
+// AggregateErrors sets the Fields of err to the list of all the field
+// validation errors merged into it. The generated request decoders of the
+// methods that use the AggregateErrors DSL call AggregateErrors on the
+// validation errors they return so that the error responses list each
+// violation. AggregateErrors returns err unchanged if it is not a ServiceError.
+func AggregateErrors(err error) error {
+	e, ok := err.(*ServiceError)
+	if !ok {
+		return err
+	}
+	e.Fields = e.violations
+	return e
+}
+
 // Error returns the error message.
 func (s *ServiceError) Error() string { return s.Message }
 
+// FieldErrors returns the field validation errors that make up the error. It
+// returns Fields if set and the field validation errors merged into the error
+// otherwise so that transports may describe the violations even if the method
+// does not use the AggregateErrors DSL.
+func (s *ServiceError) FieldErrors() []*FieldError {
+	if s.Fields != nil {
+		return s.Fields
+	}
+	return s.violations
+}
+
 // ErrorName returns the error name.
 func (s *ServiceError) ErrorName() string { return s.Name }
 
+// Is returns true if target is a ServiceError with the same name as s. Is makes
+// it possible to compare errors with the sentinel errors generated for the
+// errors defined in the design using errors.Is, e.g.:
+//
+//    if errors.Is(err, svc.ErrNotFound) {
+//        // handle "not_found" error
+//    }
+func (s *ServiceError) Is(target error) bool {
+	t, ok := target.(*ServiceError)
+	return ok && t.Name == s.Name
+}
+
 func newError(name string, timeout, temporary, fault bool, format string, v ...interface{}) *ServiceError {
 	return &ServiceError{
 		Name:      name,
@@ -195,6 +445,38 @@ func newError(name string, timeout, temporary, fault bool, format string, v ...i
 	}
 }
 
+// params is a shorthand for the message template parameters.
+type params = map[string]interface{}
+
+// newFieldError creates a permanent error that records the violation of the
+// validation rule name by the field with the given path. The message ID of the
+// error is the rule name.
+func newFieldError(name, path string, p params, format string, v ...interface{}) *ServiceError {
+	e := newError(name, false, false, false, format, v...)
+	e.MessageID, e.Params = name, p
+	e.violations = []*FieldError{{Path: path, Rule: name, Message: e.Message}}
+	e.messages = []*message{{id: name, params: p}}
+	return e
+}
+
+// setMessageID sets the message ID of the field error e created with
+// newFieldError.
+func setMessageID(e *ServiceError, id string) {
+	e.MessageID = id
+	e.messages[0].id = id
+}
+
+// fieldPath returns the path of the field with the given name in context. The
+// transport locations used as context by the generated code for the request
+// parameters are not part of the path.
+func fieldPath(name, context string) string {
+	switch context {
+	case "", "header", "query string", "metadata", "message":
+		return name
+	}
+	return context + "." + name
+}
+
 func asError(err error) *ServiceError {
 	e, ok := err.(*ServiceError)
 	if !ok {
@@ -0,0 +1,30 @@
+package goa
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestServiceErrorIs(t *testing.T) {
+	notFound := &ServiceError{Name: "not_found"}
+	cases := []struct {
+		Name     string
+		Err      error
+		Target   error
+		Expected bool
+	}{
+		{"same-name", PermanentError("not_found", "user not found"), notFound, true},
+		{"wrapped", fmt.Errorf("show: %w", PermanentError("not_found", "user not found")), notFound, true},
+		{"other-name", PermanentError("bad_request", "invalid id"), notFound, false},
+		{"other-type", errors.New("not_found"), notFound, false},
+		{"other-target", PermanentError("not_found", "user not found"), errors.New("not_found"), false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := errors.Is(c.Err, c.Target); actual != c.Expected {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package goa
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// EventHandler handles the events published on an EventBus.
+	EventHandler func(ctx context.Context, event interface{}) error
+
+	// EventBus delivers the events published in process to the handlers
+	// subscribed to the event topic. The generated service packages wrap
+	// EventBus with typed publish and subscribe functions for each method
+	// marked with the Event DSL. EventBus is safe for concurrent use.
+	EventBus struct {
+		mu   sync.RWMutex
+		subs map[string][]*eventSubscription
+	}
+
+	// eventSubscription is a handler subscribed to a topic. The pointer
+	// identifies the subscription when unsubscribing.
+	eventSubscription struct {
+		handler EventHandler
+	}
+)
+
+// NewEventBus returns an event bus with no subscriber.
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[string][]*eventSubscription)}
+}
+
+// Subscribe registers h to handle the events published on topic. It returns a
+// function that unregisters h, calling the function more than once has no
+// effect.
+func (b *EventBus) Subscribe(topic string, h EventHandler) (unsubscribe func()) {
+	sub := &eventSubscription{handler: h}
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], sub)
+	b.mu.Unlock()
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.subs[topic]
+		for i, s := range subs {
+			if s == sub {
+				b.subs[topic] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish calls the handlers subscribed to topic with event synchronously in
+// order of subscription. All the handlers are called even if some fail,
+// Publish returns the first error returned by a handler if any.
+func (b *EventBus) Publish(ctx context.Context, topic string, event interface{}) error {
+	b.mu.RLock()
+	subs := b.subs[topic]
+	b.mu.RUnlock()
+	var err error
+	for _, s := range subs {
+		if herr := s.handler(ctx, event); herr != nil && err == nil {
+			err = herr
+		}
+	}
+	return err
+}
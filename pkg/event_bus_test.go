@@ -0,0 +1,44 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEventBus(t *testing.T) {
+	var (
+		bus   = NewEventBus()
+		calls []string
+		errA  = errors.New("a")
+	)
+	unsubA := bus.Subscribe("created", func(_ context.Context, e interface{}) error {
+		calls = append(calls, "a:"+e.(string))
+		return errA
+	})
+	bus.Subscribe("created", func(_ context.Context, e interface{}) error {
+		calls = append(calls, "b:"+e.(string))
+		return errors.New("b")
+	})
+	bus.Subscribe("deleted", func(_ context.Context, e interface{}) error {
+		calls = append(calls, "c:"+e.(string))
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), "created", "1"); err != errA {
+		t.Errorf("got error %v, expected %v", err, errA)
+	}
+	unsubA()
+	unsubA()
+	if err := bus.Publish(context.Background(), "created", "2"); err == nil || err.Error() != "b" {
+		t.Errorf("got error %v, expected b", err)
+	}
+	if err := bus.Publish(context.Background(), "unknown", "3"); err != nil {
+		t.Errorf("got error %v, expected nil", err)
+	}
+	expected := []string{"a:1", "b:1", "b:2"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("got calls %v, expected %v", calls, expected)
+	}
+}
@@ -0,0 +1,58 @@
+package goa
+
+import "strings"
+
+// FieldMask lists the paths of the result fields selected by a client. A path
+// is a dot separated list of attribute names such as "owner.email". The
+// generated transport code initializes the value stored in the request context
+// under the FieldMaskKey key from the "fields" query string parameter (HTTP)
+// or from the "field_mask" request message field (gRPC) of the methods that
+// use the FieldMask DSL. An empty mask selects all the fields.
+type FieldMask []string
+
+// ParseFieldMask parses a comma separated list of field paths such as
+// "name,owner.email".
+func ParseFieldMask(s string) FieldMask {
+	var m FieldMask
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			m = append(m, p)
+		}
+	}
+	return m
+}
+
+// Includes returns true if the field with the given name or any of its
+// sub-fields is selected by the mask.
+func (m FieldMask) Includes(name string) bool {
+	if len(m) == 0 {
+		return true
+	}
+	for _, p := range m {
+		if p == name || strings.HasPrefix(p, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Sub returns the mask that applies to the sub-fields of the field with the
+// given name. The returned mask is empty and thus selects all the sub-fields if
+// m is empty or if m selects the field itself.
+func (m FieldMask) Sub(name string) FieldMask {
+	var sub FieldMask
+	for _, p := range m {
+		if p == name {
+			return nil
+		}
+		if strings.HasPrefix(p, name+".") {
+			sub = append(sub, p[len(name)+1:])
+		}
+	}
+	return sub
+}
+
+// String returns the comma separated list of field paths.
+func (m FieldMask) String() string {
+	return strings.Join(m, ",")
+}
@@ -0,0 +1,51 @@
+package goa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldMask(t *testing.T) {
+	cases := map[string]FieldMask{
+		"":                   nil,
+		"name":               {"name"},
+		"name, owner.email,": {"name", "owner.email"},
+	}
+	for s, expected := range cases {
+		if got := ParseFieldMask(s); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q: got %v, expected %v", s, got, expected)
+		}
+	}
+}
+
+func TestFieldMaskIncludes(t *testing.T) {
+	m := FieldMask{"name", "owner.email"}
+	cases := map[string]bool{
+		"name":  true,
+		"owner": true,
+		"email": false,
+		"nam":   false,
+	}
+	for name, expected := range cases {
+		if got := m.Includes(name); got != expected {
+			t.Errorf("%q: got %v, expected %v", name, got, expected)
+		}
+	}
+	if !FieldMask(nil).Includes("name") {
+		t.Errorf("empty mask does not include name")
+	}
+}
+
+func TestFieldMaskSub(t *testing.T) {
+	m := FieldMask{"name", "owner.email", "owner.address.city", "tags"}
+	cases := map[string]FieldMask{
+		"owner": {"email", "address.city"},
+		"name":  nil,
+		"other": nil,
+	}
+	for name, expected := range cases {
+		if got := m.Sub(name); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q: got %v, expected %v", name, got, expected)
+		}
+	}
+}
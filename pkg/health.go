@@ -0,0 +1,127 @@
+package goa
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type (
+	// HealthCheck verifies that a dependency of the service, e.g. a
+	// database or a downstream service, is available. It returns a non-nil
+	// error if it is not.
+	HealthCheck func(ctx context.Context) error
+
+	// Health holds the checks that report the health of a server. Liveness
+	// checks report whether the process is working and should be restarted
+	// otherwise, readiness checks report whether the process can serve
+	// requests. The health check endpoints generated for the designs that
+	// use the HealthCheck DSL run these checks. Health is safe for
+	// concurrent use.
+	Health struct {
+		mu        sync.RWMutex
+		liveness  map[string]HealthCheck
+		readiness map[string]HealthCheck
+	}
+
+	// HealthReport is the result of running health checks.
+	HealthReport struct {
+		// Status is "ok" if all the checks succeeded, "unavailable"
+		// otherwise.
+		Status string `json:"status"`
+		// Checks maps the names of the checks to their result: "ok" or
+		// the error message.
+		Checks map[string]string `json:"checks,omitempty"`
+	}
+)
+
+const (
+	// HealthStatusOK is the status of the health reports whose checks all
+	// succeeded.
+	HealthStatusOK = "ok"
+	// HealthStatusUnavailable is the status of the health reports with at
+	// least one failed check.
+	HealthStatusUnavailable = "unavailable"
+)
+
+// NewHealth returns a Health with no check. The reports of a Health with no
+// check are always healthy.
+func NewHealth() *Health {
+	return &Health{
+		liveness:  make(map[string]HealthCheck),
+		readiness: make(map[string]HealthCheck),
+	}
+}
+
+// AddLivenessCheck registers the liveness check with the given name. It
+// replaces the liveness check previously registered with the same name if any.
+func (h *Health) AddLivenessCheck(name string, c HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.liveness[name] = c
+}
+
+// AddReadinessCheck registers the readiness check with the given name. It
+// replaces the readiness check previously registered with the same name if
+// any.
+func (h *Health) AddReadinessCheck(name string, c HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.readiness[name] = c
+}
+
+// Live runs the liveness checks concurrently and returns their report.
+func (h *Health) Live(ctx context.Context) *HealthReport {
+	return h.run(ctx, h.liveness)
+}
+
+// Ready runs the readiness checks concurrently and returns their report.
+func (h *Health) Ready(ctx context.Context) *HealthReport {
+	return h.run(ctx, h.readiness)
+}
+
+// OK returns true if all the checks of the report succeeded.
+func (r *HealthReport) OK() bool {
+	return r.Status == HealthStatusOK
+}
+
+// run runs the given checks concurrently and returns their report.
+func (h *Health) run(ctx context.Context, checks map[string]HealthCheck) *HealthReport {
+	h.mu.RLock()
+	names := make([]string, 0, len(checks))
+	fns := make([]HealthCheck, 0, len(checks))
+	for n := range checks {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fns = append(fns, checks[n])
+	}
+	h.mu.RUnlock()
+
+	errs := make([]error, len(fns))
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		wg.Add(1)
+		go func(i int, fn HealthCheck) {
+			defer wg.Done()
+			errs[i] = fn(ctx)
+		}(i, fn)
+	}
+	wg.Wait()
+
+	r := &HealthReport{Status: HealthStatusOK}
+	if len(names) == 0 {
+		return r
+	}
+	r.Checks = make(map[string]string, len(names))
+	for i, n := range names {
+		if errs[i] != nil {
+			r.Status = HealthStatusUnavailable
+			r.Checks[n] = errs[i].Error()
+			continue
+		}
+		r.Checks[n] = HealthStatusOK
+	}
+	return r
+}
@@ -0,0 +1,41 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("connection refused") }
+	cases := []struct {
+		Name     string
+		Checks   map[string]HealthCheck
+		Expected *HealthReport
+	}{
+		{"no-check", nil, &HealthReport{Status: HealthStatusOK}},
+		{"ok", map[string]HealthCheck{"db": ok, "cache": ok}, &HealthReport{Status: HealthStatusOK, Checks: map[string]string{"db": "ok", "cache": "ok"}}},
+		{"failed", map[string]HealthCheck{"db": fail, "cache": ok}, &HealthReport{Status: HealthStatusUnavailable, Checks: map[string]string{"db": "connection refused", "cache": "ok"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := NewHealth()
+			for n, check := range c.Checks {
+				h.AddReadinessCheck(n, check)
+			}
+			h.AddLivenessCheck("always", fail)
+			r := h.Ready(context.Background())
+			if !reflect.DeepEqual(r, c.Expected) {
+				t.Errorf("got report %+v, expected %+v", r, c.Expected)
+			}
+			if r.OK() != (c.Expected.Status == HealthStatusOK) {
+				t.Errorf("got OK %v, expected %v", r.OK(), !r.OK())
+			}
+			if h.Live(context.Background()).OK() {
+				t.Errorf("got live report OK, expected unavailable")
+			}
+		})
+	}
+}
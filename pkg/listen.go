@@ -0,0 +1,118 @@
+package goa
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+)
+
+var (
+	// activatedOnce makes sure the sockets passed by systemd are only
+	// read once.
+	activatedOnce sync.Once
+	// activatedMu protects activated.
+	activatedMu sync.Mutex
+	// activated lists the sockets passed by systemd that have not been
+	// returned by Listen yet.
+	activated []net.Listener
+)
+
+// Listen returns a listener that accepts the connections made to the given
+// URL. URLs with the "unix" scheme listen on the Unix domain socket whose path
+// is the URL host and path (e.g. unix:///var/run/svc.sock), the other URLs
+// listen on the TCP address of the URL host. Listen removes the socket file
+// left behind by a process that did not close its listener.
+//
+// Listen supports systemd socket activation: if the process was started with
+// the LISTEN_PID and LISTEN_FDS environment variables set, Listen returns the
+// socket passed by systemd that listens on the same Unix domain socket path or
+// TCP port as the URL instead of creating a new listener.
+func Listen(u *url.URL) (net.Listener, error) {
+	network, addr := "tcp", u.Host
+	if u.Scheme == "unix" {
+		network, addr = "unix", u.Host+u.Path
+	}
+	if l := activatedListener(network, addr); l != nil {
+		return l, nil
+	}
+	if network == "unix" {
+		removeStaleSocket(addr)
+	}
+	return net.Listen(network, addr)
+}
+
+// activatedListener returns the socket passed by systemd that listens on the
+// given address, nil if there is none.
+func activatedListener(network, addr string) net.Listener {
+	activatedOnce.Do(func() { activated = listenersFromEnv() })
+	activatedMu.Lock()
+	defer activatedMu.Unlock()
+	for i, l := range activated {
+		if l.Addr().Network() != network {
+			continue
+		}
+		if network == "unix" {
+			if l.Addr().String() != addr {
+				continue
+			}
+		} else if port(l.Addr().String()) != port(addr) {
+			continue
+		}
+		activated = append(activated[:i], activated[i+1:]...)
+		return l
+	}
+	return nil
+}
+
+// listenersFromEnv returns the sockets passed by systemd as described by the
+// sd_listen_fds(3) manual. The file descriptors of the sockets start at 3.
+func listenersFromEnv() []net.Listener {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return nil
+	}
+	// Do not pass the sockets to the child processes.
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+	var ls []net.Listener
+	for fd := 3; fd < 3+n; fd++ {
+		f := os.NewFile(uintptr(fd), "LISTEN_FD_"+strconv.Itoa(fd))
+		l, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		ls = append(ls, l)
+	}
+	return ls
+}
+
+// removeStaleSocket removes the Unix domain socket file at path if no process
+// accepts connections on it.
+func removeStaleSocket(path string) {
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode()&os.ModeSocket == 0 {
+		return
+	}
+	if c, err := net.Dial("unix", path); err == nil {
+		c.Close()
+		return
+	}
+	os.Remove(path)
+}
+
+// port returns the port of the given host and port address.
+func port(addr string) string {
+	_, p, err := net.SplitHostPort(addr)
+	if err != nil {
+		return ""
+	}
+	return p
+}
@@ -0,0 +1,63 @@
+package goa
+
+import (
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListenUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "svc.sock")
+	u := &url.URL{Scheme: "unix", Path: path}
+
+	l, err := Listen(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Addr().Network() != "unix" || l.Addr().String() != path {
+		t.Errorf("got address %s %q, expected unix %q", l.Addr().Network(), l.Addr(), path)
+	}
+	if _, err := Listen(u); err == nil {
+		t.Errorf("expected an error listening on a socket in use")
+	}
+
+	// Leave the socket file behind as a process that crashed would.
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+	l, err = Listen(u)
+	if err != nil {
+		t.Fatalf("failed to listen on stale socket: %s", err)
+	}
+	l.Close()
+}
+
+func TestListenActivated(t *testing.T) {
+	sl, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sl.Close()
+	activatedOnce.Do(func() {})
+	activated = []net.Listener{sl}
+	defer func() { activated = nil }()
+
+	u := &url.URL{Scheme: "http", Host: "localhost:" + port(sl.Addr().String())}
+	l, err := Listen(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l != sl {
+		t.Errorf("got listener on %q, expected activated listener on %q", l.Addr(), sl.Addr())
+	}
+	if len(activated) != 0 {
+		t.Errorf("got %d activated listeners left, expected 0", len(activated))
+	}
+}
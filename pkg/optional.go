@@ -0,0 +1,244 @@
+package goa
+
+import "encoding/json"
+
+// The Optional types are used by the generated code to hold the values of the
+// request body fields of the methods that use the OptionalFields DSL. They
+// make it possible to distinguish between a field that is omitted (Set is
+// false), a field explicitly set to null (Set is true and Value is nil) and a
+// field set to a value (Value is not nil).
+//
+// The types implement json.Marshaler and json.Unmarshaler. Fields holding an
+// Optional value should use the "omitzero" JSON struct tag option so that
+// omitted values are not encoded.
+type (
+	// OptionalBool holds a bool value that may be omitted or null.
+	OptionalBool struct {
+		// Value is the value, nil if omitted or null.
+		Value *bool
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalInt holds an int value that may be omitted or null.
+	OptionalInt struct {
+		// Value is the value, nil if omitted or null.
+		Value *int
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalInt32 holds an int32 value that may be omitted or null.
+	OptionalInt32 struct {
+		// Value is the value, nil if omitted or null.
+		Value *int32
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalInt64 holds an int64 value that may be omitted or null.
+	OptionalInt64 struct {
+		// Value is the value, nil if omitted or null.
+		Value *int64
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalUInt holds an uint value that may be omitted or null.
+	OptionalUInt struct {
+		// Value is the value, nil if omitted or null.
+		Value *uint
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalUInt32 holds an uint32 value that may be omitted or null.
+	OptionalUInt32 struct {
+		// Value is the value, nil if omitted or null.
+		Value *uint32
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalUInt64 holds an uint64 value that may be omitted or null.
+	OptionalUInt64 struct {
+		// Value is the value, nil if omitted or null.
+		Value *uint64
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalFloat32 holds a float32 value that may be omitted or null.
+	OptionalFloat32 struct {
+		// Value is the value, nil if omitted or null.
+		Value *float32
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalFloat64 holds a float64 value that may be omitted or null.
+	OptionalFloat64 struct {
+		// Value is the value, nil if omitted or null.
+		Value *float64
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+
+	// OptionalString holds a string value that may be omitted or null.
+	OptionalString struct {
+		// Value is the value, nil if omitted or null.
+		Value *string
+		// Set is true if the value is present, possibly null.
+		Set bool
+	}
+)
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalBool) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalBool) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalBool) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalBool) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalInt) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalInt) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalInt) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalInt) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalInt32) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalInt32) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalInt32) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalInt32) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalInt64) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalInt64) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalInt64) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalInt64) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalUInt) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalUInt) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalUInt) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalUInt) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalUInt32) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalUInt32) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalUInt32) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalUInt32) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalUInt64) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalUInt64) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalUInt64) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalUInt64) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalFloat32) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalFloat32) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalFloat32) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalFloat32) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalFloat64) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalFloat64) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalFloat64) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalFloat64) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// IsNull returns true if the value is explicitly set to null.
+func (o OptionalString) IsNull() bool { return o.Set && o.Value == nil }
+
+// IsZero returns true if the value is omitted.
+func (o OptionalString) IsZero() bool { return !o.Set }
+
+// MarshalJSON encodes the value or null.
+func (o OptionalString) MarshalJSON() ([]byte, error) { return json.Marshal(o.Value) }
+
+// UnmarshalJSON decodes the value and records that it is present.
+func (o *OptionalString) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
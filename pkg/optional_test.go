@@ -0,0 +1,51 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	type body struct {
+		Name OptionalString `json:"name,omitzero"`
+		Age  OptionalInt    `json:"age,omitzero"`
+	}
+	cases := []struct {
+		name        string
+		json        string
+		expectedSet bool
+		expectedNil bool
+		expected    string
+	}{
+		{"omitted", `{}`, false, true, `{}`},
+		{"null", `{"name":null}`, true, true, `{"name":null}`},
+		{"value", `{"name":"foo"}`, true, false, `{"name":"foo"}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var b body
+			if err := json.Unmarshal([]byte(c.json), &b); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if b.Name.Set != c.expectedSet {
+				t.Errorf("got Set %v, expected %v", b.Name.Set, c.expectedSet)
+			}
+			if (b.Name.Value == nil) != c.expectedNil {
+				t.Errorf("got Value %v, expected nil to be %v", b.Name.Value, c.expectedNil)
+			}
+			if b.Name.IsNull() != (c.expectedSet && c.expectedNil) {
+				t.Errorf("got IsNull %v", b.Name.IsNull())
+			}
+			if b.Age.Set {
+				t.Errorf("got Set for omitted field")
+			}
+			js, err := json.Marshal(b)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(js) != c.expected {
+				t.Errorf("got %s, expected %s", js, c.expected)
+			}
+		})
+	}
+}
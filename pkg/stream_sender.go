@@ -0,0 +1,200 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// SendPolicy determines how a StreamSender handles the values given to
+	// Send when its queue is full.
+	SendPolicy int
+
+	// StreamSender sends values through a stream from a bounded queue so
+	// that producers are not slowed down by a slow receiver. A background
+	// goroutine sends the queued values in order with the send function
+	// given to NewStreamSender. The generated service packages wrap
+	// StreamSender with a typed sender for each client stream that sends
+	// values, e.g. NewUploadClientStreamSender. StreamSender is safe for
+	// concurrent use.
+	StreamSender struct {
+		// sent and dropped are accessed atomically and must be first
+		// for 64-bit alignment on 32-bit platforms.
+		sent    uint64
+		dropped uint64
+
+		send   func(interface{}) error
+		policy SendPolicy
+		queue  chan interface{}
+		done   chan struct{}
+		failed chan struct{}
+
+		mu        sync.RWMutex
+		closed    bool
+		closing   chan struct{}
+		closeOnce sync.Once
+
+		errOnce sync.Once
+		err     error
+	}
+
+	// StreamSenderStats describes the state of the queue of a StreamSender.
+	StreamSenderStats struct {
+		// Depth is the number of values waiting in the queue.
+		Depth int
+		// Capacity is the size of the queue.
+		Capacity int
+		// Sent is the number of values sent successfully.
+		Sent uint64
+		// Dropped is the number of values dropped because the queue was
+		// full or because a previous send failed.
+		Dropped uint64
+	}
+)
+
+const (
+	// SendBlock makes Send wait until the queue has room for the value or
+	// the context is done.
+	SendBlock SendPolicy = iota
+	// SendDropNewest makes Send drop the value given to it if the queue is
+	// full.
+	SendDropNewest
+	// SendDropOldest makes Send drop the oldest value of the queue to make
+	// room for the value given to it if the queue is full.
+	SendDropOldest
+)
+
+// ErrStreamSenderClosed is the error returned by StreamSender.Send once the
+// sender is closed.
+var ErrStreamSenderClosed = errors.New("stream sender closed")
+
+// NewStreamSender returns a sender that sends the queued values with send and
+// applies policy when the queue is full. size is the size of the queue, the
+// queue holds one value if size is less than one.
+func NewStreamSender(send func(interface{}) error, size int, policy SendPolicy) *StreamSender {
+	if size < 1 {
+		size = 1
+	}
+	s := &StreamSender{
+		send:    send,
+		policy:  policy,
+		queue:   make(chan interface{}, size),
+		done:    make(chan struct{}),
+		failed:  make(chan struct{}),
+		closing: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Send queues v. It returns the error returned by a previous send if any,
+// ErrStreamSenderClosed if the sender is closed and the context error if ctx
+// is done before the value could be queued with the SendBlock policy. The
+// values dropped with the SendDropNewest and SendDropOldest policies are
+// counted in the stats but do not cause Send to fail.
+func (s *StreamSender) Send(ctx context.Context, v interface{}) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if s.closed {
+		return ErrStreamSenderClosed
+	}
+	switch s.policy {
+	case SendDropNewest:
+		select {
+		case s.queue <- v:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+		return nil
+	case SendDropOldest:
+		for {
+			select {
+			case s.queue <- v:
+				return nil
+			default:
+			}
+			select {
+			case <-s.queue:
+				atomic.AddUint64(&s.dropped, 1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.queue <- v:
+			return nil
+		case <-s.failed:
+			return s.Err()
+		case <-s.closing:
+			return ErrStreamSenderClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Close stops accepting values and waits until the queued values are sent or
+// ctx is done. The calls to Send blocked with the SendBlock policy return
+// ErrStreamSenderClosed. Close returns the first error returned by the send
+// function if any, the context error if ctx is done first. Close does not close
+// the underlying stream.
+func (s *StreamSender) Close(ctx context.Context) error {
+	s.closeOnce.Do(func() { close(s.closing) })
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+	select {
+	case <-s.done:
+		return s.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Err returns the first error returned by the send function, nil if none.
+func (s *StreamSender) Err() error {
+	select {
+	case <-s.failed:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Stats returns the current state of the queue.
+func (s *StreamSender) Stats() StreamSenderStats {
+	return StreamSenderStats{
+		Depth:    len(s.queue),
+		Capacity: cap(s.queue),
+		Sent:     atomic.LoadUint64(&s.sent),
+		Dropped:  atomic.LoadUint64(&s.dropped),
+	}
+}
+
+// run sends the queued values until the queue is closed. The values queued
+// after a send fails are dropped.
+func (s *StreamSender) run() {
+	defer close(s.done)
+	for v := range s.queue {
+		if s.Err() != nil {
+			atomic.AddUint64(&s.dropped, 1)
+			continue
+		}
+		if err := s.send(v); err != nil {
+			s.errOnce.Do(func() {
+				s.err = err
+				close(s.failed)
+			})
+			continue
+		}
+		atomic.AddUint64(&s.sent, 1)
+	}
+}
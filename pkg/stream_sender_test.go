@@ -0,0 +1,122 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedSend returns a send function that records the values it sends and
+// blocks until release is closed. started receives each value before the send
+// blocks.
+func gatedSend(release chan struct{}) (func(interface{}) error, chan interface{}, func() []interface{}) {
+	var (
+		mu      sync.Mutex
+		sent    []interface{}
+		started = make(chan interface{}, 10)
+	)
+	send := func(v interface{}) error {
+		started <- v
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, v)
+		return nil
+	}
+	return send, started, func() []interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return sent
+	}
+}
+
+func TestStreamSenderPolicies(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Policy   SendPolicy
+		Expected []interface{}
+		Dropped  uint64
+	}{
+		{"drop-newest", SendDropNewest, []interface{}{1, 2, 3}, 1},
+		{"drop-oldest", SendDropOldest, []interface{}{1, 3, 4}, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			release := make(chan struct{})
+			send, started, sent := gatedSend(release)
+			s := NewStreamSender(send, 2, c.Policy)
+			if err := s.Send(ctx, 1); err != nil {
+				t.Fatal(err)
+			}
+			<-started
+			for _, v := range []interface{}{2, 3, 4} {
+				if err := s.Send(ctx, v); err != nil {
+					t.Fatalf("got error %v sending %v", err, v)
+				}
+			}
+			if st := s.Stats(); st.Depth != 2 || st.Capacity != 2 || st.Dropped != c.Dropped {
+				t.Errorf("got stats %+v, expected depth 2, capacity 2 and %d dropped", st, c.Dropped)
+			}
+			close(release)
+			if err := s.Close(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sent(), c.Expected) {
+				t.Errorf("got sent values %v, expected %v", sent(), c.Expected)
+			}
+			if st := s.Stats(); st.Sent != uint64(len(c.Expected)) {
+				t.Errorf("got %d sent, expected %d", st.Sent, len(c.Expected))
+			}
+		})
+	}
+}
+
+func TestStreamSenderBlock(t *testing.T) {
+	release := make(chan struct{})
+	send, started, sent := gatedSend(release)
+	s := NewStreamSender(send, 1, SendBlock)
+	if err := s.Send(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if err := s.Send(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Send(ctx, 3); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, expected %v", err, context.DeadlineExceeded)
+	}
+	close(release)
+	if err := s.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sent(), []interface{}{1, 2}) {
+		t.Errorf("got sent values %v, expected [1 2]", sent())
+	}
+	if err := s.Send(context.Background(), 4); err != ErrStreamSenderClosed {
+		t.Errorf("got error %v after close, expected %v", err, ErrStreamSenderClosed)
+	}
+}
+
+func TestStreamSenderError(t *testing.T) {
+	errSend := errors.New("connection reset")
+	s := NewStreamSender(func(interface{}) error { return errSend }, 1, SendBlock)
+	if err := s.Send(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		err = s.Send(context.Background(), i)
+	}
+	if err != errSend {
+		t.Errorf("got error %v, expected %v", err, errSend)
+	}
+	if err := s.Close(context.Background()); err != errSend {
+		t.Errorf("got close error %v, expected %v", err, errSend)
+	}
+}
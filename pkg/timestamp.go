@@ -0,0 +1,137 @@
+package goa
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// TimestampRFC3339 is the format of timestamps encoded as RFC 3339
+	// strings (e.g. "2006-01-02T15:04:05Z").
+	TimestampRFC3339 = "rfc3339"
+
+	// TimestampUnix is the format of timestamps encoded as numbers of
+	// seconds elapsed since January 1, 1970 UTC.
+	TimestampUnix = "unix"
+
+	// TimestampUnixMilli is the format of timestamps encoded as numbers of
+	// milliseconds elapsed since January 1, 1970 UTC.
+	TimestampUnixMilli = "unixmilli"
+)
+
+type (
+	// UnixTime is the type used by the generated transport types to hold
+	// the values of attributes of type Timestamp that use the
+	// TimestampUnix format. It is identical to time.Time except that it is
+	// encoded as a number of seconds elapsed since January 1, 1970 UTC.
+	UnixTime time.Time
+
+	// UnixMilliTime is the type used by the generated transport types to
+	// hold the values of attributes of type Timestamp that use the
+	// TimestampUnixMilli format. It is identical to time.Time except that
+	// it is encoded as a number of milliseconds elapsed since January 1,
+	// 1970 UTC.
+	UnixMilliTime time.Time
+)
+
+// ParseTimestamp parses s using the given timestamp format, one of
+// TimestampRFC3339, TimestampUnix or TimestampUnixMilli.
+func ParseTimestamp(s, format string) (time.Time, error) {
+	switch format {
+	case TimestampUnix, TimestampUnixMilli:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s timestamp %q", format, s)
+		}
+		if format == TimestampUnix {
+			return time.Unix(n, 0).UTC(), nil
+		}
+		return time.Unix(n/1000, (n%1000)*int64(time.Millisecond)).UTC(), nil
+	default:
+		return time.Parse(time.RFC3339Nano, s)
+	}
+}
+
+// FormatTimestamp formats t using the given timestamp format, one of
+// TimestampRFC3339, TimestampUnix or TimestampUnixMilli.
+func FormatTimestamp(t time.Time, format string) string {
+	switch format {
+	case TimestampUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case TimestampUnixMilli:
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
+
+// MarshalJSON encodes t as a JSON number of seconds.
+func (t UnixTime) MarshalJSON() ([]byte, error) {
+	return []byte(FormatTimestamp(time.Time(t), TimestampUnix)), nil
+}
+
+// UnmarshalJSON decodes a JSON number of seconds.
+func (t *UnixTime) UnmarshalJSON(data []byte) error {
+	v, err := ParseTimestamp(string(data), TimestampUnix)
+	if err != nil {
+		return err
+	}
+	*t = UnixTime(v)
+	return nil
+}
+
+// MarshalText encodes t as a number of seconds.
+func (t UnixTime) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// UnmarshalText decodes a number of seconds.
+func (t *UnixTime) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// MarshalBinary encodes t using the binary encoding of time.Time.
+func (t UnixTime) MarshalBinary() ([]byte, error) {
+	return time.Time(t).MarshalBinary()
+}
+
+// UnmarshalBinary decodes t using the binary encoding of time.Time.
+func (t *UnixTime) UnmarshalBinary(data []byte) error {
+	return (*time.Time)(t).UnmarshalBinary(data)
+}
+
+// MarshalJSON encodes t as a JSON number of milliseconds.
+func (t UnixMilliTime) MarshalJSON() ([]byte, error) {
+	return []byte(FormatTimestamp(time.Time(t), TimestampUnixMilli)), nil
+}
+
+// UnmarshalJSON decodes a JSON number of milliseconds.
+func (t *UnixMilliTime) UnmarshalJSON(data []byte) error {
+	v, err := ParseTimestamp(string(data), TimestampUnixMilli)
+	if err != nil {
+		return err
+	}
+	*t = UnixMilliTime(v)
+	return nil
+}
+
+// MarshalText encodes t as a number of milliseconds.
+func (t UnixMilliTime) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// UnmarshalText decodes a number of milliseconds.
+func (t *UnixMilliTime) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+// MarshalBinary encodes t using the binary encoding of time.Time.
+func (t UnixMilliTime) MarshalBinary() ([]byte, error) {
+	return time.Time(t).MarshalBinary()
+}
+
+// UnmarshalBinary decodes t using the binary encoding of time.Time.
+func (t *UnixMilliTime) UnmarshalBinary(data []byte) error {
+	return (*time.Time)(t).UnmarshalBinary(data)
+}
@@ -0,0 +1,84 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	ts := time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		Name     string
+		Value    string
+		Format   string
+		Expected time.Time
+		Error    bool
+	}{
+		{"rfc3339", "2020-06-30T12:00:00Z", TimestampRFC3339, ts, false},
+		{"rfc3339-offset", "2020-06-30T14:00:00+02:00", TimestampRFC3339, ts, false},
+		{"unix", "1593518400", TimestampUnix, ts, false},
+		{"unixmilli", "1593518400250", TimestampUnixMilli, ts.Add(250 * time.Millisecond), false},
+		{"invalid-rfc3339", "2020-06-30", TimestampRFC3339, time.Time{}, true},
+		{"invalid-unix", "2020-06-30T12:00:00Z", TimestampUnix, time.Time{}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			v, err := ParseTimestamp(c.Value, c.Format)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !v.Equal(c.Expected) {
+				t.Errorf("got %s, expected %s", v, c.Expected)
+			}
+		})
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2020, 6, 30, 12, 0, 0, int(250*time.Millisecond), time.UTC)
+	cases := map[string]string{
+		TimestampRFC3339:   "2020-06-30T12:00:00.25Z",
+		TimestampUnix:      "1593518400",
+		TimestampUnixMilli: "1593518400250",
+	}
+	for format, expected := range cases {
+		if actual := FormatTimestamp(ts, format); actual != expected {
+			t.Errorf("%s: got %q, expected %q", format, actual, expected)
+		}
+	}
+}
+
+func TestUnixTimeJSON(t *testing.T) {
+	var v struct {
+		S UnixTime      `json:"s"`
+		M UnixMilliTime `json:"m"`
+	}
+	const data = `{"s":1593518400,"m":1593518400250}`
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ts := time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC)
+	if !time.Time(v.S).Equal(ts) {
+		t.Errorf("got %s, expected %s", time.Time(v.S), ts)
+	}
+	if !time.Time(v.M).Equal(ts.Add(250 * time.Millisecond)) {
+		t.Errorf("got %s, expected %s", time.Time(v.M), ts.Add(250*time.Millisecond))
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(b) != data {
+		t.Errorf("got %s, expected %s", b, data)
+	}
+	if err := json.Unmarshal([]byte(`{"s":"2020-06-30T12:00:00Z"}`), &v); err == nil {
+		t.Errorf("got no error, expected one")
+	}
+}
@@ -0,0 +1,163 @@
+package goa
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// Translator resolves error messages to localized strings. The errors
+	// produced by the generated code record a stable message ID such as
+	// "invalid_length.min" together with the values of the message
+	// parameters such as the field name, see ServiceError.
+	Translator interface {
+		// Translate returns the message identified by id in the language
+		// identified by the BCP 47 tag lang (e.g. "fr-CA") built using
+		// params, false if there is no such message.
+		Translate(lang, id string, params map[string]interface{}) (string, bool)
+	}
+
+	// Catalog is a Translator that maps language tags to message templates
+	// indexed by message ID. The templates refer to the message parameters
+	// using placeholders of the form "{name}", see DefaultMessages for the
+	// English templates and their placeholders. The catalog of a language
+	// can be initialized from the JSON file written by the "goa messages"
+	// command.
+	//
+	// Translate looks up the language tag and then its base language (e.g.
+	// "fr" for "fr-CA"). It also looks up the error name if there is no
+	// template for the message ID (e.g. "invalid_length" for
+	// "invalid_length.min"). Language tags are case insensitive.
+	Catalog map[string]map[string]string
+)
+
+// DefaultMessages lists the English message templates of the errors produced
+// by the generated code indexed by message ID.
+var DefaultMessages = map[string]string{
+	"missing_payload":              "missing required payload",
+	"decode_payload":               "{error}",
+	"request_body_too_large":       "request body must not be larger than {limit} bytes",
+	"unsupported_content_encoding": `unsupported content encoding "{encoding}"`,
+	"unknown_field":                `unknown field "{field}"`,
+	"invalid_field_type":           `invalid value {value} for "{field}", must be a {type}`,
+	"missing_field":                `"{field}" is missing from {context}`,
+	"missing_field.at_least_one":   "at least one of {fields} must be set in {context}",
+	"missing_field.required_if":    `"{field}" must be set in {context} when "{condition_field}" is {condition_value}`,
+	"invalid_enum_value":           "value of {field} must be one of {allowed} but got value {value}",
+	"invalid_format":               `{field} must be formatted as a {format} but got value "{value}", {error}`,
+	"invalid_pattern":              `{field} must match the regexp "{pattern}" but got value "{value}"`,
+	"invalid_time_zone":            `{field} must be a date time in the {time_zone} time zone but got value "{value}"`,
+	"invalid_decimal":              `{field} must have at most {precision} digits and at most {scale} digits after the decimal point but got value "{value}"`,
+	"invalid_decimal.precision":    `{field} must have at most {precision} digits but got value "{value}"`,
+	"invalid_decimal.scale":        `{field} must have at most {scale} digits after the decimal point but got value "{value}"`,
+	"mutually_exclusive_fields":    "at most one of {fields} may be set in {context}",
+	"required_together_fields":     "{fields} must be set together in {context}",
+	"invalid_field":                "{field} is invalid: {error}",
+	"invalid_range.min":            "{field} must be greater or equal than {limit} but got value {value}",
+	"invalid_range.max":            "{field} must be lesser or equal than {limit} but got value {value}",
+	"invalid_length.min":           "length of {field} must be greater or equal than {limit} but got value {value} (len={length})",
+	"invalid_length.max":           "length of {field} must be lesser or equal than {limit} but got value {value} (len={length})",
+}
+
+// Translate returns the message identified by id in the language lang.
+func (c Catalog) Translate(lang, id string, params map[string]interface{}) (string, bool) {
+	msgs := c.messages(lang)
+	if msgs == nil {
+		if i := strings.IndexByte(lang, '-'); i > 0 {
+			msgs = c.messages(lang[:i])
+		}
+	}
+	tmpl, ok := msgs[id]
+	if !ok {
+		if i := strings.IndexByte(id, '.'); i > 0 {
+			tmpl, ok = msgs[id[:i]]
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	return ExpandMessage(tmpl, params), true
+}
+
+// messages returns the templates of the given language, nil if there are none.
+func (c Catalog) messages(lang string) map[string]string {
+	if msgs, ok := c[lang]; ok {
+		return msgs
+	}
+	for l, msgs := range c {
+		if strings.EqualFold(l, lang) {
+			return msgs
+		}
+	}
+	return nil
+}
+
+// ExpandMessage replaces the placeholders of the form "{name}" in tmpl with
+// the values of the corresponding params. The placeholders that do not match
+// a parameter are left unchanged.
+func ExpandMessage(tmpl string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return tmpl
+	}
+	oldnew := make([]string, 0, 2*len(params))
+	for k, v := range params {
+		oldnew = append(oldnew, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(oldnew...).Replace(tmpl)
+}
+
+// TranslateError returns a copy of err where the messages are translated by t
+// in the first language of langs for which t defines them. The field errors
+// listed in Fields are translated as well. The messages of the errors that do
+// not have a message ID such as the errors created by the service methods are
+// unchanged. TranslateError returns err unchanged if it is not a ServiceError.
+func TranslateError(err error, t Translator, langs ...string) error {
+	e, ok := err.(*ServiceError)
+	if !ok || t == nil || len(langs) == 0 {
+		return err
+	}
+	res := *e
+	if len(e.violations) == 0 {
+		if msg, ok := translate(t, langs, e.MessageID, e.Params); ok {
+			res.Message = msg
+		}
+		return &res
+	}
+	var (
+		orig = make([]string, len(e.violations))
+		msgs = make([]string, len(e.violations))
+	)
+	res.violations = make([]*FieldError, len(e.violations))
+	for i, v := range e.violations {
+		fe := *v
+		if m := e.messages[i]; m != nil {
+			if msg, ok := translate(t, langs, m.id, m.params); ok {
+				fe.Message = msg
+			}
+		}
+		res.violations[i] = &fe
+		orig[i], msgs[i] = v.Message, fe.Message
+	}
+	if e.Message == strings.Join(orig, "; ") {
+		// the message is not translated if err merges other errors
+		res.Message = strings.Join(msgs, "; ")
+	}
+	if e.Fields != nil {
+		res.Fields = res.violations
+	}
+	return &res
+}
+
+// translate returns the message identified by id in the first language of
+// langs for which t defines it.
+func translate(t Translator, langs []string, id string, params map[string]interface{}) (string, bool) {
+	if id == "" {
+		return "", false
+	}
+	for _, lang := range langs {
+		if msg, ok := t.Translate(lang, id, params); ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
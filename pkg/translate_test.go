@@ -0,0 +1,129 @@
+package goa
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatalogTranslate(t *testing.T) {
+	catalog := Catalog{
+		"fr": {
+			"missing_field":  `"{field}" est manquant`,
+			"invalid_length": "longueur de {field} invalide",
+		},
+		"fr-CA": {
+			"missing_field": `"{field}" manque`,
+		},
+	}
+	params := map[string]interface{}{"field": "name"}
+	cases := []struct {
+		Name     string
+		Lang     string
+		ID       string
+		Expected string
+		OK       bool
+	}{
+		{"exact", "fr", "missing_field", `"name" est manquant`, true},
+		{"region", "fr-CA", "missing_field", `"name" manque`, true},
+		{"case-insensitive", "FR-ca", "missing_field", `"name" manque`, true},
+		{"base-language", "fr-BE", "missing_field", `"name" est manquant`, true},
+		{"error-name", "fr", "invalid_length.min", "longueur de name invalide", true},
+		{"unknown-language", "de", "missing_field", "", false},
+		{"unknown-id", "fr", "invalid_format", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			msg, ok := catalog.Translate(c.Lang, c.ID, params)
+			if ok != c.OK {
+				t.Fatalf("got ok %v, expected %v", ok, c.OK)
+			}
+			if msg != c.Expected {
+				t.Errorf("got %q, expected %q", msg, c.Expected)
+			}
+		})
+	}
+}
+
+func TestDefaultMessages(t *testing.T) {
+	cases := []struct {
+		Name string
+		Err  error
+	}{
+		{"missing-payload", MissingPayloadError()},
+		{"unknown-field", UnknownFieldError("foo")},
+		{"missing-field", MissingFieldError("foo", "body")},
+		{"invalid-pattern", InvalidPatternError("foo", "bar", "^a$")},
+		{"invalid-time-zone", InvalidTimeZoneError("foo", "bar", "UTC")},
+		{"invalid-decimal", InvalidDecimalError("foo", "1.234", 3, 2)},
+		{"invalid-decimal-precision", InvalidDecimalError("foo", "1234", 3, -1)},
+		{"invalid-decimal-scale", InvalidDecimalError("foo", "1.234", -1, 2)},
+		{"mutually-exclusive", MutuallyExclusiveFieldsError([]string{"a", "b"}, "body")},
+		{"required-together", RequiredTogetherFieldsError([]string{"a", "b"}, "body")},
+		{"at-least-one-of", AtLeastOneOfFieldsError([]string{"a", "b"}, "body")},
+		{"invalid-range-min", InvalidRangeError("foo", 1, 2, true)},
+		{"invalid-range-max", InvalidRangeError("foo", 3, 2, false)},
+		{"invalid-length-min", InvalidLengthError("foo", "a", 1, 2, true)},
+		{"invalid-length-max", InvalidLengthError("foo", "abc", 3, 2, false)},
+	}
+	en := Catalog{"en": DefaultMessages}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			e := c.Err.(*ServiceError)
+			if _, ok := DefaultMessages[e.MessageID]; !ok {
+				t.Fatalf("no default message for %q", e.MessageID)
+			}
+			tr := TranslateError(c.Err, en, "en").(*ServiceError)
+			if strings.ContainsAny(tr.Message, "{}") {
+				t.Errorf("got message %q with unresolved placeholders", tr.Message)
+			}
+		})
+	}
+}
+
+func TestTranslateError(t *testing.T) {
+	catalog := Catalog{"fr": {
+		"missing_field":      `"{field}" est manquant`,
+		"invalid_length.min": "{field} est trop court",
+	}}
+	merged := MergeErrors(MissingFieldError("name", "body"), InvalidLengthError("body.code", "a", 1, 2, true))
+	aggregated := AggregateErrors(MergeErrors(MissingFieldError("name", "body"), InvalidLengthError("body.code", "a", 1, 2, true)))
+	custom := ValidationMessageError(MissingFieldError("name", "body"), "name is required", nil)
+	mixed := MergeErrors(MissingFieldError("name", "body"), PermanentError("invalid", "invalid request"))
+	cases := []struct {
+		Name     string
+		Err      error
+		Langs    []string
+		Expected string
+		Fields   []string
+	}{
+		{"field", MissingFieldError("name", "body"), []string{"fr"}, `"name" est manquant`, nil},
+		{"no-language", MissingFieldError("name", "body"), nil, `"name" is missing from body`, nil},
+		{"fallback-language", MissingFieldError("name", "body"), []string{"de", "fr"}, `"name" est manquant`, nil},
+		{"untranslated", InvalidPatternError("name", "a", "^b$"), []string{"fr"}, `name must match the regexp "^b$" but got value "a"`, nil},
+		{"merged", merged, []string{"fr"}, `"name" est manquant; body.code est trop court`, nil},
+		{"aggregated", aggregated, []string{"fr"}, `"name" est manquant; body.code est trop court`, []string{`"name" est manquant`, "body.code est trop court"}},
+		{"custom-message", custom, []string{"fr"}, "name is required", nil},
+		{"mixed", mixed, []string{"fr"}, `"name" is missing from body; invalid request`, nil},
+		{"service-error", PermanentError("invalid", "invalid request"), []string{"fr"}, "invalid request", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			orig := c.Err.(*ServiceError).Message
+			e := TranslateError(c.Err, catalog, c.Langs...).(*ServiceError)
+			if e.Message != c.Expected {
+				t.Errorf("got message %q, expected %q", e.Message, c.Expected)
+			}
+			if len(e.Fields) != len(c.Fields) {
+				t.Fatalf("got %d fields, expected %d", len(e.Fields), len(c.Fields))
+			}
+			for i, f := range e.Fields {
+				if f.Message != c.Fields[i] {
+					t.Errorf("got field message %q, expected %q", f.Message, c.Fields[i])
+				}
+			}
+			if m := c.Err.(*ServiceError).Message; m != orig {
+				t.Errorf("original error modified: got %q, expected %q", m, orig)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package goa
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// UUID is the type used by the generated code to hold the values of
+// attributes of type UUID. It is encoded using the canonical RFC 4122 textual
+// representation (e.g. "6ba7b810-9dad-11d1-80b4-00c04fd430c8").
+type UUID [16]byte
+
+// ParseUUID parses the RFC 4122 textual representation of a UUID. The
+// canonical form as well as the forms using the "urn:uuid:" prefix or curly
+// braces are accepted.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if err := validateUUID(s); err != nil {
+		return u, err
+	}
+	s = strings.TrimPrefix(strings.ToLower(s), "urn:uuid:")
+	s = strings.Trim(s, "{}")
+	b, err := hex.DecodeString(strings.Replace(s, "-", "", -1))
+	if err != nil || len(b) != len(u) {
+		return u, fmt.Errorf("uuid: invalid string format")
+	}
+	copy(u[:], b)
+	return u, nil
+}
+
+// MustParseUUID is like ParseUUID but panics if s cannot be parsed. It is
+// used by the generated code to initialize values that have already been
+// validated.
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String returns the canonical textual representation of u.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// MarshalText encodes u using its canonical textual representation.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText decodes the textual representation of a UUID in text.
+func (u *UUID) UnmarshalText(text []byte) error {
+	v, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
@@ -0,0 +1,60 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseUUID(t *testing.T) {
+	const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	cases := []struct {
+		Name  string
+		Value string
+		Error bool
+	}{
+		{"canonical", canonical, false},
+		{"upper-case", "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", false},
+		{"urn", "urn:uuid:" + canonical, false},
+		{"braces", "{" + canonical + "}", false},
+		{"no-dashes", "6ba7b8109dad11d180b400c04fd430c8", true},
+		{"invalid-hex", "6ba7b810-9dad-11d1-80b4-00c04fd430cz", true},
+		{"too-long", canonical + "0", true},
+		{"empty", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			u, err := ParseUUID(c.Value)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if u.String() != canonical {
+				t.Errorf("got %q, expected %q", u.String(), canonical)
+			}
+		})
+	}
+}
+
+func TestUUIDJSON(t *testing.T) {
+	var v struct {
+		ID UUID `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(`{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`), &v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(b) != `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}` {
+		t.Errorf("got %s", b)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"not-a-uuid"}`), &v); err == nil {
+		t.Errorf("got no error, expected one")
+	}
+}
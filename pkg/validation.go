@@ -2,13 +2,16 @@ package goa
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"net"
 	"net/mail"
 	"net/url"
 	"regexp"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -57,13 +60,44 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatDecimal describes decimal numbers (e.g. "-12.50").
+	FormatDecimal = "decimal"
+
+	// FormatSemver describes Semantic Versioning 2.0.0 version numbers.
+	FormatSemver = "semver"
+
+	// FormatBase64 describes RFC4648 standard base64 encoded values.
+	FormatBase64 = "base64"
+
+	// FormatE164 describes ITU-T E.164 international phone numbers.
+	FormatE164 = "e164"
+
+	// FormatULID describes ULID values.
+	FormatULID = "ulid"
+
+	// FormatDuration describes Go or ISO 8601 duration values.
+	FormatDuration = "duration"
+
+	// FormatCreditCard describes payment card numbers.
+	FormatCreditCard = "credit-card"
+
+	// FormatCountryCode describes ISO 3166-1 alpha-2 country codes.
+	FormatCountryCode = "country-code"
+
+	// FormatCurrencyCode describes ISO 4217 currency codes.
+	FormatCurrencyCode = "currency-code"
 )
 
 var (
-	hostnameRegex  = regexp.MustCompile(`^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`)
-	ipv4Regex      = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
-	uuidURNPrefix  = []byte("urn:uuid:")
-	uuidByteGroups = []int{8, 4, 4, 4, 12}
+	hostnameRegex   = regexp.MustCompile(`^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`)
+	ipv4Regex       = regexp.MustCompile(`^(?:[0-9]{1,3}\.){3}[0-9]{1,3}$`)
+	uuidURNPrefix   = []byte("urn:uuid:")
+	uuidByteGroups  = []int{8, 4, 4, 4, 12}
+	semverRegex     = regexp.MustCompile(`^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(?:-((?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	e164Regex       = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	ulidRegex       = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+	creditCardRegex = regexp.MustCompile(`^[0-9]{12,19}$`)
 )
 
 // ValidateFormat validates val against f. It returns nil if the string conforms
@@ -83,6 +117,15 @@ var (
 //     - "cidr": RFC4632 and RFC4291 CIDR notation IP address value
 //     - "regexp": Regular expression syntax accepted by RE2
 //     - "rfc1123": RFC1123 date time value
+//     - "decimal": decimal number
+//     - "semver": Semantic Versioning 2.0.0 version number
+//     - "base64": RFC4648 standard base64 encoded value
+//     - "e164": ITU-T E.164 international phone number
+//     - "ulid": ULID value
+//     - "duration": Go or ISO 8601 duration value
+//     - "credit-card": payment card number passing the Luhn checksum
+//     - "country-code": ISO 3166-1 alpha-2 country code
+//     - "currency-code": ISO 4217 currency code
 func ValidateFormat(name string, val string, f Format) error {
 	var err error
 	switch f {
@@ -128,6 +171,36 @@ func ValidateFormat(name string, val string, f Format) error {
 		}
 	case FormatRFC1123:
 		_, err = time.Parse(time.RFC1123, val)
+	case FormatDecimal:
+		_, err = ParseDecimal(val)
+	case FormatSemver:
+		if !semverRegex.MatchString(val) {
+			err = fmt.Errorf("invalid semantic version %q", val)
+		}
+	case FormatBase64:
+		_, err = base64.StdEncoding.DecodeString(val)
+	case FormatE164:
+		if !e164Regex.MatchString(val) {
+			err = fmt.Errorf("invalid E.164 phone number %q", val)
+		}
+	case FormatULID:
+		if !ulidRegex.MatchString(val) {
+			err = fmt.Errorf("invalid ULID %q", val)
+		}
+	case FormatDuration:
+		_, err = ParseDuration(val)
+	case FormatCreditCard:
+		if !creditCardRegex.MatchString(val) || !luhnValid(val) {
+			err = fmt.Errorf("invalid credit card number")
+		}
+	case FormatCountryCode:
+		if !countryCodes[val] {
+			err = fmt.Errorf("invalid ISO 3166-1 alpha-2 country code %q", val)
+		}
+	case FormatCurrencyCode:
+		if !currencyCodes[val] {
+			err = fmt.Errorf("invalid ISO 4217 currency code %q", val)
+		}
 	default:
 		return fmt.Errorf("unknown format %#v", f)
 	}
@@ -164,6 +237,147 @@ func ValidatePattern(name, val, p string) error {
 	return nil
 }
 
+// validationSampling is 1 if the validations marked with the
+// "validation:sample" meta are sampled, 0 otherwise.
+var validationSampling int32
+
+// SetValidationSampling enables or disables the sampling of the validations of
+// the array elements marked with the "validation:sample" meta in the design.
+// Sampling is disabled by default so that tests run the full validations,
+// production servers typically enable it on start.
+func SetValidationSampling(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&validationSampling, v)
+}
+
+// ValidationSampled returns true if the generated code should validate the
+// current element of an array whose validations are sampled with the given
+// rate. It always returns true when sampling is disabled.
+func ValidationSampled(rate float64) bool {
+	if atomic.LoadInt32(&validationSampling) == 0 {
+		return true
+	}
+	return rand.Float64() < rate
+}
+
+// ValidateMutuallyExclusive returns an error if more than one of the fields
+// listed in names is set. set indicates whether the field with the same index
+// in names is set. context is the name of the variable holding the fields used
+// in error messages.
+func ValidateMutuallyExclusive(context string, names []string, set ...bool) error {
+	if countSet(set) > 1 {
+		return MutuallyExclusiveFieldsError(names, context)
+	}
+	return nil
+}
+
+// ValidateRequiredTogether returns an error if some but not all of the fields
+// listed in names are set. See ValidateMutuallyExclusive for a description of
+// the arguments.
+func ValidateRequiredTogether(context string, names []string, set ...bool) error {
+	if n := countSet(set); n > 0 && n < len(set) {
+		return RequiredTogetherFieldsError(names, context)
+	}
+	return nil
+}
+
+// ValidateAtLeastOneOf returns an error if none of the fields listed in names
+// is set. See ValidateMutuallyExclusive for a description of the arguments.
+func ValidateAtLeastOneOf(context string, names []string, set ...bool) error {
+	if countSet(set) == 0 {
+		return AtLeastOneOfFieldsError(names, context)
+	}
+	return nil
+}
+
+// ValidateRequiredIf returns an error for each field listed in names that is
+// not set when cond is true. cond indicates whether the field named field has
+// the value value. See ValidateMutuallyExclusive for a description of the other
+// arguments.
+func ValidateRequiredIf(context, field string, value interface{}, cond bool, names []string, set ...bool) error {
+	if !cond {
+		return nil
+	}
+	var err error
+	for i, s := range set {
+		if !s {
+			err = MergeErrors(err, RequiredIfFieldError(names[i], context, field, value))
+		}
+	}
+	return err
+}
+
+// countSet returns the number of true values in set.
+func countSet(set []bool) int {
+	n := 0
+	for _, s := range set {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// ValidateDecimal returns an error if the decimal val has more than precision
+// digits in total or more than scale digits after the decimal point. A negative
+// precision or scale is not checked. val is either a string holding the decimal
+// representation or a value whose String method returns it such as the values
+// of the Go type used to represent the Decimal attributes. name is the name of
+// the variable used in error messages.
+func ValidateDecimal(name string, val interface{}, precision, scale int) error {
+	var s string
+	switch v := val.(type) {
+	case string:
+		s = v
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	d, err := ParseDecimal(s)
+	if err != nil {
+		// invalid values are reported by the format validation
+		return nil
+	}
+	p, sc := d.Precision(), d.Scale()
+	if precision >= 0 && p > precision || scale >= 0 && sc > scale {
+		return InvalidDecimalError(name, s, precision, scale)
+	}
+	return nil
+}
+
+// ValidateTimeZone returns an error if val is not a RFC3339 date time that uses
+// the time zone tz. tz is either "UTC" or a fixed offset of the form "+hh:mm"
+// or "-hh:mm". name is the name of the variable used in error messages.
+func ValidateTimeZone(name, val, tz string) error {
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		// invalid values are reported by the format validation
+		return nil
+	}
+	want := tz
+	if tz == "UTC" || tz == "-00:00" {
+		want = "+00:00"
+	}
+	if t.Format("-07:00") != want {
+		return InvalidTimeZoneError(name, val, tz)
+	}
+	return nil
+}
+
+// NormalizeDateTime converts the RFC3339 date time val to UTC. It returns val
+// unchanged if it is not a valid RFC3339 date time.
+func NormalizeDateTime(val string) string {
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return val
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
 // The following formats are supported:
 // "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 // "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
@@ -199,3 +413,22 @@ func validateUUID(uuid string) error {
 
 	return nil
 }
+
+// luhnValid returns true if the given string of digits passes the Luhn
+// checksum used by payment card numbers.
+func luhnValid(digits string) bool {
+	var sum int
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
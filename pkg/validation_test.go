@@ -1,6 +1,7 @@
 package goa
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
@@ -45,38 +46,56 @@ func TestValidateFormat(t *testing.T) {
 		format   Format
 		expected error
 	}{
-		"valid date":         {"validDate", validDate, FormatDate, nil},
-		"invalid date":       {"invalidDate", invalidDate, FormatDate, InvalidFormatError("invalidDate", invalidDate, FormatDate, &time.ParseError{Layout: "2006-01-02", Value: invalidDate, LayoutElem: "-", ValueElem: invalidDate[4:]})},
-		"valid date-time":    {"validDateTime", validDateTime, FormatDateTime, nil},
-		"invalid date-time":  {"invalidDateTime", invalidDateTime, FormatDateTime, InvalidFormatError("invalidDateTime", invalidDateTime, FormatDateTime, &time.ParseError{Layout: time.RFC3339, Value: invalidDateTime, LayoutElem: "-", ValueElem: invalidDateTime[4:]})},
-		"valid uuid":         {"validUUID", validUUID, FormatUUID, nil},
-		"invalid uuid":       {"invalidUUID", invalidUUID, FormatUUID, InvalidFormatError("invalidUUID", invalidUUID, FormatUUID, fmt.Errorf("uuid: UUID string too short: %s", invalidUUID))},
-		"valid email":        {"validEmail", validEmail, FormatEmail, nil},
-		"invalid email":      {"invalidEmail", invalidEmail, FormatEmail, InvalidFormatError("invalidEmail", invalidEmail, FormatEmail, errors.New("mail: no angle-addr"))},
-		"valid hostname":     {"validHostname", validHostname, FormatHostname, nil},
-		"invalid hostname":   {"invalidHostname", invalidHostname, FormatHostname, InvalidFormatError("invalidHostname", invalidHostname, FormatHostname, fmt.Errorf("hostname value '%s' does not match %s", invalidHostname, `^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`))},
-		"valid ipv4":         {"validIPv4", validIPv4, FormatIPv4, nil},
-		"valid ipv6 as ipv4": {"validIPv6", validIPv6, FormatIPv4, InvalidFormatError("validIPv6", validIPv6, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", validIPv6, FormatIPv4))},
-		"invalid ipv4":       {"invalidIPv4", invalidIPv4, FormatIPv4, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIPv4))},
-		"valid ipv6":         {"validIPv6", validIPv6, FormatIPv6, nil},
-		"valid ipv4 as ipv6": {"validIPv4", validIPv4, FormatIPv6, InvalidFormatError("validIPv4", validIPv4, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", validIPv4, FormatIPv6))},
-		"invalid ipv6":       {"invalidIPv6", invalidIPv6, FormatIPv6, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIPv6))},
-		"valid ipv4 as ip":   {"validIPv4", validIPv4, FormatIP, nil},
-		"valid ipv6 as ip":   {"validIPv6", validIPv6, FormatIP, nil},
-		"invalid ipv4 as ip": {"invalidIPv4", invalidIPv4, FormatIP, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIP))},
-		"invalid ipv6 as ip": {"invalidIPv6", invalidIPv6, FormatIP, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIP))},
-		"valid uri":          {"validURI", validURI, FormatURI, nil},
-		"invalid uri":        {"invalidURI", invalidURI, FormatURI, InvalidFormatError("invalidURI", invalidURI, FormatURI, &url.Error{Op: "parse", URL: invalidURI, Err: errors.New("invalid URI for request")})},
-		"valid mac":          {"validMAC", validMAC, FormatMAC, nil},
-		"invalid mac":        {"invalidMAC", invalidMAC, FormatMAC, InvalidFormatError("invalidMAC", invalidMAC, FormatMAC, &net.AddrError{Err: "invalid MAC address", Addr: invalidMAC})},
-		"valid cidr":         {"validCIDR", validCIDR, FormatCIDR, nil},
-		"invalid cidr":       {"invalidCIDR", invalidCIDR, FormatCIDR, InvalidFormatError("invalidCIDR", invalidCIDR, FormatCIDR, &net.ParseError{Type: "CIDR address", Text: invalidCIDR})},
-		"valid regexp":       {"validRegexp", validRegexp, FormatRegexp, nil},
-		"invalid regexp":     {"invalidRegexp", invalidRegexp, FormatRegexp, InvalidFormatError("invalidRegexp", invalidRegexp, FormatRegexp, &syntax.Error{Code: syntax.ErrMissingBracket, Expr: invalidRegexp[3:4]})},
-		"valid json":         {"validJSON", validJSON, FormatJSON, nil},
-		"invalid json":       {"invalidJSON", invalidJSON, FormatJSON, InvalidFormatError("invalidJSON", invalidJSON, FormatJSON, fmt.Errorf("invalid JSON"))},
-		"valid rfc1123":      {"validRFC1123", validRFC1123, FormatRFC1123, nil},
-		"invalid rfc1123":    {"invalidRFC1123", invalidRFC1123, FormatRFC1123, InvalidFormatError("invalidRFC1123", invalidRFC1123, FormatRFC1123, &time.ParseError{Layout: time.RFC1123, Value: invalidRFC1123, LayoutElem: ", ", ValueElem: invalidRFC1123[3:]})},
+		"valid date":            {"validDate", validDate, FormatDate, nil},
+		"invalid date":          {"invalidDate", invalidDate, FormatDate, InvalidFormatError("invalidDate", invalidDate, FormatDate, &time.ParseError{Layout: "2006-01-02", Value: invalidDate, LayoutElem: "-", ValueElem: invalidDate[4:]})},
+		"valid date-time":       {"validDateTime", validDateTime, FormatDateTime, nil},
+		"invalid date-time":     {"invalidDateTime", invalidDateTime, FormatDateTime, InvalidFormatError("invalidDateTime", invalidDateTime, FormatDateTime, &time.ParseError{Layout: time.RFC3339, Value: invalidDateTime, LayoutElem: "-", ValueElem: invalidDateTime[4:]})},
+		"valid uuid":            {"validUUID", validUUID, FormatUUID, nil},
+		"invalid uuid":          {"invalidUUID", invalidUUID, FormatUUID, InvalidFormatError("invalidUUID", invalidUUID, FormatUUID, fmt.Errorf("uuid: UUID string too short: %s", invalidUUID))},
+		"valid email":           {"validEmail", validEmail, FormatEmail, nil},
+		"invalid email":         {"invalidEmail", invalidEmail, FormatEmail, InvalidFormatError("invalidEmail", invalidEmail, FormatEmail, errors.New("mail: no angle-addr"))},
+		"valid hostname":        {"validHostname", validHostname, FormatHostname, nil},
+		"invalid hostname":      {"invalidHostname", invalidHostname, FormatHostname, InvalidFormatError("invalidHostname", invalidHostname, FormatHostname, fmt.Errorf("hostname value '%s' does not match %s", invalidHostname, `^[[:alnum:]][[:alnum:]\-]{0,61}[[:alnum:]]|[[:alpha:]]$`))},
+		"valid ipv4":            {"validIPv4", validIPv4, FormatIPv4, nil},
+		"valid ipv6 as ipv4":    {"validIPv6", validIPv6, FormatIPv4, InvalidFormatError("validIPv6", validIPv6, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", validIPv6, FormatIPv4))},
+		"invalid ipv4":          {"invalidIPv4", invalidIPv4, FormatIPv4, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIPv4, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIPv4))},
+		"valid ipv6":            {"validIPv6", validIPv6, FormatIPv6, nil},
+		"valid ipv4 as ipv6":    {"validIPv4", validIPv4, FormatIPv6, InvalidFormatError("validIPv4", validIPv4, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", validIPv4, FormatIPv6))},
+		"invalid ipv6":          {"invalidIPv6", invalidIPv6, FormatIPv6, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIPv6, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIPv6))},
+		"valid ipv4 as ip":      {"validIPv4", validIPv4, FormatIP, nil},
+		"valid ipv6 as ip":      {"validIPv6", validIPv6, FormatIP, nil},
+		"invalid ipv4 as ip":    {"invalidIPv4", invalidIPv4, FormatIP, InvalidFormatError("invalidIPv4", invalidIPv4, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv4, FormatIP))},
+		"invalid ipv6 as ip":    {"invalidIPv6", invalidIPv6, FormatIP, InvalidFormatError("invalidIPv6", invalidIPv6, FormatIP, fmt.Errorf("\"%s\" is an invalid %s value", invalidIPv6, FormatIP))},
+		"valid uri":             {"validURI", validURI, FormatURI, nil},
+		"invalid uri":           {"invalidURI", invalidURI, FormatURI, InvalidFormatError("invalidURI", invalidURI, FormatURI, &url.Error{Op: "parse", URL: invalidURI, Err: errors.New("invalid URI for request")})},
+		"valid mac":             {"validMAC", validMAC, FormatMAC, nil},
+		"invalid mac":           {"invalidMAC", invalidMAC, FormatMAC, InvalidFormatError("invalidMAC", invalidMAC, FormatMAC, &net.AddrError{Err: "invalid MAC address", Addr: invalidMAC})},
+		"valid cidr":            {"validCIDR", validCIDR, FormatCIDR, nil},
+		"invalid cidr":          {"invalidCIDR", invalidCIDR, FormatCIDR, InvalidFormatError("invalidCIDR", invalidCIDR, FormatCIDR, &net.ParseError{Type: "CIDR address", Text: invalidCIDR})},
+		"valid regexp":          {"validRegexp", validRegexp, FormatRegexp, nil},
+		"invalid regexp":        {"invalidRegexp", invalidRegexp, FormatRegexp, InvalidFormatError("invalidRegexp", invalidRegexp, FormatRegexp, &syntax.Error{Code: syntax.ErrMissingBracket, Expr: invalidRegexp[3:4]})},
+		"valid json":            {"validJSON", validJSON, FormatJSON, nil},
+		"invalid json":          {"invalidJSON", invalidJSON, FormatJSON, InvalidFormatError("invalidJSON", invalidJSON, FormatJSON, fmt.Errorf("invalid JSON"))},
+		"valid rfc1123":         {"validRFC1123", validRFC1123, FormatRFC1123, nil},
+		"invalid rfc1123":       {"invalidRFC1123", invalidRFC1123, FormatRFC1123, InvalidFormatError("invalidRFC1123", invalidRFC1123, FormatRFC1123, &time.ParseError{Layout: time.RFC1123, Value: invalidRFC1123, LayoutElem: ", ", ValueElem: invalidRFC1123[3:]})},
+		"valid decimal":         {"validDecimal", "-12.50", FormatDecimal, nil},
+		"invalid decimal":       {"invalidDecimal", "12,50", FormatDecimal, InvalidFormatError("invalidDecimal", "12,50", FormatDecimal, fmt.Errorf("invalid decimal %q", "12,50"))},
+		"valid semver":          {"validSemver", "1.2.3-rc.1+build.5", FormatSemver, nil},
+		"invalid semver":        {"invalidSemver", "1.02.3", FormatSemver, InvalidFormatError("invalidSemver", "1.02.3", FormatSemver, fmt.Errorf("invalid semantic version %q", "1.02.3"))},
+		"valid base64":          {"validBase64", "Z29hIQ==", FormatBase64, nil},
+		"invalid base64":        {"invalidBase64", "Z29hIQ", FormatBase64, InvalidFormatError("invalidBase64", "Z29hIQ", FormatBase64, base64.CorruptInputError(4))},
+		"valid e164":            {"validE164", "+14155552671", FormatE164, nil},
+		"invalid e164":          {"invalidE164", "4155552671", FormatE164, InvalidFormatError("invalidE164", "4155552671", FormatE164, fmt.Errorf("invalid E.164 phone number %q", "4155552671"))},
+		"valid ulid":            {"validULID", "01ARZ3NDEKTSV4RRFFQ69G5FAV", FormatULID, nil},
+		"invalid ulid":          {"invalidULID", "81ARZ3NDEKTSV4RRFFQ69G5FAV", FormatULID, InvalidFormatError("invalidULID", "81ARZ3NDEKTSV4RRFFQ69G5FAV", FormatULID, fmt.Errorf("invalid ULID %q", "81ARZ3NDEKTSV4RRFFQ69G5FAV"))},
+		"valid duration":        {"validDuration", "PT1H30M", FormatDuration, nil},
+		"invalid duration":      {"invalidDuration", "P1Y", FormatDuration, InvalidFormatError("invalidDuration", "P1Y", FormatDuration, fmt.Errorf("invalid ISO 8601 duration %q", "P1Y"))},
+		"valid credit card":     {"validCreditCard", "4111111111111111", FormatCreditCard, nil},
+		"invalid credit card":   {"invalidCreditCard", "4111111111111112", FormatCreditCard, InvalidFormatError("invalidCreditCard", "4111111111111112", FormatCreditCard, fmt.Errorf("invalid credit card number"))},
+		"valid country code":    {"validCountryCode", "FR", FormatCountryCode, nil},
+		"invalid country code":  {"invalidCountryCode", "XX", FormatCountryCode, InvalidFormatError("invalidCountryCode", "XX", FormatCountryCode, fmt.Errorf("invalid ISO 3166-1 alpha-2 country code %q", "XX"))},
+		"valid currency code":   {"validCurrencyCode", "EUR", FormatCurrencyCode, nil},
+		"invalid currency code": {"invalidCurrencyCode", "eur", FormatCurrencyCode, InvalidFormatError("invalidCurrencyCode", "eur", FormatCurrencyCode, fmt.Errorf("invalid ISO 4217 currency code %q", "eur"))},
 	}
 
 	for k, tc := range cases {
@@ -117,3 +136,200 @@ func TestValidatePattern(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDecimal(t *testing.T) {
+	cases := map[string]struct {
+		val       interface{}
+		precision int
+		scale     int
+		expected  error
+	}{
+		"valid":             {"123.45", 5, 2, nil},
+		"valid stringer":    {MustParseDecimal("123.45"), 5, 2, nil},
+		"too many digits":   {"1234.5", 4, -1, InvalidDecimalError("foo", "1234.5", 4, -1)},
+		"scale too large":   {MustParseDecimal("1.234"), -1, 2, InvalidDecimalError("foo", "1.234", -1, 2)},
+		"precision & scale": {"1.234", 5, 2, InvalidDecimalError("foo", "1.234", 5, 2)},
+		"leading zeros":     {"0.05", 2, 2, nil},
+		"invalid":           {"foo", 2, 2, nil},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateDecimal("foo", tc.val, tc.precision, tc.scale)
+		if actual != tc.expected {
+			// Compare only the messages because the error has always a new error ID.
+			if actual == nil || tc.expected == nil || actual.Error() != tc.expected.Error() {
+				t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+			}
+		}
+	}
+}
+
+func TestValidateFieldGroups(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	cases := map[string]struct {
+		validate func(string, []string, ...bool) error
+		set      []bool
+		expected error
+	}{
+		"mutually exclusive none": {ValidateMutuallyExclusive, []bool{false, false, false}, nil},
+		"mutually exclusive one":  {ValidateMutuallyExclusive, []bool{false, true, false}, nil},
+		"mutually exclusive two":  {ValidateMutuallyExclusive, []bool{true, true, false}, MutuallyExclusiveFieldsError(names, "body")},
+		"required together none":  {ValidateRequiredTogether, []bool{false, false, false}, nil},
+		"required together all":   {ValidateRequiredTogether, []bool{true, true, true}, nil},
+		"required together some":  {ValidateRequiredTogether, []bool{true, false, true}, RequiredTogetherFieldsError(names, "body")},
+		"at least one of none":    {ValidateAtLeastOneOf, []bool{false, false, false}, AtLeastOneOfFieldsError(names, "body")},
+		"at least one of one":     {ValidateAtLeastOneOf, []bool{false, false, true}, nil},
+		"at least one of all":     {ValidateAtLeastOneOf, []bool{true, true, true}, nil},
+	}
+
+	for k, tc := range cases {
+		actual := tc.validate("body", names, tc.set...)
+		if actual != tc.expected {
+			// Compare only the messages because the error has always a new error ID.
+			if actual == nil || tc.expected == nil || actual.Error() != tc.expected.Error() {
+				t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+			}
+		}
+	}
+}
+
+func TestValidateRequiredIf(t *testing.T) {
+	names := []string{"a", "b"}
+	cases := map[string]struct {
+		cond     bool
+		set      []bool
+		expected error
+	}{
+		"condition false": {false, []bool{false, false}, nil},
+		"all set":         {true, []bool{true, true}, nil},
+		"one missing":     {true, []bool{true, false}, RequiredIfFieldError("b", "body", "type", "card")},
+		"all missing":     {true, []bool{false, false}, MergeErrors(RequiredIfFieldError("a", "body", "type", "card"), RequiredIfFieldError("b", "body", "type", "card"))},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateRequiredIf("body", "type", "card", tc.cond, names, tc.set...)
+		if actual != tc.expected {
+			// Compare only the messages because the error has always a new error ID.
+			if actual == nil || tc.expected == nil || actual.Error() != tc.expected.Error() {
+				t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+			}
+		}
+	}
+}
+
+func TestCustomValidationError(t *testing.T) {
+	svcErr := PermanentError("invalid_iban", "bad checksum")
+	cases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"nil":           {nil, ""},
+		"error":         {errors.New("bad checksum"), "body.iban is invalid: bad checksum"},
+		"service error": {svcErr, "bad checksum"},
+	}
+
+	for k, tc := range cases {
+		actual := CustomValidationError("body.iban", tc.err)
+		if tc.expected == "" {
+			if actual != nil {
+				t.Errorf("%s: got %#v, expected nil", k, actual)
+			}
+			continue
+		}
+		if actual == nil || actual.Error() != tc.expected {
+			t.Errorf("%s: got %#v, expected %q", k, actual, tc.expected)
+		}
+	}
+	if actual := CustomValidationError("body.iban", svcErr); actual != svcErr {
+		t.Errorf("got %#v, expected the service error to be returned unchanged", actual)
+	}
+}
+
+func TestValidationMessageError(t *testing.T) {
+	if err := ValidationMessageError(nil, "invalid", 1); err != nil {
+		t.Errorf("got %#v, expected nil", err)
+	}
+	err := ValidationMessageError(InvalidRangeError("body.age", 12, 18, true), "You must be at least 18, got {value}.", 12)
+	serr, ok := err.(*ServiceError)
+	if !ok {
+		t.Fatalf("got %#v, expected a service error", err)
+	}
+	if serr.Name != "invalid_range" {
+		t.Errorf("got name %q, expected %q", serr.Name, "invalid_range")
+	}
+	if serr.Message != "You must be at least 18, got 12." {
+		t.Errorf("got message %q, expected %q", serr.Message, "You must be at least 18, got 12.")
+	}
+	agg := AggregateErrors(MergeErrors(ValidationMessageError(MissingFieldError("age", "body"), "Please enter your age{value}.", nil), err)).(*ServiceError)
+	if len(agg.Fields) != 2 {
+		t.Fatalf("got %d field errors, expected 2", len(agg.Fields))
+	}
+	if agg.Fields[0].Message != "Please enter your age." || agg.Fields[0].Path != "body.age" {
+		t.Errorf("got field error %+v, expected custom message for body.age", *agg.Fields[0])
+	}
+}
+
+func TestValidationSampled(t *testing.T) {
+	defer SetValidationSampling(false)
+	for i := 0; i < 100; i++ {
+		if !ValidationSampled(0) {
+			t.Fatal("got element skipped with sampling disabled, expected full validation")
+		}
+	}
+	SetValidationSampling(true)
+	var sampled int
+	for i := 0; i < 1000; i++ {
+		if ValidationSampled(0) {
+			t.Fatal("got element validated with a rate of 0")
+		}
+		if ValidationSampled(0.5) {
+			sampled++
+		}
+	}
+	if sampled == 0 || sampled == 1000 {
+		t.Errorf("got %d elements validated out of 1000 with a rate of 0.5", sampled)
+	}
+}
+
+func TestValidateTimeZone(t *testing.T) {
+	cases := map[string]struct {
+		val      string
+		tz       string
+		expected error
+	}{
+		"utc":              {"2015-10-26T08:31:23Z", "UTC", nil},
+		"utc offset":       {"2015-10-26T08:31:23+00:00", "UTC", nil},
+		"not utc":          {"2015-10-26T08:31:23+02:00", "UTC", InvalidTimeZoneError("foo", "2015-10-26T08:31:23+02:00", "UTC")},
+		"offset":           {"2015-10-26T08:31:23-05:30", "-05:30", nil},
+		"different offset": {"2015-10-26T08:31:23Z", "+01:00", InvalidTimeZoneError("foo", "2015-10-26T08:31:23Z", "+01:00")},
+		"invalid":          {"2015-10-26", "UTC", nil},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateTimeZone("foo", tc.val, tc.tz)
+		if actual != tc.expected {
+			// Compare only the messages because the error has always a new error ID.
+			if actual == nil || tc.expected == nil || actual.Error() != tc.expected.Error() {
+				t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+			}
+		}
+	}
+}
+
+func TestNormalizeDateTime(t *testing.T) {
+	cases := map[string]struct {
+		val      string
+		expected string
+	}{
+		"utc":      {"2015-10-26T08:31:23Z", "2015-10-26T08:31:23Z"},
+		"offset":   {"2015-10-26T08:31:23+02:00", "2015-10-26T06:31:23Z"},
+		"fraction": {"2015-10-26T08:31:23.5-01:00", "2015-10-26T09:31:23.5Z"},
+		"invalid":  {"foo", "foo"},
+	}
+
+	for k, tc := range cases {
+		if actual := NormalizeDateTime(tc.val); actual != tc.expected {
+			t.Errorf("%s: got %q, expected %q", k, actual, tc.expected)
+		}
+	}
+}
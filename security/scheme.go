@@ -12,6 +12,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -136,3 +138,58 @@ func validateScopes(expected, actual []string) error {
 	}
 	return fmt.Errorf("missing scopes: %s", strings.Join(missing, ", "))
 }
+
+// principalKey is the context key used to store the authenticated principal.
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx that holds the given principal.
+// Auther implementations may use it to record the authenticated user so that
+// the service methods can retrieve it with ContextPrincipal.
+func ContextWithPrincipal(ctx context.Context, principal interface{}) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// ContextPrincipal returns the principal stored in ctx by ContextWithPrincipal
+// if any.
+func ContextPrincipal(ctx context.Context) (interface{}, bool) {
+	p := ctx.Value(principalKey{})
+	return p, p != nil
+}
+
+// PermissionHolder is the interface implemented by principals that are granted
+// permissions, see ValidatePermissions.
+type PermissionHolder interface {
+	// Permissions returns the permissions granted to the principal.
+	Permissions() []string
+}
+
+// ValidatePermissions returns a "forbidden" error if the principal stored in
+// ctx with ContextWithPrincipal is not granted all the given permissions. The
+// principal must implement PermissionHolder, no permission is granted
+// otherwise. The generated endpoints of the methods that use the Permission
+// DSL call ValidatePermissions once the request is authorized.
+func ValidatePermissions(ctx context.Context, perms ...string) error {
+	var granted []string
+	if p, ok := ContextPrincipal(ctx); ok {
+		if h, ok := p.(PermissionHolder); ok {
+			granted = h.Permissions()
+		}
+	}
+	var missing []string
+	for _, p := range perms {
+		found := false
+		for _, g := range granted {
+			if g == p {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return goa.PermanentError("forbidden", "missing permissions: %s", strings.Join(missing, ", "))
+}
@@ -0,0 +1,56 @@
+package tcp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+)
+
+// Dialer establishes the network connections used by the clients.
+type Dialer func(ctx context.Context) (net.Conn, error)
+
+// NewDialer returns a dialer that connects to the given address. The
+// connections use TLS if config is not nil.
+func NewDialer(network, addr string, config *tls.Config) Dialer {
+	return func(ctx context.Context) (net.Conn, error) {
+		var d net.Dialer
+		c, err := d.DialContext(ctx, network, addr)
+		if err != nil || config == nil {
+			return c, err
+		}
+		tc := tls.Client(c, config)
+		if err := tc.Handshake(); err != nil {
+			c.Close()
+			return nil, err
+		}
+		return tc, nil
+	}
+}
+
+// OpenStream dials a new connection and opens a stream for the given method
+// sending payload as the message of the data frame. The returned connection
+// is closed when ctx is canceled. Callers read the results with Recv and must
+// close the connection once done.
+func OpenStream(ctx context.Context, dial Dialer, method string, payload []byte) (*Conn, error) {
+	c, err := dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn := NewConn(c)
+	if err := conn.WriteFrame(FrameOpen, []byte(method)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.WriteFrame(FrameData, payload); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-conn.Done():
+		}
+	}()
+	return conn, nil
+}
@@ -0,0 +1,62 @@
+package tcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type (
+	// Codec encodes and decodes the messages sent in the frames.
+	Codec interface {
+		// Marshal returns the encoding of v.
+		Marshal(v interface{}) ([]byte, error)
+		// Unmarshal decodes data into v.
+		Unmarshal(data []byte, v interface{}) error
+	}
+
+	// jsonCodec encodes messages using JSON.
+	jsonCodec struct{}
+
+	// protoCodec encodes protocol buffer messages.
+	protoCodec struct{}
+)
+
+var (
+	// JSONCodec encodes messages using JSON.
+	JSONCodec Codec = jsonCodec{}
+
+	// ProtoCodec encodes messages using protocol buffers. It only accepts
+	// values that implement proto.Message such as the messages generated
+	// for the gRPC transport.
+	ProtoCodec Codec = protoCodec{}
+)
+
+// Marshal returns the JSON encoding of v.
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes the JSON data into v.
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Marshal returns the protocol buffer encoding of v.
+func (protoCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("tcp: cannot encode %T, value must be a proto.Message", v)
+	}
+	return proto.Marshal(m)
+}
+
+// Unmarshal decodes the protocol buffer data into v.
+func (protoCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("tcp: cannot decode into %T, value must be a proto.Message", v)
+	}
+	return proto.Unmarshal(data, m)
+}
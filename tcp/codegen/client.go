@@ -0,0 +1,141 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// ClientFiles returns the raw TCP client files of the services that enable the
+// TCP transport on at least one method. The files contain the endpoints that
+// open the streams and the client stream implementations.
+func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.Services {
+		if f := clientFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// clientFile returns the file defining the TCP client of the given service,
+// nil if the service does not enable the TCP transport.
+func clientFile(genpkg string, svc *expr.ServiceExpr) *codegen.File {
+	data := TCPServices.Get(svc.Name)
+	if data == nil {
+		return nil
+	}
+	svcName := serviceVarName(data)
+	fpath := filepath.Join(codegen.Gendir, "tcp", svcName, "client", "client.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("tcp", "goatcp"),
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+	}
+	if data.UsesProto {
+		imports = append(imports,
+			&codegen.ImportSpec{Path: path.Join(genpkg, "grpc", svcName, "pb"), Name: data.PbPkgName},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "grpc", svcName, "client"), Name: data.GRPCClientPkgName},
+		)
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name+" TCP client", "client", imports),
+		{Name: "tcp-client-struct", Source: clientStructT, Data: data},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections,
+			&codegen.SectionTemplate{Name: "tcp-client-endpoint-init", Source: clientEndpointInitT, Data: e},
+			&codegen.SectionTemplate{Name: "tcp-client-stream", Source: clientStreamT, Data: e},
+		)
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const clientStructT = `{{ printf "Client lists the %s service endpoint TCP clients." .Service.Name | comment }}
+type Client struct {
+	dial goatcp.Dialer
+}
+
+{{ printf "NewClient instantiates TCP clients for the %s service methods that enable the TCP transport. Each stream uses a new connection established with dial, see goatcp.NewDialer." .Service.Name | comment }}
+func NewClient(dial goatcp.Dialer) *Client {
+	return &Client{dial: dial}
+}
+`
+
+// input: EndpointData
+const clientEndpointInitT = `{{ printf "%s returns an endpoint that opens a stream to the %q service %q method TCP server." .Method.VarName .ServiceName .Method.Name | comment }}
+func (c *Client) {{ .Method.VarName }}() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+{{- if .HasPayload }}
+		p, ok := v.({{ .PayloadRef }})
+		if !ok {
+			return nil, goatcp.ErrInvalidType({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, {{ printf "%q" .PayloadRef }}, v)
+		}
+	{{- if .Proto }}
+		body, err := goatcp.ProtoCodec.Marshal({{ .Proto.RequestInit }}(p))
+	{{- else }}
+		body, err := goatcp.JSONCodec.Marshal(p)
+	{{- end }}
+		if err != nil {
+			return nil, goatcp.ErrEncodingError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+		}
+{{- else }}
+		var body []byte
+{{- end }}
+		conn, err := goatcp.OpenStream(ctx, c.dial, {{ printf "%q" .Method.Name }}, body)
+		if err != nil {
+			return nil, goatcp.ErrRequestError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+		}
+		return &{{ .ClientStream }}{conn: conn}, nil
+	}
+}
+`
+
+// input: EndpointData
+const clientStreamT = `{{ printf "%s implements the %s interface." .ClientStream .ClientStreamInterface | comment }}
+type {{ .ClientStream }} struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+{{ printf "Recv reads instances of %q from the stream. It returns io.EOF once the server ends the stream. The connection is closed when Recv returns an error." .ResultRef | comment }}
+func (s *{{ .ClientStream }}) Recv() ({{ .ResultRef }}, error) {
+	var res {{ .ResultRef }}
+	body, err := s.conn.Recv()
+	if err != nil {
+		s.conn.Close()
+		return res, err
+	}
+{{- if .Proto }}
+	var message {{ .Proto.ResponseMessage }}
+	if err := goatcp.ProtoCodec.Unmarshal(body, &message); err != nil {
+		s.conn.Close()
+		return res, goatcp.ErrDecodingError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+	}
+	{{- if .Proto.ResponseValidation }}
+	if err := {{ .Proto.ResponseValidation }}(&message); err != nil {
+		s.conn.Close()
+		return res, goatcp.ErrDecodingError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+	}
+	{{- end }}
+	return {{ .Proto.ResultInit }}(&message), nil
+{{- else }}
+	if err := goatcp.JSONCodec.Unmarshal(body, &res); err != nil {
+		s.conn.Close()
+		return res, goatcp.ErrDecodingError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+	}
+	return res, nil
+{{- end }}
+}
+
+// Close closes the stream connection, use it to stop reading the results
+// before the server ends the stream.
+func (s *{{ .ClientStream }}) Close() error {
+	return s.conn.Close()
+}
+`
@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/tcp/codegen/testdata"
+)
+
+func TestClientFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"streaming-result", testdata.StreamingResultDSL, testdata.StreamingResultClientCode},
+		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadDSL, testdata.StreamingResultNoPayloadClientCode},
+		{"streaming-result-with-views", testdata.StreamingResultWithViewsDSL, testdata.StreamingResultWithViewsClientCode},
+		{"streaming-result-proto", testdata.StreamingResultProtoDSL, testdata.StreamingResultProtoClientCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunTCPDSL(t, c.DSL)
+			fs := ClientFiles("", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected one", len(fs))
+			}
+			code := codegen.SectionsCode(t, fs[0].SectionTemplates[1:])
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestClientFilesNoTCP(t *testing.T) {
+	RunTCPDSL(t, testdata.NoTCPDSL)
+	if fs := ClientFiles("", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
@@ -0,0 +1,145 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// ServerFiles returns the raw TCP server files of the services that enable the
+// TCP transport on at least one method. The files contain the method handlers
+// and the server stream implementations.
+func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.Services {
+		if f := serverFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// serverFile returns the file defining the TCP server of the given service,
+// nil if the service does not enable the TCP transport.
+func serverFile(genpkg string, svc *expr.ServiceExpr) *codegen.File {
+	data := TCPServices.Get(svc.Name)
+	if data == nil {
+		return nil
+	}
+	svcName := serviceVarName(data)
+	fpath := filepath.Join(codegen.Gendir, "tcp", svcName, "server", "server.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		codegen.GoaImport(""),
+		codegen.GoaNamedImport("tcp", "goatcp"),
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+	}
+	if data.UsesProto {
+		imports = append(imports,
+			&codegen.ImportSpec{Path: path.Join(genpkg, "grpc", svcName, "pb"), Name: data.PbPkgName},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "grpc", svcName, "server"), Name: data.GRPCServerPkgName},
+		)
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name+" TCP server", "server", imports),
+		{Name: "tcp-server-init", Source: serverInitT, Data: data},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections,
+			&codegen.SectionTemplate{Name: "tcp-handler-init", Source: handlerInitT, Data: e},
+			&codegen.SectionTemplate{Name: "tcp-server-stream", Source: serverStreamT, Data: e},
+		)
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const serverInitT = `{{ printf "New instantiates a TCP server that serves the %s service methods that enable the TCP transport. Use Serve to accept connections on a listener, wrap the listener with tls.NewListener to use TLS." .Service.Name | comment }}
+func New(e *{{ .Service.PkgName }}.Endpoints) *goatcp.Server {
+	s := goatcp.NewServer()
+{{- range .Endpoints }}
+	s.Handle({{ printf "%q" .Method.Name }}, {{ .HandlerInit }}(e.{{ .Method.VarName }}))
+{{- end }}
+	return s
+}
+`
+
+// input: EndpointData
+const handlerInitT = `{{ printf "%s creates a TCP handler which serves the %q service %q method." .HandlerInit .ServiceName .Method.Name | comment }}
+func {{ .HandlerInit }}(endpoint goa.Endpoint) goatcp.HandlerFunc {
+	return func(ctx context.Context, conn *goatcp.Conn, body []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
+		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+{{- if .HasPayload }}
+	{{- if .Proto }}
+		var message {{ .Proto.RequestMessage }}
+		if err := goatcp.ProtoCodec.Unmarshal(body, &message); err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+		{{- if .Proto.RequestValidation }}
+		if err := {{ .Proto.RequestValidation }}(&message); err != nil {
+			return err
+		}
+		{{- end }}
+		payload := {{ .Proto.PayloadInit }}(&message)
+	{{- else }}
+		var payload {{ .PayloadRef }}
+		if err := goatcp.JSONCodec.Unmarshal(body, &payload); err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+	{{- end }}
+{{- end }}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+{{- if .HasPayload }}
+			Payload: payload,
+{{- end }}
+			Stream:  &{{ .ServerStream }}{conn: conn{{ if and .ViewedResult (not .SetView) }}, view: {{ printf "%q" .ViewedResult.ViewName }}{{ end }}},
+		}
+		_, err := endpoint(ctx, v)
+		return err
+	}
+}
+`
+
+// input: EndpointData
+const serverStreamT = `{{ printf "%s implements the %s interface." .ServerStream .ServerStreamInterface | comment }}
+type {{ .ServerStream }} struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+{{- if .ViewedResult }}
+	// view is the view used to render the results.
+	view string
+{{- end }}
+}
+
+{{ printf "Send streams instances of %q to the %q method TCP connection." .ResultRef .Method.Name | comment }}
+func (s *{{ .ServerStream }}) Send(v {{ .ResultRef }}) error {
+{{- if .ViewedResult }}
+	vres := {{ .ServicePkgName }}.{{ .ViewedResult.Init.Name }}(v, s.view)
+	v = {{ .ServicePkgName }}.{{ .ViewedResult.ResultInit.Name }}(vres)
+{{- end }}
+{{- if .Proto }}
+	body, err := goatcp.ProtoCodec.Marshal({{ .Proto.ResponseInit }}(v))
+{{- else }}
+	body, err := goatcp.JSONCodec.Marshal(v)
+{{- end }}
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteFrame(goatcp.FrameData, body)
+}
+
+// Close is a no-op, the stream ends once the method returns.
+func (s *{{ .ServerStream }}) Close() error {
+	return nil
+}
+{{- if .SetView }}
+
+// SetView sets the view used to render the results.
+func (s *{{ .ServerStream }}) SetView(view string) {
+	s.view = view
+}
+{{- end }}
+`
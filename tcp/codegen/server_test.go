@@ -0,0 +1,42 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/tcp/codegen/testdata"
+)
+
+func TestServerFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"streaming-result", testdata.StreamingResultDSL, testdata.StreamingResultServerCode},
+		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadDSL, testdata.StreamingResultNoPayloadServerCode},
+		{"streaming-result-with-views", testdata.StreamingResultWithViewsDSL, testdata.StreamingResultWithViewsServerCode},
+		{"streaming-result-proto", testdata.StreamingResultProtoDSL, testdata.StreamingResultProtoServerCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunTCPDSL(t, c.DSL)
+			fs := ServerFiles("", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected one", len(fs))
+			}
+			code := codegen.SectionsCode(t, fs[0].SectionTemplates[1:])
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerFilesNoTCP(t *testing.T) {
+	RunTCPDSL(t, testdata.NoTCPDSL)
+	if fs := ServerFiles("", expr.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
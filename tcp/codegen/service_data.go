@@ -0,0 +1,216 @@
+package codegen
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
+)
+
+// TCPServices holds the data computed from the design needed to generate the
+// raw TCP transport code of the services.
+var TCPServices = make(ServicesData)
+
+type (
+	// ServicesData encapsulates the data computed from the design.
+	ServicesData map[string]*ServiceData
+
+	// ServiceData contains the data used to render the code related to a
+	// single service.
+	ServiceData struct {
+		// Service contains the related service data.
+		Service *service.Data
+		// Endpoints describes the methods served by the TCP transport.
+		Endpoints []*EndpointData
+		// UsesProto is true if at least one endpoint uses the "proto"
+		// encoding.
+		UsesProto bool
+		// PbPkgName is the name of the package generated by protoc for
+		// the gRPC transport, empty if no endpoint uses the "proto"
+		// encoding.
+		PbPkgName string
+		// GRPCServerPkgName is the name used to import the gRPC server
+		// package that defines the message constructors.
+		GRPCServerPkgName string
+		// GRPCClientPkgName is the name used to import the gRPC client
+		// package that defines the message constructors.
+		GRPCClientPkgName string
+	}
+
+	// EndpointData contains the data used to render the code related to a
+	// method served by the TCP transport.
+	EndpointData struct {
+		// ServiceName is the name of the service.
+		ServiceName string
+		// ServicePkgName is the name of the service package.
+		ServicePkgName string
+		// Method is the data for the underlying method expression.
+		Method *service.MethodData
+		// Encoding is the encoding of the messages, expr.TCPEncodingJSON or
+		// expr.TCPEncodingProto.
+		Encoding string
+		// HandlerInit is the name of the function that creates the TCP
+		// handler of the method.
+		HandlerInit string
+		// PayloadRef is the fully qualified reference to the method
+		// payload, empty if the method does not define one.
+		PayloadRef string
+		// ResultRef is the fully qualified reference to the method result.
+		ResultRef string
+		// ViewedResult describes the viewed result type if any.
+		ViewedResult *service.ViewedResultTypeData
+		// SetView is true if the view used to render the results is set
+		// by the method with SetView.
+		SetView bool
+		// ServerStream is the name of the struct that implements the
+		// service server stream interface.
+		ServerStream string
+		// ClientStream is the name of the struct that implements the
+		// service client stream interface.
+		ClientStream string
+		// Proto describes the protocol buffer messages used by the
+		// "proto" encoding, nil if the endpoint uses JSON.
+		Proto *ProtoData
+	}
+
+	// ProtoData contains the qualified names of the protocol buffer
+	// messages and constructors generated for the gRPC transport and used
+	// by the "proto" encoding.
+	ProtoData struct {
+		// RequestMessage is the request message type.
+		RequestMessage string
+		// PayloadInit builds the payload from the request message.
+		PayloadInit string
+		// RequestValidation validates the request message if needed.
+		RequestValidation string
+		// RequestInit builds the request message from the payload.
+		RequestInit string
+		// ResponseMessage is the response message type.
+		ResponseMessage string
+		// ResponseInit builds the response message from the result.
+		ResponseInit string
+		// ResultInit builds the result from the response message.
+		ResultInit string
+		// ResponseValidation validates the response message if needed.
+		ResponseValidation string
+	}
+)
+
+// Get retrieves the transport data for the service with the given name
+// computing it if needed. It returns nil if the service does not enable the
+// TCP transport on any method.
+func (d ServicesData) Get(name string) *ServiceData {
+	if data, ok := d[name]; ok {
+		return data
+	}
+	svc := expr.Root.Service(name)
+	if svc == nil {
+		return nil
+	}
+	d[name] = d.analyze(svc)
+	return d[name]
+}
+
+// analyze creates the data necessary to render the code of the given service.
+// It returns nil if no method of the service enables the TCP transport.
+func (d ServicesData) analyze(svc *expr.ServiceExpr) *ServiceData {
+	sd := service.Services.Get(svc.Name)
+	data := &ServiceData{Service: sd}
+	for _, m := range svc.Methods {
+		enc := m.TCPEncoding()
+		if enc == "" {
+			continue
+		}
+		md := sd.Method(m.Name)
+		ed := &EndpointData{
+			ServiceName:    svc.Name,
+			ServicePkgName: sd.PkgName,
+			Method:         md,
+			Encoding:       enc,
+			HandlerInit:    "New" + md.VarName + "Handler",
+			ResultRef:      sd.Scope.GoFullTypeRef(m.Result, sd.PkgName),
+			ViewedResult:   md.ViewedResult,
+			ServerStream:   md.VarName + "ServerStream",
+			ClientStream:   md.VarName + "ClientStream",
+		}
+		if m.Payload.Type != expr.Empty {
+			ed.PayloadRef = sd.Scope.GoFullTypeRef(m.Payload, sd.PkgName)
+		}
+		if md.ViewedResult != nil && md.ViewedResult.ViewName == "" {
+			ed.SetView = true
+		}
+		if enc == expr.TCPEncodingProto {
+			ed.Proto = protoData(m, data)
+		}
+		data.Endpoints = append(data.Endpoints, ed)
+	}
+	if len(data.Endpoints) == 0 {
+		return nil
+	}
+	return data
+}
+
+// protoData returns the names of the protocol buffer messages and constructors
+// generated for the gRPC endpoint of the given method. The design validations
+// guarantee that the method defines a gRPC endpoint.
+func protoData(m *expr.MethodExpr, sd *ServiceData) *ProtoData {
+	gsd := grpccodegen.GRPCServices.Get(m.Service.Name)
+	ged := gsd.Endpoint(m.Name)
+	sd.UsesProto = true
+	sd.PbPkgName = gsd.PkgName
+	sd.GRPCServerPkgName = sd.Service.PkgName + "grpc"
+	sd.GRPCClientPkgName = sd.Service.PkgName + "grpcc"
+	var (
+		spkg = sd.GRPCServerPkgName
+		cpkg = sd.GRPCClientPkgName
+		pd   = &ProtoData{}
+	)
+	if c := ged.Request.ServerConvert; c != nil {
+		pd.RequestMessage = strings.TrimPrefix(c.SrcRef, "*")
+		if c.Init != nil {
+			pd.PayloadInit = spkg + "." + c.Init.Name
+		}
+		if c.Validation != nil {
+			pd.RequestValidation = spkg + "." + c.Validation.Name
+		}
+	}
+	if c := ged.Request.ClientConvert; c != nil && c.Init != nil {
+		pd.RequestInit = cpkg + "." + c.Init.Name
+	}
+	if c := ged.ServerStream.SendConvert; c != nil {
+		pd.ResponseMessage = strings.TrimPrefix(c.TgtRef, "*")
+		pd.ResponseInit = spkg + "." + c.Init.Name
+	}
+	if c := ged.ClientStream.RecvConvert; c != nil {
+		pd.ResultInit = cpkg + "." + c.Init.Name
+		if c.Validation != nil {
+			pd.ResponseValidation = cpkg + "." + c.Validation.Name
+		}
+	}
+	return pd
+}
+
+// HasPayload returns true if the endpoint method defines a payload.
+func (e *EndpointData) HasPayload() bool {
+	return e.PayloadRef != ""
+}
+
+// ServerStreamInterface returns the qualified name of the service server
+// stream interface implemented by the server stream.
+func (e *EndpointData) ServerStreamInterface() string {
+	return e.ServicePkgName + "." + e.Method.ServerStream.Interface
+}
+
+// ClientStreamInterface returns the qualified name of the service client
+// stream interface implemented by the client stream.
+func (e *EndpointData) ClientStreamInterface() string {
+	return e.ServicePkgName + "." + e.Method.ClientStream.Interface
+}
+
+// serviceVarName returns the snake case name of the service used in the
+// generated file paths.
+func serviceVarName(sd *ServiceData) string {
+	return codegen.SnakeCase(sd.Service.VarName)
+}
@@ -0,0 +1,254 @@
+package testdata
+
+var StreamingResultClientCode = `// Client lists the ServiceStreamingResult service endpoint TCP clients.
+type Client struct {
+	dial goatcp.Dialer
+}
+
+// NewClient instantiates TCP clients for the ServiceStreamingResult service
+// methods that enable the TCP transport. Each stream uses a new connection
+// established with dial, see goatcp.NewDialer.
+func NewClient(dial goatcp.Dialer) *Client {
+	return &Client{dial: dial}
+}
+
+// MethodStreamingResult returns an endpoint that opens a stream to the
+// "ServiceStreamingResult" service "MethodStreamingResult" method TCP server.
+func (c *Client) MethodStreamingResult() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		p, ok := v.(*servicestreamingresult.MethodStreamingResultPayload)
+		if !ok {
+			return nil, goatcp.ErrInvalidType("ServiceStreamingResult", "MethodStreamingResult", "*servicestreamingresult.MethodStreamingResultPayload", v)
+		}
+		body, err := goatcp.JSONCodec.Marshal(p)
+		if err != nil {
+			return nil, goatcp.ErrEncodingError("ServiceStreamingResult", "MethodStreamingResult", err)
+		}
+		conn, err := goatcp.OpenStream(ctx, c.dial, "MethodStreamingResult", body)
+		if err != nil {
+			return nil, goatcp.ErrRequestError("ServiceStreamingResult", "MethodStreamingResult", err)
+		}
+		return &MethodStreamingResultClientStream{conn: conn}, nil
+	}
+}
+
+// MethodStreamingResultClientStream implements the
+// servicestreamingresult.MethodStreamingResultClientStream interface.
+type MethodStreamingResultClientStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+// Recv reads instances of "*servicestreamingresult.Tick" from the stream. It
+// returns io.EOF once the server ends the stream. The connection is closed
+// when Recv returns an error.
+func (s *MethodStreamingResultClientStream) Recv() (*servicestreamingresult.Tick, error) {
+	var res *servicestreamingresult.Tick
+	body, err := s.conn.Recv()
+	if err != nil {
+		s.conn.Close()
+		return res, err
+	}
+	if err := goatcp.JSONCodec.Unmarshal(body, &res); err != nil {
+		s.conn.Close()
+		return res, goatcp.ErrDecodingError("ServiceStreamingResult", "MethodStreamingResult", err)
+	}
+	return res, nil
+}
+
+// Close closes the stream connection, use it to stop reading the results
+// before the server ends the stream.
+func (s *MethodStreamingResultClientStream) Close() error {
+	return s.conn.Close()
+}
+`
+
+var StreamingResultNoPayloadClientCode = `// Client lists the ServiceStreamingResultNoPayload service endpoint TCP
+// clients.
+type Client struct {
+	dial goatcp.Dialer
+}
+
+// NewClient instantiates TCP clients for the ServiceStreamingResultNoPayload
+// service methods that enable the TCP transport. Each stream uses a new
+// connection established with dial, see goatcp.NewDialer.
+func NewClient(dial goatcp.Dialer) *Client {
+	return &Client{dial: dial}
+}
+
+// MethodStreamingResultNoPayload returns an endpoint that opens a stream to
+// the "ServiceStreamingResultNoPayload" service
+// "MethodStreamingResultNoPayload" method TCP server.
+func (c *Client) MethodStreamingResultNoPayload() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		var body []byte
+		conn, err := goatcp.OpenStream(ctx, c.dial, "MethodStreamingResultNoPayload", body)
+		if err != nil {
+			return nil, goatcp.ErrRequestError("ServiceStreamingResultNoPayload", "MethodStreamingResultNoPayload", err)
+		}
+		return &MethodStreamingResultNoPayloadClientStream{conn: conn}, nil
+	}
+}
+
+// MethodStreamingResultNoPayloadClientStream implements the
+// servicestreamingresultnopayload.MethodStreamingResultNoPayloadClientStream
+// interface.
+type MethodStreamingResultNoPayloadClientStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+// Recv reads instances of "[]string" from the stream. It returns io.EOF once
+// the server ends the stream. The connection is closed when Recv returns an
+// error.
+func (s *MethodStreamingResultNoPayloadClientStream) Recv() ([]string, error) {
+	var res []string
+	body, err := s.conn.Recv()
+	if err != nil {
+		s.conn.Close()
+		return res, err
+	}
+	if err := goatcp.JSONCodec.Unmarshal(body, &res); err != nil {
+		s.conn.Close()
+		return res, goatcp.ErrDecodingError("ServiceStreamingResultNoPayload", "MethodStreamingResultNoPayload", err)
+	}
+	return res, nil
+}
+
+// Close closes the stream connection, use it to stop reading the results
+// before the server ends the stream.
+func (s *MethodStreamingResultNoPayloadClientStream) Close() error {
+	return s.conn.Close()
+}
+`
+
+var StreamingResultWithViewsClientCode = `// Client lists the ServiceStreamingResultWithViews service endpoint TCP
+// clients.
+type Client struct {
+	dial goatcp.Dialer
+}
+
+// NewClient instantiates TCP clients for the ServiceStreamingResultWithViews
+// service methods that enable the TCP transport. Each stream uses a new
+// connection established with dial, see goatcp.NewDialer.
+func NewClient(dial goatcp.Dialer) *Client {
+	return &Client{dial: dial}
+}
+
+// MethodStreamingResultWithViews returns an endpoint that opens a stream to
+// the "ServiceStreamingResultWithViews" service
+// "MethodStreamingResultWithViews" method TCP server.
+func (c *Client) MethodStreamingResultWithViews() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		p, ok := v.(string)
+		if !ok {
+			return nil, goatcp.ErrInvalidType("ServiceStreamingResultWithViews", "MethodStreamingResultWithViews", "string", v)
+		}
+		body, err := goatcp.JSONCodec.Marshal(p)
+		if err != nil {
+			return nil, goatcp.ErrEncodingError("ServiceStreamingResultWithViews", "MethodStreamingResultWithViews", err)
+		}
+		conn, err := goatcp.OpenStream(ctx, c.dial, "MethodStreamingResultWithViews", body)
+		if err != nil {
+			return nil, goatcp.ErrRequestError("ServiceStreamingResultWithViews", "MethodStreamingResultWithViews", err)
+		}
+		return &MethodStreamingResultWithViewsClientStream{conn: conn}, nil
+	}
+}
+
+// MethodStreamingResultWithViewsClientStream implements the
+// servicestreamingresultwithviews.MethodStreamingResultWithViewsClientStream
+// interface.
+type MethodStreamingResultWithViewsClientStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+// Recv reads instances of "*servicestreamingresultwithviews.Quote" from the
+// stream. It returns io.EOF once the server ends the stream. The connection is
+// closed when Recv returns an error.
+func (s *MethodStreamingResultWithViewsClientStream) Recv() (*servicestreamingresultwithviews.Quote, error) {
+	var res *servicestreamingresultwithviews.Quote
+	body, err := s.conn.Recv()
+	if err != nil {
+		s.conn.Close()
+		return res, err
+	}
+	if err := goatcp.JSONCodec.Unmarshal(body, &res); err != nil {
+		s.conn.Close()
+		return res, goatcp.ErrDecodingError("ServiceStreamingResultWithViews", "MethodStreamingResultWithViews", err)
+	}
+	return res, nil
+}
+
+// Close closes the stream connection, use it to stop reading the results
+// before the server ends the stream.
+func (s *MethodStreamingResultWithViewsClientStream) Close() error {
+	return s.conn.Close()
+}
+`
+
+var StreamingResultProtoClientCode = `// Client lists the ServiceStreamingResultProto service endpoint TCP clients.
+type Client struct {
+	dial goatcp.Dialer
+}
+
+// NewClient instantiates TCP clients for the ServiceStreamingResultProto
+// service methods that enable the TCP transport. Each stream uses a new
+// connection established with dial, see goatcp.NewDialer.
+func NewClient(dial goatcp.Dialer) *Client {
+	return &Client{dial: dial}
+}
+
+// MethodStreamingResultProto returns an endpoint that opens a stream to the
+// "ServiceStreamingResultProto" service "MethodStreamingResultProto" method
+// TCP server.
+func (c *Client) MethodStreamingResultProto() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		p, ok := v.(*servicestreamingresultproto.MethodStreamingResultProtoPayload)
+		if !ok {
+			return nil, goatcp.ErrInvalidType("ServiceStreamingResultProto", "MethodStreamingResultProto", "*servicestreamingresultproto.MethodStreamingResultProtoPayload", v)
+		}
+		body, err := goatcp.ProtoCodec.Marshal(servicestreamingresultprotogrpcc.NewMethodStreamingResultProtoRequest(p))
+		if err != nil {
+			return nil, goatcp.ErrEncodingError("ServiceStreamingResultProto", "MethodStreamingResultProto", err)
+		}
+		conn, err := goatcp.OpenStream(ctx, c.dial, "MethodStreamingResultProto", body)
+		if err != nil {
+			return nil, goatcp.ErrRequestError("ServiceStreamingResultProto", "MethodStreamingResultProto", err)
+		}
+		return &MethodStreamingResultProtoClientStream{conn: conn}, nil
+	}
+}
+
+// MethodStreamingResultProtoClientStream implements the
+// servicestreamingresultproto.MethodStreamingResultProtoClientStream interface.
+type MethodStreamingResultProtoClientStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+// Recv reads instances of "*servicestreamingresultproto.Tick" from the stream.
+// It returns io.EOF once the server ends the stream. The connection is closed
+// when Recv returns an error.
+func (s *MethodStreamingResultProtoClientStream) Recv() (*servicestreamingresultproto.Tick, error) {
+	var res *servicestreamingresultproto.Tick
+	body, err := s.conn.Recv()
+	if err != nil {
+		s.conn.Close()
+		return res, err
+	}
+	var message service_streaming_result_protopb.MethodStreamingResultProtoResponse
+	if err := goatcp.ProtoCodec.Unmarshal(body, &message); err != nil {
+		s.conn.Close()
+		return res, goatcp.ErrDecodingError("ServiceStreamingResultProto", "MethodStreamingResultProto", err)
+	}
+	return servicestreamingresultprotogrpcc.NewTick(&message), nil
+}
+
+// Close closes the stream connection, use it to stop reading the results
+// before the server ends the stream.
+func (s *MethodStreamingResultProtoClientStream) Close() error {
+	return s.conn.Close()
+}
+`
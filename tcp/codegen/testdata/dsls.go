@@ -0,0 +1,87 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var StreamingResultDSL = func() {
+	var Tick = Type("Tick", func() {
+		Field(1, "symbol", String)
+		Field(2, "price", Float64)
+		Required("symbol", "price")
+	})
+	Service("ServiceStreamingResult", func() {
+		Method("MethodStreamingResult", func() {
+			Payload(func() {
+				Field(1, "symbol", String)
+				Required("symbol")
+			})
+			StreamingResult(Tick)
+			TCP()
+		})
+		Method("MethodUnary", func() {
+			Result(Tick)
+		})
+	})
+}
+
+var StreamingResultNoPayloadDSL = func() {
+	Service("ServiceStreamingResultNoPayload", func() {
+		TCP()
+		Method("MethodStreamingResultNoPayload", func() {
+			StreamingResult(ArrayOf(String))
+		})
+	})
+}
+
+var StreamingResultWithViewsDSL = func() {
+	var RT = ResultType("application/vnd.quote", func() {
+		Attributes(func() {
+			Attribute("symbol", String)
+			Attribute("price", Float64)
+		})
+		View("default", func() {
+			Attribute("symbol")
+			Attribute("price")
+		})
+		View("tiny", func() {
+			Attribute("symbol")
+		})
+	})
+	Service("ServiceStreamingResultWithViews", func() {
+		Method("MethodStreamingResultWithViews", func() {
+			Payload(String)
+			StreamingResult(RT)
+			TCP("json")
+		})
+	})
+}
+
+var StreamingResultProtoDSL = func() {
+	var Tick = Type("Tick", func() {
+		Field(1, "symbol", String)
+		Field(2, "price", Float64)
+		Required("symbol", "price")
+	})
+	Service("ServiceStreamingResultProto", func() {
+		Method("MethodStreamingResultProto", func() {
+			Payload(func() {
+				Field(1, "symbol", String, func() {
+					Pattern("^[A-Z]+$")
+				})
+				Required("symbol")
+			})
+			StreamingResult(Tick)
+			TCP("proto")
+			GRPC(func() {})
+		})
+	})
+}
+
+var NoTCPDSL = func() {
+	Service("ServiceNoTCP", func() {
+		Method("MethodStreamingResult", func() {
+			StreamingResult(String)
+		})
+	})
+}
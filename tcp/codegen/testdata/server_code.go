@@ -0,0 +1,219 @@
+package testdata
+
+var StreamingResultServerCode = `// New instantiates a TCP server that serves the ServiceStreamingResult service
+// methods that enable the TCP transport. Use Serve to accept connections on a
+// listener, wrap the listener with tls.NewListener to use TLS.
+func New(e *servicestreamingresult.Endpoints) *goatcp.Server {
+	s := goatcp.NewServer()
+	s.Handle("MethodStreamingResult", NewMethodStreamingResultHandler(e.MethodStreamingResult))
+	return s
+}
+
+// NewMethodStreamingResultHandler creates a TCP handler which serves the
+// "ServiceStreamingResult" service "MethodStreamingResult" method.
+func NewMethodStreamingResultHandler(endpoint goa.Endpoint) goatcp.HandlerFunc {
+	return func(ctx context.Context, conn *goatcp.Conn, body []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodStreamingResult")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceStreamingResult")
+		var payload *servicestreamingresult.MethodStreamingResultPayload
+		if err := goatcp.JSONCodec.Unmarshal(body, &payload); err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+		v := &servicestreamingresult.MethodStreamingResultEndpointInput{
+			Payload: payload,
+			Stream:  &MethodStreamingResultServerStream{conn: conn},
+		}
+		_, err := endpoint(ctx, v)
+		return err
+	}
+}
+
+// MethodStreamingResultServerStream implements the
+// servicestreamingresult.MethodStreamingResultServerStream interface.
+type MethodStreamingResultServerStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+// Send streams instances of "*servicestreamingresult.Tick" to the
+// "MethodStreamingResult" method TCP connection.
+func (s *MethodStreamingResultServerStream) Send(v *servicestreamingresult.Tick) error {
+	body, err := goatcp.JSONCodec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteFrame(goatcp.FrameData, body)
+}
+
+// Close is a no-op, the stream ends once the method returns.
+func (s *MethodStreamingResultServerStream) Close() error {
+	return nil
+}
+`
+
+var StreamingResultNoPayloadServerCode = `// New instantiates a TCP server that serves the
+// ServiceStreamingResultNoPayload service methods that enable the TCP
+// transport. Use Serve to accept connections on a listener, wrap the listener
+// with tls.NewListener to use TLS.
+func New(e *servicestreamingresultnopayload.Endpoints) *goatcp.Server {
+	s := goatcp.NewServer()
+	s.Handle("MethodStreamingResultNoPayload", NewMethodStreamingResultNoPayloadHandler(e.MethodStreamingResultNoPayload))
+	return s
+}
+
+// NewMethodStreamingResultNoPayloadHandler creates a TCP handler which serves
+// the "ServiceStreamingResultNoPayload" service
+// "MethodStreamingResultNoPayload" method.
+func NewMethodStreamingResultNoPayloadHandler(endpoint goa.Endpoint) goatcp.HandlerFunc {
+	return func(ctx context.Context, conn *goatcp.Conn, body []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodStreamingResultNoPayload")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceStreamingResultNoPayload")
+		v := &servicestreamingresultnopayload.MethodStreamingResultNoPayloadEndpointInput{
+			Stream: &MethodStreamingResultNoPayloadServerStream{conn: conn},
+		}
+		_, err := endpoint(ctx, v)
+		return err
+	}
+}
+
+// MethodStreamingResultNoPayloadServerStream implements the
+// servicestreamingresultnopayload.MethodStreamingResultNoPayloadServerStream
+// interface.
+type MethodStreamingResultNoPayloadServerStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+// Send streams instances of "[]string" to the "MethodStreamingResultNoPayload"
+// method TCP connection.
+func (s *MethodStreamingResultNoPayloadServerStream) Send(v []string) error {
+	body, err := goatcp.JSONCodec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteFrame(goatcp.FrameData, body)
+}
+
+// Close is a no-op, the stream ends once the method returns.
+func (s *MethodStreamingResultNoPayloadServerStream) Close() error {
+	return nil
+}
+`
+
+var StreamingResultWithViewsServerCode = `// New instantiates a TCP server that serves the
+// ServiceStreamingResultWithViews service methods that enable the TCP
+// transport. Use Serve to accept connections on a listener, wrap the listener
+// with tls.NewListener to use TLS.
+func New(e *servicestreamingresultwithviews.Endpoints) *goatcp.Server {
+	s := goatcp.NewServer()
+	s.Handle("MethodStreamingResultWithViews", NewMethodStreamingResultWithViewsHandler(e.MethodStreamingResultWithViews))
+	return s
+}
+
+// NewMethodStreamingResultWithViewsHandler creates a TCP handler which serves
+// the "ServiceStreamingResultWithViews" service
+// "MethodStreamingResultWithViews" method.
+func NewMethodStreamingResultWithViewsHandler(endpoint goa.Endpoint) goatcp.HandlerFunc {
+	return func(ctx context.Context, conn *goatcp.Conn, body []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodStreamingResultWithViews")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceStreamingResultWithViews")
+		var payload string
+		if err := goatcp.JSONCodec.Unmarshal(body, &payload); err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+		v := &servicestreamingresultwithviews.MethodStreamingResultWithViewsEndpointInput{
+			Payload: payload,
+			Stream:  &MethodStreamingResultWithViewsServerStream{conn: conn},
+		}
+		_, err := endpoint(ctx, v)
+		return err
+	}
+}
+
+// MethodStreamingResultWithViewsServerStream implements the
+// servicestreamingresultwithviews.MethodStreamingResultWithViewsServerStream
+// interface.
+type MethodStreamingResultWithViewsServerStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+	// view is the view used to render the results.
+	view string
+}
+
+// Send streams instances of "*servicestreamingresultwithviews.Quote" to the
+// "MethodStreamingResultWithViews" method TCP connection.
+func (s *MethodStreamingResultWithViewsServerStream) Send(v *servicestreamingresultwithviews.Quote) error {
+	vres := servicestreamingresultwithviews.NewViewedQuote(v, s.view)
+	v = servicestreamingresultwithviews.NewQuote(vres)
+	body, err := goatcp.JSONCodec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteFrame(goatcp.FrameData, body)
+}
+
+// Close is a no-op, the stream ends once the method returns.
+func (s *MethodStreamingResultWithViewsServerStream) Close() error {
+	return nil
+}
+
+// SetView sets the view used to render the results.
+func (s *MethodStreamingResultWithViewsServerStream) SetView(view string) {
+	s.view = view
+}
+`
+
+var StreamingResultProtoServerCode = `// New instantiates a TCP server that serves the ServiceStreamingResultProto
+// service methods that enable the TCP transport. Use Serve to accept
+// connections on a listener, wrap the listener with tls.NewListener to use TLS.
+func New(e *servicestreamingresultproto.Endpoints) *goatcp.Server {
+	s := goatcp.NewServer()
+	s.Handle("MethodStreamingResultProto", NewMethodStreamingResultProtoHandler(e.MethodStreamingResultProto))
+	return s
+}
+
+// NewMethodStreamingResultProtoHandler creates a TCP handler which serves the
+// "ServiceStreamingResultProto" service "MethodStreamingResultProto" method.
+func NewMethodStreamingResultProtoHandler(endpoint goa.Endpoint) goatcp.HandlerFunc {
+	return func(ctx context.Context, conn *goatcp.Conn, body []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodStreamingResultProto")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceStreamingResultProto")
+		var message service_streaming_result_protopb.MethodStreamingResultProtoRequest
+		if err := goatcp.ProtoCodec.Unmarshal(body, &message); err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+		if err := servicestreamingresultprotogrpc.ValidateMethodStreamingResultProtoRequest(&message); err != nil {
+			return err
+		}
+		payload := servicestreamingresultprotogrpc.NewMethodStreamingResultProtoPayload(&message)
+		v := &servicestreamingresultproto.MethodStreamingResultProtoEndpointInput{
+			Payload: payload,
+			Stream:  &MethodStreamingResultProtoServerStream{conn: conn},
+		}
+		_, err := endpoint(ctx, v)
+		return err
+	}
+}
+
+// MethodStreamingResultProtoServerStream implements the
+// servicestreamingresultproto.MethodStreamingResultProtoServerStream interface.
+type MethodStreamingResultProtoServerStream struct {
+	// conn is the underlying TCP connection.
+	conn *goatcp.Conn
+}
+
+// Send streams instances of "*servicestreamingresultproto.Tick" to the
+// "MethodStreamingResultProto" method TCP connection.
+func (s *MethodStreamingResultProtoServerStream) Send(v *servicestreamingresultproto.Tick) error {
+	body, err := goatcp.ProtoCodec.Marshal(servicestreamingresultprotogrpc.NewMethodStreamingResultProtoResponse(v))
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteFrame(goatcp.FrameData, body)
+}
+
+// Close is a no-op, the stream ends once the method returns.
+func (s *MethodStreamingResultProtoServerStream) Close() error {
+	return nil
+}
+`
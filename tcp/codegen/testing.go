@@ -0,0 +1,19 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
+)
+
+// RunTCPDSL returns the DSL root resulting from running the given DSL. It is
+// used only in tests.
+func RunTCPDSL(t *testing.T, dsl func()) *expr.RootExpr {
+	// reset all roots and codegen data structures
+	service.Services = make(service.ServicesData)
+	grpccodegen.GRPCServices = make(grpccodegen.ServicesData)
+	TCPServices = make(ServicesData)
+	return expr.RunDSL(t, dsl)
+}
@@ -0,0 +1,156 @@
+package tcp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// Frame kinds.
+const (
+	// FrameOpen opens a stream, the frame contains the name of the method.
+	FrameOpen byte = iota + 1
+	// FrameData contains an encoded payload or result.
+	FrameData
+	// FrameEnd terminates a stream successfully.
+	FrameEnd
+	// FrameError terminates a stream with the encoded error returned by
+	// the method.
+	FrameError
+)
+
+// DefaultMaxFrameSize is the default maximum length of the frame messages.
+const DefaultMaxFrameSize = 4 << 20
+
+// frameHeaderSize is the length of the frame kind and message length.
+const frameHeaderSize = 5
+
+type (
+	// Conn reads and writes frames on a network connection. It is safe to
+	// write frames concurrently, reads must be done by a single goroutine.
+	Conn struct {
+		// MaxFrameSize is the maximum length of the messages read from
+		// the connection. Larger frames cause ReadFrame to fail.
+		MaxFrameSize int
+
+		conn      net.Conn
+		r         *bufio.Reader
+		mu        sync.Mutex
+		w         *bufio.Writer
+		closeOnce sync.Once
+		done      chan struct{}
+		closeErr  error
+	}
+
+	// FrameTooLargeError is the error returned when reading a frame whose
+	// message length exceeds the maximum frame size.
+	FrameTooLargeError struct {
+		// Size is the length of the message.
+		Size int
+		// Max is the maximum frame size.
+		Max int
+	}
+)
+
+// NewConn wraps the given network connection so that it can be used to read
+// and write frames.
+func NewConn(c net.Conn) *Conn {
+	return &Conn{
+		MaxFrameSize: DefaultMaxFrameSize,
+		conn:         c,
+		r:            bufio.NewReader(c),
+		w:            bufio.NewWriter(c),
+		done:         make(chan struct{}),
+	}
+}
+
+// WriteFrame writes a frame of the given kind containing msg and flushes it to
+// the network connection.
+func (c *Conn) WriteFrame(kind byte, msg []byte) error {
+	var header [frameHeaderSize]byte
+	header[0] = kind
+	binary.BigEndian.PutUint32(header[1:], uint32(len(msg)))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := c.w.Write(msg); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// ReadFrame reads the next frame from the network connection and returns its
+// kind and message.
+func (c *Conn) ReadFrame() (byte, []byte, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	kind := header[0]
+	if kind < FrameOpen || kind > FrameError {
+		return 0, nil, fmt.Errorf("tcp: invalid frame kind %d", kind)
+	}
+	size := int(binary.BigEndian.Uint32(header[1:]))
+	if max := c.MaxFrameSize; max > 0 && size > max {
+		return 0, nil, &FrameTooLargeError{Size: size, Max: max}
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(c.r, msg); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return kind, msg, nil
+}
+
+// Recv reads the next result streamed by the server. It returns io.EOF once
+// the server ends the stream and the error returned by the method if the
+// stream ended with an error.
+func (c *Conn) Recv() ([]byte, error) {
+	kind, msg, err := c.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case FrameData:
+		return msg, nil
+	case FrameEnd:
+		return nil, io.EOF
+	case FrameError:
+		return nil, DecodeError(msg)
+	default:
+		return nil, fmt.Errorf("tcp: unexpected frame kind %d", kind)
+	}
+}
+
+// Close closes the network connection. It is safe to call Close multiple
+// times.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.closeErr = c.conn.Close()
+	})
+	return c.closeErr
+}
+
+// Done returns a channel that is closed when the connection is closed.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// NetConn returns the underlying network connection.
+func (c *Conn) NetConn() net.Conn {
+	return c.conn
+}
+
+// Error returns the error message.
+func (e *FrameTooLargeError) Error() string {
+	return fmt.Sprintf("tcp: frame of %d bytes exceeds the maximum frame size of %d bytes", e.Size, e.Max)
+}
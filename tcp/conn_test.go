@@ -0,0 +1,109 @@
+package tcp
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestConnFrames(t *testing.T) {
+	cases := []struct {
+		name string
+		kind byte
+		msg  []byte
+	}{
+		{"open", FrameOpen, []byte("method")},
+		{"empty-data", FrameData, nil},
+		{"data", FrameData, []byte(`{"name":"a"}`)},
+		{"end", FrameEnd, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cli, svr := net.Pipe()
+			defer cli.Close()
+			defer svr.Close()
+			go NewConn(cli).WriteFrame(c.kind, c.msg)
+			kind, msg, err := NewConn(svr).ReadFrame()
+			if err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			if kind != c.kind {
+				t.Errorf("got kind %d, expected %d", kind, c.kind)
+			}
+			if !bytes.Equal(msg, c.msg) {
+				t.Errorf("got message %q, expected %q", msg, c.msg)
+			}
+		})
+	}
+}
+
+func TestConnReadFrameErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		max  int
+	}{
+		{"invalid-kind", []byte{9, 0, 0, 0, 0}, 0},
+		{"too-large", []byte{FrameData, 0, 0, 0, 4, 'a', 'b', 'c', 'd'}, 3},
+		{"truncated", []byte{FrameData, 0, 0, 0, 4, 'a'}, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cli, svr := net.Pipe()
+			defer svr.Close()
+			go func() {
+				cli.Write(c.data)
+				cli.Close()
+			}()
+			conn := NewConn(svr)
+			conn.MaxFrameSize = c.max
+			if _, _, err := conn.ReadFrame(); err == nil {
+				t.Errorf("got no error")
+			}
+		})
+	}
+}
+
+func TestConnRecv(t *testing.T) {
+	cli, svr := net.Pipe()
+	defer cli.Close()
+	defer svr.Close()
+	go func() {
+		w := NewConn(svr)
+		w.WriteFrame(FrameData, []byte("1"))
+		w.WriteFrame(FrameError, EncodeError(goa.PermanentError("not_found", "missing")))
+	}()
+	conn := NewConn(cli)
+	msg, err := conn.Recv()
+	if err != nil {
+		t.Fatalf("got error %q", err)
+	}
+	if string(msg) != "1" {
+		t.Errorf("got message %q, expected %q", msg, "1")
+	}
+	_, err = conn.Recv()
+	gerr, ok := err.(*goa.ServiceError)
+	if !ok {
+		t.Fatalf("got error %#v, expected a service error", err)
+	}
+	if gerr.Name != "not_found" || gerr.Message != "missing" {
+		t.Errorf("got error %q (%s), expected %q (%s)", gerr.Message, gerr.Name, "missing", "not_found")
+	}
+	if err := conn.Close(); err != nil {
+		t.Errorf("got close error %q", err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Errorf("got second close error %q", err)
+	}
+	select {
+	case <-conn.Done():
+	default:
+		t.Errorf("done channel not closed")
+	}
+	if _, err := conn.Recv(); err == nil || err == io.EOF {
+		t.Errorf("got %v after close, expected an error", err)
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Package tcp contains the runtime used by the code generated for the raw TCP
+transport. The transport streams the results of methods that define a
+StreamingResult over plain TCP or TLS connections without the overhead of HTTP
+or WebSocket framing. It is intended for internal high throughput consumers.
+
+Each connection carries a single stream. Messages are sent as frames made of a
+one byte kind, a four bytes big endian length and the encoded message:
+
+    +------+----------------+-------------------+
+    | kind | length (4 B)   | message (length B)|
+    +------+----------------+-------------------+
+
+The client opens the stream with a FrameOpen frame that contains the name of
+the method followed by a FrameData frame that contains the encoded payload
+(empty if the method does not define one). The server then writes one
+FrameData frame per result and terminates the stream with either a FrameEnd
+or a FrameError frame. Messages are encoded with a Codec, either JSONCodec or
+ProtoCodec for the protocol buffer messages generated for the gRPC transport.
+
+In addition to the framing the tcp package contains:
+
+    * A Server that dispatches the streams to the generated method handlers.
+    * Client helpers that open streams and read the results.
+    * The encoding and decoding of the errors returned by the methods.
+*/
+package tcp
@@ -0,0 +1,105 @@
+package tcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ClientError is an error returned by a TCP service client.
+	ClientError struct {
+		// Name is a name for this class of errors.
+		Name string
+		// Message contains the specific error details.
+		Message string
+		// Service is the name of the service.
+		Service string
+		// Method is the name of the service method.
+		Method string
+	}
+
+	// errorMessage is the message of the error frames.
+	errorMessage struct {
+		Name      string `json:"name"`
+		ID        string `json:"id"`
+		Message   string `json:"message"`
+		Timeout   bool   `json:"timeout,omitempty"`
+		Temporary bool   `json:"temporary,omitempty"`
+		Fault     bool   `json:"fault,omitempty"`
+	}
+)
+
+// EncodeError returns the message of the error frame that describes err. The
+// error characteristics are preserved if err is a goa ServiceError, other
+// errors are encoded as faults named after the value returned by ErrorName if
+// implemented.
+func EncodeError(err error) []byte {
+	var msg errorMessage
+	if gerr, ok := err.(*goa.ServiceError); ok {
+		msg = errorMessage{
+			Name:      gerr.Name,
+			ID:        gerr.ID,
+			Message:   gerr.Message,
+			Timeout:   gerr.Timeout,
+			Temporary: gerr.Temporary,
+			Fault:     gerr.Fault,
+		}
+	} else {
+		name := "fault"
+		if n, ok := err.(interface{ ErrorName() string }); ok {
+			name = n.ErrorName()
+		}
+		msg = errorMessage{Name: name, ID: goa.NewErrorID(), Message: err.Error(), Fault: true}
+	}
+	b, _ := json.Marshal(&msg) // cannot fail
+	return b
+}
+
+// DecodeError returns the goa ServiceError described by the message of an
+// error frame.
+func DecodeError(data []byte) error {
+	var msg errorMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("tcp: invalid error frame: %s", err)
+	}
+	return &goa.ServiceError{
+		Name:      msg.Name,
+		ID:        msg.ID,
+		Message:   msg.Message,
+		Timeout:   msg.Timeout,
+		Temporary: msg.Temporary,
+		Fault:     msg.Fault,
+	}
+}
+
+// ErrInvalidType is the error returned when the wrong type is given to a
+// method function.
+func ErrInvalidType(svc, m, expected string, actual interface{}) error {
+	msg := fmt.Sprintf("invalid value expected %s, got %v", expected, actual)
+	return &ClientError{Name: "invalid_type", Message: msg, Service: svc, Method: m}
+}
+
+// ErrEncodingError is the error returned when the encoding fails.
+func ErrEncodingError(svc, m string, err error) error {
+	msg := fmt.Sprintf("failed to encode: %s", err)
+	return &ClientError{Name: "encoding_error", Message: msg, Service: svc, Method: m}
+}
+
+// ErrDecodingError is the error returned when the decoding fails.
+func ErrDecodingError(svc, m string, err error) error {
+	msg := fmt.Sprintf("failed to decode: %s", err)
+	return &ClientError{Name: "decoding_error", Message: msg, Service: svc, Method: m}
+}
+
+// ErrRequestError is the error returned when the stream cannot be opened.
+func ErrRequestError(svc, m string, err error) error {
+	msg := fmt.Sprintf("failed to open stream: %s", err)
+	return &ClientError{Name: "request_error", Message: msg, Service: svc, Method: m}
+}
+
+// Error builds an error message.
+func (c *ClientError) Error() string {
+	return fmt.Sprintf("[%s %s]: %s", c.Service, c.Method, c.Message)
+}
@@ -0,0 +1,218 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// HandlerFunc serves a stream. payload is the message of the data
+	// frame sent by the client when opening the stream. The handler writes
+	// the results with the FrameData kind. The server terminates the
+	// stream with a FrameEnd frame if the handler returns nil and with a
+	// FrameError frame describing the returned error otherwise. The context
+	// is canceled when the client closes the connection.
+	HandlerFunc func(ctx context.Context, conn *Conn, payload []byte) error
+
+	// Server dispatches the streams opened by the clients to the method
+	// handlers.
+	Server struct {
+		// MaxFrameSize is the maximum length of the messages sent by the
+		// clients, DefaultMaxFrameSize if zero.
+		MaxFrameSize int
+		// ErrorHandler is called with the errors that cannot be sent to
+		// the clients such as network errors. It may be nil.
+		ErrorHandler func(ctx context.Context, err error)
+
+		handlers  map[string]HandlerFunc
+		mu        sync.Mutex
+		listeners map[net.Listener]struct{}
+		conns     map[*Conn]struct{}
+		closed    bool
+	}
+)
+
+// ErrServerClosed is the error returned by Serve after the server is closed.
+var ErrServerClosed = errors.New("tcp: server closed")
+
+// NewServer returns a server with no handler.
+func NewServer() *Server {
+	return &Server{
+		handlers:  make(map[string]HandlerFunc),
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[*Conn]struct{}),
+	}
+}
+
+// Handle registers the handler that serves the streams opened for the given
+// method.
+func (s *Server) Handle(method string, h HandlerFunc) {
+	s.handlers[method] = h
+}
+
+// Methods returns the sorted names of the methods served by s.
+func (s *Server) Methods() []string {
+	methods := make([]string, 0, len(s.handlers))
+	for m := range s.handlers {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Serve accepts the connections on l and serves each of them in a new
+// goroutine. Use a listener created with tls.NewListener to serve TLS
+// connections. Serve always returns a non-nil error, ErrServerClosed after
+// Close is called.
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrServerClosed
+	}
+	s.listeners[l] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.listeners, l)
+		s.mu.Unlock()
+	}()
+
+	var delay time.Duration
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				if delay == 0 {
+					delay = 5 * time.Millisecond
+				} else if delay *= 2; delay > time.Second {
+					delay = time.Second
+				}
+				time.Sleep(delay)
+				continue
+			}
+			return err
+		}
+		delay = 0
+		go s.ServeConn(c)
+	}
+}
+
+// ServeConn serves the stream opened on the given connection and closes it
+// once the stream ends.
+func (s *Server) ServeConn(c net.Conn) {
+	conn := NewConn(c)
+	if s.MaxFrameSize > 0 {
+		conn.MaxFrameSize = s.MaxFrameSize
+	}
+	if !s.track(conn) {
+		conn.Close()
+		return
+	}
+	defer s.untrack(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kind, method, err := conn.ReadFrame()
+	if err != nil {
+		s.handleError(ctx, err)
+		return
+	}
+	if kind != FrameOpen {
+		s.writeError(ctx, conn, goa.PermanentError("bad_request", "stream must start with an open frame"))
+		return
+	}
+	h, ok := s.handlers[string(method)]
+	if !ok {
+		s.writeError(ctx, conn, goa.PermanentError("not_found", "unknown method %q", string(method)))
+		return
+	}
+	kind, payload, err := conn.ReadFrame()
+	if err != nil {
+		s.handleError(ctx, err)
+		return
+	}
+	if kind != FrameData {
+		s.writeError(ctx, conn, goa.PermanentError("bad_request", "open frame must be followed by a data frame"))
+		return
+	}
+
+	// Clients do not write once the stream is open, any read completing
+	// means the client went away.
+	go func() {
+		conn.ReadFrame()
+		cancel()
+	}()
+
+	if err := h(ctx, conn, payload); err != nil {
+		s.writeError(ctx, conn, err)
+		return
+	}
+	if err := conn.WriteFrame(FrameEnd, nil); err != nil {
+		s.handleError(ctx, err)
+	}
+}
+
+// Close closes the listeners and the connections of the streams being served.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	var err error
+	for l := range s.listeners {
+		if cerr := l.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	return err
+}
+
+// track records the given connection so that it gets closed by Close. It
+// returns false if the server is closed.
+func (s *Server) track(c *Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[c] = struct{}{}
+	return true
+}
+
+// untrack removes the given connection from the tracked connections.
+func (s *Server) untrack(c *Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, c)
+}
+
+// writeError terminates the stream with an error frame describing err.
+func (s *Server) writeError(ctx context.Context, conn *Conn, err error) {
+	if werr := conn.WriteFrame(FrameError, EncodeError(err)); werr != nil {
+		s.handleError(ctx, werr)
+	}
+}
+
+// handleError calls the error handler if any.
+func (s *Server) handleError(ctx context.Context, err error) {
+	if s.ErrorHandler != nil {
+		s.ErrorHandler(ctx, err)
+	}
+}
@@ -0,0 +1,107 @@
+package tcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestServer(t *testing.T) {
+	s := NewServer()
+	s.Handle("count", func(ctx context.Context, conn *Conn, payload []byte) error {
+		var n int
+		if err := JSONCodec.Unmarshal(payload, &n); err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+		for i := 0; i < n; i++ {
+			b, err := JSONCodec.Marshal(i)
+			if err != nil {
+				return err
+			}
+			if err := conn.WriteFrame(FrameData, b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	s.Handle("wait", func(ctx context.Context, conn *Conn, payload []byte) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- s.Serve(l) }()
+	dial := NewDialer("tcp", l.Addr().String(), nil)
+
+	cases := []struct {
+		name     string
+		method   string
+		payload  string
+		expected []string
+		errName  string
+	}{
+		{"results", "count", "3", []string{"0", "1", "2"}, ""},
+		{"no-result", "count", "0", nil, ""},
+		{"invalid-payload", "count", "x", nil, "decode_payload"},
+		{"unknown-method", "unknown", "", nil, "not_found"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conn, err := OpenStream(context.Background(), dial, c.method, []byte(c.payload))
+			if err != nil {
+				t.Fatalf("got error %q", err)
+			}
+			defer conn.Close()
+			var got []string
+			for {
+				msg, err := conn.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					gerr, ok := err.(*goa.ServiceError)
+					if !ok || gerr.Name != c.errName {
+						t.Fatalf("got error %#v, expected %q", err, c.errName)
+					}
+					break
+				}
+				got = append(got, string(msg))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(c.expected) {
+				t.Errorf("got results %v, expected %v", got, c.expected)
+			}
+		})
+	}
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		conn, err := OpenStream(ctx, dial, "wait", nil)
+		if err != nil {
+			t.Fatalf("got error %q", err)
+		}
+		cancel()
+		select {
+		case <-conn.Done():
+		case <-time.After(time.Second):
+			t.Errorf("connection not closed after cancel")
+		}
+	})
+
+	if err := s.Close(); err != nil {
+		t.Errorf("got close error %q", err)
+	}
+	if err := <-errc; err != ErrServerClosed {
+		t.Errorf("got serve error %v, expected %v", err, ErrServerClosed)
+	}
+	if got := s.Methods(); fmt.Sprint(got) != "[count wait]" {
+		t.Errorf("got methods %v, expected [count wait]", got)
+	}
+}